@@ -0,0 +1,346 @@
+package autopilot
+
+import (
+	"bytes"
+	"net"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcutil"
+)
+
+const (
+	// DefaultPollInterval is the default interval at which the agent
+	// re-evaluates its channels, even if nothing has changed in the mean
+	// time. This gives the agent the chance to retry after earlier
+	// failures, or after the graph has been updated.
+	DefaultPollInterval = time.Minute * 10
+)
+
+// Config houses the dependencies of the autopilot Agent.
+type Config struct {
+	// Self is the identity public key of the backing Lightning node.
+	Self *btcec.PublicKey
+
+	// Heuristic decides which nodes the agent opens channels to.
+	Heuristic AttachmentHeuristic
+
+	// ChanController is used to open new channels.
+	ChanController ChannelController
+
+	// WalletBalance returns the confirmed balance of the wallet, which is
+	// available for funding new channels.
+	WalletBalance func() (btcutil.Amount, error)
+
+	// Channels returns our current set of channels, including those
+	// still pending confirmation.
+	Channels func() ([]Channel, error)
+
+	// Graph is the current view of the channel graph.
+	Graph ChannelGraph
+
+	// Constraints bounds the funds the agent commits to channels.
+	Constraints AgentConstraints
+
+	// PollInterval is the interval at which the agent re-evaluates its
+	// channels in the absence of any state updates.
+	PollInterval time.Duration
+}
+
+// Agent opens channels on behalf of the backing Lightning node. Whenever our
+// set of channels or our wallet balance changes, the agent checks whether
+// more channels should be opened according to its constraints and, if so,
+// consults its heuristic to decide which nodes to open them to.
+type Agent struct {
+	started uint32 // To be used atomically.
+	stopped uint32 // To be used atomically.
+
+	cfg Config
+
+	// stateUpdates is signalled each time our channels or balance may
+	// have changed. It's buffered by one, as multiple updates arriving
+	// while the agent is busy only need to be handled once.
+	stateUpdates chan struct{}
+
+	// pendingOpens tracks the channels the agent is in the process of
+	// opening, until they appear within our set of channels as pending.
+	// failedNodes tracks the nodes the agent failed to open a channel
+	// to, which won't be retried for the lifetime of the agent. Both are
+	// guarded by the pendingMtx.
+	pendingMtx   sync.Mutex
+	pendingOpens map[NodeID]Channel
+	failedNodes  map[NodeID]struct{}
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// New creates a new autopilot Agent with the passed config.
+func New(cfg Config) *Agent {
+	if cfg.PollInterval == 0 {
+		cfg.PollInterval = DefaultPollInterval
+	}
+
+	return &Agent{
+		cfg:          cfg,
+		stateUpdates: make(chan struct{}, 1),
+		pendingOpens: make(map[NodeID]Channel),
+		failedNodes:  make(map[NodeID]struct{}),
+		quit:         make(chan struct{}),
+	}
+}
+
+// Start launches the agent's main loop, which immediately checks whether
+// any channels should be opened.
+func (a *Agent) Start() error {
+	if !atomic.CompareAndSwapUint32(&a.started, 0, 1) {
+		return nil
+	}
+
+	log.Infof("Autopilot agent starting with %v heuristic",
+		a.cfg.Heuristic.Name())
+
+	a.wg.Add(1)
+	go a.controller()
+
+	a.OnChannelsChanged()
+
+	return nil
+}
+
+// Stop signals the agent to exit, and waits for its goroutines to finish.
+// Channel opens already in flight are left to the ChannelController, and
+// aren't waited upon.
+func (a *Agent) Stop() error {
+	if !atomic.CompareAndSwapUint32(&a.stopped, 0, 1) {
+		return nil
+	}
+
+	log.Infof("Autopilot agent stopping")
+
+	close(a.quit)
+	a.wg.Wait()
+
+	return nil
+}
+
+// OnChannelsChanged notifies the agent that our set of channels, or our
+// wallet balance, may have changed.
+func (a *Agent) OnChannelsChanged() {
+	select {
+	case a.stateUpdates <- struct{}{}:
+	default:
+	}
+}
+
+// controller is the agent's main loop, re-evaluating our channels on each
+// state update and at each poll interval.
+//
+// NOTE: This MUST be run as a goroutine.
+func (a *Agent) controller() {
+	defer a.wg.Done()
+
+	ticker := time.NewTicker(a.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-a.stateUpdates:
+		case <-ticker.C:
+		case <-a.quit:
+			return
+		}
+
+		if err := a.openChannels(); err != nil {
+			log.Errorf("Unable to open autopilot channels: %v", err)
+		}
+	}
+}
+
+// openChannels checks whether the constraints allow for any further
+// channels, and if so, opens them to the nodes scored highest by the
+// heuristic.
+func (a *Agent) openChannels() error {
+	chans, err := a.cfg.Channels()
+	if err != nil {
+		return err
+	}
+	balance, err := a.cfg.WalletBalance()
+	if err != nil {
+		return err
+	}
+
+	// Channels which are in the process of being opened count against our
+	// budget, unless they've since appeared within our set of channels.
+	a.pendingMtx.Lock()
+	for _, c := range chans {
+		delete(a.pendingOpens, c.Node)
+	}
+	allChans := chans
+	exclude := make(map[NodeID]struct{})
+	for nID, c := range a.pendingOpens {
+		allChans = append(allChans, c)
+		exclude[nID] = struct{}{}
+	}
+	for nID := range a.failedNodes {
+		exclude[nID] = struct{}{}
+	}
+	a.pendingMtx.Unlock()
+
+	available, numChans := a.cfg.Constraints.ChannelBudget(
+		allChans, balance,
+	)
+	chanSize, numChans := a.cfg.Constraints.ChannelSizes(
+		available, numChans,
+	)
+	if numChans == 0 {
+		log.Debugf("No autopilot channels needed, have %v channels "+
+			"with %v in the wallet", len(allChans), balance)
+		return nil
+	}
+
+	// Gather the candidate nodes, skipping ourselves, those nodes we're
+	// excluding, and those we've got no way to reach.
+	self := NewNodeID(a.cfg.Self)
+	candidates := make(map[NodeID]struct{})
+	nodeAddrs := make(map[NodeID][]net.Addr)
+	err = a.cfg.Graph.ForEachNode(func(n Node) error {
+		nID := n.PubKey()
+		if nID == self {
+			return nil
+		}
+		if _, ok := exclude[nID]; ok {
+			return nil
+		}
+
+		addrs := n.Addrs()
+		if len(addrs) == 0 {
+			return nil
+		}
+
+		candidates[nID] = struct{}{}
+		nodeAddrs[nID] = addrs
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	scores, err := a.cfg.Heuristic.NodeScores(
+		a.cfg.Graph, allChans, candidates,
+	)
+	if err != nil {
+		return err
+	}
+
+	targets := RankScores(scores)
+	if uint32(len(targets)) > numChans {
+		targets = targets[:numChans]
+	}
+	if len(targets) == 0 {
+		log.Debugf("No autopilot candidates found among %v nodes",
+			len(candidates))
+		return nil
+	}
+
+	log.Infof("Opening %v autopilot channels of %v", len(targets),
+		chanSize)
+
+	for _, target := range targets {
+		pub, err := btcec.ParsePubKey(target.NodeID[:], btcec.S256())
+		if err != nil {
+			return err
+		}
+
+		a.pendingMtx.Lock()
+		a.pendingOpens[target.NodeID] = Channel{
+			Capacity: chanSize,
+			Node:     target.NodeID,
+		}
+		a.pendingMtx.Unlock()
+
+		a.wg.Add(1)
+		go a.openChannel(pub, target.NodeID, chanSize,
+			nodeAddrs[target.NodeID])
+	}
+
+	return nil
+}
+
+// openChannel attempts to open a single channel. If it fails, the target
+// node is excluded from future attempts, and the agent re-evaluates its
+// channels, so the funds can be allocated elsewhere.
+//
+// NOTE: This MUST be run as a goroutine.
+func (a *Agent) openChannel(pub *btcec.PublicKey, nID NodeID,
+	amt btcutil.Amount, addrs []net.Addr) {
+
+	defer a.wg.Done()
+
+	log.Debugf("Opening autopilot channel of %v to %x", amt,
+		pub.SerializeCompressed())
+
+	// The channel is opened within its own goroutine, so that stopping
+	// the agent needn't wait for the funding workflow to progress.
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- a.cfg.ChanController.OpenChannel(pub, amt, addrs)
+	}()
+
+	var err error
+	select {
+	case err = <-errChan:
+	case <-a.quit:
+	}
+
+	// Whatever the outcome, the channel is no longer in the process of
+	// being opened by the agent: it's either pending within our set of
+	// channels, failed, or abandoned as the agent is stopping.
+	a.pendingMtx.Lock()
+	delete(a.pendingOpens, nID)
+	if err != nil {
+		a.failedNodes[nID] = struct{}{}
+	}
+	a.pendingMtx.Unlock()
+
+	if err == nil {
+		return
+	}
+
+	log.Warnf("Unable to open autopilot channel to %x: %v",
+		pub.SerializeCompressed(), err)
+
+	a.OnChannelsChanged()
+}
+
+// RankScores returns the nodes with a positive score, ordered by descending
+// score. Ties are broken by the node's public key, so the ordering is
+// deterministic.
+func RankScores(scores map[NodeID]*NodeScore) []*NodeScore {
+	sorted := make(nodeScores, 0, len(scores))
+	for _, s := range scores {
+		if s.Score <= 0 {
+			continue
+		}
+		sorted = append(sorted, s)
+	}
+	sort.Sort(sorted)
+
+	return sorted
+}
+
+// nodeScores implements sort.Interface, ordering node scores by descending
+// score, then by ascending public key.
+type nodeScores []*NodeScore
+
+func (s nodeScores) Len() int      { return len(s) }
+func (s nodeScores) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s nodeScores) Less(i, j int) bool {
+	if s[i].Score != s[j].Score {
+		return s[i].Score > s[j].Score
+	}
+	return bytes.Compare(s[i].NodeID[:], s[j].NodeID[:]) < 0
+}
@@ -0,0 +1,421 @@
+package autopilot
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcutil"
+)
+
+// testNode is an in-memory node used to construct test channel graphs.
+type testNode struct {
+	id    NodeID
+	addrs []net.Addr
+	chans []Channel
+}
+
+func (t *testNode) PubKey() NodeID    { return t.id }
+func (t *testNode) Addrs() []net.Addr { return t.addrs }
+func (t *testNode) ForEachChannel(cb func(Channel) error) error {
+	for _, c := range t.chans {
+		if err := cb(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// testGraph is an in-memory ChannelGraph.
+type testGraph struct {
+	nodes []*testNode
+}
+
+func (t *testGraph) ForEachNode(cb func(Node) error) error {
+	for _, n := range t.nodes {
+		if err := cb(n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addChannel adds a channel between the two passed nodes to the graph.
+func (t *testGraph) addChannel(a, b *testNode, capacity btcutil.Amount) {
+	a.chans = append(a.chans, Channel{Capacity: capacity, Node: b.id})
+	b.chans = append(b.chans, Channel{Capacity: capacity, Node: a.id})
+}
+
+// newTestNode creates a new node with a fresh public key, adding it to the
+// graph.
+func (t *testGraph) newTestNode(tt *testing.T) *testNode {
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		tt.Fatalf("unable to generate key: %v", err)
+	}
+
+	n := &testNode{
+		id: NewNodeID(priv.PubKey()),
+		addrs: []net.Addr{
+			&net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 9735},
+		},
+	}
+	t.nodes = append(t.nodes, n)
+
+	return n
+}
+
+// mockChanController records the channels the agent opens, failing those to
+// the nodes within failTargets. If block is non-nil, then each open only
+// returns once it's closed.
+type mockChanController struct {
+	sync.Mutex
+	opens       map[NodeID]btcutil.Amount
+	failTargets map[NodeID]struct{}
+	opened      chan NodeID
+	block       chan struct{}
+}
+
+func newMockChanController() *mockChanController {
+	return &mockChanController{
+		opens:       make(map[NodeID]btcutil.Amount),
+		failTargets: make(map[NodeID]struct{}),
+		opened:      make(chan NodeID, 20),
+	}
+}
+
+func (m *mockChanController) OpenChannel(target *btcec.PublicKey,
+	amt btcutil.Amount, addrs []net.Addr) error {
+
+	nID := NewNodeID(target)
+
+	m.Lock()
+	_, fail := m.failTargets[nID]
+	if !fail {
+		m.opens[nID] = amt
+	}
+	m.Unlock()
+
+	m.opened <- nID
+
+	if m.block != nil {
+		<-m.block
+	}
+
+	if fail {
+		return errors.New("unable to open channel")
+	}
+	return nil
+}
+
+// channels returns the channels opened so far, as reported to the agent.
+func (m *mockChanController) channels() ([]Channel, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	chans := make([]Channel, 0, len(m.opens))
+	for nID, amt := range m.opens {
+		chans = append(chans, Channel{Capacity: amt, Node: nID})
+	}
+
+	return chans, nil
+}
+
+// TestPrefAttachmentScores tests that the preferential attachment heuristic
+// scores nodes by their normalized degree, omitting unconnected nodes and
+// existing peers.
+func TestPrefAttachmentScores(t *testing.T) {
+	t.Parallel()
+
+	g := &testGraph{}
+	hub := g.newTestNode(t)
+	a := g.newTestNode(t)
+	b := g.newTestNode(t)
+	c := g.newTestNode(t)
+	lonely := g.newTestNode(t)
+
+	g.addChannel(hub, a, 100000)
+	g.addChannel(hub, b, 100000)
+	g.addChannel(hub, c, 100000)
+	g.addChannel(hub, lonely, 100000)
+	g.addChannel(a, b, 100000)
+
+	// The lonely node is left unconnected by dropping its only channel.
+	lonely.chans = nil
+
+	candidates := map[NodeID]struct{}{
+		hub.id: {}, a.id: {}, b.id: {}, c.id: {}, lonely.id: {},
+	}
+	existing := []Channel{{Capacity: 50000, Node: c.id}}
+
+	scores, err := NewPrefAttachment().NodeScores(g, existing, candidates)
+	if err != nil {
+		t.Fatalf("unable to score nodes: %v", err)
+	}
+
+	expected := map[NodeID]float64{
+		hub.id: 1,
+		a.id:   0.5,
+		b.id:   0.5,
+	}
+	if len(scores) != len(expected) {
+		t.Fatalf("expected %v scores, got %v", len(expected),
+			len(scores))
+	}
+	for nID, score := range expected {
+		s, ok := scores[nID]
+		if !ok {
+			t.Fatalf("node %x wasn't scored", nID[:])
+		}
+		if s.Score != score {
+			t.Fatalf("expected score %v for node %x, got %v",
+				score, nID[:], s.Score)
+		}
+	}
+}
+
+// TestAgentConstraints tests the channel budget and sizing computed from the
+// agent's constraints.
+func TestAgentConstraints(t *testing.T) {
+	t.Parallel()
+
+	constraints := &AgentConstraints{
+		Allocation:  0.5,
+		MaxChanNum:  4,
+		MinChanSize: 20000,
+		MaxChanSize: 100000,
+	}
+
+	tests := []struct {
+		name     string
+		chans    []Channel
+		funds    btcutil.Amount
+		chanSize btcutil.Amount
+		numChans uint32
+	}{
+		{
+			name:     "no channels",
+			funds:    400000,
+			chanSize: 50000,
+			numChans: 4,
+		},
+		{
+			name:     "capped channel size",
+			funds:    2000000,
+			chanSize: 100000,
+			numChans: 4,
+		},
+		{
+			name:     "fewer channels above minimum size",
+			funds:    100000,
+			chanSize: 20000,
+			numChans: 2,
+		},
+		{
+			name:     "below minimum size",
+			funds:    30000,
+			numChans: 0,
+		},
+		{
+			name: "allocation reached",
+			chans: []Channel{
+				{Capacity: 200000},
+			},
+			funds:    200000,
+			numChans: 0,
+		},
+		{
+			name: "partially allocated",
+			chans: []Channel{
+				{Capacity: 100000},
+			},
+			funds:    300000,
+			chanSize: 33333,
+			numChans: 3,
+		},
+		{
+			name: "max channels reached",
+			chans: []Channel{
+				{Capacity: 1}, {Capacity: 1}, {Capacity: 1},
+				{Capacity: 1},
+			},
+			funds:    1000000,
+			numChans: 0,
+		},
+	}
+
+	for _, test := range tests {
+		available, numChans := constraints.ChannelBudget(
+			test.chans, test.funds,
+		)
+		chanSize, numChans := constraints.ChannelSizes(
+			available, numChans,
+		)
+		if numChans != test.numChans {
+			t.Fatalf("%v: expected %v channels, got %v", test.name,
+				test.numChans, numChans)
+		}
+		if numChans != 0 && chanSize != test.chanSize {
+			t.Fatalf("%v: expected channel size %v, got %v",
+				test.name, test.chanSize, chanSize)
+		}
+	}
+}
+
+// TestAgentOpensChannels tests that the agent opens channels to the highest
+// scored nodes, and redirects its funds elsewhere after a failed open.
+func TestAgentOpensChannels(t *testing.T) {
+	t.Parallel()
+
+	g := &testGraph{}
+	self := g.newTestNode(t)
+	hub := g.newTestNode(t)
+	a := g.newTestNode(t)
+	b := g.newTestNode(t)
+	c := g.newTestNode(t)
+
+	g.addChannel(hub, a, 100000)
+	g.addChannel(hub, b, 100000)
+	g.addChannel(hub, c, 100000)
+	g.addChannel(hub, self, 100000)
+	g.addChannel(a, b, 100000)
+
+	selfPub, err := btcec.ParsePubKey(self.id[:], btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to parse pubkey: %v", err)
+	}
+
+	// The channel to the hub fails, so after it the agent should move on
+	// to the next best candidates.
+	chanController := newMockChanController()
+	chanController.failTargets[hub.id] = struct{}{}
+
+	agent := New(Config{
+		Self:           selfPub,
+		Heuristic:      NewPrefAttachment(),
+		ChanController: chanController,
+		WalletBalance: func() (btcutil.Amount, error) {
+			return 1000000, nil
+		},
+		Channels: chanController.channels,
+		Graph: g,
+		Constraints: AgentConstraints{
+			Allocation:  1,
+			MaxChanNum:  2,
+			MinChanSize: 20000,
+			MaxChanSize: 100000,
+		},
+	})
+	if err := agent.Start(); err != nil {
+		t.Fatalf("unable to start agent: %v", err)
+	}
+	defer agent.Stop()
+
+	var attempts []NodeID
+	for len(attempts) < 3 {
+		select {
+		case nID := <-chanController.opened:
+			attempts = append(attempts, nID)
+		case <-time.After(time.Second * 5):
+			t.Fatalf("agent only attempted %v channels",
+				len(attempts))
+		}
+	}
+
+	// The hub is the best connected node, so it must be attempted first.
+	if attempts[0] != hub.id && attempts[1] != hub.id {
+		t.Fatalf("agent didn't attempt a channel to the hub")
+	}
+
+	chanController.Lock()
+	defer chanController.Unlock()
+
+	if len(chanController.opens) != 2 {
+		t.Fatalf("expected 2 channels, got %v",
+			len(chanController.opens))
+	}
+	if _, ok := chanController.opens[self.id]; ok {
+		t.Fatalf("agent opened channel to itself")
+	}
+	for nID, amt := range chanController.opens {
+		if nID != a.id && nID != b.id {
+			t.Fatalf("unexpected channel to %x", nID[:])
+		}
+		if amt != 100000 {
+			t.Fatalf("expected channel of 100000, got %v", amt)
+		}
+	}
+}
+
+// TestAgentStopDuringOpen tests that the agent can be stopped while a channel
+// open is in flight, and that the open is no longer tracked as pending.
+func TestAgentStopDuringOpen(t *testing.T) {
+	t.Parallel()
+
+	g := &testGraph{}
+	self := g.newTestNode(t)
+	a := g.newTestNode(t)
+	g.addChannel(self, a, 100000)
+
+	selfPub, err := btcec.ParsePubKey(self.id[:], btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to parse pubkey: %v", err)
+	}
+
+	chanController := newMockChanController()
+	chanController.block = make(chan struct{})
+	defer close(chanController.block)
+
+	agent := New(Config{
+		Self:           selfPub,
+		Heuristic:      NewPrefAttachment(),
+		ChanController: chanController,
+		WalletBalance: func() (btcutil.Amount, error) {
+			return 1000000, nil
+		},
+		Channels: func() ([]Channel, error) {
+			return nil, nil
+		},
+		Graph: g,
+		Constraints: AgentConstraints{
+			Allocation:  1,
+			MaxChanNum:  1,
+			MinChanSize: 20000,
+			MaxChanSize: 100000,
+		},
+	})
+	if err := agent.Start(); err != nil {
+		t.Fatalf("unable to start agent: %v", err)
+	}
+
+	select {
+	case <-chanController.opened:
+	case <-time.After(time.Second * 5):
+		t.Fatalf("agent didn't attempt a channel")
+	}
+
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- agent.Stop()
+	}()
+
+	select {
+	case err := <-stopped:
+		if err != nil {
+			t.Fatalf("unable to stop agent: %v", err)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatalf("agent didn't stop while a channel open was in flight")
+	}
+
+	agent.pendingMtx.Lock()
+	defer agent.pendingMtx.Unlock()
+
+	if len(agent.pendingOpens) != 0 {
+		t.Fatalf("expected no pending opens, got %v",
+			len(agent.pendingOpens))
+	}
+}
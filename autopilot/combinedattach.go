@@ -0,0 +1,124 @@
+package autopilot
+
+import (
+	"fmt"
+	"math"
+)
+
+// WeightedHeuristic is an AttachmentHeuristic along with the weight its
+// scores are given within a WeightedCombAttachment.
+type WeightedHeuristic struct {
+	// Weight is the weight of the heuristic's scores, between 0 and 1.
+	Weight float64
+
+	AttachmentHeuristic
+}
+
+// WeightedCombAttachment is an AttachmentHeuristic combining the scores of
+// several other heuristics by their weighted average.
+type WeightedCombAttachment struct {
+	heuristics []*WeightedHeuristic
+}
+
+// A compile time check to ensure that WeightedCombAttachment implements the
+// AttachmentHeuristic and ScoreSettable interfaces.
+var _ AttachmentHeuristic = (*WeightedCombAttachment)(nil)
+var _ ScoreSettable = (*WeightedCombAttachment)(nil)
+
+// NewWeightedCombAttachment creates a new WeightedCombAttachment combining the
+// passed heuristics. The weights of the heuristics must sum to 1.
+func NewWeightedCombAttachment(
+	heuristics ...*WeightedHeuristic) (*WeightedCombAttachment, error) {
+
+	if len(heuristics) == 0 {
+		return nil, fmt.Errorf("at least one heuristic must be given")
+	}
+
+	var sum float64
+	for _, h := range heuristics {
+		if h.Weight <= 0 || h.Weight > 1 {
+			return nil, fmt.Errorf("weight %v of heuristic %v must "+
+				"be greater than 0 and at most 1", h.Weight,
+				h.Name())
+		}
+		sum += h.Weight
+	}
+	if math.Abs(sum-1) > 1e-6 {
+		return nil, fmt.Errorf("weights of heuristics must sum to 1, "+
+			"got %v", sum)
+	}
+
+	return &WeightedCombAttachment{heuristics: heuristics}, nil
+}
+
+// Name returns the name of the heuristic.
+//
+// NOTE: This is part of the AttachmentHeuristic interface.
+func (c *WeightedCombAttachment) Name() string {
+	return "weightedcomb"
+}
+
+// Heuristics returns the heuristics being combined, along with their weights.
+func (c *WeightedCombAttachment) Heuristics() []*WeightedHeuristic {
+	return c.heuristics
+}
+
+// NodeScores scores each candidate node by the weighted average of the scores
+// assigned to it by each of the combined heuristics. A heuristic omitting a
+// node counts as a score of 0, and nodes scored 0 overall are omitted from the
+// result.
+//
+// NOTE: This is part of the AttachmentHeuristic interface.
+func (c *WeightedCombAttachment) NodeScores(g ChannelGraph, chans []Channel,
+	nodes map[NodeID]struct{}) (map[NodeID]*NodeScore, error) {
+
+	combined := make(map[NodeID]*NodeScore)
+	for _, h := range c.heuristics {
+		scores, err := h.NodeScores(g, chans, nodes)
+		if err != nil {
+			return nil, err
+		}
+
+		for nID, s := range scores {
+			score, ok := combined[nID]
+			if !ok {
+				score = &NodeScore{NodeID: nID}
+				combined[nID] = score
+			}
+			score.Score += h.Weight * s.Score
+		}
+	}
+
+	for nID, score := range combined {
+		if score.Score == 0 {
+			delete(combined, nID)
+		}
+	}
+
+	return combined, nil
+}
+
+// SetNodeScores passes the scores to each of the combined heuristics which
+// supports having its scores set, returning true if any of them is the target
+// heuristic.
+//
+// NOTE: This is part of the ScoreSettable interface.
+func (c *WeightedCombAttachment) SetNodeScores(targetHeuristic string,
+	scores map[NodeID]float64) (bool, error) {
+
+	var applied bool
+	for _, h := range c.heuristics {
+		s, ok := h.AttachmentHeuristic.(ScoreSettable)
+		if !ok {
+			continue
+		}
+
+		ok, err := s.SetNodeScores(targetHeuristic, scores)
+		if err != nil {
+			return false, err
+		}
+		applied = applied || ok
+	}
+
+	return applied, nil
+}
@@ -0,0 +1,129 @@
+package autopilot
+
+import (
+	"math"
+	"testing"
+)
+
+// TestWeightedCombAttachment tests that externally set scores are combined
+// with those of the preferential attachment heuristic by their weights.
+func TestWeightedCombAttachment(t *testing.T) {
+	t.Parallel()
+
+	g := &testGraph{}
+	hub := g.newTestNode(t)
+	a := g.newTestNode(t)
+	b := g.newTestNode(t)
+	outsider := g.newTestNode(t)
+
+	g.addChannel(hub, a, 100000)
+	g.addChannel(hub, b, 100000)
+
+	external := NewExternalScoreAttachment()
+	comb, err := NewWeightedCombAttachment(
+		&WeightedHeuristic{
+			Weight:              0.6,
+			AttachmentHeuristic: NewPrefAttachment(),
+		},
+		&WeightedHeuristic{
+			Weight:              0.4,
+			AttachmentHeuristic: external,
+		},
+	)
+	if err != nil {
+		t.Fatalf("unable to create heuristic: %v", err)
+	}
+
+	// Scores targeting another heuristic must not be applied, while
+	// scores out of range must be rejected.
+	ok, err := comb.SetNodeScores("preferential", nil)
+	if err != nil || ok {
+		t.Fatalf("expected scores to be ignored, got ok=%v, err=%v",
+			ok, err)
+	}
+	_, err = comb.SetNodeScores(external.Name(), map[NodeID]float64{
+		a.id: 1.5,
+	})
+	if err == nil {
+		t.Fatalf("expected out of range score to be rejected")
+	}
+
+	ok, err = comb.SetNodeScores(external.Name(), map[NodeID]float64{
+		a.id:        1,
+		outsider.id: 0.5,
+	})
+	if err != nil || !ok {
+		t.Fatalf("unable to set scores, got ok=%v, err=%v", ok, err)
+	}
+
+	candidates := map[NodeID]struct{}{
+		hub.id: {}, a.id: {}, b.id: {}, outsider.id: {},
+	}
+	scores, err := comb.NodeScores(g, nil, candidates)
+	if err != nil {
+		t.Fatalf("unable to score nodes: %v", err)
+	}
+
+	expected := map[NodeID]float64{
+		hub.id:      0.6,
+		a.id:        0.6*0.5 + 0.4,
+		b.id:        0.6 * 0.5,
+		outsider.id: 0.4 * 0.5,
+	}
+	if len(scores) != len(expected) {
+		t.Fatalf("expected %v scores, got %v", len(expected),
+			len(scores))
+	}
+	for nID, score := range expected {
+		s, ok := scores[nID]
+		if !ok {
+			t.Fatalf("node %x wasn't scored", nID[:])
+		}
+		if math.Abs(s.Score-score) > 1e-9 {
+			t.Fatalf("expected score %v for node %x, got %v",
+				score, nID[:], s.Score)
+		}
+	}
+
+	// The externally scored node now ranks above the hub.
+	ranked := RankScores(scores)
+	if ranked[0].NodeID != a.id {
+		t.Fatalf("expected externally scored node to rank first")
+	}
+}
+
+// TestWeightedCombAttachmentWeights tests that the weights of the combined
+// heuristics are validated.
+func TestWeightedCombAttachmentWeights(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		weights []float64
+		valid   bool
+	}{
+		{weights: []float64{1}, valid: true},
+		{weights: []float64{0.3, 0.7}, valid: true},
+		{weights: []float64{0.5, 0.4}, valid: false},
+		{weights: []float64{1.5, -0.5}, valid: false},
+		{weights: nil, valid: false},
+	}
+
+	for _, test := range tests {
+		var heuristics []*WeightedHeuristic
+		for _, w := range test.weights {
+			heuristics = append(heuristics, &WeightedHeuristic{
+				Weight:              w,
+				AttachmentHeuristic: NewPrefAttachment(),
+			})
+		}
+
+		_, err := NewWeightedCombAttachment(heuristics...)
+		if test.valid && err != nil {
+			t.Fatalf("weights %v: unexpected error: %v",
+				test.weights, err)
+		}
+		if !test.valid && err == nil {
+			t.Fatalf("weights %v: expected error", test.weights)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package autopilot
+
+import "github.com/roasbeef/btcutil"
+
+// AgentConstraints bounds the funds the agent commits to channels, along with
+// the number and size of the channels it opens.
+type AgentConstraints struct {
+	// Allocation is the fraction of our total funds, those within the
+	// wallet plus those committed to our channels, the agent aims to
+	// commit to channels. It must be within (0, 1].
+	Allocation float64
+
+	// MaxChanNum is the maximum number of channels the agent maintains.
+	MaxChanNum uint32
+
+	// MinChanSize is the smallest channel the agent opens.
+	MinChanSize btcutil.Amount
+
+	// MaxChanSize is the largest channel the agent opens.
+	MaxChanSize btcutil.Amount
+}
+
+// ChannelBudget returns the funds the agent may commit to new channels, along
+// with the number of channels it may open, given our existing channels and
+// the funds within the wallet.
+func (c *AgentConstraints) ChannelBudget(chans []Channel,
+	funds btcutil.Amount) (btcutil.Amount, uint32) {
+
+	if uint32(len(chans)) >= c.MaxChanNum {
+		return 0, 0
+	}
+	numChans := c.MaxChanNum - uint32(len(chans))
+
+	var allocated btcutil.Amount
+	for _, c := range chans {
+		allocated += c.Capacity
+	}
+
+	// The target allocation is a fraction of all our funds. If we've
+	// already committed at least that much, then no more channels are
+	// needed.
+	target := btcutil.Amount(c.Allocation * float64(funds+allocated))
+	if target <= allocated {
+		return 0, 0
+	}
+
+	available := target - allocated
+	if available > funds {
+		available = funds
+	}
+
+	return available, numChans
+}
+
+// ChannelSizes splits the available funds among at most numChans channels,
+// each within the configured bounds, returning the size of each channel and
+// the number of channels to open.
+func (c *AgentConstraints) ChannelSizes(available btcutil.Amount,
+	numChans uint32) (btcutil.Amount, uint32) {
+
+	if numChans == 0 || available < c.MinChanSize {
+		return 0, 0
+	}
+
+	// Spread the funds evenly among the channels, unless that would
+	// result in channels smaller than the minimum size, in which case we
+	// open fewer channels.
+	chanSize := available / btcutil.Amount(numChans)
+	if chanSize < c.MinChanSize {
+		chanSize = c.MinChanSize
+		numChans = uint32(available / c.MinChanSize)
+	}
+	if chanSize > c.MaxChanSize {
+		chanSize = c.MaxChanSize
+	}
+
+	return chanSize, numChans
+}
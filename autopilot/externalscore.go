@@ -0,0 +1,96 @@
+package autopilot
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ExternalScoreAttachment is an AttachmentHeuristic which scores nodes
+// according to scores set by an external source, such as an RPC client. This
+// allows operators to plug in their own node selection logic.
+type ExternalScoreAttachment struct {
+	mtx    sync.Mutex
+	scores map[NodeID]float64
+}
+
+// A compile time check to ensure that ExternalScoreAttachment implements the
+// AttachmentHeuristic and ScoreSettable interfaces.
+var _ AttachmentHeuristic = (*ExternalScoreAttachment)(nil)
+var _ ScoreSettable = (*ExternalScoreAttachment)(nil)
+
+// NewExternalScoreAttachment creates a new ExternalScoreAttachment heuristic
+// with no scores set.
+func NewExternalScoreAttachment() *ExternalScoreAttachment {
+	return &ExternalScoreAttachment{
+		scores: make(map[NodeID]float64),
+	}
+}
+
+// Name returns the name of the heuristic.
+//
+// NOTE: This is part of the AttachmentHeuristic interface.
+func (e *ExternalScoreAttachment) Name() string {
+	return "externalscore"
+}
+
+// SetNodeScores replaces the current set of scores with the passed scores, if
+// this heuristic is the target heuristic. Each score must be between 0 and 1.
+//
+// NOTE: This is part of the ScoreSettable interface.
+func (e *ExternalScoreAttachment) SetNodeScores(targetHeuristic string,
+	scores map[NodeID]float64) (bool, error) {
+
+	if targetHeuristic != e.Name() {
+		return false, nil
+	}
+
+	newScores := make(map[NodeID]float64, len(scores))
+	for nID, score := range scores {
+		if score < 0 || score > 1 {
+			return false, fmt.Errorf("score %v of node %x must be "+
+				"between 0 and 1", score, nID[:])
+		}
+		newScores[nID] = score
+	}
+
+	e.mtx.Lock()
+	e.scores = newScores
+	e.mtx.Unlock()
+
+	return true, nil
+}
+
+// NodeScores returns the externally set scores of the candidate nodes. Nodes
+// without a score, and nodes we already have a channel with, are omitted from
+// the result.
+//
+// NOTE: This is part of the AttachmentHeuristic interface.
+func (e *ExternalScoreAttachment) NodeScores(g ChannelGraph, chans []Channel,
+	nodes map[NodeID]struct{}) (map[NodeID]*NodeScore, error) {
+
+	existingPeers := make(map[NodeID]struct{})
+	for _, c := range chans {
+		existingPeers[c.Node] = struct{}{}
+	}
+
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	scores := make(map[NodeID]*NodeScore)
+	for nID := range nodes {
+		score, ok := e.scores[nID]
+		if !ok || score == 0 {
+			continue
+		}
+		if _, ok := existingPeers[nID]; ok {
+			continue
+		}
+
+		scores[nID] = &NodeScore{
+			NodeID: nID,
+			Score:  score,
+		}
+	}
+
+	return scores, nil
+}
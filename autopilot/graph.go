@@ -0,0 +1,72 @@
+package autopilot
+
+import (
+	"net"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+)
+
+// databaseChannelGraph wraps the channel graph within the database,
+// implementing the ChannelGraph interface.
+type databaseChannelGraph struct {
+	db *channeldb.ChannelGraph
+}
+
+// A compile time check to ensure that databaseChannelGraph implements the
+// ChannelGraph interface.
+var _ ChannelGraph = (*databaseChannelGraph)(nil)
+
+// ChannelGraphFromDatabase returns a ChannelGraph backed by the channel graph
+// within the database.
+func ChannelGraphFromDatabase(db *channeldb.ChannelGraph) ChannelGraph {
+	return &databaseChannelGraph{db: db}
+}
+
+// ForEachNode invokes the passed callback with each node within the graph.
+//
+// NOTE: This is part of the ChannelGraph interface.
+func (d *databaseChannelGraph) ForEachNode(cb func(Node) error) error {
+	return d.db.ForEachNode(func(n *channeldb.LightningNode) error {
+		return cb(dbNode{node: n})
+	})
+}
+
+// dbNode wraps a node within the database, implementing the Node interface.
+type dbNode struct {
+	node *channeldb.LightningNode
+}
+
+// A compile time check to ensure that dbNode implements the Node interface.
+var _ Node = dbNode{}
+
+// PubKey returns the identity public key of the node.
+//
+// NOTE: This is part of the Node interface.
+func (d dbNode) PubKey() NodeID {
+	return NewNodeID(d.node.PubKey)
+}
+
+// Addrs returns the addresses the node is reachable at.
+//
+// NOTE: This is part of the Node interface.
+func (d dbNode) Addrs() []net.Addr {
+	if d.node.Address == nil {
+		return nil
+	}
+
+	return []net.Addr{d.node.Address}
+}
+
+// ForEachChannel invokes the passed callback with each of the node's
+// channels.
+//
+// NOTE: This is part of the Node interface.
+func (d dbNode) ForEachChannel(cb func(Channel) error) error {
+	return d.node.ForEachChannel(nil, func(e *channeldb.ChannelEdge) error {
+		return cb(Channel{
+			ChanPoint: e.ChannelPoint,
+			Capacity:  e.Capacity,
+			Node:      NewNodeID(e.Node.PubKey),
+		})
+	})
+}
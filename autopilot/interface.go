@@ -0,0 +1,120 @@
+package autopilot
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// NodeID is the serialized compressed public key of a node within the
+// channel graph, usable as a map key.
+type NodeID [33]byte
+
+// NewNodeID creates the NodeID of the node with the passed public key.
+func NewNodeID(pub *btcec.PublicKey) NodeID {
+	var n NodeID
+	copy(n[:], pub.SerializeCompressed())
+	return n
+}
+
+// Node is a node within the channel graph which the agent may open a channel
+// to.
+type Node interface {
+	// PubKey returns the identity public key of the node.
+	PubKey() NodeID
+
+	// Addrs returns the addresses the node is reachable at.
+	Addrs() []net.Addr
+
+	// ForEachChannel invokes the passed callback with each of the node's
+	// channels. If the callback returns an error, then the iteration is
+	// halted with the error propagated back up to the caller.
+	ForEachChannel(func(Channel) error) error
+}
+
+// ChannelGraph is the view of the channel graph the agent and its heuristics
+// base their decisions upon.
+type ChannelGraph interface {
+	// ForEachNode invokes the passed callback with each node within the
+	// graph. If the callback returns an error, then the iteration is
+	// halted with the error propagated back up to the caller.
+	ForEachNode(func(Node) error) error
+}
+
+// Channel is a channel within the channel graph, as seen from one of its two
+// nodes.
+type Channel struct {
+	// ChanPoint is the funding outpoint of the channel.
+	ChanPoint wire.OutPoint
+
+	// Capacity is the total capacity of the channel.
+	Capacity btcutil.Amount
+
+	// Node is the node on the other end of the channel.
+	Node NodeID
+}
+
+// NodeScore is the score a heuristic assigned to a candidate node, between
+// 0 and 1. The higher the score, the more desirable a channel to the node is.
+type NodeScore struct {
+	// NodeID is the candidate node that was scored.
+	NodeID NodeID
+
+	// Score is the score of the node.
+	Score float64
+}
+
+// AttachmentHeuristic decides which nodes of the channel graph the agent
+// should open channels to.
+type AttachmentHeuristic interface {
+	// Name returns the name of the heuristic.
+	Name() string
+
+	// NodeScores assigns a score to each of the passed candidate nodes,
+	// given the channel graph and our existing channels. Candidates that
+	// shouldn't be connected to at all are omitted from the result.
+	NodeScores(g ChannelGraph, chans []Channel,
+		nodes map[NodeID]struct{}) (map[NodeID]*NodeScore, error)
+}
+
+// ScoreSettable is an AttachmentHeuristic whose scores can be set from an
+// external source.
+type ScoreSettable interface {
+	// SetNodeScores sets the scores of the passed nodes, if the name of
+	// the target heuristic matches. It returns whether the scores were
+	// applied.
+	SetNodeScores(targetHeuristic string,
+		scores map[NodeID]float64) (bool, error)
+}
+
+// AvailableHeuristics returns the names of the heuristics that may be
+// created by NewHeuristic.
+func AvailableHeuristics() []string {
+	return []string{"preferential", "externalscore"}
+}
+
+// NewHeuristic creates a new instance of the heuristic with the passed name.
+func NewHeuristic(name string) (AttachmentHeuristic, error) {
+	switch name {
+	case "preferential":
+		return NewPrefAttachment(), nil
+	case "externalscore":
+		return NewExternalScoreAttachment(), nil
+	default:
+		return nil, fmt.Errorf("unknown heuristic %q, must be one of: "+
+			"%v", name, AvailableHeuristics())
+	}
+}
+
+// ChannelController is used by the agent to open channels.
+type ChannelController interface {
+	// OpenChannel opens a channel of the passed amount to the target
+	// node, connecting to it over one of the passed addresses if we're
+	// not already connected. It returns once the channel funding has
+	// been initiated.
+	OpenChannel(target *btcec.PublicKey, amt btcutil.Amount,
+		addrs []net.Addr) error
+}
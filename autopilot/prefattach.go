@@ -0,0 +1,86 @@
+package autopilot
+
+// PrefAttachment is an AttachmentHeuristic implementing the preferential
+// attachment model, under which a node's score is proportional to the number
+// of channels it has. Connecting to well connected nodes keeps the number of
+// hops to the rest of the network low, and over time yields a scale-free
+// network, which is robust against the failure of random nodes.
+type PrefAttachment struct{}
+
+// A compile time check to ensure that PrefAttachment implements the
+// AttachmentHeuristic interface.
+var _ AttachmentHeuristic = (*PrefAttachment)(nil)
+
+// NewPrefAttachment creates a new PrefAttachment heuristic.
+func NewPrefAttachment() *PrefAttachment {
+	return &PrefAttachment{}
+}
+
+// Name returns the name of the heuristic.
+//
+// NOTE: This is part of the AttachmentHeuristic interface.
+func (p *PrefAttachment) Name() string {
+	return "preferential"
+}
+
+// NodeScores assigns each candidate node a score equal to its number of
+// channels, normalized by that of the best connected node within the graph.
+// Nodes without any channels, and nodes we already have a channel with, are
+// omitted from the result.
+//
+// NOTE: This is part of the AttachmentHeuristic interface.
+func (p *PrefAttachment) NodeScores(g ChannelGraph, chans []Channel,
+	nodes map[NodeID]struct{}) (map[NodeID]*NodeScore, error) {
+
+	existingPeers := make(map[NodeID]struct{})
+	for _, c := range chans {
+		existingPeers[c.Node] = struct{}{}
+	}
+
+	// Count the channels of every node within the graph, as the degree of
+	// the best connected node is needed to normalize the scores.
+	var maxChans int
+	nodeChans := make(map[NodeID]int)
+	err := g.ForEachNode(func(n Node) error {
+		var numChans int
+		err := n.ForEachChannel(func(Channel) error {
+			numChans++
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		nodeChans[n.PubKey()] = numChans
+		if numChans > maxChans {
+			maxChans = numChans
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make(map[NodeID]*NodeScore)
+	if maxChans == 0 {
+		return scores, nil
+	}
+
+	for nID := range nodes {
+		numChans := nodeChans[nID]
+		if numChans == 0 {
+			continue
+		}
+		if _, ok := existingPeers[nID]; ok {
+			continue
+		}
+
+		scores[nID] = &NodeScore{
+			NodeID: nID,
+			Score:  float64(numChans) / float64(maxChans),
+		}
+	}
+
+	return scores, nil
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/hex"
+
+	"github.com/lightningnetwork/lnd/autopilot"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/roasbeef/btcd/btcec"
+	"golang.org/x/net/context"
+)
+
+// autopilotServer implements the Autopilot gRPC service, allowing external
+// services to feed node scores into the autopilot agent's heuristics.
+type autopilotServer struct {
+	pilot *autopilotManager
+}
+
+// A compile time check to ensure that autopilotServer fully implements the
+// AutopilotServer gRPC service.
+var _ lnrpc.AutopilotServer = (*autopilotServer)(nil)
+
+// newAutopilotServer creates a new autopilotServer. It's backed by the
+// autopilotManager of the server once the server is created.
+func newAutopilotServer() *autopilotServer {
+	return &autopilotServer{}
+}
+
+// SetScores replaces the scores of the target heuristic, which must be one of
+// the configured heuristics accepting external scores.
+func (a *autopilotServer) SetScores(ctx context.Context,
+	in *lnrpc.SetScoresRequest) (*lnrpc.SetScoresResponse, error) {
+
+	rpcsLog.Debugf("[setscores] heuristic=%v, num_scores=%v",
+		in.Heuristic, len(in.Scores))
+
+	scores := make(map[autopilot.NodeID]float64, len(in.Scores))
+	for _, score := range in.Scores {
+		nID, err := parseAutopilotNodeID(score.PubKey)
+		if err != nil {
+			return nil, err
+		}
+		scores[nID] = score.Score
+	}
+
+	if err := a.pilot.SetNodeScores(in.Heuristic, scores); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.SetScoresResponse{}, nil
+}
+
+// QueryScores returns the scores each of the configured heuristics assigns to
+// the requested nodes, or to every node within the channel graph if none are
+// requested.
+func (a *autopilotServer) QueryScores(ctx context.Context,
+	in *lnrpc.QueryScoresRequest) (*lnrpc.QueryScoresResponse, error) {
+
+	nodes, err := parseAutopilotNodeIDs(in.Pubkeys)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &lnrpc.QueryScoresResponse{}
+	for _, h := range a.pilot.heuristic.Heuristics() {
+		scores, err := a.pilot.heuristicScores(
+			h, nodes, in.IgnoreLocalState,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Results = append(resp.Results, &lnrpc.HeuristicResult{
+			Heuristic: h.Name(),
+			Weight:    h.Weight,
+			Scores:    marshalAutopilotScores(scores),
+		})
+	}
+
+	return resp, nil
+}
+
+// parseAutopilotNodeID parses the passed hex encoded public key into the node
+// ID understood by the autopilot agent.
+func parseAutopilotNodeID(pubStr string) (autopilot.NodeID, error) {
+	pubBytes, err := hex.DecodeString(pubStr)
+	if err != nil {
+		return autopilot.NodeID{}, err
+	}
+	pub, err := btcec.ParsePubKey(pubBytes, btcec.S256())
+	if err != nil {
+		return autopilot.NodeID{}, err
+	}
+
+	return autopilot.NewNodeID(pub), nil
+}
+
+// parseAutopilotNodeIDs parses the passed hex encoded public keys into the
+// node IDs understood by the autopilot agent.
+func parseAutopilotNodeIDs(pubkeys []string) ([]autopilot.NodeID, error) {
+	nodes := make([]autopilot.NodeID, 0, len(pubkeys))
+	for _, pubStr := range pubkeys {
+		nID, err := parseAutopilotNodeID(pubStr)
+		if err != nil {
+			return nil, err
+		}
+
+		nodes = append(nodes, nID)
+	}
+
+	return nodes, nil
+}
+
+// marshalAutopilotScores converts the passed node scores into their RPC form,
+// ordered by descending score.
+func marshalAutopilotScores(
+	scores map[autopilot.NodeID]*autopilot.NodeScore) []*lnrpc.AutopilotNodeScore {
+
+	rpcScores := make([]*lnrpc.AutopilotNodeScore, 0, len(scores))
+	for _, score := range autopilot.RankScores(scores) {
+		rpcScores = append(rpcScores, &lnrpc.AutopilotNodeScore{
+			PubKey: hex.EncodeToString(score.NodeID[:]),
+			Score:  score.Score,
+		})
+	}
+
+	return rpcScores
+}
@@ -0,0 +1,151 @@
+// Package blockcache implements an LRU cache of blocks shared between the
+// btcd chain notifier and the wallet, so that a block both of them fetch from
+// btcd is only fetched once. Blocks fetched by the wallet's own rescans don't
+// go through the cache.
+package blockcache
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+)
+
+const (
+	// DefaultCapacity is the default total size in bytes of the blocks
+	// held within a BlockCache.
+	DefaultCapacity = 20 * 1024 * 1024
+)
+
+// GetBlockFunc fetches the block with the passed hash from the chain backend.
+type GetBlockFunc func(*chainhash.Hash) (*wire.MsgBlock, error)
+
+// cacheEntry is a block within the cache.
+type cacheEntry struct {
+	hash  chainhash.Hash
+	block *wire.MsgBlock
+	size  uint64
+}
+
+// pendingFetch is a fetch of a block from the chain backend which is in
+// progress. Concurrent requests for the block wait for it to complete rather
+// than fetching the block themselves.
+type pendingFetch struct {
+	done  chan struct{}
+	block *wire.MsgBlock
+	err   error
+}
+
+// BlockCache is an LRU cache of blocks, bounded by the total serialized size
+// of the blocks within it. As the cached blocks are shared between all of its
+// callers, the blocks returned by the cache MUST NOT be modified. It's safe
+// for concurrent use.
+type BlockCache struct {
+	capacity uint64
+
+	mtx     sync.Mutex
+	size    uint64
+	entries map[chainhash.Hash]*list.Element
+	lru     *list.List
+	pending map[chainhash.Hash]*pendingFetch
+}
+
+// NewBlockCache creates a new BlockCache holding at most capacity bytes of
+// serialized blocks. If capacity is zero, no blocks are cached, though
+// concurrent fetches of the same block are still only made once.
+func NewBlockCache(capacity uint64) *BlockCache {
+	return &BlockCache{
+		capacity: capacity,
+		entries:  make(map[chainhash.Hash]*list.Element),
+		lru:      list.New(),
+		pending:  make(map[chainhash.Hash]*pendingFetch),
+	}
+}
+
+// GetBlock returns the block with the passed hash from the cache, fetching it
+// with getBlock if it isn't cached. If the block is already being fetched on
+// behalf of another caller, we wait for that fetch instead.
+func (bc *BlockCache) GetBlock(hash *chainhash.Hash,
+	getBlock GetBlockFunc) (*wire.MsgBlock, error) {
+
+	bc.mtx.Lock()
+	if elem, ok := bc.entries[*hash]; ok {
+		bc.lru.MoveToFront(elem)
+		bc.mtx.Unlock()
+
+		log.Tracef("Block %v found in cache", hash)
+
+		return elem.Value.(*cacheEntry).block, nil
+	}
+
+	if fetch, ok := bc.pending[*hash]; ok {
+		bc.mtx.Unlock()
+
+		log.Tracef("Waiting on pending fetch of block %v", hash)
+
+		<-fetch.done
+		return fetch.block, fetch.err
+	}
+
+	fetch := &pendingFetch{
+		done: make(chan struct{}),
+	}
+	bc.pending[*hash] = fetch
+	bc.mtx.Unlock()
+
+	// The block is fetched without holding the mutex, so that requests
+	// for other blocks aren't blocked on the chain backend.
+	log.Tracef("Fetching block %v from the chain backend", hash)
+	fetch.block, fetch.err = getBlock(hash)
+	if fetch.err != nil {
+		log.Debugf("Unable to fetch block %v: %v", hash, fetch.err)
+	}
+
+	bc.mtx.Lock()
+	delete(bc.pending, *hash)
+	if fetch.err == nil {
+		bc.add(*hash, fetch.block)
+	}
+	bc.mtx.Unlock()
+
+	close(fetch.done)
+
+	return fetch.block, fetch.err
+}
+
+// add adds the passed block to the cache, evicting the least recently used
+// blocks until it fits. Blocks larger than the capacity of the cache aren't
+// added.
+//
+// NOTE: The mtx MUST be held when calling this method.
+func (bc *BlockCache) add(hash chainhash.Hash, block *wire.MsgBlock) {
+	size := uint64(block.SerializeSize())
+	if size > bc.capacity {
+		return
+	}
+
+	for bc.size+size > bc.capacity {
+		oldest := bc.lru.Back()
+		entry := bc.lru.Remove(oldest).(*cacheEntry)
+		delete(bc.entries, entry.hash)
+		bc.size -= entry.size
+
+		log.Tracef("Evicted block %v from cache", entry.hash)
+	}
+
+	bc.entries[hash] = bc.lru.PushFront(&cacheEntry{
+		hash:  hash,
+		block: block,
+		size:  size,
+	})
+	bc.size += size
+}
+
+// Size returns the total serialized size of the blocks within the cache.
+func (bc *BlockCache) Size() uint64 {
+	bc.mtx.Lock()
+	defer bc.mtx.Unlock()
+
+	return bc.size
+}
@@ -0,0 +1,190 @@
+package blockcache
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// blockSource serves blocks, counting the number of times each was fetched.
+type blockSource struct {
+	blocks map[chainhash.Hash]*wire.MsgBlock
+
+	mtx     sync.Mutex
+	fetches map[chainhash.Hash]int
+
+	// release, if set, blocks each fetch until it's closed.
+	release chan struct{}
+}
+
+// newBlockSource creates a blockSource serving numBlocks distinct blocks,
+// returning their hashes.
+func newBlockSource(numBlocks int) (*blockSource, []chainhash.Hash) {
+	src := &blockSource{
+		blocks:  make(map[chainhash.Hash]*wire.MsgBlock),
+		fetches: make(map[chainhash.Hash]int),
+	}
+
+	hashes := make([]chainhash.Hash, numBlocks)
+	for i := range hashes {
+		block := &wire.MsgBlock{
+			Header: wire.BlockHeader{
+				Nonce: uint32(i),
+			},
+		}
+		hashes[i] = block.BlockHash()
+		src.blocks[hashes[i]] = block
+	}
+
+	return src, hashes
+}
+
+func (s *blockSource) getBlock(hash *chainhash.Hash) (*wire.MsgBlock, error) {
+	if s.release != nil {
+		<-s.release
+	}
+
+	s.mtx.Lock()
+	s.fetches[*hash]++
+	s.mtx.Unlock()
+
+	return s.blocks[*hash], nil
+}
+
+func (s *blockSource) numFetches(hash chainhash.Hash) int {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return s.fetches[hash]
+}
+
+// TestBlockCacheEviction asserts that cached blocks are served without being
+// fetched again, and that the least recently used blocks are evicted once the
+// cache is full.
+func TestBlockCacheEviction(t *testing.T) {
+	t.Parallel()
+
+	src, hashes := newBlockSource(3)
+	blockSize := uint64(src.blocks[hashes[0]].SerializeSize())
+
+	// The cache only has room for two blocks.
+	cache := NewBlockCache(2 * blockSize)
+
+	for _, hash := range hashes[:2] {
+		hash := hash
+		if _, err := cache.GetBlock(&hash, src.getBlock); err != nil {
+			t.Fatalf("unable to get block: %v", err)
+		}
+	}
+
+	// Using the first block makes the second the least recently used.
+	block, err := cache.GetBlock(&hashes[0], src.getBlock)
+	if err != nil {
+		t.Fatalf("unable to get block: %v", err)
+	}
+	if block != src.blocks[hashes[0]] {
+		t.Fatalf("wrong block returned")
+	}
+	if src.numFetches(hashes[0]) != 1 {
+		t.Fatalf("cached block was fetched again")
+	}
+
+	// Adding a third block should evict the second.
+	if _, err := cache.GetBlock(&hashes[2], src.getBlock); err != nil {
+		t.Fatalf("unable to get block: %v", err)
+	}
+	if cache.Size() != 2*blockSize {
+		t.Fatalf("expected cache size %v, got %v", 2*blockSize,
+			cache.Size())
+	}
+
+	// The first and third blocks should still be cached, while the second
+	// must be fetched again.
+	for _, i := range []int{0, 2, 1} {
+		_, err := cache.GetBlock(&hashes[i], src.getBlock)
+		if err != nil {
+			t.Fatalf("unable to get block: %v", err)
+		}
+
+		expectedFetches := 1
+		if i == 1 {
+			expectedFetches = 2
+		}
+		if src.numFetches(hashes[i]) != expectedFetches {
+			t.Fatalf("expected block %d to be fetched %d times, "+
+				"was fetched %d times", i, expectedFetches,
+				src.numFetches(hashes[i]))
+		}
+	}
+
+	// A cache without any capacity shouldn't cache anything.
+	cache = NewBlockCache(0)
+	for i := 0; i < 2; i++ {
+		_, err := cache.GetBlock(&hashes[0], src.getBlock)
+		if err != nil {
+			t.Fatalf("unable to get block: %v", err)
+		}
+	}
+	if src.numFetches(hashes[0]) != 3 {
+		t.Fatalf("block was cached by cache without capacity")
+	}
+}
+
+// TestBlockCacheConcurrentFetches asserts that concurrent requests for the
+// same block only fetch it once.
+func TestBlockCacheConcurrentFetches(t *testing.T) {
+	t.Parallel()
+
+	src, hashes := newBlockSource(1)
+	src.release = make(chan struct{})
+
+	const numRequests = 10
+	var (
+		cache     = NewBlockCache(1 << 20)
+		wg        sync.WaitGroup
+		numWrong  int32
+		numFailed int32
+	)
+	for i := 0; i < numRequests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			block, err := cache.GetBlock(&hashes[0], src.getBlock)
+			if err != nil {
+				atomic.AddInt32(&numFailed, 1)
+			}
+			if block != src.blocks[hashes[0]] {
+				atomic.AddInt32(&numWrong, 1)
+			}
+		}()
+	}
+
+	// Wait for the first request to start fetching the block before
+	// allowing the fetch to complete.
+	for {
+		cache.mtx.Lock()
+		_, fetching := cache.pending[hashes[0]]
+		cache.mtx.Unlock()
+		if fetching {
+			break
+		}
+	}
+	close(src.release)
+	wg.Wait()
+
+	if numFailed != 0 || numWrong != 0 {
+		t.Fatalf("%d requests failed, %d got the wrong block",
+			numFailed, numWrong)
+	}
+
+	// Requests made while the fetch was pending should have shared it,
+	// while those made after were served from the cache.
+	if src.numFetches(hashes[0]) != 1 {
+		t.Fatalf("expected block to be fetched once, was fetched %d "+
+			"times", src.numFetches(hashes[0]))
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcd/txscript"
@@ -23,10 +24,12 @@ import (
 // counter-parties.
 // TODO(roasbeef): closures in config for sub-system pointers to decouple?
 type breachArbiter struct {
-	wallet     *lnwallet.LightningWallet
-	db         *channeldb.DB
-	notifier   chainntnfs.ChainNotifier
-	htlcSwitch *htlcSwitch
+	wallet       *lnwallet.LightningWallet
+	db           *channeldb.DB
+	notifier     chainntnfs.ChainNotifier
+	htlcSwitch   *htlcSwitch
+	chanNotifier *channelNotifier
+	shellSweeper *shellSweeper
 
 	// breachObservers is a map which tracks all the active breach
 	// observers we're currently managing. The key of the map is the
@@ -62,13 +65,17 @@ type breachArbiter struct {
 // newBreachArbiter creates a new instance of a breachArbiter initialize with
 // its dependant objects.
 func newBreachArbiter(wallet *lnwallet.LightningWallet, db *channeldb.DB,
-	notifier chainntnfs.ChainNotifier, h *htlcSwitch) *breachArbiter {
+	notifier chainntnfs.ChainNotifier, h *htlcSwitch,
+	chanNotifier *channelNotifier,
+	shellSweeper *shellSweeper) *breachArbiter {
 
 	return &breachArbiter{
-		wallet:     wallet,
-		db:         db,
-		notifier:   notifier,
-		htlcSwitch: h,
+		wallet:       wallet,
+		db:           db,
+		notifier:     notifier,
+		htlcSwitch:   h,
+		chanNotifier: chanNotifier,
+		shellSweeper: shellSweeper,
 
 		breachObservers:   make(map[wire.OutPoint]chan struct{}),
 		breachedContracts: make(chan *retributionInfo),
@@ -283,12 +290,22 @@ func (b *breachArbiter) exactRetribution(confChan *chainntnfs.ConfirmationEvent,
 
 	// Finally, broadcast the transaction, finalizing the channels'
 	// retribution against the cheating counter-party.
-	if err := b.wallet.PublishTransaction(justiceTx); err != nil {
+	label := lnwallet.MakeLabel(lnwallet.LabelTypeJusticeTransaction,
+		&breachInfo.chanPoint)
+	if err := b.wallet.PublishAndLabel(justiceTx, label); err != nil {
 		brarLog.Errorf("unable to broadcast "+
 			"justice tx: %v", err)
 		return
 	}
 
+	// Record the justice transaction so it's listed along with the other
+	// sweeps. As it has already been broadcast, failing to do so doesn't
+	// fail the retribution.
+	if err := b.recordJusticeTx(justiceTx, breachInfo); err != nil {
+		brarLog.Errorf("unable to record justice tx %v: %v",
+			justiceTx.TxHash(), err)
+	}
+
 	// As a conclusionary step, we register for a notification to be
 	// dispatched once the justice tx is confirmed. After confirmation we
 	// notify the caller that initiated the retribution work low that the
@@ -328,6 +345,34 @@ func (b *breachArbiter) exactRetribution(confChan *chainntnfs.ConfirmationEvent,
 	}
 }
 
+// recordJusticeTx adds a record of the passed justice transaction, sweeping
+// the outputs of the passed retribution, to the sweep transaction bucket.
+func (b *breachArbiter) recordJusticeTx(justiceTx *wire.MsgTx,
+	breachInfo *retributionInfo) error {
+
+	_, bestHeight, err := b.wallet.ChainIO.GetBestBlock()
+	if err != nil {
+		return err
+	}
+
+	// The inputs of the justice transaction spend our own output first,
+	// followed by the revoked output.
+	record := &sweepRecord{
+		tx:              justiceTx,
+		broadcastHeight: uint32(bestHeight),
+	}
+	for _, output := range []*breachedOutput{
+		breachInfo.selfOutput, breachInfo.revokedOutput,
+	} {
+		record.inputs = append(record.inputs, sweepInput{
+			amt:         output.amt,
+			witnessType: output.witnessType,
+		})
+	}
+
+	return putSweepRecord(b.db, record)
+}
+
 // breachObserver notifies the breachArbiter contract observer goroutine that a
 // channel's contract has been breached by the prior counter party. Once
 // notified the breachArbiter will attempt to sweep ALL funds within the
@@ -350,6 +395,16 @@ func (b *breachArbiter) breachObserver(contract *lnwallet.LightningChannel,
 	case <-settleSignal:
 		return
 
+	// A read from this channel indicates that the remote party has
+	// broadcast their current commitment transaction. Our balance within
+	// static remote key channels is swept back into the wallet, even if
+	// the peer is offline.
+	case <-contract.UnilateralCloseSignal:
+		b.shellSweeper.sweepClosedChannel(
+			contract.UnilateralCloseSummary(),
+		)
+		return
+
 	// A read from this channel indicates that a channel breach has been
 	// detected! So we notify the main coordination goroutine with the
 	// information needed to bring the counter-party to justice.
@@ -367,6 +422,10 @@ func (b *breachArbiter) breachObserver(contract *lnwallet.LightningChannel,
 		if err := contract.DeleteState(); err != nil {
 			brarLog.Errorf("unable to delete channel state: %v", err)
 		}
+		b.chanNotifier.notifyChannelEvent(
+			lnrpc.ChannelEventUpdate_CLOSED_CHANNEL, *chanPoint,
+			&contract.StateSnapshot().RemoteIdentity,
+		)
 
 		// TODO(roasbeef): need to handle case of remote broadcast
 		// mid-local initiated state-transition, possible false-positive?
@@ -411,12 +470,14 @@ func (b *breachArbiter) breachObserver(contract *lnwallet.LightningChannel,
 			selfOutput: &breachedOutput{
 				amt:         btcutil.Amount(localSignDesc.Output.Value),
 				outpoint:    breachInfo.LocalOutpoint,
+				witnessType: commitmentNoDelay,
 				witnessFunc: localWitness,
 			},
 
 			revokedOutput: &breachedOutput{
 				amt:         btcutil.Amount(remoteSignDesc.Output.Value),
 				outpoint:    breachInfo.RemoteOutpoint,
+				witnessType: commitmentRevoke,
 				witnessFunc: remoteWitness,
 			},
 
@@ -434,6 +495,7 @@ func (b *breachArbiter) breachObserver(contract *lnwallet.LightningChannel,
 type breachedOutput struct {
 	amt         btcutil.Amount
 	outpoint    wire.OutPoint
+	witnessType witnessType
 	witnessFunc witnessGenerator
 
 	twoStageClaim bool
@@ -7,6 +7,7 @@ import (
 	"net"
 	"time"
 
+	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/btcec"
 )
@@ -32,7 +33,9 @@ var _ net.Conn = (*Conn)(nil)
 // remote peer located at address which has remotePub as its long-term static
 // public key. In the case of a handshake failure, the connection is closed and
 // a non-nil error is returned.
-func Dial(localPriv *btcec.PrivateKey, netAddr *lnwire.NetAddress) (*Conn, error) {
+func Dial(localStatic keychain.SingleKeyECDH,
+	netAddr *lnwire.NetAddress) (*Conn, error) {
+
 	ipAddr := netAddr.Address.String()
 	conn, err := net.Dial("tcp", ipAddr)
 	if err != nil {
@@ -41,7 +44,7 @@ func Dial(localPriv *btcec.PrivateKey, netAddr *lnwire.NetAddress) (*Conn, error
 
 	b := &Conn{
 		conn:  conn,
-		noise: NewBrontideMachine(true, localPriv, netAddr.IdentityKey),
+		noise: NewBrontideMachine(true, localStatic, netAddr.IdentityKey),
 	}
 
 	// Initiate the handshake by sending the first act to the receiver.
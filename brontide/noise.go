@@ -13,6 +13,7 @@ import (
 
 	"github.com/aead/chacha20"
 	"github.com/btcsuite/fastsha256"
+	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/roasbeef/btcd/btcec"
 )
 
@@ -260,7 +261,7 @@ type handshakeState struct {
 
 	initiator bool
 
-	localStatic    *btcec.PrivateKey
+	localStatic    keychain.SingleKeyECDH
 	localEphemeral *btcec.PrivateKey
 
 	remoteStatic    *btcec.PublicKey
@@ -271,11 +272,12 @@ type handshakeState struct {
 // with the prologue and protocol name. If this is the responder's handshake
 // state, then the remotePub can be nil.
 func newHandshakeState(initiator bool, prologue []byte,
-	localPub *btcec.PrivateKey, remotePub *btcec.PublicKey) handshakeState {
+	localStatic keychain.SingleKeyECDH,
+	remotePub *btcec.PublicKey) handshakeState {
 
 	h := handshakeState{
 		initiator:    initiator,
-		localStatic:  localPub,
+		localStatic:  localStatic,
 		remoteStatic: remotePub,
 	}
 
@@ -293,7 +295,7 @@ func newHandshakeState(initiator bool, prologue []byte,
 	if initiator {
 		h.mixHash(remotePub.SerializeCompressed())
 	} else {
-		h.mixHash(localPub.PubKey().SerializeCompressed())
+		h.mixHash(localStatic.PubKey().SerializeCompressed())
 	}
 
 	return h
@@ -334,12 +336,14 @@ type BrontideMachine struct {
 // NewBrontideMachine creates a new instance of the brontide state-machine. If
 // the responder (listener) is creating the object, then the remotePub should
 // be nil. The handshake state within brontide is initialized using the ascii
-// string "bitcoin" as the prologue.
-func NewBrontideMachine(initiator bool, localPub *btcec.PrivateKey,
+// string "bitcoin" as the prologue. The ECDH operations involving our static
+// key are performed by the passed localStatic, so that its private key may be
+// held elsewhere.
+func NewBrontideMachine(initiator bool, localStatic keychain.SingleKeyECDH,
 	remotePub *btcec.PublicKey) *BrontideMachine {
 
-	handshake := newHandshakeState(initiator, []byte("lightning"), localPub,
-		remotePub)
+	handshake := newHandshakeState(initiator, []byte("lightning"),
+		localStatic, remotePub)
 
 	return &BrontideMachine{handshakeState: handshake}
 }
@@ -438,8 +442,11 @@ func (b *BrontideMachine) RecvActOne(actOne [ActOneSize]byte) error {
 	b.mixHash(b.remoteEphemeral.SerializeCompressed())
 
 	// es
-	s := ecdh(b.remoteEphemeral, b.localStatic)
-	b.mixKey(s)
+	s, err := b.localStatic.ECDH(b.remoteEphemeral)
+	if err != nil {
+		return err
+	}
+	b.mixKey(s[:])
 
 	// If the initiator doesn't know our static key, then this operation
 	// will fail.
@@ -535,8 +542,11 @@ func (b *BrontideMachine) GenActThree() ([ActThreeSize]byte, error) {
 	ourPubkey := b.localStatic.PubKey().SerializeCompressed()
 	ciphertext := b.EncryptAndHash(ourPubkey)
 
-	s := ecdh(b.remoteEphemeral, b.localStatic)
-	b.mixKey(s)
+	s, err := b.localStatic.ECDH(b.remoteEphemeral)
+	if err != nil {
+		return actThree, err
+	}
+	b.mixKey(s[:])
 
 	authPayload := b.EncryptAndHash([]byte{})
 
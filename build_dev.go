@@ -0,0 +1,7 @@
+// +build dev
+
+package main
+
+// isDevBuild indicates whether the daemon was built with the dev build tag,
+// enabling functionality which is only safe to use for testing and recovery.
+const isDevBuild = true
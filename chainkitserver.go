@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"golang.org/x/net/context"
+)
+
+// chainKitServer implements the ChainKit gRPC service, exposing the blocks of
+// the chain backend of the daemon.
+type chainKitServer struct {
+	bio lnwallet.BlockChainIO
+}
+
+// A compile time check to ensure that chainKitServer fully implements the
+// ChainKitServer gRPC service.
+var _ lnrpc.ChainKitServer = (*chainKitServer)(nil)
+
+// newChainKitServer creates a new chainKitServer. It's backed by the chain
+// backend of the server once the server is created.
+func newChainKitServer() *chainKitServer {
+	return &chainKitServer{}
+}
+
+// GetBestBlock returns the hash and height of the tip of the most-work chain
+// known to the chain backend.
+func (c *chainKitServer) GetBestBlock(ctx context.Context,
+	in *lnrpc.GetBestBlockRequest) (*lnrpc.GetBestBlockResponse, error) {
+
+	blockHash, height, err := c.bio.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.GetBestBlockResponse{
+		BlockHash:   blockHash[:],
+		BlockHeight: height,
+	}, nil
+}
+
+// GetBlockHash returns the hash of the block within the most-work chain at the
+// requested height.
+func (c *chainKitServer) GetBlockHash(ctx context.Context,
+	in *lnrpc.GetBlockHashRequest) (*lnrpc.GetBlockHashResponse, error) {
+
+	blockHash, err := c.bio.GetBlockHash(in.BlockHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.GetBlockHashResponse{
+		BlockHash: blockHash[:],
+	}, nil
+}
+
+// GetBlock returns the serialized block with the requested hash.
+func (c *chainKitServer) GetBlock(ctx context.Context,
+	in *lnrpc.GetBlockRequest) (*lnrpc.GetBlockResponse, error) {
+
+	blockHash, err := chainhash.NewHash(in.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Debugf("[getblock] hash=%v", blockHash)
+
+	block, err := c.bio.GetBlock(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	if err := block.Serialize(&b); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.GetBlockResponse{
+		RawBlock: b.Bytes(),
+	}, nil
+}
@@ -7,6 +7,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/lightningnetwork/lnd/blockcache"
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/roasbeef/btcd/btcjson"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
@@ -52,6 +53,10 @@ type BtcdNotifier struct {
 
 	chainConn *btcrpcclient.Client
 
+	// blockCache, if set, is the cache of blocks shared with the other
+	// subsystems fetching blocks from btcd.
+	blockCache *blockcache.BlockCache
+
 	notificationRegistry chan interface{}
 
 	spendNotifications map[wire.OutPoint][]*spendNotification
@@ -59,7 +64,8 @@ type BtcdNotifier struct {
 	confNotifications map[chainhash.Hash][]*confirmationsNotification
 	confHeap          *confirmationHeap
 
-	blockEpochClients []chan *chainntnfs.BlockEpoch
+	epochClientCounter uint64 // To be used atomically.
+	blockEpochClients  map[uint64]chan *chainntnfs.BlockEpoch
 
 	disconnectedBlockHashes chan *blockNtfn
 
@@ -82,13 +88,27 @@ var _ chainntnfs.ChainNotifier = (*BtcdNotifier)(nil)
 // detailed in the passed configuration is already running, and willing to
 // accept new websockets clients.
 func New(config *btcrpcclient.ConnConfig) (*BtcdNotifier, error) {
+	return NewWithBlockCache(config, nil)
+}
+
+// NewWithBlockCache returns a new BtcdNotifier instance which fetches all
+// blocks through the passed block cache, shared with the other subsystems
+// fetching blocks from btcd. If the block cache is nil, blocks are fetched
+// from btcd directly, as with New.
+func NewWithBlockCache(config *btcrpcclient.ConnConfig,
+	blockCache *blockcache.BlockCache) (*BtcdNotifier, error) {
+
 	notifier := &BtcdNotifier{
+		blockCache: blockCache,
+
 		notificationRegistry: make(chan interface{}),
 
 		spendNotifications: make(map[wire.OutPoint][]*spendNotification),
 		confNotifications:  make(map[chainhash.Hash][]*confirmationsNotification),
 		confHeap:           newConfirmationHeap(),
 
+		blockEpochClients: make(map[uint64]chan *chainntnfs.BlockEpoch),
+
 		disconnectedBlockHashes: make(chan *blockNtfn, 20),
 
 		chainUpdateSignal: make(chan struct{}),
@@ -116,6 +136,16 @@ func New(config *btcrpcclient.ConnConfig) (*BtcdNotifier, error) {
 	return notifier, nil
 }
 
+// getBlock fetches the block with the passed hash from btcd, unless it's
+// within the block cache.
+func (b *BtcdNotifier) getBlock(hash *chainhash.Hash) (*wire.MsgBlock, error) {
+	if b.blockCache == nil {
+		return b.chainConn.GetBlock(hash)
+	}
+
+	return b.blockCache.GetBlock(hash, b.chainConn.GetBlock)
+}
+
 // Start connects to the running btcd node over websockets, registers for block
 // notifications, and finally launches all related helper goroutines.
 func (b *BtcdNotifier) Start() error {
@@ -252,8 +282,15 @@ out:
 				b.confNotifications[txid] = append(b.confNotifications[txid], msg)
 			case *blockEpochRegistration:
 				chainntnfs.Log.Infof("New block epoch subscription")
-				b.blockEpochClients = append(b.blockEpochClients,
-					msg.epochChan)
+				b.blockEpochClients[msg.epochID] = msg.epochChan
+			case *epochCancel:
+				chainntnfs.Log.Infof("Cancelling block epoch "+
+					"subscription, id=%v", msg.epochID)
+
+				// The channel isn't closed, as a block
+				// notification dispatched before the
+				// cancellation may still be sent over it.
+				delete(b.blockEpochClients, msg.epochID)
 			}
 		case staleBlockHash := <-b.disconnectedBlockHashes:
 			// TODO(roasbeef): re-orgs
@@ -273,7 +310,7 @@ out:
 
 			currentHeight = update.blockHeight
 
-			newBlock, err := b.chainConn.GetBlock(update.blockHash)
+			newBlock, err := b.getBlock(update.blockHash)
 			if err != nil {
 				chainntnfs.Log.Errorf("Unable to get block: %v", err)
 				continue
@@ -282,9 +319,17 @@ out:
 			chainntnfs.Log.Infof("New block: height=%v, sha=%v",
 				update.blockHeight, update.blockHash)
 
+			// The clients are copied, so that they may be
+			// notified without racing with new registrations.
+			epochClients := make([]chan *chainntnfs.BlockEpoch, 0,
+				len(b.blockEpochClients))
+			for _, epochChan := range b.blockEpochClients {
+				epochClients = append(epochClients, epochChan)
+			}
+
 			b.wg.Add(1)
 			go b.notifyBlockEpochs(update.blockHeight,
-				update.blockHash)
+				update.blockHash, epochClients)
 
 			newHeight := update.blockHeight
 			for i, tx := range newBlock.Transactions {
@@ -376,7 +421,7 @@ func (b *BtcdNotifier) attemptHistoricalDispatch(msg *confirmationsNotification,
 			"historical dispatch: %v", tx.BlockHash, err)
 		return false
 	}
-	block, err := b.chainConn.GetBlock(blockHash)
+	block, err := b.getBlock(blockHash)
 	if err != nil {
 		chainntnfs.Log.Errorf("unable to get block hash: %v", err)
 		return false
@@ -427,9 +472,11 @@ func (b *BtcdNotifier) attemptHistoricalDispatch(msg *confirmationsNotification,
 	return false
 }
 
-// notifyBlockEpochs notifies all registered block epoch clients of the newly
+// notifyBlockEpochs notifies the passed block epoch clients of the newly
 // connected block to the main chain.
-func (b *BtcdNotifier) notifyBlockEpochs(newHeight int32, newSha *chainhash.Hash) {
+func (b *BtcdNotifier) notifyBlockEpochs(newHeight int32, newSha *chainhash.Hash,
+	epochClients []chan *chainntnfs.BlockEpoch) {
+
 	defer b.wg.Done()
 
 	epoch := &chainntnfs.BlockEpoch{
@@ -438,7 +485,7 @@ func (b *BtcdNotifier) notifyBlockEpochs(newHeight int32, newSha *chainhash.Hash
 	}
 
 	// TODO(roasbeef): spwan a new goroutine for each client instead?
-	for _, epochChan := range b.blockEpochClients {
+	for _, epochChan := range epochClients {
 		// Attempt a non-blocking send. If the buffered channel is
 		// full, then we no-op and move onto the next client.
 		select {
@@ -634,14 +681,23 @@ func (b *BtcdNotifier) RegisterConfirmationsNtfn(txid *chainhash.Hash,
 // blockEpochRegistration represents a client's intent to receive a
 // notification with each newly connected block.
 type blockEpochRegistration struct {
+	epochID uint64
+
 	epochChan chan *chainntnfs.BlockEpoch
 }
 
+// epochCancel is a message sent to the BtcdNotifier when a client wishes to
+// cancel an outstanding epoch notification that has yet to be dispatched.
+type epochCancel struct {
+	epochID uint64
+}
+
 // RegisterBlockEpochNtfn returns a BlockEpochEvent which subscribes the
 // caller to receive notificationsm, of each new block connected to the main
 // chain.
 func (b *BtcdNotifier) RegisterBlockEpochNtfn() (*chainntnfs.BlockEpochEvent, error) {
 	registration := &blockEpochRegistration{
+		epochID:   atomic.AddUint64(&b.epochClientCounter, 1),
 		epochChan: make(chan *chainntnfs.BlockEpoch, 20),
 	}
 
@@ -652,6 +708,19 @@ func (b *BtcdNotifier) RegisterBlockEpochNtfn() (*chainntnfs.BlockEpochEvent, er
 	case b.notificationRegistry <- registration:
 		return &chainntnfs.BlockEpochEvent{
 			Epochs: registration.epochChan,
+			Cancel: func() {
+				cancel := &epochCancel{
+					epochID: registration.epochID,
+				}
+
+				// The notifier may have already been shut
+				// down, in which case there's nothing left to
+				// cancel.
+				select {
+				case b.notificationRegistry <- cancel:
+				case <-b.quit:
+				}
+			},
 		}, nil
 	}
 }
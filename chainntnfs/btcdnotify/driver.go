@@ -3,16 +3,18 @@ package btcdnotify
 import (
 	"fmt"
 
+	"github.com/lightningnetwork/lnd/blockcache"
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/roasbeef/btcrpcclient"
 )
 
 // createNewNotifier creates a new instance of the ChainNotifier interface
-// implemented by BtcdNotifier.
+// implemented by BtcdNotifier. An optional second argument is the block cache
+// the notifier fetches blocks through.
 func createNewNotifier(args ...interface{}) (chainntnfs.ChainNotifier, error) {
-	if len(args) != 1 {
+	if len(args) != 1 && len(args) != 2 {
 		return nil, fmt.Errorf("incorrect number of arguments to .New(...), "+
-			"expected 1, instead passed %v", len(args))
+			"expected 1 or 2, instead passed %v", len(args))
 	}
 
 	config, ok := args[0].(*btcrpcclient.ConnConfig)
@@ -21,7 +23,17 @@ func createNewNotifier(args ...interface{}) (chainntnfs.ChainNotifier, error) {
 			"incorrect, expected a *btcrpcclient.ConnConfig")
 	}
 
-	return New(config)
+	if len(args) == 1 {
+		return New(config)
+	}
+
+	blockCache, ok := args[1].(*blockcache.BlockCache)
+	if !ok {
+		return nil, fmt.Errorf("second argument to btcdnotifier.New is " +
+			"incorrect, expected a *blockcache.BlockCache")
+	}
+
+	return NewWithBlockCache(config, blockCache)
 }
 
 // init registers a driver for the BtcdNotifier concrete implementation of the
@@ -131,6 +131,11 @@ type BlockEpoch struct {
 // connected to the main-chain.
 type BlockEpochEvent struct {
 	Epochs chan *BlockEpoch // MUST be buffered.
+
+	// Cancel is a closure that should be executed by the caller in the
+	// case that they wish to abandon their registered block epoch
+	// notification, such as when shutting down.
+	Cancel func()
 }
 
 // NotifierDriver represents a "driver" for a particular interface. A driver is
@@ -0,0 +1,118 @@
+package chanbackup
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"io/ioutil"
+
+	"github.com/lightningnetwork/lnd/keychain"
+)
+
+var (
+	// ErrInvalidPayload is returned when an encrypted backup is too short
+	// to have been produced by encryptPayloadToWriter.
+	ErrInvalidPayload = errors.New("encrypted backup payload is too short")
+
+	// ErrDecryptionFailed is returned when an encrypted backup fails to
+	// authenticate, either as it was encrypted by another wallet, or as it
+	// was corrupted.
+	ErrDecryptionFailed = errors.New("unable to decrypt backup, it was " +
+		"either created by another wallet or is corrupted")
+)
+
+// backupKeyLocator locates the key from which the key encrypting our static
+// channel backups is derived.
+var backupKeyLocator = keychain.KeyLocator{
+	Family: keychain.KeyFamilyStaticBackup,
+	Index:  0,
+}
+
+// genEncryptionKey derives the key used to encrypt our static channel
+// backups. The key is the sha256 of the public key of the static backup key
+// family, so it can be recomputed by any wallet restored from the same seed.
+func genEncryptionKey(keyRing keychain.KeyRing) ([]byte, error) {
+	keyDesc, err := keyRing.DeriveKey(backupKeyLocator)
+	if err != nil {
+		return nil, err
+	}
+
+	encryptionKey := sha256.Sum256(keyDesc.PubKey.SerializeCompressed())
+	return encryptionKey[:], nil
+}
+
+// newAEAD returns the AES-256-GCM cipher keyed with our backup encryption
+// key.
+func newAEAD(keyRing keychain.KeyRing) (cipher.AEAD, error) {
+	encryptionKey, err := genEncryptionKey(keyRing)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(encryptionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// encryptPayloadToWriter encrypts the passed payload with our backup
+// encryption key, and writes it to the passed writer as:
+//
+//	nonce || ciphertext
+func encryptPayloadToWriter(payload bytes.Buffer, w io.Writer,
+	keyRing keychain.KeyRing) error {
+
+	aead, err := newAEAD(keyRing)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	ciphertext := aead.Seal(nil, nonce, payload.Bytes(), nil)
+
+	if _, err := w.Write(nonce); err != nil {
+		return err
+	}
+	_, err = w.Write(ciphertext)
+	return err
+}
+
+// decryptPayloadFromReader reads an encrypted payload written by
+// encryptPayloadToWriter from the passed reader, and decrypts it with our
+// backup encryption key.
+func decryptPayloadFromReader(r io.Reader,
+	keyRing keychain.KeyRing) ([]byte, error) {
+
+	aead, err := newAEAD(keyRing)
+	if err != nil {
+		return nil, err
+	}
+
+	packed, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(packed) < aead.NonceSize()+aead.Overhead() {
+		return nil, ErrInvalidPayload
+	}
+
+	nonce := packed[:aead.NonceSize()]
+	ciphertext := packed[aead.NonceSize():]
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, ErrDecryptionFailed
+	}
+
+	return plaintext, nil
+}
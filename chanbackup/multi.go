@@ -0,0 +1,113 @@
+package chanbackup
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/lightningnetwork/lnd/keychain"
+)
+
+// MultiBackupVersion denotes the version of the serialization format of a
+// multi-channel backup.
+type MultiBackupVersion byte
+
+const (
+	// DefaultMultiVersion is the current version of multi-channel
+	// backups.
+	DefaultMultiVersion MultiBackupVersion = 0
+)
+
+// Multi is a static backup of a set of channels, typically all of our open
+// channels. It allows all the channels to be restored from a single file.
+type Multi struct {
+	// Version is the version of the serialization format of the backup.
+	Version MultiBackupVersion
+
+	// StaticBackups is the set of single channel backups within the
+	// multi-channel backup.
+	StaticBackups []Single
+}
+
+// Serialize writes the plaintext serialization of the backup to the passed
+// writer.
+func (m *Multi) Serialize(w io.Writer) error {
+	if m.Version != DefaultMultiVersion {
+		return fmt.Errorf("unknown multi backup version: %v",
+			m.Version)
+	}
+
+	var scratch [4]byte
+
+	if _, err := w.Write([]byte{byte(m.Version)}); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint32(scratch[:], uint32(len(m.StaticBackups)))
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+
+	for _, single := range m.StaticBackups {
+		if err := single.Serialize(w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Deserialize reads the plaintext serialization of a backup from the passed
+// reader.
+func (m *Multi) Deserialize(r io.Reader) error {
+	var scratch [4]byte
+
+	if _, err := io.ReadFull(r, scratch[:1]); err != nil {
+		return err
+	}
+	m.Version = MultiBackupVersion(scratch[0])
+	if m.Version != DefaultMultiVersion {
+		return fmt.Errorf("unknown multi backup version: %v",
+			m.Version)
+	}
+
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return err
+	}
+	numBackups := byteOrder.Uint32(scratch[:])
+
+	m.StaticBackups = nil
+	for i := uint32(0); i < numBackups; i++ {
+		var single Single
+		if err := single.Deserialize(r); err != nil {
+			return err
+		}
+		m.StaticBackups = append(m.StaticBackups, single)
+	}
+
+	return nil
+}
+
+// PackToWriter serializes the backup, then encrypts it with the backup
+// encryption key derived from the passed key ring before writing it to the
+// passed writer.
+func (m *Multi) PackToWriter(w io.Writer, keyRing keychain.KeyRing) error {
+	var b bytes.Buffer
+	if err := m.Serialize(&b); err != nil {
+		return err
+	}
+
+	return encryptPayloadToWriter(b, w, keyRing)
+}
+
+// UnpackFromReader decrypts an encrypted backup read from the passed reader
+// with the backup encryption key derived from the passed key ring, then
+// deserializes it.
+func (m *Multi) UnpackFromReader(r io.Reader, keyRing keychain.KeyRing) error {
+	plaintext, err := decryptPayloadFromReader(r, keyRing)
+	if err != nil {
+		return err
+	}
+
+	return m.Deserialize(bytes.NewReader(plaintext))
+}
@@ -0,0 +1,50 @@
+package chanbackup
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+// TestMultiPackUnpack tests that a multi-channel backup can be packed and
+// unpacked, preserving each of the single channel backups within it.
+func TestMultiPackUnpack(t *testing.T) {
+	t.Parallel()
+
+	multi := Multi{
+		Version: DefaultMultiVersion,
+		StaticBackups: []Single{
+			newTestSingle(t, 1),
+			newTestSingle(t, 2),
+			newTestSingle(t, 3),
+		},
+	}
+	keyRing := &mockKeyRing{seed: 1}
+
+	var b bytes.Buffer
+	if err := multi.PackToWriter(&b, keyRing); err != nil {
+		t.Fatalf("unable to pack multi: %v", err)
+	}
+
+	var unpacked Multi
+	err := unpacked.UnpackFromReader(bytes.NewReader(b.Bytes()), keyRing)
+	if err != nil {
+		t.Fatalf("unable to unpack multi: %v", err)
+	}
+	if !reflect.DeepEqual(multi, unpacked) {
+		t.Fatalf("multis don't match: expected %v, got %v", multi,
+			unpacked)
+	}
+
+	// A single channel backup isn't a valid multi-channel backup, as the
+	// number of backups it claims to hold won't match.
+	var single bytes.Buffer
+	s := newTestSingle(t, 1)
+	if err := s.PackToWriter(&single, keyRing); err != nil {
+		t.Fatalf("unable to pack single: %v", err)
+	}
+	err = unpacked.UnpackFromReader(bytes.NewReader(single.Bytes()), keyRing)
+	if err == nil {
+		t.Fatalf("expected single backup to be rejected as a multi")
+	}
+}
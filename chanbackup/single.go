@@ -0,0 +1,297 @@
+package chanbackup
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// SingleBackupVersion denotes the version of the serialization format of a
+// single channel backup.
+type SingleBackupVersion byte
+
+const (
+	// DefaultSingleVersion is the version of single channel backups of
+	// channels with the legacy commitment type.
+	DefaultSingleVersion SingleBackupVersion = 0
+
+	// StaticRemoteKeyVersion is the version of single channel backups of
+	// channels with the static remote key commitment type. These
+	// additionally hold the locator of our payment key, allowing our
+	// balance to be swept from the commitment transaction of the peer
+	// using our seed alone.
+	StaticRemoteKeyVersion SingleBackupVersion = 1
+
+	// maxAddresses is the maximum number of peer addresses a single
+	// channel backup may hold.
+	maxAddresses = 32
+)
+
+var byteOrder = binary.BigEndian
+
+// Single is a static backup of a single channel. It holds enough to locate
+// the peer of the channel after all our channel state was lost, so the peer
+// can be asked to force close the channel in order for our funds to be
+// recovered. As the backup doesn't change along with the state of the
+// channel, it only needs to be taken once the channel is opened.
+type Single struct {
+	// Version is the version of the serialization format of the backup.
+	Version SingleBackupVersion
+
+	// FundingOutpoint is the funding outpoint of the channel.
+	FundingOutpoint wire.OutPoint
+
+	// ShortChannelID is the short channel ID of the channel, or zero if
+	// it wasn't known when the backup was taken.
+	ShortChannelID uint64
+
+	// RemoteNodePub is the identity public key of the peer of the
+	// channel.
+	RemoteNodePub *btcec.PublicKey
+
+	// Addresses is the set of addresses the peer could be reached at.
+	Addresses []*net.TCPAddr
+
+	// Capacity is the total capacity of the channel.
+	Capacity btcutil.Amount
+
+	// PaymentKeyLoc is the key locator of the key the commitment
+	// transaction of the peer pays our balance to. It's only set for
+	// backups of the StaticRemoteKeyVersion.
+	PaymentKeyLoc keychain.KeyLocator
+}
+
+// NewSingle creates a backup of the passed channel, whose peer can be reached
+// at the passed addresses. Only the first maxAddresses addresses are kept.
+func NewSingle(channel *channeldb.OpenChannel, shortChanID uint64,
+	addrs []*net.TCPAddr) Single {
+
+	if len(addrs) > maxAddresses {
+		addrs = addrs[:maxAddresses]
+	}
+
+	single := Single{
+		Version:         DefaultSingleVersion,
+		FundingOutpoint: *channel.ChanID,
+		ShortChannelID:  shortChanID,
+		RemoteNodePub:   channel.IdentityPub,
+		Addresses:       addrs,
+		Capacity:        channel.Capacity,
+	}
+
+	// Only the current commitment type of the channel is considered, as
+	// the peer may still broadcast a commitment of the prior type while an
+	// upgrade is pending.
+	if channel.CommitType == channeldb.CommitTypeStaticRemoteKey {
+		single.Version = StaticRemoteKeyVersion
+		single.PaymentKeyLoc = channel.OurPaymentKeyLoc
+	}
+
+	return single
+}
+
+// CommitType returns the commitment type of the backed up channel.
+func (s *Single) CommitType() channeldb.CommitmentType {
+	if s.Version == StaticRemoteKeyVersion {
+		return channeldb.CommitTypeStaticRemoteKey
+	}
+
+	return channeldb.CommitTypeLegacy
+}
+
+// ChannelShell returns the shell of the backed up channel to be stored within
+// the database upon restoring it.
+func (s *Single) ChannelShell(restoredAt time.Time) *channeldb.ChannelShell {
+	return &channeldb.ChannelShell{
+		ChanPoint:     s.FundingOutpoint,
+		ShortChanID:   s.ShortChannelID,
+		RemotePub:     s.RemoteNodePub,
+		Addresses:     s.Addresses,
+		Capacity:      s.Capacity,
+		RestoredAt:    restoredAt,
+		CommitType:    s.CommitType(),
+		PaymentKeyLoc: s.PaymentKeyLoc,
+	}
+}
+
+// Serialize writes the plaintext serialization of the backup to the passed
+// writer.
+func (s *Single) Serialize(w io.Writer) error {
+	if !knownSingleVersion(s.Version) {
+		return fmt.Errorf("unknown single backup version: %v",
+			s.Version)
+	}
+	if len(s.Addresses) > maxAddresses {
+		return fmt.Errorf("single backup may hold at most %v "+
+			"addresses, has %v", maxAddresses, len(s.Addresses))
+	}
+
+	var scratch [8]byte
+
+	if _, err := w.Write([]byte{byte(s.Version)}); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(s.FundingOutpoint.Hash[:]); err != nil {
+		return err
+	}
+	byteOrder.PutUint32(scratch[:4], s.FundingOutpoint.Index)
+	if _, err := w.Write(scratch[:4]); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint64(scratch[:], s.ShortChannelID)
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(s.RemoteNodePub.SerializeCompressed()); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint64(scratch[:], uint64(s.Capacity))
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+
+	if _, err := w.Write([]byte{byte(len(s.Addresses))}); err != nil {
+		return err
+	}
+	for _, addr := range s.Addresses {
+		if err := wire.WriteVarString(w, 0, addr.String()); err != nil {
+			return err
+		}
+	}
+
+	if s.Version == StaticRemoteKeyVersion {
+		byteOrder.PutUint32(scratch[:4], uint32(s.PaymentKeyLoc.Family))
+		byteOrder.PutUint32(scratch[4:], s.PaymentKeyLoc.Index)
+		if _, err := w.Write(scratch[:]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Deserialize reads the plaintext serialization of a backup from the passed
+// reader.
+func (s *Single) Deserialize(r io.Reader) error {
+	var scratch [8]byte
+
+	if _, err := io.ReadFull(r, scratch[:1]); err != nil {
+		return err
+	}
+	s.Version = SingleBackupVersion(scratch[0])
+	if !knownSingleVersion(s.Version) {
+		return fmt.Errorf("unknown single backup version: %v",
+			s.Version)
+	}
+
+	if _, err := io.ReadFull(r, s.FundingOutpoint.Hash[:]); err != nil {
+		return err
+	}
+	if _, err := io.ReadFull(r, scratch[:4]); err != nil {
+		return err
+	}
+	s.FundingOutpoint.Index = byteOrder.Uint32(scratch[:4])
+
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return err
+	}
+	s.ShortChannelID = byteOrder.Uint64(scratch[:])
+
+	var pub [33]byte
+	if _, err := io.ReadFull(r, pub[:]); err != nil {
+		return err
+	}
+	remotePub, err := btcec.ParsePubKey(pub[:], btcec.S256())
+	if err != nil {
+		return err
+	}
+	s.RemoteNodePub = remotePub
+
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return err
+	}
+	s.Capacity = btcutil.Amount(byteOrder.Uint64(scratch[:]))
+
+	if _, err := io.ReadFull(r, scratch[:1]); err != nil {
+		return err
+	}
+	numAddrs := int(scratch[0])
+	if numAddrs > maxAddresses {
+		return fmt.Errorf("single backup may hold at most %v "+
+			"addresses, has %v", maxAddresses, numAddrs)
+	}
+
+	s.Addresses = make([]*net.TCPAddr, numAddrs)
+	for i := 0; i < numAddrs; i++ {
+		addrString, err := wire.ReadVarString(r, 0)
+		if err != nil {
+			return err
+		}
+
+		addr, err := channeldb.ParseTCPAddr(addrString)
+		if err != nil {
+			return err
+		}
+		s.Addresses[i] = addr
+	}
+
+	if s.Version == StaticRemoteKeyVersion {
+		if _, err := io.ReadFull(r, scratch[:]); err != nil {
+			return err
+		}
+		s.PaymentKeyLoc = keychain.KeyLocator{
+			Family: keychain.KeyFamily(byteOrder.Uint32(scratch[:4])),
+			Index:  byteOrder.Uint32(scratch[4:]),
+		}
+	}
+
+	return nil
+}
+
+// knownSingleVersion returns true if the passed single backup version is
+// known.
+func knownSingleVersion(version SingleBackupVersion) bool {
+	switch version {
+	case DefaultSingleVersion, StaticRemoteKeyVersion:
+		return true
+	default:
+		return false
+	}
+}
+
+// PackToWriter serializes the backup, then encrypts it with the backup
+// encryption key derived from the passed key ring before writing it to the
+// passed writer.
+func (s *Single) PackToWriter(w io.Writer, keyRing keychain.KeyRing) error {
+	var b bytes.Buffer
+	if err := s.Serialize(&b); err != nil {
+		return err
+	}
+
+	return encryptPayloadToWriter(b, w, keyRing)
+}
+
+// UnpackFromReader decrypts an encrypted backup read from the passed reader
+// with the backup encryption key derived from the passed key ring, then
+// deserializes it.
+func (s *Single) UnpackFromReader(r io.Reader, keyRing keychain.KeyRing) error {
+	plaintext, err := decryptPayloadFromReader(r, keyRing)
+	if err != nil {
+		return err
+	}
+
+	return s.Deserialize(bytes.NewReader(plaintext))
+}
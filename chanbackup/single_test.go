@@ -0,0 +1,175 @@
+package chanbackup
+
+import (
+	"bytes"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// mockKeyRing is a key ring which derives the same key for every locator,
+// determined by its seed.
+type mockKeyRing struct {
+	seed byte
+}
+
+func (m *mockKeyRing) DeriveNextKey(
+	keyFam keychain.KeyFamily) (keychain.KeyDescriptor, error) {
+
+	return m.DeriveKey(keychain.KeyLocator{Family: keyFam})
+}
+
+func (m *mockKeyRing) DeriveKey(
+	keyLoc keychain.KeyLocator) (keychain.KeyDescriptor, error) {
+
+	var privBytes [32]byte
+	privBytes[31] = m.seed
+	_, pub := btcec.PrivKeyFromBytes(btcec.S256(), privBytes[:])
+
+	return keychain.KeyDescriptor{
+		KeyLocator: keyLoc,
+		PubKey:     pub,
+	}, nil
+}
+
+// newTestSingle returns a single channel backup of a channel with the passed
+// funding output index.
+func newTestSingle(t *testing.T, index uint32) Single {
+	var privBytes [32]byte
+	privBytes[0] = byte(index + 1)
+	_, remotePub := btcec.PrivKeyFromBytes(btcec.S256(), privBytes[:])
+
+	addr, err := net.ResolveTCPAddr("tcp", "10.0.0.1:9735")
+	if err != nil {
+		t.Fatalf("unable to create test addr: %v", err)
+	}
+
+	return Single{
+		Version: DefaultSingleVersion,
+		FundingOutpoint: wire.OutPoint{
+			Hash:  [32]byte{0x01, 0x02, 0x03},
+			Index: index,
+		},
+		ShortChannelID: 1234,
+		RemoteNodePub:  remotePub,
+		Addresses:      []*net.TCPAddr{addr},
+		Capacity:       500000,
+	}
+}
+
+// TestSinglePackUnpack tests that a single channel backup can be packed and
+// unpacked with the same key ring, but not with another.
+func TestSinglePackUnpack(t *testing.T) {
+	t.Parallel()
+
+	single := newTestSingle(t, 1)
+	keyRing := &mockKeyRing{seed: 1}
+
+	var b bytes.Buffer
+	if err := single.PackToWriter(&b, keyRing); err != nil {
+		t.Fatalf("unable to pack single: %v", err)
+	}
+	packed := b.Bytes()
+
+	var unpacked Single
+	err := unpacked.UnpackFromReader(bytes.NewReader(packed), keyRing)
+	if err != nil {
+		t.Fatalf("unable to unpack single: %v", err)
+	}
+	if !reflect.DeepEqual(single, unpacked) {
+		t.Fatalf("singles don't match: expected %v, got %v", single,
+			unpacked)
+	}
+
+	// A backup packed by another wallet should fail to decrypt.
+	otherKeyRing := &mockKeyRing{seed: 2}
+	err = unpacked.UnpackFromReader(bytes.NewReader(packed), otherKeyRing)
+	if err != ErrDecryptionFailed {
+		t.Fatalf("expected ErrDecryptionFailed, got %v", err)
+	}
+
+	// As should a corrupted one.
+	packed[len(packed)-1] ^= 0x01
+	err = unpacked.UnpackFromReader(bytes.NewReader(packed), keyRing)
+	if err != ErrDecryptionFailed {
+		t.Fatalf("expected ErrDecryptionFailed, got %v", err)
+	}
+
+	// A truncated backup can't have been produced by us at all.
+	err = unpacked.UnpackFromReader(bytes.NewReader(packed[:10]), keyRing)
+	if err != ErrInvalidPayload {
+		t.Fatalf("expected ErrInvalidPayload, got %v", err)
+	}
+}
+
+// TestSingleUnknownVersion tests that single channel backups of an unknown
+// version are rejected.
+func TestSingleUnknownVersion(t *testing.T) {
+	t.Parallel()
+
+	single := newTestSingle(t, 1)
+	single.Version = StaticRemoteKeyVersion + 1
+
+	var b bytes.Buffer
+	if err := single.Serialize(&b); err == nil {
+		t.Fatalf("expected serialization of unknown version to fail")
+	}
+
+	single.Version = DefaultSingleVersion
+	if err := single.Serialize(&b); err != nil {
+		t.Fatalf("unable to serialize single: %v", err)
+	}
+	serialized := b.Bytes()
+	serialized[0] = byte(StaticRemoteKeyVersion + 1)
+
+	var deserialized Single
+	err := deserialized.Deserialize(bytes.NewReader(serialized))
+	if err == nil {
+		t.Fatalf("expected deserialization of unknown version to fail")
+	}
+}
+
+// TestSingleStaticRemoteKey tests that the payment key locator of a backup of
+// a static remote key channel survives serialization, and is carried over to
+// the restored channel shell.
+func TestSingleStaticRemoteKey(t *testing.T) {
+	t.Parallel()
+
+	single := newTestSingle(t, 1)
+	single.Version = StaticRemoteKeyVersion
+	single.PaymentKeyLoc = keychain.KeyLocator{
+		Family: keychain.KeyFamilyPaymentBase,
+		Index:  7,
+	}
+
+	var b bytes.Buffer
+	if err := single.Serialize(&b); err != nil {
+		t.Fatalf("unable to serialize single: %v", err)
+	}
+
+	var deserialized Single
+	err := deserialized.Deserialize(bytes.NewReader(b.Bytes()))
+	if err != nil {
+		t.Fatalf("unable to deserialize single: %v", err)
+	}
+	if !reflect.DeepEqual(single, deserialized) {
+		t.Fatalf("singles don't match: expected %v, got %v", single,
+			deserialized)
+	}
+
+	shell := deserialized.ChannelShell(time.Unix(1500000000, 0))
+	if shell.CommitType != channeldb.CommitTypeStaticRemoteKey {
+		t.Fatalf("expected commitment type %v, got %v",
+			channeldb.CommitTypeStaticRemoteKey, shell.CommitType)
+	}
+	if shell.PaymentKeyLoc != single.PaymentKeyLoc {
+		t.Fatalf("expected payment key locator %v, got %v",
+			single.PaymentKeyLoc, shell.PaymentKeyLoc)
+	}
+}
@@ -0,0 +1,196 @@
+package chanfitness
+
+import (
+	"time"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+// eventType is the type of an event recorded within a channel's event log.
+type eventType int
+
+const (
+	// peerOnlineEvent indicates that the channel's peer connected to us.
+	peerOnlineEvent eventType = iota
+
+	// peerOfflineEvent indicates that the channel's peer disconnected
+	// from us.
+	peerOfflineEvent
+
+	// channelActiveEvent indicates that the channel became usable for
+	// forwarding HTLCs.
+	channelActiveEvent
+
+	// channelInactiveEvent indicates that the channel could no longer be
+	// used for forwarding HTLCs.
+	channelInactiveEvent
+)
+
+// String returns a human readable form of the event type.
+func (e eventType) String() string {
+	switch e {
+	case peerOnlineEvent:
+		return "peer_online"
+	case peerOfflineEvent:
+		return "peer_offline"
+	case channelActiveEvent:
+		return "channel_active"
+	case channelInactiveEvent:
+		return "channel_inactive"
+	default:
+		return "unknown"
+	}
+}
+
+// event is a single entry within a channel's event log.
+type event struct {
+	timestamp time.Time
+	eventType eventType
+}
+
+// chanState is the state of a channel at a point in time, along with the
+// durations accumulated since the channel was opened.
+type chanState struct {
+	// at is the time the state applies to.
+	at time.Time
+
+	// peerOnline and chanActive are whether the peer was online, and the
+	// channel active, as of the time at.
+	peerOnline bool
+	chanActive bool
+
+	// uptime and activeTime are the portion of the time between the
+	// channel being opened and the time at that the peer was online, and
+	// the channel was active, respectively.
+	uptime     time.Duration
+	activeTime time.Duration
+}
+
+// advance accumulates the durations of the state up until the passed time,
+// which must not be before the time of the state.
+func (s *chanState) advance(to time.Time) {
+	if to.Before(s.at) {
+		return
+	}
+
+	elapsed := to.Sub(s.at)
+	if s.peerOnline {
+		s.uptime += elapsed
+	}
+	if s.chanActive {
+		s.activeTime += elapsed
+	}
+	s.at = to
+}
+
+// apply advances the state to the time of the passed event, then applies the
+// transition the event represents.
+func (s *chanState) apply(e *event) {
+	s.advance(e.timestamp)
+
+	switch e.eventType {
+	case peerOnlineEvent:
+		s.peerOnline = true
+	case peerOfflineEvent:
+		s.peerOnline = false
+	case channelActiveEvent:
+		s.chanActive = true
+	case channelInactiveEvent:
+		s.chanActive = false
+	}
+}
+
+// chanEventLog records the events concerning a single channel and its peer.
+// Events older than the last flush are folded into the log's base state, so
+// the log's memory use remains bounded for long lived channels.
+type chanEventLog struct {
+	// chanPoint is the funding outpoint of the channel.
+	chanPoint wire.OutPoint
+
+	// peer is the serialized public key of the channel's peer.
+	peer string
+
+	// openedAt is the time monitoring of the channel began.
+	openedAt time.Time
+
+	// base is the state of the channel as of the oldest event remaining
+	// within the log.
+	base chanState
+
+	// events are the events which have occurred since the time of the
+	// base state, in the order they occurred.
+	events []*event
+
+	// flapCount is the number of times the peer has gone offline since
+	// monitoring began, with lastFlap being the last time it did.
+	flapCount int
+	lastFlap  time.Time
+}
+
+// newChanEventLog creates a new event log for the channel, beginning at the
+// passed time with the peer's current connectivity.
+func newChanEventLog(chanPoint wire.OutPoint, peer string, now time.Time,
+	peerOnline bool) *chanEventLog {
+
+	return &chanEventLog{
+		chanPoint: chanPoint,
+		peer:      peer,
+		openedAt:  now,
+		base: chanState{
+			at:         now,
+			peerOnline: peerOnline,
+		},
+	}
+}
+
+// current returns the state of the log, with the durations accumulated up
+// until the passed time.
+func (l *chanEventLog) current(now time.Time) chanState {
+	state := l.base
+	for _, e := range l.events {
+		state.apply(e)
+	}
+	state.advance(now)
+
+	return state
+}
+
+// add records an event within the log, unless it doesn't change the state
+// of the channel, such as a peer coming online which is already online.
+func (l *chanEventLog) add(eventType eventType, now time.Time) {
+	state := l.current(now)
+	switch {
+	case eventType == peerOnlineEvent && state.peerOnline:
+		return
+	case eventType == peerOfflineEvent && !state.peerOnline:
+		return
+	case eventType == channelActiveEvent && state.chanActive:
+		return
+	case eventType == channelInactiveEvent && !state.chanActive:
+		return
+	}
+
+	if eventType == peerOfflineEvent {
+		l.flapCount++
+		l.lastFlap = now
+	}
+
+	l.events = append(l.events, &event{
+		timestamp: now,
+		eventType: eventType,
+	})
+}
+
+// flush folds every event which occurred before the passed cutoff into the
+// base state of the log.
+func (l *chanEventLog) flush(cutoff time.Time) {
+	var i int
+	for ; i < len(l.events); i++ {
+		if !l.events[i].timestamp.Before(cutoff) {
+			break
+		}
+		l.base.apply(l.events[i])
+	}
+
+	l.events = l.events[i:]
+}
@@ -0,0 +1,294 @@
+package chanfitness
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+const (
+	// DefaultFlushInterval is the default interval at which the events
+	// within each channel's event log are folded into its totals.
+	DefaultFlushInterval = time.Hour
+)
+
+var (
+	// ErrChannelNotFound is returned when insights are requested for a
+	// channel which isn't being monitored.
+	ErrChannelNotFound = errors.New("channel not found")
+)
+
+// Config houses the dependencies of the ChannelEventStore.
+type Config struct {
+	// FlushInterval is the interval at which the events within each
+	// channel's event log are folded into its totals.
+	FlushInterval time.Duration
+
+	// Now returns the current time, allowing tests to control the clock.
+	Now func() time.Time
+}
+
+// ChannelInsights summarizes the reliability of a channel and its peer since
+// monitoring of the channel began.
+type ChannelInsights struct {
+	// ChanPoint is the funding outpoint of the channel.
+	ChanPoint wire.OutPoint
+
+	// Peer is the serialized public key of the channel's peer.
+	Peer []byte
+
+	// Lifetime is the time the channel has been monitored for.
+	Lifetime time.Duration
+
+	// Uptime is the portion of the lifetime the peer was online for.
+	Uptime time.Duration
+
+	// ActiveTime is the portion of the lifetime the channel was usable
+	// for forwarding HTLCs.
+	ActiveTime time.Duration
+
+	// FlapCount is the number of times the peer went offline, with
+	// LastFlap being the last time it did.
+	FlapCount int
+	LastFlap  time.Time
+}
+
+// ChannelEventStore records, in memory, the events concerning the
+// connectivity of our peers and the activity of our channels. From these, it
+// derives the lifetime and uptime of each channel, which are used to judge
+// the fitness of our peers.
+type ChannelEventStore struct {
+	started uint32 // To be used atomically.
+	stopped uint32 // To be used atomically.
+
+	cfg *Config
+
+	// channels maps the funding outpoint of each monitored channel to its
+	// event log. onlinePeers is the set of serialized public keys of the
+	// peers currently connected to us. Both are guarded by the mtx.
+	mtx         sync.Mutex
+	channels    map[wire.OutPoint]*chanEventLog
+	onlinePeers map[string]struct{}
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewChannelEventStore creates a new ChannelEventStore, monitoring no
+// channels.
+func NewChannelEventStore(cfg *Config) *ChannelEventStore {
+	if cfg.FlushInterval == 0 {
+		cfg.FlushInterval = DefaultFlushInterval
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+
+	return &ChannelEventStore{
+		cfg:         cfg,
+		channels:    make(map[wire.OutPoint]*chanEventLog),
+		onlinePeers: make(map[string]struct{}),
+		quit:        make(chan struct{}),
+	}
+}
+
+// Start launches the goroutine periodically flushing the event logs.
+func (c *ChannelEventStore) Start() error {
+	if !atomic.CompareAndSwapUint32(&c.started, 0, 1) {
+		return nil
+	}
+
+	log.Infof("Channel event store starting")
+
+	c.wg.Add(1)
+	go c.flusher()
+
+	return nil
+}
+
+// Stop signals the flushing goroutine to exit, and waits for it to do so.
+func (c *ChannelEventStore) Stop() error {
+	if !atomic.CompareAndSwapUint32(&c.stopped, 0, 1) {
+		return nil
+	}
+
+	log.Infof("Channel event store shutting down")
+
+	close(c.quit)
+	c.wg.Wait()
+
+	return nil
+}
+
+// flusher periodically folds the events within each channel's event log into
+// its totals.
+//
+// NOTE: This MUST be run as a goroutine.
+func (c *ChannelEventStore) flusher() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.flush()
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// flush folds the events older than the flush interval within each channel's
+// event log into its totals.
+func (c *ChannelEventStore) flush() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	cutoff := c.cfg.Now().Add(-c.cfg.FlushInterval)
+	for _, l := range c.channels {
+		l.flush(cutoff)
+	}
+}
+
+// AddChannel begins monitoring the channel with the passed funding outpoint
+// and peer. If the channel is already monitored, then this is a no-op.
+func (c *ChannelEventStore) AddChannel(chanPoint wire.OutPoint, peer []byte) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if _, ok := c.channels[chanPoint]; ok {
+		return
+	}
+
+	_, online := c.onlinePeers[string(peer)]
+	c.channels[chanPoint] = newChanEventLog(
+		chanPoint, string(peer), c.cfg.Now(), online,
+	)
+
+	log.Debugf("Monitoring ChannelPoint(%v) of peer %x", chanPoint, peer)
+}
+
+// RemoveChannel stops monitoring the channel with the passed funding
+// outpoint, discarding its event log.
+func (c *ChannelEventStore) RemoveChannel(chanPoint wire.OutPoint) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	delete(c.channels, chanPoint)
+}
+
+// PeerOnline records that the peer with the passed serialized public key has
+// connected, within the event logs of each of its channels.
+func (c *ChannelEventStore) PeerOnline(peer []byte) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.onlinePeers[string(peer)] = struct{}{}
+	c.addPeerEvent(string(peer), peerOnlineEvent)
+}
+
+// PeerOffline records that the peer with the passed serialized public key has
+// disconnected, within the event logs of each of its channels.
+func (c *ChannelEventStore) PeerOffline(peer []byte) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	delete(c.onlinePeers, string(peer))
+	c.addPeerEvent(string(peer), peerOfflineEvent)
+}
+
+// addPeerEvent records the event within the event logs of each of the peer's
+// channels.
+//
+// NOTE: The mtx MUST be held when calling this method.
+func (c *ChannelEventStore) addPeerEvent(peer string, eventType eventType) {
+	now := c.cfg.Now()
+	for _, l := range c.channels {
+		if l.peer == peer {
+			l.add(eventType, now)
+		}
+	}
+}
+
+// ChannelActive records that the channel with the passed funding outpoint has
+// become usable for forwarding HTLCs.
+func (c *ChannelEventStore) ChannelActive(chanPoint wire.OutPoint) {
+	c.addChannelEvent(chanPoint, channelActiveEvent)
+}
+
+// ChannelInactive records that the channel with the passed funding outpoint
+// can no longer be used for forwarding HTLCs.
+func (c *ChannelEventStore) ChannelInactive(chanPoint wire.OutPoint) {
+	c.addChannelEvent(chanPoint, channelInactiveEvent)
+}
+
+// addChannelEvent records the event within the channel's event log, if the
+// channel is being monitored.
+func (c *ChannelEventStore) addChannelEvent(chanPoint wire.OutPoint,
+	eventType eventType) {
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	l, ok := c.channels[chanPoint]
+	if !ok {
+		log.Debugf("Ignoring %v event of unmonitored "+
+			"ChannelPoint(%v)", eventType, chanPoint)
+		return
+	}
+
+	l.add(eventType, c.cfg.Now())
+}
+
+// Insights returns the insights into the channel with the passed funding
+// outpoint. If the channel isn't being monitored, then ErrChannelNotFound is
+// returned.
+func (c *ChannelEventStore) Insights(
+	chanPoint wire.OutPoint) (*ChannelInsights, error) {
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	l, ok := c.channels[chanPoint]
+	if !ok {
+		return nil, ErrChannelNotFound
+	}
+
+	return c.insights(l), nil
+}
+
+// AllInsights returns the insights into every monitored channel.
+func (c *ChannelEventStore) AllInsights() []*ChannelInsights {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	insights := make([]*ChannelInsights, 0, len(c.channels))
+	for _, l := range c.channels {
+		insights = append(insights, c.insights(l))
+	}
+
+	return insights
+}
+
+// insights derives the insights into a channel from its event log.
+//
+// NOTE: The mtx MUST be held when calling this method.
+func (c *ChannelEventStore) insights(l *chanEventLog) *ChannelInsights {
+	now := c.cfg.Now()
+	state := l.current(now)
+
+	return &ChannelInsights{
+		ChanPoint:  l.chanPoint,
+		Peer:       []byte(l.peer),
+		Lifetime:   now.Sub(l.openedAt),
+		Uptime:     state.uptime,
+		ActiveTime: state.activeTime,
+		FlapCount:  l.flapCount,
+		LastFlap:   l.lastFlap,
+	}
+}
@@ -0,0 +1,165 @@
+package chanfitness
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+// testClock is a manually advanced clock.
+type testClock struct {
+	now time.Time
+}
+
+func (t *testClock) Now() time.Time          { return t.now }
+func (t *testClock) advance(d time.Duration) { t.now = t.now.Add(d) }
+
+// newTestStore creates a ChannelEventStore backed by a test clock.
+func newTestStore() (*ChannelEventStore, *testClock) {
+	clock := &testClock{now: time.Unix(1500000000, 0)}
+	store := NewChannelEventStore(&Config{
+		FlushInterval: time.Hour,
+		Now:           clock.Now,
+	})
+
+	return store, clock
+}
+
+// assertInsights asserts the lifetime, uptime, active time and flap count of
+// the channel.
+func assertInsights(t *testing.T, store *ChannelEventStore,
+	chanPoint wire.OutPoint, lifetime, uptime, activeTime time.Duration,
+	flapCount int) {
+
+	insights, err := store.Insights(chanPoint)
+	if err != nil {
+		t.Fatalf("unable to fetch insights: %v", err)
+	}
+
+	if insights.Lifetime != lifetime {
+		t.Fatalf("expected lifetime of %v, got %v", lifetime,
+			insights.Lifetime)
+	}
+	if insights.Uptime != uptime {
+		t.Fatalf("expected uptime of %v, got %v", uptime,
+			insights.Uptime)
+	}
+	if insights.ActiveTime != activeTime {
+		t.Fatalf("expected active time of %v, got %v", activeTime,
+			insights.ActiveTime)
+	}
+	if insights.FlapCount != flapCount {
+		t.Fatalf("expected %v flaps, got %v", flapCount,
+			insights.FlapCount)
+	}
+}
+
+// TestChannelUptime asserts that the lifetime and uptime of a channel are
+// properly accumulated across several connections of its peer.
+func TestChannelUptime(t *testing.T) {
+	t.Parallel()
+
+	store, clock := newTestStore()
+
+	peer := []byte("peer")
+	chanPoint := wire.OutPoint{Index: 1}
+	store.AddChannel(chanPoint, peer)
+
+	// The peer connects after a minute, then disconnects after another.
+	clock.advance(time.Minute)
+	store.PeerOnline(peer)
+	clock.advance(time.Minute)
+	store.PeerOffline(peer)
+
+	// Duplicate disconnections shouldn't affect the uptime, or count as
+	// flaps.
+	clock.advance(time.Minute)
+	store.PeerOffline(peer)
+
+	assertInsights(t, store, chanPoint, 3*time.Minute, time.Minute, 0, 1)
+
+	// The current connection of the peer should be included within its
+	// uptime, as should the time the channel has been active.
+	store.PeerOnline(peer)
+	store.ChannelActive(chanPoint)
+	clock.advance(2 * time.Minute)
+
+	assertInsights(t, store, chanPoint, 5*time.Minute, 3*time.Minute,
+		2*time.Minute, 1)
+
+	// A channel which isn't monitored has no insights.
+	if _, err := store.Insights(wire.OutPoint{}); err != ErrChannelNotFound {
+		t.Fatalf("expected ErrChannelNotFound, got %v", err)
+	}
+}
+
+// TestChannelAddedWhilePeerOnline asserts that a channel added while its
+// peer is online accumulates uptime from the moment it was added, and that
+// events of other peers don't affect it.
+func TestChannelAddedWhilePeerOnline(t *testing.T) {
+	t.Parallel()
+
+	store, clock := newTestStore()
+
+	peer := []byte("peer")
+	store.PeerOnline(peer)
+	clock.advance(time.Hour)
+
+	chanPoint := wire.OutPoint{Index: 1}
+	store.AddChannel(chanPoint, peer)
+	clock.advance(time.Minute)
+
+	store.PeerOffline([]byte("other"))
+	clock.advance(time.Minute)
+
+	assertInsights(t, store, chanPoint, 2*time.Minute, 2*time.Minute, 0, 0)
+
+	// Once removed, the channel is no longer monitored.
+	store.RemoveChannel(chanPoint)
+	if _, err := store.Insights(chanPoint); err != ErrChannelNotFound {
+		t.Fatalf("expected ErrChannelNotFound, got %v", err)
+	}
+}
+
+// TestFlushEventLog asserts that flushing the event log folds old events
+// into the totals without altering the insights.
+func TestFlushEventLog(t *testing.T) {
+	t.Parallel()
+
+	store, clock := newTestStore()
+
+	peer := []byte("peer")
+	chanPoint := wire.OutPoint{Index: 1}
+	store.AddChannel(chanPoint, peer)
+
+	// The peer flaps once each hour, being online for half of it.
+	for i := 0; i < 4; i++ {
+		store.PeerOnline(peer)
+		store.ChannelActive(chanPoint)
+		clock.advance(30 * time.Minute)
+		store.ChannelInactive(chanPoint)
+		store.PeerOffline(peer)
+		clock.advance(30 * time.Minute)
+	}
+	store.PeerOnline(peer)
+	clock.advance(10 * time.Minute)
+
+	assertInsights(t, store, chanPoint, 250*time.Minute, 130*time.Minute,
+		120*time.Minute, 4)
+
+	// Only the events within the last hour should remain after the
+	// flush: the last inactive and offline events, and the final online
+	// event.
+	store.flush()
+
+	store.mtx.Lock()
+	numEvents := len(store.channels[chanPoint].events)
+	store.mtx.Unlock()
+	if numEvents != 3 {
+		t.Fatalf("expected 3 events after flush, got %v", numEvents)
+	}
+
+	assertInsights(t, store, chanPoint, 250*time.Minute, 130*time.Minute,
+		120*time.Minute, 4)
+}
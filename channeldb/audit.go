@@ -0,0 +1,172 @@
+package channeldb
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/boltdb/bolt"
+	"github.com/lightningnetwork/lnd/elkrem"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// ExportForAudit writes a copy of the database to a new file at the passed
+// path, stripped of the secrets which would allow the holder of the copy to
+// spend our funds or claim our payments: the preimages of our invoices, the
+// root of the revocation preimages we send to our channel peers, and the
+// revocation preimages we've received from them. The remaining state, such
+// as channel balances, commitment transactions, payments and the channel
+// graph, is retained so the copy may be shared with support staff or
+// accountants.
+//
+// The copy is written key by key into a fresh database rather than copying
+// the database file, so that no stale pages holding the stripped secrets are
+// carried over. As a consequence, the copy MUST NOT be used to run a node.
+func (d *DB) ExportForAudit(destPath string) error {
+	if fileExists(destPath) {
+		return fmt.Errorf("unable to export database: %v already "+
+			"exists", destPath)
+	}
+
+	dest, err := bolt.Open(destPath, dbFilePermission, nil)
+	if err != nil {
+		return err
+	}
+
+	err = d.View(func(srcTx *bolt.Tx) error {
+		return dest.Update(func(destTx *bolt.Tx) error {
+			return srcTx.ForEach(func(name []byte, b *bolt.Bucket) error {
+				destBucket, err := destTx.CreateBucket(name)
+				if err != nil {
+					return err
+				}
+
+				return exportBucket(b, destBucket, [][]byte{name})
+			})
+		})
+	})
+	closeErr := dest.Close()
+	if err != nil {
+		os.Remove(destPath)
+		return err
+	}
+
+	return closeErr
+}
+
+// exportBucket recursively copies the contents of the source bucket, found at
+// the passed path of bucket names, into the destination bucket, redacting any
+// secrets within it.
+func exportBucket(src, dest *bolt.Bucket, path [][]byte) error {
+	return src.ForEach(func(k, v []byte) error {
+		// A nil value indicates a nested bucket.
+		if v == nil {
+			destBucket, err := dest.CreateBucket(k)
+			if err != nil {
+				return err
+			}
+
+			nestedPath := append(path[:len(path):len(path)], k)
+			return exportBucket(src.Bucket(k), destBucket, nestedPath)
+		}
+
+		redacted, err := redactValue(path, k, v)
+		if err != nil {
+			return err
+		}
+
+		return dest.Put(k, redacted)
+	})
+}
+
+// redactValue returns the passed value, stored under the passed key within
+// the bucket at the passed path, with any secrets within it stripped.
+func redactValue(path [][]byte, k, v []byte) ([]byte, error) {
+	switch {
+	// Each invoice is stored within the top-level invoice bucket, keyed by
+	// its number.
+	case len(path) == 1 && bytes.Equal(path[0], invoiceBucket):
+		return redactInvoice(v)
+
+	// The revocation state of each channel is stored within the bucket of
+	// the channel's peer, within the open channel bucket.
+	case len(path) == 2 && bytes.Equal(path[0], openChannelBucket) &&
+		bytes.HasPrefix(k, elkremStateKey):
+
+		return redactElkremState(v)
+	}
+
+	return v, nil
+}
+
+// redactInvoice strips the payment preimage from the passed serialized
+// invoice. The payment hash of the invoice remains available within the
+// invoice index.
+func redactInvoice(v []byte) ([]byte, error) {
+	invoice, err := deserializeInvoice(bytes.NewReader(v))
+	if err != nil {
+		return nil, err
+	}
+
+	invoice.Terms.PaymentPreimage = [32]byte{}
+
+	var b bytes.Buffer
+	if err := serializeInvoice(&b, invoice); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// redactElkremState strips the root of our elkrem sender, along with the
+// revocation preimages received from our peer, from the passed serialized
+// revocation state of a channel. The state remains readable, holding an
+// all-zero root and an empty receiver.
+func redactElkremState(v []byte) ([]byte, error) {
+	r := bytes.NewReader(v)
+
+	revKey, err := wire.ReadVarBytes(r, 0, 1000, "")
+	if err != nil {
+		return nil, err
+	}
+	var revHash [32]byte
+	if _, err := r.Read(revHash[:]); err != nil {
+		return nil, err
+	}
+
+	// The sender root and the receiver are read only to be discarded.
+	if _, err := wire.ReadVarBytes(r, 0, 1000, ""); err != nil {
+		return nil, err
+	}
+	if _, err := wire.ReadVarBytes(r, 0, 1000, ""); err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	if err := wire.WriteVarBytes(&b, 0, revKey); err != nil {
+		return nil, err
+	}
+	if _, err := b.Write(revHash[:]); err != nil {
+		return nil, err
+	}
+
+	sender := elkrem.NewElkremSender(chainhash.Hash{})
+	if err := wire.WriteVarBytes(&b, 0, sender.ToBytes()); err != nil {
+		return nil, err
+	}
+	receiverBytes, err := (&elkrem.ElkremReceiver{}).ToBytes()
+	if err != nil {
+		return nil, err
+	}
+	if err := wire.WriteVarBytes(&b, 0, receiverBytes); err != nil {
+		return nil, err
+	}
+
+	// Finally, the state hint obfuscator is carried over as is.
+	if _, err := r.WriteTo(&b); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
@@ -0,0 +1,107 @@
+package channeldb
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestExportForAudit asserts that an audit export of the database retains the
+// state of our channels and invoices, while being stripped of their secrets.
+func TestExportForAudit(t *testing.T) {
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	state, err := createTestChannelState(db)
+	if err != nil {
+		t.Fatalf("unable to create channel state: %v", err)
+	}
+	if err := state.FullSync(); err != nil {
+		t.Fatalf("unable to save channel state: %v", err)
+	}
+	invoice, err := randInvoice(1000)
+	if err != nil {
+		t.Fatalf("unable to create invoice: %v", err)
+	}
+	if err := db.AddInvoice(invoice); err != nil {
+		t.Fatalf("unable to add invoice: %v", err)
+	}
+
+	exportDir, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(exportDir)
+
+	exportPath := filepath.Join(exportDir, dbName)
+	if err := db.ExportForAudit(exportPath); err != nil {
+		t.Fatalf("unable to export database: %v", err)
+	}
+
+	// An existing file must never be overwritten.
+	if err := db.ExportForAudit(exportPath); err == nil {
+		t.Fatalf("export overwrote existing file")
+	}
+
+	// Neither the invoice preimage, nor the elkrem root, should be found
+	// anywhere within the exported file.
+	exported, err := ioutil.ReadFile(exportPath)
+	if err != nil {
+		t.Fatalf("unable to read export: %v", err)
+	}
+	if bytes.Contains(exported, invoice.Terms.PaymentPreimage[:]) {
+		t.Fatalf("export contains invoice preimage")
+	}
+	if bytes.Contains(exported, key[:]) {
+		t.Fatalf("export contains elkrem root")
+	}
+
+	auditDB, err := Open(exportDir)
+	if err != nil {
+		t.Fatalf("unable to open export: %v", err)
+	}
+	defer auditDB.Close()
+
+	invoices, err := auditDB.FetchAllInvoices(false)
+	if err != nil {
+		t.Fatalf("unable to fetch invoices: %v", err)
+	}
+	if len(invoices) != 1 {
+		t.Fatalf("expected 1 invoice, got %d", len(invoices))
+	}
+	if invoices[0].Terms.Value != invoice.Terms.Value {
+		t.Fatalf("expected invoice value %v, got %v",
+			invoice.Terms.Value, invoices[0].Terms.Value)
+	}
+	if invoices[0].Terms.PaymentPreimage != [32]byte{} {
+		t.Fatalf("invoice preimage wasn't stripped")
+	}
+
+	channels, err := auditDB.FetchOpenChannels(state.IdentityPub)
+	if err != nil {
+		t.Fatalf("unable to fetch channels: %v", err)
+	}
+	if len(channels) != 1 {
+		t.Fatalf("expected 1 channel, got %d", len(channels))
+	}
+	channel := channels[0]
+	if channel.OurBalance != state.OurBalance ||
+		channel.TheirBalance != state.TheirBalance {
+
+		t.Fatalf("channel balances don't match")
+	}
+	if !bytes.Equal(channel.LocalElkrem.ToBytes(), make([]byte, 32)) {
+		t.Fatalf("elkrem root wasn't stripped")
+	}
+	if channel.RemoteElkrem.UpTo() != 0 {
+		t.Fatalf("received revocation preimages weren't stripped")
+	}
+	if channel.StateHintObsfucator != state.StateHintObsfucator {
+		t.Fatalf("state hint obfuscator doesn't match")
+	}
+}
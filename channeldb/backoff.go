@@ -0,0 +1,110 @@
+package channeldb
+
+import (
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+var (
+	// peerBackoffBucket is the name of the bucket within the database that
+	// stores the reconnection backoff state of our persistent peers. Each
+	// peer is keyed by its serialized compressed public key, with the
+	// value being its serialized PeerBackoff.
+	peerBackoffBucket = []byte("peer-backoff")
+)
+
+// PeerBackoff is the state of the backoff applied when reconnecting to a
+// persistent peer. It's persisted so that a peer which repeatedly
+// disconnects isn't immediately reconnected to after a restart.
+type PeerBackoff struct {
+	// LastConnected is the time at which the connection to the peer was
+	// last established.
+	LastConnected time.Time
+
+	// LastDisconnected is the time at which the connection to the peer
+	// was last lost.
+	LastDisconnected time.Time
+
+	// Backoff is the delay to wait for after the connection was lost
+	// before reconnecting to the peer.
+	Backoff time.Duration
+}
+
+// NextRetry returns the earliest time at which the peer should be reconnected
+// to.
+func (b *PeerBackoff) NextRetry() time.Time {
+	return b.LastDisconnected.Add(b.Backoff)
+}
+
+// PutPeerBackoff stores the backoff state of the peer with the passed public
+// key, overwriting any prior state.
+func (d *DB) PutPeerBackoff(pubKey *btcec.PublicKey, backoff *PeerBackoff) error {
+	var b [24]byte
+	byteOrder.PutUint64(b[:8], uint64(backoff.LastConnected.Unix()))
+	byteOrder.PutUint64(b[8:16], uint64(backoff.LastDisconnected.Unix()))
+	byteOrder.PutUint64(b[16:], uint64(backoff.Backoff))
+
+	return d.Update(func(tx *bolt.Tx) error {
+		backoffs, err := tx.CreateBucketIfNotExists(peerBackoffBucket)
+		if err != nil {
+			return err
+		}
+
+		return backoffs.Put(pubKey.SerializeCompressed(), b[:])
+	})
+}
+
+// DeletePeerBackoffs removes the backoff state of the peers with the passed
+// serialized compressed public keys. Peers without any stored state are
+// skipped.
+func (d *DB) DeletePeerBackoffs(pubKeys [][]byte) error {
+	return d.Update(func(tx *bolt.Tx) error {
+		backoffs := tx.Bucket(peerBackoffBucket)
+		if backoffs == nil {
+			return nil
+		}
+
+		for _, pubKey := range pubKeys {
+			if err := backoffs.Delete(pubKey); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// FetchPeerBackoffs returns the backoff state of all peers, keyed by their
+// serialized compressed public key.
+func (d *DB) FetchPeerBackoffs() (map[string]*PeerBackoff, error) {
+	peerBackoffs := make(map[string]*PeerBackoff)
+
+	err := d.View(func(tx *bolt.Tx) error {
+		backoffs := tx.Bucket(peerBackoffBucket)
+		if backoffs == nil {
+			return nil
+		}
+
+		return backoffs.ForEach(func(k, v []byte) error {
+			if len(v) != 24 {
+				return nil
+			}
+
+			connected := int64(byteOrder.Uint64(v[:8]))
+			disconnected := int64(byteOrder.Uint64(v[8:16]))
+			peerBackoffs[string(k)] = &PeerBackoff{
+				LastConnected:    time.Unix(connected, 0),
+				LastDisconnected: time.Unix(disconnected, 0),
+				Backoff:          time.Duration(byteOrder.Uint64(v[16:])),
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return peerBackoffs, nil
+}
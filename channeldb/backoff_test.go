@@ -0,0 +1,76 @@
+package channeldb
+
+import (
+	"testing"
+	"time"
+)
+
+// TestPeerBackoffs tests that the backoff state of peers can be stored,
+// overwritten and deleted.
+func TestPeerBackoffs(t *testing.T) {
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	backoffs, err := db.FetchPeerBackoffs()
+	if err != nil {
+		t.Fatalf("unable to fetch backoffs: %v", err)
+	}
+	if len(backoffs) != 0 {
+		t.Fatalf("expected no backoffs, got %v", len(backoffs))
+	}
+
+	connected := time.Unix(1500000000, 0)
+	backoff := &PeerBackoff{
+		LastConnected:    connected,
+		LastDisconnected: connected.Add(time.Minute),
+		Backoff:          time.Second * 10,
+	}
+	if err := db.PutPeerBackoff(pubKey, backoff); err != nil {
+		t.Fatalf("unable to store backoff: %v", err)
+	}
+
+	// Storing the backoff of the same peer again should overwrite it.
+	backoff.Backoff *= 2
+	if err := db.PutPeerBackoff(pubKey, backoff); err != nil {
+		t.Fatalf("unable to store backoff: %v", err)
+	}
+
+	backoffs, err = db.FetchPeerBackoffs()
+	if err != nil {
+		t.Fatalf("unable to fetch backoffs: %v", err)
+	}
+	if len(backoffs) != 1 {
+		t.Fatalf("expected 1 backoff, got %v", len(backoffs))
+	}
+	stored, ok := backoffs[string(pubKey.SerializeCompressed())]
+	if !ok {
+		t.Fatalf("backoff of peer not found")
+	}
+	if !stored.LastConnected.Equal(backoff.LastConnected) ||
+		!stored.LastDisconnected.Equal(backoff.LastDisconnected) ||
+		stored.Backoff != backoff.Backoff {
+
+		t.Fatalf("expected backoff %v, got %v", backoff, stored)
+	}
+	expectedRetry := connected.Add(time.Minute + time.Second*20)
+	if !stored.NextRetry().Equal(expectedRetry) {
+		t.Fatalf("expected next retry at %v, got %v", expectedRetry,
+			stored.NextRetry())
+	}
+
+	// Once deleted, the backoff of the peer should no longer be found.
+	err = db.DeletePeerBackoffs([][]byte{pubKey.SerializeCompressed()})
+	if err != nil {
+		t.Fatalf("unable to delete backoff: %v", err)
+	}
+	backoffs, err = db.FetchPeerBackoffs()
+	if err != nil {
+		t.Fatalf("unable to fetch backoffs: %v", err)
+	}
+	if len(backoffs) != 0 {
+		t.Fatalf("expected no backoffs, got %v", len(backoffs))
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/boltdb/bolt"
 	"github.com/lightningnetwork/lnd/elkrem"
+	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcd/wire"
@@ -96,8 +97,44 @@ var (
 	// deliveryScriptsKey stores the scripts for the final delivery in the
 	// case of a cooperative closure.
 	deliveryScriptsKey = []byte("dsk")
+
+	// keyLocatorsKey stores the key locators of our multi-sig and
+	// commitment keys, allowing them to be re-derived from the wallet's
+	// seed.
+	keyLocatorsKey = []byte("klk")
+
+	// chanConstraintsKey stores the channel constraints imposed by both
+	// parties during the funding workflow.
+	chanConstraintsKey = []byte("cck")
+
+	// commitFormatKey stores the current and pending commitment types of
+	// a channel, along with the payment keys of both parties if either
+	// type pays to them, and the height of the last upgrade.
+	commitFormatKey = []byte("cfk")
 )
 
+// ChannelConstraints are the constraints imposed by one party of a channel
+// upon the other. They limit the HTLCs the constrained party may offer, and
+// the balance it must keep within the channel. A zero value for any of the
+// amounts, or for the number of HTLCs, indicates the absence of the
+// respective constraint.
+type ChannelConstraints struct {
+	// ChanReserve is the balance the constrained party must keep within
+	// the channel at all times.
+	ChanReserve btcutil.Amount
+
+	// MaxPendingAmount is the maximum total value of the pending HTLCs
+	// the constrained party may offer at any one time.
+	MaxPendingAmount btcutil.Amount
+
+	// MinHTLC is the smallest HTLC the constrained party may offer.
+	MinHTLC btcutil.Amount
+
+	// MaxAcceptedHtlcs is the maximum number of pending HTLCs the
+	// constrained party may offer at any one time.
+	MaxAcceptedHtlcs uint16
+}
+
 // ChannelType is an enum-like type that describes one of several possible
 // channel types. Each open channel is associated with a particular type as the
 // channel type may determine how higher level operations are conducted such as
@@ -119,6 +156,38 @@ const (
 	DualFunder = 1
 )
 
+// CommitmentType denotes the format of the commitment transactions of a
+// channel.
+type CommitmentType uint8
+
+const (
+	// NOTE: iota isn't used here for this enum needs to be stable
+	// long-term as it will be persisted to the database.
+
+	// CommitTypeLegacy is the original commitment format, within which
+	// the output paying to the remote party pays to its commitment key,
+	// the key also used within its delayed output.
+	CommitTypeLegacy CommitmentType = 0
+
+	// CommitTypeStaticRemoteKey is the commitment format within which the
+	// output paying to the remote party pays to a dedicated payment key
+	// derived from its seed. As the key never changes, the remote party
+	// is able to sweep the output with only its seed.
+	CommitTypeStaticRemoteKey CommitmentType = 1
+)
+
+// String returns a human readable name for the commitment type.
+func (c CommitmentType) String() string {
+	switch c {
+	case CommitTypeLegacy:
+		return "legacy"
+	case CommitTypeStaticRemoteKey:
+		return "static_remote_key"
+	default:
+		return "unknown"
+	}
+}
+
 // OpenChannel encapsulates the persistent and dynamic state of an open channel
 // with a remote node. An open channel supports several options for on-disk
 // serialization depending on the exact context. Full (upon channel creation)
@@ -151,16 +220,63 @@ type OpenChannel struct {
 	// this amount are not enforceable onchain from out point of view.
 	OurDustLimit btcutil.Amount
 
+	// OurConstraints are the constraints we've imposed upon the remote
+	// party: the HTLCs they offer us must satisfy them, and their balance
+	// must remain above the reserve. Channels created before constraints
+	// were negotiated will have empty constraints.
+	OurConstraints ChannelConstraints
+
+	// TheirConstraints are the constraints the remote party has imposed
+	// upon us, which our outgoing HTLCs and balance must satisfy.
+	TheirConstraints ChannelConstraints
+
 	// OurCommitKey is the key to be used within our commitment transaction
 	// to generate the scripts for outputs paying to ourself, and
 	// revocation clauses.
 	OurCommitKey *btcec.PublicKey
 
+	// OurCommitKeyLoc is the key locator of our commitment key within the
+	// wallet's key chain. Channels created before keys were derived from
+	// key families will have an empty key locator.
+	OurCommitKeyLoc keychain.KeyLocator
+
 	// TheirCommitKey is the key to be used within our commitment
 	// transaction to generate the scripts for outputs paying to ourself,
 	// and revocation clauses.
 	TheirCommitKey *btcec.PublicKey
 
+	// CommitType is the format of the current commitment transactions of
+	// the channel.
+	CommitType CommitmentType
+
+	// PendingCommitType is the format the commitment transactions of the
+	// channel are being upgraded to, all new commitments being created in
+	// this format. Both formats are kept until the commitments in the
+	// prior format have been revoked, after which CommitType is set to
+	// PendingCommitType. If no upgrade is pending, both are equal.
+	PendingCommitType CommitmentType
+
+	// UpgradeHeight is the commitment height, agreed upon by both
+	// parties, from which the commitments of both chains are created in
+	// the PendingCommitType format. It's zero if the channel was never
+	// upgraded.
+	UpgradeHeight uint64
+
+	// OurPaymentKey is the key the remote commitment transaction pays our
+	// balance to if the commitment type is CommitTypeStaticRemoteKey. It
+	// may be nil if neither commitment type requires it.
+	OurPaymentKey *btcec.PublicKey
+
+	// OurPaymentKeyLoc is the key locator of our payment key within the
+	// wallet's key chain.
+	OurPaymentKeyLoc keychain.KeyLocator
+
+	// TheirPaymentKey is the key our commitment transaction pays the
+	// balance of the remote party to if the commitment type is
+	// CommitTypeStaticRemoteKey. It may be nil if neither commitment type
+	// requires it.
+	TheirPaymentKey *btcec.PublicKey
+
 	// Capacity is the total capacity of this channel.
 	// TODO(roasbeef): need another field to mark how much fees have been
 	// allocated independent of capacity.
@@ -202,6 +318,11 @@ type OpenChannel struct {
 	// transaction that we control.
 	OurMultiSigKey *btcec.PublicKey
 
+	// OurMultiSigKeyLoc is the key locator of our multi-sig key within the
+	// wallet's key chain. Channels created before keys were derived from
+	// key families will have an empty key locator.
+	OurMultiSigKeyLoc keychain.KeyLocator
+
 	// TheirMultiSigKey is the multi-sig key used within the funding
 	// transaction for the remote party.
 	TheirMultiSigKey *btcec.PublicKey
@@ -407,6 +528,100 @@ func (c *OpenChannel) UpdateCommitment(newCommitment *wire.MsgTx,
 	})
 }
 
+// UpgradeCommitType marks the channel as being upgraded to the passed
+// commitment type, which all commitments from the passed height onwards will
+// be created in. The payment keys of both parties are stored along with the
+// new type, as it may require them. The upgrade is only completed once
+// CompleteCommitTypeUpgrade is called.
+func (c *OpenChannel) UpgradeCommitType(commitType CommitmentType,
+	height uint64, ourPaymentKey *btcec.PublicKey,
+	ourPaymentKeyLoc keychain.KeyLocator,
+	theirPaymentKey *btcec.PublicKey) error {
+
+	c.Lock()
+	defer c.Unlock()
+
+	if c.PendingCommitType != c.CommitType {
+		return fmt.Errorf("upgrade to commitment type %v already "+
+			"pending", c.PendingCommitType)
+	}
+
+	return c.updateCommitFormat(func() {
+		c.PendingCommitType = commitType
+		c.UpgradeHeight = height
+		c.OurPaymentKey = ourPaymentKey
+		c.OurPaymentKeyLoc = ourPaymentKeyLoc
+		c.TheirPaymentKey = theirPaymentKey
+	})
+}
+
+// CompleteCommitTypeUpgrade completes a pending commitment type upgrade, to
+// be called once the commitments in the prior format have been revoked.
+func (c *OpenChannel) CompleteCommitTypeUpgrade() error {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.updateCommitFormat(func() {
+		c.CommitType = c.PendingCommitType
+	})
+}
+
+// AbortCommitTypeUpgrade abandons a pending commitment type upgrade, to be
+// called if the remote party never recorded it, and no commitment was
+// created in the new format. The payment keys are dropped unless the
+// current type requires them.
+func (c *OpenChannel) AbortCommitTypeUpgrade() error {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.updateCommitFormat(func() {
+		c.PendingCommitType = c.CommitType
+		c.UpgradeHeight = 0
+		if c.CommitType != CommitTypeStaticRemoteKey {
+			c.OurPaymentKey = nil
+			c.OurPaymentKeyLoc = keychain.KeyLocator{}
+			c.TheirPaymentKey = nil
+		}
+	})
+}
+
+// updateCommitFormat applies the passed modification of the commitment
+// format of the channel, and writes the new format to disk. The in-memory
+// state is only modified if the write succeeds.
+//
+// NOTE: The channel's mutex must be held when calling this method.
+func (c *OpenChannel) updateCommitFormat(modify func()) error {
+	prevType, prevPendingType := c.CommitType, c.PendingCommitType
+	prevHeight := c.UpgradeHeight
+	prevOurKey, prevOurKeyLoc := c.OurPaymentKey, c.OurPaymentKeyLoc
+	prevTheirKey := c.TheirPaymentKey
+	modify()
+
+	err := c.Db.Update(func(tx *bolt.Tx) error {
+		chanBucket, err := tx.CreateBucketIfNotExists(openChannelBucket)
+		if err != nil {
+			return err
+		}
+
+		id := c.IdentityPub.SerializeCompressed()
+		nodeChanBucket, err := chanBucket.CreateBucketIfNotExists(id)
+		if err != nil {
+			return err
+		}
+
+		return putChanCommitFormat(nodeChanBucket, c)
+	})
+	if err != nil {
+		c.CommitType, c.PendingCommitType = prevType, prevPendingType
+		c.UpgradeHeight = prevHeight
+		c.OurPaymentKey, c.OurPaymentKeyLoc = prevOurKey, prevOurKeyLoc
+		c.TheirPaymentKey = prevTheirKey
+		return err
+	}
+
+	return nil
+}
+
 // HTLC is the on-disk representation of a hash time-locked contract. HTLC's
 // are contained within ChannelDeltas which encode the current state of the
 // commitment between state updates.
@@ -734,6 +949,15 @@ func putOpenChannel(openChanBucket *bolt.Bucket, nodeChanBucket *bolt.Bucket,
 	if err := putChanDeliveryScripts(nodeChanBucket, channel); err != nil {
 		return err
 	}
+	if err := putChanKeyLocators(nodeChanBucket, channel); err != nil {
+		return err
+	}
+	if err := putChanConstraints(nodeChanBucket, channel); err != nil {
+		return err
+	}
+	if err := putChanCommitFormat(nodeChanBucket, channel); err != nil {
+		return err
+	}
 	if err := putCurrentHtlcs(nodeChanBucket, channel.Htlcs,
 		channel.ChanID); err != nil {
 		return err
@@ -771,6 +995,15 @@ func fetchOpenChannel(openChanBucket *bolt.Bucket, nodeChanBucket *bolt.Bucket,
 	if err = fetchChanDeliveryScripts(nodeChanBucket, channel); err != nil {
 		return nil, err
 	}
+	if err = fetchChanKeyLocators(nodeChanBucket, channel); err != nil {
+		return nil, err
+	}
+	if err = fetchChanConstraints(nodeChanBucket, channel); err != nil {
+		return nil, err
+	}
+	if err = fetchChanCommitFormat(nodeChanBucket, channel); err != nil {
+		return nil, err
+	}
 	channel.Htlcs, err = fetchCurrentHtlcs(nodeChanBucket, chanID)
 	if err != nil {
 		return nil, err
@@ -839,6 +1072,15 @@ func deleteOpenChannel(openChanBucket *bolt.Bucket, nodeChanBucket *bolt.Bucket,
 	if err := deleteChanDeliveryScripts(nodeChanBucket, channelID); err != nil {
 		return err
 	}
+	if err := deleteChanKeyLocators(nodeChanBucket, channelID); err != nil {
+		return err
+	}
+	if err := deleteChanConstraints(nodeChanBucket, channelID); err != nil {
+		return err
+	}
+	if err := deleteChanCommitFormat(nodeChanBucket, channelID); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -1579,6 +1821,288 @@ func fetchChanDeliveryScripts(nodeChanBucket *bolt.Bucket, channel *OpenChannel)
 	return nil
 }
 
+func putChanKeyLocators(nodeChanBucket *bolt.Bucket, channel *OpenChannel) error {
+	var bc bytes.Buffer
+	if err := writeOutpoint(&bc, channel.ChanID); err != nil {
+		return err
+	}
+	locatorsKey := make([]byte, len(keyLocatorsKey)+bc.Len())
+	copy(locatorsKey[:3], keyLocatorsKey)
+	copy(locatorsKey[3:], bc.Bytes())
+
+	var b bytes.Buffer
+	if err := writeKeyLocator(&b, channel.OurMultiSigKeyLoc); err != nil {
+		return err
+	}
+	if err := writeKeyLocator(&b, channel.OurCommitKeyLoc); err != nil {
+		return err
+	}
+
+	return nodeChanBucket.Put(locatorsKey, b.Bytes())
+}
+
+func deleteChanKeyLocators(nodeChanBucket *bolt.Bucket, chanID []byte) error {
+	locatorsKey := make([]byte, len(keyLocatorsKey)+len(chanID))
+	copy(locatorsKey[:3], keyLocatorsKey)
+	copy(locatorsKey[3:], chanID)
+	return nodeChanBucket.Delete(locatorsKey)
+}
+
+func fetchChanKeyLocators(nodeChanBucket *bolt.Bucket, channel *OpenChannel) error {
+	var bc bytes.Buffer
+	if err := writeOutpoint(&bc, channel.ChanID); err != nil {
+		return err
+	}
+	locatorsKey := make([]byte, len(keyLocatorsKey)+bc.Len())
+	copy(locatorsKey[:3], keyLocatorsKey)
+	copy(locatorsKey[3:], bc.Bytes())
+
+	// Channels created before key locators were stored won't have any,
+	// so their locators are left empty.
+	locatorBytes := nodeChanBucket.Get(locatorsKey)
+	if locatorBytes == nil {
+		return nil
+	}
+	r := bytes.NewReader(locatorBytes)
+
+	var err error
+	channel.OurMultiSigKeyLoc, err = readKeyLocator(r)
+	if err != nil {
+		return err
+	}
+	channel.OurCommitKeyLoc, err = readKeyLocator(r)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func putChanConstraints(nodeChanBucket *bolt.Bucket, channel *OpenChannel) error {
+	var bc bytes.Buffer
+	if err := writeOutpoint(&bc, channel.ChanID); err != nil {
+		return err
+	}
+	constraintsKey := make([]byte, len(chanConstraintsKey)+bc.Len())
+	copy(constraintsKey[:3], chanConstraintsKey)
+	copy(constraintsKey[3:], bc.Bytes())
+
+	var b bytes.Buffer
+	if err := writeChanConstraints(&b, channel.OurConstraints); err != nil {
+		return err
+	}
+	if err := writeChanConstraints(&b, channel.TheirConstraints); err != nil {
+		return err
+	}
+
+	return nodeChanBucket.Put(constraintsKey, b.Bytes())
+}
+
+func deleteChanConstraints(nodeChanBucket *bolt.Bucket, chanID []byte) error {
+	constraintsKey := make([]byte, len(chanConstraintsKey)+len(chanID))
+	copy(constraintsKey[:3], chanConstraintsKey)
+	copy(constraintsKey[3:], chanID)
+	return nodeChanBucket.Delete(constraintsKey)
+}
+
+func fetchChanConstraints(nodeChanBucket *bolt.Bucket, channel *OpenChannel) error {
+	var bc bytes.Buffer
+	if err := writeOutpoint(&bc, channel.ChanID); err != nil {
+		return err
+	}
+	constraintsKey := make([]byte, len(chanConstraintsKey)+bc.Len())
+	copy(constraintsKey[:3], chanConstraintsKey)
+	copy(constraintsKey[3:], bc.Bytes())
+
+	// Channels created before constraints were negotiated won't have
+	// any, so they're left unconstrained.
+	constraintBytes := nodeChanBucket.Get(constraintsKey)
+	if constraintBytes == nil {
+		return nil
+	}
+	r := bytes.NewReader(constraintBytes)
+
+	var err error
+	channel.OurConstraints, err = readChanConstraints(r)
+	if err != nil {
+		return err
+	}
+	channel.TheirConstraints, err = readChanConstraints(r)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func putChanCommitFormat(nodeChanBucket *bolt.Bucket, channel *OpenChannel) error {
+	var bc bytes.Buffer
+	if err := writeOutpoint(&bc, channel.ChanID); err != nil {
+		return err
+	}
+	formatKey := make([]byte, len(commitFormatKey)+bc.Len())
+	copy(formatKey[:3], commitFormatKey)
+	copy(formatKey[3:], bc.Bytes())
+
+	var b bytes.Buffer
+	err := b.WriteByte(byte(channel.CommitType))
+	if err != nil {
+		return err
+	}
+	if err := b.WriteByte(byte(channel.PendingCommitType)); err != nil {
+		return err
+	}
+
+	// The payment keys are only written if they're set, which is
+	// signalled by a flag byte.
+	if channel.OurPaymentKey == nil || channel.TheirPaymentKey == nil {
+		if err := b.WriteByte(0); err != nil {
+			return err
+		}
+	} else {
+		if err := b.WriteByte(1); err != nil {
+			return err
+		}
+		if _, err := b.Write(channel.OurPaymentKey.SerializeCompressed()); err != nil {
+			return err
+		}
+		if err := writeKeyLocator(&b, channel.OurPaymentKeyLoc); err != nil {
+			return err
+		}
+		if _, err := b.Write(channel.TheirPaymentKey.SerializeCompressed()); err != nil {
+			return err
+		}
+	}
+
+	var height [8]byte
+	byteOrder.PutUint64(height[:], channel.UpgradeHeight)
+	if _, err := b.Write(height[:]); err != nil {
+		return err
+	}
+
+	return nodeChanBucket.Put(formatKey, b.Bytes())
+}
+
+func deleteChanCommitFormat(nodeChanBucket *bolt.Bucket, chanID []byte) error {
+	formatKey := make([]byte, len(commitFormatKey)+len(chanID))
+	copy(formatKey[:3], commitFormatKey)
+	copy(formatKey[3:], chanID)
+	return nodeChanBucket.Delete(formatKey)
+}
+
+func fetchChanCommitFormat(nodeChanBucket *bolt.Bucket, channel *OpenChannel) error {
+	var bc bytes.Buffer
+	if err := writeOutpoint(&bc, channel.ChanID); err != nil {
+		return err
+	}
+	formatKey := make([]byte, len(commitFormatKey)+bc.Len())
+	copy(formatKey[:3], commitFormatKey)
+	copy(formatKey[3:], bc.Bytes())
+
+	// Channels created before commitment types were stored are legacy
+	// channels, which don't use payment keys.
+	formatBytes := nodeChanBucket.Get(formatKey)
+	if formatBytes == nil {
+		channel.CommitType = CommitTypeLegacy
+		channel.PendingCommitType = CommitTypeLegacy
+		return nil
+	}
+	r := bytes.NewReader(formatBytes)
+
+	var scratch [33]byte
+	if _, err := io.ReadFull(r, scratch[:3]); err != nil {
+		return err
+	}
+	channel.CommitType = CommitmentType(scratch[0])
+	channel.PendingCommitType = CommitmentType(scratch[1])
+	if scratch[2] == 1 {
+		var err error
+		if _, err := io.ReadFull(r, scratch[:]); err != nil {
+			return err
+		}
+		channel.OurPaymentKey, err = btcec.ParsePubKey(
+			scratch[:], btcec.S256(),
+		)
+		if err != nil {
+			return err
+		}
+		channel.OurPaymentKeyLoc, err = readKeyLocator(r)
+		if err != nil {
+			return err
+		}
+		if _, err := io.ReadFull(r, scratch[:]); err != nil {
+			return err
+		}
+		channel.TheirPaymentKey, err = btcec.ParsePubKey(
+			scratch[:], btcec.S256(),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err := io.ReadFull(r, scratch[:8]); err != nil {
+		return err
+	}
+	channel.UpgradeHeight = byteOrder.Uint64(scratch[:8])
+
+	return nil
+}
+
+// writeChanConstraints serializes a set of channel constraints as the
+// reserve, max pending amount, and min HTLC, followed by the max number of
+// accepted HTLCs.
+func writeChanConstraints(w io.Writer, c ChannelConstraints) error {
+	var scratch [26]byte
+	byteOrder.PutUint64(scratch[:8], uint64(c.ChanReserve))
+	byteOrder.PutUint64(scratch[8:16], uint64(c.MaxPendingAmount))
+	byteOrder.PutUint64(scratch[16:24], uint64(c.MinHTLC))
+	byteOrder.PutUint16(scratch[24:], c.MaxAcceptedHtlcs)
+
+	_, err := w.Write(scratch[:])
+	return err
+}
+
+// readChanConstraints deserializes a set of channel constraints written by
+// writeChanConstraints.
+func readChanConstraints(r io.Reader) (ChannelConstraints, error) {
+	var scratch [26]byte
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return ChannelConstraints{}, err
+	}
+
+	return ChannelConstraints{
+		ChanReserve:      btcutil.Amount(byteOrder.Uint64(scratch[:8])),
+		MaxPendingAmount: btcutil.Amount(byteOrder.Uint64(scratch[8:16])),
+		MinHTLC:          btcutil.Amount(byteOrder.Uint64(scratch[16:24])),
+		MaxAcceptedHtlcs: byteOrder.Uint16(scratch[24:]),
+	}, nil
+}
+
+// writeKeyLocator serializes a key locator as its key family followed by its
+// index.
+func writeKeyLocator(w io.Writer, keyLoc keychain.KeyLocator) error {
+	var scratch [8]byte
+	byteOrder.PutUint32(scratch[:4], uint32(keyLoc.Family))
+	byteOrder.PutUint32(scratch[4:], keyLoc.Index)
+
+	_, err := w.Write(scratch[:])
+	return err
+}
+
+// readKeyLocator deserializes a key locator written by writeKeyLocator.
+func readKeyLocator(r io.Reader) (keychain.KeyLocator, error) {
+	var scratch [8]byte
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return keychain.KeyLocator{}, err
+	}
+
+	return keychain.KeyLocator{
+		Family: keychain.KeyFamily(byteOrder.Uint32(scratch[:4])),
+		Index:  byteOrder.Uint32(scratch[4:]),
+	}, nil
+}
+
 // htlcDiskSize represents the number of btyes a serialized HTLC takes up on
 // disk. The size of an HTLC on disk is 49 bytes total: incoming (1) + amt (8)
 // + rhash (32) + timeouts (8) + output index (2)
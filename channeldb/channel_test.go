@@ -10,6 +10,7 @@ import (
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/elkrem"
+	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
@@ -135,6 +136,19 @@ func createTestChannelState(cdb *DB) (*OpenChannel, error) {
 	var obsfucator [4]byte
 	copy(obsfucator[:], key[:])
 
+	ourConstraints := ChannelConstraints{
+		ChanReserve:      btcutil.Amount(100),
+		MaxPendingAmount: btcutil.Amount(8000),
+		MinHTLC:          btcutil.Amount(1),
+		MaxAcceptedHtlcs: 30,
+	}
+	theirConstraints := ChannelConstraints{
+		ChanReserve:      btcutil.Amount(150),
+		MaxPendingAmount: btcutil.Amount(9000),
+		MinHTLC:          btcutil.Amount(5),
+		MaxAcceptedHtlcs: 483,
+	}
+
 	return &OpenChannel{
 		IsInitiator:                true,
 		ChanType:                   SingleFunder,
@@ -143,7 +157,10 @@ func createTestChannelState(cdb *DB) (*OpenChannel, error) {
 		MinFeePerKb:                btcutil.Amount(5000),
 		TheirDustLimit:             btcutil.Amount(200),
 		OurDustLimit:               btcutil.Amount(200),
+		OurConstraints:             ourConstraints,
+		TheirConstraints:           theirConstraints,
 		OurCommitKey:               privKey.PubKey(),
+		OurCommitKeyLoc:            keychain.KeyLocator{Family: 3, Index: 1},
 		TheirCommitKey:             pubKey,
 		Capacity:                   btcutil.Amount(10000),
 		OurBalance:                 btcutil.Amount(3000),
@@ -155,6 +172,7 @@ func createTestChannelState(cdb *DB) (*OpenChannel, error) {
 		StateHintObsfucator:        obsfucator,
 		FundingOutpoint:            testOutpoint,
 		OurMultiSigKey:             privKey.PubKey(),
+		OurMultiSigKeyLoc:          keychain.KeyLocator{Index: 2},
 		TheirMultiSigKey:           privKey.PubKey(),
 		FundingWitnessScript:       script,
 		TheirCurrentRevocation:     privKey.PubKey(),
@@ -271,6 +289,18 @@ func TestOpenChannelPutGetDelete(t *testing.T) {
 		newState.OurMultiSigKey.SerializeCompressed()) {
 		t.Fatalf("our multisig key doesn't match")
 	}
+	if state.OurMultiSigKeyLoc != newState.OurMultiSigKeyLoc {
+		t.Fatalf("our multisig key locator doesn't match")
+	}
+	if state.OurCommitKeyLoc != newState.OurCommitKeyLoc {
+		t.Fatalf("our commit key locator doesn't match")
+	}
+	if state.OurConstraints != newState.OurConstraints {
+		t.Fatalf("our constraints don't match")
+	}
+	if state.TheirConstraints != newState.TheirConstraints {
+		t.Fatalf("their constraints don't match")
+	}
 	if !bytes.Equal(state.TheirMultiSigKey.SerializeCompressed(),
 		newState.TheirMultiSigKey.SerializeCompressed()) {
 		t.Fatalf("their multisig key doesn't match")
@@ -508,3 +538,113 @@ func TestChannelStateTransition(t *testing.T) {
 		t.Fatalf("revocation state wasn't synced!")
 	}
 }
+
+// TestCommitTypeUpgrade asserts that a pending commitment type upgrade, along
+// with the payment keys it requires, is persisted until it's completed.
+func TestCommitTypeUpgrade(t *testing.T) {
+	cdb, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	channel, err := createTestChannelState(cdb)
+	if err != nil {
+		t.Fatalf("unable to create channel state: %v", err)
+	}
+	if err := channel.FullSync(); err != nil {
+		t.Fatalf("unable to save and serialize channel state: %v", err)
+	}
+
+	fetchChannel := func() *OpenChannel {
+		channels, err := cdb.FetchOpenChannels(channel.IdentityPub)
+		if err != nil {
+			t.Fatalf("unable to fetch open channel: %v", err)
+		}
+		return channels[0]
+	}
+
+	// A channel synced without a commitment type is a legacy channel.
+	diskChannel := fetchChannel()
+	if diskChannel.CommitType != CommitTypeLegacy ||
+		diskChannel.PendingCommitType != CommitTypeLegacy {
+		t.Fatalf("expected legacy channel, got %v/%v",
+			diskChannel.CommitType, diskChannel.PendingCommitType)
+	}
+	if diskChannel.OurPaymentKey != nil {
+		t.Fatalf("legacy channel shouldn't have payment keys")
+	}
+
+	ourKeyLoc := keychain.KeyLocator{Family: 3, Index: 7}
+	err = channel.UpgradeCommitType(
+		CommitTypeStaticRemoteKey, 5, privKey.PubKey(), ourKeyLoc,
+		pubKey,
+	)
+	if err != nil {
+		t.Fatalf("unable to upgrade commitment type: %v", err)
+	}
+
+	// Both formats must be retained while the upgrade is pending.
+	diskChannel = fetchChannel()
+	if diskChannel.CommitType != CommitTypeLegacy {
+		t.Fatalf("expected legacy commitment type, got %v",
+			diskChannel.CommitType)
+	}
+	if diskChannel.PendingCommitType != CommitTypeStaticRemoteKey {
+		t.Fatalf("expected pending static remote key type, got %v",
+			diskChannel.PendingCommitType)
+	}
+	if !diskChannel.OurPaymentKey.IsEqual(privKey.PubKey()) ||
+		diskChannel.OurPaymentKeyLoc != ourKeyLoc {
+		t.Fatalf("our payment key doesn't match")
+	}
+	if !diskChannel.TheirPaymentKey.IsEqual(pubKey) {
+		t.Fatalf("their payment key doesn't match")
+	}
+	if diskChannel.UpgradeHeight != 5 {
+		t.Fatalf("expected upgrade height 5, got %v",
+			diskChannel.UpgradeHeight)
+	}
+
+	// A second upgrade can't be started until the first one completes.
+	err = channel.UpgradeCommitType(
+		CommitTypeStaticRemoteKey, 5, privKey.PubKey(), ourKeyLoc,
+		pubKey,
+	)
+	if err == nil {
+		t.Fatalf("second upgrade shouldn't be allowed")
+	}
+
+	// Aborting the upgrade should restore the legacy format, dropping the
+	// payment keys, after which it may be started anew.
+	if err := channel.AbortCommitTypeUpgrade(); err != nil {
+		t.Fatalf("unable to abort upgrade: %v", err)
+	}
+	diskChannel = fetchChannel()
+	if diskChannel.CommitType != CommitTypeLegacy ||
+		diskChannel.PendingCommitType != CommitTypeLegacy {
+		t.Fatalf("upgrade not aborted, got %v/%v",
+			diskChannel.CommitType, diskChannel.PendingCommitType)
+	}
+	if diskChannel.OurPaymentKey != nil || diskChannel.UpgradeHeight != 0 {
+		t.Fatalf("aborted upgrade shouldn't leave payment keys or " +
+			"height")
+	}
+	err = channel.UpgradeCommitType(
+		CommitTypeStaticRemoteKey, 6, privKey.PubKey(), ourKeyLoc,
+		pubKey,
+	)
+	if err != nil {
+		t.Fatalf("unable to upgrade commitment type: %v", err)
+	}
+
+	if err := channel.CompleteCommitTypeUpgrade(); err != nil {
+		t.Fatalf("unable to complete upgrade: %v", err)
+	}
+	diskChannel = fetchChannel()
+	if diskChannel.CommitType != CommitTypeStaticRemoteKey ||
+		diskChannel.PendingCommitType != CommitTypeStaticRemoteKey {
+		t.Fatalf("upgrade not completed, got %v/%v",
+			diskChannel.CommitType, diskChannel.PendingCommitType)
+	}
+}
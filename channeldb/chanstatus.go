@@ -0,0 +1,87 @@
+package channeldb
+
+import (
+	"bytes"
+
+	"github.com/boltdb/bolt"
+	"github.com/roasbeef/btcd/wire"
+)
+
+var (
+	// manualChanStatusBucket is the name of the bucket within the database
+	// that stores the channels whose status has been set manually,
+	// overriding its automatic management. Each channel is keyed by its
+	// serialized funding outpoint, with a single byte value which is 1 if
+	// the channel was disabled, and 0 if it was enabled.
+	manualChanStatusBucket = []byte("manual-chan-status")
+)
+
+// SetManualChanStatus records that the channel identified by the passed
+// funding outpoint has been manually disabled, or enabled.
+func (d *DB) SetManualChanStatus(chanPoint *wire.OutPoint, disabled bool) error {
+	var b bytes.Buffer
+	if err := writeOutpoint(&b, chanPoint); err != nil {
+		return err
+	}
+
+	var status byte
+	if disabled {
+		status = 1
+	}
+
+	return d.Update(func(tx *bolt.Tx) error {
+		statuses, err := tx.CreateBucketIfNotExists(manualChanStatusBucket)
+		if err != nil {
+			return err
+		}
+
+		return statuses.Put(b.Bytes(), []byte{status})
+	})
+}
+
+// ClearManualChanStatus removes the manually set status of the channel
+// identified by the passed funding outpoint, returning it to automatic
+// management.
+func (d *DB) ClearManualChanStatus(chanPoint *wire.OutPoint) error {
+	var b bytes.Buffer
+	if err := writeOutpoint(&b, chanPoint); err != nil {
+		return err
+	}
+
+	return d.Update(func(tx *bolt.Tx) error {
+		statuses := tx.Bucket(manualChanStatusBucket)
+		if statuses == nil {
+			return nil
+		}
+
+		return statuses.Delete(b.Bytes())
+	})
+}
+
+// FetchManualChanStatuses returns the funding outpoints of all channels whose
+// status has been set manually, each mapped to whether it was disabled.
+func (d *DB) FetchManualChanStatuses() (map[wire.OutPoint]bool, error) {
+	chanStatuses := make(map[wire.OutPoint]bool)
+
+	err := d.View(func(tx *bolt.Tx) error {
+		statuses := tx.Bucket(manualChanStatusBucket)
+		if statuses == nil {
+			return nil
+		}
+
+		return statuses.ForEach(func(k, v []byte) error {
+			var chanPoint wire.OutPoint
+			if err := readOutpoint(bytes.NewReader(k), &chanPoint); err != nil {
+				return err
+			}
+
+			chanStatuses[chanPoint] = len(v) == 1 && v[0] == 1
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return chanStatuses, nil
+}
@@ -0,0 +1,58 @@
+package channeldb
+
+import (
+	"testing"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+// TestManualChanStatuses tests that the manually set status of channels can
+// be stored, overwritten, and cleared.
+func TestManualChanStatuses(t *testing.T) {
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	// Initially, no channel has a manual status.
+	statuses, err := db.FetchManualChanStatuses()
+	if err != nil {
+		t.Fatalf("unable to fetch statuses: %v", err)
+	}
+	if len(statuses) != 0 {
+		t.Fatalf("expected no statuses, got %v", len(statuses))
+	}
+
+	op1 := wire.OutPoint{Hash: key, Index: 1}
+	op2 := wire.OutPoint{Hash: key, Index: 2}
+	op3 := wire.OutPoint{Hash: key, Index: 3}
+	for _, op := range []wire.OutPoint{op1, op2, op3} {
+		if err := db.SetManualChanStatus(&op, true); err != nil {
+			t.Fatalf("unable to set status: %v", err)
+		}
+	}
+
+	// Re-enabling the second channel should overwrite its status, while
+	// clearing the third should remove it altogether.
+	if err := db.SetManualChanStatus(&op2, false); err != nil {
+		t.Fatalf("unable to set status: %v", err)
+	}
+	if err := db.ClearManualChanStatus(&op3); err != nil {
+		t.Fatalf("unable to clear status: %v", err)
+	}
+
+	statuses, err = db.FetchManualChanStatuses()
+	if err != nil {
+		t.Fatalf("unable to fetch statuses: %v", err)
+	}
+	if len(statuses) != 2 {
+		t.Fatalf("expected 2 statuses, got %v", len(statuses))
+	}
+	if disabled, ok := statuses[op1]; !ok || !disabled {
+		t.Fatalf("expected %v to be disabled", op1)
+	}
+	if disabled, ok := statuses[op2]; !ok || disabled {
+		t.Fatalf("expected %v to be enabled", op2)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/boltdb/bolt"
 	"github.com/roasbeef/btcd/btcec"
@@ -39,6 +40,12 @@ var (
 			number:    0,
 			migration: nil,
 		},
+		{
+			// The version which indexes payments by their
+			// payment hash.
+			number:    1,
+			migration: migratePaymentHashIndex,
+		},
 	}
 
 	// Big endian is the preferred byte order, due to cursor scans over
@@ -56,11 +63,30 @@ var bufPool = &sync.Pool{
 type DB struct {
 	*bolt.DB
 	dbPath string
+
+	// graphCache is an in-memory copy of the channel graph, or nil if the
+	// graph cache is disabled.
+	graphCache *graphCache
+
+	// updateMtx serializes the read-write transactions along with the
+	// modifications they make to the graph cache. Bolt runs the commit
+	// handlers of a transaction after releasing its writer lock, so
+	// without it the cache could be modified out of order.
+	updateMtx sync.Mutex
+
+	// txObserver, if non-nil, is passed the duration of each transaction.
+	txObserver func(update bool, elapsed time.Duration)
 }
 
 // Open opens an existing channeldb. Any necessary schemas migrations due to
-// updates will take place as necessary.
-func Open(dbPath string) (*DB, error) {
+// updates will take place as necessary. Unless disabled through the passed
+// options, the channel graph is loaded into memory.
+func Open(dbPath string, modifiers ...OptionModifier) (*DB, error) {
+	opts := DefaultOptions()
+	for _, modifier := range modifiers {
+		modifier(&opts)
+	}
+
 	path := filepath.Join(dbPath, dbName)
 
 	if !fileExists(path) {
@@ -75,8 +101,9 @@ func Open(dbPath string) (*DB, error) {
 	}
 
 	chanDB := &DB{
-		DB:     bdb,
-		dbPath: dbPath,
+		DB:         bdb,
+		dbPath:     dbPath,
+		txObserver: opts.TxObserver,
 	}
 
 	// Synchronize the version of database and apply migrations if needed.
@@ -85,9 +112,53 @@ func Open(dbPath string) (*DB, error) {
 		return nil, err
 	}
 
+	// With the database up to date, we'll load the channel graph into
+	// memory if the graph cache is enabled.
+	if !opts.NoGraphCache {
+		cache := newGraphCache()
+		if err := chanDB.View(cache.populate); err != nil {
+			bdb.Close()
+			return nil, err
+		}
+		chanDB.graphCache = cache
+	}
+
 	return chanDB, nil
 }
 
+// Update executes the passed function within the context of a read-write
+// transaction, passing the duration of the transaction to the configured
+// observer.
+func (d *DB) Update(fn func(*bolt.Tx) error) error {
+	d.updateMtx.Lock()
+	defer d.updateMtx.Unlock()
+
+	start := time.Now()
+	err := d.DB.Update(fn)
+	d.observeTx(true, start)
+
+	return err
+}
+
+// View executes the passed function within the context of a read-only
+// transaction, passing the duration of the transaction to the configured
+// observer.
+func (d *DB) View(fn func(*bolt.Tx) error) error {
+	start := time.Now()
+	err := d.DB.View(fn)
+	d.observeTx(false, start)
+
+	return err
+}
+
+// observeTx passes the duration of a transaction which began at the passed
+// time to the configured observer, if any.
+func (d *DB) observeTx(update bool, start time.Time) {
+	if d.txObserver != nil {
+		d.txObserver(update, time.Since(start))
+	}
+}
+
 // Wipe completely deletes all saved state within all used buckets within the
 // database. The deletion is done in a single transaction, therefore this
 // operation is fully atomic.
@@ -130,6 +201,9 @@ func (d *DB) Wipe() error {
 			return err
 		}
 
+		d.updateGraphCache(tx, func(cache *graphCache) {
+			cache.reset()
+		})
 		return nil
 	})
 }
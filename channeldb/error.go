@@ -14,7 +14,11 @@ var (
 	ErrNoInvoicesCreated = fmt.Errorf("there are no existing invoices")
 	ErrDuplicateInvoice  = fmt.Errorf("invoice with payment hash already exists")
 
+	ErrInvoiceAlreadySettled  = fmt.Errorf("invoice already settled")
+	ErrInvoiceAlreadyCanceled = fmt.Errorf("invoice already canceled")
+
 	ErrNoPaymentsCreated = fmt.Errorf("there are no existing payments")
+	ErrPaymentNotFound   = fmt.Errorf("unable to locate payment")
 
 	ErrNodeNotFound = fmt.Errorf("link node with target identity not found")
 	ErrMetaNotFound = fmt.Errorf("unable to locate meta information")
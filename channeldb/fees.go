@@ -1 +1,66 @@
 package channeldb
+
+import (
+	"bytes"
+
+	"github.com/boltdb/bolt"
+	"github.com/roasbeef/btcd/wire"
+)
+
+var (
+	// feeManagedBucket is the name of the bucket within the database that
+	// stores the set of channels which have opted into automatic fee
+	// management. Each channel is keyed by its serialized funding outpoint,
+	// with an empty value.
+	feeManagedBucket = []byte("fee-managed-channels")
+)
+
+// SetFeeManaged marks the channel identified by the passed funding outpoint as
+// having opted in, or out, of automatic fee management.
+func (d *DB) SetFeeManaged(chanPoint *wire.OutPoint, managed bool) error {
+	var b bytes.Buffer
+	if err := writeOutpoint(&b, chanPoint); err != nil {
+		return err
+	}
+
+	return d.Update(func(tx *bolt.Tx) error {
+		managedChans, err := tx.CreateBucketIfNotExists(feeManagedBucket)
+		if err != nil {
+			return err
+		}
+
+		if !managed {
+			return managedChans.Delete(b.Bytes())
+		}
+
+		return managedChans.Put(b.Bytes(), []byte{})
+	})
+}
+
+// FetchFeeManagedChannels returns the funding outpoints of all channels which
+// have opted into automatic fee management.
+func (d *DB) FetchFeeManagedChannels() ([]wire.OutPoint, error) {
+	var chanPoints []wire.OutPoint
+
+	err := d.View(func(tx *bolt.Tx) error {
+		managedChans := tx.Bucket(feeManagedBucket)
+		if managedChans == nil {
+			return nil
+		}
+
+		return managedChans.ForEach(func(k, _ []byte) error {
+			var chanPoint wire.OutPoint
+			if err := readOutpoint(bytes.NewReader(k), &chanPoint); err != nil {
+				return err
+			}
+
+			chanPoints = append(chanPoints, chanPoint)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return chanPoints, nil
+}
@@ -0,0 +1,48 @@
+package channeldb
+
+import (
+	"testing"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+// TestFeeManagedChannels tests that channels can opt into, and back out of,
+// automatic fee management.
+func TestFeeManagedChannels(t *testing.T) {
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	// Initially, no channel is managed.
+	chanPoints, err := db.FetchFeeManagedChannels()
+	if err != nil {
+		t.Fatalf("unable to fetch managed channels: %v", err)
+	}
+	if len(chanPoints) != 0 {
+		t.Fatalf("expected no managed channels, got %v", len(chanPoints))
+	}
+
+	op1 := wire.OutPoint{Hash: key, Index: 1}
+	op2 := wire.OutPoint{Hash: key, Index: 2}
+	for _, op := range []wire.OutPoint{op1, op2} {
+		if err := db.SetFeeManaged(&op, true); err != nil {
+			t.Fatalf("unable to opt in channel: %v", err)
+		}
+	}
+
+	// Opting the first channel back out should leave only the second.
+	if err := db.SetFeeManaged(&op1, false); err != nil {
+		t.Fatalf("unable to opt out channel: %v", err)
+	}
+
+	chanPoints, err = db.FetchFeeManagedChannels()
+	if err != nil {
+		t.Fatalf("unable to fetch managed channels: %v", err)
+	}
+	if len(chanPoints) != 1 || chanPoints[0] != op2 {
+		t.Fatalf("expected only %v to be managed, got %v", op2,
+			chanPoints)
+	}
+}
@@ -183,6 +183,12 @@ func (c *ChannelGraph) ForEachNode(cb func(*LightningNode) error) error {
 	// TODO(roasbeef): need to also pass in a transaction? or reverse order
 	// to get all in memory THEN execute callback?
 
+	// If the graph is cached, then we can traverse it without accessing
+	// the disk.
+	if c.db.graphCache != nil {
+		return c.db.graphCache.forEachNode(c.db, cb)
+	}
+
 	return c.db.View(func(tx *bolt.Tx) error {
 		// First grab the nodes bucket which stores the mapping from
 		// pubKey to node information.
@@ -271,7 +277,14 @@ func (c *ChannelGraph) SetSourceNode(node *LightningNode) error {
 
 		// Finally, we commit the information of the lightning node
 		// itself.
-		return addLightningNode(tx, node)
+		if err := addLightningNode(tx, node); err != nil {
+			return err
+		}
+
+		c.db.updateGraphCache(tx, func(cache *graphCache) {
+			cache.addNode(node)
+		})
+		return nil
 	})
 }
 
@@ -281,7 +294,14 @@ func (c *ChannelGraph) SetSourceNode(node *LightningNode) error {
 // TODO(roasbeef): also need sig of announcement
 func (c *ChannelGraph) AddLightningNode(node *LightningNode) error {
 	return c.db.Update(func(tx *bolt.Tx) error {
-		return addLightningNode(tx, node)
+		if err := addLightningNode(tx, node); err != nil {
+			return err
+		}
+
+		c.db.updateGraphCache(tx, func(cache *graphCache) {
+			cache.addNode(node)
+		})
+		return nil
 	})
 }
 
@@ -353,7 +373,14 @@ func (c *ChannelGraph) DeleteLightningNode(nodePub *btcec.PublicKey) error {
 		if err := aliases.Delete(pub); err != nil {
 			return err
 		}
-		return nodes.Delete(pub)
+		if err := nodes.Delete(pub); err != nil {
+			return err
+		}
+
+		c.db.updateGraphCache(tx, func(cache *graphCache) {
+			cache.deleteNode(pub)
+		})
+		return nil
 	})
 }
 
@@ -423,7 +450,14 @@ func (c *ChannelGraph) AddChannelEdge(from, to *btcec.PublicKey,
 		if err := writeOutpoint(&b, chanPoint); err != nil {
 			return err
 		}
-		return chanIndex.Put(b.Bytes(), chanKey[:])
+		if err := chanIndex.Put(b.Bytes(), chanKey[:]); err != nil {
+			return err
+		}
+
+		c.db.updateGraphCache(tx, func(cache *graphCache) {
+			cache.addChannel(chanID, *chanPoint, node1, node2)
+		})
+		return nil
 	})
 }
 
@@ -498,19 +532,40 @@ const (
 	pruneTipBytes = 32 + 4
 )
 
+// PrunedChannel describes a channel which has been pruned from the channel
+// graph as its funding output has been spent.
+type PrunedChannel struct {
+	// ChannelID is the unique identifier of the channel within the chain.
+	ChannelID uint64
+
+	// ChannelPoint is the funding outpoint of the channel.
+	ChannelPoint wire.OutPoint
+
+	// Capacity is the capacity of the channel, or zero if neither node
+	// had advertised a policy for the channel.
+	Capacity btcutil.Amount
+}
+
 // PruneGraph prunes newly closed channels from the channel graph in response
 // to a new block being solved on the network. Any transactions which spend the
 // funding output of any known channels within he graph will be deleted.
 // Additionally, the "prune tip", or the last block which has been used to
 // prune the graph is stored so callers can ensure the graph is fully in sync
-// with the current UTXO state. An integer is returned which reflects the
-// number of channels pruned due to the new incoming block.
+// with the current UTXO state. A description of each channel pruned due to
+// the new incoming block is returned.
 func (c *ChannelGraph) PruneGraph(spentOutputs []*wire.OutPoint,
-	blockHash *chainhash.Hash, blockHeight uint32) (uint32, error) {
+	blockHash *chainhash.Hash, blockHeight uint32) ([]*PrunedChannel, error) {
 
-	var numChans uint32
+	var prunedChans []*PrunedChannel
 
 	err := c.db.Update(func(tx *bolt.Tx) error {
+		// The node bucket is needed to read the edges of each channel
+		// prior to deleting them.
+		nodes, err := tx.CreateBucketIfNotExists(nodeBucket)
+		if err != nil {
+			return err
+		}
+
 		// First grab the edges bucket which houses the information
 		// we'd like to delete
 		edges, err := tx.CreateBucketIfNotExists(edgeBucket)
@@ -535,17 +590,47 @@ func (c *ChannelGraph) PruneGraph(spentOutputs []*wire.OutPoint,
 			// TODO(roasbeef): load channel bloom filter, continue
 			// if NOT if filter
 
-			// Attempt to delete the channel, and ErrEdgeNotFound
-			// will be returned if that outpoint isn't known to be
-			// a channel. If no error is returned, then a channel
-			// was successfully pruned.
-			err := delChannelByEdge(edges, edgeIndex, chanIndex,
+			// If the outpoint isn't known to be a channel, then
+			// there's nothing to prune.
+			var b bytes.Buffer
+			if err := writeOutpoint(&b, chanPoint); err != nil {
+				return err
+			}
+			chanID := chanIndex.Get(b.Bytes())
+			if chanID == nil {
+				continue
+			}
+
+			// Before deleting the channel, we'll read its edges
+			// in order to report the channel's capacity.
+			pruned := &PrunedChannel{
+				ChannelID:    byteOrder.Uint64(chanID),
+				ChannelPoint: *chanPoint,
+			}
+			edge1, edge2, err := fetchEdges(edgeIndex, edges, nodes,
+				chanID, c.db)
+			if err != nil {
+				return err
+			}
+			switch {
+			case edge1 != nil:
+				pruned.Capacity = edge1.Capacity
+			case edge2 != nil:
+				pruned.Capacity = edge2.Capacity
+			}
+
+			err = delChannelByEdge(edges, edgeIndex, chanIndex,
 				chanPoint)
-			if err != nil && err != ErrEdgeNotFound {
+			if err != nil {
 				return err
-			} else if err == nil {
-				numChans += 1
 			}
+
+			spent := *chanPoint
+			c.db.updateGraphCache(tx, func(cache *graphCache) {
+				cache.deleteChannel(spent)
+			})
+
+			prunedChans = append(prunedChans, pruned)
 		}
 
 		metaBucket, err := tx.CreateBucketIfNotExists(graphMetaBucket)
@@ -563,10 +648,10 @@ func (c *ChannelGraph) PruneGraph(spentOutputs []*wire.OutPoint,
 		return metaBucket.Put(pruneTipKey, newTip[:])
 	})
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	return numChans, nil
+	return prunedChans, nil
 }
 
 // PruneTip returns the block height and hash of the latest block that has been
@@ -630,7 +715,15 @@ func (c *ChannelGraph) DeleteChannelEdge(chanPoint *wire.OutPoint) error {
 			return err
 		}
 
-		return delChannelByEdge(edges, edgeIndex, chanIndex, chanPoint)
+		err = delChannelByEdge(edges, edgeIndex, chanIndex, chanPoint)
+		if err != nil {
+			return err
+		}
+
+		c.db.updateGraphCache(tx, func(cache *graphCache) {
+			cache.deleteChannel(*chanPoint)
+		})
+		return nil
 	})
 }
 
@@ -748,10 +841,10 @@ func (r *ChannelGraph) UpdateEdgeInfo(edge *ChannelEdge) error {
 			return ErrEdgeNotFound
 		}
 
-		// Depending on the flags value parsed above, either the first
-		// or second node is being updated.
+		// Depending on the least significant bit of the flags, either
+		// the first or second node is being updated.
 		var fromNode, toNode []byte
-		if edge.Flags == 0 {
+		if edge.Flags&1 == 0 {
 			fromNode = nodeInfo[:33]
 			toNode = nodeInfo[33:]
 		} else {
@@ -761,7 +854,18 @@ func (r *ChannelGraph) UpdateEdgeInfo(edge *ChannelEdge) error {
 
 		// Finally, with the direction of the edge being updated
 		// identified, we update the on-disk edge representation.
-		return putChannelEdge(edges, edge, fromNode, toNode)
+		if err := putChannelEdge(edges, edge, fromNode, toNode); err != nil {
+			return err
+		}
+
+		// The node keys are copied, as the memory of the edge index is
+		// only valid for the lifetime of the transaction.
+		from := append([]byte(nil), fromNode...)
+		to := append([]byte(nil), toNode...)
+		r.db.updateGraphCache(tx, func(cache *graphCache) {
+			cache.updateEdge(edge, from, to)
+		})
+		return nil
 	})
 }
 
@@ -945,9 +1049,14 @@ func (l *LightningNode) ForEachChannel(tx *bolt.Tx, cb func(*ChannelEdge) error)
 		return nil
 	}
 
-	// If no transaction was provided, then we'll create a new transaction
-	// to execute the transaction within.
+	// If no transaction was provided, then we'll serve the traversal from
+	// the graph cache if it's enabled, otherwise we'll create a new
+	// transaction to execute the transaction within.
 	if tx == nil {
+		if l.db.graphCache != nil {
+			return l.db.graphCache.forEachChannel(l.PubKey, l.db, cb)
+		}
+
 		return l.db.View(traversal)
 	}
 
@@ -999,6 +1108,18 @@ type ChannelEdge struct {
 	// HTLC's for each millionth of a satoshi forwarded.
 	FeeProportionalMillionths btcutil.Amount
 
+	// InboundFeeBaseMSat is the base fee, in mSAT's, that this node will
+	// charge for HTLC's entering it through this channel. It's added to
+	// the fee of the edge the HTLC is forwarded over, and may be negative
+	// in order to offer a discount.
+	InboundFeeBaseMSat btcutil.Amount
+
+	// InboundFeeProportionalMillionths is the rate that this node will
+	// charge for HTLC's entering it through this channel, for each
+	// millionth of a satoshi forwarded. Like the inbound base fee, it may
+	// be negative.
+	InboundFeeProportionalMillionths btcutil.Amount
+
 	// Capacity is the total capacity of the channel, this is determined by
 	// the value output in the outpoint that created this channel.
 	Capacity btcutil.Amount
@@ -1080,6 +1201,10 @@ func (c *ChannelGraph) FetchChannelEdgesByID(chanID uint64) (*ChannelEdge, *Chan
 		channelID [8]byte
 	)
 
+	if c.db.graphCache != nil {
+		return c.db.graphCache.fetchChannelEdges(chanID, c.db)
+	}
+
 	err := c.db.View(func(tx *bolt.Tx) error {
 		// First, grab the node bucket. This will be used to populate
 		// the Node pointers in each edge read from disk.
@@ -1118,6 +1243,50 @@ func (c *ChannelGraph) FetchChannelEdgesByID(chanID uint64) (*ChannelEdge, *Chan
 	return edge1, edge2, nil
 }
 
+// FetchChannelNodes returns the public keys of the two nodes connected by the
+// channel identified by the channel ID. The first returned key is that of the
+// "first" node within the channel, which advertises the edge with a flag
+// value of 0. If the channel can't be found, then ErrEdgeNotFound is
+// returned.
+func (c *ChannelGraph) FetchChannelNodes(chanID uint64) (*btcec.PublicKey,
+	*btcec.PublicKey, error) {
+
+	var (
+		node1, node2 *btcec.PublicKey
+		channelID    [8]byte
+	)
+
+	err := c.db.View(func(tx *bolt.Tx) error {
+		edges := tx.Bucket(edgeBucket)
+		if edges == nil {
+			return ErrGraphNoEdgesFound
+		}
+		edgeIndex := edges.Bucket(edgeIndexBucket)
+		if edgeIndex == nil {
+			return ErrGraphNoEdgesFound
+		}
+
+		byteOrder.PutUint64(channelID[:], chanID)
+		nodeInfo := edgeIndex.Get(channelID[:])
+		if nodeInfo == nil {
+			return ErrEdgeNotFound
+		}
+
+		var err error
+		node1, err = btcec.ParsePubKey(nodeInfo[:33], btcec.S256())
+		if err != nil {
+			return err
+		}
+		node2, err = btcec.ParsePubKey(nodeInfo[33:], btcec.S256())
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return node1, node2, nil
+}
+
 // NewChannelEdge returns a new blank ChannelEdge.
 func (c *ChannelGraph) NewChannelEdge() *ChannelEdge {
 	return &ChannelEdge{db: c.db}
@@ -1271,6 +1440,15 @@ func putChannelEdge(edges *bolt.Bucket, edge *ChannelEdge, from, to []byte) erro
 		return err
 	}
 
+	// The inbound fees are written after the node the edge leads to, so
+	// edges written before their introduction can still be read.
+	if err := binary.Write(&b, byteOrder, int64(edge.InboundFeeBaseMSat)); err != nil {
+		return err
+	}
+	if err := binary.Write(&b, byteOrder, int64(edge.InboundFeeProportionalMillionths)); err != nil {
+		return err
+	}
+
 	return edges.Put(edgeKey[:], b.Bytes()[:])
 }
 
@@ -1332,62 +1510,90 @@ func fetchChannelEdge(edges *bolt.Bucket, chanID []byte,
 }
 
 func deserializeChannelEdge(r io.Reader, nodes *bolt.Bucket) (*ChannelEdge, error) {
+	edge, pub, err := deserializeChannelEdgePolicy(r)
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := fetchLightningNode(nodes, pub[:])
+	if err != nil {
+		return nil, err
+	}
+
+	edge.Node = node
+	return edge, nil
+}
+
+// deserializeChannelEdgePolicy reads a directed edge from the passed reader,
+// returning it along with the public key of the node the edge leads to.
+func deserializeChannelEdgePolicy(r io.Reader) (*ChannelEdge, [33]byte, error) {
+	var pub [33]byte
 	edge := &ChannelEdge{}
 
 	if err := binary.Read(r, byteOrder, &edge.ChannelID); err != nil {
-		return nil, err
+		return nil, pub, err
 	}
 
 	edge.ChannelPoint = wire.OutPoint{}
 	if err := readOutpoint(r, &edge.ChannelPoint); err != nil {
-		return nil, err
+		return nil, pub, err
 	}
 
 	var scratch [8]byte
 	if _, err := r.Read(scratch[:]); err != nil {
-		return nil, err
+		return nil, pub, err
 	}
 	unix := int64(byteOrder.Uint64(scratch[:]))
 	edge.LastUpdate = time.Unix(unix, 0)
 
 	if err := binary.Read(r, byteOrder, &edge.Flags); err != nil {
-		return nil, err
+		return nil, pub, err
 	}
 	if err := binary.Read(r, byteOrder, &edge.Expiry); err != nil {
-		return nil, err
+		return nil, pub, err
 	}
 
 	var n uint64
 	if err := binary.Read(r, byteOrder, &n); err != nil {
-		return nil, err
+		return nil, pub, err
 	}
 	edge.MinHTLC = btcutil.Amount(n)
 
 	if err := binary.Read(r, byteOrder, &n); err != nil {
-		return nil, err
+		return nil, pub, err
 	}
 	edge.FeeBaseMSat = btcutil.Amount(n)
 
 	if err := binary.Read(r, byteOrder, &n); err != nil {
-		return nil, err
+		return nil, pub, err
 	}
 	edge.FeeProportionalMillionths = btcutil.Amount(n)
 
 	if err := binary.Read(r, byteOrder, &n); err != nil {
-		return nil, err
+		return nil, pub, err
 	}
 	edge.Capacity = btcutil.Amount(n)
 
-	var pub [33]byte
 	if _, err := r.Read(pub[:]); err != nil {
-		return nil, err
+		return nil, pub, err
 	}
 
-	node, err := fetchLightningNode(nodes, pub[:])
-	if err != nil {
-		return nil, err
+	// Edges written before the introduction of inbound fees end after
+	// the node they lead to, in which case no inbound fees are charged.
+	var inboundFee int64
+	err := binary.Read(r, byteOrder, &inboundFee)
+	switch {
+	case err == io.EOF:
+	case err != nil:
+		return nil, pub, err
+	default:
+		edge.InboundFeeBaseMSat = btcutil.Amount(inboundFee)
+
+		if err := binary.Read(r, byteOrder, &inboundFee); err != nil {
+			return nil, pub, err
+		}
+		edge.InboundFeeProportionalMillionths = btcutil.Amount(inboundFee)
 	}
 
-	edge.Node = node
-	return edge, nil
+	return edge, pub, nil
 }
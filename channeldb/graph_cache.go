@@ -0,0 +1,376 @@
+package channeldb
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/boltdb/bolt"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// cachedChannel is the in-memory counterpart of an entry within the edge
+// index, recording the two nodes of a channel.
+type cachedChannel struct {
+	node1 [33]byte
+	node2 [33]byte
+}
+
+// cachedEdge is the in-memory counterpart of a directed edge within the edge
+// bucket. The policy of the edge is stored without the node it leads to, as
+// the node may be updated independently of the edge.
+type cachedEdge struct {
+	policy ChannelEdge
+	to     [33]byte
+}
+
+// graphCache is an in-memory copy of the channel graph, allowing path finding
+// to traverse the graph without a disk access for each visited node and edge.
+// The cache is populated from disk once when the database is opened, and is
+// afterwards kept in sync by each transaction mutating the graph, once the
+// transaction commits.
+type graphCache struct {
+	mtx sync.RWMutex
+
+	// nodes maps the public key of each node to its information.
+	nodes map[[33]byte]*LightningNode
+
+	// nodeChannels maps the public key of each node to its outgoing
+	// directed edges, keyed by channel ID.
+	nodeChannels map[[33]byte]map[uint64]*cachedEdge
+
+	// channels and chanPoints mirror the edge index and the channel point
+	// index respectively.
+	channels   map[uint64]cachedChannel
+	chanPoints map[wire.OutPoint]uint64
+}
+
+// newGraphCache creates a new, empty graphCache.
+func newGraphCache() *graphCache {
+	return &graphCache{
+		nodes:        make(map[[33]byte]*LightningNode),
+		nodeChannels: make(map[[33]byte]map[uint64]*cachedEdge),
+		channels:     make(map[uint64]cachedChannel),
+		chanPoints:   make(map[wire.OutPoint]uint64),
+	}
+}
+
+// updateGraphCache applies the passed modification to the graph cache, if it's
+// enabled, once the passed transaction commits. Modifications of transactions
+// which fail to commit are never applied.
+func (d *DB) updateGraphCache(tx *bolt.Tx, update func(*graphCache)) {
+	if d.graphCache == nil {
+		return
+	}
+
+	tx.OnCommit(func() {
+		update(d.graphCache)
+	})
+}
+
+// populate loads the entire channel graph stored within the database into the
+// cache.
+func (g *graphCache) populate(tx *bolt.Tx) error {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	nodes := tx.Bucket(nodeBucket)
+	if nodes == nil {
+		return nil
+	}
+	err := nodes.ForEach(func(pubKey, nodeBytes []byte) error {
+		// Skip the source key, and the keys of the nested buckets.
+		if bytes.Equal(pubKey, sourceKey) || len(pubKey) != 33 {
+			return nil
+		}
+
+		node, err := deserializeLightningNode(bytes.NewReader(nodeBytes))
+		if err != nil {
+			return err
+		}
+
+		var pub [33]byte
+		copy(pub[:], pubKey)
+		g.nodes[pub] = node
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	edges := tx.Bucket(edgeBucket)
+	if edges == nil {
+		return nil
+	}
+	err = edges.ForEach(func(edgeKey, edgeBytes []byte) error {
+		// Skip the keys of the nested index buckets.
+		if len(edgeKey) != 33+8 || edgeBytes == nil {
+			return nil
+		}
+
+		edge, to, err := deserializeChannelEdgePolicy(
+			bytes.NewReader(edgeBytes),
+		)
+		if err != nil {
+			return err
+		}
+
+		var from [33]byte
+		copy(from[:], edgeKey[:33])
+		g.putEdge(from, to, edge)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if edgeIndex := edges.Bucket(edgeIndexBucket); edgeIndex != nil {
+		err := edgeIndex.ForEach(func(chanID, nodeInfo []byte) error {
+			var channel cachedChannel
+			copy(channel.node1[:], nodeInfo[:33])
+			copy(channel.node2[:], nodeInfo[33:])
+			g.channels[byteOrder.Uint64(chanID)] = channel
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	chanIndex := edges.Bucket(channelPointBucket)
+	if chanIndex == nil {
+		return nil
+	}
+	return chanIndex.ForEach(func(chanPoint, chanID []byte) error {
+		var op wire.OutPoint
+		if err := readOutpoint(bytes.NewReader(chanPoint), &op); err != nil {
+			return err
+		}
+		g.chanPoints[op] = byteOrder.Uint64(chanID)
+		return nil
+	})
+}
+
+// reset empties the cache.
+func (g *graphCache) reset() {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	g.nodes = make(map[[33]byte]*LightningNode)
+	g.nodeChannels = make(map[[33]byte]map[uint64]*cachedEdge)
+	g.channels = make(map[uint64]cachedChannel)
+	g.chanPoints = make(map[wire.OutPoint]uint64)
+}
+
+// addNode adds the passed node to the cache, replacing any prior version of
+// it.
+func (g *graphCache) addNode(node *LightningNode) {
+	var pub [33]byte
+	copy(pub[:], node.PubKey.SerializeCompressed())
+
+	n := *node
+	n.db = nil
+
+	g.mtx.Lock()
+	g.nodes[pub] = &n
+	g.mtx.Unlock()
+}
+
+// deleteNode removes the passed node from the cache. Like on disk, the edges
+// of the node are left in place.
+func (g *graphCache) deleteNode(pub []byte) {
+	var key [33]byte
+	copy(key[:], pub)
+
+	g.mtx.Lock()
+	delete(g.nodes, key)
+	g.mtx.Unlock()
+}
+
+// addChannel records a channel between the two passed nodes, which must be
+// passed in the order they're stored within the edge index.
+func (g *graphCache) addChannel(chanID uint64, chanPoint wire.OutPoint,
+	node1, node2 []byte) {
+
+	var channel cachedChannel
+	copy(channel.node1[:], node1)
+	copy(channel.node2[:], node2)
+
+	g.mtx.Lock()
+	g.channels[chanID] = channel
+	g.chanPoints[chanPoint] = chanID
+	g.mtx.Unlock()
+}
+
+// updateEdge sets the policy of the directed edge of the passed channel from
+// the first passed node to the second.
+func (g *graphCache) updateEdge(edge *ChannelEdge, from, to []byte) {
+	var fromKey, toKey [33]byte
+	copy(fromKey[:], from)
+	copy(toKey[:], to)
+
+	g.mtx.Lock()
+	g.putEdge(fromKey, toKey, edge)
+	g.mtx.Unlock()
+}
+
+// putEdge stores a copy of the passed policy as the directed edge from the
+// first passed node to the second.
+//
+// NOTE: The mtx MUST be held when calling this method.
+func (g *graphCache) putEdge(from, to [33]byte, edge *ChannelEdge) {
+	policy := *edge
+	policy.Node = nil
+	policy.db = nil
+
+	if _, ok := g.nodeChannels[from]; !ok {
+		g.nodeChannels[from] = make(map[uint64]*cachedEdge)
+	}
+	g.nodeChannels[from][edge.ChannelID] = &cachedEdge{
+		policy: policy,
+		to:     to,
+	}
+}
+
+// deleteChannel removes the channel with the passed funding outpoint, along
+// with both of its directed edges, from the cache.
+func (g *graphCache) deleteChannel(chanPoint wire.OutPoint) {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	chanID, ok := g.chanPoints[chanPoint]
+	if !ok {
+		return
+	}
+	delete(g.chanPoints, chanPoint)
+
+	channel, ok := g.channels[chanID]
+	if !ok {
+		return
+	}
+	delete(g.channels, chanID)
+
+	for _, node := range [][33]byte{channel.node1, channel.node2} {
+		delete(g.nodeChannels[node], chanID)
+		if len(g.nodeChannels[node]) == 0 {
+			delete(g.nodeChannels, node)
+		}
+	}
+}
+
+// fetchNode returns a copy of the node with the passed public key, bound to
+// the passed database.
+//
+// NOTE: The mtx MUST be held when calling this method.
+func (g *graphCache) fetchNode(pub [33]byte, db *DB) (*LightningNode, error) {
+	node, ok := g.nodes[pub]
+	if !ok {
+		return nil, ErrGraphNodeNotFound
+	}
+
+	n := *node
+	n.db = db
+	return &n, nil
+}
+
+// fetchEdge returns a copy of the passed directed edge, pointing to the node
+// it leads to, bound to the passed database.
+//
+// NOTE: The mtx MUST be held when calling this method.
+func (g *graphCache) fetchEdge(edge *cachedEdge, db *DB) (*ChannelEdge, error) {
+	node, err := g.fetchNode(edge.to, db)
+	if err != nil {
+		return nil, err
+	}
+
+	e := edge.policy
+	e.Node = node
+	e.db = db
+	return &e, nil
+}
+
+// forEachNode executes the passed callback with each node within the cache.
+// The callback is executed without holding the lock of the cache, so it may
+// freely access the graph.
+func (g *graphCache) forEachNode(db *DB, cb func(*LightningNode) error) error {
+	g.mtx.RLock()
+	nodes := make([]*LightningNode, 0, len(g.nodes))
+	for pub := range g.nodes {
+		node, err := g.fetchNode(pub, db)
+		if err != nil {
+			g.mtx.RUnlock()
+			return err
+		}
+		nodes = append(nodes, node)
+	}
+	g.mtx.RUnlock()
+
+	for _, node := range nodes {
+		if err := cb(node); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// forEachChannel executes the passed callback with each outgoing directed
+// edge of the passed node. Like forEachNode, the callback is executed without
+// holding the lock of the cache.
+func (g *graphCache) forEachChannel(pub *btcec.PublicKey, db *DB,
+	cb func(*ChannelEdge) error) error {
+
+	var key [33]byte
+	copy(key[:], pub.SerializeCompressed())
+
+	g.mtx.RLock()
+	edges := make([]*ChannelEdge, 0, len(g.nodeChannels[key]))
+	for _, cachedEdge := range g.nodeChannels[key] {
+		edge, err := g.fetchEdge(cachedEdge, db)
+		if err != nil {
+			g.mtx.RUnlock()
+			return err
+		}
+		edges = append(edges, edge)
+	}
+	g.mtx.RUnlock()
+
+	for _, edge := range edges {
+		if err := cb(edge); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fetchChannelEdges returns the two directed edges of the channel with the
+// passed ID, the first being the edge advertised by the first node of the
+// channel. Either edge is nil if it hasn't been advertised yet.
+func (g *graphCache) fetchChannelEdges(chanID uint64,
+	db *DB) (*ChannelEdge, *ChannelEdge, error) {
+
+	g.mtx.RLock()
+	defer g.mtx.RUnlock()
+
+	channel, ok := g.channels[chanID]
+	if !ok {
+		return nil, nil, ErrEdgeNotFound
+	}
+
+	var edges [2]*ChannelEdge
+	for i, node := range [][33]byte{channel.node1, channel.node2} {
+		cachedEdge, ok := g.nodeChannels[node][chanID]
+		if !ok {
+			continue
+		}
+
+		edge, err := g.fetchEdge(cachedEdge, db)
+		if err != nil {
+			return nil, nil, err
+		}
+		edges[i] = edge
+	}
+
+	return edges[0], edges[1], nil
+}
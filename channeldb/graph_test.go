@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/boltdb/bolt"
 	"github.com/btcsuite/fastsha256"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
@@ -305,17 +306,19 @@ func TestEdgeInfoUpdates(t *testing.T) {
 		db:                        db,
 	}
 	edge2 := &ChannelEdge{
-		ChannelID:                 chanID,
-		ChannelPoint:              outpoint,
-		LastUpdate:                time.Unix(124234, 0),
-		Flags:                     1,
-		Expiry:                    99,
-		MinHTLC:                   2342135,
-		FeeBaseMSat:               4352345,
-		FeeProportionalMillionths: 90392423,
-		Capacity:                  324523,
-		Node:                      firstNode,
-		db:                        db,
+		ChannelID:                        chanID,
+		ChannelPoint:                     outpoint,
+		LastUpdate:                       time.Unix(124234, 0),
+		Flags:                            1,
+		Expiry:                           99,
+		MinHTLC:                          2342135,
+		FeeBaseMSat:                      4352345,
+		FeeProportionalMillionths:        90392423,
+		InboundFeeBaseMSat:               -1000,
+		InboundFeeProportionalMillionths: -250,
+		Capacity:                         324523,
+		Node:                             firstNode,
+		db:                               db,
 	}
 
 	// Next, insert both nodes into the database, they should both be
@@ -347,6 +350,25 @@ func TestEdgeInfoUpdates(t *testing.T) {
 			chanID)
 	}
 
+	// The nodes connected by the channel should be returned with the
+	// "first" node leading.
+	dbNode1, dbNode2, err := graph.FetchChannelNodes(chanID)
+	if err != nil {
+		t.Fatalf("unable to fetch channel nodes: %v", err)
+	}
+	if !bytes.Equal(dbNode1.SerializeCompressed(),
+		firstNode.PubKey.SerializeCompressed()) {
+		t.Fatalf("first node mismatch: expected %x, got %x",
+			firstNode.PubKey.SerializeCompressed(),
+			dbNode1.SerializeCompressed())
+	}
+	if !bytes.Equal(dbNode2.SerializeCompressed(),
+		secondNode.PubKey.SerializeCompressed()) {
+		t.Fatalf("second node mismatch: expected %x, got %x",
+			secondNode.PubKey.SerializeCompressed(),
+			dbNode2.SerializeCompressed())
+	}
+
 	// With the edges inserted, perform some queries to ensure that they've
 	// been inserted properly.
 	dbEdge1, dbEdge2, err := graph.FetchChannelEdgesByID(chanID)
@@ -634,13 +656,20 @@ func TestGraphPruning(t *testing.T) {
 	copy(blockHash[:], bytes.Repeat([]byte{1}, 32))
 	blockHeight := uint32(1)
 	block := channelPoints[:2]
-	numPruned, err := graph.PruneGraph(block, &blockHash, blockHeight)
+	prunedChans, err := graph.PruneGraph(block, &blockHash, blockHeight)
 	if err != nil {
 		t.Fatalf("unable to prune graph: %v", err)
 	}
-	if numPruned != 2 {
+	if len(prunedChans) != 2 {
 		t.Fatalf("incorrect number of channels pruned: expected %v, got %v",
-			2, numPruned)
+			2, len(prunedChans))
+	}
+	for i, pruned := range prunedChans {
+		if pruned.ChannelPoint != *block[i] {
+			t.Fatalf("pruned channel #%v has wrong channel point: "+
+				"expected %v, got %v", i, block[i],
+				pruned.ChannelPoint)
+		}
 	}
 
 	// Now ensure that the prune tip has been updated.
@@ -659,14 +688,14 @@ func TestGraphPruning(t *testing.T) {
 	}
 	blockHash = fastsha256.Sum256(blockHash[:])
 	blockHeight = 2
-	numPruned, err = graph.PruneGraph([]*wire.OutPoint{nonChannel},
+	prunedChans, err = graph.PruneGraph([]*wire.OutPoint{nonChannel},
 		&blockHash, blockHeight)
 	if err != nil {
 		t.Fatalf("unable to prune graph: %v", err)
 	}
 
 	// No channels should've been detected as pruned.
-	if numPruned != 0 {
+	if len(prunedChans) != 0 {
 		t.Fatalf("channels were pruned but shouldn't have been")
 	}
 
@@ -678,16 +707,16 @@ func TestGraphPruning(t *testing.T) {
 	// from the graph.
 	blockHash = fastsha256.Sum256(blockHash[:])
 	blockHeight = 3
-	numPruned, err = graph.PruneGraph(channelPoints[2:], &blockHash,
+	prunedChans, err = graph.PruneGraph(channelPoints[2:], &blockHash,
 		blockHeight)
 	if err != nil {
 		t.Fatalf("unable to prune graph: %v", err)
 	}
 
 	// The remainder of the channels should've been pruned from the graph.
-	if numPruned != 2 {
+	if len(prunedChans) != 2 {
 		t.Fatalf("incorrect number of channels pruned: expected %v, got %v",
-			2, numPruned)
+			2, len(prunedChans))
 	}
 
 	// The prune tip should be updated, and no channels should be found
@@ -695,3 +724,191 @@ func TestGraphPruning(t *testing.T) {
 	assertPruneTip(t, graph, &blockHash, blockHeight)
 	asserNumChans(t, graph, 0)
 }
+
+// assertGraphCacheConsistent asserts that the outgoing edges of each of the
+// passed nodes, and the nodes of the graph, as served by the graph cache match
+// those stored on disk.
+func assertGraphCacheConsistent(t *testing.T, db *DB, nodes []*LightningNode) {
+	if db.graphCache == nil {
+		t.Fatalf("graph cache not enabled")
+	}
+
+	for i, node := range nodes {
+		cachedEdges := make(map[uint64]*ChannelEdge)
+		err := node.ForEachChannel(nil, func(e *ChannelEdge) error {
+			cachedEdges[e.ChannelID] = e
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unable to traverse cached edges: %v", err)
+		}
+
+		diskEdges := make(map[uint64]*ChannelEdge)
+		err = db.View(func(tx *bolt.Tx) error {
+			return node.ForEachChannel(tx, func(e *ChannelEdge) error {
+				diskEdges[e.ChannelID] = e
+				return nil
+			})
+		})
+		if err != nil {
+			t.Fatalf("unable to traverse edges: %v", err)
+		}
+
+		if !reflect.DeepEqual(cachedEdges, diskEdges) {
+			t.Fatalf("cached edges of node %d don't match those on "+
+				"disk: expected %v, got %v", i, diskEdges,
+				cachedEdges)
+		}
+
+		for chanID, diskEdge := range diskEdges {
+			edge1, edge2, err := db.ChannelGraph().FetchChannelEdgesByID(
+				chanID,
+			)
+			if err != nil {
+				t.Fatalf("unable to fetch cached edges: %v", err)
+			}
+			if !reflect.DeepEqual(edge1, diskEdge) &&
+				!reflect.DeepEqual(edge2, diskEdge) {
+
+				t.Fatalf("cached edges of channel %d don't "+
+					"match those on disk", chanID)
+			}
+		}
+	}
+
+	numCachedNodes := 0
+	err := db.ChannelGraph().ForEachNode(func(*LightningNode) error {
+		numCachedNodes++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to traverse cached nodes: %v", err)
+	}
+	numDiskNodes := 0
+	err = db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(nodeBucket).ForEach(func(k, _ []byte) error {
+			if len(k) == 33 {
+				numDiskNodes++
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("unable to count nodes: %v", err)
+	}
+	if numCachedNodes != numDiskNodes {
+		t.Fatalf("expected %d cached nodes, got %d", numDiskNodes,
+			numCachedNodes)
+	}
+}
+
+// TestGraphCache asserts that the graph cache is kept in sync with the graph
+// stored on disk as the graph is modified, and that it's populated from disk
+// when the database is opened.
+func TestGraphCache(t *testing.T) {
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	graph := db.ChannelGraph()
+
+	// We'll create a line of nodes, with a channel between each node and
+	// the next, both edges of which are advertised.
+	const numNodes = 4
+	nodes := make([]*LightningNode, numNodes)
+	for i := range nodes {
+		node, err := createTestVertex(db)
+		if err != nil {
+			t.Fatalf("unable to create node: %v", err)
+		}
+		if err := graph.AddLightningNode(node); err != nil {
+			t.Fatalf("unable to add node: %v", err)
+		}
+		nodes[i] = node
+	}
+
+	chanPoints := make([]*wire.OutPoint, 0, numNodes-1)
+	for i := 0; i < numNodes-1; i++ {
+		chanID := uint64(i + 1)
+		op := wire.OutPoint{
+			Hash: fastsha256.Sum256([]byte{byte(i)}),
+		}
+		chanPoints = append(chanPoints, &op)
+
+		err := graph.AddChannelEdge(nodes[i].PubKey, nodes[i+1].PubKey,
+			&op, chanID)
+		if err != nil {
+			t.Fatalf("unable to add edge: %v", err)
+		}
+
+		for _, flags := range []uint16{0, 1} {
+			edge := randEdge(chanID, op, db)
+			edge.Flags = flags
+			if err := graph.UpdateEdgeInfo(edge); err != nil {
+				t.Fatalf("unable to update edge: %v", err)
+			}
+		}
+	}
+	assertGraphCacheConsistent(t, db, nodes)
+
+	// Updating the policy of an edge, and the information of a node,
+	// should be reflected within the cache.
+	edge := randEdge(2, *chanPoints[1], db)
+	edge.Flags = 1
+	if err := graph.UpdateEdgeInfo(edge); err != nil {
+		t.Fatalf("unable to update edge: %v", err)
+	}
+	nodes[2].Alias = "updated"
+	if err := graph.AddLightningNode(nodes[2]); err != nil {
+		t.Fatalf("unable to update node: %v", err)
+	}
+	assertGraphCacheConsistent(t, db, nodes)
+
+	// Pruning and deleting channels should remove them from the cache.
+	var blockHash chainhash.Hash
+	_, err = graph.PruneGraph(chanPoints[:1], &blockHash, 1)
+	if err != nil {
+		t.Fatalf("unable to prune graph: %v", err)
+	}
+	if err := graph.DeleteChannelEdge(chanPoints[2]); err != nil {
+		t.Fatalf("unable to delete edge: %v", err)
+	}
+	assertGraphCacheConsistent(t, db, nodes)
+
+	_, _, err = graph.FetchChannelEdgesByID(1)
+	if err != ErrEdgeNotFound {
+		t.Fatalf("expected pruned channel to be unknown, got %v", err)
+	}
+
+	// Deleting a node without any channels should remove it as well.
+	if err := graph.DeleteLightningNode(nodes[0].PubKey); err != nil {
+		t.Fatalf("unable to delete node: %v", err)
+	}
+	assertGraphCacheConsistent(t, db, nodes[1:])
+
+	// Finally, once reopened the cache should be populated with the graph
+	// stored on disk, unless it's disabled.
+	dbPath := db.dbPath
+	db.Close()
+
+	db, err = Open(dbPath)
+	if err != nil {
+		t.Fatalf("unable to reopen database: %v", err)
+	}
+	for _, node := range nodes[1:] {
+		node.db = db
+	}
+	assertGraphCacheConsistent(t, db, nodes[1:])
+	db.Close()
+
+	db, err = Open(dbPath, OptionNoGraphCache(true))
+	if err != nil {
+		t.Fatalf("unable to reopen database: %v", err)
+	}
+	defer db.Close()
+	if db.graphCache != nil {
+		t.Fatalf("graph cache enabled despite being disabled")
+	}
+}
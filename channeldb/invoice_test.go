@@ -131,3 +131,69 @@ func TestInvoiceWorkflow(t *testing.T) {
 		}
 	}
 }
+
+// TestCancelInvoice tests that invoices retain their expiry, and that open
+// invoices can be canceled, after which they're no longer pending, while
+// settled and canceled invoices can't be.
+func TestCancelInvoice(t *testing.T) {
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	invoice, err := randInvoice(btcutil.Amount(1000))
+	if err != nil {
+		t.Fatalf("unable to create invoice: %v", err)
+	}
+	invoice.Expiry = time.Hour
+	if err := db.AddInvoice(invoice); err != nil {
+		t.Fatalf("unable to add invoice: %v", err)
+	}
+	settled, err := randInvoice(btcutil.Amount(1000))
+	if err != nil {
+		t.Fatalf("unable to create invoice: %v", err)
+	}
+	if err := db.AddInvoice(settled); err != nil {
+		t.Fatalf("unable to add invoice: %v", err)
+	}
+
+	paymentHash := fastsha256.Sum256(invoice.Terms.PaymentPreimage[:])
+	settledHash := fastsha256.Sum256(settled.Terms.PaymentPreimage[:])
+	if err := db.SettleInvoice(settledHash); err != nil {
+		t.Fatalf("unable to settle invoice: %v", err)
+	}
+
+	if err := db.CancelInvoice(paymentHash); err != nil {
+		t.Fatalf("unable to cancel invoice: %v", err)
+	}
+	dbInvoice, err := db.LookupInvoice(paymentHash)
+	if err != nil {
+		t.Fatalf("unable to find invoice: %v", err)
+	}
+	if !dbInvoice.Terms.Canceled || dbInvoice.Terms.Settled {
+		t.Fatalf("invoice should be canceled but isn't")
+	}
+	if dbInvoice.Expiry != time.Hour {
+		t.Fatalf("expected expiry of %v, got %v", time.Hour,
+			dbInvoice.Expiry)
+	}
+
+	if err := db.CancelInvoice(paymentHash); err != ErrInvoiceAlreadyCanceled {
+		t.Fatalf("expected ErrInvoiceAlreadyCanceled, got %v", err)
+	}
+	if err := db.CancelInvoice(settledHash); err != ErrInvoiceAlreadySettled {
+		t.Fatalf("expected ErrInvoiceAlreadySettled, got %v", err)
+	}
+	if err := db.SettleInvoice(paymentHash); err != ErrInvoiceAlreadyCanceled {
+		t.Fatalf("expected ErrInvoiceAlreadyCanceled, got %v", err)
+	}
+
+	pending, err := db.FetchAllInvoices(true)
+	if err != nil {
+		t.Fatalf("unable to fetch invoices: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Fatalf("expected no pending invoices, got %v", len(pending))
+	}
+}
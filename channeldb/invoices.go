@@ -61,6 +61,10 @@ type ContractTerm struct {
 	// Settled indicates if this particular contract term has been fully
 	// settled by the payer.
 	Settled bool
+
+	// Canceled indicates if the invoice has been canceled, after which it
+	// can no longer be paid.
+	Canceled bool
 }
 
 // Invoice is a payment invoice generated by a payee in order to request
@@ -96,6 +100,21 @@ type Invoice struct {
 	// TODO(roasbeef): later allow for multiple terms to fulfill the final
 	// invoice: payment fragmentation, etc.
 	Terms ContractTerm
+
+	// Expiry is the duration after its creation at which the invoice
+	// expires, and is canceled if it's still unpaid. If zero, the invoice
+	// never expires.
+	Expiry time.Duration
+}
+
+// ExpiryTime returns the time at which the invoice expires, and whether it
+// expires at all.
+func (i *Invoice) ExpiryTime() (time.Time, bool) {
+	if i.Expiry == 0 {
+		return time.Time{}, false
+	}
+
+	return i.CreationDate.Add(i.Expiry), true
 }
 
 func validateInvoice(i *Invoice) error {
@@ -198,8 +217,8 @@ func (d *DB) LookupInvoice(paymentHash [32]byte) (*Invoice, error) {
 }
 
 // FetchAllInvoices returns all invoices currently stored within the database.
-// If the pendingOnly param is true, then only unsettled invoices will be
-// returned, skipping all invoices that are fully settled.
+// If the pendingOnly param is true, then only open invoices will be returned,
+// skipping all invoices that are fully settled or canceled.
 func (d *DB) FetchAllInvoices(pendingOnly bool) ([]*Invoice, error) {
 	var invoices []*Invoice
 
@@ -223,7 +242,9 @@ func (d *DB) FetchAllInvoices(pendingOnly bool) ([]*Invoice, error) {
 				return err
 			}
 
-			if pendingOnly && invoice.Terms.Settled {
+			if pendingOnly && (invoice.Terms.Settled ||
+				invoice.Terms.Canceled) {
+
 				return nil
 			}
 
@@ -242,7 +263,7 @@ func (d *DB) FetchAllInvoices(pendingOnly bool) ([]*Invoice, error) {
 // SettleInvoice attempts to mark an invoice corresponding to the passed
 // payment hash as fully settled. If an invoice matching the passed payment
 // hash doesn't existing within the database, then the action will fail with a
-// "not found" error.
+// "not found" error. Canceled invoices can't be settled.
 func (d *DB) SettleInvoice(paymentHash [32]byte) error {
 	return d.Update(func(tx *bolt.Tx) error {
 		invoices, err := tx.CreateBucketIfNotExists(invoiceBucket)
@@ -265,6 +286,47 @@ func (d *DB) SettleInvoice(paymentHash [32]byte) error {
 	})
 }
 
+// CancelInvoice marks the invoice corresponding to the passed payment hash as
+// canceled, after which it can no longer be paid. Invoices which have already
+// been settled or canceled can't be canceled.
+func (d *DB) CancelInvoice(paymentHash [32]byte) error {
+	return d.Update(func(tx *bolt.Tx) error {
+		invoices, err := tx.CreateBucketIfNotExists(invoiceBucket)
+		if err != nil {
+			return err
+		}
+		invoiceIndex, err := invoices.CreateBucketIfNotExists(invoiceIndexBucket)
+		if err != nil {
+			return err
+		}
+
+		invoiceNum := invoiceIndex.Get(paymentHash[:])
+		if invoiceNum == nil {
+			return ErrInvoiceNotFound
+		}
+
+		invoice, err := fetchInvoice(invoiceNum, invoices)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case invoice.Terms.Settled:
+			return ErrInvoiceAlreadySettled
+		case invoice.Terms.Canceled:
+			return ErrInvoiceAlreadyCanceled
+		}
+		invoice.Terms.Canceled = true
+
+		var buf bytes.Buffer
+		if err := serializeInvoice(&buf, invoice); err != nil {
+			return err
+		}
+
+		return invoices.Put(invoiceNum, buf.Bytes())
+	})
+}
+
 func putInvoice(invoices *bolt.Bucket, invoiceIndex *bolt.Bucket,
 	i *Invoice, invoiceNum uint32) error {
 
@@ -325,14 +387,24 @@ func serializeInvoice(w io.Writer, i *Invoice) error {
 		return err
 	}
 
+	// The state of the invoice is encoded within a single byte: 0 if it's
+	// open, 1 if it's settled, and 2 if it's canceled.
 	var settleByte [1]byte
-	if i.Terms.Settled {
+	switch {
+	case i.Terms.Settled:
 		settleByte[0] = 1
+	case i.Terms.Canceled:
+		settleByte[0] = 2
 	}
 	if _, err := w.Write(settleByte[:]); err != nil {
 		return err
 	}
 
+	byteOrder.PutUint64(scratch[:], uint64(i.Expiry))
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -382,8 +454,22 @@ func deserializeInvoice(r io.Reader) (*Invoice, error) {
 	if _, err := io.ReadFull(r, settleByte[:]); err != nil {
 		return nil, err
 	}
-	if settleByte[0] == 1 {
+	switch settleByte[0] {
+	case 1:
 		invoice.Terms.Settled = true
+	case 2:
+		invoice.Terms.Canceled = true
+	}
+
+	// Invoices stored before expiries were introduced lack one, in which
+	// case they never expire.
+	_, err = io.ReadFull(r, scratch[:])
+	switch err {
+	case nil:
+		invoice.Expiry = time.Duration(byteOrder.Uint64(scratch[:]))
+	case io.EOF:
+	default:
+		return nil, err
 	}
 
 	return invoice, nil
@@ -395,6 +481,9 @@ func settleInvoice(invoices *bolt.Bucket, invoiceNum []byte) error {
 		return err
 	}
 
+	if invoice.Terms.Canceled {
+		return ErrInvoiceAlreadyCanceled
+	}
 	invoice.Terms.Settled = true
 
 	var buf bytes.Buffer
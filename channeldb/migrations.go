@@ -0,0 +1,58 @@
+package channeldb
+
+import (
+	"bytes"
+
+	"github.com/boltdb/bolt"
+)
+
+// migratePaymentHashIndex indexes the payments recorded before the payment
+// hash index was introduced, so they can be looked up by their payment hash.
+// Should several payments share a payment hash, then the index refers to the
+// most recent one, as it would had they been recorded with the index in
+// place.
+func migratePaymentHashIndex(tx *bolt.Tx) error {
+	payments := tx.Bucket(paymentBucket)
+	if payments == nil {
+		return nil
+	}
+	index, err := payments.CreateBucketIfNotExists(paymentIndexBucket)
+	if err != nil {
+		return err
+	}
+
+	// As the bucket is scanned in ascending order of payment IDs, a
+	// payment hash ends up mapped to the latest payment carrying it. The
+	// entries are gathered first, as the bucket can't be modified while
+	// it's being iterated over.
+	paymentIDs := make(map[[32]byte][]byte)
+	err = payments.ForEach(func(k, v []byte) error {
+		// If the value is nil, then we ignore it as it may be a
+		// sub-bucket.
+		if v == nil {
+			return nil
+		}
+
+		payment, err := deserializeOutgoingPayment(bytes.NewReader(v))
+		if err != nil {
+			return err
+		}
+
+		paymentIDs[payment.PaymentHash] = append([]byte(nil), k...)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for paymentHash, paymentID := range paymentIDs {
+		if index.Get(paymentHash[:]) != nil {
+			continue
+		}
+		if err := index.Put(paymentHash[:], paymentID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
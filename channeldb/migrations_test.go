@@ -0,0 +1,105 @@
+package channeldb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/boltdb/bolt"
+	"github.com/roasbeef/btcutil"
+)
+
+// TestPaymentHashIndexMigration asserts that payments recorded before the
+// payment hash index was introduced can be looked up and deleted by their
+// payment hash once the database is migrated, the index referring to the
+// most recent payment carrying each payment hash.
+func TestPaymentHashIndexMigration(t *testing.T) {
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	// Record the payments as they were prior to the index, the first
+	// and last sharing a payment hash.
+	var hashA, hashB [32]byte
+	hashA[0] = 1
+	hashB[0] = 2
+	payments := []*OutgoingPayment{
+		makeFakePayment(), makeFakePayment(), makeFakePayment(),
+	}
+	payments[0].PaymentHash = hashA
+	payments[1].PaymentHash = hashB
+	payments[2].PaymentHash = hashA
+	for i, payment := range payments {
+		payment.Fee = btcutil.Amount(i + 1)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(paymentBucket)
+		if err != nil {
+			return err
+		}
+		err = bucket.DeleteBucket(paymentIndexBucket)
+		if err != nil && err != bolt.ErrBucketNotFound {
+			return err
+		}
+
+		for _, payment := range payments {
+			paymentID, err := bucket.NextSequence()
+			if err != nil {
+				return err
+			}
+			var k [8]byte
+			binary.BigEndian.PutUint64(k[:], paymentID)
+
+			var b bytes.Buffer
+			err = serializeOutgoingPayment(&b, payment)
+			if err != nil {
+				return err
+			}
+			if err := bucket.Put(k[:], b.Bytes()); err != nil {
+				return err
+			}
+		}
+
+		return putMeta(&Meta{DbVersionNumber: 0}, tx)
+	})
+	if err != nil {
+		t.Fatalf("unable to record payments: %v", err)
+	}
+
+	// Without the index, the payments can't be found.
+	if _, err := db.FetchPayment(hashB); err != ErrPaymentNotFound {
+		t.Fatalf("expected ErrPaymentNotFound, got: %v", err)
+	}
+
+	if err := db.syncVersions(dbVersions); err != nil {
+		t.Fatalf("unable to migrate db: %v", err)
+	}
+
+	// The index should refer to the latest payment of each payment hash.
+	payment, err := db.FetchPayment(hashA)
+	if err != nil {
+		t.Fatalf("unable to fetch payment: %v", err)
+	}
+	if payment.Fee != payments[2].Fee {
+		t.Fatalf("expected latest payment with fee %v, got fee %v",
+			payments[2].Fee, payment.Fee)
+	}
+
+	if err := db.DeletePayment(hashB, false); err != nil {
+		t.Fatalf("unable to delete payment: %v", err)
+	}
+	if _, err := db.FetchPayment(hashB); err != ErrPaymentNotFound {
+		t.Fatalf("expected ErrPaymentNotFound, got: %v", err)
+	}
+
+	allPayments, err := db.FetchAllPayments()
+	if err != nil {
+		t.Fatalf("unable to fetch payments: %v", err)
+	}
+	if len(allPayments) != 2 {
+		t.Fatalf("expected 2 payments, got %v", len(allPayments))
+	}
+}
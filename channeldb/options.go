@@ -0,0 +1,41 @@
+package channeldb
+
+import "time"
+
+// Options holds the optional parameters of the database.
+type Options struct {
+	// NoGraphCache disables the in-memory cache of the channel graph. The
+	// graph is then read from disk on each traversal, trading path finding
+	// speed for a smaller memory footprint.
+	NoGraphCache bool
+
+	// TxObserver, if non-nil, is called with the duration of each
+	// transaction once it completes, along with whether it was a
+	// read-write update or a read-only view.
+	TxObserver func(update bool, elapsed time.Duration)
+}
+
+// DefaultOptions returns the options used when opening the database if none
+// are modified.
+func DefaultOptions() Options {
+	return Options{}
+}
+
+// OptionModifier modifies the options used to open the database.
+type OptionModifier func(*Options)
+
+// OptionNoGraphCache disables the in-memory cache of the channel graph if the
+// passed value is true.
+func OptionNoGraphCache(noGraphCache bool) OptionModifier {
+	return func(o *Options) {
+		o.NoGraphCache = noGraphCache
+	}
+}
+
+// OptionTxObserver sets the callback which is passed the duration of each
+// transaction of the database.
+func OptionTxObserver(observer func(bool, time.Duration)) OptionModifier {
+	return func(o *Options) {
+		o.TxObserver = observer
+	}
+}
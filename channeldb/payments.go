@@ -4,8 +4,11 @@ import (
 	"bytes"
 	"encoding/binary"
 	"io"
+	"time"
 
 	"github.com/boltdb/bolt"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/wire"
 	"github.com/roasbeef/btcutil"
 )
 
@@ -17,11 +20,165 @@ var (
 	// which is a monotonically increasing uint64.  BoltDB's sequence
 	// feature is used for generating monotonically increasing id.
 	paymentBucket = []byte("payments")
+
+	// paymentIndexBucket is a sub-bucket of the payments bucket which maps
+	// the payment hash of each payment to its ID. It allows repeated
+	// attempts to settle a payment to be folded into a single record.
+	paymentIndexBucket = []byte("payment-hash-index")
 )
 
-// OutgoingPayment represents a successful payment between the daemon and a
-// remote node. Details such as the total fee paid, and the time of the payment
-// are stored.
+// MaxFailedAttempts is the maximum number of failed attempts recorded for a
+// single payment. Once exceeded, the oldest failed attempts are dropped, so
+// that the record of a payment retried over a long timeout stays bounded.
+const MaxFailedAttempts = 100
+
+// PaymentStatus denotes the outcome of an outgoing payment.
+type PaymentStatus byte
+
+const (
+	// StatusSucceeded denotes a payment which was settled by its
+	// recipient.
+	StatusSucceeded PaymentStatus = 0
+
+	// StatusFailed denotes a payment which couldn't be settled.
+	StatusFailed PaymentStatus = 1
+)
+
+// String returns a human-readable description of the payment status.
+func (s PaymentStatus) String() string {
+	switch s {
+	case StatusSucceeded:
+		return "Succeeded"
+	case StatusFailed:
+		return "Failed"
+	default:
+		return "Unknown"
+	}
+}
+
+// FailureReason describes why a payment was abandoned, allowing callers to
+// react to a failed payment without parsing the error it failed with.
+type FailureReason byte
+
+const (
+	// FailureReasonNone denotes a payment which wasn't abandoned, either
+	// as it succeeded, or as the caller controls retrying it. Payments
+	// recorded before failure reasons were tracked have it too.
+	FailureReasonNone FailureReason = 0
+
+	// FailureReasonTimeout denotes a payment which couldn't be settled
+	// before its timeout expired.
+	FailureReasonTimeout FailureReason = 1
+
+	// FailureReasonNoRoute denotes a payment for which no route within
+	// its fee and CLTV limits could be found.
+	FailureReasonNoRoute FailureReason = 2
+
+	// FailureReasonError denotes a payment which failed with an error
+	// that retrying it can't overcome.
+	FailureReasonError FailureReason = 3
+
+	// FailureReasonIncorrectPaymentDetails denotes a payment rejected by
+	// its recipient, as either its payment hash is unknown, or its amount
+	// is incorrect.
+	FailureReasonIncorrectPaymentDetails FailureReason = 4
+
+	// FailureReasonInsufficientBalance denotes a payment which none of
+	// our channels had the balance to carry.
+	FailureReasonInsufficientBalance FailureReason = 5
+
+	// FailureReasonCanceled denotes a payment abandoned as the daemon
+	// shut down while it was being retried.
+	FailureReasonCanceled FailureReason = 6
+)
+
+// String returns a human-readable description of the failure reason.
+func (r FailureReason) String() string {
+	switch r {
+	case FailureReasonNone:
+		return "None"
+	case FailureReasonTimeout:
+		return "Timeout"
+	case FailureReasonNoRoute:
+		return "NoRoute"
+	case FailureReasonError:
+		return "Error"
+	case FailureReasonIncorrectPaymentDetails:
+		return "IncorrectPaymentDetails"
+	case FailureReasonInsufficientBalance:
+		return "InsufficientBalance"
+	case FailureReasonCanceled:
+		return "Canceled"
+	default:
+		return "Unknown"
+	}
+}
+
+// HTLCFailure describes why the HTLC of an attempt to settle a payment
+// failed.
+type HTLCFailure struct {
+	// SourceIndex is the index within the route of the node the failure
+	// originated at, zero being ourselves. Cancellations don't identify
+	// the node which originated them, so it's left unset for those.
+	SourceIndex uint32
+
+	// Cancelled is true if the HTLC was cancelled by a remote node, rather
+	// than failing locally.
+	Cancelled bool
+
+	// Reason is the reason the HTLC was cancelled. It's only meaningful if
+	// the HTLC was cancelled, as failures arising locally aren't
+	// cancellations.
+	Reason lnwire.CancelReason
+
+	// Message is a human-readable description of the failure.
+	Message string
+}
+
+// PaymentAttempt describes an attempt to route a payment to its recipient.
+type PaymentAttempt struct {
+	// Fee is the total fee of the route in satoshis.
+	Fee btcutil.Amount
+
+	// TimeLockLength is the total cumulative time-lock of the route.
+	TimeLockLength uint32
+
+	// Path is the hex-encoded compressed public key of each of the nodes
+	// of the route, excluding the outgoing node.
+	Path [][33]byte
+
+	// AttemptTime and ResolveTime are the times at which the HTLC of the
+	// attempt was sent, and was settled or failed. They're zero for
+	// attempts recorded before they were tracked.
+	AttemptTime time.Time
+	ResolveTime time.Time
+
+	// Failure describes why the attempt failed. It's nil if the attempt
+	// succeeded, or was recorded before failures were tracked.
+	Failure *HTLCFailure
+}
+
+// PaymentParams are the limits placed upon a payment by its sender, bounding
+// the worst-case latency of the payment.
+type PaymentParams struct {
+	// Timeout is the duration after which no further attempts to settle
+	// the payment are made. An attempt in flight once it expires is still
+	// allowed to resolve. If zero, only a single attempt is made.
+	Timeout time.Duration
+
+	// CltvLimit is the maximum total time-lock of the routes the payment
+	// may be sent over. If zero, the time-lock isn't limited.
+	CltvLimit uint32
+
+	// MaxParts is the maximum number of HTLCs the payment may be split
+	// into. If zero, the payment isn't split.
+	MaxParts uint32
+}
+
+// OutgoingPayment represents a payment between the daemon and a remote node.
+// Details such as the total fee paid, and the time of the payment are stored.
+// A payment is recorded along with its outcome, and any prior failed attempts
+// to settle it.
 type OutgoingPayment struct {
 	Invoice
 
@@ -42,10 +199,50 @@ type OutgoingPayment struct {
 	// TODO(roasbeef): weave through preimage on payment success to can
 	// store only supplemental info the embedded Invoice
 	PaymentHash [32]byte
+
+	// Status is the outcome of the payment. The route described above is
+	// that of the final attempt to settle the payment.
+	Status PaymentStatus
+
+	// FailedAttempts are the prior attempts to settle the payment which
+	// failed, in the order they were made.
+	FailedAttempts []PaymentAttempt
+
+	// Params are the limits placed upon the payment by its sender.
+	Params PaymentParams
+
+	// AttemptTime, ResolveTime and Failure describe the HTLC of the final
+	// attempt to settle the payment, as within PaymentAttempt.
+	AttemptTime time.Time
+	ResolveTime time.Time
+	Failure     *HTLCFailure
+
+	// FailureReason is the reason a failed payment was abandoned.
+	FailureReason FailureReason
+
+	// SequenceNum is the index of the payment within the payments bucket,
+	// reflecting the order in which payments were created. It's only set
+	// for payments returned by QueryPayments, and isn't serialized.
+	SequenceNum uint64
+}
+
+// FinalAttempt returns the final attempt to settle the payment.
+func (p *OutgoingPayment) FinalAttempt() PaymentAttempt {
+	return PaymentAttempt{
+		Fee:            p.Fee,
+		TimeLockLength: p.TimeLockLength,
+		Path:           p.Path,
+		AttemptTime:    p.AttemptTime,
+		ResolveTime:    p.ResolveTime,
+		Failure:        p.Failure,
+	}
 }
 
-// AddPayment saves a successful payment to the database. It is assumed that
-// all payment are sent using unique payment hashes.
+// AddPayment saves the outcome of a payment to the database. If a prior
+// attempt to settle a payment with the same payment hash failed, then the
+// payment replaces the failed one, inheriting its failed attempts, of which
+// only the latest MaxFailedAttempts are retained. Otherwise, the payment is
+// recorded anew.
 func (db *DB) AddPayment(payment *OutgoingPayment) error {
 	// Validate the field of the inner voice within the outgoing payment,
 	// these must also adhere to the same constraints as regular invoices.
@@ -53,37 +250,160 @@ func (db *DB) AddPayment(payment *OutgoingPayment) error {
 		return err
 	}
 
-	// We first serialize the payment before starting the database
-	// transaction so we can avoid creating a DB payment in the case of a
-	// serialization error.
-	var b bytes.Buffer
-	if err := serializeOutgoingPayment(&b, payment); err != nil {
-		return err
-	}
-	paymentBytes := b.Bytes()
-
 	return db.Update(func(tx *bolt.Tx) error {
 		payments, err := tx.CreateBucketIfNotExists(paymentBucket)
 		if err != nil {
 			return err
 		}
+		index, err := payments.CreateBucketIfNotExists(paymentIndexBucket)
+		if err != nil {
+			return err
+		}
+
+		// If the last payment with this payment hash failed, we fold
+		// it into this one, keeping its place within the bucket. The
+		// payment also keeps its creation date, so that it stays
+		// consistent with its place when payments are queried by
+		// date.
+		p := *payment
+		paymentIdBytes := index.Get(payment.PaymentHash[:])
+		if paymentIdBytes != nil {
+			prev, err := fetchPayment(payments, paymentIdBytes)
+			if err != nil {
+				return err
+			}
+
+			if prev != nil && prev.Status == StatusFailed {
+				p.CreationDate = prev.CreationDate
+				p.FailedAttempts = append(prev.FailedAttempts,
+					prev.FinalAttempt())
+				p.FailedAttempts = append(p.FailedAttempts,
+					payment.FailedAttempts...)
+
+				numDropped := len(p.FailedAttempts) -
+					MaxFailedAttempts
+				if numDropped > 0 {
+					p.FailedAttempts = p.FailedAttempts[numDropped:]
+				}
+			} else {
+				paymentIdBytes = nil
+			}
+		}
+
+		if paymentIdBytes == nil {
+			// Obtain the new unique sequence number for this
+			// payment.
+			paymentId, err := payments.NextSequence()
+			if err != nil {
+				return err
+			}
+
+			// We use BigEndian for keys as it orders keys in
+			// ascending order. This allows bucket scans to order
+			// payments in the order in which they were created.
+			paymentIdBytes = make([]byte, 8)
+			binary.BigEndian.PutUint64(paymentIdBytes, paymentId)
+		}
+
+		var b bytes.Buffer
+		if err := serializeOutgoingPayment(&b, &p); err != nil {
+			return err
+		}
+		if err := payments.Put(paymentIdBytes, b.Bytes()); err != nil {
+			return err
+		}
 
-		// Obtain the new unique sequence number for this payment.
-		paymentId, err := payments.NextSequence()
+		return index.Put(payment.PaymentHash[:], paymentIdBytes)
+	})
+}
+
+// FailPayment records the reason the failed payment with the passed payment
+// hash was abandoned. ErrPaymentNotFound is returned if no attempt to settle
+// the payment was recorded, or if it succeeded.
+func (db *DB) FailPayment(paymentHash [32]byte, reason FailureReason) error {
+	return db.Update(func(tx *bolt.Tx) error {
+		payments := tx.Bucket(paymentBucket)
+		if payments == nil {
+			return ErrPaymentNotFound
+		}
+		index := payments.Bucket(paymentIndexBucket)
+		if index == nil {
+			return ErrPaymentNotFound
+		}
+
+		paymentIdBytes := index.Get(paymentHash[:])
+		if paymentIdBytes == nil {
+			return ErrPaymentNotFound
+		}
+		payment, err := fetchPayment(payments, paymentIdBytes)
 		if err != nil {
 			return err
 		}
+		if payment == nil || payment.Status != StatusFailed {
+			return ErrPaymentNotFound
+		}
 
-		// We use BigEndian for keys as it orders keys in
-		// ascending order. This allows bucket scans to order payments
-		// in the order in which they were created.
-		paymentIdBytes := make([]byte, 8)
-		binary.BigEndian.PutUint64(paymentIdBytes, paymentId)
+		payment.FailureReason = reason
 
-		return payments.Put(paymentIdBytes, paymentBytes)
+		var b bytes.Buffer
+		if err := serializeOutgoingPayment(&b, payment); err != nil {
+			return err
+		}
+		return payments.Put(paymentIdBytes, b.Bytes())
 	})
 }
 
+// fetchPayment retrieves the payment with the passed ID from the payments
+// bucket, returning nil if it doesn't exist.
+func fetchPayment(payments *bolt.Bucket, paymentID []byte) (*OutgoingPayment,
+	error) {
+
+	paymentBytes := payments.Get(paymentID)
+	if paymentBytes == nil {
+		return nil, nil
+	}
+
+	return deserializeOutgoingPayment(bytes.NewReader(paymentBytes))
+}
+
+// FetchPayment returns the latest payment with the passed payment hash.
+// ErrPaymentNotFound is returned if no attempt to settle the payment was
+// recorded.
+func (db *DB) FetchPayment(paymentHash [32]byte) (*OutgoingPayment, error) {
+	var payment *OutgoingPayment
+	err := db.View(func(tx *bolt.Tx) error {
+		payments := tx.Bucket(paymentBucket)
+		if payments == nil {
+			return ErrPaymentNotFound
+		}
+		index := payments.Bucket(paymentIndexBucket)
+		if index == nil {
+			return ErrPaymentNotFound
+		}
+
+		paymentIdBytes := index.Get(paymentHash[:])
+		if paymentIdBytes == nil {
+			return ErrPaymentNotFound
+		}
+
+		var err error
+		payment, err = fetchPayment(payments, paymentIdBytes)
+		if err != nil {
+			return err
+		}
+		if payment == nil {
+			return ErrPaymentNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
 // FetchAllPayments returns all outgoing payments in DB.
 func (db *DB) FetchAllPayments() ([]*OutgoingPayment, error) {
 	var payments []*OutgoingPayment
@@ -118,6 +438,334 @@ func (db *DB) FetchAllPayments() ([]*OutgoingPayment, error) {
 	return payments, nil
 }
 
+// PaymentsQuery represents a query to the payments database, paginating
+// through the payments in the order in which they were created.
+type PaymentsQuery struct {
+	// IndexOffset is the sequence number of the payment the query starts
+	// after, or before if Reversed is set. The payment itself isn't
+	// included. If zero, the query starts at the first payment, or the
+	// last if Reversed is set.
+	IndexOffset uint64
+
+	// MaxPayments is the maximum number of payments to return. If zero,
+	// the number of payments is unlimited.
+	MaxPayments uint64
+
+	// Reversed, if set, paginates backwards from the index offset,
+	// returning the payments preceding it. The payments are still
+	// returned in the order in which they were created.
+	Reversed bool
+
+	// CreationDateStart and CreationDateEnd, if non-zero, restrict the
+	// query to payments created within the inclusive time range.
+	CreationDateStart time.Time
+	CreationDateEnd   time.Time
+
+	// CountTotalPayments, if set, counts the total number of payments
+	// within the database, regardless of the query.
+	CountTotalPayments bool
+}
+
+// PaymentsQueryResp is the response to a PaymentsQuery.
+type PaymentsQueryResp struct {
+	// Payments are the payments matching the query, in the order in which
+	// they were created.
+	Payments []*OutgoingPayment
+
+	// FirstIndexOffset and LastIndexOffset are the sequence numbers of the
+	// first and last payments returned, allowing the query to be resumed
+	// in either direction.
+	FirstIndexOffset uint64
+	LastIndexOffset  uint64
+
+	// TotalNumPayments is the total number of payments within the
+	// database. It's only set if CountTotalPayments was set.
+	TotalNumPayments uint64
+}
+
+// QueryPayments returns a page of the payments within the database, as
+// dictated by the passed query. The payments are traversed along their
+// sequence numbers, so only the requested page is ever held in memory.
+func (db *DB) QueryPayments(query PaymentsQuery) (PaymentsQueryResp, error) {
+	var resp PaymentsQueryResp
+
+	err := db.View(func(tx *bolt.Tx) error {
+		payments := tx.Bucket(paymentBucket)
+		if payments == nil {
+			return nil
+		}
+
+		var offsetKey [8]byte
+		binary.BigEndian.PutUint64(offsetKey[:], query.IndexOffset)
+
+		// Position the cursor at the first payment to be considered,
+		// in the direction of the query.
+		var (
+			c    = payments.Cursor()
+			next = c.Next
+			k, v []byte
+		)
+		switch {
+		case !query.Reversed && query.IndexOffset == 0:
+			k, v = c.First()
+
+		case !query.Reversed:
+			var startKey [8]byte
+			binary.BigEndian.PutUint64(
+				startKey[:], query.IndexOffset+1,
+			)
+			k, v = c.Seek(startKey[:])
+
+		case query.IndexOffset == 0:
+			next = c.Prev
+			k, v = c.Last()
+
+		default:
+			next = c.Prev
+			k, v = c.Seek(offsetKey[:])
+			if k == nil {
+				k, v = c.Last()
+			}
+		}
+
+		for ; k != nil; k, v = next() {
+			// If the value is nil, then we ignore it as it may be
+			// a sub-bucket.
+			if v == nil {
+				continue
+			}
+
+			// When paginating backwards, the cursor may have been
+			// positioned at or beyond the index offset.
+			if query.Reversed && query.IndexOffset != 0 &&
+				bytes.Compare(k, offsetKey[:]) >= 0 {
+
+				continue
+			}
+
+			if query.MaxPayments != 0 &&
+				uint64(len(resp.Payments)) >= query.MaxPayments {
+
+				break
+			}
+
+			payment, err := deserializeOutgoingPayment(
+				bytes.NewReader(v),
+			)
+			if err != nil {
+				return err
+			}
+
+			created := payment.CreationDate
+			if !query.CreationDateStart.IsZero() &&
+				created.Before(query.CreationDateStart) {
+
+				continue
+			}
+			if !query.CreationDateEnd.IsZero() &&
+				created.After(query.CreationDateEnd) {
+
+				continue
+			}
+
+			payment.SequenceNum = binary.BigEndian.Uint64(k)
+			resp.Payments = append(resp.Payments, payment)
+		}
+
+		if !query.CountTotalPayments {
+			return nil
+		}
+
+		return payments.ForEach(func(k, v []byte) error {
+			if v != nil {
+				resp.TotalNumPayments++
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return PaymentsQueryResp{}, err
+	}
+
+	// Payments gathered while paginating backwards are returned in the
+	// order in which they were created.
+	if query.Reversed {
+		numPayments := len(resp.Payments)
+		for i := 0; i < numPayments/2; i++ {
+			j := numPayments - 1 - i
+			resp.Payments[i], resp.Payments[j] =
+				resp.Payments[j], resp.Payments[i]
+		}
+	}
+
+	if len(resp.Payments) > 0 {
+		resp.FirstIndexOffset = resp.Payments[0].SequenceNum
+		resp.LastIndexOffset =
+			resp.Payments[len(resp.Payments)-1].SequenceNum
+	}
+
+	return resp, nil
+}
+
+// DeletePayment deletes the payment with the passed payment hash from the DB,
+// looking it up through the payment hash index. Should several payments share
+// the payment hash, then the most recent one is deleted. If
+// failedAttemptsOnly is set, then the payment itself is kept, and only the
+// records of its failed attempts are deleted.
+func (db *DB) DeletePayment(paymentHash [32]byte,
+	failedAttemptsOnly bool) error {
+
+	return db.Update(func(tx *bolt.Tx) error {
+		payments := tx.Bucket(paymentBucket)
+		if payments == nil {
+			return ErrPaymentNotFound
+		}
+		index := payments.Bucket(paymentIndexBucket)
+		if index == nil {
+			return ErrPaymentNotFound
+		}
+
+		paymentIdBytes := index.Get(paymentHash[:])
+		if paymentIdBytes == nil {
+			return ErrPaymentNotFound
+		}
+		payment, err := fetchPayment(payments, paymentIdBytes)
+		if err != nil {
+			return err
+		}
+		if payment == nil {
+			return ErrPaymentNotFound
+		}
+
+		if !failedAttemptsOnly {
+			if err := payments.Delete(paymentIdBytes); err != nil {
+				return err
+			}
+			return index.Delete(paymentHash[:])
+		}
+
+		if len(payment.FailedAttempts) == 0 {
+			return nil
+		}
+		payment.FailedAttempts = nil
+
+		var b bytes.Buffer
+		if err := serializeOutgoingPayment(&b, payment); err != nil {
+			return err
+		}
+		return payments.Put(paymentIdBytes, b.Bytes())
+	})
+}
+
+// DeleteFailedPayments prunes the records of failed payments from the DB. If
+// failedOnly is set, then all failed payments are deleted. If
+// failedAttemptsOnly is set, then the records of the failed attempts of
+// succeeded payments are deleted, while the payments themselves are kept. The
+// number of payments deleted or pruned is returned.
+func (db *DB) DeleteFailedPayments(failedOnly,
+	failedAttemptsOnly bool) (int, error) {
+
+	var numDeleted int
+	err := db.Update(func(tx *bolt.Tx) error {
+		payments := tx.Bucket(paymentBucket)
+		if payments == nil {
+			return nil
+		}
+
+		var err error
+		numDeleted, err = deletePayments(payments,
+			func(p *OutgoingPayment) (bool, bool) {
+				switch p.Status {
+				case StatusFailed:
+					return failedOnly, false
+				default:
+					return false, failedAttemptsOnly
+				}
+			},
+		)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return numDeleted, nil
+}
+
+// deletePayments applies the passed filter to each payment within the
+// payments bucket. The filter returns whether the payment should be deleted
+// entirely, or whether only its failed attempts should be pruned. The number
+// of payments deleted or pruned is returned.
+func deletePayments(payments *bolt.Bucket,
+	filter func(*OutgoingPayment) (bool, bool)) (int, error) {
+
+	// As the bucket can't be modified while it's being iterated over, we
+	// first gather the payments to delete and prune.
+	var (
+		toDelete = make(map[string]*OutgoingPayment)
+		toPrune  = make(map[string]*OutgoingPayment)
+	)
+	err := payments.ForEach(func(k, v []byte) error {
+		// If the value is nil, then we ignore it as it may be a
+		// sub-bucket.
+		if v == nil {
+			return nil
+		}
+
+		payment, err := deserializeOutgoingPayment(bytes.NewReader(v))
+		if err != nil {
+			return err
+		}
+
+		deletePayment, pruneAttempts := filter(payment)
+		switch {
+		case deletePayment:
+			toDelete[string(k)] = payment
+		case pruneAttempts && len(payment.FailedAttempts) != 0:
+			toPrune[string(k)] = payment
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	index := payments.Bucket(paymentIndexBucket)
+	for k, payment := range toDelete {
+		if err := payments.Delete([]byte(k)); err != nil {
+			return 0, err
+		}
+
+		// Only remove the payment from the index if the index still
+		// refers to it, rather than a later payment with the same
+		// payment hash.
+		if index == nil {
+			continue
+		}
+		hash := payment.PaymentHash[:]
+		if string(index.Get(hash)) == k {
+			if err := index.Delete(hash); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	for k, payment := range toPrune {
+		payment.FailedAttempts = nil
+
+		var b bytes.Buffer
+		if err := serializeOutgoingPayment(&b, payment); err != nil {
+			return 0, err
+		}
+		if err := payments.Put([]byte(k), b.Bytes()); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(toDelete) + len(toPrune), nil
+}
+
 // DeleteAllPayments deletes all payments from DB.
 func (db *DB) DeleteAllPayments() error {
 	return db.Update(func(tx *bolt.Tx) error {
@@ -171,6 +819,114 @@ func serializeOutgoingPayment(w io.Writer, p *OutgoingPayment) error {
 		return err
 	}
 
+	if _, err := w.Write([]byte{byte(p.Status)}); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint32(scratch[:4], uint32(len(p.FailedAttempts)))
+	if _, err := w.Write(scratch[:4]); err != nil {
+		return err
+	}
+	for _, attempt := range p.FailedAttempts {
+		if err := serializePaymentAttempt(w, &attempt); err != nil {
+			return err
+		}
+	}
+
+	byteOrder.PutUint64(scratch[:], uint64(p.Params.Timeout))
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint32(scratch[:4], p.Params.CltvLimit)
+	if _, err := w.Write(scratch[:4]); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint32(scratch[:4], p.Params.MaxParts)
+	if _, err := w.Write(scratch[:4]); err != nil {
+		return err
+	}
+
+	// The HTLC details of each attempt are appended last, those of the
+	// final attempt followed by those of the failed ones, so that
+	// payments recorded before they were tracked can still be read.
+	final := p.FinalAttempt()
+	if err := serializeAttemptHTLC(w, &final); err != nil {
+		return err
+	}
+	for _, attempt := range p.FailedAttempts {
+		if err := serializeAttemptHTLC(w, &attempt); err != nil {
+			return err
+		}
+	}
+
+	_, err := w.Write([]byte{byte(p.FailureReason)})
+	return err
+}
+
+func serializeAttemptHTLC(w io.Writer, a *PaymentAttempt) error {
+	for _, t := range []time.Time{a.AttemptTime, a.ResolveTime} {
+		timeBytes, err := t.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		if err := wire.WriteVarBytes(w, 0, timeBytes); err != nil {
+			return err
+		}
+	}
+
+	if a.Failure == nil {
+		_, err := w.Write([]byte{0})
+		return err
+	}
+	if _, err := w.Write([]byte{1}); err != nil {
+		return err
+	}
+
+	var scratch [4]byte
+	byteOrder.PutUint32(scratch[:], a.Failure.SourceIndex)
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+	var cancelled byte
+	if a.Failure.Cancelled {
+		cancelled = 1
+	}
+	if _, err := w.Write([]byte{cancelled}); err != nil {
+		return err
+	}
+	byteOrder.PutUint16(scratch[:2], uint16(a.Failure.Reason))
+	if _, err := w.Write(scratch[:2]); err != nil {
+		return err
+	}
+
+	return wire.WriteVarString(w, 0, a.Failure.Message)
+}
+
+func serializePaymentAttempt(w io.Writer, a *PaymentAttempt) error {
+	var scratch [8]byte
+
+	byteOrder.PutUint64(scratch[:], uint64(a.Fee))
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint32(scratch[:4], a.TimeLockLength)
+	if _, err := w.Write(scratch[:4]); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint32(scratch[:4], uint32(len(a.Path)))
+	if _, err := w.Write(scratch[:4]); err != nil {
+		return err
+	}
+	for _, hop := range a.Path {
+		if _, err := w.Write(hop[:]); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -212,5 +968,153 @@ func deserializeOutgoingPayment(r io.Reader) (*OutgoingPayment, error) {
 		return nil, err
 	}
 
+	// Payments recorded before their outcome was tracked lack the
+	// remaining fields, and were all successful.
+	var status [1]byte
+	if _, err := r.Read(status[:]); err == io.EOF {
+		return p, nil
+	} else if err != nil {
+		return nil, err
+	}
+	p.Status = PaymentStatus(status[0])
+
+	if _, err = r.Read(scratch[:4]); err != nil {
+		return nil, err
+	}
+	numAttempts := byteOrder.Uint32(scratch[:4])
+
+	if numAttempts > 0 {
+		p.FailedAttempts = make([]PaymentAttempt, numAttempts)
+	}
+	for i := uint32(0); i < numAttempts; i++ {
+		attempt, err := deserializePaymentAttempt(r)
+		if err != nil {
+			return nil, err
+		}
+		p.FailedAttempts[i] = *attempt
+	}
+
+	// Payments recorded before their parameters were tracked lack them,
+	// and were made without any limits.
+	if _, err := r.Read(scratch[:]); err == io.EOF {
+		return p, nil
+	} else if err != nil {
+		return nil, err
+	}
+	p.Params.Timeout = time.Duration(byteOrder.Uint64(scratch[:]))
+
+	if _, err = r.Read(scratch[:4]); err != nil {
+		return nil, err
+	}
+	p.Params.CltvLimit = byteOrder.Uint32(scratch[:4])
+
+	if _, err = r.Read(scratch[:4]); err != nil {
+		return nil, err
+	}
+	p.Params.MaxParts = byteOrder.Uint32(scratch[:4])
+
+	// Payments recorded before the HTLC details of their attempts were
+	// tracked lack them.
+	var final PaymentAttempt
+	if err := deserializeAttemptHTLC(r, &final); err == io.EOF {
+		return p, nil
+	} else if err != nil {
+		return nil, err
+	}
+	p.AttemptTime = final.AttemptTime
+	p.ResolveTime = final.ResolveTime
+	p.Failure = final.Failure
+
+	for i := range p.FailedAttempts {
+		err := deserializeAttemptHTLC(r, &p.FailedAttempts[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Likewise, payments recorded before failure reasons were tracked
+	// lack one.
+	var reason [1]byte
+	if _, err := io.ReadFull(r, reason[:]); err == io.EOF {
+		return p, nil
+	} else if err != nil {
+		return nil, err
+	}
+	p.FailureReason = FailureReason(reason[0])
+
 	return p, nil
 }
+
+func deserializeAttemptHTLC(r io.Reader, a *PaymentAttempt) error {
+	for _, t := range []*time.Time{&a.AttemptTime, &a.ResolveTime} {
+		timeBytes, err := wire.ReadVarBytes(r, 0, 300, "time")
+		if err != nil {
+			return err
+		}
+		if err := t.UnmarshalBinary(timeBytes); err != nil {
+			return err
+		}
+	}
+
+	var failed [1]byte
+	if _, err := io.ReadFull(r, failed[:]); err != nil {
+		return err
+	}
+	if failed[0] == 0 {
+		return nil
+	}
+
+	var scratch [4]byte
+	failure := &HTLCFailure{}
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return err
+	}
+	failure.SourceIndex = byteOrder.Uint32(scratch[:])
+	if _, err := io.ReadFull(r, scratch[:1]); err != nil {
+		return err
+	}
+	failure.Cancelled = scratch[0] == 1
+	if _, err := io.ReadFull(r, scratch[:2]); err != nil {
+		return err
+	}
+	failure.Reason = lnwire.CancelReason(byteOrder.Uint16(scratch[:2]))
+
+	message, err := wire.ReadVarString(r, 0)
+	if err != nil {
+		return err
+	}
+	failure.Message = message
+	a.Failure = failure
+
+	return nil
+}
+
+func deserializePaymentAttempt(r io.Reader) (*PaymentAttempt, error) {
+	var scratch [8]byte
+
+	a := &PaymentAttempt{}
+
+	if _, err := r.Read(scratch[:]); err != nil {
+		return nil, err
+	}
+	a.Fee = btcutil.Amount(byteOrder.Uint64(scratch[:]))
+
+	if _, err := r.Read(scratch[:4]); err != nil {
+		return nil, err
+	}
+	a.TimeLockLength = byteOrder.Uint32(scratch[:4])
+
+	if _, err := r.Read(scratch[:4]); err != nil {
+		return nil, err
+	}
+	pathLen := byteOrder.Uint32(scratch[:4])
+
+	a.Path = make([][33]byte, pathLen)
+	for i := uint32(0); i < pathLen; i++ {
+		if _, err := r.Read(a.Path[i][:]); err != nil {
+			return nil, err
+		}
+	}
+
+	return a, nil
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/btcsuite/fastsha256"
 	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcutil"
 )
 
@@ -34,6 +35,11 @@ func makeFakePayment() *OutgoingPayment {
 		Path:           fakePath,
 		TimeLockLength: 1000,
 		PaymentHash:    fastsha256.Sum256(rev[:]),
+		Params: PaymentParams{
+			Timeout:   time.Minute,
+			CltvLimit: 1440,
+			MaxParts:  1,
+		},
 	}
 }
 
@@ -117,6 +123,123 @@ func TestOutgoingPaymentSerialization(t *testing.T) {
 	}
 }
 
+// TestPaymentParamsSerialization tests that payments recorded before their
+// parameters were tracked are read as having been made without any limits.
+func TestPaymentParamsSerialization(t *testing.T) {
+	fakePayment := makeFakePayment()
+
+	var b bytes.Buffer
+	if err := serializeOutgoingPayment(&b, fakePayment); err != nil {
+		t.Fatalf("unable to serialize outgoing payment: %v", err)
+	}
+
+	// Strip the parameters, and the HTLC details of the final attempt and
+	// failure reason following them, from the serialized payment, leaving
+	// it as it would've been recorded before they were tracked.
+	var htlcDetails bytes.Buffer
+	final := fakePayment.FinalAttempt()
+	if err := serializeAttemptHTLC(&htlcDetails, &final); err != nil {
+		t.Fatalf("unable to serialize attempt: %v", err)
+	}
+	legacyPayment := b.Bytes()[:b.Len()-16-htlcDetails.Len()-1]
+	newPayment, err := deserializeOutgoingPayment(
+		bytes.NewReader(legacyPayment),
+	)
+	if err != nil {
+		t.Fatalf("unable to deserialize outgoing payment: %v", err)
+	}
+
+	fakePayment.Params = PaymentParams{}
+	if !reflect.DeepEqual(fakePayment, newPayment) {
+		t.Fatalf("expected payment %v, got %v",
+			spew.Sdump(fakePayment), spew.Sdump(newPayment))
+	}
+}
+
+// TestPaymentAttemptHTLCSerialization tests that the HTLC details of the
+// attempts to settle a payment are serialized, and that payments recorded
+// before they were tracked are read as lacking them.
+func TestPaymentAttemptHTLCSerialization(t *testing.T) {
+	fakePayment := makeFakePayment()
+	fakePayment.Status = StatusFailed
+	fakePayment.AttemptTime = time.Unix(1000, 0)
+	fakePayment.ResolveTime = time.Unix(1002, 0)
+	fakePayment.Failure = &HTLCFailure{
+		Cancelled: true,
+		Reason:    lnwire.UnknownPaymentHash,
+		Message:   lnwire.CancelReason(lnwire.UnknownPaymentHash).String(),
+	}
+	fakePayment.FailedAttempts = []PaymentAttempt{
+		{
+			Fee:         10,
+			Path:        fakePayment.Path[:1],
+			AttemptTime: time.Unix(900, 0),
+			ResolveTime: time.Unix(901, 0),
+			Failure: &HTLCFailure{
+				Message: "insufficient capacity",
+			},
+		},
+		{
+			Fee:  20,
+			Path: fakePayment.Path[:2],
+		},
+	}
+	fakePayment.FailureReason = FailureReasonIncorrectPaymentDetails
+
+	var b bytes.Buffer
+	if err := serializeOutgoingPayment(&b, fakePayment); err != nil {
+		t.Fatalf("unable to serialize outgoing payment: %v", err)
+	}
+	serialized := b.Bytes()
+
+	newPayment, err := deserializeOutgoingPayment(
+		bytes.NewReader(serialized),
+	)
+	if err != nil {
+		t.Fatalf("unable to deserialize outgoing payment: %v", err)
+	}
+	if !reflect.DeepEqual(fakePayment, newPayment) {
+		t.Fatalf("expected payment %v, got %v",
+			spew.Sdump(fakePayment), spew.Sdump(newPayment))
+	}
+
+	// Strip the HTLC details of every attempt, along with the failure
+	// reason following them, leaving the payment as it would've been
+	// recorded before they were tracked.
+	var htlcDetails bytes.Buffer
+	final := fakePayment.FinalAttempt()
+	attempts := append(
+		[]PaymentAttempt{final}, fakePayment.FailedAttempts...,
+	)
+	for _, attempt := range attempts {
+		if err := serializeAttemptHTLC(&htlcDetails, &attempt); err != nil {
+			t.Fatalf("unable to serialize attempt: %v", err)
+		}
+	}
+	legacyPayment := serialized[:len(serialized)-htlcDetails.Len()-1]
+
+	newPayment, err = deserializeOutgoingPayment(
+		bytes.NewReader(legacyPayment),
+	)
+	if err != nil {
+		t.Fatalf("unable to deserialize outgoing payment: %v", err)
+	}
+
+	fakePayment.AttemptTime = time.Time{}
+	fakePayment.ResolveTime = time.Time{}
+	fakePayment.Failure = nil
+	fakePayment.FailureReason = FailureReasonNone
+	for i := range fakePayment.FailedAttempts {
+		fakePayment.FailedAttempts[i].AttemptTime = time.Time{}
+		fakePayment.FailedAttempts[i].ResolveTime = time.Time{}
+		fakePayment.FailedAttempts[i].Failure = nil
+	}
+	if !reflect.DeepEqual(fakePayment, newPayment) {
+		t.Fatalf("expected payment %v, got %v",
+			spew.Sdump(fakePayment), spew.Sdump(newPayment))
+	}
+}
+
 func TestOutgoingPaymentWorkflow(t *testing.T) {
 	db, cleanUp, err := makeTestDB()
 	defer cleanUp()
@@ -185,3 +308,380 @@ func TestOutgoingPaymentWorkflow(t *testing.T) {
 			len(paymentsAfterDeletion), 0)
 	}
 }
+
+// TestPaymentFailedAttempts tests that failed attempts to settle a payment are
+// folded into the payment, and that failed payments and attempts can be
+// pruned.
+func TestPaymentFailedAttempts(t *testing.T) {
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	// First, we'll record a failed attempt to settle a payment, followed
+	// by a successful one. Only a single payment should be recorded,
+	// retaining the failed attempt along with the creation date of the
+	// failed payment.
+	failed := makeFakePayment()
+	failed.CreationDate = failed.CreationDate.Add(-time.Hour)
+	failed.Status = StatusFailed
+	failed.Fee = 50
+	if err := db.AddPayment(failed); err != nil {
+		t.Fatalf("unable to put payment in DB: %v", err)
+	}
+	settled := makeFakePayment()
+	if err := db.AddPayment(settled); err != nil {
+		t.Fatalf("unable to put payment in DB: %v", err)
+	}
+
+	payments, err := db.FetchAllPayments()
+	if err != nil {
+		t.Fatalf("unable to fetch payments from DB: %v", err)
+	}
+	settled.CreationDate = failed.CreationDate
+	settled.FailedAttempts = []PaymentAttempt{failed.FinalAttempt()}
+	if !reflect.DeepEqual(payments, []*OutgoingPayment{settled}) {
+		t.Fatalf("expected payment %v, got %v", spew.Sdump(settled),
+			spew.Sdump(payments))
+	}
+
+	// Next, we'll add a failed payment along with another settled one.
+	otherFailed, err := makeRandomFakePayment()
+	if err != nil {
+		t.Fatalf("Internal error in tests: %v", err)
+	}
+	otherFailed.Status = StatusFailed
+	if err := db.AddPayment(otherFailed); err != nil {
+		t.Fatalf("unable to put payment in DB: %v", err)
+	}
+	otherSettled, err := makeRandomFakePayment()
+	if err != nil {
+		t.Fatalf("Internal error in tests: %v", err)
+	}
+	if err := db.AddPayment(otherSettled); err != nil {
+		t.Fatalf("unable to put payment in DB: %v", err)
+	}
+
+	// Pruning the failed attempts of settled payments should only affect
+	// the first payment.
+	numDeleted, err := db.DeleteFailedPayments(false, true)
+	if err != nil {
+		t.Fatalf("unable to delete failed attempts: %v", err)
+	}
+	if numDeleted != 1 {
+		t.Fatalf("expected 1 payment to be pruned, got %v", numDeleted)
+	}
+	settled.FailedAttempts = nil
+
+	// Deleting failed payments should only remove the failed payment.
+	numDeleted, err = db.DeleteFailedPayments(true, false)
+	if err != nil {
+		t.Fatalf("unable to delete failed payments: %v", err)
+	}
+	if numDeleted != 1 {
+		t.Fatalf("expected 1 payment to be deleted, got %v", numDeleted)
+	}
+
+	payments, err = db.FetchAllPayments()
+	if err != nil {
+		t.Fatalf("unable to fetch payments from DB: %v", err)
+	}
+	expectedPayments := []*OutgoingPayment{settled, otherSettled}
+	if !reflect.DeepEqual(payments, expectedPayments) {
+		t.Fatalf("expected payments %v, got %v",
+			spew.Sdump(expectedPayments), spew.Sdump(payments))
+	}
+
+	// Finally, a single payment can be deleted by its payment hash, after
+	// which it can no longer be found.
+	if err := db.DeletePayment(otherSettled.PaymentHash, false); err != nil {
+		t.Fatalf("unable to delete payment: %v", err)
+	}
+	err = db.DeletePayment(otherSettled.PaymentHash, false)
+	if err != ErrPaymentNotFound {
+		t.Fatalf("expected ErrPaymentNotFound, got %v", err)
+	}
+
+	payments, err = db.FetchAllPayments()
+	if err != nil {
+		t.Fatalf("unable to fetch payments from DB: %v", err)
+	}
+	if !reflect.DeepEqual(payments, []*OutgoingPayment{settled}) {
+		t.Fatalf("expected payment %v, got %v", spew.Sdump(settled),
+			spew.Sdump(payments))
+	}
+
+	// Deleting only the failed attempts of a payment should keep the
+	// payment itself.
+	retried, err := makeRandomFakePayment()
+	if err != nil {
+		t.Fatalf("Internal error in tests: %v", err)
+	}
+	retriedFailed := *retried
+	retriedFailed.Status = StatusFailed
+	if err := db.AddPayment(&retriedFailed); err != nil {
+		t.Fatalf("unable to put payment in DB: %v", err)
+	}
+	if err := db.AddPayment(retried); err != nil {
+		t.Fatalf("unable to put payment in DB: %v", err)
+	}
+	if err := db.DeletePayment(retried.PaymentHash, true); err != nil {
+		t.Fatalf("unable to delete failed attempts: %v", err)
+	}
+
+	payments, err = db.FetchAllPayments()
+	if err != nil {
+		t.Fatalf("unable to fetch payments from DB: %v", err)
+	}
+	expectedPayments = []*OutgoingPayment{settled, retried}
+	if !reflect.DeepEqual(payments, expectedPayments) {
+		t.Fatalf("expected payments %v, got %v",
+			spew.Sdump(expectedPayments), spew.Sdump(payments))
+	}
+}
+
+// TestPaymentFailedAttemptsCap tests that only the latest MaxFailedAttempts
+// failed attempts to settle a payment are retained.
+func TestPaymentFailedAttemptsCap(t *testing.T) {
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	// Each failed attempt is told apart by its fee.
+	for i := 0; i < MaxFailedAttempts+10; i++ {
+		failed := makeFakePayment()
+		failed.Status = StatusFailed
+		failed.Fee = btcutil.Amount(i)
+		if err := db.AddPayment(failed); err != nil {
+			t.Fatalf("unable to put payment in DB: %v", err)
+		}
+	}
+	if err := db.AddPayment(makeFakePayment()); err != nil {
+		t.Fatalf("unable to put payment in DB: %v", err)
+	}
+
+	payments, err := db.FetchAllPayments()
+	if err != nil {
+		t.Fatalf("unable to fetch payments from DB: %v", err)
+	}
+	if len(payments) != 1 {
+		t.Fatalf("expected 1 payment, got %v", len(payments))
+	}
+	attempts := payments[0].FailedAttempts
+	if len(attempts) != MaxFailedAttempts {
+		t.Fatalf("expected %v failed attempts, got %v",
+			MaxFailedAttempts, len(attempts))
+	}
+	for i, attempt := range attempts {
+		if attempt.Fee != btcutil.Amount(i+10) {
+			t.Fatalf("expected failed attempt %v to have fee %v, "+
+				"got %v", i, i+10, attempt.Fee)
+		}
+	}
+}
+
+// TestFailPayment tests that the reason a failed payment was abandoned can be
+// recorded, that a retry of the payment clears it, and that the latest payment
+// with a payment hash can be looked up.
+func TestFailPayment(t *testing.T) {
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	// A payment which was never attempted can't be failed.
+	payment := makeFakePayment()
+	err = db.FailPayment(payment.PaymentHash, FailureReasonNoRoute)
+	if err != ErrPaymentNotFound {
+		t.Fatalf("expected ErrPaymentNotFound, got %v", err)
+	}
+
+	payment.Status = StatusFailed
+	if err := db.AddPayment(payment); err != nil {
+		t.Fatalf("unable to put payment in DB: %v", err)
+	}
+	err = db.FailPayment(payment.PaymentHash, FailureReasonTimeout)
+	if err != nil {
+		t.Fatalf("unable to fail payment: %v", err)
+	}
+
+	payments, err := db.FetchAllPayments()
+	if err != nil {
+		t.Fatalf("unable to fetch payments from DB: %v", err)
+	}
+	if len(payments) != 1 {
+		t.Fatalf("expected 1 payment, got %v", len(payments))
+	}
+	if payments[0].FailureReason != FailureReasonTimeout {
+		t.Fatalf("expected failure reason %v, got %v",
+			FailureReasonTimeout, payments[0].FailureReason)
+	}
+
+	// Once the payment is retried and succeeds, its failure reason
+	// should be cleared, and it can no longer be failed.
+	settled := makeFakePayment()
+	if err := db.AddPayment(settled); err != nil {
+		t.Fatalf("unable to put payment in DB: %v", err)
+	}
+	payments, err = db.FetchAllPayments()
+	if err != nil {
+		t.Fatalf("unable to fetch payments from DB: %v", err)
+	}
+	if payments[0].FailureReason != FailureReasonNone {
+		t.Fatalf("expected no failure reason, got %v",
+			payments[0].FailureReason)
+	}
+	err = db.FailPayment(payment.PaymentHash, FailureReasonTimeout)
+	if err != ErrPaymentNotFound {
+		t.Fatalf("expected ErrPaymentNotFound, got %v", err)
+	}
+
+	// The latest payment with the payment hash should be returned when
+	// it's looked up, while unknown payments can't be found.
+	dbPayment, err := db.FetchPayment(payment.PaymentHash)
+	if err != nil {
+		t.Fatalf("unable to fetch payment: %v", err)
+	}
+	if dbPayment.Status != StatusSucceeded {
+		t.Fatalf("expected status %v, got %v", StatusSucceeded,
+			dbPayment.Status)
+	}
+	if _, err := db.FetchPayment([32]byte{1}); err != ErrPaymentNotFound {
+		t.Fatalf("expected ErrPaymentNotFound, got %v", err)
+	}
+}
+
+// TestQueryPayments tests that payments can be paginated through in either
+// direction, and filtered by their creation date.
+func TestQueryPayments(t *testing.T) {
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	// We'll create ten payments, each created ten seconds after the
+	// previous one. Their sequence numbers start at one.
+	const numPayments = 10
+	for i := 0; i < numPayments; i++ {
+		payment, err := makeRandomFakePayment()
+		if err != nil {
+			t.Fatalf("Internal error in tests: %v", err)
+		}
+		payment.CreationDate = time.Unix(int64(i)*10, 0)
+		if err := db.AddPayment(payment); err != nil {
+			t.Fatalf("unable to put payment in DB: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		query PaymentsQuery
+		first uint64
+		last  uint64
+		num   int
+	}{
+		{
+			name:  "first page",
+			query: PaymentsQuery{MaxPayments: 3},
+			first: 1,
+			last:  3,
+			num:   3,
+		},
+		{
+			name:  "second page",
+			query: PaymentsQuery{IndexOffset: 3, MaxPayments: 3},
+			first: 4,
+			last:  6,
+			num:   3,
+		},
+		{
+			name:  "last page",
+			query: PaymentsQuery{Reversed: true, MaxPayments: 3},
+			first: 8,
+			last:  10,
+			num:   3,
+		},
+		{
+			name: "previous page",
+			query: PaymentsQuery{
+				IndexOffset: 8,
+				Reversed:    true,
+				MaxPayments: 3,
+			},
+			first: 5,
+			last:  7,
+			num:   3,
+		},
+		{
+			name: "partial previous page",
+			query: PaymentsQuery{
+				IndexOffset: 2,
+				Reversed:    true,
+				MaxPayments: 5,
+			},
+			first: 1,
+			last:  1,
+			num:   1,
+		},
+		{
+			name:  "past last payment",
+			query: PaymentsQuery{IndexOffset: numPayments},
+			num:   0,
+		},
+		{
+			name: "creation date range",
+			query: PaymentsQuery{
+				CreationDateStart: time.Unix(20, 0),
+				CreationDateEnd:   time.Unix(40, 0),
+			},
+			first: 3,
+			last:  5,
+			num:   3,
+		},
+	}
+
+	for _, test := range tests {
+		resp, err := db.QueryPayments(test.query)
+		if err != nil {
+			t.Fatalf("%v: unable to query payments: %v", test.name,
+				err)
+		}
+
+		if len(resp.Payments) != test.num {
+			t.Fatalf("%v: expected %v payments, got %v", test.name,
+				test.num, len(resp.Payments))
+		}
+		if resp.FirstIndexOffset != test.first ||
+			resp.LastIndexOffset != test.last {
+
+			t.Fatalf("%v: expected payments %v to %v, got %v to %v",
+				test.name, test.first, test.last,
+				resp.FirstIndexOffset, resp.LastIndexOffset)
+		}
+		for i, payment := range resp.Payments {
+			if payment.SequenceNum != test.first+uint64(i) {
+				t.Fatalf("%v: payments out of order", test.name)
+			}
+		}
+		if resp.TotalNumPayments != 0 {
+			t.Fatalf("%v: total count not requested", test.name)
+		}
+	}
+
+	resp, err := db.QueryPayments(PaymentsQuery{
+		MaxPayments:        1,
+		CountTotalPayments: true,
+	})
+	if err != nil {
+		t.Fatalf("unable to query payments: %v", err)
+	}
+	if resp.TotalNumPayments != numPayments {
+		t.Fatalf("expected %v total payments, got %v", numPayments,
+			resp.TotalNumPayments)
+	}
+}
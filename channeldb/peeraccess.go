@@ -0,0 +1,200 @@
+package channeldb
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+
+	"github.com/boltdb/bolt"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+var (
+	// bannedPeersBucket is the name of the bucket within the database that
+	// stores the peers we refuse connections and channels from. Each peer
+	// is keyed by its serialized PeerAccessEntry, with an empty value.
+	bannedPeersBucket = []byte("banned-peers")
+
+	// allowedPeersBucket is the name of the bucket within the database
+	// that stores the peers we accept connections and channels from when
+	// running in allowlist only mode. Each peer is keyed by its serialized
+	// PeerAccessEntry, with an empty value.
+	allowedPeersBucket = []byte("allowed-peers")
+)
+
+// PeerAccessList identifies one of the lists controlling which peers we
+// accept connections and channels from.
+type PeerAccessList uint8
+
+const (
+	// BannedPeers is the list of peers we refuse connections and channels
+	// from.
+	BannedPeers PeerAccessList = 0
+
+	// AllowedPeers is the list of peers we solely accept connections and
+	// channels from when running in allowlist only mode.
+	AllowedPeers PeerAccessList = 1
+)
+
+// String returns a human-readable name of the list.
+func (l PeerAccessList) String() string {
+	switch l {
+	case BannedPeers:
+		return "banned"
+	case AllowedPeers:
+		return "allowed"
+	default:
+		return fmt.Sprintf("PeerAccessList(%d)", uint8(l))
+	}
+}
+
+// bucket returns the name of the bucket storing the entries of the list.
+func (l PeerAccessList) bucket() ([]byte, error) {
+	switch l {
+	case BannedPeers:
+		return bannedPeersBucket, nil
+	case AllowedPeers:
+		return allowedPeersBucket, nil
+	default:
+		return nil, fmt.Errorf("unknown peer access list %v", l)
+	}
+}
+
+// PeerAccessEntry identifies the peers an entry of a PeerAccessList applies
+// to, either by their public key or by their IP address. Exactly one of its
+// fields is set.
+type PeerAccessEntry struct {
+	// PubKey is the identity public key of the peer.
+	PubKey *btcec.PublicKey
+
+	// IP is the IP address the peers connect from, or are connected to.
+	IP net.IP
+}
+
+// String returns the hex encoded public key, or the IP address, the entry
+// applies to.
+func (e *PeerAccessEntry) String() string {
+	if e.PubKey != nil {
+		return hex.EncodeToString(e.PubKey.SerializeCompressed())
+	}
+
+	return e.IP.String()
+}
+
+// serialize returns the key of the entry within the bucket of its list. Public
+// keys are serialized in their 33 byte compressed form, while IP addresses
+// are serialized in their 16 byte form, so both can be told apart.
+func (e *PeerAccessEntry) serialize() ([]byte, error) {
+	switch {
+	case e.PubKey != nil:
+		return e.PubKey.SerializeCompressed(), nil
+
+	case e.IP.To16() != nil:
+		return e.IP.To16(), nil
+
+	default:
+		return nil, fmt.Errorf("peer access entry has neither a " +
+			"public key nor an IP address")
+	}
+}
+
+// deserializePeerAccessEntry parses an entry serialized by serialize.
+func deserializePeerAccessEntry(b []byte) (*PeerAccessEntry, error) {
+	switch len(b) {
+	case btcec.PubKeyBytesLenCompressed:
+		pubKey, err := btcec.ParsePubKey(b, btcec.S256())
+		if err != nil {
+			return nil, err
+		}
+		return &PeerAccessEntry{PubKey: pubKey}, nil
+
+	case net.IPv6len:
+		ip := make(net.IP, net.IPv6len)
+		copy(ip, b)
+		return &PeerAccessEntry{IP: ip}, nil
+
+	default:
+		return nil, fmt.Errorf("invalid peer access entry of %v bytes",
+			len(b))
+	}
+}
+
+// AddPeerAccessEntry adds the passed entry to the passed list.
+func (d *DB) AddPeerAccessEntry(list PeerAccessList,
+	entry *PeerAccessEntry) error {
+
+	bucketName, err := list.bucket()
+	if err != nil {
+		return err
+	}
+	key, err := entry.serialize()
+	if err != nil {
+		return err
+	}
+
+	return d.Update(func(tx *bolt.Tx) error {
+		entries, err := tx.CreateBucketIfNotExists(bucketName)
+		if err != nil {
+			return err
+		}
+
+		return entries.Put(key, nil)
+	})
+}
+
+// RemovePeerAccessEntry removes the passed entry from the passed list, if
+// present.
+func (d *DB) RemovePeerAccessEntry(list PeerAccessList,
+	entry *PeerAccessEntry) error {
+
+	bucketName, err := list.bucket()
+	if err != nil {
+		return err
+	}
+	key, err := entry.serialize()
+	if err != nil {
+		return err
+	}
+
+	return d.Update(func(tx *bolt.Tx) error {
+		entries := tx.Bucket(bucketName)
+		if entries == nil {
+			return nil
+		}
+
+		return entries.Delete(key)
+	})
+}
+
+// FetchPeerAccessEntries returns all the entries of the passed list.
+func (d *DB) FetchPeerAccessEntries(list PeerAccessList) ([]*PeerAccessEntry,
+	error) {
+
+	bucketName, err := list.bucket()
+	if err != nil {
+		return nil, err
+	}
+
+	var accessEntries []*PeerAccessEntry
+	err = d.View(func(tx *bolt.Tx) error {
+		entries := tx.Bucket(bucketName)
+		if entries == nil {
+			return nil
+		}
+
+		return entries.ForEach(func(k, _ []byte) error {
+			entry, err := deserializePeerAccessEntry(k)
+			if err != nil {
+				return err
+			}
+
+			accessEntries = append(accessEntries, entry)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return accessEntries, nil
+}
@@ -0,0 +1,74 @@
+package channeldb
+
+import (
+	"net"
+	"testing"
+)
+
+// TestPeerAccessEntries tests that public keys and IP addresses can be added
+// to and removed from the peer access lists, independently of each other.
+func TestPeerAccessEntries(t *testing.T) {
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	banned, err := db.FetchPeerAccessEntries(BannedPeers)
+	if err != nil {
+		t.Fatalf("unable to fetch entries: %v", err)
+	}
+	if len(banned) != 0 {
+		t.Fatalf("expected no banned peers, got %v", len(banned))
+	}
+
+	pubKeyEntry := &PeerAccessEntry{PubKey: pubKey}
+	ipv4Entry := &PeerAccessEntry{IP: net.ParseIP("10.0.0.1")}
+	ipv6Entry := &PeerAccessEntry{IP: net.ParseIP("2001:db8::1")}
+	entries := []*PeerAccessEntry{pubKeyEntry, ipv4Entry, ipv6Entry}
+	for _, entry := range entries {
+		if err := db.AddPeerAccessEntry(BannedPeers, entry); err != nil {
+			t.Fatalf("unable to ban %v: %v", entry, err)
+		}
+	}
+	if err := db.AddPeerAccessEntry(AllowedPeers, pubKeyEntry); err != nil {
+		t.Fatalf("unable to allow %v: %v", pubKeyEntry, err)
+	}
+
+	// An entry without either a public key or an IP address should be
+	// rejected.
+	err = db.AddPeerAccessEntry(BannedPeers, &PeerAccessEntry{})
+	if err == nil {
+		t.Fatalf("empty entry was accepted")
+	}
+
+	if err := db.RemovePeerAccessEntry(BannedPeers, ipv6Entry); err != nil {
+		t.Fatalf("unable to unban %v: %v", ipv6Entry, err)
+	}
+
+	banned, err = db.FetchPeerAccessEntries(BannedPeers)
+	if err != nil {
+		t.Fatalf("unable to fetch entries: %v", err)
+	}
+	bannedStrs := make(map[string]struct{})
+	for _, entry := range banned {
+		bannedStrs[entry.String()] = struct{}{}
+	}
+	if len(bannedStrs) != 2 {
+		t.Fatalf("expected 2 banned peers, got %v", len(bannedStrs))
+	}
+	for _, entry := range []*PeerAccessEntry{pubKeyEntry, ipv4Entry} {
+		if _, ok := bannedStrs[entry.String()]; !ok {
+			t.Fatalf("expected %v to be banned", entry)
+		}
+	}
+
+	// The allowed list should be unaffected by changes to the banned one.
+	allowed, err := db.FetchPeerAccessEntries(AllowedPeers)
+	if err != nil {
+		t.Fatalf("unable to fetch entries: %v", err)
+	}
+	if len(allowed) != 1 || !allowed[0].PubKey.IsEqual(pubKey) {
+		t.Fatalf("expected %v to be the only allowed peer", pubKeyEntry)
+	}
+}
@@ -0,0 +1,279 @@
+package channeldb
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+var (
+	// channelShellBucket is the name of the bucket within the database
+	// that stores the shells of channels restored from a static channel
+	// backup. Each shell is keyed by its serialized funding outpoint.
+	channelShellBucket = []byte("channel-shells")
+)
+
+// ChannelShell is the minimal state of a channel restored from a static
+// channel backup. As the state of the channel itself was lost, a shell can't
+// be used to update the channel. It only records enough to locate the peer
+// of the channel, which is expected to force close it so its funds can be
+// recovered.
+type ChannelShell struct {
+	// ChanPoint is the funding outpoint of the channel.
+	ChanPoint wire.OutPoint
+
+	// ShortChanID is the short channel ID of the channel, or zero if it
+	// wasn't known when the backup was taken.
+	ShortChanID uint64
+
+	// RemotePub is the identity public key of the peer of the channel.
+	RemotePub *btcec.PublicKey
+
+	// Addresses is the set of addresses the peer could be reached at.
+	Addresses []*net.TCPAddr
+
+	// Capacity is the total capacity of the channel.
+	Capacity btcutil.Amount
+
+	// RestoredAt is the time the channel was restored.
+	RestoredAt time.Time
+
+	// CommitType is the commitment type of the channel when it was backed
+	// up.
+	CommitType CommitmentType
+
+	// PaymentKeyLoc is the key locator of the key the commitment
+	// transaction of the peer pays our balance to, if the channel uses
+	// the static remote key commitment type.
+	PaymentKeyLoc keychain.KeyLocator
+}
+
+// AddChannelShells stores the passed channel shells, replacing any existing
+// shell of the same channel.
+func (d *DB) AddChannelShells(shells []*ChannelShell) error {
+	return d.Update(func(tx *bolt.Tx) error {
+		shellBucket, err := tx.CreateBucketIfNotExists(channelShellBucket)
+		if err != nil {
+			return err
+		}
+
+		for _, shell := range shells {
+			var k bytes.Buffer
+			if err := writeOutpoint(&k, &shell.ChanPoint); err != nil {
+				return err
+			}
+
+			var v bytes.Buffer
+			if err := serializeChannelShell(&v, shell); err != nil {
+				return err
+			}
+
+			if err := shellBucket.Put(k.Bytes(), v.Bytes()); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// FetchChannelShells returns the shells of the channels with the passed peer,
+// or all channel shells if the peer is nil.
+func (d *DB) FetchChannelShells(remotePub *btcec.PublicKey) ([]*ChannelShell, error) {
+	var shells []*ChannelShell
+
+	err := d.View(func(tx *bolt.Tx) error {
+		shellBucket := tx.Bucket(channelShellBucket)
+		if shellBucket == nil {
+			return nil
+		}
+
+		return shellBucket.ForEach(func(k, v []byte) error {
+			shell, err := deserializeChannelShell(bytes.NewReader(v))
+			if err != nil {
+				return err
+			}
+			if err := readOutpoint(bytes.NewReader(k), &shell.ChanPoint); err != nil {
+				return err
+			}
+
+			if remotePub != nil && !shell.RemotePub.IsEqual(remotePub) {
+				return nil
+			}
+
+			shells = append(shells, shell)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return shells, nil
+}
+
+// DeleteChannelShell removes the shell of the channel identified by the passed
+// funding outpoint, once its funds have been recovered.
+func (d *DB) DeleteChannelShell(chanPoint *wire.OutPoint) error {
+	var k bytes.Buffer
+	if err := writeOutpoint(&k, chanPoint); err != nil {
+		return err
+	}
+
+	return d.Update(func(tx *bolt.Tx) error {
+		shellBucket := tx.Bucket(channelShellBucket)
+		if shellBucket == nil {
+			return ErrChannelNoExist
+		}
+		if shellBucket.Get(k.Bytes()) == nil {
+			return ErrChannelNoExist
+		}
+
+		return shellBucket.Delete(k.Bytes())
+	})
+}
+
+func serializeChannelShell(w io.Writer, shell *ChannelShell) error {
+	var scratch [8]byte
+
+	byteOrder.PutUint64(scratch[:], shell.ShortChanID)
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(shell.RemotePub.SerializeCompressed()); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint64(scratch[:], uint64(shell.Capacity))
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint64(scratch[:], uint64(shell.RestoredAt.Unix()))
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint32(scratch[:4], uint32(len(shell.Addresses)))
+	if _, err := w.Write(scratch[:4]); err != nil {
+		return err
+	}
+	for _, addr := range shell.Addresses {
+		if err := wire.WriteVarString(w, 0, addr.String()); err != nil {
+			return err
+		}
+	}
+
+	if _, err := w.Write([]byte{byte(shell.CommitType)}); err != nil {
+		return err
+	}
+
+	return writeKeyLocator(w, shell.PaymentKeyLoc)
+}
+
+func deserializeChannelShell(r io.Reader) (*ChannelShell, error) {
+	var (
+		err     error
+		scratch [8]byte
+	)
+
+	shell := &ChannelShell{}
+
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	shell.ShortChanID = byteOrder.Uint64(scratch[:])
+
+	var pub [33]byte
+	if _, err := io.ReadFull(r, pub[:]); err != nil {
+		return nil, err
+	}
+	shell.RemotePub, err = btcec.ParsePubKey(pub[:], btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	shell.Capacity = btcutil.Amount(byteOrder.Uint64(scratch[:]))
+
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	shell.RestoredAt = time.Unix(int64(byteOrder.Uint64(scratch[:])), 0)
+
+	if _, err := io.ReadFull(r, scratch[:4]); err != nil {
+		return nil, err
+	}
+	numAddrs := byteOrder.Uint32(scratch[:4])
+
+	shell.Addresses = make([]*net.TCPAddr, numAddrs)
+	for i := uint32(0); i < numAddrs; i++ {
+		addrString, err := wire.ReadVarString(r, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		addr, err := ParseTCPAddr(addrString)
+		if err != nil {
+			return nil, err
+		}
+		shell.Addresses[i] = addr
+	}
+
+	// Shells stored before commitment types were recorded end here, and
+	// are of the legacy commitment type.
+	if _, err := io.ReadFull(r, scratch[:1]); err == io.EOF {
+		return shell, nil
+	} else if err != nil {
+		return nil, err
+	}
+	shell.CommitType = CommitmentType(scratch[0])
+
+	shell.PaymentKeyLoc, err = readKeyLocator(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return shell, nil
+}
+
+// ParseTCPAddr parses the string form of a TCP address, as produced by
+// net.TCPAddr's String method, without performing any DNS lookups. As stored
+// addresses are always IP addresses, a host name is rejected rather than
+// resolved.
+func ParseTCPAddr(addrString string) (*net.TCPAddr, error) {
+	host, portString, err := net.SplitHostPort(addrString)
+	if err != nil {
+		return nil, err
+	}
+
+	var zone string
+	if i := strings.LastIndex(host, "%"); i != -1 {
+		host, zone = host[:i], host[i+1:]
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP address: %v", host)
+	}
+
+	port, err := strconv.ParseUint(portString, 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port: %v", portString)
+	}
+
+	return &net.TCPAddr{IP: ip, Port: int(port), Zone: zone}, nil
+}
@@ -0,0 +1,125 @@
+package channeldb
+
+import (
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// TestChannelShells tests that the shells of restored channels can be stored,
+// fetched by peer, and deleted.
+func TestChannelShells(t *testing.T) {
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	_, pub1 := btcec.PrivKeyFromBytes(btcec.S256(), key[:])
+	_, pub2 := btcec.PrivKeyFromBytes(btcec.S256(), rev[:])
+	addr, err := net.ResolveTCPAddr("tcp", "10.0.0.1:9000")
+	if err != nil {
+		t.Fatalf("unable to create test addr: %v", err)
+	}
+
+	shell1 := &ChannelShell{
+		ChanPoint:   wire.OutPoint{Hash: key, Index: 1},
+		ShortChanID: 1234,
+		RemotePub:   pub1,
+		Addresses:   []*net.TCPAddr{addr},
+		Capacity:    500000,
+		RestoredAt:  time.Unix(1500000000, 0),
+		CommitType:  CommitTypeStaticRemoteKey,
+		PaymentKeyLoc: keychain.KeyLocator{
+			Family: keychain.KeyFamilyPaymentBase,
+			Index:  7,
+		},
+	}
+	shell2 := &ChannelShell{
+		ChanPoint:  wire.OutPoint{Hash: key, Index: 2},
+		RemotePub:  pub2,
+		Capacity:   100000,
+		RestoredAt: time.Unix(1500000000, 0),
+	}
+	err = db.AddChannelShells([]*ChannelShell{shell1, shell2})
+	if err != nil {
+		t.Fatalf("unable to add channel shells: %v", err)
+	}
+
+	shells, err := db.FetchChannelShells(nil)
+	if err != nil {
+		t.Fatalf("unable to fetch channel shells: %v", err)
+	}
+	if len(shells) != 2 {
+		t.Fatalf("expected 2 channel shells, got %v", len(shells))
+	}
+
+	// Fetching the shells of the first peer should only return its own,
+	// intact.
+	shells, err = db.FetchChannelShells(pub1)
+	if err != nil {
+		t.Fatalf("unable to fetch channel shells: %v", err)
+	}
+	if len(shells) != 1 {
+		t.Fatalf("expected 1 channel shell, got %v", len(shells))
+	}
+	if !reflect.DeepEqual(shells[0], shell1) {
+		t.Fatalf("channel shells don't match: expected %v, got %v",
+			shell1, shells[0])
+	}
+
+	// Once deleted, the shell should no longer be returned, and deleting
+	// it again should fail.
+	if err := db.DeleteChannelShell(&shell1.ChanPoint); err != nil {
+		t.Fatalf("unable to delete channel shell: %v", err)
+	}
+	shells, err = db.FetchChannelShells(pub1)
+	if err != nil {
+		t.Fatalf("unable to fetch channel shells: %v", err)
+	}
+	if len(shells) != 0 {
+		t.Fatalf("expected no channel shells, got %v", len(shells))
+	}
+	err = db.DeleteChannelShell(&shell1.ChanPoint)
+	if err != ErrChannelNoExist {
+		t.Fatalf("expected ErrChannelNoExist, got %v", err)
+	}
+}
+
+// TestParseTCPAddr tests that stored addresses are parsed without being
+// resolved, and that host names are rejected.
+func TestParseTCPAddr(t *testing.T) {
+	tests := []struct {
+		addr  string
+		valid bool
+	}{
+		{addr: "10.0.0.1:9735", valid: true},
+		{addr: "[2001:db8::1]:9735", valid: true},
+		{addr: "[fe80::1%eth0]:9735", valid: true},
+		{addr: "example.com:9735", valid: false},
+		{addr: "10.0.0.1", valid: false},
+		{addr: "10.0.0.1:70000", valid: false},
+	}
+
+	for _, test := range tests {
+		addr, err := ParseTCPAddr(test.addr)
+		if !test.valid {
+			if err == nil {
+				t.Fatalf("expected %v to be rejected", test.addr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("unable to parse %v: %v", test.addr, err)
+		}
+
+		if addr.String() != test.addr {
+			t.Fatalf("expected %v, got %v", test.addr, addr)
+		}
+	}
+}
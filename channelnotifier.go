@@ -0,0 +1,69 @@
+package main
+
+import (
+	"sync"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// channelEvent describes a change in the state of one of our channels.
+type channelEvent struct {
+	eventType lnrpc.ChannelEventUpdate_UpdateType
+	chanPoint wire.OutPoint
+	remotePub *btcec.PublicKey
+}
+
+// channelNotifier dispatches events concerning the opening, closing, and
+// activity of our channels to any subscribed clients.
+type channelNotifier struct {
+	notifier *eventNotifier
+
+	// closed is the set of channels whose closure has already been
+	// notified. A channel may be reported as closed by several
+	// sub-systems, such as both the breachArbiter and the peer wiping the
+	// breached channel, yet clients are only notified of it once.
+	closedMtx sync.Mutex
+	closed    map[wire.OutPoint]struct{}
+}
+
+// newChannelNotifier creates a new channelNotifier with no subscribed
+// clients.
+func newChannelNotifier() *channelNotifier {
+	return &channelNotifier{
+		notifier: newEventNotifier(defaultEventQueueSize),
+		closed:   make(map[wire.OutPoint]struct{}),
+	}
+}
+
+// notifyChannelEvent hands off a new event concerning the channel with the
+// passed outpoint to all currently registered clients.
+func (c *channelNotifier) notifyChannelEvent(
+	eventType lnrpc.ChannelEventUpdate_UpdateType, chanPoint wire.OutPoint,
+	remotePub *btcec.PublicKey) {
+
+	if eventType == lnrpc.ChannelEventUpdate_CLOSED_CHANNEL {
+		c.closedMtx.Lock()
+		_, ok := c.closed[chanPoint]
+		c.closed[chanPoint] = struct{}{}
+		c.closedMtx.Unlock()
+
+		if ok {
+			return
+		}
+	}
+
+	c.notifier.notify(&channelEvent{
+		eventType: eventType,
+		chanPoint: chanPoint,
+		remotePub: remotePub,
+	})
+}
+
+// SubscribeChannelEvents returns an eventSubscription which allows the caller
+// to receive async notifications of any events concerning our channels. Each
+// event sent over the subscription is a *channelEvent.
+func (c *channelNotifier) SubscribeChannelEvents() *eventSubscription {
+	return c.notifier.subscribe()
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// TestChannelNotifierOrdering asserts that channel events are delivered to
+// subscribers in the order they occurred, and that the closure of a channel
+// is only notified once, however many sub-systems report it.
+func TestChannelNotifierOrdering(t *testing.T) {
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+
+	notifier := newChannelNotifier()
+	client := notifier.SubscribeChannelEvents()
+	defer client.Cancel()
+
+	events := []lnrpc.ChannelEventUpdate_UpdateType{
+		lnrpc.ChannelEventUpdate_PENDING_OPEN_CHANNEL,
+		lnrpc.ChannelEventUpdate_OPEN_CHANNEL,
+		lnrpc.ChannelEventUpdate_ACTIVE_CHANNEL,
+		lnrpc.ChannelEventUpdate_INACTIVE_CHANNEL,
+		lnrpc.ChannelEventUpdate_CLOSED_CHANNEL,
+	}
+	chanPoint := wire.OutPoint{Index: 1}
+	for _, eventType := range events {
+		notifier.notifyChannelEvent(eventType, chanPoint, priv.PubKey())
+	}
+
+	// A breached channel is reported closed by both the breachArbiter and
+	// the peer wiping it, yet only the first report should be delivered.
+	notifier.notifyChannelEvent(
+		lnrpc.ChannelEventUpdate_CLOSED_CHANNEL, chanPoint,
+		priv.PubKey(),
+	)
+
+	for i, eventType := range events {
+		select {
+		case e := <-client.Events:
+			event := e.(*channelEvent)
+			if event.eventType != eventType {
+				t.Fatalf("event #%v: expected %v, got %v", i,
+					eventType, event.eventType)
+			}
+			if event.chanPoint != chanPoint {
+				t.Fatalf("event #%v: wrong channel point", i)
+			}
+		case <-time.After(time.Second * 5):
+			t.Fatalf("event #%v not received", i)
+		}
+	}
+
+	select {
+	case e := <-client.Events:
+		t.Fatalf("unexpected event: %v",
+			e.(*channelEvent).eventType)
+	case <-time.After(time.Millisecond * 100):
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/chanstatus"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// newChanStatusManager creates a channel status manager which disables and
+// re-enables the channels of the server, either manually or following the
+// connectivity of their peers within the timeouts of the passed config.
+func newChanStatusManager(s *server,
+	cfg *chanStatusConfig) *chanstatus.Manager {
+
+	return chanstatus.New(&chanstatus.Config{
+		DisableTimeout: cfg.DisableTimeout,
+		EnableTimeout:  cfg.EnableTimeout,
+		FetchPeers: func() ([]*btcec.PublicKey, error) {
+			return fetchChannelPeers(s)
+		},
+		FetchChannels: func(
+			peer *btcec.PublicKey) ([]*chanstatus.ChannelStatus, error) {
+
+			return fetchChanStatuses(s, peer)
+		},
+		SetDisabled: func(chanPoint wire.OutPoint, disabled bool) error {
+			return s.chanRouter.SetChannelDisabled(&chanPoint, disabled)
+		},
+		FetchManual: s.chanDB.FetchManualChanStatuses,
+		SetManual: func(chanPoint wire.OutPoint, disabled bool) error {
+			return s.chanDB.SetManualChanStatus(&chanPoint, disabled)
+		},
+		ClearManual: func(chanPoint wire.OutPoint) error {
+			return s.chanDB.ClearManualChanStatus(&chanPoint)
+		},
+	})
+}
+
+// fetchChannelPeers returns the public key of each peer we have at least one
+// open channel with.
+func fetchChannelPeers(s *server) ([]*btcec.PublicKey, error) {
+	dbChannels, err := s.chanDB.FetchAllChannels()
+	if err != nil && err != channeldb.ErrNoActiveChannels {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{})
+	var peers []*btcec.PublicKey
+	for _, dbChannel := range dbChannels {
+		key := string(dbChannel.IdentityPub.SerializeCompressed())
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		peers = append(peers, dbChannel.IdentityPub)
+	}
+
+	return peers, nil
+}
+
+// fetchChanStatuses returns the status of each of our open channels with the
+// passed peer. Channels we've yet to announce a policy for are skipped, as
+// there's no channel update to disable.
+func fetchChanStatuses(s *server,
+	peer *btcec.PublicKey) ([]*chanstatus.ChannelStatus, error) {
+
+	dbChannels, err := s.chanDB.FetchOpenChannels(peer)
+	if err != nil {
+		return nil, err
+	}
+
+	chans := make([]*chanstatus.ChannelStatus, 0, len(dbChannels))
+	for _, dbChannel := range dbChannels {
+		policy, err := s.chanRouter.OwnChannelPolicy(dbChannel.ChanID)
+		switch {
+		case err == channeldb.ErrEdgeNotFound:
+			continue
+		case err != nil:
+			return nil, err
+		}
+
+		chans = append(chans, &chanstatus.ChannelStatus{
+			ChanPoint: *dbChannel.ChanID,
+			Disabled:  policy.Flags&lnwire.ChanUpdateDisabled != 0,
+		})
+	}
+
+	return chans, nil
+}
@@ -0,0 +1,367 @@
+package chanstatus
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+)
+
+const (
+	// DefaultDisableTimeout is the default amount of time a peer must be
+	// offline before its channels are automatically disabled. It's also
+	// the default of the daemon's chanstatus.disabletimeout option.
+	DefaultDisableTimeout = 20 * time.Minute
+
+	// DefaultEnableTimeout is the default amount of time a peer must stay
+	// online after reconnecting before its channels are automatically
+	// re-enabled. This ensures a flapping peer doesn't cause a channel
+	// update to be broadcast on each reconnection. It's also the default
+	// of the daemon's chanstatus.enabletimeout option.
+	DefaultEnableTimeout = 19 * time.Minute
+)
+
+// Action is a request to change the status of a channel.
+type Action uint8
+
+const (
+	// ActionEnable manually enables the channel, which stays enabled
+	// regardless of the connectivity of its peer.
+	ActionEnable Action = iota
+
+	// ActionDisable manually disables the channel, which stays disabled
+	// regardless of the connectivity of its peer.
+	ActionDisable
+
+	// ActionAuto returns the channel to automatic management, where it's
+	// disabled while its peer is offline.
+	ActionAuto
+)
+
+// String returns a human readable version of the action.
+func (a Action) String() string {
+	switch a {
+	case ActionEnable:
+		return "enable"
+	case ActionDisable:
+		return "disable"
+	case ActionAuto:
+		return "auto"
+	default:
+		return "unknown"
+	}
+}
+
+// ChannelStatus is a snapshot of our direction of a channel.
+type ChannelStatus struct {
+	// ChanPoint is the funding outpoint of the channel.
+	ChanPoint wire.OutPoint
+
+	// Disabled is true if we've announced the channel as disabled.
+	Disabled bool
+}
+
+// Config houses the parameters and dependencies of the Manager.
+type Config struct {
+	// DisableTimeout is the amount of time a peer must be offline before
+	// its automatically managed channels are disabled.
+	DisableTimeout time.Duration
+
+	// EnableTimeout is the amount of time a peer must stay online before
+	// its automatically managed channels are re-enabled.
+	EnableTimeout time.Duration
+
+	// FetchPeers returns the public keys of all peers we have open
+	// channels with.
+	FetchPeers func() ([]*btcec.PublicKey, error)
+
+	// FetchChannels returns the status of each of our open channels with
+	// the passed peer. Channels we've yet to announce are omitted.
+	FetchChannels func(peer *btcec.PublicKey) ([]*ChannelStatus, error)
+
+	// SetDisabled announces the channel with the passed funding outpoint
+	// as disabled, or enabled.
+	SetDisabled func(chanPoint wire.OutPoint, disabled bool) error
+
+	// FetchManual returns the channels whose status has been set
+	// manually, each mapped to whether it was disabled. SetManual and
+	// ClearManual persist a manually set status, and its removal.
+	FetchManual func() (map[wire.OutPoint]bool, error)
+	SetManual   func(chanPoint wire.OutPoint, disabled bool) error
+	ClearManual func(chanPoint wire.OutPoint) error
+}
+
+// peerState tracks the connectivity of a peer.
+type peerState struct {
+	pubKey *btcec.PublicKey
+	online bool
+
+	// timer fires once the peer has been in its current state for long
+	// enough that its channels should follow. It's nil once the channels
+	// are up to date.
+	timer *time.Timer
+}
+
+// Manager disables and re-enables our channels, either as requested manually,
+// or automatically as their peers go offline and come back online. Disabling
+// a channel broadcasts a channel update with the disable bit set, so the
+// network stops routing through a channel which can't be used.
+type Manager struct {
+	started uint32 // To be used atomically.
+	stopped uint32 // To be used atomically.
+
+	cfg *Config
+
+	// manual maps the funding outpoint of each channel whose status has
+	// been set manually to whether it was disabled, while peers maps the
+	// serialized public key of each known peer to its state. Both are
+	// guarded by the mtx.
+	mtx    sync.Mutex
+	manual map[wire.OutPoint]bool
+	peers  map[string]*peerState
+
+	quit chan struct{}
+}
+
+// New creates a new channel status manager from the passed config.
+func New(cfg *Config) *Manager {
+	if cfg.DisableTimeout == 0 {
+		cfg.DisableTimeout = DefaultDisableTimeout
+	}
+	if cfg.EnableTimeout == 0 {
+		cfg.EnableTimeout = DefaultEnableTimeout
+	}
+
+	return &Manager{
+		cfg:    cfg,
+		manual: make(map[wire.OutPoint]bool),
+		peers:  make(map[string]*peerState),
+		quit:   make(chan struct{}),
+	}
+}
+
+// Start loads the manually set channel statuses, removing those of channels
+// which are no longer open. As no peer is connected yet, the channels of each
+// peer will be disabled unless it comes online within the disable timeout.
+func (m *Manager) Start() error {
+	if !atomic.CompareAndSwapUint32(&m.started, 0, 1) {
+		return nil
+	}
+
+	log.Infof("Channel status manager starting")
+
+	manual, err := m.cfg.FetchManual()
+	if err != nil {
+		return err
+	}
+	peers, err := m.cfg.FetchPeers()
+	if err != nil {
+		return err
+	}
+
+	// The manually set status of a channel which has since been closed
+	// has expired, so it's removed rather than carried forward forever.
+	open := make(map[wire.OutPoint]struct{})
+	for _, pubKey := range peers {
+		chans, err := m.cfg.FetchChannels(pubKey)
+		if err != nil {
+			return err
+		}
+		for _, c := range chans {
+			open[c.ChanPoint] = struct{}{}
+		}
+	}
+	for chanPoint := range manual {
+		if _, ok := open[chanPoint]; ok {
+			continue
+		}
+
+		log.Debugf("Removing expired manual status of closed "+
+			"ChannelPoint(%v)", chanPoint)
+
+		if err := m.cfg.ClearManual(chanPoint); err != nil {
+			return err
+		}
+		delete(manual, chanPoint)
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.manual = manual
+	for _, pubKey := range peers {
+		if _, ok := m.peers[string(pubKey.SerializeCompressed())]; ok {
+			continue
+		}
+
+		m.setPeerState(pubKey, false, m.cfg.DisableTimeout)
+	}
+
+	return nil
+}
+
+// Stop signals the channel status manager to exit, halting any pending
+// status changes.
+func (m *Manager) Stop() error {
+	if !atomic.CompareAndSwapUint32(&m.stopped, 0, 1) {
+		return nil
+	}
+
+	log.Infof("Channel status manager shutting down")
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	close(m.quit)
+	for _, state := range m.peers {
+		if state.timer != nil {
+			state.timer.Stop()
+		}
+	}
+
+	return nil
+}
+
+// PeerOnline notifies the manager that the passed peer has connected. Its
+// automatically managed channels are re-enabled if it stays online for the
+// enable timeout.
+func (m *Manager) PeerOnline(pubKey *btcec.PublicKey) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.setPeerState(pubKey, true, m.cfg.EnableTimeout)
+}
+
+// PeerOffline notifies the manager that the passed peer has disconnected. Its
+// automatically managed channels are disabled if it stays offline for the
+// disable timeout.
+func (m *Manager) PeerOffline(pubKey *btcec.PublicKey) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.setPeerState(pubKey, false, m.cfg.DisableTimeout)
+}
+
+// setPeerState records the connectivity of the peer, and schedules its
+// channels to follow once it has been in that state for the passed timeout.
+// A pending change in the opposite direction is cancelled.
+//
+// NOTE: The mtx MUST be held when calling this method.
+func (m *Manager) setPeerState(pubKey *btcec.PublicKey, online bool,
+	timeout time.Duration) {
+
+	key := string(pubKey.SerializeCompressed())
+	state, ok := m.peers[key]
+	switch {
+	case !ok:
+		state = &peerState{pubKey: pubKey}
+		m.peers[key] = state
+
+	case state.online == online:
+		return
+	}
+
+	state.online = online
+	if state.timer != nil {
+		state.timer.Stop()
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(timeout, func() {
+		m.mtx.Lock()
+		defer m.mtx.Unlock()
+
+		// The timer may have been replaced, or the manager stopped,
+		// while we were waiting for the mutex.
+		select {
+		case <-m.quit:
+			return
+		default:
+		}
+		if state.timer != timer {
+			return
+		}
+
+		state.timer = nil
+		m.applyPeerState(state)
+	})
+	state.timer = timer
+}
+
+// applyPeerState disables the automatically managed channels of the peer if
+// it's offline, or enables them if it's online.
+//
+// NOTE: The mtx MUST be held when calling this method.
+func (m *Manager) applyPeerState(state *peerState) {
+	chans, err := m.cfg.FetchChannels(state.pubKey)
+	if err != nil {
+		log.Errorf("Unable to fetch channels of peer %x: %v",
+			state.pubKey.SerializeCompressed(), err)
+		return
+	}
+
+	disabled := !state.online
+	for _, c := range chans {
+		if _, ok := m.manual[c.ChanPoint]; ok {
+			continue
+		}
+		if c.Disabled == disabled {
+			continue
+		}
+
+		log.Infof("Setting ChannelPoint(%v) disabled=%v, as peer %x "+
+			"is online=%v", c.ChanPoint, disabled,
+			state.pubKey.SerializeCompressed(), state.online)
+
+		if err := m.cfg.SetDisabled(c.ChanPoint, disabled); err != nil {
+			log.Errorf("Unable to update status of "+
+				"ChannelPoint(%v): %v", c.ChanPoint, err)
+		}
+	}
+}
+
+// SetStatus applies the passed action to the channel with the passed funding
+// outpoint. Manually set statuses are persisted, so they outlive restarts. A
+// channel returned to automatic management immediately follows the state of
+// its peer, unless a change is already pending.
+func (m *Manager) SetStatus(chanPoint wire.OutPoint, action Action) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	log.Infof("Applying action %v to ChannelPoint(%v)", action, chanPoint)
+
+	switch action {
+	case ActionEnable, ActionDisable:
+
+	case ActionAuto:
+		if err := m.cfg.ClearManual(chanPoint); err != nil {
+			return err
+		}
+		delete(m.manual, chanPoint)
+
+		for _, state := range m.peers {
+			if state.timer == nil {
+				m.applyPeerState(state)
+			}
+		}
+
+		return nil
+
+	default:
+		return fmt.Errorf("unknown channel status action: %v", action)
+	}
+
+	disabled := action == ActionDisable
+	if err := m.cfg.SetDisabled(chanPoint, disabled); err != nil {
+		return err
+	}
+	if err := m.cfg.SetManual(chanPoint, disabled); err != nil {
+		return err
+	}
+	m.manual[chanPoint] = disabled
+
+	return nil
+}
@@ -0,0 +1,217 @@
+package chanstatus
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// mockChannels is a set of channels with a single peer, whose status is
+// updated by the manager.
+type mockChannels struct {
+	sync.Mutex
+	disabled map[wire.OutPoint]bool
+	manual   map[wire.OutPoint]bool
+}
+
+func (m *mockChannels) fetchChannels(*btcec.PublicKey) ([]*ChannelStatus, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	var chans []*ChannelStatus
+	for chanPoint, disabled := range m.disabled {
+		chans = append(chans, &ChannelStatus{
+			ChanPoint: chanPoint,
+			Disabled:  disabled,
+		})
+	}
+	return chans, nil
+}
+
+func (m *mockChannels) setDisabled(chanPoint wire.OutPoint, disabled bool) error {
+	m.Lock()
+	defer m.Unlock()
+
+	m.disabled[chanPoint] = disabled
+	return nil
+}
+
+func (m *mockChannels) isDisabled(chanPoint wire.OutPoint) bool {
+	m.Lock()
+	defer m.Unlock()
+
+	return m.disabled[chanPoint]
+}
+
+func (m *mockChannels) fetchManual() (map[wire.OutPoint]bool, error) {
+	manual := make(map[wire.OutPoint]bool)
+	for chanPoint, disabled := range m.manual {
+		manual[chanPoint] = disabled
+	}
+	return manual, nil
+}
+
+func (m *mockChannels) setManual(chanPoint wire.OutPoint, disabled bool) error {
+	m.manual[chanPoint] = disabled
+	return nil
+}
+
+func (m *mockChannels) clearManual(chanPoint wire.OutPoint) error {
+	delete(m.manual, chanPoint)
+	return nil
+}
+
+// assertDisabled waits for the channel to reach the expected status.
+func assertDisabled(t *testing.T, channels *mockChannels,
+	chanPoint wire.OutPoint, disabled bool) {
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if channels.isDisabled(chanPoint) == disabled {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatalf("expected ChannelPoint(%v) disabled=%v", chanPoint, disabled)
+}
+
+// TestChanStatusManager tests that channels are disabled and re-enabled as
+// their peer goes offline and comes back online, unless their status was set
+// manually.
+func TestChanStatusManager(t *testing.T) {
+	t.Parallel()
+
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	peer := priv.PubKey()
+
+	autoPoint := wire.OutPoint{Index: 1}
+	manualPoint := wire.OutPoint{Index: 2}
+	channels := &mockChannels{
+		disabled: map[wire.OutPoint]bool{
+			autoPoint:   false,
+			manualPoint: false,
+		},
+		manual: make(map[wire.OutPoint]bool),
+	}
+
+	const timeout = 50 * time.Millisecond
+	m := New(&Config{
+		DisableTimeout: timeout,
+		EnableTimeout:  timeout,
+		FetchPeers: func() ([]*btcec.PublicKey, error) {
+			return []*btcec.PublicKey{peer}, nil
+		},
+		FetchChannels: channels.fetchChannels,
+		SetDisabled:   channels.setDisabled,
+		FetchManual:   channels.fetchManual,
+		SetManual:     channels.setManual,
+		ClearManual:   channels.clearManual,
+	})
+	if err := m.Start(); err != nil {
+		t.Fatalf("unable to start manager: %v", err)
+	}
+	defer m.Stop()
+
+	// The peer connects before the disable timeout, so no channel should
+	// be disabled.
+	m.PeerOnline(peer)
+	time.Sleep(2 * timeout)
+	assertDisabled(t, channels, autoPoint, false)
+
+	// Manually enabling the second channel should keep it enabled once
+	// the peer goes offline, while the first is disabled.
+	if err := m.SetStatus(manualPoint, ActionEnable); err != nil {
+		t.Fatalf("unable to set status: %v", err)
+	}
+	m.PeerOffline(peer)
+	assertDisabled(t, channels, autoPoint, true)
+	assertDisabled(t, channels, manualPoint, false)
+
+	// Returning the second channel to automatic management should disable
+	// it right away, as its peer is offline.
+	if err := m.SetStatus(manualPoint, ActionAuto); err != nil {
+		t.Fatalf("unable to set status: %v", err)
+	}
+	assertDisabled(t, channels, manualPoint, true)
+
+	// A peer which reconnects only briefly shouldn't have its channels
+	// re-enabled.
+	m.PeerOnline(peer)
+	m.PeerOffline(peer)
+	time.Sleep(2 * timeout)
+	assertDisabled(t, channels, autoPoint, true)
+
+	// Once the peer stays online, both channels are re-enabled, unless
+	// disabled manually.
+	if err := m.SetStatus(manualPoint, ActionDisable); err != nil {
+		t.Fatalf("unable to set status: %v", err)
+	}
+	m.PeerOnline(peer)
+	assertDisabled(t, channels, autoPoint, false)
+	assertDisabled(t, channels, manualPoint, true)
+	if disabled, ok := channels.manual[manualPoint]; !ok || !disabled {
+		t.Fatalf("expected manual status to be persisted")
+	}
+}
+
+// TestChanStatusManagerExpiredManual tests that the manually set status of a
+// channel which is no longer open is removed once the manager starts.
+func TestChanStatusManagerExpiredManual(t *testing.T) {
+	t.Parallel()
+
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	peer := priv.PubKey()
+
+	openPoint := wire.OutPoint{Index: 1}
+	closedPoint := wire.OutPoint{Index: 2}
+	channels := &mockChannels{
+		disabled: map[wire.OutPoint]bool{
+			openPoint: true,
+		},
+		manual: map[wire.OutPoint]bool{
+			openPoint:   true,
+			closedPoint: true,
+		},
+	}
+
+	m := New(&Config{
+		FetchPeers: func() ([]*btcec.PublicKey, error) {
+			return []*btcec.PublicKey{peer}, nil
+		},
+		FetchChannels: channels.fetchChannels,
+		SetDisabled:   channels.setDisabled,
+		FetchManual:   channels.fetchManual,
+		SetManual:     channels.setManual,
+		ClearManual:   channels.clearManual,
+	})
+	if err := m.Start(); err != nil {
+		t.Fatalf("unable to start manager: %v", err)
+	}
+	defer m.Stop()
+
+	if _, ok := channels.manual[closedPoint]; ok {
+		t.Fatalf("expected manual status of closed channel to be " +
+			"removed")
+	}
+	if _, ok := channels.manual[openPoint]; !ok {
+		t.Fatalf("expected manual status of open channel to be kept")
+	}
+
+	m.mtx.Lock()
+	_, ok := m.manual[closedPoint]
+	m.mtx.Unlock()
+	if ok {
+		t.Fatalf("expected manager to forget manual status of " +
+			"closed channel")
+	}
+}
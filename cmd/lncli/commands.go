@@ -1,16 +1,25 @@
 package main
 
 import (
+	"bufio"
 	"bytes"
+	"encoding/base64"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"strconv"
 	"strings"
 
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
 	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/zpay32"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcutil"
 	"github.com/urfave/cli"
 	"golang.org/x/net/context"
 )
@@ -18,22 +27,62 @@ import (
 // TODO(roasbeef): cli logic for supporting both positional and unix style
 // arguments.
 
+// printRespJson prints the passed response as indented JSON. Responses of
+// the RPC server are printed with all their fields, including those set to
+// their zero value, named as within the proto definitions, so the output is
+// stable for scripts to parse.
 func printRespJson(resp interface{}) {
+	if msg, ok := resp.(proto.Message); ok {
+		marshaler := &jsonpb.Marshaler{
+			EmitDefaults: true,
+			OrigName:     true,
+			Indent:       "\t",
+		}
+		if err := marshaler.Marshal(os.Stdout, msg); err != nil {
+			fatal(err)
+		}
+		fmt.Println()
+		return
+	}
+
 	b, err := json.Marshal(resp)
 	if err != nil {
 		fatal(err)
 	}
 
-	// TODO(roasbeef): disable 'omitempty' like behavior
-
 	var out bytes.Buffer
 	json.Indent(&out, b, "", "\t")
+	out.WriteString("\n")
 	out.WriteTo(os.Stdout)
 }
 
+// readArg returns the passed argument, or flag value, unless it's "-", in
+// which case the value is read from stdin instead, allowing large or
+// sensitive values to be piped in by scripts. Surrounding whitespace, such as
+// a trailing newline, is trimmed from the value read.
+func readArg(arg string) (string, error) {
+	if arg != "-" {
+		return arg, nil
+	}
+
+	b, err := ioutil.ReadAll(os.Stdin)
+	if err != nil {
+		return "", fmt.Errorf("unable to read from stdin: %v", err)
+	}
+
+	return strings.TrimSpace(string(b)), nil
+}
+
 var NewAddressCommand = cli.Command{
-	Name:   "newaddress",
-	Usage:  "generates a new address. Three address types are supported: p2wkh, np2wkh, p2pkh",
+	Name:  "newaddress",
+	Usage: "generates a new address. Three address types are supported: p2wkh (BIP84), np2wkh (BIP49), p2pkh",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "account",
+			Usage: "the name of the imported account to derive " +
+				"the address from, only p2wkh is supported",
+		},
+	},
 	Action: newAddress,
 }
 
@@ -58,6 +107,19 @@ func newAddress(ctx *cli.Context) error {
 	}
 
 	ctxb := context.Background()
+	if ctx.String("account") != "" {
+		addr, err := client.NextAddr(ctxb, &lnrpc.AddrRequest{
+			Type:    addrType,
+			Account: ctx.String("account"),
+		})
+		if err != nil {
+			return err
+		}
+
+		printRespJson(addr)
+		return nil
+	}
+
 	addr, err := client.NewAddress(ctxb, &lnrpc.NewAddressRequest{
 		Type: addrType,
 	})
@@ -72,7 +134,8 @@ func newAddress(ctx *cli.Context) error {
 var SendCoinsCommand = cli.Command{
 	Name:        "sendcoins",
 	Description: "send a specified amount of bitcoin to the passed address",
-	Usage:       "sendcoins --addr=<bitcoin addresss> --amt=<num coins in satoshis>",
+	Usage: "sendcoins --addr=<bitcoin addresss> " +
+		"[--amt=<num coins in satoshis> | --sweepall]",
 	Flags: []cli.Flag{
 		cli.StringFlag{
 			Name:  "addr",
@@ -83,6 +146,43 @@ var SendCoinsCommand = cli.Command{
 			Name:  "amt",
 			Usage: "the number of bitcoin denominated in satoshis to send",
 		},
+		cli.BoolFlag{
+			Name: "sweepall",
+			Usage: "send all the eligible coins of the wallet to the " +
+				"address, minus the fee",
+		},
+		cli.IntFlag{
+			Name: "conf_target",
+			Usage: "the number of blocks the transaction should " +
+				"confirm within",
+		},
+		cli.IntFlag{
+			Name:  "sat_per_byte",
+			Usage: "an explicit fee rate in satoshis per byte",
+		},
+		cli.StringFlag{
+			Name:  "label",
+			Usage: "a label to attach to the transaction",
+		},
+		cli.IntFlag{
+			Name: "min_confs",
+			Usage: "the minimum number of confirmations the coins " +
+				"funding the transaction must have",
+		},
+		cli.BoolFlag{
+			Name:  "unconfirmed",
+			Usage: "allow unconfirmed coins to fund the transaction",
+		},
+		cli.StringFlag{
+			Name: "coin_selection_strategy",
+			Usage: "the strategy used to select the coins funding " +
+				"the transaction: largest, random or smallest",
+		},
+		cli.StringSliceFlag{
+			Name: "utxo",
+			Usage: "an outpoint of the form txid:index to spend, " +
+				"may be specified multiple times",
+		},
 	},
 	Action: sendCoins,
 }
@@ -91,9 +191,22 @@ func sendCoins(ctx *cli.Context) error {
 	ctxb := context.Background()
 	client := getClient(ctx)
 
+	strategy, outpoints, err := parseCoinSelectionArgs(ctx)
+	if err != nil {
+		return err
+	}
+
 	req := &lnrpc.SendCoinsRequest{
-		Addr:   ctx.String("addr"),
-		Amount: int64(ctx.Int("amt")),
+		Addr:                  ctx.String("addr"),
+		Amount:                int64(ctx.Int("amt")),
+		Outpoints:             outpoints,
+		CoinSelectionStrategy: strategy,
+		TargetConf:            uint32(ctx.Int("conf_target")),
+		SatPerByte:            int64(ctx.Int("sat_per_byte")),
+		SendAll:               ctx.Bool("sweepall"),
+		Label:                 ctx.String("label"),
+		MinConfs:              int32(ctx.Int("min_confs")),
+		SpendUnconfirmed:      ctx.Bool("unconfirmed"),
 	}
 	txid, err := client.SendCoins(ctxb, req)
 	if err != nil {
@@ -108,14 +221,41 @@ var SendManyCommand = cli.Command{
 	Name: "sendmany",
 	Description: "create and broadcast a transaction paying the specified " +
 		"amount(s) to the passed address(es)",
-	Usage:  `sendmany '{"ExampleAddr": NumCoinsInSatoshis, "SecondAddr": NumCoins}'`,
+	Usage: `sendmany '{"ExampleAddr": NumCoinsInSatoshis, "SecondAddr": NumCoins}'`,
+	Flags: []cli.Flag{
+		cli.IntFlag{
+			Name: "conf_target",
+			Usage: "the number of blocks the transaction should " +
+				"confirm within",
+		},
+		cli.IntFlag{
+			Name:  "sat_per_byte",
+			Usage: "an explicit fee rate in satoshis per byte",
+		},
+		cli.StringFlag{
+			Name:  "label",
+			Usage: "a label to attach to the transaction",
+		},
+		cli.IntFlag{
+			Name: "min_confs",
+			Usage: "the minimum number of confirmations the coins " +
+				"funding the transaction must have",
+		},
+		cli.BoolFlag{
+			Name:  "unconfirmed",
+			Usage: "allow unconfirmed coins to fund the transaction",
+		},
+	},
 	Action: sendMany,
 }
 
 func sendMany(ctx *cli.Context) error {
 	var amountToAddr map[string]int64
 
-	jsonMap := ctx.Args().Get(0)
+	jsonMap, err := readArg(ctx.Args().Get(0))
+	if err != nil {
+		return err
+	}
 	if err := json.Unmarshal([]byte(jsonMap), &amountToAddr); err != nil {
 		return err
 	}
@@ -123,7 +263,14 @@ func sendMany(ctx *cli.Context) error {
 	ctxb := context.Background()
 	client := getClient(ctx)
 
-	txid, err := client.SendMany(ctxb, &lnrpc.SendManyRequest{amountToAddr})
+	txid, err := client.SendMany(ctxb, &lnrpc.SendManyRequest{
+		AddrToAmount:     amountToAddr,
+		TargetConf:       uint32(ctx.Int("conf_target")),
+		SatPerByte:       int64(ctx.Int("sat_per_byte")),
+		Label:            ctx.String("label"),
+		MinConfs:         int32(ctx.Int("min_confs")),
+		SpendUnconfirmed: ctx.Bool("unconfirmed"),
+	})
 	if err != nil {
 		return err
 	}
@@ -134,7 +281,9 @@ func sendMany(ctx *cli.Context) error {
 
 var ConnectCommand = cli.Command{
 	Name:  "connect",
-	Usage: "connect to a remote lnd peer: <pubkey>@host (--perm=true|false])",
+	Usage: "connect to a remote lnd peer: <pubkey>[@host] (--perm=true|false])",
+	Description: "If the host is omitted, then the address the peer has " +
+		"advertised within the channel graph is used.",
 	Flags: []cli.Flag{
 		cli.BoolFlag{
 			Name: "perm",
@@ -152,14 +301,16 @@ func connectPeer(ctx *cli.Context) error {
 
 	targetAddress := ctx.Args().Get(0)
 	splitAddr := strings.Split(targetAddress, "@")
-	if len(splitAddr) != 2 {
+	if len(splitAddr) > 2 || splitAddr[0] == "" {
 		return fmt.Errorf("target address expected in format: " +
-			"pubkey@host:port")
+			"pubkey[@host:port]")
 	}
 
 	addr := &lnrpc.LightningAddress{
 		Pubkey: splitAddr[0],
-		Host:   splitAddr[1],
+	}
+	if len(splitAddr) == 2 {
+		addr.Host = splitAddr[1]
 	}
 	req := &lnrpc.ConnectPeerRequest{
 		Addr: addr,
@@ -212,6 +363,42 @@ var OpenChannelCommand = cli.Command{
 			Name:  "block",
 			Usage: "block and wait until the channel is fully open",
 		},
+		cli.StringFlag{
+			Name: "coin_selection_strategy",
+			Usage: "the strategy used to select the coins funding " +
+				"the transaction: largest, random or smallest",
+		},
+		cli.StringSliceFlag{
+			Name: "utxo",
+			Usage: "an outpoint of the form txid:index to spend, " +
+				"may be specified multiple times",
+		},
+		cli.IntFlag{
+			Name: "remote_reserve",
+			Usage: "the number of satoshis the remote party must " +
+				"keep as its balance within the channel",
+		},
+		cli.IntFlag{
+			Name: "remote_max_value_in_flight",
+			Usage: "the maximum total value in satoshis of the " +
+				"pending htlcs the remote party may offer",
+		},
+		cli.IntFlag{
+			Name: "min_htlc",
+			Usage: "the smallest htlc in satoshis the remote " +
+				"party may offer",
+		},
+		cli.IntFlag{
+			Name: "remote_max_htlcs",
+			Usage: "the maximum number of pending htlcs the " +
+				"remote party may offer",
+		},
+		cli.StringFlag{
+			Name: "close_address",
+			Usage: "(optional) the address our funds are paid to " +
+				"upon a cooperative close, committed to " +
+				"during the funding workflow",
+		},
 	},
 	Action: openChannel,
 }
@@ -226,10 +413,23 @@ func openChannel(ctx *cli.Context) error {
 			"at the same time, only one can be specified")
 	}
 
+	strategy, outpoints, err := parseCoinSelectionArgs(ctx)
+	if err != nil {
+		return err
+	}
+
 	req := &lnrpc.OpenChannelRequest{
-		LocalFundingAmount: int64(ctx.Int("local_amt")),
-		PushSat:            int64(ctx.Int("push_amt")),
-		NumConfs:           uint32(ctx.Int("num_confs")),
+		LocalFundingAmount:    int64(ctx.Int("local_amt")),
+		PushSat:               int64(ctx.Int("push_amt")),
+		NumConfs:              uint32(ctx.Int("num_confs")),
+		Outpoints:             outpoints,
+		CoinSelectionStrategy: strategy,
+
+		RemoteChanReserveSat:      int64(ctx.Int("remote_reserve")),
+		RemoteMaxValueInFlightSat: int64(ctx.Int("remote_max_value_in_flight")),
+		MinHtlcSat:                int64(ctx.Int("min_htlc")),
+		RemoteMaxHtlcs:            uint32(ctx.Int("remote_max_htlcs")),
+		CloseAddress:              ctx.String("close_address"),
 	}
 
 	if ctx.Int("peer_id") != 0 {
@@ -284,7 +484,8 @@ func openChannel(ctx *cli.Context) error {
 var CloseChannelCommand = cli.Command{
 	Name: "closechannel",
 	Description: "Close an existing channel. The channel can be closed either " +
-		"cooperatively, or uncooperatively (forced).",
+		"cooperatively, or uncooperatively (forced). Channels whose " +
+		"peer is offline can only be force closed.",
 	Usage: "closechannel funding_txid output_index time_limit allow_force",
 	Flags: []cli.Flag{
 		cli.StringFlag{
@@ -296,6 +497,16 @@ var CloseChannelCommand = cli.Command{
 			Usage: "the output index for the funding output of the funding " +
 				"transaction",
 		},
+		cli.IntFlag{
+			Name: "chan_id",
+			Usage: "the short channel ID of the channel, which may be " +
+				"set in place of the funding outpoint",
+		},
+		cli.StringFlag{
+			Name: "delivery_address",
+			Usage: "(optional) the address a force closed channel's " +
+				"funds should be swept to, instead of the wallet",
+		},
 		cli.StringFlag{
 			Name: "time_limit",
 			Usage: "a relative deadline afterwhich the attempt should be " +
@@ -318,18 +529,25 @@ func closeChannel(ctx *cli.Context) error {
 	ctxb := context.Background()
 	client := getClient(ctx)
 
-	txid, err := chainhash.NewHashFromStr(ctx.String("funding_txid"))
-	if err != nil {
-		return err
-	}
-
 	// TODO(roasbeef): implement time deadline within server
 	req := &lnrpc.CloseChannelRequest{
-		ChannelPoint: &lnrpc.ChannelPoint{
+		ChanId:          uint64(ctx.Int("chan_id")),
+		Force:           ctx.Bool("force"),
+		DeliveryAddress: ctx.String("delivery_address"),
+	}
+
+	// The channel is identified by its funding outpoint, unless its short
+	// channel ID was specified instead.
+	if ctx.String("funding_txid") != "" || req.ChanId == 0 {
+		txid, err := chainhash.NewHashFromStr(ctx.String("funding_txid"))
+		if err != nil {
+			return err
+		}
+
+		req.ChannelPoint = &lnrpc.ChannelPoint{
 			FundingTxid: txid[:],
 			OutputIndex: uint32(ctx.Int("output_index")),
-		},
-		Force: ctx.Bool("force"),
+		}
 	}
 
 	stream, err := client.CloseChannel(ctxb, req)
@@ -369,6 +587,58 @@ func closeChannel(ctx *cli.Context) error {
 	return nil
 }
 
+var AbandonChannelCommand = cli.Command{
+	Name: "abandonchannel",
+	Description: "Remove a channel from the database without taking " +
+		"any action on-chain, for recovering from channels which " +
+		"are stuck. Any funds within the channel are forfeited unless " +
+		"recovered by other means.",
+	Usage: "abandonchannel funding_txid output_index",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "funding_txid",
+			Usage: "the txid of the channel's funding transaction",
+		},
+		cli.IntFlag{
+			Name: "output_index",
+			Usage: "the output index for the funding output of the funding " +
+				"transaction",
+		},
+		cli.BoolFlag{
+			Name: "i_know_what_i_am_doing",
+			Usage: "acknowledge that abandoning the channel may " +
+				"forfeit its funds, required outside of dev builds",
+		},
+	},
+	Action: abandonChannel,
+}
+
+func abandonChannel(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	txid, err := chainhash.NewHashFromStr(ctx.String("funding_txid"))
+	if err != nil {
+		return err
+	}
+
+	req := &lnrpc.AbandonChannelRequest{
+		ChannelPoint: &lnrpc.ChannelPoint{
+			FundingTxid: txid[:],
+			OutputIndex: uint32(ctx.Int("output_index")),
+		},
+		IKnowWhatIAmDoing: ctx.Bool("i_know_what_i_am_doing"),
+	}
+
+	resp, err := client.AbandonChannel(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
 var ListPeersCommand = cli.Command{
 	Name:        "listpeers",
 	Description: "List all active, currently connected peers.",
@@ -389,6 +659,111 @@ func listPeers(ctx *cli.Context) error {
 	return nil
 }
 
+var SubscribePeerEventsCommand = cli.Command{
+	Name:        "subscribepeerevents",
+	Usage:       "subscribepeerevents",
+	Description: "print each peer coming online or going offline, starting with all currently connected peers",
+	Action:      subscribePeerEvents,
+}
+
+func subscribePeerEvents(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	stream, err := client.SubscribePeerEvents(ctxb,
+		&lnrpc.PeerEventSubscription{})
+	if err != nil {
+		return err
+	}
+
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		printRespJson(event)
+	}
+}
+
+var UpdatePeerAccessCommand = cli.Command{
+	Name: "updatepeeraccess",
+	Usage: "updatepeeraccess --list=banned|allowed --peer=<pubkey|ip> " +
+		"[--remove]",
+	Description: "Adds a peer, identified by its public key or by its IP " +
+		"address, to the list of banned peers or the allowlist, or " +
+		"removes it with --remove. Connections and channels from " +
+		"banned peers are refused, as are those from peers which " +
+		"aren't allowlisted when running with --allowlistonly. Any " +
+		"peer whose connection would now be refused is disconnected.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "list",
+			Usage: "the list to update: banned or allowed",
+		},
+		cli.StringFlag{
+			Name: "peer",
+			Usage: "the hex encoded public key, or the IP address, " +
+				"of the peer",
+		},
+		cli.BoolFlag{
+			Name:  "remove",
+			Usage: "remove the peer from the list, rather than add it",
+		},
+	},
+	Action: updatePeerAccess,
+}
+
+func updatePeerAccess(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	var list lnrpc.PeerAccessList
+	switch ctx.String("list") {
+	case "banned":
+		list = lnrpc.PeerAccessList_BANNED
+	case "allowed":
+		list = lnrpc.PeerAccessList_ALLOWED
+	default:
+		return fmt.Errorf("list must be one of banned or allowed")
+	}
+
+	req := &lnrpc.UpdatePeerAccessRequest{
+		List:   list,
+		Peer:   ctx.String("peer"),
+		Remove: ctx.Bool("remove"),
+	}
+
+	resp, err := client.UpdatePeerAccess(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var ListPeerAccessCommand = cli.Command{
+	Name:        "listpeeraccess",
+	Description: "List the banned peers and the allowlist.",
+	Action:      listPeerAccess,
+}
+
+func listPeerAccess(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	resp, err := client.ListPeerAccess(ctxb, &lnrpc.ListPeerAccessRequest{})
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
 var WalletBalanceCommand = cli.Command{
 	Name:        "walletbalance",
 	Description: "compute and display the wallet's current balance",
@@ -439,6 +814,52 @@ func channelBalance(ctx *cli.Context) error {
 	return nil
 }
 
+var GetChanBalanceCommand = cli.Command{
+	Name: "getchanbalance",
+	Description: "break down the balance of a single channel, returning " +
+		"the amounts which can actually be sent and received over " +
+		"it once the channel reserve, the pending HTLCs, and the " +
+		"commitment fee are accounted for",
+	Usage: "getchanbalance funding_txid output_index",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "funding_txid",
+			Usage: "the txid of the channel's funding transaction",
+		},
+		cli.IntFlag{
+			Name: "output_index",
+			Usage: "the output index for the funding output of the funding " +
+				"transaction",
+		},
+	},
+	Action: getChanBalance,
+}
+
+func getChanBalance(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	txid, err := chainhash.NewHashFromStr(ctx.String("funding_txid"))
+	if err != nil {
+		return err
+	}
+
+	req := &lnrpc.GetChannelBalanceRequest{
+		ChannelPoint: &lnrpc.ChannelPoint{
+			FundingTxid: txid[:],
+			OutputIndex: uint32(ctx.Int("output_index")),
+		},
+	}
+
+	resp, err := client.GetChannelBalance(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
 var GetInfoCommand = cli.Command{
 	Name:        "getinfo",
 	Description: "returns basic information related to the active daemon",
@@ -459,45 +880,136 @@ func getInfo(ctx *cli.Context) error {
 	return nil
 }
 
-var PendingChannelsCommand = cli.Command{
-	Name:        "pendingchannels",
-	Description: "display information pertaining to pending channels",
-	Usage:       "pendingchannels --status=[all|opening|closing]",
-	Flags: []cli.Flag{
-		cli.BoolFlag{
-			Name:  "open, o",
-			Usage: "display the status of new pending channels",
-		},
-		cli.BoolFlag{
-			Name:  "close, c",
-			Usage: "display the status of channels being closed",
-		},
-		cli.BoolFlag{
-			Name: "all, a",
-			Usage: "display the status of channels in the " +
-				"process of being opened or closed",
-		},
-	},
-	Action: pendingChannels,
+var SetAliasCommand = cli.Command{
+	Name:        "setalias",
+	Usage:       "setalias <alias>",
+	Description: "set the alias advertised within our node announcement",
+	Action:      setAlias,
 }
 
-func pendingChannels(ctx *cli.Context) error {
+func setAlias(ctx *cli.Context) error {
 	ctxb := context.Background()
 	client := getClient(ctx)
 
-	var channelStatus lnrpc.ChannelStatus
-	switch {
-	case ctx.Bool("all"):
-		channelStatus = lnrpc.ChannelStatus_ALL
-	case ctx.Bool("open"):
-		channelStatus = lnrpc.ChannelStatus_OPENING
-	case ctx.Bool("close"):
-		channelStatus = lnrpc.ChannelStatus_CLOSING
-	default:
-		channelStatus = lnrpc.ChannelStatus_ALL
+	if len(ctx.Args()) != 1 {
+		return fmt.Errorf("an alias must be specified")
 	}
 
-	req := &lnrpc.PendingChannelRequest{channelStatus}
+	resp, err := client.SetAlias(ctxb, &lnrpc.SetAliasRequest{
+		NewAlias: ctx.Args().First(),
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var UpdateNodeAnnouncementCommand = cli.Command{
+	Name:  "updatenodeannouncement",
+	Usage: "updatenodeannouncement --alias=[alias] --color=[#rrggbb] --address=[host:port] --add_feature=[bit] --remove_feature=[bit]",
+	Description: "update the alias, color, address, or advertised " +
+		"features of the node, re-broadcasting its node announcement",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "alias",
+			Usage: "the new alias of the node",
+		},
+		cli.StringFlag{
+			Name:  "color",
+			Usage: "the new color of the node, in the hex format #rrggbb",
+		},
+		cli.StringFlag{
+			Name:  "address",
+			Usage: "the new host:port address the node is reachable at",
+		},
+		cli.IntSliceFlag{
+			Name: "add_feature",
+			Usage: "a feature bit to advertise, may be specified " +
+				"multiple times",
+		},
+		cli.IntSliceFlag{
+			Name: "remove_feature",
+			Usage: "a feature bit to no longer advertise, may be " +
+				"specified multiple times",
+		},
+	},
+	Action: updateNodeAnnouncement,
+}
+
+func updateNodeAnnouncement(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	req := &lnrpc.NodeAnnouncementUpdateRequest{
+		Alias:   ctx.String("alias"),
+		Color:   ctx.String("color"),
+		Address: ctx.String("address"),
+	}
+	for _, bit := range ctx.IntSlice("add_feature") {
+		req.FeatureUpdates = append(req.FeatureUpdates,
+			&lnrpc.UpdateFeatureAction{
+				Action:     lnrpc.UpdateFeatureAction_ADD,
+				FeatureBit: uint32(bit),
+			})
+	}
+	for _, bit := range ctx.IntSlice("remove_feature") {
+		req.FeatureUpdates = append(req.FeatureUpdates,
+			&lnrpc.UpdateFeatureAction{
+				Action:     lnrpc.UpdateFeatureAction_REMOVE,
+				FeatureBit: uint32(bit),
+			})
+	}
+
+	resp, err := client.UpdateNodeAnnouncement(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var PendingChannelsCommand = cli.Command{
+	Name:        "pendingchannels",
+	Description: "display information pertaining to pending channels",
+	Usage:       "pendingchannels --status=[all|opening|closing]",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "open, o",
+			Usage: "display the status of new pending channels",
+		},
+		cli.BoolFlag{
+			Name:  "close, c",
+			Usage: "display the status of channels being closed",
+		},
+		cli.BoolFlag{
+			Name: "all, a",
+			Usage: "display the status of channels in the " +
+				"process of being opened or closed",
+		},
+	},
+	Action: pendingChannels,
+}
+
+func pendingChannels(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	var channelStatus lnrpc.ChannelStatus
+	switch {
+	case ctx.Bool("all"):
+		channelStatus = lnrpc.ChannelStatus_ALL
+	case ctx.Bool("open"):
+		channelStatus = lnrpc.ChannelStatus_OPENING
+	case ctx.Bool("close"):
+		channelStatus = lnrpc.ChannelStatus_CLOSING
+	default:
+		channelStatus = lnrpc.ChannelStatus_ALL
+	}
+
+	req := &lnrpc.PendingChannelRequest{channelStatus}
 	resp, err := client.PendingChannels(ctxb, req)
 	if err != nil {
 		return err
@@ -511,12 +1023,24 @@ func pendingChannels(ctx *cli.Context) error {
 var ListChannelsCommand = cli.Command{
 	Name:        "listchannels",
 	Description: "list all open channels",
-	Usage:       "listchannels --active_only",
+	Usage:       "listchannels --active_only --public_only",
 	Flags: []cli.Flag{
 		cli.BoolFlag{
 			Name:  "active_only, a",
 			Usage: "only list channels which are currently active",
 		},
+		cli.BoolFlag{
+			Name:  "inactive_only, i",
+			Usage: "only list channels which are currently inactive",
+		},
+		cli.BoolFlag{
+			Name:  "public_only",
+			Usage: "only list channels which are part of the public graph",
+		},
+		cli.BoolFlag{
+			Name:  "private_only",
+			Usage: "only list channels which aren't part of the public graph",
+		},
 	},
 	Action: listChannels,
 }
@@ -525,7 +1049,12 @@ func listChannels(ctx *cli.Context) error {
 	ctxb := context.Background()
 	client := getClient(ctx)
 
-	req := &lnrpc.ListChannelsRequest{}
+	req := &lnrpc.ListChannelsRequest{
+		ActiveOnly:   ctx.Bool("active_only"),
+		InactiveOnly: ctx.Bool("inactive_only"),
+		PublicOnly:   ctx.Bool("public_only"),
+		PrivateOnly:  ctx.Bool("private_only"),
+	}
 	resp, err := client.ListChannels(ctxb, req)
 	if err != nil {
 		return err
@@ -536,6 +1065,65 @@ func listChannels(ctx *cli.Context) error {
 	return nil
 }
 
+var SubscribeChannelEventsCommand = cli.Command{
+	Name:        "subscribechannelevents",
+	Usage:       "subscribechannelevents",
+	Description: "print all events concerning the node's channels as they occur",
+	Action:      subscribeChannelEvents,
+}
+
+func subscribeChannelEvents(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	stream, err := client.SubscribeChannelEvents(ctxb,
+		&lnrpc.ChannelEventSubscription{})
+	if err != nil {
+		return err
+	}
+
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		printRespJson(event)
+	}
+}
+
+var SubscribeHtlcEventsCommand = cli.Command{
+	Name:  "subscribehtlcevents",
+	Usage: "subscribehtlcevents",
+	Description: "print all HTLC's forwarded, failed, or settled by the " +
+		"node's switch as they occur",
+	Action: subscribeHtlcEvents,
+}
+
+func subscribeHtlcEvents(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	stream, err := client.SubscribeHtlcEvents(ctxb,
+		&lnrpc.SubscribeHtlcEventsRequest{})
+	if err != nil {
+		return err
+	}
+
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		printRespJson(event)
+	}
+}
+
 var SendPaymentCommand = cli.Command{
 	Name:        "sendpayment",
 	Description: "send a payment over lightning",
@@ -559,8 +1147,46 @@ var SendPaymentCommand = cli.Command{
 			Usage: "use the debug rHash when sending the HTLC",
 		},
 		cli.StringFlag{
-			Name:  "pay_req",
-			Usage: "a zbase32-check encoded payment request to fulfill",
+			Name: "pay_req",
+			Usage: "a zbase32-check encoded payment request to " +
+				"fulfill, or - to read it from stdin",
+		},
+		cli.IntFlag{
+			Name: "timeout",
+			Usage: "the number of seconds to keep retrying the " +
+				"payment for, if zero only a single attempt is " +
+				"made",
+		},
+		cli.IntFlag{
+			Name: "cltv_limit",
+			Usage: "the maximum total time lock of the route " +
+				"the payment may be sent over",
+		},
+		cli.IntFlag{
+			Name: "max_parts",
+			Usage: "the maximum number of HTLCs the payment may " +
+				"be split into, at most 1 as multi-path " +
+				"payments aren't supported",
+		},
+		cli.IntFlag{
+			Name: "fee_limit",
+			Usage: "the maximum routing fee in satoshis to pay, " +
+				"overriding the default limit of the daemon. " +
+				"--fee_limit=0 means the default limit of the " +
+				"daemon applies, rather than no fee being paid",
+		},
+		cli.IntFlag{
+			Name: "fee_limit_msat",
+			Usage: "the maximum routing fee in millisatoshis to " +
+				"pay, overriding the default limit of the " +
+				"daemon, which applies if zero",
+		},
+		cli.IntFlag{
+			Name: "fee_limit_percent",
+			Usage: "the maximum routing fee to pay as a " +
+				"percentage of the amount, overriding the " +
+				"default limit of the daemon, which applies " +
+				"if zero",
 		},
 	},
 	Action: sendPaymentCommand,
@@ -569,10 +1195,15 @@ var SendPaymentCommand = cli.Command{
 func sendPaymentCommand(ctx *cli.Context) error {
 	client := getClient(ctx)
 
+	payReq, err := readArg(ctx.String("pay_req"))
+	if err != nil {
+		return err
+	}
+
 	var req *lnrpc.SendRequest
-	if ctx.String("pay_req") != "" {
+	if payReq != "" {
 		req = &lnrpc.SendRequest{
-			PaymentRequest: ctx.String("pay_req"),
+			PaymentRequest: payReq,
 		}
 	} else {
 		destNode, err := hex.DecodeString(ctx.String("dest"))
@@ -602,6 +1233,13 @@ func sendPaymentCommand(ctx *cli.Context) error {
 		}
 	}
 
+	req.TimeoutSeconds = int32(ctx.Int("timeout"))
+	req.CltvLimit = uint32(ctx.Int("cltv_limit"))
+	req.MaxParts = uint32(ctx.Int("max_parts"))
+	req.FeeLimit = int64(ctx.Int("fee_limit"))
+	req.FeeLimitMsat = int64(ctx.Int("fee_limit_msat"))
+	req.FeeLimitPercent = int64(ctx.Int("fee_limit_percent"))
+
 	paymentStream, err := client.SendPayment(context.Background())
 	if err != nil {
 		return err
@@ -623,6 +1261,170 @@ func sendPaymentCommand(ctx *cli.Context) error {
 	return nil
 }
 
+// defaultFeeLimitPercent is the percentage of the amount of a payment which
+// payinvoice limits the routing fee of the payment to, unless a fee limit is
+// set explicitly or --force is used.
+const defaultFeeLimitPercent = 5
+
+var PayInvoiceCommand = cli.Command{
+	Name: "payinvoice",
+	Usage: "payinvoice [--fee_limit=N] [--fee_limit_msat=N] " +
+		"[--fee_limit_percent=N] [--timeout=N] [--cltv_limit=N] " +
+		"[--max_parts=N] [--force] <pay_req>",
+	Description: "decode and display the passed payment request, then " +
+		"pay it once confirmed. Unless --force is set, the routing " +
+		"fee is limited to 5% of the amount by default",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "pay_req",
+			Usage: "a zbase32-check encoded payment request to " +
+				"fulfill, or - to read it from stdin",
+		},
+		cli.IntFlag{
+			Name: "fee_limit",
+			Usage: "the maximum routing fee in satoshis to pay, " +
+				"overriding the default limit, which " +
+				"applies if zero",
+		},
+		cli.IntFlag{
+			Name: "fee_limit_msat",
+			Usage: "the maximum routing fee in millisatoshis to " +
+				"pay, overriding the default limit, which " +
+				"applies if zero",
+		},
+		cli.IntFlag{
+			Name: "fee_limit_percent",
+			Usage: "the maximum routing fee to pay as a " +
+				"percentage of the amount, overriding the " +
+				"default limit, which applies if zero",
+		},
+		cli.IntFlag{
+			Name: "timeout",
+			Usage: "the number of seconds to keep retrying the " +
+				"payment for, if zero only a single attempt is " +
+				"made",
+		},
+		cli.IntFlag{
+			Name: "cltv_limit",
+			Usage: "the maximum total time lock of the route " +
+				"the payment may be sent over",
+		},
+		cli.IntFlag{
+			Name: "max_parts",
+			Usage: "the maximum number of HTLCs the payment may " +
+				"be split into, at most 1 as multi-path " +
+				"payments aren't supported",
+		},
+		cli.BoolFlag{
+			Name: "force",
+			Usage: "pay without asking for confirmation, and " +
+				"without the default fee limit",
+		},
+	},
+	Action: payInvoice,
+}
+
+func payInvoice(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	var payReqArg string
+	switch {
+	case ctx.IsSet("pay_req"):
+		payReqArg = ctx.String("pay_req")
+	case ctx.Args().Present():
+		payReqArg = ctx.Args().First()
+	default:
+		return fmt.Errorf("pay_req argument missing")
+	}
+	encodedPayReq, err := readArg(payReqArg)
+	if err != nil {
+		return err
+	}
+
+	payReq, err := zpay32.Decode(encodedPayReq)
+	if err != nil {
+		return fmt.Errorf("unable to decode payment request: %v", err)
+	}
+
+	force := ctx.Bool("force")
+
+	// An explicit fee limit is always enforced, while the default one is
+	// skipped if forced. A limit of zero is the same as none being set.
+	// Percentage-based limits are raised to the fee limit floor of the
+	// daemon, so tiny payments can still be routed.
+	var feeLimit, feeLimitMsat, feeLimitPercent int64
+	switch {
+	case ctx.Int("fee_limit") != 0:
+		feeLimit = int64(ctx.Int("fee_limit"))
+	case ctx.Int("fee_limit_msat") != 0:
+		feeLimitMsat = int64(ctx.Int("fee_limit_msat"))
+	case ctx.Int("fee_limit_percent") != 0:
+		feeLimitPercent = int64(ctx.Int("fee_limit_percent"))
+	case !force:
+		feeLimitPercent = defaultFeeLimitPercent
+	}
+
+	fmt.Printf("Destination:  %x\n",
+		payReq.Destination.SerializeCompressed())
+	fmt.Printf("Amount:       %v\n", payReq.Amount)
+	fmt.Printf("Payment hash: %x\n", payReq.PaymentHash[:])
+	switch {
+	case feeLimit != 0:
+		fmt.Printf("Fee limit:    %v\n", btcutil.Amount(feeLimit))
+	case feeLimitMsat != 0:
+		fmt.Printf("Fee limit:    %v msat\n", feeLimitMsat)
+	case feeLimitPercent != 0:
+		fmt.Printf("Fee limit:    %v%% of the amount\n",
+			feeLimitPercent)
+	}
+
+	// The payment request format carries neither a description nor an
+	// expiry, so only the fields above can be confirmed.
+	if !force && !promptConfirmation("Send payment? (yes/no): ") {
+		return fmt.Errorf("payment not confirmed")
+	}
+
+	resp, err := client.SendPaymentSync(ctxb, &lnrpc.SendRequest{
+		PaymentRequest:  encodedPayReq,
+		FeeLimit:        feeLimit,
+		FeeLimitMsat:    feeLimitMsat,
+		FeeLimitPercent: feeLimitPercent,
+		TimeoutSeconds:  int32(ctx.Int("timeout")),
+		CltvLimit:       uint32(ctx.Int("cltv_limit")),
+		MaxParts:        uint32(ctx.Int("max_parts")),
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+// promptConfirmation prints the passed question, returning true only if the
+// user answers yes. If stdin is closed, for example as the payment request was
+// read from it, no answer can be given, so false is returned.
+func promptConfirmation(question string) bool {
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		fmt.Print(question)
+
+		answer, err := reader.ReadString('\n')
+		if err != nil {
+			fmt.Println()
+			return false
+		}
+
+		switch strings.ToLower(strings.TrimSpace(answer)) {
+		case "yes", "y":
+			return true
+		case "no", "n":
+			return false
+		}
+	}
+}
+
 var AddInvoiceCommand = cli.Command{
 	Name:        "addinvoice",
 	Description: "add a new invoice, expressing intent for a future payment",
@@ -644,6 +1446,12 @@ var AddInvoiceCommand = cli.Command{
 			Name:  "value",
 			Usage: "the value of this invoice in satoshis",
 		},
+		cli.IntFlag{
+			Name: "expiry",
+			Usage: "the time in seconds after which the invoice " +
+				"expires, and is canceled if unpaid. If unset, " +
+				"the invoice never expires",
+		},
 	},
 	Action: addInvoice,
 }
@@ -666,6 +1474,7 @@ func addInvoice(ctx *cli.Context) error {
 		Receipt:   receipt,
 		RPreimage: preimage,
 		Value:     int64(ctx.Int("value")),
+		Expiry:    int64(ctx.Int("expiry")),
 	}
 
 	resp, err := client.AddInvoice(context.Background(), invoice)
@@ -720,6 +1529,43 @@ func lookupInvoice(ctx *cli.Context) error {
 	return nil
 }
 
+var DecodePayReqCommand = cli.Command{
+	Name:        "decodepayreq",
+	Description: "decode a payment request into its destination, payment hash and amount",
+	Usage:       "decodepayreq [pay_req]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "pay_req",
+			Usage: "the encoded payment request",
+		},
+	},
+	Action: decodePayReq,
+}
+
+func decodePayReq(ctx *cli.Context) error {
+	client := getClient(ctx)
+
+	var payReq string
+	switch {
+	case ctx.IsSet("pay_req"):
+		payReq = ctx.String("pay_req")
+	case ctx.Args().Present():
+		payReq = ctx.Args().First()
+	default:
+		return fmt.Errorf("pay_req argument missing")
+	}
+
+	resp, err := client.DecodePayReq(context.Background(),
+		&lnrpc.PayReqString{PayReq: payReq})
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+
+	return nil
+}
+
 var ListInvoicesCommand = cli.Command{
 	Name:        "listinvoices",
 	Usage:       "listinvoice --pending_only=[true|false]",
@@ -756,6 +1602,35 @@ func listInvoices(ctx *cli.Context) error {
 	return nil
 }
 
+var SubscribeInvoicesCommand = cli.Command{
+	Name:        "subscribeinvoices",
+	Usage:       "subscribeinvoices",
+	Description: "print each invoice as it's added or settled",
+	Action:      subscribeInvoices,
+}
+
+func subscribeInvoices(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	stream, err := client.SubscribeInvoices(ctxb,
+		&lnrpc.InvoiceSubscription{})
+	if err != nil {
+		return err
+	}
+
+	for {
+		invoice, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		printRespJson(invoice)
+	}
+}
+
 var DescribeGraphCommand = cli.Command{
 	Name: "describegraph",
 	Description: "prints a human readable version of the known channel " +
@@ -779,36 +1654,206 @@ func describeGraph(ctx *cli.Context) error {
 }
 
 var ListPaymentsCommand = cli.Command{
-	Name:        "listpayments",
-	Usage:       "listpayments",
-	Description: "list all outgoing payments",
-	Action:      listPayments,
-}
-
-func listPayments(ctx *cli.Context) error {
-	client := getClient(ctx)
-
-	req := &lnrpc.ListPaymentsRequest{}
-
-	payments, err := client.ListPayments(context.Background(), req)
-	if err != nil {
-		return err
-	}
-
-	printRespJson(payments)
+	Name: "listpayments",
+	Usage: "listpayments [--index_offset=N] [--max_payments=N] " +
+		"[--paginate_backwards] [--count_total_payments] " +
+		"[--creation_date_start=T] [--creation_date_end=T]",
+	Description: "list outgoing payments, optionally a page at a time",
+	Flags: []cli.Flag{
+		cli.IntFlag{
+			Name: "index_offset",
+			Usage: "the index of the payment the page starts " +
+				"after, or before if paginating backwards",
+		},
+		cli.IntFlag{
+			Name:  "max_payments",
+			Usage: "the maximum number of payments to return",
+		},
+		cli.BoolFlag{
+			Name:  "paginate_backwards",
+			Usage: "return the payments preceding the index offset",
+		},
+		cli.BoolFlag{
+			Name:  "count_total_payments",
+			Usage: "also return the total number of payments",
+		},
+		cli.IntFlag{
+			Name: "creation_date_start",
+			Usage: "only return payments created at or after " +
+				"this unix timestamp",
+		},
+		cli.IntFlag{
+			Name: "creation_date_end",
+			Usage: "only return payments created at or before " +
+				"this unix timestamp",
+		},
+	},
+	Action: listPayments,
+}
+
+func listPayments(ctx *cli.Context) error {
+	client := getClient(ctx)
+
+	req := &lnrpc.ListPaymentsRequest{
+		IndexOffset:        uint64(ctx.Int("index_offset")),
+		MaxPayments:        uint64(ctx.Int("max_payments")),
+		Reversed:           ctx.Bool("paginate_backwards"),
+		CountTotalPayments: ctx.Bool("count_total_payments"),
+		CreationDateStart:  uint64(ctx.Int("creation_date_start")),
+		CreationDateEnd:    uint64(ctx.Int("creation_date_end")),
+	}
+
+	payments, err := client.ListPayments(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(payments)
+	return nil
+}
+
+var TrackPaymentCommand = cli.Command{
+	Name:  "trackpayment",
+	Usage: "trackpayment --payment_hash=H",
+	Description: "print the state of an outgoing payment, followed by " +
+		"each update to it, until it either succeeds or is abandoned",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "payment_hash",
+			Usage: "the hex-encoded hash of the payment to track",
+		},
+	},
+	Action: trackPayment,
+}
+
+func trackPayment(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	paymentHash, err := hex.DecodeString(ctx.String("payment_hash"))
+	if err != nil {
+		return fmt.Errorf("unable to decode payment hash: %v", err)
+	}
+
+	stream, err := client.TrackPayment(ctxb,
+		&lnrpc.TrackPaymentRequest{PaymentHash: paymentHash})
+	if err != nil {
+		return err
+	}
+
+	for {
+		payment, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		printRespJson(payment)
+	}
+}
+
+var DeletePaymentsCommand = cli.Command{
+	Name: "deletepayments",
+	Usage: "deletepayments [--payment_hash=H] [--failed_only] " +
+		"[--failed_attempts_only]",
+	Description: "delete outgoing payments from the database. If a " +
+		"payment hash is given, only that payment is deleted. " +
+		"Otherwise, all payments are deleted, unless restricted to " +
+		"failed payments, or to the failed attempts of succeeded " +
+		"payments.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "payment_hash",
+			Usage: "the hex-encoded hash of the payment to delete",
+		},
+		cli.BoolFlag{
+			Name:  "failed_only",
+			Usage: "only delete failed payments",
+		},
+		cli.BoolFlag{
+			Name: "failed_attempts_only",
+			Usage: "only delete the records of failed attempts, " +
+				"keeping the payments themselves",
+		},
+	},
+	Action: deletePayments,
+}
+
+func deletePayments(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	if ctx.String("payment_hash") != "" {
+		if ctx.Bool("failed_only") {
+			return fmt.Errorf("failed_only cannot be used along " +
+				"with payment_hash")
+		}
+
+		paymentHash, err := hex.DecodeString(ctx.String("payment_hash"))
+		if err != nil {
+			return fmt.Errorf("unable to decode payment hash: %v",
+				err)
+		}
+
+		req := &lnrpc.DeletePaymentRequest{
+			PaymentHash:        paymentHash,
+			FailedAttemptsOnly: ctx.Bool("failed_attempts_only"),
+		}
+		resp, err := client.DeletePayment(ctxb, req)
+		if err != nil {
+			return err
+		}
+
+		printRespJson(resp)
+		return nil
+	}
+
+	req := &lnrpc.DeleteAllPaymentsRequest{
+		FailedPaymentsOnly: ctx.Bool("failed_only"),
+		FailedAttemptsOnly: ctx.Bool("failed_attempts_only"),
+	}
+	resp, err := client.DeleteAllPayments(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
 	return nil
 }
 
 var GetChanInfoCommand = cli.Command{
-	Name:  "getchaninfo",
-	Usage: "getchaninfo --chand_id=[8_byte_channel_id]",
+	Name: "getchaninfo",
+	Usage: "getchaninfo --chan_id=[8_byte_channel_id] | " +
+		"--chan_id_str=[BLOCKxTXxOUT] | " +
+		"--full_chan_id=[32_byte_channel_id] | --funding_txid=[txid] " +
+		"--output_index=[index]",
 	Description: "prints out the latest authenticated state for a " +
-		"particular channel",
+		"particular channel, identified by any form of its " +
+		"channel ID or by its channel point",
 	Flags: []cli.Flag{
 		cli.IntFlag{
 			Name:  "chan_id",
 			Usage: "the 8-byte compact channel ID to query for",
 		},
+		cli.StringFlag{
+			Name: "chan_id_str",
+			Usage: "the channel ID to query for in its human " +
+				"readable BLOCKxTXxOUT form",
+		},
+		cli.StringFlag{
+			Name:  "full_chan_id",
+			Usage: "the hex encoded 32-byte channel ID to query for",
+		},
+		cli.StringFlag{
+			Name:  "funding_txid",
+			Usage: "the txid of the channel's funding transaction",
+		},
+		cli.IntFlag{
+			Name: "output_index",
+			Usage: "the output index for the funding output of the " +
+				"funding transaction",
+		},
 	},
 	Action: getChanInfo,
 }
@@ -818,7 +1863,20 @@ func getChanInfo(ctx *cli.Context) error {
 	client := getClient(ctx)
 
 	req := &lnrpc.ChanInfoRequest{
-		ChanId: uint64(ctx.Int("chan_id")),
+		ChanId:     uint64(ctx.Int("chan_id")),
+		ChanIdStr:  ctx.String("chan_id_str"),
+		FullChanId: ctx.String("full_chan_id"),
+	}
+	if ctx.String("funding_txid") != "" {
+		txid, err := chainhash.NewHashFromStr(ctx.String("funding_txid"))
+		if err != nil {
+			return err
+		}
+
+		req.ChanPoint = &lnrpc.ChannelPoint{
+			FundingTxid: txid[:],
+			OutputIndex: uint32(ctx.Int("output_index")),
+		}
 	}
 
 	chanInfo, err := client.GetChanInfo(ctxb, req)
@@ -862,6 +1920,68 @@ func getNodeInfo(ctx *cli.Context) error {
 	return nil
 }
 
+var GetNodeAddressesCommand = cli.Command{
+	Name:  "getnodeaddresses",
+	Usage: "getnodeaddresses --pub_key=[33_byte_serialized_pub_key]",
+	Description: "prints out the known network addresses of a node, along " +
+		"with the time each was last seen, and its features if we're " +
+		"connected to it",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "pub_key",
+			Usage: "the 33-byte hex-encoded compressed public of the target " +
+				"node",
+		},
+	},
+	Action: getNodeAddresses,
+}
+
+func getNodeAddresses(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	req := &lnrpc.NodeAddressesRequest{
+		PubKey: ctx.String("pub_key"),
+	}
+
+	nodeAddrs, err := client.GetNodeAddresses(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(nodeAddrs)
+	return nil
+}
+
+var SubscribeChannelGraphCommand = cli.Command{
+	Name:        "subscribechannelgraph",
+	Usage:       "subscribechannelgraph",
+	Description: "print all changes to the channel graph as they occur",
+	Action:      subscribeChannelGraph,
+}
+
+func subscribeChannelGraph(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	stream, err := client.SubscribeChannelGraph(ctxb,
+		&lnrpc.GraphTopologySubscription{})
+	if err != nil {
+		return err
+	}
+
+	for {
+		update, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		printRespJson(update)
+	}
+}
+
 var QueryRouteCommand = cli.Command{
 	Name:        "queryroute",
 	Usage:       "queryroute --dest=[dest_pub_key] --amt=[amt_to_send_in_satoshis]",
@@ -898,6 +2018,50 @@ func queryRoute(ctx *cli.Context) error {
 	return nil
 }
 
+var EstimateRouteFeeCommand = cli.Command{
+	Name:  "estimateroutefee",
+	Usage: "estimateroutefee --dest=[dest_pub_key] --amt=[amt_to_send_in_satoshis] [--probe]",
+	Description: "estimates the fee and time lock of a payment to the " +
+		"destination, based on pathfinding or, if --probe is set, " +
+		"on a probe payment sent along the route",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "dest",
+			Usage: "the 33-byte hex-encoded public key for the payment " +
+				"destination",
+		},
+		cli.IntFlag{
+			Name:  "amt",
+			Usage: "the amount to send expressed in satoshis",
+		},
+		cli.BoolFlag{
+			Name: "probe",
+			Usage: "confirm the route is able to carry the payment " +
+				"by sending a probe payment which can't be settled",
+		},
+	},
+	Action: estimateRouteFee,
+}
+
+func estimateRouteFee(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	req := &lnrpc.RouteFeeRequest{
+		PubKey: ctx.String("dest"),
+		Amt:    int64(ctx.Int("amt")),
+		Probe:  ctx.Bool("probe"),
+	}
+
+	resp, err := client.EstimateRouteFee(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
 var GetNetworkInfoCommand = cli.Command{
 	Name:  "getnetworkinfo",
 	Usage: "getnetworkinfo",
@@ -920,3 +2084,1782 @@ func getNetworkInfo(ctx *cli.Context) error {
 	printRespJson(netInfo)
 	return nil
 }
+
+var SendCustomCommand = cli.Command{
+	Name:  "sendcustom",
+	Usage: "sendcustom --peer=<pubkey> --type=<msg type> --data=<hex payload>",
+	Description: "send a custom protocol message to a connected peer. The " +
+		"message type must fall within the custom message range (32768+)",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "peer",
+			Usage: "the hex encoded public key of the target peer",
+		},
+		cli.IntFlag{
+			Name:  "type",
+			Usage: "the message type of the custom message",
+		},
+		cli.StringFlag{
+			Name:  "data",
+			Usage: "the hex encoded payload of the custom message",
+		},
+	},
+	Action: sendCustom,
+}
+
+func sendCustom(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	peer, err := hex.DecodeString(ctx.String("peer"))
+	if err != nil {
+		return err
+	}
+	data, err := hex.DecodeString(ctx.String("data"))
+	if err != nil {
+		return err
+	}
+
+	req := &lnrpc.SendCustomMessageRequest{
+		Peer: peer,
+		Type: uint32(ctx.Int("type")),
+		Data: data,
+	}
+
+	resp, err := client.SendCustomMessage(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var SubscribeCustomCommand = cli.Command{
+	Name:        "subscribecustom",
+	Usage:       "subscribecustom",
+	Description: "print all custom protocol messages received from peers",
+	Action:      subscribeCustom,
+}
+
+func subscribeCustom(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	stream, err := client.SubscribeCustomMessages(ctxb,
+		&lnrpc.SubscribeCustomMessagesRequest{})
+	if err != nil {
+		return err
+	}
+
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		printRespJson(struct {
+			Peer string `json:"peer"`
+			Type uint32 `json:"type"`
+			Data string `json:"data"`
+		}{
+			Peer: hex.EncodeToString(msg.Peer),
+			Type: msg.Type,
+			Data: hex.EncodeToString(msg.Data),
+		})
+	}
+}
+
+var ListUnspentCommand = cli.Command{
+	Name:        "listunspent",
+	Usage:       "listunspent --min_confs=<n> --max_confs=<n>",
+	Description: "list the unspent witness outputs controlled by the wallet",
+	Flags: []cli.Flag{
+		cli.IntFlag{
+			Name:  "min_confs",
+			Usage: "the minimum number of confirmations for an output",
+		},
+		cli.IntFlag{
+			Name: "max_confs",
+			Usage: "the maximum number of confirmations for an output, " +
+				"zero for no limit",
+		},
+		cli.StringFlag{
+			Name: "account",
+			Usage: "the name of the imported account to list the " +
+				"outputs of",
+		},
+	},
+	Action: listUnspent,
+}
+
+func listUnspent(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	req := &lnrpc.ListUnspentRequest{
+		MinConfs: int32(ctx.Int("min_confs")),
+		MaxConfs: int32(ctx.Int("max_confs")),
+		Account:  ctx.String("account"),
+	}
+	resp, err := client.ListUnspent(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var ListAddressesCommand = cli.Command{
+	Name:        "listaddresses",
+	Usage:       "listaddresses",
+	Description: "list all the addresses generated by the wallet",
+	Action:      listAddresses,
+}
+
+func listAddresses(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	resp, err := client.ListAddresses(ctxb, &lnrpc.ListAddressesRequest{})
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var EstimateFeeCommand = cli.Command{
+	Name:        "estimatefee",
+	Usage:       "estimatefee --conf_target=<num blocks>",
+	Description: "estimate the fee rate required to confirm within the target",
+	Flags: []cli.Flag{
+		cli.IntFlag{
+			Name:  "conf_target",
+			Usage: "the number of blocks the transaction should confirm within",
+			Value: 6,
+		},
+	},
+	Action: estimateFee,
+}
+
+func estimateFee(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	req := &lnrpc.EstimateFeeRequest{
+		ConfTarget: uint32(ctx.Int("conf_target")),
+	}
+	resp, err := client.EstimateFee(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var PublishTxCommand = cli.Command{
+	Name:        "publishtx",
+	Usage:       "publishtx <hex encoded tx>",
+	Description: "broadcast a fully signed raw transaction to the network",
+	Action:      publishTx,
+}
+
+func publishTx(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	txHex, err := readArg(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	rawTx, err := hex.DecodeString(txHex)
+	if err != nil {
+		return fmt.Errorf("unable to decode tx: %v", err)
+	}
+
+	resp, err := client.PublishTransaction(ctxb,
+		&lnrpc.PublishTransactionRequest{TxHex: rawTx})
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var FundPsbtCommand = cli.Command{
+	Name: "fundpsbt",
+	Usage: "fundpsbt [--template_psbt=<base64 psbt> | " +
+		`--outputs='{"ExampleAddr": NumCoinsInSatoshis}'] ` +
+		"[--conf_target=<num blocks> | --sat_per_byte=<fee rate>]",
+	Description: "fund the outputs of a template PSBT, or a set of raw " +
+		"outputs, using the coins of the wallet. The selected wallet " +
+		"inputs are locked, and a change output is added if required",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "template_psbt",
+			Usage: "the base64 encoded PSBT to fund, or - to read it from stdin",
+		},
+		cli.StringFlag{
+			Name:  "outputs",
+			Usage: "a JSON map of addresses to amounts in satoshis",
+		},
+		cli.IntFlag{
+			Name:  "conf_target",
+			Usage: "the number of blocks the transaction should confirm within",
+		},
+		cli.IntFlag{
+			Name:  "sat_per_byte",
+			Usage: "an explicit fee rate in satoshis per byte",
+		},
+		cli.StringFlag{
+			Name: "coin_selection_strategy",
+			Usage: "the strategy used to select the coins funding " +
+				"the PSBT: largest, random or smallest",
+		},
+		cli.StringFlag{
+			Name: "account",
+			Usage: "the name of the imported account whose coins " +
+				"fund the PSBT, instead of those of the wallet",
+		},
+	},
+	Action: fundPsbt,
+}
+
+func fundPsbt(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	strategy, _, err := parseCoinSelectionArgs(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := &lnrpc.FundPsbtRequest{
+		TargetConf:            uint32(ctx.Int("conf_target")),
+		SatPerByte:            int64(ctx.Int("sat_per_byte")),
+		CoinSelectionStrategy: strategy,
+		Account:               ctx.String("account"),
+	}
+
+	templatePsbt, err := readArg(ctx.String("template_psbt"))
+	if err != nil {
+		return err
+	}
+	outputs, err := readArg(ctx.String("outputs"))
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case templatePsbt != "":
+		packet, err := base64.StdEncoding.DecodeString(templatePsbt)
+		if err != nil {
+			return fmt.Errorf("unable to decode psbt: %v", err)
+		}
+		req.Psbt = packet
+
+	case outputs != "":
+		var amountToAddr map[string]int64
+		err := json.Unmarshal([]byte(outputs), &amountToAddr)
+		if err != nil {
+			return fmt.Errorf("unable to decode outputs: %v", err)
+		}
+		req.RawOutputs = amountToAddr
+
+	default:
+		return fmt.Errorf("either template_psbt or outputs must be set")
+	}
+
+	resp, err := client.FundPsbt(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var FinalizePsbtCommand = cli.Command{
+	Name:  "finalizepsbt",
+	Usage: "finalizepsbt <base64 funded psbt>",
+	Description: "sign the wallet inputs of a funded PSBT, then finalize it " +
+		"and return the fully signed transaction",
+	Action: finalizePsbt,
+}
+
+func finalizePsbt(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	psbt, err := readArg(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	packet, err := base64.StdEncoding.DecodeString(psbt)
+	if err != nil {
+		return fmt.Errorf("unable to decode psbt: %v", err)
+	}
+
+	resp, err := client.FinalizePsbt(ctxb, &lnrpc.FinalizePsbtRequest{
+		FundedPsbt: packet,
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJson(struct {
+		SignedPsbt string `json:"signed_psbt"`
+		RawFinalTx string `json:"raw_final_tx"`
+	}{
+		SignedPsbt: base64.StdEncoding.EncodeToString(resp.SignedPsbt),
+		RawFinalTx: hex.EncodeToString(resp.RawFinalTx),
+	})
+	return nil
+}
+
+var LeaseOutputCommand = cli.Command{
+	Name: "leaseoutput",
+	Usage: "leaseoutput --lock_id=<hex id> --outpoint=<txid:index> " +
+		"[--expiry=<seconds>]",
+	Description: "lock an output of the wallet, preventing it from being " +
+		"used for coin selection until the lease expires or is released",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "lock_id",
+			Usage: "the hex encoded 32-byte id of the lease",
+		},
+		cli.StringFlag{
+			Name:  "outpoint",
+			Usage: "the outpoint to lease, in the form txid:index",
+		},
+		cli.IntFlag{
+			Name: "expiry",
+			Usage: "the duration of the lease in seconds, at " +
+				"most a week. If unset, the output is leased " +
+				"for 10 minutes",
+		},
+	},
+	Action: leaseOutput,
+}
+
+// parseOutPoint parses an outpoint of the form txid:index.
+func parseOutPoint(s string) (*lnrpc.OutPoint, error) {
+	outpoint := strings.Split(s, ":")
+	if len(outpoint) != 2 {
+		return nil, fmt.Errorf("outpoint must be of the form " +
+			"txid:index")
+	}
+	index, err := strconv.ParseUint(outpoint[1], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode output index: %v",
+			err)
+	}
+
+	return &lnrpc.OutPoint{
+		TxidStr:     outpoint[0],
+		OutputIndex: uint32(index),
+	}, nil
+}
+
+// parseCoinSelectionArgs parses the coin selection strategy and explicit
+// outpoint flags shared by the commands which fund transactions.
+func parseCoinSelectionArgs(ctx *cli.Context) (lnrpc.CoinSelectionStrategy,
+	[]*lnrpc.OutPoint, error) {
+
+	var strategy lnrpc.CoinSelectionStrategy
+	switch ctx.String("coin_selection_strategy") {
+	case "":
+		strategy = lnrpc.CoinSelectionStrategy_STRATEGY_USE_GLOBAL_CONFIG
+	case "largest":
+		strategy = lnrpc.CoinSelectionStrategy_STRATEGY_LARGEST
+	case "random":
+		strategy = lnrpc.CoinSelectionStrategy_STRATEGY_RANDOM
+	case "smallest":
+		strategy = lnrpc.CoinSelectionStrategy_STRATEGY_SMALLEST
+	default:
+		return 0, nil, fmt.Errorf("unknown coin selection strategy: %v",
+			ctx.String("coin_selection_strategy"))
+	}
+
+	var outpoints []*lnrpc.OutPoint
+	for _, utxo := range ctx.StringSlice("utxo") {
+		outpoint, err := parseOutPoint(utxo)
+		if err != nil {
+			return 0, nil, err
+		}
+		outpoints = append(outpoints, outpoint)
+	}
+
+	return strategy, outpoints, nil
+}
+
+// parseLeaseArgs parses the lock ID and outpoint flags shared by the lease
+// commands.
+func parseLeaseArgs(ctx *cli.Context) ([]byte, *lnrpc.OutPoint, error) {
+	lockID, err := hex.DecodeString(ctx.String("lock_id"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to decode lock id: %v", err)
+	}
+
+	outpoint, err := parseOutPoint(ctx.String("outpoint"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return lockID, outpoint, nil
+}
+
+func leaseOutput(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	lockID, outpoint, err := parseLeaseArgs(ctx)
+	if err != nil {
+		return err
+	}
+
+	if ctx.Int("expiry") < 0 {
+		return fmt.Errorf("expiry must not be negative")
+	}
+
+	resp, err := client.LeaseOutput(ctxb, &lnrpc.LeaseOutputRequest{
+		Id:                lockID,
+		Outpoint:          outpoint,
+		ExpirationSeconds: uint64(ctx.Int("expiry")),
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var ReleaseOutputCommand = cli.Command{
+	Name:        "releaseoutput",
+	Usage:       "releaseoutput --lock_id=<hex id> --outpoint=<txid:index>",
+	Description: "release an output previously leased with the same lock id",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "lock_id",
+			Usage: "the hex encoded 32-byte id of the lease",
+		},
+		cli.StringFlag{
+			Name:  "outpoint",
+			Usage: "the outpoint to release, in the form txid:index",
+		},
+	},
+	Action: releaseOutput,
+}
+
+func releaseOutput(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	lockID, outpoint, err := parseLeaseArgs(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.ReleaseOutput(ctxb, &lnrpc.ReleaseOutputRequest{
+		Id:       lockID,
+		Outpoint: outpoint,
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var ListLeasesCommand = cli.Command{
+	Name:        "listleases",
+	Usage:       "listleases",
+	Description: "list all the currently leased outputs of the wallet",
+	Action:      listLeases,
+}
+
+func listLeases(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	resp, err := client.ListLeases(ctxb, &lnrpc.ListLeasesRequest{})
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var ListSweepsCommand = cli.Command{
+	Name:  "listsweeps",
+	Usage: "listsweeps",
+	Description: "list all the transactions broadcast to sweep our " +
+		"funds from closed channels, including justice transactions",
+	Action: listSweeps,
+}
+
+func listSweeps(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	resp, err := client.ListSweeps(ctxb, &lnrpc.ListSweepsRequest{})
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var ListChainTxnsCommand = cli.Command{
+	Name: "listchaintxns",
+	Usage: "listchaintxns [--start_height=N] [--end_height=N] " +
+		"[--index_offset=N] [--max_transactions=N]",
+	Description: "list the on-chain transactions relevant to the wallet, " +
+		"optionally filtered by the range of block heights they were " +
+		"confirmed within",
+	Flags: []cli.Flag{
+		cli.IntFlag{
+			Name:  "start_height",
+			Usage: "the height of the first block to include transactions from",
+		},
+		cli.IntFlag{
+			Name: "end_height",
+			Usage: "the height of the last block to include " +
+				"transactions from, if unset unconfirmed " +
+				"transactions are included as well",
+		},
+		cli.IntFlag{
+			Name:  "index_offset",
+			Usage: "the number of transactions to skip",
+		},
+		cli.IntFlag{
+			Name:  "max_transactions",
+			Usage: "the maximum number of transactions to return",
+		},
+	},
+	Action: listChainTxns,
+}
+
+func listChainTxns(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	resp, err := client.GetTransactions(ctxb, &lnrpc.GetTransactionsRequest{
+		StartHeight:     int32(ctx.Int("start_height")),
+		EndHeight:       int32(ctx.Int("end_height")),
+		IndexOffset:     uint32(ctx.Int("index_offset")),
+		MaxTransactions: uint32(ctx.Int("max_transactions")),
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var SubscribeTransactionsCommand = cli.Command{
+	Name:        "subscribetransactions",
+	Usage:       "subscribetransactions",
+	Description: "print each on-chain transaction relevant to the wallet as it's seen or confirmed",
+	Action:      subscribeTransactions,
+}
+
+func subscribeTransactions(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	stream, err := client.SubscribeTransactions(ctxb,
+		&lnrpc.GetTransactionsRequest{})
+	if err != nil {
+		return err
+	}
+
+	for {
+		tx, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		printRespJson(tx)
+	}
+}
+
+var GetBestBlockCommand = cli.Command{
+	Name:        "getbestblock",
+	Usage:       "getbestblock",
+	Description: "display the hash and height of the tip of the chain known to the daemon's chain backend",
+	Action:      getBestBlock,
+}
+
+func getBestBlock(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getChainKitClient(ctx)
+
+	resp, err := client.GetBestBlock(ctxb, &lnrpc.GetBestBlockRequest{})
+	if err != nil {
+		return err
+	}
+	blockHash, err := chainhash.NewHash(resp.BlockHash)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(struct {
+		BlockHash   string `json:"block_hash"`
+		BlockHeight int32  `json:"block_height"`
+	}{
+		BlockHash:   blockHash.String(),
+		BlockHeight: resp.BlockHeight,
+	})
+	return nil
+}
+
+var GetBlockHashCommand = cli.Command{
+	Name:        "getblockhash",
+	Usage:       "getblockhash <height>",
+	Description: "display the hash of the block at the passed height within the chain",
+	Action:      getBlockHash,
+}
+
+func getBlockHash(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getChainKitClient(ctx)
+
+	if len(ctx.Args()) != 1 {
+		return fmt.Errorf("a block height must be specified")
+	}
+	height, err := strconv.ParseInt(ctx.Args().First(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("unable to parse height: %v", err)
+	}
+
+	resp, err := client.GetBlockHash(ctxb, &lnrpc.GetBlockHashRequest{
+		BlockHeight: height,
+	})
+	if err != nil {
+		return err
+	}
+	blockHash, err := chainhash.NewHash(resp.BlockHash)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(struct {
+		BlockHash string `json:"block_hash"`
+	}{
+		BlockHash: blockHash.String(),
+	})
+	return nil
+}
+
+var GetBlockCommand = cli.Command{
+	Name:        "getblock",
+	Usage:       "getblock <block hash>",
+	Description: "display the hex encoded block with the passed hash",
+	Action:      getBlock,
+}
+
+func getBlock(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getChainKitClient(ctx)
+
+	if len(ctx.Args()) != 1 {
+		return fmt.Errorf("a block hash must be specified")
+	}
+	blockHash, err := chainhash.NewHashFromStr(ctx.Args().First())
+	if err != nil {
+		return fmt.Errorf("unable to parse block hash: %v", err)
+	}
+
+	resp, err := client.GetBlock(ctxb, &lnrpc.GetBlockRequest{
+		BlockHash: blockHash[:],
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJson(struct {
+		RawBlock string `json:"raw_block"`
+	}{
+		RawBlock: hex.EncodeToString(resp.RawBlock),
+	})
+	return nil
+}
+
+var LabelTxCommand = cli.Command{
+	Name:        "labeltx",
+	Usage:       "labeltx [--overwrite] <txid> <label>",
+	Description: "attach a label to an on-chain transaction of the wallet",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "overwrite",
+			Usage: "replace the existing label of the transaction",
+		},
+	},
+	Action: labelTx,
+}
+
+func labelTx(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	if len(ctx.Args()) != 2 {
+		return fmt.Errorf("a txid and label must be specified")
+	}
+
+	txid, err := chainhash.NewHashFromStr(ctx.Args().Get(0))
+	if err != nil {
+		return fmt.Errorf("unable to decode txid: %v", err)
+	}
+
+	resp, err := client.LabelTransaction(ctxb, &lnrpc.LabelTransactionRequest{
+		Txid:      txid[:],
+		Label:     ctx.Args().Get(1),
+		Overwrite: ctx.Bool("overwrite"),
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var ImportAccountCommand = cli.Command{
+	Name: "importaccount",
+	Usage: "importaccount <name> <extended public key> " +
+		"[--master_key_fingerprint=<hex>] [--birthday_height=<height>] " +
+		"[--dry_run]",
+	Description: "import an account backed by an extended public key, " +
+		"allowing its addresses to be derived and its outputs to be " +
+		"watched. The outputs of an imported account can't be spent " +
+		"by the wallet, but can fund PSBTs which are signed externally",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "master_key_fingerprint",
+			Usage: "the hex encoded fingerprint of the master key " +
+				"the extended public key was derived from",
+		},
+		cli.IntFlag{
+			Name: "birthday_height",
+			Usage: "the height of the first block which may " +
+				"contain outputs paying to the account, from " +
+				"which the chain is rescanned. If unset, the " +
+				"entire chain is rescanned",
+		},
+		cli.BoolFlag{
+			Name: "dry_run",
+			Usage: "only return the first addresses of the " +
+				"account, without importing it",
+		},
+	},
+	Action: importAccount,
+}
+
+func importAccount(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	if len(ctx.Args()) != 2 {
+		return fmt.Errorf("the account name and extended public key " +
+			"must be specified")
+	}
+
+	req := &lnrpc.ImportAccountRequest{
+		Name:              ctx.Args().Get(0),
+		ExtendedPublicKey: ctx.Args().Get(1),
+		BirthdayHeight:    uint32(ctx.Int("birthday_height")),
+		DryRun:            ctx.Bool("dry_run"),
+	}
+	if ctx.String("master_key_fingerprint") != "" {
+		fingerprint, err := hex.DecodeString(
+			ctx.String("master_key_fingerprint"))
+		if err != nil || len(fingerprint) != 4 {
+			return fmt.Errorf("master key fingerprint must be 4 " +
+				"hex encoded bytes")
+		}
+		req.MasterKeyFingerprint = binary.BigEndian.Uint32(fingerprint)
+	}
+
+	resp, err := client.ImportAccount(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var ListAccountsCommand = cli.Command{
+	Name:        "listaccounts",
+	Usage:       "listaccounts [--name=<account name>]",
+	Description: "list the default account of the wallet along with all imported accounts",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "name",
+			Usage: "only list the account with the passed name",
+		},
+	},
+	Action: listAccounts,
+}
+
+func listAccounts(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	resp, err := client.ListAccounts(ctxb, &lnrpc.ListAccountsRequest{
+		Name: ctx.String("name"),
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var GetRecoveryInfoCommand = cli.Command{
+	Name:        "getrecoveryinfo",
+	Usage:       "getrecoveryinfo",
+	Description: "display the progress of the wallet's recovery, if the node was started in recovery mode",
+	Action:      getRecoveryInfo,
+}
+
+func getRecoveryInfo(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	resp, err := client.GetRecoveryInfo(ctxb, &lnrpc.GetRecoveryInfoRequest{})
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var DebugLevelCommand = cli.Command{
+	Name:  "debuglevel",
+	Usage: "Set the debug level.",
+	Description: "Logging level for all subsystems {trace, debug, info, warn, error, critical} \n" +
+		"You may also specify <subsystem>=<level>,<subsystem2>=<level>,... to set the log level for individual subsystems\n" +
+		"A leading bare level may be combined with subsystem levels, e.g. info,INVC=trace\n\n" +
+		"Use show to list available subsystems",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "show",
+			Usage: "if true, then the list of available sub-systems will be printed out",
+		},
+		cli.StringFlag{
+			Name:  "level",
+			Usage: "the level specification to target either a coarse logging level, or granular set of specific sub-systems with logging levels for each",
+		},
+	},
+	Action: debugLevel,
+}
+
+func debugLevel(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	req := &lnrpc.DebugLevelRequest{
+		Show:      ctx.Bool("show"),
+		LevelSpec: ctx.String("level"),
+	}
+
+	resp, err := client.DebugLevel(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var StopCommand = cli.Command{
+	Name:  "stop",
+	Usage: "Stop and shutdown the daemon.",
+	Description: "Gracefully stops all of the daemon's subsystems, then " +
+		"shuts it down. If the subsystems don't stop within the " +
+		"daemon's shutdown timeout, it exits regardless.",
+	Action: stopDaemon,
+}
+
+func stopDaemon(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	_, err := client.StopDaemon(ctxb, &lnrpc.StopRequest{})
+	return err
+}
+
+var GetDebugInfoCommand = cli.Command{
+	Name:  "getdebuginfo",
+	Usage: "display the daemon's config and runtime state",
+	Description: "Displays the sanitized active config, the features " +
+		"advertised, the status of each subserver, and the most " +
+		"recent lines of the log as one bundle, to be attached to " +
+		"support requests. Sensitive config options are redacted.",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "goroutines",
+			Usage: "include the stack traces of all goroutines",
+		},
+		cli.StringFlag{
+			Name: "heap_profile",
+			Usage: "if set, a heap profile in the pprof format is " +
+				"written to this file",
+		},
+		cli.IntFlag{
+			Name: "num_log_lines",
+			Usage: "the maximum number of recent log lines to " +
+				"include, if zero all retained lines are included",
+		},
+	},
+	Action: getDebugInfo,
+}
+
+func getDebugInfo(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	heapProfileFile := ctx.String("heap_profile")
+	req := &lnrpc.GetDebugInfoRequest{
+		IncludeGoroutines:  ctx.Bool("goroutines"),
+		IncludeHeapProfile: heapProfileFile != "",
+		NumLogLines:        uint32(ctx.Int("num_log_lines")),
+	}
+
+	resp, err := client.GetDebugInfo(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	if heapProfileFile != "" {
+		err := ioutil.WriteFile(heapProfileFile, resp.HeapProfile, 0600)
+		if err != nil {
+			return err
+		}
+		resp.HeapProfile = nil
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var CPUProfileCommand = cli.Command{
+	Name:  "cpuprofile",
+	Usage: "capture a CPU profile of the daemon",
+	Description: "Profiles the CPU usage of the daemon for the given " +
+		"number of seconds, writing the profile in the pprof format " +
+		"to the given file.",
+	Flags: []cli.Flag{
+		cli.IntFlag{
+			Name:  "duration",
+			Usage: "the number of seconds to profile for, at most 300",
+			Value: 30,
+		},
+		cli.StringFlag{
+			Name:  "output_file",
+			Usage: "the file the profile is written to",
+		},
+	},
+	Action: cpuProfile,
+}
+
+func cpuProfile(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	outputFile := ctx.String("output_file")
+	if outputFile == "" {
+		return fmt.Errorf("output_file must be set")
+	}
+
+	req := &lnrpc.CPUProfileRequest{
+		DurationSecs: uint32(ctx.Int("duration")),
+	}
+
+	resp, err := client.CaptureCPUProfile(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(outputFile, resp.Profile, 0600)
+}
+
+var ExportChannelDbCommand = cli.Command{
+	Name:  "exportchanneldb",
+	Usage: "export a copy of the channel database for auditing",
+	Description: "Writes a copy of the channel database to the given path " +
+		"on the daemon's filesystem, stripped of the invoice " +
+		"preimages and revocation secrets which would allow its " +
+		"holder to steal funds. The copy may be shared with support " +
+		"staff or accountants, but can't be used to run a node.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "dest_path",
+			Usage: "the path the copy is written to, which must not exist",
+		},
+	},
+	Action: exportChannelDb,
+}
+
+func exportChannelDb(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	destPath := ctx.String("dest_path")
+	if destPath == "" {
+		return fmt.Errorf("dest_path must be set")
+	}
+
+	req := &lnrpc.ExportChannelDbRequest{
+		DestPath: destPath,
+	}
+
+	resp, err := client.ExportChannelDbForAudit(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var GetStateCommand = cli.Command{
+	Name:  "state",
+	Usage: "get the current state of the daemon",
+	Description: "Displays the phase of the daemon's startup: " +
+		"waiting to start, unlocked, RPC active or server active. " +
+		"If --subscribe is set, each subsequent state is displayed " +
+		"as the daemon transitions to it.",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "subscribe",
+			Usage: "keep displaying the state of the daemon as it changes",
+		},
+	},
+	Action: getState,
+}
+
+func getState(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getStateClient(ctx)
+
+	if !ctx.Bool("subscribe") {
+		resp, err := client.GetState(ctxb, &lnrpc.GetStateRequest{})
+		if err != nil {
+			return err
+		}
+
+		printRespJson(resp)
+		return nil
+	}
+
+	stream, err := client.SubscribeState(ctxb, &lnrpc.SubscribeStateRequest{})
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		printRespJson(resp)
+	}
+}
+
+var AutopilotStatusCommand = cli.Command{
+	Name:        "autopilotstatus",
+	Usage:       "get whether the autopilot agent is active",
+	Description: "Displays whether the autopilot agent is currently opening channels on behalf of the node.",
+	Action:      autopilotStatus,
+}
+
+func autopilotStatus(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	resp, err := client.AutopilotStatus(ctxb, &lnrpc.AutopilotStatusRequest{})
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var ModifyAutopilotCommand = cli.Command{
+	Name:  "modifyautopilot",
+	Usage: "modifyautopilot --enable|--disable",
+	Description: "Enables or disables the autopilot agent until the " +
+		"daemon restarts. Channels the agent has already begun " +
+		"funding are unaffected by disabling it.",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "enable",
+			Usage: "start the autopilot agent",
+		},
+		cli.BoolFlag{
+			Name:  "disable",
+			Usage: "stop the autopilot agent",
+		},
+	},
+	Action: modifyAutopilot,
+}
+
+func modifyAutopilot(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	if ctx.Bool("enable") == ctx.Bool("disable") {
+		return fmt.Errorf("exactly one of --enable and --disable " +
+			"must be specified")
+	}
+
+	resp, err := client.ModifyAutopilotStatus(ctxb,
+		&lnrpc.ModifyAutopilotStatusRequest{
+			Enable: ctx.Bool("enable"),
+		})
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var QueryAutopilotScoresCommand = cli.Command{
+	Name:  "queryscores",
+	Usage: "queryscores [<pubkey>...]",
+	Description: "Displays the scores the autopilot agent's heuristic " +
+		"assigns to the passed nodes, or to every node within the " +
+		"channel graph if none are passed. Nodes the agent wouldn't " +
+		"open a channel to are omitted.",
+	Action: queryAutopilotScores,
+}
+
+func queryAutopilotScores(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	resp, err := client.QueryAutopilotScores(ctxb,
+		&lnrpc.QueryAutopilotScoresRequest{
+			Pubkeys: ctx.Args(),
+		})
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var SetAutopilotScoresCommand = cli.Command{
+	Name:  "setscores",
+	Usage: "setscores [--heuristic=<name>] '{\"<pubkey>\": <score>, ...}'",
+	Description: "Replaces the scores of a heuristic accepting external " +
+		"scores, such as externalscore, with the passed scores. Each " +
+		"score must be between 0 and 1. The autopilot agent combines " +
+		"these scores with those of the other configured heuristics.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "heuristic",
+			Value: "externalscore",
+			Usage: "the name of the heuristic to set the scores of",
+		},
+	},
+	Action: setAutopilotScores,
+}
+
+func setAutopilotScores(ctx *cli.Context) error {
+	var scoreByPubkey map[string]float64
+
+	jsonMap, err := readArg(ctx.Args().Get(0))
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal([]byte(jsonMap), &scoreByPubkey); err != nil {
+		return err
+	}
+
+	ctxb := context.Background()
+	client := getAutopilotClient(ctx)
+
+	req := &lnrpc.SetScoresRequest{
+		Heuristic: ctx.String("heuristic"),
+	}
+	for pubkey, score := range scoreByPubkey {
+		req.Scores = append(req.Scores, &lnrpc.AutopilotNodeScore{
+			PubKey: pubkey,
+			Score:  score,
+		})
+	}
+
+	resp, err := client.SetScores(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var QueryHeuristicScoresCommand = cli.Command{
+	Name:  "queryheuristics",
+	Usage: "queryheuristics [--ignore_local_state] [<pubkey>...]",
+	Description: "Displays the scores each of the autopilot agent's " +
+		"heuristics assigns to the passed nodes, or to every node " +
+		"within the channel graph if none are passed, along with the " +
+		"weight given to each heuristic.",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name: "ignore_local_state",
+			Usage: "also score the nodes we already have a " +
+				"channel with",
+		},
+	},
+	Action: queryHeuristicScores,
+}
+
+func queryHeuristicScores(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getAutopilotClient(ctx)
+
+	resp, err := client.QueryScores(ctxb, &lnrpc.QueryScoresRequest{
+		Pubkeys:          ctx.Args(),
+		IgnoreLocalState: ctx.Bool("ignore_local_state"),
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var ChannelInsightsCommand = cli.Command{
+	Name:  "channelinsights",
+	Usage: "channelinsights [--funding_txid=<txid> --output_index=<index>]",
+	Description: "Displays the lifetime, uptime, active time and flap " +
+		"count of a channel, or of every monitored channel if none " +
+		"is specified. Channels are monitored from the time the node " +
+		"starts, or from the time they're opened.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "funding_txid",
+			Usage: "the txid of the channel's funding transaction",
+		},
+		cli.IntFlag{
+			Name: "output_index",
+			Usage: "the output index for the funding output of the funding " +
+				"transaction",
+		},
+	},
+	Action: channelInsights,
+}
+
+func channelInsights(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	req := &lnrpc.ChannelInsightsRequest{}
+	if ctx.String("funding_txid") != "" {
+		txid, err := chainhash.NewHashFromStr(ctx.String("funding_txid"))
+		if err != nil {
+			return err
+		}
+
+		req.ChanPoint = &lnrpc.ChannelPoint{
+			FundingTxid: txid[:],
+			OutputIndex: uint32(ctx.Int("output_index")),
+		}
+	}
+
+	resp, err := client.ChannelInsights(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var SetFeeManagementCommand = cli.Command{
+	Name: "setfeemanagement",
+	Usage: "setfeemanagement --funding_txid=<txid> --output_index=<index> " +
+		"--enable|--disable",
+	Description: "Opts a channel into, or out of, automatic fee " +
+		"management. The fees of managed channels are adjusted " +
+		"based on their liquidity and recent flow, within the " +
+		"bounds set by the feemanager options of lnd.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "funding_txid",
+			Usage: "the txid of the channel's funding transaction",
+		},
+		cli.IntFlag{
+			Name: "output_index",
+			Usage: "the output index for the funding output of the funding " +
+				"transaction",
+		},
+		cli.BoolFlag{
+			Name:  "enable",
+			Usage: "opt the channel into fee management",
+		},
+		cli.BoolFlag{
+			Name:  "disable",
+			Usage: "opt the channel out of fee management",
+		},
+	},
+	Action: setFeeManagement,
+}
+
+func setFeeManagement(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	if ctx.Bool("enable") == ctx.Bool("disable") {
+		return fmt.Errorf("exactly one of --enable and --disable " +
+			"must be set")
+	}
+
+	txid, err := chainhash.NewHashFromStr(ctx.String("funding_txid"))
+	if err != nil {
+		return err
+	}
+
+	req := &lnrpc.SetFeeManagementRequest{
+		ChanPoint: &lnrpc.ChannelPoint{
+			FundingTxid: txid[:],
+			OutputIndex: uint32(ctx.Int("output_index")),
+		},
+		Enable: ctx.Bool("enable"),
+	}
+
+	resp, err := client.SetFeeManagement(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var UpdateChanStatusCommand = cli.Command{
+	Name: "updatechanstatus",
+	Usage: "updatechanstatus --funding_txid=<txid> --output_index=<index> " +
+		"--action=enable|disable|auto",
+	Description: "Disables, or re-enables, forwarding over a channel, " +
+		"broadcasting the change to the network. Manually set " +
+		"statuses persist until the channel is returned to automatic " +
+		"management with --action=auto, where it's disabled while its " +
+		"peer is offline.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "funding_txid",
+			Usage: "the txid of the channel's funding transaction",
+		},
+		cli.IntFlag{
+			Name: "output_index",
+			Usage: "the output index for the funding output of the funding " +
+				"transaction",
+		},
+		cli.StringFlag{
+			Name:  "action",
+			Usage: "the action to take: enable, disable, or auto",
+		},
+	},
+	Action: updateChanStatus,
+}
+
+func updateChanStatus(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	var action lnrpc.ChanStatusAction
+	switch ctx.String("action") {
+	case "enable":
+		action = lnrpc.ChanStatusAction_ENABLE
+	case "disable":
+		action = lnrpc.ChanStatusAction_DISABLE
+	case "auto":
+		action = lnrpc.ChanStatusAction_AUTO
+	default:
+		return fmt.Errorf("action must be one of enable, disable, " +
+			"or auto")
+	}
+
+	txid, err := chainhash.NewHashFromStr(ctx.String("funding_txid"))
+	if err != nil {
+		return err
+	}
+
+	req := &lnrpc.UpdateChanStatusRequest{
+		ChanPoint: &lnrpc.ChannelPoint{
+			FundingTxid: txid[:],
+			OutputIndex: uint32(ctx.Int("output_index")),
+		},
+		Action: action,
+	}
+
+	resp, err := client.UpdateChanStatus(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var UpgradeChannelCommand = cli.Command{
+	Name: "upgradechannel",
+	Usage: "upgradechannel --funding_txid=<txid> --output_index=<index> " +
+		"--type=static_remote_key",
+	Description: "Upgrades the commitment type of an active channel " +
+		"without closing it. The channel is briefly quiesced while the " +
+		"upgrade is negotiated with the remote peer, which must " +
+		"support the new commitment type.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "funding_txid",
+			Usage: "the txid of the channel's funding transaction",
+		},
+		cli.IntFlag{
+			Name: "output_index",
+			Usage: "the output index for the funding output of the funding " +
+				"transaction",
+		},
+		cli.StringFlag{
+			Name:  "type",
+			Usage: "the commitment type to upgrade to: static_remote_key",
+			Value: "static_remote_key",
+		},
+	},
+	Action: upgradeChannel,
+}
+
+func upgradeChannel(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	var commitType lnrpc.CommitmentType
+	switch ctx.String("type") {
+	case "static_remote_key":
+		commitType = lnrpc.CommitmentType_STATIC_REMOTE_KEY
+	default:
+		return fmt.Errorf("type must be static_remote_key")
+	}
+
+	txid, err := chainhash.NewHashFromStr(ctx.String("funding_txid"))
+	if err != nil {
+		return err
+	}
+
+	req := &lnrpc.UpgradeChannelRequest{
+		ChanPoint: &lnrpc.ChannelPoint{
+			FundingTxid: txid[:],
+			OutputIndex: uint32(ctx.Int("output_index")),
+		},
+		CommitmentType: commitType,
+	}
+
+	resp, err := client.UpgradeChannel(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var ExportChanBackupCommand = cli.Command{
+	Name: "exportchanbackup",
+	Description: "Export an encrypted static backup of a single channel, " +
+		"or of all our open channels with --all. The backups can " +
+		"later be used to recover the funds within the channels " +
+		"after their state was lost.",
+	Usage: "exportchanbackup --funding_txid=X --output_index=Y | --all",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "funding_txid",
+			Usage: "the txid of the channel's funding transaction",
+		},
+		cli.IntFlag{
+			Name: "output_index",
+			Usage: "the output index for the funding output of the funding " +
+				"transaction",
+		},
+		cli.BoolFlag{
+			Name:  "all",
+			Usage: "export a multi-channel backup of all open channels",
+		},
+		cli.StringFlag{
+			Name: "output_file",
+			Usage: "(optional) write the raw backup to this file " +
+				"rather than printing it",
+		},
+	},
+	Action: exportChanBackup,
+}
+
+func exportChanBackup(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	var (
+		backup []byte
+		resp   interface{}
+	)
+	if ctx.Bool("all") {
+		snapshot, err := client.ExportAllChannelBackups(
+			ctxb, &lnrpc.ChanBackupExportRequest{},
+		)
+		if err != nil {
+			return err
+		}
+
+		multi := snapshot.MultiChanBackup
+		chanPoints := make([]string, 0, len(multi.ChanPoints))
+		for _, chanPoint := range multi.ChanPoints {
+			txid, err := chainhash.NewHash(chanPoint.FundingTxid)
+			if err != nil {
+				return err
+			}
+			chanPoints = append(chanPoints, fmt.Sprintf("%v:%v",
+				txid, chanPoint.OutputIndex))
+		}
+
+		backup = multi.MultiChanBackup
+		resp = struct {
+			ChanPoints      []string `json:"chan_points"`
+			MultiChanBackup string   `json:"multi_chan_backup"`
+		}{
+			ChanPoints:      chanPoints,
+			MultiChanBackup: hex.EncodeToString(backup),
+		}
+	} else {
+		txid, err := chainhash.NewHashFromStr(ctx.String("funding_txid"))
+		if err != nil {
+			return err
+		}
+
+		chanBackup, err := client.ExportChannelBackup(ctxb,
+			&lnrpc.ExportChannelBackupRequest{
+				ChanPoint: &lnrpc.ChannelPoint{
+					FundingTxid: txid[:],
+					OutputIndex: uint32(ctx.Int("output_index")),
+				},
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		backup = chanBackup.ChanBackup
+		resp = struct {
+			ChanPoint  string `json:"chan_point"`
+			ChanBackup string `json:"chan_backup"`
+		}{
+			ChanPoint: fmt.Sprintf("%v:%v", txid,
+				ctx.Int("output_index")),
+			ChanBackup: hex.EncodeToString(backup),
+		}
+	}
+
+	if ctx.String("output_file") != "" {
+		return ioutil.WriteFile(ctx.String("output_file"), backup, 0600)
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var RestoreChanBackupCommand = cli.Command{
+	Name: "restorechanbackup",
+	Description: "Restore channels from an encrypted static backup after " +
+		"their state was lost. The peer of each channel is connected " +
+		"to and requested to force close the channel, so the funds " +
+		"within it can be recovered.",
+	Usage: "restorechanbackup --single_backup=X | --multi_backup=X | " +
+		"--multi_file=F",
+	Flags:  chanBackupFlags,
+	Action: restoreChanBackup,
+}
+
+// chanBackupFlags are the flags through which a backup to restore or verify
+// is specified.
+var chanBackupFlags = []cli.Flag{
+	cli.StringFlag{
+		Name: "single_backup",
+		Usage: "a hex encoded single channel backup, as returned by " +
+			"exportchanbackup, or - to read it from stdin",
+	},
+	cli.StringFlag{
+		Name: "multi_backup",
+		Usage: "a hex encoded multi-channel backup, as returned by " +
+			"exportchanbackup --all, or - to read it from stdin",
+	},
+	cli.StringFlag{
+		Name:  "multi_file",
+		Usage: "the path to a file holding a multi-channel backup",
+	},
+}
+
+// parseChanBackup parses the backup to restore or verify from the flags of
+// the command, returning whether it's a multi-channel backup.
+func parseChanBackup(ctx *cli.Context) ([]byte, bool, error) {
+	var (
+		numSet  int
+		backup  []byte
+		isMulti bool
+		err     error
+	)
+	singleBackup, err := readArg(ctx.String("single_backup"))
+	if err != nil {
+		return nil, false, err
+	}
+	multiBackup, err := readArg(ctx.String("multi_backup"))
+	if err != nil {
+		return nil, false, err
+	}
+
+	if singleBackup != "" {
+		numSet++
+		backup, err = hex.DecodeString(singleBackup)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	if multiBackup != "" {
+		numSet++
+		isMulti = true
+		backup, err = hex.DecodeString(multiBackup)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	if ctx.String("multi_file") != "" {
+		numSet++
+		isMulti = true
+		backup, err = ioutil.ReadFile(ctx.String("multi_file"))
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	if numSet != 1 {
+		return nil, false, fmt.Errorf("exactly one of " +
+			"--single_backup, --multi_backup and --multi_file " +
+			"must be set")
+	}
+
+	return backup, isMulti, nil
+}
+
+func restoreChanBackup(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	backup, isMulti, err := parseChanBackup(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := &lnrpc.RestoreChanBackupRequest{}
+	if isMulti {
+		req.Backup = &lnrpc.RestoreChanBackupRequest_MultiChanBackup{
+			MultiChanBackup: backup,
+		}
+	} else {
+		req.Backup = &lnrpc.RestoreChanBackupRequest_ChanBackups{
+			ChanBackups: &lnrpc.ChannelBackups{
+				ChanBackups: []*lnrpc.ChannelBackup{
+					{ChanBackup: backup},
+				},
+			},
+		}
+	}
+
+	resp, err := client.RestoreChannelBackups(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var VerifyChanBackupCommand = cli.Command{
+	Name: "verifychanbackup",
+	Description: "Verify that an encrypted static backup was created by " +
+		"this node's wallet and is of a known format, without " +
+		"restoring any channels.",
+	Usage: "verifychanbackup --single_backup=X | --multi_backup=X | " +
+		"--multi_file=F",
+	Flags:  chanBackupFlags,
+	Action: verifyChanBackup,
+}
+
+func verifyChanBackup(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	backup, isMulti, err := parseChanBackup(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := &lnrpc.ChanBackupSnapshot{}
+	if isMulti {
+		req.MultiChanBackup = &lnrpc.MultiChanBackup{
+			MultiChanBackup: backup,
+		}
+	} else {
+		req.SingleChanBackups = &lnrpc.ChannelBackups{
+			ChanBackups: []*lnrpc.ChannelBackup{
+				{ChanBackup: backup},
+			},
+		}
+	}
+
+	resp, err := client.VerifyChanBackup(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	chanPoints := make([]string, 0, len(resp.ChanPoints))
+	for _, chanPoint := range resp.ChanPoints {
+		txid, err := chainhash.NewHash(chanPoint.FundingTxid)
+		if err != nil {
+			return err
+		}
+		chanPoints = append(chanPoints, fmt.Sprintf("%v:%v", txid,
+			chanPoint.OutputIndex))
+	}
+
+	printRespJson(struct {
+		ChanPoints []string `json:"chan_points"`
+	}{
+		ChanPoints: chanPoints,
+	})
+	return nil
+}
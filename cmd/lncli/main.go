@@ -8,6 +8,7 @@ import (
 	"github.com/urfave/cli"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 func fatal(err error) {
@@ -20,12 +21,38 @@ func getClient(ctx *cli.Context) lnrpc.LightningClient {
 	return lnrpc.NewLightningClient(conn)
 }
 
+func getStateClient(ctx *cli.Context) lnrpc.StateClient {
+	conn := getClientConn(ctx)
+	return lnrpc.NewStateClient(conn)
+}
+
+func getAutopilotClient(ctx *cli.Context) lnrpc.AutopilotClient {
+	conn := getClientConn(ctx)
+	return lnrpc.NewAutopilotClient(conn)
+}
+
+func getChainKitClient(ctx *cli.Context) lnrpc.ChainKitClient {
+	conn := getClientConn(ctx)
+	return lnrpc.NewChainKitClient(conn)
+}
+
 func getClientConn(ctx *cli.Context) *grpc.ClientConn {
 	// TODO(roasbeef): macaroon based auth
 	// * http://www.grpc.io/docs/guides/auth.html
 	// * http://research.google.com/pubs/pub41892.html
 	// * https://github.com/go-macaroon/macaroon
+
+	// If the daemon's TLS certificate is set, then the connection to it is
+	// encrypted and the daemon authenticated with the certificate.
 	opts := []grpc.DialOption{grpc.WithInsecure()}
+	if certPath := ctx.GlobalString("tlscertpath"); certPath != "" {
+		creds, err := credentials.NewClientTLSFromFile(certPath, "")
+		if err != nil {
+			fatal(fmt.Errorf("unable to read TLS certificate: %v",
+				err))
+		}
+		opts = []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	}
 
 	conn, err := grpc.Dial(ctx.GlobalString("rpcserver"), opts...)
 	if err != nil {
@@ -46,6 +73,11 @@ func main() {
 			Value: "localhost:10009",
 			Usage: "host:port of ln daemon",
 		},
+		cli.StringFlag{
+			Name: "tlscertpath",
+			Usage: "path to the TLS certificate served by ln " +
+				"daemon, required if it's set with --tlscertpath",
+		},
 	}
 	app.Commands = []cli.Command{
 		NewAddressCommand,
@@ -54,22 +86,78 @@ func main() {
 		ConnectCommand,
 		OpenChannelCommand,
 		CloseChannelCommand,
+		AbandonChannelCommand,
 		ListPeersCommand,
+		SubscribePeerEventsCommand,
+		UpdatePeerAccessCommand,
+		ListPeerAccessCommand,
 		WalletBalanceCommand,
 		ChannelBalanceCommand,
+		GetChanBalanceCommand,
 		GetInfoCommand,
+		SetAliasCommand,
+		UpdateNodeAnnouncementCommand,
 		PendingChannelsCommand,
 		SendPaymentCommand,
+		PayInvoiceCommand,
 		AddInvoiceCommand,
 		LookupInvoiceCommand,
+		DecodePayReqCommand,
 		ListInvoicesCommand,
+		SubscribeInvoicesCommand,
 		ListChannelsCommand,
+		SubscribeChannelEventsCommand,
+		SubscribeHtlcEventsCommand,
 		ListPaymentsCommand,
+		TrackPaymentCommand,
+		DeletePaymentsCommand,
 		DescribeGraphCommand,
 		GetChanInfoCommand,
 		GetNodeInfoCommand,
+		GetNodeAddressesCommand,
+		SubscribeChannelGraphCommand,
 		QueryRouteCommand,
+		EstimateRouteFeeCommand,
 		GetNetworkInfoCommand,
+		SendCustomCommand,
+		SubscribeCustomCommand,
+		ListUnspentCommand,
+		ListAddressesCommand,
+		EstimateFeeCommand,
+		PublishTxCommand,
+		FundPsbtCommand,
+		FinalizePsbtCommand,
+		LeaseOutputCommand,
+		ReleaseOutputCommand,
+		ListLeasesCommand,
+		ListSweepsCommand,
+		ListChainTxnsCommand,
+		SubscribeTransactionsCommand,
+		GetBestBlockCommand,
+		GetBlockHashCommand,
+		GetBlockCommand,
+		LabelTxCommand,
+		ImportAccountCommand,
+		ListAccountsCommand,
+		GetRecoveryInfoCommand,
+		DebugLevelCommand,
+		StopCommand,
+		GetDebugInfoCommand,
+		CPUProfileCommand,
+		ExportChannelDbCommand,
+		GetStateCommand,
+		AutopilotStatusCommand,
+		ModifyAutopilotCommand,
+		QueryAutopilotScoresCommand,
+		SetAutopilotScoresCommand,
+		QueryHeuristicScoresCommand,
+		ChannelInsightsCommand,
+		SetFeeManagementCommand,
+		UpdateChanStatusCommand,
+		UpgradeChannelCommand,
+		ExportChanBackupCommand,
+		RestoreChanBackupCommand,
+		VerifyChanBackupCommand,
 	}
 
 	if err := app.Run(os.Args); err != nil {
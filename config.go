@@ -1,18 +1,34 @@
 package main
 
 import (
+	"encoding/hex"
 	"fmt"
+	"image/color"
+	"io/ioutil"
+	"math"
 	"net"
 	"os"
 	"path/filepath"
+	"reflect"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	flags "github.com/btcsuite/go-flags"
+	"github.com/lightningnetwork/lnd/blockcache"
 	"github.com/lightningnetwork/lnd/brontide"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/chanstatus"
+	"github.com/lightningnetwork/lnd/healthcheck"
+	"github.com/lightningnetwork/lnd/hodl"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwallet/remotesigner"
 	"github.com/lightningnetwork/lnd/lnwire"
-	"github.com/roasbeef/btcd/btcec"
+	"github.com/lightningnetwork/lnd/monitoring"
+	"github.com/lightningnetwork/lnd/routing"
+	"github.com/roasbeef/btcd/chaincfg"
 	"github.com/roasbeef/btcutil"
 )
 
@@ -22,6 +38,8 @@ const (
 	defaultLogLevel           = "info"
 	defaultLogDirname         = "logs"
 	defaultLogFilename        = "lnd.log"
+	defaultMaxLogFiles        = 3
+	defaultMaxLogFileSize     = 10
 	defaultRPCPort            = 10009
 	defaultSPVMode            = false
 	defaultPeerPort           = 10011
@@ -30,6 +48,35 @@ const (
 	defaultRPCPass            = "passwd"
 	defaultSPVHostAdr         = "localhost:18333"
 	defaultMaxPendingChannels = 1
+	defaultMinChanSize        = 20000
+	defaultMinFundingConfs    = 1
+	defaultMaxFundingConfs    = 6
+	defaultCoinSelection      = "default"
+	defaultChangeType         = "p2wkh"
+	defaultMinHTLC            = 1
+	defaultTimeLockDelta      = 40
+	defaultColor              = "#3399ff"
+
+	defaultAutopilotMaxChannels    = 5
+	defaultAutopilotAllocation     = 0.6
+	defaultAutopilotMinChannelSize = 20000
+	defaultAutopilotMaxChannelSize = 1<<24 - 1
+	defaultAutopilotHeuristic      = "preferential"
+
+	defaultFeeManagerBaseFee           = 1000
+	defaultFeeManagerMinFeeRate        = 1
+	defaultFeeManagerMaxFeeRate        = 5000
+	defaultFeeManagerUpdateInterval    = 10 * time.Minute
+	defaultFeeManagerMinUpdateInterval = time.Hour
+
+	defaultShutdownTimeout = 30 * time.Second
+
+	defaultFeeLimitFloor = 10
+
+	defaultReputationMinResolved    = 10
+	defaultReputationMinSuccessRate = 0.5
+	defaultReputationMaxHoldTime    = 90 * time.Second
+	defaultReputationGeneralSlots   = 10
 )
 
 var (
@@ -53,27 +100,242 @@ type config struct {
 	DataDir    string `short:"b" long:"datadir" description:"The directory to store lnd's data within"`
 	LogDir     string `long:"logdir" description:"Directory to log output."`
 
+	MaxLogFiles    int `long:"maxlogfiles" description:"Maximum number of compressed rotated log files to keep (0 for no rotated files)"`
+	MaxLogFileSize int `long:"maxlogfilesize" description:"Maximum size in MB of the log file before it's rotated"`
+
 	Listeners   []string `long:"listen" description:"Add an interface/port to listen for connections (default all interfaces port: 10011)"`
 	ExternalIPs []string `long:"externalip" description:"Add an ip to the list of local addresses we claim to listen on to peers"`
 
-	DebugLevel string `short:"d" long:"debuglevel" description:"Logging level for all subsystems {trace, debug, info, warn, error, critical} -- You may also specify <subsystem>=<level>,<subsystem2>=<level>,... to set the log level for individual subsystems -- Use show to list available subsystems"`
+	DebugLevel string `short:"d" long:"debuglevel" description:"Logging level for all subsystems {trace, debug, info, warn, error, critical} -- You may also specify <subsystem>=<level>,<subsystem2>=<level>,... to set the log level for individual subsystems, optionally preceded by a level for all other subsystems (e.g. info,INVC=trace) -- Use show to list available subsystems"`
+
+	Profile string `long:"profile" description:"Enable HTTP profiling on given port of localhost -- NOTE port must be between 1024 and 65536"`
 
-	Profile string `long:"profile" description:"Enable HTTP profiling on given port -- NOTE port must be between 1024 and 65536"`
+	GCPercent int `long:"gcpercent" description:"The garbage collection target percentage, as with the GOGC environment variable: a collection is triggered once the heap has grown by this percentage since the previous collection. A negative value disables garbage collection. If zero, the runtime's default is used."`
 
-	PeerPort int    `long:"peerport" description:"The port to listen on for incoming p2p connections"`
-	RPCPort  int    `long:"rpcport" description:"The port for the rpc server"`
-	SPVMode  bool   `long:"spv" description:"assert to enter spv wallet mode"`
-	RPCHost  string `long:"btcdhost" description:"The btcd rpc listening address. If a port is omitted, then the default port for the selected chain parameters will be used."`
-	RPCUser  string `short:"u" long:"rpcuser" description:"Username for RPC connections"`
-	RPCPass  string `short:"P" long:"rpcpass" default-mask:"-" description:"Password for RPC connections"`
+	BlockCacheSize uint64 `long:"blockcachesize" description:"The maximum total size in bytes of the blocks cached in memory, shared between the btcd chain notifier and the wallet's GetBlock calls so that a block is only fetched from btcd once. If zero, blocks aren't cached."`
+
+	NoGraphCache bool `long:"nographcache" description:"Don't keep an in-memory copy of the channel graph for path finding. Each route search then reads the graph from disk, which is slower but reduces memory usage on constrained devices."`
+
+	BlockProfileRate int `long:"blockprofilerate" description:"Sample an average of one blocking event per the given number of nanoseconds spent blocked within the block profile served by --profile. If zero, blocking events aren't profiled."`
+
+	ShutdownTimeout time.Duration `long:"shutdowntimeout" description:"The amount of time the daemon's subsystems are given to stop once a shutdown is requested. If they haven't stopped by then, the daemon exits regardless, with exit code 2."`
+
+	PeerPort int  `long:"peerport" description:"The port to listen on for incoming p2p connections"`
+	RPCPort  int  `long:"rpcport" description:"The port for the rpc server"`
+	SPVMode  bool `long:"spv" description:"assert to enter spv wallet mode"`
+
+	TLSCertPath string `long:"tlscertpath" description:"Path to the TLS certificate served by the RPC server. If set, along with tlskeypath, then the RPC server, and the connection of the REST proxy to it, use TLS. The certificate must be valid for localhost, and for any other host the RPC server is reached through."`
+	TLSKeyPath  string `long:"tlskeypath" description:"Path to the private key of the TLS certificate served by the RPC server"`
 
-	RPCCert            string `long:"rpccert" description:"File containing btcd's certificate file"`
-	RawRPCCert         string `long:"rawrpccert" description:"The raw bytes of btcd's PEM-encoded certificate chain which will be used to authenticate the RPC connection."`
 	SPVHostAdr         string `long:"spvhostadr" description:"Address of full bitcoin node. It is used in SPV mode."`
-	TestNet3           bool   `long:"testnet" description:"Use the test network"`
-	SimNet             bool   `long:"simnet" description:"Use the simulation test network"`
 	DebugHTLC          bool   `long:"debughtlc" description:"Activate the debug htlc mode. With the debug HTLC mode, all payments sent use a pre-determined R-Hash. Additionally, all HTLC's sent to a node with the debug HTLC R-Hash are immediately settled in the next available state transition."`
 	MaxPendingChannels int    `long:"maxpendingchannels" description:"The maximum number of incoming pending channels permitted per peer."`
+	ChanReserve        int64  `long:"chanreserve" description:"The default balance in satoshis the remote party must keep within each new channel. If zero, 1% of the channel capacity is required."`
+	MaxPendingAmount   int64  `long:"maxpendingamt" description:"The default maximum total value in satoshis of the pending HTLCs the remote party may offer within each new channel. If zero, the entire channel capacity is permitted, though never more than 16777215 satoshis within wumbo channels."`
+	MinHTLC            int64  `long:"minhtlc" description:"The default smallest HTLC in satoshis the remote party may offer within each new channel."`
+	MaxAcceptedHTLCs   uint16 `long:"maxacceptedhtlcs" description:"The default maximum number of pending HTLCs the remote party may offer within each new channel."`
+	TimeLockDelta      uint32 `long:"timelockdelta" description:"The number of blocks required between the expiry of an incoming HTLC and the expiry of the HTLC forwarded in response. This value is advertised within our channel updates."`
+	MaxChanSize        int64  `long:"maxchansize" description:"The largest channel in satoshis that we'll open or accept. If zero, the largest channel permitted by the protocol is used: 16777215 satoshis, or 1000000000 satoshis if wumbo channels are enabled."`
+	MinChanSize        int64  `long:"minchansize" description:"The smallest channel in satoshis that we'll accept."`
+	MaxChansPerPeer    int    `long:"maxchansperpeer" description:"The maximum number of channels, both open and pending, permitted with a single peer. Channels proposed by a peer beyond this limit are rejected. If zero, the number of channels per peer is unlimited."`
+	MinFundingConfs    uint32 `long:"minfundingconfs" description:"The fewest confirmations of the funding transaction required before an inbound channel is considered open."`
+	MaxFundingConfs    uint32 `long:"maxfundingconfs" description:"The most confirmations of the funding transaction required before an inbound channel is considered open. Between minfundingconfs and this value, the number of confirmations required scales with the size of the channel relative to maxchansize."`
+	MaxDustExposure    int64  `long:"maxdustexposure" description:"The maximum amount in satoshis of a channel's funds which may be lost to miners on either commitment transaction: the sum of all dust HTLCs plus the commitment fee, evaluated at twice the current fee rate. New HTLCs exceeding this threshold are failed. A value of zero disables the limit."`
+
+	UpfrontShutdownAddr string `long:"upfrontshutdownaddr" description:"The address our funds are paid to upon a cooperative close of new channels, unless another is specified when opening the channel. The address is committed to during the funding workflow, and any close paying elsewhere is rejected. Only P2PKH, P2SH and P2WKH addresses are supported. If unset, a fresh wallet address is used for each channel."`
+
+	NoSelfPayments bool `long:"noselfpayments" description:"Refuse to pay our own invoices. Otherwise, payments to ourselves are settled directly against the invoice, without being routed through any channel."`
+
+	FeeLimit        int64 `long:"feelimit" description:"The default maximum total routing fee in satoshis of payments which don't specify a fee limit of their own, bounding the fees of all parts of a payment combined. If zero, then feelimitpercent applies."`
+	FeeLimitPercent int64 `long:"feelimitpercent" description:"The default maximum total routing fee of payments which don't specify a fee limit of their own, as a percentage of their amount. Only applies if feelimit isn't set. If zero, the fee of such payments isn't limited."`
+	FeeLimitFloor   int64 `long:"feelimitfloor" description:"The minimum fee limit in satoshis that percentage-based fee limits are raised to, so that tiny payments, whose percentage-based limit would be close to zero, can still be routed."`
+
+	UniformInvoiceFailures bool `long:"uniforminvoicefailures" description:"Fail every HTLC paying to us which can't be settled, whether its payment hash is unknown, its amount too low, or its expiry too soon, with the same reason and after performing the same checks, so probers can't distinguish the state of our invoices."`
+
+	InvoiceAcceptTimeout time.Duration `long:"invoiceaccepttimeout" description:"The time the invoice acceptance hooks registered over the RPC interface are given to decide on an HTLC paying one of our invoices, after which the HTLC is canceled."`
+
+	MaxOpenInvoices int `long:"maxopeninvoices" description:"The maximum number of unsettled invoices which may exist at once. Invoices added beyond it are refused. If zero, the number is unlimited."`
+	MaxInvoiceHtlcs int `long:"maxinvoicehtlcs" description:"The maximum number of accepted HTLCs which may be pending settlement for a single invoice. HTLCs beyond it are canceled. If zero, the number is unlimited."`
+
+	GraphValidationWorkers int `long:"graphvalidationworkers" description:"The maximum number of channel and node announcements validated in parallel. Announcements depending on each other are still processed in the order they arrived. If zero, four workers per CPU are used."`
+
+	CustomMessageRanges []string `long:"custommessagerange" description:"Add a range of custom peer message types (e.g. 32768-32800, or a single type such as 40000) that applications may send and receive over the RPC interface. If unset, the entire custom message range is permitted."`
+
+	// customMsgRanges is the parsed form of CustomMessageRanges.
+	customMsgRanges []customMsgRange
+
+	BanPeers      []string `long:"banpeer" description:"Refuse connections and channels from a peer, identified by its hex encoded public key or by its IP address, may be specified multiple times. Peers may also be banned over the RPC interface, in which case the ban is persisted."`
+	AllowPeers    []string `long:"allowpeer" description:"Add a peer, identified by its hex encoded public key or by its IP address, to the allowlist, may be specified multiple times."`
+	AllowlistOnly bool     `long:"allowlistonly" description:"Refuse connections and channels from any peer which isn't allowlisted, as private deployments may require."`
+
+	// bannedPeers and allowedPeers are the parsed forms of BanPeers and
+	// AllowPeers.
+	bannedPeers  []*channeldb.PeerAccessEntry
+	allowedPeers []*channeldb.PeerAccessEntry
+
+	CoinSelectionStrategy string `long:"coinselectionstrategy" description:"The strategy used to choose the coins of the wallet funding on-chain transactions and channels, unless a request specifies its own. The default strategy considers the coins in the order the wallet lists them {default, largest, random, smallest}"`
+
+	// coinSelectionStrategy is the parsed form of CoinSelectionStrategy.
+	coinSelectionStrategy lnwallet.CoinSelectionStrategy
+
+	ChangeType string `long:"changetype" description:"The type of the addresses the change of transactions funded by the wallet is sent to {p2wkh, np2wkh}"`
+
+	// changeType is the parsed form of ChangeType.
+	changeType lnwallet.AddressType
+
+	RecoveryWindow          uint32 `long:"recoverywindow" description:"The number of addresses of each branch of the wallet to derive ahead of time when restoring the wallet. If non-zero, the chain is rescanned from the wallet birthday on start up so that the funds of a wallet restored from its seed are found."`
+	ResetWalletTransactions bool   `long:"reset-wallet-transactions" description:"Rescan the chain from the wallet birthday on start up, re-populating the wallet's transaction history."`
+
+	RemoteSigner            bool          `long:"remotesigner" description:"Run in watch-only mode, forwarding the derivation of all keys, including the node identity key and the keys of the on-chain wallet, and all signing operations to a remote lnd signer instance."`
+	RemoteSignerHost        string        `long:"remotesignerhost" description:"The host:port of the gRPC interface of the remote signer"`
+	RemoteSignerTLSCertPath string        `long:"remotesignertlscertpath" description:"Path to the TLS certificate served by the gRPC interface of the remote signer, which must use TLS"`
+	RemoteSignerTimeout     time.Duration `long:"remotesignertimeout" description:"The timeout of each request sent to the remote signer"`
+	RemoteSignerAccounts    []string      `long:"remotesigneraccount" description:"A key family the remote signer may derive keys within {multisig, paymentbase, staticbackup, nodekey}, may be specified multiple times. If unset, all key families are permitted."`
+
+	// remoteSignerFamilies is the parsed form of RemoteSignerAccounts.
+	remoteSignerFamilies []keychain.KeyFamily
+
+	SignerAccounts []string `long:"signeraccount" description:"When acting as the remote signer of a watch-only node, a key family the watch-only node may derive keys and request signatures within {multisig, paymentbase, staticbackup, nodekey}, may be specified multiple times. If unset, all key families are permitted."`
+
+	// signerFamilies is the parsed form of SignerAccounts.
+	signerFamilies []keychain.KeyFamily
+
+	Alias string `long:"alias" description:"The node alias, used as a human readable identifier within the node announcement. If unset, a prefix of the node's public key is used."`
+	Color string `long:"color" description:"The color of the node within the node announcement, in hex format (e.g. #3399ff)."`
+
+	// color is the parsed form of Color.
+	color color.RGBA
+
+	Hodl *hodl.Config `group:"hodl" namespace:"hodl"`
+
+	Prometheus *monitoring.Config `group:"prometheus" namespace:"prometheus"`
+
+	HealthChecks *healthcheck.Config `group:"healthcheck" namespace:"healthcheck"`
+
+	// The following options are the deprecated names of options which
+	// have moved into the Bitcoin and btcd sections. They're still
+	// accepted, so that existing command lines and config files keep
+	// working, but a warning is logged for each one used.
+	DeprecatedRPCHost    string `long:"btcdhost" description:"Deprecated, use btcd.rpchost instead"`
+	DeprecatedRPCUser    string `short:"u" long:"rpcuser" description:"Deprecated, use btcd.rpcuser instead"`
+	DeprecatedRPCPass    string `short:"P" long:"rpcpass" default-mask:"-" description:"Deprecated, use btcd.rpcpass instead"`
+	DeprecatedRPCCert    string `long:"rpccert" description:"Deprecated, use btcd.rpccert instead"`
+	DeprecatedRawRPCCert string `long:"rawrpccert" description:"Deprecated, use btcd.rawrpccert instead"`
+	DeprecatedTestNet3   bool   `long:"testnet" description:"Deprecated, use bitcoin.testnet instead"`
+	DeprecatedSimNet     bool   `long:"simnet" description:"Deprecated, use bitcoin.simnet instead"`
+
+	Bitcoin *chainConfig `group:"Bitcoin" namespace:"bitcoin"`
+
+	Btcd *btcdConfig `group:"btcd" namespace:"btcd"`
+
+	Autopilot *autopilotConfig `group:"Autopilot" namespace:"autopilot"`
+
+	FeeManager *feeManagerConfig `group:"FeeManager" namespace:"feemanager"`
+
+	ChanStatus *chanStatusConfig `group:"ChanStatus" namespace:"chanstatus"`
+
+	Routing *routingConfig `group:"Routing" namespace:"routing"`
+
+	HtlcLimits *htlcLimitsConfig `group:"HtlcLimits" namespace:"htlclimits"`
+
+	Reputation *reputationConfig `group:"Reputation" namespace:"reputation"`
+
+	Protocol *protocolConfig `group:"Protocol" namespace:"protocol"`
+}
+
+// chainConfig houses the options selecting the network of the chain lnd
+// operates upon. At most one network may be selected, with the test network
+// used if none is.
+type chainConfig struct {
+	TestNet3 bool `long:"testnet" description:"Use the test network"`
+	SimNet   bool `long:"simnet" description:"Use the simulation test network"`
+	RegTest  bool `long:"regtest" description:"Use the regression test network"`
+	SigNet   bool `long:"signet" description:"Use the signet test network"`
+
+	SigNetChallenge string `long:"signetchallenge" description:"The hex encoded challenge script blocks of a custom signet must satisfy. If unset, the default, global signet is used."`
+}
+
+// btcdConfig houses the options used to connect to the btcd instance backing
+// lnd's wallet and chain notifications.
+type btcdConfig struct {
+	RPCHost    string `long:"rpchost" description:"The btcd rpc listening address. If a port is omitted, then the default port for the selected chain parameters will be used."`
+	RPCUser    string `long:"rpcuser" description:"Username for RPC connections to btcd"`
+	RPCPass    string `long:"rpcpass" default-mask:"-" description:"Password for RPC connections to btcd"`
+	RPCCert    string `long:"rpccert" description:"File containing btcd's certificate file"`
+	RawRPCCert string `long:"rawrpccert" description:"The raw bytes of btcd's PEM-encoded certificate chain which will be used to authenticate the RPC connection."`
+}
+
+// autopilotConfig houses the options of the autopilot agent, which opens
+// channels automatically on behalf of the node.
+type autopilotConfig struct {
+	Active         bool    `long:"active" description:"If the autopilot agent should be active or not."`
+	MaxChannels    uint32  `long:"maxchannels" description:"The maximum number of channels that should be created"`
+	Allocation     float64 `long:"allocation" description:"The percentage of total funds that should be committed to automatic channel establishment, as a fraction between 0 and 1"`
+	MinChannelSize int64   `long:"minchansize" description:"The smallest channel that the autopilot agent should create, in satoshis"`
+	MaxChannelSize int64   `long:"maxchansize" description:"The largest channel that the autopilot agent should create, in satoshis"`
+
+	Heuristic map[string]float64 `long:"heuristic" description:"A heuristic to score nodes with, and the weight given to its scores, e.g. preferential:0.6. May be specified multiple times, in which case the weights must sum to 1. The available heuristics are preferential and externalscore, the latter being set over RPC. Defaults to preferential:1"`
+}
+
+// feeManagerConfig houses the options of the fee manager, which adjusts the
+// forwarding fees of the channels that opted into it based on their liquidity
+// and recent flow.
+type feeManagerConfig struct {
+	Active            bool          `long:"active" description:"If the fee manager should be active or not. Channels must still opt into fee management individually over RPC."`
+	BaseFee           int64         `long:"basefee" description:"The base fee that managed channels should charge, in millisatoshi"`
+	MinFeeRate        int64         `long:"minfeerate" description:"The lowest fee rate that managed channels should charge, in millionths, used when their local balance is plentiful"`
+	MaxFeeRate        int64         `long:"maxfeerate" description:"The highest fee rate that managed channels should charge, in millionths, used when their local balance is depleted"`
+	UpdateInterval    time.Duration `long:"updateinterval" description:"The interval at which the fees of managed channels are re-evaluated"`
+	MinUpdateInterval time.Duration `long:"minupdateinterval" description:"The minimum amount of time between two fee updates of a single channel, limiting the rate of channel updates broadcast to the network"`
+}
+
+// chanStatusConfig houses the options of the channel status manager, which
+// disables our channels while their peers are offline.
+type chanStatusConfig struct {
+	DisableTimeout time.Duration `long:"disabletimeout" description:"The amount of time a peer must be offline before its channels are disabled, unless their status was set manually"`
+	EnableTimeout  time.Duration `long:"enabletimeout" description:"The amount of time a peer must stay online after reconnecting before its disabled channels are re-enabled"`
+}
+
+// routingConfig houses the options of path finding.
+type routingConfig struct {
+	ProbabilityEstimator  string  `long:"probabilityestimator" description:"The model used to estimate the probability that payments are forwarded over each channel, which path finding takes into account {apriori, bimodal}"`
+	AprioriHopProbability float64 `long:"apriori.hopprobability" description:"The probability the apriori model assumes payments are forwarded over any channel with, within (0, 1]"`
+	BimodalScale          int64   `long:"bimodal.scale" description:"The scale of the liquidity distribution assumed by the bimodal model, in satoshis. The smaller the scale compared to the capacity of a channel, the more its liquidity is assumed to be found on either of its sides"`
+
+	// probabilityEstimator is the estimator selected by the options
+	// above.
+	probabilityEstimator routing.ProbabilityEstimator
+}
+
+// htlcLimitsConfig houses the limits imposed upon the HTLC's the switch
+// forwards, protecting our channels from being jammed by peers holding on to
+// many HTLC's, or to large ones. A limit of zero is disabled.
+type htlcLimitsConfig struct {
+	MaxHtlcs     int   `long:"maxhtlcs" description:"The maximum number of forwarded HTLC's in flight across all channels. If zero, the number is unlimited."`
+	MaxValue     int64 `long:"maxvalue" description:"The maximum value of the forwarded HTLC's in flight across all channels, in satoshis. If zero, the value is unlimited."`
+	MaxPeerHtlcs int   `long:"maxpeerhtlcs" description:"The maximum number of forwarded HTLC's in flight which arrived from a single peer. If zero, the number is unlimited."`
+	MaxPeerValue int64 `long:"maxpeervalue" description:"The maximum value of the forwarded HTLC's in flight which arrived from a single peer, in satoshis. If zero, the value is unlimited."`
+	MaxChanHtlcs int   `long:"maxchanhtlcs" description:"The maximum number of forwarded HTLC's in flight which arrived over a single channel. If zero, the number is unlimited."`
+	MaxChanValue int64 `long:"maxchanvalue" description:"The maximum value of the forwarded HTLC's in flight which arrived over a single channel, in satoshis. If zero, the value is unlimited."`
+}
+
+// reputationConfig houses the parameters of the local reputation the switch
+// tracks for each peer. Only the endorsements of the peers with a good
+// reputation are relayed, while unendorsed HTLC's may optionally be
+// restricted to a bounded number of slots and amount of liquidity of each
+// outgoing channel.
+type reputationConfig struct {
+	MinResolved        int           `long:"minresolved" description:"The minimum number of forwarded HTLC's of a peer which must have been resolved before its endorsements are relayed"`
+	MinSuccessRate     float64       `long:"minsuccessrate" description:"The minimum fraction of the resolved HTLC's of a peer which must have been settled for its endorsements to be relayed"`
+	MaxHoldTime        time.Duration `long:"maxholdtime" description:"The maximum average time the HTLC's of a peer may be held until resolved for its endorsements to be relayed"`
+	RestrictUnendorsed bool          `long:"restrictunendorsed" description:"If set, then the unendorsed HTLC's forwarded over each channel are restricted to the general slots and liquidity"`
+	GeneralSlots       int           `long:"generalslots" description:"The maximum number of unendorsed HTLC's in flight over each outgoing channel. If zero, the number is unlimited."`
+	GeneralValue       int64         `long:"generalvalue" description:"The maximum value of the unendorsed HTLC's in flight over each outgoing channel, in satoshis. If zero, the value is unlimited."`
+}
+
+// protocolConfig houses the options enabling optional protocol features.
+type protocolConfig struct {
+	WumboChannels bool `long:"wumbo-channels" description:"If set, then lnd will open and accept channels larger than 16777215 satoshis with peers which also support them, up to the maxchansize option"`
 }
 
 // loadConfig initializes and parses the config using a config file and command
@@ -82,23 +344,97 @@ type config struct {
 // The configuration proceeds as follows:
 // 	1) Start with a default config with sane settings
 // 	2) Pre-parse the command line to check for an alternative config file
-// 	3) Load configuration file overwriting defaults with any specified options
+// 	3) Load configuration file overwriting defaults with any specified options,
+// 	   expanding any environment variables within the values of the file
 // 	4) Parse CLI options and overwrite/add any specified options
+//
+// As a result, options given on the command line take precedence over those
+// within the config file, which in turn take precedence over the defaults.
 func loadConfig() (*config, error) {
 	defaultCfg := config{
 		ConfigFile:         defaultConfigFile,
 		DataDir:            defaultDataDir,
 		DebugLevel:         defaultLogLevel,
 		LogDir:             defaultLogDir,
+		MaxLogFiles:        defaultMaxLogFiles,
+		MaxLogFileSize:     defaultMaxLogFileSize,
 		PeerPort:           defaultPeerPort,
 		RPCPort:            defaultRPCPort,
 		SPVMode:            defaultSPVMode,
-		RPCHost:            defaultRPCHost,
-		RPCUser:            defaultRPCUser,
-		RPCPass:            defaultRPCPass,
-		RPCCert:            defaultRPCCertFile,
 		SPVHostAdr:         defaultSPVHostAdr,
 		MaxPendingChannels: defaultMaxPendingChannels,
+		MinChanSize:        defaultMinChanSize,
+		MinFundingConfs:    defaultMinFundingConfs,
+		MaxFundingConfs:    defaultMaxFundingConfs,
+		MinHTLC:            defaultMinHTLC,
+		MaxAcceptedHTLCs:   lnwallet.DefaultMaxAcceptedHTLCs,
+		MaxDustExposure:    int64(lnwallet.DefaultMaxDustExposure),
+		TimeLockDelta:      defaultTimeLockDelta,
+		ShutdownTimeout:    defaultShutdownTimeout,
+		FeeLimitFloor:      defaultFeeLimitFloor,
+
+		InvoiceAcceptTimeout: defaultInvoiceAcceptTimeout,
+
+		CoinSelectionStrategy: defaultCoinSelection,
+		ChangeType:            defaultChangeType,
+
+		BlockCacheSize: blockcache.DefaultCapacity,
+
+		RemoteSignerTimeout: remotesigner.DefaultTimeout,
+
+		Color: defaultColor,
+
+		Hodl: &hodl.Config{},
+
+		Prometheus: &monitoring.Config{},
+
+		HealthChecks: healthcheck.DefaultConfig(),
+
+		Bitcoin: &chainConfig{},
+
+		Btcd: &btcdConfig{
+			RPCHost: defaultRPCHost,
+			RPCUser: defaultRPCUser,
+			RPCPass: defaultRPCPass,
+			RPCCert: defaultRPCCertFile,
+		},
+
+		Autopilot: &autopilotConfig{
+			MaxChannels:    defaultAutopilotMaxChannels,
+			Allocation:     defaultAutopilotAllocation,
+			MinChannelSize: defaultAutopilotMinChannelSize,
+			MaxChannelSize: defaultAutopilotMaxChannelSize,
+		},
+
+		FeeManager: &feeManagerConfig{
+			BaseFee:           defaultFeeManagerBaseFee,
+			MinFeeRate:        defaultFeeManagerMinFeeRate,
+			MaxFeeRate:        defaultFeeManagerMaxFeeRate,
+			UpdateInterval:    defaultFeeManagerUpdateInterval,
+			MinUpdateInterval: defaultFeeManagerMinUpdateInterval,
+		},
+
+		ChanStatus: &chanStatusConfig{
+			DisableTimeout: chanstatus.DefaultDisableTimeout,
+			EnableTimeout:  chanstatus.DefaultEnableTimeout,
+		},
+
+		Routing: &routingConfig{
+			ProbabilityEstimator:  routing.AprioriEstimatorName,
+			AprioriHopProbability: routing.DefaultAprioriHopProbability,
+			BimodalScale:          int64(routing.DefaultBimodalScale),
+		},
+
+		HtlcLimits: &htlcLimitsConfig{},
+
+		Reputation: &reputationConfig{
+			MinResolved:    defaultReputationMinResolved,
+			MinSuccessRate: defaultReputationMinSuccessRate,
+			MaxHoldTime:    defaultReputationMaxHoldTime,
+			GeneralSlots:   defaultReputationGeneralSlots,
+		},
+
+		Protocol: &protocolConfig{},
 	}
 
 	// Pre-parse the command line options to pick up an alternative config
@@ -136,10 +472,19 @@ func loadConfig() (*config, error) {
 		return nil, err
 	}
 
-	// Next, load any additional configuration options from the file.
+	// Next, load any additional configuration options from the file. A
+	// missing config file is only an error if one was explicitly
+	// specified.
 	cfg := defaultCfg
-	if err := flags.IniParse(preCfg.ConfigFile, &cfg); err != nil {
-		fmt.Fprintln(os.Stderr, err)
+	configFile := cleanAndExpandPath(preCfg.ConfigFile)
+	if err := parseConfigFile(configFile, &cfg); err != nil {
+		if !os.IsNotExist(err) || preCfg.ConfigFile != defaultConfigFile {
+			str := "%s: Unable to load config file %v: %v"
+			err := fmt.Errorf(str, funcName, configFile, err)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, err
+		}
 	}
 
 	// Finally, parse the remaining command line options again to ensure
@@ -148,25 +493,88 @@ func loadConfig() (*config, error) {
 		return nil, err
 	}
 
-	// Multiple networks can't be selected simultaneously.
-	// Count number of network flags passed; assign active network params
-	// while we're at it
-	numNets := 0
-	if cfg.TestNet3 {
-		numNets++
+	// Carry the values of any deprecated options over to the options
+	// which replaced them, so they're validated along with the rest.
+	deprecatedOpts := applyDeprecatedOptions(&cfg)
+
+	// Multiple networks can't be selected simultaneously. Record each
+	// network selected; assign active network params while we're at it.
+	var selectedNets []string
+	if cfg.Bitcoin.TestNet3 {
+		selectedNets = append(selectedNets, "bitcoin.testnet")
 		activeNetParams = testNetParams
 	}
-	if cfg.SimNet {
-		numNets++
+	if cfg.Bitcoin.SimNet {
+		selectedNets = append(selectedNets, "bitcoin.simnet")
 		activeNetParams = simNetParams
 	}
-	if numNets > 1 {
-		str := "%s: The testnet, segnet, and simnet params can't be " +
-			"used together -- choose one of the three"
+	if cfg.Bitcoin.RegTest {
+		selectedNets = append(selectedNets, "bitcoin.regtest")
+		activeNetParams = regTestNetParams
+	}
+	if cfg.Bitcoin.SigNet {
+		selectedNets = append(selectedNets, "bitcoin.signet")
+
+		challenge := cfg.Bitcoin.SigNetChallenge
+		if challenge == "" {
+			challenge = defaultSignetChallenge
+		}
+		challengeScript, err := hex.DecodeString(challenge)
+		if err != nil || len(challengeScript) == 0 {
+			str := "%s: The bitcoin.signetchallenge option must be " +
+				"a hex encoded script: %v"
+			err := fmt.Errorf(str, funcName, challenge)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, err
+		}
+		activeNetParams = newSignetParams(challengeScript)
+	}
+	if len(selectedNets) > 1 {
+		str := "%s: The %v options can't be used together -- choose " +
+			"one of them"
+		err := fmt.Errorf(str, funcName,
+			strings.Join(selectedNets, " and "))
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	if cfg.Bitcoin.SigNetChallenge != "" && !cfg.Bitcoin.SigNet {
+		str := "%s: The bitcoin.signetchallenge option requires the " +
+			"bitcoin.signet option"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// btcd, our only chain backend, doesn't know of the signet network,
+	// so it can't serve the chain of a signet.
+	if cfg.Bitcoin.SigNet {
+		str := "%s: The bitcoin.signet option isn't supported by the " +
+			"btcd chain backend -- choose bitcoin.testnet, " +
+			"bitcoin.simnet or bitcoin.regtest instead"
 		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
 		return nil, err
 	}
 
+	// Unless btcd's certificate is given directly, it must be readable
+	// from the configured certificate file.
+	if cfg.Btcd.RawRPCCert == "" {
+		cfg.Btcd.RPCCert = cleanAndExpandPath(cfg.Btcd.RPCCert)
+		if _, err := os.Stat(cfg.Btcd.RPCCert); err != nil {
+			str := "%s: Unable to read btcd's certificate: %v -- " +
+				"set the btcd.rpccert option to the location " +
+				"of btcd's rpc.cert, or set btcd.rawrpccert"
+			err := fmt.Errorf(str, funcName, err)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, err
+		}
+	}
+
 	// Validate profile port number
 	if cfg.Profile != "" {
 		profilePort, err := strconv.Atoi(cfg.Profile)
@@ -179,22 +587,460 @@ func loadConfig() (*config, error) {
 		}
 	}
 
+	if cfg.GraphValidationWorkers < 0 {
+		str := "%s: The number of graph validation workers must not " +
+			"be negative"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	if cfg.BlockProfileRate < 0 {
+		str := "%s: The block profile rate must not be negative"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	if cfg.ShutdownTimeout <= 0 {
+		str := "%s: The shutdown timeout must be positive"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	if cfg.InvoiceAcceptTimeout <= 0 {
+		str := "%s: The invoice accept timeout must be positive"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	if cfg.MaxOpenInvoices < 0 || cfg.MaxInvoiceHtlcs < 0 {
+		str := "%s: The maxopeninvoices and maxinvoicehtlcs options " +
+			"must not be negative"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// Parse the custom message type ranges applications are permitted to
+	// exchange with our peers.
+	customMsgRanges, err := parseCustomMsgRanges(cfg.CustomMessageRanges)
+	if err != nil {
+		err := fmt.Errorf("%s: %v", funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	cfg.customMsgRanges = customMsgRanges
+
+	// Parse the peers banned from, or allowlisted for, connecting and
+	// opening channels with us.
+	cfg.bannedPeers, err = parsePeerAccessEntries(cfg.BanPeers)
+	if err != nil {
+		err := fmt.Errorf("%s: invalid banned peer: %v", funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	cfg.allowedPeers, err = parsePeerAccessEntries(cfg.AllowPeers)
+	if err != nil {
+		err := fmt.Errorf("%s: invalid allowed peer: %v", funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// Parse the default coin selection strategy of the wallet.
+	cfg.coinSelectionStrategy, err = lnwallet.ParseCoinSelectionStrategy(
+		cfg.CoinSelectionStrategy)
+	if err != nil {
+		err := fmt.Errorf("%s: %v", funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// Parse the type of the wallet's change addresses. Change can't be
+	// sent to legacy p2pkh addresses, as the wallet only spends witness
+	// outputs.
+	cfg.changeType, err = lnwallet.ParseAddressType(cfg.ChangeType)
+	if err == nil && cfg.changeType == lnwallet.PubKeyHash {
+		err = fmt.Errorf("change type %q is not supported",
+			cfg.ChangeType)
+	}
+	if err != nil {
+		err := fmt.Errorf("%s: %v", funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// Validate the default channel constraints imposed upon the remote
+	// party of new channels.
+	switch {
+	case cfg.ChanReserve < 0 || cfg.MaxPendingAmount < 0 || cfg.MinHTLC < 0:
+		str := "%s: The chanreserve, maxpendingamt, and minhtlc " +
+			"options must not be negative"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+
+	case cfg.MaxAcceptedHTLCs == 0 ||
+		cfg.MaxAcceptedHTLCs > lnwallet.MaxHTLCNumber:
+
+		str := "%s: The maxacceptedhtlcs option must be between 1 " +
+			"and %d"
+		err := fmt.Errorf(str, funcName, lnwallet.MaxHTLCNumber)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// Ensure the maximum channel size is within the limit permitted by
+	// the protocol, which is only lifted if wumbo channels are enabled.
+	maxChanSize := lnwallet.MaxBtcFundingAmount
+	if cfg.Protocol.WumboChannels {
+		maxChanSize = lnwallet.MaxBtcFundingAmountWumbo
+	}
+	switch {
+	case cfg.MaxChanSize == 0:
+		cfg.MaxChanSize = int64(maxChanSize)
+
+	case cfg.MaxChanSize < 0 || btcutil.Amount(cfg.MaxChanSize) > maxChanSize:
+		str := "%s: The maxchansize option must be positive, and at " +
+			"most %d satoshis unless wumbo channels are enabled " +
+			"with the protocol.wumbo-channels option, in which " +
+			"case it may be up to %d satoshis"
+		err := fmt.Errorf(str, funcName, lnwallet.MaxBtcFundingAmount,
+			lnwallet.MaxBtcFundingAmountWumbo)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// Ensure the policy towards inbound channels is sane: the minimum
+	// channel size can't exceed the maximum, and the scaling of the
+	// required funding confirmations needs a non-empty range.
+	switch {
+	case cfg.MinChanSize < 0 || cfg.MinChanSize > cfg.MaxChanSize:
+		str := "%s: The minchansize option must be positive, and no " +
+			"greater than maxchansize"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+
+	case cfg.MaxChansPerPeer < 0:
+		str := "%s: The maxchansperpeer option must be positive"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+
+	case cfg.MinFundingConfs == 0 ||
+		cfg.MinFundingConfs > cfg.MaxFundingConfs:
+		str := "%s: The minfundingconfs option must be at least 1, " +
+			"and no greater than maxfundingconfs"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// Ensure the upfront shutdown address, if any, is one we'll be able to
+	// commit to during the funding workflow.
+	_, err = parseUpfrontShutdownAddr(cfg.UpfrontShutdownAddr)
+	if err != nil {
+		str := "%s: Invalid upfrontshutdownaddr: %v"
+		err := fmt.Errorf(str, funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// The time lock delta must leave room for the expiry grace period, and
+	// must fit within the channel update announcement.
+	if cfg.TimeLockDelta <= expiryGraceDelta ||
+		cfg.TimeLockDelta > math.MaxUint16 {
+
+		str := "%s: The timelockdelta option must be between %d and %d"
+		err := fmt.Errorf(str, funcName, expiryGraceDelta+1,
+			math.MaxUint16)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	if cfg.MaxDustExposure < 0 {
+		str := "%s: The maxdustexposure option must not be negative"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	if cfg.FeeLimit < 0 || cfg.FeeLimitPercent < 0 || cfg.FeeLimitFloor < 0 {
+		str := "%s: The feelimit, feelimitpercent and feelimitfloor " +
+			"options must not be negative"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// Validate the remote signer options, parsing the key families the
+	// signer may derive keys within.
+	if cfg.RemoteSigner && (cfg.RemoteSignerHost == "" ||
+		cfg.RemoteSignerTLSCertPath == "") {
+
+		str := "%s: The remotesignerhost and remotesignertlscertpath " +
+			"options must be set when the remote signer is enabled"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	cfg.remoteSignerFamilies, err = parseKeyFamilies(
+		cfg.RemoteSignerAccounts)
+	if err != nil {
+		err := fmt.Errorf("%s: %v", funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	cfg.signerFamilies, err = parseKeyFamilies(cfg.SignerAccounts)
+	if err != nil {
+		err := fmt.Errorf("%s: %v", funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	if cfg.RemoteSignerTLSCertPath != "" {
+		cfg.RemoteSignerTLSCertPath = cleanAndExpandPath(
+			cfg.RemoteSignerTLSCertPath)
+	}
+
+	// The RPC server may only serve TLS if both its certificate and key
+	// are known.
+	if (cfg.TLSCertPath == "") != (cfg.TLSKeyPath == "") {
+		str := "%s: The tlscertpath and tlskeypath options must be " +
+			"set together"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	if cfg.TLSCertPath != "" {
+		cfg.TLSCertPath = cleanAndExpandPath(cfg.TLSCertPath)
+		cfg.TLSKeyPath = cleanAndExpandPath(cfg.TLSKeyPath)
+	}
+
+	// Ensure the alias fits within the node announcement, and parse the
+	// color we'll advertise alongside it.
+	alias, err := lnwire.NewAlias(cfg.Alias)
+	if err == nil {
+		err = alias.Validate()
+	}
+	if err != nil {
+		err := fmt.Errorf("%s: invalid alias: %v", funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	cfg.color, err = parseHexColor(cfg.Color)
+	if err != nil {
+		err := fmt.Errorf("%s: %v", funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// Validate the intervals, timeouts and attempts of the health checks.
+	if err := cfg.HealthChecks.Validate(); err != nil {
+		err := fmt.Errorf("%s: %v", funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// Ensure the autopilot agent's budget is sane.
+	switch {
+	case cfg.Autopilot.Allocation <= 0 || cfg.Autopilot.Allocation > 1:
+		str := "%s: The autopilot.allocation option must be greater " +
+			"than 0 and at most 1"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+
+	case cfg.Autopilot.MinChannelSize <= 0 ||
+		cfg.Autopilot.MaxChannelSize < cfg.Autopilot.MinChannelSize:
+
+		str := "%s: The autopilot.minchansize option must be " +
+			"positive, and no greater than autopilot.maxchansize"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+
+	case cfg.Autopilot.MaxChannelSize > cfg.MaxChanSize:
+		str := "%s: The autopilot.maxchansize option must be no " +
+			"greater than maxchansize"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	if len(cfg.Autopilot.Heuristic) == 0 {
+		cfg.Autopilot.Heuristic = map[string]float64{
+			defaultAutopilotHeuristic: 1,
+		}
+	}
+	if _, err := newAutopilotHeuristic(cfg.Autopilot.Heuristic); err != nil {
+		err := fmt.Errorf("%s: invalid autopilot.heuristic: %v",
+			funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// Ensure the fee manager's bounds and intervals are sane.
+	switch {
+	case cfg.FeeManager.BaseFee < 0 || cfg.FeeManager.MinFeeRate < 0 ||
+		cfg.FeeManager.MaxFeeRate < cfg.FeeManager.MinFeeRate:
+
+		str := "%s: The feemanager.basefee and feemanager.minfeerate " +
+			"options must not be negative, and feemanager.minfeerate " +
+			"must be no greater than feemanager.maxfeerate"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+
+	case cfg.FeeManager.UpdateInterval <= 0 ||
+		cfg.FeeManager.MinUpdateInterval <= 0:
+
+		str := "%s: The feemanager.updateinterval and " +
+			"feemanager.minupdateinterval options must be positive"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// Ensure the channel status manager's timeouts are sane.
+	if cfg.ChanStatus.DisableTimeout <= 0 ||
+		cfg.ChanStatus.EnableTimeout <= 0 {
+
+		str := "%s: The chanstatus.disabletimeout and " +
+			"chanstatus.enabletimeout options must be positive"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// Select the probability estimator used by path finding, ensuring
+	// the parameters of its model are sane.
+	cfg.Routing.probabilityEstimator, err = routing.NewProbabilityEstimator(
+		cfg.Routing.ProbabilityEstimator,
+		cfg.Routing.AprioriHopProbability,
+		btcutil.Amount(cfg.Routing.BimodalScale),
+	)
+	if err != nil {
+		err := fmt.Errorf("%s: invalid routing options: %v", funcName,
+			err)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// Ensure the HTLC limits of the switch are sane.
+	limits := cfg.HtlcLimits
+	if limits.MaxHtlcs < 0 || limits.MaxValue < 0 ||
+		limits.MaxPeerHtlcs < 0 || limits.MaxPeerValue < 0 ||
+		limits.MaxChanHtlcs < 0 || limits.MaxChanValue < 0 {
+
+		str := "%s: The htlclimits options must not be negative"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// Ensure the reputation parameters are sane, and that the general
+	// slots and liquidity are bounded if unendorsed HTLC's are restricted
+	// to them.
+	reputation := cfg.Reputation
+	switch {
+	case reputation.MinResolved < 0 || reputation.MaxHoldTime < 0 ||
+		reputation.GeneralSlots < 0 || reputation.GeneralValue < 0:
+
+		err = fmt.Errorf("%s: The reputation options must not be "+
+			"negative", funcName)
+
+	case reputation.MinSuccessRate < 0 || reputation.MinSuccessRate > 1:
+		err = fmt.Errorf("%s: The reputation.minsuccessrate option "+
+			"must be between 0 and 1", funcName)
+
+	case reputation.RestrictUnendorsed && reputation.GeneralSlots == 0 &&
+		reputation.GeneralValue == 0:
+
+		err = fmt.Errorf("%s: Either the reputation.generalslots or "+
+			"reputation.generalvalue option must be set to restrict "+
+			"unendorsed HTLC's", funcName)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
 	// Append the network type to the data directory so it is "namespaced"
 	// per network. In addition to the block database, there are other
 	// pieces of data that are saved to disk such as address manager state.
 	// All data is specific to a network, so namespacing the data directory
 	// means each individual piece of serialized data does not have to
 	// worry about changing names per network and such.
-	cfg.DataDir = cleanAndExpandPath(cfg.DataDir)
-	cfg.DataDir = filepath.Join(cfg.DataDir, activeNetParams.Name)
+	network := normalizeNetwork(activeNetParams.Name)
+	cfg.DataDir, err = networkDir(cleanAndExpandPath(cfg.DataDir),
+		activeNetParams.Name, network)
+	if err != nil {
+		err := fmt.Errorf("%s: %v", funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+	}
 
 	// Append the network type to the log directory so it is "namespaced"
 	// per network in the same fashion as the data directory.
-	cfg.LogDir = cleanAndExpandPath(cfg.LogDir)
-	cfg.LogDir = filepath.Join(cfg.LogDir, activeNetParams.Name)
+	cfg.LogDir, err = networkDir(cleanAndExpandPath(cfg.LogDir),
+		activeNetParams.Name, network)
+	if err != nil {
+		err := fmt.Errorf("%s: %v", funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+	}
 
-	// Initialize logging at the default logging level.
-	initSeelogLogger(filepath.Join(cfg.LogDir, defaultLogFilename))
+	// Initialize logging at the default logging level, rotating the log
+	// file as configured.
+	if cfg.MaxLogFileSize <= 0 || cfg.MaxLogFiles < 0 {
+		str := "%s: The maxlogfilesize option must be positive, and " +
+			"the maxlogfiles option must not be negative"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	initLogRotator(filepath.Join(cfg.LogDir, defaultLogFilename),
+		int64(cfg.MaxLogFileSize)*1024*1024, cfg.MaxLogFiles)
 	setLogLevels(defaultLogLevel)
 
 	// Parse, validate, and set debug log level(s).
@@ -205,9 +1051,213 @@ func loadConfig() (*config, error) {
 		return nil, err
 	}
 
+	// Now that logging is set up, warn about each deprecated option used.
+	for _, opt := range deprecatedOpts {
+		ltndLog.Warnf("The %v option is deprecated, use %v instead",
+			opt, deprecatedOptions[opt])
+	}
+
 	return &cfg, nil
 }
 
+// deprecatedOptions maps the name of each deprecated option to the name of
+// the option replacing it.
+var deprecatedOptions = map[string]string{
+	"btcdhost":   "btcd.rpchost",
+	"rpcuser":    "btcd.rpcuser",
+	"rpcpass":    "btcd.rpcpass",
+	"rpccert":    "btcd.rpccert",
+	"rawrpccert": "btcd.rawrpccert",
+	"testnet":    "bitcoin.testnet",
+	"simnet":     "bitcoin.simnet",
+}
+
+// applyDeprecatedOptions sets the option replacing each deprecated option set
+// within cfg to the value of the deprecated option, returning the names of
+// the deprecated options set.
+func applyDeprecatedOptions(cfg *config) []string {
+	var used []string
+	if cfg.DeprecatedRPCHost != "" {
+		cfg.Btcd.RPCHost = cfg.DeprecatedRPCHost
+		used = append(used, "btcdhost")
+	}
+	if cfg.DeprecatedRPCUser != "" {
+		cfg.Btcd.RPCUser = cfg.DeprecatedRPCUser
+		used = append(used, "rpcuser")
+	}
+	if cfg.DeprecatedRPCPass != "" {
+		cfg.Btcd.RPCPass = cfg.DeprecatedRPCPass
+		used = append(used, "rpcpass")
+	}
+	if cfg.DeprecatedRPCCert != "" {
+		cfg.Btcd.RPCCert = cfg.DeprecatedRPCCert
+		used = append(used, "rpccert")
+	}
+	if cfg.DeprecatedRawRPCCert != "" {
+		cfg.Btcd.RawRPCCert = cfg.DeprecatedRawRPCCert
+		used = append(used, "rawrpccert")
+	}
+	if cfg.DeprecatedTestNet3 {
+		cfg.Bitcoin.TestNet3 = true
+		used = append(used, "testnet")
+	}
+	if cfg.DeprecatedSimNet {
+		cfg.Bitcoin.SimNet = true
+		used = append(used, "simnet")
+	}
+
+	return used
+}
+
+// parseConfigFile parses the options within the passed INI config file into
+// cfg, first expanding any environment variables within the values of the
+// file.
+func parseConfigFile(configFile string, cfg *config) error {
+	contents, err := ioutil.ReadFile(configFile)
+	if err != nil {
+		return err
+	}
+
+	expanded, err := expandConfigEnv(string(contents))
+	if err != nil {
+		return err
+	}
+
+	parser := flags.NewIniParser(flags.NewParser(cfg, flags.Default))
+	return parser.Parse(strings.NewReader(expanded))
+}
+
+// expandConfigEnv expands the environment variables referenced as either
+// $VAR or ${VAR} within the values of the passed INI config file contents. A
+// literal $ may be written as $$. An error is returned if any referenced
+// variable isn't set, as an empty value is rarely what was intended.
+func expandConfigEnv(contents string) (string, error) {
+	lines := strings.Split(contents, "\n")
+	for i, line := range lines {
+		// Comments and section headers are left untouched, as are
+		// lines without a value.
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || trimmed[0] == ';' || trimmed[0] == '#' ||
+			trimmed[0] == '[' {
+
+			continue
+		}
+		sep := strings.Index(line, "=")
+		if sep == -1 {
+			continue
+		}
+
+		var unset []string
+		value := os.Expand(line[sep+1:], func(name string) string {
+			if name == "$" {
+				return "$"
+			}
+
+			value, ok := os.LookupEnv(name)
+			if !ok {
+				unset = append(unset, name)
+			}
+			return value
+		})
+		if len(unset) > 0 {
+			return "", fmt.Errorf("line %d references unset "+
+				"environment variables %v -- set them, or "+
+				"write a literal $ as $$", i+1,
+				strings.Join(unset, ", "))
+		}
+
+		lines[i] = line[:sep+1] + value
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// normalizeNetwork returns the name of the network's directory within the
+// data and log directories. The name of the test network is stripped of its
+// version, so the directory name is stable across test network resets.
+func normalizeNetwork(network string) string {
+	if network == chaincfg.TestNet3Params.Name {
+		return "testnet"
+	}
+
+	return network
+}
+
+// networkDir returns the network's directory within the passed directory.
+// Directories named after the network's legacy, non-normalized name are moved
+// into place, so existing data is carried over.
+func networkDir(dir, legacyNetwork, network string) (string, error) {
+	netDir := filepath.Join(dir, network)
+	if legacyNetwork == network {
+		return netDir, nil
+	}
+
+	legacyDir := filepath.Join(dir, legacyNetwork)
+	if _, err := os.Stat(legacyDir); err != nil {
+		return netDir, nil
+	}
+	if _, err := os.Stat(netDir); err == nil {
+		return "", fmt.Errorf("both %v and %v exist -- remove or "+
+			"merge one of them", legacyDir, netDir)
+	}
+
+	if err := os.Rename(legacyDir, netDir); err != nil {
+		return "", fmt.Errorf("unable to move %v to %v: %v",
+			legacyDir, netDir, err)
+	}
+
+	return netDir, nil
+}
+
+// parseKeyFamilies parses the passed key family names, ignoring any spaces
+// within them.
+func parseKeyFamilies(names []string) ([]keychain.KeyFamily, error) {
+	var keyFams []keychain.KeyFamily
+	for _, name := range names {
+		name = strings.ToLower(strings.Replace(name, " ", "", -1))
+
+		found := false
+		for _, keyFam := range keychain.KeyFamilies {
+			famName := strings.Replace(keyFam.String(), " ", "", -1)
+			if famName == name {
+				keyFams = append(keyFams, keyFam)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown key family: %v", name)
+		}
+	}
+
+	return keyFams, nil
+}
+
+// parseHexColor parses a color in the hex format "#rrggbb".
+func parseHexColor(colorStr string) (color.RGBA, error) {
+	if len(colorStr) != 7 || colorStr[0] != '#' {
+		return color.RGBA{}, fmt.Errorf("color must be in the hex "+
+			"format #rrggbb: %v", colorStr)
+	}
+
+	colorBytes, err := hex.DecodeString(colorStr[1:])
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("color must be in the hex "+
+			"format #rrggbb: %v", colorStr)
+	}
+
+	return color.RGBA{
+		R: colorBytes[0],
+		G: colorBytes[1],
+		B: colorBytes[2],
+	}, nil
+}
+
+// encodeHexColor encodes a color in the hex format "#rrggbb".
+func encodeHexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
 // cleanAndExpandPath expands environment variables and leading ~ in the
 // passed path, cleans the result, and returns it.
 func cleanAndExpandPath(path string) string {
@@ -242,16 +1292,28 @@ func parseAndSetDebugLevels(debugLevel string) error {
 	}
 
 	// Split the specified string into subsystem/level pairs while detecting
-	// issues and update the log levels accordingly.
-	for _, logLevelPair := range strings.Split(debugLevel, ",") {
-		if !strings.Contains(logLevelPair, "=") {
-			str := "The specified debug level contains an invalid " +
-				"subsystem/level pair [%v]"
-			return fmt.Errorf(str, logLevelPair)
+	// issues and update the log levels accordingly. The first element may
+	// instead be a bare level, which is applied to all subsystems before
+	// the individual subsystem levels.
+	for i, logLevelPair := range strings.Split(debugLevel, ",") {
+		if i == 0 && !strings.Contains(logLevelPair, "=") {
+			if !validLogLevel(logLevelPair) {
+				str := "The specified debug level [%v] is " +
+					"invalid"
+				return fmt.Errorf(str, logLevelPair)
+			}
+
+			setLogLevels(logLevelPair)
+			continue
 		}
 
 		// Extract the specified subsystem and log level.
 		fields := strings.Split(logLevelPair, "=")
+		if len(fields) != 2 {
+			str := "The specified debug level contains an invalid " +
+				"subsystem/level pair [%v]"
+			return fmt.Errorf(str, logLevelPair)
+		}
 		subsysID, logLevel := fields[0], fields[1]
 
 		// Validate subsystem.
@@ -308,9 +1370,69 @@ func supportedSubsystems() []string {
 
 // noiseDial is a factory function which creates a connmgr compliant dialing
 // function by returning a closure which includes the server's identity key.
-func noiseDial(idPriv *btcec.PrivateKey) func(net.Addr) (net.Conn, error) {
+func noiseDial(nodeKey keychain.NodeKey) func(net.Addr) (net.Conn, error) {
 	return func(a net.Addr) (net.Conn, error) {
 		lnAddr := a.(*lnwire.NetAddress)
-		return brontide.Dial(idPriv, lnAddr)
+		return brontide.Dial(nodeKey, lnAddr)
+	}
+}
+
+// redactedConfigValue replaces the value of sensitive options within
+// sanitized configs.
+const redactedConfigValue = "<redacted>"
+
+// sanitizedConfig returns the active value of each option within the passed
+// config, keyed by its long option name prefixed with the namespaces of the
+// groups enclosing it, e.g. bitcoin.testnet. The values of sensitive options,
+// which are masked within the help output, are redacted.
+func sanitizedConfig(cfg interface{}) map[string]string {
+	options := make(map[string]string)
+	addConfigOptions(options, "", reflect.ValueOf(cfg))
+
+	return options
+}
+
+// addConfigOptions adds each option within the config struct v to the passed
+// set of options, prefixing their names with the passed prefix.
+func addConfigOptions(options map[string]string, prefix string,
+	v reflect.Value) {
+
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		// Unexported fields hold the parsed forms of options rather
+		// than options themselves.
+		if field.PkgPath != "" {
+			continue
+		}
+
+		if namespace, ok := field.Tag.Lookup("namespace"); ok {
+			addConfigOptions(
+				options, prefix+namespace+".", v.Field(i),
+			)
+			continue
+		}
+
+		name := field.Tag.Get("long")
+		if name == "" {
+			continue
+		}
+
+		value := fmt.Sprint(v.Field(i).Interface())
+		if field.Tag.Get("default-mask") == "-" && value != "" {
+			value = redactedConfigValue
+		}
+		options[prefix+name] = value
 	}
 }
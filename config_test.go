@@ -0,0 +1,168 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestExpandConfigEnv asserts that environment variables are expanded within
+// the values of a config file, leaving comments and section headers untouched.
+func TestExpandConfigEnv(t *testing.T) {
+	if err := os.Setenv("LND_TEST_RPCPASS", "secret"); err != nil {
+		t.Fatalf("unable to set env var: %v", err)
+	}
+	defer os.Unsetenv("LND_TEST_RPCPASS")
+	os.Unsetenv("LND_TEST_UNSET")
+
+	contents := "[btcd]\n" +
+		"; rpcpass=$LND_TEST_UNSET\n" +
+		"btcd.rpcpass=${LND_TEST_RPCPASS}\n" +
+		"btcd.rpcuser=$LND_TEST_RPCPASS-user\n" +
+		"alias=$$5 node\n"
+	expected := "[btcd]\n" +
+		"; rpcpass=$LND_TEST_UNSET\n" +
+		"btcd.rpcpass=secret\n" +
+		"btcd.rpcuser=secret-user\n" +
+		"alias=$5 node\n"
+
+	expanded, err := expandConfigEnv(contents)
+	if err != nil {
+		t.Fatalf("unable to expand config: %v", err)
+	}
+	if expanded != expected {
+		t.Fatalf("expected expanded config %q, got %q", expected,
+			expanded)
+	}
+
+	_, err = expandConfigEnv("btcd.rpcpass=$LND_TEST_UNSET\n")
+	if err == nil {
+		t.Fatalf("unset environment variable should be rejected")
+	}
+}
+
+// TestNetworkDir asserts that a directory named after the legacy name of the
+// network is moved into place, unless both directories exist.
+func TestNetworkDir(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "networkdir")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	network := normalizeNetwork("testnet3")
+	if network != "testnet" {
+		t.Fatalf("expected normalized network testnet, got %v",
+			network)
+	}
+
+	// Without a legacy directory, the normalized directory is used.
+	dir, err := networkDir(tempDir, "testnet3", network)
+	if err != nil {
+		t.Fatalf("unable to get network dir: %v", err)
+	}
+	if dir != filepath.Join(tempDir, "testnet") {
+		t.Fatalf("unexpected network dir: %v", dir)
+	}
+
+	// An existing legacy directory should be moved into place.
+	legacyDir := filepath.Join(tempDir, "testnet3")
+	if err := os.Mkdir(legacyDir, 0700); err != nil {
+		t.Fatalf("unable to create legacy dir: %v", err)
+	}
+	if _, err := networkDir(tempDir, "testnet3", network); err != nil {
+		t.Fatalf("unable to get network dir: %v", err)
+	}
+	if _, err := os.Stat(dir); err != nil {
+		t.Fatalf("legacy dir not moved: %v", err)
+	}
+	if _, err := os.Stat(legacyDir); !os.IsNotExist(err) {
+		t.Fatalf("legacy dir should no longer exist")
+	}
+
+	// If both directories exist, the conflict must be resolved manually.
+	if err := os.Mkdir(legacyDir, 0700); err != nil {
+		t.Fatalf("unable to create legacy dir: %v", err)
+	}
+	if _, err := networkDir(tempDir, "testnet3", network); err == nil {
+		t.Fatalf("conflicting network dirs should be rejected")
+	}
+}
+
+// TestSanitizedConfig asserts that the options of a config are keyed by their
+// namespaced names, with the values of sensitive options redacted.
+func TestSanitizedConfig(t *testing.T) {
+	cfg := &config{
+		DebugLevel: "debug",
+		Bitcoin: &chainConfig{
+			SimNet: true,
+		},
+		Btcd: &btcdConfig{
+			RPCUser: "user",
+			RPCPass: "secret",
+		},
+	}
+
+	options := sanitizedConfig(cfg)
+
+	expected := map[string]string{
+		"debuglevel":     "debug",
+		"bitcoin.simnet": "true",
+		"btcd.rpcuser":   "user",
+		"btcd.rpcpass":   redactedConfigValue,
+	}
+	for name, value := range expected {
+		if options[name] != value {
+			t.Fatalf("expected option %v to be %q, got %q", name,
+				value, options[name])
+		}
+	}
+
+	// Groups which aren't set shouldn't be present at all.
+	if _, ok := options["autopilot.active"]; ok {
+		t.Fatalf("unexpected option for unset autopilot group")
+	}
+
+	// An empty password has nothing to redact.
+	cfg.Btcd.RPCPass = ""
+	if value := sanitizedConfig(cfg)["btcd.rpcpass"]; value != "" {
+		t.Fatalf("expected empty rpcpass, got %q", value)
+	}
+}
+
+// TestApplyDeprecatedOptions asserts that the values of deprecated options
+// are carried over to the options replacing them, and that each deprecated
+// option used is reported.
+func TestApplyDeprecatedOptions(t *testing.T) {
+	cfg := config{
+		DeprecatedRPCUser:  "user",
+		DeprecatedTestNet3: true,
+		Bitcoin:            &chainConfig{},
+		Btcd: &btcdConfig{
+			RPCHost: "localhost",
+			RPCUser: "default",
+		},
+	}
+
+	used := applyDeprecatedOptions(&cfg)
+	if len(used) != 2 || used[0] != "rpcuser" || used[1] != "testnet" {
+		t.Fatalf("unexpected deprecated options reported: %v", used)
+	}
+	if cfg.Btcd.RPCUser != "user" {
+		t.Fatalf("expected btcd.rpcuser to be user, got %v",
+			cfg.Btcd.RPCUser)
+	}
+	if cfg.Btcd.RPCHost != "localhost" {
+		t.Fatalf("btcd.rpchost shouldn't be modified, got %v",
+			cfg.Btcd.RPCHost)
+	}
+	if !cfg.Bitcoin.TestNet3 {
+		t.Fatalf("expected bitcoin.testnet to be set")
+	}
+	for _, opt := range used {
+		if _, ok := deprecatedOptions[opt]; !ok {
+			t.Fatalf("no replacement known for option %v", opt)
+		}
+	}
+}
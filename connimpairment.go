@@ -0,0 +1,137 @@
+package main
+
+import (
+	"container/list"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// connImpairment simulates a poor network connection to a peer, delaying each
+// message sent to it by a fixed latency plus a random jitter, and dropping
+// messages at random. The randomness is seeded, so that a run exhibiting a
+// bug may be reproduced exactly. Impairments are only set through the Dev
+// service, so are only ever applied by daemons built with the dev build tag.
+type connImpairment struct {
+	latency  time.Duration
+	jitter   time.Duration
+	dropRate float64
+
+	// mtx guards rand, which isn't safe for concurrent use.
+	mtx  sync.Mutex
+	rand *rand.Rand
+}
+
+// newConnImpairment creates a new connImpairment delaying messages by the
+// passed latency plus up to jitter, and dropping them with the passed
+// probability, its randomness seeded by seed.
+func newConnImpairment(latency, jitter time.Duration, dropRate float64,
+	seed int64) *connImpairment {
+
+	return &connImpairment{
+		latency:  latency,
+		jitter:   jitter,
+		dropRate: dropRate,
+		rand:     rand.New(rand.NewSource(seed)),
+	}
+}
+
+// next returns the delay to apply before sending the next message, and
+// whether the message should be dropped instead. The messages establishing and
+// keeping alive the connection itself are never dropped, only delayed.
+func (c *connImpairment) next(msg lnwire.Message) (time.Duration, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	delay := c.latency
+	if c.jitter > 0 {
+		delay += time.Duration(c.rand.Int63n(int64(c.jitter) + 1))
+	}
+
+	// The dropped messages are drawn even without a drop rate, so that
+	// the jitter of a run is reproduced whatever its drop rate.
+	drop := c.rand.Float64() < c.dropRate
+
+	switch msg.(type) {
+	case *lnwire.Init, *lnwire.Ping, *lnwire.Pong:
+		drop = false
+	}
+
+	return delay, drop
+}
+
+// delayedMsg is a message sent over an impaired connection, held back until
+// its release time.
+type delayedMsg struct {
+	msg       lnwire.Message
+	releaseAt time.Time
+}
+
+// delayQueue holds back the messages sent over an impaired connection until
+// their delays have elapsed. Messages are released in the order they were
+// queued, as they would be by a real connection, so a message is never
+// released ahead of the one queued before it, whatever its own delay.
+type delayQueue struct {
+	msgs *list.List
+}
+
+// newDelayQueue creates a new empty delayQueue.
+func newDelayQueue() *delayQueue {
+	return &delayQueue{
+		msgs: list.New(),
+	}
+}
+
+// push queues the passed message, to be released once the passed delay has
+// elapsed from now, but not before the messages queued ahead of it.
+func (q *delayQueue) push(msg lnwire.Message, delay time.Duration,
+	now time.Time) {
+
+	releaseAt := now.Add(delay)
+	if last := q.msgs.Back(); last != nil {
+		prev := last.Value.(*delayedMsg)
+		if prev.releaseAt.After(releaseAt) {
+			releaseAt = prev.releaseAt
+		}
+	}
+
+	q.msgs.PushBack(&delayedMsg{
+		msg:       msg,
+		releaseAt: releaseAt,
+	})
+}
+
+// nextRelease returns the time at which the message at the head of the queue
+// is released. If the queue is empty, then false is returned.
+func (q *delayQueue) nextRelease() (time.Time, bool) {
+	head := q.msgs.Front()
+	if head == nil {
+		return time.Time{}, false
+	}
+
+	return head.Value.(*delayedMsg).releaseAt, true
+}
+
+// popReleased removes and returns, in order, the messages due to be released
+// by the passed time.
+func (q *delayQueue) popReleased(now time.Time) []lnwire.Message {
+	var released []lnwire.Message
+	for head := q.msgs.Front(); head != nil; head = q.msgs.Front() {
+		delayed := head.Value.(*delayedMsg)
+		if delayed.releaseAt.After(now) {
+			break
+		}
+
+		q.msgs.Remove(head)
+		released = append(released, delayed.msg)
+	}
+
+	return released
+}
+
+// empty returns whether the queue holds no messages.
+func (q *delayQueue) empty() bool {
+	return q.msgs.Len() == 0
+}
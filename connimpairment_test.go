@@ -0,0 +1,120 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// TestConnImpairment asserts that an impaired connection delays messages
+// within the bounds of its latency and jitter, drops them according to its
+// drop rate, and that impairments sharing a seed behave identically.
+func TestConnImpairment(t *testing.T) {
+	const (
+		latency = 50 * time.Millisecond
+		jitter  = 20 * time.Millisecond
+	)
+
+	msg := &lnwire.CommitRevocation{}
+
+	a := newConnImpairment(latency, jitter, 0.5, 7)
+	b := newConnImpairment(latency, jitter, 0.5, 7)
+
+	var numDropped int
+	for i := 0; i < 1000; i++ {
+		delayA, dropA := a.next(msg)
+		delayB, dropB := b.next(msg)
+		if delayA != delayB || dropA != dropB {
+			t.Fatalf("message %v: impairments with the same seed "+
+				"diverged", i)
+		}
+
+		if delayA < latency || delayA > latency+jitter {
+			t.Fatalf("message %v: delay of %v out of bounds", i,
+				delayA)
+		}
+		if dropA {
+			numDropped++
+		}
+	}
+
+	// Roughly half of the messages should have been dropped.
+	if numDropped < 400 || numDropped > 600 {
+		t.Fatalf("expected about 500 dropped messages, got %v",
+			numDropped)
+	}
+
+	// Without a drop rate, no message should be dropped, and with a drop
+	// rate of one, every message should be.
+	never := newConnImpairment(0, 0, 0, 1)
+	always := newConnImpairment(0, 0, 1, 1)
+	for i := 0; i < 100; i++ {
+		if delay, drop := never.next(msg); delay != 0 || drop {
+			t.Fatalf("expected no impairment, got delay of %v, "+
+				"drop=%v", delay, drop)
+		}
+		if _, drop := always.next(msg); !drop {
+			t.Fatalf("expected message to be dropped")
+		}
+	}
+
+	// The messages establishing and keeping alive the connection should
+	// never be dropped.
+	exempt := []lnwire.Message{
+		&lnwire.Init{}, lnwire.NewPing(1), &lnwire.Pong{},
+	}
+	for _, exemptMsg := range exempt {
+		if _, drop := always.next(exemptMsg); drop {
+			t.Fatalf("%T shouldn't be dropped", exemptMsg)
+		}
+	}
+}
+
+// TestDelayQueue asserts that delayed messages are released once their delays
+// have elapsed, and never ahead of the messages queued before them.
+func TestDelayQueue(t *testing.T) {
+	q := newDelayQueue()
+	if _, ok := q.nextRelease(); ok || !q.empty() {
+		t.Fatalf("new queue should be empty")
+	}
+
+	// The second message has a shorter delay than the first, so should be
+	// held back until the first one is released.
+	now := time.Unix(1000, 0)
+	first, second, third := lnwire.NewPing(1), lnwire.NewPing(2),
+		lnwire.NewPing(3)
+	q.push(first, 50*time.Millisecond, now)
+	q.push(second, 10*time.Millisecond, now)
+	q.push(third, 100*time.Millisecond, now)
+
+	next, ok := q.nextRelease()
+	if !ok || !next.Equal(now.Add(50*time.Millisecond)) {
+		t.Fatalf("expected next release at %v, got %v",
+			now.Add(50*time.Millisecond), next)
+	}
+
+	released := q.popReleased(now.Add(20 * time.Millisecond))
+	if len(released) != 0 {
+		t.Fatalf("expected no released messages, got %v",
+			len(released))
+	}
+
+	released = q.popReleased(now.Add(50 * time.Millisecond))
+	if len(released) != 2 || released[0] != first ||
+		released[1] != second {
+
+		t.Fatalf("expected first two messages to be released in "+
+			"order, got %v", released)
+	}
+
+	released = q.popReleased(now.Add(time.Second))
+	if len(released) != 1 || released[0] != third {
+		t.Fatalf("expected third message to be released, got %v",
+			released)
+	}
+	if !q.empty() {
+		t.Fatalf("queue should be empty once all messages are " +
+			"released")
+	}
+}
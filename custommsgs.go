@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// customMsgRange is an inclusive range of custom message types which external
+// applications are permitted to send to, and receive from, our peers.
+type customMsgRange struct {
+	start uint32
+	end   uint32
+}
+
+// contains returns true if the message type falls within the range.
+func (r customMsgRange) contains(msgType uint32) bool {
+	return msgType >= r.start && msgType <= r.end
+}
+
+// parseCustomMsgRanges parses a set of custom message type ranges of the form
+// "<start>-<end>", or a single "<type>". All types must fall within the custom
+// message range defined by BOLT 01.
+func parseCustomMsgRanges(rawRanges []string) ([]customMsgRange, error) {
+	ranges := make([]customMsgRange, 0, len(rawRanges))
+	for _, rawRange := range rawRanges {
+		bounds := strings.SplitN(rawRange, "-", 2)
+
+		start, err := strconv.ParseUint(bounds[0], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid custom message range "+
+				"%q: %v", rawRange, err)
+		}
+		end := start
+		if len(bounds) == 2 {
+			end, err = strconv.ParseUint(bounds[1], 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid custom message "+
+					"range %q: %v", rawRange, err)
+			}
+		}
+
+		switch {
+		case uint32(start) < lnwire.CustomTypeStart:
+			return nil, fmt.Errorf("invalid custom message range "+
+				"%q: types must be at least %v", rawRange,
+				lnwire.CustomTypeStart)
+		case end < start:
+			return nil, fmt.Errorf("invalid custom message range "+
+				"%q: end is below start", rawRange)
+		}
+
+		ranges = append(ranges, customMsgRange{
+			start: uint32(start),
+			end:   uint32(end),
+		})
+	}
+
+	return ranges, nil
+}
+
+// customMessage couples a custom message with the peer it was received from.
+type customMessage struct {
+	peer *btcec.PublicKey
+	msg  *lnwire.Custom
+}
+
+// customMessageRouter dispatches custom messages received from our peers to
+// any subscribed clients, in the order they were received. If a set of
+// permitted message type ranges has been configured, then only messages within
+// those ranges may be sent or received. Otherwise, the entire custom message
+// range is permitted.
+type customMessageRouter struct {
+	allowed []customMsgRange
+
+	notifier *eventNotifier
+}
+
+// newCustomMessageRouter creates a new customMessageRouter which permits
+// message types within the passed ranges.
+func newCustomMessageRouter(allowed []customMsgRange) *customMessageRouter {
+	return &customMessageRouter{
+		allowed:  allowed,
+		notifier: newEventNotifier(defaultEventQueueSize),
+	}
+}
+
+// isAllowed returns true if the passed message type may be exchanged with
+// our peers.
+func (c *customMessageRouter) isAllowed(msgType uint32) bool {
+	if msgType < lnwire.CustomTypeStart {
+		return false
+	}
+
+	// If no ranges were configured, then the entire custom range is
+	// permitted.
+	if len(c.allowed) == 0 {
+		return true
+	}
+
+	for _, r := range c.allowed {
+		if r.contains(msgType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// deliver hands off a custom message received from the target peer to all
+// currently registered clients. Messages outside of the permitted ranges are
+// dropped.
+func (c *customMessageRouter) deliver(peer *btcec.PublicKey,
+	msg *lnwire.Custom) {
+
+	if !c.isAllowed(msg.Type) {
+		peerLog.Debugf("Dropping custom message of type %v from %x: "+
+			"type not permitted", msg.Type,
+			peer.SerializeCompressed())
+		return
+	}
+
+	c.notifier.notify(&customMessage{
+		peer: peer,
+		msg:  msg,
+	})
+}
+
+// SubscribeMessages returns an eventSubscription which allows the caller to
+// receive async notifications of any custom messages received from our peers.
+// Each event sent over the subscription is a *customMessage.
+func (c *customMessageRouter) SubscribeMessages() *eventSubscription {
+	return c.notifier.subscribe()
+}
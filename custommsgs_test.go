@@ -0,0 +1,42 @@
+package main
+
+import "testing"
+
+// TestParseCustomMsgRanges asserts that custom message type ranges are parsed
+// properly, and that ranges outside of the custom type range are rejected.
+func TestParseCustomMsgRanges(t *testing.T) {
+	ranges, err := parseCustomMsgRanges([]string{"32768-32800", "40000"})
+	if err != nil {
+		t.Fatalf("unable to parse ranges: %v", err)
+	}
+
+	router := newCustomMessageRouter(ranges)
+	for _, msgType := range []uint32{32768, 32790, 32800, 40000} {
+		if !router.isAllowed(msgType) {
+			t.Fatalf("type %v should be allowed", msgType)
+		}
+	}
+	for _, msgType := range []uint32{100, 32801, 39999, 40001} {
+		if router.isAllowed(msgType) {
+			t.Fatalf("type %v shouldn't be allowed", msgType)
+		}
+	}
+
+	// With no ranges configured, the entire custom range is allowed.
+	router = newCustomMessageRouter(nil)
+	if !router.isAllowed(50000) || router.isAllowed(32767) {
+		t.Fatalf("default router should only allow the custom range")
+	}
+
+	invalidRanges := [][]string{
+		{"100"},
+		{"32768-100"},
+		{"abc"},
+		{"32768-"},
+	}
+	for _, invalid := range invalidRanges {
+		if _, err := parseCustomMsgRanges(invalid); err == nil {
+			t.Fatalf("expected %v to be rejected", invalid)
+		}
+	}
+}
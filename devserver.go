@@ -0,0 +1,323 @@
+// +build dev
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// devServer implements the Dev gRPC service, allowing integration frameworks
+// to set up network topologies and drive channel state machines on test
+// networks.
+type devServer struct {
+	server *server
+}
+
+// A compile time check to ensure that devServer fully implements the
+// DevServer gRPC service.
+var _ lnrpc.DevServer = (*devServer)(nil)
+
+// registerDevServer registers the Dev service with the passed gRPC server,
+// returning the closure which binds it to the server once the server is
+// created.
+func registerDevServer(grpcServer *grpc.Server) func(*server) {
+	devSrv := &devServer{}
+	lnrpc.RegisterDevServer(grpcServer, devSrv)
+
+	return func(s *server) {
+		devSrv.server = s
+	}
+}
+
+// ImportGraph adds the passed nodes and channels, in the format returned by
+// DescribeGraph, to our channel graph. The announcements of the imported
+// nodes and channels are neither validated nor propagated to our peers.
+func (d *devServer) ImportGraph(ctx context.Context,
+	in *lnrpc.ChannelGraph) (*lnrpc.ImportGraphResponse, error) {
+
+	rpcsLog.Debugf("[importgraph] num_nodes=%v, num_channels=%v",
+		len(in.Nodes), len(in.Edges))
+
+	graph := d.server.chanDB.ChannelGraph()
+
+	for _, rpcNode := range in.Nodes {
+		node, err := unmarshalGraphNode(rpcNode)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := graph.AddLightningNode(node); err != nil {
+			return nil, fmt.Errorf("unable to add node %v: %v",
+				rpcNode.PubKey, err)
+		}
+	}
+
+	for _, rpcEdge := range in.Edges {
+		if err := importGraphEdge(graph, rpcEdge); err != nil {
+			return nil, fmt.Errorf("unable to add channel %v: %v",
+				rpcEdge.ChannelId, err)
+		}
+	}
+
+	return &lnrpc.ImportGraphResponse{
+		NumNodes:    uint32(len(in.Nodes)),
+		NumChannels: uint32(len(in.Edges)),
+	}, nil
+}
+
+// QuiesceChannel quiesces the target channel through the stfu handshake with
+// its remote peer, returning once the channel is quiescent. If requested, the
+// channel is resumed instead.
+func (d *devServer) QuiesceChannel(ctx context.Context,
+	in *lnrpc.QuiesceChannelRequest) (*lnrpc.QuiesceChannelResponse, error) {
+
+	req := &linkControlReq{
+		op:      linkQuiesce,
+		timeout: time.Duration(in.TimeoutSeconds) * time.Second,
+	}
+	if in.Resume {
+		req.op = linkResume
+	}
+
+	if err := d.controlLink(in.ChanPoint, req); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.QuiesceChannelResponse{}, nil
+}
+
+// ForceStateTransition signs a new commitment for the remote peer of the
+// target channel, whether or not any updates are pending.
+func (d *devServer) ForceStateTransition(ctx context.Context,
+	in *lnrpc.ForceStateTransitionRequest) (
+	*lnrpc.ForceStateTransitionResponse, error) {
+
+	req := &linkControlReq{op: linkForceCommit}
+	if err := d.controlLink(in.ChanPoint, req); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.ForceStateTransitionResponse{}, nil
+}
+
+// SetConnImpairment impairs the connection to the target peer, delaying and
+// dropping the messages sent to it, so that timing and locking bugs within the
+// channel reestablishment and payment retries may be reproduced. If no latency,
+// jitter nor drop rate is set, then the connection is no longer impaired.
+func (d *devServer) SetConnImpairment(ctx context.Context,
+	in *lnrpc.SetConnImpairmentRequest) (*lnrpc.SetConnImpairmentResponse,
+	error) {
+
+	pub, err := parseGraphPubKey(in.PubKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pub_key: %v", err)
+	}
+	if in.DropRate < 0 || in.DropRate > 1 {
+		return nil, fmt.Errorf("drop_rate must be between 0 and 1")
+	}
+
+	rpcsLog.Debugf("[setconnimpairment] peer=%v, latency_ms=%v, "+
+		"jitter_ms=%v, drop_rate=%v, seed=%v", in.PubKey, in.LatencyMs,
+		in.JitterMs, in.DropRate, in.Seed)
+
+	var impairment *connImpairment
+	if in.LatencyMs != 0 || in.JitterMs != 0 || in.DropRate != 0 {
+		impairment = newConnImpairment(
+			time.Duration(in.LatencyMs)*time.Millisecond,
+			time.Duration(in.JitterMs)*time.Millisecond,
+			in.DropRate, in.Seed,
+		)
+	}
+
+	pubStr := string(pub.SerializeCompressed())
+	d.server.setConnImpairment(pubStr, impairment)
+
+	return &lnrpc.SetConnImpairmentResponse{}, nil
+}
+
+// controlLink applies the passed operation to the link of the target channel,
+// which must be active.
+func (d *devServer) controlLink(rpcChanPoint *lnrpc.ChannelPoint,
+	req *linkControlReq) error {
+
+	if rpcChanPoint == nil {
+		return fmt.Errorf("chan_point must be set")
+	}
+
+	var (
+		txid *chainhash.Hash
+		err  error
+	)
+	if rpcChanPoint.FundingTxidStr != "" {
+		txid, err = chainhash.NewHashFromStr(rpcChanPoint.FundingTxidStr)
+	} else {
+		txid, err = chainhash.NewHash(rpcChanPoint.FundingTxid)
+	}
+	if err != nil {
+		return err
+	}
+	chanPoint := wire.NewOutPoint(txid, rpcChanPoint.OutputIndex)
+
+	rpcsLog.Debugf("[%v] chan_point=%v", req.op, chanPoint)
+
+	return d.server.controlChannelLink(*chanPoint, req)
+}
+
+// unmarshalGraphNode converts a node, as returned by DescribeGraph, into its
+// channel graph representation.
+func unmarshalGraphNode(rpcNode *lnrpc.LightningNode) (*channeldb.LightningNode,
+	error) {
+
+	pub, err := parseGraphPubKey(rpcNode.PubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	node := &channeldb.LightningNode{
+		LastUpdate: time.Unix(int64(rpcNode.LastUpdate), 0),
+		PubKey:     pub,
+		Alias:      rpcNode.Alias,
+	}
+
+	addr := rpcNode.Address
+	if len(rpcNode.Addresses) != 0 {
+		addr = rpcNode.Addresses[0].Addr
+	}
+	if addr != "" {
+		node.Address, err = net.ResolveTCPAddr("tcp", addr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if rpcNode.Color != "" {
+		node.Color, err = parseHexColor(rpcNode.Color)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return node, nil
+}
+
+// importGraphEdge adds the passed channel, as returned by DescribeGraph, along
+// with the routing policies of both of its nodes, to the channel graph.
+func importGraphEdge(graph *channeldb.ChannelGraph,
+	rpcEdge *lnrpc.ChannelEdge) error {
+
+	node1, err := parseGraphPubKey(rpcEdge.Node1Pub)
+	if err != nil {
+		return err
+	}
+	node2, err := parseGraphPubKey(rpcEdge.Node2Pub)
+	if err != nil {
+		return err
+	}
+	chanPoint, err := parseChanPointStr(rpcEdge.ChanPoint)
+	if err != nil {
+		return err
+	}
+
+	err = graph.AddChannelEdge(node1, node2, chanPoint, rpcEdge.ChannelId)
+	if err != nil {
+		return err
+	}
+
+	// The graph orders the nodes of a channel by their public keys, the
+	// policy of the smaller key being flagged as the first.
+	node1First := bytes.Compare(
+		node1.SerializeCompressed(), node2.SerializeCompressed(),
+	) == -1
+
+	policies := []struct {
+		policy *lnrpc.RoutingPolicy
+		first  bool
+	}{
+		{rpcEdge.Node1Policy, node1First},
+		{rpcEdge.Node2Policy, !node1First},
+	}
+	lastUpdate := time.Unix(int64(rpcEdge.LastUpdate), 0)
+	for _, p := range policies {
+		policy := p.policy
+		if policy == nil {
+			continue
+		}
+
+		edge := &channeldb.ChannelEdge{
+			ChannelID:    rpcEdge.ChannelId,
+			ChannelPoint: *chanPoint,
+			LastUpdate:   lastUpdate,
+			Expiry:       uint16(policy.TimeLockDelta),
+			MinHTLC:      btcutil.Amount(policy.MinHtlc),
+			FeeBaseMSat:  btcutil.Amount(policy.FeeBaseMsat),
+			FeeProportionalMillionths: btcutil.Amount(
+				policy.FeeRateMilliMsat,
+			),
+			InboundFeeBaseMSat: btcutil.Amount(
+				policy.InboundFeeBaseMsat,
+			),
+			InboundFeeProportionalMillionths: btcutil.Amount(
+				policy.InboundFeeRateMilliMsat,
+			),
+			Capacity: btcutil.Amount(rpcEdge.Capacity),
+		}
+		if !p.first {
+			edge.Flags = 1
+		}
+		if policy.Disabled {
+			edge.Flags |= lnwire.ChanUpdateDisabled
+		}
+
+		if err := graph.UpdateEdgeInfo(edge); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseGraphPubKey parses a hex encoded public key of the channel graph.
+func parseGraphPubKey(pubStr string) (*btcec.PublicKey, error) {
+	pubBytes, err := hex.DecodeString(pubStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return btcec.ParsePubKey(pubBytes, btcec.S256())
+}
+
+// parseChanPointStr parses a channel point in the txid:index form.
+func parseChanPointStr(chanPointStr string) (*wire.OutPoint, error) {
+	parts := strings.Split(chanPointStr, ":")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid channel point: %v",
+			chanPointStr)
+	}
+
+	txid, err := chainhash.NewHashFromStr(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	index, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	return wire.NewOutPoint(txid, uint32(index)), nil
+}
@@ -0,0 +1,11 @@
+// +build !dev
+
+package main
+
+import "google.golang.org/grpc"
+
+// registerDevServer is a no-op, as the Dev service is only served by daemons
+// built with the dev build tag.
+func registerDevServer(grpcServer *grpc.Server) func(*server) {
+	return func(*server) {}
+}
@@ -0,0 +1,201 @@
+// +build dev
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"golang.org/x/net/context"
+)
+
+// TestUnmarshalGraphNode asserts that nodes, in the format returned by
+// DescribeGraph, are properly parsed for import.
+func TestUnmarshalGraphNode(t *testing.T) {
+	_, pub := btcec.PrivKeyFromBytes(btcec.S256(), []byte{1})
+	pubStr := hex.EncodeToString(pub.SerializeCompressed())
+
+	node, err := unmarshalGraphNode(&lnrpc.LightningNode{
+		LastUpdate: 1000,
+		PubKey:     pubStr,
+		Alias:      "alice",
+		Color:      "#3399ff",
+		Addresses: []*lnrpc.NodeAddress{
+			{Network: "tcp", Addr: "127.0.0.1:10011"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unable to parse node: %v", err)
+	}
+
+	if !node.PubKey.IsEqual(pub) {
+		t.Fatalf("pubkey parsed incorrectly")
+	}
+	if node.LastUpdate.Unix() != 1000 {
+		t.Fatalf("expected last update 1000, got %v",
+			node.LastUpdate.Unix())
+	}
+	if node.Alias != "alice" {
+		t.Fatalf("expected alias alice, got %v", node.Alias)
+	}
+	if node.Color.R != 0x33 || node.Color.G != 0x99 || node.Color.B != 0xff {
+		t.Fatalf("color parsed incorrectly: %v", node.Color)
+	}
+	if node.Address.String() != "127.0.0.1:10011" {
+		t.Fatalf("expected address 127.0.0.1:10011, got %v",
+			node.Address)
+	}
+
+	// A node without an address or color should be accepted, while an
+	// invalid public key should be rejected.
+	if _, err := unmarshalGraphNode(&lnrpc.LightningNode{
+		PubKey: pubStr,
+	}); err != nil {
+		t.Fatalf("unable to parse bare node: %v", err)
+	}
+	if _, err := unmarshalGraphNode(&lnrpc.LightningNode{
+		PubKey: "00",
+	}); err == nil {
+		t.Fatalf("invalid pubkey should be rejected")
+	}
+}
+
+// TestParseChanPointStr asserts that channel points in the txid:index form are
+// properly parsed.
+func TestParseChanPointStr(t *testing.T) {
+	const txid = "a5f4f1a2b2d39d7ca5a2cbd8c1d8b1e5a9ea7c23a4b62e96a1fd3f4d0ce2e0a7"
+
+	chanPoint, err := parseChanPointStr(txid + ":3")
+	if err != nil {
+		t.Fatalf("unable to parse channel point: %v", err)
+	}
+	if chanPoint.Hash.String() != txid || chanPoint.Index != 3 {
+		t.Fatalf("channel point parsed incorrectly: %v", chanPoint)
+	}
+
+	invalid := []string{"", txid, txid + ":", txid + ":x", "zz:1"}
+	for _, chanPointStr := range invalid {
+		if _, err := parseChanPointStr(chanPointStr); err == nil {
+			t.Fatalf("channel point %q should be rejected",
+				chanPointStr)
+		}
+	}
+}
+
+// TestImportGraph asserts that nodes and channels imported through the Dev
+// service are added to the channel graph, with the policy of each node of a
+// channel stored in the proper direction.
+func TestImportGraph(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "importgraph")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	cdb, err := channeldb.Open(tempDir)
+	if err != nil {
+		t.Fatalf("unable to open channeldb: %v", err)
+	}
+	defer cdb.Close()
+
+	devSrv := &devServer{server: &server{chanDB: cdb}}
+
+	// The nodes are ordered such that the first node of the imported
+	// channel has the larger key, so its policy must be stored as the
+	// second edge of the channel.
+	_, pubA := btcec.PrivKeyFromBytes(btcec.S256(), []byte{1})
+	_, pubB := btcec.PrivKeyFromBytes(btcec.S256(), []byte{2})
+	if bytes.Compare(pubA.SerializeCompressed(),
+		pubB.SerializeCompressed()) == -1 {
+
+		pubA, pubB = pubB, pubA
+	}
+	pubStrA := hex.EncodeToString(pubA.SerializeCompressed())
+	pubStrB := hex.EncodeToString(pubB.SerializeCompressed())
+
+	const (
+		chanID    = 1234
+		chanPoint = "a5f4f1a2b2d39d7ca5a2cbd8c1d8b1e5a9ea7c23a4b62e96a1fd3f4d0ce2e0a7:1"
+	)
+	resp, err := devSrv.ImportGraph(context.Background(), &lnrpc.ChannelGraph{
+		Nodes: []*lnrpc.LightningNode{
+			{PubKey: pubStrA, Alias: "alice"},
+			{PubKey: pubStrB, Alias: "bob"},
+		},
+		Edges: []*lnrpc.ChannelEdge{{
+			ChannelId: chanID,
+			ChanPoint: chanPoint,
+			Node1Pub:  pubStrA,
+			Node2Pub:  pubStrB,
+			Capacity:  100000,
+			Node1Policy: &lnrpc.RoutingPolicy{
+				TimeLockDelta: 40,
+				FeeBaseMsat:   1000,
+				Disabled:      true,
+			},
+			Node2Policy: &lnrpc.RoutingPolicy{
+				TimeLockDelta: 144,
+				FeeBaseMsat:   2000,
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("unable to import graph: %v", err)
+	}
+	if resp.NumNodes != 2 || resp.NumChannels != 1 {
+		t.Fatalf("expected 2 nodes and 1 channel, got %v and %v",
+			resp.NumNodes, resp.NumChannels)
+	}
+
+	graph := cdb.ChannelGraph()
+	node, err := graph.FetchLightningNode(pubA)
+	if err != nil {
+		t.Fatalf("unable to fetch imported node: %v", err)
+	}
+	if node.Alias != "alice" {
+		t.Fatalf("expected alias alice, got %v", node.Alias)
+	}
+
+	edge1, edge2, err := graph.FetchChannelEdgesByID(chanID)
+	if err != nil {
+		t.Fatalf("unable to fetch imported channel: %v", err)
+	}
+	if edge1 == nil || edge2 == nil {
+		t.Fatalf("policies of imported channel missing")
+	}
+	if edge1.Expiry != 144 || edge1.FeeBaseMSat != 2000 ||
+		edge1.Flags&lnwire.ChanUpdateDisabled != 0 {
+
+		t.Fatalf("policy of smaller key stored incorrectly: %v", edge1)
+	}
+	if edge2.Expiry != 40 || edge2.FeeBaseMSat != 1000 ||
+		edge2.Flags&1 != 1 ||
+		edge2.Flags&lnwire.ChanUpdateDisabled == 0 {
+
+		t.Fatalf("policy of larger key stored incorrectly: %v", edge2)
+	}
+	if edge1.ChannelPoint.String() != chanPoint {
+		t.Fatalf("expected channel point %v, got %v", chanPoint,
+			edge1.ChannelPoint)
+	}
+
+	// A channel with an invalid channel point is rejected.
+	_, err = devSrv.ImportGraph(context.Background(), &lnrpc.ChannelGraph{
+		Edges: []*lnrpc.ChannelEdge{{
+			ChannelId: chanID + 1,
+			ChanPoint: "invalid",
+			Node1Pub:  pubStrA,
+			Node2Pub:  pubStrB,
+		}},
+	})
+	if err == nil {
+		t.Fatalf("channel with invalid channel point imported")
+	}
+}
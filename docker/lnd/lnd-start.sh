@@ -1,5 +1,5 @@
 #!/bin/bash
 
-/go/bin/lnd --datadir=/data --logdir=/data --simnet  \
-            --btcdhost=btcd --rpccert=/rpc/rpc.cert  \
-            --rpcuser=${RPCUSER} --rpcpass=${RPCPASS} --debuglevel=debug
+/go/bin/lnd --datadir=/data --logdir=/data --bitcoin.simnet  \
+            --btcd.rpchost=btcd --btcd.rpccert=/rpc/rpc.cert  \
+            --btcd.rpcuser=${RPCUSER} --btcd.rpcpass=${RPCPASS} --debuglevel=debug
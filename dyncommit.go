@@ -0,0 +1,315 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+var (
+	// errUpgradeAborted is returned to a pending commitment upgrade if the
+	// channel is resumed before the upgrade was agreed upon.
+	errUpgradeAborted = errors.New("channel resumed before the " +
+		"commitment upgrade completed")
+)
+
+// commitUpgrade is a commitment type upgrade initiated by us, which is being
+// negotiated with the remote peer.
+//
+// An upgrade proceeds as follows: the channel is first quiesced, after which
+// the initiator of the quiescence sends a DynPropose with the target
+// commitment type, the height of the next commitment and its payment key.
+// The remote peer answers with either a DynAck carrying its own payment key,
+// or a DynReject. Once the upgrade has been accepted, both sides resume the
+// channel, and all commitments from the agreed height onwards are created in
+// the new format. The channel keeps the prior format until the commitments
+// created before the upgrade have been revoked.
+//
+// As the remote peer records the upgrade before sending its DynAck, the
+// DynAck may be lost along with the connection, leaving the upgrade recorded
+// by the remote peer alone. Hence, once the channel is reestablished, both
+// sides announce the format they create new commitments in with a
+// DynReestablish, and an upgrade which only one side recorded is abandoned
+// before any commitment is signed.
+type commitUpgrade struct {
+	commitType channeldb.CommitmentType
+
+	// height is the commitment height from which the new type applies,
+	// as sent within our DynPropose.
+	height uint64
+
+	// quiesced is sent upon once the channel is quiescent, or with an
+	// error if it's resumed before it does.
+	quiesced chan error
+
+	// proposed is true once our DynPropose has been sent.
+	proposed bool
+
+	// paymentKey is the payment key sent within our DynPropose.
+	paymentKey keychain.KeyDescriptor
+
+	// err is sent upon once the upgrade has been agreed upon, or has
+	// failed.
+	err chan error
+}
+
+// initiateCommitUpgrade starts upgrading the commitment type of the channel
+// to the type requested by the passed linkUpgradeCommitment operation. The
+// result of the upgrade is sent over the error channel of the request.
+func (p *peer) initiateCommitUpgrade(state *commitmentState,
+	req *linkControlReq) {
+
+	current, pending := state.channel.CommitTypes()
+	switch {
+	case state.pendingUpgrade != nil || current != pending:
+		req.err <- fmt.Errorf("commitment type upgrade already pending")
+		return
+
+	case req.commitType != channeldb.CommitTypeStaticRemoteKey:
+		req.err <- fmt.Errorf("unsupported commitment type: %v",
+			req.commitType)
+		return
+
+	case req.commitType <= current:
+		req.err <- fmt.Errorf("channel already uses commitment "+
+			"type %v", current)
+		return
+
+	case !p.supportsStaticRemoteKey():
+		req.err <- fmt.Errorf("peer doesn't support %v commitments",
+			req.commitType)
+		return
+
+	case !p.supportsQuiescence():
+		req.err <- fmt.Errorf("peer doesn't support quiescence")
+		return
+	}
+
+	peerLog.Infof("Upgrading ChannelPoint(%v) to commitment type %v",
+		state.chanPoint, req.commitType)
+
+	state.pendingUpgrade = &commitUpgrade{
+		commitType: req.commitType,
+		quiesced:   make(chan error, 1),
+		err:        req.err,
+	}
+	state.quiescer.initiate(0, state.pendingUpgrade.quiesced)
+}
+
+// proposeCommitUpgrade sends our DynPropose for the pending upgrade once the
+// channel is quiescent, as signalled by a nil error.
+func (p *peer) proposeCommitUpgrade(state *commitmentState, err error) {
+	upgrade := state.pendingUpgrade
+	if err != nil {
+		p.failCommitUpgrade(state, err)
+		return
+	}
+
+	// If the remote peer initiated quiescence at the same time as we did
+	// and won, it's up to it to propose any upgrade.
+	if !state.quiescer.isInitiator() {
+		p.failCommitUpgrade(state, fmt.Errorf("remote peer is the "+
+			"initiator of the quiescence"))
+		return
+	}
+
+	keyRing := p.server.lnwallet.KeyRing
+	paymentKey, err := keyRing.DeriveNextKey(keychain.KeyFamilyPaymentBase)
+	if err != nil {
+		p.failCommitUpgrade(state, err)
+		state.quiescer.resume(errUpgradeAborted)
+		return
+	}
+
+	upgrade.proposed = true
+	upgrade.height = state.channel.CommitUpgradeHeight()
+	upgrade.paymentKey = paymentKey
+	p.queueMsg(&lnwire.DynPropose{
+		ChannelPoint:     state.chanPoint,
+		CommitType:       uint8(upgrade.commitType),
+		ActivationHeight: upgrade.height,
+		PaymentKey:       paymentKey.PubKey,
+	}, nil)
+}
+
+// failCommitUpgrade fails our pending commitment upgrade with the passed
+// error.
+func (p *peer) failCommitUpgrade(state *commitmentState, err error) {
+	peerLog.Errorf("Unable to upgrade commitment type of "+
+		"ChannelPoint(%v): %v", state.chanPoint, err)
+
+	state.pendingUpgrade.err <- err
+	state.pendingUpgrade = nil
+}
+
+// handleDynPropose processes an upgrade proposed by the remote peer, which
+// must be the initiator of the current quiescence of the channel. The upgrade
+// is either accepted with a DynAck or rejected with a DynReject, after which
+// we resume the channel. An error is returned if the proposal violates the
+// protocol.
+func (p *peer) handleDynPropose(state *commitmentState,
+	msg *lnwire.DynPropose) error {
+
+	if !state.quiescer.isQuiescent() || state.quiescer.isInitiator() {
+		return fmt.Errorf("upgrade proposed while remote peer isn't " +
+			"the quiescence initiator")
+	}
+
+	reject := func(reason string) {
+		peerLog.Warnf("Rejecting commitment upgrade of "+
+			"ChannelPoint(%v): %v", state.chanPoint, reason)
+
+		p.queueMsg(&lnwire.DynReject{
+			ChannelPoint: state.chanPoint,
+			Reason:       reason,
+		}, nil)
+		state.quiescer.resume(errUpgradeAborted)
+	}
+
+	commitType := channeldb.CommitmentType(msg.CommitType)
+	if commitType != channeldb.CommitTypeStaticRemoteKey ||
+		!p.supportsStaticRemoteKey() {
+
+		reject(fmt.Sprintf("unsupported commitment type: %v",
+			commitType))
+		return nil
+	}
+
+	keyRing := p.server.lnwallet.KeyRing
+	paymentKey, err := keyRing.DeriveNextKey(keychain.KeyFamilyPaymentBase)
+	if err != nil {
+		reject("internal error")
+		return nil
+	}
+
+	err = state.channel.UpgradeCommitType(
+		commitType, msg.ActivationHeight, paymentKey, msg.PaymentKey,
+	)
+	if err != nil {
+		reject(err.Error())
+		return nil
+	}
+
+	p.queueMsg(&lnwire.DynAck{
+		ChannelPoint: state.chanPoint,
+		PaymentKey:   paymentKey.PubKey,
+	}, nil)
+	state.quiescer.resume(errUpgradeAborted)
+
+	return nil
+}
+
+// handleDynAck processes the acceptance of our proposed upgrade by the remote
+// peer. The upgrade is applied to the channel, which is then resumed, and a
+// new commitment is signed right away so the upgrade gets locked in. An error
+// is returned if no upgrade was proposed.
+func (p *peer) handleDynAck(state *commitmentState, msg *lnwire.DynAck) error {
+	upgrade := state.pendingUpgrade
+	if upgrade == nil || !upgrade.proposed {
+		return fmt.Errorf("received unexpected upgrade ack")
+	}
+
+	err := state.channel.UpgradeCommitType(
+		upgrade.commitType, upgrade.height, upgrade.paymentKey,
+		msg.PaymentKey,
+	)
+	state.pendingUpgrade = nil
+	upgrade.err <- err
+	state.quiescer.resume(errUpgradeAborted)
+	if err != nil {
+		return err
+	}
+
+	sent, err := p.updateCommitTx(state)
+	if err != nil {
+		return err
+	}
+	if sent {
+		state.numUnAcked += 1
+	}
+
+	return nil
+}
+
+// handleDynReject processes the rejection of our proposed upgrade by the
+// remote peer, resuming the channel. An error is returned if no upgrade was
+// proposed.
+func (p *peer) handleDynReject(state *commitmentState,
+	msg *lnwire.DynReject) error {
+
+	upgrade := state.pendingUpgrade
+	if upgrade == nil || !upgrade.proposed {
+		return fmt.Errorf("received unexpected upgrade reject")
+	}
+
+	p.failCommitUpgrade(state, fmt.Errorf("upgrade rejected by remote "+
+		"peer: %v", msg.Reason))
+	state.quiescer.resume(errUpgradeAborted)
+
+	return nil
+}
+
+// reestablishCommitUpgrade announces the format we create new commitments in
+// to the remote peer, once the link of the channel is started. If we recorded
+// an upgrade which has yet to be completed, no commitment is signed until the
+// remote peer announced its own format in turn.
+func (p *peer) reestablishCommitUpgrade(state *commitmentState) {
+	if !p.supportsQuiescence() {
+		return
+	}
+
+	current, pending := state.channel.CommitTypes()
+	state.reconcilingUpgrade = current != pending
+
+	p.queueMsg(&lnwire.DynReestablish{
+		ChannelPoint: state.chanPoint,
+		CommitType:   uint8(pending),
+	}, nil)
+}
+
+// handleDynReestablish reconciles any upgrade we recorded with the format the
+// remote peer creates new commitments in, abandoning the upgrade if the
+// remote peer never recorded it. An error is returned if the formats can't be
+// reconciled.
+func (p *peer) handleDynReestablish(state *commitmentState,
+	msg *lnwire.DynReestablish) error {
+
+	if !p.supportsQuiescence() {
+		return fmt.Errorf("received reestablish from peer %v which "+
+			"didn't negotiate quiescence", p)
+	}
+
+	remoteType := channeldb.CommitmentType(msg.CommitType)
+	aborted, err := state.channel.ReconcileCommitUpgrade(remoteType)
+	if err != nil {
+		return err
+	}
+	if aborted {
+		peerLog.Warnf("Abandoned commitment type upgrade of "+
+			"ChannelPoint(%v) unknown to peer %v", state.chanPoint,
+			p)
+	}
+
+	// Now that the format of the next commitment is known, we'll sign any
+	// updates which were held back in the meantime.
+	if !state.reconcilingUpgrade {
+		return nil
+	}
+	state.reconcilingUpgrade = false
+
+	if !state.channel.PendingUpdates() {
+		return nil
+	}
+	sent, err := p.updateCommitTx(state)
+	if err != nil {
+		return err
+	}
+	if sent {
+		state.numUnAcked += 1
+	}
+
+	return nil
+}
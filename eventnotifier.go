@@ -0,0 +1,171 @@
+package main
+
+import (
+	"errors"
+	"sync"
+)
+
+// defaultEventQueueSize is the number of events which may be queued for a
+// subscribed client before it's deemed too slow to keep up, and is
+// unsubscribed.
+const defaultEventQueueSize = 1000
+
+// errEventQueueOverflow is returned to the clients of streaming RPCs which
+// were unsubscribed as they failed to keep up with the events sent to them.
+var errEventQueueOverflow = errors.New("client unsubscribed as it failed " +
+	"to keep up with the events sent to it")
+
+// eventNotifier dispatches events to any subscribed clients. Each client is
+// handed the events in the order they were sent, through a queue of its own,
+// so that a slow client never blocks the notifying party. The queues are
+// bounded: a client falling behind by more than the size of its queue is
+// unsubscribed, and notified of it through its Overflow channel.
+type eventNotifier struct {
+	queueSize int
+
+	clientMtx    sync.Mutex
+	nextClientID uint32
+	clients      map[uint32]*eventSubscription
+}
+
+// newEventNotifier creates a new eventNotifier, queueing up to queueSize
+// events for each of its clients.
+func newEventNotifier(queueSize int) *eventNotifier {
+	return &eventNotifier{
+		queueSize: queueSize,
+		clients:   make(map[uint32]*eventSubscription),
+	}
+}
+
+// notify hands off the passed event to all currently subscribed clients.
+func (n *eventNotifier) notify(event interface{}) {
+	n.clientMtx.Lock()
+	defer n.clientMtx.Unlock()
+
+	for id, client := range n.clients {
+		if client.queueEvent(event) {
+			continue
+		}
+
+		// The client's queue is full, so it's unsubscribed rather
+		// than letting its queue grow without bound.
+		delete(n.clients, id)
+		close(client.Overflow)
+	}
+}
+
+// subscribe returns a new eventSubscription, delivering the passed initial
+// events before any sent to the notifier afterwards.
+func (n *eventNotifier) subscribe(initial ...interface{}) *eventSubscription {
+	client := &eventSubscription{
+		Events:    make(chan interface{}),
+		Overflow:  make(chan struct{}),
+		queue:     initial,
+		queueSize: n.queueSize + len(initial),
+		newEvents: make(chan struct{}, 1),
+		notifier:  n,
+		quit:      make(chan struct{}),
+	}
+	if len(initial) != 0 {
+		client.newEvents <- struct{}{}
+	}
+
+	n.clientMtx.Lock()
+	n.clients[n.nextClientID] = client
+	client.id = n.nextClientID
+	n.nextClientID++
+	n.clientMtx.Unlock()
+
+	go client.eventDispatcher()
+
+	return client
+}
+
+// eventSubscription represents an intent to receive all the events sent to an
+// eventNotifier. Events are delivered over the Events channel in the order
+// they were sent.
+type eventSubscription struct {
+	Events chan interface{}
+
+	// Overflow is closed once the client is unsubscribed for failing to
+	// keep up with the events sent to it.
+	Overflow chan struct{}
+
+	queueMtx  sync.Mutex
+	queue     []interface{}
+	queueSize int
+	newEvents chan struct{}
+
+	notifier   *eventNotifier
+	id         uint32
+	cancelOnce sync.Once
+	quit       chan struct{}
+}
+
+// queueEvent adds the event to the subscription's queue, waking up the
+// goroutine delivering events if needed. False is returned if the queue is
+// full.
+func (s *eventSubscription) queueEvent(event interface{}) bool {
+	s.queueMtx.Lock()
+	if len(s.queue) >= s.queueSize {
+		s.queueMtx.Unlock()
+		return false
+	}
+	s.queue = append(s.queue, event)
+	s.queueMtx.Unlock()
+
+	select {
+	case s.newEvents <- struct{}{}:
+	default:
+	}
+
+	return true
+}
+
+// eventDispatcher delivers queued events to the client in order, without
+// blocking the parties notifying the eventNotifier.
+//
+// NOTE: This MUST be run as a goroutine.
+func (s *eventSubscription) eventDispatcher() {
+	for {
+		select {
+		case <-s.newEvents:
+		case <-s.quit:
+			return
+		}
+
+		for {
+			// Events are popped off the queue one at a time, so
+			// that no more than a single event is held outside of
+			// it.
+			s.queueMtx.Lock()
+			if len(s.queue) == 0 {
+				s.queueMtx.Unlock()
+				break
+			}
+			event := s.queue[0]
+			s.queue[0] = nil
+			s.queue = s.queue[1:]
+			s.queueMtx.Unlock()
+
+			select {
+			case s.Events <- event:
+			case <-s.quit:
+				return
+			}
+		}
+	}
+}
+
+// Cancel unregisters the eventSubscription, freeing any previously allocated
+// resources. It's safe to call once the client has been unsubscribed due to
+// an overflow of its queue.
+func (s *eventSubscription) Cancel() {
+	s.cancelOnce.Do(func() {
+		s.notifier.clientMtx.Lock()
+		delete(s.notifier.clients, s.id)
+		s.notifier.clientMtx.Unlock()
+
+		close(s.quit)
+	})
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// TestEventNotifierOrdering asserts that events are delivered to a client in
+// the order they were sent, after any initial events.
+func TestEventNotifierOrdering(t *testing.T) {
+	t.Parallel()
+
+	notifier := newEventNotifier(defaultEventQueueSize)
+	client := notifier.subscribe(-2, -1)
+	defer client.Cancel()
+
+	const numEvents = 100
+	for i := 0; i < numEvents; i++ {
+		notifier.notify(i)
+	}
+
+	for i := -2; i < numEvents; i++ {
+		select {
+		case event := <-client.Events:
+			if event.(int) != i {
+				t.Fatalf("expected event %v, got %v", i, event)
+			}
+		case <-time.After(time.Second * 5):
+			t.Fatalf("event %v not received", i)
+		}
+	}
+}
+
+// TestEventNotifierOverflow asserts that a client which fails to keep up with
+// the events sent to it is unsubscribed, and that cancelling it afterwards is
+// safe.
+func TestEventNotifierOverflow(t *testing.T) {
+	t.Parallel()
+
+	notifier := newEventNotifier(2)
+	client := notifier.subscribe()
+
+	// The dispatcher may hold one event while blocking on delivery, so
+	// sending more than the queue size plus one must overflow it.
+	for i := 0; i < 4; i++ {
+		notifier.notify(i)
+	}
+
+	select {
+	case <-client.Overflow:
+	case <-time.After(time.Second * 5):
+		t.Fatalf("client wasn't unsubscribed on overflow")
+	}
+
+	notifier.clientMtx.Lock()
+	numClients := len(notifier.clients)
+	notifier.clientMtx.Unlock()
+	if numClients != 0 {
+		t.Fatalf("expected no clients, got %v", numClients)
+	}
+
+	client.Cancel()
+	client.Cancel()
+}
@@ -0,0 +1,32 @@
+package feature
+
+import "github.com/lightningnetwork/lnd/lnwire"
+
+// setDesc describes which feature bits should be advertised in which feature
+// sets.
+type setDesc map[lnwire.FeatureBit]map[Set]struct{}
+
+// defaultSetDesc are the default set descriptors for generating feature
+// vectors. Each set is annotated with the corresponding identifier from BOLT
+// 9 indicating where it should be advertised.
+var defaultSetDesc = setDesc{
+	lnwire.InitialRoutingSyncOptional: {
+		SetInit: {}, // I
+	},
+	lnwire.UpfrontShutdownScriptOptional: {
+		SetInit:    {}, // I
+		SetNodeAnn: {}, // N
+	},
+	lnwire.StaticRemoteKeyOptional: {
+		SetInit:    {}, // I
+		SetNodeAnn: {}, // N
+	},
+	lnwire.WumboChannelsOptional: {
+		SetInit:    {}, // I
+		SetNodeAnn: {}, // N
+	},
+	lnwire.QuiescenceOptional: {
+		SetInit:    {}, // I
+		SetNodeAnn: {}, // N
+	},
+}
@@ -0,0 +1,53 @@
+package feature
+
+import (
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// deps maps a feature to the set of features it depends on. A feature
+// vector that sets a feature without also setting all of its dependencies is
+// considered invalid.
+var deps = map[lnwire.FeatureBit]map[lnwire.FeatureBit]struct{}{
+	lnwire.MPPOptional: {
+		lnwire.PaymentAddrOptional: {},
+	},
+}
+
+// ErrMissingFeatureDep is returned when a feature vector sets a feature
+// without also setting one of the features it depends on.
+type ErrMissingFeatureDep struct {
+	dep lnwire.FeatureBit
+}
+
+// NewErrMissingFeatureDep creates a new ErrMissingFeatureDep error.
+func NewErrMissingFeatureDep(dep lnwire.FeatureBit) ErrMissingFeatureDep {
+	return ErrMissingFeatureDep{dep: dep}
+}
+
+// Error returns a human-readable description of the missing dep error.
+func (e ErrMissingFeatureDep) Error() string {
+	return fmt.Sprintf("missing feature dependency: %v", e.dep)
+}
+
+// ValidateDeps asserts that a feature vector sets all features and their
+// transitive dependencies properly. Either the required or optional bit of a
+// dependency satisfies the check.
+func ValidateDeps(fv *lnwire.FeatureVector) error {
+	for _, bit := range fv.Features() {
+		// Normalize to the optional bit, as that's how the dependency
+		// map is keyed.
+		if bit.IsRequired() {
+			bit = bit.Pair()
+		}
+
+		for dep := range deps[bit] {
+			if !fv.HasFeature(dep) {
+				return NewErrMissingFeatureDep(dep)
+			}
+		}
+	}
+
+	return nil
+}
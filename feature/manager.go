@@ -0,0 +1,200 @@
+package feature
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// Config houses any runtime modifications to the default set descriptors. For
+// our purposes, this typically means disabling certain features to test
+// specific behavior or to turn off features that some other subsystem
+// depends on.
+type Config struct {
+	// NoStaticRemoteKey unsets any bits signaling support for the
+	// static_remotekey commitment format.
+	NoStaticRemoteKey bool
+
+	// NoWumbo unsets any bits signaling support for channels larger than
+	// 2^24 satoshis.
+	NoWumbo bool
+}
+
+// Manager is responsible for generating feature vectors for different
+// requested feature sets.
+type Manager struct {
+	// fsets is a map of feature set to raw feature vectors. Requests are
+	// fulfilled by cloning these internal feature vectors. It's guarded
+	// by the mtx, as the sets may be updated at runtime.
+	mtx   sync.RWMutex
+	fsets map[Set]*lnwire.FeatureVector
+}
+
+// NewManager creates a new feature Manager, applying any custom modifications
+// to its feature sets.
+func NewManager(cfg Config) (*Manager, error) {
+	return newManager(cfg, defaultSetDesc)
+}
+
+// newManager creates a new feature Manager, applying any custom modifications
+// to its feature sets. The given set description allows the caller to
+// override the default feature sets.
+func newManager(cfg Config, desc setDesc) (*Manager, error) {
+	// First build the default feature vector for all known sets.
+	fsets := make(map[Set]*lnwire.FeatureVector)
+	for bit, sets := range desc {
+		for set := range sets {
+			fv, ok := fsets[set]
+			if !ok {
+				fv = lnwire.NewFeatureVector()
+			}
+
+			fv.Set(bit)
+			fsets[set] = fv
+		}
+	}
+
+	// Now, remove any features as directed by the config.
+	for set, fv := range fsets {
+		if cfg.NoStaticRemoteKey {
+			fv.Unset(lnwire.StaticRemoteKeyOptional)
+			fv.Unset(lnwire.StaticRemoteKeyRequired)
+		}
+		if cfg.NoWumbo {
+			fv.Unset(lnwire.WumboChannelsOptional)
+			fv.Unset(lnwire.WumboChannelsRequired)
+		}
+
+		// Finally, ensure that the resulting vector is still
+		// consistent before handing it out to the rest of the daemon.
+		if err := ValidateDeps(fv); err != nil {
+			return nil, fmt.Errorf("invalid feature set %v: %v",
+				set, err)
+		}
+	}
+
+	return &Manager{
+		fsets: fsets,
+	}, nil
+}
+
+// Get returns a copy of the feature vector for the passed set. If no set is
+// known, an empty feature vector is returned.
+func (m *Manager) Get(set Set) *lnwire.FeatureVector {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	if fv, ok := m.fsets[set]; ok {
+		return fv.Clone()
+	}
+
+	return lnwire.NewFeatureVector()
+}
+
+// ListSets returns a list of the feature sets that our node supports.
+func (m *Manager) ListSets() []Set {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	var sets []Set
+	for set := range m.fsets {
+		sets = append(sets, set)
+	}
+
+	return sets
+}
+
+// PrepareFeatureSetUpdate returns the feature vector which results from
+// setting and then unsetting the passed feature bits within the given set,
+// without applying it. An error is returned if the resulting feature vector
+// doesn't satisfy all feature dependencies.
+func (m *Manager) PrepareFeatureSetUpdate(set Set, setBits,
+	unsetBits []lnwire.FeatureBit) (*lnwire.FeatureVector, error) {
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	fv, ok := m.fsets[set]
+	if ok {
+		fv = fv.Clone()
+	} else {
+		fv = lnwire.NewFeatureVector()
+	}
+
+	for _, bit := range setBits {
+		fv.Set(bit)
+	}
+	for _, bit := range unsetBits {
+		fv.Unset(bit)
+	}
+
+	if err := ValidateDeps(fv); err != nil {
+		return nil, fmt.Errorf("invalid feature set %v: %v", set, err)
+	}
+
+	return fv, nil
+}
+
+// SetFeatureSet replaces the given set with the passed feature vector, as
+// returned by PrepareFeatureSetUpdate.
+func (m *Manager) SetFeatureSet(set Set, fv *lnwire.FeatureVector) {
+	m.mtx.Lock()
+	m.fsets[set] = fv
+	m.mtx.Unlock()
+}
+
+// UpdateFeatureSet sets and then unsets the passed feature bits within the
+// given set. The update is only applied if the resulting feature vector still
+// satisfies all feature dependencies, otherwise an error is returned and the
+// set is left untouched.
+func (m *Manager) UpdateFeatureSet(set Set, setBits,
+	unsetBits []lnwire.FeatureBit) error {
+
+	fv, err := m.PrepareFeatureSetUpdate(set, setBits, unsetBits)
+	if err != nil {
+		return err
+	}
+	m.SetFeatureSet(set, fv)
+
+	return nil
+}
+
+// ValidateRemote checks the feature vector sent by a remote peer within its
+// Init message. An error is returned if the peer requires a feature we don't
+// understand, or if it advertises a feature without also advertising the
+// features it depends on.
+func ValidateRemote(fv *lnwire.FeatureVector) error {
+	if unknown := fv.UnknownRequiredFeatures(); len(unknown) > 0 {
+		return fmt.Errorf("peer requires unknown features: %v",
+			unknown)
+	}
+
+	return ValidateDeps(fv)
+}
+
+// SupportsWumbo returns true if a remote node's feature vector signals that
+// it's willing to open and accept channels larger than 2^24 satoshis.
+func SupportsWumbo(fv *lnwire.FeatureVector) bool {
+	return fv.HasFeature(lnwire.WumboChannelsOptional)
+}
+
+// SupportsUpfrontShutdown returns true if a remote node's feature vector
+// signals that it commits to its delivery script during the funding workflow,
+// and enforces the remote party's upon a cooperative close.
+func SupportsUpfrontShutdown(fv *lnwire.FeatureVector) bool {
+	return fv.HasFeature(lnwire.UpfrontShutdownScriptOptional)
+}
+
+// SupportsStaticRemoteKey returns true if a remote node's feature vector
+// signals that it understands commitments paying the balance of the remote
+// party to a static key.
+func SupportsStaticRemoteKey(fv *lnwire.FeatureVector) bool {
+	return fv.HasFeature(lnwire.StaticRemoteKeyOptional)
+}
+
+// SupportsQuiescence returns true if a remote node's feature vector signals
+// that it understands the Stfu message, and so is able to quiesce a channel.
+func SupportsQuiescence(fv *lnwire.FeatureVector) bool {
+	return fv.HasFeature(lnwire.QuiescenceOptional)
+}
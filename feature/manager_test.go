@@ -0,0 +1,175 @@
+package feature
+
+import (
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// TestManagerDefaultSets asserts that the default manager advertises the
+// expected bits within each of the feature sets.
+func TestManagerDefaultSets(t *testing.T) {
+	m, err := NewManager(Config{})
+	if err != nil {
+		t.Fatalf("unable to create feature manager: %v", err)
+	}
+
+	initFeatures := m.Get(SetInit)
+	if !initFeatures.IsSet(lnwire.InitialRoutingSyncOptional) {
+		t.Fatalf("init set should signal initial routing sync")
+	}
+
+	// Neither payment addresses nor multi-path payments are supported, so
+	// no set should signal them.
+	for _, set := range m.ListSets() {
+		fv := m.Get(set)
+		if fv.HasFeature(lnwire.PaymentAddrOptional) {
+			t.Fatalf("%v shouldn't signal payment addr", set)
+		}
+		if fv.HasFeature(lnwire.MPPOptional) {
+			t.Fatalf("%v shouldn't signal mpp", set)
+		}
+	}
+
+	// Modifying a returned vector must not alter the manager's sets.
+	initFeatures.Unset(lnwire.InitialRoutingSyncOptional)
+	if !m.Get(SetInit).IsSet(lnwire.InitialRoutingSyncOptional) {
+		t.Fatalf("manager set was modified through returned vector")
+	}
+}
+
+// TestManagerInvalidSetDesc asserts that a feature manager can't be created
+// from set descriptors signaling a feature without its dependencies.
+func TestManagerInvalidSetDesc(t *testing.T) {
+	desc := setDesc{
+		lnwire.MPPOptional: {
+			SetInvoice: {},
+		},
+	}
+	if _, err := newManager(Config{}, desc); err == nil {
+		t.Fatalf("expected mpp without payment addr to be rejected")
+	}
+
+	desc[lnwire.PaymentAddrOptional] = map[Set]struct{}{
+		SetInvoice: {},
+	}
+	if _, err := newManager(Config{}, desc); err != nil {
+		t.Fatalf("unable to create feature manager: %v", err)
+	}
+}
+
+// TestManagerNoWumbo asserts that wumbo channels are signaled by default
+// within the init and node announcement sets, unless disabled.
+func TestManagerNoWumbo(t *testing.T) {
+	m, err := NewManager(Config{})
+	if err != nil {
+		t.Fatalf("unable to create feature manager: %v", err)
+	}
+	if !SupportsWumbo(m.Get(SetInit)) || !SupportsWumbo(m.Get(SetNodeAnn)) {
+		t.Fatalf("init and node announcement sets should signal wumbo")
+	}
+
+	m, err = NewManager(Config{NoWumbo: true})
+	if err != nil {
+		t.Fatalf("unable to create feature manager: %v", err)
+	}
+	for _, set := range m.ListSets() {
+		if SupportsWumbo(m.Get(set)) {
+			t.Fatalf("%v shouldn't signal wumbo", set)
+		}
+	}
+}
+
+// TestManagerUpdateFeatureSet asserts that feature sets can be updated at
+// runtime, and that updates breaking a feature dependency are rejected.
+func TestManagerUpdateFeatureSet(t *testing.T) {
+	m, err := NewManager(Config{})
+	if err != nil {
+		t.Fatalf("unable to create feature manager: %v", err)
+	}
+
+	err = m.UpdateFeatureSet(
+		SetNodeAnn, []lnwire.FeatureBit{lnwire.FeatureBit(101)}, nil,
+	)
+	if err != nil {
+		t.Fatalf("unable to update feature set: %v", err)
+	}
+	if !m.Get(SetNodeAnn).IsSet(lnwire.FeatureBit(101)) {
+		t.Fatalf("node announcement set should signal new bit")
+	}
+
+	// Signaling mpp without payment addresses within the node
+	// announcement set should fail, leaving the set untouched.
+	err = m.UpdateFeatureSet(
+		SetNodeAnn, []lnwire.FeatureBit{lnwire.MPPOptional}, nil,
+	)
+	if err == nil {
+		t.Fatalf("expected update breaking mpp dependency to fail")
+	}
+	if m.Get(SetNodeAnn).HasFeature(lnwire.MPPOptional) {
+		t.Fatalf("failed update shouldn't modify the feature set")
+	}
+
+	// A prepared update should only be applied once set.
+	fv, err := m.PrepareFeatureSetUpdate(
+		SetNodeAnn, []lnwire.FeatureBit{lnwire.FeatureBit(103)}, nil,
+	)
+	if err != nil {
+		t.Fatalf("unable to prepare feature set update: %v", err)
+	}
+	if m.Get(SetNodeAnn).IsSet(lnwire.FeatureBit(103)) {
+		t.Fatalf("prepared update shouldn't modify the feature set")
+	}
+	m.SetFeatureSet(SetNodeAnn, fv)
+	if !m.Get(SetNodeAnn).IsSet(lnwire.FeatureBit(103)) {
+		t.Fatalf("node announcement set should signal prepared bit")
+	}
+}
+
+// TestValidateRemote checks that the remote feature validation rejects
+// unknown required bits and missing dependencies.
+func TestValidateRemote(t *testing.T) {
+	tests := []struct {
+		name  string
+		fv    *lnwire.FeatureVector
+		valid bool
+	}{
+		{
+			name:  "empty",
+			fv:    lnwire.NewFeatureVector(),
+			valid: true,
+		},
+		{
+			name:  "unknown odd",
+			fv:    lnwire.NewFeatureVector(lnwire.FeatureBit(101)),
+			valid: true,
+		},
+		{
+			name:  "unknown even",
+			fv:    lnwire.NewFeatureVector(lnwire.FeatureBit(100)),
+			valid: false,
+		},
+		{
+			name: "mpp with payment addr",
+			fv: lnwire.NewFeatureVector(
+				lnwire.MPPOptional, lnwire.PaymentAddrRequired,
+			),
+			valid: true,
+		},
+		{
+			name:  "mpp without payment addr",
+			fv:    lnwire.NewFeatureVector(lnwire.MPPRequired),
+			valid: false,
+		},
+	}
+
+	for _, test := range tests {
+		err := ValidateRemote(test.fv)
+		if test.valid && err != nil {
+			t.Fatalf("%v: expected valid, got: %v", test.name, err)
+		}
+		if !test.valid && err == nil {
+			t.Fatalf("%v: expected validation failure", test.name)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package feature
+
+// Set is an enum identifying various feature sets, which separates the single
+// feature namespace into distinct categories depending what context a feature
+// vector is being used.
+type Set uint8
+
+const (
+	// SetInit identifies features that should be sent in an Init message
+	// to a remote peer.
+	SetInit Set = iota
+
+	// SetNodeAnn identifies features that should be advertised on node
+	// announcements.
+	SetNodeAnn
+
+	// SetInvoice identifies features that should be advertised on
+	// invoices generated by the daemon.
+	SetInvoice
+)
+
+// String returns a human-readable description of a Set.
+func (s Set) String() string {
+	switch s {
+	case SetInit:
+		return "SetInit"
+	case SetNodeAnn:
+		return "SetNodeAnn"
+	case SetInvoice:
+		return "SetInvoice"
+	default:
+		return "SetUnknown"
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/feemanager"
+	"github.com/lightningnetwork/lnd/routing"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// newFeeManager creates a fee manager which adjusts the fees of the channels
+// of the server that opted into fee management, within the bounds of the
+// passed config.
+func newFeeManager(s *server, cfg *feeManagerConfig) *feemanager.Manager {
+	return feemanager.New(&feemanager.Config{
+		BaseFee:           btcutil.Amount(cfg.BaseFee),
+		MinFeeRate:        btcutil.Amount(cfg.MinFeeRate),
+		MaxFeeRate:        btcutil.Amount(cfg.MaxFeeRate),
+		UpdateInterval:    cfg.UpdateInterval,
+		MinUpdateInterval: cfg.MinUpdateInterval,
+		FetchChannels: func() ([]*feemanager.ChannelState, error) {
+			return fetchFeeManagerChannels(s)
+		},
+		UpdatePolicy: func(chanPoint wire.OutPoint, baseFee,
+			feeRate btcutil.Amount) error {
+
+			return s.chanRouter.UpdateChannelPolicy(
+				&chanPoint, &routing.FeeSchema{
+					BaseFee: baseFee,
+					FeeRate: feeRate,
+				},
+			)
+		},
+		FetchManaged: s.chanDB.FetchFeeManagedChannels,
+		SetManaged: func(chanPoint wire.OutPoint, managed bool) error {
+			return s.chanDB.SetFeeManaged(&chanPoint, managed)
+		},
+	})
+}
+
+// fetchFeeManagerChannels returns the state of each of our open channels
+// along with the fees we've announced for it. Channels we've yet to announce a
+// policy for are skipped, as they have no fees to adjust.
+func fetchFeeManagerChannels(s *server) ([]*feemanager.ChannelState, error) {
+	dbChannels, err := s.chanDB.FetchAllChannels()
+	if err != nil && err != channeldb.ErrNoActiveChannels {
+		return nil, err
+	}
+
+	chans := make([]*feemanager.ChannelState, 0, len(dbChannels))
+	for _, dbChannel := range dbChannels {
+		policy, err := s.chanRouter.OwnChannelPolicy(dbChannel.ChanID)
+		switch {
+		case err == channeldb.ErrEdgeNotFound:
+			continue
+		case err != nil:
+			return nil, err
+		}
+
+		chans = append(chans, &feemanager.ChannelState{
+			ChanPoint:     *dbChannel.ChanID,
+			Capacity:      dbChannel.Capacity,
+			LocalBalance:  dbChannel.OurBalance,
+			TotalSent:     btcutil.Amount(dbChannel.TotalSatoshisSent),
+			TotalReceived: btcutil.Amount(dbChannel.TotalSatoshisReceived),
+			BaseFee:       policy.FeeBaseMSat,
+			FeeRate:       policy.FeeProportionalMillionths,
+		})
+	}
+
+	return chans, nil
+}
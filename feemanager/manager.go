@@ -0,0 +1,361 @@
+package feemanager
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+const (
+	// DefaultUpdateInterval is the default interval at which the fees of
+	// the managed channels are re-evaluated.
+	DefaultUpdateInterval = 10 * time.Minute
+
+	// DefaultMinUpdateInterval is the default minimum amount of time
+	// between two fee updates of a single channel. As each update is
+	// broadcast to the network, this ensures we don't spam our peers
+	// with channel updates, which they'd be entitled to ignore.
+	DefaultMinUpdateInterval = time.Hour
+
+	// minRelativeChange is the smallest change, relative to the current
+	// fee rate, which will warrant an update of the fee rate of a channel.
+	// Smaller changes aren't worth the bandwidth of a channel update.
+	minRelativeChange = 0.1
+)
+
+// ChannelState is a snapshot of a channel, used to determine the fee rate it
+// should charge.
+type ChannelState struct {
+	// ChanPoint is the funding outpoint of the channel.
+	ChanPoint wire.OutPoint
+
+	// Capacity is the total capacity of the channel, with LocalBalance
+	// being our portion of it.
+	Capacity     btcutil.Amount
+	LocalBalance btcutil.Amount
+
+	// TotalSent and TotalReceived are the total number of satoshis we've
+	// sent and received over the channel over its lifetime.
+	TotalSent     btcutil.Amount
+	TotalReceived btcutil.Amount
+
+	// BaseFee and FeeRate are the fees the channel currently charges.
+	BaseFee btcutil.Amount
+	FeeRate btcutil.Amount
+}
+
+// Config houses the parameters and dependencies of the Manager.
+type Config struct {
+	// BaseFee is the base fee that managed channels will charge.
+	BaseFee btcutil.Amount
+
+	// MinFeeRate and MaxFeeRate bound the fee rates, in millionths, that
+	// managed channels will charge. Channels whose local balance is
+	// plentiful charge close to the minimum, while depleted channels
+	// charge close to the maximum.
+	MinFeeRate btcutil.Amount
+	MaxFeeRate btcutil.Amount
+
+	// UpdateInterval is the interval at which the fees of the managed
+	// channels are re-evaluated.
+	UpdateInterval time.Duration
+
+	// MinUpdateInterval is the minimum amount of time between two fee
+	// updates of a single channel.
+	MinUpdateInterval time.Duration
+
+	// FetchChannels returns the current state of all our open channels.
+	FetchChannels func() ([]*ChannelState, error)
+
+	// UpdatePolicy announces the new fees of the channel with the passed
+	// funding outpoint.
+	UpdatePolicy func(chanPoint wire.OutPoint, baseFee,
+		feeRate btcutil.Amount) error
+
+	// FetchManaged returns the funding outpoints of the channels which
+	// have opted into fee management, while SetManaged persists a channel
+	// opting in or out.
+	FetchManaged func() ([]wire.OutPoint, error)
+	SetManaged   func(chanPoint wire.OutPoint, managed bool) error
+
+	// Now returns the current time, allowing tests to control the clock.
+	Now func() time.Time
+}
+
+// chanRecord tracks the state of a managed channel between evaluations.
+type chanRecord struct {
+	// lastUpdate is the last time the fees of the channel were updated.
+	lastUpdate time.Time
+
+	// totalSent and totalReceived are the totals of the channel as of the
+	// last evaluation, used to derive the flow of the channel since. They
+	// are only valid if initialized is set.
+	totalSent     btcutil.Amount
+	totalReceived btcutil.Amount
+	initialized   bool
+}
+
+// Manager periodically adjusts the forwarding fees of the channels which have
+// opted into fee management, based on their liquidity and recent flow. The
+// more depleted our side of a channel, and the more funds flowing out of it,
+// the higher the fee rate it charges, so the remaining liquidity is used
+// sparingly and rebalancing through it is encouraged.
+type Manager struct {
+	started uint32 // To be used atomically.
+	stopped uint32 // To be used atomically.
+
+	cfg *Config
+
+	// managed maps the funding outpoint of each channel which opted into
+	// fee management to its record. It's guarded by the mtx.
+	mtx     sync.Mutex
+	managed map[wire.OutPoint]*chanRecord
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// New creates a new fee manager from the passed config.
+func New(cfg *Config) *Manager {
+	if cfg.UpdateInterval == 0 {
+		cfg.UpdateInterval = DefaultUpdateInterval
+	}
+	if cfg.MinUpdateInterval == 0 {
+		cfg.MinUpdateInterval = DefaultMinUpdateInterval
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+
+	return &Manager{
+		cfg:     cfg,
+		managed: make(map[wire.OutPoint]*chanRecord),
+		quit:    make(chan struct{}),
+	}
+}
+
+// Start loads the set of managed channels, and launches the goroutine
+// periodically adjusting their fees.
+func (m *Manager) Start() error {
+	if !atomic.CompareAndSwapUint32(&m.started, 0, 1) {
+		return nil
+	}
+
+	log.Infof("Fee manager starting")
+
+	chanPoints, err := m.cfg.FetchManaged()
+	if err != nil {
+		return err
+	}
+
+	m.mtx.Lock()
+	for _, chanPoint := range chanPoints {
+		m.managed[chanPoint] = &chanRecord{}
+	}
+	m.mtx.Unlock()
+
+	m.wg.Add(1)
+	go m.updater()
+
+	return nil
+}
+
+// Stop signals the fee manager to exit, and waits for it to do so.
+func (m *Manager) Stop() error {
+	if !atomic.CompareAndSwapUint32(&m.stopped, 0, 1) {
+		return nil
+	}
+
+	log.Infof("Fee manager shutting down")
+
+	close(m.quit)
+	m.wg.Wait()
+
+	return nil
+}
+
+// updater periodically re-evaluates the fees of the managed channels.
+//
+// NOTE: This MUST be run as a goroutine.
+func (m *Manager) updater() {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(m.cfg.UpdateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := m.evaluate(); err != nil {
+				log.Errorf("Unable to evaluate channel fees: %v",
+					err)
+			}
+
+		case <-m.quit:
+			return
+		}
+	}
+}
+
+// SetManaged opts the channel with the passed funding outpoint into, or out
+// of, fee management.
+func (m *Manager) SetManaged(chanPoint wire.OutPoint, managed bool) error {
+	if err := m.cfg.SetManaged(chanPoint, managed); err != nil {
+		return err
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	switch _, ok := m.managed[chanPoint]; {
+	case managed && !ok:
+		m.managed[chanPoint] = &chanRecord{}
+	case !managed:
+		delete(m.managed, chanPoint)
+	}
+
+	log.Infof("ChannelPoint(%v) fee management enabled: %v", chanPoint,
+		managed)
+
+	return nil
+}
+
+// IsManaged returns whether the channel with the passed funding outpoint has
+// opted into fee management.
+func (m *Manager) IsManaged(chanPoint wire.OutPoint) bool {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	_, ok := m.managed[chanPoint]
+	return ok
+}
+
+// evaluate adjusts the fees of each managed channel whose fee rate has
+// drifted sufficiently from the rate its current state warrants, unless its
+// fees were updated too recently.
+func (m *Manager) evaluate() error {
+	chans, err := m.cfg.FetchChannels()
+	if err != nil {
+		return err
+	}
+
+	// The updates are only determined while holding the mutex, as
+	// announcing the new fees may take a while, and shouldn't block the
+	// channels from being opted into or out of fee management.
+	type feeUpdate struct {
+		record    *chanRecord
+		chanPoint wire.OutPoint
+		feeRate   btcutil.Amount
+	}
+	var updates []feeUpdate
+
+	m.mtx.Lock()
+	now := m.cfg.Now()
+	for _, c := range chans {
+		record, ok := m.managed[c.ChanPoint]
+		if !ok {
+			continue
+		}
+
+		// The flow of the channel is measured since the last
+		// evaluation, so it's only known from the second one onwards.
+		var sent, received btcutil.Amount
+		if record.initialized {
+			sent = c.TotalSent - record.totalSent
+			received = c.TotalReceived - record.totalReceived
+		}
+		record.totalSent = c.TotalSent
+		record.totalReceived = c.TotalReceived
+		record.initialized = true
+
+		if now.Sub(record.lastUpdate) < m.cfg.MinUpdateInterval {
+			continue
+		}
+
+		feeRate := m.targetFeeRate(c, sent, received)
+		if !needsUpdate(c, m.cfg.BaseFee, feeRate) {
+			continue
+		}
+
+		updates = append(updates, feeUpdate{
+			record:    record,
+			chanPoint: c.ChanPoint,
+			feeRate:   feeRate,
+		})
+	}
+	m.mtx.Unlock()
+
+	for _, update := range updates {
+		log.Debugf("Updating fees of ChannelPoint(%v): base_fee=%v, "+
+			"fee_rate=%v", update.chanPoint, m.cfg.BaseFee,
+			update.feeRate)
+
+		err := m.cfg.UpdatePolicy(
+			update.chanPoint, m.cfg.BaseFee, update.feeRate,
+		)
+		if err != nil {
+			log.Errorf("Unable to update fees of "+
+				"ChannelPoint(%v): %v", update.chanPoint, err)
+			continue
+		}
+
+		m.mtx.Lock()
+		update.record.lastUpdate = now
+		m.mtx.Unlock()
+	}
+
+	return nil
+}
+
+// targetFeeRate returns the fee rate the channel should charge, given the
+// number of satoshis sent and received over it since the last evaluation. The
+// fee rate scales with the depletion of our local balance, and is further
+// raised by a net outward flow, or lowered by a net inward flow, so the fees
+// react to a channel being drained before its balance runs out.
+func (m *Manager) targetFeeRate(c *ChannelState, sent,
+	received btcutil.Amount) btcutil.Amount {
+
+	if c.Capacity <= 0 {
+		return m.cfg.MaxFeeRate
+	}
+
+	depletion := 1 - float64(c.LocalBalance)/float64(c.Capacity)
+	netFlow := float64(sent-received) / float64(c.Capacity)
+
+	score := depletion + netFlow/2
+	switch {
+	case score < 0:
+		score = 0
+	case score > 1:
+		score = 1
+	}
+
+	feeRange := float64(m.cfg.MaxFeeRate - m.cfg.MinFeeRate)
+	return m.cfg.MinFeeRate + btcutil.Amount(feeRange*score)
+}
+
+// needsUpdate returns whether the fees of the channel should be updated to
+// the passed fees. Changes of the fee rate too small to be worth a channel
+// update are ignored.
+func needsUpdate(c *ChannelState, baseFee, feeRate btcutil.Amount) bool {
+	if c.BaseFee != baseFee {
+		return true
+	}
+
+	diff := feeRate - c.FeeRate
+	if diff < 0 {
+		diff = -diff
+	}
+
+	switch {
+	case diff == 0:
+		return false
+	case c.FeeRate == 0:
+		return true
+	default:
+		return float64(diff)/float64(c.FeeRate) >= minRelativeChange
+	}
+}
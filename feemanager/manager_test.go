@@ -0,0 +1,219 @@
+package feemanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// mockChannels is a set of channels whose fees are updated by the manager.
+type mockChannels struct {
+	chans   map[wire.OutPoint]*ChannelState
+	managed map[wire.OutPoint]bool
+	updates int
+}
+
+func (m *mockChannels) fetchChannels() ([]*ChannelState, error) {
+	var chans []*ChannelState
+	for _, c := range m.chans {
+		state := *c
+		chans = append(chans, &state)
+	}
+	return chans, nil
+}
+
+func (m *mockChannels) updatePolicy(chanPoint wire.OutPoint, baseFee,
+	feeRate btcutil.Amount) error {
+
+	m.chans[chanPoint].BaseFee = baseFee
+	m.chans[chanPoint].FeeRate = feeRate
+	m.updates++
+	return nil
+}
+
+func (m *mockChannels) fetchManaged() ([]wire.OutPoint, error) {
+	var chanPoints []wire.OutPoint
+	for chanPoint, managed := range m.managed {
+		if managed {
+			chanPoints = append(chanPoints, chanPoint)
+		}
+	}
+	return chanPoints, nil
+}
+
+func (m *mockChannels) setManaged(chanPoint wire.OutPoint, managed bool) error {
+	m.managed[chanPoint] = managed
+	return nil
+}
+
+// assertFees asserts the fees currently charged by the channel.
+func assertFees(t *testing.T, c *ChannelState, baseFee,
+	feeRate btcutil.Amount) {
+
+	if c.BaseFee != baseFee || c.FeeRate != feeRate {
+		t.Fatalf("expected base_fee=%v, fee_rate=%v, got base_fee=%v, "+
+			"fee_rate=%v", baseFee, feeRate, c.BaseFee, c.FeeRate)
+	}
+}
+
+// TestFeeManager tests that the fees of managed channels are adjusted to
+// their liquidity and flow, while respecting the update rate limit.
+func TestFeeManager(t *testing.T) {
+	t.Parallel()
+
+	managedPoint := wire.OutPoint{Index: 1}
+	otherPoint := wire.OutPoint{Index: 2}
+	channels := &mockChannels{
+		chans: map[wire.OutPoint]*ChannelState{
+			managedPoint: {
+				ChanPoint:    managedPoint,
+				Capacity:     1000000,
+				LocalBalance: 500000,
+			},
+			otherPoint: {
+				ChanPoint:    otherPoint,
+				Capacity:     1000000,
+				LocalBalance: 0,
+			},
+		},
+		managed: map[wire.OutPoint]bool{
+			managedPoint: true,
+		},
+	}
+
+	now := time.Unix(1500000000, 0)
+	m := New(&Config{
+		BaseFee:           1,
+		MinFeeRate:        1,
+		MaxFeeRate:        1001,
+		UpdateInterval:    time.Hour,
+		MinUpdateInterval: time.Hour,
+		FetchChannels:     channels.fetchChannels,
+		UpdatePolicy:      channels.updatePolicy,
+		FetchManaged:      channels.fetchManaged,
+		SetManaged:        channels.setManaged,
+		Now:               func() time.Time { return now },
+	})
+	if err := m.Start(); err != nil {
+		t.Fatalf("unable to start fee manager: %v", err)
+	}
+	defer m.Stop()
+
+	// A balanced channel should charge the midpoint of the fee range,
+	// while the unmanaged channel should be left untouched.
+	if err := m.evaluate(); err != nil {
+		t.Fatalf("unable to evaluate fees: %v", err)
+	}
+	assertFees(t, channels.chans[managedPoint], 1, 501)
+	assertFees(t, channels.chans[otherPoint], 0, 0)
+
+	// Draining the channel shouldn't result in an update until the rate
+	// limit has expired.
+	managed := channels.chans[managedPoint]
+	managed.LocalBalance = 300000
+	managed.TotalSent = 200000
+	now = now.Add(time.Minute)
+	if err := m.evaluate(); err != nil {
+		t.Fatalf("unable to evaluate fees: %v", err)
+	}
+	assertFees(t, managed, 1, 501)
+
+	// Once it has, the depletion of the channel raises its fee rate,
+	// further raised by the outward flow since the last evaluation.
+	managed.LocalBalance = 200000
+	managed.TotalSent = 300000
+	now = now.Add(time.Hour)
+	if err := m.evaluate(); err != nil {
+		t.Fatalf("unable to evaluate fees: %v", err)
+	}
+	assertFees(t, managed, 1, 851)
+
+	// A change of the fee rate too small to be worth an update should be
+	// ignored.
+	managed.LocalBalance = 170000
+	now = now.Add(time.Hour)
+	updates := channels.updates
+	if err := m.evaluate(); err != nil {
+		t.Fatalf("unable to evaluate fees: %v", err)
+	}
+	if channels.updates != updates {
+		t.Fatalf("expected insignificant change to be ignored")
+	}
+
+	// Finally, once opted out, the channel's fees are no longer managed.
+	if err := m.SetManaged(managedPoint, false); err != nil {
+		t.Fatalf("unable to opt out channel: %v", err)
+	}
+	if m.IsManaged(managedPoint) || channels.managed[managedPoint] {
+		t.Fatalf("channel should no longer be managed")
+	}
+	managed.LocalBalance = 1000000
+	now = now.Add(time.Hour)
+	if err := m.evaluate(); err != nil {
+		t.Fatalf("unable to evaluate fees: %v", err)
+	}
+	assertFees(t, managed, 1, 851)
+}
+
+// TestFeeManagerUpdateUnlocked tests that the fee manager doesn't hold its
+// mutex while announcing new fees, so that the set of managed channels can be
+// queried or modified in the meantime.
+func TestFeeManagerUpdateUnlocked(t *testing.T) {
+	t.Parallel()
+
+	chanPoint := wire.OutPoint{Index: 1}
+	channels := &mockChannels{
+		chans: map[wire.OutPoint]*ChannelState{
+			chanPoint: {
+				ChanPoint:    chanPoint,
+				Capacity:     1000000,
+				LocalBalance: 500000,
+			},
+		},
+		managed: map[wire.OutPoint]bool{
+			chanPoint: true,
+		},
+	}
+
+	var m *Manager
+	m = New(&Config{
+		BaseFee:           1,
+		MinFeeRate:        1,
+		MaxFeeRate:        1001,
+		UpdateInterval:    time.Hour,
+		MinUpdateInterval: time.Hour,
+		FetchChannels:     channels.fetchChannels,
+		UpdatePolicy: func(chanPoint wire.OutPoint, baseFee,
+			feeRate btcutil.Amount) error {
+
+			if !m.IsManaged(chanPoint) {
+				t.Errorf("expected ChannelPoint(%v) to be "+
+					"managed", chanPoint)
+			}
+			return channels.updatePolicy(chanPoint, baseFee, feeRate)
+		},
+		FetchManaged: channels.fetchManaged,
+		SetManaged:   channels.setManaged,
+	})
+	if err := m.Start(); err != nil {
+		t.Fatalf("unable to start fee manager: %v", err)
+	}
+	defer m.Stop()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- m.evaluate()
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unable to evaluate fees: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("fee update blocked on the manager's mutex")
+	}
+	assertFees(t, channels.chans[chanPoint], 1, 501)
+}
@@ -3,11 +3,13 @@ package main
 import (
 	"bytes"
 	"encoding/hex"
+	"net"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/go-errors/errors"
+	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
@@ -102,6 +104,22 @@ type fundingErrorMsg struct {
 // pending single funded channels indexed by their pending channel identifier.
 type pendingChannels map[uint64]*reservationWithCtx
 
+// fundingConfig houses the dependencies of the fundingManager which are
+// provided by the rest of the daemon.
+type fundingConfig struct {
+	// Wallet is the daemon's internal Lightning enabled wallet.
+	Wallet *lnwallet.LightningWallet
+
+	// BreachArbiter is handed each newly opened channel, so it can watch
+	// it for breaches.
+	BreachArbiter *breachArbiter
+
+	// CheckPeer returns an error if channels with the peer with the
+	// passed public key, connected over the passed address, must be
+	// refused.
+	CheckPeer func(*btcec.PublicKey, *net.TCPAddr) error
+}
+
 // fundingManager acts as an orchestrator/bridge between the wallet's
 // 'ChannelReservation' workflow, and the wire protocol's funding initiation
 // messages. Any requests to initiate the funding workflow for a channel,
@@ -121,10 +139,7 @@ type fundingManager struct {
 	resMtx             sync.RWMutex
 	activeReservations map[int32]pendingChannels
 
-	// wallet is the daemon's internal Lightning enabled wallet.
-	wallet *lnwallet.LightningWallet
-
-	breachAribter *breachArbiter
+	cfg *fundingConfig
 
 	// fundingMsgs is a channel which receives wrapped wire messages
 	// related to funding workflow from outside peers.
@@ -146,7 +161,7 @@ type fundingManager struct {
 
 // newFundingManager creates and initializes a new instance of the
 // fundingManager.
-func newFundingManager(w *lnwallet.LightningWallet, b *breachArbiter) *fundingManager {
+func newFundingManager(cfg *fundingConfig) *fundingManager {
 	// TODO(roasbeef): remove once we actually sign the funding_locked
 	// stuffs
 	s := "30450221008ce2bc69281ce27da07e6683571319d18e949ddfa2965fb6caa" +
@@ -156,8 +171,7 @@ func newFundingManager(w *lnwallet.LightningWallet, b *breachArbiter) *fundingMa
 	fakeSig, _ := btcec.ParseSignature(fakeSigHex, btcec.S256())
 
 	return &fundingManager{
-		wallet:        w,
-		breachAribter: b,
+		cfg: cfg,
 
 		fakeProof: &channelProof{
 			nodeSig:    fakeSig,
@@ -330,16 +344,22 @@ func (f *fundingManager) handleFundingRequest(fmsg *fundingRequestMsg) {
 	// Check number of pending channels to be smaller than maximum allowed
 	// number and send ErrorGeneric to remote peer if condition is violated.
 	if len(f.activeReservations[fmsg.peer.id]) >= cfg.MaxPendingChannels {
-		errMsg := &lnwire.ErrorGeneric{
-			ChannelPoint: &wire.OutPoint{
-				Hash:  chainhash.Hash{},
-				Index: 0,
-			},
-			Problem:          "Number of pending channels exceed maximum",
-			Code:             lnwire.ErrorMaxPendingChannels,
-			PendingChannelID: fmsg.msg.ChannelID,
-		}
-		fmsg.peer.queueMsg(errMsg, nil)
+		f.rejectFundingRequest(fmsg, lnwire.ErrorMaxPendingChannels,
+			"Number of pending channels exceed maximum")
+		return
+	}
+
+	// Refuse channels from peers which are banned, or aren't allowlisted
+	// while running in allowlist only mode.
+	err := f.cfg.CheckPeer(
+		fmsg.peer.addr.IdentityKey, fmsg.peer.addr.Address,
+	)
+	if err != nil {
+		fndgLog.Errorf("Rejecting fundingRequest from peerID(%v): %v",
+			fmsg.peer.id, err)
+
+		f.rejectFundingRequest(fmsg, lnwire.ErrorChanRejected,
+			err.Error())
 		return
 	}
 
@@ -347,6 +367,18 @@ func (f *fundingManager) handleFundingRequest(fmsg *fundingRequestMsg) {
 	amt := msg.FundingAmount
 	delay := msg.CsvDelay
 
+	// Before any reservation is made for the channel, ensure that it
+	// satisfies our policy towards inbound channels: its size must be
+	// within our limits, and the peer mustn't already have as many
+	// channels with us as we permit.
+	if err := f.verifyInboundChannel(amt, fmsg.peer); err != nil {
+		fndgLog.Errorf("Rejecting fundingRequest from peerID(%v): %v",
+			fmsg.peer.id, err)
+
+		f.rejectFundingRequest(fmsg, err.code, err.Error())
+		return
+	}
+
 	// TODO(roasbeef): error if funding flow already ongoing
 	fndgLog.Infof("Recv'd fundingRequest(amt=%v, push=%v, delay=%v, pendingId=%v) "+
 		"from peerID(%v)", amt, msg.PushSatoshis, delay, msg.ChannelID,
@@ -355,6 +387,31 @@ func (f *fundingManager) handleFundingRequest(fmsg *fundingRequestMsg) {
 	ourDustLimit := lnwallet.DefaultDustLimit()
 	theirDustlimit := msg.DustLimit
 
+	// Ensure that the constraints the initiator wishes to impose upon us
+	// leave the channel usable before committing to it.
+	theirConstraints := constraintsFromWire(msg.ChannelReserve,
+		msg.MaxValueInFlight, msg.HtlcMinimum, msg.MaxAcceptedHTLCs)
+	err = lnwallet.VerifyConstraints(theirConstraints, amt)
+	if err != nil {
+		fndgLog.Errorf("Unacceptable channel constraints from "+
+			"peerID(%v): %v", fmsg.peer.id, err)
+		fmsg.peer.Disconnect()
+		return
+	}
+	ourConstraints := defaultConstraints(channeldb.ChannelConstraints{},
+		amt)
+
+	// The initiator may only propose a channel type we both support.
+	commitType, err := commitTypeFromWire(msg.ChannelType, fmsg.peer)
+	if err != nil {
+		fndgLog.Errorf("Rejecting fundingRequest from peerID(%v): %v",
+			fmsg.peer.id, err)
+
+		f.rejectFundingRequest(fmsg, lnwire.ErrorUnsupportedChanType,
+			err.Error())
+		return
+	}
+
 	// Attempt to initialize a reservation within the wallet. If the wallet
 	// has insufficient resources to create the channel, then the reservation
 	// attempt may be rejected. Note that since we're on the responding
@@ -363,9 +420,9 @@ func (f *fundingManager) handleFundingRequest(fmsg *fundingRequestMsg) {
 	// TODO(roasbeef): passing num confs 1 is irrelevant here, make signed?
 	// TODO(roasbeef): assuming this was an inbound connection, replace
 	// port with default advertised port
-	reservation, err := f.wallet.InitChannelReservation(amt, 0,
+	reservation, err := f.cfg.Wallet.InitChannelReservation(amt, 0,
 		fmsg.peer.addr.IdentityKey, fmsg.peer.addr.Address, 1, delay,
-		ourDustLimit, msg.PushSatoshis)
+		ourDustLimit, msg.PushSatoshis, nil)
 	if err != nil {
 		// TODO(roasbeef): push ErrorGeneric message
 		fndgLog.Errorf("Unable to initialize reservation: %v", err)
@@ -373,7 +430,20 @@ func (f *fundingManager) handleFundingRequest(fmsg *fundingRequestMsg) {
 		return
 	}
 
+	reservation.SetCommitType(commitType)
 	reservation.SetTheirDustLimit(theirDustlimit)
+	reservation.SetOurConstraints(ourConstraints)
+	reservation.SetTheirConstraints(theirConstraints)
+
+	// If an upfront shutdown address has been configured, commit to it as
+	// our delivery address in place of the one generated by the wallet.
+	if err := setUpfrontShutdownAddr(reservation, nil); err != nil {
+		fndgLog.Errorf("Unable to set upfront shutdown address: %v",
+			err)
+		reservation.Cancel()
+		fmsg.peer.Disconnect()
+		return
+	}
 
 	// Once the reservation has been created successfully, we add it to this
 	// peers map of pending reservations to track this particular reservation
@@ -399,6 +469,7 @@ func (f *fundingManager) handleFundingRequest(fmsg *fundingRequestMsg) {
 		FundingAmount:   amt,
 		MultiSigKey:     msg.ChannelDerivationPoint,
 		CommitKey:       msg.CommitmentKey,
+		PaymentKey:      msg.PaymentKey,
 		DeliveryAddress: addrs[0],
 		CsvDelay:        delay,
 	}
@@ -422,6 +493,11 @@ func (f *fundingManager) handleFundingRequest(fmsg *fundingRequestMsg) {
 		ourContribution.RevocationKey, ourContribution.CommitKey,
 		ourContribution.MultiSigKey, ourContribution.CsvDelay,
 		deliveryScript, ourDustLimit)
+	fundingResp.ChannelReserve = ourConstraints.ChanReserve
+	fundingResp.MaxValueInFlight = ourConstraints.MaxPendingAmount
+	fundingResp.HtlcMinimum = ourConstraints.MinHTLC
+	fundingResp.MaxAcceptedHTLCs = ourConstraints.MaxAcceptedHtlcs
+	fundingResp.PaymentKey = ourContribution.PaymentKey
 
 	fmsg.peer.queueMsg(fundingResp, nil)
 }
@@ -452,6 +528,24 @@ func (f *fundingManager) handleFundingResponse(fmsg *fundingResponseMsg) {
 
 	resCtx.reservation.SetTheirDustLimit(msg.DustLimit)
 
+	// Ensure that the constraints the responder wishes to impose upon us
+	// leave the channel usable, otherwise the funding workflow is aborted.
+	theirConstraints := constraintsFromWire(msg.ChannelReserve,
+		msg.MaxValueInFlight, msg.HtlcMinimum, msg.MaxAcceptedHTLCs)
+	capacity := resCtx.reservation.Capacity()
+	err = lnwallet.VerifyConstraints(theirConstraints, capacity)
+	if err != nil {
+		fndgLog.Errorf("Unacceptable channel constraints from "+
+			"peerID(%v): %v", peerID, err)
+		if _, err := f.cancelReservationCtx(peerID, chanID); err != nil {
+			fndgLog.Warnf("unable to delete reservation: %v", err)
+		}
+		fmsg.peer.Disconnect()
+		resCtx.err <- err
+		return
+	}
+	resCtx.reservation.SetTheirConstraints(theirConstraints)
+
 	// The remote node has responded with their portion of the channel
 	// contribution. At this point, we can process their contribution which
 	// allows us to construct and sign both the commitment transaction, and
@@ -466,6 +560,7 @@ func (f *fundingManager) handleFundingResponse(fmsg *fundingResponseMsg) {
 		FundingAmount:   0,
 		MultiSigKey:     msg.ChannelDerivationPoint,
 		CommitKey:       msg.CommitmentKey,
+		PaymentKey:      msg.PaymentKey,
 		DeliveryAddress: addrs[0],
 		RevocationKey:   msg.RevocationKey,
 		CsvDelay:        msg.CsvDelay,
@@ -570,6 +665,11 @@ func (f *fundingManager) handleFundingComplete(fmsg *fundingCompleteMsg) {
 
 	signComplete := lnwire.NewSingleFundingSignComplete(chanID, ourCommitSig)
 	fmsg.peer.queueMsg(signComplete, nil)
+
+	fmsg.peer.server.channelNotifier.notifyChannelEvent(
+		lnrpc.ChannelEventUpdate_PENDING_OPEN_CHANNEL, *fundingOut,
+		fmsg.peer.addr.IdentityKey,
+	)
 }
 
 // processFundingSignComplete sends a single funding sign complete message
@@ -664,7 +764,7 @@ func newChanAnnouncement(localIdentity *btcec.PublicKey,
 		ChannelID:                 chanID,
 		Timestamp:                 uint32(time.Now().Unix()),
 		Flags:                     chanFlags,
-		Expiry:                    1,
+		Expiry:                    uint16(cfg.TimeLockDelta),
 		HtlcMinimumMstat:          0,
 		FeeBaseMstat:              0,
 		FeeProportionalMillionths: 0,
@@ -711,6 +811,11 @@ func (f *fundingManager) handleFundingSignComplete(fmsg *fundingSignCompleteMsg)
 	// is over.
 	// TODO(roasbeef): add abstraction over updates to accomdate
 	// long-polling, or SSE, etc.
+	fmsg.peer.server.channelNotifier.notifyChannelEvent(
+		lnrpc.ChannelEventUpdate_PENDING_OPEN_CHANNEL, *fundingPoint,
+		fmsg.peer.addr.IdentityKey,
+	)
+
 	resCtx.updates <- &lnrpc.OpenStatusUpdate{
 		Update: &lnrpc.OpenStatusUpdate_ChanPending{
 			ChanPending: &lnrpc.PendingUpdate{
@@ -737,6 +842,11 @@ func (f *fundingManager) handleFundingSignComplete(fmsg *fundingSignCompleteMsg)
 		// Now that the channel is open, we need to notify a number of
 		// parties of this event.
 
+		fmsg.peer.server.channelNotifier.notifyChannelEvent(
+			lnrpc.ChannelEventUpdate_OPEN_CHANNEL, *fundingPoint,
+			fmsg.peer.addr.IdentityKey,
+		)
+
 		// First we send the newly opened channel to the source server
 		// peer.
 		fmsg.peer.newChannels <- openChan
@@ -744,7 +854,7 @@ func (f *fundingManager) handleFundingSignComplete(fmsg *fundingSignCompleteMsg)
 		// Afterwards we send the breach arbiter the new channel so it
 		// can watch for attempts to breach the channel's contract by
 		// the remote party.
-		f.breachAribter.newContracts <- openChan
+		f.cfg.BreachArbiter.newContracts <- openChan
 
 		// With the block height and the transaction index known, we
 		// can construct the compact chainID which is used on the
@@ -798,7 +908,7 @@ func (f *fundingManager) announceChannel(s *server,
 
 	// TODO(roasbeef): need a Signer.SignMessage method to finalize
 	// advertisements
-	localIdentity := s.identityPriv.PubKey()
+	localIdentity := s.nodeKey.PubKey()
 	chanAnnouncement := newChanAnnouncement(localIdentity, channel,
 		chanID, localProof, remoteProof)
 
@@ -831,6 +941,55 @@ func (f *fundingManager) handleFundingOpen(fmsg *fundingOpenMsg) {
 	// TODO(roasbeef): send off to the spv proof verifier, in the routing
 	// sub-module.
 
+	// Before committing to the channel, we'll wait for the funding
+	// transaction to reach the number of confirmations warranted by the
+	// size of the channel.
+	numConfs := numRequiredConfs(resCtx.reservation.Capacity())
+	fundingTxid := resCtx.reservation.FundingOutpoint().Hash
+	notifier := fmsg.peer.server.chainNotifier
+	confNtfn, err := notifier.RegisterConfirmationsNtfn(&fundingTxid,
+		numConfs)
+	if err != nil {
+		fndgLog.Errorf("unable to register for confirmation of "+
+			"funding txid(%v): %v", fundingTxid, err)
+		fmsg.peer.Disconnect()
+		return
+	}
+
+	fndgLog.Infof("Waiting for funding txid(%v) of pendingID(%v) to reach "+
+		"%v confirmations", fundingTxid, chanID, numConfs)
+
+	// As this may take a while, we wait within a goroutine so other
+	// funding workflows aren't held up.
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+
+		select {
+		case _, ok := <-confNtfn.Confirmed:
+			if !ok {
+				fndgLog.Warnf("ChainNotifier shutting down, "+
+					"cannot complete funding of pendingID(%v)",
+					chanID)
+				return
+			}
+		case <-f.quit:
+			return
+		}
+
+		f.finalizeFundingOpen(fmsg, resCtx)
+	}()
+}
+
+// finalizeFundingOpen commits the channel of the responder to a single funder
+// channel workflow to disk once its funding transaction has sufficiently
+// confirmed, and notifies the source peer of the newly opened channel.
+func (f *fundingManager) finalizeFundingOpen(fmsg *fundingOpenMsg,
+	resCtx *reservationWithCtx) {
+
+	chanID := fmsg.msg.ChannelID
+	peerID := fmsg.peer.id
+
 	// Now that we've verified the initiator's proof, we'll commit the
 	// channel state to disk, and notify the source peer of a newly opened
 	// channel.
@@ -846,7 +1005,7 @@ func (f *fundingManager) handleFundingOpen(fmsg *fundingOpenMsg) {
 	f.deleteReservationCtx(peerID, chanID)
 
 	fndgLog.Infof("FundingOpen: ChannelPoint(%v) with peerID(%v) is now open",
-		resCtx.reservation.FundingOutpoint, peerID)
+		resCtx.reservation.FundingOutpoint(), peerID)
 
 	// Notify the L3 routing manager of the newly active channel link.
 	// TODO(roasbeef): should have sigs, only after funding_locked is
@@ -859,10 +1018,23 @@ func (f *fundingManager) handleFundingOpen(fmsg *fundingOpenMsg) {
 	// Send the newly opened channel to the breach arbiter to it can watch
 	// for uncooperative channel breaches, potentially punishing the
 	// counter-party for attempting to cheat us.
-	f.breachAribter.newContracts <- openChan
+	select {
+	case f.cfg.BreachArbiter.newContracts <- openChan:
+	case <-f.quit:
+		return
+	}
+
+	fmsg.peer.server.channelNotifier.notifyChannelEvent(
+		lnrpc.ChannelEventUpdate_OPEN_CHANNEL,
+		*openChan.ChannelPoint(), fmsg.peer.addr.IdentityKey,
+	)
 
 	// Finally, notify the target peer of the newly open channel.
-	fmsg.peer.newChannels <- openChan
+	select {
+	case fmsg.peer.newChannels <- openChan:
+	case <-fmsg.peer.quit:
+	case <-f.quit:
+	}
 }
 
 // initFundingWorkflow sends a message to the funding manager instructing it
@@ -894,13 +1066,47 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 		msg.pushAmt, capacity, numConfs, msg.peer.addr.Address,
 		ourDustLimit)
 
+	err := f.cfg.CheckPeer(nodeID, msg.peer.addr.Address)
+	if err != nil {
+		msg.err <- err
+		return
+	}
+
+	if err := verifyChanSize(capacity, msg.peer); err != nil {
+		msg.err <- err
+		return
+	}
+
 	// Initialize a funding reservation with the local wallet. If the
 	// wallet doesn't have enough funds to commit to this channel, then
 	// the request will fail, and be aborted.
-	reservation, err := f.wallet.InitChannelReservation(capacity, localAmt,
+	reservation, err := f.cfg.Wallet.InitChannelReservation(capacity, localAmt,
 		nodeID, msg.peer.addr.Address, uint16(numConfs), 4,
-		ourDustLimit, msg.pushAmt)
+		ourDustLimit, msg.pushAmt, msg.coinSelection)
+	if err != nil {
+		msg.err <- err
+		return
+	}
+
+	// Impose the requested constraints upon the remote party, falling
+	// back to the configured defaults for any left unset.
+	ourConstraints := defaultConstraints(msg.constraints, capacity)
+	reservation.SetOurConstraints(ourConstraints)
+
+	// Use the static remote key commitment type if the peer supports it,
+	// allowing our funds to be recovered from our seed alone should the
+	// peer force close the channel.
+	channelType := lnwire.ChanTypeLegacy
+	if msg.peer.supportsStaticRemoteKey() {
+		channelType = lnwire.ChanTypeStaticRemoteKey
+		reservation.SetCommitType(channeldb.CommitTypeStaticRemoteKey)
+	}
+
+	// Commit to the requested upfront shutdown address, or the configured
+	// default, as our delivery address if either is set.
+	err = setUpfrontShutdownAddr(reservation, msg.deliveryAddr)
 	if err != nil {
+		reservation.Cancel()
 		msg.err <- err
 		return
 	}
@@ -944,7 +1150,7 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 	// TODO(roasbeef): need to set fee/kb
 	fundingReq := lnwire.NewSingleFundingRequest(
 		chanID,
-		msg.channelType,
+		channelType,
 		msg.coinType,
 		0, // TODO(roasbeef): grab from fee estimation model
 		capacity,
@@ -955,9 +1161,253 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 		ourDustLimit,
 		msg.pushAmt,
 	)
+	fundingReq.ChannelReserve = ourConstraints.ChanReserve
+	fundingReq.MaxValueInFlight = ourConstraints.MaxPendingAmount
+	fundingReq.HtlcMinimum = ourConstraints.MinHTLC
+	fundingReq.MaxAcceptedHTLCs = ourConstraints.MaxAcceptedHtlcs
+	fundingReq.PaymentKey = contribution.PaymentKey
 	msg.peer.queueMsg(fundingReq, nil)
 }
 
+// commitTypeFromWire returns the commitment type of a channel of the passed
+// wire channel type, proposed by the passed peer. An error is returned if the
+// channel type is unknown, or not supported by both us and the peer.
+func commitTypeFromWire(channelType uint8,
+	peer *peer) (channeldb.CommitmentType, error) {
+
+	switch channelType {
+	case lnwire.ChanTypeLegacy:
+		return channeldb.CommitTypeLegacy, nil
+
+	case lnwire.ChanTypeStaticRemoteKey:
+		if !peer.supportsStaticRemoteKey() {
+			return 0, errors.Errorf("static remote key channels " +
+				"not supported")
+		}
+		return channeldb.CommitTypeStaticRemoteKey, nil
+
+	default:
+		return 0, errors.Errorf("unknown channel type: %v", channelType)
+	}
+}
+
+// defaultConstraints returns the channel constraints to impose upon the
+// remote party within a channel of the passed capacity, replacing any unset
+// field of the passed constraints with the configured default.
+func defaultConstraints(c channeldb.ChannelConstraints,
+	capacity btcutil.Amount) channeldb.ChannelConstraints {
+
+	if c.ChanReserve == 0 {
+		c.ChanReserve = btcutil.Amount(cfg.ChanReserve)
+	}
+	if c.ChanReserve == 0 {
+		c.ChanReserve = lnwallet.DefaultChanReserve(capacity)
+	}
+	if c.MaxPendingAmount == 0 {
+		c.MaxPendingAmount = btcutil.Amount(cfg.MaxPendingAmount)
+	}
+	if c.MaxPendingAmount == 0 {
+		c.MaxPendingAmount = lnwallet.DefaultMaxPendingAmount(capacity)
+	}
+	if c.MaxPendingAmount > capacity {
+		c.MaxPendingAmount = capacity
+	}
+	if c.MinHTLC == 0 {
+		c.MinHTLC = btcutil.Amount(cfg.MinHTLC)
+	}
+	if c.MaxAcceptedHtlcs == 0 {
+		c.MaxAcceptedHtlcs = cfg.MaxAcceptedHTLCs
+	}
+
+	return c
+}
+
+// verifyChanSize ensures that a channel of the passed capacity is no larger
+// than our maximum channel size, and that channels beyond the limit of the
+// protocol are only opened with peers which negotiated wumbo channels.
+func verifyChanSize(capacity btcutil.Amount, p *peer) error {
+	maxChanSize := btcutil.Amount(cfg.MaxChanSize)
+	if capacity > maxChanSize {
+		return errors.Errorf("channel of %v exceeds the maximum channel "+
+			"size of %v", capacity, maxChanSize)
+	}
+
+	if capacity > lnwallet.MaxBtcFundingAmount && !p.supportsWumbo() {
+		return errors.Errorf("channel of %v exceeds the maximum channel "+
+			"size of %v, as wumbo channels weren't negotiated",
+			capacity, lnwallet.MaxBtcFundingAmount)
+	}
+
+	return nil
+}
+
+// fundingPolicyError is returned when an inbound channel violates our policy
+// towards inbound channels, carrying the code used to reject it.
+type fundingPolicyError struct {
+	code lnwire.ErrorCode
+	err  error
+}
+
+// Error returns a human-readable description of the policy violation.
+func (e *fundingPolicyError) Error() string {
+	return e.err.Error()
+}
+
+// verifyInboundChannel ensures that an inbound channel of the passed capacity
+// proposed by the target peer satisfies our channel size limits, and that
+// accepting it wouldn't exceed the number of channels we permit per peer. Both
+// open and pending channels count towards the latter limit.
+func (f *fundingManager) verifyInboundChannel(capacity btcutil.Amount,
+	p *peer) *fundingPolicyError {
+
+	if err := verifyChanSize(capacity, p); err != nil {
+		return &fundingPolicyError{lnwire.ErrorChanTooLarge, err}
+	}
+
+	minChanSize := btcutil.Amount(cfg.MinChanSize)
+	if capacity < minChanSize {
+		err := errors.Errorf("channel of %v is below the minimum "+
+			"channel size of %v", capacity, minChanSize)
+		return &fundingPolicyError{lnwire.ErrorChanTooSmall, err}
+	}
+
+	if cfg.MaxChansPerPeer == 0 {
+		return nil
+	}
+
+	openChans, err := f.cfg.Wallet.ChannelDB.FetchOpenChannels(
+		p.addr.IdentityKey,
+	)
+	if err != nil {
+		return &fundingPolicyError{lnwire.ErrorMaxChannelsPerPeer, err}
+	}
+
+	f.resMtx.RLock()
+	numPending := len(f.activeReservations[p.id])
+	f.resMtx.RUnlock()
+
+	numChans := len(openChans) + numPending
+	if numChans >= cfg.MaxChansPerPeer {
+		err := errors.Errorf("peer already has %v channels, the "+
+			"maximum number of channels per peer is %v", numChans,
+			cfg.MaxChansPerPeer)
+		return &fundingPolicyError{lnwire.ErrorMaxChannelsPerPeer, err}
+	}
+
+	return nil
+}
+
+// rejectFundingRequest rejects the pending channel proposed by the funding
+// request, notifying the remote peer of the reason via an ErrorGeneric.
+func (f *fundingManager) rejectFundingRequest(fmsg *fundingRequestMsg,
+	code lnwire.ErrorCode, problem string) {
+
+	errMsg := &lnwire.ErrorGeneric{
+		ChannelPoint: &wire.OutPoint{
+			Hash:  chainhash.Hash{},
+			Index: 0,
+		},
+		Problem:          problem,
+		Code:             code,
+		PendingChannelID: fmsg.msg.ChannelID,
+	}
+	fmsg.peer.queueMsg(errMsg, nil)
+}
+
+// numRequiredConfs returns the number of confirmations we require of the
+// funding transaction of an inbound channel of the passed capacity before
+// considering it open. The number scales linearly with the capacity of the
+// channel relative to our maximum channel size, within the configured bounds,
+// as larger channels put more of our funds at risk should the funding
+// transaction be double spent.
+func numRequiredConfs(capacity btcutil.Amount) uint32 {
+	minConfs := cfg.MinFundingConfs
+	maxConfs := cfg.MaxFundingConfs
+
+	maxChanSize := btcutil.Amount(cfg.MaxChanSize)
+	if maxChanSize <= 0 {
+		return maxConfs
+	}
+
+	confs := uint32(uint64(maxConfs) * uint64(capacity) /
+		uint64(maxChanSize))
+	switch {
+	case confs < minConfs:
+		return minConfs
+	case confs > maxConfs:
+		return maxConfs
+	default:
+		return confs
+	}
+}
+
+// parseUpfrontShutdownAddr decodes an address our funds are to be paid to upon
+// a cooperative close. Only addresses whose script fits within the delivery
+// script of the funding messages are accepted. An empty string yields a nil
+// address.
+func parseUpfrontShutdownAddr(addr string) (btcutil.Address, error) {
+	if addr == "" {
+		return nil, nil
+	}
+
+	deliveryAddr, err := btcutil.DecodeAddress(addr,
+		activeNetParams.Params)
+	if err != nil {
+		return nil, err
+	}
+	if !deliveryAddr.IsForNet(activeNetParams.Params) {
+		return nil, errors.Errorf("upfront shutdown address %v is not "+
+			"for the active network", addr)
+	}
+
+	deliveryScript, err := txscript.PayToAddrScript(deliveryAddr)
+	if err != nil {
+		return nil, err
+	}
+	if len(deliveryScript) > 25 {
+		return nil, errors.Errorf("upfront shutdown address %v must be "+
+			"a P2PKH, P2SH or P2WKH address", addr)
+	}
+
+	return deliveryAddr, nil
+}
+
+// setUpfrontShutdownAddr commits the reservation to paying our funds to the
+// passed address upon a cooperative close, falling back to the configured
+// upfront shutdown address if nil. If neither is set, the reservation is left
+// with the fresh delivery address generated by the wallet.
+func setUpfrontShutdownAddr(reservation *lnwallet.ChannelReservation,
+	deliveryAddr btcutil.Address) error {
+
+	if deliveryAddr == nil {
+		var err error
+		deliveryAddr, err = parseUpfrontShutdownAddr(
+			cfg.UpfrontShutdownAddr,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	if deliveryAddr == nil {
+		return nil
+	}
+
+	return reservation.SetOurDeliveryAddress(deliveryAddr)
+}
+
+// constraintsFromWire assembles the channel constraints carried within a
+// funding message.
+func constraintsFromWire(reserve, maxInFlight, minHTLC btcutil.Amount,
+	maxHTLCs uint16) channeldb.ChannelConstraints {
+
+	return channeldb.ChannelConstraints{
+		ChanReserve:      reserve,
+		MaxPendingAmount: maxInFlight,
+		MinHTLC:          minHTLC,
+		MaxAcceptedHtlcs: maxHTLCs,
+	}
+}
+
 // processErrorGeneric sends a message to the fundingManager allowing it to
 // process the occurred generic error.
 func (f *fundingManager) processErrorGeneric(err *lnwire.ErrorGeneric,
@@ -973,7 +1423,10 @@ func (f *fundingManager) handleErrorGenericMsg(fmsg *fundingErrorMsg) {
 	e := fmsg.err
 
 	switch e.Code {
-	case lnwire.ErrorMaxPendingChannels:
+	case lnwire.ErrorMaxPendingChannels, lnwire.ErrorChanTooLarge,
+		lnwire.ErrorChanTooSmall, lnwire.ErrorMaxChannelsPerPeer,
+		lnwire.ErrorChanRejected:
+
 		peerID := fmsg.peer.id
 		chanID := fmsg.err.PendingChannelID
 
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/lightningnetwork/lnd/feature"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcutil"
+)
+
+// TestNumRequiredConfs asserts that the number of confirmations required of
+// the funding transaction of an inbound channel scales with its size, within
+// the configured bounds.
+func TestNumRequiredConfs(t *testing.T) {
+	oldCfg := cfg
+	defer func() {
+		cfg = oldCfg
+	}()
+	cfg = &config{
+		MaxChanSize:     1000000,
+		MinFundingConfs: 2,
+		MaxFundingConfs: 6,
+	}
+
+	tests := []struct {
+		capacity btcutil.Amount
+		confs    uint32
+	}{
+		// Small channels only require the minimum.
+		{capacity: 20000, confs: 2},
+
+		// Channels in between scale linearly with their size.
+		{capacity: 500000, confs: 3},
+		{capacity: 850000, confs: 5},
+
+		// The largest channels require the maximum.
+		{capacity: 1000000, confs: 6},
+	}
+
+	for _, test := range tests {
+		confs := numRequiredConfs(test.capacity)
+		if confs != test.confs {
+			t.Fatalf("expected %v confs for channel of %v, got %v",
+				test.confs, test.capacity, confs)
+		}
+	}
+}
+
+// TestVerifyChanSize asserts that channels larger than our maximum channel
+// size are rejected, and that channels beyond the limit of the protocol are
+// only permitted with peers which negotiated wumbo channels.
+func TestVerifyChanSize(t *testing.T) {
+	oldCfg := cfg
+	defer func() {
+		cfg = oldCfg
+	}()
+	cfg = &config{
+		MaxChanSize: int64(lnwallet.MaxBtcFundingAmount) * 10,
+	}
+
+	newPeer := func(wumbo bool, remoteFeatures *lnwire.FeatureVector) *peer {
+		featureMgr, err := feature.NewManager(feature.Config{
+			NoWumbo: !wumbo,
+		})
+		if err != nil {
+			t.Fatalf("unable to create feature manager: %v", err)
+		}
+
+		return &peer{
+			server:              &server{featureMgr: featureMgr},
+			remoteLocalFeatures: remoteFeatures,
+		}
+	}
+
+	wumboFeatures := lnwire.NewFeatureVector(lnwire.WumboChannelsOptional)
+	wumbo := btcutil.Amount(lnwallet.MaxBtcFundingAmount) + 1
+
+	tests := []struct {
+		name     string
+		capacity btcutil.Amount
+		peer     *peer
+		valid    bool
+	}{
+		{
+			name:     "regular channel without wumbo",
+			capacity: lnwallet.MaxBtcFundingAmount,
+			peer:     newPeer(false, nil),
+			valid:    true,
+		},
+		{
+			name:     "wumbo channel negotiated",
+			capacity: wumbo,
+			peer:     newPeer(true, wumboFeatures),
+			valid:    true,
+		},
+		{
+			name:     "wumbo channel disabled locally",
+			capacity: wumbo,
+			peer:     newPeer(false, wumboFeatures),
+			valid:    false,
+		},
+		{
+			name:     "wumbo channel unsupported by peer",
+			capacity: wumbo,
+			peer:     newPeer(true, lnwire.NewFeatureVector()),
+			valid:    false,
+		},
+		{
+			name:     "wumbo channel before init",
+			capacity: wumbo,
+			peer:     newPeer(true, nil),
+			valid:    false,
+		},
+		{
+			name:     "channel above maximum size",
+			capacity: btcutil.Amount(cfg.MaxChanSize) + 1,
+			peer:     newPeer(true, wumboFeatures),
+			valid:    false,
+		},
+	}
+
+	for _, test := range tests {
+		err := verifyChanSize(test.capacity, test.peer)
+		switch {
+		case test.valid && err != nil:
+			t.Fatalf("%v: unexpected error: %v", test.name, err)
+		case !test.valid && err == nil:
+			t.Fatalf("%v: expected channel of %v to be rejected",
+				test.name, test.capacity)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package healthcheck
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultInterval is the default time between runs of each check.
+	DefaultInterval = time.Minute
+
+	// DefaultAttempts is the default number of consecutive failed
+	// attempts after which a check is considered failed.
+	DefaultAttempts = 3
+
+	// DefaultTimeout is the default time a single attempt of a check may
+	// run for.
+	DefaultTimeout = 30 * time.Second
+
+	// DefaultBackoff is the default time waited between failed attempts.
+	DefaultBackoff = 30 * time.Second
+
+	// DefaultRequiredDiskSpace is the default minimum ratio of free disk
+	// space within the data directory.
+	DefaultRequiredDiskSpace = 0.1
+
+	// DefaultTLSInterval is the default time between checks of the
+	// expiry of the chain backend's TLS certificate, which changes far
+	// less often than the state of the other checked resources.
+	DefaultTLSInterval = time.Hour
+)
+
+// CheckConfig houses the command line options shared by all checks.
+type CheckConfig struct {
+	Interval  time.Duration `long:"interval" description:"How often the check is run"`
+	Attempts  int           `long:"attempts" description:"The number of consecutive failed attempts after which the check is considered failed. Set to 0 to disable the check."`
+	Timeout   time.Duration `long:"timeout" description:"The time a single attempt of the check may run for"`
+	Backoff   time.Duration `long:"backoff" description:"The time waited between failed attempts of the check"`
+	AlertOnly bool          `long:"alertonly" description:"Only log an error once the check has failed, rather than shutting down"`
+}
+
+// DiskCheckConfig houses the command line options of the disk space check.
+type DiskCheckConfig struct {
+	RequiredRemaining float64 `long:"diskrequired" description:"The minimum ratio of free disk space within the data directory, between 0 and 1"`
+
+	CheckConfig
+}
+
+// Config houses the command line options of each of the health checks.
+type Config struct {
+	ChainCheck *CheckConfig `group:"chainbackend" namespace:"chainbackend"`
+
+	DiskCheck *DiskCheckConfig `group:"diskspace" namespace:"diskspace"`
+
+	TLSCheck *CheckConfig `group:"tls" namespace:"tls"`
+
+	RemoteSigner *CheckConfig `group:"remotesigner" namespace:"remotesigner"`
+}
+
+// DefaultConfig returns the default options of each of the health checks.
+func DefaultConfig() *Config {
+	defaultCheck := func(interval time.Duration) CheckConfig {
+		return CheckConfig{
+			Interval: interval,
+			Attempts: DefaultAttempts,
+			Timeout:  DefaultTimeout,
+			Backoff:  DefaultBackoff,
+		}
+	}
+
+	chainCheck := defaultCheck(DefaultInterval)
+	tlsCheck := defaultCheck(DefaultTLSInterval)
+	remoteSigner := defaultCheck(DefaultInterval)
+
+	return &Config{
+		ChainCheck: &chainCheck,
+		DiskCheck: &DiskCheckConfig{
+			RequiredRemaining: DefaultRequiredDiskSpace,
+			CheckConfig:       defaultCheck(DefaultInterval),
+		},
+		TLSCheck:     &tlsCheck,
+		RemoteSigner: &remoteSigner,
+	}
+}
+
+// Validate checks that the options of each of the health checks are sane.
+func (c *Config) Validate() error {
+	checks := map[string]*CheckConfig{
+		"chainbackend": c.ChainCheck,
+		"diskspace":    &c.DiskCheck.CheckConfig,
+		"tls":          c.TLSCheck,
+		"remotesigner": c.RemoteSigner,
+	}
+	for name, check := range checks {
+		if err := check.validate(); err != nil {
+			return fmt.Errorf("invalid %v health check: %v", name,
+				err)
+		}
+	}
+
+	if c.DiskCheck.RequiredRemaining < 0 ||
+		c.DiskCheck.RequiredRemaining >= 1 {
+
+		return fmt.Errorf("invalid diskspace health check: required " +
+			"disk space ratio must be within [0, 1)")
+	}
+
+	return nil
+}
+
+// validate checks that the options of a single check are sane. The remaining
+// options of a disabled check aren't validated.
+func (c *CheckConfig) validate() error {
+	switch {
+	case c.Attempts < 0:
+		return fmt.Errorf("attempts must not be negative")
+
+	case c.Attempts == 0:
+		return nil
+
+	case c.Interval <= 0:
+		return fmt.Errorf("interval must be positive")
+
+	case c.Timeout <= 0:
+		return fmt.Errorf("timeout must be positive")
+
+	case c.Backoff < 0:
+		return fmt.Errorf("backoff must not be negative")
+	}
+
+	return nil
+}
+
+// NewObservation creates a check from the passed options. If the check is
+// disabled, then nil is returned.
+func (c *CheckConfig) NewObservation(name string,
+	check func() error) *Observation {
+
+	if c.Attempts == 0 {
+		return nil
+	}
+
+	return &Observation{
+		Name:      name,
+		Check:     check,
+		Interval:  c.Interval,
+		Attempts:  c.Attempts,
+		Timeout:   c.Timeout,
+		Backoff:   c.Backoff,
+		AlertOnly: c.AlertOnly,
+	}
+}
@@ -0,0 +1,16 @@
+// +build linux darwin freebsd
+
+package healthcheck
+
+import "syscall"
+
+// AvailableDiskSpaceRatio returns the ratio of the disk space available to
+// unprivileged users within the file system holding the passed path.
+func AvailableDiskSpaceRatio(path string) (float64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+
+	return float64(stat.Bavail) / float64(stat.Blocks), nil
+}
@@ -0,0 +1,12 @@
+// +build !linux,!darwin,!freebsd
+
+package healthcheck
+
+import "fmt"
+
+// AvailableDiskSpaceRatio returns an error, as querying the available disk
+// space isn't supported on this platform.
+func AvailableDiskSpaceRatio(path string) (float64, error) {
+	return 0, fmt.Errorf("disk space check not supported on this " +
+		"platform")
+}
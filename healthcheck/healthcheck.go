@@ -0,0 +1,194 @@
+// Package healthcheck contains a monitor which periodically runs a set of
+// liveness checks against the resources the daemon depends on, such as its
+// chain backend or disk, shutting the daemon down once a check has failed
+// repeatedly.
+package healthcheck
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// MonitorConfig houses the set of checks run by the Monitor, along with the
+// function it calls to shut down the daemon upon a sustained failure.
+type MonitorConfig struct {
+	// Checks is the set of checks that are periodically run.
+	Checks []*Observation
+
+	// Shutdown is called when a check which isn't AlertOnly has failed
+	// all of its attempts. It should request a clean shutdown of the
+	// daemon.
+	Shutdown func(format string, params ...interface{})
+}
+
+// Monitor periodically runs a set of health checks, each within its own
+// goroutine.
+type Monitor struct {
+	started uint32
+	stopped uint32
+
+	cfg *MonitorConfig
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewMonitor creates a new Monitor running the checks within the passed
+// config.
+func NewMonitor(cfg *MonitorConfig) *Monitor {
+	return &Monitor{
+		cfg:  cfg,
+		quit: make(chan struct{}),
+	}
+}
+
+// Start launches a goroutine for each check, periodically running it until
+// the Monitor is stopped.
+func (m *Monitor) Start() error {
+	if !atomic.CompareAndSwapUint32(&m.started, 0, 1) {
+		return nil
+	}
+
+	log.Infof("Health monitor starting with %v checks", len(m.cfg.Checks))
+
+	for _, check := range m.cfg.Checks {
+		m.wg.Add(1)
+		go func(o *Observation) {
+			defer m.wg.Done()
+			o.monitor(m.cfg.Shutdown, m.quit)
+		}(check)
+	}
+
+	return nil
+}
+
+// Stop signals all checks to exit, blocking until they have done so.
+func (m *Monitor) Stop() error {
+	if !atomic.CompareAndSwapUint32(&m.stopped, 0, 1) {
+		return nil
+	}
+
+	log.Infof("Health monitor shutting down")
+
+	close(m.quit)
+	m.wg.Wait()
+
+	return nil
+}
+
+// Observation is a single health check, along with the schedule on which it's
+// run and the number of times it's retried before it's considered failed.
+type Observation struct {
+	// Name is a human readable name of the check, used within logs.
+	Name string
+
+	// Check is the function run to determine whether the checked
+	// resource is healthy. A nil error indicates success.
+	Check func() error
+
+	// Interval is the time between consecutive runs of the check.
+	Interval time.Duration
+
+	// Attempts is the number of consecutive times the check may fail
+	// before the resource is considered unhealthy.
+	Attempts int
+
+	// Timeout is the time the check may run for before it's considered
+	// to have failed.
+	Timeout time.Duration
+
+	// Backoff is the time waited between failed attempts of the check.
+	Backoff time.Duration
+
+	// AlertOnly, if true, causes a sustained failure of the check to only
+	// be logged, rather than shutting down the daemon.
+	AlertOnly bool
+}
+
+// String returns the name of the check.
+func (o *Observation) String() string {
+	return o.Name
+}
+
+// monitor runs the check each interval until the quit channel is closed. Once
+// the check fails all of its attempts, the shutdown function is called and
+// the check exits, unless the check is AlertOnly.
+//
+// NOTE: This MUST be run as a goroutine.
+func (o *Observation) monitor(shutdown func(string, ...interface{}),
+	quit chan struct{}) {
+
+	ticker := time.NewTicker(o.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if o.retryCheck(quit) {
+				continue
+			}
+
+			if o.AlertOnly {
+				log.Errorf("Health check: %v failed after %v "+
+					"attempts", o, o.Attempts)
+				continue
+			}
+
+			shutdown("Health check: %v failed after %v attempts",
+				o, o.Attempts)
+			return
+
+		case <-quit:
+			return
+		}
+	}
+}
+
+// retryCheck runs the check up to the configured number of attempts, waiting
+// for the backoff between failed attempts. It returns true if the check
+// passed, or if the quit channel was closed before all attempts were made.
+func (o *Observation) retryCheck(quit chan struct{}) bool {
+	for attempt := 1; attempt <= o.Attempts; attempt++ {
+		err := o.runCheck(quit)
+		if err == nil {
+			return true
+		}
+
+		log.Debugf("Health check: %v, attempt %v of %v failed: %v",
+			o, attempt, o.Attempts, err)
+
+		if attempt == o.Attempts {
+			break
+		}
+
+		select {
+		case <-time.After(o.Backoff):
+		case <-quit:
+			return true
+		}
+	}
+
+	return false
+}
+
+// runCheck runs the check a single time, failing it if it doesn't complete
+// within the configured timeout.
+func (o *Observation) runCheck(quit chan struct{}) error {
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- o.Check()
+	}()
+
+	select {
+	case err := <-errChan:
+		return err
+
+	case <-time.After(o.Timeout):
+		return fmt.Errorf("check timed out after %v", o.Timeout)
+
+	case <-quit:
+		return nil
+	}
+}
@@ -0,0 +1,185 @@
+package healthcheck
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestMonitorShutdown asserts that the shutdown function is called once a
+// check has failed all of its attempts, and that a check which recovers
+// before exhausting its attempts doesn't trigger a shutdown.
+func TestMonitorShutdown(t *testing.T) {
+	var calls int32
+	flakyCheck := func() error {
+		// Fail every other attempt, so the check always passes on
+		// retry.
+		if atomic.AddInt32(&calls, 1)%2 == 1 {
+			return fmt.Errorf("flaky")
+		}
+		return nil
+	}
+
+	shutdownChan := make(chan string, 1)
+	m := NewMonitor(&MonitorConfig{
+		Checks: []*Observation{
+			{
+				Name:     "flaky",
+				Check:    flakyCheck,
+				Interval: time.Millisecond,
+				Attempts: 2,
+				Timeout:  time.Second,
+			},
+			{
+				Name: "failing",
+				Check: func() error {
+					return fmt.Errorf("failed")
+				},
+				Interval: time.Millisecond,
+				Attempts: 3,
+				Timeout:  time.Second,
+				Backoff:  time.Millisecond,
+			},
+		},
+		Shutdown: func(format string, params ...interface{}) {
+			shutdownChan <- fmt.Sprintf(format, params...)
+		},
+	})
+	if err := m.Start(); err != nil {
+		t.Fatalf("unable to start monitor: %v", err)
+	}
+	defer m.Stop()
+
+	select {
+	case reason := <-shutdownChan:
+		expected := "Health check: failing failed after 3 attempts"
+		if reason != expected {
+			t.Fatalf("expected shutdown reason %q, got %q",
+				expected, reason)
+		}
+
+	case <-time.After(5 * time.Second):
+		t.Fatalf("shutdown not requested")
+	}
+}
+
+// TestRetryCheck asserts that a check is only considered failed once it has
+// failed all of its attempts, and that timed out attempts are failures.
+func TestRetryCheck(t *testing.T) {
+	quit := make(chan struct{})
+
+	var calls int32
+	o := &Observation{
+		Name: "test",
+		Check: func() error {
+			if atomic.AddInt32(&calls, 1) < 3 {
+				return fmt.Errorf("failed")
+			}
+			return nil
+		},
+		Attempts: 3,
+		Timeout:  time.Second,
+	}
+	if !o.retryCheck(quit) {
+		t.Fatalf("check should pass on its final attempt")
+	}
+
+	atomic.StoreInt32(&calls, 0)
+	o.Attempts = 2
+	if o.retryCheck(quit) {
+		t.Fatalf("check should fail after exhausting its attempts")
+	}
+	if atomic.LoadInt32(&calls) != 2 {
+		t.Fatalf("expected 2 attempts, got %v", calls)
+	}
+
+	block := make(chan struct{})
+	defer close(block)
+	o.Check = func() error {
+		<-block
+		return nil
+	}
+	o.Timeout = time.Millisecond
+	if o.retryCheck(quit) {
+		t.Fatalf("timed out check should fail")
+	}
+}
+
+// TestConfigValidate asserts that the default config is valid, that disabled
+// checks aren't validated, and that invalid options are rejected.
+func TestConfigValidate(t *testing.T) {
+	cfg := DefaultConfig()
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("default config should be valid: %v", err)
+	}
+
+	if o := cfg.ChainCheck.NewObservation("chain", nil); o == nil {
+		t.Fatalf("enabled check should create an observation")
+	}
+
+	cfg.TLSCheck.Timeout = 0
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("zero timeout should be rejected")
+	}
+
+	cfg.TLSCheck.Attempts = 0
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("disabled check shouldn't be validated: %v", err)
+	}
+	if o := cfg.TLSCheck.NewObservation("tls", nil); o != nil {
+		t.Fatalf("disabled check shouldn't create an observation")
+	}
+
+	cfg.DiskCheck.RequiredRemaining = 1
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("required disk space ratio of 1 should be rejected")
+	}
+}
+
+// TestCheckCertExpiry asserts that certificates are only accepted within
+// their validity period.
+func TestCheckCertExpiry(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+
+	notBefore := time.Unix(1500000000, 0)
+	notAfter := notBefore.Add(24 * time.Hour)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{Organization: []string{"lnd"}},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	derBytes, err := x509.CreateCertificate(rand.Reader, template,
+		template, &priv.PublicKey, priv)
+	if err != nil {
+		t.Fatalf("unable to create certificate: %v", err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: derBytes,
+	})
+
+	if err := CheckCertExpiry(certPEM, notBefore.Add(time.Hour)); err != nil {
+		t.Fatalf("certificate should be valid: %v", err)
+	}
+	if err := CheckCertExpiry(certPEM, notAfter.Add(time.Hour)); err == nil {
+		t.Fatalf("expired certificate should be rejected")
+	}
+	if err := CheckCertExpiry(certPEM, notBefore.Add(-time.Hour)); err == nil {
+		t.Fatalf("not yet valid certificate should be rejected")
+	}
+	if err := CheckCertExpiry([]byte("garbage"), notBefore); err == nil {
+		t.Fatalf("invalid certificate should be rejected")
+	}
+}
@@ -0,0 +1,33 @@
+package healthcheck
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+)
+
+// CheckCertExpiry returns an error if the passed PEM encoded certificate
+// isn't valid at the passed time.
+func CheckCertExpiry(certPEM []byte, now time.Time) error {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return fmt.Errorf("unable to decode PEM certificate")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case now.Before(cert.NotBefore):
+		return fmt.Errorf("certificate not valid until %v",
+			cert.NotBefore)
+
+	case now.After(cert.NotAfter):
+		return fmt.Errorf("certificate expired at %v", cert.NotAfter)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+// +build dev
+
+package hodl
+
+// Config houses the command line flags for each of the hodl points. These
+// flags are only available within builds using the dev build tag.
+type Config struct {
+	ExitSettle bool `long:"exit-settle" description:"Instructs the node to refrain from settling HTLC's for which it is the exit hop"`
+
+	AddIncoming bool `long:"add-incoming" description:"Instructs the node to drop locked-in HTLC adds received from its peers"`
+
+	SettleIncoming bool `long:"settle-incoming" description:"Instructs the node to drop locked-in HTLC settles received from its peers"`
+
+	FailIncoming bool `long:"fail-incoming" description:"Instructs the node to drop locked-in HTLC cancellations received from its peers"`
+
+	AddOutgoing bool `long:"add-outgoing" description:"Instructs the node to drop HTLC adds before extending them to its peers"`
+
+	SettleOutgoing bool `long:"settle-outgoing" description:"Instructs the node to drop HTLC settles before sending them to its peers"`
+
+	FailOutgoing bool `long:"fail-outgoing" description:"Instructs the node to drop HTLC cancellations before sending them to its peers"`
+
+	Commit bool `long:"commit" description:"Instructs the node to refrain from signing new commitment states"`
+}
+
+// Mask returns the Mask containing each of the flags set within the Config.
+func (c *Config) Mask() Mask {
+	var flags []Flag
+	if c.ExitSettle {
+		flags = append(flags, ExitSettle)
+	}
+	if c.AddIncoming {
+		flags = append(flags, AddIncoming)
+	}
+	if c.SettleIncoming {
+		flags = append(flags, SettleIncoming)
+	}
+	if c.FailIncoming {
+		flags = append(flags, FailIncoming)
+	}
+	if c.AddOutgoing {
+		flags = append(flags, AddOutgoing)
+	}
+	if c.SettleOutgoing {
+		flags = append(flags, SettleOutgoing)
+	}
+	if c.FailOutgoing {
+		flags = append(flags, FailOutgoing)
+	}
+	if c.Commit {
+		flags = append(flags, Commit)
+	}
+
+	return MaskFromFlags(flags...)
+}
@@ -0,0 +1,12 @@
+// +build !dev
+
+package hodl
+
+// Config is an empty struct in production builds, ensuring none of the hodl
+// flags can be set from the command line.
+type Config struct{}
+
+// Mask always returns MaskNone in production builds.
+func (c *Config) Mask() Mask {
+	return MaskNone
+}
@@ -0,0 +1,109 @@
+package hodl
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Flag is a single bit within a Mask, signalling a point within the link at
+// which HTLC's should be intentionally held rather than processed.
+type Flag uint32
+
+const (
+	// ExitSettle causes the exit hop to refrain from settling HTLC's it
+	// has an invoice for, leaving them pending within the channel.
+	ExitSettle Flag = 1 << iota
+
+	// AddIncoming causes the link to drop locked-in HTLC adds received
+	// from the remote peer rather than forwarding them to the switch.
+	AddIncoming
+
+	// SettleIncoming causes the link to drop locked-in HTLC settles
+	// received from the remote peer rather than forwarding them to the
+	// switch.
+	SettleIncoming
+
+	// FailIncoming causes the link to drop locked-in HTLC cancellations
+	// received from the remote peer rather than forwarding them to the
+	// switch.
+	FailIncoming
+
+	// AddOutgoing causes the link to drop HTLC adds received from the
+	// switch before they're added to the channel.
+	AddOutgoing
+
+	// SettleOutgoing causes the link to drop HTLC settles received from
+	// the switch before they're applied to the channel.
+	SettleOutgoing
+
+	// FailOutgoing causes the link to drop HTLC cancellations received
+	// from the switch before they're applied to the channel.
+	FailOutgoing
+
+	// Commit causes the link to refrain from signing new commitment
+	// states, holding all pending updates before they're committed.
+	Commit
+)
+
+// String returns a human-readable version of the Flag.
+func (f Flag) String() string {
+	switch f {
+	case ExitSettle:
+		return "ExitSettle"
+	case AddIncoming:
+		return "AddIncoming"
+	case SettleIncoming:
+		return "SettleIncoming"
+	case FailIncoming:
+		return "FailIncoming"
+	case AddOutgoing:
+		return "AddOutgoing"
+	case SettleOutgoing:
+		return "SettleOutgoing"
+	case FailOutgoing:
+		return "FailOutgoing"
+	case Commit:
+		return "Commit"
+	default:
+		return fmt.Sprintf("UnknownHodlFlag(%d)", uint32(f))
+	}
+}
+
+// Warning returns a warning to be logged each time the flag causes the link
+// to hold an update.
+func (f Flag) Warning() string {
+	return fmt.Sprintf("hodl=%v: holding update", f)
+}
+
+// Mask is a bitvector of Flags, signalling the set of hodl points which are
+// currently active.
+type Mask uint32
+
+// MaskNone is the Mask with no hodl points active.
+const MaskNone Mask = 0
+
+// MaskFromFlags merges the passed flags into a single Mask.
+func MaskFromFlags(flags ...Flag) Mask {
+	var mask Mask
+	for _, flag := range flags {
+		mask |= Mask(flag)
+	}
+
+	return mask
+}
+
+// String returns a human-readable list of the flags set within the Mask.
+func (m Mask) String() string {
+	if m == MaskNone {
+		return "hodl.Mask(NONE)"
+	}
+
+	var active []string
+	for flag := ExitSettle; flag <= Commit; flag <<= 1 {
+		if uint32(m)&uint32(flag) != 0 {
+			active = append(active, flag.String())
+		}
+	}
+
+	return fmt.Sprintf("hodl.Mask(%s)", strings.Join(active, "|"))
+}
@@ -0,0 +1,32 @@
+// +build dev
+
+package hodl
+
+import "testing"
+
+// TestMask asserts that a Mask reports exactly the flags it was created from
+// as active.
+func TestMask(t *testing.T) {
+	mask := MaskFromFlags(ExitSettle, FailOutgoing, Commit)
+
+	for flag := ExitSettle; flag <= Commit; flag <<= 1 {
+		expected := flag == ExitSettle || flag == FailOutgoing ||
+			flag == Commit
+		if mask.Active(flag) != expected {
+			t.Fatalf("expected %v active=%v", flag, expected)
+		}
+	}
+
+	expectedStr := "hodl.Mask(ExitSettle|FailOutgoing|Commit)"
+	if mask.String() != expectedStr {
+		t.Fatalf("expected %v, got %v", expectedStr, mask.String())
+	}
+
+	cfg := &Config{SettleIncoming: true}
+	if cfg.Mask() != MaskFromFlags(SettleIncoming) {
+		t.Fatalf("config mask mismatch: %v", cfg.Mask())
+	}
+	if MaskNone.Active(SettleIncoming) {
+		t.Fatalf("empty mask shouldn't have active flags")
+	}
+}
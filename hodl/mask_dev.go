@@ -0,0 +1,8 @@
+// +build dev
+
+package hodl
+
+// Active returns true if the passed flag is set within the Mask.
+func (m Mask) Active(flag Flag) bool {
+	return uint32(m)&uint32(flag) != 0
+}
@@ -0,0 +1,9 @@
+// +build !dev
+
+package hodl
+
+// Active always returns false in production builds, ensuring that no hodl
+// points can ever be triggered.
+func (m Mask) Active(flag Flag) bool {
+	return false
+}
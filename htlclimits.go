@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// htlcUsage is the number and total value of a set of in-flight HTLC's.
+type htlcUsage struct {
+	numHtlcs int
+	value    btcutil.Amount
+}
+
+// exceeds returns true if adding an HTLC of amt to the usage would exceed
+// either of the passed limits, each of which is disabled if zero.
+func (u *htlcUsage) exceeds(amt btcutil.Amount, maxHtlcs int,
+	maxValue int64) bool {
+
+	if maxHtlcs != 0 && u.numHtlcs+1 > maxHtlcs {
+		return true
+	}
+
+	return maxValue != 0 && u.value+amt > btcutil.Amount(maxValue)
+}
+
+// htlcLimiter acts as a circuit breaker within the switch, tracking the
+// forwarded HTLC's in flight both in total, and by the peer and channel they
+// arrived from. Forwards which would exceed any of the configured limits are
+// refused, so that a single peer can't jam all our channels by holding on to
+// the HTLC's it sends us.
+//
+// NOTE: The htlcLimiter isn't safe for concurrent use, it's solely accessed
+// by the switch's htlcForwarder goroutine.
+type htlcLimiter struct {
+	cfg htlcLimitsConfig
+
+	total htlcUsage
+	peers map[string]*htlcUsage
+	chans map[wire.OutPoint]*htlcUsage
+}
+
+// newHtlcLimiter creates a new htlcLimiter enforcing the passed limits. If
+// the passed config is nil, no limits are enforced.
+func newHtlcLimiter(cfg *htlcLimitsConfig) *htlcLimiter {
+	l := &htlcLimiter{
+		peers: make(map[string]*htlcUsage),
+		chans: make(map[wire.OutPoint]*htlcUsage),
+	}
+	if cfg != nil {
+		l.cfg = *cfg
+	}
+
+	return l
+}
+
+// addHtlc accounts for a newly forwarded HTLC of amt which arrived over the
+// passed channel with the peer with the passed serialized public key. If
+// forwarding the HTLC would exceed any of the limits, then it isn't
+// accounted for and an error describing the exceeded limit is returned.
+func (l *htlcLimiter) addHtlc(chanPoint wire.OutPoint, peerKey string,
+	amt btcutil.Amount) error {
+
+	peerUsage, ok := l.peers[peerKey]
+	if !ok {
+		peerUsage = &htlcUsage{}
+	}
+	chanUsage, ok := l.chans[chanPoint]
+	if !ok {
+		chanUsage = &htlcUsage{}
+	}
+
+	switch {
+	case l.total.exceeds(amt, l.cfg.MaxHtlcs, l.cfg.MaxValue):
+		return fmt.Errorf("global HTLC limit reached: %v HTLC's "+
+			"worth %v in flight", l.total.numHtlcs, l.total.value)
+
+	case peerUsage.exceeds(amt, l.cfg.MaxPeerHtlcs, l.cfg.MaxPeerValue):
+		return fmt.Errorf("peer HTLC limit reached: %v HTLC's worth "+
+			"%v in flight", peerUsage.numHtlcs, peerUsage.value)
+
+	case chanUsage.exceeds(amt, l.cfg.MaxChanHtlcs, l.cfg.MaxChanValue):
+		return fmt.Errorf("channel HTLC limit reached: %v HTLC's "+
+			"worth %v in flight", chanUsage.numHtlcs,
+			chanUsage.value)
+	}
+
+	for _, usage := range []*htlcUsage{&l.total, peerUsage, chanUsage} {
+		usage.numHtlcs++
+		usage.value += amt
+	}
+	l.peers[peerKey] = peerUsage
+	l.chans[chanPoint] = chanUsage
+
+	return nil
+}
+
+// removeHtlc releases an HTLC previously accounted for by addHtlc, once it's
+// been settled or cancelled.
+func (l *htlcLimiter) removeHtlc(chanPoint wire.OutPoint, peerKey string,
+	amt btcutil.Amount) {
+
+	l.total.numHtlcs--
+	l.total.value -= amt
+
+	if usage, ok := l.peers[peerKey]; ok {
+		usage.numHtlcs--
+		usage.value -= amt
+		if usage.numHtlcs <= 0 {
+			delete(l.peers, peerKey)
+		}
+	}
+
+	if usage, ok := l.chans[chanPoint]; ok {
+		usage.numHtlcs--
+		usage.value -= amt
+		if usage.numHtlcs <= 0 {
+			delete(l.chans, chanPoint)
+		}
+	}
+}
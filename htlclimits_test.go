@@ -0,0 +1,61 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+// TestHtlcLimiter asserts that forwards are refused once any of the global,
+// per-peer, or per-channel limits would be exceeded, and that released HTLC's
+// free up their share of the limits.
+func TestHtlcLimiter(t *testing.T) {
+	limiter := newHtlcLimiter(&htlcLimitsConfig{
+		MaxHtlcs:     3,
+		MaxPeerValue: 1000,
+		MaxChanHtlcs: 1,
+	})
+
+	chanA := wire.OutPoint{Index: 0}
+	chanB := wire.OutPoint{Index: 1}
+	chanC := wire.OutPoint{Index: 2}
+	chanD := wire.OutPoint{Index: 3}
+
+	if err := limiter.addHtlc(chanA, "alice", 600); err != nil {
+		t.Fatalf("unable to add htlc: %v", err)
+	}
+
+	// A second HTLC over the same channel exceeds the channel limit.
+	if err := limiter.addHtlc(chanA, "alice", 100); err == nil {
+		t.Fatalf("channel limit wasn't enforced")
+	}
+
+	// An HTLC over another channel with the same peer exceeds the value
+	// allowed in flight with the peer.
+	if err := limiter.addHtlc(chanB, "alice", 500); err == nil {
+		t.Fatalf("peer limit wasn't enforced")
+	}
+	if err := limiter.addHtlc(chanB, "alice", 400); err != nil {
+		t.Fatalf("unable to add htlc: %v", err)
+	}
+
+	// Other peers aren't constrained by the usage of Alice, but only by
+	// their own limits and the global one.
+	if err := limiter.addHtlc(chanC, "bob", 500); err != nil {
+		t.Fatalf("unable to add htlc: %v", err)
+	}
+	if err := limiter.addHtlc(chanD, "carol", 1); err == nil {
+		t.Fatalf("global limit wasn't enforced")
+	}
+
+	// Once Alice's first HTLC is released, both her channel and Carol's
+	// HTLC can be forwarded again.
+	limiter.removeHtlc(chanA, "alice", 600)
+	if err := limiter.addHtlc(chanD, "carol", 1); err != nil {
+		t.Fatalf("unable to add htlc: %v", err)
+	}
+	if len(limiter.chans) != 3 || len(limiter.peers) != 3 {
+		t.Fatalf("expected 3 channels and peers to be tracked, got %v "+
+			"and %v", len(limiter.chans), len(limiter.peers))
+	}
+}
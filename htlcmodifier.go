@@ -0,0 +1,227 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// defaultHtlcModifierTimeout is the time the htlcModifier waits for its client
+// to respond to an HTLC, after which the HTLC is processed unmodified.
+const defaultHtlcModifierTimeout = 10 * time.Second
+
+// ErrHtlcModifierExists is returned when attempting to register a client with
+// the htlcModifier while another one is already registered.
+var ErrHtlcModifierExists = errors.New("an htlc modifier is already " +
+	"registered")
+
+// exitHtlc describes an HTLC arriving for one of our invoices.
+type exitHtlc struct {
+	paymentHash [32]byte
+	invoiceAmt  btcutil.Amount
+	htlcAmt     btcutil.Amount
+	expiry      uint32
+	chanPoint   wire.OutPoint
+	htlcIndex   uint32
+}
+
+// htlcModification is the decision of the client of the htlcModifier on an
+// HTLC arriving for one of our invoices.
+type htlcModification struct {
+	// amtPaid, if non-zero, is the amount the HTLC is considered to pay
+	// towards the invoice, instead of the amount it carries.
+	amtPaid btcutil.Amount
+
+	// cancel indicates that the HTLC should be canceled.
+	cancel bool
+}
+
+// htlcModifierStream is the stream over which the htlcModifier exchanges
+// HTLCs and modifications with its client.
+type htlcModifierStream interface {
+	Send(*lnrpc.HtlcModifyRequest) error
+	Recv() (*lnrpc.HtlcModifyResponse, error)
+}
+
+// htlcModifierClient is the client currently registered with the
+// htlcModifier.
+type htlcModifierClient struct {
+	// requests is the queue of HTLCs to be sent to the client.
+	requests chan *lnrpc.HtlcModifyRequest
+
+	// pending maps the ID of each request sent to the client to the
+	// channel its response is delivered over. It's guarded by the mtx of
+	// the htlcModifier.
+	pending map[uint64]chan *htlcModification
+
+	quit chan struct{}
+}
+
+// htlcModifier allows an external process to inspect the HTLCs arriving for
+// our invoices, overriding the amount they pay towards the invoice or
+// canceling them. This allows, for example, a service provider to deduct a
+// fee from the payments it forwards to us. Unlike the switch, which forwards
+// HTLCs, the modifier is only consulted for HTLCs we're the final destination
+// of. If no client is registered, HTLCs are processed unmodified.
+type htlcModifier struct {
+	mtx    sync.Mutex
+	client *htlcModifierClient
+	nextID uint64
+
+	timeout time.Duration
+}
+
+// newHtlcModifier creates a new htlcModifier, waiting up to the passed timeout
+// for its client to respond to each HTLC.
+func newHtlcModifier(timeout time.Duration) *htlcModifier {
+	return &htlcModifier{
+		timeout: timeout,
+	}
+}
+
+// modify sends the passed HTLC to the registered client, returning its
+// decision. False is returned if no client is registered, or if the client
+// failed to respond in time, in which case the HTLC should be processed
+// unmodified.
+//
+// NOTE: This blocks until the client responds or the timeout expires, so the
+// link the HTLC arrived over calls it from a goroutine of its own.
+func (m *htlcModifier) modify(htlc *exitHtlc) (*htlcModification, bool) {
+	m.mtx.Lock()
+	client := m.client
+	if client == nil {
+		m.mtx.Unlock()
+		return nil, false
+	}
+
+	id := m.nextID
+	m.nextID++
+
+	resp := make(chan *htlcModification, 1)
+	client.pending[id] = resp
+	m.mtx.Unlock()
+
+	defer func() {
+		m.mtx.Lock()
+		delete(client.pending, id)
+		m.mtx.Unlock()
+	}()
+
+	req := &lnrpc.HtlcModifyRequest{
+		RequestId:   id,
+		PaymentHash: htlc.paymentHash[:],
+		InvoiceAmt:  int64(htlc.invoiceAmt),
+		HtlcAmt:     int64(htlc.htlcAmt),
+		Expiry:      htlc.expiry,
+		ChanPoint:   htlc.chanPoint.String(),
+		HtlcIndex:   uint64(htlc.htlcIndex),
+	}
+
+	timeout := time.After(m.timeout)
+	select {
+	case client.requests <- req:
+	case <-client.quit:
+		return nil, false
+	case <-timeout:
+		invcLog.Warnf("Htlc modifier failed to receive HTLC %v of "+
+			"ChannelPoint(%v) in time", htlc.htlcIndex,
+			htlc.chanPoint)
+		return nil, false
+	}
+
+	select {
+	case mod := <-resp:
+		return mod, true
+	case <-client.quit:
+		return nil, false
+	case <-timeout:
+		invcLog.Warnf("Htlc modifier failed to respond to HTLC %v of "+
+			"ChannelPoint(%v) in time", htlc.htlcIndex,
+			htlc.chanPoint)
+		return nil, false
+	}
+}
+
+// serve registers the client at the other end of the passed stream, sending it
+// each HTLC arriving for our invoices until the stream fails or the passed
+// quit channel is closed. Only a single client may be registered at a time.
+func (m *htlcModifier) serve(stream htlcModifierStream,
+	quit <-chan struct{}) error {
+
+	client := &htlcModifierClient{
+		requests: make(chan *lnrpc.HtlcModifyRequest),
+		pending:  make(map[uint64]chan *htlcModification),
+		quit:     make(chan struct{}),
+	}
+
+	m.mtx.Lock()
+	if m.client != nil {
+		m.mtx.Unlock()
+		return ErrHtlcModifierExists
+	}
+	m.client = client
+	m.mtx.Unlock()
+
+	invcLog.Infof("Htlc modifier registered")
+
+	defer func() {
+		m.mtx.Lock()
+		m.client = nil
+		m.mtx.Unlock()
+
+		close(client.quit)
+
+		invcLog.Infof("Htlc modifier unregistered")
+	}()
+
+	// The responses of the client are read within a goroutine of their
+	// own, so that requests may be sent while waiting for them.
+	errChan := make(chan error, 1)
+	go func() {
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				errChan <- err
+				return
+			}
+
+			m.mtx.Lock()
+			respChan, ok := client.pending[resp.RequestId]
+			m.mtx.Unlock()
+			if !ok {
+				invcLog.Warnf("Htlc modifier responded to "+
+					"unknown request %v", resp.RequestId)
+				continue
+			}
+
+			// Only the first response to each request is
+			// delivered.
+			select {
+			case respChan <- &htlcModification{
+				amtPaid: btcutil.Amount(resp.AmtPaid),
+				cancel:  resp.Cancel,
+			}:
+			default:
+			}
+		}
+	}()
+
+	for {
+		select {
+		case req := <-client.requests:
+			if err := stream.Send(req); err != nil {
+				return err
+			}
+
+		case err := <-errChan:
+			return err
+
+		case <-quit:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/roasbeef/btcutil"
+)
+
+// mockModifierStream is a mock htlcModifierStream, connecting the
+// htlcModifier to a client within the test.
+type mockModifierStream struct {
+	requests  chan *lnrpc.HtlcModifyRequest
+	responses chan *lnrpc.HtlcModifyResponse
+}
+
+func newMockModifierStream() *mockModifierStream {
+	return &mockModifierStream{
+		requests:  make(chan *lnrpc.HtlcModifyRequest),
+		responses: make(chan *lnrpc.HtlcModifyResponse),
+	}
+}
+
+func (m *mockModifierStream) Send(req *lnrpc.HtlcModifyRequest) error {
+	m.requests <- req
+	return nil
+}
+
+func (m *mockModifierStream) Recv() (*lnrpc.HtlcModifyResponse, error) {
+	resp, ok := <-m.responses
+	if !ok {
+		return nil, errors.New("stream closed")
+	}
+	return resp, nil
+}
+
+// TestHtlcModifier asserts that HTLCs are passed to the registered client,
+// whose decisions are returned, and that HTLCs are left unmodified if no
+// client is registered or the client fails to respond in time.
+func TestHtlcModifier(t *testing.T) {
+	t.Parallel()
+
+	modifier := newHtlcModifier(100 * time.Millisecond)
+	htlc := &exitHtlc{
+		invoiceAmt: 1000,
+		htlcAmt:    990,
+	}
+
+	// Without a client, HTLCs should be left unmodified.
+	if _, ok := modifier.modify(htlc); ok {
+		t.Fatalf("htlc modified without a client")
+	}
+
+	stream := newMockModifierStream()
+	quit := make(chan struct{})
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- modifier.serve(stream, quit)
+	}()
+
+	// Modify the HTLC in the background, responding as the client.
+	modify := func() chan *htlcModification {
+		result := make(chan *htlcModification, 1)
+		go func() {
+			mod, _ := modifier.modify(htlc)
+			result <- mod
+		}()
+		return result
+	}
+
+	// Wait for the client to be registered, after which the HTLC should
+	// be received by the client.
+	for i := 0; ; i++ {
+		modifier.mtx.Lock()
+		registered := modifier.client != nil
+		modifier.mtx.Unlock()
+		if registered {
+			break
+		}
+
+		if i == 100 {
+			t.Fatalf("client wasn't registered")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	result := modify()
+	req := <-stream.requests
+	if req.InvoiceAmt != 1000 || req.HtlcAmt != 990 {
+		t.Fatalf("unexpected request: %v", req)
+	}
+
+	// A second client should be refused while the first is registered.
+	err := modifier.serve(newMockModifierStream(), quit)
+	if err != ErrHtlcModifierExists {
+		t.Fatalf("expected ErrHtlcModifierExists, got %v", err)
+	}
+
+	stream.responses <- &lnrpc.HtlcModifyResponse{
+		RequestId: req.RequestId,
+		AmtPaid:   1000,
+	}
+	select {
+	case mod := <-result:
+		if mod == nil || mod.amtPaid != btcutil.Amount(1000) ||
+			mod.cancel {
+
+			t.Fatalf("unexpected modification: %v", mod)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("modification not returned")
+	}
+
+	// An HTLC which isn't responded to in time should be left
+	// unmodified.
+	result = modify()
+	<-stream.requests
+	select {
+	case mod := <-result:
+		if mod != nil {
+			t.Fatalf("expected no modification, got %v", mod)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("modification didn't time out")
+	}
+
+	// Once the stream fails, the client should be unregistered.
+	close(stream.responses)
+	select {
+	case <-serveErr:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("client wasn't unregistered")
+	}
+	if _, ok := modifier.modify(htlc); ok {
+		t.Fatalf("htlc modified after client unregistered")
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// htlcEvent describes an HTLC traversing the htlcSwitch being forwarded,
+// failed, or settled.
+type htlcEvent struct {
+	eventType lnrpc.HtlcEventType
+
+	// incomingChan is the channel the HTLC arrived over, or nil if we
+	// initiated the payment.
+	incomingChan *wire.OutPoint
+
+	// outgoingChan is the channel the HTLC was, or would have been,
+	// forwarded over, or nil if no such channel could be found.
+	outgoingChan *wire.OutPoint
+
+	payHash [32]byte
+	amt     btcutil.Amount
+
+	// failure describes why the HTLC failed. It's empty for forward and
+	// settle events.
+	failure string
+
+	timestamp time.Time
+}
+
+// htlcNotifier dispatches events concerning the HTLC's forwarded, failed, and
+// settled by the htlcSwitch to any subscribed clients.
+type htlcNotifier struct {
+	notifier *eventNotifier
+}
+
+// newHtlcNotifier creates a new htlcNotifier with no subscribed clients.
+func newHtlcNotifier() *htlcNotifier {
+	return &htlcNotifier{
+		notifier: newEventNotifier(defaultEventQueueSize),
+	}
+}
+
+// notifyHtlcEvent timestamps the passed event, then hands it off to all
+// currently registered clients.
+func (h *htlcNotifier) notifyHtlcEvent(event *htlcEvent) {
+	event.timestamp = time.Now()
+
+	h.notifier.notify(event)
+}
+
+// SubscribeHtlcEvents returns an eventSubscription which allows the caller to
+// receive async notifications of any HTLC forwarded, failed, or settled by
+// the htlcSwitch. Each event sent over the subscription is an *htlcEvent.
+func (h *htlcNotifier) SubscribeHtlcEvents() *eventSubscription {
+	return h.notifier.subscribe()
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// TestHtlcNotifierOrdering asserts that HTLC events are timestamped and
+// delivered to subscribers in the order they occurred, and that cancelled
+// subscribers no longer receive events.
+func TestHtlcNotifierOrdering(t *testing.T) {
+	notifier := newHtlcNotifier()
+	client := notifier.SubscribeHtlcEvents()
+	defer client.Cancel()
+
+	incoming := &wire.OutPoint{Index: 1}
+	outgoing := &wire.OutPoint{Index: 2}
+	events := []*htlcEvent{
+		{
+			eventType:    lnrpc.HtlcEventType_FORWARD,
+			incomingChan: incoming,
+			outgoingChan: outgoing,
+			payHash:      [32]byte{1},
+			amt:          1000,
+		},
+		{
+			eventType:    lnrpc.HtlcEventType_SETTLE,
+			incomingChan: incoming,
+			outgoingChan: outgoing,
+			payHash:      [32]byte{1},
+			amt:          1000,
+		},
+		{
+			eventType:    lnrpc.HtlcEventType_LINK_FAIL,
+			incomingChan: incoming,
+			payHash:      [32]byte{2},
+			amt:          2000,
+			failure:      "UnknownDestination",
+		},
+	}
+	for _, event := range events {
+		notifier.notifyHtlcEvent(event)
+	}
+
+	for i, expected := range events {
+		select {
+		case e := <-client.Events:
+			event := e.(*htlcEvent)
+			if event != expected {
+				t.Fatalf("event #%v: expected %v, got %v", i,
+					expected.eventType, event.eventType)
+			}
+			if event.timestamp.IsZero() {
+				t.Fatalf("event #%v wasn't timestamped", i)
+			}
+		case <-time.After(time.Second * 5):
+			t.Fatalf("event #%v not received", i)
+		}
+	}
+
+	// Once cancelled, the client should be removed from the notifier.
+	otherClient := notifier.SubscribeHtlcEvents()
+	otherClient.Cancel()
+	notifier.notifyHtlcEvent(&htlcEvent{
+		eventType: lnrpc.HtlcEventType_FORWARD,
+	})
+	if len(notifier.notifier.clients) != 1 {
+		t.Fatalf("expected a single client, have %v",
+			len(notifier.notifier.clients))
+	}
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// peerReputation holds the outcome statistics of the HTLC's a peer has
+// forwarded to us, from which its local reputation is derived.
+type peerReputation struct {
+	// forwarded is the number of HTLC's of the peer we've forwarded.
+	forwarded uint64
+
+	// endorsed is the number of forwarded HTLC's which the peer endorsed.
+	endorsed uint64
+
+	// settled is the number of forwarded HTLC's which were settled.
+	settled uint64
+
+	// failed is the number of forwarded HTLC's which were cancelled or
+	// timed out.
+	failed uint64
+
+	// holdTime is the total time the resolved HTLC's were held in flight
+	// for.
+	holdTime time.Duration
+}
+
+// resolved returns the number of forwarded HTLC's which have been resolved.
+func (r *peerReputation) resolved() uint64 {
+	return r.settled + r.failed
+}
+
+// successRate returns the fraction of the resolved HTLC's which were
+// settled.
+func (r *peerReputation) successRate() float64 {
+	if r.resolved() == 0 {
+		return 0
+	}
+
+	return float64(r.settled) / float64(r.resolved())
+}
+
+// avgHoldTime returns the average time the resolved HTLC's were held in
+// flight for.
+func (r *peerReputation) avgHoldTime() time.Duration {
+	if r.resolved() == 0 {
+		return 0
+	}
+
+	return r.holdTime / time.Duration(r.resolved())
+}
+
+// reputationHtlc is a forwarded HTLC accounted for by the reputationTracker
+// which is still in flight.
+type reputationHtlc struct {
+	peerKey  string
+	outChan  wire.OutPoint
+	amt      btcutil.Amount
+	endorsed bool
+}
+
+// reputationTracker tracks the local reputation of each peer within the
+// switch, as a mitigation against channel jamming. The endorsement signal of
+// an HTLC is only relayed if the peer it arrived from has a good reputation,
+// meaning enough of its HTLC's have been resolved, most of them were settled,
+// and they weren't held for long. Unendorsed HTLC's may optionally be
+// restricted to a bounded number of slots and amount of liquidity of each
+// outgoing channel, so that the remainder stays available to endorsed ones.
+type reputationTracker struct {
+	cfg *reputationConfig
+
+	// mtx guards the peers map, which is read by the RPC server, while
+	// the general usage and the HTLC's in flight are solely accessed by
+	// the switch's htlcForwarder goroutine.
+	mtx   sync.RWMutex
+	peers map[string]*peerReputation
+
+	general map[wire.OutPoint]*htlcUsage
+
+	// inFlight maps each incoming HTLC accounted for to its details, so
+	// that each outcome is recorded against the HTLC it belongs to, even
+	// if several HTLC's share the same payment hash.
+	inFlight map[incomingHtlcKey]*reputationHtlc
+}
+
+// newReputationTracker creates a new reputationTracker using the passed
+// parameters. If the passed config is nil, then no peer is considered to
+// have a good reputation, and unendorsed HTLC's aren't restricted.
+func newReputationTracker(cfg *reputationConfig) *reputationTracker {
+	return &reputationTracker{
+		cfg:      cfg,
+		peers:    make(map[string]*peerReputation),
+		general:  make(map[wire.OutPoint]*htlcUsage),
+		inFlight: make(map[incomingHtlcKey]*reputationHtlc),
+	}
+}
+
+// goodReputation returns true if the peer with the passed serialized public
+// key has a good reputation.
+func (r *reputationTracker) goodReputation(peerKey string) bool {
+	if r.cfg == nil {
+		return false
+	}
+
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	rep, ok := r.peers[peerKey]
+	if !ok {
+		return false
+	}
+
+	return rep.resolved() >= uint64(r.cfg.MinResolved) &&
+		rep.successRate() >= r.cfg.MinSuccessRate &&
+		rep.avgHoldTime() <= r.cfg.MaxHoldTime
+}
+
+// endorse returns true if an HTLC arriving from the peer with the passed
+// serialized public key should be endorsed when forwarded, which is only the
+// case if the peer endorsed it and has a good reputation.
+func (r *reputationTracker) endorse(peerKey string, endorsed bool) bool {
+	return endorsed && r.goodReputation(peerKey)
+}
+
+// addHtlc accounts for the newly forwarded incoming HTLC identified by the
+// passed key, of amt, arriving from the peer with the passed serialized public
+// key and extended over the passed outgoing channel. If the HTLC isn't
+// endorsed and unendorsed HTLC's are restricted, then an error is returned if
+// it would exceed the general slots or liquidity of the channel, in which case
+// it isn't accounted for. An HTLC already in flight is refused as well.
+func (r *reputationTracker) addHtlc(key incomingHtlcKey, peerKey string,
+	outChan wire.OutPoint, amt btcutil.Amount,
+	incomingEndorsed, endorsed bool) error {
+
+	if _, ok := r.inFlight[key]; ok {
+		return fmt.Errorf("HTLC %v of channel %v already in flight",
+			key.index, key.chanPoint)
+	}
+
+	if !endorsed && r.cfg != nil && r.cfg.RestrictUnendorsed {
+		usage, ok := r.general[outChan]
+		if !ok {
+			usage = &htlcUsage{}
+		}
+		if usage.exceeds(amt, r.cfg.GeneralSlots, r.cfg.GeneralValue) {
+			return fmt.Errorf("general slots of channel %v "+
+				"exhausted: %v unendorsed HTLC's worth %v in "+
+				"flight", outChan, usage.numHtlcs, usage.value)
+		}
+
+		usage.numHtlcs++
+		usage.value += amt
+		r.general[outChan] = usage
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	rep, ok := r.peers[peerKey]
+	if !ok {
+		rep = &peerReputation{}
+		r.peers[peerKey] = rep
+	}
+	rep.forwarded++
+	if incomingEndorsed {
+		rep.endorsed++
+	}
+
+	r.inFlight[key] = &reputationHtlc{
+		peerKey:  peerKey,
+		outChan:  outChan,
+		amt:      amt,
+		endorsed: endorsed,
+	}
+
+	return nil
+}
+
+// resolveHtlc records the outcome of the incoming HTLC identified by the
+// passed key, previously accounted for by addHtlc, which was held in flight
+// for the passed duration, releasing it from the general slots of its
+// outgoing channel if it was unendorsed. HTLC's which aren't in flight are
+// ignored.
+func (r *reputationTracker) resolveHtlc(key incomingHtlcKey, settled bool,
+	held time.Duration) {
+
+	htlc, ok := r.inFlight[key]
+	if !ok {
+		return
+	}
+	delete(r.inFlight, key)
+
+	usage, ok := r.general[htlc.outChan]
+	if ok && !htlc.endorsed {
+		usage.numHtlcs--
+		usage.value -= htlc.amt
+		if usage.numHtlcs <= 0 {
+			delete(r.general, htlc.outChan)
+		}
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	rep, ok := r.peers[htlc.peerKey]
+	if !ok {
+		return
+	}
+	if settled {
+		rep.settled++
+	} else {
+		rep.failed++
+	}
+	rep.holdTime += held
+}
+
+// reputation returns a copy of the outcome statistics of the HTLC's of the
+// peer with the passed serialized public key, if it has forwarded any.
+func (r *reputationTracker) reputation(peerKey string) (peerReputation, bool) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	rep, ok := r.peers[peerKey]
+	if !ok {
+		return peerReputation{}, false
+	}
+
+	return *rep, true
+}
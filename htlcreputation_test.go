@@ -0,0 +1,107 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+// TestReputationTracker asserts that endorsements are only relayed for peers
+// with a good reputation, that unendorsed HTLC's are restricted to the
+// general slots of their outgoing channel, and that outcomes are recorded
+// against the incoming HTLC they belong to.
+func TestReputationTracker(t *testing.T) {
+	tracker := newReputationTracker(&reputationConfig{
+		MinResolved:        2,
+		MinSuccessRate:     0.5,
+		MaxHoldTime:        time.Minute,
+		RestrictUnendorsed: true,
+		GeneralSlots:       1,
+	})
+
+	aliceChan := wire.OutPoint{Index: 0}
+	bobChan := wire.OutPoint{Index: 2}
+	outChan := wire.OutPoint{Index: 1}
+	htlcKey := func(chanPoint wire.OutPoint, index uint32) incomingHtlcKey {
+		return incomingHtlcKey{chanPoint: chanPoint, index: index}
+	}
+
+	// Alice has no reputation yet, so her endorsements aren't relayed.
+	if tracker.endorse("alice", true) {
+		t.Fatalf("endorsement of peer without reputation was relayed")
+	}
+
+	// As her first HTLC isn't endorsed, it occupies the only general slot
+	// of the channel, so any further unendorsed HTLC is refused.
+	err := tracker.addHtlc(htlcKey(aliceChan, 0), "alice", outChan, 1000,
+		true, false)
+	if err != nil {
+		t.Fatalf("unable to add htlc: %v", err)
+	}
+	err = tracker.addHtlc(htlcKey(bobChan, 0), "bob", outChan, 1000,
+		false, false)
+	if err == nil {
+		t.Fatalf("general slots weren't restricted")
+	}
+
+	// The same HTLC can't be accounted for twice.
+	err = tracker.addHtlc(htlcKey(aliceChan, 0), "alice", outChan, 1000,
+		true, true)
+	if err == nil {
+		t.Fatalf("HTLC in flight was accounted for twice")
+	}
+
+	// Resolving an HTLC which isn't in flight doesn't affect her
+	// reputation.
+	tracker.resolveHtlc(htlcKey(aliceChan, 5), false, time.Second)
+	if rep, _ := tracker.reputation("alice"); rep.failed != 0 {
+		t.Fatalf("unknown HTLC was recorded as failed")
+	}
+
+	// Once her HTLC is settled swiftly, along with a second one, she has
+	// gained a good reputation.
+	tracker.resolveHtlc(htlcKey(aliceChan, 0), true, time.Second)
+	err = tracker.addHtlc(htlcKey(aliceChan, 1), "alice", outChan, 1000,
+		true, false)
+	if err != nil {
+		t.Fatalf("unable to add htlc: %v", err)
+	}
+	tracker.resolveHtlc(htlcKey(aliceChan, 1), true, time.Second)
+	if !tracker.endorse("alice", true) {
+		t.Fatalf("endorsement of peer with good reputation wasn't " +
+			"relayed")
+	}
+	if tracker.endorse("alice", false) {
+		t.Fatalf("unendorsed HTLC was endorsed")
+	}
+
+	// Endorsed HTLC's aren't restricted to the general slots.
+	err = tracker.addHtlc(htlcKey(bobChan, 0), "bob", outChan, 1000,
+		false, false)
+	if err != nil {
+		t.Fatalf("unable to add htlc: %v", err)
+	}
+	err = tracker.addHtlc(htlcKey(aliceChan, 2), "alice", outChan, 1000,
+		true, true)
+	if err != nil {
+		t.Fatalf("unable to add htlc: %v", err)
+	}
+
+	// Should her HTLC's start being held for long, then she loses her
+	// good reputation.
+	tracker.resolveHtlc(htlcKey(aliceChan, 2), true, time.Hour)
+	if tracker.endorse("alice", true) {
+		t.Fatalf("endorsement of peer with bad reputation was relayed")
+	}
+
+	rep, ok := tracker.reputation("alice")
+	if !ok {
+		t.Fatalf("reputation of alice not found")
+	}
+	if rep.forwarded != 3 || rep.endorsed != 3 || rep.settled != 3 ||
+		rep.failed != 0 {
+
+		t.Fatalf("unexpected reputation: %+v", rep)
+	}
+}
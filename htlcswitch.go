@@ -10,9 +10,12 @@ import (
 	"github.com/btcsuite/fastsha256"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lightning-onion"
+	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/monitoring"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcd/wire"
@@ -26,6 +29,13 @@ const (
 	htlcQueueSize = 50
 )
 
+var (
+	// errInsufficientBandwidth is returned when sending a payment if none
+	// of the links to the first hop of its route has the bandwidth to
+	// carry it.
+	errInsufficientBandwidth = fmt.Errorf("Insufficient capacity")
+)
+
 // link represents a an active channel capable of forwarding HTLC's. Each
 // active channel registered with the htlc switch creates a new link which will
 // be used for forwarding outgoing HTLC's. The link also has additional
@@ -60,16 +70,34 @@ type htlcPacket struct {
 	payHash [32]byte
 	amt     btcutil.Amount
 
+	// selfPayment indicates that the packet pays one of our own invoices,
+	// in which case it's settled directly against the invoice rather than
+	// being sent over a link.
+	selfPayment bool
+
+	// htlcIndex is the index of an HTLC forwarded to the switch within
+	// the log of the remote party of the srcLink.
+	htlcIndex uint32
+
 	err chan error
 }
 
-// circuitKey uniquely identifies an active Sphinx (onion routing) circuit
-// between two open channels. Currently, the rHash of the HTLC which created
-// the circuit is used to uniquely identify each circuit.
+// circuitKey identifies the active Sphinx (onion routing) circuits between
+// two open channels created by HTLC's sharing the same rHash. Each circuit is
+// then told apart by the channel its outgoing HTLC was extended over.
 // TODO(roasbeef): need to also add in the settle/clear channel points in order
 // to support fragmenting payments on the link layer: 1 to N, N to N, etc.
 type circuitKey [32]byte
 
+// incomingHtlcKey uniquely identifies an HTLC extended to us, by the channel
+// it arrived over and its index within the log of the remote party. The HTLC's
+// in flight are accounted for by this key, as several HTLC's may share the
+// same payment hash.
+type incomingHtlcKey struct {
+	chanPoint wire.OutPoint
+	index     uint32
+}
+
 // paymentCircuit represents an active Sphinx (onion routing) circuit between
 // two active links within the htlcSwitch. A payment circuit is created once a
 // link forwards an HTLC add request which initiates the creation of the
@@ -97,6 +125,72 @@ type paymentCircuit struct {
 	// complete unless the reference count on the circuit is greater than
 	// 1.
 	settle *link
+
+	// incomingExpiry is the absolute timelock of the HTLC extended to us
+	// over the settle link.
+	incomingExpiry uint32
+
+	// outgoingExpiry is the absolute timelock of the HTLC we extended
+	// over the clear link. It's always at least timeLockDelta blocks
+	// below incomingExpiry.
+	outgoingExpiry uint32
+
+	// incoming identifies the HTLC extended to us over the settle link.
+	incoming incomingHtlcKey
+
+	// amt is the amount of the HTLC we extended over the clear link.
+	amt btcutil.Amount
+
+	// endorsed denotes if we endorsed the HTLC we extended over the clear
+	// link.
+	endorsed bool
+
+	// addedAt is the time at which the circuit was created, used to track
+	// how long the HTLC is held in flight for.
+	addedAt time.Time
+
+	// onChain denotes that the HTLC we extended over the clear link
+	// expired without being resolved by the downstream peer, so its
+	// channel was force closed. The incoming HTLC is only resolved once
+	// the outgoing HTLC has been either claimed or timed out on-chain.
+	onChain bool
+}
+
+// onChainResolution is the outcome of an outgoing HTLC which was resolved
+// on-chain after the channel it was extended over was force closed.
+type onChainResolution struct {
+	payHash circuitKey
+
+	// chanPoint is the force closed channel the HTLC was extended over.
+	chanPoint wire.OutPoint
+
+	// preimage is set if the remote party claimed the HTLC on-chain,
+	// revealing the preimage of its payment hash. Otherwise, the HTLC
+	// timed out back to us.
+	preimage *[32]byte
+}
+
+// expiryGraceDelta is the minimum number of blocks beyond the current height
+// an HTLC's expiry must lie for it to be forwarded or accepted. This grace
+// period gives us time to react to an HTLC timing out before the remote party
+// is able to claim it on-chain.
+const expiryGraceDelta = 3
+
+// outgoingExpiry computes the expiry of the HTLC we'll extend over the
+// outgoing link given the expiry of the incoming HTLC, the current height,
+// and our required timelock delta. If the resulting HTLC would expire too
+// soon, then false is returned and the HTLC should be cancelled back.
+func outgoingExpiry(incoming, height, timeLockDelta uint32) (uint32, bool) {
+	if incoming < timeLockDelta {
+		return 0, false
+	}
+
+	outgoing := incoming - timeLockDelta
+	if outgoing <= height+expiryGraceDelta {
+		return 0, false
+	}
+
+	return outgoing, true
 }
 
 // htlcSwitch is a central messaging bus for all incoming/outgoing HTLC's.
@@ -132,10 +226,17 @@ type htlcSwitch struct {
 	onionMtx   sync.RWMutex
 	onionIndex map[[ripemd160.Size]byte][]*link
 
-	// paymentCircuits maps a circuit key to an active payment circuit
-	// amongst two oepn channels. This map is used to properly clear/settle
-	// onion routed payments within the network.
-	paymentCircuits map[circuitKey]*paymentCircuit
+	// paymentCircuits maps a circuit key to the active payment circuits
+	// amongst two oepn channels sharing it. This map is used to properly
+	// clear/settle onion routed payments within the network. A circuit is
+	// only removed once its HTLC has been resolved, even if other HTLC's
+	// with the same payment hash are forwarded in the meantime.
+	paymentCircuits map[circuitKey][]*paymentCircuit
+
+	// forwardedHtlcs indexes the active payment circuits by the incoming
+	// HTLC which created them, so that an HTLC forwarded twice is neither
+	// extended nor accounted for twice.
+	forwardedHtlcs map[incomingHtlcKey]*paymentCircuit
 
 	// linkControl is a channel used by connected links to notify the
 	// switch of a non-multi-hop triggered link state update.
@@ -152,23 +253,82 @@ type htlcSwitch struct {
 	// fully locked in.
 	htlcPlex chan *htlcPacket
 
+	// notifier is used to receive new block notifications in order to
+	// detect outgoing HTLC's which have timed out, and to watch for their
+	// on-chain resolution.
+	notifier chainntnfs.ChainNotifier
+
+	// blockEpochs is the registration for new block notifications,
+	// cancelled once the switch is stopped.
+	blockEpochs *chainntnfs.BlockEpochEvent
+
+	// onChainResolutions is the channel over which the outgoing HTLC's
+	// resolved on-chain are handed to the htlcForwarder, which then
+	// resolves their incoming HTLC's.
+	onChainResolutions chan *onChainResolution
+
+	// bio is used to query for the current best height when the switch
+	// is started.
+	bio lnwallet.BlockChainIO
+
+	// invoices is used to settle payments to our own invoices.
+	invoices *invoiceRegistry
+
+	// timeLockDelta is the number of blocks we require between the
+	// expiry of an incoming HTLC and the expiry of the HTLC we forward
+	// in response.
+	timeLockDelta uint32
+
+	// htlcNotifier is notified of each HTLC forwarded, failed, or settled
+	// by the switch.
+	htlcNotifier *htlcNotifier
+
+	// htlcLimiter refuses forwards which would exceed the limits on the
+	// number and value of the HTLC's in flight.
+	htlcLimiter *htlcLimiter
+
+	// reputation tracks the local reputation of each peer, deciding which
+	// endorsements are relayed, and restricting unendorsed HTLC's.
+	reputation *reputationTracker
+
 	// TODO(roasbeef): sampler to log sat/sec and tx/sec
 
 	wg   sync.WaitGroup
 	quit chan struct{}
 }
 
-// newHtlcSwitch creates a new htlcSwitch.
-func newHtlcSwitch() *htlcSwitch {
+// newHtlcSwitch creates a new htlcSwitch. The passed timeLockDelta is the
+// CLTV delta enforced between the incoming and outgoing HTLC's of each
+// forwarded payment, and the passed invoice registry is used to settle
+// payments to our own invoices. The passed htlcNotifier is notified of each
+// HTLC traversing the switch, while forwards exceeding the passed limits are
+// refused. If the limits are nil, none are enforced. Likewise, the local
+// reputation of peers is tracked using the passed parameters, if any.
+func newHtlcSwitch(notifier chainntnfs.ChainNotifier,
+	bio lnwallet.BlockChainIO, invoices *invoiceRegistry,
+	timeLockDelta uint32, htlcNotifier *htlcNotifier,
+	htlcLimits *htlcLimitsConfig,
+	reputation *reputationConfig) *htlcSwitch {
+
 	return &htlcSwitch{
+		notifier:         notifier,
+		bio:              bio,
+		invoices:         invoices,
+		timeLockDelta:    timeLockDelta,
+		htlcNotifier:     htlcNotifier,
+		htlcLimiter:      newHtlcLimiter(htlcLimits),
+		reputation:       newReputationTracker(reputation),
 		chanIndex:        make(map[wire.OutPoint]*link),
 		interfaces:       make(map[chainhash.Hash][]*link),
 		onionIndex:       make(map[[ripemd160.Size]byte][]*link),
-		paymentCircuits:  make(map[circuitKey]*paymentCircuit),
+		paymentCircuits:  make(map[circuitKey][]*paymentCircuit),
+		forwardedHtlcs:   make(map[incomingHtlcKey]*paymentCircuit),
 		linkControl:      make(chan interface{}),
 		htlcPlex:         make(chan *htlcPacket, htlcQueueSize),
 		outgoingPayments: make(chan *htlcPacket, htlcQueueSize),
-		quit:             make(chan struct{}),
+		onChainResolutions: make(chan *onChainResolution,
+			htlcQueueSize),
+		quit: make(chan struct{}),
 	}
 }
 
@@ -180,9 +340,18 @@ func (h *htlcSwitch) Start() error {
 
 	hswcLog.Tracef("Starting HTLC switch")
 
+	_, bestHeight, err := h.bio.GetBestBlock()
+	if err != nil {
+		return err
+	}
+	h.blockEpochs, err = h.notifier.RegisterBlockEpochNtfn()
+	if err != nil {
+		return err
+	}
+
 	h.wg.Add(2)
 	go h.networkAdmin()
-	go h.htlcForwarder()
+	go h.htlcForwarder(uint32(bestHeight), h.blockEpochs)
 
 	return nil
 }
@@ -199,6 +368,10 @@ func (h *htlcSwitch) Stop() error {
 	close(h.quit)
 	h.wg.Wait()
 
+	if h.blockEpochs != nil {
+		h.blockEpochs.Cancel()
+	}
+
 	return nil
 }
 
@@ -214,6 +387,46 @@ func (h *htlcSwitch) SendHTLC(htlcPkt *htlcPacket) error {
 	return <-htlcPkt.err
 }
 
+// settleSelfPayment settles the invoice paid by the passed packet, which pays
+// to ourselves. As with HTLC's arriving over a link, the invoice must be
+// neither canceled nor expired, and the payment must carry at least the
+// amount requested by the invoice.
+func (h *htlcSwitch) settleSelfPayment(htlcPkt *htlcPacket) error {
+	htlcAdd := htlcPkt.msg.(*lnwire.HTLCAddRequest)
+	rHash := chainhash.Hash(htlcAdd.RedemptionHashes[0])
+
+	invoice, err := h.invoices.LookupInvoice(rHash)
+	if err != nil {
+		return fmt.Errorf("unable to pay ourselves, no invoice "+
+			"found for payment hash %x: %v", rHash[:], err)
+	}
+
+	expiry, expires := invoice.ExpiryTime()
+	switch {
+	case invoice.Terms.Settled:
+		return fmt.Errorf("unable to pay ourselves, invoice %x "+
+			"is already settled", rHash[:])
+
+	case invoice.Terms.Canceled:
+		return fmt.Errorf("unable to pay ourselves, invoice %x "+
+			"has been canceled", rHash[:])
+
+	case expires && !time.Now().Before(expiry):
+		return fmt.Errorf("unable to pay ourselves, invoice %x "+
+			"has expired", rHash[:])
+
+	case htlcAdd.Amount < invoice.Terms.Value:
+		return fmt.Errorf("unable to pay ourselves, payment of %v "+
+			"is below invoice amount of %v", htlcAdd.Amount,
+			invoice.Terms.Value)
+	}
+
+	hswcLog.Infof("Settling self payment of %v to invoice %x",
+		htlcAdd.Amount, rHash[:])
+
+	return h.invoices.SettleInvoice(rHash)
+}
+
 // htlcForwarder is responsible for optimally forwarding (and possibly
 // fragmenting) incoming/outgoing HTLC's amongst all active interfaces and
 // their links. The duties of the forwarder are similar to that of a network
@@ -222,7 +435,13 @@ func (h *htlcSwitch) SendHTLC(htlcPkt *htlcPacket) error {
 // and tear down active onion routed payments.Each active channel is modeled
 // as networked device with meta-data such as the available payment bandwidth,
 // and total link capacity.
-func (h *htlcSwitch) htlcForwarder() {
+//
+// The forwarder also tracks the current block height in order to enforce the
+// CLTV delta of forwarded HTLC's, and to cancel back incoming HTLC's before
+// the corresponding outgoing HTLC's time out.
+func (h *htlcSwitch) htlcForwarder(bestHeight uint32,
+	blockEpochs *chainntnfs.BlockEpochEvent) {
+
 	// TODO(roasbeef): track pending payments here instead of within each peer?
 	// Examine settles/timeouts from htlcPlex. Add src to htlcPacket, key by
 	// (src, htlcKey).
@@ -235,6 +454,17 @@ out:
 	for {
 		select {
 		case htlcPkt := <-h.outgoingPayments:
+			// Payments to ourselves aren't sent over any link,
+			// instead they're short-circuited, settling the
+			// invoice they pay directly.
+			if htlcPkt.selfPayment {
+				htlcPkt.err <- h.settleSelfPayment(htlcPkt)
+				continue
+			}
+
+			wireMsg := htlcPkt.msg.(*lnwire.HTLCAddRequest)
+			amt := btcutil.Amount(wireMsg.Amount)
+
 			dest := htlcPkt.dest
 			h.interfaceMtx.RLock()
 			chanInterface, ok := h.interfaces[dest]
@@ -244,12 +474,15 @@ out:
 					dest[:])
 				hswcLog.Errorf(err.Error())
 				htlcPkt.err <- err
+				h.htlcNotifier.notifyHtlcEvent(&htlcEvent{
+					eventType: lnrpc.HtlcEventType_LINK_FAIL,
+					payHash:   wireMsg.RedemptionHashes[0],
+					amt:       amt,
+					failure:   err.Error(),
+				})
 				continue
 			}
 
-			wireMsg := htlcPkt.msg.(*lnwire.HTLCAddRequest)
-			amt := btcutil.Amount(wireMsg.Amount)
-
 			// Handle this send request in a distinct goroutine in
 			// order to avoid a possible deadlock between the htlc
 			// switch and channel's htlc manager.
@@ -273,11 +506,24 @@ out:
 				hswcLog.Tracef("Decrementing link %v bandwidth to %v",
 					link.chanPoint, n)
 
+				h.htlcNotifier.notifyHtlcEvent(&htlcEvent{
+					eventType:    lnrpc.HtlcEventType_FORWARD,
+					outgoingChan: link.chanPoint,
+					payHash:      wireMsg.RedemptionHashes[0],
+					amt:          amt,
+				})
+
 				continue out
 			}
 
 			hswcLog.Errorf("Unable to send payment, insufficient capacity")
-			htlcPkt.err <- fmt.Errorf("Insufficient capacity")
+			htlcPkt.err <- errInsufficientBandwidth
+			h.htlcNotifier.notifyHtlcEvent(&htlcEvent{
+				eventType: lnrpc.HtlcEventType_LINK_FAIL,
+				payHash:   wireMsg.RedemptionHashes[0],
+				amt:       amt,
+				failure:   lnwire.InsufficientCapacity.String(),
+			})
 		case pkt := <-h.htlcPlex:
 			// TODO(roasbeef): properly account with cleared vs settled
 			numUpdates += 1
@@ -293,6 +539,20 @@ out:
 			// settle message.
 			case *lnwire.HTLCAddRequest:
 				payHash := wireMsg.RedemptionHashes[0]
+				srcLink := pkt.srcLink
+
+				// An HTLC which has already been forwarded is
+				// ignored, as its circuit is still active.
+				incoming := incomingHtlcKey{
+					chanPoint: srcLink,
+					index:     pkt.htlcIndex,
+				}
+				if _, ok := h.forwardedHtlcs[incoming]; ok {
+					hswcLog.Warnf("HTLC %x from %v with "+
+						"index %v already forwarded",
+						payHash, srcLink, pkt.htlcIndex)
+					continue
+				}
 
 				// Create the two ends of the payment circuit
 				// required to ensure completion of this new
@@ -325,6 +585,14 @@ out:
 					h.chanIndexMtx.RUnlock()
 
 					cancelLink.linkChan <- cancelPkt
+					monitoring.IncHtlcEvent(monitoring.HtlcFailed)
+					h.htlcNotifier.notifyHtlcEvent(&htlcEvent{
+						eventType:    lnrpc.HtlcEventType_LINK_FAIL,
+						incomingChan: &srcLink,
+						payHash:      payHash,
+						amt:          wireMsg.Amount,
+						failure:      lnwire.UnknownDestination.String(),
+					})
 					continue
 				}
 
@@ -332,6 +600,37 @@ out:
 				settleLink := h.chanIndex[pkt.srcLink]
 				h.chanIndexMtx.RUnlock()
 
+				// If the HTLC we'd extend over the next link
+				// doesn't leave us enough time to claim the
+				// incoming HTLC after the outgoing one has
+				// been resolved, then we cancel it back.
+				expiry, ok := outgoingExpiry(wireMsg.Expiry,
+					bestHeight, h.timeLockDelta)
+				if !ok {
+					hswcLog.Errorf("unable to forward HTLC "+
+						"%x: expiry %v too soon, height=%v, "+
+						"delta=%v", payHash, wireMsg.Expiry,
+						bestHeight, h.timeLockDelta)
+
+					settleLink.linkChan <- &htlcPacket{
+						payHash: payHash,
+						msg: &lnwire.CancelHTLC{
+							Reason: lnwire.ExpiryTooSoon,
+						},
+						err: make(chan error, 1),
+					}
+					monitoring.IncHtlcEvent(monitoring.HtlcFailed)
+					h.htlcNotifier.notifyHtlcEvent(&htlcEvent{
+						eventType:    lnrpc.HtlcEventType_LINK_FAIL,
+						incomingChan: &srcLink,
+						outgoingChan: clearLink[0].chanPoint,
+						payHash:      payHash,
+						amt:          wireMsg.Amount,
+						failure:      lnwire.ExpiryTooSoon.String(),
+					})
+					continue
+				}
+
 				// If the link we're attempting to forward the
 				// HTLC over has insufficient capacity, then
 				// we'll cancel the HTLC as the payment cannot
@@ -353,16 +652,83 @@ out:
 					}
 
 					settleLink.linkChan <- pkt
+					monitoring.IncHtlcEvent(monitoring.HtlcFailed)
+					h.htlcNotifier.notifyHtlcEvent(&htlcEvent{
+						eventType:    lnrpc.HtlcEventType_LINK_FAIL,
+						incomingChan: &srcLink,
+						outgoingChan: clearLink[0].chanPoint,
+						payHash:      payHash,
+						amt:          wireMsg.Amount,
+						failure:      lnwire.InsufficientCapacity.String(),
+					})
+					continue
+				}
+
+				// If forwarding the HTLC would exceed the
+				// limits on the HTLC's in flight, or the
+				// general slots of the outgoing channel in
+				// case we don't endorse it, then we cancel it
+				// back, as the peer may attempt to jam our
+				// channels.
+				peerKey := linkPeerKey(settleLink)
+				endorsed := h.reputation.endorse(peerKey,
+					wireMsg.Endorsed)
+				err := h.htlcLimiter.addHtlc(*settleLink.chanPoint,
+					peerKey, wireMsg.Amount)
+				if err == nil {
+					err = h.reputation.addHtlc(incoming,
+						peerKey, *clearLink[0].chanPoint,
+						wireMsg.Amount, wireMsg.Endorsed,
+						endorsed)
+					if err != nil {
+						h.htlcLimiter.removeHtlc(
+							*settleLink.chanPoint,
+							peerKey, wireMsg.Amount,
+						)
+					}
+				}
+				if err != nil {
+					hswcLog.Warnf("unable to forward HTLC "+
+						"%x from %v: %v", payHash,
+						settleLink.chanPoint, err)
+
+					settleLink.linkChan <- &htlcPacket{
+						payHash: payHash,
+						msg: &lnwire.CancelHTLC{
+							Reason: lnwire.TemporaryChannelFailure,
+						},
+						err: make(chan error, 1),
+					}
+					monitoring.IncHtlcEvent(monitoring.HtlcFailed)
+					h.htlcNotifier.notifyHtlcEvent(&htlcEvent{
+						eventType:    lnrpc.HtlcEventType_LINK_FAIL,
+						incomingChan: &srcLink,
+						outgoingChan: clearLink[0].chanPoint,
+						payHash:      payHash,
+						amt:          wireMsg.Amount,
+						failure:      lnwire.TemporaryChannelFailure.String(),
+					})
 					continue
 				}
 
 				circuit := &paymentCircuit{
-					clear:  clearLink[0],
-					settle: settleLink,
+					clear:          clearLink[0],
+					settle:         settleLink,
+					incoming:       incoming,
+					incomingExpiry: wireMsg.Expiry,
+					outgoingExpiry: expiry,
+					amt:            wireMsg.Amount,
+					endorsed:       endorsed,
+					addedAt:        time.Now(),
 				}
+				wireMsg.Expiry = expiry
+				wireMsg.Endorsed = endorsed
 
+				// Circuits sharing the same payment hash are
+				// kept alongside each other, each being
+				// released once its own HTLC is resolved.
 				cKey := circuitKey(wireMsg.RedemptionHashes[0])
-				h.paymentCircuits[cKey] = circuit
+				h.addCircuit(cKey, circuit)
 
 				hswcLog.Debugf("Creating onion circuit for %x: %v<->%v",
 					cKey[:], clearLink[0].chanPoint,
@@ -386,6 +752,14 @@ out:
 					circuit.clear.chanPoint, n)
 
 				satRecv += pkt.amt
+				monitoring.IncHtlcEvent(monitoring.HtlcForwarded)
+				h.htlcNotifier.notifyHtlcEvent(&htlcEvent{
+					eventType:    lnrpc.HtlcEventType_FORWARD,
+					incomingChan: settleLink.chanPoint,
+					outgoingChan: circuit.clear.chanPoint,
+					payHash:      payHash,
+					amt:          circuit.amt,
+				})
 
 			// We've just received a settle message which means we
 			// can finalize the payment circuit by forwarding the
@@ -399,11 +773,19 @@ out:
 				// If we initiated the payment then there won't
 				// be an active circuit to continue propagating
 				// the settle over. Therefore, we exit early.
-				circuit, ok := h.paymentCircuits[cKey]
-				if !ok {
+				circuit := h.findCircuit(cKey, pkt.srcLink)
+				if circuit == nil {
 					hswcLog.Debugf("No existing circuit "+
 						"for %x to settle", rHash[:])
 					satSent += pkt.amt
+
+					srcLink := pkt.srcLink
+					h.htlcNotifier.notifyHtlcEvent(&htlcEvent{
+						eventType:    lnrpc.HtlcEventType_SETTLE,
+						outgoingChan: &srcLink,
+						payHash:      rHash,
+						amt:          pkt.amt,
+					})
 					continue
 				}
 
@@ -427,8 +809,16 @@ out:
 					circuit.settle.chanPoint, n)
 
 				satSent += pkt.amt
+				monitoring.IncHtlcEvent(monitoring.HtlcSettled)
+				h.htlcNotifier.notifyHtlcEvent(&htlcEvent{
+					eventType:    lnrpc.HtlcEventType_SETTLE,
+					incomingChan: circuit.settle.chanPoint,
+					outgoingChan: circuit.clear.chanPoint,
+					payHash:      rHash,
+					amt:          circuit.amt,
+				})
 
-				delete(h.paymentCircuits, cKey)
+				h.removeCircuit(cKey, circuit, true)
 
 			// We've just received an HTLC cancellation triggered
 			// by an upstream peer somewhere within the ultimate
@@ -438,10 +828,20 @@ out:
 				// In order to properly handle the error, well
 				// need to look up the original circuit that
 				// the incoming HTLC created.
-				circuit, ok := h.paymentCircuits[pkt.payHash]
-				if !ok {
+				cKey := circuitKey(pkt.payHash)
+				circuit := h.findCircuit(cKey, pkt.srcLink)
+				if circuit == nil {
 					hswcLog.Debugf("No existing circuit "+
 						"for %x to cancel", pkt.payHash)
+
+					srcLink := pkt.srcLink
+					h.htlcNotifier.notifyHtlcEvent(&htlcEvent{
+						eventType:    lnrpc.HtlcEventType_FORWARD_FAIL,
+						outgoingChan: &srcLink,
+						payHash:      pkt.payHash,
+						amt:          pkt.amt,
+						failure:      wireMsg.Reason.String(),
+					})
 					continue
 				}
 
@@ -465,8 +865,121 @@ out:
 					err:     make(chan error, 1),
 				}
 
-				delete(h.paymentCircuits, pkt.payHash)
+				monitoring.IncHtlcEvent(monitoring.HtlcFailed)
+				h.htlcNotifier.notifyHtlcEvent(&htlcEvent{
+					eventType:    lnrpc.HtlcEventType_FORWARD_FAIL,
+					incomingChan: circuit.settle.chanPoint,
+					outgoingChan: circuit.clear.chanPoint,
+					payHash:      pkt.payHash,
+					amt:          circuit.amt,
+					failure:      wireMsg.Reason.String(),
+				})
+
+				h.removeCircuit(cKey, circuit, false)
+			}
+		case epoch, ok := <-blockEpochs.Epochs:
+			if !ok {
+				break out
+			}
+			bestHeight = uint32(epoch.Height)
+
+			// Any outgoing HTLC's which have now expired without
+			// being resolved by the downstream peer may still be
+			// claimed by it on-chain with the preimage, so the
+			// incoming HTLC's can't yet be cancelled back.
+			// Instead, the downstream channels are force closed,
+			// and the incoming HTLC's are only resolved once the
+			// outgoing HTLC's are resolved on-chain.
+			forceClosed := make(map[wire.OutPoint]struct{})
+			for cKey, circuits := range h.paymentCircuits {
+				for _, circuit := range circuits {
+					if circuit.onChain ||
+						circuit.outgoingExpiry > bestHeight {
+
+						continue
+					}
+					circuit.onChain = true
+
+					chanPoint := *circuit.clear.chanPoint
+					hswcLog.Warnf("Outgoing HTLC %x on %v "+
+						"expired at height %v, force "+
+						"closing channel to resolve it "+
+						"on-chain", cKey[:], chanPoint,
+						circuit.outgoingExpiry)
+
+					if _, ok := forceClosed[chanPoint]; ok {
+						continue
+					}
+					forceClosed[chanPoint] = struct{}{}
+
+					h.wg.Add(1)
+					go h.forceCloseLink(chanPoint)
+				}
+			}
+		case res := <-h.onChainResolutions:
+			circuit := h.findCircuit(res.payHash, res.chanPoint)
+			if circuit == nil {
+				continue
+			}
+
+			// If the downstream peer claimed the HTLC on-chain,
+			// then we settle the incoming HTLC with the preimage
+			// it revealed. Otherwise, the outgoing HTLC has timed
+			// out, and it's now safe to cancel back the incoming
+			// HTLC.
+			if res.preimage != nil {
+				hswcLog.Infof("Outgoing HTLC %x on %v claimed "+
+					"on-chain, settling %v", res.payHash[:],
+					circuit.clear.chanPoint,
+					circuit.settle.chanPoint)
+
+				circuit.settle.linkChan <- &htlcPacket{
+					msg: &lnwire.HTLCSettleRequest{
+						RedemptionProofs: [][32]byte{
+							*res.preimage,
+						},
+					},
+					err: make(chan error, 1),
+				}
+				atomic.AddInt64(&circuit.settle.availableBandwidth,
+					int64(circuit.amt))
+
+				monitoring.IncHtlcEvent(monitoring.HtlcSettled)
+				h.htlcNotifier.notifyHtlcEvent(&htlcEvent{
+					eventType:    lnrpc.HtlcEventType_SETTLE,
+					incomingChan: circuit.settle.chanPoint,
+					outgoingChan: circuit.clear.chanPoint,
+					payHash:      res.payHash,
+					amt:          circuit.amt,
+				})
+
+				h.removeCircuit(res.payHash, circuit, true)
+				continue
+			}
+
+			hswcLog.Infof("Outgoing HTLC %x on %v timed out "+
+				"on-chain, cancelling back over %v",
+				res.payHash[:], circuit.clear.chanPoint,
+				circuit.settle.chanPoint)
+
+			circuit.settle.linkChan <- &htlcPacket{
+				payHash: res.payHash,
+				msg: &lnwire.CancelHTLC{
+					Reason: lnwire.UpstreamTimeout,
+				},
+				err: make(chan error, 1),
 			}
+			monitoring.IncHtlcEvent(monitoring.HtlcFailed)
+			h.htlcNotifier.notifyHtlcEvent(&htlcEvent{
+				eventType:    lnrpc.HtlcEventType_FORWARD_FAIL,
+				incomingChan: circuit.settle.chanPoint,
+				outgoingChan: circuit.clear.chanPoint,
+				payHash:      res.payHash,
+				amt:          circuit.amt,
+				failure:      lnwire.UpstreamTimeout.String(),
+			})
+
+			h.removeCircuit(res.payHash, circuit, false)
 		case <-logTicker.C:
 			if numUpdates == 0 {
 				continue
@@ -487,6 +1000,163 @@ out:
 	h.wg.Done()
 }
 
+// forceCloseLink force closes the channel of the passed link, in order to
+// resolve its expired outgoing HTLC's on-chain.
+//
+// NOTE: This MUST be run as a goroutine.
+func (h *htlcSwitch) forceCloseLink(chanPoint wire.OutPoint) {
+	defer h.wg.Done()
+
+	req := &closeLinkReq{
+		CloseType: CloseForce,
+		chanPoint: &chanPoint,
+		updates:   make(chan *lnrpc.CloseStatusUpdate, 1),
+		err:       make(chan error, 1),
+	}
+	select {
+	case h.linkControl <- req:
+	case <-h.quit:
+		return
+	}
+
+	for {
+		select {
+		case update := <-req.updates:
+			if update.GetChanClose() != nil {
+				return
+			}
+		case err := <-req.err:
+			hswcLog.Errorf("Unable to force close ChannelPoint(%v) "+
+				"with expired HTLC's: %v", chanPoint, err)
+			return
+		case <-h.quit:
+			return
+		}
+	}
+}
+
+// watchHtlcTimeouts watches the outgoing HTLC outputs of the passed force
+// closed channel until they're spent, either by the remote party claiming
+// them with the preimage, or by us sweeping them once they've timed out. The
+// outcome is then handed to the htlcForwarder, so that the incoming HTLC's of
+// any circuits they belong to are resolved accordingly.
+func (h *htlcSwitch) watchHtlcTimeouts(closeSummary *lnwallet.ForceCloseSummary) {
+	for _, htlc := range closeSummary.HtlcTimeouts {
+		spendNtfn, err := h.notifier.RegisterSpendNtfn(&htlc.Outpoint)
+		if err != nil {
+			hswcLog.Errorf("Unable to watch HTLC output %v: %v",
+				htlc.Outpoint, err)
+			continue
+		}
+
+		h.wg.Add(1)
+		go func(payHash [32]byte) {
+			defer h.wg.Done()
+
+			var spend *chainntnfs.SpendDetail
+			select {
+			case s, ok := <-spendNtfn.Spend:
+				if !ok {
+					return
+				}
+				spend = s
+			case <-h.quit:
+				return
+			}
+
+			// The success clause of the HTLC script reveals the
+			// preimage within the witness of the spending input,
+			// while the timeout clause doesn't.
+			res := &onChainResolution{
+				payHash:   payHash,
+				chanPoint: closeSummary.ChanPoint,
+			}
+			txIn := spend.SpendingTx.TxIn[spend.SpenderInputIndex]
+			for _, item := range txIn.Witness {
+				if len(item) != 32 ||
+					fastsha256.Sum256(item) != payHash {
+
+					continue
+				}
+
+				var preimage [32]byte
+				copy(preimage[:], item)
+				res.preimage = &preimage
+				break
+			}
+
+			select {
+			case h.onChainResolutions <- res:
+			case <-h.quit:
+			}
+		}(htlc.PaymentHash)
+	}
+}
+
+// addCircuit adds the passed circuit to the set of active circuits with the
+// passed payment hash.
+func (h *htlcSwitch) addCircuit(cKey circuitKey, circuit *paymentCircuit) {
+	h.paymentCircuits[cKey] = append(h.paymentCircuits[cKey], circuit)
+	h.forwardedHtlcs[circuit.incoming] = circuit
+}
+
+// findCircuit returns the oldest active circuit with the passed payment hash
+// whose outgoing HTLC was extended over the channel with the passed outpoint,
+// or nil if there's none.
+func (h *htlcSwitch) findCircuit(cKey circuitKey,
+	outgoing wire.OutPoint) *paymentCircuit {
+
+	for _, circuit := range h.paymentCircuits[cKey] {
+		if *circuit.clear.chanPoint == outgoing {
+			return circuit
+		}
+	}
+
+	return nil
+}
+
+// removeCircuit removes the passed circuit, whose HTLC has been settled or
+// cancelled, from the set of active circuits with the passed payment hash,
+// then releases the HTLC it accounted for.
+func (h *htlcSwitch) removeCircuit(cKey circuitKey, circuit *paymentCircuit,
+	settled bool) {
+
+	circuits := h.paymentCircuits[cKey]
+	for i, c := range circuits {
+		if c != circuit {
+			continue
+		}
+
+		circuits = append(circuits[:i], circuits[i+1:]...)
+		break
+	}
+	if len(circuits) == 0 {
+		delete(h.paymentCircuits, cKey)
+	} else {
+		h.paymentCircuits[cKey] = circuits
+	}
+	delete(h.forwardedHtlcs, circuit.incoming)
+
+	h.releaseCircuit(circuit, settled)
+}
+
+// releaseCircuit releases the HTLC accounted for by the passed circuit from
+// the limits on the HTLC's in flight once it's been settled or cancelled,
+// recording its outcome within the reputation of the peer it arrived from.
+func (h *htlcSwitch) releaseCircuit(circuit *paymentCircuit, settled bool) {
+	peerKey := linkPeerKey(circuit.settle)
+	h.htlcLimiter.removeHtlc(*circuit.settle.chanPoint, peerKey,
+		circuit.amt)
+	h.reputation.resolveHtlc(circuit.incoming, settled,
+		time.Since(circuit.addedAt))
+}
+
+// linkPeerKey returns the serialized public key of the peer the passed link
+// is with.
+func linkPeerKey(l *link) string {
+	return string(l.peer.addr.IdentityKey.SerializeCompressed())
+}
+
 // networkAdmin is responsible for handline requests to register, unregister,
 // and close any link. In the event that a unregister requests leaves an
 // interface with no active links, that interface is garbage collected.
@@ -737,6 +1407,12 @@ const (
 	// CloseBreach indicates that a channel breach has been dtected, and
 	// the link should immediately be marked as unavailable.
 	CloseBreach
+
+	// CloseForce indicates that the channel should be unilaterally
+	// closed by broadcasting our latest commitment transaction, such as
+	// when an outgoing HTLC has expired without the remote party
+	// cancelling it.
+	CloseForce
 )
 
 // closeChanReq represents a request to close a particular channel specified by
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/fastsha256"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// TestOutgoingExpiry asserts that the switch only forwards HTLC's which leave
+// at least the time lock delta between the incoming and outgoing HTLC, and
+// whose outgoing expiry lies beyond the grace period.
+func TestOutgoingExpiry(t *testing.T) {
+	const (
+		height        = 100
+		timeLockDelta = 10
+	)
+
+	tests := []struct {
+		incoming uint32
+		outgoing uint32
+		ok       bool
+	}{
+		// The incoming HTLC leaves plenty of room after the delta.
+		{incoming: 150, outgoing: 140, ok: true},
+
+		// The outgoing HTLC lies just beyond the grace period.
+		{incoming: height + expiryGraceDelta + timeLockDelta + 1,
+			outgoing: height + expiryGraceDelta + 1, ok: true},
+
+		// The outgoing HTLC would expire within the grace period.
+		{incoming: height + expiryGraceDelta + timeLockDelta, ok: false},
+
+		// The incoming HTLC has already expired.
+		{incoming: height, ok: false},
+
+		// The incoming expiry is smaller than the delta itself.
+		{incoming: timeLockDelta - 1, ok: false},
+	}
+
+	for i, test := range tests {
+		outgoing, ok := outgoingExpiry(test.incoming, height,
+			timeLockDelta)
+		if ok != test.ok {
+			t.Fatalf("test #%v: expected ok=%v, got %v", i,
+				test.ok, ok)
+		}
+		if ok && outgoing != test.outgoing {
+			t.Fatalf("test #%v: expected outgoing expiry %v, "+
+				"got %v", i, test.outgoing, outgoing)
+		}
+	}
+}
+
+// TestSettleSelfPayment asserts that payments to ourselves are settled
+// directly against the invoice they pay, as long as they pay at least its
+// amount, and only once.
+func TestSettleSelfPayment(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "selfpayment")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	cdb, err := channeldb.Open(tempDir)
+	if err != nil {
+		t.Fatalf("unable to open channeldb: %v", err)
+	}
+	defer cdb.Close()
+
+	invoices := newInvoiceRegistry(cdb, defaultInvoiceAcceptTimeout,
+		0, 0)
+	htlcSwitch := newHtlcSwitch(nil, nil, invoices, 0, newHtlcNotifier(),
+		nil, nil)
+
+	const invoiceAmt = btcutil.Amount(10000)
+	preimage := [32]byte{1, 2, 3}
+	invoice := &channeldb.Invoice{
+		Memo:         []byte("self"),
+		CreationDate: time.Now(),
+		Terms: channeldb.ContractTerm{
+			Value:           invoiceAmt,
+			PaymentPreimage: preimage,
+		},
+	}
+	if err := invoices.AddInvoice(invoice); err != nil {
+		t.Fatalf("unable to add invoice: %v", err)
+	}
+	rHash := fastsha256.Sum256(preimage[:])
+
+	selfPayment := func(amt btcutil.Amount) *htlcPacket {
+		return &htlcPacket{
+			msg: &lnwire.HTLCAddRequest{
+				Amount:           amt,
+				RedemptionHashes: [][32]byte{rHash},
+			},
+			selfPayment: true,
+		}
+	}
+
+	// A payment below the invoice amount should be refused.
+	if err := htlcSwitch.settleSelfPayment(selfPayment(invoiceAmt - 1)); err == nil {
+		t.Fatalf("expected payment below invoice amount to fail")
+	}
+
+	// A payment of the invoice amount should settle it.
+	if err := htlcSwitch.settleSelfPayment(selfPayment(invoiceAmt)); err != nil {
+		t.Fatalf("unable to settle self payment: %v", err)
+	}
+	dbInvoice, err := invoices.LookupInvoice(chainhash.Hash(rHash))
+	if err != nil {
+		t.Fatalf("unable to lookup invoice: %v", err)
+	}
+	if !dbInvoice.Terms.Settled {
+		t.Fatalf("invoice wasn't settled")
+	}
+
+	// Paying the invoice again should fail, as should paying an unknown
+	// invoice.
+	if err := htlcSwitch.settleSelfPayment(selfPayment(invoiceAmt)); err == nil {
+		t.Fatalf("expected payment of settled invoice to fail")
+	}
+	unknown := selfPayment(invoiceAmt)
+	unknown.msg.(*lnwire.HTLCAddRequest).RedemptionHashes[0] = [32]byte{9}
+	if err := htlcSwitch.settleSelfPayment(unknown); err == nil {
+		t.Fatalf("expected payment of unknown invoice to fail")
+	}
+}
+
+// TestPaymentCircuitsSharingHash asserts that circuits sharing a payment hash
+// are kept alongside each other, each being resolved against the channel its
+// outgoing HTLC was extended over, and that the HTLC's in flight remain
+// accounted for until their own circuit is removed.
+func TestPaymentCircuitsSharingHash(t *testing.T) {
+	htlcSwitch := newHtlcSwitch(nil, nil, nil, 0, newHtlcNotifier(),
+		&htlcLimitsConfig{MaxChanHtlcs: 2}, nil)
+
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	remote := &peer{addr: &lnwire.NetAddress{IdentityKey: priv.PubKey()}}
+	newLink := func(index uint32) *link {
+		return &link{
+			peer:      remote,
+			chanPoint: &wire.OutPoint{Index: index},
+		}
+	}
+	incomingLink, outLinkA, outLinkB := newLink(0), newLink(1), newLink(2)
+
+	// Two HTLC's with the same payment hash arrive over the same channel,
+	// and are forwarded over different channels.
+	payHash := circuitKey{1}
+	circuits := make([]*paymentCircuit, 2)
+	for i, outLink := range []*link{outLinkA, outLinkB} {
+		circuits[i] = &paymentCircuit{
+			clear:  outLink,
+			settle: incomingLink,
+			incoming: incomingHtlcKey{
+				chanPoint: *incomingLink.chanPoint,
+				index:     uint32(i),
+			},
+			amt: 1000,
+		}
+		err := htlcSwitch.htlcLimiter.addHtlc(*incomingLink.chanPoint,
+			linkPeerKey(incomingLink), 1000)
+		if err != nil {
+			t.Fatalf("unable to account for htlc: %v", err)
+		}
+		htlcSwitch.addCircuit(payHash, circuits[i])
+	}
+
+	// Each circuit should be found by the channel its outgoing HTLC was
+	// extended over.
+	if c := htlcSwitch.findCircuit(payHash, *outLinkB.chanPoint); c != circuits[1] {
+		t.Fatalf("expected second circuit, got %v", c)
+	}
+	if c := htlcSwitch.findCircuit(payHash, *incomingLink.chanPoint); c != nil {
+		t.Fatalf("expected no circuit, got %v", c)
+	}
+
+	// Once the first HTLC is resolved, the second circuit remains active,
+	// and its HTLC is still accounted for, so a third HTLC over the
+	// incoming channel is within its limit, but a fourth one isn't.
+	htlcSwitch.removeCircuit(payHash, circuits[0], false)
+	if c := htlcSwitch.findCircuit(payHash, *outLinkA.chanPoint); c != nil {
+		t.Fatalf("expected resolved circuit to be removed")
+	}
+	if c := htlcSwitch.findCircuit(payHash, *outLinkB.chanPoint); c != circuits[1] {
+		t.Fatalf("expected second circuit to remain active")
+	}
+	if _, ok := htlcSwitch.forwardedHtlcs[circuits[0].incoming]; ok {
+		t.Fatalf("resolved HTLC still indexed")
+	}
+	if _, ok := htlcSwitch.forwardedHtlcs[circuits[1].incoming]; !ok {
+		t.Fatalf("active HTLC not indexed")
+	}
+	err = htlcSwitch.htlcLimiter.addHtlc(*incomingLink.chanPoint,
+		linkPeerKey(incomingLink), 1000)
+	if err != nil {
+		t.Fatalf("unable to account for htlc: %v", err)
+	}
+	err = htlcSwitch.htlcLimiter.addHtlc(*incomingLink.chanPoint,
+		linkPeerKey(incomingLink), 1000)
+	if err == nil {
+		t.Fatalf("channel limit wasn't enforced")
+	}
+
+	htlcSwitch.removeCircuit(payHash, circuits[1], true)
+	if _, ok := htlcSwitch.paymentCircuits[payHash]; ok {
+		t.Fatalf("expected no circuits left for payment hash")
+	}
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"container/heap"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/btcsuite/fastsha256"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+)
+
+// acceptedInvoiceRecheckInterval is the interval after which an expired
+// invoice, which wasn't canceled as an HTLC paying it was pending, is checked
+// again. Should the HTLC have failed in the meantime, the invoice is canceled
+// then.
+const acceptedInvoiceRecheckInterval = time.Minute
+
+// invoiceExpiry is an entry within the invoiceExpiryQueue, recording the time
+// at which an open invoice expires.
+type invoiceExpiry struct {
+	rHash  chainhash.Hash
+	expiry time.Time
+}
+
+// invoiceExpiryQueue is a list of invoiceExpiries sorted according to their
+// expiry, the invoice expiring the soonest being at its head.
+type invoiceExpiryQueue []*invoiceExpiry
+
+// Len returns the number of items in the priority queue. It is part of the
+// heap.Interface implementation.
+func (q invoiceExpiryQueue) Len() int { return len(q) }
+
+// Less returns whether the item in the priority queue with index i should sort
+// before the item with index j. It is part of the heap.Interface implementation.
+func (q invoiceExpiryQueue) Less(i, j int) bool {
+	return q[i].expiry.Before(q[j].expiry)
+}
+
+// Swap swaps the items at the passed indices in the priority queue. It is
+// part of the heap.Interface implementation.
+func (q invoiceExpiryQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+// Push pushes the passed item onto the priority queue. It is part of the
+// heap.Interface implementation.
+func (q *invoiceExpiryQueue) Push(x interface{}) {
+	*q = append(*q, x.(*invoiceExpiry))
+}
+
+// Pop removes the highest priority item (according to Less) from the priority
+// queue and returns it. It is part of the heap.Interface implementation.
+func (q *invoiceExpiryQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	*q = old[0 : n-1]
+	return x
+}
+
+// invoiceExpiryWatcher cancels open invoices the moment they expire, so that
+// they can no longer be paid, notifying the clients subscribed to them. On
+// start up, every open invoice with an expiry is loaded into a queue ordered
+// by expiry, and invoices added afterwards are queued as they're created.
+// Invoices for which an HTLC has been accepted, but is yet to be settled, are
+// left open, as they're about to be settled, and queued to be checked again
+// later, in case the HTLC fails instead.
+type invoiceExpiryWatcher struct {
+	started  int32
+	shutdown int32
+
+	registry *invoiceRegistry
+
+	// mtx guards the queue, which is modified both by addInvoice and by
+	// the expiryHandler goroutine.
+	mtx   sync.Mutex
+	queue invoiceExpiryQueue
+
+	// wakeup is signalled whenever an invoice is queued, so that the
+	// expiryHandler may reconsider the next expiry it waits for.
+	wakeup chan struct{}
+
+	wg   sync.WaitGroup
+	quit chan struct{}
+}
+
+// newInvoiceExpiryWatcher creates a new invoiceExpiryWatcher, canceling the
+// expired invoices of the passed registry.
+func newInvoiceExpiryWatcher(registry *invoiceRegistry) *invoiceExpiryWatcher {
+	return &invoiceExpiryWatcher{
+		registry: registry,
+		wakeup:   make(chan struct{}, 1),
+		quit:     make(chan struct{}),
+	}
+}
+
+// Start loads every open invoice with an expiry into the queue, and launches
+// the goroutine canceling them once they expire.
+func (w *invoiceExpiryWatcher) Start() error {
+	if !atomic.CompareAndSwapInt32(&w.started, 0, 1) {
+		return nil
+	}
+
+	invoices, err := w.registry.cdb.FetchAllInvoices(true)
+	if err != nil && err != channeldb.ErrNoInvoicesCreated {
+		return err
+	}
+	for _, invoice := range invoices {
+		expiry, ok := invoice.ExpiryTime()
+		if !ok {
+			continue
+		}
+
+		rHash := fastsha256.Sum256(invoice.Terms.PaymentPreimage[:])
+		w.addInvoice(rHash, expiry)
+	}
+
+	w.wg.Add(1)
+	go w.expiryHandler()
+
+	return nil
+}
+
+// Stop stops canceling expired invoices.
+func (w *invoiceExpiryWatcher) Stop() error {
+	if !atomic.CompareAndSwapInt32(&w.shutdown, 0, 1) {
+		return nil
+	}
+
+	close(w.quit)
+	w.wg.Wait()
+
+	return nil
+}
+
+// addInvoice queues the open invoice identified by the passed payment hash,
+// which is to be canceled at the passed expiry.
+func (w *invoiceExpiryWatcher) addInvoice(rHash chainhash.Hash,
+	expiry time.Time) {
+
+	w.mtx.Lock()
+	heap.Push(&w.queue, &invoiceExpiry{
+		rHash:  rHash,
+		expiry: expiry,
+	})
+	w.mtx.Unlock()
+
+	select {
+	case w.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+// nextExpiry returns the time at which the invoice at the head of the queue
+// expires, if the queue isn't empty.
+func (w *invoiceExpiryWatcher) nextExpiry() (time.Time, bool) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	if len(w.queue) == 0 {
+		return time.Time{}, false
+	}
+
+	return w.queue[0].expiry, true
+}
+
+// expiryHandler waits for the invoice at the head of the queue to expire,
+// canceling every expired invoice as it does.
+//
+// NOTE: This MUST be run as a goroutine.
+func (w *invoiceExpiryWatcher) expiryHandler() {
+	defer w.wg.Done()
+
+	for {
+		w.cancelExpired(time.Now())
+
+		// If the queue is empty, then we'll wait until an invoice is
+		// queued.
+		var (
+			timer   *time.Timer
+			timeout <-chan time.Time
+		)
+		if next, ok := w.nextExpiry(); ok {
+			timer = time.NewTimer(next.Sub(time.Now()))
+			timeout = timer.C
+		}
+
+		select {
+		case <-timeout:
+		case <-w.wakeup:
+		case <-w.quit:
+		}
+
+		if timer != nil {
+			timer.Stop()
+		}
+
+		select {
+		case <-w.quit:
+			return
+		default:
+		}
+	}
+}
+
+// cancelExpired cancels every queued invoice which has expired as of the
+// passed time. Expired invoices which are being paid are queued again, to be
+// checked once more after the acceptedInvoiceRecheckInterval.
+func (w *invoiceExpiryWatcher) cancelExpired(now time.Time) {
+	var expired []chainhash.Hash
+
+	w.mtx.Lock()
+	for len(w.queue) > 0 && !w.queue[0].expiry.After(now) {
+		entry := heap.Pop(&w.queue).(*invoiceExpiry)
+		expired = append(expired, entry.rHash)
+	}
+	w.mtx.Unlock()
+
+	for _, rHash := range expired {
+		if w.registry.hasAcceptedHtlcs(rHash) {
+			invcLog.Debugf("Not canceling expired invoice %x, as "+
+				"it's being paid", rHash[:])
+			w.addInvoice(
+				rHash, now.Add(acceptedInvoiceRecheckInterval),
+			)
+			continue
+		}
+
+		err := w.registry.CancelInvoice(rHash)
+		switch err {
+		case nil:
+			invcLog.Infof("Canceled expired invoice %x", rHash[:])
+
+		// The invoice may have been paid in the meantime.
+		case channeldb.ErrInvoiceAlreadySettled,
+			channeldb.ErrInvoiceAlreadyCanceled:
+
+		default:
+			invcLog.Errorf("unable to cancel expired invoice %x: "+
+				"%v", rHash[:], err)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/fastsha256"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+)
+
+// TestInvoiceExpiryWatcher asserts that open invoices are canceled once they
+// expire, whether they were created before or after the watcher started, that
+// subscribers are notified of the cancellation, and that invoices which don't
+// expire, or are being paid, are left open. Invoices which were being paid are
+// canceled once their HTLCs fail.
+func TestInvoiceExpiryWatcher(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "invoiceexpiry")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	cdb, err := channeldb.Open(tempDir)
+	if err != nil {
+		t.Fatalf("unable to open channeldb: %v", err)
+	}
+	defer cdb.Close()
+
+	invoices := newInvoiceRegistry(cdb, defaultInvoiceAcceptTimeout,
+		0, 0)
+
+	addInvoice := func(preimage [32]byte, creation time.Time,
+		expiry time.Duration) (*channeldb.Invoice, chainhash.Hash) {
+
+		invoice := &channeldb.Invoice{
+			CreationDate: creation,
+			Terms: channeldb.ContractTerm{
+				Value:           10000,
+				PaymentPreimage: preimage,
+			},
+			Expiry: expiry,
+		}
+		if err := invoices.AddInvoice(invoice); err != nil {
+			t.Fatalf("unable to add invoice: %v", err)
+		}
+
+		return invoice, fastsha256.Sum256(preimage[:])
+	}
+
+	// The first invoice expired before the watcher started, the second
+	// never expires, and the third is being paid.
+	now := time.Now()
+	_, expiredHash := addInvoice([32]byte{1}, now.Add(-time.Hour),
+		time.Minute)
+	_, eternalHash := addInvoice([32]byte{2}, now, 0)
+	payingInvoice, payingHash := addInvoice([32]byte{3}, now,
+		10*time.Millisecond)
+	if err := invoices.AcceptInvoice(payingHash, payingInvoice); err != nil {
+		t.Fatalf("unable to accept invoice: %v", err)
+	}
+
+	allClient := invoices.SubscribeNotifications()
+	defer allClient.Cancel()
+
+	watcher := newInvoiceExpiryWatcher(invoices)
+	if err := watcher.Start(); err != nil {
+		t.Fatalf("unable to start expiry watcher: %v", err)
+	}
+	defer watcher.Stop()
+
+	assertCanceled := func(rHash chainhash.Hash) {
+		select {
+		case invoice := <-allClient.CanceledInvoices:
+			canceledHash := fastsha256.Sum256(
+				invoice.Terms.PaymentPreimage[:],
+			)
+			if canceledHash != rHash {
+				t.Fatalf("expected invoice %x to be canceled, "+
+					"got %x", rHash[:], canceledHash[:])
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("invoice %x wasn't canceled", rHash[:])
+		}
+
+		invoice, err := invoices.LookupInvoice(rHash)
+		if err != nil {
+			t.Fatalf("unable to look up invoice: %v", err)
+		}
+		if !invoice.Terms.Canceled {
+			t.Fatalf("invoice %x not canceled on disk", rHash[:])
+		}
+	}
+
+	assertCanceled(expiredHash)
+
+	// An invoice added after the watcher started should be canceled once
+	// it expires, and a client subscribed to it should be notified.
+	_, newHash := addInvoice([32]byte{4}, time.Now(), 50*time.Millisecond)
+	singleClient, err := invoices.SubscribeSingleInvoice(newHash)
+	if err != nil {
+		t.Fatalf("unable to subscribe to invoice: %v", err)
+	}
+	defer singleClient.Cancel()
+
+	newInvoice, err := invoices.LookupInvoice(newHash)
+	if err != nil {
+		t.Fatalf("unable to look up invoice: %v", err)
+	}
+	expiry, _ := newInvoice.ExpiryTime()
+	watcher.addInvoice(newHash, expiry)
+
+	assertCanceled(newHash)
+
+	for _, state := range []lnrpc.InvoiceState{
+		lnrpc.InvoiceState_OPEN, lnrpc.InvoiceState_CANCELED,
+	} {
+		select {
+		case update := <-singleClient.Updates:
+			if update.state != state {
+				t.Fatalf("expected state %v, got %v", state,
+					update.state)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no update for state %v received", state)
+		}
+	}
+
+	// Neither the invoice which never expires, nor the one being paid,
+	// should have been canceled.
+	for _, rHash := range []chainhash.Hash{eternalHash, payingHash} {
+		invoice, err := invoices.LookupInvoice(rHash)
+		if err != nil {
+			t.Fatalf("unable to look up invoice: %v", err)
+		}
+		if invoice.Terms.Canceled {
+			t.Fatalf("invoice %x unexpectedly canceled", rHash[:])
+		}
+	}
+
+	// Canceled invoices should no longer be returned as open.
+	open, err := cdb.FetchAllInvoices(true)
+	if err != nil {
+		t.Fatalf("unable to fetch open invoices: %v", err)
+	}
+	if len(open) != 2 {
+		t.Fatalf("expected 2 open invoices, got %v", len(open))
+	}
+
+	// Once the HTLC paying the expired invoice fails, the invoice should
+	// be canceled when it's checked again.
+	invoices.releaseAcceptedHtlc(payingHash)
+	watcher.cancelExpired(time.Now().Add(acceptedInvoiceRecheckInterval))
+	assertCanceled(payingHash)
+}
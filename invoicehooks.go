@@ -0,0 +1,249 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnrpc"
+)
+
+// defaultInvoiceAcceptTimeout is the default time the acceptance hooks of the
+// invoice registry are given to decide on an HTLC, after which the HTLC is
+// canceled.
+const defaultInvoiceAcceptTimeout = 30 * time.Second
+
+var (
+	// errInvoiceAcceptTimeout is returned when an acceptance hook fails to
+	// decide on an HTLC in time.
+	errInvoiceAcceptTimeout = errors.New("acceptance hook failed to " +
+		"decide in time")
+
+	// errInvoiceAcceptorGone is returned when the client of an RPC
+	// acceptance hook disconnects before deciding on an HTLC.
+	errInvoiceAcceptorGone = errors.New("invoice acceptor disconnected")
+
+	// errInvoiceAcceptAborted is returned when the decision on an HTLC is
+	// no longer awaited, as the link it arrived over has exited.
+	errInvoiceAcceptAborted = errors.New("acceptance of HTLC aborted")
+)
+
+// invoiceAcceptRequest describes an HTLC paying one of our open invoices,
+// which is passed to the acceptance hooks of the invoice registry.
+type invoiceAcceptRequest struct {
+	htlc    *exitHtlc
+	invoice *channeldb.Invoice
+}
+
+// invoiceAcceptHook imposes extra conditions upon the HTLCs paying our open
+// invoices, such as a KYC check, or the availability of the goods sold. A nil
+// error accepts the HTLC, otherwise the error describes why it's rejected.
+// The passed quit channel is closed once the decision is no longer awaited,
+// in which case the hook should return promptly.
+type invoiceAcceptHook func(req *invoiceAcceptRequest,
+	quit <-chan struct{}) error
+
+// RegisterAcceptHook registers the passed hook, which is consulted on each
+// HTLC paying one of our open invoices before it's accepted. The returned
+// function unregisters the hook.
+func (i *invoiceRegistry) RegisterAcceptHook(hook invoiceAcceptHook) func() {
+	i.hookMtx.Lock()
+	id := i.nextHookID
+	i.nextHookID++
+	i.acceptHooks[id] = hook
+	i.hookMtx.Unlock()
+
+	return func() {
+		i.hookMtx.Lock()
+		delete(i.acceptHooks, id)
+		i.hookMtx.Unlock()
+	}
+}
+
+// checkAcceptHooks consults every registered acceptance hook on the passed
+// request concurrently. An error is returned if any of them rejects the HTLC,
+// or fails to decide within the accept timeout, in which case the HTLC is to
+// be canceled. Should the passed quit channel be closed first, the decision is
+// abandoned.
+//
+// NOTE: This blocks until every hook has decided, so it mustn't be called
+// from the htlcManager. Instead, the HTLC is checked within a goroutine of its
+// own, and resolved in a later state transition once the outcome is delivered
+// back to the htlcManager.
+func (i *invoiceRegistry) checkAcceptHooks(req *invoiceAcceptRequest,
+	quit <-chan struct{}) error {
+
+	i.hookMtx.RLock()
+	hooks := make([]invoiceAcceptHook, 0, len(i.acceptHooks))
+	for _, hook := range i.acceptHooks {
+		hooks = append(hooks, hook)
+	}
+	i.hookMtx.RUnlock()
+
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	// Once we return, the decisions of any remaining hooks are no longer
+	// awaited.
+	hookQuit := make(chan struct{})
+	defer close(hookQuit)
+
+	results := make(chan error, len(hooks))
+	for _, hook := range hooks {
+		go func(hook invoiceAcceptHook) {
+			results <- hook(req, hookQuit)
+		}(hook)
+	}
+
+	timeout := time.After(i.acceptTimeout)
+	for range hooks {
+		select {
+		case err := <-results:
+			if err != nil {
+				return err
+			}
+
+		case <-timeout:
+			return errInvoiceAcceptTimeout
+
+		case <-quit:
+			return errInvoiceAcceptAborted
+		}
+	}
+
+	return nil
+}
+
+// invoiceAcceptorStream is the stream over which an RPC acceptance hook
+// exchanges HTLCs and decisions with its client.
+type invoiceAcceptorStream interface {
+	Send(*lnrpc.InvoiceAcceptRequest) error
+	Recv() (*lnrpc.InvoiceAcceptResponse, error)
+}
+
+// serveInvoiceAcceptor registers the client at the other end of the passed
+// stream as an acceptance hook, sending it each HTLC paying one of our open
+// invoices until the stream fails or the passed quit channel is closed.
+// Unlike the htlcModifier, any number of clients may be registered at once,
+// each of which must accept an HTLC for it to be accepted.
+func (i *invoiceRegistry) serveInvoiceAcceptor(stream invoiceAcceptorStream,
+	quit <-chan struct{}) error {
+
+	var (
+		// pending maps the ID of each request sent to the client to
+		// the channel its decision is delivered over.
+		mtx     sync.Mutex
+		nextID  uint64
+		pending = make(map[uint64]chan error)
+
+		requests = make(chan *lnrpc.InvoiceAcceptRequest)
+		done     = make(chan struct{})
+	)
+
+	hook := func(req *invoiceAcceptRequest, hookQuit <-chan struct{}) error {
+		mtx.Lock()
+		id := nextID
+		nextID++
+
+		resp := make(chan error, 1)
+		pending[id] = resp
+		mtx.Unlock()
+
+		defer func() {
+			mtx.Lock()
+			delete(pending, id)
+			mtx.Unlock()
+		}()
+
+		rpcReq := &lnrpc.InvoiceAcceptRequest{
+			RequestId:   id,
+			PaymentHash: req.htlc.paymentHash[:],
+			Memo:        string(req.invoice.Memo),
+			InvoiceAmt:  int64(req.invoice.Terms.Value),
+			HtlcAmt:     int64(req.htlc.htlcAmt),
+			Expiry:      req.htlc.expiry,
+			ChanPoint:   req.htlc.chanPoint.String(),
+			HtlcIndex:   uint64(req.htlc.htlcIndex),
+		}
+
+		select {
+		case requests <- rpcReq:
+		case <-done:
+			return errInvoiceAcceptorGone
+		case <-hookQuit:
+			return errInvoiceAcceptTimeout
+		}
+
+		select {
+		case err := <-resp:
+			return err
+		case <-done:
+			return errInvoiceAcceptorGone
+		case <-hookQuit:
+			return errInvoiceAcceptTimeout
+		}
+	}
+
+	unregister := i.RegisterAcceptHook(hook)
+	invcLog.Infof("Invoice acceptor registered")
+
+	defer func() {
+		unregister()
+		close(done)
+
+		invcLog.Infof("Invoice acceptor unregistered")
+	}()
+
+	// The decisions of the client are read within a goroutine of their
+	// own, so that requests may be sent while waiting for them.
+	errChan := make(chan error, 1)
+	go func() {
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				errChan <- err
+				return
+			}
+
+			mtx.Lock()
+			respChan, ok := pending[resp.RequestId]
+			mtx.Unlock()
+			if !ok {
+				invcLog.Warnf("Invoice acceptor responded to "+
+					"unknown request %v", resp.RequestId)
+				continue
+			}
+
+			var decision error
+			if !resp.Accept {
+				decision = fmt.Errorf("rejected by invoice "+
+					"acceptor: %v", resp.Reason)
+			}
+
+			// Only the first response to each request is
+			// delivered.
+			select {
+			case respChan <- decision:
+			default:
+			}
+		}
+	}()
+
+	for {
+		select {
+		case req := <-requests:
+			if err := stream.Send(req); err != nil {
+				return err
+			}
+
+		case err := <-errChan:
+			return err
+
+		case <-quit:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnrpc"
+)
+
+// mockAcceptorStream is a mock invoiceAcceptorStream, connecting an RPC
+// acceptance hook to a client within the test.
+type mockAcceptorStream struct {
+	requests  chan *lnrpc.InvoiceAcceptRequest
+	responses chan *lnrpc.InvoiceAcceptResponse
+}
+
+func (m *mockAcceptorStream) Send(req *lnrpc.InvoiceAcceptRequest) error {
+	m.requests <- req
+	return nil
+}
+
+func (m *mockAcceptorStream) Recv() (*lnrpc.InvoiceAcceptResponse, error) {
+	resp, ok := <-m.responses
+	if !ok {
+		return nil, errors.New("stream closed")
+	}
+	return resp, nil
+}
+
+// TestInvoiceAcceptHooks asserts that HTLCs are only accepted once every
+// registered acceptance hook accepts them in time, whether the hook is
+// in-process or served over an RPC stream.
+func TestInvoiceAcceptHooks(t *testing.T) {
+	t.Parallel()
+
+	registry := newInvoiceRegistry(nil, 100*time.Millisecond, 0, 0)
+	req := &invoiceAcceptRequest{
+		htlc: &exitHtlc{htlcAmt: 1000},
+		invoice: &channeldb.Invoice{
+			Memo: []byte("coffee"),
+		},
+	}
+
+	check := func() chan error {
+		result := make(chan error, 1)
+		go func() {
+			result <- registry.checkAcceptHooks(req, nil)
+		}()
+		return result
+	}
+
+	// Without any hooks, every HTLC is accepted.
+	if err := registry.checkAcceptHooks(req, nil); err != nil {
+		t.Fatalf("htlc rejected without hooks: %v", err)
+	}
+
+	// An in-process hook may reject the HTLC.
+	errOutOfStock := errors.New("out of stock")
+	unregister := registry.RegisterAcceptHook(
+		func(*invoiceAcceptRequest, <-chan struct{}) error {
+			return errOutOfStock
+		},
+	)
+	if err := registry.checkAcceptHooks(req, nil); err != errOutOfStock {
+		t.Fatalf("expected errOutOfStock, got %v", err)
+	}
+	unregister()
+
+	stream := &mockAcceptorStream{
+		requests:  make(chan *lnrpc.InvoiceAcceptRequest),
+		responses: make(chan *lnrpc.InvoiceAcceptResponse),
+	}
+	quit := make(chan struct{})
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- registry.serveInvoiceAcceptor(stream, quit)
+	}()
+
+	// Wait for the RPC hook to be registered, after which the HTLC should
+	// be sent to the client.
+	for i := 0; ; i++ {
+		registry.hookMtx.RLock()
+		registered := len(registry.acceptHooks) == 1
+		registry.hookMtx.RUnlock()
+		if registered {
+			break
+		}
+
+		if i == 100 {
+			t.Fatalf("acceptor wasn't registered")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	result := check()
+	rpcReq := <-stream.requests
+	if rpcReq.Memo != "coffee" || rpcReq.HtlcAmt != 1000 {
+		t.Fatalf("unexpected request: %v", rpcReq)
+	}
+	stream.responses <- &lnrpc.InvoiceAcceptResponse{
+		RequestId: rpcReq.RequestId,
+		Accept:    true,
+	}
+	select {
+	case err := <-result:
+		if err != nil {
+			t.Fatalf("htlc rejected: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("decision not returned")
+	}
+
+	// An HTLC which isn't decided on in time should be rejected.
+	result = check()
+	<-stream.requests
+	select {
+	case err := <-result:
+		if err != errInvoiceAcceptTimeout {
+			t.Fatalf("expected errInvoiceAcceptTimeout, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("decision didn't time out")
+	}
+
+	// Once the HTLC is no longer awaited, its decision is abandoned.
+	abort := make(chan struct{})
+	result = make(chan error, 1)
+	go func() {
+		result <- registry.checkAcceptHooks(req, abort)
+	}()
+	<-stream.requests
+	close(abort)
+	select {
+	case err := <-result:
+		if err != errInvoiceAcceptAborted {
+			t.Fatalf("expected errInvoiceAcceptAborted, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("decision wasn't abandoned")
+	}
+
+	// Once the stream fails, the hook should be unregistered.
+	close(stream.responses)
+	select {
+	case <-serveErr:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("acceptor wasn't unregistered")
+	}
+	if err := registry.checkAcceptHooks(req, nil); err != nil {
+		t.Fatalf("htlc rejected after acceptor unregistered: %v", err)
+	}
+}
@@ -2,12 +2,14 @@ package main
 
 import (
 	"bytes"
+	"errors"
 	"sync"
 	"time"
 
 	"github.com/btcsuite/fastsha256"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcutil"
 )
@@ -21,6 +23,17 @@ var (
 	debugPre, _ = chainhash.NewHash(bytes.Repeat([]byte{1}, 32))
 
 	debugHash = chainhash.Hash(fastsha256.Sum256(debugPre[:]))
+
+	// ErrTooManyOpenInvoices is returned when attempting to add an invoice
+	// while the maximum number of open invoices has been reached.
+	ErrTooManyOpenInvoices = errors.New("maximum number of open invoices " +
+		"reached")
+
+	// ErrTooManyInvoiceHtlcs is returned when attempting to accept an HTLC
+	// paying an invoice for which the maximum number of accepted HTLCs is
+	// already pending.
+	ErrTooManyInvoiceHtlcs = errors.New("maximum number of pending HTLCs " +
+		"for invoice reached")
 )
 
 // invoiceRegistry is a central registry of all the outstanding invoices
@@ -35,6 +48,40 @@ type invoiceRegistry struct {
 	nextClientID        uint32
 	notificationClients map[uint32]*invoiceSubscription
 
+	// singleInvoiceClients are the clients subscribed to the updates of a
+	// single invoice. They're guarded by the clientMtx.
+	singleInvoiceClients map[uint32]*singleInvoiceSubscription
+
+	// acceptedInvoices maps the payment hashes of the invoices for which
+	// an HTLC has been accepted, but is yet to be settled or failed, to
+	// the number of such HTLCs. It's guarded by the clientMtx.
+	acceptedInvoices map[chainhash.Hash]int
+
+	// numOpenInvoices is the number of invoices which are neither settled
+	// nor canceled. It's loaded from disk the first time an invoice is
+	// added while the number of open invoices is limited, and guarded by
+	// the registry's mutex.
+	numOpenInvoices    int
+	openInvoicesLoaded bool
+
+	// maxOpenInvoices is the maximum number of unsettled invoices which
+	// may exist at once, while maxInvoiceHtlcs is the maximum number of
+	// accepted HTLCs which may be pending for a single invoice. A limit of
+	// zero is disabled.
+	maxOpenInvoices int
+	maxInvoiceHtlcs int
+
+	// acceptHooks are the hooks consulted before accepting an HTLC paying
+	// one of our open invoices, keyed by their ID. They're guarded by the
+	// hookMtx.
+	hookMtx     sync.RWMutex
+	nextHookID  uint64
+	acceptHooks map[uint64]invoiceAcceptHook
+
+	// acceptTimeout is the time the acceptance hooks are given to decide
+	// on an HTLC, after which the HTLC is canceled.
+	acceptTimeout time.Duration
+
 	// debugInvoices is a mp which stores special "debug" invoices which
 	// should be only created/used when manual tests require an invoice
 	// that *all* nodes are able to fully settle.
@@ -44,12 +91,25 @@ type invoiceRegistry struct {
 // newInvoiceRegistry creates a new invoice registry. The invoice registry
 // wraps the persistent on-disk invoice storage with an additional in-memory
 // layer. The in-memory layer is in pace such that debug invoices can be added
-// which are volatile yet available system wide within the daemon.
-func newInvoiceRegistry(cdb *channeldb.DB) *invoiceRegistry {
+// which are volatile yet available system wide within the daemon. The
+// acceptance hooks registered with the registry are given the passed timeout
+// to decide on each HTLC, while the number of open invoices, and of the
+// pending HTLCs of each invoice, are bounded by the passed limits.
+func newInvoiceRegistry(cdb *channeldb.DB, acceptTimeout time.Duration,
+	maxOpenInvoices, maxInvoiceHtlcs int) *invoiceRegistry {
+
 	return &invoiceRegistry{
 		cdb:                 cdb,
 		debugInvoices:       make(map[chainhash.Hash]*channeldb.Invoice),
 		notificationClients: make(map[uint32]*invoiceSubscription),
+		singleInvoiceClients: make(
+			map[uint32]*singleInvoiceSubscription,
+		),
+		acceptedInvoices: make(map[chainhash.Hash]int),
+		maxOpenInvoices:  maxOpenInvoices,
+		maxInvoiceHtlcs:  maxInvoiceHtlcs,
+		acceptHooks:      make(map[uint64]invoiceAcceptHook),
+		acceptTimeout:    acceptTimeout,
 	}
 }
 
@@ -72,7 +132,7 @@ func (i *invoiceRegistry) AddDebugInvoice(amt btcutil.Amount, preimage chainhash
 	i.debugInvoices[paymentHash] = invoice
 	i.Unlock()
 
-	ltndLog.Debugf("Adding debug invoice %v", newLogClosure(func() string {
+	invcLog.Debugf("Adding debug invoice %v", newLogClosure(func() string {
 		return spew.Sdump(invoice)
 	}))
 }
@@ -81,19 +141,59 @@ func (i *invoiceRegistry) AddDebugInvoice(amt btcutil.Amount, preimage chainhash
 // the passed preimage. Additionally, any memo or recipt data provided will
 // also be stored on-disk. Once this invoice is added, sub-systems within the
 // daemon add/forward HTLC's are able to obtain the proper preimage required
-// for redemption in the case that we're the final destination.
+// for redemption in the case that we're the final destination. If the
+// maximum number of open invoices has been reached, ErrTooManyOpenInvoices is
+// returned.
 func (i *invoiceRegistry) AddInvoice(invoice *channeldb.Invoice) error {
-	ltndLog.Debugf("Adding invoice %v", newLogClosure(func() string {
+	invcLog.Debugf("Adding invoice %v", newLogClosure(func() string {
 		return spew.Sdump(invoice)
 	}))
 
+	// The open invoices are counted while holding the registry's lock, so
+	// concurrent additions can't exceed the limit.
+	i.Lock()
+	defer i.Unlock()
+
+	if i.maxOpenInvoices != 0 {
+		if err := i.loadOpenInvoices(); err != nil {
+			return err
+		}
+		if i.numOpenInvoices >= i.maxOpenInvoices {
+			return ErrTooManyOpenInvoices
+		}
+	}
+
 	// TODO(roasbeef): also check in memory for quick lookups/settles?
 	if err := i.cdb.AddInvoice(invoice); err != nil {
 		return err
 	}
 
+	if i.openInvoicesLoaded {
+		i.numOpenInvoices++
+	}
+
 	// TODO(roasbeef): re-enable?
-	//go i.notifyClients(invoice, false)
+	//go i.notifyClients(invoice, lnrpc.InvoiceState_OPEN)
+
+	return nil
+}
+
+// loadOpenInvoices counts the open invoices on disk, unless they've already
+// been counted.
+//
+// NOTE: The registry's mutex MUST be held when calling this method.
+func (i *invoiceRegistry) loadOpenInvoices() error {
+	if i.openInvoicesLoaded {
+		return nil
+	}
+
+	openInvoices, err := i.cdb.FetchAllInvoices(true)
+	if err != nil && err != channeldb.ErrNoInvoicesCreated {
+		return err
+	}
+
+	i.numOpenInvoices = len(openInvoices)
+	i.openInvoicesLoaded = true
 
 	return nil
 }
@@ -120,9 +220,11 @@ func (i *invoiceRegistry) LookupInvoice(rHash chainhash.Hash) (*channeldb.Invoic
 
 // SettleInvoice attempts to mark an invoice as settled. If the invoice is a
 // dbueg invoice, then this method is a nooop as debug invoices are never fully
-// settled.
+// settled. If the invoice has been canceled, then it isn't settled, and
+// channeldb.ErrInvoiceAlreadyCanceled is returned, in which case the HTLCs
+// paying it must be cancelled.
 func (i *invoiceRegistry) SettleInvoice(rHash chainhash.Hash) error {
-	ltndLog.Debugf("Settling invoice %x", rHash[:])
+	invcLog.Debugf("Settling invoice %x", rHash[:])
 
 	// First check the in-memory debug invoice index to see if this is an
 	// existing invoice added for debugging.
@@ -136,38 +238,163 @@ func (i *invoiceRegistry) SettleInvoice(rHash chainhash.Hash) error {
 	}
 	i.RUnlock()
 
+	// The HTLCs accepted for the invoice are being settled, so they're no
+	// longer pending, even if the invoice can't be settled on disk.
+	i.clientMtx.Lock()
+	delete(i.acceptedInvoices, rHash)
+	i.clientMtx.Unlock()
+
 	// If this isn't a debug invoice, then we'll attempt to settle an
-	// invoice matching this rHash on disk (if one exists).
+	// invoice matching this rHash on disk (if one exists). An invoice
+	// which is settled again is only counted as open once.
+	i.Lock()
+	wasOpen := false
+	if i.openInvoicesLoaded {
+		invoice, err := i.cdb.LookupInvoice(rHash)
+		if err != nil {
+			i.Unlock()
+			return err
+		}
+		wasOpen = !invoice.Terms.Settled && !invoice.Terms.Canceled
+	}
 	if err := i.cdb.SettleInvoice(rHash); err != nil {
+		i.Unlock()
 		return err
 	}
+	if wasOpen {
+		i.numOpenInvoices--
+	}
+	i.Unlock()
 
 	// Launch a new goroutine to notify any/all registered invoice
 	// notification clients.
 	go func() {
 		invoice, err := i.cdb.LookupInvoice(rHash)
 		if err != nil {
-			ltndLog.Errorf("unable to find invoice: %v", err)
+			invcLog.Errorf("unable to find invoice: %v", err)
 			return
 		}
 
-		i.notifyClients(invoice, true)
+		i.notifyClients(invoice, lnrpc.InvoiceState_SETTLED)
+
+		i.clientMtx.Lock()
+		i.notifySingleInvoiceClients(rHash, &invoiceUpdate{
+			invoice: invoice,
+			state:   lnrpc.InvoiceState_SETTLED,
+		})
+		i.clientMtx.Unlock()
 	}()
 
 	return nil
 }
 
+// CancelInvoice cancels the open invoice identified by the passed payment hash,
+// after which it can no longer be paid, notifying any clients subscribed to
+// it, or to all invoices.
+func (i *invoiceRegistry) CancelInvoice(rHash chainhash.Hash) error {
+	invcLog.Debugf("Canceling invoice %x", rHash[:])
+
+	i.Lock()
+	if err := i.cdb.CancelInvoice(rHash); err != nil {
+		i.Unlock()
+		return err
+	}
+	if i.openInvoicesLoaded {
+		i.numOpenInvoices--
+	}
+	i.Unlock()
+
+	invoice, err := i.cdb.LookupInvoice(rHash)
+	if err != nil {
+		return err
+	}
+
+	i.notifyClients(invoice, lnrpc.InvoiceState_CANCELED)
+
+	// Any HTLCs accepted for the invoice will be canceled rather than
+	// settled, so they no longer count towards it.
+	i.clientMtx.Lock()
+	delete(i.acceptedInvoices, rHash)
+	i.notifySingleInvoiceClients(rHash, &invoiceUpdate{
+		invoice: invoice,
+		state:   lnrpc.InvoiceState_CANCELED,
+	})
+	i.clientMtx.Unlock()
+
+	return nil
+}
+
+// hasAcceptedHtlcs returns true if an HTLC paying the invoice identified by
+// the passed payment hash has been accepted, but is yet to be settled.
+func (i *invoiceRegistry) hasAcceptedHtlcs(rHash chainhash.Hash) bool {
+	i.clientMtx.Lock()
+	defer i.clientMtx.Unlock()
+
+	return i.acceptedInvoices[rHash] > 0
+}
+
+// AcceptInvoice records that an HTLC paying the passed invoice, identified by
+// its payment hash, has been accepted and is yet to be settled, notifying the
+// clients subscribed to the invoice. If the maximum number of accepted HTLCs
+// is already pending for the invoice, then the HTLC isn't recorded, and
+// ErrTooManyInvoiceHtlcs is returned.
+func (i *invoiceRegistry) AcceptInvoice(rHash chainhash.Hash,
+	invoice *channeldb.Invoice) error {
+
+	i.clientMtx.Lock()
+	defer i.clientMtx.Unlock()
+
+	numHtlcs := i.acceptedInvoices[rHash]
+	if i.maxInvoiceHtlcs != 0 && numHtlcs >= i.maxInvoiceHtlcs {
+		return ErrTooManyInvoiceHtlcs
+	}
+
+	invcLog.Debugf("Accepted HTLC paying invoice %x", rHash[:])
+
+	i.acceptedInvoices[rHash] = numHtlcs + 1
+	i.notifySingleInvoiceClients(rHash, &invoiceUpdate{
+		invoice: invoice,
+		state:   lnrpc.InvoiceState_ACCEPTED,
+	})
+
+	return nil
+}
+
+// releaseAcceptedHtlc records that an accepted HTLC paying the invoice
+// identified by the passed payment hash is no longer pending, as it has been
+// failed rather than settled, so it no longer counts towards the invoice.
+func (i *invoiceRegistry) releaseAcceptedHtlc(rHash chainhash.Hash) {
+	i.clientMtx.Lock()
+	defer i.clientMtx.Unlock()
+
+	numHtlcs, ok := i.acceptedInvoices[rHash]
+	switch {
+	case !ok:
+		return
+	case numHtlcs <= 1:
+		delete(i.acceptedInvoices, rHash)
+	default:
+		i.acceptedInvoices[rHash] = numHtlcs - 1
+	}
+}
+
 // notifyClients notifies all currently registered invoice notification clients
-// of a newly added/settled invoice.
-func (i *invoiceRegistry) notifyClients(invoice *channeldb.Invoice, settle bool) {
+// of a newly added, settled, or canceled invoice, according to the passed
+// state.
+func (i *invoiceRegistry) notifyClients(invoice *channeldb.Invoice,
+	state lnrpc.InvoiceState) {
+
 	i.clientMtx.Lock()
 	defer i.clientMtx.Unlock()
 
 	for _, client := range i.notificationClients {
 		var eventChan chan *channeldb.Invoice
-		if settle {
+		switch state {
+		case lnrpc.InvoiceState_SETTLED:
 			eventChan = client.SettledInvoices
-		} else {
+		case lnrpc.InvoiceState_CANCELED:
+			eventChan = client.CanceledInvoices
+		default:
 			eventChan = client.NewInvoices
 		}
 
@@ -177,14 +404,15 @@ func (i *invoiceRegistry) notifyClients(invoice *channeldb.Invoice, settle bool)
 	}
 }
 
-// invoiceSubscription represents an intent to receive updates for newly added
-// or settled invoices. For each newly added invoice, a copy of the invoice
-// will be sent over the NewInvoices channel. Similarly, for each newly settled
-// invoice, a copy of the invoice will be sent over the SettledInvoices
-// channel.
+// invoiceSubscription represents an intent to receive updates for newly added,
+// settled, or canceled invoices. For each newly added invoice, a copy of the
+// invoice will be sent over the NewInvoices channel. Similarly, for each newly
+// settled or canceled invoice, a copy of the invoice will be sent over the
+// SettledInvoices or CanceledInvoices channel.
 type invoiceSubscription struct {
-	NewInvoices     chan *channeldb.Invoice
-	SettledInvoices chan *channeldb.Invoice
+	NewInvoices      chan *channeldb.Invoice
+	SettledInvoices  chan *channeldb.Invoice
+	CanceledInvoices chan *channeldb.Invoice
 
 	inv *invoiceRegistry
 	id  uint32
@@ -203,9 +431,10 @@ func (i *invoiceSubscription) Cancel() {
 // added.
 func (i *invoiceRegistry) SubscribeNotifications() *invoiceSubscription {
 	client := &invoiceSubscription{
-		NewInvoices:     make(chan *channeldb.Invoice),
-		SettledInvoices: make(chan *channeldb.Invoice),
-		inv:             i,
+		NewInvoices:      make(chan *channeldb.Invoice),
+		SettledInvoices:  make(chan *channeldb.Invoice),
+		CanceledInvoices: make(chan *channeldb.Invoice),
+		inv:              i,
 	}
 
 	i.clientMtx.Lock()
@@ -216,3 +445,147 @@ func (i *invoiceRegistry) SubscribeNotifications() *invoiceSubscription {
 
 	return client
 }
+
+// invoiceUpdate describes the state of a single invoice following an update
+// to it.
+type invoiceUpdate struct {
+	invoice *channeldb.Invoice
+	state   lnrpc.InvoiceState
+}
+
+// notifySingleInvoiceClients hands off the update to the invoice with the
+// passed payment hash to the clients subscribed to it.
+//
+// NOTE: The clientMtx MUST be held when calling this method.
+func (i *invoiceRegistry) notifySingleInvoiceClients(rHash chainhash.Hash,
+	update *invoiceUpdate) {
+
+	for _, client := range i.singleInvoiceClients {
+		if client.rHash == rHash {
+			client.queueUpdate(update)
+		}
+	}
+}
+
+// singleInvoiceSubscription represents an intent to receive the updates to a
+// single invoice, identified by its payment hash. Updates are delivered in the
+// order they occurred.
+type singleInvoiceSubscription struct {
+	Updates chan *invoiceUpdate
+
+	queueMtx   sync.Mutex
+	queue      []*invoiceUpdate
+	settled    bool
+	newUpdates chan struct{}
+
+	rHash chainhash.Hash
+	inv   *invoiceRegistry
+	id    uint32
+	quit  chan struct{}
+}
+
+// queueUpdate adds the update to the subscription's queue, waking up the
+// goroutine delivering updates if needed. As an invoice is only settled once,
+// any further settle updates are dropped.
+func (s *singleInvoiceSubscription) queueUpdate(update *invoiceUpdate) {
+	s.queueMtx.Lock()
+	if s.settled {
+		s.queueMtx.Unlock()
+		return
+	}
+	s.settled = update.state == lnrpc.InvoiceState_SETTLED
+	s.queue = append(s.queue, update)
+	s.queueMtx.Unlock()
+
+	select {
+	case s.newUpdates <- struct{}{}:
+	default:
+	}
+}
+
+// updateDispatcher delivers queued updates to the client in order, without
+// blocking the registry while it accepts or settles invoices.
+//
+// NOTE: This MUST be run as a goroutine.
+func (s *singleInvoiceSubscription) updateDispatcher() {
+	for {
+		select {
+		case <-s.newUpdates:
+		case <-s.quit:
+			return
+		}
+
+		s.queueMtx.Lock()
+		updates := s.queue
+		s.queue = nil
+		s.queueMtx.Unlock()
+
+		for _, update := range updates {
+			select {
+			case s.Updates <- update:
+			case <-s.quit:
+				return
+			}
+		}
+	}
+}
+
+// Cancel unregisters the singleInvoiceSubscription, freeing any previously
+// allocated resources.
+func (s *singleInvoiceSubscription) Cancel() {
+	s.inv.clientMtx.Lock()
+	delete(s.inv.singleInvoiceClients, s.id)
+	s.inv.clientMtx.Unlock()
+
+	close(s.quit)
+}
+
+// SubscribeSingleInvoice returns a singleInvoiceSubscription which allows the
+// caller to receive async notifications of the updates to the invoice with the
+// passed payment hash. The current state of the invoice is delivered first,
+// bringing the caller up to date.
+func (i *invoiceRegistry) SubscribeSingleInvoice(
+	rHash chainhash.Hash) (*singleInvoiceSubscription, error) {
+
+	// The invoice is looked up while holding the clientMtx, so no update
+	// to it can slip by between the lookup and the registration of the
+	// client.
+	i.clientMtx.Lock()
+	defer i.clientMtx.Unlock()
+
+	invoice, err := i.LookupInvoice(rHash)
+	if err != nil {
+		return nil, err
+	}
+
+	state := lnrpc.InvoiceState_OPEN
+	if _, ok := i.acceptedInvoices[rHash]; ok {
+		state = lnrpc.InvoiceState_ACCEPTED
+	}
+	switch {
+	case invoice.Terms.Settled:
+		state = lnrpc.InvoiceState_SETTLED
+	case invoice.Terms.Canceled:
+		state = lnrpc.InvoiceState_CANCELED
+	}
+
+	client := &singleInvoiceSubscription{
+		Updates:    make(chan *invoiceUpdate),
+		newUpdates: make(chan struct{}, 1),
+		rHash:      rHash,
+		inv:        i,
+		quit:       make(chan struct{}),
+	}
+	client.queueUpdate(&invoiceUpdate{
+		invoice: invoice,
+		state:   state,
+	})
+
+	i.singleInvoiceClients[i.nextClientID] = client
+	client.id = i.nextClientID
+	i.nextClientID++
+
+	go client.updateDispatcher()
+
+	return client, nil
+}
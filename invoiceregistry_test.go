@@ -0,0 +1,211 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/fastsha256"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+)
+
+// TestSubscribeSingleInvoice asserts that a client subscribed to a single
+// invoice first receives its current state, followed by the acceptance and
+// settlement of an HTLC paying it.
+func TestSubscribeSingleInvoice(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "singleinvoice")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	cdb, err := channeldb.Open(tempDir)
+	if err != nil {
+		t.Fatalf("unable to open channeldb: %v", err)
+	}
+	defer cdb.Close()
+
+	invoices := newInvoiceRegistry(cdb, defaultInvoiceAcceptTimeout,
+		0, 0)
+
+	preimage := [32]byte{1, 2, 3}
+	invoice := &channeldb.Invoice{
+		Memo:         []byte("single"),
+		CreationDate: time.Now(),
+		Terms: channeldb.ContractTerm{
+			Value:           10000,
+			PaymentPreimage: preimage,
+		},
+	}
+	if err := invoices.AddInvoice(invoice); err != nil {
+		t.Fatalf("unable to add invoice: %v", err)
+	}
+	rHash := chainhash.Hash(fastsha256.Sum256(preimage[:]))
+
+	// Subscribing to an unknown invoice should fail.
+	if _, err := invoices.SubscribeSingleInvoice(chainhash.Hash{}); err == nil {
+		t.Fatalf("expected subscription to unknown invoice to fail")
+	}
+
+	client, err := invoices.SubscribeSingleInvoice(rHash)
+	if err != nil {
+		t.Fatalf("unable to subscribe to invoice: %v", err)
+	}
+	defer client.Cancel()
+
+	assertState := func(client *singleInvoiceSubscription,
+		state lnrpc.InvoiceState) {
+
+		select {
+		case update := <-client.Updates:
+			if update.state != state {
+				t.Fatalf("expected state %v, got %v", state,
+					update.state)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no update for state %v received", state)
+		}
+	}
+
+	assertState(client, lnrpc.InvoiceState_OPEN)
+
+	if err := invoices.AcceptInvoice(rHash, invoice); err != nil {
+		t.Fatalf("unable to accept invoice: %v", err)
+	}
+	assertState(client, lnrpc.InvoiceState_ACCEPTED)
+
+	// A new subscriber should be told the HTLC has already been accepted.
+	lateClient, err := invoices.SubscribeSingleInvoice(rHash)
+	if err != nil {
+		t.Fatalf("unable to subscribe to invoice: %v", err)
+	}
+	assertState(lateClient, lnrpc.InvoiceState_ACCEPTED)
+	lateClient.Cancel()
+
+	if err := invoices.SettleInvoice(rHash); err != nil {
+		t.Fatalf("unable to settle invoice: %v", err)
+	}
+	assertState(client, lnrpc.InvoiceState_SETTLED)
+}
+
+// TestCancelAcceptedInvoice asserts that canceling an invoice for which HTLCs
+// have been accepted no longer counts them towards the invoice, and that the
+// invoice can't be settled afterwards.
+func TestCancelAcceptedInvoice(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "cancelaccepted")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	cdb, err := channeldb.Open(tempDir)
+	if err != nil {
+		t.Fatalf("unable to open channeldb: %v", err)
+	}
+	defer cdb.Close()
+
+	invoices := newInvoiceRegistry(cdb, defaultInvoiceAcceptTimeout,
+		0, 0)
+
+	preimage := [32]byte{4, 5, 6}
+	invoice := &channeldb.Invoice{
+		CreationDate: time.Now(),
+		Terms: channeldb.ContractTerm{
+			Value:           10000,
+			PaymentPreimage: preimage,
+		},
+	}
+	if err := invoices.AddInvoice(invoice); err != nil {
+		t.Fatalf("unable to add invoice: %v", err)
+	}
+	rHash := chainhash.Hash(fastsha256.Sum256(preimage[:]))
+
+	if err := invoices.AcceptInvoice(rHash, invoice); err != nil {
+		t.Fatalf("unable to accept invoice: %v", err)
+	}
+	if !invoices.hasAcceptedHtlcs(rHash) {
+		t.Fatalf("expected accepted htlcs")
+	}
+
+	if err := invoices.CancelInvoice(rHash); err != nil {
+		t.Fatalf("unable to cancel invoice: %v", err)
+	}
+	if invoices.hasAcceptedHtlcs(rHash) {
+		t.Fatalf("accepted htlcs retained after cancel")
+	}
+
+	// The canceled invoice can no longer be settled, so the HTLCs paying
+	// it are to be cancelled.
+	err = invoices.SettleInvoice(rHash)
+	if err != channeldb.ErrInvoiceAlreadyCanceled {
+		t.Fatalf("expected ErrInvoiceAlreadyCanceled, got %v", err)
+	}
+}
+
+// TestInvoiceLimits asserts that invoices beyond the maximum number of open
+// invoices are refused, and that HTLCs beyond the maximum number of pending
+// HTLCs of an invoice aren't accepted.
+func TestInvoiceLimits(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "invoicelimits")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	cdb, err := channeldb.Open(tempDir)
+	if err != nil {
+		t.Fatalf("unable to open channeldb: %v", err)
+	}
+	defer cdb.Close()
+
+	invoices := newInvoiceRegistry(cdb, defaultInvoiceAcceptTimeout, 2, 1)
+
+	newInvoice := func(preimage [32]byte) *channeldb.Invoice {
+		return &channeldb.Invoice{
+			CreationDate: time.Now(),
+			Terms: channeldb.ContractTerm{
+				Value:           10000,
+				PaymentPreimage: preimage,
+			},
+		}
+	}
+
+	first := newInvoice([32]byte{1})
+	if err := invoices.AddInvoice(first); err != nil {
+		t.Fatalf("unable to add invoice: %v", err)
+	}
+	if err := invoices.AddInvoice(newInvoice([32]byte{2})); err != nil {
+		t.Fatalf("unable to add invoice: %v", err)
+	}
+	err = invoices.AddInvoice(newInvoice([32]byte{3}))
+	if err != ErrTooManyOpenInvoices {
+		t.Fatalf("expected ErrTooManyOpenInvoices, got %v", err)
+	}
+
+	// Only a single HTLC paying the first invoice may be pending at once.
+	rHash := chainhash.Hash(fastsha256.Sum256(first.Terms.PaymentPreimage[:]))
+	if err := invoices.AcceptInvoice(rHash, first); err != nil {
+		t.Fatalf("unable to accept invoice: %v", err)
+	}
+	if err := invoices.AcceptInvoice(rHash, first); err != ErrTooManyInvoiceHtlcs {
+		t.Fatalf("expected ErrTooManyInvoiceHtlcs, got %v", err)
+	}
+
+	// Once the pending HTLC has been failed, another may be accepted.
+	invoices.releaseAcceptedHtlc(rHash)
+	if err := invoices.AcceptInvoice(rHash, first); err != nil {
+		t.Fatalf("unable to accept invoice: %v", err)
+	}
+
+	// Once the first invoice is settled, it's no longer open, so another
+	// invoice may be added.
+	if err := invoices.SettleInvoice(rHash); err != nil {
+		t.Fatalf("unable to settle invoice: %v", err)
+	}
+	if err := invoices.AddInvoice(newInvoice([32]byte{3})); err != nil {
+		t.Fatalf("unable to add invoice: %v", err)
+	}
+}
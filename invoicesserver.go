@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+)
+
+// invoicesServer implements the Invoices gRPC service, allowing an external
+// process to take part in the settlement of the HTLCs paying our invoices.
+type invoicesServer struct {
+	server *server
+}
+
+// A compile time check to ensure that invoicesServer fully implements the
+// InvoicesServer gRPC service.
+var _ lnrpc.InvoicesServer = (*invoicesServer)(nil)
+
+// newInvoicesServer creates a new invoicesServer. It's backed by the server
+// once the server is created.
+func newInvoicesServer() *invoicesServer {
+	return &invoicesServer{}
+}
+
+// HtlcModifier registers the caller as the htlc modifier of the daemon,
+// sending it each HTLC arriving for one of our invoices, and applying its
+// responses, until the stream is closed.
+func (i *invoicesServer) HtlcModifier(
+	stream lnrpc.Invoices_HtlcModifierServer) error {
+
+	return i.server.htlcModifier.serve(stream, i.server.quit)
+}
+
+// InvoiceAcceptor registers the caller as an acceptance hook of the invoice
+// registry, sending it each HTLC paying one of our open invoices, which is
+// only accepted if the caller accepts it in time, until the stream is closed.
+func (i *invoicesServer) InvoiceAcceptor(
+	stream lnrpc.Invoices_InvoiceAcceptorServer) error {
+
+	return i.server.invoices.serveInvoiceAcceptor(stream, i.server.quit)
+}
+
+// SubscribeSingleInvoice streams the state of the invoice with the passed
+// payment hash to the caller, followed by each update to it as an HTLC paying
+// it is accepted, and then settled. The stream ends once the invoice is
+// settled.
+func (i *invoicesServer) SubscribeSingleInvoice(req *lnrpc.PaymentHash,
+	updateStream lnrpc.Invoices_SubscribeSingleInvoiceServer) error {
+
+	var (
+		payHash [32]byte
+		rHash   []byte
+		err     error
+	)
+
+	// If the RHash as a raw string was provided, then decode that and use
+	// that directly. Otherwise, we use the raw bytes provided.
+	if req.RHashStr != "" {
+		rHash, err = hex.DecodeString(req.RHashStr)
+		if err != nil {
+			return err
+		}
+	} else {
+		rHash = req.RHash
+	}
+
+	// Ensure that the payment hash is *exactly* 32-bytes.
+	if len(rHash) != 32 {
+		return fmt.Errorf("payment hash must be exactly 32 bytes, "+
+			"is instead %v", len(rHash))
+	}
+	copy(payHash[:], rHash)
+
+	invoiceClient, err := i.server.invoices.SubscribeSingleInvoice(payHash)
+	if err != nil {
+		return err
+	}
+	defer invoiceClient.Cancel()
+
+	for {
+		select {
+		case update := <-invoiceClient.Updates:
+			invoice := &lnrpc.Invoice{
+				Memo:      string(update.invoice.Memo[:]),
+				Receipt:   update.invoice.Receipt[:],
+				RPreimage: update.invoice.Terms.PaymentPreimage[:],
+				RHash:     payHash[:],
+				Value:     int64(update.invoice.Terms.Value),
+				Settled:   update.state == lnrpc.InvoiceState_SETTLED,
+				State:     update.state,
+				Expiry: int64(
+					update.invoice.Expiry / time.Second,
+				),
+			}
+			if err := updateStream.Send(invoice); err != nil {
+				return err
+			}
+
+			// Once settled or canceled, the invoice won't be
+			// updated any further, so there's nothing left to
+			// stream.
+			switch update.state {
+			case lnrpc.InvoiceState_SETTLED,
+				lnrpc.InvoiceState_CANCELED:
+
+				return nil
+			}
+
+		case <-updateStream.Context().Done():
+			return nil
+
+		case <-i.server.quit:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package keychain
+
+import "github.com/roasbeef/btcd/btcec"
+
+const (
+	// BIP0043Purpose is the "purpose" value that we'll use for the first
+	// version of our key derivation scheme. All keys are expected to be
+	// derived from this purpose, then the particular key family.
+	//
+	// Derivation paths within the key ring take the form of:
+	//	m/1017'/keyFamily'/0/index
+	BIP0043Purpose = 1017
+)
+
+// KeyFamily represents a "family" of keys that will be used within various
+// contracts created by lnd. These families are meant to be distinct branches
+// within the HD key chain of the backing wallet. Usage of key families within
+// the interfaces below are strict in order to promote integrability and the
+// ability to restore all keys given a user master seed backup.
+//
+// The key derivation in this file follows the following hierarchy based on
+// BIP43:
+//
+//	m/1017'/keyFamily'/0/index
+type KeyFamily uint32
+
+// NOTE: Families 1, 2 and 4 are reserved for the revocation, HTLC and delay
+// basepoints, which channels don't use yet, as their commitment key stands in
+// for them.
+const (
+	// KeyFamilyMultiSig are keys to be used within multi-sig scripts.
+	KeyFamilyMultiSig KeyFamily = 0
+
+	// KeyFamilyPaymentBase are keys used within channels that will be
+	// combined with per-state randomness to produce public keys that will
+	// be used in scripts that pay directly to us without any delay.
+	KeyFamilyPaymentBase KeyFamily = 3
+
+	// KeyFamilyStaticBackup is the family of the key used to derive the
+	// key which encrypts our static channel backups.
+	KeyFamilyStaticBackup KeyFamily = 5
+
+	// KeyFamilyNodeKey is the family of the key identifying our node
+	// within the network when it's held by a remote signer. Only the
+	// first key of the family is used.
+	KeyFamilyNodeKey KeyFamily = 6
+)
+
+// String returns a human readable name for the key family.
+func (k KeyFamily) String() string {
+	switch k {
+	case KeyFamilyMultiSig:
+		return "multisig"
+	case KeyFamilyPaymentBase:
+		return "payment base"
+	case KeyFamilyStaticBackup:
+		return "static backup"
+	case KeyFamilyNodeKey:
+		return "node key"
+	default:
+		return "unknown"
+	}
+}
+
+// KeyFamilies is the set of all the key families known to the key ring.
+var KeyFamilies = []KeyFamily{
+	KeyFamilyMultiSig,
+	KeyFamilyPaymentBase,
+	KeyFamilyStaticBackup,
+	KeyFamilyNodeKey,
+}
+
+// KeyLocator is a two-tuple that can be used to derive *any* key that has
+// ever been used under the key derivation mechanisms described in this file.
+// Version 0 of our key derivation schema uses the following BIP43-like
+// derivation:
+//
+//	m/1017'/keyFamily'/0/index
+//
+// Our purpose is 1017 (chosen arbitrarily for now), and the key family and
+// index select the particular key.
+type KeyLocator struct {
+	// Family is the family of key being identified.
+	Family KeyFamily
+
+	// Index is the precise index of the key being identified.
+	Index uint32
+}
+
+// KeyDescriptor wraps a KeyLocator and also optionally includes a public key.
+// If the public key is nil, then the KeyLocator alone identifies the key.
+// Otherwise, the public key takes precedence, as the zero KeyLocator is both a
+// valid locator, that of the first multi-sig key, and the locator of keys
+// whose precise locator isn't known. This will be used by the SecretKeyRing
+// interface to lookup arbitrary private keys.
+type KeyDescriptor struct {
+	// KeyLocator is the internal KeyLocator of the descriptor.
+	KeyLocator
+
+	// PubKey is an optional public key that fully describes a target key.
+	PubKey *btcec.PublicKey
+}
+
+// KeyRing is the primary interface that will be used to perform public
+// derivation of various keys used within the peer-to-peer network, and also
+// within any created contracts. All derivation required by the KeyRing is
+// based off of public derivation, so a system with only an extended public
+// key (for the particular purpose+family) can derive this set of keys.
+type KeyRing interface {
+	// DeriveNextKey attempts to derive the *next* key within the key
+	// family (account in BIP43) specified. This method should return the
+	// next external child within this branch.
+	DeriveNextKey(keyFam KeyFamily) (KeyDescriptor, error)
+
+	// DeriveKey attempts to derive an arbitrary key specified by the
+	// passed KeyLocator. This may be used in several recovery scenarios,
+	// or when manually rotating something like our current default node
+	// key.
+	DeriveKey(keyLoc KeyLocator) (KeyDescriptor, error)
+}
+
+// SecretKeyRing is a similar to the regular KeyRing interface, but it is also
+// able to derive *private keys*. As this is a super-set of the regular
+// KeyRing, we also expect the SecretKeyRing to implement the full KeyRing
+// interface. The methods in this struct may be used to extract the node key in
+// order to accept inbound network connections, or to do manual signing for
+// recovery purposes.
+type SecretKeyRing interface {
+	KeyRing
+
+	// DerivePrivKey attempts to derive the private key that corresponds to
+	// the passed key descriptor. If the public key is set, but doesn't
+	// match the key of the KeyLocator, then the key ring will look up the
+	// locator of the public key among the keys it has derived.
+	DerivePrivKey(keyDesc KeyDescriptor) (*btcec.PrivateKey, error)
+
+	// LocateKey returns the locator of the passed public key, which must
+	// have been derived by the key ring.
+	LocateKey(pubKey *btcec.PublicKey) (KeyLocator, error)
+}
@@ -0,0 +1,65 @@
+package keychain
+
+import (
+	"crypto/sha256"
+
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// SingleKeyECDH is a key able to perform an ECDH operation with any public
+// key, without necessarily exposing its private key.
+type SingleKeyECDH interface {
+	// PubKey returns the public key of the key.
+	PubKey() *btcec.PublicKey
+
+	// ECDH performs an ECDH operation between the passed public key and
+	// the key, returning the sha256 of the compressed shared point.
+	ECDH(pubKey *btcec.PublicKey) ([32]byte, error)
+}
+
+// NodeKey is the key identifying our node within the network. Besides the
+// ECDH operations of the transport and onion layers, it signs the gossip
+// messages we announce to the network.
+type NodeKey interface {
+	SingleKeyECDH
+
+	// SignDigest signs the passed 32-byte digest.
+	SignDigest(digest []byte) (*btcec.Signature, error)
+}
+
+// PrivKeyNodeKey is an implementation of the NodeKey interface backed by a
+// private key held in memory.
+type PrivKeyNodeKey struct {
+	// PrivKey is the private key of the node.
+	PrivKey *btcec.PrivateKey
+}
+
+// A compile time check to ensure that PrivKeyNodeKey implements the NodeKey
+// interface.
+var _ NodeKey = (*PrivKeyNodeKey)(nil)
+
+// PubKey returns the public key of the key.
+//
+// NOTE: This is part of the keychain.SingleKeyECDH interface.
+func (p *PrivKeyNodeKey) PubKey() *btcec.PublicKey {
+	return p.PrivKey.PubKey()
+}
+
+// ECDH performs an ECDH operation between the passed public key and the key,
+// returning the sha256 of the compressed shared point.
+//
+// NOTE: This is part of the keychain.SingleKeyECDH interface.
+func (p *PrivKeyNodeKey) ECDH(pubKey *btcec.PublicKey) ([32]byte, error) {
+	s := &btcec.PublicKey{}
+	s.X, s.Y = pubKey.Curve.ScalarMult(pubKey.X, pubKey.Y,
+		p.PrivKey.D.Bytes())
+
+	return sha256.Sum256(s.SerializeCompressed()), nil
+}
+
+// SignDigest signs the passed 32-byte digest.
+//
+// NOTE: This is part of the keychain.NodeKey interface.
+func (p *PrivKeyNodeKey) SignDigest(digest []byte) (*btcec.Signature, error) {
+	return p.PrivKey.Sign(digest)
+}
@@ -0,0 +1,47 @@
+package keychain
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+)
+
+// TestPrivKeyNodeKey ensures that both parties of an ECDH operation performed
+// with a PrivKeyNodeKey arrive at the same shared key, and that its
+// signatures verify under its public key.
+func TestPrivKeyNodeKey(t *testing.T) {
+	t.Parallel()
+
+	alicePriv, _ := btcec.PrivKeyFromBytes(
+		btcec.S256(), bytes.Repeat([]byte{0x01}, 32),
+	)
+	bobPriv, _ := btcec.PrivKeyFromBytes(
+		btcec.S256(), bytes.Repeat([]byte{0x02}, 32),
+	)
+	alice := &PrivKeyNodeKey{PrivKey: alicePriv}
+	bob := &PrivKeyNodeKey{PrivKey: bobPriv}
+
+	aliceShared, err := alice.ECDH(bob.PubKey())
+	if err != nil {
+		t.Fatalf("unable to perform ECDH: %v", err)
+	}
+	bobShared, err := bob.ECDH(alice.PubKey())
+	if err != nil {
+		t.Fatalf("unable to perform ECDH: %v", err)
+	}
+	if aliceShared != bobShared {
+		t.Fatalf("shared keys don't match: %x vs %x", aliceShared,
+			bobShared)
+	}
+
+	digest := chainhash.DoubleHashB([]byte("node announcement"))
+	sig, err := alice.SignDigest(digest)
+	if err != nil {
+		t.Fatalf("unable to sign digest: %v", err)
+	}
+	if !sig.Verify(digest, alice.PubKey()) {
+		t.Fatalf("signature doesn't verify under the node key")
+	}
+}
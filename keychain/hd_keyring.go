@@ -0,0 +1,243 @@
+package keychain
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcutil/hdkeychain"
+)
+
+var (
+	// ErrCannotDerivePrivKey is returned when DerivePrivKey is unable to
+	// derive a private key given only the public key and target key
+	// family.
+	ErrCannotDerivePrivKey = errors.New("unable to derive private key")
+)
+
+// IndexStore persists the number of keys derived within each key family, so
+// that keys are never reused across restarts, along with the locator of each
+// derived key, so that its private key can be found from its public key alone.
+type IndexStore interface {
+	// NextKeyIndex returns the next unused index within the passed key
+	// family, marking it as used.
+	NextKeyIndex(keyFam KeyFamily) (uint32, error)
+
+	// NumKeys returns the number of keys which have been derived within
+	// the passed key family.
+	NumKeys(keyFam KeyFamily) (uint32, error)
+
+	// PutKeyLocator stores the locator of the passed public key.
+	PutKeyLocator(pubKey *btcec.PublicKey, keyLoc KeyLocator) error
+
+	// FetchKeyLocator returns the locator of the passed public key. False
+	// is returned if the locator isn't known.
+	FetchKeyLocator(pubKey *btcec.PublicKey) (KeyLocator, bool, error)
+}
+
+// HDKeyRing is an implementation of both the KeyRing and SecretKeyRing
+// interfaces backed by a root extended private key. All keys are derived
+// deterministically from the root key, so they can be recovered from it alone.
+type HDKeyRing struct {
+	root *hdkeychain.ExtendedKey
+
+	indexes IndexStore
+
+	// branches caches the extended key of the external branch of each key
+	// family: m/1017'/keyFamily'/0.
+	branches map[KeyFamily]*hdkeychain.ExtendedKey
+	mtx      sync.Mutex
+}
+
+// A compile time check to ensure that HDKeyRing implements the SecretKeyRing
+// interface.
+var _ SecretKeyRing = (*HDKeyRing)(nil)
+
+// NewHDKeyRing creates a new key ring deriving all keys from the passed root
+// extended private key. The passed IndexStore tracks the next unused key of
+// each key family.
+func NewHDKeyRing(root *hdkeychain.ExtendedKey, indexes IndexStore) *HDKeyRing {
+	return &HDKeyRing{
+		root:     root,
+		indexes:  indexes,
+		branches: make(map[KeyFamily]*hdkeychain.ExtendedKey),
+	}
+}
+
+// branchKey returns the extended key of the external branch of the passed key
+// family, deriving it if it isn't yet cached.
+func (h *HDKeyRing) branchKey(keyFam KeyFamily) (*hdkeychain.ExtendedKey, error) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	if branch, ok := h.branches[keyFam]; ok {
+		return branch, nil
+	}
+
+	purpose, err := h.root.Child(hdkeychain.HardenedKeyStart + BIP0043Purpose)
+	if err != nil {
+		return nil, err
+	}
+	family, err := purpose.Child(hdkeychain.HardenedKeyStart +
+		uint32(keyFam))
+	if err != nil {
+		return nil, err
+	}
+	branch, err := family.Child(0)
+	if err != nil {
+		return nil, err
+	}
+
+	h.branches[keyFam] = branch
+	return branch, nil
+}
+
+// deriveChild derives the extended key specified by the passed key locator.
+func (h *HDKeyRing) deriveChild(keyLoc KeyLocator) (*hdkeychain.ExtendedKey, error) {
+	branch, err := h.branchKey(keyLoc.Family)
+	if err != nil {
+		return nil, err
+	}
+
+	return branch.Child(keyLoc.Index)
+}
+
+// DeriveNextKey attempts to derive the *next* key within the key family
+// (account in BIP43) specified.
+//
+// NOTE: This is part of the keychain.KeyRing interface.
+func (h *HDKeyRing) DeriveNextKey(keyFam KeyFamily) (KeyDescriptor, error) {
+	index, err := h.indexes.NextKeyIndex(keyFam)
+	if err != nil {
+		return KeyDescriptor{}, err
+	}
+
+	keyDesc, err := h.DeriveKey(KeyLocator{
+		Family: keyFam,
+		Index:  index,
+	})
+	if err != nil {
+		return KeyDescriptor{}, err
+	}
+
+	// The locator of the key is stored, so that its private key can later
+	// be found from the public key alone.
+	err = h.indexes.PutKeyLocator(keyDesc.PubKey, keyDesc.KeyLocator)
+	if err != nil {
+		return KeyDescriptor{}, err
+	}
+
+	return keyDesc, nil
+}
+
+// DeriveKey attempts to derive an arbitrary key specified by the passed
+// KeyLocator.
+//
+// NOTE: This is part of the keychain.KeyRing interface.
+func (h *HDKeyRing) DeriveKey(keyLoc KeyLocator) (KeyDescriptor, error) {
+	child, err := h.deriveChild(keyLoc)
+	if err != nil {
+		return KeyDescriptor{}, err
+	}
+
+	pubKey, err := child.ECPubKey()
+	if err != nil {
+		return KeyDescriptor{}, err
+	}
+
+	return KeyDescriptor{
+		KeyLocator: keyLoc,
+		PubKey:     pubKey,
+	}, nil
+}
+
+// DerivePrivKey attempts to derive the private key that corresponds to the
+// passed key descriptor. If the public key is set, but doesn't match the key
+// of the KeyLocator, then the locator of the public key is looked up among the
+// keys derived so far.
+//
+// NOTE: This is part of the keychain.SecretKeyRing interface.
+func (h *HDKeyRing) DerivePrivKey(keyDesc KeyDescriptor) (*btcec.PrivateKey, error) {
+	// We'll first try the passed locator. As the zero locator is that of
+	// the first multi-sig key, it's tried even if the locator may just be
+	// unknown.
+	privKey, err := h.derivePrivKey(keyDesc.KeyLocator)
+	if err != nil {
+		return nil, err
+	}
+	if keyDesc.PubKey == nil || privKey.PubKey().IsEqual(keyDesc.PubKey) {
+		return privKey, nil
+	}
+
+	// Otherwise, we'll look up the locator of the public key.
+	keyLoc, err := h.LocateKey(keyDesc.PubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.derivePrivKey(keyLoc)
+}
+
+// LocateKey returns the locator of the passed public key, which must have
+// been derived by the key ring.
+//
+// NOTE: This is part of the keychain.SecretKeyRing interface.
+func (h *HDKeyRing) LocateKey(pubKey *btcec.PublicKey) (KeyLocator, error) {
+	keyLoc, ok, err := h.indexes.FetchKeyLocator(pubKey)
+	if err != nil {
+		return KeyLocator{}, err
+	}
+	if ok {
+		return keyLoc, nil
+	}
+
+	// Keys derived before their locators were stored aren't indexed, so
+	// we'll need to scan each key family for them. Once found, the
+	// locator is stored, so that the scan isn't repeated.
+	keyLoc, err = h.scanKeyLocator(pubKey)
+	if err != nil {
+		return KeyLocator{}, err
+	}
+	if err := h.indexes.PutKeyLocator(pubKey, keyLoc); err != nil {
+		return KeyLocator{}, err
+	}
+
+	return keyLoc, nil
+}
+
+// derivePrivKey derives the private key specified by the passed key locator.
+func (h *HDKeyRing) derivePrivKey(keyLoc KeyLocator) (*btcec.PrivateKey, error) {
+	child, err := h.deriveChild(keyLoc)
+	if err != nil {
+		return nil, err
+	}
+
+	return child.ECPrivKey()
+}
+
+// scanKeyLocator scans every key derived so far within each key family for
+// the passed public key, returning its locator.
+func (h *HDKeyRing) scanKeyLocator(pubKey *btcec.PublicKey) (KeyLocator, error) {
+	for _, keyFam := range KeyFamilies {
+		numKeys, err := h.indexes.NumKeys(keyFam)
+		if err != nil {
+			return KeyLocator{}, err
+		}
+
+		for i := uint32(0); i < numKeys; i++ {
+			keyLoc := KeyLocator{
+				Family: keyFam,
+				Index:  i,
+			}
+			keyDesc, err := h.DeriveKey(keyLoc)
+			if err != nil {
+				return KeyLocator{}, err
+			}
+			if keyDesc.PubKey.IsEqual(pubKey) {
+				return keyLoc, nil
+			}
+		}
+	}
+
+	return KeyLocator{}, ErrCannotDerivePrivKey
+}
@@ -0,0 +1,196 @@
+package keychain
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg"
+	"github.com/roasbeef/btcutil/hdkeychain"
+)
+
+var testSeed = bytes.Repeat([]byte{0x01}, 32)
+
+// mockIndexStore is an in-memory implementation of the IndexStore interface.
+type mockIndexStore struct {
+	indexes  map[KeyFamily]uint32
+	locators map[string]KeyLocator
+}
+
+func (m *mockIndexStore) NextKeyIndex(keyFam KeyFamily) (uint32, error) {
+	index := m.indexes[keyFam]
+	m.indexes[keyFam]++
+	return index, nil
+}
+
+func (m *mockIndexStore) NumKeys(keyFam KeyFamily) (uint32, error) {
+	return m.indexes[keyFam], nil
+}
+
+func (m *mockIndexStore) PutKeyLocator(pubKey *btcec.PublicKey,
+	keyLoc KeyLocator) error {
+
+	m.locators[string(pubKey.SerializeCompressed())] = keyLoc
+	return nil
+}
+
+func (m *mockIndexStore) FetchKeyLocator(
+	pubKey *btcec.PublicKey) (KeyLocator, bool, error) {
+
+	keyLoc, ok := m.locators[string(pubKey.SerializeCompressed())]
+	return keyLoc, ok, nil
+}
+
+func newTestKeyRing(t *testing.T) *HDKeyRing {
+	root, err := hdkeychain.NewMaster(testSeed, &chaincfg.RegressionNetParams)
+	if err != nil {
+		t.Fatalf("unable to create root key: %v", err)
+	}
+
+	return NewHDKeyRing(root, &mockIndexStore{
+		indexes:  make(map[KeyFamily]uint32),
+		locators: make(map[string]KeyLocator),
+	})
+}
+
+// TestDeriveNextKey ensures that each key family yields a distinct sequence of
+// keys, and that the keys can be re-derived from their key locators alone.
+func TestDeriveNextKey(t *testing.T) {
+	t.Parallel()
+
+	keyRing := newTestKeyRing(t)
+
+	seen := make(map[string]struct{})
+	for _, keyFam := range KeyFamilies {
+		for i := uint32(0); i < 3; i++ {
+			keyDesc, err := keyRing.DeriveNextKey(keyFam)
+			if err != nil {
+				t.Fatalf("unable to derive next key: %v", err)
+			}
+			if keyDesc.Family != keyFam || keyDesc.Index != i {
+				t.Fatalf("expected locator (%v, %v), got (%v, %v)",
+					keyFam, i, keyDesc.Family, keyDesc.Index)
+			}
+
+			pubKey := string(keyDesc.PubKey.SerializeCompressed())
+			if _, ok := seen[pubKey]; ok {
+				t.Fatalf("key %v reused", keyDesc.KeyLocator)
+			}
+			seen[pubKey] = struct{}{}
+
+			// The key should be reproducible from its locator,
+			// even by a fresh key ring sharing the same root.
+			derived, err := newTestKeyRing(t).DeriveKey(
+				keyDesc.KeyLocator)
+			if err != nil {
+				t.Fatalf("unable to derive key: %v", err)
+			}
+			if !derived.PubKey.IsEqual(keyDesc.PubKey) {
+				t.Fatalf("key %v not reproducible",
+					keyDesc.KeyLocator)
+			}
+		}
+	}
+}
+
+// TestDerivePrivKey ensures that the private key of a derived key can be
+// obtained using either its key locator, or its public key alone.
+func TestDerivePrivKey(t *testing.T) {
+	t.Parallel()
+
+	keyRing := newTestKeyRing(t)
+
+	var keyDescs []KeyDescriptor
+	for _, keyFam := range KeyFamilies {
+		keyDesc, err := keyRing.DeriveNextKey(keyFam)
+		if err != nil {
+			t.Fatalf("unable to derive next key: %v", err)
+		}
+		keyDescs = append(keyDescs, keyDesc)
+	}
+
+	for _, keyDesc := range keyDescs {
+		privKey, err := keyRing.DerivePrivKey(keyDesc)
+		if err != nil {
+			t.Fatalf("unable to derive priv key: %v", err)
+		}
+		if !privKey.PubKey().IsEqual(keyDesc.PubKey) {
+			t.Fatalf("priv key mismatch for %v", keyDesc.KeyLocator)
+		}
+
+		// Without the locator, the key ring should look up the
+		// locator of the key.
+		privKey, err = keyRing.DerivePrivKey(KeyDescriptor{
+			PubKey: keyDesc.PubKey,
+		})
+		if err != nil {
+			t.Fatalf("unable to derive priv key: %v", err)
+		}
+		if !privKey.PubKey().IsEqual(keyDesc.PubKey) {
+			t.Fatalf("priv key mismatch for %v", keyDesc.KeyLocator)
+		}
+	}
+
+	// A key which was never derived by the key ring can't be found.
+	unknownKey, err := newTestKeyRing(t).DeriveKey(KeyLocator{
+		Family: KeyFamilyMultiSig,
+		Index:  100,
+	})
+	if err != nil {
+		t.Fatalf("unable to derive key: %v", err)
+	}
+	_, err = keyRing.DerivePrivKey(KeyDescriptor{PubKey: unknownKey.PubKey})
+	if err != ErrCannotDerivePrivKey {
+		t.Fatalf("expected ErrCannotDerivePrivKey, got %v", err)
+	}
+}
+
+// TestDerivePrivKeyLegacy ensures that the private key of a key derived before
+// the locators of keys were stored is found by scanning the derived keys, and
+// that its locator is stored once found.
+func TestDerivePrivKeyLegacy(t *testing.T) {
+	t.Parallel()
+
+	keyRing := newTestKeyRing(t)
+	indexes := keyRing.indexes.(*mockIndexStore)
+
+	// Derive a few keys within each family without storing their
+	// locators, as the key ring used to.
+	var keyDescs []KeyDescriptor
+	for _, keyFam := range KeyFamilies {
+		for i := 0; i < 3; i++ {
+			index, _ := indexes.NextKeyIndex(keyFam)
+			keyDesc, err := keyRing.DeriveKey(KeyLocator{
+				Family: keyFam,
+				Index:  index,
+			})
+			if err != nil {
+				t.Fatalf("unable to derive key: %v", err)
+			}
+			keyDescs = append(keyDescs, keyDesc)
+		}
+	}
+
+	for _, keyDesc := range keyDescs {
+		privKey, err := keyRing.DerivePrivKey(KeyDescriptor{
+			PubKey: keyDesc.PubKey,
+		})
+		if err != nil {
+			t.Fatalf("unable to derive priv key: %v", err)
+		}
+		if !privKey.PubKey().IsEqual(keyDesc.PubKey) {
+			t.Fatalf("priv key mismatch for %v", keyDesc.KeyLocator)
+		}
+
+		// The first multi-sig key is that of the zero locator, so
+		// it's found without a scan.
+		if keyDesc.KeyLocator == (KeyLocator{}) {
+			continue
+		}
+		keyLoc, ok, _ := indexes.FetchKeyLocator(keyDesc.PubKey)
+		if !ok || keyLoc != keyDesc.KeyLocator {
+			t.Fatalf("expected locator %v to be stored, got %v",
+				keyDesc.KeyLocator, keyLoc)
+		}
+	}
+}
@@ -10,19 +10,28 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"runtime/debug"
 	"strconv"
 	"strings"
+	"time"
 
 	"golang.org/x/net/context"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	proxy "github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/lightningnetwork/lightning-onion"
+	"github.com/lightningnetwork/lnd/blockcache"
 	"github.com/lightningnetwork/lnd/chainntnfs/btcdnotify"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/healthcheck"
+	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwallet/btcwallet"
+	"github.com/lightningnetwork/lnd/lnwallet/remotesigner"
+	"github.com/lightningnetwork/lnd/monitoring"
 
 	"github.com/roasbeef/btcrpcclient"
 )
@@ -32,6 +41,22 @@ var (
 	shutdownChannel = make(chan struct{})
 )
 
+const (
+	// exitCodeError is the exit code of the daemon if it failed to start,
+	// or stopped due to an error.
+	exitCodeError = 1
+
+	// exitCodeForcedShutdown is the exit code of the daemon if its
+	// subsystems didn't stop within the shutdown timeout, in which case it
+	// exits regardless.
+	exitCodeForcedShutdown = 2
+
+	// defaultFallbackFeeRate is the fee rate, in satoshis-per-byte, used
+	// for on-chain transactions when btcd is unable to produce a fee
+	// estimate, such as before it has seen enough blocks.
+	defaultFallbackFeeRate = 50
+)
+
 // lndMain is the true entry point for lnd. This function is required since
 // defers created in the top-level scope of a main method aren't executed if
 // os.Exit() is called.
@@ -43,15 +68,23 @@ func lndMain() error {
 		return err
 	}
 	cfg = loadedConfig
+	defer logRotator.Close()
 	defer backendLog.Flush()
 
 	// Show version at startup.
 	ltndLog.Infof("Version %s", version())
 
-	// Enable http profiling server if requested.
+	// Apply the runtime tuning options.
+	if cfg.GCPercent != 0 {
+		debug.SetGCPercent(cfg.GCPercent)
+	}
+	runtime.SetBlockProfileRate(cfg.BlockProfileRate)
+
+	// Enable http profiling server if requested. As the profiles expose
+	// the internals of the daemon, they're only served on localhost.
 	if cfg.Profile != "" {
 		go func() {
-			listenAddr := net.JoinHostPort("", cfg.Profile)
+			listenAddr := net.JoinHostPort("localhost", cfg.Profile)
 			profileRedirect := http.RedirectHandler("/debug/pprof",
 				http.StatusSeeOther)
 			http.Handle("/", profileRedirect)
@@ -59,9 +92,45 @@ func lndMain() error {
 		}()
 	}
 
+	// Start the gRPC server listening for HTTP/2 connections right away,
+	// allowing callers to follow the startup of the daemon through the
+	// State service. As services can't be registered with a gRPC server
+	// once it's serving, all services are registered now, and bound to
+	// the server once it's created. Until then, the calls of all services
+	// other than the State service are rejected.
+	stateSrv := newStateServer()
+	defer stateSrv.Stop()
+	grpcEndpoint := fmt.Sprintf("localhost:%d", loadedConfig.RPCPort)
+	tlsOpts, proxyOpts, err := rpcTLSOpts()
+	if err != nil {
+		fmt.Printf("unable to load TLS certificate: %v\n", err)
+		return err
+	}
+	unaryMonitor, streamMonitor := monitoring.GetInterceptors(cfg.Prometheus)
+	opts := append([]grpc.ServerOption{
+		grpc.UnaryInterceptor(stateSrv.unaryGate(unaryMonitor)),
+		grpc.StreamInterceptor(stateSrv.streamGate(streamMonitor)),
+	}, tlsOpts...)
+	grpcServer := grpc.NewServer(opts...)
+	lnrpc.RegisterStateServer(grpcServer, stateSrv)
+	services := registerServices(grpcServer)
+	lis, err := net.Listen("tcp", grpcEndpoint)
+	if err != nil {
+		fmt.Printf("failed to listen: %v", err)
+		return err
+	}
+	defer grpcServer.Stop()
+	go func() {
+		rpcsLog.Infof("RPC server listening on %s", lis.Addr())
+		grpcServer.Serve(lis)
+	}()
+
 	// Open the channeldb, which is dedicated to storing channel, and
 	// network related meta-data.
-	chanDB, err := channeldb.Open(cfg.DataDir)
+	chanDB, err := channeldb.Open(
+		cfg.DataDir, channeldb.OptionNoGraphCache(cfg.NoGraphCache),
+		channeldb.OptionTxObserver(monitoring.ObserveDBTx),
+	)
 	if err != nil {
 		fmt.Println("unable to open channeldb: ", err)
 		return err
@@ -72,13 +141,13 @@ func lndMain() error {
 	// specified in the config, then we'll set that directly. Otherwise, we
 	// attempt to read the cert from the path specified in the config.
 	var rpcCert []byte
-	if cfg.RawRPCCert != "" {
-		rpcCert, err = hex.DecodeString(cfg.RawRPCCert)
+	if cfg.Btcd.RawRPCCert != "" {
+		rpcCert, err = hex.DecodeString(cfg.Btcd.RawRPCCert)
 		if err != nil {
 			return err
 		}
 	} else {
-		certFile, err := os.Open(cfg.RPCCert)
+		certFile, err := os.Open(cfg.Btcd.RPCCert)
 		if err != nil {
 			return err
 		}
@@ -95,14 +164,14 @@ func lndMain() error {
 	// specified, then we use that directly. Otherwise, we assume the
 	// default port according to the selected chain parameters.
 	var btcdHost string
-	if strings.Contains(cfg.RPCHost, ":") {
-		btcdHost = cfg.RPCHost
+	if strings.Contains(cfg.Btcd.RPCHost, ":") {
+		btcdHost = cfg.Btcd.RPCHost
 	} else {
-		btcdHost = fmt.Sprintf("%v:%v", cfg.RPCHost, activeNetParams.rpcPort)
+		btcdHost = fmt.Sprintf("%v:%v", cfg.Btcd.RPCHost, activeNetParams.rpcPort)
 	}
 
-	btcdUser := cfg.RPCUser
-	btcdPass := cfg.RPCPass
+	btcdUser := cfg.Btcd.RPCUser
+	btcdPass := cfg.Btcd.RPCPass
 
 	// TODO(roasbeef): parse config here and select chosen notifier instead
 	rpcConfig := &btcrpcclient.ConnConfig{
@@ -115,7 +184,8 @@ func lndMain() error {
 		DisableConnectOnNew:  true,
 		DisableAutoReconnect: false,
 	}
-	notifier, err := btcdnotify.New(rpcConfig)
+	blockCache := blockcache.NewBlockCache(cfg.BlockCacheSize)
+	notifier, err := btcdnotify.NewWithBlockCache(rpcConfig, blockCache)
 	if err != nil {
 		return err
 	}
@@ -125,71 +195,197 @@ func lndMain() error {
 		PrivatePass: []byte("hello"),
 		DataDir:     filepath.Join(cfg.DataDir, "lnwallet"),
 		RpcHost:     btcdHost,
-		RpcUser:     cfg.RPCUser,
-		RpcPass:     cfg.RPCPass,
+		RpcUser:     cfg.Btcd.RPCUser,
+		RpcPass:     cfg.Btcd.RPCPass,
 		CACert:      rpcCert,
 		NetParams:   activeNetParams.Params,
+
+		RecoveryWindow:          cfg.RecoveryWindow,
+		ResetWalletTransactions: cfg.ResetWalletTransactions,
+		BlockCache:              blockCache,
 	}
 	wc, err := btcwallet.New(walletConfig)
 	if err != nil {
 		fmt.Printf("unable to create wallet controller: %v\n", err)
 		return err
 	}
-	signer := wc
-	bio := wc
+	var (
+		walletController lnwallet.WalletController = wc
+		signer           lnwallet.Signer           = wc
+		keyRing          keychain.KeyRing          = wc
+		bio                                        = wc
+	)
+
+	// If a remote signer is configured, then all the keys, including our
+	// node key and those of our on-chain funds, will be derived by, and
+	// all signing operations forwarded to, the remote signer instead of
+	// our local wallet.
+	var remoteSigner *remotesigner.RemoteSigner
+	if cfg.RemoteSigner {
+		remoteSigner, err = remotesigner.New(&remotesigner.Config{
+			RPCHost:         cfg.RemoteSignerHost,
+			TLSCertPath:     cfg.RemoteSignerTLSCertPath,
+			NetParams:       activeNetParams.Params,
+			Timeout:         cfg.RemoteSignerTimeout,
+			AllowedFamilies: cfg.remoteSignerFamilies,
+		})
+		if err != nil {
+			fmt.Printf("unable to connect to remote signer: %v\n", err)
+			return err
+		}
+		defer remoteSigner.Stop()
+
+		ltndLog.Infof("Using remote signer at %v",
+			cfg.RemoteSignerHost)
+
+		walletController = remotesigner.NewWalletController(
+			wc, remoteSigner,
+		)
+		signer = remoteSigner
+		keyRing = remoteSigner
+	}
 
 	// Create, and start the lnwallet, which handles the core payment
 	// channel logic, and exposes control via proxy state machines.
 	wallet, err := lnwallet.NewLightningWallet(chanDB, notifier,
-		wc, signer, bio, activeNetParams.Params)
+		walletController, signer, keyRing, bio, activeNetParams.Params)
 	if err != nil {
 		fmt.Printf("unable to create wallet: %v\n", err)
 		return err
 	}
+	wallet.CoinSelectionStrategy = cfg.coinSelectionStrategy
+	wallet.ChangeAddressType = cfg.changeType
 	if err := wallet.Startup(); err != nil {
 		fmt.Printf("unable to start wallet: %v\n", err)
 		return err
 	}
 	ltndLog.Info("LightningWallet opened")
+	stateSrv.setState(lnrpc.WalletState_UNLOCKED)
+
+	// With the wallet open, we'll create the fee estimator, which queries
+	// btcd for the fee rates required by our on-chain transactions.
+	feeEstimator, err := lnwallet.NewBtcdFeeEstimator(
+		*rpcConfig, defaultFallbackFeeRate,
+	)
+	if err != nil {
+		return err
+	}
+	if err := feeEstimator.Start(); err != nil {
+		return err
+	}
+	defer feeEstimator.Stop()
+
+	// Our node key is the identity key of our wallet, unless it's held by
+	// the remote signer, in which case the onion packets sent to us are
+	// processed by the signer as well.
+	var (
+		nodeKey keychain.NodeKey
+		onion   onionProcessor
+	)
+	if remoteSigner != nil {
+		nodeKey, err = remoteSigner.NodeKey()
+		if err != nil {
+			fmt.Printf("unable to fetch node key: %v\n", err)
+			return err
+		}
+		onion = remoteSigner
+	} else {
+		identityPriv, err := wallet.GetIdentitykey()
+		if err != nil {
+			return err
+		}
+		nodeKey = &keychain.PrivKeyNodeKey{PrivKey: identityPriv}
+
+		// TODO(roasbeef): derive proper onion key based on rotation
+		// schedule
+		onion = sphinx.NewRouter(identityPriv, activeNetParams.Params)
+	}
 
 	// Set up the core server which will listen for incoming peer
 	// connections.
 	defaultListenAddrs := []string{
 		net.JoinHostPort("", strconv.Itoa(cfg.PeerPort)),
 	}
-	server, err := newServer(defaultListenAddrs, notifier, bio, wallet, chanDB)
+	server, err := newServer(defaultListenAddrs, notifier, bio, wallet,
+		feeEstimator, nodeKey, onion, chanDB, services.rpc)
 	if err != nil {
 		srvrLog.Errorf("unable to create server: %v\n", err)
 		return err
 	}
+	services.bind(server)
+
+	// With all services registered, start serving the Prometheus metrics
+	// if requested.
+	err = monitoring.Start(cfg.Prometheus, grpcServer,
+		server.monitoringSources())
+	if err != nil {
+		ltndLog.Errorf("unable to start monitoring: %v", err)
+		return err
+	}
+
 	if err := server.Start(); err != nil {
 		srvrLog.Errorf("unable to create to start server: %v\n", err)
 		return err
 	}
 
+	// Only now that the server is running are the calls of all services
+	// served.
+	stateSrv.setState(lnrpc.WalletState_RPC_ACTIVE)
+
+	// With the server started, the autopilot agent may begin opening
+	// channels on our behalf, if requested.
+	if cfg.Autopilot.Active {
+		if err := server.pilot.Enable(); err != nil {
+			ltndLog.Errorf("unable to start autopilot agent: %v", err)
+			return err
+		}
+	}
+
 	addInterruptHandler(func() {
 		ltndLog.Infof("Gracefully shutting down the server...")
-		server.Stop()
-		server.WaitForShutdown()
-	})
 
-	// Initialize, and register our implementation of the gRPC server.
-	var opts []grpc.ServerOption
-	grpcServer := grpc.NewServer(opts...)
-	lnrpc.RegisterLightningServer(grpcServer, server.rpcServer)
+		// The server is given the shutdown timeout to stop its
+		// subsystems, so that a stuck subsystem can't keep the daemon
+		// from exiting.
+		stopped := make(chan struct{})
+		go func() {
+			server.Stop()
+			server.WaitForShutdown()
+			close(stopped)
+		}()
 
-	// Next, Start the grpc server listening for HTTP/2 connections.
-	grpcEndpoint := fmt.Sprintf("localhost:%d", loadedConfig.RPCPort)
-	lis, err := net.Listen("tcp", grpcEndpoint)
-	if err != nil {
-		fmt.Printf("failed to listen: %v", err)
+		select {
+		case <-stopped:
+		case <-time.After(cfg.ShutdownTimeout):
+			// NOTE: os.Exit skips the deferred close of the
+			// database, as a subsystem may still be stuck within
+			// a transaction, upon which closing it would block.
+			// Each transaction is synced to disk once committed,
+			// and the file lock is released by the OS on exit,
+			// so no committed state is lost.
+			ltndLog.Criticalf("Server didn't shut down within %v, "+
+				"forcing exit", cfg.ShutdownTimeout)
+			backendLog.Flush()
+			logRotator.Close()
+			os.Exit(exitCodeForcedShutdown)
+		}
+	})
+
+	// With the server running, we'll begin monitoring the resources it
+	// depends upon, shutting down should any of them become unavailable.
+	healthMonitor := healthcheck.NewMonitor(&healthcheck.MonitorConfig{
+		Checks: healthChecks(cfg.HealthChecks, bio, rpcCert,
+			remoteSigner),
+		Shutdown: func(format string, params ...interface{}) {
+			ltndLog.Criticalf(format, params...)
+			requestShutdown()
+		},
+	})
+	if err := healthMonitor.Start(); err != nil {
+		ltndLog.Errorf("unable to start health monitor: %v", err)
 		return err
 	}
-	defer lis.Close()
-	go func() {
-		rpcsLog.Infof("RPC server listening on %s", lis.Addr())
-		grpcServer.Serve(lis)
-	}()
+	defer healthMonitor.Stop()
 
 	// Finally, start the REST proxy for our gRPC server above.
 	ctx := context.Background()
@@ -201,7 +397,6 @@ func lndMain() error {
 	mux.Handle("GET", swaggerPattern, func(w http.ResponseWriter, r *http.Request, p map[string]string) {
 		http.ServeFile(w, r, "lnrpc/rpc.swagger.json")
 	})
-	proxyOpts := []grpc.DialOption{grpc.WithInsecure()}
 	err = lnrpc.RegisterLightningHandlerFromEndpoint(ctx, mux, grpcEndpoint, proxyOpts)
 	if err != nil {
 		return err
@@ -211,6 +406,9 @@ func lndMain() error {
 		http.ListenAndServe(":8080", mux)
 	}()
 
+	// With all subsystems running, the daemon is now fully started.
+	stateSrv.setState(lnrpc.WalletState_SERVER_ACTIVE)
+
 	// Wait for shutdown signal from either a graceful server stop or from
 	// the interrupt handler.
 	<-shutdownChannel
@@ -218,6 +416,130 @@ func lndMain() error {
 	return nil
 }
 
+// grpcServices houses the implementations of the gRPC services served along
+// with the State service. They're registered with the gRPC server before the
+// server is created, and bound to it once it is.
+type grpcServices struct {
+	rpc       *rpcServer
+	autopilot *autopilotServer
+	chainKit  *chainKitServer
+	invoices  *invoicesServer
+
+	// bindDev binds the Dev service, which is only served by daemons
+	// built with the dev build tag, to the server.
+	bindDev func(*server)
+}
+
+// registerServices registers all services other than the State service with
+// the passed gRPC server, returning their unbound implementations.
+func registerServices(grpcServer *grpc.Server) *grpcServices {
+	services := &grpcServices{
+		rpc:       newRpcServer(),
+		autopilot: newAutopilotServer(),
+		chainKit:  newChainKitServer(),
+		invoices:  newInvoicesServer(),
+	}
+
+	lnrpc.RegisterLightningServer(grpcServer, services.rpc)
+	lnrpc.RegisterAutopilotServer(grpcServer, services.autopilot)
+	lnrpc.RegisterChainKitServer(grpcServer, services.chainKit)
+	lnrpc.RegisterInvoicesServer(grpcServer, services.invoices)
+	services.bindDev = registerDevServer(grpcServer)
+
+	return services
+}
+
+// bind binds the services to the passed server. The Lightning service is
+// bound by newServer itself.
+//
+// NOTE: This MUST be called before the daemon reaches the RPC_ACTIVE state,
+// as the services are only guarded by the gate of the State service until
+// then.
+func (g *grpcServices) bind(s *server) {
+	g.autopilot.pilot = s.pilot
+	g.chainKit.bio = s.bio
+	g.invoices.server = s
+	g.bindDev(s)
+}
+
+// rpcTLSOpts returns the options of the gRPC server, and those of the REST
+// proxy's connection to it, which enable TLS if the RPC server's certificate
+// is configured.
+func rpcTLSOpts() ([]grpc.ServerOption, []grpc.DialOption, error) {
+	if cfg.TLSCertPath == "" {
+		return nil, []grpc.DialOption{grpc.WithInsecure()}, nil
+	}
+
+	serverCreds, err := credentials.NewServerTLSFromFile(
+		cfg.TLSCertPath, cfg.TLSKeyPath,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	clientCreds, err := credentials.NewClientTLSFromFile(
+		cfg.TLSCertPath, "",
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serverOpts := []grpc.ServerOption{grpc.Creds(serverCreds)}
+	proxyOpts := []grpc.DialOption{
+		grpc.WithTransportCredentials(clientCreds),
+	}
+
+	return serverOpts, proxyOpts, nil
+}
+
+// healthChecks returns the set of enabled health checks of the chain backend,
+// the disk holding the data directory, the chain backend's TLS certificate
+// and, if configured, the remote signer.
+func healthChecks(hcCfg *healthcheck.Config, bio lnwallet.BlockChainIO,
+	rpcCert []byte,
+	remoteSigner *remotesigner.RemoteSigner) []*healthcheck.Observation {
+
+	checks := []*healthcheck.Observation{
+		hcCfg.ChainCheck.NewObservation("chain backend", func() error {
+			_, _, err := bio.GetBestBlock()
+			return err
+		}),
+		hcCfg.DiskCheck.NewObservation("disk space", func() error {
+			free, err := healthcheck.AvailableDiskSpaceRatio(
+				cfg.DataDir)
+			if err != nil {
+				return err
+			}
+
+			required := hcCfg.DiskCheck.RequiredRemaining
+			if free < required {
+				return fmt.Errorf("require %.2f%% free disk "+
+					"space, have %.2f%%", required*100,
+					free*100)
+			}
+
+			return nil
+		}),
+		hcCfg.TLSCheck.NewObservation("tls certificate", func() error {
+			return healthcheck.CheckCertExpiry(rpcCert, time.Now())
+		}),
+	}
+	if remoteSigner != nil {
+		checks = append(checks, hcCfg.RemoteSigner.NewObservation(
+			"remote signer", remoteSigner.Ping,
+		))
+	}
+
+	// Filter out any disabled checks.
+	enabled := checks[:0]
+	for _, check := range checks {
+		if check != nil {
+			enabled = append(enabled, check)
+		}
+	}
+
+	return enabled
+}
+
 func main() {
 	// Use all processor cores.
 	// TODO(roasbeef): remove this if required version # is > 1.6?
@@ -227,6 +549,6 @@ func main() {
 	// be executed in the case of a graceful shutdown.
 	if err := lndMain(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		os.Exit(exitCodeError)
 	}
 }
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"flag"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -31,6 +32,18 @@ import (
 	"google.golang.org/grpc"
 )
 
+var (
+	// flakeHunter, if set, runs the integration tests repeatedly in order
+	// to surface those which fail intermittently.
+	flakeHunter = flag.Bool("run-flake-hunter", false, "run the "+
+		"integration tests repeatedly until one of them fails")
+
+	// flakeHunterIterations is the number of times the integration tests
+	// are run by the flake hunter.
+	flakeHunterIterations = flag.Int("flake-hunter-iterations", 20,
+		"the number of times the flake hunter runs the integration tests")
+)
+
 // harnessTest wraps a regular testing.T providing enhanced error detection
 // and propagation. All error will be augmented with a full stack-trace in
 // order to aide in debugging. Additionally, any panics caused by active
@@ -41,24 +54,34 @@ type harnessTest struct {
 	// testCase is populated during test execution and represents the
 	// current test case.
 	testCase *testCase
+
+	// iteration is the flake hunter iteration the test case is run
+	// within, starting from one. It's zero outside of flake hunter mode.
+	iteration int
 }
 
 // newHarnessTest creates a new instance of a harnessTest from a regular
 // testing.T instance.
 func newHarnessTest(t *testing.T) *harnessTest {
-	return &harnessTest{t, nil}
+	return &harnessTest{t: t}
 }
 
 // Fatalf causes the current active test-case to fail with a fatal error. All
 // integration tests should mark test failures soley with this method due to
-// the error stack traces it produces.
+// the error stack traces it produces. Within flake hunter mode, the iteration
+// the failure occurred within is reported along with it.
 func (h *harnessTest) Fatalf(format string, a ...interface{}) {
 	stacktrace := errors.Wrap(fmt.Sprintf(format, a...), 1).ErrorStack()
 
-	if h.testCase != nil {
+	switch {
+	case h.testCase != nil && h.iteration != 0:
+		h.t.Fatalf("Failed: (%v), flake hunter iteration %v: exited "+
+			"with error: \n%v", h.testCase.name, h.iteration,
+			stacktrace)
+	case h.testCase != nil:
 		h.t.Fatalf("Failed: (%v): exited with error: \n"+
 			"%v", h.testCase.name, stacktrace)
-	} else {
+	default:
 		h.t.Fatalf("Error outside of test: %v", stacktrace)
 	}
 }
@@ -74,6 +97,12 @@ func (h *harnessTest) RunTestCase(testCase *testCase, net *networkHarness) {
 	defer func() {
 		if err := recover(); err != nil {
 			description := errors.Wrap(err, 2).ErrorStack()
+			if h.iteration != 0 {
+				h.t.Fatalf("Failed: (%v), flake hunter "+
+					"iteration %v, paniced with: \n%v",
+					h.testCase.name, h.iteration,
+					description)
+			}
 			h.t.Fatalf("Failed: (%v) paniced with: \n%v",
 				h.testCase.name, description)
 		}
@@ -194,9 +223,209 @@ func closeChannelAndAssert(t *harnessTest, net *networkHarness, ctx context.Cont
 
 	assertTxInBlock(t, block, closingTxid)
 
+	// The channel should no longer be listed among the active channels of
+	// the node which closed it.
+	chanPointTxid, err := chainhash.NewHash(fundingChanPoint.FundingTxid)
+	if err != nil {
+		t.Fatalf("unable to create sha hash: %v", err)
+	}
+	chanPoint := wire.OutPoint{
+		Hash:  *chanPointTxid,
+		Index: fundingChanPoint.OutputIndex,
+	}
+	if err := net.AssertChannelClosed(ctx, node, &chanPoint); err != nil {
+		t.Fatalf("unable to assert channel closure: %v", err)
+	}
+
 	return closingTxid
 }
 
+// forceCloseChannelAndAssert force closes the channel identified by the passed
+// funding outpoint from the passed node, asserting the closing transaction is
+// mined as with closeChannelAndAssert. Additionally, it asserts that the
+// channel is then reported as closing by the node, with the funds of its
+// time-locked outputs in limbo. The txid of the closing transaction is
+// returned.
+func forceCloseChannelAndAssert(t *harnessTest, net *networkHarness,
+	ctx context.Context, node *lightningNode,
+	fundingChanPoint *lnrpc.ChannelPoint) *chainhash.Hash {
+
+	closingTxid := closeChannelAndAssert(t, net, ctx, node,
+		fundingChanPoint, true)
+	assertChannelClosing(t, ctx, node, closingTxid)
+
+	return closingTxid
+}
+
+// assertChannelClosing asserts that the passed node reports the channel force
+// closed by the passed closing transaction as closing, with funds in limbo
+// awaiting the maturity of its time-locked outputs.
+func assertChannelClosing(t *harnessTest, ctx context.Context,
+	node *lightningNode, closingTxid *chainhash.Hash) {
+
+	req := &lnrpc.PendingChannelRequest{
+		Status: lnrpc.ChannelStatus_CLOSING,
+	}
+	resp, err := node.PendingChannels(ctx, req)
+	if err != nil {
+		t.Fatalf("unable to query for pending channels: %v", err)
+	}
+
+	for _, pendingChan := range resp.PendingChannels {
+		if pendingChan.ClosingTxid != closingTxid.String() {
+			continue
+		}
+
+		if pendingChan.LimboBalance == 0 {
+			t.Fatalf("closing channel %v has no funds in limbo",
+				pendingChan.ChannelPoint)
+		}
+		return
+	}
+
+	t.Fatalf("channel closed by %v not found among closing channels: %v",
+		closingTxid, spew.Sdump(resp.PendingChannels))
+}
+
+// assertSweepAndMine asserts that the passed node has broadcast a transaction
+// sweeping the time-locked outputs of the passed closing transaction into its
+// wallet, then mines a block asserting the sweep transaction is included
+// within it. Finally, the sweep is asserted to be listed among the sweeps of
+// the node. The swept outputs should have matured prior to calling this
+// function.
+func assertSweepAndMine(t *harnessTest, net *networkHarness,
+	ctx context.Context, node *lightningNode,
+	closingTxid *chainhash.Hash) *chainhash.Hash {
+
+	// There should be exactly one transaction within the mempool, the
+	// sweep transaction.
+	// TODO(roasbeef): assertion may not necessarily hold with concurrent
+	// test executions
+	mempool, err := net.WaitForMempoolTxns(ctx, 1)
+	if err != nil {
+		t.Fatalf("sweep tx not found in mempool: %v", err)
+	}
+	sweepTxid := mempool[0]
+
+	// All inputs of the sweep transaction should be spending from the
+	// closing transaction which was broadcast on-chain.
+	sweepTx, err := net.Miner.Node.GetRawTransaction(sweepTxid)
+	if err != nil {
+		t.Fatalf("unable to fetch sweep tx: %v", err)
+	}
+	for _, txIn := range sweepTx.MsgTx().TxIn {
+		if !closingTxid.IsEqual(&txIn.PreviousOutPoint.Hash) {
+			t.Fatalf("sweep transaction not spending from closing "+
+				"tx %v, instead spending %v", closingTxid,
+				txIn.PreviousOutPoint)
+		}
+	}
+
+	// The sweep transaction should be included within the next block as
+	// the input scripts and the sequence locks on the inputs should be
+	// properly met.
+	block := mineBlocks(t, net, 1)[0]
+	assertTxInBlock(t, block, sweepTxid)
+
+	sweepsResp, err := node.ListSweeps(ctx, &lnrpc.ListSweepsRequest{})
+	if err != nil {
+		t.Fatalf("unable to list sweeps: %v", err)
+	}
+	for _, sweep := range sweepsResp.Sweeps {
+		if sweep.Txid != sweepTxid.String() {
+			continue
+		}
+
+		if len(sweep.Inputs) != len(sweepTx.MsgTx().TxIn) {
+			t.Fatalf("sweep %v lists %v inputs, expected %v",
+				sweepTxid, len(sweep.Inputs),
+				len(sweepTx.MsgTx().TxIn))
+		}
+		return sweepTxid
+	}
+
+	t.Fatalf("sweep tx %v not found among node's sweeps: %v", sweepTxid,
+		spew.Sdump(sweepsResp.Sweeps))
+	return nil
+}
+
+// payInvoiceAndAssert creates an invoice of the passed amount at the
+// receiving node, pays it from the sending node, then asserts that the
+// invoice has been settled. The payment hash of the invoice is returned.
+func payInvoiceAndAssert(t *harnessTest, net *networkHarness,
+	ctx context.Context, sender, receiver *lightningNode,
+	amt btcutil.Amount) []byte {
+
+	invoice := &lnrpc.Invoice{
+		Memo:  "harness payment",
+		Value: int64(amt),
+	}
+	invoiceResp, err := receiver.AddInvoice(ctx, invoice)
+	if err != nil {
+		t.Fatalf("unable to add invoice: %v", err)
+	}
+
+	sendStream, err := sender.SendPayment(ctx)
+	if err != nil {
+		t.Fatalf("unable to create payment stream: %v", err)
+	}
+	sendReq := &lnrpc.SendRequest{
+		PaymentHash: invoiceResp.RHash,
+		Dest:        receiver.PubKey[:],
+		Amt:         int64(amt),
+	}
+	if err := sendStream.Send(sendReq); err != nil {
+		t.Fatalf("unable to send payment: %v", err)
+	}
+	resp, err := sendStream.Recv()
+	if err != nil {
+		t.Fatalf("error when attempting recv: %v", err)
+	}
+	if resp.PaymentError != "" {
+		t.Fatalf("payment failed: %v", resp.PaymentError)
+	}
+
+	payHash := &lnrpc.PaymentHash{
+		RHash: invoiceResp.RHash,
+	}
+	dbInvoice, err := receiver.LookupInvoice(ctx, payHash)
+	if err != nil {
+		t.Fatalf("unable to lookup invoice: %v", err)
+	}
+	if !dbInvoice.Settled {
+		t.Fatalf("invoice should be marked as settled: %v",
+			spew.Sdump(dbInvoice))
+	}
+
+	return invoiceResp.RHash
+}
+
+// assertPaymentFailed asserts that the next response received over the passed
+// payment stream reports the payment as having failed for the passed reason,
+// with an error containing the passed substring.
+func assertPaymentFailed(t *harnessTest,
+	payStream lnrpc.Lightning_SendPaymentClient,
+	reason lnrpc.PaymentFailureReason, errSubstr string) {
+
+	resp, err := payStream.Recv()
+	if err != nil {
+		t.Fatalf("error when attempting recv: %v", err)
+	}
+
+	if resp.PaymentError == "" {
+		t.Fatalf("payment should have failed with %v", reason)
+	}
+	if resp.FailureReason != reason {
+		t.Fatalf("payment should have failed with %v, instead failed "+
+			"with %v: %v", reason, resp.FailureReason,
+			resp.PaymentError)
+	}
+	if !strings.Contains(resp.PaymentError, errSubstr) {
+		t.Fatalf("payment error should contain %q, instead: %v",
+			errSubstr, resp.PaymentError)
+	}
+}
+
 // testBasicChannelFunding performs a test exercising expected behavior from a
 // basic funding workflow. The test creates a new channel between Alice and
 // Bob, then immediately closes the channel after asserting some expected post
@@ -374,6 +603,12 @@ func testChannelForceClosure(net *networkHarness, t *harnessTest) {
 		t.Fatalf("Node restart failed: %v", err)
 	}
 
+	// With the commitment transaction confirmed, Alice should report the
+	// channel as closing, her balance being in limbo until her
+	// time-locked output matures.
+	ctxt, _ = context.WithTimeout(ctxb, timeout)
+	assertChannelClosing(t, ctxt, net.Alice, closingTxID)
+
 	// Currently within the codebase, the default CSV is 4 relative blocks.
 	// For the persistence test, we generate three blocks, then trigger
 	// a restart and then generate the final block that should trigger
@@ -396,66 +631,10 @@ func testChannelForceClosure(net *networkHarness, t *harnessTest) {
 		t.Fatalf("unable to mine blocks: %v", err)
 	}
 
-	// At this point, the sweeping transaction should now be broadcast. So
-	// we fetch the node's mempool to ensure it has been properly
-	// broadcast.
-	var sweepingTXID *chainhash.Hash
-	var mempool []*chainhash.Hash
-	mempoolTimeout := time.After(3 * time.Second)
-	checkMempoolTick := time.Tick(100 * time.Millisecond)
-mempoolPoll:
-	for {
-		select {
-		case <-mempoolTimeout:
-			t.Fatalf("sweep tx not found in mempool")
-		case <-checkMempoolTick:
-			mempool, err = net.Miner.Node.GetRawMempool()
-			if err != nil {
-				t.Fatalf("unable to fetch node's mempool: %v", err)
-			}
-			if len(mempool) != 0 {
-				break mempoolPoll
-			}
-		}
-	}
-
-	// There should be exactly one transaction within the mempool at this
-	// point.
-	// TODO(roasbeef): assertion may not necessarily hold with concurrent
-	// test executions
-	if len(mempool) != 1 {
-		t.Fatalf("node's mempool is wrong size, expected 1 got %v",
-			len(mempool))
-	}
-	sweepingTXID = mempool[0]
-
-	// Fetch the sweep transaction, all input it's spending should be from
-	// the commitment transaction which was broadcast on-chain.
-	sweepTx, err := net.Miner.Node.GetRawTransaction(sweepingTXID)
-	if err != nil {
-		t.Fatalf("unable to fetch sweep tx: %v", err)
-	}
-	for _, txIn := range sweepTx.MsgTx().TxIn {
-		if !closingTxID.IsEqual(&txIn.PreviousOutPoint.Hash) {
-			t.Fatalf("sweep transaction not spending from commit "+
-				"tx %v, instead spending %v",
-				closingTxID, txIn.PreviousOutPoint)
-		}
-	}
-
-	// Finally, we mine an additional block which should include the sweep
-	// transaction as the input scripts and the sequence locks on the
-	// inputs should be properly met.
-	blockHash, err := net.Miner.Node.Generate(1)
-	if err != nil {
-		t.Fatalf("unable to generate block: %v", err)
-	}
-	block, err := net.Miner.Node.GetBlock(blockHash[0])
-	if err != nil {
-		t.Fatalf("unable to get block: %v", err)
-	}
-
-	assertTxInBlock(t, block, sweepTx.Hash())
+	// At this point, the sweeping transaction should now be broadcast,
+	// and once mined, be listed among Alice's sweeps.
+	ctxt, _ = context.WithTimeout(ctxb, 3*time.Second)
+	assertSweepAndMine(t, net, ctxt, net.Alice, closingTxID)
 }
 
 func testSingleHopInvoice(net *networkHarness, t *harnessTest) {
@@ -1492,8 +1671,6 @@ func testHtlcErrorPropagation(net *networkHarness, t *harnessTest) {
 		t.Fatalf("unable to generate carol invoice: %v", err)
 	}
 
-	// TODO(roasbeef): return failure response rather than failing entire
-	// stream on payment error.
 	alicePayStream, err := net.Alice.SendPayment(ctxb)
 	if err != nil {
 		t.Fatalf("unable to create payment stream: %v", err)
@@ -1510,29 +1687,16 @@ func testHtlcErrorPropagation(net *networkHarness, t *harnessTest) {
 		t.Fatalf("unable to send payment: %v", err)
 	}
 
-	// The payment should've resulted in an error since we went it with the
-	// wrong payment hash.
-	_, err = alicePayStream.Recv()
-	if err == nil {
-		t.Fatalf("payment should have been rejected due to invalid " +
-			"payment hash")
-	} else if !strings.Contains(err.Error(), "preimage") {
-		// TODO(roasbeef): make into proper gRPC error code
-		t.Fatalf("payment should have failed due to unknown preimage, "+
-			"instead failed due to : %v", err)
-	}
+	// The payment should've failed since we went it with the wrong
+	// payment hash, Carol rejecting the payment details.
+	assertPaymentFailed(t, alicePayStream,
+		lnrpc.PaymentFailureReason_FAILURE_REASON_INCORRECT_PAYMENT_DETAILS,
+		"preimage")
 
 	// The balances of all parties should be the same as initially since
 	// the HTLC was cancelled.
 	assertBaseBalance()
 
-	// We need to create another payment stream since the first one was
-	// closed due to an error.
-	alicePayStream, err = net.Alice.SendPayment(ctxb)
-	if err != nil {
-		t.Fatalf("unable to create payment stream: %v", err)
-	}
-
 	// Next, we'll test the case of a recognized payHash but, an incorrect
 	// value on the extended HTLC.
 	sendReq = &lnrpc.SendRequest{
@@ -1544,16 +1708,11 @@ func testHtlcErrorPropagation(net *networkHarness, t *harnessTest) {
 		t.Fatalf("unable to send payment: %v", err)
 	}
 
-	// The payment should fail with an error since we sent 1k satoshis
-	// isn't of 10k as was requested.
-	_, err = alicePayStream.Recv()
-	if err == nil {
-		t.Fatalf("payment should have been rejected due to wrong " +
-			"HTLC amount")
-	} else if !strings.Contains(err.Error(), "htlc value") {
-		t.Fatalf("payment should have failed due to unknown preimage, "+
-			"instead failed due to : %v", err)
-	}
+	// The payment should fail since we sent 1k satoshis isn't of 10k as
+	// was requested.
+	assertPaymentFailed(t, alicePayStream,
+		lnrpc.PaymentFailureReason_FAILURE_REASON_INCORRECT_PAYMENT_DETAILS,
+		"htlc value")
 
 	// The balances of all parties should be the same as initially since
 	// the HTLC was cancelled.
@@ -1604,14 +1763,8 @@ func testHtlcErrorPropagation(net *networkHarness, t *harnessTest) {
 	}); err != nil {
 		t.Fatalf("unable to send payment: %v", err)
 	}
-	_, err = alicePayStream.Recv()
-	if err == nil {
-		t.Fatalf("payment should fail due to insufficient "+
-			"capacity: %v", err)
-	} else if !strings.Contains(err.Error(), "capacity") {
-		t.Fatalf("payment should fail due to insufficient capacity, "+
-			"instead: %v", err)
-	}
+	assertPaymentFailed(t, alicePayStream,
+		lnrpc.PaymentFailureReason_FAILURE_REASON_ERROR, "capacity")
 
 	// For our final test, we'll ensure that if a target link isn't
 	// available for what ever reason then the payment fails accordingly.
@@ -1632,13 +1785,8 @@ func testHtlcErrorPropagation(net *networkHarness, t *harnessTest) {
 	}); err != nil {
 		t.Fatalf("unable to send payment: %v", err)
 	}
-	_, err = alicePayStream.Recv()
-	if err == nil {
-		t.Fatalf("payment should have failed")
-	} else if !strings.Contains(err.Error(), "hop unknown") {
-		t.Fatalf("payment should fail due to unknown hop, instead: %v",
-			err)
-	}
+	assertPaymentFailed(t, alicePayStream,
+		lnrpc.PaymentFailureReason_FAILURE_REASON_ERROR, "hop unknown")
 
 	// Finally, immediately close the channel. This function will also
 	// block until the channel is closed and will additionally assert the
@@ -1650,12 +1798,162 @@ func testHtlcErrorPropagation(net *networkHarness, t *harnessTest) {
 	// trigger a sweep of the funds by the utxoNursery.
 	// TODO(roasbeef): use config value for default CSV here.
 	ctxt, _ = context.WithTimeout(ctxb, timeout)
-	closeChannelAndAssert(t, net, ctxt, net.Bob, chanPointBob, true)
+	forceCloseChannelAndAssert(t, net, ctxt, net.Bob, chanPointBob)
 	if _, err := net.Miner.Node.Generate(5); err != nil {
 		t.Fatalf("unable to generate blocks: %v", err)
 	}
 }
 
+// testPaymentRoundTrip opens a channel between Alice and Bob, pays an invoice
+// of Bob's, then, once Bob has a balance within the channel, pays an invoice
+// of Alice's in the opposite direction, before closing the channel.
+func testPaymentRoundTrip(net *networkHarness, t *harnessTest) {
+	ctxb := context.Background()
+	timeout := time.Duration(time.Second * 5)
+
+	chanAmt := btcutil.Amount(100000)
+	ctxt, _ := context.WithTimeout(ctxb, timeout)
+	chanPoint := openChannelAndAssert(t, net, ctxt, net.Alice, net.Bob,
+		chanAmt, 0)
+
+	const paymentAmt = 10000
+	ctxt, _ = context.WithTimeout(ctxb, timeout)
+	payInvoiceAndAssert(t, net, ctxt, net.Alice, net.Bob, paymentAmt)
+	ctxt, _ = context.WithTimeout(ctxb, timeout)
+	payInvoiceAndAssert(t, net, ctxt, net.Bob, net.Alice, paymentAmt/2)
+
+	// With both payments settled, Alice and Bob should agree upon the
+	// balances of the channel, Alice, its initiator, paying the
+	// commitment fee out of hers.
+	balReq := &lnrpc.GetChannelBalanceRequest{
+		ChannelPoint: chanPoint,
+	}
+	ctxt, _ = context.WithTimeout(ctxb, timeout)
+	aliceBal, err := net.Alice.GetChannelBalance(ctxt, balReq)
+	if err != nil {
+		t.Fatalf("unable to get alice's channel balance: %v", err)
+	}
+	ctxt, _ = context.WithTimeout(ctxb, timeout)
+	bobBal, err := net.Bob.GetChannelBalance(ctxt, balReq)
+	if err != nil {
+		t.Fatalf("unable to get bob's channel balance: %v", err)
+	}
+	if aliceBal.Capacity != int64(chanAmt) {
+		t.Fatalf("alice's capacity is incorrect: expected %v got %v",
+			int64(chanAmt), aliceBal.Capacity)
+	}
+	if aliceBal.LocalBalance+aliceBal.CommitFee !=
+		int64(chanAmt)-paymentAmt/2 {
+
+		t.Fatalf("alice's balance is incorrect: expected %v got %v",
+			int64(chanAmt)-paymentAmt/2-aliceBal.CommitFee,
+			aliceBal.LocalBalance)
+	}
+	if bobBal.LocalBalance != paymentAmt/2 {
+		t.Fatalf("bob's balance is incorrect: expected %v got %v",
+			paymentAmt/2, bobBal.LocalBalance)
+	}
+	if aliceBal.LocalBalance != bobBal.RemoteBalance ||
+		aliceBal.RemoteBalance != bobBal.LocalBalance {
+
+		t.Fatalf("balances of alice and bob don't match: %v vs %v",
+			spew.Sdump(aliceBal), spew.Sdump(bobBal))
+	}
+	if aliceBal.PendingOutgoing != 0 || aliceBal.PendingIncoming != 0 {
+		t.Fatalf("alice has pending htlcs: %v", spew.Sdump(aliceBal))
+	}
+
+	ctxt, _ = context.WithTimeout(ctxb, timeout)
+	closeChannelAndAssert(t, net, ctxt, net.Alice, chanPoint, false)
+}
+
+// testTrackPayment asserts that the outcome of a payment, whether settled or
+// rejected by its destination, is reported along with the reason of its
+// failure both to its sender, and when tracking the payment.
+func testTrackPayment(net *networkHarness, t *harnessTest) {
+	ctxb := context.Background()
+	timeout := time.Duration(time.Second * 5)
+
+	chanAmt := btcutil.Amount(100000)
+	ctxt, _ := context.WithTimeout(ctxb, timeout)
+	chanPoint := openChannelAndAssert(t, net, ctxt, net.Alice, net.Bob,
+		chanAmt, 0)
+
+	// trackPayment tracks the payment with the passed hash until it
+	// completes, asserting that it completed with the passed status and
+	// failure reason.
+	trackPayment := func(payHash []byte, status lnrpc.PaymentStatus,
+		reason lnrpc.PaymentFailureReason) {
+
+		ctxt, _ := context.WithTimeout(ctxb, timeout)
+		req := &lnrpc.TrackPaymentRequest{
+			PaymentHash: payHash,
+		}
+		trackStream, err := net.Alice.TrackPayment(ctxt, req)
+		if err != nil {
+			t.Fatalf("unable to track payment: %v", err)
+		}
+
+		// As the payment has already completed, its final state
+		// should be sent straight away, after which the stream ends.
+		payment, err := trackStream.Recv()
+		if err != nil {
+			t.Fatalf("unable to receive payment update: %v", err)
+		}
+		if payment.PaymentHash != hex.EncodeToString(payHash) {
+			t.Fatalf("payment hash mismatch: expected %x got %v",
+				payHash, payment.PaymentHash)
+		}
+		if payment.Status != status {
+			t.Fatalf("payment status mismatch: expected %v got %v",
+				status, payment.Status)
+		}
+		if payment.FailureReason != reason {
+			t.Fatalf("payment failure reason mismatch: expected "+
+				"%v got %v", reason, payment.FailureReason)
+		}
+		if _, err := trackStream.Recv(); err != io.EOF {
+			t.Fatalf("stream should have ended once the payment "+
+				"completed, instead: %v", err)
+		}
+	}
+
+	// First, Alice pays an invoice of Bob's, which should be tracked as
+	// having succeeded.
+	const paymentAmt = 1000
+	ctxt, _ = context.WithTimeout(ctxb, timeout)
+	payHash := payInvoiceAndAssert(t, net, ctxt, net.Alice, net.Bob,
+		paymentAmt)
+	trackPayment(payHash, lnrpc.PaymentStatus_SUCCEEDED,
+		lnrpc.PaymentFailureReason_FAILURE_REASON_NONE)
+
+	// Next, Alice pays Bob with a payment hash he doesn't know of. Bob
+	// should reject the payment details, which should be reported to
+	// Alice, and recorded as the reason the payment failed.
+	ctxt, _ = context.WithTimeout(ctxb, timeout)
+	payStream, err := net.Alice.SendPayment(ctxt)
+	if err != nil {
+		t.Fatalf("unable to create payment stream: %v", err)
+	}
+	unknownHash := bytes.Repeat([]byte("T"), 32)
+	sendReq := &lnrpc.SendRequest{
+		PaymentHash: unknownHash,
+		Dest:        net.Bob.PubKey[:],
+		Amt:         paymentAmt,
+	}
+	if err := payStream.Send(sendReq); err != nil {
+		t.Fatalf("unable to send payment: %v", err)
+	}
+	assertPaymentFailed(t, payStream,
+		lnrpc.PaymentFailureReason_FAILURE_REASON_INCORRECT_PAYMENT_DETAILS,
+		"preimage")
+	trackPayment(unknownHash, lnrpc.PaymentStatus_FAILED,
+		lnrpc.PaymentFailureReason_FAILURE_REASON_INCORRECT_PAYMENT_DETAILS)
+
+	ctxt, _ = context.WithTimeout(ctxb, timeout)
+	closeChannelAndAssert(t, net, ctxt, net.Alice, chanPoint, false)
+}
+
 type testCase struct {
 	name string
 	test func(net *networkHarness, t *harnessTest)
@@ -1682,6 +1980,14 @@ var testsCases = []*testCase{
 		name: "list outgoing payments",
 		test: testListPayments,
 	},
+	{
+		name: "payment round trip",
+		test: testPaymentRoundTrip,
+	},
+	{
+		name: "track payment",
+		test: testTrackPayment,
+	},
 	{
 		name: "max pending channel",
 		test: testMaxPendingChannels,
@@ -1776,9 +2082,29 @@ func TestLightningNetworkDaemon(t *testing.T) {
 		ht.Fatalf("unable to set up test lightning network: %v", err)
 	}
 
-	t.Logf("Running %v integration tests", len(testsCases))
-	for _, testCase := range testsCases {
-		ht.RunTestCase(testCase, lndHarness)
+	// In flake hunter mode, the tests are run repeatedly, the first
+	// failure halting the run, and being reported along with the
+	// iteration it occurred within. As the final test leaves Bob's state
+	// unusable, it's only run within the final iteration.
+	iterations := 1
+	if *flakeHunter && *flakeHunterIterations > 1 {
+		iterations = *flakeHunterIterations
+	}
+	for i := 0; i < iterations; i++ {
+		cases := testsCases
+		if i < iterations-1 {
+			cases = testsCases[:len(testsCases)-1]
+		}
+		if *flakeHunter {
+			ht.iteration = i + 1
+			t.Logf("Flake hunter iteration %v of %v", ht.iteration,
+				iterations)
+		}
+
+		t.Logf("Running %v integration tests", len(cases))
+		for _, testCase := range cases {
+			ht.RunTestCase(testCase, lndHarness)
+		}
 	}
 
 	close(testsFin)
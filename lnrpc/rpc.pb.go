@@ -29,15 +29,22 @@ It has these top-level messages:
 	ActiveChannel
 	ListChannelsRequest
 	ListChannelsResponse
+	ChannelEventSubscription
+	ChannelEventUpdate
 	Peer
 	ListPeersRequest
 	ListPeersResponse
+	PeerEventSubscription
+	PeerEvent
 	GetInfoRequest
 	GetInfoResponse
+	Feature
 	ConfirmationUpdate
 	ChannelOpenUpdate
 	ChannelCloseUpdate
 	CloseChannelRequest
+	AbandonChannelRequest
+	AbandonChannelResponse
 	CloseStatusUpdate
 	PendingUpdate
 	OpenChannelRequest
@@ -54,6 +61,7 @@ It has these top-level messages:
 	NodeInfoRequest
 	NodeInfo
 	LightningNode
+	NodeAddress
 	RoutingPolicy
 	ChannelEdge
 	ChannelGraphRequest
@@ -61,8 +69,17 @@ It has these top-level messages:
 	ChanInfoRequest
 	NetworkInfoRequest
 	NetworkInfo
+	DegreeCount
+	GraphTopologySubscription
+	GraphTopologyUpdate
+	NodeUpdate
+	ChannelEdgeUpdate
+	ClosedChannelUpdate
 	SetAliasRequest
 	SetAliasResponse
+	UpdateFeatureAction
+	NodeAnnouncementUpdateRequest
+	NodeAnnouncementUpdateResponse
 	Invoice
 	AddInvoiceResponse
 	PaymentHash
@@ -74,6 +91,149 @@ It has these top-level messages:
 	ListPaymentsResponse
 	DeleteAllPaymentsRequest
 	DeleteAllPaymentsResponse
+	DeletePaymentRequest
+	DeletePaymentResponse
+	SendCustomMessageRequest
+	SendCustomMessageResponse
+	SubscribeCustomMessagesRequest
+	CustomMessage
+	KeyLocator
+	KeyDescriptor
+	TxOut
+	SignDescriptor
+	SignReq
+	SignResp
+	InputScript
+	InputScriptResp
+	SharedKeyRequest
+	SharedKeyResponse
+	Utxo
+	ListUnspentRequest
+	ListUnspentResponse
+	ListAddressesRequest
+	ListAddressesResponse
+	AddrRequest
+	DeriveKeyRequest
+	DeriveNextKeyRequest
+	PublishTransactionRequest
+	PublishTransactionResponse
+	EstimateFeeRequest
+	EstimateFeeResponse
+	FundPsbtRequest
+	FundPsbtResponse
+	SignPsbtRequest
+	SignPsbtResponse
+	FinalizePsbtRequest
+	FinalizePsbtResponse
+	OutPoint
+	LeaseOutputRequest
+	LeaseOutputResponse
+	ReleaseOutputRequest
+	ReleaseOutputResponse
+	UtxoLease
+	ListLeasesRequest
+	ListLeasesResponse
+	LabelTransactionRequest
+	LabelTransactionResponse
+	Account
+	ImportAccountRequest
+	ImportAccountResponse
+	ListAccountsRequest
+	ListAccountsResponse
+	GetRecoveryInfoRequest
+	GetRecoveryInfoResponse
+	DebugLevelRequest
+	DebugLevelResponse
+	AutopilotStatusRequest
+	AutopilotStatusResponse
+	ModifyAutopilotStatusRequest
+	ModifyAutopilotStatusResponse
+	QueryAutopilotScoresRequest
+	AutopilotNodeScore
+	QueryAutopilotScoresResponse
+	ChannelInsightsRequest
+	ChannelInsight
+	ChannelInsightsResponse
+	SetFeeManagementRequest
+	SetFeeManagementResponse
+	SetScoresRequest
+	SetScoresResponse
+	QueryScoresRequest
+	HeuristicResult
+	QueryScoresResponse
+	SubscribeStateRequest
+	SubscribeStateResponse
+	GetStateRequest
+	GetStateResponse
+	ExportChannelBackupRequest
+	ChannelBackup
+	ChannelBackups
+	MultiChanBackup
+	ChanBackupExportRequest
+	ChanBackupSnapshot
+	RestoreChanBackupRequest
+	RestoreBackupResponse
+	VerifyChanBackupResponse
+	GetDebugInfoRequest
+	FeatureSet
+	SubserverStatus
+	GetDebugInfoResponse
+	CPUProfileRequest
+	CPUProfileResponse
+	ExportChannelDbRequest
+	ExportChannelDbResponse
+	ImportGraphResponse
+	QuiesceChannelRequest
+	QuiesceChannelResponse
+	ForceStateTransitionRequest
+	ForceStateTransitionResponse
+	GetBestBlockRequest
+	GetBestBlockResponse
+	GetBlockHashRequest
+	GetBlockHashResponse
+	GetBlockRequest
+	GetBlockResponse
+	HtlcModifyRequest
+	HtlcModifyResponse
+	ListSweepsRequest
+	SweepInput
+	Sweep
+	ListSweepsResponse
+	RouteFeeRequest
+	RouteFeeResponse
+	NodeAddressesRequest
+	NodeAddressesResponse
+	UpdateChanStatusRequest
+	UpdateChanStatusResponse
+	UpgradeChannelRequest
+	UpgradeChannelResponse
+	PayReqString
+	PayReq
+	SubscribeHtlcEventsRequest
+	HtlcEvent
+	StopRequest
+	StopResponse
+	ReconnectingPeer
+	UpdatePeerAccessRequest
+	UpdatePeerAccessResponse
+	ListPeerAccessRequest
+	ListPeerAccessResponse
+	PeerReputation
+	InvoiceAcceptRequest
+	InvoiceAcceptResponse
+	SendToRouteRequest
+	HTLCAttempt
+	HTLCFailure
+	GetChannelBalanceRequest
+	GetChannelBalanceResponse
+	CommitmentTypeBalance
+	SetConnImpairmentRequest
+	SetConnImpairmentResponse
+	SignDigestRequest
+	SignDigestResponse
+	ProcessOnionRequest
+	ProcessOnionResponse
+	TrackPaymentRequest
 */
 package lnrpc
 
@@ -122,6 +282,331 @@ func (x ChannelStatus) String() string {
 }
 func (ChannelStatus) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
 
+type PaymentStatus int32
+
+const (
+	PaymentStatus_SUCCEEDED PaymentStatus = 0
+	PaymentStatus_FAILED    PaymentStatus = 1
+)
+
+var PaymentStatus_name = map[int32]string{
+	0: "SUCCEEDED",
+	1: "FAILED",
+}
+var PaymentStatus_value = map[string]int32{
+	"SUCCEEDED": 0,
+	"FAILED":    1,
+}
+
+func (x PaymentStatus) String() string {
+	return proto.EnumName(PaymentStatus_name, int32(x))
+}
+func (PaymentStatus) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+type CoinSelectionStrategy int32
+
+const (
+	CoinSelectionStrategy_STRATEGY_USE_GLOBAL_CONFIG CoinSelectionStrategy = 0
+	CoinSelectionStrategy_STRATEGY_LARGEST           CoinSelectionStrategy = 1
+	CoinSelectionStrategy_STRATEGY_RANDOM            CoinSelectionStrategy = 2
+	CoinSelectionStrategy_STRATEGY_SMALLEST          CoinSelectionStrategy = 3
+)
+
+var CoinSelectionStrategy_name = map[int32]string{
+	0: "STRATEGY_USE_GLOBAL_CONFIG",
+	1: "STRATEGY_LARGEST",
+	2: "STRATEGY_RANDOM",
+	3: "STRATEGY_SMALLEST",
+}
+var CoinSelectionStrategy_value = map[string]int32{
+	"STRATEGY_USE_GLOBAL_CONFIG": 0,
+	"STRATEGY_LARGEST":           1,
+	"STRATEGY_RANDOM":            2,
+	"STRATEGY_SMALLEST":          3,
+}
+
+func (x CoinSelectionStrategy) String() string {
+	return proto.EnumName(CoinSelectionStrategy_name, int32(x))
+}
+func (CoinSelectionStrategy) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+type WalletState int32
+
+const (
+	// NON_EXISTING and LOCKED are reserved for wallets protected by a
+	// password. The wallet is currently opened automatically on startup, so
+	// these states aren't reported.
+	WalletState_NON_EXISTING WalletState = 0
+	WalletState_LOCKED       WalletState = 1
+	// UNLOCKED indicates the wallet has been opened, and the remaining
+	// subsystems are being created.
+	WalletState_UNLOCKED WalletState = 2
+	// RPC_ACTIVE indicates the server has been started, and the calls of
+	// all services are served, though the autopilot agent and the REST proxy
+	// may not yet be running.
+	WalletState_RPC_ACTIVE WalletState = 3
+	// SERVER_ACTIVE indicates the daemon is fully started.
+	WalletState_SERVER_ACTIVE WalletState = 4
+	// WAITING_TO_START indicates the daemon is starting up, and the wallet
+	// hasn't yet been opened.
+	WalletState_WAITING_TO_START WalletState = 255
+)
+
+var WalletState_name = map[int32]string{
+	0:   "NON_EXISTING",
+	1:   "LOCKED",
+	2:   "UNLOCKED",
+	3:   "RPC_ACTIVE",
+	4:   "SERVER_ACTIVE",
+	255: "WAITING_TO_START",
+}
+var WalletState_value = map[string]int32{
+	"NON_EXISTING":     0,
+	"LOCKED":           1,
+	"UNLOCKED":         2,
+	"RPC_ACTIVE":       3,
+	"SERVER_ACTIVE":    4,
+	"WAITING_TO_START": 255,
+}
+
+func (x WalletState) String() string {
+	return proto.EnumName(WalletState_name, int32(x))
+}
+func (WalletState) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+type InvoiceState int32
+
+const (
+	InvoiceState_OPEN InvoiceState = 0
+	// An HTLC paying the invoice has been accepted, but is yet to be
+	// settled.
+	InvoiceState_ACCEPTED InvoiceState = 1
+	InvoiceState_SETTLED  InvoiceState = 2
+	// The invoice expired before being paid, and can no longer be.
+	InvoiceState_CANCELED InvoiceState = 3
+)
+
+var InvoiceState_name = map[int32]string{
+	0: "OPEN",
+	1: "ACCEPTED",
+	2: "SETTLED",
+	3: "CANCELED",
+}
+var InvoiceState_value = map[string]int32{
+	"OPEN":     0,
+	"ACCEPTED": 1,
+	"SETTLED":  2,
+	"CANCELED": 3,
+}
+
+func (x InvoiceState) String() string {
+	return proto.EnumName(InvoiceState_name, int32(x))
+}
+func (InvoiceState) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+type ChanStatusAction int32
+
+const (
+	// Manually enable the channel, regardless of the connectivity of its
+	// peer.
+	ChanStatusAction_ENABLE ChanStatusAction = 0
+	// Manually disable the channel, regardless of the connectivity of its
+	// peer.
+	ChanStatusAction_DISABLE ChanStatusAction = 1
+	// Return the channel to automatic management, where it's disabled while
+	// its peer is offline.
+	ChanStatusAction_AUTO ChanStatusAction = 2
+)
+
+var ChanStatusAction_name = map[int32]string{
+	0: "ENABLE",
+	1: "DISABLE",
+	2: "AUTO",
+}
+var ChanStatusAction_value = map[string]int32{
+	"ENABLE":  0,
+	"DISABLE": 1,
+	"AUTO":    2,
+}
+
+func (x ChanStatusAction) String() string {
+	return proto.EnumName(ChanStatusAction_name, int32(x))
+}
+func (ChanStatusAction) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+type CommitmentType int32
+
+const (
+	// The original commitment format, within which the balance of the
+	// remote party is paid to its commitment key, the key also used within
+	// its delayed output.
+	CommitmentType_LEGACY CommitmentType = 0
+	// The commitment format within which the balance of the remote party
+	// is paid to a dedicated payment key derived from its seed, which
+	// allows it to be recovered from the seed alone.
+	CommitmentType_STATIC_REMOTE_KEY CommitmentType = 1
+)
+
+var CommitmentType_name = map[int32]string{
+	0: "LEGACY",
+	1: "STATIC_REMOTE_KEY",
+}
+var CommitmentType_value = map[string]int32{
+	"LEGACY":            0,
+	"STATIC_REMOTE_KEY": 1,
+}
+
+func (x CommitmentType) String() string {
+	return proto.EnumName(CommitmentType_name, int32(x))
+}
+func (CommitmentType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+type HtlcEventType int32
+
+const (
+	// The HTLC was forwarded over the outgoing channel.
+	HtlcEventType_FORWARD HtlcEventType = 0
+	// The HTLC was failed by a downstream peer, or timed out, after being
+	// forwarded, and has been failed back.
+	HtlcEventType_FORWARD_FAIL HtlcEventType = 1
+	// The HTLC was settled.
+	HtlcEventType_SETTLE HtlcEventType = 2
+	// The HTLC couldn't be forwarded over the outgoing channel, and has
+	// been failed back.
+	HtlcEventType_LINK_FAIL HtlcEventType = 3
+)
+
+var HtlcEventType_name = map[int32]string{
+	0: "FORWARD",
+	1: "FORWARD_FAIL",
+	2: "SETTLE",
+	3: "LINK_FAIL",
+}
+var HtlcEventType_value = map[string]int32{
+	"FORWARD":      0,
+	"FORWARD_FAIL": 1,
+	"SETTLE":       2,
+	"LINK_FAIL":    3,
+}
+
+func (x HtlcEventType) String() string {
+	return proto.EnumName(HtlcEventType_name, int32(x))
+}
+func (HtlcEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+type PeerAccessList int32
+
+const (
+	// The peers we refuse connections and channels from.
+	PeerAccessList_BANNED PeerAccessList = 0
+	// The peers we solely accept connections and channels from when
+	// running in allowlist only mode.
+	PeerAccessList_ALLOWED PeerAccessList = 1
+)
+
+var PeerAccessList_name = map[int32]string{
+	0: "BANNED",
+	1: "ALLOWED",
+}
+var PeerAccessList_value = map[string]int32{
+	"BANNED":  0,
+	"ALLOWED": 1,
+}
+
+func (x PeerAccessList) String() string {
+	return proto.EnumName(PeerAccessList_name, int32(x))
+}
+func (PeerAccessList) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+type HTLCFailureCode int32
+
+const (
+	// The HTLC failed locally, before being cancelled by a remote node.
+	HTLCFailureCode_LOCAL_FAILURE             HTLCFailureCode = 0
+	HTLCFailureCode_INSUFFICIENT_CAPACITY     HTLCFailureCode = 1
+	HTLCFailureCode_UPSTREAM_TIMEOUT          HTLCFailureCode = 2
+	HTLCFailureCode_UNKNOWN_PAYMENT_HASH      HTLCFailureCode = 3
+	HTLCFailureCode_UNKNOWN_DESTINATION       HTLCFailureCode = 4
+	HTLCFailureCode_SPHINX_PARSE_ERROR        HTLCFailureCode = 5
+	HTLCFailureCode_INCORRECT_VALUE           HTLCFailureCode = 6
+	HTLCFailureCode_EXPIRY_TOO_SOON           HTLCFailureCode = 7
+	HTLCFailureCode_TEMPORARY_CHANNEL_FAILURE HTLCFailureCode = 8
+)
+
+var HTLCFailureCode_name = map[int32]string{
+	0: "LOCAL_FAILURE",
+	1: "INSUFFICIENT_CAPACITY",
+	2: "UPSTREAM_TIMEOUT",
+	3: "UNKNOWN_PAYMENT_HASH",
+	4: "UNKNOWN_DESTINATION",
+	5: "SPHINX_PARSE_ERROR",
+	6: "INCORRECT_VALUE",
+	7: "EXPIRY_TOO_SOON",
+	8: "TEMPORARY_CHANNEL_FAILURE",
+}
+var HTLCFailureCode_value = map[string]int32{
+	"LOCAL_FAILURE":             0,
+	"INSUFFICIENT_CAPACITY":     1,
+	"UPSTREAM_TIMEOUT":          2,
+	"UNKNOWN_PAYMENT_HASH":      3,
+	"UNKNOWN_DESTINATION":       4,
+	"SPHINX_PARSE_ERROR":        5,
+	"INCORRECT_VALUE":           6,
+	"EXPIRY_TOO_SOON":           7,
+	"TEMPORARY_CHANNEL_FAILURE": 8,
+}
+
+func (x HTLCFailureCode) String() string {
+	return proto.EnumName(HTLCFailureCode_name, int32(x))
+}
+func (HTLCFailureCode) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+type PaymentFailureReason int32
+
+const (
+	// The payment wasn't abandoned, either as it succeeded, or as the
+	// caller controls retrying it.
+	PaymentFailureReason_FAILURE_REASON_NONE PaymentFailureReason = 0
+	// The payment couldn't be settled before its timeout expired.
+	PaymentFailureReason_FAILURE_REASON_TIMEOUT PaymentFailureReason = 1
+	// No route within the fee and CLTV limits of the payment was found.
+	PaymentFailureReason_FAILURE_REASON_NO_ROUTE PaymentFailureReason = 2
+	// The payment failed with an error that retrying it can't overcome.
+	PaymentFailureReason_FAILURE_REASON_ERROR PaymentFailureReason = 3
+	// The recipient rejected the payment, as either its payment hash is
+	// unknown, or its amount is incorrect.
+	PaymentFailureReason_FAILURE_REASON_INCORRECT_PAYMENT_DETAILS PaymentFailureReason = 4
+	// None of our channels had the balance to carry the payment.
+	PaymentFailureReason_FAILURE_REASON_INSUFFICIENT_BALANCE PaymentFailureReason = 5
+	// The payment was abandoned as the daemon shut down while it was
+	// being retried.
+	PaymentFailureReason_FAILURE_REASON_CANCELED PaymentFailureReason = 6
+)
+
+var PaymentFailureReason_name = map[int32]string{
+	0: "FAILURE_REASON_NONE",
+	1: "FAILURE_REASON_TIMEOUT",
+	2: "FAILURE_REASON_NO_ROUTE",
+	3: "FAILURE_REASON_ERROR",
+	4: "FAILURE_REASON_INCORRECT_PAYMENT_DETAILS",
+	5: "FAILURE_REASON_INSUFFICIENT_BALANCE",
+	6: "FAILURE_REASON_CANCELED",
+}
+var PaymentFailureReason_value = map[string]int32{
+	"FAILURE_REASON_NONE":                      0,
+	"FAILURE_REASON_TIMEOUT":                   1,
+	"FAILURE_REASON_NO_ROUTE":                  2,
+	"FAILURE_REASON_ERROR":                     3,
+	"FAILURE_REASON_INCORRECT_PAYMENT_DETAILS": 4,
+	"FAILURE_REASON_INSUFFICIENT_BALANCE":      5,
+	"FAILURE_REASON_CANCELED":                  6,
+}
+
+func (x PaymentFailureReason) String() string {
+	return proto.EnumName(PaymentFailureReason_name, int32(x))
+}
+func (PaymentFailureReason) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
 type NewAddressRequest_AddressType int32
 
 const (
@@ -148,6 +633,82 @@ func (NewAddressRequest_AddressType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor0, []int{11, 0}
 }
 
+type ChannelEventUpdate_UpdateType int32
+
+const (
+	ChannelEventUpdate_OPEN_CHANNEL         ChannelEventUpdate_UpdateType = 0
+	ChannelEventUpdate_CLOSED_CHANNEL       ChannelEventUpdate_UpdateType = 1
+	ChannelEventUpdate_ACTIVE_CHANNEL       ChannelEventUpdate_UpdateType = 2
+	ChannelEventUpdate_INACTIVE_CHANNEL     ChannelEventUpdate_UpdateType = 3
+	ChannelEventUpdate_PENDING_OPEN_CHANNEL ChannelEventUpdate_UpdateType = 4
+)
+
+var ChannelEventUpdate_UpdateType_name = map[int32]string{
+	0: "OPEN_CHANNEL",
+	1: "CLOSED_CHANNEL",
+	2: "ACTIVE_CHANNEL",
+	3: "INACTIVE_CHANNEL",
+	4: "PENDING_OPEN_CHANNEL",
+}
+var ChannelEventUpdate_UpdateType_value = map[string]int32{
+	"OPEN_CHANNEL":         0,
+	"CLOSED_CHANNEL":       1,
+	"ACTIVE_CHANNEL":       2,
+	"INACTIVE_CHANNEL":     3,
+	"PENDING_OPEN_CHANNEL": 4,
+}
+
+func (x ChannelEventUpdate_UpdateType) String() string {
+	return proto.EnumName(ChannelEventUpdate_UpdateType_name, int32(x))
+}
+func (ChannelEventUpdate_UpdateType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{21, 0}
+}
+
+type PeerEvent_EventType int32
+
+const (
+	PeerEvent_PEER_ONLINE  PeerEvent_EventType = 0
+	PeerEvent_PEER_OFFLINE PeerEvent_EventType = 1
+)
+
+var PeerEvent_EventType_name = map[int32]string{
+	0: "PEER_ONLINE",
+	1: "PEER_OFFLINE",
+}
+var PeerEvent_EventType_value = map[string]int32{
+	"PEER_ONLINE":  0,
+	"PEER_OFFLINE": 1,
+}
+
+func (x PeerEvent_EventType) String() string {
+	return proto.EnumName(PeerEvent_EventType_name, int32(x))
+}
+func (PeerEvent_EventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{26, 0} }
+
+type UpdateFeatureAction_Action int32
+
+const (
+	UpdateFeatureAction_ADD    UpdateFeatureAction_Action = 0
+	UpdateFeatureAction_REMOVE UpdateFeatureAction_Action = 1
+)
+
+var UpdateFeatureAction_Action_name = map[int32]string{
+	0: "ADD",
+	1: "REMOVE",
+}
+var UpdateFeatureAction_Action_value = map[string]int32{
+	"ADD":    0,
+	"REMOVE": 1,
+}
+
+func (x UpdateFeatureAction_Action) String() string {
+	return proto.EnumName(UpdateFeatureAction_Action_name, int32(x))
+}
+func (UpdateFeatureAction_Action) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{68, 0}
+}
+
 type Transaction struct {
 	TxHash           string  `protobuf:"bytes,1,opt,name=tx_hash" json:"tx_hash,omitempty"`
 	Amount           float64 `protobuf:"fixed64,2,opt,name=amount" json:"amount,omitempty"`
@@ -156,6 +717,7 @@ type Transaction struct {
 	BlockHeight      int32   `protobuf:"varint,5,opt,name=block_height" json:"block_height,omitempty"`
 	TimeStamp        int64   `protobuf:"varint,6,opt,name=time_stamp" json:"time_stamp,omitempty"`
 	TotalFees        int64   `protobuf:"varint,7,opt,name=total_fees" json:"total_fees,omitempty"`
+	Label            string  `protobuf:"bytes,8,opt,name=label" json:"label,omitempty"`
 }
 
 func (m *Transaction) Reset()                    { *m = Transaction{} }
@@ -212,7 +774,18 @@ func (m *Transaction) GetTotalFees() int64 {
 	return 0
 }
 
+func (m *Transaction) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
 type GetTransactionsRequest struct {
+	StartHeight     int32  `protobuf:"varint,1,opt,name=start_height" json:"start_height,omitempty"`
+	EndHeight       int32  `protobuf:"varint,2,opt,name=end_height" json:"end_height,omitempty"`
+	IndexOffset     uint32 `protobuf:"varint,3,opt,name=index_offset" json:"index_offset,omitempty"`
+	MaxTransactions uint32 `protobuf:"varint,4,opt,name=max_transactions" json:"max_transactions,omitempty"`
 }
 
 func (m *GetTransactionsRequest) Reset()                    { *m = GetTransactionsRequest{} }
@@ -220,8 +793,37 @@ func (m *GetTransactionsRequest) String() string            { return proto.Compa
 func (*GetTransactionsRequest) ProtoMessage()               {}
 func (*GetTransactionsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
 
+func (m *GetTransactionsRequest) GetStartHeight() int32 {
+	if m != nil {
+		return m.StartHeight
+	}
+	return 0
+}
+
+func (m *GetTransactionsRequest) GetEndHeight() int32 {
+	if m != nil {
+		return m.EndHeight
+	}
+	return 0
+}
+
+func (m *GetTransactionsRequest) GetIndexOffset() uint32 {
+	if m != nil {
+		return m.IndexOffset
+	}
+	return 0
+}
+
+func (m *GetTransactionsRequest) GetMaxTransactions() uint32 {
+	if m != nil {
+		return m.MaxTransactions
+	}
+	return 0
+}
+
 type TransactionDetails struct {
-	Transactions []*Transaction `protobuf:"bytes,1,rep,name=transactions" json:"transactions,omitempty"`
+	Transactions    []*Transaction `protobuf:"bytes,1,rep,name=transactions" json:"transactions,omitempty"`
+	LastIndexOffset uint32         `protobuf:"varint,2,opt,name=last_index_offset" json:"last_index_offset,omitempty"`
 }
 
 func (m *TransactionDetails) Reset()                    { *m = TransactionDetails{} }
@@ -236,6 +838,13 @@ func (m *TransactionDetails) GetTransactions() []*Transaction {
 	return nil
 }
 
+func (m *TransactionDetails) GetLastIndexOffset() uint32 {
+	if m != nil {
+		return m.LastIndexOffset
+	}
+	return 0
+}
+
 type SendRequest struct {
 	Dest              []byte `protobuf:"bytes,1,opt,name=dest,proto3" json:"dest,omitempty"`
 	DestString        string `protobuf:"bytes,2,opt,name=dest_string" json:"dest_string,omitempty"`
@@ -243,6 +852,31 @@ type SendRequest struct {
 	PaymentHash       []byte `protobuf:"bytes,4,opt,name=payment_hash,proto3" json:"payment_hash,omitempty"`
 	PaymentHashString string `protobuf:"bytes,5,opt,name=payment_hash_string" json:"payment_hash_string,omitempty"`
 	PaymentRequest    string `protobuf:"bytes,6,opt,name=payment_request" json:"payment_request,omitempty"`
+	// The maximum total fee in satoshis to pay to the nodes along the
+	// route. If zero, then fee_limit_msat or fee_limit_percent applies.
+	FeeLimit int64 `protobuf:"varint,7,opt,name=fee_limit" json:"fee_limit,omitempty"`
+	// The number of seconds after which no further attempts to settle the
+	// payment are made. An attempt in flight once it expires is still
+	// allowed to resolve. If zero, only a single attempt is made.
+	TimeoutSeconds int32 `protobuf:"varint,8,opt,name=timeout_seconds" json:"timeout_seconds,omitempty"`
+	// The maximum total time lock of the route the payment may be sent
+	// over. If zero, the time lock isn't limited.
+	CltvLimit uint32 `protobuf:"varint,9,opt,name=cltv_limit" json:"cltv_limit,omitempty"`
+	// The maximum number of HTLCs the payment may be split into. If zero,
+	// the payment isn't split. As multi-path payments aren't supported yet,
+	// values above one are rejected.
+	MaxParts uint32 `protobuf:"varint,10,opt,name=max_parts" json:"max_parts,omitempty"`
+	// The maximum total fee to pay to the nodes along the route, as a
+	// percentage of the amount of the payment. Limits below the fee limit
+	// floor of the daemon are raised to it, so that tiny payments can still
+	// be routed. Only one of fee_limit, fee_limit_msat and fee_limit_percent
+	// may be set. If none is, the default fee limit of the daemon applies.
+	// Each limit bounds the fees of all parts of the payment combined.
+	FeeLimitPercent int64 `protobuf:"varint,11,opt,name=fee_limit_percent" json:"fee_limit_percent,omitempty"`
+	// The maximum total fee in millisatoshis to pay to the nodes along the
+	// route. As route fees are whole satoshis, the limit is rounded down to
+	// a whole satoshi.
+	FeeLimitMsat int64 `protobuf:"varint,12,opt,name=fee_limit_msat" json:"fee_limit_msat,omitempty"`
 }
 
 func (m *SendRequest) Reset()                    { *m = SendRequest{} }
@@ -292,7 +926,53 @@ func (m *SendRequest) GetPaymentRequest() string {
 	return ""
 }
 
+func (m *SendRequest) GetFeeLimit() int64 {
+	if m != nil {
+		return m.FeeLimit
+	}
+	return 0
+}
+
+func (m *SendRequest) GetTimeoutSeconds() int32 {
+	if m != nil {
+		return m.TimeoutSeconds
+	}
+	return 0
+}
+
+func (m *SendRequest) GetCltvLimit() uint32 {
+	if m != nil {
+		return m.CltvLimit
+	}
+	return 0
+}
+
+func (m *SendRequest) GetMaxParts() uint32 {
+	if m != nil {
+		return m.MaxParts
+	}
+	return 0
+}
+
+func (m *SendRequest) GetFeeLimitPercent() int64 {
+	if m != nil {
+		return m.FeeLimitPercent
+	}
+	return 0
+}
+
+func (m *SendRequest) GetFeeLimitMsat() int64 {
+	if m != nil {
+		return m.FeeLimitMsat
+	}
+	return 0
+}
+
 type SendResponse struct {
+	// A description of the error the payment failed with, if it failed.
+	PaymentError string `protobuf:"bytes,1,opt,name=payment_error" json:"payment_error,omitempty"`
+	// The reason the payment was abandoned, if it failed.
+	FailureReason PaymentFailureReason `protobuf:"varint,2,opt,name=failure_reason,enum=lnrpc.PaymentFailureReason" json:"failure_reason,omitempty"`
 }
 
 func (m *SendResponse) Reset()                    { *m = SendResponse{} }
@@ -300,6 +980,20 @@ func (m *SendResponse) String() string            { return proto.CompactTextStri
 func (*SendResponse) ProtoMessage()               {}
 func (*SendResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
+func (m *SendResponse) GetPaymentError() string {
+	if m != nil {
+		return m.PaymentError
+	}
+	return ""
+}
+
+func (m *SendResponse) GetFailureReason() PaymentFailureReason {
+	if m != nil {
+		return m.FailureReason
+	}
+	return PaymentFailureReason_FAILURE_REASON_NONE
+}
+
 type ChannelPoint struct {
 	FundingTxid    []byte `protobuf:"bytes,1,opt,name=funding_txid,proto3" json:"funding_txid,omitempty"`
 	FundingTxidStr string `protobuf:"bytes,2,opt,name=funding_txid_str" json:"funding_txid_str,omitempty"`
@@ -357,7 +1051,12 @@ func (m *LightningAddress) GetHost() string {
 }
 
 type SendManyRequest struct {
-	AddrToAmount map[string]int64 `protobuf:"bytes,1,rep,name=AddrToAmount" json:"AddrToAmount,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	AddrToAmount     map[string]int64 `protobuf:"bytes,1,rep,name=AddrToAmount" json:"AddrToAmount,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	TargetConf       uint32           `protobuf:"varint,2,opt,name=target_conf" json:"target_conf,omitempty"`
+	SatPerByte       int64            `protobuf:"varint,3,opt,name=sat_per_byte" json:"sat_per_byte,omitempty"`
+	Label            string           `protobuf:"bytes,4,opt,name=label" json:"label,omitempty"`
+	MinConfs         int32            `protobuf:"varint,5,opt,name=min_confs" json:"min_confs,omitempty"`
+	SpendUnconfirmed bool             `protobuf:"varint,6,opt,name=spend_unconfirmed" json:"spend_unconfirmed,omitempty"`
 }
 
 func (m *SendManyRequest) Reset()                    { *m = SendManyRequest{} }
@@ -372,6 +1071,41 @@ func (m *SendManyRequest) GetAddrToAmount() map[string]int64 {
 	return nil
 }
 
+func (m *SendManyRequest) GetTargetConf() uint32 {
+	if m != nil {
+		return m.TargetConf
+	}
+	return 0
+}
+
+func (m *SendManyRequest) GetSatPerByte() int64 {
+	if m != nil {
+		return m.SatPerByte
+	}
+	return 0
+}
+
+func (m *SendManyRequest) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *SendManyRequest) GetMinConfs() int32 {
+	if m != nil {
+		return m.MinConfs
+	}
+	return 0
+}
+
+func (m *SendManyRequest) GetSpendUnconfirmed() bool {
+	if m != nil {
+		return m.SpendUnconfirmed
+	}
+	return false
+}
+
 type SendManyResponse struct {
 	Txid string `protobuf:"bytes,1,opt,name=txid" json:"txid,omitempty"`
 }
@@ -389,8 +1123,16 @@ func (m *SendManyResponse) GetTxid() string {
 }
 
 type SendCoinsRequest struct {
-	Addr   string `protobuf:"bytes,1,opt,name=addr" json:"addr,omitempty"`
-	Amount int64  `protobuf:"varint,2,opt,name=amount" json:"amount,omitempty"`
+	Addr                  string                `protobuf:"bytes,1,opt,name=addr" json:"addr,omitempty"`
+	Amount                int64                 `protobuf:"varint,2,opt,name=amount" json:"amount,omitempty"`
+	Outpoints             []*OutPoint           `protobuf:"bytes,3,rep,name=outpoints" json:"outpoints,omitempty"`
+	CoinSelectionStrategy CoinSelectionStrategy `protobuf:"varint,4,opt,name=coin_selection_strategy,enum=lnrpc.CoinSelectionStrategy" json:"coin_selection_strategy,omitempty"`
+	TargetConf            uint32                `protobuf:"varint,5,opt,name=target_conf" json:"target_conf,omitempty"`
+	SatPerByte            int64                 `protobuf:"varint,6,opt,name=sat_per_byte" json:"sat_per_byte,omitempty"`
+	SendAll               bool                  `protobuf:"varint,7,opt,name=send_all" json:"send_all,omitempty"`
+	Label                 string                `protobuf:"bytes,8,opt,name=label" json:"label,omitempty"`
+	MinConfs              int32                 `protobuf:"varint,9,opt,name=min_confs" json:"min_confs,omitempty"`
+	SpendUnconfirmed      bool                  `protobuf:"varint,10,opt,name=spend_unconfirmed" json:"spend_unconfirmed,omitempty"`
 }
 
 func (m *SendCoinsRequest) Reset()                    { *m = SendCoinsRequest{} }
@@ -412,6 +1154,62 @@ func (m *SendCoinsRequest) GetAmount() int64 {
 	return 0
 }
 
+func (m *SendCoinsRequest) GetOutpoints() []*OutPoint {
+	if m != nil {
+		return m.Outpoints
+	}
+	return nil
+}
+
+func (m *SendCoinsRequest) GetCoinSelectionStrategy() CoinSelectionStrategy {
+	if m != nil {
+		return m.CoinSelectionStrategy
+	}
+	return CoinSelectionStrategy_STRATEGY_USE_GLOBAL_CONFIG
+}
+
+func (m *SendCoinsRequest) GetTargetConf() uint32 {
+	if m != nil {
+		return m.TargetConf
+	}
+	return 0
+}
+
+func (m *SendCoinsRequest) GetSatPerByte() int64 {
+	if m != nil {
+		return m.SatPerByte
+	}
+	return 0
+}
+
+func (m *SendCoinsRequest) GetSendAll() bool {
+	if m != nil {
+		return m.SendAll
+	}
+	return false
+}
+
+func (m *SendCoinsRequest) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *SendCoinsRequest) GetMinConfs() int32 {
+	if m != nil {
+		return m.MinConfs
+	}
+	return 0
+}
+
+func (m *SendCoinsRequest) GetSpendUnconfirmed() bool {
+	if m != nil {
+		return m.SpendUnconfirmed
+	}
+	return false
+}
+
 type SendCoinsResponse struct {
 	Txid string `protobuf:"bytes,1,opt,name=txid" json:"txid,omitempty"`
 }
@@ -568,6 +1366,44 @@ type ActiveChannel struct {
 	TotalSatoshisReceived int64   `protobuf:"varint,9,opt,name=total_satoshis_received" json:"total_satoshis_received,omitempty"`
 	NumUpdates            uint64  `protobuf:"varint,10,opt,name=num_updates" json:"num_updates,omitempty"`
 	PendingHtlcs          []*HTLC `protobuf:"bytes,11,rep,name=pending_htlcs" json:"pending_htlcs,omitempty"`
+	// Whether the peer of the channel is currently online.
+	Active bool `protobuf:"varint,12,opt,name=active" json:"active,omitempty"`
+	// The fee in satoshis paid by the current commitment transaction.
+	CommitFee int64 `protobuf:"varint,13,opt,name=commit_fee" json:"commit_fee,omitempty"`
+	// The weight of the current commitment transaction once signed.
+	CommitWeight int64 `protobuf:"varint,14,opt,name=commit_weight" json:"commit_weight,omitempty"`
+	// Whether the channel is absent from the public channel graph.
+	Private bool `protobuf:"varint,15,opt,name=private" json:"private,omitempty"`
+	// The number of seconds the channel has been monitored for, since
+	// either the node started or the channel was opened.
+	Lifetime int64 `protobuf:"varint,16,opt,name=lifetime" json:"lifetime,omitempty"`
+	// The number of seconds the channel's peer has been online within the
+	// channel's lifetime.
+	Uptime int64 `protobuf:"varint,17,opt,name=uptime" json:"uptime,omitempty"`
+	// Whether the channel can currently be cooperatively closed. Channels
+	// whose peer is offline can only be force closed.
+	CoopClosable bool `protobuf:"varint,18,opt,name=coop_closable" json:"coop_closable,omitempty"`
+	// The channel ID in its human readable BLOCKxTXxOUT form. This is only
+	// set for channels announced to the public graph.
+	ChanIdStr string `protobuf:"bytes,19,opt,name=chan_id_str" json:"chan_id_str,omitempty"`
+	// The format of the channel's current commitment transactions.
+	CommitmentType CommitmentType `protobuf:"varint,20,opt,name=commitment_type,enum=lnrpc.CommitmentType" json:"commitment_type,omitempty"`
+	// Whether an upgrade of the channel's commitment type has been agreed
+	// upon, but the commitments in the prior format haven't been revoked
+	// yet.
+	UpgradePending bool `protobuf:"varint,21,opt,name=upgrade_pending" json:"upgrade_pending,omitempty"`
+	// The largest HTLC we can currently send over the channel, accounting for
+	// the channel reserve, the pending HTLCs, and the commitment fee.
+	SpendableBalance int64 `protobuf:"varint,22,opt,name=spendable_balance" json:"spendable_balance,omitempty"`
+	// The largest HTLC we can currently receive over the channel, accounting
+	// for the same on the side of the remote party.
+	ReceivableBalance int64 `protobuf:"varint,23,opt,name=receivable_balance" json:"receivable_balance,omitempty"`
+	// The channel reserve the remote party requires us to keep.
+	LocalChanReserve int64 `protobuf:"varint,24,opt,name=local_chan_reserve" json:"local_chan_reserve,omitempty"`
+	// The channel reserve we require the remote party to keep.
+	RemoteChanReserve int64 `protobuf:"varint,25,opt,name=remote_chan_reserve" json:"remote_chan_reserve,omitempty"`
+	// The hex encoded 32-byte channel ID, derived from the channel point.
+	FullChanId string `protobuf:"bytes,26,opt,name=full_chan_id" json:"full_chan_id,omitempty"`
 }
 
 func (m *ActiveChannel) Reset()                    { *m = ActiveChannel{} }
@@ -652,7 +1488,116 @@ func (m *ActiveChannel) GetPendingHtlcs() []*HTLC {
 	return nil
 }
 
-type ListChannelsRequest struct {
+func (m *ActiveChannel) GetActive() bool {
+	if m != nil {
+		return m.Active
+	}
+	return false
+}
+
+func (m *ActiveChannel) GetCommitFee() int64 {
+	if m != nil {
+		return m.CommitFee
+	}
+	return 0
+}
+
+func (m *ActiveChannel) GetCommitWeight() int64 {
+	if m != nil {
+		return m.CommitWeight
+	}
+	return 0
+}
+
+func (m *ActiveChannel) GetPrivate() bool {
+	if m != nil {
+		return m.Private
+	}
+	return false
+}
+
+func (m *ActiveChannel) GetLifetime() int64 {
+	if m != nil {
+		return m.Lifetime
+	}
+	return 0
+}
+
+func (m *ActiveChannel) GetUptime() int64 {
+	if m != nil {
+		return m.Uptime
+	}
+	return 0
+}
+
+func (m *ActiveChannel) GetCoopClosable() bool {
+	if m != nil {
+		return m.CoopClosable
+	}
+	return false
+}
+
+func (m *ActiveChannel) GetChanIdStr() string {
+	if m != nil {
+		return m.ChanIdStr
+	}
+	return ""
+}
+
+func (m *ActiveChannel) GetCommitmentType() CommitmentType {
+	if m != nil {
+		return m.CommitmentType
+	}
+	return CommitmentType_LEGACY
+}
+
+func (m *ActiveChannel) GetUpgradePending() bool {
+	if m != nil {
+		return m.UpgradePending
+	}
+	return false
+}
+
+func (m *ActiveChannel) GetSpendableBalance() int64 {
+	if m != nil {
+		return m.SpendableBalance
+	}
+	return 0
+}
+
+func (m *ActiveChannel) GetReceivableBalance() int64 {
+	if m != nil {
+		return m.ReceivableBalance
+	}
+	return 0
+}
+
+func (m *ActiveChannel) GetLocalChanReserve() int64 {
+	if m != nil {
+		return m.LocalChanReserve
+	}
+	return 0
+}
+
+func (m *ActiveChannel) GetRemoteChanReserve() int64 {
+	if m != nil {
+		return m.RemoteChanReserve
+	}
+	return 0
+}
+
+func (m *ActiveChannel) GetFullChanId() string {
+	if m != nil {
+		return m.FullChanId
+	}
+	return ""
+}
+
+type ListChannelsRequest struct {
+	ActiveOnly   bool `protobuf:"varint,1,opt,name=active_only" json:"active_only,omitempty"`
+	InactiveOnly bool `protobuf:"varint,2,opt,name=inactive_only" json:"inactive_only,omitempty"`
+	PublicOnly   bool `protobuf:"varint,3,opt,name=public_only" json:"public_only,omitempty"`
+	PrivateOnly  bool `protobuf:"varint,4,opt,name=private_only" json:"private_only,omitempty"`
 }
 
 func (m *ListChannelsRequest) Reset()                    { *m = ListChannelsRequest{} }
@@ -660,6 +1605,34 @@ func (m *ListChannelsRequest) String() string            { return proto.CompactT
 func (*ListChannelsRequest) ProtoMessage()               {}
 func (*ListChannelsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
+func (m *ListChannelsRequest) GetActiveOnly() bool {
+	if m != nil {
+		return m.ActiveOnly
+	}
+	return false
+}
+
+func (m *ListChannelsRequest) GetInactiveOnly() bool {
+	if m != nil {
+		return m.InactiveOnly
+	}
+	return false
+}
+
+func (m *ListChannelsRequest) GetPublicOnly() bool {
+	if m != nil {
+		return m.PublicOnly
+	}
+	return false
+}
+
+func (m *ListChannelsRequest) GetPrivateOnly() bool {
+	if m != nil {
+		return m.PrivateOnly
+	}
+	return false
+}
+
 type ListChannelsResponse struct {
 	Channels []*ActiveChannel `protobuf:"bytes,11,rep,name=channels" json:"channels,omitempty"`
 }
@@ -676,6 +1649,46 @@ func (m *ListChannelsResponse) GetChannels() []*ActiveChannel {
 	return nil
 }
 
+type ChannelEventSubscription struct {
+}
+
+func (m *ChannelEventSubscription) Reset()                    { *m = ChannelEventSubscription{} }
+func (m *ChannelEventSubscription) String() string            { return proto.CompactTextString(m) }
+func (*ChannelEventSubscription) ProtoMessage()               {}
+func (*ChannelEventSubscription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+type ChannelEventUpdate struct {
+	Type         ChannelEventUpdate_UpdateType `protobuf:"varint,1,opt,name=type,enum=lnrpc.ChannelEventUpdate_UpdateType" json:"type,omitempty"`
+	ChannelPoint *ChannelPoint                 `protobuf:"bytes,2,opt,name=channel_point" json:"channel_point,omitempty"`
+	RemotePubkey string                        `protobuf:"bytes,3,opt,name=remote_pubkey" json:"remote_pubkey,omitempty"`
+}
+
+func (m *ChannelEventUpdate) Reset()                    { *m = ChannelEventUpdate{} }
+func (m *ChannelEventUpdate) String() string            { return proto.CompactTextString(m) }
+func (*ChannelEventUpdate) ProtoMessage()               {}
+func (*ChannelEventUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *ChannelEventUpdate) GetType() ChannelEventUpdate_UpdateType {
+	if m != nil {
+		return m.Type
+	}
+	return ChannelEventUpdate_OPEN_CHANNEL
+}
+
+func (m *ChannelEventUpdate) GetChannelPoint() *ChannelPoint {
+	if m != nil {
+		return m.ChannelPoint
+	}
+	return nil
+}
+
+func (m *ChannelEventUpdate) GetRemotePubkey() string {
+	if m != nil {
+		return m.RemotePubkey
+	}
+	return ""
+}
+
 type Peer struct {
 	PubKey    string `protobuf:"bytes,1,opt,name=pub_key" json:"pub_key,omitempty"`
 	PeerId    int32  `protobuf:"varint,2,opt,name=peer_id" json:"peer_id,omitempty"`
@@ -685,12 +1698,15 @@ type Peer struct {
 	SatSent   int64  `protobuf:"varint,6,opt,name=sat_sent" json:"sat_sent,omitempty"`
 	SatRecv   int64  `protobuf:"varint,7,opt,name=sat_recv" json:"sat_recv,omitempty"`
 	Inbound   bool   `protobuf:"varint,8,opt,name=inbound" json:"inbound,omitempty"`
+	// The local reputation of the peer, derived from the outcome of the
+	// HTLC's it has forwarded to us. Unset if it hasn't forwarded any.
+	Reputation *PeerReputation `protobuf:"bytes,9,opt,name=reputation" json:"reputation,omitempty"`
 }
 
 func (m *Peer) Reset()                    { *m = Peer{} }
 func (m *Peer) String() string            { return proto.CompactTextString(m) }
 func (*Peer) ProtoMessage()               {}
-func (*Peer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*Peer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
 func (m *Peer) GetPubKey() string {
 	if m != nil {
@@ -748,22 +1764,32 @@ func (m *Peer) GetInbound() bool {
 	return false
 }
 
+func (m *Peer) GetReputation() *PeerReputation {
+	if m != nil {
+		return m.Reputation
+	}
+	return nil
+}
+
 type ListPeersRequest struct {
 }
 
 func (m *ListPeersRequest) Reset()                    { *m = ListPeersRequest{} }
 func (m *ListPeersRequest) String() string            { return proto.CompactTextString(m) }
 func (*ListPeersRequest) ProtoMessage()               {}
-func (*ListPeersRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (*ListPeersRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
 
 type ListPeersResponse struct {
 	Peers []*Peer `protobuf:"bytes,1,rep,name=peers" json:"peers,omitempty"`
+	// The persistent peers we're currently disconnected from, along with
+	// their reconnection backoff.
+	ReconnectingPeers []*ReconnectingPeer `protobuf:"bytes,2,rep,name=reconnecting_peers" json:"reconnecting_peers,omitempty"`
 }
 
 func (m *ListPeersResponse) Reset()                    { *m = ListPeersResponse{} }
 func (m *ListPeersResponse) String() string            { return proto.CompactTextString(m) }
 func (*ListPeersResponse) ProtoMessage()               {}
-func (*ListPeersResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+func (*ListPeersResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
 
 func (m *ListPeersResponse) GetPeers() []*Peer {
 	if m != nil {
@@ -772,13 +1798,52 @@ func (m *ListPeersResponse) GetPeers() []*Peer {
 	return nil
 }
 
+func (m *ListPeersResponse) GetReconnectingPeers() []*ReconnectingPeer {
+	if m != nil {
+		return m.ReconnectingPeers
+	}
+	return nil
+}
+
+type PeerEventSubscription struct {
+}
+
+func (m *PeerEventSubscription) Reset()                    { *m = PeerEventSubscription{} }
+func (m *PeerEventSubscription) String() string            { return proto.CompactTextString(m) }
+func (*PeerEventSubscription) ProtoMessage()               {}
+func (*PeerEventSubscription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+type PeerEvent struct {
+	PubKey string              `protobuf:"bytes,1,opt,name=pub_key" json:"pub_key,omitempty"`
+	Type   PeerEvent_EventType `protobuf:"varint,2,opt,name=type,enum=lnrpc.PeerEvent_EventType" json:"type,omitempty"`
+}
+
+func (m *PeerEvent) Reset()                    { *m = PeerEvent{} }
+func (m *PeerEvent) String() string            { return proto.CompactTextString(m) }
+func (*PeerEvent) ProtoMessage()               {}
+func (*PeerEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *PeerEvent) GetPubKey() string {
+	if m != nil {
+		return m.PubKey
+	}
+	return ""
+}
+
+func (m *PeerEvent) GetType() PeerEvent_EventType {
+	if m != nil {
+		return m.Type
+	}
+	return PeerEvent_PEER_ONLINE
+}
+
 type GetInfoRequest struct {
 }
 
 func (m *GetInfoRequest) Reset()                    { *m = GetInfoRequest{} }
 func (m *GetInfoRequest) String() string            { return proto.CompactTextString(m) }
 func (*GetInfoRequest) ProtoMessage()               {}
-func (*GetInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+func (*GetInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
 
 type GetInfoResponse struct {
 	IdentityPubkey     string `protobuf:"bytes,1,opt,name=identity_pubkey" json:"identity_pubkey,omitempty"`
@@ -790,12 +1855,22 @@ type GetInfoResponse struct {
 	BlockHash          string `protobuf:"bytes,8,opt,name=block_hash" json:"block_hash,omitempty"`
 	SyncedToChain      bool   `protobuf:"varint,9,opt,name=synced_to_chain" json:"synced_to_chain,omitempty"`
 	Testnet            bool   `protobuf:"varint,10,opt,name=testnet" json:"testnet,omitempty"`
+	// The color of the node in hex code format.
+	Color string `protobuf:"bytes,11,opt,name=color" json:"color,omitempty"`
+	// The number of channels whose peer is currently offline.
+	NumInactiveChannels uint32 `protobuf:"varint,12,opt,name=num_inactive_channels" json:"num_inactive_channels,omitempty"`
+	// The version of the running daemon.
+	Version string `protobuf:"bytes,13,opt,name=version" json:"version,omitempty"`
+	// The name of the chain network the daemon is running on.
+	Chain string `protobuf:"bytes,14,opt,name=chain" json:"chain,omitempty"`
+	// The features advertised within our node announcement.
+	Features []*Feature `protobuf:"bytes,15,rep,name=features" json:"features,omitempty"`
 }
 
 func (m *GetInfoResponse) Reset()                    { *m = GetInfoResponse{} }
 func (m *GetInfoResponse) String() string            { return proto.CompactTextString(m) }
 func (*GetInfoResponse) ProtoMessage()               {}
-func (*GetInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+func (*GetInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
 
 func (m *GetInfoResponse) GetIdentityPubkey() string {
 	if m != nil {
@@ -860,6 +1935,81 @@ func (m *GetInfoResponse) GetTestnet() bool {
 	return false
 }
 
+func (m *GetInfoResponse) GetColor() string {
+	if m != nil {
+		return m.Color
+	}
+	return ""
+}
+
+func (m *GetInfoResponse) GetNumInactiveChannels() uint32 {
+	if m != nil {
+		return m.NumInactiveChannels
+	}
+	return 0
+}
+
+func (m *GetInfoResponse) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *GetInfoResponse) GetChain() string {
+	if m != nil {
+		return m.Chain
+	}
+	return ""
+}
+
+func (m *GetInfoResponse) GetFeatures() []*Feature {
+	if m != nil {
+		return m.Features
+	}
+	return nil
+}
+
+type Feature struct {
+	Bit        uint32 `protobuf:"varint,1,opt,name=bit" json:"bit,omitempty"`
+	Name       string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	IsRequired bool   `protobuf:"varint,3,opt,name=is_required" json:"is_required,omitempty"`
+	IsKnown    bool   `protobuf:"varint,4,opt,name=is_known" json:"is_known,omitempty"`
+}
+
+func (m *Feature) Reset()                    { *m = Feature{} }
+func (m *Feature) String() string            { return proto.CompactTextString(m) }
+func (*Feature) ProtoMessage()               {}
+func (*Feature) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *Feature) GetBit() uint32 {
+	if m != nil {
+		return m.Bit
+	}
+	return 0
+}
+
+func (m *Feature) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Feature) GetIsRequired() bool {
+	if m != nil {
+		return m.IsRequired
+	}
+	return false
+}
+
+func (m *Feature) GetIsKnown() bool {
+	if m != nil {
+		return m.IsKnown
+	}
+	return false
+}
+
 type ConfirmationUpdate struct {
 	BlockSha     []byte `protobuf:"bytes,1,opt,name=block_sha,proto3" json:"block_sha,omitempty"`
 	BlockHeight  int32  `protobuf:"varint,2,opt,name=block_height" json:"block_height,omitempty"`
@@ -869,7 +2019,7 @@ type ConfirmationUpdate struct {
 func (m *ConfirmationUpdate) Reset()                    { *m = ConfirmationUpdate{} }
 func (m *ConfirmationUpdate) String() string            { return proto.CompactTextString(m) }
 func (*ConfirmationUpdate) ProtoMessage()               {}
-func (*ConfirmationUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+func (*ConfirmationUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
 
 func (m *ConfirmationUpdate) GetBlockSha() []byte {
 	if m != nil {
@@ -899,7 +2049,7 @@ type ChannelOpenUpdate struct {
 func (m *ChannelOpenUpdate) Reset()                    { *m = ChannelOpenUpdate{} }
 func (m *ChannelOpenUpdate) String() string            { return proto.CompactTextString(m) }
 func (*ChannelOpenUpdate) ProtoMessage()               {}
-func (*ChannelOpenUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+func (*ChannelOpenUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
 
 func (m *ChannelOpenUpdate) GetChannelPoint() *ChannelPoint {
 	if m != nil {
@@ -916,7 +2066,7 @@ type ChannelCloseUpdate struct {
 func (m *ChannelCloseUpdate) Reset()                    { *m = ChannelCloseUpdate{} }
 func (m *ChannelCloseUpdate) String() string            { return proto.CompactTextString(m) }
 func (*ChannelCloseUpdate) ProtoMessage()               {}
-func (*ChannelCloseUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+func (*ChannelCloseUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
 
 func (m *ChannelCloseUpdate) GetClosingTxid() []byte {
 	if m != nil {
@@ -936,12 +2086,20 @@ type CloseChannelRequest struct {
 	ChannelPoint *ChannelPoint `protobuf:"bytes,1,opt,name=channel_point" json:"channel_point,omitempty"`
 	TimeLimit    int64         `protobuf:"varint,2,opt,name=time_limit" json:"time_limit,omitempty"`
 	Force        bool          `protobuf:"varint,3,opt,name=force" json:"force,omitempty"`
+	// The short channel ID of the channel to close, which may be set in
+	// place of the channel point for channels within the public graph.
+	ChanId uint64 `protobuf:"varint,4,opt,name=chan_id" json:"chan_id,omitempty"`
+	// An address the funds of a force closed channel should be swept to
+	// once mature, instead of a fresh wallet address. The delivery
+	// address of a cooperative close is committed to when opening the
+	// channel, so it may only be set to that same address.
+	DeliveryAddress string `protobuf:"bytes,5,opt,name=delivery_address" json:"delivery_address,omitempty"`
 }
 
 func (m *CloseChannelRequest) Reset()                    { *m = CloseChannelRequest{} }
 func (m *CloseChannelRequest) String() string            { return proto.CompactTextString(m) }
 func (*CloseChannelRequest) ProtoMessage()               {}
-func (*CloseChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+func (*CloseChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
 
 func (m *CloseChannelRequest) GetChannelPoint() *ChannelPoint {
 	if m != nil {
@@ -964,6 +2122,55 @@ func (m *CloseChannelRequest) GetForce() bool {
 	return false
 }
 
+func (m *CloseChannelRequest) GetChanId() uint64 {
+	if m != nil {
+		return m.ChanId
+	}
+	return 0
+}
+
+func (m *CloseChannelRequest) GetDeliveryAddress() string {
+	if m != nil {
+		return m.DeliveryAddress
+	}
+	return ""
+}
+
+type AbandonChannelRequest struct {
+	ChannelPoint *ChannelPoint `protobuf:"bytes,1,opt,name=channel_point" json:"channel_point,omitempty"`
+	// Abandoning a channel forfeits any funds within it unless they're
+	// recovered by other means, so outside of dev builds the request must
+	// explicitly acknowledge this.
+	IKnowWhatIAmDoing bool `protobuf:"varint,2,opt,name=i_know_what_i_am_doing" json:"i_know_what_i_am_doing,omitempty"`
+}
+
+func (m *AbandonChannelRequest) Reset()                    { *m = AbandonChannelRequest{} }
+func (m *AbandonChannelRequest) String() string            { return proto.CompactTextString(m) }
+func (*AbandonChannelRequest) ProtoMessage()               {}
+func (*AbandonChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+func (m *AbandonChannelRequest) GetChannelPoint() *ChannelPoint {
+	if m != nil {
+		return m.ChannelPoint
+	}
+	return nil
+}
+
+func (m *AbandonChannelRequest) GetIKnowWhatIAmDoing() bool {
+	if m != nil {
+		return m.IKnowWhatIAmDoing
+	}
+	return false
+}
+
+type AbandonChannelResponse struct {
+}
+
+func (m *AbandonChannelResponse) Reset()                    { *m = AbandonChannelResponse{} }
+func (m *AbandonChannelResponse) String() string            { return proto.CompactTextString(m) }
+func (*AbandonChannelResponse) ProtoMessage()               {}
+func (*AbandonChannelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
 type CloseStatusUpdate struct {
 	// Types that are valid to be assigned to Update:
 	//	*CloseStatusUpdate_ClosePending
@@ -975,7 +2182,7 @@ type CloseStatusUpdate struct {
 func (m *CloseStatusUpdate) Reset()                    { *m = CloseStatusUpdate{} }
 func (m *CloseStatusUpdate) String() string            { return proto.CompactTextString(m) }
 func (*CloseStatusUpdate) ProtoMessage()               {}
-func (*CloseStatusUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+func (*CloseStatusUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
 
 type isCloseStatusUpdate_Update interface {
 	isCloseStatusUpdate_Update()
@@ -1123,7 +2330,7 @@ type PendingUpdate struct {
 func (m *PendingUpdate) Reset()                    { *m = PendingUpdate{} }
 func (m *PendingUpdate) String() string            { return proto.CompactTextString(m) }
 func (*PendingUpdate) ProtoMessage()               {}
-func (*PendingUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+func (*PendingUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
 
 func (m *PendingUpdate) GetTxid() []byte {
 	if m != nil {
@@ -1133,18 +2340,31 @@ func (m *PendingUpdate) GetTxid() []byte {
 }
 
 type OpenChannelRequest struct {
-	TargetPeerId       int32  `protobuf:"varint,1,opt,name=target_peer_id" json:"target_peer_id,omitempty"`
-	NodePubkey         []byte `protobuf:"bytes,2,opt,name=node_pubkey,proto3" json:"node_pubkey,omitempty"`
-	NodePubkeyString   string `protobuf:"bytes,3,opt,name=node_pubkey_string" json:"node_pubkey_string,omitempty"`
-	LocalFundingAmount int64  `protobuf:"varint,4,opt,name=local_funding_amount" json:"local_funding_amount,omitempty"`
-	PushSat            int64  `protobuf:"varint,5,opt,name=push_sat" json:"push_sat,omitempty"`
-	NumConfs           uint32 `protobuf:"varint,6,opt,name=num_confs" json:"num_confs,omitempty"`
+	TargetPeerId          int32                 `protobuf:"varint,1,opt,name=target_peer_id" json:"target_peer_id,omitempty"`
+	NodePubkey            []byte                `protobuf:"bytes,2,opt,name=node_pubkey,proto3" json:"node_pubkey,omitempty"`
+	NodePubkeyString      string                `protobuf:"bytes,3,opt,name=node_pubkey_string" json:"node_pubkey_string,omitempty"`
+	LocalFundingAmount    int64                 `protobuf:"varint,4,opt,name=local_funding_amount" json:"local_funding_amount,omitempty"`
+	PushSat               int64                 `protobuf:"varint,5,opt,name=push_sat" json:"push_sat,omitempty"`
+	NumConfs              uint32                `protobuf:"varint,6,opt,name=num_confs" json:"num_confs,omitempty"`
+	Outpoints             []*OutPoint           `protobuf:"bytes,7,rep,name=outpoints" json:"outpoints,omitempty"`
+	CoinSelectionStrategy CoinSelectionStrategy `protobuf:"varint,8,opt,name=coin_selection_strategy,enum=lnrpc.CoinSelectionStrategy" json:"coin_selection_strategy,omitempty"`
+	// The constraints to impose upon the remote party of the channel. Any
+	// left unset fall back to the configured defaults.
+	RemoteChanReserveSat      int64  `protobuf:"varint,9,opt,name=remote_chan_reserve_sat" json:"remote_chan_reserve_sat,omitempty"`
+	RemoteMaxValueInFlightSat int64  `protobuf:"varint,10,opt,name=remote_max_value_in_flight_sat" json:"remote_max_value_in_flight_sat,omitempty"`
+	MinHtlcSat                int64  `protobuf:"varint,11,opt,name=min_htlc_sat" json:"min_htlc_sat,omitempty"`
+	RemoteMaxHtlcs            uint32 `protobuf:"varint,12,opt,name=remote_max_htlcs" json:"remote_max_htlcs,omitempty"`
+	// The address our funds are to be paid to upon a cooperative close of
+	// the channel. The address is committed to during the funding workflow,
+	// and any close paying elsewhere is rejected. If unset, the configured
+	// upfront shutdown address, or a fresh wallet address, is used.
+	CloseAddress string `protobuf:"bytes,13,opt,name=close_address" json:"close_address,omitempty"`
 }
 
 func (m *OpenChannelRequest) Reset()                    { *m = OpenChannelRequest{} }
 func (m *OpenChannelRequest) String() string            { return proto.CompactTextString(m) }
 func (*OpenChannelRequest) ProtoMessage()               {}
-func (*OpenChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+func (*OpenChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
 
 func (m *OpenChannelRequest) GetTargetPeerId() int32 {
 	if m != nil {
@@ -1188,6 +2408,55 @@ func (m *OpenChannelRequest) GetNumConfs() uint32 {
 	return 0
 }
 
+func (m *OpenChannelRequest) GetOutpoints() []*OutPoint {
+	if m != nil {
+		return m.Outpoints
+	}
+	return nil
+}
+
+func (m *OpenChannelRequest) GetCoinSelectionStrategy() CoinSelectionStrategy {
+	if m != nil {
+		return m.CoinSelectionStrategy
+	}
+	return CoinSelectionStrategy_STRATEGY_USE_GLOBAL_CONFIG
+}
+
+func (m *OpenChannelRequest) GetRemoteChanReserveSat() int64 {
+	if m != nil {
+		return m.RemoteChanReserveSat
+	}
+	return 0
+}
+
+func (m *OpenChannelRequest) GetRemoteMaxValueInFlightSat() int64 {
+	if m != nil {
+		return m.RemoteMaxValueInFlightSat
+	}
+	return 0
+}
+
+func (m *OpenChannelRequest) GetMinHtlcSat() int64 {
+	if m != nil {
+		return m.MinHtlcSat
+	}
+	return 0
+}
+
+func (m *OpenChannelRequest) GetRemoteMaxHtlcs() uint32 {
+	if m != nil {
+		return m.RemoteMaxHtlcs
+	}
+	return 0
+}
+
+func (m *OpenChannelRequest) GetCloseAddress() string {
+	if m != nil {
+		return m.CloseAddress
+	}
+	return ""
+}
+
 type OpenStatusUpdate struct {
 	// Types that are valid to be assigned to Update:
 	//	*OpenStatusUpdate_ChanPending
@@ -1199,7 +2468,7 @@ type OpenStatusUpdate struct {
 func (m *OpenStatusUpdate) Reset()                    { *m = OpenStatusUpdate{} }
 func (m *OpenStatusUpdate) String() string            { return proto.CompactTextString(m) }
 func (*OpenStatusUpdate) ProtoMessage()               {}
-func (*OpenStatusUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+func (*OpenStatusUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
 
 type isOpenStatusUpdate_Update interface {
 	isOpenStatusUpdate_Update()
@@ -1347,7 +2616,7 @@ type PendingChannelRequest struct {
 func (m *PendingChannelRequest) Reset()                    { *m = PendingChannelRequest{} }
 func (m *PendingChannelRequest) String() string            { return proto.CompactTextString(m) }
 func (*PendingChannelRequest) ProtoMessage()               {}
-func (*PendingChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+func (*PendingChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
 
 func (m *PendingChannelRequest) GetStatus() ChannelStatus {
 	if m != nil {
@@ -1363,7 +2632,7 @@ type PendingChannelResponse struct {
 func (m *PendingChannelResponse) Reset()                    { *m = PendingChannelResponse{} }
 func (m *PendingChannelResponse) String() string            { return proto.CompactTextString(m) }
 func (*PendingChannelResponse) ProtoMessage()               {}
-func (*PendingChannelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+func (*PendingChannelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
 
 func (m *PendingChannelResponse) GetPendingChannels() []*PendingChannelResponse_PendingChannel {
 	if m != nil {
@@ -1381,13 +2650,25 @@ type PendingChannelResponse_PendingChannel struct {
 	RemoteBalance int64         `protobuf:"varint,6,opt,name=remote_balance" json:"remote_balance,omitempty"`
 	ClosingTxid   string        `protobuf:"bytes,7,opt,name=closing_txid" json:"closing_txid,omitempty"`
 	Status        ChannelStatus `protobuf:"varint,8,opt,name=status,enum=lnrpc.ChannelStatus" json:"status,omitempty"`
+	// The funds of a closing channel within time-locked outputs yet
+	// to be swept.
+	LimboBalance int64 `protobuf:"varint,9,opt,name=limbo_balance" json:"limbo_balance,omitempty"`
+	// The height at which the last of the time-locked outputs
+	// matures, or zero if the closing transaction is unconfirmed.
+	MaturityHeight uint32 `protobuf:"varint,10,opt,name=maturity_height" json:"maturity_height,omitempty"`
+	// The number of blocks until the last of the time-locked outputs
+	// matures.
+	BlocksTilMaturity int32 `protobuf:"varint,11,opt,name=blocks_til_maturity" json:"blocks_til_maturity,omitempty"`
+	// The outgoing HTLC outputs of the closing transaction, awaiting
+	// their timeout.
+	PendingHtlcs []*PendingChannelResponse_PendingHtlc `protobuf:"bytes,12,rep,name=pending_htlcs" json:"pending_htlcs,omitempty"`
 }
 
 func (m *PendingChannelResponse_PendingChannel) Reset()         { *m = PendingChannelResponse_PendingChannel{} }
 func (m *PendingChannelResponse_PendingChannel) String() string { return proto.CompactTextString(m) }
 func (*PendingChannelResponse_PendingChannel) ProtoMessage()    {}
 func (*PendingChannelResponse_PendingChannel) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{34, 0}
+	return fileDescriptor0, []int{41, 0}
 }
 
 func (m *PendingChannelResponse_PendingChannel) GetPeerId() int32 {
@@ -1446,14 +2727,88 @@ func (m *PendingChannelResponse_PendingChannel) GetStatus() ChannelStatus {
 	return ChannelStatus_ALL
 }
 
-type WalletBalanceRequest struct {
-	WitnessOnly bool `protobuf:"varint,1,opt,name=witness_only" json:"witness_only,omitempty"`
-}
+func (m *PendingChannelResponse_PendingChannel) GetLimboBalance() int64 {
+	if m != nil {
+		return m.LimboBalance
+	}
+	return 0
+}
+
+func (m *PendingChannelResponse_PendingChannel) GetMaturityHeight() uint32 {
+	if m != nil {
+		return m.MaturityHeight
+	}
+	return 0
+}
+
+func (m *PendingChannelResponse_PendingChannel) GetBlocksTilMaturity() int32 {
+	if m != nil {
+		return m.BlocksTilMaturity
+	}
+	return 0
+}
+
+func (m *PendingChannelResponse_PendingChannel) GetPendingHtlcs() []*PendingChannelResponse_PendingHtlc {
+	if m != nil {
+		return m.PendingHtlcs
+	}
+	return nil
+}
+
+type PendingChannelResponse_PendingHtlc struct {
+	// The outpoint of the HTLC output.
+	Outpoint string `protobuf:"bytes,1,opt,name=outpoint" json:"outpoint,omitempty"`
+	Amount   int64  `protobuf:"varint,2,opt,name=amount" json:"amount,omitempty"`
+	// The height at which the HTLC output can be swept, or zero if
+	// the closing transaction is unconfirmed.
+	MaturityHeight uint32 `protobuf:"varint,3,opt,name=maturity_height" json:"maturity_height,omitempty"`
+	// The number of blocks until the HTLC output can be swept.
+	BlocksTilMaturity int32 `protobuf:"varint,4,opt,name=blocks_til_maturity" json:"blocks_til_maturity,omitempty"`
+}
+
+func (m *PendingChannelResponse_PendingHtlc) Reset()         { *m = PendingChannelResponse_PendingHtlc{} }
+func (m *PendingChannelResponse_PendingHtlc) String() string { return proto.CompactTextString(m) }
+func (*PendingChannelResponse_PendingHtlc) ProtoMessage()    {}
+func (*PendingChannelResponse_PendingHtlc) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{41, 1}
+}
+
+func (m *PendingChannelResponse_PendingHtlc) GetOutpoint() string {
+	if m != nil {
+		return m.Outpoint
+	}
+	return ""
+}
+
+func (m *PendingChannelResponse_PendingHtlc) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *PendingChannelResponse_PendingHtlc) GetMaturityHeight() uint32 {
+	if m != nil {
+		return m.MaturityHeight
+	}
+	return 0
+}
+
+func (m *PendingChannelResponse_PendingHtlc) GetBlocksTilMaturity() int32 {
+	if m != nil {
+		return m.BlocksTilMaturity
+	}
+	return 0
+}
+
+type WalletBalanceRequest struct {
+	WitnessOnly bool `protobuf:"varint,1,opt,name=witness_only" json:"witness_only,omitempty"`
+}
 
 func (m *WalletBalanceRequest) Reset()                    { *m = WalletBalanceRequest{} }
 func (m *WalletBalanceRequest) String() string            { return proto.CompactTextString(m) }
 func (*WalletBalanceRequest) ProtoMessage()               {}
-func (*WalletBalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+func (*WalletBalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
 
 func (m *WalletBalanceRequest) GetWitnessOnly() bool {
 	if m != nil {
@@ -1463,13 +2818,23 @@ func (m *WalletBalanceRequest) GetWitnessOnly() bool {
 }
 
 type WalletBalanceResponse struct {
+	// The confirmed balance of the wallet in BTC.
 	Balance float64 `protobuf:"fixed64,1,opt,name=balance" json:"balance,omitempty"`
+	// The balances of the wallet in satoshis: the total of its unspent outputs,
+	// those of them with at least one confirmation, and those without any.
+	TotalBalance       int64 `protobuf:"varint,2,opt,name=total_balance" json:"total_balance,omitempty"`
+	ConfirmedBalance   int64 `protobuf:"varint,3,opt,name=confirmed_balance" json:"confirmed_balance,omitempty"`
+	UnconfirmedBalance int64 `protobuf:"varint,4,opt,name=unconfirmed_balance" json:"unconfirmed_balance,omitempty"`
+	// The portion of the total balance within outputs which are leased, or
+	// reserved to fund pending channels, and therefore unavailable for coin
+	// selection.
+	LockedBalance int64 `protobuf:"varint,5,opt,name=locked_balance" json:"locked_balance,omitempty"`
 }
 
 func (m *WalletBalanceResponse) Reset()                    { *m = WalletBalanceResponse{} }
 func (m *WalletBalanceResponse) String() string            { return proto.CompactTextString(m) }
 func (*WalletBalanceResponse) ProtoMessage()               {}
-func (*WalletBalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+func (*WalletBalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
 
 func (m *WalletBalanceResponse) GetBalance() float64 {
 	if m != nil {
@@ -1478,22 +2843,65 @@ func (m *WalletBalanceResponse) GetBalance() float64 {
 	return 0
 }
 
+func (m *WalletBalanceResponse) GetTotalBalance() int64 {
+	if m != nil {
+		return m.TotalBalance
+	}
+	return 0
+}
+
+func (m *WalletBalanceResponse) GetConfirmedBalance() int64 {
+	if m != nil {
+		return m.ConfirmedBalance
+	}
+	return 0
+}
+
+func (m *WalletBalanceResponse) GetUnconfirmedBalance() int64 {
+	if m != nil {
+		return m.UnconfirmedBalance
+	}
+	return 0
+}
+
+func (m *WalletBalanceResponse) GetLockedBalance() int64 {
+	if m != nil {
+		return m.LockedBalance
+	}
+	return 0
+}
+
 type ChannelBalanceRequest struct {
 }
 
 func (m *ChannelBalanceRequest) Reset()                    { *m = ChannelBalanceRequest{} }
 func (m *ChannelBalanceRequest) String() string            { return proto.CompactTextString(m) }
 func (*ChannelBalanceRequest) ProtoMessage()               {}
-func (*ChannelBalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+func (*ChannelBalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
 
 type ChannelBalanceResponse struct {
+	// The settled local balance of our open channels.
 	Balance int64 `protobuf:"varint,1,opt,name=balance" json:"balance,omitempty"`
+	// The settled balances of our open channels on either side, excluding the
+	// pending HTLCs and the commitment fees.
+	LocalBalance  int64 `protobuf:"varint,2,opt,name=local_balance" json:"local_balance,omitempty"`
+	RemoteBalance int64 `protobuf:"varint,3,opt,name=remote_balance" json:"remote_balance,omitempty"`
+	// The total amounts of the pending HTLCs we've offered, and of those
+	// offered to us.
+	UnsettledLocalBalance  int64 `protobuf:"varint,4,opt,name=unsettled_local_balance" json:"unsettled_local_balance,omitempty"`
+	UnsettledRemoteBalance int64 `protobuf:"varint,5,opt,name=unsettled_remote_balance" json:"unsettled_remote_balance,omitempty"`
+	// The balances of the channels yet to be fully opened on either side.
+	PendingOpenLocalBalance  int64 `protobuf:"varint,6,opt,name=pending_open_local_balance" json:"pending_open_local_balance,omitempty"`
+	PendingOpenRemoteBalance int64 `protobuf:"varint,7,opt,name=pending_open_remote_balance" json:"pending_open_remote_balance,omitempty"`
+	// The balances of our open channels split by their commitment type. Channels
+	// yet to be fully opened aren't included.
+	CommitmentTypeBalances []*CommitmentTypeBalance `protobuf:"bytes,8,rep,name=commitment_type_balances" json:"commitment_type_balances,omitempty"`
 }
 
 func (m *ChannelBalanceResponse) Reset()                    { *m = ChannelBalanceResponse{} }
 func (m *ChannelBalanceResponse) String() string            { return proto.CompactTextString(m) }
 func (*ChannelBalanceResponse) ProtoMessage()               {}
-func (*ChannelBalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+func (*ChannelBalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
 
 func (m *ChannelBalanceResponse) GetBalance() int64 {
 	if m != nil {
@@ -1502,6 +2910,55 @@ func (m *ChannelBalanceResponse) GetBalance() int64 {
 	return 0
 }
 
+func (m *ChannelBalanceResponse) GetLocalBalance() int64 {
+	if m != nil {
+		return m.LocalBalance
+	}
+	return 0
+}
+
+func (m *ChannelBalanceResponse) GetRemoteBalance() int64 {
+	if m != nil {
+		return m.RemoteBalance
+	}
+	return 0
+}
+
+func (m *ChannelBalanceResponse) GetUnsettledLocalBalance() int64 {
+	if m != nil {
+		return m.UnsettledLocalBalance
+	}
+	return 0
+}
+
+func (m *ChannelBalanceResponse) GetUnsettledRemoteBalance() int64 {
+	if m != nil {
+		return m.UnsettledRemoteBalance
+	}
+	return 0
+}
+
+func (m *ChannelBalanceResponse) GetPendingOpenLocalBalance() int64 {
+	if m != nil {
+		return m.PendingOpenLocalBalance
+	}
+	return 0
+}
+
+func (m *ChannelBalanceResponse) GetPendingOpenRemoteBalance() int64 {
+	if m != nil {
+		return m.PendingOpenRemoteBalance
+	}
+	return 0
+}
+
+func (m *ChannelBalanceResponse) GetCommitmentTypeBalances() []*CommitmentTypeBalance {
+	if m != nil {
+		return m.CommitmentTypeBalances
+	}
+	return nil
+}
+
 type RouteRequest struct {
 	PubKey string `protobuf:"bytes,1,opt,name=pub_key" json:"pub_key,omitempty"`
 	Amt    int64  `protobuf:"varint,2,opt,name=amt" json:"amt,omitempty"`
@@ -1510,7 +2967,7 @@ type RouteRequest struct {
 func (m *RouteRequest) Reset()                    { *m = RouteRequest{} }
 func (m *RouteRequest) String() string            { return proto.CompactTextString(m) }
 func (*RouteRequest) ProtoMessage()               {}
-func (*RouteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+func (*RouteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
 
 func (m *RouteRequest) GetPubKey() string {
 	if m != nil {
@@ -1531,12 +2988,14 @@ type Hop struct {
 	ChanCapacity int64  `protobuf:"varint,2,opt,name=chan_capacity" json:"chan_capacity,omitempty"`
 	AmtToForward int64  `protobuf:"varint,3,opt,name=amt_to_forward" json:"amt_to_forward,omitempty"`
 	Fee          int64  `protobuf:"varint,4,opt,name=fee" json:"fee,omitempty"`
+	// The channel ID in its human readable BLOCKxTXxOUT form.
+	ChanIdStr string `protobuf:"bytes,5,opt,name=chan_id_str" json:"chan_id_str,omitempty"`
 }
 
 func (m *Hop) Reset()                    { *m = Hop{} }
 func (m *Hop) String() string            { return proto.CompactTextString(m) }
 func (*Hop) ProtoMessage()               {}
-func (*Hop) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+func (*Hop) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
 
 func (m *Hop) GetChanId() uint64 {
 	if m != nil {
@@ -1566,6 +3025,13 @@ func (m *Hop) GetFee() int64 {
 	return 0
 }
 
+func (m *Hop) GetChanIdStr() string {
+	if m != nil {
+		return m.ChanIdStr
+	}
+	return ""
+}
+
 type Route struct {
 	TotalTimeLock uint32 `protobuf:"varint,1,opt,name=total_time_lock" json:"total_time_lock,omitempty"`
 	TotalFees     int64  `protobuf:"varint,2,opt,name=total_fees" json:"total_fees,omitempty"`
@@ -1576,7 +3042,7 @@ type Route struct {
 func (m *Route) Reset()                    { *m = Route{} }
 func (m *Route) String() string            { return proto.CompactTextString(m) }
 func (*Route) ProtoMessage()               {}
-func (*Route) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+func (*Route) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
 
 func (m *Route) GetTotalTimeLock() uint32 {
 	if m != nil {
@@ -1613,7 +3079,7 @@ type NodeInfoRequest struct {
 func (m *NodeInfoRequest) Reset()                    { *m = NodeInfoRequest{} }
 func (m *NodeInfoRequest) String() string            { return proto.CompactTextString(m) }
 func (*NodeInfoRequest) ProtoMessage()               {}
-func (*NodeInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+func (*NodeInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
 
 func (m *NodeInfoRequest) GetPubKey() string {
 	if m != nil {
@@ -1631,7 +3097,7 @@ type NodeInfo struct {
 func (m *NodeInfo) Reset()                    { *m = NodeInfo{} }
 func (m *NodeInfo) String() string            { return proto.CompactTextString(m) }
 func (*NodeInfo) ProtoMessage()               {}
-func (*NodeInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+func (*NodeInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
 
 func (m *NodeInfo) GetNode() *LightningNode {
 	if m != nil {
@@ -1659,12 +3125,20 @@ type LightningNode struct {
 	PubKey     string `protobuf:"bytes,2,opt,name=pub_key" json:"pub_key,omitempty"`
 	Address    string `protobuf:"bytes,3,opt,name=address" json:"address,omitempty"`
 	Alias      string `protobuf:"bytes,4,opt,name=alias" json:"alias,omitempty"`
+	// All addresses the node has advertised.
+	Addresses []*NodeAddress `protobuf:"bytes,5,rep,name=addresses" json:"addresses,omitempty"`
+	// The node's color, as an RGB hex string.
+	Color string `protobuf:"bytes,6,opt,name=color" json:"color,omitempty"`
+	// The global features of the node. As node announcements don't carry
+	// feature bits, they're only known for our own node, and for the nodes
+	// we're connected to from their init message.
+	Features []*Feature `protobuf:"bytes,7,rep,name=features" json:"features,omitempty"`
 }
 
 func (m *LightningNode) Reset()                    { *m = LightningNode{} }
 func (m *LightningNode) String() string            { return proto.CompactTextString(m) }
 func (*LightningNode) ProtoMessage()               {}
-func (*LightningNode) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+func (*LightningNode) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
 
 func (m *LightningNode) GetLastUpdate() uint32 {
 	if m != nil {
@@ -1694,17 +3168,79 @@ func (m *LightningNode) GetAlias() string {
 	return ""
 }
 
+func (m *LightningNode) GetAddresses() []*NodeAddress {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+func (m *LightningNode) GetColor() string {
+	if m != nil {
+		return m.Color
+	}
+	return ""
+}
+
+func (m *LightningNode) GetFeatures() []*Feature {
+	if m != nil {
+		return m.Features
+	}
+	return nil
+}
+
+type NodeAddress struct {
+	Network string `protobuf:"bytes,1,opt,name=network" json:"network,omitempty"`
+	Addr    string `protobuf:"bytes,2,opt,name=addr" json:"addr,omitempty"`
+	// The last time the address was seen, either within an announcement of
+	// the node, or as the address of our connection to it.
+	LastSeen uint32 `protobuf:"varint,3,opt,name=last_seen" json:"last_seen,omitempty"`
+}
+
+func (m *NodeAddress) Reset()                    { *m = NodeAddress{} }
+func (m *NodeAddress) String() string            { return proto.CompactTextString(m) }
+func (*NodeAddress) ProtoMessage()               {}
+func (*NodeAddress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+
+func (m *NodeAddress) GetNetwork() string {
+	if m != nil {
+		return m.Network
+	}
+	return ""
+}
+
+func (m *NodeAddress) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+func (m *NodeAddress) GetLastSeen() uint32 {
+	if m != nil {
+		return m.LastSeen
+	}
+	return 0
+}
+
 type RoutingPolicy struct {
 	TimeLockDelta    uint32 `protobuf:"varint,1,opt,name=time_lock_delta" json:"time_lock_delta,omitempty"`
 	MinHtlc          int64  `protobuf:"varint,2,opt,name=min_htlc" json:"min_htlc,omitempty"`
 	FeeBaseMsat      int64  `protobuf:"varint,3,opt,name=fee_base_msat" json:"fee_base_msat,omitempty"`
 	FeeRateMilliMsat int64  `protobuf:"varint,4,opt,name=fee_rate_milli_msat" json:"fee_rate_milli_msat,omitempty"`
+	// The fees charged by the node for HTLCs entering it through the
+	// channel. These are added to the fees of the channel the HTLC is
+	// forwarded over, and may be negative to offer a discount.
+	InboundFeeBaseMsat      int64 `protobuf:"varint,5,opt,name=inbound_fee_base_msat" json:"inbound_fee_base_msat,omitempty"`
+	InboundFeeRateMilliMsat int64 `protobuf:"varint,6,opt,name=inbound_fee_rate_milli_msat" json:"inbound_fee_rate_milli_msat,omitempty"`
+	// Whether the node has disabled forwarding over the channel.
+	Disabled bool `protobuf:"varint,7,opt,name=disabled" json:"disabled,omitempty"`
 }
 
 func (m *RoutingPolicy) Reset()                    { *m = RoutingPolicy{} }
 func (m *RoutingPolicy) String() string            { return proto.CompactTextString(m) }
 func (*RoutingPolicy) ProtoMessage()               {}
-func (*RoutingPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+func (*RoutingPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
 
 func (m *RoutingPolicy) GetTimeLockDelta() uint32 {
 	if m != nil {
@@ -1734,6 +3270,27 @@ func (m *RoutingPolicy) GetFeeRateMilliMsat() int64 {
 	return 0
 }
 
+func (m *RoutingPolicy) GetInboundFeeBaseMsat() int64 {
+	if m != nil {
+		return m.InboundFeeBaseMsat
+	}
+	return 0
+}
+
+func (m *RoutingPolicy) GetInboundFeeRateMilliMsat() int64 {
+	if m != nil {
+		return m.InboundFeeRateMilliMsat
+	}
+	return 0
+}
+
+func (m *RoutingPolicy) GetDisabled() bool {
+	if m != nil {
+		return m.Disabled
+	}
+	return false
+}
+
 type ChannelEdge struct {
 	ChannelId   uint64         `protobuf:"varint,1,opt,name=channel_id" json:"channel_id,omitempty"`
 	ChanPoint   string         `protobuf:"bytes,2,opt,name=chan_point" json:"chan_point,omitempty"`
@@ -1743,12 +3300,16 @@ type ChannelEdge struct {
 	Capacity    int64          `protobuf:"varint,6,opt,name=capacity" json:"capacity,omitempty"`
 	Node1Policy *RoutingPolicy `protobuf:"bytes,7,opt,name=node1_policy" json:"node1_policy,omitempty"`
 	Node2Policy *RoutingPolicy `protobuf:"bytes,8,opt,name=node2_policy" json:"node2_policy,omitempty"`
+	// The channel ID in its human readable BLOCKxTXxOUT form.
+	ChannelIdStr string `protobuf:"bytes,9,opt,name=channel_id_str" json:"channel_id_str,omitempty"`
+	// The hex encoded 32-byte channel ID, derived from the channel point.
+	FullChanId string `protobuf:"bytes,10,opt,name=full_chan_id" json:"full_chan_id,omitempty"`
 }
 
 func (m *ChannelEdge) Reset()                    { *m = ChannelEdge{} }
 func (m *ChannelEdge) String() string            { return proto.CompactTextString(m) }
 func (*ChannelEdge) ProtoMessage()               {}
-func (*ChannelEdge) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+func (*ChannelEdge) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
 
 func (m *ChannelEdge) GetChannelId() uint64 {
 	if m != nil {
@@ -1806,13 +3367,27 @@ func (m *ChannelEdge) GetNode2Policy() *RoutingPolicy {
 	return nil
 }
 
+func (m *ChannelEdge) GetChannelIdStr() string {
+	if m != nil {
+		return m.ChannelIdStr
+	}
+	return ""
+}
+
+func (m *ChannelEdge) GetFullChanId() string {
+	if m != nil {
+		return m.FullChanId
+	}
+	return ""
+}
+
 type ChannelGraphRequest struct {
 }
 
 func (m *ChannelGraphRequest) Reset()                    { *m = ChannelGraphRequest{} }
 func (m *ChannelGraphRequest) String() string            { return proto.CompactTextString(m) }
 func (*ChannelGraphRequest) ProtoMessage()               {}
-func (*ChannelGraphRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+func (*ChannelGraphRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
 
 type ChannelGraph struct {
 	Nodes []*LightningNode `protobuf:"bytes,1,rep,name=nodes" json:"nodes,omitempty"`
@@ -1822,7 +3397,7 @@ type ChannelGraph struct {
 func (m *ChannelGraph) Reset()                    { *m = ChannelGraph{} }
 func (m *ChannelGraph) String() string            { return proto.CompactTextString(m) }
 func (*ChannelGraph) ProtoMessage()               {}
-func (*ChannelGraph) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+func (*ChannelGraph) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
 
 func (m *ChannelGraph) GetNodes() []*LightningNode {
 	if m != nil {
@@ -1839,13 +3414,20 @@ func (m *ChannelGraph) GetEdges() []*ChannelEdge {
 }
 
 type ChanInfoRequest struct {
+	// Exactly one of the following identifiers of the channel must be set.
 	ChanId uint64 `protobuf:"varint,1,opt,name=chan_id" json:"chan_id,omitempty"`
+	// The channel ID in its human readable BLOCKxTXxOUT form.
+	ChanIdStr string `protobuf:"bytes,2,opt,name=chan_id_str" json:"chan_id_str,omitempty"`
+	// The funding outpoint of the channel.
+	ChanPoint *ChannelPoint `protobuf:"bytes,3,opt,name=chan_point" json:"chan_point,omitempty"`
+	// The hex encoded 32-byte channel ID.
+	FullChanId string `protobuf:"bytes,4,opt,name=full_chan_id" json:"full_chan_id,omitempty"`
 }
 
 func (m *ChanInfoRequest) Reset()                    { *m = ChanInfoRequest{} }
 func (m *ChanInfoRequest) String() string            { return proto.CompactTextString(m) }
 func (*ChanInfoRequest) ProtoMessage()               {}
-func (*ChanInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+func (*ChanInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
 
 func (m *ChanInfoRequest) GetChanId() uint64 {
 	if m != nil {
@@ -1854,13 +3436,34 @@ func (m *ChanInfoRequest) GetChanId() uint64 {
 	return 0
 }
 
+func (m *ChanInfoRequest) GetChanIdStr() string {
+	if m != nil {
+		return m.ChanIdStr
+	}
+	return ""
+}
+
+func (m *ChanInfoRequest) GetChanPoint() *ChannelPoint {
+	if m != nil {
+		return m.ChanPoint
+	}
+	return nil
+}
+
+func (m *ChanInfoRequest) GetFullChanId() string {
+	if m != nil {
+		return m.FullChanId
+	}
+	return ""
+}
+
 type NetworkInfoRequest struct {
 }
 
 func (m *NetworkInfoRequest) Reset()                    { *m = NetworkInfoRequest{} }
 func (m *NetworkInfoRequest) String() string            { return proto.CompactTextString(m) }
 func (*NetworkInfoRequest) ProtoMessage()               {}
-func (*NetworkInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+func (*NetworkInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
 
 type NetworkInfo struct {
 	GraphDiameter        uint32  `protobuf:"varint,1,opt,name=graph_diameter" json:"graph_diameter,omitempty"`
@@ -1872,12 +3475,19 @@ type NetworkInfo struct {
 	AvgChannelSize       float64 `protobuf:"fixed64,7,opt,name=avg_channel_size" json:"avg_channel_size,omitempty"`
 	MinChannelSize       int64   `protobuf:"varint,8,opt,name=min_channel_size" json:"min_channel_size,omitempty"`
 	MaxChannelSize       int64   `protobuf:"varint,9,opt,name=max_channel_size" json:"max_channel_size,omitempty"`
+	// The median capacity of the channels within the graph.
+	MedianChannelSize int64 `protobuf:"varint,10,opt,name=median_channel_size" json:"median_channel_size,omitempty"`
+	// The number of nodes with no channels within the graph.
+	NumIsolatedNodes uint32 `protobuf:"varint,11,opt,name=num_isolated_nodes" json:"num_isolated_nodes,omitempty"`
+	// The number of nodes having each out-degree, in increasing order of
+	// out-degree.
+	OutDegreeDistribution []*DegreeCount `protobuf:"bytes,12,rep,name=out_degree_distribution" json:"out_degree_distribution,omitempty"`
 }
 
 func (m *NetworkInfo) Reset()                    { *m = NetworkInfo{} }
 func (m *NetworkInfo) String() string            { return proto.CompactTextString(m) }
 func (*NetworkInfo) ProtoMessage()               {}
-func (*NetworkInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+func (*NetworkInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
 
 func (m *NetworkInfo) GetGraphDiameter() uint32 {
 	if m != nil {
@@ -1942,93 +3552,455 @@ func (m *NetworkInfo) GetMaxChannelSize() int64 {
 	return 0
 }
 
-type SetAliasRequest struct {
-	NewAlias string `protobuf:"bytes,1,opt,name=new_alias" json:"new_alias,omitempty"`
+func (m *NetworkInfo) GetMedianChannelSize() int64 {
+	if m != nil {
+		return m.MedianChannelSize
+	}
+	return 0
 }
 
-func (m *SetAliasRequest) Reset()                    { *m = SetAliasRequest{} }
-func (m *SetAliasRequest) String() string            { return proto.CompactTextString(m) }
-func (*SetAliasRequest) ProtoMessage()               {}
-func (*SetAliasRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+func (m *NetworkInfo) GetNumIsolatedNodes() uint32 {
+	if m != nil {
+		return m.NumIsolatedNodes
+	}
+	return 0
+}
 
-func (m *SetAliasRequest) GetNewAlias() string {
+func (m *NetworkInfo) GetOutDegreeDistribution() []*DegreeCount {
 	if m != nil {
-		return m.NewAlias
+		return m.OutDegreeDistribution
 	}
-	return ""
+	return nil
 }
 
-type SetAliasResponse struct {
+type DegreeCount struct {
+	OutDegree uint32 `protobuf:"varint,1,opt,name=out_degree" json:"out_degree,omitempty"`
+	NumNodes  uint32 `protobuf:"varint,2,opt,name=num_nodes" json:"num_nodes,omitempty"`
 }
 
-func (m *SetAliasResponse) Reset()                    { *m = SetAliasResponse{} }
-func (m *SetAliasResponse) String() string            { return proto.CompactTextString(m) }
-func (*SetAliasResponse) ProtoMessage()               {}
-func (*SetAliasResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+func (m *DegreeCount) Reset()                    { *m = DegreeCount{} }
+func (m *DegreeCount) String() string            { return proto.CompactTextString(m) }
+func (*DegreeCount) ProtoMessage()               {}
+func (*DegreeCount) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
 
-type Invoice struct {
-	Memo         string `protobuf:"bytes,1,opt,name=memo" json:"memo,omitempty"`
-	Receipt      []byte `protobuf:"bytes,2,opt,name=receipt,proto3" json:"receipt,omitempty"`
-	RPreimage    []byte `protobuf:"bytes,3,opt,name=r_preimage,proto3" json:"r_preimage,omitempty"`
-	RHash        []byte `protobuf:"bytes,4,opt,name=r_hash,proto3" json:"r_hash,omitempty"`
-	Value        int64  `protobuf:"varint,5,opt,name=value" json:"value,omitempty"`
-	Settled      bool   `protobuf:"varint,6,opt,name=settled" json:"settled,omitempty"`
-	CreationDate int64  `protobuf:"varint,7,opt,name=creation_date" json:"creation_date,omitempty"`
-	SettleDate   int64  `protobuf:"varint,8,opt,name=settle_date" json:"settle_date,omitempty"`
+func (m *DegreeCount) GetOutDegree() uint32 {
+	if m != nil {
+		return m.OutDegree
+	}
+	return 0
 }
 
-func (m *Invoice) Reset()                    { *m = Invoice{} }
-func (m *Invoice) String() string            { return proto.CompactTextString(m) }
-func (*Invoice) ProtoMessage()               {}
-func (*Invoice) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
-
-func (m *Invoice) GetMemo() string {
+func (m *DegreeCount) GetNumNodes() uint32 {
 	if m != nil {
-		return m.Memo
+		return m.NumNodes
 	}
-	return ""
+	return 0
 }
 
-func (m *Invoice) GetReceipt() []byte {
+type GraphTopologySubscription struct {
+}
+
+func (m *GraphTopologySubscription) Reset()                    { *m = GraphTopologySubscription{} }
+func (m *GraphTopologySubscription) String() string            { return proto.CompactTextString(m) }
+func (*GraphTopologySubscription) ProtoMessage()               {}
+func (*GraphTopologySubscription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
+
+type GraphTopologyUpdate struct {
+	NodeUpdates    []*NodeUpdate          `protobuf:"bytes,1,rep,name=node_updates" json:"node_updates,omitempty"`
+	ChannelUpdates []*ChannelEdgeUpdate   `protobuf:"bytes,2,rep,name=channel_updates" json:"channel_updates,omitempty"`
+	ClosedChans    []*ClosedChannelUpdate `protobuf:"bytes,3,rep,name=closed_chans" json:"closed_chans,omitempty"`
+}
+
+func (m *GraphTopologyUpdate) Reset()                    { *m = GraphTopologyUpdate{} }
+func (m *GraphTopologyUpdate) String() string            { return proto.CompactTextString(m) }
+func (*GraphTopologyUpdate) ProtoMessage()               {}
+func (*GraphTopologyUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
+
+func (m *GraphTopologyUpdate) GetNodeUpdates() []*NodeUpdate {
 	if m != nil {
-		return m.Receipt
+		return m.NodeUpdates
 	}
 	return nil
 }
 
-func (m *Invoice) GetRPreimage() []byte {
+func (m *GraphTopologyUpdate) GetChannelUpdates() []*ChannelEdgeUpdate {
 	if m != nil {
-		return m.RPreimage
+		return m.ChannelUpdates
 	}
 	return nil
 }
 
-func (m *Invoice) GetRHash() []byte {
+func (m *GraphTopologyUpdate) GetClosedChans() []*ClosedChannelUpdate {
 	if m != nil {
-		return m.RHash
+		return m.ClosedChans
 	}
 	return nil
 }
 
-func (m *Invoice) GetValue() int64 {
+type NodeUpdate struct {
+	Addresses   []string `protobuf:"bytes,1,rep,name=addresses" json:"addresses,omitempty"`
+	IdentityKey string   `protobuf:"bytes,2,opt,name=identity_key" json:"identity_key,omitempty"`
+	Alias       string   `protobuf:"bytes,3,opt,name=alias" json:"alias,omitempty"`
+	Color       string   `protobuf:"bytes,4,opt,name=color" json:"color,omitempty"`
+	// The global features of the node, if known. As for LightningNode,
+	// they're only known for our own node and the nodes we're connected to.
+	Features []*Feature `protobuf:"bytes,5,rep,name=features" json:"features,omitempty"`
+}
+
+func (m *NodeUpdate) Reset()                    { *m = NodeUpdate{} }
+func (m *NodeUpdate) String() string            { return proto.CompactTextString(m) }
+func (*NodeUpdate) ProtoMessage()               {}
+func (*NodeUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{63} }
+
+func (m *NodeUpdate) GetAddresses() []string {
 	if m != nil {
-		return m.Value
+		return m.Addresses
 	}
-	return 0
+	return nil
 }
 
-func (m *Invoice) GetSettled() bool {
+func (m *NodeUpdate) GetIdentityKey() string {
 	if m != nil {
-		return m.Settled
+		return m.IdentityKey
 	}
-	return false
+	return ""
 }
 
-func (m *Invoice) GetCreationDate() int64 {
+func (m *NodeUpdate) GetAlias() string {
 	if m != nil {
-		return m.CreationDate
+		return m.Alias
 	}
-	return 0
+	return ""
+}
+
+func (m *NodeUpdate) GetColor() string {
+	if m != nil {
+		return m.Color
+	}
+	return ""
+}
+
+func (m *NodeUpdate) GetFeatures() []*Feature {
+	if m != nil {
+		return m.Features
+	}
+	return nil
+}
+
+type ChannelEdgeUpdate struct {
+	ChanId          uint64         `protobuf:"varint,1,opt,name=chan_id" json:"chan_id,omitempty"`
+	ChanPoint       *ChannelPoint  `protobuf:"bytes,2,opt,name=chan_point" json:"chan_point,omitempty"`
+	Capacity        int64          `protobuf:"varint,3,opt,name=capacity" json:"capacity,omitempty"`
+	RoutingPolicy   *RoutingPolicy `protobuf:"bytes,4,opt,name=routing_policy" json:"routing_policy,omitempty"`
+	AdvertisingNode string         `protobuf:"bytes,5,opt,name=advertising_node" json:"advertising_node,omitempty"`
+	ConnectingNode  string         `protobuf:"bytes,6,opt,name=connecting_node" json:"connecting_node,omitempty"`
+	// The channel ID in its human readable BLOCKxTXxOUT form.
+	ChanIdStr string `protobuf:"bytes,7,opt,name=chan_id_str" json:"chan_id_str,omitempty"`
+	// The hex encoded 32-byte channel ID, derived from the channel point.
+	FullChanId string `protobuf:"bytes,8,opt,name=full_chan_id" json:"full_chan_id,omitempty"`
+}
+
+func (m *ChannelEdgeUpdate) Reset()                    { *m = ChannelEdgeUpdate{} }
+func (m *ChannelEdgeUpdate) String() string            { return proto.CompactTextString(m) }
+func (*ChannelEdgeUpdate) ProtoMessage()               {}
+func (*ChannelEdgeUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{64} }
+
+func (m *ChannelEdgeUpdate) GetChanId() uint64 {
+	if m != nil {
+		return m.ChanId
+	}
+	return 0
+}
+
+func (m *ChannelEdgeUpdate) GetChanPoint() *ChannelPoint {
+	if m != nil {
+		return m.ChanPoint
+	}
+	return nil
+}
+
+func (m *ChannelEdgeUpdate) GetCapacity() int64 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+func (m *ChannelEdgeUpdate) GetRoutingPolicy() *RoutingPolicy {
+	if m != nil {
+		return m.RoutingPolicy
+	}
+	return nil
+}
+
+func (m *ChannelEdgeUpdate) GetAdvertisingNode() string {
+	if m != nil {
+		return m.AdvertisingNode
+	}
+	return ""
+}
+
+func (m *ChannelEdgeUpdate) GetConnectingNode() string {
+	if m != nil {
+		return m.ConnectingNode
+	}
+	return ""
+}
+
+func (m *ChannelEdgeUpdate) GetChanIdStr() string {
+	if m != nil {
+		return m.ChanIdStr
+	}
+	return ""
+}
+
+func (m *ChannelEdgeUpdate) GetFullChanId() string {
+	if m != nil {
+		return m.FullChanId
+	}
+	return ""
+}
+
+type ClosedChannelUpdate struct {
+	ChanId       uint64        `protobuf:"varint,1,opt,name=chan_id" json:"chan_id,omitempty"`
+	Capacity     int64         `protobuf:"varint,2,opt,name=capacity" json:"capacity,omitempty"`
+	ClosedHeight uint32        `protobuf:"varint,3,opt,name=closed_height" json:"closed_height,omitempty"`
+	ChanPoint    *ChannelPoint `protobuf:"bytes,4,opt,name=chan_point" json:"chan_point,omitempty"`
+	// The channel ID in its human readable BLOCKxTXxOUT form.
+	ChanIdStr string `protobuf:"bytes,5,opt,name=chan_id_str" json:"chan_id_str,omitempty"`
+	// The hex encoded 32-byte channel ID, derived from the channel point.
+	FullChanId string `protobuf:"bytes,6,opt,name=full_chan_id" json:"full_chan_id,omitempty"`
+}
+
+func (m *ClosedChannelUpdate) Reset()                    { *m = ClosedChannelUpdate{} }
+func (m *ClosedChannelUpdate) String() string            { return proto.CompactTextString(m) }
+func (*ClosedChannelUpdate) ProtoMessage()               {}
+func (*ClosedChannelUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{65} }
+
+func (m *ClosedChannelUpdate) GetChanId() uint64 {
+	if m != nil {
+		return m.ChanId
+	}
+	return 0
+}
+
+func (m *ClosedChannelUpdate) GetCapacity() int64 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+func (m *ClosedChannelUpdate) GetClosedHeight() uint32 {
+	if m != nil {
+		return m.ClosedHeight
+	}
+	return 0
+}
+
+func (m *ClosedChannelUpdate) GetChanPoint() *ChannelPoint {
+	if m != nil {
+		return m.ChanPoint
+	}
+	return nil
+}
+
+func (m *ClosedChannelUpdate) GetChanIdStr() string {
+	if m != nil {
+		return m.ChanIdStr
+	}
+	return ""
+}
+
+func (m *ClosedChannelUpdate) GetFullChanId() string {
+	if m != nil {
+		return m.FullChanId
+	}
+	return ""
+}
+
+type SetAliasRequest struct {
+	NewAlias string `protobuf:"bytes,1,opt,name=new_alias" json:"new_alias,omitempty"`
+}
+
+func (m *SetAliasRequest) Reset()                    { *m = SetAliasRequest{} }
+func (m *SetAliasRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetAliasRequest) ProtoMessage()               {}
+func (*SetAliasRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{66} }
+
+func (m *SetAliasRequest) GetNewAlias() string {
+	if m != nil {
+		return m.NewAlias
+	}
+	return ""
+}
+
+type SetAliasResponse struct {
+}
+
+func (m *SetAliasResponse) Reset()                    { *m = SetAliasResponse{} }
+func (m *SetAliasResponse) String() string            { return proto.CompactTextString(m) }
+func (*SetAliasResponse) ProtoMessage()               {}
+func (*SetAliasResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{67} }
+
+type UpdateFeatureAction struct {
+	Action     UpdateFeatureAction_Action `protobuf:"varint,1,opt,name=action,enum=lnrpc.UpdateFeatureAction_Action" json:"action,omitempty"`
+	FeatureBit uint32                     `protobuf:"varint,2,opt,name=feature_bit" json:"feature_bit,omitempty"`
+}
+
+func (m *UpdateFeatureAction) Reset()                    { *m = UpdateFeatureAction{} }
+func (m *UpdateFeatureAction) String() string            { return proto.CompactTextString(m) }
+func (*UpdateFeatureAction) ProtoMessage()               {}
+func (*UpdateFeatureAction) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{68} }
+
+func (m *UpdateFeatureAction) GetAction() UpdateFeatureAction_Action {
+	if m != nil {
+		return m.Action
+	}
+	return UpdateFeatureAction_ADD
+}
+
+func (m *UpdateFeatureAction) GetFeatureBit() uint32 {
+	if m != nil {
+		return m.FeatureBit
+	}
+	return 0
+}
+
+type NodeAnnouncementUpdateRequest struct {
+	// The feature bits to set or unset within our node announcement
+	// feature set.
+	FeatureUpdates []*UpdateFeatureAction `protobuf:"bytes,1,rep,name=feature_updates" json:"feature_updates,omitempty"`
+	// The new color of the node, in the hex format #rrggbb. If empty, the
+	// color is left unchanged.
+	Color string `protobuf:"bytes,2,opt,name=color" json:"color,omitempty"`
+	// The new alias of the node. If empty, the alias is left unchanged.
+	Alias string `protobuf:"bytes,3,opt,name=alias" json:"alias,omitempty"`
+	// The new host:port address the node is reachable at. If empty, the
+	// address is left unchanged.
+	Address string `protobuf:"bytes,4,opt,name=address" json:"address,omitempty"`
+}
+
+func (m *NodeAnnouncementUpdateRequest) Reset()                    { *m = NodeAnnouncementUpdateRequest{} }
+func (m *NodeAnnouncementUpdateRequest) String() string            { return proto.CompactTextString(m) }
+func (*NodeAnnouncementUpdateRequest) ProtoMessage()               {}
+func (*NodeAnnouncementUpdateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{69} }
+
+func (m *NodeAnnouncementUpdateRequest) GetFeatureUpdates() []*UpdateFeatureAction {
+	if m != nil {
+		return m.FeatureUpdates
+	}
+	return nil
+}
+
+func (m *NodeAnnouncementUpdateRequest) GetColor() string {
+	if m != nil {
+		return m.Color
+	}
+	return ""
+}
+
+func (m *NodeAnnouncementUpdateRequest) GetAlias() string {
+	if m != nil {
+		return m.Alias
+	}
+	return ""
+}
+
+func (m *NodeAnnouncementUpdateRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+type NodeAnnouncementUpdateResponse struct {
+	// A description of each change applied to the node announcement.
+	Ops []string `protobuf:"bytes,1,rep,name=ops" json:"ops,omitempty"`
+}
+
+func (m *NodeAnnouncementUpdateResponse) Reset()         { *m = NodeAnnouncementUpdateResponse{} }
+func (m *NodeAnnouncementUpdateResponse) String() string { return proto.CompactTextString(m) }
+func (*NodeAnnouncementUpdateResponse) ProtoMessage()    {}
+func (*NodeAnnouncementUpdateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{70}
+}
+
+func (m *NodeAnnouncementUpdateResponse) GetOps() []string {
+	if m != nil {
+		return m.Ops
+	}
+	return nil
+}
+
+type Invoice struct {
+	Memo         string `protobuf:"bytes,1,opt,name=memo" json:"memo,omitempty"`
+	Receipt      []byte `protobuf:"bytes,2,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	RPreimage    []byte `protobuf:"bytes,3,opt,name=r_preimage,proto3" json:"r_preimage,omitempty"`
+	RHash        []byte `protobuf:"bytes,4,opt,name=r_hash,proto3" json:"r_hash,omitempty"`
+	Value        int64  `protobuf:"varint,5,opt,name=value" json:"value,omitempty"`
+	Settled      bool   `protobuf:"varint,6,opt,name=settled" json:"settled,omitempty"`
+	CreationDate int64  `protobuf:"varint,7,opt,name=creation_date" json:"creation_date,omitempty"`
+	SettleDate   int64  `protobuf:"varint,8,opt,name=settle_date" json:"settle_date,omitempty"`
+	// The state of the invoice.
+	State InvoiceState `protobuf:"varint,9,opt,name=state,enum=lnrpc.InvoiceState" json:"state,omitempty"`
+	// The time in seconds after its creation the invoice expires, after which
+	// it's canceled. Zero if the invoice doesn't expire.
+	Expiry int64 `protobuf:"varint,10,opt,name=expiry" json:"expiry,omitempty"`
+}
+
+func (m *Invoice) Reset()                    { *m = Invoice{} }
+func (m *Invoice) String() string            { return proto.CompactTextString(m) }
+func (*Invoice) ProtoMessage()               {}
+func (*Invoice) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{71} }
+
+func (m *Invoice) GetMemo() string {
+	if m != nil {
+		return m.Memo
+	}
+	return ""
+}
+
+func (m *Invoice) GetReceipt() []byte {
+	if m != nil {
+		return m.Receipt
+	}
+	return nil
+}
+
+func (m *Invoice) GetRPreimage() []byte {
+	if m != nil {
+		return m.RPreimage
+	}
+	return nil
+}
+
+func (m *Invoice) GetRHash() []byte {
+	if m != nil {
+		return m.RHash
+	}
+	return nil
+}
+
+func (m *Invoice) GetValue() int64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *Invoice) GetSettled() bool {
+	if m != nil {
+		return m.Settled
+	}
+	return false
+}
+
+func (m *Invoice) GetCreationDate() int64 {
+	if m != nil {
+		return m.CreationDate
+	}
+	return 0
 }
 
 func (m *Invoice) GetSettleDate() int64 {
@@ -2038,6 +4010,20 @@ func (m *Invoice) GetSettleDate() int64 {
 	return 0
 }
 
+func (m *Invoice) GetState() InvoiceState {
+	if m != nil {
+		return m.State
+	}
+	return InvoiceState_OPEN
+}
+
+func (m *Invoice) GetExpiry() int64 {
+	if m != nil {
+		return m.Expiry
+	}
+	return 0
+}
+
 type AddInvoiceResponse struct {
 	RHash          []byte `protobuf:"bytes,1,opt,name=r_hash,proto3" json:"r_hash,omitempty"`
 	PaymentRequest string `protobuf:"bytes,2,opt,name=payment_request" json:"payment_request,omitempty"`
@@ -2046,7 +4032,7 @@ type AddInvoiceResponse struct {
 func (m *AddInvoiceResponse) Reset()                    { *m = AddInvoiceResponse{} }
 func (m *AddInvoiceResponse) String() string            { return proto.CompactTextString(m) }
 func (*AddInvoiceResponse) ProtoMessage()               {}
-func (*AddInvoiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
+func (*AddInvoiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{72} }
 
 func (m *AddInvoiceResponse) GetRHash() []byte {
 	if m != nil {
@@ -2070,7 +4056,7 @@ type PaymentHash struct {
 func (m *PaymentHash) Reset()                    { *m = PaymentHash{} }
 func (m *PaymentHash) String() string            { return proto.CompactTextString(m) }
 func (*PaymentHash) ProtoMessage()               {}
-func (*PaymentHash) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
+func (*PaymentHash) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{73} }
 
 func (m *PaymentHash) GetRHashStr() string {
 	if m != nil {
@@ -2093,7 +4079,7 @@ type ListInvoiceRequest struct {
 func (m *ListInvoiceRequest) Reset()                    { *m = ListInvoiceRequest{} }
 func (m *ListInvoiceRequest) String() string            { return proto.CompactTextString(m) }
 func (*ListInvoiceRequest) ProtoMessage()               {}
-func (*ListInvoiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+func (*ListInvoiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{74} }
 
 func (m *ListInvoiceRequest) GetPendingOnly() bool {
 	if m != nil {
@@ -2109,7 +4095,7 @@ type ListInvoiceResponse struct {
 func (m *ListInvoiceResponse) Reset()                    { *m = ListInvoiceResponse{} }
 func (m *ListInvoiceResponse) String() string            { return proto.CompactTextString(m) }
 func (*ListInvoiceResponse) ProtoMessage()               {}
-func (*ListInvoiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
+func (*ListInvoiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{75} }
 
 func (m *ListInvoiceResponse) GetInvoices() []*Invoice {
 	if m != nil {
@@ -2124,7 +4110,7 @@ type InvoiceSubscription struct {
 func (m *InvoiceSubscription) Reset()                    { *m = InvoiceSubscription{} }
 func (m *InvoiceSubscription) String() string            { return proto.CompactTextString(m) }
 func (*InvoiceSubscription) ProtoMessage()               {}
-func (*InvoiceSubscription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
+func (*InvoiceSubscription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{76} }
 
 type Payment struct {
 	PaymentHash  string   `protobuf:"bytes,1,opt,name=payment_hash" json:"payment_hash,omitempty"`
@@ -2132,12 +4118,30 @@ type Payment struct {
 	CreationDate int64    `protobuf:"varint,3,opt,name=creation_date" json:"creation_date,omitempty"`
 	Path         []string `protobuf:"bytes,4,rep,name=path" json:"path,omitempty"`
 	Fee          int64    `protobuf:"varint,5,opt,name=fee" json:"fee,omitempty"`
+	// The outcome of the payment. The path and fee above are those of the
+	// final attempt to settle the payment.
+	Status PaymentStatus `protobuf:"varint,6,opt,name=status,enum=lnrpc.PaymentStatus" json:"status,omitempty"`
+	// The number of prior attempts to settle the payment which failed.
+	NumFailedAttempts uint32 `protobuf:"varint,7,opt,name=num_failed_attempts" json:"num_failed_attempts,omitempty"`
+	// The index of the payment, reflecting the order in which payments
+	// were created. It's used to paginate through the payments.
+	PaymentIndex uint64 `protobuf:"varint,8,opt,name=payment_index" json:"payment_index,omitempty"`
+	// The limits placed upon the payment when it was sent, as described
+	// within SendRequest.
+	TimeoutSeconds int32  `protobuf:"varint,9,opt,name=timeout_seconds" json:"timeout_seconds,omitempty"`
+	CltvLimit      uint32 `protobuf:"varint,10,opt,name=cltv_limit" json:"cltv_limit,omitempty"`
+	MaxParts       uint32 `protobuf:"varint,11,opt,name=max_parts" json:"max_parts,omitempty"`
+	// The attempts made to settle the payment, in the order they were made,
+	// the final one being last.
+	Htlcs []*HTLCAttempt `protobuf:"bytes,12,rep,name=htlcs" json:"htlcs,omitempty"`
+	// The reason the payment was abandoned, if it failed.
+	FailureReason PaymentFailureReason `protobuf:"varint,13,opt,name=failure_reason,enum=lnrpc.PaymentFailureReason" json:"failure_reason,omitempty"`
 }
 
 func (m *Payment) Reset()                    { *m = Payment{} }
 func (m *Payment) String() string            { return proto.CompactTextString(m) }
 func (*Payment) ProtoMessage()               {}
-func (*Payment) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
+func (*Payment) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{77} }
 
 func (m *Payment) GetPaymentHash() string {
 	if m != nil {
@@ -2174,1478 +4178,8881 @@ func (m *Payment) GetFee() int64 {
 	return 0
 }
 
-type ListPaymentsRequest struct {
+func (m *Payment) GetStatus() PaymentStatus {
+	if m != nil {
+		return m.Status
+	}
+	return PaymentStatus_SUCCEEDED
 }
 
-func (m *ListPaymentsRequest) Reset()                    { *m = ListPaymentsRequest{} }
-func (m *ListPaymentsRequest) String() string            { return proto.CompactTextString(m) }
-func (*ListPaymentsRequest) ProtoMessage()               {}
-func (*ListPaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
-
-type ListPaymentsResponse struct {
-	Payments []*Payment `protobuf:"bytes,1,rep,name=payments" json:"payments,omitempty"`
+func (m *Payment) GetNumFailedAttempts() uint32 {
+	if m != nil {
+		return m.NumFailedAttempts
+	}
+	return 0
 }
 
-func (m *ListPaymentsResponse) Reset()                    { *m = ListPaymentsResponse{} }
-func (m *ListPaymentsResponse) String() string            { return proto.CompactTextString(m) }
-func (*ListPaymentsResponse) ProtoMessage()               {}
-func (*ListPaymentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
-
-func (m *ListPaymentsResponse) GetPayments() []*Payment {
+func (m *Payment) GetPaymentIndex() uint64 {
 	if m != nil {
-		return m.Payments
+		return m.PaymentIndex
 	}
-	return nil
+	return 0
 }
 
-type DeleteAllPaymentsRequest struct {
-}
-
-func (m *DeleteAllPaymentsRequest) Reset()                    { *m = DeleteAllPaymentsRequest{} }
-func (m *DeleteAllPaymentsRequest) String() string            { return proto.CompactTextString(m) }
-func (*DeleteAllPaymentsRequest) ProtoMessage()               {}
-func (*DeleteAllPaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{63} }
-
-type DeleteAllPaymentsResponse struct {
+func (m *Payment) GetTimeoutSeconds() int32 {
+	if m != nil {
+		return m.TimeoutSeconds
+	}
+	return 0
 }
 
-func (m *DeleteAllPaymentsResponse) Reset()                    { *m = DeleteAllPaymentsResponse{} }
-func (m *DeleteAllPaymentsResponse) String() string            { return proto.CompactTextString(m) }
-func (*DeleteAllPaymentsResponse) ProtoMessage()               {}
-func (*DeleteAllPaymentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{64} }
-
-func init() {
-	proto.RegisterType((*Transaction)(nil), "lnrpc.Transaction")
-	proto.RegisterType((*GetTransactionsRequest)(nil), "lnrpc.GetTransactionsRequest")
-	proto.RegisterType((*TransactionDetails)(nil), "lnrpc.TransactionDetails")
-	proto.RegisterType((*SendRequest)(nil), "lnrpc.SendRequest")
-	proto.RegisterType((*SendResponse)(nil), "lnrpc.SendResponse")
-	proto.RegisterType((*ChannelPoint)(nil), "lnrpc.ChannelPoint")
-	proto.RegisterType((*LightningAddress)(nil), "lnrpc.LightningAddress")
-	proto.RegisterType((*SendManyRequest)(nil), "lnrpc.SendManyRequest")
-	proto.RegisterType((*SendManyResponse)(nil), "lnrpc.SendManyResponse")
-	proto.RegisterType((*SendCoinsRequest)(nil), "lnrpc.SendCoinsRequest")
-	proto.RegisterType((*SendCoinsResponse)(nil), "lnrpc.SendCoinsResponse")
-	proto.RegisterType((*NewAddressRequest)(nil), "lnrpc.NewAddressRequest")
-	proto.RegisterType((*NewWitnessAddressRequest)(nil), "lnrpc.NewWitnessAddressRequest")
-	proto.RegisterType((*NewAddressResponse)(nil), "lnrpc.NewAddressResponse")
-	proto.RegisterType((*ConnectPeerRequest)(nil), "lnrpc.ConnectPeerRequest")
-	proto.RegisterType((*ConnectPeerResponse)(nil), "lnrpc.ConnectPeerResponse")
-	proto.RegisterType((*HTLC)(nil), "lnrpc.HTLC")
-	proto.RegisterType((*ActiveChannel)(nil), "lnrpc.ActiveChannel")
-	proto.RegisterType((*ListChannelsRequest)(nil), "lnrpc.ListChannelsRequest")
-	proto.RegisterType((*ListChannelsResponse)(nil), "lnrpc.ListChannelsResponse")
-	proto.RegisterType((*Peer)(nil), "lnrpc.Peer")
-	proto.RegisterType((*ListPeersRequest)(nil), "lnrpc.ListPeersRequest")
-	proto.RegisterType((*ListPeersResponse)(nil), "lnrpc.ListPeersResponse")
-	proto.RegisterType((*GetInfoRequest)(nil), "lnrpc.GetInfoRequest")
-	proto.RegisterType((*GetInfoResponse)(nil), "lnrpc.GetInfoResponse")
-	proto.RegisterType((*ConfirmationUpdate)(nil), "lnrpc.ConfirmationUpdate")
-	proto.RegisterType((*ChannelOpenUpdate)(nil), "lnrpc.ChannelOpenUpdate")
-	proto.RegisterType((*ChannelCloseUpdate)(nil), "lnrpc.ChannelCloseUpdate")
-	proto.RegisterType((*CloseChannelRequest)(nil), "lnrpc.CloseChannelRequest")
-	proto.RegisterType((*CloseStatusUpdate)(nil), "lnrpc.CloseStatusUpdate")
-	proto.RegisterType((*PendingUpdate)(nil), "lnrpc.PendingUpdate")
-	proto.RegisterType((*OpenChannelRequest)(nil), "lnrpc.OpenChannelRequest")
-	proto.RegisterType((*OpenStatusUpdate)(nil), "lnrpc.OpenStatusUpdate")
-	proto.RegisterType((*PendingChannelRequest)(nil), "lnrpc.PendingChannelRequest")
-	proto.RegisterType((*PendingChannelResponse)(nil), "lnrpc.PendingChannelResponse")
-	proto.RegisterType((*PendingChannelResponse_PendingChannel)(nil), "lnrpc.PendingChannelResponse.PendingChannel")
-	proto.RegisterType((*WalletBalanceRequest)(nil), "lnrpc.WalletBalanceRequest")
-	proto.RegisterType((*WalletBalanceResponse)(nil), "lnrpc.WalletBalanceResponse")
-	proto.RegisterType((*ChannelBalanceRequest)(nil), "lnrpc.ChannelBalanceRequest")
-	proto.RegisterType((*ChannelBalanceResponse)(nil), "lnrpc.ChannelBalanceResponse")
-	proto.RegisterType((*RouteRequest)(nil), "lnrpc.RouteRequest")
-	proto.RegisterType((*Hop)(nil), "lnrpc.Hop")
-	proto.RegisterType((*Route)(nil), "lnrpc.Route")
-	proto.RegisterType((*NodeInfoRequest)(nil), "lnrpc.NodeInfoRequest")
-	proto.RegisterType((*NodeInfo)(nil), "lnrpc.NodeInfo")
-	proto.RegisterType((*LightningNode)(nil), "lnrpc.LightningNode")
-	proto.RegisterType((*RoutingPolicy)(nil), "lnrpc.RoutingPolicy")
-	proto.RegisterType((*ChannelEdge)(nil), "lnrpc.ChannelEdge")
-	proto.RegisterType((*ChannelGraphRequest)(nil), "lnrpc.ChannelGraphRequest")
-	proto.RegisterType((*ChannelGraph)(nil), "lnrpc.ChannelGraph")
-	proto.RegisterType((*ChanInfoRequest)(nil), "lnrpc.ChanInfoRequest")
-	proto.RegisterType((*NetworkInfoRequest)(nil), "lnrpc.NetworkInfoRequest")
-	proto.RegisterType((*NetworkInfo)(nil), "lnrpc.NetworkInfo")
-	proto.RegisterType((*SetAliasRequest)(nil), "lnrpc.SetAliasRequest")
-	proto.RegisterType((*SetAliasResponse)(nil), "lnrpc.SetAliasResponse")
-	proto.RegisterType((*Invoice)(nil), "lnrpc.Invoice")
-	proto.RegisterType((*AddInvoiceResponse)(nil), "lnrpc.AddInvoiceResponse")
-	proto.RegisterType((*PaymentHash)(nil), "lnrpc.PaymentHash")
-	proto.RegisterType((*ListInvoiceRequest)(nil), "lnrpc.ListInvoiceRequest")
-	proto.RegisterType((*ListInvoiceResponse)(nil), "lnrpc.ListInvoiceResponse")
-	proto.RegisterType((*InvoiceSubscription)(nil), "lnrpc.InvoiceSubscription")
-	proto.RegisterType((*Payment)(nil), "lnrpc.Payment")
-	proto.RegisterType((*ListPaymentsRequest)(nil), "lnrpc.ListPaymentsRequest")
-	proto.RegisterType((*ListPaymentsResponse)(nil), "lnrpc.ListPaymentsResponse")
-	proto.RegisterType((*DeleteAllPaymentsRequest)(nil), "lnrpc.DeleteAllPaymentsRequest")
-	proto.RegisterType((*DeleteAllPaymentsResponse)(nil), "lnrpc.DeleteAllPaymentsResponse")
-	proto.RegisterEnum("lnrpc.ChannelStatus", ChannelStatus_name, ChannelStatus_value)
-	proto.RegisterEnum("lnrpc.NewAddressRequest_AddressType", NewAddressRequest_AddressType_name, NewAddressRequest_AddressType_value)
+func (m *Payment) GetCltvLimit() uint32 {
+	if m != nil {
+		return m.CltvLimit
+	}
+	return 0
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// Client API for Lightning service
+func (m *Payment) GetMaxParts() uint32 {
+	if m != nil {
+		return m.MaxParts
+	}
+	return 0
+}
 
-type LightningClient interface {
-	WalletBalance(ctx context.Context, in *WalletBalanceRequest, opts ...grpc.CallOption) (*WalletBalanceResponse, error)
-	ChannelBalance(ctx context.Context, in *ChannelBalanceRequest, opts ...grpc.CallOption) (*ChannelBalanceResponse, error)
-	GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*TransactionDetails, error)
-	SendCoins(ctx context.Context, in *SendCoinsRequest, opts ...grpc.CallOption) (*SendCoinsResponse, error)
-	SubscribeTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (Lightning_SubscribeTransactionsClient, error)
-	SendMany(ctx context.Context, in *SendManyRequest, opts ...grpc.CallOption) (*SendManyResponse, error)
-	NewAddress(ctx context.Context, in *NewAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error)
-	NewWitnessAddress(ctx context.Context, in *NewWitnessAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error)
-	ConnectPeer(ctx context.Context, in *ConnectPeerRequest, opts ...grpc.CallOption) (*ConnectPeerResponse, error)
-	ListPeers(ctx context.Context, in *ListPeersRequest, opts ...grpc.CallOption) (*ListPeersResponse, error)
-	GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error)
-	// TODO(roasbeef): merge with below with bool?
-	PendingChannels(ctx context.Context, in *PendingChannelRequest, opts ...grpc.CallOption) (*PendingChannelResponse, error)
-	ListChannels(ctx context.Context, in *ListChannelsRequest, opts ...grpc.CallOption) (*ListChannelsResponse, error)
-	OpenChannelSync(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (*ChannelPoint, error)
-	OpenChannel(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (Lightning_OpenChannelClient, error)
-	CloseChannel(ctx context.Context, in *CloseChannelRequest, opts ...grpc.CallOption) (Lightning_CloseChannelClient, error)
-	SendPayment(ctx context.Context, opts ...grpc.CallOption) (Lightning_SendPaymentClient, error)
-	SendPaymentSync(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*SendResponse, error)
-	AddInvoice(ctx context.Context, in *Invoice, opts ...grpc.CallOption) (*AddInvoiceResponse, error)
-	ListInvoices(ctx context.Context, in *ListInvoiceRequest, opts ...grpc.CallOption) (*ListInvoiceResponse, error)
-	LookupInvoice(ctx context.Context, in *PaymentHash, opts ...grpc.CallOption) (*Invoice, error)
-	SubscribeInvoices(ctx context.Context, in *InvoiceSubscription, opts ...grpc.CallOption) (Lightning_SubscribeInvoicesClient, error)
-	ListPayments(ctx context.Context, in *ListPaymentsRequest, opts ...grpc.CallOption) (*ListPaymentsResponse, error)
-	DeleteAllPayments(ctx context.Context, in *DeleteAllPaymentsRequest, opts ...grpc.CallOption) (*DeleteAllPaymentsResponse, error)
-	DescribeGraph(ctx context.Context, in *ChannelGraphRequest, opts ...grpc.CallOption) (*ChannelGraph, error)
-	GetChanInfo(ctx context.Context, in *ChanInfoRequest, opts ...grpc.CallOption) (*ChannelEdge, error)
-	GetNodeInfo(ctx context.Context, in *NodeInfoRequest, opts ...grpc.CallOption) (*NodeInfo, error)
-	QueryRoute(ctx context.Context, in *RouteRequest, opts ...grpc.CallOption) (*Route, error)
-	GetNetworkInfo(ctx context.Context, in *NetworkInfoRequest, opts ...grpc.CallOption) (*NetworkInfo, error)
-	SetAlias(ctx context.Context, in *SetAliasRequest, opts ...grpc.CallOption) (*SetAliasResponse, error)
+func (m *Payment) GetHtlcs() []*HTLCAttempt {
+	if m != nil {
+		return m.Htlcs
+	}
+	return nil
 }
 
-type lightningClient struct {
-	cc *grpc.ClientConn
+func (m *Payment) GetFailureReason() PaymentFailureReason {
+	if m != nil {
+		return m.FailureReason
+	}
+	return PaymentFailureReason_FAILURE_REASON_NONE
 }
 
-func NewLightningClient(cc *grpc.ClientConn) LightningClient {
-	return &lightningClient{cc}
+type ListPaymentsRequest struct {
+	// The index of the payment the page starts after, or before if
+	// reversed is set. If zero, the page starts at the first payment, or
+	// the last if reversed is set.
+	IndexOffset uint64 `protobuf:"varint,1,opt,name=index_offset" json:"index_offset,omitempty"`
+	// The maximum number of payments to return. If zero, all payments
+	// following the index offset are returned.
+	MaxPayments uint64 `protobuf:"varint,2,opt,name=max_payments" json:"max_payments,omitempty"`
+	// If set, the payments preceding the index offset are returned,
+	// allowing the payments to be paginated through backwards. The
+	// payments are still returned in the order in which they were created.
+	Reversed bool `protobuf:"varint,3,opt,name=reversed" json:"reversed,omitempty"`
+	// If set, the total number of payments is returned, regardless of
+	// pagination and filters.
+	CountTotalPayments bool `protobuf:"varint,4,opt,name=count_total_payments" json:"count_total_payments,omitempty"`
+	// If non-zero, only payments created at or after this unix timestamp
+	// in seconds are returned.
+	CreationDateStart uint64 `protobuf:"varint,5,opt,name=creation_date_start" json:"creation_date_start,omitempty"`
+	// If non-zero, only payments created at or before this unix timestamp
+	// in seconds are returned.
+	CreationDateEnd uint64 `protobuf:"varint,6,opt,name=creation_date_end" json:"creation_date_end,omitempty"`
 }
 
-func (c *lightningClient) WalletBalance(ctx context.Context, in *WalletBalanceRequest, opts ...grpc.CallOption) (*WalletBalanceResponse, error) {
-	out := new(WalletBalanceResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/WalletBalance", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ListPaymentsRequest) Reset()                    { *m = ListPaymentsRequest{} }
+func (m *ListPaymentsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListPaymentsRequest) ProtoMessage()               {}
+func (*ListPaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{78} }
+
+func (m *ListPaymentsRequest) GetIndexOffset() uint64 {
+	if m != nil {
+		return m.IndexOffset
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) ChannelBalance(ctx context.Context, in *ChannelBalanceRequest, opts ...grpc.CallOption) (*ChannelBalanceResponse, error) {
-	out := new(ChannelBalanceResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ChannelBalance", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ListPaymentsRequest) GetMaxPayments() uint64 {
+	if m != nil {
+		return m.MaxPayments
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*TransactionDetails, error) {
-	out := new(TransactionDetails)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetTransactions", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ListPaymentsRequest) GetReversed() bool {
+	if m != nil {
+		return m.Reversed
 	}
-	return out, nil
+	return false
 }
 
-func (c *lightningClient) SendCoins(ctx context.Context, in *SendCoinsRequest, opts ...grpc.CallOption) (*SendCoinsResponse, error) {
-	out := new(SendCoinsResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendCoins", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ListPaymentsRequest) GetCountTotalPayments() bool {
+	if m != nil {
+		return m.CountTotalPayments
 	}
-	return out, nil
+	return false
 }
 
-func (c *lightningClient) SubscribeTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (Lightning_SubscribeTransactionsClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[0], c.cc, "/lnrpc.Lightning/SubscribeTransactions", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &lightningSubscribeTransactionsClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+func (m *ListPaymentsRequest) GetCreationDateStart() uint64 {
+	if m != nil {
+		return m.CreationDateStart
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	return 0
+}
+
+func (m *ListPaymentsRequest) GetCreationDateEnd() uint64 {
+	if m != nil {
+		return m.CreationDateEnd
 	}
-	return x, nil
+	return 0
 }
 
-type Lightning_SubscribeTransactionsClient interface {
-	Recv() (*Transaction, error)
-	grpc.ClientStream
+type ListPaymentsResponse struct {
+	Payments []*Payment `protobuf:"bytes,1,rep,name=payments" json:"payments,omitempty"`
+	// The indexes of the first and last payments returned, which may be
+	// used as the index offset of the next request to resume paginating
+	// backwards or forwards respectively.
+	FirstIndexOffset uint64 `protobuf:"varint,2,opt,name=first_index_offset" json:"first_index_offset,omitempty"`
+	LastIndexOffset  uint64 `protobuf:"varint,3,opt,name=last_index_offset" json:"last_index_offset,omitempty"`
+	// The total number of payments, only set if count_total_payments was
+	// set.
+	TotalNumPayments uint64 `protobuf:"varint,4,opt,name=total_num_payments" json:"total_num_payments,omitempty"`
 }
 
-type lightningSubscribeTransactionsClient struct {
-	grpc.ClientStream
+func (m *ListPaymentsResponse) Reset()                    { *m = ListPaymentsResponse{} }
+func (m *ListPaymentsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListPaymentsResponse) ProtoMessage()               {}
+func (*ListPaymentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{79} }
+
+func (m *ListPaymentsResponse) GetPayments() []*Payment {
+	if m != nil {
+		return m.Payments
+	}
+	return nil
 }
 
-func (x *lightningSubscribeTransactionsClient) Recv() (*Transaction, error) {
-	m := new(Transaction)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *ListPaymentsResponse) GetFirstIndexOffset() uint64 {
+	if m != nil {
+		return m.FirstIndexOffset
 	}
-	return m, nil
+	return 0
 }
 
-func (c *lightningClient) SendMany(ctx context.Context, in *SendManyRequest, opts ...grpc.CallOption) (*SendManyResponse, error) {
-	out := new(SendManyResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendMany", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ListPaymentsResponse) GetLastIndexOffset() uint64 {
+	if m != nil {
+		return m.LastIndexOffset
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) NewAddress(ctx context.Context, in *NewAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error) {
-	out := new(NewAddressResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/NewAddress", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ListPaymentsResponse) GetTotalNumPayments() uint64 {
+	if m != nil {
+		return m.TotalNumPayments
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) NewWitnessAddress(ctx context.Context, in *NewWitnessAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error) {
-	out := new(NewAddressResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/NewWitnessAddress", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+type DeleteAllPaymentsRequest struct {
+	// If set, only failed payments are deleted.
+	FailedPaymentsOnly bool `protobuf:"varint,1,opt,name=failed_payments_only" json:"failed_payments_only,omitempty"`
+	// If set, only the records of the failed attempts of succeeded
+	// payments are deleted, while the payments themselves are kept. May be
+	// combined with failed_payments_only.
+	FailedAttemptsOnly bool `protobuf:"varint,2,opt,name=failed_attempts_only" json:"failed_attempts_only,omitempty"`
+}
+
+func (m *DeleteAllPaymentsRequest) Reset()                    { *m = DeleteAllPaymentsRequest{} }
+func (m *DeleteAllPaymentsRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteAllPaymentsRequest) ProtoMessage()               {}
+func (*DeleteAllPaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{80} }
+
+func (m *DeleteAllPaymentsRequest) GetFailedPaymentsOnly() bool {
+	if m != nil {
+		return m.FailedPaymentsOnly
 	}
-	return out, nil
+	return false
 }
 
-func (c *lightningClient) ConnectPeer(ctx context.Context, in *ConnectPeerRequest, opts ...grpc.CallOption) (*ConnectPeerResponse, error) {
-	out := new(ConnectPeerResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ConnectPeer", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *DeleteAllPaymentsRequest) GetFailedAttemptsOnly() bool {
+	if m != nil {
+		return m.FailedAttemptsOnly
 	}
-	return out, nil
+	return false
 }
 
-func (c *lightningClient) ListPeers(ctx context.Context, in *ListPeersRequest, opts ...grpc.CallOption) (*ListPeersResponse, error) {
-	out := new(ListPeersResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListPeers", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type DeleteAllPaymentsResponse struct {
+	// The number of payments deleted, or pruned of their failed attempts.
+	// Only set if either of the options of the request were set.
+	NumDeleted int64 `protobuf:"varint,1,opt,name=num_deleted" json:"num_deleted,omitempty"`
 }
 
-func (c *lightningClient) GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error) {
-	out := new(GetInfoResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetInfo", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
+func (m *DeleteAllPaymentsResponse) Reset()                    { *m = DeleteAllPaymentsResponse{} }
+func (m *DeleteAllPaymentsResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteAllPaymentsResponse) ProtoMessage()               {}
+func (*DeleteAllPaymentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{81} }
 
-func (c *lightningClient) PendingChannels(ctx context.Context, in *PendingChannelRequest, opts ...grpc.CallOption) (*PendingChannelResponse, error) {
-	out := new(PendingChannelResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/PendingChannels", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *DeleteAllPaymentsResponse) GetNumDeleted() int64 {
+	if m != nil {
+		return m.NumDeleted
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) ListChannels(ctx context.Context, in *ListChannelsRequest, opts ...grpc.CallOption) (*ListChannelsResponse, error) {
-	out := new(ListChannelsResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListChannels", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type DeletePaymentRequest struct {
+	PaymentHash []byte `protobuf:"bytes,1,opt,name=payment_hash,proto3" json:"payment_hash,omitempty"`
+	// If set, only the records of the failed attempts of the payment are
+	// deleted, while the payment itself is kept.
+	FailedAttemptsOnly bool `protobuf:"varint,2,opt,name=failed_attempts_only" json:"failed_attempts_only,omitempty"`
 }
 
-func (c *lightningClient) OpenChannelSync(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (*ChannelPoint, error) {
-	out := new(ChannelPoint)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/OpenChannelSync", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *DeletePaymentRequest) Reset()                    { *m = DeletePaymentRequest{} }
+func (m *DeletePaymentRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeletePaymentRequest) ProtoMessage()               {}
+func (*DeletePaymentRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{82} }
+
+func (m *DeletePaymentRequest) GetPaymentHash() []byte {
+	if m != nil {
+		return m.PaymentHash
 	}
-	return out, nil
+	return nil
 }
 
-func (c *lightningClient) OpenChannel(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (Lightning_OpenChannelClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[1], c.cc, "/lnrpc.Lightning/OpenChannel", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &lightningOpenChannelClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+func (m *DeletePaymentRequest) GetFailedAttemptsOnly() bool {
+	if m != nil {
+		return m.FailedAttemptsOnly
 	}
-	return x, nil
+	return false
 }
 
-type Lightning_OpenChannelClient interface {
-	Recv() (*OpenStatusUpdate, error)
-	grpc.ClientStream
+type DeletePaymentResponse struct {
 }
 
-type lightningOpenChannelClient struct {
-	grpc.ClientStream
+func (m *DeletePaymentResponse) Reset()                    { *m = DeletePaymentResponse{} }
+func (m *DeletePaymentResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeletePaymentResponse) ProtoMessage()               {}
+func (*DeletePaymentResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{83} }
+
+type SendCustomMessageRequest struct {
+	Peer []byte `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+	Type uint32 `protobuf:"varint,2,opt,name=type" json:"type,omitempty"`
+	Data []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
 }
 
-func (x *lightningOpenChannelClient) Recv() (*OpenStatusUpdate, error) {
-	m := new(OpenStatusUpdate)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *SendCustomMessageRequest) Reset()                    { *m = SendCustomMessageRequest{} }
+func (m *SendCustomMessageRequest) String() string            { return proto.CompactTextString(m) }
+func (*SendCustomMessageRequest) ProtoMessage()               {}
+func (*SendCustomMessageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{84} }
+
+func (m *SendCustomMessageRequest) GetPeer() []byte {
+	if m != nil {
+		return m.Peer
 	}
-	return m, nil
+	return nil
 }
 
-func (c *lightningClient) CloseChannel(ctx context.Context, in *CloseChannelRequest, opts ...grpc.CallOption) (Lightning_CloseChannelClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[2], c.cc, "/lnrpc.Lightning/CloseChannel", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &lightningCloseChannelClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+func (m *SendCustomMessageRequest) GetType() uint32 {
+	if m != nil {
+		return m.Type
 	}
-	return x, nil
+	return 0
 }
 
-type Lightning_CloseChannelClient interface {
-	Recv() (*CloseStatusUpdate, error)
-	grpc.ClientStream
+func (m *SendCustomMessageRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
 }
 
-type lightningCloseChannelClient struct {
-	grpc.ClientStream
+type SendCustomMessageResponse struct {
 }
 
-func (x *lightningCloseChannelClient) Recv() (*CloseStatusUpdate, error) {
-	m := new(CloseStatusUpdate)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
-}
+func (m *SendCustomMessageResponse) Reset()                    { *m = SendCustomMessageResponse{} }
+func (m *SendCustomMessageResponse) String() string            { return proto.CompactTextString(m) }
+func (*SendCustomMessageResponse) ProtoMessage()               {}
+func (*SendCustomMessageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{85} }
 
-func (c *lightningClient) SendPayment(ctx context.Context, opts ...grpc.CallOption) (Lightning_SendPaymentClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[3], c.cc, "/lnrpc.Lightning/SendPayment", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &lightningSendPaymentClient{stream}
-	return x, nil
+type SubscribeCustomMessagesRequest struct {
 }
 
-type Lightning_SendPaymentClient interface {
-	Send(*SendRequest) error
-	Recv() (*SendResponse, error)
-	grpc.ClientStream
+func (m *SubscribeCustomMessagesRequest) Reset()         { *m = SubscribeCustomMessagesRequest{} }
+func (m *SubscribeCustomMessagesRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeCustomMessagesRequest) ProtoMessage()    {}
+func (*SubscribeCustomMessagesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{86}
 }
 
-type lightningSendPaymentClient struct {
-	grpc.ClientStream
+type CustomMessage struct {
+	Peer []byte `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+	Type uint32 `protobuf:"varint,2,opt,name=type" json:"type,omitempty"`
+	Data []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
 }
 
-func (x *lightningSendPaymentClient) Send(m *SendRequest) error {
-	return x.ClientStream.SendMsg(m)
-}
+func (m *CustomMessage) Reset()                    { *m = CustomMessage{} }
+func (m *CustomMessage) String() string            { return proto.CompactTextString(m) }
+func (*CustomMessage) ProtoMessage()               {}
+func (*CustomMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{87} }
 
-func (x *lightningSendPaymentClient) Recv() (*SendResponse, error) {
-	m := new(SendResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *CustomMessage) GetPeer() []byte {
+	if m != nil {
+		return m.Peer
 	}
-	return m, nil
+	return nil
 }
 
-func (c *lightningClient) SendPaymentSync(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*SendResponse, error) {
-	out := new(SendResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendPaymentSync", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *CustomMessage) GetType() uint32 {
+	if m != nil {
+		return m.Type
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) AddInvoice(ctx context.Context, in *Invoice, opts ...grpc.CallOption) (*AddInvoiceResponse, error) {
-	out := new(AddInvoiceResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/AddInvoice", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *CustomMessage) GetData() []byte {
+	if m != nil {
+		return m.Data
 	}
-	return out, nil
+	return nil
 }
 
-func (c *lightningClient) ListInvoices(ctx context.Context, in *ListInvoiceRequest, opts ...grpc.CallOption) (*ListInvoiceResponse, error) {
-	out := new(ListInvoiceResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListInvoices", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type KeyLocator struct {
+	KeyFamily int32 `protobuf:"varint,1,opt,name=key_family" json:"key_family,omitempty"`
+	KeyIndex  int32 `protobuf:"varint,2,opt,name=key_index" json:"key_index,omitempty"`
 }
 
-func (c *lightningClient) LookupInvoice(ctx context.Context, in *PaymentHash, opts ...grpc.CallOption) (*Invoice, error) {
-	out := new(Invoice)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/LookupInvoice", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *KeyLocator) Reset()                    { *m = KeyLocator{} }
+func (m *KeyLocator) String() string            { return proto.CompactTextString(m) }
+func (*KeyLocator) ProtoMessage()               {}
+func (*KeyLocator) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{88} }
+
+func (m *KeyLocator) GetKeyFamily() int32 {
+	if m != nil {
+		return m.KeyFamily
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) SubscribeInvoices(ctx context.Context, in *InvoiceSubscription, opts ...grpc.CallOption) (Lightning_SubscribeInvoicesClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[4], c.cc, "/lnrpc.Lightning/SubscribeInvoices", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &lightningSubscribeInvoicesClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+func (m *KeyLocator) GetKeyIndex() int32 {
+	if m != nil {
+		return m.KeyIndex
 	}
-	return x, nil
+	return 0
 }
 
-type Lightning_SubscribeInvoicesClient interface {
-	Recv() (*Invoice, error)
-	grpc.ClientStream
+type KeyDescriptor struct {
+	RawKeyBytes []byte      `protobuf:"bytes,1,opt,name=raw_key_bytes,proto3" json:"raw_key_bytes,omitempty"`
+	KeyLoc      *KeyLocator `protobuf:"bytes,2,opt,name=key_loc" json:"key_loc,omitempty"`
 }
 
-type lightningSubscribeInvoicesClient struct {
-	grpc.ClientStream
+func (m *KeyDescriptor) Reset()                    { *m = KeyDescriptor{} }
+func (m *KeyDescriptor) String() string            { return proto.CompactTextString(m) }
+func (*KeyDescriptor) ProtoMessage()               {}
+func (*KeyDescriptor) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{89} }
+
+func (m *KeyDescriptor) GetRawKeyBytes() []byte {
+	if m != nil {
+		return m.RawKeyBytes
+	}
+	return nil
 }
 
-func (x *lightningSubscribeInvoicesClient) Recv() (*Invoice, error) {
-	m := new(Invoice)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *KeyDescriptor) GetKeyLoc() *KeyLocator {
+	if m != nil {
+		return m.KeyLoc
 	}
-	return m, nil
+	return nil
 }
 
-func (c *lightningClient) ListPayments(ctx context.Context, in *ListPaymentsRequest, opts ...grpc.CallOption) (*ListPaymentsResponse, error) {
-	out := new(ListPaymentsResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListPayments", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+type TxOut struct {
+	Value    int64  `protobuf:"varint,1,opt,name=value" json:"value,omitempty"`
+	PkScript []byte `protobuf:"bytes,2,opt,name=pk_script,proto3" json:"pk_script,omitempty"`
+}
+
+func (m *TxOut) Reset()                    { *m = TxOut{} }
+func (m *TxOut) String() string            { return proto.CompactTextString(m) }
+func (*TxOut) ProtoMessage()               {}
+func (*TxOut) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{90} }
+
+func (m *TxOut) GetValue() int64 {
+	if m != nil {
+		return m.Value
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) DeleteAllPayments(ctx context.Context, in *DeleteAllPaymentsRequest, opts ...grpc.CallOption) (*DeleteAllPaymentsResponse, error) {
-	out := new(DeleteAllPaymentsResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DeleteAllPayments", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *TxOut) GetPkScript() []byte {
+	if m != nil {
+		return m.PkScript
 	}
-	return out, nil
+	return nil
 }
 
-func (c *lightningClient) DescribeGraph(ctx context.Context, in *ChannelGraphRequest, opts ...grpc.CallOption) (*ChannelGraph, error) {
-	out := new(ChannelGraph)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DescribeGraph", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+type SignDescriptor struct {
+	KeyDesc       *KeyDescriptor `protobuf:"bytes,1,opt,name=key_desc" json:"key_desc,omitempty"`
+	SingleTweak   []byte         `protobuf:"bytes,2,opt,name=single_tweak,proto3" json:"single_tweak,omitempty"`
+	WitnessScript []byte         `protobuf:"bytes,3,opt,name=witness_script,proto3" json:"witness_script,omitempty"`
+	Output        *TxOut         `protobuf:"bytes,4,opt,name=output" json:"output,omitempty"`
+	Sighash       uint32         `protobuf:"varint,5,opt,name=sighash" json:"sighash,omitempty"`
+	InputIndex    int32          `protobuf:"varint,6,opt,name=input_index" json:"input_index,omitempty"`
+}
+
+func (m *SignDescriptor) Reset()                    { *m = SignDescriptor{} }
+func (m *SignDescriptor) String() string            { return proto.CompactTextString(m) }
+func (*SignDescriptor) ProtoMessage()               {}
+func (*SignDescriptor) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{91} }
+
+func (m *SignDescriptor) GetKeyDesc() *KeyDescriptor {
+	if m != nil {
+		return m.KeyDesc
 	}
-	return out, nil
+	return nil
 }
 
-func (c *lightningClient) GetChanInfo(ctx context.Context, in *ChanInfoRequest, opts ...grpc.CallOption) (*ChannelEdge, error) {
-	out := new(ChannelEdge)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetChanInfo", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *SignDescriptor) GetSingleTweak() []byte {
+	if m != nil {
+		return m.SingleTweak
 	}
-	return out, nil
+	return nil
 }
 
-func (c *lightningClient) GetNodeInfo(ctx context.Context, in *NodeInfoRequest, opts ...grpc.CallOption) (*NodeInfo, error) {
-	out := new(NodeInfo)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetNodeInfo", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *SignDescriptor) GetWitnessScript() []byte {
+	if m != nil {
+		return m.WitnessScript
 	}
-	return out, nil
+	return nil
 }
 
-func (c *lightningClient) QueryRoute(ctx context.Context, in *RouteRequest, opts ...grpc.CallOption) (*Route, error) {
-	out := new(Route)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/QueryRoute", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *SignDescriptor) GetOutput() *TxOut {
+	if m != nil {
+		return m.Output
 	}
-	return out, nil
+	return nil
 }
 
-func (c *lightningClient) GetNetworkInfo(ctx context.Context, in *NetworkInfoRequest, opts ...grpc.CallOption) (*NetworkInfo, error) {
-	out := new(NetworkInfo)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetNetworkInfo", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *SignDescriptor) GetSighash() uint32 {
+	if m != nil {
+		return m.Sighash
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) SetAlias(ctx context.Context, in *SetAliasRequest, opts ...grpc.CallOption) (*SetAliasResponse, error) {
-	out := new(SetAliasResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SetAlias", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *SignDescriptor) GetInputIndex() int32 {
+	if m != nil {
+		return m.InputIndex
 	}
-	return out, nil
+	return 0
 }
 
-// Server API for Lightning service
+type SignReq struct {
+	RawTxBytes []byte            `protobuf:"bytes,1,opt,name=raw_tx_bytes,proto3" json:"raw_tx_bytes,omitempty"`
+	SignDescs  []*SignDescriptor `protobuf:"bytes,2,rep,name=sign_descs" json:"sign_descs,omitempty"`
+}
 
-type LightningServer interface {
-	WalletBalance(context.Context, *WalletBalanceRequest) (*WalletBalanceResponse, error)
-	ChannelBalance(context.Context, *ChannelBalanceRequest) (*ChannelBalanceResponse, error)
-	GetTransactions(context.Context, *GetTransactionsRequest) (*TransactionDetails, error)
-	SendCoins(context.Context, *SendCoinsRequest) (*SendCoinsResponse, error)
-	SubscribeTransactions(*GetTransactionsRequest, Lightning_SubscribeTransactionsServer) error
-	SendMany(context.Context, *SendManyRequest) (*SendManyResponse, error)
-	NewAddress(context.Context, *NewAddressRequest) (*NewAddressResponse, error)
-	NewWitnessAddress(context.Context, *NewWitnessAddressRequest) (*NewAddressResponse, error)
-	ConnectPeer(context.Context, *ConnectPeerRequest) (*ConnectPeerResponse, error)
-	ListPeers(context.Context, *ListPeersRequest) (*ListPeersResponse, error)
-	GetInfo(context.Context, *GetInfoRequest) (*GetInfoResponse, error)
-	// TODO(roasbeef): merge with below with bool?
-	PendingChannels(context.Context, *PendingChannelRequest) (*PendingChannelResponse, error)
-	ListChannels(context.Context, *ListChannelsRequest) (*ListChannelsResponse, error)
-	OpenChannelSync(context.Context, *OpenChannelRequest) (*ChannelPoint, error)
-	OpenChannel(*OpenChannelRequest, Lightning_OpenChannelServer) error
-	CloseChannel(*CloseChannelRequest, Lightning_CloseChannelServer) error
-	SendPayment(Lightning_SendPaymentServer) error
-	SendPaymentSync(context.Context, *SendRequest) (*SendResponse, error)
-	AddInvoice(context.Context, *Invoice) (*AddInvoiceResponse, error)
-	ListInvoices(context.Context, *ListInvoiceRequest) (*ListInvoiceResponse, error)
-	LookupInvoice(context.Context, *PaymentHash) (*Invoice, error)
-	SubscribeInvoices(*InvoiceSubscription, Lightning_SubscribeInvoicesServer) error
-	ListPayments(context.Context, *ListPaymentsRequest) (*ListPaymentsResponse, error)
-	DeleteAllPayments(context.Context, *DeleteAllPaymentsRequest) (*DeleteAllPaymentsResponse, error)
-	DescribeGraph(context.Context, *ChannelGraphRequest) (*ChannelGraph, error)
-	GetChanInfo(context.Context, *ChanInfoRequest) (*ChannelEdge, error)
-	GetNodeInfo(context.Context, *NodeInfoRequest) (*NodeInfo, error)
-	QueryRoute(context.Context, *RouteRequest) (*Route, error)
-	GetNetworkInfo(context.Context, *NetworkInfoRequest) (*NetworkInfo, error)
-	SetAlias(context.Context, *SetAliasRequest) (*SetAliasResponse, error)
-}
+func (m *SignReq) Reset()                    { *m = SignReq{} }
+func (m *SignReq) String() string            { return proto.CompactTextString(m) }
+func (*SignReq) ProtoMessage()               {}
+func (*SignReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{92} }
 
-func RegisterLightningServer(s *grpc.Server, srv LightningServer) {
-	s.RegisterService(&_Lightning_serviceDesc, srv)
+func (m *SignReq) GetRawTxBytes() []byte {
+	if m != nil {
+		return m.RawTxBytes
+	}
+	return nil
 }
 
-func _Lightning_WalletBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(WalletBalanceRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(LightningServer).WalletBalance(ctx, in)
+func (m *SignReq) GetSignDescs() []*SignDescriptor {
+	if m != nil {
+		return m.SignDescs
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/WalletBalance",
+	return nil
+}
+
+type SignResp struct {
+	RawSigs [][]byte `protobuf:"bytes,1,rep,name=raw_sigs,proto3" json:"raw_sigs,omitempty"`
+}
+
+func (m *SignResp) Reset()                    { *m = SignResp{} }
+func (m *SignResp) String() string            { return proto.CompactTextString(m) }
+func (*SignResp) ProtoMessage()               {}
+func (*SignResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{93} }
+
+func (m *SignResp) GetRawSigs() [][]byte {
+	if m != nil {
+		return m.RawSigs
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).WalletBalance(ctx, req.(*WalletBalanceRequest))
+	return nil
+}
+
+type InputScript struct {
+	Witness   [][]byte `protobuf:"bytes,1,rep,name=witness,proto3" json:"witness,omitempty"`
+	SigScript []byte   `protobuf:"bytes,2,opt,name=sig_script,proto3" json:"sig_script,omitempty"`
+}
+
+func (m *InputScript) Reset()                    { *m = InputScript{} }
+func (m *InputScript) String() string            { return proto.CompactTextString(m) }
+func (*InputScript) ProtoMessage()               {}
+func (*InputScript) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{94} }
+
+func (m *InputScript) GetWitness() [][]byte {
+	if m != nil {
+		return m.Witness
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _Lightning_ChannelBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ChannelBalanceRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *InputScript) GetSigScript() []byte {
+	if m != nil {
+		return m.SigScript
 	}
-	if interceptor == nil {
-		return srv.(LightningServer).ChannelBalance(ctx, in)
+	return nil
+}
+
+type InputScriptResp struct {
+	InputScripts []*InputScript `protobuf:"bytes,1,rep,name=input_scripts" json:"input_scripts,omitempty"`
+}
+
+func (m *InputScriptResp) Reset()                    { *m = InputScriptResp{} }
+func (m *InputScriptResp) String() string            { return proto.CompactTextString(m) }
+func (*InputScriptResp) ProtoMessage()               {}
+func (*InputScriptResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{95} }
+
+func (m *InputScriptResp) GetInputScripts() []*InputScript {
+	if m != nil {
+		return m.InputScripts
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/ChannelBalance",
+	return nil
+}
+
+type SharedKeyRequest struct {
+	EphemeralPubkey []byte         `protobuf:"bytes,1,opt,name=ephemeral_pubkey,proto3" json:"ephemeral_pubkey,omitempty"`
+	KeyDesc         *KeyDescriptor `protobuf:"bytes,2,opt,name=key_desc" json:"key_desc,omitempty"`
+}
+
+func (m *SharedKeyRequest) Reset()                    { *m = SharedKeyRequest{} }
+func (m *SharedKeyRequest) String() string            { return proto.CompactTextString(m) }
+func (*SharedKeyRequest) ProtoMessage()               {}
+func (*SharedKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{96} }
+
+func (m *SharedKeyRequest) GetEphemeralPubkey() []byte {
+	if m != nil {
+		return m.EphemeralPubkey
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).ChannelBalance(ctx, req.(*ChannelBalanceRequest))
+	return nil
+}
+
+func (m *SharedKeyRequest) GetKeyDesc() *KeyDescriptor {
+	if m != nil {
+		return m.KeyDesc
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _Lightning_GetTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetTransactionsRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+type SharedKeyResponse struct {
+	SharedKey []byte `protobuf:"bytes,1,opt,name=shared_key,proto3" json:"shared_key,omitempty"`
+}
+
+func (m *SharedKeyResponse) Reset()                    { *m = SharedKeyResponse{} }
+func (m *SharedKeyResponse) String() string            { return proto.CompactTextString(m) }
+func (*SharedKeyResponse) ProtoMessage()               {}
+func (*SharedKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{97} }
+
+func (m *SharedKeyResponse) GetSharedKey() []byte {
+	if m != nil {
+		return m.SharedKey
 	}
-	if interceptor == nil {
-		return srv.(LightningServer).GetTransactions(ctx, in)
+	return nil
+}
+
+type Utxo struct {
+	Txid          string `protobuf:"bytes,1,opt,name=txid" json:"txid,omitempty"`
+	OutputIndex   uint32 `protobuf:"varint,2,opt,name=output_index" json:"output_index,omitempty"`
+	AmountSat     int64  `protobuf:"varint,3,opt,name=amount_sat" json:"amount_sat,omitempty"`
+	PkScript      []byte `protobuf:"bytes,4,opt,name=pk_script,proto3" json:"pk_script,omitempty"`
+	Confirmations int64  `protobuf:"varint,5,opt,name=confirmations" json:"confirmations,omitempty"`
+}
+
+func (m *Utxo) Reset()                    { *m = Utxo{} }
+func (m *Utxo) String() string            { return proto.CompactTextString(m) }
+func (*Utxo) ProtoMessage()               {}
+func (*Utxo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{98} }
+
+func (m *Utxo) GetTxid() string {
+	if m != nil {
+		return m.Txid
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/GetTransactions",
+	return ""
+}
+
+func (m *Utxo) GetOutputIndex() uint32 {
+	if m != nil {
+		return m.OutputIndex
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).GetTransactions(ctx, req.(*GetTransactionsRequest))
+	return 0
+}
+
+func (m *Utxo) GetAmountSat() int64 {
+	if m != nil {
+		return m.AmountSat
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-func _Lightning_SendCoins_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SendCoinsRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *Utxo) GetPkScript() []byte {
+	if m != nil {
+		return m.PkScript
 	}
-	if interceptor == nil {
-		return srv.(LightningServer).SendCoins(ctx, in)
+	return nil
+}
+
+func (m *Utxo) GetConfirmations() int64 {
+	if m != nil {
+		return m.Confirmations
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/SendCoins",
+	return 0
+}
+
+type ListUnspentRequest struct {
+	MinConfs int32  `protobuf:"varint,1,opt,name=min_confs" json:"min_confs,omitempty"`
+	MaxConfs int32  `protobuf:"varint,2,opt,name=max_confs" json:"max_confs,omitempty"`
+	Account  string `protobuf:"bytes,3,opt,name=account" json:"account,omitempty"`
+}
+
+func (m *ListUnspentRequest) Reset()                    { *m = ListUnspentRequest{} }
+func (m *ListUnspentRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListUnspentRequest) ProtoMessage()               {}
+func (*ListUnspentRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{99} }
+
+func (m *ListUnspentRequest) GetMinConfs() int32 {
+	if m != nil {
+		return m.MinConfs
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).SendCoins(ctx, req.(*SendCoinsRequest))
+	return 0
+}
+
+func (m *ListUnspentRequest) GetMaxConfs() int32 {
+	if m != nil {
+		return m.MaxConfs
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-func _Lightning_SubscribeTransactions_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(GetTransactionsRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func (m *ListUnspentRequest) GetAccount() string {
+	if m != nil {
+		return m.Account
 	}
-	return srv.(LightningServer).SubscribeTransactions(m, &lightningSubscribeTransactionsServer{stream})
+	return ""
 }
 
-type Lightning_SubscribeTransactionsServer interface {
-	Send(*Transaction) error
-	grpc.ServerStream
+type ListUnspentResponse struct {
+	Utxos []*Utxo `protobuf:"bytes,1,rep,name=utxos" json:"utxos,omitempty"`
 }
 
-type lightningSubscribeTransactionsServer struct {
-	grpc.ServerStream
+func (m *ListUnspentResponse) Reset()                    { *m = ListUnspentResponse{} }
+func (m *ListUnspentResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListUnspentResponse) ProtoMessage()               {}
+func (*ListUnspentResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{100} }
+
+func (m *ListUnspentResponse) GetUtxos() []*Utxo {
+	if m != nil {
+		return m.Utxos
+	}
+	return nil
 }
 
-func (x *lightningSubscribeTransactionsServer) Send(m *Transaction) error {
-	return x.ServerStream.SendMsg(m)
+type ListAddressesRequest struct {
 }
 
-func _Lightning_SendMany_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SendManyRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *ListAddressesRequest) Reset()                    { *m = ListAddressesRequest{} }
+func (m *ListAddressesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListAddressesRequest) ProtoMessage()               {}
+func (*ListAddressesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{101} }
+
+type ListAddressesResponse struct {
+	Addresses []string `protobuf:"bytes,1,rep,name=addresses" json:"addresses,omitempty"`
+}
+
+func (m *ListAddressesResponse) Reset()                    { *m = ListAddressesResponse{} }
+func (m *ListAddressesResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListAddressesResponse) ProtoMessage()               {}
+func (*ListAddressesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{102} }
+
+func (m *ListAddressesResponse) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
 	}
-	if interceptor == nil {
-		return srv.(LightningServer).SendMany(ctx, in)
+	return nil
+}
+
+type AddrRequest struct {
+	// The type of address to return.
+	Type NewAddressRequest_AddressType `protobuf:"varint,1,opt,name=type,enum=lnrpc.NewAddressRequest_AddressType" json:"type,omitempty"`
+	// If true, an internal (change) address is returned, otherwise an
+	// external one.
+	Change bool `protobuf:"varint,2,opt,name=change" json:"change,omitempty"`
+	// The name of the imported account to derive the address from, if any.
+	Account string `protobuf:"bytes,3,opt,name=account" json:"account,omitempty"`
+}
+
+func (m *AddrRequest) Reset()                    { *m = AddrRequest{} }
+func (m *AddrRequest) String() string            { return proto.CompactTextString(m) }
+func (*AddrRequest) ProtoMessage()               {}
+func (*AddrRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{103} }
+
+func (m *AddrRequest) GetType() NewAddressRequest_AddressType {
+	if m != nil {
+		return m.Type
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/SendMany",
+	return NewAddressRequest_WITNESS_PUBKEY_HASH
+}
+
+func (m *AddrRequest) GetChange() bool {
+	if m != nil {
+		return m.Change
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).SendMany(ctx, req.(*SendManyRequest))
+	return false
+}
+
+func (m *AddrRequest) GetAccount() string {
+	if m != nil {
+		return m.Account
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _Lightning_NewAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NewAddressRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+type DeriveKeyRequest struct {
+	KeyLoc *KeyLocator `protobuf:"bytes,1,opt,name=key_loc" json:"key_loc,omitempty"`
+}
+
+func (m *DeriveKeyRequest) Reset()                    { *m = DeriveKeyRequest{} }
+func (m *DeriveKeyRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeriveKeyRequest) ProtoMessage()               {}
+func (*DeriveKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{104} }
+
+func (m *DeriveKeyRequest) GetKeyLoc() *KeyLocator {
+	if m != nil {
+		return m.KeyLoc
 	}
-	if interceptor == nil {
-		return srv.(LightningServer).NewAddress(ctx, in)
+	return nil
+}
+
+type DeriveNextKeyRequest struct {
+	KeyFamily int32 `protobuf:"varint,1,opt,name=key_family" json:"key_family,omitempty"`
+}
+
+func (m *DeriveNextKeyRequest) Reset()                    { *m = DeriveNextKeyRequest{} }
+func (m *DeriveNextKeyRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeriveNextKeyRequest) ProtoMessage()               {}
+func (*DeriveNextKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{105} }
+
+func (m *DeriveNextKeyRequest) GetKeyFamily() int32 {
+	if m != nil {
+		return m.KeyFamily
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/NewAddress",
+	return 0
+}
+
+type PublishTransactionRequest struct {
+	TxHex []byte `protobuf:"bytes,1,opt,name=tx_hex,proto3" json:"tx_hex,omitempty"`
+}
+
+func (m *PublishTransactionRequest) Reset()                    { *m = PublishTransactionRequest{} }
+func (m *PublishTransactionRequest) String() string            { return proto.CompactTextString(m) }
+func (*PublishTransactionRequest) ProtoMessage()               {}
+func (*PublishTransactionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{106} }
+
+func (m *PublishTransactionRequest) GetTxHex() []byte {
+	if m != nil {
+		return m.TxHex
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).NewAddress(ctx, req.(*NewAddressRequest))
+	return nil
+}
+
+type PublishTransactionResponse struct {
+	Txid string `protobuf:"bytes,1,opt,name=txid" json:"txid,omitempty"`
+}
+
+func (m *PublishTransactionResponse) Reset()                    { *m = PublishTransactionResponse{} }
+func (m *PublishTransactionResponse) String() string            { return proto.CompactTextString(m) }
+func (*PublishTransactionResponse) ProtoMessage()               {}
+func (*PublishTransactionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{107} }
+
+func (m *PublishTransactionResponse) GetTxid() string {
+	if m != nil {
+		return m.Txid
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _Lightning_NewWitnessAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+type EstimateFeeRequest struct {
+	ConfTarget uint32 `protobuf:"varint,1,opt,name=conf_target" json:"conf_target,omitempty"`
+}
+
+func (m *EstimateFeeRequest) Reset()                    { *m = EstimateFeeRequest{} }
+func (m *EstimateFeeRequest) String() string            { return proto.CompactTextString(m) }
+func (*EstimateFeeRequest) ProtoMessage()               {}
+func (*EstimateFeeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{108} }
+
+func (m *EstimateFeeRequest) GetConfTarget() uint32 {
+	if m != nil {
+		return m.ConfTarget
+	}
+	return 0
+}
+
+type EstimateFeeResponse struct {
+	SatPerByte   int64 `protobuf:"varint,1,opt,name=sat_per_byte" json:"sat_per_byte,omitempty"`
+	SatPerWeight int64 `protobuf:"varint,2,opt,name=sat_per_weight" json:"sat_per_weight,omitempty"`
+}
+
+func (m *EstimateFeeResponse) Reset()                    { *m = EstimateFeeResponse{} }
+func (m *EstimateFeeResponse) String() string            { return proto.CompactTextString(m) }
+func (*EstimateFeeResponse) ProtoMessage()               {}
+func (*EstimateFeeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{109} }
+
+func (m *EstimateFeeResponse) GetSatPerByte() int64 {
+	if m != nil {
+		return m.SatPerByte
+	}
+	return 0
+}
+
+func (m *EstimateFeeResponse) GetSatPerWeight() int64 {
+	if m != nil {
+		return m.SatPerWeight
+	}
+	return 0
+}
+
+type FundPsbtRequest struct {
+	Psbt                  []byte                `protobuf:"bytes,1,opt,name=psbt,proto3" json:"psbt,omitempty"`
+	RawOutputs            map[string]int64      `protobuf:"bytes,2,rep,name=raw_outputs" json:"raw_outputs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	TargetConf            uint32                `protobuf:"varint,3,opt,name=target_conf" json:"target_conf,omitempty"`
+	SatPerByte            int64                 `protobuf:"varint,4,opt,name=sat_per_byte" json:"sat_per_byte,omitempty"`
+	CoinSelectionStrategy CoinSelectionStrategy `protobuf:"varint,5,opt,name=coin_selection_strategy,enum=lnrpc.CoinSelectionStrategy" json:"coin_selection_strategy,omitempty"`
+	Account               string                `protobuf:"bytes,6,opt,name=account" json:"account,omitempty"`
+}
+
+func (m *FundPsbtRequest) Reset()                    { *m = FundPsbtRequest{} }
+func (m *FundPsbtRequest) String() string            { return proto.CompactTextString(m) }
+func (*FundPsbtRequest) ProtoMessage()               {}
+func (*FundPsbtRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{110} }
+
+func (m *FundPsbtRequest) GetPsbt() []byte {
+	if m != nil {
+		return m.Psbt
+	}
+	return nil
+}
+
+func (m *FundPsbtRequest) GetRawOutputs() map[string]int64 {
+	if m != nil {
+		return m.RawOutputs
+	}
+	return nil
+}
+
+func (m *FundPsbtRequest) GetTargetConf() uint32 {
+	if m != nil {
+		return m.TargetConf
+	}
+	return 0
+}
+
+func (m *FundPsbtRequest) GetSatPerByte() int64 {
+	if m != nil {
+		return m.SatPerByte
+	}
+	return 0
+}
+
+func (m *FundPsbtRequest) GetCoinSelectionStrategy() CoinSelectionStrategy {
+	if m != nil {
+		return m.CoinSelectionStrategy
+	}
+	return CoinSelectionStrategy_STRATEGY_USE_GLOBAL_CONFIG
+}
+
+func (m *FundPsbtRequest) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
+type FundPsbtResponse struct {
+	FundedPsbt        []byte `protobuf:"bytes,1,opt,name=funded_psbt,proto3" json:"funded_psbt,omitempty"`
+	ChangeOutputIndex int32  `protobuf:"varint,2,opt,name=change_output_index" json:"change_output_index,omitempty"`
+}
+
+func (m *FundPsbtResponse) Reset()                    { *m = FundPsbtResponse{} }
+func (m *FundPsbtResponse) String() string            { return proto.CompactTextString(m) }
+func (*FundPsbtResponse) ProtoMessage()               {}
+func (*FundPsbtResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{111} }
+
+func (m *FundPsbtResponse) GetFundedPsbt() []byte {
+	if m != nil {
+		return m.FundedPsbt
+	}
+	return nil
+}
+
+func (m *FundPsbtResponse) GetChangeOutputIndex() int32 {
+	if m != nil {
+		return m.ChangeOutputIndex
+	}
+	return 0
+}
+
+type SignPsbtRequest struct {
+	FundedPsbt []byte `protobuf:"bytes,1,opt,name=funded_psbt,proto3" json:"funded_psbt,omitempty"`
+}
+
+func (m *SignPsbtRequest) Reset()                    { *m = SignPsbtRequest{} }
+func (m *SignPsbtRequest) String() string            { return proto.CompactTextString(m) }
+func (*SignPsbtRequest) ProtoMessage()               {}
+func (*SignPsbtRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{112} }
+
+func (m *SignPsbtRequest) GetFundedPsbt() []byte {
+	if m != nil {
+		return m.FundedPsbt
+	}
+	return nil
+}
+
+type SignPsbtResponse struct {
+	SignedPsbt   []byte   `protobuf:"bytes,1,opt,name=signed_psbt,proto3" json:"signed_psbt,omitempty"`
+	SignedInputs []uint32 `protobuf:"varint,2,rep,packed,name=signed_inputs" json:"signed_inputs,omitempty"`
+}
+
+func (m *SignPsbtResponse) Reset()                    { *m = SignPsbtResponse{} }
+func (m *SignPsbtResponse) String() string            { return proto.CompactTextString(m) }
+func (*SignPsbtResponse) ProtoMessage()               {}
+func (*SignPsbtResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{113} }
+
+func (m *SignPsbtResponse) GetSignedPsbt() []byte {
+	if m != nil {
+		return m.SignedPsbt
+	}
+	return nil
+}
+
+func (m *SignPsbtResponse) GetSignedInputs() []uint32 {
+	if m != nil {
+		return m.SignedInputs
+	}
+	return nil
+}
+
+type FinalizePsbtRequest struct {
+	FundedPsbt []byte `protobuf:"bytes,1,opt,name=funded_psbt,proto3" json:"funded_psbt,omitempty"`
+}
+
+func (m *FinalizePsbtRequest) Reset()                    { *m = FinalizePsbtRequest{} }
+func (m *FinalizePsbtRequest) String() string            { return proto.CompactTextString(m) }
+func (*FinalizePsbtRequest) ProtoMessage()               {}
+func (*FinalizePsbtRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{114} }
+
+func (m *FinalizePsbtRequest) GetFundedPsbt() []byte {
+	if m != nil {
+		return m.FundedPsbt
+	}
+	return nil
+}
+
+type FinalizePsbtResponse struct {
+	SignedPsbt []byte `protobuf:"bytes,1,opt,name=signed_psbt,proto3" json:"signed_psbt,omitempty"`
+	RawFinalTx []byte `protobuf:"bytes,2,opt,name=raw_final_tx,proto3" json:"raw_final_tx,omitempty"`
+}
+
+func (m *FinalizePsbtResponse) Reset()                    { *m = FinalizePsbtResponse{} }
+func (m *FinalizePsbtResponse) String() string            { return proto.CompactTextString(m) }
+func (*FinalizePsbtResponse) ProtoMessage()               {}
+func (*FinalizePsbtResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{115} }
+
+func (m *FinalizePsbtResponse) GetSignedPsbt() []byte {
+	if m != nil {
+		return m.SignedPsbt
+	}
+	return nil
+}
+
+func (m *FinalizePsbtResponse) GetRawFinalTx() []byte {
+	if m != nil {
+		return m.RawFinalTx
+	}
+	return nil
+}
+
+type OutPoint struct {
+	TxidBytes   []byte `protobuf:"bytes,1,opt,name=txid_bytes,proto3" json:"txid_bytes,omitempty"`
+	TxidStr     string `protobuf:"bytes,2,opt,name=txid_str" json:"txid_str,omitempty"`
+	OutputIndex uint32 `protobuf:"varint,3,opt,name=output_index" json:"output_index,omitempty"`
+}
+
+func (m *OutPoint) Reset()                    { *m = OutPoint{} }
+func (m *OutPoint) String() string            { return proto.CompactTextString(m) }
+func (*OutPoint) ProtoMessage()               {}
+func (*OutPoint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{116} }
+
+func (m *OutPoint) GetTxidBytes() []byte {
+	if m != nil {
+		return m.TxidBytes
+	}
+	return nil
+}
+
+func (m *OutPoint) GetTxidStr() string {
+	if m != nil {
+		return m.TxidStr
+	}
+	return ""
+}
+
+func (m *OutPoint) GetOutputIndex() uint32 {
+	if m != nil {
+		return m.OutputIndex
+	}
+	return 0
+}
+
+type LeaseOutputRequest struct {
+	Id                []byte    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Outpoint          *OutPoint `protobuf:"bytes,2,opt,name=outpoint" json:"outpoint,omitempty"`
+	ExpirationSeconds uint64    `protobuf:"varint,3,opt,name=expiration_seconds" json:"expiration_seconds,omitempty"`
+}
+
+func (m *LeaseOutputRequest) Reset()                    { *m = LeaseOutputRequest{} }
+func (m *LeaseOutputRequest) String() string            { return proto.CompactTextString(m) }
+func (*LeaseOutputRequest) ProtoMessage()               {}
+func (*LeaseOutputRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{117} }
+
+func (m *LeaseOutputRequest) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *LeaseOutputRequest) GetOutpoint() *OutPoint {
+	if m != nil {
+		return m.Outpoint
+	}
+	return nil
+}
+
+func (m *LeaseOutputRequest) GetExpirationSeconds() uint64 {
+	if m != nil {
+		return m.ExpirationSeconds
+	}
+	return 0
+}
+
+type LeaseOutputResponse struct {
+	Expiration uint64 `protobuf:"varint,1,opt,name=expiration" json:"expiration,omitempty"`
+}
+
+func (m *LeaseOutputResponse) Reset()                    { *m = LeaseOutputResponse{} }
+func (m *LeaseOutputResponse) String() string            { return proto.CompactTextString(m) }
+func (*LeaseOutputResponse) ProtoMessage()               {}
+func (*LeaseOutputResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{118} }
+
+func (m *LeaseOutputResponse) GetExpiration() uint64 {
+	if m != nil {
+		return m.Expiration
+	}
+	return 0
+}
+
+type ReleaseOutputRequest struct {
+	Id       []byte    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Outpoint *OutPoint `protobuf:"bytes,2,opt,name=outpoint" json:"outpoint,omitempty"`
+}
+
+func (m *ReleaseOutputRequest) Reset()                    { *m = ReleaseOutputRequest{} }
+func (m *ReleaseOutputRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReleaseOutputRequest) ProtoMessage()               {}
+func (*ReleaseOutputRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{119} }
+
+func (m *ReleaseOutputRequest) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *ReleaseOutputRequest) GetOutpoint() *OutPoint {
+	if m != nil {
+		return m.Outpoint
+	}
+	return nil
+}
+
+type ReleaseOutputResponse struct {
+}
+
+func (m *ReleaseOutputResponse) Reset()                    { *m = ReleaseOutputResponse{} }
+func (m *ReleaseOutputResponse) String() string            { return proto.CompactTextString(m) }
+func (*ReleaseOutputResponse) ProtoMessage()               {}
+func (*ReleaseOutputResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{120} }
+
+type UtxoLease struct {
+	Id         []byte    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Outpoint   *OutPoint `protobuf:"bytes,2,opt,name=outpoint" json:"outpoint,omitempty"`
+	Expiration uint64    `protobuf:"varint,3,opt,name=expiration" json:"expiration,omitempty"`
+}
+
+func (m *UtxoLease) Reset()                    { *m = UtxoLease{} }
+func (m *UtxoLease) String() string            { return proto.CompactTextString(m) }
+func (*UtxoLease) ProtoMessage()               {}
+func (*UtxoLease) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{121} }
+
+func (m *UtxoLease) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *UtxoLease) GetOutpoint() *OutPoint {
+	if m != nil {
+		return m.Outpoint
+	}
+	return nil
+}
+
+func (m *UtxoLease) GetExpiration() uint64 {
+	if m != nil {
+		return m.Expiration
+	}
+	return 0
+}
+
+type ListLeasesRequest struct {
+}
+
+func (m *ListLeasesRequest) Reset()                    { *m = ListLeasesRequest{} }
+func (m *ListLeasesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListLeasesRequest) ProtoMessage()               {}
+func (*ListLeasesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{122} }
+
+type ListLeasesResponse struct {
+	LockedUtxos []*UtxoLease `protobuf:"bytes,1,rep,name=locked_utxos" json:"locked_utxos,omitempty"`
+}
+
+func (m *ListLeasesResponse) Reset()                    { *m = ListLeasesResponse{} }
+func (m *ListLeasesResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListLeasesResponse) ProtoMessage()               {}
+func (*ListLeasesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{123} }
+
+func (m *ListLeasesResponse) GetLockedUtxos() []*UtxoLease {
+	if m != nil {
+		return m.LockedUtxos
+	}
+	return nil
+}
+
+type LabelTransactionRequest struct {
+	Txid      []byte `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	Label     string `protobuf:"bytes,2,opt,name=label" json:"label,omitempty"`
+	Overwrite bool   `protobuf:"varint,3,opt,name=overwrite" json:"overwrite,omitempty"`
+}
+
+func (m *LabelTransactionRequest) Reset()                    { *m = LabelTransactionRequest{} }
+func (m *LabelTransactionRequest) String() string            { return proto.CompactTextString(m) }
+func (*LabelTransactionRequest) ProtoMessage()               {}
+func (*LabelTransactionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{124} }
+
+func (m *LabelTransactionRequest) GetTxid() []byte {
+	if m != nil {
+		return m.Txid
+	}
+	return nil
+}
+
+func (m *LabelTransactionRequest) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *LabelTransactionRequest) GetOverwrite() bool {
+	if m != nil {
+		return m.Overwrite
+	}
+	return false
+}
+
+type LabelTransactionResponse struct {
+}
+
+func (m *LabelTransactionResponse) Reset()                    { *m = LabelTransactionResponse{} }
+func (m *LabelTransactionResponse) String() string            { return proto.CompactTextString(m) }
+func (*LabelTransactionResponse) ProtoMessage()               {}
+func (*LabelTransactionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{125} }
+
+type Account struct {
+	Name                 string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	ExtendedPublicKey    string `protobuf:"bytes,2,opt,name=extended_public_key" json:"extended_public_key,omitempty"`
+	MasterKeyFingerprint uint32 `protobuf:"varint,3,opt,name=master_key_fingerprint" json:"master_key_fingerprint,omitempty"`
+	ExternalKeyCount     uint32 `protobuf:"varint,4,opt,name=external_key_count" json:"external_key_count,omitempty"`
+	InternalKeyCount     uint32 `protobuf:"varint,5,opt,name=internal_key_count" json:"internal_key_count,omitempty"`
+	WatchOnly            bool   `protobuf:"varint,6,opt,name=watch_only" json:"watch_only,omitempty"`
+}
+
+func (m *Account) Reset()                    { *m = Account{} }
+func (m *Account) String() string            { return proto.CompactTextString(m) }
+func (*Account) ProtoMessage()               {}
+func (*Account) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{126} }
+
+func (m *Account) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Account) GetExtendedPublicKey() string {
+	if m != nil {
+		return m.ExtendedPublicKey
+	}
+	return ""
+}
+
+func (m *Account) GetMasterKeyFingerprint() uint32 {
+	if m != nil {
+		return m.MasterKeyFingerprint
+	}
+	return 0
+}
+
+func (m *Account) GetExternalKeyCount() uint32 {
+	if m != nil {
+		return m.ExternalKeyCount
+	}
+	return 0
+}
+
+func (m *Account) GetInternalKeyCount() uint32 {
+	if m != nil {
+		return m.InternalKeyCount
+	}
+	return 0
+}
+
+func (m *Account) GetWatchOnly() bool {
+	if m != nil {
+		return m.WatchOnly
+	}
+	return false
+}
+
+type ImportAccountRequest struct {
+	Name                 string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	ExtendedPublicKey    string `protobuf:"bytes,2,opt,name=extended_public_key" json:"extended_public_key,omitempty"`
+	MasterKeyFingerprint uint32 `protobuf:"varint,3,opt,name=master_key_fingerprint" json:"master_key_fingerprint,omitempty"`
+	DryRun               bool   `protobuf:"varint,4,opt,name=dry_run" json:"dry_run,omitempty"`
+	// The height of the first block which may contain outputs paying to
+	// the account. The chain is rescanned from this height once the
+	// account is imported. If zero, then the entire chain is rescanned.
+	BirthdayHeight uint32 `protobuf:"varint,5,opt,name=birthday_height" json:"birthday_height,omitempty"`
+}
+
+func (m *ImportAccountRequest) Reset()                    { *m = ImportAccountRequest{} }
+func (m *ImportAccountRequest) String() string            { return proto.CompactTextString(m) }
+func (*ImportAccountRequest) ProtoMessage()               {}
+func (*ImportAccountRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{127} }
+
+func (m *ImportAccountRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ImportAccountRequest) GetExtendedPublicKey() string {
+	if m != nil {
+		return m.ExtendedPublicKey
+	}
+	return ""
+}
+
+func (m *ImportAccountRequest) GetMasterKeyFingerprint() uint32 {
+	if m != nil {
+		return m.MasterKeyFingerprint
+	}
+	return 0
+}
+
+func (m *ImportAccountRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *ImportAccountRequest) GetBirthdayHeight() uint32 {
+	if m != nil {
+		return m.BirthdayHeight
+	}
+	return 0
+}
+
+type ImportAccountResponse struct {
+	Account             *Account `protobuf:"bytes,1,opt,name=account" json:"account,omitempty"`
+	DryRunExternalAddrs []string `protobuf:"bytes,2,rep,name=dry_run_external_addrs" json:"dry_run_external_addrs,omitempty"`
+	DryRunInternalAddrs []string `protobuf:"bytes,3,rep,name=dry_run_internal_addrs" json:"dry_run_internal_addrs,omitempty"`
+}
+
+func (m *ImportAccountResponse) Reset()                    { *m = ImportAccountResponse{} }
+func (m *ImportAccountResponse) String() string            { return proto.CompactTextString(m) }
+func (*ImportAccountResponse) ProtoMessage()               {}
+func (*ImportAccountResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{128} }
+
+func (m *ImportAccountResponse) GetAccount() *Account {
+	if m != nil {
+		return m.Account
+	}
+	return nil
+}
+
+func (m *ImportAccountResponse) GetDryRunExternalAddrs() []string {
+	if m != nil {
+		return m.DryRunExternalAddrs
+	}
+	return nil
+}
+
+func (m *ImportAccountResponse) GetDryRunInternalAddrs() []string {
+	if m != nil {
+		return m.DryRunInternalAddrs
+	}
+	return nil
+}
+
+type ListAccountsRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *ListAccountsRequest) Reset()                    { *m = ListAccountsRequest{} }
+func (m *ListAccountsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListAccountsRequest) ProtoMessage()               {}
+func (*ListAccountsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{129} }
+
+func (m *ListAccountsRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ListAccountsResponse struct {
+	Accounts []*Account `protobuf:"bytes,1,rep,name=accounts" json:"accounts,omitempty"`
+}
+
+func (m *ListAccountsResponse) Reset()                    { *m = ListAccountsResponse{} }
+func (m *ListAccountsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListAccountsResponse) ProtoMessage()               {}
+func (*ListAccountsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{130} }
+
+func (m *ListAccountsResponse) GetAccounts() []*Account {
+	if m != nil {
+		return m.Accounts
+	}
+	return nil
+}
+
+type GetRecoveryInfoRequest struct {
+}
+
+func (m *GetRecoveryInfoRequest) Reset()                    { *m = GetRecoveryInfoRequest{} }
+func (m *GetRecoveryInfoRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetRecoveryInfoRequest) ProtoMessage()               {}
+func (*GetRecoveryInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{131} }
+
+type GetRecoveryInfoResponse struct {
+	RecoveryMode     bool    `protobuf:"varint,1,opt,name=recovery_mode" json:"recovery_mode,omitempty"`
+	RecoveryFinished bool    `protobuf:"varint,2,opt,name=recovery_finished" json:"recovery_finished,omitempty"`
+	Progress         float64 `protobuf:"fixed64,3,opt,name=progress" json:"progress,omitempty"`
+}
+
+func (m *GetRecoveryInfoResponse) Reset()                    { *m = GetRecoveryInfoResponse{} }
+func (m *GetRecoveryInfoResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetRecoveryInfoResponse) ProtoMessage()               {}
+func (*GetRecoveryInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{132} }
+
+func (m *GetRecoveryInfoResponse) GetRecoveryMode() bool {
+	if m != nil {
+		return m.RecoveryMode
+	}
+	return false
+}
+
+func (m *GetRecoveryInfoResponse) GetRecoveryFinished() bool {
+	if m != nil {
+		return m.RecoveryFinished
+	}
+	return false
+}
+
+func (m *GetRecoveryInfoResponse) GetProgress() float64 {
+	if m != nil {
+		return m.Progress
+	}
+	return 0
+}
+
+type DebugLevelRequest struct {
+	Show      bool   `protobuf:"varint,1,opt,name=show" json:"show,omitempty"`
+	LevelSpec string `protobuf:"bytes,2,opt,name=level_spec" json:"level_spec,omitempty"`
+}
+
+func (m *DebugLevelRequest) Reset()                    { *m = DebugLevelRequest{} }
+func (m *DebugLevelRequest) String() string            { return proto.CompactTextString(m) }
+func (*DebugLevelRequest) ProtoMessage()               {}
+func (*DebugLevelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{133} }
+
+func (m *DebugLevelRequest) GetShow() bool {
+	if m != nil {
+		return m.Show
+	}
+	return false
+}
+
+func (m *DebugLevelRequest) GetLevelSpec() string {
+	if m != nil {
+		return m.LevelSpec
+	}
+	return ""
+}
+
+type DebugLevelResponse struct {
+	SubSystems string `protobuf:"bytes,1,opt,name=sub_systems" json:"sub_systems,omitempty"`
+}
+
+func (m *DebugLevelResponse) Reset()                    { *m = DebugLevelResponse{} }
+func (m *DebugLevelResponse) String() string            { return proto.CompactTextString(m) }
+func (*DebugLevelResponse) ProtoMessage()               {}
+func (*DebugLevelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{134} }
+
+func (m *DebugLevelResponse) GetSubSystems() string {
+	if m != nil {
+		return m.SubSystems
+	}
+	return ""
+}
+
+type AutopilotStatusRequest struct {
+}
+
+func (m *AutopilotStatusRequest) Reset()                    { *m = AutopilotStatusRequest{} }
+func (m *AutopilotStatusRequest) String() string            { return proto.CompactTextString(m) }
+func (*AutopilotStatusRequest) ProtoMessage()               {}
+func (*AutopilotStatusRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{135} }
+
+type AutopilotStatusResponse struct {
+	Active bool `protobuf:"varint,1,opt,name=active" json:"active,omitempty"`
+}
+
+func (m *AutopilotStatusResponse) Reset()                    { *m = AutopilotStatusResponse{} }
+func (m *AutopilotStatusResponse) String() string            { return proto.CompactTextString(m) }
+func (*AutopilotStatusResponse) ProtoMessage()               {}
+func (*AutopilotStatusResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{136} }
+
+func (m *AutopilotStatusResponse) GetActive() bool {
+	if m != nil {
+		return m.Active
+	}
+	return false
+}
+
+type ModifyAutopilotStatusRequest struct {
+	Enable bool `protobuf:"varint,1,opt,name=enable" json:"enable,omitempty"`
+}
+
+func (m *ModifyAutopilotStatusRequest) Reset()                    { *m = ModifyAutopilotStatusRequest{} }
+func (m *ModifyAutopilotStatusRequest) String() string            { return proto.CompactTextString(m) }
+func (*ModifyAutopilotStatusRequest) ProtoMessage()               {}
+func (*ModifyAutopilotStatusRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{137} }
+
+func (m *ModifyAutopilotStatusRequest) GetEnable() bool {
+	if m != nil {
+		return m.Enable
+	}
+	return false
+}
+
+type ModifyAutopilotStatusResponse struct {
+}
+
+func (m *ModifyAutopilotStatusResponse) Reset()         { *m = ModifyAutopilotStatusResponse{} }
+func (m *ModifyAutopilotStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*ModifyAutopilotStatusResponse) ProtoMessage()    {}
+func (*ModifyAutopilotStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{138}
+}
+
+type QueryAutopilotScoresRequest struct {
+	// The hex encoded public keys of the nodes to score. If empty, every
+	// node within the channel graph is scored.
+	Pubkeys []string `protobuf:"bytes,1,rep,name=pubkeys" json:"pubkeys,omitempty"`
+}
+
+func (m *QueryAutopilotScoresRequest) Reset()                    { *m = QueryAutopilotScoresRequest{} }
+func (m *QueryAutopilotScoresRequest) String() string            { return proto.CompactTextString(m) }
+func (*QueryAutopilotScoresRequest) ProtoMessage()               {}
+func (*QueryAutopilotScoresRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{139} }
+
+func (m *QueryAutopilotScoresRequest) GetPubkeys() []string {
+	if m != nil {
+		return m.Pubkeys
+	}
+	return nil
+}
+
+type AutopilotNodeScore struct {
+	PubKey string  `protobuf:"bytes,1,opt,name=pub_key" json:"pub_key,omitempty"`
+	Score  float64 `protobuf:"fixed64,2,opt,name=score" json:"score,omitempty"`
+}
+
+func (m *AutopilotNodeScore) Reset()                    { *m = AutopilotNodeScore{} }
+func (m *AutopilotNodeScore) String() string            { return proto.CompactTextString(m) }
+func (*AutopilotNodeScore) ProtoMessage()               {}
+func (*AutopilotNodeScore) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{140} }
+
+func (m *AutopilotNodeScore) GetPubKey() string {
+	if m != nil {
+		return m.PubKey
+	}
+	return ""
+}
+
+func (m *AutopilotNodeScore) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+type QueryAutopilotScoresResponse struct {
+	// The name of the heuristic the scores were assigned by.
+	Heuristic string `protobuf:"bytes,1,opt,name=heuristic" json:"heuristic,omitempty"`
+	// The scores of the nodes, ordered by descending score. Nodes the
+	// heuristic wouldn't open a channel to are omitted.
+	Scores []*AutopilotNodeScore `protobuf:"bytes,2,rep,name=scores" json:"scores,omitempty"`
+}
+
+func (m *QueryAutopilotScoresResponse) Reset()                    { *m = QueryAutopilotScoresResponse{} }
+func (m *QueryAutopilotScoresResponse) String() string            { return proto.CompactTextString(m) }
+func (*QueryAutopilotScoresResponse) ProtoMessage()               {}
+func (*QueryAutopilotScoresResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{141} }
+
+func (m *QueryAutopilotScoresResponse) GetHeuristic() string {
+	if m != nil {
+		return m.Heuristic
+	}
+	return ""
+}
+
+func (m *QueryAutopilotScoresResponse) GetScores() []*AutopilotNodeScore {
+	if m != nil {
+		return m.Scores
+	}
+	return nil
+}
+
+type ChannelInsightsRequest struct {
+	// The channel to return insights into. If unset, insights into every
+	// monitored channel are returned.
+	ChanPoint *ChannelPoint `protobuf:"bytes,1,opt,name=chan_point" json:"chan_point,omitempty"`
+}
+
+func (m *ChannelInsightsRequest) Reset()                    { *m = ChannelInsightsRequest{} }
+func (m *ChannelInsightsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ChannelInsightsRequest) ProtoMessage()               {}
+func (*ChannelInsightsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{142} }
+
+func (m *ChannelInsightsRequest) GetChanPoint() *ChannelPoint {
+	if m != nil {
+		return m.ChanPoint
+	}
+	return nil
+}
+
+type ChannelInsight struct {
+	ChanPoint    string `protobuf:"bytes,1,opt,name=chan_point" json:"chan_point,omitempty"`
+	RemotePubkey string `protobuf:"bytes,2,opt,name=remote_pubkey" json:"remote_pubkey,omitempty"`
+	// The number of seconds the channel has been monitored for, since
+	// either the node started or the channel was opened.
+	Lifetime int64 `protobuf:"varint,3,opt,name=lifetime" json:"lifetime,omitempty"`
+	// The number of seconds the channel's peer has been online within the
+	// channel's lifetime.
+	Uptime int64 `protobuf:"varint,4,opt,name=uptime" json:"uptime,omitempty"`
+	// The number of seconds the channel has been usable for forwarding
+	// HTLCs within its lifetime.
+	ActiveTime int64 `protobuf:"varint,5,opt,name=active_time" json:"active_time,omitempty"`
+	// The number of times the channel's peer has gone offline within the
+	// channel's lifetime, and the unix timestamp of the last time it did.
+	FlapCount uint32 `protobuf:"varint,6,opt,name=flap_count" json:"flap_count,omitempty"`
+	LastFlap  int64  `protobuf:"varint,7,opt,name=last_flap" json:"last_flap,omitempty"`
+}
+
+func (m *ChannelInsight) Reset()                    { *m = ChannelInsight{} }
+func (m *ChannelInsight) String() string            { return proto.CompactTextString(m) }
+func (*ChannelInsight) ProtoMessage()               {}
+func (*ChannelInsight) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{143} }
+
+func (m *ChannelInsight) GetChanPoint() string {
+	if m != nil {
+		return m.ChanPoint
+	}
+	return ""
+}
+
+func (m *ChannelInsight) GetRemotePubkey() string {
+	if m != nil {
+		return m.RemotePubkey
+	}
+	return ""
+}
+
+func (m *ChannelInsight) GetLifetime() int64 {
+	if m != nil {
+		return m.Lifetime
+	}
+	return 0
+}
+
+func (m *ChannelInsight) GetUptime() int64 {
+	if m != nil {
+		return m.Uptime
+	}
+	return 0
+}
+
+func (m *ChannelInsight) GetActiveTime() int64 {
+	if m != nil {
+		return m.ActiveTime
+	}
+	return 0
+}
+
+func (m *ChannelInsight) GetFlapCount() uint32 {
+	if m != nil {
+		return m.FlapCount
+	}
+	return 0
+}
+
+func (m *ChannelInsight) GetLastFlap() int64 {
+	if m != nil {
+		return m.LastFlap
+	}
+	return 0
+}
+
+type ChannelInsightsResponse struct {
+	Insights []*ChannelInsight `protobuf:"bytes,1,rep,name=insights" json:"insights,omitempty"`
+}
+
+func (m *ChannelInsightsResponse) Reset()                    { *m = ChannelInsightsResponse{} }
+func (m *ChannelInsightsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ChannelInsightsResponse) ProtoMessage()               {}
+func (*ChannelInsightsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{144} }
+
+func (m *ChannelInsightsResponse) GetInsights() []*ChannelInsight {
+	if m != nil {
+		return m.Insights
+	}
+	return nil
+}
+
+type SetFeeManagementRequest struct {
+	// The channel to opt into, or out of, fee management.
+	ChanPoint *ChannelPoint `protobuf:"bytes,1,opt,name=chan_point" json:"chan_point,omitempty"`
+	// Whether the fees of the channel should be adjusted automatically by
+	// the fee manager, based on its liquidity and recent flow.
+	Enable bool `protobuf:"varint,2,opt,name=enable" json:"enable,omitempty"`
+}
+
+func (m *SetFeeManagementRequest) Reset()                    { *m = SetFeeManagementRequest{} }
+func (m *SetFeeManagementRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetFeeManagementRequest) ProtoMessage()               {}
+func (*SetFeeManagementRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{145} }
+
+func (m *SetFeeManagementRequest) GetChanPoint() *ChannelPoint {
+	if m != nil {
+		return m.ChanPoint
+	}
+	return nil
+}
+
+func (m *SetFeeManagementRequest) GetEnable() bool {
+	if m != nil {
+		return m.Enable
+	}
+	return false
+}
+
+type SetFeeManagementResponse struct {
+}
+
+func (m *SetFeeManagementResponse) Reset()                    { *m = SetFeeManagementResponse{} }
+func (m *SetFeeManagementResponse) String() string            { return proto.CompactTextString(m) }
+func (*SetFeeManagementResponse) ProtoMessage()               {}
+func (*SetFeeManagementResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{146} }
+
+type SetScoresRequest struct {
+	// The name of the heuristic to set the scores of, which must be one of
+	// the active heuristics accepting external scores.
+	Heuristic string `protobuf:"bytes,1,opt,name=heuristic" json:"heuristic,omitempty"`
+	// The new scores, each between 0 and 1, replacing all previously set
+	// scores. Nodes without a score are scored 0.
+	Scores []*AutopilotNodeScore `protobuf:"bytes,2,rep,name=scores" json:"scores,omitempty"`
+}
+
+func (m *SetScoresRequest) Reset()                    { *m = SetScoresRequest{} }
+func (m *SetScoresRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetScoresRequest) ProtoMessage()               {}
+func (*SetScoresRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{147} }
+
+func (m *SetScoresRequest) GetHeuristic() string {
+	if m != nil {
+		return m.Heuristic
+	}
+	return ""
+}
+
+func (m *SetScoresRequest) GetScores() []*AutopilotNodeScore {
+	if m != nil {
+		return m.Scores
+	}
+	return nil
+}
+
+type SetScoresResponse struct {
+}
+
+func (m *SetScoresResponse) Reset()                    { *m = SetScoresResponse{} }
+func (m *SetScoresResponse) String() string            { return proto.CompactTextString(m) }
+func (*SetScoresResponse) ProtoMessage()               {}
+func (*SetScoresResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{148} }
+
+type QueryScoresRequest struct {
+	// The hex encoded public keys of the nodes to score. If empty, every
+	// node within the channel graph is scored.
+	Pubkeys []string `protobuf:"bytes,1,rep,name=pubkeys" json:"pubkeys,omitempty"`
+	// If set, then our existing channels aren't taken into account, so
+	// nodes we already have a channel with are scored as well.
+	IgnoreLocalState bool `protobuf:"varint,2,opt,name=ignore_local_state" json:"ignore_local_state,omitempty"`
+}
+
+func (m *QueryScoresRequest) Reset()                    { *m = QueryScoresRequest{} }
+func (m *QueryScoresRequest) String() string            { return proto.CompactTextString(m) }
+func (*QueryScoresRequest) ProtoMessage()               {}
+func (*QueryScoresRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{149} }
+
+func (m *QueryScoresRequest) GetPubkeys() []string {
+	if m != nil {
+		return m.Pubkeys
+	}
+	return nil
+}
+
+func (m *QueryScoresRequest) GetIgnoreLocalState() bool {
+	if m != nil {
+		return m.IgnoreLocalState
+	}
+	return false
+}
+
+type HeuristicResult struct {
+	// The name of the heuristic.
+	Heuristic string `protobuf:"bytes,1,opt,name=heuristic" json:"heuristic,omitempty"`
+	// The weight of the heuristic's scores within the combined score.
+	Weight float64 `protobuf:"fixed64,2,opt,name=weight" json:"weight,omitempty"`
+	// The scores of the nodes, ordered by descending score.
+	Scores []*AutopilotNodeScore `protobuf:"bytes,3,rep,name=scores" json:"scores,omitempty"`
+}
+
+func (m *HeuristicResult) Reset()                    { *m = HeuristicResult{} }
+func (m *HeuristicResult) String() string            { return proto.CompactTextString(m) }
+func (*HeuristicResult) ProtoMessage()               {}
+func (*HeuristicResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{150} }
+
+func (m *HeuristicResult) GetHeuristic() string {
+	if m != nil {
+		return m.Heuristic
+	}
+	return ""
+}
+
+func (m *HeuristicResult) GetWeight() float64 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
+func (m *HeuristicResult) GetScores() []*AutopilotNodeScore {
+	if m != nil {
+		return m.Scores
+	}
+	return nil
+}
+
+type QueryScoresResponse struct {
+	Results []*HeuristicResult `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
+}
+
+func (m *QueryScoresResponse) Reset()                    { *m = QueryScoresResponse{} }
+func (m *QueryScoresResponse) String() string            { return proto.CompactTextString(m) }
+func (*QueryScoresResponse) ProtoMessage()               {}
+func (*QueryScoresResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{151} }
+
+func (m *QueryScoresResponse) GetResults() []*HeuristicResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type SubscribeStateRequest struct {
+}
+
+func (m *SubscribeStateRequest) Reset()                    { *m = SubscribeStateRequest{} }
+func (m *SubscribeStateRequest) String() string            { return proto.CompactTextString(m) }
+func (*SubscribeStateRequest) ProtoMessage()               {}
+func (*SubscribeStateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{152} }
+
+type SubscribeStateResponse struct {
+	State WalletState `protobuf:"varint,1,opt,name=state,enum=lnrpc.WalletState" json:"state,omitempty"`
+}
+
+func (m *SubscribeStateResponse) Reset()                    { *m = SubscribeStateResponse{} }
+func (m *SubscribeStateResponse) String() string            { return proto.CompactTextString(m) }
+func (*SubscribeStateResponse) ProtoMessage()               {}
+func (*SubscribeStateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{153} }
+
+func (m *SubscribeStateResponse) GetState() WalletState {
+	if m != nil {
+		return m.State
+	}
+	return WalletState_NON_EXISTING
+}
+
+type GetStateRequest struct {
+}
+
+func (m *GetStateRequest) Reset()                    { *m = GetStateRequest{} }
+func (m *GetStateRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetStateRequest) ProtoMessage()               {}
+func (*GetStateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{154} }
+
+type GetStateResponse struct {
+	State WalletState `protobuf:"varint,1,opt,name=state,enum=lnrpc.WalletState" json:"state,omitempty"`
+}
+
+func (m *GetStateResponse) Reset()                    { *m = GetStateResponse{} }
+func (m *GetStateResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetStateResponse) ProtoMessage()               {}
+func (*GetStateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{155} }
+
+func (m *GetStateResponse) GetState() WalletState {
+	if m != nil {
+		return m.State
+	}
+	return WalletState_NON_EXISTING
+}
+
+type ExportChannelBackupRequest struct {
+	// The channel point of the channel to back up.
+	ChanPoint *ChannelPoint `protobuf:"bytes,1,opt,name=chan_point" json:"chan_point,omitempty"`
+}
+
+func (m *ExportChannelBackupRequest) Reset()                    { *m = ExportChannelBackupRequest{} }
+func (m *ExportChannelBackupRequest) String() string            { return proto.CompactTextString(m) }
+func (*ExportChannelBackupRequest) ProtoMessage()               {}
+func (*ExportChannelBackupRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{156} }
+
+func (m *ExportChannelBackupRequest) GetChanPoint() *ChannelPoint {
+	if m != nil {
+		return m.ChanPoint
+	}
+	return nil
+}
+
+type ChannelBackup struct {
+	// The channel point of the backed up channel.
+	ChanPoint *ChannelPoint `protobuf:"bytes,1,opt,name=chan_point" json:"chan_point,omitempty"`
+	// The encrypted static backup of the channel.
+	ChanBackup []byte `protobuf:"bytes,2,opt,name=chan_backup,proto3" json:"chan_backup,omitempty"`
+}
+
+func (m *ChannelBackup) Reset()                    { *m = ChannelBackup{} }
+func (m *ChannelBackup) String() string            { return proto.CompactTextString(m) }
+func (*ChannelBackup) ProtoMessage()               {}
+func (*ChannelBackup) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{157} }
+
+func (m *ChannelBackup) GetChanPoint() *ChannelPoint {
+	if m != nil {
+		return m.ChanPoint
+	}
+	return nil
+}
+
+func (m *ChannelBackup) GetChanBackup() []byte {
+	if m != nil {
+		return m.ChanBackup
+	}
+	return nil
+}
+
+type ChannelBackups struct {
+	ChanBackups []*ChannelBackup `protobuf:"bytes,1,rep,name=chan_backups" json:"chan_backups,omitempty"`
+}
+
+func (m *ChannelBackups) Reset()                    { *m = ChannelBackups{} }
+func (m *ChannelBackups) String() string            { return proto.CompactTextString(m) }
+func (*ChannelBackups) ProtoMessage()               {}
+func (*ChannelBackups) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{158} }
+
+func (m *ChannelBackups) GetChanBackups() []*ChannelBackup {
+	if m != nil {
+		return m.ChanBackups
+	}
+	return nil
+}
+
+type MultiChanBackup struct {
+	// The channel points of the backed up channels.
+	ChanPoints []*ChannelPoint `protobuf:"bytes,1,rep,name=chan_points" json:"chan_points,omitempty"`
+	// The encrypted static backup of all the channels.
+	MultiChanBackup []byte `protobuf:"bytes,2,opt,name=multi_chan_backup,proto3" json:"multi_chan_backup,omitempty"`
+}
+
+func (m *MultiChanBackup) Reset()                    { *m = MultiChanBackup{} }
+func (m *MultiChanBackup) String() string            { return proto.CompactTextString(m) }
+func (*MultiChanBackup) ProtoMessage()               {}
+func (*MultiChanBackup) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{159} }
+
+func (m *MultiChanBackup) GetChanPoints() []*ChannelPoint {
+	if m != nil {
+		return m.ChanPoints
+	}
+	return nil
+}
+
+func (m *MultiChanBackup) GetMultiChanBackup() []byte {
+	if m != nil {
+		return m.MultiChanBackup
+	}
+	return nil
+}
+
+type ChanBackupExportRequest struct {
+}
+
+func (m *ChanBackupExportRequest) Reset()                    { *m = ChanBackupExportRequest{} }
+func (m *ChanBackupExportRequest) String() string            { return proto.CompactTextString(m) }
+func (*ChanBackupExportRequest) ProtoMessage()               {}
+func (*ChanBackupExportRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{160} }
+
+type ChanBackupSnapshot struct {
+	// A backup of each of our open channels.
+	SingleChanBackups *ChannelBackups `protobuf:"bytes,1,opt,name=single_chan_backups" json:"single_chan_backups,omitempty"`
+	// A single backup of all our open channels.
+	MultiChanBackup *MultiChanBackup `protobuf:"bytes,2,opt,name=multi_chan_backup" json:"multi_chan_backup,omitempty"`
+}
+
+func (m *ChanBackupSnapshot) Reset()                    { *m = ChanBackupSnapshot{} }
+func (m *ChanBackupSnapshot) String() string            { return proto.CompactTextString(m) }
+func (*ChanBackupSnapshot) ProtoMessage()               {}
+func (*ChanBackupSnapshot) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{161} }
+
+func (m *ChanBackupSnapshot) GetSingleChanBackups() *ChannelBackups {
+	if m != nil {
+		return m.SingleChanBackups
+	}
+	return nil
+}
+
+func (m *ChanBackupSnapshot) GetMultiChanBackup() *MultiChanBackup {
+	if m != nil {
+		return m.MultiChanBackup
+	}
+	return nil
+}
+
+type RestoreChanBackupRequest struct {
+	// Types that are valid to be assigned to Backup:
+	//	*RestoreChanBackupRequest_ChanBackups
+	//	*RestoreChanBackupRequest_MultiChanBackup
+	Backup isRestoreChanBackupRequest_Backup `protobuf_oneof:"backup"`
+}
+
+func (m *RestoreChanBackupRequest) Reset()                    { *m = RestoreChanBackupRequest{} }
+func (m *RestoreChanBackupRequest) String() string            { return proto.CompactTextString(m) }
+func (*RestoreChanBackupRequest) ProtoMessage()               {}
+func (*RestoreChanBackupRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{162} }
+
+type isRestoreChanBackupRequest_Backup interface {
+	isRestoreChanBackupRequest_Backup()
+}
+
+type RestoreChanBackupRequest_ChanBackups struct {
+	ChanBackups *ChannelBackups `protobuf:"bytes,1,opt,name=chan_backups,oneof"`
+}
+type RestoreChanBackupRequest_MultiChanBackup struct {
+	MultiChanBackup []byte `protobuf:"bytes,2,opt,name=multi_chan_backup,proto3,oneof"`
+}
+
+func (*RestoreChanBackupRequest_ChanBackups) isRestoreChanBackupRequest_Backup()     {}
+func (*RestoreChanBackupRequest_MultiChanBackup) isRestoreChanBackupRequest_Backup() {}
+
+func (m *RestoreChanBackupRequest) GetBackup() isRestoreChanBackupRequest_Backup {
+	if m != nil {
+		return m.Backup
+	}
+	return nil
+}
+
+func (m *RestoreChanBackupRequest) GetChanBackups() *ChannelBackups {
+	if x, ok := m.GetBackup().(*RestoreChanBackupRequest_ChanBackups); ok {
+		return x.ChanBackups
+	}
+	return nil
+}
+
+func (m *RestoreChanBackupRequest) GetMultiChanBackup() []byte {
+	if x, ok := m.GetBackup().(*RestoreChanBackupRequest_MultiChanBackup); ok {
+		return x.MultiChanBackup
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*RestoreChanBackupRequest) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _RestoreChanBackupRequest_OneofMarshaler, _RestoreChanBackupRequest_OneofUnmarshaler, _RestoreChanBackupRequest_OneofSizer, []interface{}{
+		(*RestoreChanBackupRequest_ChanBackups)(nil),
+		(*RestoreChanBackupRequest_MultiChanBackup)(nil),
+	}
+}
+
+func _RestoreChanBackupRequest_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*RestoreChanBackupRequest)
+	// backup
+	switch x := m.Backup.(type) {
+	case *RestoreChanBackupRequest_ChanBackups:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ChanBackups); err != nil {
+			return err
+		}
+	case *RestoreChanBackupRequest_MultiChanBackup:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		b.EncodeRawBytes(x.MultiChanBackup)
+	case nil:
+	default:
+		return fmt.Errorf("RestoreChanBackupRequest.Backup has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _RestoreChanBackupRequest_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*RestoreChanBackupRequest)
+	switch tag {
+	case 1: // backup.chan_backups
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ChannelBackups)
+		err := b.DecodeMessage(msg)
+		m.Backup = &RestoreChanBackupRequest_ChanBackups{msg}
+		return true, err
+	case 2: // backup.multi_chan_backup
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeRawBytes(true)
+		m.Backup = &RestoreChanBackupRequest_MultiChanBackup{x}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _RestoreChanBackupRequest_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*RestoreChanBackupRequest)
+	// backup
+	switch x := m.Backup.(type) {
+	case *RestoreChanBackupRequest_ChanBackups:
+		s := proto.Size(x.ChanBackups)
+		n += proto.SizeVarint(1<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *RestoreChanBackupRequest_MultiChanBackup:
+		n += proto.SizeVarint(2<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(len(x.MultiChanBackup)))
+		n += len(x.MultiChanBackup)
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type RestoreBackupResponse struct {
+	// The number of channels restored. Channels which are still open
+	// aren't restored.
+	NumRestored uint32 `protobuf:"varint,1,opt,name=num_restored" json:"num_restored,omitempty"`
+}
+
+func (m *RestoreBackupResponse) Reset()                    { *m = RestoreBackupResponse{} }
+func (m *RestoreBackupResponse) String() string            { return proto.CompactTextString(m) }
+func (*RestoreBackupResponse) ProtoMessage()               {}
+func (*RestoreBackupResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{163} }
+
+func (m *RestoreBackupResponse) GetNumRestored() uint32 {
+	if m != nil {
+		return m.NumRestored
+	}
+	return 0
+}
+
+type VerifyChanBackupResponse struct {
+	// The channel points of the channels within the verified backups.
+	ChanPoints []*ChannelPoint `protobuf:"bytes,1,rep,name=chan_points" json:"chan_points,omitempty"`
+}
+
+func (m *VerifyChanBackupResponse) Reset()                    { *m = VerifyChanBackupResponse{} }
+func (m *VerifyChanBackupResponse) String() string            { return proto.CompactTextString(m) }
+func (*VerifyChanBackupResponse) ProtoMessage()               {}
+func (*VerifyChanBackupResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{164} }
+
+func (m *VerifyChanBackupResponse) GetChanPoints() []*ChannelPoint {
+	if m != nil {
+		return m.ChanPoints
+	}
+	return nil
+}
+
+type GetDebugInfoRequest struct {
+	// Whether to include the stack traces of all goroutines.
+	IncludeGoroutines bool `protobuf:"varint,1,opt,name=include_goroutines" json:"include_goroutines,omitempty"`
+	// Whether to include a heap profile in the pprof format.
+	IncludeHeapProfile bool `protobuf:"varint,2,opt,name=include_heap_profile" json:"include_heap_profile,omitempty"`
+	// The maximum number of recent log lines to include. If zero, all
+	// retained log lines are included.
+	NumLogLines uint32 `protobuf:"varint,3,opt,name=num_log_lines" json:"num_log_lines,omitempty"`
+}
+
+func (m *GetDebugInfoRequest) Reset()                    { *m = GetDebugInfoRequest{} }
+func (m *GetDebugInfoRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetDebugInfoRequest) ProtoMessage()               {}
+func (*GetDebugInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{165} }
+
+func (m *GetDebugInfoRequest) GetIncludeGoroutines() bool {
+	if m != nil {
+		return m.IncludeGoroutines
+	}
+	return false
+}
+
+func (m *GetDebugInfoRequest) GetIncludeHeapProfile() bool {
+	if m != nil {
+		return m.IncludeHeapProfile
+	}
+	return false
+}
+
+func (m *GetDebugInfoRequest) GetNumLogLines() uint32 {
+	if m != nil {
+		return m.NumLogLines
+	}
+	return 0
+}
+
+type FeatureSet struct {
+	Name     string     `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Features []*Feature `protobuf:"bytes,2,rep,name=features" json:"features,omitempty"`
+}
+
+func (m *FeatureSet) Reset()                    { *m = FeatureSet{} }
+func (m *FeatureSet) String() string            { return proto.CompactTextString(m) }
+func (*FeatureSet) ProtoMessage()               {}
+func (*FeatureSet) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{166} }
+
+func (m *FeatureSet) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *FeatureSet) GetFeatures() []*Feature {
+	if m != nil {
+		return m.Features
+	}
+	return nil
+}
+
+type SubserverStatus struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// Whether the subserver is currently active.
+	Active bool `protobuf:"varint,2,opt,name=active" json:"active,omitempty"`
+}
+
+func (m *SubserverStatus) Reset()                    { *m = SubserverStatus{} }
+func (m *SubserverStatus) String() string            { return proto.CompactTextString(m) }
+func (*SubserverStatus) ProtoMessage()               {}
+func (*SubserverStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{167} }
+
+func (m *SubserverStatus) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SubserverStatus) GetActive() bool {
+	if m != nil {
+		return m.Active
+	}
+	return false
+}
+
+type GetDebugInfoResponse struct {
+	Version string `protobuf:"bytes,1,opt,name=version" json:"version,omitempty"`
+	// The active value of each config option, keyed by its namespaced
+	// name. The values of sensitive options are redacted.
+	Config map[string]string `protobuf:"bytes,2,rep,name=config" json:"config,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The features we advertise within each feature set.
+	FeatureSets []*FeatureSet      `protobuf:"bytes,3,rep,name=feature_sets" json:"feature_sets,omitempty"`
+	Subservers  []*SubserverStatus `protobuf:"bytes,4,rep,name=subservers" json:"subservers,omitempty"`
+	// The port HTTP profiling is served on, or empty if it's disabled.
+	ProfilePort string `protobuf:"bytes,5,opt,name=profile_port" json:"profile_port,omitempty"`
+	// The stack traces of all goroutines, if requested.
+	Goroutines string `protobuf:"bytes,6,opt,name=goroutines" json:"goroutines,omitempty"`
+	// The heap profile in the pprof format, if requested.
+	HeapProfile []byte `protobuf:"bytes,7,opt,name=heap_profile,proto3" json:"heap_profile,omitempty"`
+	// The most recent lines of the log, oldest first.
+	LogLines []string `protobuf:"bytes,8,rep,name=log_lines" json:"log_lines,omitempty"`
+}
+
+func (m *GetDebugInfoResponse) Reset()                    { *m = GetDebugInfoResponse{} }
+func (m *GetDebugInfoResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetDebugInfoResponse) ProtoMessage()               {}
+func (*GetDebugInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{168} }
+
+func (m *GetDebugInfoResponse) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *GetDebugInfoResponse) GetConfig() map[string]string {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+func (m *GetDebugInfoResponse) GetFeatureSets() []*FeatureSet {
+	if m != nil {
+		return m.FeatureSets
+	}
+	return nil
+}
+
+func (m *GetDebugInfoResponse) GetSubservers() []*SubserverStatus {
+	if m != nil {
+		return m.Subservers
+	}
+	return nil
+}
+
+func (m *GetDebugInfoResponse) GetProfilePort() string {
+	if m != nil {
+		return m.ProfilePort
+	}
+	return ""
+}
+
+func (m *GetDebugInfoResponse) GetGoroutines() string {
+	if m != nil {
+		return m.Goroutines
+	}
+	return ""
+}
+
+func (m *GetDebugInfoResponse) GetHeapProfile() []byte {
+	if m != nil {
+		return m.HeapProfile
+	}
+	return nil
+}
+
+func (m *GetDebugInfoResponse) GetLogLines() []string {
+	if m != nil {
+		return m.LogLines
+	}
+	return nil
+}
+
+type CPUProfileRequest struct {
+	// The number of seconds to profile for, at most 300.
+	DurationSecs uint32 `protobuf:"varint,1,opt,name=duration_secs" json:"duration_secs,omitempty"`
+}
+
+func (m *CPUProfileRequest) Reset()                    { *m = CPUProfileRequest{} }
+func (m *CPUProfileRequest) String() string            { return proto.CompactTextString(m) }
+func (*CPUProfileRequest) ProtoMessage()               {}
+func (*CPUProfileRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{169} }
+
+func (m *CPUProfileRequest) GetDurationSecs() uint32 {
+	if m != nil {
+		return m.DurationSecs
+	}
+	return 0
+}
+
+type CPUProfileResponse struct {
+	// The CPU profile in the pprof format.
+	Profile []byte `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+}
+
+func (m *CPUProfileResponse) Reset()                    { *m = CPUProfileResponse{} }
+func (m *CPUProfileResponse) String() string            { return proto.CompactTextString(m) }
+func (*CPUProfileResponse) ProtoMessage()               {}
+func (*CPUProfileResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{170} }
+
+func (m *CPUProfileResponse) GetProfile() []byte {
+	if m != nil {
+		return m.Profile
+	}
+	return nil
+}
+
+type ExportChannelDbRequest struct {
+	// The path on the daemon's filesystem the copy is written to. An
+	// existing file is never overwritten.
+	DestPath string `protobuf:"bytes,1,opt,name=dest_path" json:"dest_path,omitempty"`
+}
+
+func (m *ExportChannelDbRequest) Reset()                    { *m = ExportChannelDbRequest{} }
+func (m *ExportChannelDbRequest) String() string            { return proto.CompactTextString(m) }
+func (*ExportChannelDbRequest) ProtoMessage()               {}
+func (*ExportChannelDbRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{171} }
+
+func (m *ExportChannelDbRequest) GetDestPath() string {
+	if m != nil {
+		return m.DestPath
+	}
+	return ""
+}
+
+type ExportChannelDbResponse struct {
+}
+
+func (m *ExportChannelDbResponse) Reset()                    { *m = ExportChannelDbResponse{} }
+func (m *ExportChannelDbResponse) String() string            { return proto.CompactTextString(m) }
+func (*ExportChannelDbResponse) ProtoMessage()               {}
+func (*ExportChannelDbResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{172} }
+
+type ImportGraphResponse struct {
+	NumNodes    uint32 `protobuf:"varint,1,opt,name=num_nodes" json:"num_nodes,omitempty"`
+	NumChannels uint32 `protobuf:"varint,2,opt,name=num_channels" json:"num_channels,omitempty"`
+}
+
+func (m *ImportGraphResponse) Reset()                    { *m = ImportGraphResponse{} }
+func (m *ImportGraphResponse) String() string            { return proto.CompactTextString(m) }
+func (*ImportGraphResponse) ProtoMessage()               {}
+func (*ImportGraphResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{173} }
+
+func (m *ImportGraphResponse) GetNumNodes() uint32 {
+	if m != nil {
+		return m.NumNodes
+	}
+	return 0
+}
+
+func (m *ImportGraphResponse) GetNumChannels() uint32 {
+	if m != nil {
+		return m.NumChannels
+	}
+	return 0
+}
+
+type QuiesceChannelRequest struct {
+	ChanPoint *ChannelPoint `protobuf:"bytes,1,opt,name=chan_point" json:"chan_point,omitempty"`
+	// If true, the channel is resumed, undoing an earlier quiescence.
+	Resume bool `protobuf:"varint,2,opt,name=resume" json:"resume,omitempty"`
+	// The number of seconds after which the channel is automatically
+	// resumed. If zero, a default of one minute is used.
+	TimeoutSeconds uint32 `protobuf:"varint,3,opt,name=timeout_seconds" json:"timeout_seconds,omitempty"`
+}
+
+func (m *QuiesceChannelRequest) Reset()                    { *m = QuiesceChannelRequest{} }
+func (m *QuiesceChannelRequest) String() string            { return proto.CompactTextString(m) }
+func (*QuiesceChannelRequest) ProtoMessage()               {}
+func (*QuiesceChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{174} }
+
+func (m *QuiesceChannelRequest) GetChanPoint() *ChannelPoint {
+	if m != nil {
+		return m.ChanPoint
+	}
+	return nil
+}
+
+func (m *QuiesceChannelRequest) GetResume() bool {
+	if m != nil {
+		return m.Resume
+	}
+	return false
+}
+
+func (m *QuiesceChannelRequest) GetTimeoutSeconds() uint32 {
+	if m != nil {
+		return m.TimeoutSeconds
+	}
+	return 0
+}
+
+type QuiesceChannelResponse struct {
+}
+
+func (m *QuiesceChannelResponse) Reset()                    { *m = QuiesceChannelResponse{} }
+func (m *QuiesceChannelResponse) String() string            { return proto.CompactTextString(m) }
+func (*QuiesceChannelResponse) ProtoMessage()               {}
+func (*QuiesceChannelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{175} }
+
+type ForceStateTransitionRequest struct {
+	ChanPoint *ChannelPoint `protobuf:"bytes,1,opt,name=chan_point" json:"chan_point,omitempty"`
+}
+
+func (m *ForceStateTransitionRequest) Reset()                    { *m = ForceStateTransitionRequest{} }
+func (m *ForceStateTransitionRequest) String() string            { return proto.CompactTextString(m) }
+func (*ForceStateTransitionRequest) ProtoMessage()               {}
+func (*ForceStateTransitionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{176} }
+
+func (m *ForceStateTransitionRequest) GetChanPoint() *ChannelPoint {
+	if m != nil {
+		return m.ChanPoint
+	}
+	return nil
+}
+
+type ForceStateTransitionResponse struct {
+}
+
+func (m *ForceStateTransitionResponse) Reset()                    { *m = ForceStateTransitionResponse{} }
+func (m *ForceStateTransitionResponse) String() string            { return proto.CompactTextString(m) }
+func (*ForceStateTransitionResponse) ProtoMessage()               {}
+func (*ForceStateTransitionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{177} }
+
+type GetBestBlockRequest struct {
+}
+
+func (m *GetBestBlockRequest) Reset()                    { *m = GetBestBlockRequest{} }
+func (m *GetBestBlockRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetBestBlockRequest) ProtoMessage()               {}
+func (*GetBestBlockRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{178} }
+
+type GetBestBlockResponse struct {
+	// The hash of the tip of the most-work chain, in its internal byte
+	// order.
+	BlockHash   []byte `protobuf:"bytes,1,opt,name=block_hash,proto3" json:"block_hash,omitempty"`
+	BlockHeight int32  `protobuf:"varint,2,opt,name=block_height" json:"block_height,omitempty"`
+}
+
+func (m *GetBestBlockResponse) Reset()                    { *m = GetBestBlockResponse{} }
+func (m *GetBestBlockResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetBestBlockResponse) ProtoMessage()               {}
+func (*GetBestBlockResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{179} }
+
+func (m *GetBestBlockResponse) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *GetBestBlockResponse) GetBlockHeight() int32 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+type GetBlockHashRequest struct {
+	BlockHeight int64 `protobuf:"varint,1,opt,name=block_height" json:"block_height,omitempty"`
+}
+
+func (m *GetBlockHashRequest) Reset()                    { *m = GetBlockHashRequest{} }
+func (m *GetBlockHashRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetBlockHashRequest) ProtoMessage()               {}
+func (*GetBlockHashRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{180} }
+
+func (m *GetBlockHashRequest) GetBlockHeight() int64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+type GetBlockHashResponse struct {
+	// The hash of the block within the most-work chain at the requested
+	// height, in its internal byte order.
+	BlockHash []byte `protobuf:"bytes,1,opt,name=block_hash,proto3" json:"block_hash,omitempty"`
+}
+
+func (m *GetBlockHashResponse) Reset()                    { *m = GetBlockHashResponse{} }
+func (m *GetBlockHashResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetBlockHashResponse) ProtoMessage()               {}
+func (*GetBlockHashResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{181} }
+
+func (m *GetBlockHashResponse) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+type GetBlockRequest struct {
+	// The hash of the requested block, in its internal byte order.
+	BlockHash []byte `protobuf:"bytes,1,opt,name=block_hash,proto3" json:"block_hash,omitempty"`
+}
+
+func (m *GetBlockRequest) Reset()                    { *m = GetBlockRequest{} }
+func (m *GetBlockRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetBlockRequest) ProtoMessage()               {}
+func (*GetBlockRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{182} }
+
+func (m *GetBlockRequest) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+type GetBlockResponse struct {
+	// The requested block, serialized in the wire format.
+	RawBlock []byte `protobuf:"bytes,1,opt,name=raw_block,proto3" json:"raw_block,omitempty"`
+}
+
+func (m *GetBlockResponse) Reset()                    { *m = GetBlockResponse{} }
+func (m *GetBlockResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetBlockResponse) ProtoMessage()               {}
+func (*GetBlockResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{183} }
+
+func (m *GetBlockResponse) GetRawBlock() []byte {
+	if m != nil {
+		return m.RawBlock
+	}
+	return nil
+}
+
+type HtlcModifyRequest struct {
+	// The ID of the request, which must be set within the response.
+	RequestId   uint64 `protobuf:"varint,1,opt,name=request_id" json:"request_id,omitempty"`
+	PaymentHash []byte `protobuf:"bytes,2,opt,name=payment_hash,proto3" json:"payment_hash,omitempty"`
+	// The amount in satoshis requested by the invoice.
+	InvoiceAmt int64 `protobuf:"varint,3,opt,name=invoice_amt" json:"invoice_amt,omitempty"`
+	// The amount in satoshis carried by the HTLC.
+	HtlcAmt int64 `protobuf:"varint,4,opt,name=htlc_amt" json:"htlc_amt,omitempty"`
+	// The absolute height at which the HTLC expires.
+	Expiry uint32 `protobuf:"varint,5,opt,name=expiry" json:"expiry,omitempty"`
+	// The channel the HTLC arrived over, along with its index within the
+	// update log of the channel.
+	ChanPoint string `protobuf:"bytes,6,opt,name=chan_point" json:"chan_point,omitempty"`
+	HtlcIndex uint64 `protobuf:"varint,7,opt,name=htlc_index" json:"htlc_index,omitempty"`
+}
+
+func (m *HtlcModifyRequest) Reset()                    { *m = HtlcModifyRequest{} }
+func (m *HtlcModifyRequest) String() string            { return proto.CompactTextString(m) }
+func (*HtlcModifyRequest) ProtoMessage()               {}
+func (*HtlcModifyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{184} }
+
+func (m *HtlcModifyRequest) GetRequestId() uint64 {
+	if m != nil {
+		return m.RequestId
+	}
+	return 0
+}
+
+func (m *HtlcModifyRequest) GetPaymentHash() []byte {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return nil
+}
+
+func (m *HtlcModifyRequest) GetInvoiceAmt() int64 {
+	if m != nil {
+		return m.InvoiceAmt
+	}
+	return 0
+}
+
+func (m *HtlcModifyRequest) GetHtlcAmt() int64 {
+	if m != nil {
+		return m.HtlcAmt
+	}
+	return 0
+}
+
+func (m *HtlcModifyRequest) GetExpiry() uint32 {
+	if m != nil {
+		return m.Expiry
+	}
+	return 0
+}
+
+func (m *HtlcModifyRequest) GetChanPoint() string {
+	if m != nil {
+		return m.ChanPoint
+	}
+	return ""
+}
+
+func (m *HtlcModifyRequest) GetHtlcIndex() uint64 {
+	if m != nil {
+		return m.HtlcIndex
+	}
+	return 0
+}
+
+type HtlcModifyResponse struct {
+	// The ID of the request being responded to.
+	RequestId uint64 `protobuf:"varint,1,opt,name=request_id" json:"request_id,omitempty"`
+	// If non-zero, the amount in satoshis the HTLC is considered to pay
+	// towards the invoice, instead of the amount it carries.
+	AmtPaid int64 `protobuf:"varint,2,opt,name=amt_paid" json:"amt_paid,omitempty"`
+	// If true, the HTLC is canceled rather than settled.
+	Cancel bool `protobuf:"varint,3,opt,name=cancel" json:"cancel,omitempty"`
+}
+
+func (m *HtlcModifyResponse) Reset()                    { *m = HtlcModifyResponse{} }
+func (m *HtlcModifyResponse) String() string            { return proto.CompactTextString(m) }
+func (*HtlcModifyResponse) ProtoMessage()               {}
+func (*HtlcModifyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{185} }
+
+func (m *HtlcModifyResponse) GetRequestId() uint64 {
+	if m != nil {
+		return m.RequestId
+	}
+	return 0
+}
+
+func (m *HtlcModifyResponse) GetAmtPaid() int64 {
+	if m != nil {
+		return m.AmtPaid
+	}
+	return 0
+}
+
+func (m *HtlcModifyResponse) GetCancel() bool {
+	if m != nil {
+		return m.Cancel
+	}
+	return false
+}
+
+type ListSweepsRequest struct {
+}
+
+func (m *ListSweepsRequest) Reset()                    { *m = ListSweepsRequest{} }
+func (m *ListSweepsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListSweepsRequest) ProtoMessage()               {}
+func (*ListSweepsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{186} }
+
+type SweepInput struct {
+	// The output spent by the input.
+	Outpoint *OutPoint `protobuf:"bytes,1,opt,name=outpoint" json:"outpoint,omitempty"`
+	// The value of the spent output in satoshis.
+	Amount int64 `protobuf:"varint,2,opt,name=amount" json:"amount,omitempty"`
+	// The kind of output spent, such as a delayed commitment output or a
+	// timed out HTLC output.
+	WitnessType string `protobuf:"bytes,3,opt,name=witness_type" json:"witness_type,omitempty"`
+}
+
+func (m *SweepInput) Reset()                    { *m = SweepInput{} }
+func (m *SweepInput) String() string            { return proto.CompactTextString(m) }
+func (*SweepInput) ProtoMessage()               {}
+func (*SweepInput) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{187} }
+
+func (m *SweepInput) GetOutpoint() *OutPoint {
+	if m != nil {
+		return m.Outpoint
+	}
+	return nil
+}
+
+func (m *SweepInput) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *SweepInput) GetWitnessType() string {
+	if m != nil {
+		return m.WitnessType
+	}
+	return ""
+}
+
+type Sweep struct {
+	Txid string `protobuf:"bytes,1,opt,name=txid" json:"txid,omitempty"`
+	// The value in satoshis recovered by the sweep, after fees.
+	AmountSwept int64         `protobuf:"varint,2,opt,name=amount_swept" json:"amount_swept,omitempty"`
+	TotalFees   int64         `protobuf:"varint,3,opt,name=total_fees" json:"total_fees,omitempty"`
+	Inputs      []*SweepInput `protobuf:"bytes,4,rep,name=inputs" json:"inputs,omitempty"`
+	// The height of the best block when the sweep was broadcast.
+	BroadcastHeight uint32 `protobuf:"varint,5,opt,name=broadcast_height" json:"broadcast_height,omitempty"`
+	// The height of the block which confirmed the sweep, or zero if it's
+	// unconfirmed, or isn't known to the wallet as it swept to an external
+	// script.
+	ConfirmationHeight int32 `protobuf:"varint,6,opt,name=confirmation_height" json:"confirmation_height,omitempty"`
+}
+
+func (m *Sweep) Reset()                    { *m = Sweep{} }
+func (m *Sweep) String() string            { return proto.CompactTextString(m) }
+func (*Sweep) ProtoMessage()               {}
+func (*Sweep) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{188} }
+
+func (m *Sweep) GetTxid() string {
+	if m != nil {
+		return m.Txid
+	}
+	return ""
+}
+
+func (m *Sweep) GetAmountSwept() int64 {
+	if m != nil {
+		return m.AmountSwept
+	}
+	return 0
+}
+
+func (m *Sweep) GetTotalFees() int64 {
+	if m != nil {
+		return m.TotalFees
+	}
+	return 0
+}
+
+func (m *Sweep) GetInputs() []*SweepInput {
+	if m != nil {
+		return m.Inputs
+	}
+	return nil
+}
+
+func (m *Sweep) GetBroadcastHeight() uint32 {
+	if m != nil {
+		return m.BroadcastHeight
+	}
+	return 0
+}
+
+func (m *Sweep) GetConfirmationHeight() int32 {
+	if m != nil {
+		return m.ConfirmationHeight
+	}
+	return 0
+}
+
+type ListSweepsResponse struct {
+	Sweeps []*Sweep `protobuf:"bytes,1,rep,name=sweeps" json:"sweeps,omitempty"`
+}
+
+func (m *ListSweepsResponse) Reset()                    { *m = ListSweepsResponse{} }
+func (m *ListSweepsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListSweepsResponse) ProtoMessage()               {}
+func (*ListSweepsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{189} }
+
+func (m *ListSweepsResponse) GetSweeps() []*Sweep {
+	if m != nil {
+		return m.Sweeps
+	}
+	return nil
+}
+
+type RouteFeeRequest struct {
+	// The hex-encoded identity public key of the destination.
+	PubKey string `protobuf:"bytes,1,opt,name=pub_key" json:"pub_key,omitempty"`
+	// The amount in satoshis to be received by the destination.
+	Amt int64 `protobuf:"varint,2,opt,name=amt" json:"amt,omitempty"`
+	// If set, the fee is estimated by sending a probe payment with an
+	// unpayable payment hash along the route, confirming that it's able to
+	// carry the amount, rather than by pathfinding alone.
+	Probe bool `protobuf:"varint,3,opt,name=probe" json:"probe,omitempty"`
+}
+
+func (m *RouteFeeRequest) Reset()                    { *m = RouteFeeRequest{} }
+func (m *RouteFeeRequest) String() string            { return proto.CompactTextString(m) }
+func (*RouteFeeRequest) ProtoMessage()               {}
+func (*RouteFeeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{190} }
+
+func (m *RouteFeeRequest) GetPubKey() string {
+	if m != nil {
+		return m.PubKey
+	}
+	return ""
+}
+
+func (m *RouteFeeRequest) GetAmt() int64 {
+	if m != nil {
+		return m.Amt
+	}
+	return 0
+}
+
+func (m *RouteFeeRequest) GetProbe() bool {
+	if m != nil {
+		return m.Probe
+	}
+	return false
+}
+
+type RouteFeeResponse struct {
+	// The total fee in satoshis expected to be paid to the nodes along the
+	// route.
+	RoutingFee int64 `protobuf:"varint,1,opt,name=routing_fee" json:"routing_fee,omitempty"`
+	// The total time lock of the route, in blocks.
+	TimeLockDelay uint32 `protobuf:"varint,2,opt,name=time_lock_delay" json:"time_lock_delay,omitempty"`
+}
+
+func (m *RouteFeeResponse) Reset()                    { *m = RouteFeeResponse{} }
+func (m *RouteFeeResponse) String() string            { return proto.CompactTextString(m) }
+func (*RouteFeeResponse) ProtoMessage()               {}
+func (*RouteFeeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{191} }
+
+func (m *RouteFeeResponse) GetRoutingFee() int64 {
+	if m != nil {
+		return m.RoutingFee
+	}
+	return 0
+}
+
+func (m *RouteFeeResponse) GetTimeLockDelay() uint32 {
+	if m != nil {
+		return m.TimeLockDelay
+	}
+	return 0
+}
+
+type NodeAddressesRequest struct {
+	PubKey string `protobuf:"bytes,1,opt,name=pub_key" json:"pub_key,omitempty"`
+}
+
+func (m *NodeAddressesRequest) Reset()                    { *m = NodeAddressesRequest{} }
+func (m *NodeAddressesRequest) String() string            { return proto.CompactTextString(m) }
+func (*NodeAddressesRequest) ProtoMessage()               {}
+func (*NodeAddressesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{192} }
+
+func (m *NodeAddressesRequest) GetPubKey() string {
+	if m != nil {
+		return m.PubKey
+	}
+	return ""
+}
+
+type NodeAddressesResponse struct {
+	// The known network addresses of the node.
+	Addresses []*NodeAddress `protobuf:"bytes,1,rep,name=addresses" json:"addresses,omitempty"`
+	// The global features of the node, as sent within its init message.
+	// They're only known while we're connected to the node.
+	Features []*Feature `protobuf:"bytes,2,rep,name=features" json:"features,omitempty"`
+}
+
+func (m *NodeAddressesResponse) Reset()                    { *m = NodeAddressesResponse{} }
+func (m *NodeAddressesResponse) String() string            { return proto.CompactTextString(m) }
+func (*NodeAddressesResponse) ProtoMessage()               {}
+func (*NodeAddressesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{193} }
+
+func (m *NodeAddressesResponse) GetAddresses() []*NodeAddress {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+func (m *NodeAddressesResponse) GetFeatures() []*Feature {
+	if m != nil {
+		return m.Features
+	}
+	return nil
+}
+
+type UpdateChanStatusRequest struct {
+	// The channel to update the status of.
+	ChanPoint *ChannelPoint    `protobuf:"bytes,1,opt,name=chan_point" json:"chan_point,omitempty"`
+	Action    ChanStatusAction `protobuf:"varint,2,opt,name=action,enum=lnrpc.ChanStatusAction" json:"action,omitempty"`
+}
+
+func (m *UpdateChanStatusRequest) Reset()                    { *m = UpdateChanStatusRequest{} }
+func (m *UpdateChanStatusRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateChanStatusRequest) ProtoMessage()               {}
+func (*UpdateChanStatusRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{194} }
+
+func (m *UpdateChanStatusRequest) GetChanPoint() *ChannelPoint {
+	if m != nil {
+		return m.ChanPoint
+	}
+	return nil
+}
+
+func (m *UpdateChanStatusRequest) GetAction() ChanStatusAction {
+	if m != nil {
+		return m.Action
+	}
+	return ChanStatusAction_ENABLE
+}
+
+type UpdateChanStatusResponse struct {
+}
+
+func (m *UpdateChanStatusResponse) Reset()                    { *m = UpdateChanStatusResponse{} }
+func (m *UpdateChanStatusResponse) String() string            { return proto.CompactTextString(m) }
+func (*UpdateChanStatusResponse) ProtoMessage()               {}
+func (*UpdateChanStatusResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{195} }
+
+type UpgradeChannelRequest struct {
+	// The channel to upgrade, which must be active.
+	ChanPoint *ChannelPoint `protobuf:"bytes,1,opt,name=chan_point" json:"chan_point,omitempty"`
+	// The commitment type to upgrade the channel to.
+	CommitmentType CommitmentType `protobuf:"varint,2,opt,name=commitment_type,enum=lnrpc.CommitmentType" json:"commitment_type,omitempty"`
+}
+
+func (m *UpgradeChannelRequest) Reset()                    { *m = UpgradeChannelRequest{} }
+func (m *UpgradeChannelRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpgradeChannelRequest) ProtoMessage()               {}
+func (*UpgradeChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{196} }
+
+func (m *UpgradeChannelRequest) GetChanPoint() *ChannelPoint {
+	if m != nil {
+		return m.ChanPoint
+	}
+	return nil
+}
+
+func (m *UpgradeChannelRequest) GetCommitmentType() CommitmentType {
+	if m != nil {
+		return m.CommitmentType
+	}
+	return CommitmentType_LEGACY
+}
+
+type UpgradeChannelResponse struct {
+}
+
+func (m *UpgradeChannelResponse) Reset()                    { *m = UpgradeChannelResponse{} }
+func (m *UpgradeChannelResponse) String() string            { return proto.CompactTextString(m) }
+func (*UpgradeChannelResponse) ProtoMessage()               {}
+func (*UpgradeChannelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{197} }
+
+type PayReqString struct {
+	// The encoded payment request.
+	PayReq string `protobuf:"bytes,1,opt,name=pay_req" json:"pay_req,omitempty"`
+}
+
+func (m *PayReqString) Reset()                    { *m = PayReqString{} }
+func (m *PayReqString) String() string            { return proto.CompactTextString(m) }
+func (*PayReqString) ProtoMessage()               {}
+func (*PayReqString) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{198} }
+
+func (m *PayReqString) GetPayReq() string {
+	if m != nil {
+		return m.PayReq
+	}
+	return ""
+}
+
+type PayReq struct {
+	// The identity public key of the node to be paid.
+	Destination string `protobuf:"bytes,1,opt,name=destination" json:"destination,omitempty"`
+	// The payment hash to use within the HTLC extended to the destination.
+	PaymentHash string `protobuf:"bytes,2,opt,name=payment_hash" json:"payment_hash,omitempty"`
+	// The amount to be paid, expressed in satoshis.
+	NumSatoshis int64 `protobuf:"varint,3,opt,name=num_satoshis" json:"num_satoshis,omitempty"`
+}
+
+func (m *PayReq) Reset()                    { *m = PayReq{} }
+func (m *PayReq) String() string            { return proto.CompactTextString(m) }
+func (*PayReq) ProtoMessage()               {}
+func (*PayReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{199} }
+
+func (m *PayReq) GetDestination() string {
+	if m != nil {
+		return m.Destination
+	}
+	return ""
+}
+
+func (m *PayReq) GetPaymentHash() string {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return ""
+}
+
+func (m *PayReq) GetNumSatoshis() int64 {
+	if m != nil {
+		return m.NumSatoshis
+	}
+	return 0
+}
+
+type SubscribeHtlcEventsRequest struct {
+}
+
+func (m *SubscribeHtlcEventsRequest) Reset()                    { *m = SubscribeHtlcEventsRequest{} }
+func (m *SubscribeHtlcEventsRequest) String() string            { return proto.CompactTextString(m) }
+func (*SubscribeHtlcEventsRequest) ProtoMessage()               {}
+func (*SubscribeHtlcEventsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{200} }
+
+type HtlcEvent struct {
+	// The type of the event.
+	EventType HtlcEventType `protobuf:"varint,1,opt,name=event_type,enum=lnrpc.HtlcEventType" json:"event_type,omitempty"`
+	// The channel the HTLC arrived over. It's unset if we initiated the
+	// payment.
+	IncomingChannelPoint *ChannelPoint `protobuf:"bytes,2,opt,name=incoming_channel_point" json:"incoming_channel_point,omitempty"`
+	// The channel the HTLC was, or would have been, forwarded over. It's
+	// unset if no such channel could be found.
+	OutgoingChannelPoint *ChannelPoint `protobuf:"bytes,3,opt,name=outgoing_channel_point" json:"outgoing_channel_point,omitempty"`
+	// The payment hash of the HTLC.
+	PaymentHash string `protobuf:"bytes,4,opt,name=payment_hash" json:"payment_hash,omitempty"`
+	// The amount of the HTLC, expressed in satoshis.
+	AmtSat int64 `protobuf:"varint,5,opt,name=amt_sat" json:"amt_sat,omitempty"`
+	// Why the HTLC failed. It's only set for FORWARD_FAIL and LINK_FAIL
+	// events.
+	FailureReason string `protobuf:"bytes,6,opt,name=failure_reason" json:"failure_reason,omitempty"`
+	// The time at which the event occurred, in nanoseconds since the unix
+	// epoch.
+	TimestampNs int64 `protobuf:"varint,7,opt,name=timestamp_ns" json:"timestamp_ns,omitempty"`
+}
+
+func (m *HtlcEvent) Reset()                    { *m = HtlcEvent{} }
+func (m *HtlcEvent) String() string            { return proto.CompactTextString(m) }
+func (*HtlcEvent) ProtoMessage()               {}
+func (*HtlcEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{201} }
+
+func (m *HtlcEvent) GetEventType() HtlcEventType {
+	if m != nil {
+		return m.EventType
+	}
+	return HtlcEventType_FORWARD
+}
+
+func (m *HtlcEvent) GetIncomingChannelPoint() *ChannelPoint {
+	if m != nil {
+		return m.IncomingChannelPoint
+	}
+	return nil
+}
+
+func (m *HtlcEvent) GetOutgoingChannelPoint() *ChannelPoint {
+	if m != nil {
+		return m.OutgoingChannelPoint
+	}
+	return nil
+}
+
+func (m *HtlcEvent) GetPaymentHash() string {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return ""
+}
+
+func (m *HtlcEvent) GetAmtSat() int64 {
+	if m != nil {
+		return m.AmtSat
+	}
+	return 0
+}
+
+func (m *HtlcEvent) GetFailureReason() string {
+	if m != nil {
+		return m.FailureReason
+	}
+	return ""
+}
+
+func (m *HtlcEvent) GetTimestampNs() int64 {
+	if m != nil {
+		return m.TimestampNs
+	}
+	return 0
+}
+
+type StopRequest struct {
+}
+
+func (m *StopRequest) Reset()                    { *m = StopRequest{} }
+func (m *StopRequest) String() string            { return proto.CompactTextString(m) }
+func (*StopRequest) ProtoMessage()               {}
+func (*StopRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{202} }
+
+type StopResponse struct {
+}
+
+func (m *StopResponse) Reset()                    { *m = StopResponse{} }
+func (m *StopResponse) String() string            { return proto.CompactTextString(m) }
+func (*StopResponse) ProtoMessage()               {}
+func (*StopResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{203} }
+
+type ReconnectingPeer struct {
+	// The identity pubkey of the peer.
+	PubKey string `protobuf:"bytes,1,opt,name=pub_key" json:"pub_key,omitempty"`
+	// The unix timestamp at which we last connected to the peer, or 0 if
+	// we never have.
+	LastConnected int64 `protobuf:"varint,2,opt,name=last_connected" json:"last_connected,omitempty"`
+	// The delay, in seconds, we wait for after losing the connection to
+	// the peer before reconnecting to it.
+	Backoff int64 `protobuf:"varint,3,opt,name=backoff" json:"backoff,omitempty"`
+	// The unix timestamp before which we won't attempt to reconnect to
+	// the peer.
+	NextRetry int64 `protobuf:"varint,4,opt,name=next_retry" json:"next_retry,omitempty"`
+}
+
+func (m *ReconnectingPeer) Reset()                    { *m = ReconnectingPeer{} }
+func (m *ReconnectingPeer) String() string            { return proto.CompactTextString(m) }
+func (*ReconnectingPeer) ProtoMessage()               {}
+func (*ReconnectingPeer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{204} }
+
+func (m *ReconnectingPeer) GetPubKey() string {
+	if m != nil {
+		return m.PubKey
+	}
+	return ""
+}
+
+func (m *ReconnectingPeer) GetLastConnected() int64 {
+	if m != nil {
+		return m.LastConnected
+	}
+	return 0
+}
+
+func (m *ReconnectingPeer) GetBackoff() int64 {
+	if m != nil {
+		return m.Backoff
+	}
+	return 0
+}
+
+func (m *ReconnectingPeer) GetNextRetry() int64 {
+	if m != nil {
+		return m.NextRetry
+	}
+	return 0
+}
+
+type UpdatePeerAccessRequest struct {
+	// The list to update.
+	List PeerAccessList `protobuf:"varint,1,opt,name=list,enum=lnrpc.PeerAccessList" json:"list,omitempty"`
+	// The peer to add to, or remove from, the list, identified either by
+	// its hex encoded public key or by its IP address.
+	Peer string `protobuf:"bytes,2,opt,name=peer" json:"peer,omitempty"`
+	// Whether to remove the peer from the list, rather than add it.
+	Remove bool `protobuf:"varint,3,opt,name=remove" json:"remove,omitempty"`
+}
+
+func (m *UpdatePeerAccessRequest) Reset()                    { *m = UpdatePeerAccessRequest{} }
+func (m *UpdatePeerAccessRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdatePeerAccessRequest) ProtoMessage()               {}
+func (*UpdatePeerAccessRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{205} }
+
+func (m *UpdatePeerAccessRequest) GetList() PeerAccessList {
+	if m != nil {
+		return m.List
+	}
+	return PeerAccessList_BANNED
+}
+
+func (m *UpdatePeerAccessRequest) GetPeer() string {
+	if m != nil {
+		return m.Peer
+	}
+	return ""
+}
+
+func (m *UpdatePeerAccessRequest) GetRemove() bool {
+	if m != nil {
+		return m.Remove
+	}
+	return false
+}
+
+type UpdatePeerAccessResponse struct {
+}
+
+func (m *UpdatePeerAccessResponse) Reset()                    { *m = UpdatePeerAccessResponse{} }
+func (m *UpdatePeerAccessResponse) String() string            { return proto.CompactTextString(m) }
+func (*UpdatePeerAccessResponse) ProtoMessage()               {}
+func (*UpdatePeerAccessResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{206} }
+
+type ListPeerAccessRequest struct {
+}
+
+func (m *ListPeerAccessRequest) Reset()                    { *m = ListPeerAccessRequest{} }
+func (m *ListPeerAccessRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListPeerAccessRequest) ProtoMessage()               {}
+func (*ListPeerAccessRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{207} }
+
+type ListPeerAccessResponse struct {
+	// The peers we refuse connections and channels from.
+	Banned []string `protobuf:"bytes,1,rep,name=banned" json:"banned,omitempty"`
+	// The peers we solely accept connections and channels from when
+	// running in allowlist only mode.
+	Allowed []string `protobuf:"bytes,2,rep,name=allowed" json:"allowed,omitempty"`
+	// Whether we're running in allowlist only mode.
+	AllowlistOnly bool `protobuf:"varint,3,opt,name=allowlist_only" json:"allowlist_only,omitempty"`
+}
+
+func (m *ListPeerAccessResponse) Reset()                    { *m = ListPeerAccessResponse{} }
+func (m *ListPeerAccessResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListPeerAccessResponse) ProtoMessage()               {}
+func (*ListPeerAccessResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{208} }
+
+func (m *ListPeerAccessResponse) GetBanned() []string {
+	if m != nil {
+		return m.Banned
+	}
+	return nil
+}
+
+func (m *ListPeerAccessResponse) GetAllowed() []string {
+	if m != nil {
+		return m.Allowed
+	}
+	return nil
+}
+
+func (m *ListPeerAccessResponse) GetAllowlistOnly() bool {
+	if m != nil {
+		return m.AllowlistOnly
+	}
+	return false
+}
+
+type PeerReputation struct {
+	// The number of HTLC's of the peer we've forwarded.
+	HtlcsForwarded uint64 `protobuf:"varint,1,opt,name=htlcs_forwarded" json:"htlcs_forwarded,omitempty"`
+	// The number of forwarded HTLC's which the peer endorsed.
+	HtlcsEndorsed uint64 `protobuf:"varint,2,opt,name=htlcs_endorsed" json:"htlcs_endorsed,omitempty"`
+	// The number of forwarded HTLC's which were settled.
+	HtlcsSettled uint64 `protobuf:"varint,3,opt,name=htlcs_settled" json:"htlcs_settled,omitempty"`
+	// The number of forwarded HTLC's which were cancelled or timed out.
+	HtlcsFailed uint64 `protobuf:"varint,4,opt,name=htlcs_failed" json:"htlcs_failed,omitempty"`
+	// The average time, in milliseconds, the resolved HTLC's were held in
+	// flight for.
+	AvgHoldTime int64 `protobuf:"varint,5,opt,name=avg_hold_time" json:"avg_hold_time,omitempty"`
+	// Whether the peer has a good reputation, in which case its
+	// endorsements are relayed.
+	GoodReputation bool `protobuf:"varint,6,opt,name=good_reputation" json:"good_reputation,omitempty"`
+}
+
+func (m *PeerReputation) Reset()                    { *m = PeerReputation{} }
+func (m *PeerReputation) String() string            { return proto.CompactTextString(m) }
+func (*PeerReputation) ProtoMessage()               {}
+func (*PeerReputation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{209} }
+
+func (m *PeerReputation) GetHtlcsForwarded() uint64 {
+	if m != nil {
+		return m.HtlcsForwarded
+	}
+	return 0
+}
+
+func (m *PeerReputation) GetHtlcsEndorsed() uint64 {
+	if m != nil {
+		return m.HtlcsEndorsed
+	}
+	return 0
+}
+
+func (m *PeerReputation) GetHtlcsSettled() uint64 {
+	if m != nil {
+		return m.HtlcsSettled
+	}
+	return 0
+}
+
+func (m *PeerReputation) GetHtlcsFailed() uint64 {
+	if m != nil {
+		return m.HtlcsFailed
+	}
+	return 0
+}
+
+func (m *PeerReputation) GetAvgHoldTime() int64 {
+	if m != nil {
+		return m.AvgHoldTime
+	}
+	return 0
+}
+
+func (m *PeerReputation) GetGoodReputation() bool {
+	if m != nil {
+		return m.GoodReputation
+	}
+	return false
+}
+
+type InvoiceAcceptRequest struct {
+	// The ID of the request, which must be set within the response.
+	RequestId   uint64 `protobuf:"varint,1,opt,name=request_id" json:"request_id,omitempty"`
+	PaymentHash []byte `protobuf:"bytes,2,opt,name=payment_hash" json:"payment_hash,omitempty"`
+	// The memo of the invoice being paid.
+	Memo string `protobuf:"bytes,3,opt,name=memo" json:"memo,omitempty"`
+	// The amount in satoshis requested by the invoice.
+	InvoiceAmt int64 `protobuf:"varint,4,opt,name=invoice_amt" json:"invoice_amt,omitempty"`
+	// The amount in satoshis carried by the HTLC.
+	HtlcAmt int64 `protobuf:"varint,5,opt,name=htlc_amt" json:"htlc_amt,omitempty"`
+	// The absolute height at which the HTLC expires.
+	Expiry uint32 `protobuf:"varint,6,opt,name=expiry" json:"expiry,omitempty"`
+	// The channel the HTLC arrived over, along with its index within the
+	// update log of the channel.
+	ChanPoint string `protobuf:"bytes,7,opt,name=chan_point" json:"chan_point,omitempty"`
+	HtlcIndex uint64 `protobuf:"varint,8,opt,name=htlc_index" json:"htlc_index,omitempty"`
+}
+
+func (m *InvoiceAcceptRequest) Reset()                    { *m = InvoiceAcceptRequest{} }
+func (m *InvoiceAcceptRequest) String() string            { return proto.CompactTextString(m) }
+func (*InvoiceAcceptRequest) ProtoMessage()               {}
+func (*InvoiceAcceptRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{210} }
+
+func (m *InvoiceAcceptRequest) GetRequestId() uint64 {
+	if m != nil {
+		return m.RequestId
+	}
+	return 0
+}
+
+func (m *InvoiceAcceptRequest) GetPaymentHash() []byte {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return nil
+}
+
+func (m *InvoiceAcceptRequest) GetMemo() string {
+	if m != nil {
+		return m.Memo
+	}
+	return ""
+}
+
+func (m *InvoiceAcceptRequest) GetInvoiceAmt() int64 {
+	if m != nil {
+		return m.InvoiceAmt
+	}
+	return 0
+}
+
+func (m *InvoiceAcceptRequest) GetHtlcAmt() int64 {
+	if m != nil {
+		return m.HtlcAmt
+	}
+	return 0
+}
+
+func (m *InvoiceAcceptRequest) GetExpiry() uint32 {
+	if m != nil {
+		return m.Expiry
+	}
+	return 0
+}
+
+func (m *InvoiceAcceptRequest) GetChanPoint() string {
+	if m != nil {
+		return m.ChanPoint
+	}
+	return ""
+}
+
+func (m *InvoiceAcceptRequest) GetHtlcIndex() uint64 {
+	if m != nil {
+		return m.HtlcIndex
+	}
+	return 0
+}
+
+type InvoiceAcceptResponse struct {
+	// The ID of the request being responded to.
+	RequestId uint64 `protobuf:"varint,1,opt,name=request_id" json:"request_id,omitempty"`
+	// If true, the HTLC is accepted. Otherwise, it's canceled.
+	Accept bool `protobuf:"varint,2,opt,name=accept" json:"accept,omitempty"`
+	// The reason the HTLC is rejected, which is logged.
+	Reason string `protobuf:"bytes,3,opt,name=reason" json:"reason,omitempty"`
+}
+
+func (m *InvoiceAcceptResponse) Reset()                    { *m = InvoiceAcceptResponse{} }
+func (m *InvoiceAcceptResponse) String() string            { return proto.CompactTextString(m) }
+func (*InvoiceAcceptResponse) ProtoMessage()               {}
+func (*InvoiceAcceptResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{211} }
+
+func (m *InvoiceAcceptResponse) GetRequestId() uint64 {
+	if m != nil {
+		return m.RequestId
+	}
+	return 0
+}
+
+func (m *InvoiceAcceptResponse) GetAccept() bool {
+	if m != nil {
+		return m.Accept
+	}
+	return false
+}
+
+func (m *InvoiceAcceptResponse) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type SendToRouteRequest struct {
+	// The payment hash of the HTLC to send.
+	PaymentHash []byte `protobuf:"bytes,1,opt,name=payment_hash" json:"payment_hash,omitempty"`
+	// The route to send the HTLC over, as returned by QueryRoute.
+	Route *Route `protobuf:"bytes,2,opt,name=route" json:"route,omitempty"`
+}
+
+func (m *SendToRouteRequest) Reset()                    { *m = SendToRouteRequest{} }
+func (m *SendToRouteRequest) String() string            { return proto.CompactTextString(m) }
+func (*SendToRouteRequest) ProtoMessage()               {}
+func (*SendToRouteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{212} }
+
+func (m *SendToRouteRequest) GetPaymentHash() []byte {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return nil
+}
+
+func (m *SendToRouteRequest) GetRoute() *Route {
+	if m != nil {
+		return m.Route
+	}
+	return nil
+}
+
+type HTLCAttempt struct {
+	// The outcome of the attempt.
+	Status PaymentStatus `protobuf:"varint,1,opt,name=status,enum=lnrpc.PaymentStatus" json:"status,omitempty"`
+	// The hex-encoded public key of each of the nodes of the route the HTLC
+	// was sent over, excluding ourselves.
+	Path []string `protobuf:"bytes,2,rep,name=path" json:"path,omitempty"`
+	// The total fee in satoshis, and total time lock, of the route.
+	Fee           int64  `protobuf:"varint,3,opt,name=fee" json:"fee,omitempty"`
+	TotalTimeLock uint32 `protobuf:"varint,4,opt,name=total_time_lock" json:"total_time_lock,omitempty"`
+	// The times, in nanoseconds since the unix epoch, at which the HTLC was
+	// sent, and was settled or failed. Zero for attempts recorded before
+	// they were tracked.
+	AttemptTimeNs int64 `protobuf:"varint,5,opt,name=attempt_time_ns" json:"attempt_time_ns,omitempty"`
+	ResolveTimeNs int64 `protobuf:"varint,6,opt,name=resolve_time_ns" json:"resolve_time_ns,omitempty"`
+	// Why the attempt failed. Unset if it succeeded.
+	Failure *HTLCFailure `protobuf:"bytes,7,opt,name=failure" json:"failure,omitempty"`
+}
+
+func (m *HTLCAttempt) Reset()                    { *m = HTLCAttempt{} }
+func (m *HTLCAttempt) String() string            { return proto.CompactTextString(m) }
+func (*HTLCAttempt) ProtoMessage()               {}
+func (*HTLCAttempt) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{213} }
+
+func (m *HTLCAttempt) GetStatus() PaymentStatus {
+	if m != nil {
+		return m.Status
+	}
+	return PaymentStatus_SUCCEEDED
+}
+
+func (m *HTLCAttempt) GetPath() []string {
+	if m != nil {
+		return m.Path
+	}
+	return nil
+}
+
+func (m *HTLCAttempt) GetFee() int64 {
+	if m != nil {
+		return m.Fee
+	}
+	return 0
+}
+
+func (m *HTLCAttempt) GetTotalTimeLock() uint32 {
+	if m != nil {
+		return m.TotalTimeLock
+	}
+	return 0
+}
+
+func (m *HTLCAttempt) GetAttemptTimeNs() int64 {
+	if m != nil {
+		return m.AttemptTimeNs
+	}
+	return 0
+}
+
+func (m *HTLCAttempt) GetResolveTimeNs() int64 {
+	if m != nil {
+		return m.ResolveTimeNs
+	}
+	return 0
+}
+
+func (m *HTLCAttempt) GetFailure() *HTLCFailure {
+	if m != nil {
+		return m.Failure
+	}
+	return nil
+}
+
+type HTLCFailure struct {
+	// The reason the HTLC was cancelled, if it was cancelled by a remote
+	// node.
+	Code HTLCFailureCode `protobuf:"varint,1,opt,name=code,enum=lnrpc.HTLCFailureCode" json:"code,omitempty"`
+	// The index within the route of the node the failure originated at, zero
+	// being ourselves. Cancellations don't identify the node which originated
+	// them, so it's left unset for those.
+	FailureSourceIndex uint32 `protobuf:"varint,2,opt,name=failure_source_index" json:"failure_source_index,omitempty"`
+	// A human-readable description of the failure.
+	Message string `protobuf:"bytes,3,opt,name=message" json:"message,omitempty"`
+}
+
+func (m *HTLCFailure) Reset()                    { *m = HTLCFailure{} }
+func (m *HTLCFailure) String() string            { return proto.CompactTextString(m) }
+func (*HTLCFailure) ProtoMessage()               {}
+func (*HTLCFailure) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{214} }
+
+func (m *HTLCFailure) GetCode() HTLCFailureCode {
+	if m != nil {
+		return m.Code
+	}
+	return HTLCFailureCode_LOCAL_FAILURE
+}
+
+func (m *HTLCFailure) GetFailureSourceIndex() uint32 {
+	if m != nil {
+		return m.FailureSourceIndex
+	}
+	return 0
+}
+
+func (m *HTLCFailure) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type GetChannelBalanceRequest struct {
+	// The channel point of the channel to break down the balance of.
+	ChannelPoint *ChannelPoint `protobuf:"bytes,1,opt,name=channel_point" json:"channel_point,omitempty"`
+}
+
+func (m *GetChannelBalanceRequest) Reset()                    { *m = GetChannelBalanceRequest{} }
+func (m *GetChannelBalanceRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetChannelBalanceRequest) ProtoMessage()               {}
+func (*GetChannelBalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{215} }
+
+func (m *GetChannelBalanceRequest) GetChannelPoint() *ChannelPoint {
+	if m != nil {
+		return m.ChannelPoint
+	}
+	return nil
+}
+
+type GetChannelBalanceResponse struct {
+	// The total amount of funds within the channel.
+	Capacity int64 `protobuf:"varint,1,opt,name=capacity" json:"capacity,omitempty"`
+	// The settled balance of each party, excluding the pending HTLCs it
+	// offered, and the commitment fee if it initiated the channel.
+	LocalBalance  int64 `protobuf:"varint,2,opt,name=local_balance" json:"local_balance,omitempty"`
+	RemoteBalance int64 `protobuf:"varint,3,opt,name=remote_balance" json:"remote_balance,omitempty"`
+	// The channel reserve the remote party requires us to keep.
+	LocalChanReserve int64 `protobuf:"varint,4,opt,name=local_chan_reserve" json:"local_chan_reserve,omitempty"`
+	// The channel reserve we require the remote party to keep.
+	RemoteChanReserve int64 `protobuf:"varint,5,opt,name=remote_chan_reserve" json:"remote_chan_reserve,omitempty"`
+	// The total amounts of the pending HTLCs we've offered, and of those
+	// offered to us.
+	PendingOutgoing int64 `protobuf:"varint,6,opt,name=pending_outgoing" json:"pending_outgoing,omitempty"`
+	PendingIncoming int64 `protobuf:"varint,7,opt,name=pending_incoming" json:"pending_incoming,omitempty"`
+	// The fee paid by the commitment transaction, deducted from the balance
+	// of the initiator of the channel.
+	CommitFee int64 `protobuf:"varint,8,opt,name=commit_fee" json:"commit_fee,omitempty"`
+	// The largest HTLC we can currently send over the channel, including the
+	// updates to it not yet committed. It excludes the channel reserve and,
+	// if we initiated the channel, the fee of the commitment carrying it, and
+	// is bounded by the limits the remote party places upon the total amount
+	// and number of our pending HTLCs.
+	SpendableBalance int64 `protobuf:"varint,9,opt,name=spendable_balance" json:"spendable_balance,omitempty"`
+	// The largest HTLC the remote party can currently send us over the
+	// channel, derived likewise.
+	ReceivableBalance int64 `protobuf:"varint,10,opt,name=receivable_balance" json:"receivable_balance,omitempty"`
+}
+
+func (m *GetChannelBalanceResponse) Reset()                    { *m = GetChannelBalanceResponse{} }
+func (m *GetChannelBalanceResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetChannelBalanceResponse) ProtoMessage()               {}
+func (*GetChannelBalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{216} }
+
+func (m *GetChannelBalanceResponse) GetCapacity() int64 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+func (m *GetChannelBalanceResponse) GetLocalBalance() int64 {
+	if m != nil {
+		return m.LocalBalance
+	}
+	return 0
+}
+
+func (m *GetChannelBalanceResponse) GetRemoteBalance() int64 {
+	if m != nil {
+		return m.RemoteBalance
+	}
+	return 0
+}
+
+func (m *GetChannelBalanceResponse) GetLocalChanReserve() int64 {
+	if m != nil {
+		return m.LocalChanReserve
+	}
+	return 0
+}
+
+func (m *GetChannelBalanceResponse) GetRemoteChanReserve() int64 {
+	if m != nil {
+		return m.RemoteChanReserve
+	}
+	return 0
+}
+
+func (m *GetChannelBalanceResponse) GetPendingOutgoing() int64 {
+	if m != nil {
+		return m.PendingOutgoing
+	}
+	return 0
+}
+
+func (m *GetChannelBalanceResponse) GetPendingIncoming() int64 {
+	if m != nil {
+		return m.PendingIncoming
+	}
+	return 0
+}
+
+func (m *GetChannelBalanceResponse) GetCommitFee() int64 {
+	if m != nil {
+		return m.CommitFee
+	}
+	return 0
+}
+
+func (m *GetChannelBalanceResponse) GetSpendableBalance() int64 {
+	if m != nil {
+		return m.SpendableBalance
+	}
+	return 0
+}
+
+func (m *GetChannelBalanceResponse) GetReceivableBalance() int64 {
+	if m != nil {
+		return m.ReceivableBalance
+	}
+	return 0
+}
+
+type CommitmentTypeBalance struct {
+	// The commitment type the balances are those of.
+	CommitmentType CommitmentType `protobuf:"varint,1,opt,name=commitment_type,enum=lnrpc.CommitmentType" json:"commitment_type,omitempty"`
+	// The number of open channels of the commitment type.
+	NumChannels uint32 `protobuf:"varint,2,opt,name=num_channels" json:"num_channels,omitempty"`
+	// The settled and unsettled balances of the channels, as within
+	// ChannelBalanceResponse.
+	LocalBalance           int64 `protobuf:"varint,3,opt,name=local_balance" json:"local_balance,omitempty"`
+	RemoteBalance          int64 `protobuf:"varint,4,opt,name=remote_balance" json:"remote_balance,omitempty"`
+	UnsettledLocalBalance  int64 `protobuf:"varint,5,opt,name=unsettled_local_balance" json:"unsettled_local_balance,omitempty"`
+	UnsettledRemoteBalance int64 `protobuf:"varint,6,opt,name=unsettled_remote_balance" json:"unsettled_remote_balance,omitempty"`
+}
+
+func (m *CommitmentTypeBalance) Reset()                    { *m = CommitmentTypeBalance{} }
+func (m *CommitmentTypeBalance) String() string            { return proto.CompactTextString(m) }
+func (*CommitmentTypeBalance) ProtoMessage()               {}
+func (*CommitmentTypeBalance) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{217} }
+
+func (m *CommitmentTypeBalance) GetCommitmentType() CommitmentType {
+	if m != nil {
+		return m.CommitmentType
+	}
+	return CommitmentType_LEGACY
+}
+
+func (m *CommitmentTypeBalance) GetNumChannels() uint32 {
+	if m != nil {
+		return m.NumChannels
+	}
+	return 0
+}
+
+func (m *CommitmentTypeBalance) GetLocalBalance() int64 {
+	if m != nil {
+		return m.LocalBalance
+	}
+	return 0
+}
+
+func (m *CommitmentTypeBalance) GetRemoteBalance() int64 {
+	if m != nil {
+		return m.RemoteBalance
+	}
+	return 0
+}
+
+func (m *CommitmentTypeBalance) GetUnsettledLocalBalance() int64 {
+	if m != nil {
+		return m.UnsettledLocalBalance
+	}
+	return 0
+}
+
+func (m *CommitmentTypeBalance) GetUnsettledRemoteBalance() int64 {
+	if m != nil {
+		return m.UnsettledRemoteBalance
+	}
+	return 0
+}
+
+type SetConnImpairmentRequest struct {
+	// The hex encoded identity public key of the peer whose connection is to
+	// be impaired.
+	PubKey string `protobuf:"bytes,1,opt,name=pub_key" json:"pub_key,omitempty"`
+	// The number of milliseconds each message sent to the peer is delayed
+	// by, plus a random amount of up to jitter_ms.
+	LatencyMs uint32 `protobuf:"varint,2,opt,name=latency_ms" json:"latency_ms,omitempty"`
+	JitterMs  uint32 `protobuf:"varint,3,opt,name=jitter_ms" json:"jitter_ms,omitempty"`
+	// The probability, between 0 and 1, of each message sent to the peer
+	// being dropped. The init, ping and pong messages maintaining the
+	// connection itself are never dropped.
+	DropRate float64 `protobuf:"fixed64,4,opt,name=drop_rate" json:"drop_rate,omitempty"`
+	// The seed of the random jitter and drops, so that a run may be
+	// reproduced.
+	Seed int64 `protobuf:"varint,5,opt,name=seed" json:"seed,omitempty"`
+}
+
+func (m *SetConnImpairmentRequest) Reset()                    { *m = SetConnImpairmentRequest{} }
+func (m *SetConnImpairmentRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetConnImpairmentRequest) ProtoMessage()               {}
+func (*SetConnImpairmentRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{218} }
+
+func (m *SetConnImpairmentRequest) GetPubKey() string {
+	if m != nil {
+		return m.PubKey
+	}
+	return ""
+}
+
+func (m *SetConnImpairmentRequest) GetLatencyMs() uint32 {
+	if m != nil {
+		return m.LatencyMs
+	}
+	return 0
+}
+
+func (m *SetConnImpairmentRequest) GetJitterMs() uint32 {
+	if m != nil {
+		return m.JitterMs
+	}
+	return 0
+}
+
+func (m *SetConnImpairmentRequest) GetDropRate() float64 {
+	if m != nil {
+		return m.DropRate
+	}
+	return 0
+}
+
+func (m *SetConnImpairmentRequest) GetSeed() int64 {
+	if m != nil {
+		return m.Seed
+	}
+	return 0
+}
+
+type SetConnImpairmentResponse struct {
+}
+
+func (m *SetConnImpairmentResponse) Reset()                    { *m = SetConnImpairmentResponse{} }
+func (m *SetConnImpairmentResponse) String() string            { return proto.CompactTextString(m) }
+func (*SetConnImpairmentResponse) ProtoMessage()               {}
+func (*SetConnImpairmentResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{219} }
+
+type SignDigestRequest struct {
+	// The locator of the key to sign the digest with.
+	KeyLoc *KeyLocator `protobuf:"bytes,1,opt,name=key_loc" json:"key_loc,omitempty"`
+	// The 32-byte digest to sign.
+	Digest []byte `protobuf:"bytes,2,opt,name=digest" json:"digest,omitempty"`
+}
+
+func (m *SignDigestRequest) Reset()                    { *m = SignDigestRequest{} }
+func (m *SignDigestRequest) String() string            { return proto.CompactTextString(m) }
+func (*SignDigestRequest) ProtoMessage()               {}
+func (*SignDigestRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{220} }
+
+func (m *SignDigestRequest) GetKeyLoc() *KeyLocator {
+	if m != nil {
+		return m.KeyLoc
+	}
+	return nil
+}
+
+func (m *SignDigestRequest) GetDigest() []byte {
+	if m != nil {
+		return m.Digest
+	}
+	return nil
+}
+
+type SignDigestResponse struct {
+	// The DER-encoded signature of the digest.
+	Signature []byte `protobuf:"bytes,1,opt,name=signature" json:"signature,omitempty"`
+}
+
+func (m *SignDigestResponse) Reset()                    { *m = SignDigestResponse{} }
+func (m *SignDigestResponse) String() string            { return proto.CompactTextString(m) }
+func (*SignDigestResponse) ProtoMessage()               {}
+func (*SignDigestResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{221} }
+
+func (m *SignDigestResponse) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type ProcessOnionRequest struct {
+	// The serialized onion packet.
+	OnionBlob []byte `protobuf:"bytes,1,opt,name=onion_blob" json:"onion_blob,omitempty"`
+	// The associated data the packet's HMAC commits to, the payment hash of
+	// the HTLC carrying it.
+	AssocData []byte `protobuf:"bytes,2,opt,name=assoc_data" json:"assoc_data,omitempty"`
+}
+
+func (m *ProcessOnionRequest) Reset()                    { *m = ProcessOnionRequest{} }
+func (m *ProcessOnionRequest) String() string            { return proto.CompactTextString(m) }
+func (*ProcessOnionRequest) ProtoMessage()               {}
+func (*ProcessOnionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{222} }
+
+func (m *ProcessOnionRequest) GetOnionBlob() []byte {
+	if m != nil {
+		return m.OnionBlob
+	}
+	return nil
+}
+
+func (m *ProcessOnionRequest) GetAssocData() []byte {
+	if m != nil {
+		return m.AssocData
+	}
+	return nil
+}
+
+type ProcessOnionResponse struct {
+	// Whether we're the final hop of the route.
+	ExitNode bool `protobuf:"varint,1,opt,name=exit_node" json:"exit_node,omitempty"`
+	// The 20-byte address of the next hop, unless we're the final hop.
+	NextHop []byte `protobuf:"bytes,2,opt,name=next_hop" json:"next_hop,omitempty"`
+	// The serialized onion packet to forward to the next hop, unless we're
+	// the final hop.
+	NextOnionBlob []byte `protobuf:"bytes,3,opt,name=next_onion_blob" json:"next_onion_blob,omitempty"`
+}
+
+func (m *ProcessOnionResponse) Reset()                    { *m = ProcessOnionResponse{} }
+func (m *ProcessOnionResponse) String() string            { return proto.CompactTextString(m) }
+func (*ProcessOnionResponse) ProtoMessage()               {}
+func (*ProcessOnionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{223} }
+
+func (m *ProcessOnionResponse) GetExitNode() bool {
+	if m != nil {
+		return m.ExitNode
+	}
+	return false
+}
+
+func (m *ProcessOnionResponse) GetNextHop() []byte {
+	if m != nil {
+		return m.NextHop
+	}
+	return nil
+}
+
+func (m *ProcessOnionResponse) GetNextOnionBlob() []byte {
+	if m != nil {
+		return m.NextOnionBlob
+	}
+	return nil
+}
+
+type TrackPaymentRequest struct {
+	// The payment hash of the payment to track.
+	PaymentHash []byte `protobuf:"bytes,1,opt,name=payment_hash,proto3" json:"payment_hash,omitempty"`
+}
+
+func (m *TrackPaymentRequest) Reset()                    { *m = TrackPaymentRequest{} }
+func (m *TrackPaymentRequest) String() string            { return proto.CompactTextString(m) }
+func (*TrackPaymentRequest) ProtoMessage()               {}
+func (*TrackPaymentRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{224} }
+
+func (m *TrackPaymentRequest) GetPaymentHash() []byte {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Transaction)(nil), "lnrpc.Transaction")
+	proto.RegisterType((*GetTransactionsRequest)(nil), "lnrpc.GetTransactionsRequest")
+	proto.RegisterType((*TransactionDetails)(nil), "lnrpc.TransactionDetails")
+	proto.RegisterType((*SendRequest)(nil), "lnrpc.SendRequest")
+	proto.RegisterType((*SendResponse)(nil), "lnrpc.SendResponse")
+	proto.RegisterType((*ChannelPoint)(nil), "lnrpc.ChannelPoint")
+	proto.RegisterType((*LightningAddress)(nil), "lnrpc.LightningAddress")
+	proto.RegisterType((*SendManyRequest)(nil), "lnrpc.SendManyRequest")
+	proto.RegisterType((*SendManyResponse)(nil), "lnrpc.SendManyResponse")
+	proto.RegisterType((*SendCoinsRequest)(nil), "lnrpc.SendCoinsRequest")
+	proto.RegisterType((*SendCoinsResponse)(nil), "lnrpc.SendCoinsResponse")
+	proto.RegisterType((*NewAddressRequest)(nil), "lnrpc.NewAddressRequest")
+	proto.RegisterType((*NewWitnessAddressRequest)(nil), "lnrpc.NewWitnessAddressRequest")
+	proto.RegisterType((*NewAddressResponse)(nil), "lnrpc.NewAddressResponse")
+	proto.RegisterType((*ConnectPeerRequest)(nil), "lnrpc.ConnectPeerRequest")
+	proto.RegisterType((*ConnectPeerResponse)(nil), "lnrpc.ConnectPeerResponse")
+	proto.RegisterType((*HTLC)(nil), "lnrpc.HTLC")
+	proto.RegisterType((*ActiveChannel)(nil), "lnrpc.ActiveChannel")
+	proto.RegisterType((*ListChannelsRequest)(nil), "lnrpc.ListChannelsRequest")
+	proto.RegisterType((*ListChannelsResponse)(nil), "lnrpc.ListChannelsResponse")
+	proto.RegisterType((*ChannelEventSubscription)(nil), "lnrpc.ChannelEventSubscription")
+	proto.RegisterType((*ChannelEventUpdate)(nil), "lnrpc.ChannelEventUpdate")
+	proto.RegisterType((*Peer)(nil), "lnrpc.Peer")
+	proto.RegisterType((*ListPeersRequest)(nil), "lnrpc.ListPeersRequest")
+	proto.RegisterType((*ListPeersResponse)(nil), "lnrpc.ListPeersResponse")
+	proto.RegisterType((*PeerEventSubscription)(nil), "lnrpc.PeerEventSubscription")
+	proto.RegisterType((*PeerEvent)(nil), "lnrpc.PeerEvent")
+	proto.RegisterType((*GetInfoRequest)(nil), "lnrpc.GetInfoRequest")
+	proto.RegisterType((*GetInfoResponse)(nil), "lnrpc.GetInfoResponse")
+	proto.RegisterType((*Feature)(nil), "lnrpc.Feature")
+	proto.RegisterType((*ConfirmationUpdate)(nil), "lnrpc.ConfirmationUpdate")
+	proto.RegisterType((*ChannelOpenUpdate)(nil), "lnrpc.ChannelOpenUpdate")
+	proto.RegisterType((*ChannelCloseUpdate)(nil), "lnrpc.ChannelCloseUpdate")
+	proto.RegisterType((*CloseChannelRequest)(nil), "lnrpc.CloseChannelRequest")
+	proto.RegisterType((*AbandonChannelRequest)(nil), "lnrpc.AbandonChannelRequest")
+	proto.RegisterType((*AbandonChannelResponse)(nil), "lnrpc.AbandonChannelResponse")
+	proto.RegisterType((*CloseStatusUpdate)(nil), "lnrpc.CloseStatusUpdate")
+	proto.RegisterType((*PendingUpdate)(nil), "lnrpc.PendingUpdate")
+	proto.RegisterType((*OpenChannelRequest)(nil), "lnrpc.OpenChannelRequest")
+	proto.RegisterType((*OpenStatusUpdate)(nil), "lnrpc.OpenStatusUpdate")
+	proto.RegisterType((*PendingChannelRequest)(nil), "lnrpc.PendingChannelRequest")
+	proto.RegisterType((*PendingChannelResponse)(nil), "lnrpc.PendingChannelResponse")
+	proto.RegisterType((*PendingChannelResponse_PendingChannel)(nil), "lnrpc.PendingChannelResponse.PendingChannel")
+	proto.RegisterType((*PendingChannelResponse_PendingHtlc)(nil), "lnrpc.PendingChannelResponse.PendingHtlc")
+	proto.RegisterType((*WalletBalanceRequest)(nil), "lnrpc.WalletBalanceRequest")
+	proto.RegisterType((*WalletBalanceResponse)(nil), "lnrpc.WalletBalanceResponse")
+	proto.RegisterType((*ChannelBalanceRequest)(nil), "lnrpc.ChannelBalanceRequest")
+	proto.RegisterType((*ChannelBalanceResponse)(nil), "lnrpc.ChannelBalanceResponse")
+	proto.RegisterType((*RouteRequest)(nil), "lnrpc.RouteRequest")
+	proto.RegisterType((*Hop)(nil), "lnrpc.Hop")
+	proto.RegisterType((*Route)(nil), "lnrpc.Route")
+	proto.RegisterType((*NodeInfoRequest)(nil), "lnrpc.NodeInfoRequest")
+	proto.RegisterType((*NodeInfo)(nil), "lnrpc.NodeInfo")
+	proto.RegisterType((*LightningNode)(nil), "lnrpc.LightningNode")
+	proto.RegisterType((*NodeAddress)(nil), "lnrpc.NodeAddress")
+	proto.RegisterType((*RoutingPolicy)(nil), "lnrpc.RoutingPolicy")
+	proto.RegisterType((*ChannelEdge)(nil), "lnrpc.ChannelEdge")
+	proto.RegisterType((*ChannelGraphRequest)(nil), "lnrpc.ChannelGraphRequest")
+	proto.RegisterType((*ChannelGraph)(nil), "lnrpc.ChannelGraph")
+	proto.RegisterType((*ChanInfoRequest)(nil), "lnrpc.ChanInfoRequest")
+	proto.RegisterType((*NetworkInfoRequest)(nil), "lnrpc.NetworkInfoRequest")
+	proto.RegisterType((*NetworkInfo)(nil), "lnrpc.NetworkInfo")
+	proto.RegisterType((*DegreeCount)(nil), "lnrpc.DegreeCount")
+	proto.RegisterType((*GraphTopologySubscription)(nil), "lnrpc.GraphTopologySubscription")
+	proto.RegisterType((*GraphTopologyUpdate)(nil), "lnrpc.GraphTopologyUpdate")
+	proto.RegisterType((*NodeUpdate)(nil), "lnrpc.NodeUpdate")
+	proto.RegisterType((*ChannelEdgeUpdate)(nil), "lnrpc.ChannelEdgeUpdate")
+	proto.RegisterType((*ClosedChannelUpdate)(nil), "lnrpc.ClosedChannelUpdate")
+	proto.RegisterType((*SetAliasRequest)(nil), "lnrpc.SetAliasRequest")
+	proto.RegisterType((*SetAliasResponse)(nil), "lnrpc.SetAliasResponse")
+	proto.RegisterType((*UpdateFeatureAction)(nil), "lnrpc.UpdateFeatureAction")
+	proto.RegisterType((*NodeAnnouncementUpdateRequest)(nil), "lnrpc.NodeAnnouncementUpdateRequest")
+	proto.RegisterType((*NodeAnnouncementUpdateResponse)(nil), "lnrpc.NodeAnnouncementUpdateResponse")
+	proto.RegisterType((*Invoice)(nil), "lnrpc.Invoice")
+	proto.RegisterType((*AddInvoiceResponse)(nil), "lnrpc.AddInvoiceResponse")
+	proto.RegisterType((*PaymentHash)(nil), "lnrpc.PaymentHash")
+	proto.RegisterType((*ListInvoiceRequest)(nil), "lnrpc.ListInvoiceRequest")
+	proto.RegisterType((*ListInvoiceResponse)(nil), "lnrpc.ListInvoiceResponse")
+	proto.RegisterType((*InvoiceSubscription)(nil), "lnrpc.InvoiceSubscription")
+	proto.RegisterType((*Payment)(nil), "lnrpc.Payment")
+	proto.RegisterType((*ListPaymentsRequest)(nil), "lnrpc.ListPaymentsRequest")
+	proto.RegisterType((*ListPaymentsResponse)(nil), "lnrpc.ListPaymentsResponse")
+	proto.RegisterType((*DeleteAllPaymentsRequest)(nil), "lnrpc.DeleteAllPaymentsRequest")
+	proto.RegisterType((*DeleteAllPaymentsResponse)(nil), "lnrpc.DeleteAllPaymentsResponse")
+	proto.RegisterType((*DeletePaymentRequest)(nil), "lnrpc.DeletePaymentRequest")
+	proto.RegisterType((*DeletePaymentResponse)(nil), "lnrpc.DeletePaymentResponse")
+	proto.RegisterType((*SendCustomMessageRequest)(nil), "lnrpc.SendCustomMessageRequest")
+	proto.RegisterType((*SendCustomMessageResponse)(nil), "lnrpc.SendCustomMessageResponse")
+	proto.RegisterType((*SubscribeCustomMessagesRequest)(nil), "lnrpc.SubscribeCustomMessagesRequest")
+	proto.RegisterType((*CustomMessage)(nil), "lnrpc.CustomMessage")
+	proto.RegisterType((*KeyLocator)(nil), "lnrpc.KeyLocator")
+	proto.RegisterType((*KeyDescriptor)(nil), "lnrpc.KeyDescriptor")
+	proto.RegisterType((*TxOut)(nil), "lnrpc.TxOut")
+	proto.RegisterType((*SignDescriptor)(nil), "lnrpc.SignDescriptor")
+	proto.RegisterType((*SignReq)(nil), "lnrpc.SignReq")
+	proto.RegisterType((*SignResp)(nil), "lnrpc.SignResp")
+	proto.RegisterType((*InputScript)(nil), "lnrpc.InputScript")
+	proto.RegisterType((*InputScriptResp)(nil), "lnrpc.InputScriptResp")
+	proto.RegisterType((*SharedKeyRequest)(nil), "lnrpc.SharedKeyRequest")
+	proto.RegisterType((*SharedKeyResponse)(nil), "lnrpc.SharedKeyResponse")
+	proto.RegisterType((*Utxo)(nil), "lnrpc.Utxo")
+	proto.RegisterType((*ListUnspentRequest)(nil), "lnrpc.ListUnspentRequest")
+	proto.RegisterType((*ListUnspentResponse)(nil), "lnrpc.ListUnspentResponse")
+	proto.RegisterType((*ListAddressesRequest)(nil), "lnrpc.ListAddressesRequest")
+	proto.RegisterType((*ListAddressesResponse)(nil), "lnrpc.ListAddressesResponse")
+	proto.RegisterType((*AddrRequest)(nil), "lnrpc.AddrRequest")
+	proto.RegisterType((*DeriveKeyRequest)(nil), "lnrpc.DeriveKeyRequest")
+	proto.RegisterType((*DeriveNextKeyRequest)(nil), "lnrpc.DeriveNextKeyRequest")
+	proto.RegisterType((*PublishTransactionRequest)(nil), "lnrpc.PublishTransactionRequest")
+	proto.RegisterType((*PublishTransactionResponse)(nil), "lnrpc.PublishTransactionResponse")
+	proto.RegisterType((*EstimateFeeRequest)(nil), "lnrpc.EstimateFeeRequest")
+	proto.RegisterType((*EstimateFeeResponse)(nil), "lnrpc.EstimateFeeResponse")
+	proto.RegisterType((*FundPsbtRequest)(nil), "lnrpc.FundPsbtRequest")
+	proto.RegisterType((*FundPsbtResponse)(nil), "lnrpc.FundPsbtResponse")
+	proto.RegisterType((*SignPsbtRequest)(nil), "lnrpc.SignPsbtRequest")
+	proto.RegisterType((*SignPsbtResponse)(nil), "lnrpc.SignPsbtResponse")
+	proto.RegisterType((*FinalizePsbtRequest)(nil), "lnrpc.FinalizePsbtRequest")
+	proto.RegisterType((*FinalizePsbtResponse)(nil), "lnrpc.FinalizePsbtResponse")
+	proto.RegisterType((*OutPoint)(nil), "lnrpc.OutPoint")
+	proto.RegisterType((*LeaseOutputRequest)(nil), "lnrpc.LeaseOutputRequest")
+	proto.RegisterType((*LeaseOutputResponse)(nil), "lnrpc.LeaseOutputResponse")
+	proto.RegisterType((*ReleaseOutputRequest)(nil), "lnrpc.ReleaseOutputRequest")
+	proto.RegisterType((*ReleaseOutputResponse)(nil), "lnrpc.ReleaseOutputResponse")
+	proto.RegisterType((*UtxoLease)(nil), "lnrpc.UtxoLease")
+	proto.RegisterType((*ListLeasesRequest)(nil), "lnrpc.ListLeasesRequest")
+	proto.RegisterType((*ListLeasesResponse)(nil), "lnrpc.ListLeasesResponse")
+	proto.RegisterType((*LabelTransactionRequest)(nil), "lnrpc.LabelTransactionRequest")
+	proto.RegisterType((*LabelTransactionResponse)(nil), "lnrpc.LabelTransactionResponse")
+	proto.RegisterType((*Account)(nil), "lnrpc.Account")
+	proto.RegisterType((*ImportAccountRequest)(nil), "lnrpc.ImportAccountRequest")
+	proto.RegisterType((*ImportAccountResponse)(nil), "lnrpc.ImportAccountResponse")
+	proto.RegisterType((*ListAccountsRequest)(nil), "lnrpc.ListAccountsRequest")
+	proto.RegisterType((*ListAccountsResponse)(nil), "lnrpc.ListAccountsResponse")
+	proto.RegisterType((*GetRecoveryInfoRequest)(nil), "lnrpc.GetRecoveryInfoRequest")
+	proto.RegisterType((*GetRecoveryInfoResponse)(nil), "lnrpc.GetRecoveryInfoResponse")
+	proto.RegisterType((*DebugLevelRequest)(nil), "lnrpc.DebugLevelRequest")
+	proto.RegisterType((*DebugLevelResponse)(nil), "lnrpc.DebugLevelResponse")
+	proto.RegisterType((*AutopilotStatusRequest)(nil), "lnrpc.AutopilotStatusRequest")
+	proto.RegisterType((*AutopilotStatusResponse)(nil), "lnrpc.AutopilotStatusResponse")
+	proto.RegisterType((*ModifyAutopilotStatusRequest)(nil), "lnrpc.ModifyAutopilotStatusRequest")
+	proto.RegisterType((*ModifyAutopilotStatusResponse)(nil), "lnrpc.ModifyAutopilotStatusResponse")
+	proto.RegisterType((*QueryAutopilotScoresRequest)(nil), "lnrpc.QueryAutopilotScoresRequest")
+	proto.RegisterType((*AutopilotNodeScore)(nil), "lnrpc.AutopilotNodeScore")
+	proto.RegisterType((*QueryAutopilotScoresResponse)(nil), "lnrpc.QueryAutopilotScoresResponse")
+	proto.RegisterType((*ChannelInsightsRequest)(nil), "lnrpc.ChannelInsightsRequest")
+	proto.RegisterType((*ChannelInsight)(nil), "lnrpc.ChannelInsight")
+	proto.RegisterType((*ChannelInsightsResponse)(nil), "lnrpc.ChannelInsightsResponse")
+	proto.RegisterType((*SetFeeManagementRequest)(nil), "lnrpc.SetFeeManagementRequest")
+	proto.RegisterType((*SetFeeManagementResponse)(nil), "lnrpc.SetFeeManagementResponse")
+	proto.RegisterType((*SetScoresRequest)(nil), "lnrpc.SetScoresRequest")
+	proto.RegisterType((*SetScoresResponse)(nil), "lnrpc.SetScoresResponse")
+	proto.RegisterType((*QueryScoresRequest)(nil), "lnrpc.QueryScoresRequest")
+	proto.RegisterType((*HeuristicResult)(nil), "lnrpc.HeuristicResult")
+	proto.RegisterType((*QueryScoresResponse)(nil), "lnrpc.QueryScoresResponse")
+	proto.RegisterType((*SubscribeStateRequest)(nil), "lnrpc.SubscribeStateRequest")
+	proto.RegisterType((*SubscribeStateResponse)(nil), "lnrpc.SubscribeStateResponse")
+	proto.RegisterType((*GetStateRequest)(nil), "lnrpc.GetStateRequest")
+	proto.RegisterType((*GetStateResponse)(nil), "lnrpc.GetStateResponse")
+	proto.RegisterType((*ExportChannelBackupRequest)(nil), "lnrpc.ExportChannelBackupRequest")
+	proto.RegisterType((*ChannelBackup)(nil), "lnrpc.ChannelBackup")
+	proto.RegisterType((*ChannelBackups)(nil), "lnrpc.ChannelBackups")
+	proto.RegisterType((*MultiChanBackup)(nil), "lnrpc.MultiChanBackup")
+	proto.RegisterType((*ChanBackupExportRequest)(nil), "lnrpc.ChanBackupExportRequest")
+	proto.RegisterType((*ChanBackupSnapshot)(nil), "lnrpc.ChanBackupSnapshot")
+	proto.RegisterType((*RestoreChanBackupRequest)(nil), "lnrpc.RestoreChanBackupRequest")
+	proto.RegisterType((*RestoreBackupResponse)(nil), "lnrpc.RestoreBackupResponse")
+	proto.RegisterType((*VerifyChanBackupResponse)(nil), "lnrpc.VerifyChanBackupResponse")
+	proto.RegisterType((*GetDebugInfoRequest)(nil), "lnrpc.GetDebugInfoRequest")
+	proto.RegisterType((*FeatureSet)(nil), "lnrpc.FeatureSet")
+	proto.RegisterType((*SubserverStatus)(nil), "lnrpc.SubserverStatus")
+	proto.RegisterType((*GetDebugInfoResponse)(nil), "lnrpc.GetDebugInfoResponse")
+	proto.RegisterType((*CPUProfileRequest)(nil), "lnrpc.CPUProfileRequest")
+	proto.RegisterType((*CPUProfileResponse)(nil), "lnrpc.CPUProfileResponse")
+	proto.RegisterType((*ExportChannelDbRequest)(nil), "lnrpc.ExportChannelDbRequest")
+	proto.RegisterType((*ExportChannelDbResponse)(nil), "lnrpc.ExportChannelDbResponse")
+	proto.RegisterType((*ImportGraphResponse)(nil), "lnrpc.ImportGraphResponse")
+	proto.RegisterType((*QuiesceChannelRequest)(nil), "lnrpc.QuiesceChannelRequest")
+	proto.RegisterType((*QuiesceChannelResponse)(nil), "lnrpc.QuiesceChannelResponse")
+	proto.RegisterType((*ForceStateTransitionRequest)(nil), "lnrpc.ForceStateTransitionRequest")
+	proto.RegisterType((*ForceStateTransitionResponse)(nil), "lnrpc.ForceStateTransitionResponse")
+	proto.RegisterType((*GetBestBlockRequest)(nil), "lnrpc.GetBestBlockRequest")
+	proto.RegisterType((*GetBestBlockResponse)(nil), "lnrpc.GetBestBlockResponse")
+	proto.RegisterType((*GetBlockHashRequest)(nil), "lnrpc.GetBlockHashRequest")
+	proto.RegisterType((*GetBlockHashResponse)(nil), "lnrpc.GetBlockHashResponse")
+	proto.RegisterType((*GetBlockRequest)(nil), "lnrpc.GetBlockRequest")
+	proto.RegisterType((*GetBlockResponse)(nil), "lnrpc.GetBlockResponse")
+	proto.RegisterType((*HtlcModifyRequest)(nil), "lnrpc.HtlcModifyRequest")
+	proto.RegisterType((*HtlcModifyResponse)(nil), "lnrpc.HtlcModifyResponse")
+	proto.RegisterType((*ListSweepsRequest)(nil), "lnrpc.ListSweepsRequest")
+	proto.RegisterType((*SweepInput)(nil), "lnrpc.SweepInput")
+	proto.RegisterType((*Sweep)(nil), "lnrpc.Sweep")
+	proto.RegisterType((*ListSweepsResponse)(nil), "lnrpc.ListSweepsResponse")
+	proto.RegisterType((*RouteFeeRequest)(nil), "lnrpc.RouteFeeRequest")
+	proto.RegisterType((*RouteFeeResponse)(nil), "lnrpc.RouteFeeResponse")
+	proto.RegisterType((*NodeAddressesRequest)(nil), "lnrpc.NodeAddressesRequest")
+	proto.RegisterType((*NodeAddressesResponse)(nil), "lnrpc.NodeAddressesResponse")
+	proto.RegisterType((*UpdateChanStatusRequest)(nil), "lnrpc.UpdateChanStatusRequest")
+	proto.RegisterType((*UpdateChanStatusResponse)(nil), "lnrpc.UpdateChanStatusResponse")
+	proto.RegisterType((*UpgradeChannelRequest)(nil), "lnrpc.UpgradeChannelRequest")
+	proto.RegisterType((*UpgradeChannelResponse)(nil), "lnrpc.UpgradeChannelResponse")
+	proto.RegisterType((*PayReqString)(nil), "lnrpc.PayReqString")
+	proto.RegisterType((*PayReq)(nil), "lnrpc.PayReq")
+	proto.RegisterType((*SubscribeHtlcEventsRequest)(nil), "lnrpc.SubscribeHtlcEventsRequest")
+	proto.RegisterType((*HtlcEvent)(nil), "lnrpc.HtlcEvent")
+	proto.RegisterType((*StopRequest)(nil), "lnrpc.StopRequest")
+	proto.RegisterType((*StopResponse)(nil), "lnrpc.StopResponse")
+	proto.RegisterType((*ReconnectingPeer)(nil), "lnrpc.ReconnectingPeer")
+	proto.RegisterType((*UpdatePeerAccessRequest)(nil), "lnrpc.UpdatePeerAccessRequest")
+	proto.RegisterType((*UpdatePeerAccessResponse)(nil), "lnrpc.UpdatePeerAccessResponse")
+	proto.RegisterType((*ListPeerAccessRequest)(nil), "lnrpc.ListPeerAccessRequest")
+	proto.RegisterType((*ListPeerAccessResponse)(nil), "lnrpc.ListPeerAccessResponse")
+	proto.RegisterType((*PeerReputation)(nil), "lnrpc.PeerReputation")
+	proto.RegisterType((*InvoiceAcceptRequest)(nil), "lnrpc.InvoiceAcceptRequest")
+	proto.RegisterType((*InvoiceAcceptResponse)(nil), "lnrpc.InvoiceAcceptResponse")
+	proto.RegisterType((*SendToRouteRequest)(nil), "lnrpc.SendToRouteRequest")
+	proto.RegisterType((*HTLCAttempt)(nil), "lnrpc.HTLCAttempt")
+	proto.RegisterType((*HTLCFailure)(nil), "lnrpc.HTLCFailure")
+	proto.RegisterType((*GetChannelBalanceRequest)(nil), "lnrpc.GetChannelBalanceRequest")
+	proto.RegisterType((*GetChannelBalanceResponse)(nil), "lnrpc.GetChannelBalanceResponse")
+	proto.RegisterType((*CommitmentTypeBalance)(nil), "lnrpc.CommitmentTypeBalance")
+	proto.RegisterType((*SetConnImpairmentRequest)(nil), "lnrpc.SetConnImpairmentRequest")
+	proto.RegisterType((*SetConnImpairmentResponse)(nil), "lnrpc.SetConnImpairmentResponse")
+	proto.RegisterType((*SignDigestRequest)(nil), "lnrpc.SignDigestRequest")
+	proto.RegisterType((*SignDigestResponse)(nil), "lnrpc.SignDigestResponse")
+	proto.RegisterType((*ProcessOnionRequest)(nil), "lnrpc.ProcessOnionRequest")
+	proto.RegisterType((*ProcessOnionResponse)(nil), "lnrpc.ProcessOnionResponse")
+	proto.RegisterType((*TrackPaymentRequest)(nil), "lnrpc.TrackPaymentRequest")
+	proto.RegisterEnum("lnrpc.ChannelStatus", ChannelStatus_name, ChannelStatus_value)
+	proto.RegisterEnum("lnrpc.PaymentStatus", PaymentStatus_name, PaymentStatus_value)
+	proto.RegisterEnum("lnrpc.CoinSelectionStrategy", CoinSelectionStrategy_name, CoinSelectionStrategy_value)
+	proto.RegisterEnum("lnrpc.WalletState", WalletState_name, WalletState_value)
+	proto.RegisterEnum("lnrpc.InvoiceState", InvoiceState_name, InvoiceState_value)
+	proto.RegisterEnum("lnrpc.ChanStatusAction", ChanStatusAction_name, ChanStatusAction_value)
+	proto.RegisterEnum("lnrpc.CommitmentType", CommitmentType_name, CommitmentType_value)
+	proto.RegisterEnum("lnrpc.HtlcEventType", HtlcEventType_name, HtlcEventType_value)
+	proto.RegisterEnum("lnrpc.PeerAccessList", PeerAccessList_name, PeerAccessList_value)
+	proto.RegisterEnum("lnrpc.HTLCFailureCode", HTLCFailureCode_name, HTLCFailureCode_value)
+	proto.RegisterEnum("lnrpc.PaymentFailureReason", PaymentFailureReason_name, PaymentFailureReason_value)
+	proto.RegisterEnum("lnrpc.NewAddressRequest_AddressType", NewAddressRequest_AddressType_name, NewAddressRequest_AddressType_value)
+	proto.RegisterEnum("lnrpc.ChannelEventUpdate_UpdateType", ChannelEventUpdate_UpdateType_name, ChannelEventUpdate_UpdateType_value)
+	proto.RegisterEnum("lnrpc.PeerEvent_EventType", PeerEvent_EventType_name, PeerEvent_EventType_value)
+	proto.RegisterEnum("lnrpc.UpdateFeatureAction_Action", UpdateFeatureAction_Action_name, UpdateFeatureAction_Action_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Lightning service
+
+type LightningClient interface {
+	WalletBalance(ctx context.Context, in *WalletBalanceRequest, opts ...grpc.CallOption) (*WalletBalanceResponse, error)
+	ChannelBalance(ctx context.Context, in *ChannelBalanceRequest, opts ...grpc.CallOption) (*ChannelBalanceResponse, error)
+	GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*TransactionDetails, error)
+	SendCoins(ctx context.Context, in *SendCoinsRequest, opts ...grpc.CallOption) (*SendCoinsResponse, error)
+	SubscribeTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (Lightning_SubscribeTransactionsClient, error)
+	SendMany(ctx context.Context, in *SendManyRequest, opts ...grpc.CallOption) (*SendManyResponse, error)
+	NewAddress(ctx context.Context, in *NewAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error)
+	NewWitnessAddress(ctx context.Context, in *NewWitnessAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error)
+	ConnectPeer(ctx context.Context, in *ConnectPeerRequest, opts ...grpc.CallOption) (*ConnectPeerResponse, error)
+	ListPeers(ctx context.Context, in *ListPeersRequest, opts ...grpc.CallOption) (*ListPeersResponse, error)
+	SubscribePeerEvents(ctx context.Context, in *PeerEventSubscription, opts ...grpc.CallOption) (Lightning_SubscribePeerEventsClient, error)
+	// UpdatePeerAccess adds a peer to, or removes it from, the list of banned
+	// peers or the allowlist. Peers are identified either by their public
+	// key or by their IP address. Once updated, we disconnect from any peer
+	// whose connection we'd now refuse.
+	UpdatePeerAccess(ctx context.Context, in *UpdatePeerAccessRequest, opts ...grpc.CallOption) (*UpdatePeerAccessResponse, error)
+	// ListPeerAccess returns the list of banned peers and the allowlist.
+	ListPeerAccess(ctx context.Context, in *ListPeerAccessRequest, opts ...grpc.CallOption) (*ListPeerAccessResponse, error)
+	GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error)
+	// TODO(roasbeef): merge with below with bool?
+	PendingChannels(ctx context.Context, in *PendingChannelRequest, opts ...grpc.CallOption) (*PendingChannelResponse, error)
+	ListChannels(ctx context.Context, in *ListChannelsRequest, opts ...grpc.CallOption) (*ListChannelsResponse, error)
+	// GetChannelBalance breaks down the balance of a single channel, returning
+	// the amounts we can actually send and receive over it, which account for
+	// the channel reserve, the pending HTLCs, and the commitment fee.
+	GetChannelBalance(ctx context.Context, in *GetChannelBalanceRequest, opts ...grpc.CallOption) (*GetChannelBalanceResponse, error)
+	OpenChannelSync(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (*ChannelPoint, error)
+	OpenChannel(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (Lightning_OpenChannelClient, error)
+	CloseChannel(ctx context.Context, in *CloseChannelRequest, opts ...grpc.CallOption) (Lightning_CloseChannelClient, error)
+	// AbandonChannel removes all state of a channel from the database,
+	// recording it as closed, without taking any action on-chain. It's
+	// meant for recovering from channels which are stuck, for example as
+	// their funding transaction never confirmed.
+	AbandonChannel(ctx context.Context, in *AbandonChannelRequest, opts ...grpc.CallOption) (*AbandonChannelResponse, error)
+	SubscribeChannelEvents(ctx context.Context, in *ChannelEventSubscription, opts ...grpc.CallOption) (Lightning_SubscribeChannelEventsClient, error)
+	// SubscribeHtlcEvents returns a uni-directional stream which sends an
+	// event each time an HTLC traversing the switch is forwarded, fails to
+	// be forwarded, is failed back, or is settled.
+	SubscribeHtlcEvents(ctx context.Context, in *SubscribeHtlcEventsRequest, opts ...grpc.CallOption) (Lightning_SubscribeHtlcEventsClient, error)
+	SendPayment(ctx context.Context, opts ...grpc.CallOption) (Lightning_SendPaymentClient, error)
+	SendPaymentSync(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*SendResponse, error)
+	// SendToRouteV2 makes a single attempt to settle a payment over the
+	// passed route, returning the outcome of the attempt. Failed attempts
+	// aren't retried, leaving the caller in control of retrying, yet each
+	// attempt is recorded within the payments store as with SendPayment.
+	SendToRouteV2(ctx context.Context, in *SendToRouteRequest, opts ...grpc.CallOption) (*HTLCAttempt, error)
+	// TrackPayment returns a uni-directional stream which sends the current
+	// state of the payment with the passed payment hash, followed by an
+	// update each time an attempt to settle it is recorded, until it either
+	// succeeds or is abandoned.
+	TrackPayment(ctx context.Context, in *TrackPaymentRequest, opts ...grpc.CallOption) (Lightning_TrackPaymentClient, error)
+	AddInvoice(ctx context.Context, in *Invoice, opts ...grpc.CallOption) (*AddInvoiceResponse, error)
+	ListInvoices(ctx context.Context, in *ListInvoiceRequest, opts ...grpc.CallOption) (*ListInvoiceResponse, error)
+	LookupInvoice(ctx context.Context, in *PaymentHash, opts ...grpc.CallOption) (*Invoice, error)
+	// DecodePayReq decodes the passed payment request, returning the
+	// destination, payment hash and amount encoded within it. Decoded
+	// payment requests are cached, so repeated decodes of the same request
+	// are cheap.
+	DecodePayReq(ctx context.Context, in *PayReqString, opts ...grpc.CallOption) (*PayReq, error)
+	SubscribeInvoices(ctx context.Context, in *InvoiceSubscription, opts ...grpc.CallOption) (Lightning_SubscribeInvoicesClient, error)
+	ListPayments(ctx context.Context, in *ListPaymentsRequest, opts ...grpc.CallOption) (*ListPaymentsResponse, error)
+	DeleteAllPayments(ctx context.Context, in *DeleteAllPaymentsRequest, opts ...grpc.CallOption) (*DeleteAllPaymentsResponse, error)
+	// DeletePayment deletes the payment with the given payment hash, or
+	// only the records of its failed attempts.
+	DeletePayment(ctx context.Context, in *DeletePaymentRequest, opts ...grpc.CallOption) (*DeletePaymentResponse, error)
+	DescribeGraph(ctx context.Context, in *ChannelGraphRequest, opts ...grpc.CallOption) (*ChannelGraph, error)
+	GetChanInfo(ctx context.Context, in *ChanInfoRequest, opts ...grpc.CallOption) (*ChannelEdge, error)
+	GetNodeInfo(ctx context.Context, in *NodeInfoRequest, opts ...grpc.CallOption) (*NodeInfo, error)
+	// GetNodeAddresses returns the known network addresses of the target node,
+	// along with the time each was last seen, and the node's feature bits if
+	// we're connected to it.
+	GetNodeAddresses(ctx context.Context, in *NodeAddressesRequest, opts ...grpc.CallOption) (*NodeAddressesResponse, error)
+	QueryRoute(ctx context.Context, in *RouteRequest, opts ...grpc.CallOption) (*Route, error)
+	EstimateRouteFee(ctx context.Context, in *RouteFeeRequest, opts ...grpc.CallOption) (*RouteFeeResponse, error)
+	GetNetworkInfo(ctx context.Context, in *NetworkInfoRequest, opts ...grpc.CallOption) (*NetworkInfo, error)
+	SubscribeChannelGraph(ctx context.Context, in *GraphTopologySubscription, opts ...grpc.CallOption) (Lightning_SubscribeChannelGraphClient, error)
+	SetAlias(ctx context.Context, in *SetAliasRequest, opts ...grpc.CallOption) (*SetAliasResponse, error)
+	UpdateNodeAnnouncement(ctx context.Context, in *NodeAnnouncementUpdateRequest, opts ...grpc.CallOption) (*NodeAnnouncementUpdateResponse, error)
+	SendCustomMessage(ctx context.Context, in *SendCustomMessageRequest, opts ...grpc.CallOption) (*SendCustomMessageResponse, error)
+	SubscribeCustomMessages(ctx context.Context, in *SubscribeCustomMessagesRequest, opts ...grpc.CallOption) (Lightning_SubscribeCustomMessagesClient, error)
+	SignOutputRaw(ctx context.Context, in *SignReq, opts ...grpc.CallOption) (*SignResp, error)
+	ComputeInputScript(ctx context.Context, in *SignReq, opts ...grpc.CallOption) (*InputScriptResp, error)
+	DeriveSharedKey(ctx context.Context, in *SharedKeyRequest, opts ...grpc.CallOption) (*SharedKeyResponse, error)
+	// SignDigest signs the passed digest with the key at the passed key
+	// locator, allowing a watch-only node to sign its gossip messages with
+	// the node key held by its remote signer.
+	SignDigest(ctx context.Context, in *SignDigestRequest, opts ...grpc.CallOption) (*SignDigestResponse, error)
+	// ProcessOnion processes an onion packet destined to the watch-only node
+	// whose node key is held by this remote signer, as the ECDH operation of
+	// the onion layer requires the node key.
+	ProcessOnion(ctx context.Context, in *ProcessOnionRequest, opts ...grpc.CallOption) (*ProcessOnionResponse, error)
+	ListUnspent(ctx context.Context, in *ListUnspentRequest, opts ...grpc.CallOption) (*ListUnspentResponse, error)
+	ListAddresses(ctx context.Context, in *ListAddressesRequest, opts ...grpc.CallOption) (*ListAddressesResponse, error)
+	NextAddr(ctx context.Context, in *AddrRequest, opts ...grpc.CallOption) (*NewAddressResponse, error)
+	DeriveKey(ctx context.Context, in *DeriveKeyRequest, opts ...grpc.CallOption) (*KeyDescriptor, error)
+	DeriveNextKey(ctx context.Context, in *DeriveNextKeyRequest, opts ...grpc.CallOption) (*KeyDescriptor, error)
+	PublishTransaction(ctx context.Context, in *PublishTransactionRequest, opts ...grpc.CallOption) (*PublishTransactionResponse, error)
+	EstimateFee(ctx context.Context, in *EstimateFeeRequest, opts ...grpc.CallOption) (*EstimateFeeResponse, error)
+	FundPsbt(ctx context.Context, in *FundPsbtRequest, opts ...grpc.CallOption) (*FundPsbtResponse, error)
+	SignPsbt(ctx context.Context, in *SignPsbtRequest, opts ...grpc.CallOption) (*SignPsbtResponse, error)
+	FinalizePsbt(ctx context.Context, in *FinalizePsbtRequest, opts ...grpc.CallOption) (*FinalizePsbtResponse, error)
+	LeaseOutput(ctx context.Context, in *LeaseOutputRequest, opts ...grpc.CallOption) (*LeaseOutputResponse, error)
+	ReleaseOutput(ctx context.Context, in *ReleaseOutputRequest, opts ...grpc.CallOption) (*ReleaseOutputResponse, error)
+	ListLeases(ctx context.Context, in *ListLeasesRequest, opts ...grpc.CallOption) (*ListLeasesResponse, error)
+	LabelTransaction(ctx context.Context, in *LabelTransactionRequest, opts ...grpc.CallOption) (*LabelTransactionResponse, error)
+	ListSweeps(ctx context.Context, in *ListSweepsRequest, opts ...grpc.CallOption) (*ListSweepsResponse, error)
+	ImportAccount(ctx context.Context, in *ImportAccountRequest, opts ...grpc.CallOption) (*ImportAccountResponse, error)
+	ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error)
+	GetRecoveryInfo(ctx context.Context, in *GetRecoveryInfoRequest, opts ...grpc.CallOption) (*GetRecoveryInfoResponse, error)
+	DebugLevel(ctx context.Context, in *DebugLevelRequest, opts ...grpc.CallOption) (*DebugLevelResponse, error)
+	// StopDaemon gracefully shuts down the daemon, as if it had received a
+	// SIGINT (Ctrl+C) signal. The shutdown starts once the response has
+	// been sent.
+	StopDaemon(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*StopResponse, error)
+	// GetDebugInfo returns a bundle of the daemon's sanitized config and
+	// runtime state to be attached to support requests.
+	GetDebugInfo(ctx context.Context, in *GetDebugInfoRequest, opts ...grpc.CallOption) (*GetDebugInfoResponse, error)
+	// CaptureCPUProfile profiles the CPU usage of the daemon for the
+	// requested duration, returning the profile in the pprof format.
+	CaptureCPUProfile(ctx context.Context, in *CPUProfileRequest, opts ...grpc.CallOption) (*CPUProfileResponse, error)
+	// ExportChannelDbForAudit writes a copy of the channel database to the
+	// given path on the daemon's filesystem, stripped of the invoice
+	// preimages and revocation secrets which would allow its holder to steal
+	// funds, so it may be shared with support staff or accountants.
+	ExportChannelDbForAudit(ctx context.Context, in *ExportChannelDbRequest, opts ...grpc.CallOption) (*ExportChannelDbResponse, error)
+	AutopilotStatus(ctx context.Context, in *AutopilotStatusRequest, opts ...grpc.CallOption) (*AutopilotStatusResponse, error)
+	ModifyAutopilotStatus(ctx context.Context, in *ModifyAutopilotStatusRequest, opts ...grpc.CallOption) (*ModifyAutopilotStatusResponse, error)
+	QueryAutopilotScores(ctx context.Context, in *QueryAutopilotScoresRequest, opts ...grpc.CallOption) (*QueryAutopilotScoresResponse, error)
+	ChannelInsights(ctx context.Context, in *ChannelInsightsRequest, opts ...grpc.CallOption) (*ChannelInsightsResponse, error)
+	SetFeeManagement(ctx context.Context, in *SetFeeManagementRequest, opts ...grpc.CallOption) (*SetFeeManagementResponse, error)
+	// UpdateChanStatus disables, or re-enables, forwarding over one of our
+	// channels, broadcasting a channel update with the disable bit set
+	// accordingly. A channel may also be returned to automatic management,
+	// where it's disabled while its peer is offline.
+	UpdateChanStatus(ctx context.Context, in *UpdateChanStatusRequest, opts ...grpc.CallOption) (*UpdateChanStatusResponse, error)
+	// UpgradeChannel upgrades the commitment type of an active channel
+	// without closing it. The channel is quiesced while the upgrade is
+	// negotiated with the remote peer, then resumed, the new commitment
+	// type being used for all commitments created after the upgrade.
+	UpgradeChannel(ctx context.Context, in *UpgradeChannelRequest, opts ...grpc.CallOption) (*UpgradeChannelResponse, error)
+	// ExportChannelBackup returns an encrypted static backup of a single
+	// channel, while ExportAllChannelBackups returns backups of all our
+	// open channels. The backups are encrypted with a key derived from the
+	// wallet's seed.
+	ExportChannelBackup(ctx context.Context, in *ExportChannelBackupRequest, opts ...grpc.CallOption) (*ChannelBackup, error)
+	ExportAllChannelBackups(ctx context.Context, in *ChanBackupExportRequest, opts ...grpc.CallOption) (*ChanBackupSnapshot, error)
+	// RestoreChannelBackups restores the channels within the passed static
+	// backups, after they've lost their state. The peer of each channel is
+	// connected to, and requested to force close the channel so its funds
+	// can be recovered.
+	RestoreChannelBackups(ctx context.Context, in *RestoreChanBackupRequest, opts ...grpc.CallOption) (*RestoreBackupResponse, error)
+	// VerifyChanBackup checks that the passed backups were created by our
+	// wallet and are of a known format, without restoring any channels.
+	VerifyChanBackup(ctx context.Context, in *ChanBackupSnapshot, opts ...grpc.CallOption) (*VerifyChanBackupResponse, error)
+}
+
+type lightningClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewLightningClient(cc *grpc.ClientConn) LightningClient {
+	return &lightningClient{cc}
+}
+
+func (c *lightningClient) WalletBalance(ctx context.Context, in *WalletBalanceRequest, opts ...grpc.CallOption) (*WalletBalanceResponse, error) {
+	out := new(WalletBalanceResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/WalletBalance", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ChannelBalance(ctx context.Context, in *ChannelBalanceRequest, opts ...grpc.CallOption) (*ChannelBalanceResponse, error) {
+	out := new(ChannelBalanceResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ChannelBalance", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*TransactionDetails, error) {
+	out := new(TransactionDetails)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetTransactions", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SendCoins(ctx context.Context, in *SendCoinsRequest, opts ...grpc.CallOption) (*SendCoinsResponse, error) {
+	out := new(SendCoinsResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendCoins", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SubscribeTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (Lightning_SubscribeTransactionsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[0], c.cc, "/lnrpc.Lightning/SubscribeTransactions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSubscribeTransactionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_SubscribeTransactionsClient interface {
+	Recv() (*Transaction, error)
+	grpc.ClientStream
+}
+
+type lightningSubscribeTransactionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSubscribeTransactionsClient) Recv() (*Transaction, error) {
+	m := new(Transaction)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) SendMany(ctx context.Context, in *SendManyRequest, opts ...grpc.CallOption) (*SendManyResponse, error) {
+	out := new(SendManyResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendMany", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) NewAddress(ctx context.Context, in *NewAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error) {
+	out := new(NewAddressResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/NewAddress", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) NewWitnessAddress(ctx context.Context, in *NewWitnessAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error) {
+	out := new(NewAddressResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/NewWitnessAddress", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ConnectPeer(ctx context.Context, in *ConnectPeerRequest, opts ...grpc.CallOption) (*ConnectPeerResponse, error) {
+	out := new(ConnectPeerResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ConnectPeer", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ListPeers(ctx context.Context, in *ListPeersRequest, opts ...grpc.CallOption) (*ListPeersResponse, error) {
+	out := new(ListPeersResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListPeers", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SubscribePeerEvents(ctx context.Context, in *PeerEventSubscription, opts ...grpc.CallOption) (Lightning_SubscribePeerEventsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[1], c.cc, "/lnrpc.Lightning/SubscribePeerEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSubscribePeerEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_SubscribePeerEventsClient interface {
+	Recv() (*PeerEvent, error)
+	grpc.ClientStream
+}
+
+type lightningSubscribePeerEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSubscribePeerEventsClient) Recv() (*PeerEvent, error) {
+	m := new(PeerEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) UpdatePeerAccess(ctx context.Context, in *UpdatePeerAccessRequest, opts ...grpc.CallOption) (*UpdatePeerAccessResponse, error) {
+	out := new(UpdatePeerAccessResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/UpdatePeerAccess", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ListPeerAccess(ctx context.Context, in *ListPeerAccessRequest, opts ...grpc.CallOption) (*ListPeerAccessResponse, error) {
+	out := new(ListPeerAccessResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListPeerAccess", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error) {
+	out := new(GetInfoResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) PendingChannels(ctx context.Context, in *PendingChannelRequest, opts ...grpc.CallOption) (*PendingChannelResponse, error) {
+	out := new(PendingChannelResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/PendingChannels", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ListChannels(ctx context.Context, in *ListChannelsRequest, opts ...grpc.CallOption) (*ListChannelsResponse, error) {
+	out := new(ListChannelsResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListChannels", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) GetChannelBalance(ctx context.Context, in *GetChannelBalanceRequest, opts ...grpc.CallOption) (*GetChannelBalanceResponse, error) {
+	out := new(GetChannelBalanceResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetChannelBalance", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) OpenChannelSync(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (*ChannelPoint, error) {
+	out := new(ChannelPoint)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/OpenChannelSync", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) OpenChannel(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (Lightning_OpenChannelClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[2], c.cc, "/lnrpc.Lightning/OpenChannel", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningOpenChannelClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_OpenChannelClient interface {
+	Recv() (*OpenStatusUpdate, error)
+	grpc.ClientStream
+}
+
+type lightningOpenChannelClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningOpenChannelClient) Recv() (*OpenStatusUpdate, error) {
+	m := new(OpenStatusUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) CloseChannel(ctx context.Context, in *CloseChannelRequest, opts ...grpc.CallOption) (Lightning_CloseChannelClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[3], c.cc, "/lnrpc.Lightning/CloseChannel", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningCloseChannelClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_CloseChannelClient interface {
+	Recv() (*CloseStatusUpdate, error)
+	grpc.ClientStream
+}
+
+type lightningCloseChannelClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningCloseChannelClient) Recv() (*CloseStatusUpdate, error) {
+	m := new(CloseStatusUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) AbandonChannel(ctx context.Context, in *AbandonChannelRequest, opts ...grpc.CallOption) (*AbandonChannelResponse, error) {
+	out := new(AbandonChannelResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/AbandonChannel", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SubscribeChannelEvents(ctx context.Context, in *ChannelEventSubscription, opts ...grpc.CallOption) (Lightning_SubscribeChannelEventsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[4], c.cc, "/lnrpc.Lightning/SubscribeChannelEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSubscribeChannelEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_SubscribeChannelEventsClient interface {
+	Recv() (*ChannelEventUpdate, error)
+	grpc.ClientStream
+}
+
+type lightningSubscribeChannelEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSubscribeChannelEventsClient) Recv() (*ChannelEventUpdate, error) {
+	m := new(ChannelEventUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) SubscribeHtlcEvents(ctx context.Context, in *SubscribeHtlcEventsRequest, opts ...grpc.CallOption) (Lightning_SubscribeHtlcEventsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[5], c.cc, "/lnrpc.Lightning/SubscribeHtlcEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSubscribeHtlcEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_SubscribeHtlcEventsClient interface {
+	Recv() (*HtlcEvent, error)
+	grpc.ClientStream
+}
+
+type lightningSubscribeHtlcEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSubscribeHtlcEventsClient) Recv() (*HtlcEvent, error) {
+	m := new(HtlcEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) SendPayment(ctx context.Context, opts ...grpc.CallOption) (Lightning_SendPaymentClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[6], c.cc, "/lnrpc.Lightning/SendPayment", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSendPaymentClient{stream}
+	return x, nil
+}
+
+type Lightning_SendPaymentClient interface {
+	Send(*SendRequest) error
+	Recv() (*SendResponse, error)
+	grpc.ClientStream
+}
+
+type lightningSendPaymentClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSendPaymentClient) Send(m *SendRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *lightningSendPaymentClient) Recv() (*SendResponse, error) {
+	m := new(SendResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) SendPaymentSync(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*SendResponse, error) {
+	out := new(SendResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendPaymentSync", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SendToRouteV2(ctx context.Context, in *SendToRouteRequest, opts ...grpc.CallOption) (*HTLCAttempt, error) {
+	out := new(HTLCAttempt)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendToRouteV2", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) TrackPayment(ctx context.Context, in *TrackPaymentRequest, opts ...grpc.CallOption) (Lightning_TrackPaymentClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[7], c.cc, "/lnrpc.Lightning/TrackPayment", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningTrackPaymentClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_TrackPaymentClient interface {
+	Recv() (*Payment, error)
+	grpc.ClientStream
+}
+
+type lightningTrackPaymentClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningTrackPaymentClient) Recv() (*Payment, error) {
+	m := new(Payment)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) AddInvoice(ctx context.Context, in *Invoice, opts ...grpc.CallOption) (*AddInvoiceResponse, error) {
+	out := new(AddInvoiceResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/AddInvoice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ListInvoices(ctx context.Context, in *ListInvoiceRequest, opts ...grpc.CallOption) (*ListInvoiceResponse, error) {
+	out := new(ListInvoiceResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListInvoices", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) LookupInvoice(ctx context.Context, in *PaymentHash, opts ...grpc.CallOption) (*Invoice, error) {
+	out := new(Invoice)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/LookupInvoice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) DecodePayReq(ctx context.Context, in *PayReqString, opts ...grpc.CallOption) (*PayReq, error) {
+	out := new(PayReq)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DecodePayReq", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SubscribeInvoices(ctx context.Context, in *InvoiceSubscription, opts ...grpc.CallOption) (Lightning_SubscribeInvoicesClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[8], c.cc, "/lnrpc.Lightning/SubscribeInvoices", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSubscribeInvoicesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_SubscribeInvoicesClient interface {
+	Recv() (*Invoice, error)
+	grpc.ClientStream
+}
+
+type lightningSubscribeInvoicesClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSubscribeInvoicesClient) Recv() (*Invoice, error) {
+	m := new(Invoice)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) ListPayments(ctx context.Context, in *ListPaymentsRequest, opts ...grpc.CallOption) (*ListPaymentsResponse, error) {
+	out := new(ListPaymentsResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListPayments", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) DeleteAllPayments(ctx context.Context, in *DeleteAllPaymentsRequest, opts ...grpc.CallOption) (*DeleteAllPaymentsResponse, error) {
+	out := new(DeleteAllPaymentsResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DeleteAllPayments", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) DeletePayment(ctx context.Context, in *DeletePaymentRequest, opts ...grpc.CallOption) (*DeletePaymentResponse, error) {
+	out := new(DeletePaymentResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DeletePayment", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) DescribeGraph(ctx context.Context, in *ChannelGraphRequest, opts ...grpc.CallOption) (*ChannelGraph, error) {
+	out := new(ChannelGraph)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DescribeGraph", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) GetChanInfo(ctx context.Context, in *ChanInfoRequest, opts ...grpc.CallOption) (*ChannelEdge, error) {
+	out := new(ChannelEdge)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetChanInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) GetNodeInfo(ctx context.Context, in *NodeInfoRequest, opts ...grpc.CallOption) (*NodeInfo, error) {
+	out := new(NodeInfo)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetNodeInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) GetNodeAddresses(ctx context.Context, in *NodeAddressesRequest, opts ...grpc.CallOption) (*NodeAddressesResponse, error) {
+	out := new(NodeAddressesResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetNodeAddresses", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) QueryRoute(ctx context.Context, in *RouteRequest, opts ...grpc.CallOption) (*Route, error) {
+	out := new(Route)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/QueryRoute", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) EstimateRouteFee(ctx context.Context, in *RouteFeeRequest, opts ...grpc.CallOption) (*RouteFeeResponse, error) {
+	out := new(RouteFeeResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/EstimateRouteFee", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) GetNetworkInfo(ctx context.Context, in *NetworkInfoRequest, opts ...grpc.CallOption) (*NetworkInfo, error) {
+	out := new(NetworkInfo)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetNetworkInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SubscribeChannelGraph(ctx context.Context, in *GraphTopologySubscription, opts ...grpc.CallOption) (Lightning_SubscribeChannelGraphClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[9], c.cc, "/lnrpc.Lightning/SubscribeChannelGraph", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSubscribeChannelGraphClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_SubscribeChannelGraphClient interface {
+	Recv() (*GraphTopologyUpdate, error)
+	grpc.ClientStream
+}
+
+type lightningSubscribeChannelGraphClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSubscribeChannelGraphClient) Recv() (*GraphTopologyUpdate, error) {
+	m := new(GraphTopologyUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) SetAlias(ctx context.Context, in *SetAliasRequest, opts ...grpc.CallOption) (*SetAliasResponse, error) {
+	out := new(SetAliasResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SetAlias", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) UpdateNodeAnnouncement(ctx context.Context, in *NodeAnnouncementUpdateRequest, opts ...grpc.CallOption) (*NodeAnnouncementUpdateResponse, error) {
+	out := new(NodeAnnouncementUpdateResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/UpdateNodeAnnouncement", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SendCustomMessage(ctx context.Context, in *SendCustomMessageRequest, opts ...grpc.CallOption) (*SendCustomMessageResponse, error) {
+	out := new(SendCustomMessageResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendCustomMessage", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SubscribeCustomMessages(ctx context.Context, in *SubscribeCustomMessagesRequest, opts ...grpc.CallOption) (Lightning_SubscribeCustomMessagesClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[10], c.cc, "/lnrpc.Lightning/SubscribeCustomMessages", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSubscribeCustomMessagesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_SubscribeCustomMessagesClient interface {
+	Recv() (*CustomMessage, error)
+	grpc.ClientStream
+}
+
+type lightningSubscribeCustomMessagesClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSubscribeCustomMessagesClient) Recv() (*CustomMessage, error) {
+	m := new(CustomMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) SignOutputRaw(ctx context.Context, in *SignReq, opts ...grpc.CallOption) (*SignResp, error) {
+	out := new(SignResp)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SignOutputRaw", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ComputeInputScript(ctx context.Context, in *SignReq, opts ...grpc.CallOption) (*InputScriptResp, error) {
+	out := new(InputScriptResp)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ComputeInputScript", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) DeriveSharedKey(ctx context.Context, in *SharedKeyRequest, opts ...grpc.CallOption) (*SharedKeyResponse, error) {
+	out := new(SharedKeyResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DeriveSharedKey", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SignDigest(ctx context.Context, in *SignDigestRequest, opts ...grpc.CallOption) (*SignDigestResponse, error) {
+	out := new(SignDigestResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SignDigest", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ProcessOnion(ctx context.Context, in *ProcessOnionRequest, opts ...grpc.CallOption) (*ProcessOnionResponse, error) {
+	out := new(ProcessOnionResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ProcessOnion", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ListUnspent(ctx context.Context, in *ListUnspentRequest, opts ...grpc.CallOption) (*ListUnspentResponse, error) {
+	out := new(ListUnspentResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListUnspent", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ListAddresses(ctx context.Context, in *ListAddressesRequest, opts ...grpc.CallOption) (*ListAddressesResponse, error) {
+	out := new(ListAddressesResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListAddresses", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) NextAddr(ctx context.Context, in *AddrRequest, opts ...grpc.CallOption) (*NewAddressResponse, error) {
+	out := new(NewAddressResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/NextAddr", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) DeriveKey(ctx context.Context, in *DeriveKeyRequest, opts ...grpc.CallOption) (*KeyDescriptor, error) {
+	out := new(KeyDescriptor)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DeriveKey", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) DeriveNextKey(ctx context.Context, in *DeriveNextKeyRequest, opts ...grpc.CallOption) (*KeyDescriptor, error) {
+	out := new(KeyDescriptor)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DeriveNextKey", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) PublishTransaction(ctx context.Context, in *PublishTransactionRequest, opts ...grpc.CallOption) (*PublishTransactionResponse, error) {
+	out := new(PublishTransactionResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/PublishTransaction", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) EstimateFee(ctx context.Context, in *EstimateFeeRequest, opts ...grpc.CallOption) (*EstimateFeeResponse, error) {
+	out := new(EstimateFeeResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/EstimateFee", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) FundPsbt(ctx context.Context, in *FundPsbtRequest, opts ...grpc.CallOption) (*FundPsbtResponse, error) {
+	out := new(FundPsbtResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/FundPsbt", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SignPsbt(ctx context.Context, in *SignPsbtRequest, opts ...grpc.CallOption) (*SignPsbtResponse, error) {
+	out := new(SignPsbtResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SignPsbt", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) FinalizePsbt(ctx context.Context, in *FinalizePsbtRequest, opts ...grpc.CallOption) (*FinalizePsbtResponse, error) {
+	out := new(FinalizePsbtResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/FinalizePsbt", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) LeaseOutput(ctx context.Context, in *LeaseOutputRequest, opts ...grpc.CallOption) (*LeaseOutputResponse, error) {
+	out := new(LeaseOutputResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/LeaseOutput", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ReleaseOutput(ctx context.Context, in *ReleaseOutputRequest, opts ...grpc.CallOption) (*ReleaseOutputResponse, error) {
+	out := new(ReleaseOutputResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ReleaseOutput", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ListLeases(ctx context.Context, in *ListLeasesRequest, opts ...grpc.CallOption) (*ListLeasesResponse, error) {
+	out := new(ListLeasesResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListLeases", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) LabelTransaction(ctx context.Context, in *LabelTransactionRequest, opts ...grpc.CallOption) (*LabelTransactionResponse, error) {
+	out := new(LabelTransactionResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/LabelTransaction", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ListSweeps(ctx context.Context, in *ListSweepsRequest, opts ...grpc.CallOption) (*ListSweepsResponse, error) {
+	out := new(ListSweepsResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListSweeps", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ImportAccount(ctx context.Context, in *ImportAccountRequest, opts ...grpc.CallOption) (*ImportAccountResponse, error) {
+	out := new(ImportAccountResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ImportAccount", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error) {
+	out := new(ListAccountsResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListAccounts", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) GetRecoveryInfo(ctx context.Context, in *GetRecoveryInfoRequest, opts ...grpc.CallOption) (*GetRecoveryInfoResponse, error) {
+	out := new(GetRecoveryInfoResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetRecoveryInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) DebugLevel(ctx context.Context, in *DebugLevelRequest, opts ...grpc.CallOption) (*DebugLevelResponse, error) {
+	out := new(DebugLevelResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DebugLevel", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) StopDaemon(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*StopResponse, error) {
+	out := new(StopResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/StopDaemon", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) GetDebugInfo(ctx context.Context, in *GetDebugInfoRequest, opts ...grpc.CallOption) (*GetDebugInfoResponse, error) {
+	out := new(GetDebugInfoResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetDebugInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) CaptureCPUProfile(ctx context.Context, in *CPUProfileRequest, opts ...grpc.CallOption) (*CPUProfileResponse, error) {
+	out := new(CPUProfileResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/CaptureCPUProfile", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ExportChannelDbForAudit(ctx context.Context, in *ExportChannelDbRequest, opts ...grpc.CallOption) (*ExportChannelDbResponse, error) {
+	out := new(ExportChannelDbResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ExportChannelDbForAudit", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) AutopilotStatus(ctx context.Context, in *AutopilotStatusRequest, opts ...grpc.CallOption) (*AutopilotStatusResponse, error) {
+	out := new(AutopilotStatusResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/AutopilotStatus", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ModifyAutopilotStatus(ctx context.Context, in *ModifyAutopilotStatusRequest, opts ...grpc.CallOption) (*ModifyAutopilotStatusResponse, error) {
+	out := new(ModifyAutopilotStatusResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ModifyAutopilotStatus", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) QueryAutopilotScores(ctx context.Context, in *QueryAutopilotScoresRequest, opts ...grpc.CallOption) (*QueryAutopilotScoresResponse, error) {
+	out := new(QueryAutopilotScoresResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/QueryAutopilotScores", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ChannelInsights(ctx context.Context, in *ChannelInsightsRequest, opts ...grpc.CallOption) (*ChannelInsightsResponse, error) {
+	out := new(ChannelInsightsResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ChannelInsights", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SetFeeManagement(ctx context.Context, in *SetFeeManagementRequest, opts ...grpc.CallOption) (*SetFeeManagementResponse, error) {
+	out := new(SetFeeManagementResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SetFeeManagement", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) UpdateChanStatus(ctx context.Context, in *UpdateChanStatusRequest, opts ...grpc.CallOption) (*UpdateChanStatusResponse, error) {
+	out := new(UpdateChanStatusResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/UpdateChanStatus", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) UpgradeChannel(ctx context.Context, in *UpgradeChannelRequest, opts ...grpc.CallOption) (*UpgradeChannelResponse, error) {
+	out := new(UpgradeChannelResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/UpgradeChannel", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ExportChannelBackup(ctx context.Context, in *ExportChannelBackupRequest, opts ...grpc.CallOption) (*ChannelBackup, error) {
+	out := new(ChannelBackup)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ExportChannelBackup", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ExportAllChannelBackups(ctx context.Context, in *ChanBackupExportRequest, opts ...grpc.CallOption) (*ChanBackupSnapshot, error) {
+	out := new(ChanBackupSnapshot)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ExportAllChannelBackups", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) RestoreChannelBackups(ctx context.Context, in *RestoreChanBackupRequest, opts ...grpc.CallOption) (*RestoreBackupResponse, error) {
+	out := new(RestoreBackupResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/RestoreChannelBackups", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) VerifyChanBackup(ctx context.Context, in *ChanBackupSnapshot, opts ...grpc.CallOption) (*VerifyChanBackupResponse, error) {
+	out := new(VerifyChanBackupResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/VerifyChanBackup", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Lightning service
+
+type LightningServer interface {
+	WalletBalance(context.Context, *WalletBalanceRequest) (*WalletBalanceResponse, error)
+	ChannelBalance(context.Context, *ChannelBalanceRequest) (*ChannelBalanceResponse, error)
+	GetTransactions(context.Context, *GetTransactionsRequest) (*TransactionDetails, error)
+	SendCoins(context.Context, *SendCoinsRequest) (*SendCoinsResponse, error)
+	SubscribeTransactions(*GetTransactionsRequest, Lightning_SubscribeTransactionsServer) error
+	SendMany(context.Context, *SendManyRequest) (*SendManyResponse, error)
+	NewAddress(context.Context, *NewAddressRequest) (*NewAddressResponse, error)
+	NewWitnessAddress(context.Context, *NewWitnessAddressRequest) (*NewAddressResponse, error)
+	ConnectPeer(context.Context, *ConnectPeerRequest) (*ConnectPeerResponse, error)
+	ListPeers(context.Context, *ListPeersRequest) (*ListPeersResponse, error)
+	SubscribePeerEvents(*PeerEventSubscription, Lightning_SubscribePeerEventsServer) error
+	// UpdatePeerAccess adds a peer to, or removes it from, the list of banned
+	// peers or the allowlist. Peers are identified either by their public
+	// key or by their IP address. Once updated, we disconnect from any peer
+	// whose connection we'd now refuse.
+	UpdatePeerAccess(context.Context, *UpdatePeerAccessRequest) (*UpdatePeerAccessResponse, error)
+	// ListPeerAccess returns the list of banned peers and the allowlist.
+	ListPeerAccess(context.Context, *ListPeerAccessRequest) (*ListPeerAccessResponse, error)
+	GetInfo(context.Context, *GetInfoRequest) (*GetInfoResponse, error)
+	// TODO(roasbeef): merge with below with bool?
+	PendingChannels(context.Context, *PendingChannelRequest) (*PendingChannelResponse, error)
+	ListChannels(context.Context, *ListChannelsRequest) (*ListChannelsResponse, error)
+	// GetChannelBalance breaks down the balance of a single channel, returning
+	// the amounts we can actually send and receive over it, which account for
+	// the channel reserve, the pending HTLCs, and the commitment fee.
+	GetChannelBalance(context.Context, *GetChannelBalanceRequest) (*GetChannelBalanceResponse, error)
+	OpenChannelSync(context.Context, *OpenChannelRequest) (*ChannelPoint, error)
+	OpenChannel(*OpenChannelRequest, Lightning_OpenChannelServer) error
+	CloseChannel(*CloseChannelRequest, Lightning_CloseChannelServer) error
+	// AbandonChannel removes all state of a channel from the database,
+	// recording it as closed, without taking any action on-chain. It's
+	// meant for recovering from channels which are stuck, for example as
+	// their funding transaction never confirmed.
+	AbandonChannel(context.Context, *AbandonChannelRequest) (*AbandonChannelResponse, error)
+	SubscribeChannelEvents(*ChannelEventSubscription, Lightning_SubscribeChannelEventsServer) error
+	// SubscribeHtlcEvents returns a uni-directional stream which sends an
+	// event each time an HTLC traversing the switch is forwarded, fails to
+	// be forwarded, is failed back, or is settled.
+	SubscribeHtlcEvents(*SubscribeHtlcEventsRequest, Lightning_SubscribeHtlcEventsServer) error
+	SendPayment(Lightning_SendPaymentServer) error
+	SendPaymentSync(context.Context, *SendRequest) (*SendResponse, error)
+	// SendToRouteV2 makes a single attempt to settle a payment over the
+	// passed route, returning the outcome of the attempt. Failed attempts
+	// aren't retried, leaving the caller in control of retrying, yet each
+	// attempt is recorded within the payments store as with SendPayment.
+	SendToRouteV2(context.Context, *SendToRouteRequest) (*HTLCAttempt, error)
+	// TrackPayment returns a uni-directional stream which sends the current
+	// state of the payment with the passed payment hash, followed by an
+	// update each time an attempt to settle it is recorded, until it either
+	// succeeds or is abandoned.
+	TrackPayment(*TrackPaymentRequest, Lightning_TrackPaymentServer) error
+	AddInvoice(context.Context, *Invoice) (*AddInvoiceResponse, error)
+	ListInvoices(context.Context, *ListInvoiceRequest) (*ListInvoiceResponse, error)
+	LookupInvoice(context.Context, *PaymentHash) (*Invoice, error)
+	// DecodePayReq decodes the passed payment request, returning the
+	// destination, payment hash and amount encoded within it. Decoded
+	// payment requests are cached, so repeated decodes of the same request
+	// are cheap.
+	DecodePayReq(context.Context, *PayReqString) (*PayReq, error)
+	SubscribeInvoices(*InvoiceSubscription, Lightning_SubscribeInvoicesServer) error
+	ListPayments(context.Context, *ListPaymentsRequest) (*ListPaymentsResponse, error)
+	DeleteAllPayments(context.Context, *DeleteAllPaymentsRequest) (*DeleteAllPaymentsResponse, error)
+	// DeletePayment deletes the payment with the given payment hash, or
+	// only the records of its failed attempts.
+	DeletePayment(context.Context, *DeletePaymentRequest) (*DeletePaymentResponse, error)
+	DescribeGraph(context.Context, *ChannelGraphRequest) (*ChannelGraph, error)
+	GetChanInfo(context.Context, *ChanInfoRequest) (*ChannelEdge, error)
+	GetNodeInfo(context.Context, *NodeInfoRequest) (*NodeInfo, error)
+	// GetNodeAddresses returns the known network addresses of the target node,
+	// along with the time each was last seen, and the node's feature bits if
+	// we're connected to it.
+	GetNodeAddresses(context.Context, *NodeAddressesRequest) (*NodeAddressesResponse, error)
+	QueryRoute(context.Context, *RouteRequest) (*Route, error)
+	EstimateRouteFee(context.Context, *RouteFeeRequest) (*RouteFeeResponse, error)
+	GetNetworkInfo(context.Context, *NetworkInfoRequest) (*NetworkInfo, error)
+	SubscribeChannelGraph(*GraphTopologySubscription, Lightning_SubscribeChannelGraphServer) error
+	SetAlias(context.Context, *SetAliasRequest) (*SetAliasResponse, error)
+	UpdateNodeAnnouncement(context.Context, *NodeAnnouncementUpdateRequest) (*NodeAnnouncementUpdateResponse, error)
+	SendCustomMessage(context.Context, *SendCustomMessageRequest) (*SendCustomMessageResponse, error)
+	SubscribeCustomMessages(*SubscribeCustomMessagesRequest, Lightning_SubscribeCustomMessagesServer) error
+	SignOutputRaw(context.Context, *SignReq) (*SignResp, error)
+	ComputeInputScript(context.Context, *SignReq) (*InputScriptResp, error)
+	DeriveSharedKey(context.Context, *SharedKeyRequest) (*SharedKeyResponse, error)
+	// SignDigest signs the passed digest with the key at the passed key
+	// locator, allowing a watch-only node to sign its gossip messages with
+	// the node key held by its remote signer.
+	SignDigest(context.Context, *SignDigestRequest) (*SignDigestResponse, error)
+	// ProcessOnion processes an onion packet destined to the watch-only node
+	// whose node key is held by this remote signer, as the ECDH operation of
+	// the onion layer requires the node key.
+	ProcessOnion(context.Context, *ProcessOnionRequest) (*ProcessOnionResponse, error)
+	ListUnspent(context.Context, *ListUnspentRequest) (*ListUnspentResponse, error)
+	ListAddresses(context.Context, *ListAddressesRequest) (*ListAddressesResponse, error)
+	NextAddr(context.Context, *AddrRequest) (*NewAddressResponse, error)
+	DeriveKey(context.Context, *DeriveKeyRequest) (*KeyDescriptor, error)
+	DeriveNextKey(context.Context, *DeriveNextKeyRequest) (*KeyDescriptor, error)
+	PublishTransaction(context.Context, *PublishTransactionRequest) (*PublishTransactionResponse, error)
+	EstimateFee(context.Context, *EstimateFeeRequest) (*EstimateFeeResponse, error)
+	FundPsbt(context.Context, *FundPsbtRequest) (*FundPsbtResponse, error)
+	SignPsbt(context.Context, *SignPsbtRequest) (*SignPsbtResponse, error)
+	FinalizePsbt(context.Context, *FinalizePsbtRequest) (*FinalizePsbtResponse, error)
+	LeaseOutput(context.Context, *LeaseOutputRequest) (*LeaseOutputResponse, error)
+	ReleaseOutput(context.Context, *ReleaseOutputRequest) (*ReleaseOutputResponse, error)
+	ListLeases(context.Context, *ListLeasesRequest) (*ListLeasesResponse, error)
+	LabelTransaction(context.Context, *LabelTransactionRequest) (*LabelTransactionResponse, error)
+	ListSweeps(context.Context, *ListSweepsRequest) (*ListSweepsResponse, error)
+	ImportAccount(context.Context, *ImportAccountRequest) (*ImportAccountResponse, error)
+	ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error)
+	GetRecoveryInfo(context.Context, *GetRecoveryInfoRequest) (*GetRecoveryInfoResponse, error)
+	DebugLevel(context.Context, *DebugLevelRequest) (*DebugLevelResponse, error)
+	// StopDaemon gracefully shuts down the daemon, as if it had received a
+	// SIGINT (Ctrl+C) signal. The shutdown starts once the response has
+	// been sent.
+	StopDaemon(context.Context, *StopRequest) (*StopResponse, error)
+	// GetDebugInfo returns a bundle of the daemon's sanitized config and
+	// runtime state to be attached to support requests.
+	GetDebugInfo(context.Context, *GetDebugInfoRequest) (*GetDebugInfoResponse, error)
+	// CaptureCPUProfile profiles the CPU usage of the daemon for the
+	// requested duration, returning the profile in the pprof format.
+	CaptureCPUProfile(context.Context, *CPUProfileRequest) (*CPUProfileResponse, error)
+	// ExportChannelDbForAudit writes a copy of the channel database to the
+	// given path on the daemon's filesystem, stripped of the invoice
+	// preimages and revocation secrets which would allow its holder to steal
+	// funds, so it may be shared with support staff or accountants.
+	ExportChannelDbForAudit(context.Context, *ExportChannelDbRequest) (*ExportChannelDbResponse, error)
+	AutopilotStatus(context.Context, *AutopilotStatusRequest) (*AutopilotStatusResponse, error)
+	ModifyAutopilotStatus(context.Context, *ModifyAutopilotStatusRequest) (*ModifyAutopilotStatusResponse, error)
+	QueryAutopilotScores(context.Context, *QueryAutopilotScoresRequest) (*QueryAutopilotScoresResponse, error)
+	ChannelInsights(context.Context, *ChannelInsightsRequest) (*ChannelInsightsResponse, error)
+	SetFeeManagement(context.Context, *SetFeeManagementRequest) (*SetFeeManagementResponse, error)
+	// UpdateChanStatus disables, or re-enables, forwarding over one of our
+	// channels, broadcasting a channel update with the disable bit set
+	// accordingly. A channel may also be returned to automatic management,
+	// where it's disabled while its peer is offline.
+	UpdateChanStatus(context.Context, *UpdateChanStatusRequest) (*UpdateChanStatusResponse, error)
+	// UpgradeChannel upgrades the commitment type of an active channel
+	// without closing it. The channel is quiesced while the upgrade is
+	// negotiated with the remote peer, then resumed, the new commitment
+	// type being used for all commitments created after the upgrade.
+	UpgradeChannel(context.Context, *UpgradeChannelRequest) (*UpgradeChannelResponse, error)
+	// ExportChannelBackup returns an encrypted static backup of a single
+	// channel, while ExportAllChannelBackups returns backups of all our
+	// open channels. The backups are encrypted with a key derived from the
+	// wallet's seed.
+	ExportChannelBackup(context.Context, *ExportChannelBackupRequest) (*ChannelBackup, error)
+	ExportAllChannelBackups(context.Context, *ChanBackupExportRequest) (*ChanBackupSnapshot, error)
+	// RestoreChannelBackups restores the channels within the passed static
+	// backups, after they've lost their state. The peer of each channel is
+	// connected to, and requested to force close the channel so its funds
+	// can be recovered.
+	RestoreChannelBackups(context.Context, *RestoreChanBackupRequest) (*RestoreBackupResponse, error)
+	// VerifyChanBackup checks that the passed backups were created by our
+	// wallet and are of a known format, without restoring any channels.
+	VerifyChanBackup(context.Context, *ChanBackupSnapshot) (*VerifyChanBackupResponse, error)
+}
+
+func RegisterLightningServer(s *grpc.Server, srv LightningServer) {
+	s.RegisterService(&_Lightning_serviceDesc, srv)
+}
+
+func _Lightning_WalletBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WalletBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).WalletBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/WalletBalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).WalletBalance(ctx, req.(*WalletBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ChannelBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ChannelBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ChannelBalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ChannelBalance(ctx, req.(*ChannelBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_GetTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).GetTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/GetTransactions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).GetTransactions(ctx, req.(*GetTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_SendCoins_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendCoinsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).SendCoins(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/SendCoins",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).SendCoins(ctx, req.(*SendCoinsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_SubscribeTransactions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetTransactionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).SubscribeTransactions(m, &lightningSubscribeTransactionsServer{stream})
+}
+
+type Lightning_SubscribeTransactionsServer interface {
+	Send(*Transaction) error
+	grpc.ServerStream
+}
+
+type lightningSubscribeTransactionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningSubscribeTransactionsServer) Send(m *Transaction) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Lightning_SendMany_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendManyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).SendMany(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/SendMany",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).SendMany(ctx, req.(*SendManyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_NewAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).NewAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/NewAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).NewAddress(ctx, req.(*NewAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_NewWitnessAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(NewWitnessAddressRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(LightningServer).NewWitnessAddress(ctx, in)
+	if interceptor == nil {
+		return srv.(LightningServer).NewWitnessAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/NewWitnessAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).NewWitnessAddress(ctx, req.(*NewWitnessAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ConnectPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConnectPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ConnectPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ConnectPeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ConnectPeer(ctx, req.(*ConnectPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ListPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPeersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ListPeers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ListPeers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ListPeers(ctx, req.(*ListPeersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_SubscribePeerEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PeerEventSubscription)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).SubscribePeerEvents(m, &lightningSubscribePeerEventsServer{stream})
+}
+
+type Lightning_SubscribePeerEventsServer interface {
+	Send(*PeerEvent) error
+	grpc.ServerStream
+}
+
+type lightningSubscribePeerEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningSubscribePeerEventsServer) Send(m *PeerEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Lightning_UpdatePeerAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePeerAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).UpdatePeerAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/UpdatePeerAccess",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).UpdatePeerAccess(ctx, req.(*UpdatePeerAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ListPeerAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPeerAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ListPeerAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ListPeerAccess",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ListPeerAccess(ctx, req.(*ListPeerAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_GetInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).GetInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/GetInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).GetInfo(ctx, req.(*GetInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_PendingChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PendingChannelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).PendingChannels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/PendingChannels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).PendingChannels(ctx, req.(*PendingChannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ListChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChannelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ListChannels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ListChannels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ListChannels(ctx, req.(*ListChannelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_GetChannelBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChannelBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).GetChannelBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/GetChannelBalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).GetChannelBalance(ctx, req.(*GetChannelBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_OpenChannelSync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenChannelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).OpenChannelSync(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/OpenChannelSync",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).OpenChannelSync(ctx, req.(*OpenChannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_OpenChannel_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(OpenChannelRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).OpenChannel(m, &lightningOpenChannelServer{stream})
+}
+
+type Lightning_OpenChannelServer interface {
+	Send(*OpenStatusUpdate) error
+	grpc.ServerStream
+}
+
+type lightningOpenChannelServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningOpenChannelServer) Send(m *OpenStatusUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Lightning_CloseChannel_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CloseChannelRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).CloseChannel(m, &lightningCloseChannelServer{stream})
+}
+
+type Lightning_CloseChannelServer interface {
+	Send(*CloseStatusUpdate) error
+	grpc.ServerStream
+}
+
+type lightningCloseChannelServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningCloseChannelServer) Send(m *CloseStatusUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Lightning_AbandonChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AbandonChannelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).AbandonChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/AbandonChannel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).AbandonChannel(ctx, req.(*AbandonChannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_SubscribeChannelEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ChannelEventSubscription)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).SubscribeChannelEvents(m, &lightningSubscribeChannelEventsServer{stream})
+}
+
+type Lightning_SubscribeChannelEventsServer interface {
+	Send(*ChannelEventUpdate) error
+	grpc.ServerStream
+}
+
+type lightningSubscribeChannelEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningSubscribeChannelEventsServer) Send(m *ChannelEventUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Lightning_SubscribeHtlcEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeHtlcEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).SubscribeHtlcEvents(m, &lightningSubscribeHtlcEventsServer{stream})
+}
+
+type Lightning_SubscribeHtlcEventsServer interface {
+	Send(*HtlcEvent) error
+	grpc.ServerStream
+}
+
+type lightningSubscribeHtlcEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningSubscribeHtlcEventsServer) Send(m *HtlcEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Lightning_SendPayment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LightningServer).SendPayment(&lightningSendPaymentServer{stream})
+}
+
+type Lightning_SendPaymentServer interface {
+	Send(*SendResponse) error
+	Recv() (*SendRequest, error)
+	grpc.ServerStream
+}
+
+type lightningSendPaymentServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningSendPaymentServer) Send(m *SendResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *lightningSendPaymentServer) Recv() (*SendRequest, error) {
+	m := new(SendRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Lightning_SendPaymentSync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).SendPaymentSync(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/SendPaymentSync",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).SendPaymentSync(ctx, req.(*SendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_SendToRouteV2_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendToRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).SendToRouteV2(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/SendToRouteV2",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).SendToRouteV2(ctx, req.(*SendToRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_TrackPayment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TrackPaymentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).TrackPayment(m, &lightningTrackPaymentServer{stream})
+}
+
+type Lightning_TrackPaymentServer interface {
+	Send(*Payment) error
+	grpc.ServerStream
+}
+
+type lightningTrackPaymentServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningTrackPaymentServer) Send(m *Payment) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Lightning_AddInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Invoice)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).AddInvoice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/AddInvoice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).AddInvoice(ctx, req.(*Invoice))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ListInvoices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInvoiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ListInvoices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ListInvoices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ListInvoices(ctx, req.(*ListInvoiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_LookupInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PaymentHash)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).LookupInvoice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/LookupInvoice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).LookupInvoice(ctx, req.(*PaymentHash))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_DecodePayReq_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PayReqString)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).DecodePayReq(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/DecodePayReq",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).DecodePayReq(ctx, req.(*PayReqString))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_SubscribeInvoices_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(InvoiceSubscription)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).SubscribeInvoices(m, &lightningSubscribeInvoicesServer{stream})
+}
+
+type Lightning_SubscribeInvoicesServer interface {
+	Send(*Invoice) error
+	grpc.ServerStream
+}
+
+type lightningSubscribeInvoicesServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningSubscribeInvoicesServer) Send(m *Invoice) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Lightning_ListPayments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPaymentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ListPayments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ListPayments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ListPayments(ctx, req.(*ListPaymentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_DeleteAllPayments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAllPaymentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).DeleteAllPayments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/DeleteAllPayments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).DeleteAllPayments(ctx, req.(*DeleteAllPaymentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_DeletePayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).DeletePayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/DeletePayment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).DeletePayment(ctx, req.(*DeletePaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_DescribeGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).DescribeGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/DescribeGraph",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).DescribeGraph(ctx, req.(*ChannelGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_GetChanInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChanInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).GetChanInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/GetChanInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).GetChanInfo(ctx, req.(*ChanInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_GetNodeInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).GetNodeInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/GetNodeInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).GetNodeInfo(ctx, req.(*NodeInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_GetNodeAddresses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeAddressesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).GetNodeAddresses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/GetNodeAddresses",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).GetNodeAddresses(ctx, req.(*NodeAddressesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_QueryRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).QueryRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/QueryRoute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).QueryRoute(ctx, req.(*RouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_EstimateRouteFee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RouteFeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).EstimateRouteFee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/EstimateRouteFee",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).EstimateRouteFee(ctx, req.(*RouteFeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_GetNetworkInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NetworkInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).GetNetworkInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/GetNetworkInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).GetNetworkInfo(ctx, req.(*NetworkInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_SubscribeChannelGraph_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GraphTopologySubscription)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).SubscribeChannelGraph(m, &lightningSubscribeChannelGraphServer{stream})
+}
+
+type Lightning_SubscribeChannelGraphServer interface {
+	Send(*GraphTopologyUpdate) error
+	grpc.ServerStream
+}
+
+type lightningSubscribeChannelGraphServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningSubscribeChannelGraphServer) Send(m *GraphTopologyUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Lightning_SetAlias_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAliasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).SetAlias(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/SetAlias",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).SetAlias(ctx, req.(*SetAliasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_UpdateNodeAnnouncement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeAnnouncementUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).UpdateNodeAnnouncement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/UpdateNodeAnnouncement",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).UpdateNodeAnnouncement(ctx, req.(*NodeAnnouncementUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_SendCustomMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendCustomMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).SendCustomMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/SendCustomMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).SendCustomMessage(ctx, req.(*SendCustomMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_SubscribeCustomMessages_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeCustomMessagesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).SubscribeCustomMessages(m, &lightningSubscribeCustomMessagesServer{stream})
+}
+
+type Lightning_SubscribeCustomMessagesServer interface {
+	Send(*CustomMessage) error
+	grpc.ServerStream
+}
+
+type lightningSubscribeCustomMessagesServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningSubscribeCustomMessagesServer) Send(m *CustomMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Lightning_SignOutputRaw_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).SignOutputRaw(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/SignOutputRaw",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).SignOutputRaw(ctx, req.(*SignReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ComputeInputScript_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ComputeInputScript(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ComputeInputScript",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ComputeInputScript(ctx, req.(*SignReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_DeriveSharedKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SharedKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).DeriveSharedKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/DeriveSharedKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).DeriveSharedKey(ctx, req.(*SharedKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_SignDigest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignDigestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).SignDigest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/SignDigest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).SignDigest(ctx, req.(*SignDigestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ProcessOnion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProcessOnionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ProcessOnion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ProcessOnion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ProcessOnion(ctx, req.(*ProcessOnionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ListUnspent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUnspentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ListUnspent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ListUnspent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ListUnspent(ctx, req.(*ListUnspentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ListAddresses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAddressesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ListAddresses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ListAddresses",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ListAddresses(ctx, req.(*ListAddressesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_NextAddr_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddrRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).NextAddr(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/NextAddr",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).NextAddr(ctx, req.(*AddrRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_DeriveKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeriveKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).DeriveKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/DeriveKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).DeriveKey(ctx, req.(*DeriveKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_DeriveNextKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeriveNextKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).DeriveNextKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/DeriveNextKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).DeriveNextKey(ctx, req.(*DeriveNextKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_PublishTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).PublishTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/PublishTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).PublishTransaction(ctx, req.(*PublishTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_EstimateFee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EstimateFeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).EstimateFee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/EstimateFee",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).EstimateFee(ctx, req.(*EstimateFeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_FundPsbt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FundPsbtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).FundPsbt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/FundPsbt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).FundPsbt(ctx, req.(*FundPsbtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_SignPsbt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignPsbtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).SignPsbt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/SignPsbt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).SignPsbt(ctx, req.(*SignPsbtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_FinalizePsbt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinalizePsbtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).FinalizePsbt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/FinalizePsbt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).FinalizePsbt(ctx, req.(*FinalizePsbtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_LeaseOutput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaseOutputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).LeaseOutput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/LeaseOutput",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).LeaseOutput(ctx, req.(*LeaseOutputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ReleaseOutput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseOutputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ReleaseOutput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ReleaseOutput",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ReleaseOutput(ctx, req.(*ReleaseOutputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ListLeases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLeasesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ListLeases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ListLeases",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ListLeases(ctx, req.(*ListLeasesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_LabelTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabelTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).LabelTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/LabelTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).LabelTransaction(ctx, req.(*LabelTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ListSweeps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSweepsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ListSweeps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ListSweeps",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ListSweeps(ctx, req.(*ListSweepsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ImportAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ImportAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ImportAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ImportAccount(ctx, req.(*ImportAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ListAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ListAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ListAccounts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ListAccounts(ctx, req.(*ListAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_GetRecoveryInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecoveryInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).GetRecoveryInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/GetRecoveryInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).GetRecoveryInfo(ctx, req.(*GetRecoveryInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_DebugLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DebugLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).DebugLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/DebugLevel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).DebugLevel(ctx, req.(*DebugLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_StopDaemon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).StopDaemon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/StopDaemon",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).StopDaemon(ctx, req.(*StopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_GetDebugInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDebugInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).GetDebugInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/GetDebugInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).GetDebugInfo(ctx, req.(*GetDebugInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_CaptureCPUProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CPUProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).CaptureCPUProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/CaptureCPUProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).CaptureCPUProfile(ctx, req.(*CPUProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ExportChannelDbForAudit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportChannelDbRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ExportChannelDbForAudit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ExportChannelDbForAudit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ExportChannelDbForAudit(ctx, req.(*ExportChannelDbRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_AutopilotStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AutopilotStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).AutopilotStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/AutopilotStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).AutopilotStatus(ctx, req.(*AutopilotStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ModifyAutopilotStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModifyAutopilotStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ModifyAutopilotStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ModifyAutopilotStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ModifyAutopilotStatus(ctx, req.(*ModifyAutopilotStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_QueryAutopilotScores_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryAutopilotScoresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).QueryAutopilotScores(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/QueryAutopilotScores",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).QueryAutopilotScores(ctx, req.(*QueryAutopilotScoresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ChannelInsights_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelInsightsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ChannelInsights(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ChannelInsights",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ChannelInsights(ctx, req.(*ChannelInsightsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_SetFeeManagement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetFeeManagementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).SetFeeManagement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/SetFeeManagement",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).SetFeeManagement(ctx, req.(*SetFeeManagementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_UpdateChanStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateChanStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).UpdateChanStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/UpdateChanStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).UpdateChanStatus(ctx, req.(*UpdateChanStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_UpgradeChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpgradeChannelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).UpgradeChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/UpgradeChannel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).UpgradeChannel(ctx, req.(*UpgradeChannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ExportChannelBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportChannelBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ExportChannelBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ExportChannelBackup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ExportChannelBackup(ctx, req.(*ExportChannelBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ExportAllChannelBackups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChanBackupExportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ExportAllChannelBackups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ExportAllChannelBackups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ExportAllChannelBackups(ctx, req.(*ChanBackupExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_RestoreChannelBackups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreChanBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).RestoreChannelBackups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/RestoreChannelBackups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).RestoreChannelBackups(ctx, req.(*RestoreChanBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_VerifyChanBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChanBackupSnapshot)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).VerifyChanBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/VerifyChanBackup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).VerifyChanBackup(ctx, req.(*ChanBackupSnapshot))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Lightning_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "lnrpc.Lightning",
+	HandlerType: (*LightningServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "WalletBalance",
+			Handler:    _Lightning_WalletBalance_Handler,
+		},
+		{
+			MethodName: "ChannelBalance",
+			Handler:    _Lightning_ChannelBalance_Handler,
+		},
+		{
+			MethodName: "GetTransactions",
+			Handler:    _Lightning_GetTransactions_Handler,
+		},
+		{
+			MethodName: "SendCoins",
+			Handler:    _Lightning_SendCoins_Handler,
+		},
+		{
+			MethodName: "SendMany",
+			Handler:    _Lightning_SendMany_Handler,
+		},
+		{
+			MethodName: "NewAddress",
+			Handler:    _Lightning_NewAddress_Handler,
+		},
+		{
+			MethodName: "NewWitnessAddress",
+			Handler:    _Lightning_NewWitnessAddress_Handler,
+		},
+		{
+			MethodName: "ConnectPeer",
+			Handler:    _Lightning_ConnectPeer_Handler,
+		},
+		{
+			MethodName: "ListPeers",
+			Handler:    _Lightning_ListPeers_Handler,
+		},
+		{
+			MethodName: "GetInfo",
+			Handler:    _Lightning_GetInfo_Handler,
+		},
+		{
+			MethodName: "PendingChannels",
+			Handler:    _Lightning_PendingChannels_Handler,
+		},
+		{
+			MethodName: "ListChannels",
+			Handler:    _Lightning_ListChannels_Handler,
+		},
+		{
+			MethodName: "GetChannelBalance",
+			Handler:    _Lightning_GetChannelBalance_Handler,
+		},
+		{
+			MethodName: "OpenChannelSync",
+			Handler:    _Lightning_OpenChannelSync_Handler,
+		},
+		{
+			MethodName: "AbandonChannel",
+			Handler:    _Lightning_AbandonChannel_Handler,
+		},
+		{
+			MethodName: "SendPaymentSync",
+			Handler:    _Lightning_SendPaymentSync_Handler,
+		},
+		{
+			MethodName: "SendToRouteV2",
+			Handler:    _Lightning_SendToRouteV2_Handler,
+		},
+		{
+			MethodName: "AddInvoice",
+			Handler:    _Lightning_AddInvoice_Handler,
+		},
+		{
+			MethodName: "ListInvoices",
+			Handler:    _Lightning_ListInvoices_Handler,
+		},
+		{
+			MethodName: "LookupInvoice",
+			Handler:    _Lightning_LookupInvoice_Handler,
+		},
+		{
+			MethodName: "DecodePayReq",
+			Handler:    _Lightning_DecodePayReq_Handler,
+		},
+		{
+			MethodName: "ListPayments",
+			Handler:    _Lightning_ListPayments_Handler,
+		},
+		{
+			MethodName: "DeleteAllPayments",
+			Handler:    _Lightning_DeleteAllPayments_Handler,
+		},
+		{
+			MethodName: "DeletePayment",
+			Handler:    _Lightning_DeletePayment_Handler,
+		},
+		{
+			MethodName: "DescribeGraph",
+			Handler:    _Lightning_DescribeGraph_Handler,
+		},
+		{
+			MethodName: "GetChanInfo",
+			Handler:    _Lightning_GetChanInfo_Handler,
+		},
+		{
+			MethodName: "GetNodeInfo",
+			Handler:    _Lightning_GetNodeInfo_Handler,
+		},
+		{
+			MethodName: "GetNodeAddresses",
+			Handler:    _Lightning_GetNodeAddresses_Handler,
+		},
+		{
+			MethodName: "QueryRoute",
+			Handler:    _Lightning_QueryRoute_Handler,
+		},
+		{
+			MethodName: "EstimateRouteFee",
+			Handler:    _Lightning_EstimateRouteFee_Handler,
+		},
+		{
+			MethodName: "GetNetworkInfo",
+			Handler:    _Lightning_GetNetworkInfo_Handler,
+		},
+		{
+			MethodName: "SetAlias",
+			Handler:    _Lightning_SetAlias_Handler,
+		},
+		{
+			MethodName: "UpdateNodeAnnouncement",
+			Handler:    _Lightning_UpdateNodeAnnouncement_Handler,
+		},
+		{
+			MethodName: "SendCustomMessage",
+			Handler:    _Lightning_SendCustomMessage_Handler,
+		},
+		{
+			MethodName: "SignOutputRaw",
+			Handler:    _Lightning_SignOutputRaw_Handler,
+		},
+		{
+			MethodName: "ComputeInputScript",
+			Handler:    _Lightning_ComputeInputScript_Handler,
+		},
+		{
+			MethodName: "DeriveSharedKey",
+			Handler:    _Lightning_DeriveSharedKey_Handler,
+		},
+		{
+			MethodName: "SignDigest",
+			Handler:    _Lightning_SignDigest_Handler,
+		},
+		{
+			MethodName: "ProcessOnion",
+			Handler:    _Lightning_ProcessOnion_Handler,
+		},
+		{
+			MethodName: "ListUnspent",
+			Handler:    _Lightning_ListUnspent_Handler,
+		},
+		{
+			MethodName: "ListAddresses",
+			Handler:    _Lightning_ListAddresses_Handler,
+		},
+		{
+			MethodName: "NextAddr",
+			Handler:    _Lightning_NextAddr_Handler,
+		},
+		{
+			MethodName: "DeriveKey",
+			Handler:    _Lightning_DeriveKey_Handler,
+		},
+		{
+			MethodName: "DeriveNextKey",
+			Handler:    _Lightning_DeriveNextKey_Handler,
+		},
+		{
+			MethodName: "PublishTransaction",
+			Handler:    _Lightning_PublishTransaction_Handler,
+		},
+		{
+			MethodName: "EstimateFee",
+			Handler:    _Lightning_EstimateFee_Handler,
+		},
+		{
+			MethodName: "FundPsbt",
+			Handler:    _Lightning_FundPsbt_Handler,
+		},
+		{
+			MethodName: "SignPsbt",
+			Handler:    _Lightning_SignPsbt_Handler,
+		},
+		{
+			MethodName: "FinalizePsbt",
+			Handler:    _Lightning_FinalizePsbt_Handler,
+		},
+		{
+			MethodName: "LeaseOutput",
+			Handler:    _Lightning_LeaseOutput_Handler,
+		},
+		{
+			MethodName: "ReleaseOutput",
+			Handler:    _Lightning_ReleaseOutput_Handler,
+		},
+		{
+			MethodName: "ListLeases",
+			Handler:    _Lightning_ListLeases_Handler,
+		},
+		{
+			MethodName: "LabelTransaction",
+			Handler:    _Lightning_LabelTransaction_Handler,
+		},
+		{
+			MethodName: "ListSweeps",
+			Handler:    _Lightning_ListSweeps_Handler,
+		},
+		{
+			MethodName: "ImportAccount",
+			Handler:    _Lightning_ImportAccount_Handler,
+		},
+		{
+			MethodName: "ListAccounts",
+			Handler:    _Lightning_ListAccounts_Handler,
+		},
+		{
+			MethodName: "GetRecoveryInfo",
+			Handler:    _Lightning_GetRecoveryInfo_Handler,
+		},
+		{
+			MethodName: "DebugLevel",
+			Handler:    _Lightning_DebugLevel_Handler,
+		},
+		{
+			MethodName: "StopDaemon",
+			Handler:    _Lightning_StopDaemon_Handler,
+		},
+		{
+			MethodName: "GetDebugInfo",
+			Handler:    _Lightning_GetDebugInfo_Handler,
+		},
+		{
+			MethodName: "CaptureCPUProfile",
+			Handler:    _Lightning_CaptureCPUProfile_Handler,
+		},
+		{
+			MethodName: "ExportChannelDbForAudit",
+			Handler:    _Lightning_ExportChannelDbForAudit_Handler,
+		},
+		{
+			MethodName: "AutopilotStatus",
+			Handler:    _Lightning_AutopilotStatus_Handler,
+		},
+		{
+			MethodName: "ModifyAutopilotStatus",
+			Handler:    _Lightning_ModifyAutopilotStatus_Handler,
+		},
+		{
+			MethodName: "QueryAutopilotScores",
+			Handler:    _Lightning_QueryAutopilotScores_Handler,
+		},
+		{
+			MethodName: "ChannelInsights",
+			Handler:    _Lightning_ChannelInsights_Handler,
+		},
+		{
+			MethodName: "SetFeeManagement",
+			Handler:    _Lightning_SetFeeManagement_Handler,
+		},
+		{
+			MethodName: "UpdateChanStatus",
+			Handler:    _Lightning_UpdateChanStatus_Handler,
+		},
+		{
+			MethodName: "UpgradeChannel",
+			Handler:    _Lightning_UpgradeChannel_Handler,
+		},
+		{
+			MethodName: "ExportChannelBackup",
+			Handler:    _Lightning_ExportChannelBackup_Handler,
+		},
+		{
+			MethodName: "ExportAllChannelBackups",
+			Handler:    _Lightning_ExportAllChannelBackups_Handler,
+		},
+		{
+			MethodName: "RestoreChannelBackups",
+			Handler:    _Lightning_RestoreChannelBackups_Handler,
+		},
+		{
+			MethodName: "VerifyChanBackup",
+			Handler:    _Lightning_VerifyChanBackup_Handler,
+		},
+		{
+			MethodName: "UpdatePeerAccess",
+			Handler:    _Lightning_UpdatePeerAccess_Handler,
+		},
+		{
+			MethodName: "ListPeerAccess",
+			Handler:    _Lightning_ListPeerAccess_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeTransactions",
+			Handler:       _Lightning_SubscribeTransactions_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribePeerEvents",
+			Handler:       _Lightning_SubscribePeerEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "OpenChannel",
+			Handler:       _Lightning_OpenChannel_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "CloseChannel",
+			Handler:       _Lightning_CloseChannel_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeChannelEvents",
+			Handler:       _Lightning_SubscribeChannelEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeHtlcEvents",
+			Handler:       _Lightning_SubscribeHtlcEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SendPayment",
+			Handler:       _Lightning_SendPayment_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "TrackPayment",
+			Handler:       _Lightning_TrackPayment_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeInvoices",
+			Handler:       _Lightning_SubscribeInvoices_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeChannelGraph",
+			Handler:       _Lightning_SubscribeChannelGraph_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeCustomMessages",
+			Handler:       _Lightning_SubscribeCustomMessages_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "rpc.proto",
+}
+
+// Client API for State service
+
+type StateClient interface {
+	SubscribeState(ctx context.Context, in *SubscribeStateRequest, opts ...grpc.CallOption) (State_SubscribeStateClient, error)
+	GetState(ctx context.Context, in *GetStateRequest, opts ...grpc.CallOption) (*GetStateResponse, error)
+}
+
+type stateClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewStateClient(cc *grpc.ClientConn) StateClient {
+	return &stateClient{cc}
+}
+
+func (c *stateClient) SubscribeState(ctx context.Context, in *SubscribeStateRequest, opts ...grpc.CallOption) (State_SubscribeStateClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_State_serviceDesc.Streams[0], c.cc, "/lnrpc.State/SubscribeState", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &stateSubscribeStateClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/NewWitnessAddress",
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).NewWitnessAddress(ctx, req.(*NewWitnessAddressRequest))
+	return x, nil
+}
+
+type State_SubscribeStateClient interface {
+	Recv() (*SubscribeStateResponse, error)
+	grpc.ClientStream
+}
+
+type stateSubscribeStateClient struct {
+	grpc.ClientStream
+}
+
+func (x *stateSubscribeStateClient) Recv() (*SubscribeStateResponse, error) {
+	m := new(SubscribeStateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *stateClient) GetState(ctx context.Context, in *GetStateRequest, opts ...grpc.CallOption) (*GetStateResponse, error) {
+	out := new(GetStateResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.State/GetState", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for State service
+
+type StateServer interface {
+	SubscribeState(*SubscribeStateRequest, State_SubscribeStateServer) error
+	GetState(context.Context, *GetStateRequest) (*GetStateResponse, error)
+}
+
+func RegisterStateServer(s *grpc.Server, srv StateServer) {
+	s.RegisterService(&_State_serviceDesc, srv)
+}
+
+func _State_SubscribeState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeStateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return interceptor(ctx, in, info, handler)
+	return srv.(StateServer).SubscribeState(m, &stateSubscribeStateServer{stream})
 }
 
-func _Lightning_ConnectPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ConnectPeerRequest)
+type State_SubscribeStateServer interface {
+	Send(*SubscribeStateResponse) error
+	grpc.ServerStream
+}
+
+type stateSubscribeStateServer struct {
+	grpc.ServerStream
+}
+
+func (x *stateSubscribeStateServer) Send(m *SubscribeStateResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _State_GetState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStateRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).ConnectPeer(ctx, in)
+		return srv.(StateServer).GetState(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/ConnectPeer",
+		FullMethod: "/lnrpc.State/GetState",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).ConnectPeer(ctx, req.(*ConnectPeerRequest))
+		return srv.(StateServer).GetState(ctx, req.(*GetStateRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_ListPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListPeersRequest)
-	if err := dec(in); err != nil {
+var _State_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "lnrpc.State",
+	HandlerType: (*StateServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetState",
+			Handler:    _State_GetState_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeState",
+			Handler:       _State_SubscribeState_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "rpc.proto",
+}
+
+// Client API for Autopilot service
+
+type AutopilotClient interface {
+	SetScores(ctx context.Context, in *SetScoresRequest, opts ...grpc.CallOption) (*SetScoresResponse, error)
+	QueryScores(ctx context.Context, in *QueryScoresRequest, opts ...grpc.CallOption) (*QueryScoresResponse, error)
+}
+
+type autopilotClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAutopilotClient(cc *grpc.ClientConn) AutopilotClient {
+	return &autopilotClient{cc}
+}
+
+func (c *autopilotClient) SetScores(ctx context.Context, in *SetScoresRequest, opts ...grpc.CallOption) (*SetScoresResponse, error) {
+	out := new(SetScoresResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Autopilot/SetScores", in, out, c.cc, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(LightningServer).ListPeers(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/ListPeers",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).ListPeers(ctx, req.(*ListPeersRequest))
+	return out, nil
+}
+
+func (c *autopilotClient) QueryScores(ctx context.Context, in *QueryScoresRequest, opts ...grpc.CallOption) (*QueryScoresResponse, error) {
+	out := new(QueryScoresResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Autopilot/QueryScores", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _Lightning_GetInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetInfoRequest)
+// Server API for Autopilot service
+
+type AutopilotServer interface {
+	SetScores(context.Context, *SetScoresRequest) (*SetScoresResponse, error)
+	QueryScores(context.Context, *QueryScoresRequest) (*QueryScoresResponse, error)
+}
+
+func RegisterAutopilotServer(s *grpc.Server, srv AutopilotServer) {
+	s.RegisterService(&_Autopilot_serviceDesc, srv)
+}
+
+func _Autopilot_SetScores_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetScoresRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).GetInfo(ctx, in)
+		return srv.(AutopilotServer).SetScores(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/GetInfo",
+		FullMethod: "/lnrpc.Autopilot/SetScores",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).GetInfo(ctx, req.(*GetInfoRequest))
+		return srv.(AutopilotServer).SetScores(ctx, req.(*SetScoresRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_PendingChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PendingChannelRequest)
+func _Autopilot_QueryScores_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryScoresRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).PendingChannels(ctx, in)
+		return srv.(AutopilotServer).QueryScores(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/PendingChannels",
+		FullMethod: "/lnrpc.Autopilot/QueryScores",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).PendingChannels(ctx, req.(*PendingChannelRequest))
+		return srv.(AutopilotServer).QueryScores(ctx, req.(*QueryScoresRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_ListChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListChannelsRequest)
-	if err := dec(in); err != nil {
+var _Autopilot_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "lnrpc.Autopilot",
+	HandlerType: (*AutopilotServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetScores",
+			Handler:    _Autopilot_SetScores_Handler,
+		},
+		{
+			MethodName: "QueryScores",
+			Handler:    _Autopilot_QueryScores_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rpc.proto",
+}
+
+// Client API for Invoices service
+
+type InvoicesClient interface {
+	// HtlcModifier sends each HTLC arriving for one of our invoices to the
+	// client, which responds with whether to override the amount the HTLC
+	// pays towards the invoice, or to cancel it. Only a single client may
+	// be connected at a time. HTLCs arriving while none is connected, or
+	// which aren't responded to in time, are processed unmodified.
+	HtlcModifier(ctx context.Context, opts ...grpc.CallOption) (Invoices_HtlcModifierClient, error)
+	// InvoiceAcceptor sends each HTLC paying one of our open invoices to the
+	// client, which must accept it for the HTLC to be accepted, allowing it
+	// to impose conditions of its own, such as a KYC check. Any number of
+	// clients may be connected at once. HTLCs which aren't accepted in time
+	// are canceled.
+	InvoiceAcceptor(ctx context.Context, opts ...grpc.CallOption) (Invoices_InvoiceAcceptorClient, error)
+	// SubscribeSingleInvoice sends the current state of the invoice with the
+	// given payment hash, followed by each subsequent update to it: an HTLC
+	// paying it being accepted, and the invoice being settled.
+	SubscribeSingleInvoice(ctx context.Context, in *PaymentHash, opts ...grpc.CallOption) (Invoices_SubscribeSingleInvoiceClient, error)
+}
+
+type invoicesClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewInvoicesClient(cc *grpc.ClientConn) InvoicesClient {
+	return &invoicesClient{cc}
+}
+
+func (c *invoicesClient) HtlcModifier(ctx context.Context, opts ...grpc.CallOption) (Invoices_HtlcModifierClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Invoices_serviceDesc.Streams[0], c.cc, "/lnrpc.Invoices/HtlcModifier", opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(LightningServer).ListChannels(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/ListChannels",
+	x := &invoicesHtlcModifierClient{stream}
+	return x, nil
+}
+
+type Invoices_HtlcModifierClient interface {
+	Send(*HtlcModifyResponse) error
+	Recv() (*HtlcModifyRequest, error)
+	grpc.ClientStream
+}
+
+type invoicesHtlcModifierClient struct {
+	grpc.ClientStream
+}
+
+func (x *invoicesHtlcModifierClient) Send(m *HtlcModifyResponse) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *invoicesHtlcModifierClient) Recv() (*HtlcModifyRequest, error) {
+	m := new(HtlcModifyRequest)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).ListChannels(ctx, req.(*ListChannelsRequest))
+	return m, nil
+}
+
+func (c *invoicesClient) InvoiceAcceptor(ctx context.Context, opts ...grpc.CallOption) (Invoices_InvoiceAcceptorClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Invoices_serviceDesc.Streams[1], c.cc, "/lnrpc.Invoices/InvoiceAcceptor", opts...)
+	if err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	x := &invoicesInvoiceAcceptorClient{stream}
+	return x, nil
 }
 
-func _Lightning_OpenChannelSync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(OpenChannelRequest)
-	if err := dec(in); err != nil {
+type Invoices_InvoiceAcceptorClient interface {
+	Send(*InvoiceAcceptResponse) error
+	Recv() (*InvoiceAcceptRequest, error)
+	grpc.ClientStream
+}
+
+type invoicesInvoiceAcceptorClient struct {
+	grpc.ClientStream
+}
+
+func (x *invoicesInvoiceAcceptorClient) Send(m *InvoiceAcceptResponse) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *invoicesInvoiceAcceptorClient) Recv() (*InvoiceAcceptRequest, error) {
+	m := new(InvoiceAcceptRequest)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(LightningServer).OpenChannelSync(ctx, in)
+	return m, nil
+}
+
+func (c *invoicesClient) SubscribeSingleInvoice(ctx context.Context, in *PaymentHash, opts ...grpc.CallOption) (Invoices_SubscribeSingleInvoiceClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Invoices_serviceDesc.Streams[2], c.cc, "/lnrpc.Invoices/SubscribeSingleInvoice", opts...)
+	if err != nil {
+		return nil, err
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/OpenChannelSync",
+	x := &invoicesSubscribeSingleInvoiceClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).OpenChannelSync(ctx, req.(*OpenChannelRequest))
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return x, nil
 }
 
-func _Lightning_OpenChannel_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(OpenChannelRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+type Invoices_SubscribeSingleInvoiceClient interface {
+	Recv() (*Invoice, error)
+	grpc.ClientStream
+}
+
+type invoicesSubscribeSingleInvoiceClient struct {
+	grpc.ClientStream
+}
+
+func (x *invoicesSubscribeSingleInvoiceClient) Recv() (*Invoice, error) {
+	m := new(Invoice)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return srv.(LightningServer).OpenChannel(m, &lightningOpenChannelServer{stream})
+	return m, nil
 }
 
-type Lightning_OpenChannelServer interface {
-	Send(*OpenStatusUpdate) error
+// Server API for Invoices service
+
+type InvoicesServer interface {
+	// HtlcModifier sends each HTLC arriving for one of our invoices to the
+	// client, which responds with whether to override the amount the HTLC
+	// pays towards the invoice, or to cancel it. Only a single client may
+	// be connected at a time. HTLCs arriving while none is connected, or
+	// which aren't responded to in time, are processed unmodified.
+	HtlcModifier(Invoices_HtlcModifierServer) error
+	// InvoiceAcceptor sends each HTLC paying one of our open invoices to the
+	// client, which must accept it for the HTLC to be accepted, allowing it
+	// to impose conditions of its own, such as a KYC check. Any number of
+	// clients may be connected at once. HTLCs which aren't accepted in time
+	// are canceled.
+	InvoiceAcceptor(Invoices_InvoiceAcceptorServer) error
+	// SubscribeSingleInvoice sends the current state of the invoice with the
+	// given payment hash, followed by each subsequent update to it: an HTLC
+	// paying it being accepted, and the invoice being settled.
+	SubscribeSingleInvoice(*PaymentHash, Invoices_SubscribeSingleInvoiceServer) error
+}
+
+func RegisterInvoicesServer(s *grpc.Server, srv InvoicesServer) {
+	s.RegisterService(&_Invoices_serviceDesc, srv)
+}
+
+func _Invoices_HtlcModifier_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(InvoicesServer).HtlcModifier(&invoicesHtlcModifierServer{stream})
+}
+
+type Invoices_HtlcModifierServer interface {
+	Send(*HtlcModifyRequest) error
+	Recv() (*HtlcModifyResponse, error)
 	grpc.ServerStream
 }
 
-type lightningOpenChannelServer struct {
+type invoicesHtlcModifierServer struct {
 	grpc.ServerStream
 }
 
-func (x *lightningOpenChannelServer) Send(m *OpenStatusUpdate) error {
+func (x *invoicesHtlcModifierServer) Send(m *HtlcModifyRequest) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Lightning_CloseChannel_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(CloseChannelRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func (x *invoicesHtlcModifierServer) Recv() (*HtlcModifyResponse, error) {
+	m := new(HtlcModifyResponse)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return srv.(LightningServer).CloseChannel(m, &lightningCloseChannelServer{stream})
+	return m, nil
 }
 
-type Lightning_CloseChannelServer interface {
-	Send(*CloseStatusUpdate) error
+func _Invoices_InvoiceAcceptor_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(InvoicesServer).InvoiceAcceptor(&invoicesInvoiceAcceptorServer{stream})
+}
+
+type Invoices_InvoiceAcceptorServer interface {
+	Send(*InvoiceAcceptRequest) error
+	Recv() (*InvoiceAcceptResponse, error)
 	grpc.ServerStream
 }
 
-type lightningCloseChannelServer struct {
+type invoicesInvoiceAcceptorServer struct {
 	grpc.ServerStream
 }
 
-func (x *lightningCloseChannelServer) Send(m *CloseStatusUpdate) error {
+func (x *invoicesInvoiceAcceptorServer) Send(m *InvoiceAcceptRequest) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Lightning_SendPayment_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(LightningServer).SendPayment(&lightningSendPaymentServer{stream})
+func (x *invoicesInvoiceAcceptorServer) Recv() (*InvoiceAcceptResponse, error) {
+	m := new(InvoiceAcceptResponse)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
-type Lightning_SendPaymentServer interface {
-	Send(*SendResponse) error
-	Recv() (*SendRequest, error)
+func _Invoices_SubscribeSingleInvoice_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PaymentHash)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(InvoicesServer).SubscribeSingleInvoice(m, &invoicesSubscribeSingleInvoiceServer{stream})
+}
+
+type Invoices_SubscribeSingleInvoiceServer interface {
+	Send(*Invoice) error
 	grpc.ServerStream
 }
 
-type lightningSendPaymentServer struct {
+type invoicesSubscribeSingleInvoiceServer struct {
 	grpc.ServerStream
 }
 
-func (x *lightningSendPaymentServer) Send(m *SendResponse) error {
+func (x *invoicesSubscribeSingleInvoiceServer) Send(m *Invoice) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func (x *lightningSendPaymentServer) Recv() (*SendRequest, error) {
-	m := new(SendRequest)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
+var _Invoices_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "lnrpc.Invoices",
+	HandlerType: (*InvoicesServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "HtlcModifier",
+			Handler:       _Invoices_HtlcModifier_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "InvoiceAcceptor",
+			Handler:       _Invoices_InvoiceAcceptor_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "SubscribeSingleInvoice",
+			Handler:       _Invoices_SubscribeSingleInvoice_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "rpc.proto",
+}
+
+// Client API for ChainKit service
+
+type ChainKitClient interface {
+	GetBestBlock(ctx context.Context, in *GetBestBlockRequest, opts ...grpc.CallOption) (*GetBestBlockResponse, error)
+	GetBlockHash(ctx context.Context, in *GetBlockHashRequest, opts ...grpc.CallOption) (*GetBlockHashResponse, error)
+	GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*GetBlockResponse, error)
+}
+
+type chainKitClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewChainKitClient(cc *grpc.ClientConn) ChainKitClient {
+	return &chainKitClient{cc}
+}
+
+func (c *chainKitClient) GetBestBlock(ctx context.Context, in *GetBestBlockRequest, opts ...grpc.CallOption) (*GetBestBlockResponse, error) {
+	out := new(GetBestBlockResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.ChainKit/GetBestBlock", in, out, c.cc, opts...)
+	if err != nil {
 		return nil, err
 	}
-	return m, nil
+	return out, nil
 }
 
-func _Lightning_SendPaymentSync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SendRequest)
-	if err := dec(in); err != nil {
+func (c *chainKitClient) GetBlockHash(ctx context.Context, in *GetBlockHashRequest, opts ...grpc.CallOption) (*GetBlockHashResponse, error) {
+	out := new(GetBlockHashResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.ChainKit/GetBlockHash", in, out, c.cc, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(LightningServer).SendPaymentSync(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/SendPaymentSync",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).SendPaymentSync(ctx, req.(*SendRequest))
+	return out, nil
+}
+
+func (c *chainKitClient) GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*GetBlockResponse, error) {
+	out := new(GetBlockResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.ChainKit/GetBlock", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _Lightning_AddInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Invoice)
+// Server API for ChainKit service
+
+type ChainKitServer interface {
+	GetBestBlock(context.Context, *GetBestBlockRequest) (*GetBestBlockResponse, error)
+	GetBlockHash(context.Context, *GetBlockHashRequest) (*GetBlockHashResponse, error)
+	GetBlock(context.Context, *GetBlockRequest) (*GetBlockResponse, error)
+}
+
+func RegisterChainKitServer(s *grpc.Server, srv ChainKitServer) {
+	s.RegisterService(&_ChainKit_serviceDesc, srv)
+}
+
+func _ChainKit_GetBestBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBestBlockRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).AddInvoice(ctx, in)
+		return srv.(ChainKitServer).GetBestBlock(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/AddInvoice",
+		FullMethod: "/lnrpc.ChainKit/GetBestBlock",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).AddInvoice(ctx, req.(*Invoice))
+		return srv.(ChainKitServer).GetBestBlock(ctx, req.(*GetBestBlockRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_ListInvoices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListInvoiceRequest)
+func _ChainKit_GetBlockHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockHashRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).ListInvoices(ctx, in)
+		return srv.(ChainKitServer).GetBlockHash(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/ListInvoices",
+		FullMethod: "/lnrpc.ChainKit/GetBlockHash",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).ListInvoices(ctx, req.(*ListInvoiceRequest))
+		return srv.(ChainKitServer).GetBlockHash(ctx, req.(*GetBlockHashRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_LookupInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PaymentHash)
+func _ChainKit_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).LookupInvoice(ctx, in)
+		return srv.(ChainKitServer).GetBlock(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/LookupInvoice",
+		FullMethod: "/lnrpc.ChainKit/GetBlock",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).LookupInvoice(ctx, req.(*PaymentHash))
+		return srv.(ChainKitServer).GetBlock(ctx, req.(*GetBlockRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_SubscribeInvoices_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(InvoiceSubscription)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
-	}
-	return srv.(LightningServer).SubscribeInvoices(m, &lightningSubscribeInvoicesServer{stream})
+var _ChainKit_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "lnrpc.ChainKit",
+	HandlerType: (*ChainKitServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBestBlock",
+			Handler:    _ChainKit_GetBestBlock_Handler,
+		},
+		{
+			MethodName: "GetBlockHash",
+			Handler:    _ChainKit_GetBlockHash_Handler,
+		},
+		{
+			MethodName: "GetBlock",
+			Handler:    _ChainKit_GetBlock_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rpc.proto",
 }
 
-type Lightning_SubscribeInvoicesServer interface {
-	Send(*Invoice) error
-	grpc.ServerStream
+// Client API for Dev service
+
+type DevClient interface {
+	ImportGraph(ctx context.Context, in *ChannelGraph, opts ...grpc.CallOption) (*ImportGraphResponse, error)
+	QuiesceChannel(ctx context.Context, in *QuiesceChannelRequest, opts ...grpc.CallOption) (*QuiesceChannelResponse, error)
+	ForceStateTransition(ctx context.Context, in *ForceStateTransitionRequest, opts ...grpc.CallOption) (*ForceStateTransitionResponse, error)
+	SetConnImpairment(ctx context.Context, in *SetConnImpairmentRequest, opts ...grpc.CallOption) (*SetConnImpairmentResponse, error)
 }
 
-type lightningSubscribeInvoicesServer struct {
-	grpc.ServerStream
+type devClient struct {
+	cc *grpc.ClientConn
 }
 
-func (x *lightningSubscribeInvoicesServer) Send(m *Invoice) error {
-	return x.ServerStream.SendMsg(m)
+func NewDevClient(cc *grpc.ClientConn) DevClient {
+	return &devClient{cc}
 }
 
-func _Lightning_ListPayments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListPaymentsRequest)
-	if err := dec(in); err != nil {
+func (c *devClient) ImportGraph(ctx context.Context, in *ChannelGraph, opts ...grpc.CallOption) (*ImportGraphResponse, error) {
+	out := new(ImportGraphResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Dev/ImportGraph", in, out, c.cc, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(LightningServer).ListPayments(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/ListPayments",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).ListPayments(ctx, req.(*ListPaymentsRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _Lightning_DeleteAllPayments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteAllPaymentsRequest)
-	if err := dec(in); err != nil {
+func (c *devClient) QuiesceChannel(ctx context.Context, in *QuiesceChannelRequest, opts ...grpc.CallOption) (*QuiesceChannelResponse, error) {
+	out := new(QuiesceChannelResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Dev/QuiesceChannel", in, out, c.cc, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(LightningServer).DeleteAllPayments(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/DeleteAllPayments",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).DeleteAllPayments(ctx, req.(*DeleteAllPaymentsRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _Lightning_DescribeGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ChannelGraphRequest)
-	if err := dec(in); err != nil {
+func (c *devClient) ForceStateTransition(ctx context.Context, in *ForceStateTransitionRequest, opts ...grpc.CallOption) (*ForceStateTransitionResponse, error) {
+	out := new(ForceStateTransitionResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Dev/ForceStateTransition", in, out, c.cc, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(LightningServer).DescribeGraph(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/DescribeGraph",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).DescribeGraph(ctx, req.(*ChannelGraphRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _Lightning_GetChanInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ChanInfoRequest)
-	if err := dec(in); err != nil {
+func (c *devClient) SetConnImpairment(ctx context.Context, in *SetConnImpairmentRequest, opts ...grpc.CallOption) (*SetConnImpairmentResponse, error) {
+	out := new(SetConnImpairmentResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Dev/SetConnImpairment", in, out, c.cc, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(LightningServer).GetChanInfo(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/GetChanInfo",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).GetChanInfo(ctx, req.(*ChanInfoRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _Lightning_GetNodeInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NodeInfoRequest)
+// Server API for Dev service
+
+type DevServer interface {
+	ImportGraph(context.Context, *ChannelGraph) (*ImportGraphResponse, error)
+	QuiesceChannel(context.Context, *QuiesceChannelRequest) (*QuiesceChannelResponse, error)
+	ForceStateTransition(context.Context, *ForceStateTransitionRequest) (*ForceStateTransitionResponse, error)
+	SetConnImpairment(context.Context, *SetConnImpairmentRequest) (*SetConnImpairmentResponse, error)
+}
+
+func RegisterDevServer(s *grpc.Server, srv DevServer) {
+	s.RegisterService(&_Dev_serviceDesc, srv)
+}
+
+func _Dev_ImportGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelGraph)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).GetNodeInfo(ctx, in)
+		return srv.(DevServer).ImportGraph(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/GetNodeInfo",
+		FullMethod: "/lnrpc.Dev/ImportGraph",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).GetNodeInfo(ctx, req.(*NodeInfoRequest))
+		return srv.(DevServer).ImportGraph(ctx, req.(*ChannelGraph))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_QueryRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RouteRequest)
+func _Dev_QuiesceChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuiesceChannelRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).QueryRoute(ctx, in)
+		return srv.(DevServer).QuiesceChannel(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/QueryRoute",
+		FullMethod: "/lnrpc.Dev/QuiesceChannel",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).QueryRoute(ctx, req.(*RouteRequest))
+		return srv.(DevServer).QuiesceChannel(ctx, req.(*QuiesceChannelRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_GetNetworkInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NetworkInfoRequest)
+func _Dev_ForceStateTransition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForceStateTransitionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).GetNetworkInfo(ctx, in)
+		return srv.(DevServer).ForceStateTransition(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/GetNetworkInfo",
+		FullMethod: "/lnrpc.Dev/ForceStateTransition",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).GetNetworkInfo(ctx, req.(*NetworkInfoRequest))
+		return srv.(DevServer).ForceStateTransition(ctx, req.(*ForceStateTransitionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_SetAlias_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetAliasRequest)
+func _Dev_SetConnImpairment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetConnImpairmentRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).SetAlias(ctx, in)
+		return srv.(DevServer).SetConnImpairment(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/SetAlias",
+		FullMethod: "/lnrpc.Dev/SetConnImpairment",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).SetAlias(ctx, req.(*SetAliasRequest))
+		return srv.(DevServer).SetConnImpairment(ctx, req.(*SetConnImpairmentRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _Lightning_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "lnrpc.Lightning",
-	HandlerType: (*LightningServer)(nil),
+var _Dev_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "lnrpc.Dev",
+	HandlerType: (*DevServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "WalletBalance",
-			Handler:    _Lightning_WalletBalance_Handler,
-		},
-		{
-			MethodName: "ChannelBalance",
-			Handler:    _Lightning_ChannelBalance_Handler,
-		},
-		{
-			MethodName: "GetTransactions",
-			Handler:    _Lightning_GetTransactions_Handler,
-		},
-		{
-			MethodName: "SendCoins",
-			Handler:    _Lightning_SendCoins_Handler,
-		},
-		{
-			MethodName: "SendMany",
-			Handler:    _Lightning_SendMany_Handler,
-		},
-		{
-			MethodName: "NewAddress",
-			Handler:    _Lightning_NewAddress_Handler,
-		},
-		{
-			MethodName: "NewWitnessAddress",
-			Handler:    _Lightning_NewWitnessAddress_Handler,
-		},
-		{
-			MethodName: "ConnectPeer",
-			Handler:    _Lightning_ConnectPeer_Handler,
-		},
-		{
-			MethodName: "ListPeers",
-			Handler:    _Lightning_ListPeers_Handler,
-		},
-		{
-			MethodName: "GetInfo",
-			Handler:    _Lightning_GetInfo_Handler,
-		},
-		{
-			MethodName: "PendingChannels",
-			Handler:    _Lightning_PendingChannels_Handler,
-		},
-		{
-			MethodName: "ListChannels",
-			Handler:    _Lightning_ListChannels_Handler,
-		},
-		{
-			MethodName: "OpenChannelSync",
-			Handler:    _Lightning_OpenChannelSync_Handler,
-		},
-		{
-			MethodName: "SendPaymentSync",
-			Handler:    _Lightning_SendPaymentSync_Handler,
-		},
-		{
-			MethodName: "AddInvoice",
-			Handler:    _Lightning_AddInvoice_Handler,
-		},
-		{
-			MethodName: "ListInvoices",
-			Handler:    _Lightning_ListInvoices_Handler,
-		},
-		{
-			MethodName: "LookupInvoice",
-			Handler:    _Lightning_LookupInvoice_Handler,
-		},
-		{
-			MethodName: "ListPayments",
-			Handler:    _Lightning_ListPayments_Handler,
-		},
-		{
-			MethodName: "DeleteAllPayments",
-			Handler:    _Lightning_DeleteAllPayments_Handler,
-		},
-		{
-			MethodName: "DescribeGraph",
-			Handler:    _Lightning_DescribeGraph_Handler,
-		},
-		{
-			MethodName: "GetChanInfo",
-			Handler:    _Lightning_GetChanInfo_Handler,
-		},
-		{
-			MethodName: "GetNodeInfo",
-			Handler:    _Lightning_GetNodeInfo_Handler,
-		},
-		{
-			MethodName: "QueryRoute",
-			Handler:    _Lightning_QueryRoute_Handler,
-		},
-		{
-			MethodName: "GetNetworkInfo",
-			Handler:    _Lightning_GetNetworkInfo_Handler,
-		},
-		{
-			MethodName: "SetAlias",
-			Handler:    _Lightning_SetAlias_Handler,
-		},
-	},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "SubscribeTransactions",
-			Handler:       _Lightning_SubscribeTransactions_Handler,
-			ServerStreams: true,
-		},
-		{
-			StreamName:    "OpenChannel",
-			Handler:       _Lightning_OpenChannel_Handler,
-			ServerStreams: true,
+			MethodName: "ImportGraph",
+			Handler:    _Dev_ImportGraph_Handler,
 		},
 		{
-			StreamName:    "CloseChannel",
-			Handler:       _Lightning_CloseChannel_Handler,
-			ServerStreams: true,
+			MethodName: "QuiesceChannel",
+			Handler:    _Dev_QuiesceChannel_Handler,
 		},
 		{
-			StreamName:    "SendPayment",
-			Handler:       _Lightning_SendPayment_Handler,
-			ServerStreams: true,
-			ClientStreams: true,
+			MethodName: "ForceStateTransition",
+			Handler:    _Dev_ForceStateTransition_Handler,
 		},
 		{
-			StreamName:    "SubscribeInvoices",
-			Handler:       _Lightning_SubscribeInvoices_Handler,
-			ServerStreams: true,
+			MethodName: "SetConnImpairment",
+			Handler:    _Dev_SetConnImpairment_Handler,
 		},
 	},
+	Streams:  []grpc.StreamDesc{},
 	Metadata: "rpc.proto",
 }
 
 func init() { proto.RegisterFile("rpc.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 2981 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xb4, 0x39, 0xcd, 0x6f, 0x1b, 0xc7,
-	0xf5, 0x5e, 0x51, 0xb4, 0xc8, 0xc7, 0xef, 0x91, 0x44, 0xd1, 0xb4, 0x93, 0xc8, 0x13, 0x27, 0x3f,
-	0xfd, 0x04, 0xc7, 0xb2, 0x95, 0x43, 0x83, 0x04, 0x49, 0xa1, 0xc8, 0xaa, 0x65, 0x54, 0x91, 0x95,
-	0x48, 0x8e, 0xdb, 0xa4, 0xc5, 0x66, 0xc5, 0x1d, 0x51, 0x1b, 0x2f, 0x77, 0xb7, 0xbb, 0x43, 0xc9,
-	0xac, 0xa1, 0x4b, 0x0f, 0x05, 0x7a, 0x2e, 0x50, 0x04, 0x28, 0x50, 0xb4, 0xf7, 0xa2, 0xe8, 0xff,
-	0xd1, 0xde, 0x7a, 0x6b, 0xaf, 0x3d, 0xf6, 0x8f, 0x28, 0xe6, 0xcd, 0xcc, 0x72, 0x66, 0x49, 0x07,
-	0x0d, 0x8a, 0xde, 0xc8, 0x37, 0x33, 0xef, 0xfb, 0x7b, 0xa1, 0x9a, 0x26, 0x83, 0x7b, 0x49, 0x1a,
-	0xf3, 0x98, 0x94, 0xc3, 0x28, 0x4d, 0x06, 0xfd, 0x5b, 0xc3, 0x38, 0x1e, 0x86, 0x6c, 0xcb, 0x4b,
-	0x82, 0x2d, 0x2f, 0x8a, 0x62, 0xee, 0xf1, 0x20, 0x8e, 0x32, 0x79, 0x89, 0xfe, 0xd6, 0x81, 0xda,
-	0x49, 0xea, 0x45, 0x99, 0x37, 0x10, 0x60, 0xd2, 0x82, 0x25, 0xfe, 0xc2, 0x3d, 0xf7, 0xb2, 0xf3,
-	0x9e, 0xb3, 0xee, 0x6c, 0x54, 0x49, 0x13, 0xae, 0x7b, 0xa3, 0x78, 0x1c, 0xf1, 0xde, 0xc2, 0xba,
-	0xb3, 0xe1, 0x90, 0x1b, 0xd0, 0x89, 0xc6, 0x23, 0x77, 0x10, 0x47, 0x67, 0x41, 0x3a, 0x92, 0xb8,
-	0x7a, 0xa5, 0x75, 0x67, 0xa3, 0x4c, 0x08, 0xc0, 0x69, 0x18, 0x0f, 0x9e, 0xcb, 0xe7, 0x8b, 0xf8,
-	0x7c, 0x05, 0xea, 0x0a, 0xc6, 0x82, 0xe1, 0x39, 0xef, 0x95, 0xf5, 0x4d, 0x1e, 0x8c, 0x98, 0x9b,
-	0x71, 0x6f, 0x94, 0xf4, 0xae, 0xaf, 0x3b, 0x1b, 0x25, 0x84, 0xc5, 0xdc, 0x0b, 0xdd, 0x33, 0xc6,
-	0xb2, 0xde, 0x92, 0x80, 0xd1, 0x1e, 0x74, 0x1f, 0x31, 0x6e, 0xf0, 0x97, 0x7d, 0xc6, 0x7e, 0x36,
-	0x66, 0x19, 0xa7, 0x1f, 0x01, 0x31, 0xc0, 0x0f, 0x19, 0xf7, 0x82, 0x30, 0x23, 0x1b, 0x50, 0xe7,
-	0xc6, 0xe5, 0x9e, 0xb3, 0x5e, 0xda, 0xa8, 0x6d, 0x93, 0x7b, 0xa8, 0x89, 0x7b, 0xc6, 0x03, 0xfa,
-	0x2b, 0x07, 0x6a, 0xc7, 0x2c, 0xf2, 0x15, 0x3e, 0x52, 0x87, 0x45, 0x9f, 0x65, 0x1c, 0x85, 0xae,
-	0x93, 0x65, 0xa8, 0x89, 0x7f, 0x6e, 0xc6, 0xd3, 0x20, 0x1a, 0xa2, 0xe4, 0x55, 0x52, 0x83, 0x92,
-	0x37, 0xe2, 0x28, 0x6b, 0x49, 0xc8, 0x95, 0x78, 0x93, 0x11, 0x8b, 0xf8, 0x54, 0xda, 0x3a, 0xb9,
-	0x09, 0xcb, 0x26, 0x54, 0xbf, 0x2f, 0xe3, 0xfb, 0x35, 0x68, 0xe9, 0xc3, 0x54, 0x52, 0x45, 0xc9,
-	0xab, 0xb4, 0x09, 0x75, 0xc9, 0x4a, 0x96, 0xc4, 0x51, 0xc6, 0xe8, 0x09, 0xd4, 0x77, 0xcf, 0xbd,
-	0x28, 0x62, 0xe1, 0x51, 0x1c, 0x44, 0x5c, 0xd0, 0x3a, 0x1b, 0x47, 0x7e, 0x10, 0x0d, 0x5d, 0xfe,
-	0x22, 0xf0, 0x15, 0x8f, 0x3d, 0x68, 0x9b, 0x50, 0x41, 0x4b, 0x31, 0xba, 0x02, 0xf5, 0x78, 0xcc,
-	0x93, 0x31, 0x77, 0x83, 0xc8, 0x67, 0x2f, 0x90, 0xe3, 0x06, 0xbd, 0x0f, 0xed, 0x03, 0x61, 0x82,
-	0x28, 0x88, 0x86, 0x3b, 0xbe, 0x9f, 0xb2, 0x2c, 0x13, 0xc6, 0x4d, 0xc6, 0xa7, 0xcf, 0xd9, 0x44,
-	0x19, 0xbb, 0x0e, 0x8b, 0xe7, 0x71, 0x26, 0x4d, 0x5d, 0xa5, 0xbf, 0x74, 0xa0, 0x25, 0x18, 0xfb,
-	0xc4, 0x8b, 0x26, 0x5a, 0x4f, 0x1f, 0x41, 0x5d, 0x3c, 0x3e, 0x89, 0x77, 0xa4, 0x53, 0x48, 0x0d,
-	0x6f, 0x28, 0x0d, 0x17, 0x6e, 0xdf, 0x33, 0xaf, 0xee, 0x45, 0x3c, 0x9d, 0xf4, 0xdf, 0x85, 0xce,
-	0x0c, 0x50, 0x68, 0x76, 0xca, 0x43, 0x03, 0xca, 0x17, 0x5e, 0x38, 0x66, 0xc8, 0x44, 0xe9, 0xfd,
-	0x85, 0xf7, 0x1c, 0xba, 0x0e, 0xed, 0x29, 0x66, 0xa9, 0x24, 0xc1, 0x6a, 0xae, 0x8c, 0xaa, 0x10,
-	0x4e, 0xdc, 0xd8, 0x8d, 0x83, 0xdc, 0x45, 0xc4, 0x0d, 0xcf, 0xf7, 0xd3, 0xb9, 0x7e, 0x5c, 0xa2,
-	0xb7, 0xa1, 0x63, 0xbc, 0x98, 0x8b, 0xf4, 0x1b, 0x07, 0x3a, 0x87, 0xec, 0x52, 0x29, 0x4b, 0xa3,
-	0xdd, 0x86, 0x45, 0x3e, 0x49, 0x18, 0xde, 0x69, 0x6e, 0xdf, 0x51, 0x92, 0xcf, 0xdc, 0xbb, 0xa7,
-	0xfe, 0x9e, 0x4c, 0x12, 0x46, 0x9f, 0x40, 0xcd, 0xf8, 0x4b, 0xd6, 0x60, 0xf9, 0xd9, 0xe3, 0x93,
-	0xc3, 0xbd, 0xe3, 0x63, 0xf7, 0xe8, 0xe9, 0xc7, 0x3f, 0xdc, 0xfb, 0xb1, 0xbb, 0xbf, 0x73, 0xbc,
-	0xdf, 0xbe, 0x46, 0xba, 0x40, 0x0e, 0xf7, 0x8e, 0x4f, 0xf6, 0x1e, 0x5a, 0x70, 0x87, 0xb4, 0xa0,
-	0x66, 0x02, 0x16, 0x68, 0x1f, 0x7a, 0x87, 0xec, 0xf2, 0x59, 0xc0, 0x23, 0x96, 0x65, 0x36, 0x61,
-	0xfa, 0x16, 0x10, 0x93, 0x1b, 0x25, 0x5a, 0x0b, 0x96, 0x3c, 0x09, 0x52, 0xd2, 0x3d, 0x06, 0xb2,
-	0x1b, 0x47, 0x11, 0x1b, 0xf0, 0x23, 0xc6, 0x52, 0x2d, 0xdd, 0x5b, 0x86, 0xd2, 0x6a, 0xdb, 0x6b,
-	0x4a, 0xba, 0x19, 0xc7, 0xa9, 0xc3, 0x62, 0xc2, 0xd2, 0x11, 0xea, 0xb2, 0x42, 0xdf, 0x86, 0x65,
-	0x0b, 0xd5, 0x94, 0x64, 0xc2, 0x58, 0xea, 0x2a, 0x85, 0x96, 0x69, 0x02, 0x8b, 0xfb, 0x27, 0x07,
-	0xbb, 0xa4, 0x0d, 0x95, 0x20, 0x1a, 0xc4, 0x23, 0x11, 0x1b, 0xe2, 0xa4, 0x52, 0xb4, 0x0e, 0xe9,
-	0x40, 0x15, 0x03, 0x48, 0xa4, 0x0e, 0xf4, 0xdf, 0xba, 0x48, 0x3c, 0xec, 0x45, 0x12, 0xa4, 0x98,
-	0x72, 0x74, 0x3a, 0x11, 0x61, 0xd7, 0x10, 0xa1, 0x90, 0xb2, 0x8b, 0x78, 0x20, 0x8f, 0x7c, 0x16,
-	0x7a, 0x13, 0x8c, 0xb9, 0x06, 0xfd, 0xc3, 0x02, 0x34, 0x76, 0x06, 0x3c, 0xb8, 0x60, 0x2a, 0xa2,
-	0xc8, 0x2a, 0x34, 0x52, 0x36, 0x8a, 0x39, 0x73, 0x2d, 0xcf, 0x5f, 0x85, 0xc6, 0x40, 0xde, 0x70,
-	0x13, 0x11, 0x74, 0x2a, 0x94, 0x5a, 0xb0, 0x24, 0xc0, 0x42, 0x04, 0xc1, 0xc5, 0xa2, 0x60, 0x7d,
-	0xe0, 0x25, 0xde, 0x20, 0xe0, 0x13, 0x24, 0x5e, 0x12, 0x2f, 0xc3, 0x78, 0xe0, 0x85, 0xee, 0xa9,
-	0x17, 0x7a, 0xd1, 0x80, 0x21, 0xe5, 0x12, 0xe9, 0x42, 0x53, 0xd1, 0xd1, 0x70, 0x99, 0xe6, 0x6e,
-	0x40, 0x67, 0x1c, 0x65, 0x8c, 0xf3, 0x90, 0xf9, 0xf9, 0x11, 0x66, 0x3b, 0x91, 0x3d, 0x64, 0x06,
-	0xcc, 0x3c, 0x1e, 0x67, 0xe7, 0x41, 0xe6, 0x66, 0x2c, 0xe2, 0xbd, 0x0a, 0x1e, 0xbe, 0x01, 0x6b,
-	0x85, 0xc3, 0x94, 0x0d, 0x58, 0x70, 0xc1, 0xfc, 0x5e, 0x15, 0x2f, 0x2c, 0x43, 0x4d, 0x24, 0xe6,
-	0x71, 0xe2, 0x7b, 0x9c, 0x65, 0x3d, 0x40, 0x76, 0x29, 0x34, 0x12, 0x26, 0x93, 0xc4, 0x39, 0x0f,
-	0x07, 0x59, 0xaf, 0x86, 0xf1, 0x5a, 0x53, 0x76, 0x15, 0xd6, 0xa0, 0xab, 0xb0, 0x7c, 0x10, 0x64,
-	0x5c, 0x29, 0xc8, 0xc8, 0xb0, 0x2b, 0x36, 0x58, 0x59, 0xf5, 0x6d, 0xa8, 0x28, 0x4d, 0x69, 0x6c,
-	0x2b, 0x0a, 0x9b, 0xa5, 0x68, 0xfa, 0x1b, 0x07, 0x16, 0x85, 0x3b, 0xa0, 0x1b, 0x8c, 0x4f, 0xdd,
-	0xa9, 0xae, 0x0d, 0xbf, 0x58, 0xc0, 0x72, 0x60, 0xf8, 0x66, 0x09, 0x6f, 0x88, 0x4a, 0x32, 0xe1,
-	0x4c, 0x29, 0x60, 0x11, 0x45, 0xc9, 0x61, 0x29, 0x1b, 0x5c, 0xa0, 0x92, 0xd1, 0x1a, 0x99, 0xc7,
-	0xe5, 0x2d, 0xa9, 0x5e, 0x05, 0xc1, 0x3b, 0x52, 0xab, 0x2d, 0x58, 0x0a, 0xa2, 0xd3, 0x78, 0x1c,
-	0xf9, 0xa8, 0xc9, 0x0a, 0x25, 0x22, 0x11, 0x66, 0xe8, 0xaa, 0xb9, 0xb0, 0x5b, 0xd0, 0x31, 0x60,
-	0x4a, 0xd2, 0x3e, 0x94, 0x05, 0x9f, 0xba, 0x8c, 0x68, 0xa5, 0x89, 0x4b, 0xb4, 0x0d, 0xcd, 0x47,
-	0x8c, 0x3f, 0x8e, 0xce, 0x62, 0x8d, 0xe2, 0x1f, 0x0e, 0xb4, 0x72, 0x90, 0xc2, 0xb0, 0x06, 0xad,
-	0xc0, 0x67, 0x11, 0x0f, 0xf8, 0xc4, 0x76, 0xb7, 0x06, 0x94, 0xbd, 0x30, 0xf0, 0x32, 0xe5, 0x66,
-	0xb7, 0x60, 0x45, 0xd8, 0x4e, 0x9b, 0x2a, 0xd7, 0x2f, 0x66, 0x6e, 0xe1, 0x17, 0xe2, 0xd4, 0x43,
-	0xf5, 0x4e, 0x0f, 0xa5, 0xef, 0x77, 0xa0, 0x2a, 0x9f, 0x0a, 0x46, 0xd1, 0xe9, 0x67, 0x6a, 0xee,
-	0x75, 0x84, 0xda, 0xd5, 0xb9, 0xa2, 0x4b, 0x52, 0x36, 0x89, 0x06, 0xcc, 0x77, 0x79, 0x2c, 0x10,
-	0x07, 0x11, 0x3a, 0x53, 0x05, 0xdb, 0x00, 0x96, 0xf1, 0x88, 0x71, 0x74, 0xa4, 0x0a, 0x7d, 0x8a,
-	0xd9, 0x22, 0x2f, 0xf9, 0x4f, 0xd1, 0xcb, 0x04, 0x71, 0x89, 0x33, 0x3b, 0xf7, 0x54, 0x59, 0x2a,
-	0x12, 0x97, 0x16, 0xee, 0x42, 0x53, 0x77, 0x0d, 0x99, 0x1b, 0xb2, 0x33, 0xae, 0x8a, 0xd2, 0xf7,
-	0xa1, 0xa3, 0xfc, 0xe5, 0x49, 0xc2, 0x34, 0xd6, 0xcd, 0x62, 0x2c, 0xca, 0x64, 0xb4, 0xac, 0xf4,
-	0x6f, 0xd6, 0x46, 0xfa, 0x01, 0x10, 0xf5, 0x7f, 0x37, 0x8c, 0x33, 0xa6, 0x30, 0xac, 0x40, 0x7d,
-	0x10, 0xc6, 0x59, 0xa1, 0x62, 0xb6, 0x60, 0x29, 0x1b, 0x0f, 0x06, 0xc2, 0xcd, 0x64, 0xde, 0xf2,
-	0x61, 0x19, 0x5f, 0x29, 0x0c, 0x3a, 0x07, 0x7e, 0x07, 0xfa, 0x79, 0x27, 0x13, 0x06, 0xa3, 0x40,
-	0x27, 0xaf, 0x06, 0x94, 0xcf, 0xe2, 0x74, 0xc0, 0x50, 0xc6, 0x0a, 0xfd, 0xb3, 0x03, 0x1d, 0x24,
-	0x73, 0xcc, 0x3d, 0x3e, 0xce, 0x14, 0x8b, 0xef, 0x40, 0x43, 0xb0, 0xc8, 0xb4, 0xd1, 0x15, 0x91,
-	0x95, 0xdc, 0xc9, 0x10, 0x2a, 0x2f, 0xef, 0x5f, 0x23, 0x0f, 0xa0, 0x6e, 0xb6, 0x5c, 0x48, 0xa9,
-	0xb6, 0x7d, 0x43, 0xb3, 0x34, 0x63, 0x9a, 0xfd, 0x6b, 0x64, 0x0b, 0x00, 0x73, 0x17, 0x92, 0x41,
-	0x5e, 0x8c, 0x07, 0x33, 0x3a, 0xdb, 0xbf, 0xf6, 0x71, 0x05, 0xae, 0xcb, 0xec, 0x41, 0x5f, 0x83,
-	0x86, 0xc5, 0x80, 0x55, 0x18, 0xeb, 0xf4, 0xf7, 0x0e, 0x10, 0x61, 0xaf, 0x82, 0xde, 0xba, 0xd0,
-	0xe4, 0x5e, 0x3a, 0x64, 0xdc, 0xb5, 0xd2, 0x3e, 0x66, 0xa6, 0xd8, 0xcf, 0x13, 0xee, 0x02, 0x1a,
-	0xa3, 0x0f, 0xc4, 0x00, 0xea, 0x4e, 0xa9, 0xa4, 0xc3, 0x41, 0xa6, 0x54, 0xdd, 0xe0, 0xa8, 0xda,
-	0xb0, 0xa8, 0x43, 0x3c, 0x19, 0x8b, 0xe6, 0xca, 0xe3, 0x2a, 0xd7, 0xaa, 0x18, 0x40, 0xef, 0x92,
-	0xde, 0x4e, 0xff, 0xe8, 0x40, 0x5b, 0xb0, 0x68, 0xe9, 0xfc, 0x2e, 0xd4, 0x51, 0x23, 0xff, 0x33,
-	0x95, 0xbf, 0x03, 0x55, 0x24, 0x10, 0x27, 0x2c, 0x52, 0x1a, 0xef, 0xd9, 0x1a, 0x9f, 0xba, 0xb9,
-	0xa5, 0xf0, 0x0f, 0x61, 0x55, 0x91, 0x2f, 0xe8, 0xf4, 0x0e, 0x5c, 0xcf, 0x50, 0x04, 0xd5, 0x6f,
-	0xac, 0xd8, 0xe8, 0xa4, 0x78, 0xf4, 0x4f, 0x0b, 0xd0, 0x2d, 0xbe, 0x57, 0x29, 0xe8, 0x07, 0xd0,
-	0x9e, 0x49, 0x2b, 0x32, 0x9f, 0xdd, 0xb5, 0xe5, 0x2e, 0x3c, 0x2c, 0x80, 0xfb, 0x7f, 0x71, 0xa0,
-	0x69, 0x83, 0x66, 0xea, 0xbb, 0x08, 0xbb, 0x3c, 0xdd, 0x69, 0x4b, 0xcf, 0x29, 0xad, 0xd2, 0xc8,
-	0xff, 0x75, 0x25, 0x2d, 0x06, 0xf9, 0x12, 0xa2, 0x9d, 0x2a, 0xac, 0xf2, 0x2d, 0x0a, 0xbb, 0x0b,
-	0x2b, 0xcf, 0xbc, 0x30, 0x64, 0xfc, 0x63, 0x89, 0x52, 0xab, 0x7b, 0x05, 0xea, 0x97, 0xb2, 0xa9,
-	0x72, 0xe3, 0x28, 0x94, 0xd9, 0xba, 0x42, 0x37, 0x60, 0xb5, 0x70, 0x7b, 0xda, 0xe1, 0x68, 0x9e,
-	0xc4, 0x4d, 0x87, 0xae, 0xc1, 0xaa, 0x22, 0x64, 0x23, 0xa6, 0xff, 0x0f, 0xdd, 0xe2, 0xc1, 0x7c,
-	0x1c, 0x25, 0x7a, 0x17, 0xea, 0x9f, 0xc5, 0x63, 0x9e, 0xf3, 0x34, 0x53, 0x3f, 0xd5, 0x20, 0x22,
-	0xfb, 0xd8, 0xcf, 0xa0, 0xb4, 0x1f, 0x27, 0x66, 0xa3, 0xe2, 0x60, 0x69, 0x54, 0x5a, 0x77, 0x73,
-	0x1d, 0x2f, 0x68, 0x65, 0x7a, 0x23, 0x2e, 0xd2, 0xfd, 0x59, 0x9c, 0x5e, 0x7a, 0xa9, 0xaf, 0xe6,
-	0x99, 0x1a, 0x94, 0xce, 0x18, 0x93, 0x86, 0xa0, 0x1e, 0x94, 0x91, 0x03, 0x51, 0x1f, 0x64, 0xd3,
-	0x21, 0x73, 0x9c, 0x68, 0xc6, 0x1c, 0x5d, 0x4c, 0x8c, 0x61, 0x2d, 0xef, 0xd9, 0x24, 0x6c, 0x3a,
-	0x25, 0xf5, 0xc4, 0x3c, 0x91, 0x88, 0x52, 0x25, 0x1c, 0x0e, 0x74, 0xd7, 0x11, 0x27, 0x94, 0x42,
-	0xeb, 0x30, 0xf6, 0x99, 0x51, 0x40, 0x67, 0xe4, 0xa4, 0x3f, 0x81, 0x8a, 0xbe, 0x43, 0x28, 0x2c,
-	0x8a, 0x74, 0x51, 0x08, 0xd9, 0xbc, 0x2f, 0x15, 0xf7, 0x84, 0xf1, 0x30, 0x0d, 0x68, 0x37, 0x5f,
-	0x40, 0x56, 0x45, 0x56, 0x42, 0xb6, 0x72, 0x4d, 0x20, 0x6f, 0xf4, 0x29, 0x34, 0xec, 0xe7, 0xcb,
-	0x50, 0x0b, 0xbd, 0x8c, 0xab, 0x0e, 0x4a, 0x09, 0x6a, 0x30, 0x95, 0x77, 0x84, 0x76, 0xaf, 0x92,
-	0x97, 0x72, 0x1c, 0x78, 0x69, 0x04, 0x0d, 0xa1, 0xbb, 0x20, 0x1a, 0x1e, 0xc5, 0x61, 0x30, 0x98,
-	0xa0, 0x0e, 0xb5, 0xf6, 0x44, 0x6f, 0xca, 0x3d, 0x85, 0xba, 0x0d, 0x95, 0x51, 0x10, 0x61, 0x5f,
-	0xa6, 0x34, 0xb8, 0x0a, 0x8d, 0x33, 0x26, 0xdc, 0x3c, 0x63, 0xee, 0x48, 0xa4, 0xb7, 0x92, 0xee,
-	0x0b, 0x05, 0x38, 0xf5, 0x38, 0x73, 0x47, 0x41, 0x18, 0x06, 0xf2, 0x50, 0xda, 0xea, 0xef, 0x0e,
-	0xd4, 0x94, 0x67, 0xed, 0xf9, 0x43, 0x26, 0x2c, 0xa3, 0xa3, 0x2d, 0xf7, 0x05, 0x05, 0xb3, 0x3a,
-	0xdb, 0x82, 0xb4, 0xa5, 0xbc, 0x99, 0x88, 0x7d, 0xf6, 0x40, 0x64, 0x65, 0x35, 0xc0, 0x2b, 0xd0,
-	0x36, 0x82, 0xca, 0x33, 0x91, 0x2b, 0x43, 0x71, 0x13, 0xea, 0xea, 0x1d, 0xca, 0x8c, 0xa1, 0x38,
-	0xb5, 0x92, 0xad, 0x0f, 0x75, 0x77, 0x5b, 0xdf, 0xad, 0xbc, 0xfa, 0xae, 0x68, 0x4d, 0x95, 0x6c,
-	0x8f, 0x52, 0x2f, 0x39, 0xd7, 0xc1, 0xf4, 0x79, 0x3e, 0x20, 0x23, 0x98, 0xbc, 0x09, 0x65, 0x81,
-	0x52, 0x27, 0xb6, 0xf9, 0xde, 0x71, 0x1b, 0xca, 0xcc, 0x1f, 0xa2, 0xb7, 0x9a, 0x4b, 0x01, 0x43,
-	0x77, 0xc2, 0x29, 0xc5, 0xdf, 0x82, 0x53, 0x5a, 0x71, 0x45, 0x57, 0xc4, 0x74, 0xc5, 0x2f, 0xe3,
-	0xf4, 0xb9, 0xd9, 0xfc, 0xfd, 0xcb, 0x81, 0x9a, 0x01, 0x16, 0x4e, 0x37, 0x14, 0xac, 0xb9, 0x7e,
-	0xe0, 0x8d, 0x18, 0x67, 0xa9, 0xb2, 0xb9, 0x08, 0xbf, 0x8b, 0xa1, 0x1b, 0x8f, 0xb9, 0xeb, 0xb3,
-	0x61, 0xca, 0x98, 0xda, 0xaa, 0x74, 0xa1, 0x39, 0xf2, 0x5e, 0x98, 0xf0, 0x92, 0xd9, 0xdd, 0x49,
-	0xe9, 0x16, 0x75, 0x77, 0x67, 0x79, 0xb9, 0xec, 0xf9, 0x5e, 0x87, 0xae, 0xf4, 0xf2, 0x48, 0x72,
-	0xe1, 0x16, 0x2c, 0xd4, 0x83, 0xb6, 0x20, 0xac, 0x5d, 0x23, 0x0b, 0x7e, 0x2e, 0xa7, 0x0e, 0x47,
-	0x9c, 0x08, 0x37, 0xb4, 0x4e, 0x2a, 0xfa, 0x8d, 0x60, 0xca, 0x3a, 0xc1, 0x59, 0x83, 0xde, 0x81,
-	0xd6, 0x31, 0xe3, 0x3b, 0xc2, 0xed, 0xb5, 0xa2, 0x04, 0xa7, 0xec, 0xd2, 0x95, 0xa1, 0x20, 0xe3,
-	0x97, 0x88, 0xa1, 0x5c, 0xdf, 0x52, 0xbb, 0x8d, 0x6f, 0x1c, 0x58, 0x7a, 0x1c, 0x5d, 0xc4, 0xc1,
-	0x00, 0x9b, 0x8a, 0x11, 0x1b, 0xc5, 0xd3, 0xa9, 0x00, 0x27, 0x9a, 0x84, 0xab, 0x0e, 0x81, 0x00,
-	0xa4, 0x6e, 0x92, 0xb2, 0x60, 0xe4, 0x0d, 0x99, 0x1a, 0x02, 0x9b, 0x70, 0x3d, 0x35, 0x17, 0x2e,
-	0xf9, 0xb2, 0xa0, 0xac, 0x7b, 0x7d, 0x35, 0x5a, 0xa1, 0xd8, 0x15, 0xcc, 0x82, 0x29, 0x53, 0x73,
-	0xa1, 0xf0, 0xf3, 0x25, 0x3d, 0x2b, 0xc9, 0x7b, 0x12, 0x88, 0xe2, 0xd2, 0x0f, 0x81, 0xec, 0xf8,
-	0xbe, 0x62, 0x2e, 0x4f, 0xcf, 0x53, 0x8a, 0xb2, 0x89, 0x9c, 0xb3, 0xc5, 0x91, 0xdb, 0x92, 0x07,
-	0x50, 0x3b, 0x92, 0x07, 0xfb, 0x5e, 0x76, 0x2e, 0xb9, 0xd7, 0x4b, 0xa0, 0xe9, 0x0e, 0x42, 0xe1,
-	0x42, 0x09, 0xe9, 0x26, 0x10, 0x31, 0x75, 0xe4, 0x24, 0xf3, 0x1a, 0xa4, 0x2b, 0xb6, 0x51, 0x83,
-	0xbe, 0x27, 0xa7, 0xb4, 0x22, 0x7b, 0xeb, 0x62, 0x94, 0x46, 0x90, 0xf6, 0xfe, 0xa6, 0x72, 0x6c,
-	0x75, 0x53, 0xc4, 0x90, 0xfa, 0x79, 0x3c, 0x3e, 0xcd, 0x06, 0x69, 0x90, 0xe0, 0x02, 0xec, 0x2b,
-	0x58, 0x52, 0xec, 0xce, 0xec, 0xb2, 0xe6, 0xed, 0x61, 0x66, 0x35, 0x29, 0x73, 0x53, 0x1d, 0x16,
-	0x13, 0x8f, 0x9f, 0x63, 0x86, 0xaf, 0xea, 0x2a, 0x82, 0xc6, 0xd0, 0x73, 0xa5, 0xa2, 0x92, 0x8f,
-	0x5a, 0xef, 0xc9, 0xb9, 0x72, 0x0a, 0x9e, 0x4a, 0xa2, 0xb8, 0x28, 0x4a, 0xa2, 0xae, 0xd2, 0x3e,
-	0xf4, 0x1e, 0xb2, 0x90, 0x71, 0xb6, 0x13, 0x86, 0x45, 0xac, 0x37, 0xe1, 0xc6, 0x9c, 0x33, 0x89,
-	0x7a, 0x73, 0x1b, 0x1a, 0x56, 0xf9, 0x27, 0x4b, 0x50, 0xda, 0x39, 0x38, 0x68, 0x5f, 0x23, 0x35,
-	0x58, 0x7a, 0x72, 0xb4, 0x77, 0xf8, 0xf8, 0xf0, 0x51, 0xdb, 0x11, 0x7f, 0x76, 0x0f, 0x9e, 0x1c,
-	0x8b, 0x3f, 0x0b, 0xdb, 0x7f, 0x5d, 0x85, 0x6a, 0x9e, 0x40, 0xc8, 0xd7, 0xd0, 0xb0, 0x3a, 0x00,
-	0x72, 0x53, 0xf1, 0x36, 0xaf, 0x8b, 0xe8, 0xdf, 0x9a, 0x7f, 0xa8, 0x42, 0xe0, 0xf5, 0x5f, 0xfc,
-	0xed, 0x9f, 0xbf, 0x5e, 0xe8, 0x91, 0xee, 0xd6, 0xc5, 0x83, 0x2d, 0x55, 0xfa, 0xb7, 0x70, 0x66,
-	0xc2, 0x09, 0x8c, 0x3c, 0x87, 0xa6, 0xdd, 0x2a, 0x90, 0x5b, 0x76, 0xae, 0x2a, 0x50, 0x7b, 0xed,
-	0x15, 0xa7, 0x8a, 0xdc, 0x2d, 0x24, 0xd7, 0x25, 0x2b, 0x26, 0x39, 0x9d, 0x3d, 0x08, 0xc3, 0xa1,
-	0xd5, 0xdc, 0xb0, 0x12, 0x8d, 0x6f, 0xfe, 0xe6, 0xb5, 0x7f, 0x63, 0x76, 0x9b, 0xaa, 0xd6, 0xaf,
-	0xb4, 0x87, 0xa4, 0x08, 0x69, 0x0b, 0x52, 0xe6, 0x22, 0x96, 0x7c, 0x09, 0xd5, 0x7c, 0xdb, 0x46,
-	0xd6, 0x8c, 0x6d, 0xa1, 0xb9, 0xb1, 0xeb, 0xf7, 0x66, 0x0f, 0x94, 0x10, 0x37, 0x11, 0xf3, 0x2a,
-	0x9d, 0xc1, 0xfc, 0xbe, 0xb3, 0x49, 0x0e, 0x60, 0x55, 0xb9, 0xf6, 0x29, 0xfb, 0x2e, 0x92, 0xcc,
-	0xd9, 0x0b, 0xdf, 0x77, 0xc8, 0x07, 0x50, 0xd1, 0xcb, 0x46, 0xd2, 0x9d, 0xbf, 0xd7, 0xec, 0xaf,
-	0xcd, 0xc0, 0x95, 0x13, 0xef, 0x00, 0x4c, 0x77, 0x6f, 0xa4, 0xf7, 0xaa, 0xe5, 0x60, 0xae, 0xc4,
-	0x39, 0x8b, 0xba, 0x21, 0x2e, 0x1d, 0xed, 0xd5, 0x1e, 0x79, 0x63, 0x7a, 0x7f, 0xee, 0xd2, 0xef,
-	0x5b, 0x10, 0xd2, 0x2e, 0xea, 0xae, 0x4d, 0x9a, 0x42, 0x77, 0x11, 0xbb, 0x54, 0xed, 0x0b, 0xf9,
-	0x02, 0x6a, 0xc6, 0xd6, 0x8e, 0x18, 0x83, 0x4d, 0x61, 0x29, 0xd8, 0xef, 0xcf, 0x3b, 0x52, 0xd8,
-	0x57, 0x10, 0x7b, 0x93, 0x56, 0x05, 0x76, 0xdc, 0x42, 0x08, 0x93, 0x7c, 0x2a, 0x82, 0x47, 0xed,
-	0x53, 0xc8, 0x74, 0x8b, 0x68, 0x6f, 0x5d, 0x72, 0x7b, 0xcf, 0xac, 0x5e, 0x68, 0x07, 0xb1, 0xd6,
-	0xc8, 0x14, 0x2b, 0xf9, 0x04, 0x96, 0xd4, 0x7a, 0x85, 0xac, 0x4e, 0xed, 0x6a, 0x14, 0xe1, 0x7e,
-	0xb7, 0x08, 0x56, 0xc8, 0x96, 0x11, 0x59, 0x83, 0xd4, 0x04, 0xb2, 0x21, 0xe3, 0x81, 0xc0, 0x11,
-	0x42, 0xcb, 0x1e, 0x67, 0xb2, 0x3c, 0xcc, 0xe6, 0x4e, 0x62, 0x79, 0x98, 0xcd, 0x1f, 0x97, 0xec,
-	0x30, 0xd3, 0xe1, 0xb5, 0xa5, 0x12, 0x39, 0xf9, 0x29, 0xd4, 0xcd, 0x65, 0x1a, 0xe9, 0x1b, 0x92,
-	0x17, 0x16, 0x6f, 0xfd, 0x9b, 0x73, 0xcf, 0x6c, 0x75, 0x93, 0xba, 0x49, 0x86, 0x7c, 0x01, 0x2d,
-	0x63, 0x1e, 0x3f, 0x9e, 0x44, 0x83, 0xdc, 0x9c, 0xb3, 0x73, 0x7a, 0x7f, 0xee, 0x22, 0x65, 0x0d,
-	0x11, 0x77, 0xa8, 0x85, 0x58, 0x98, 0x72, 0x17, 0x6a, 0x06, 0x8e, 0x6f, 0xc3, 0xbb, 0x66, 0x1c,
-	0x99, 0x73, 0xf7, 0x7d, 0x87, 0xfc, 0xce, 0x81, 0xba, 0xb9, 0x6a, 0xc9, 0x15, 0x30, 0x67, 0xff,
-	0x92, 0xbb, 0xc5, 0xcc, 0xd2, 0x84, 0x7e, 0x8e, 0x4c, 0x1e, 0x6d, 0x1e, 0x5a, 0x4a, 0x7e, 0x69,
-	0x8d, 0x97, 0xf7, 0xcc, 0xaf, 0x22, 0x57, 0xc5, 0x43, 0xf3, 0xc3, 0xc8, 0xd5, 0xd6, 0x4b, 0xdc,
-	0xd3, 0x5c, 0xdd, 0x77, 0xc8, 0xfb, 0xf2, 0x73, 0x90, 0x2e, 0x89, 0xc4, 0x08, 0xf0, 0xa2, 0xda,
-	0xcc, 0x6f, 0x35, 0x1b, 0xce, 0x7d, 0x87, 0x7c, 0x25, 0x3f, 0x93, 0xa8, 0xb7, 0xa8, 0xfd, 0xff,
-	0xf4, 0x3d, 0xbd, 0x83, 0x12, 0xbd, 0x4e, 0x6f, 0x58, 0x12, 0x15, 0x33, 0xdc, 0x11, 0xc0, 0xb4,
-	0x35, 0x21, 0x85, 0x0a, 0x9f, 0xc7, 0xfe, 0x6c, 0xf7, 0x62, 0x5b, 0x55, 0x37, 0x0a, 0x02, 0xe3,
-	0xd7, 0xd2, 0x21, 0xd5, 0xfd, 0x2c, 0x37, 0xeb, 0x6c, 0x3f, 0xd2, 0xef, 0xcf, 0x3b, 0x52, 0xf8,
-	0xdf, 0x44, 0xfc, 0xaf, 0x91, 0x9b, 0x26, 0xfe, 0xad, 0x97, 0x66, 0xff, 0x72, 0x45, 0x3e, 0x87,
-	0xc6, 0x41, 0x1c, 0x3f, 0x1f, 0x27, 0x5a, 0x00, 0x62, 0x17, 0x76, 0xd1, 0x2f, 0xf5, 0x8b, 0x6d,
-	0xcb, 0x6d, 0xc4, 0x7c, 0x93, 0xdc, 0xb0, 0x31, 0x4f, 0x7b, 0xaa, 0x2b, 0xe2, 0x41, 0x27, 0xcf,
-	0xfb, 0xb9, 0x20, 0x7d, 0x1b, 0x8f, 0xd9, 0xf3, 0xcc, 0xd0, 0xb0, 0x2a, 0x71, 0x4e, 0x23, 0xd3,
-	0x38, 0xef, 0x3b, 0x3a, 0x6e, 0x75, 0x47, 0x61, 0xc5, 0x6d, 0xa1, 0x05, 0xb1, 0xe2, 0xb6, 0xd8,
-	0x82, 0xd8, 0x71, 0xab, 0xfb, 0x1c, 0x12, 0x42, 0x67, 0xa6, 0x6b, 0xc9, 0x73, 0xfd, 0xab, 0x7a,
-	0x9d, 0xfe, 0xfa, 0xab, 0x2f, 0xd8, 0xd4, 0x36, 0x6d, 0x6a, 0xc7, 0xd0, 0x78, 0xc8, 0xa4, 0x68,
-	0x72, 0x6e, 0xea, 0xdb, 0x89, 0xc0, 0x9c, 0xb1, 0x8a, 0x49, 0x02, 0xcf, 0xec, 0xb4, 0x8c, 0x03,
-	0x0e, 0xf9, 0x12, 0x6a, 0x8f, 0x18, 0xd7, 0x63, 0x53, 0x5e, 0x31, 0x0b, 0x73, 0x54, 0x7f, 0xde,
-	0xb8, 0xb5, 0x8e, 0xd8, 0xfa, 0xa4, 0x97, 0x63, 0xdb, 0x12, 0x13, 0x9a, 0x0c, 0x59, 0x37, 0xf0,
-	0xaf, 0xc8, 0x8f, 0x10, 0x79, 0xbe, 0x04, 0xd0, 0xc8, 0x0b, 0x9b, 0x83, 0x7e, 0xab, 0x00, 0x9f,
-	0x87, 0x59, 0x8c, 0x50, 0x5b, 0x2f, 0xd5, 0x2c, 0x2f, 0x30, 0xc3, 0xa7, 0x63, 0x96, 0x4e, 0xe4,
-	0x9e, 0x63, 0xd9, 0x98, 0x3e, 0x73, 0xbf, 0xaf, 0x9b, 0x40, 0xfa, 0x7f, 0x88, 0xf2, 0x36, 0x79,
-	0x63, 0x8a, 0x32, 0x15, 0x07, 0x53, 0x9c, 0x5b, 0x2f, 0xbd, 0x11, 0xbf, 0x22, 0xcf, 0xf0, 0xcb,
-	0x80, 0x39, 0x0c, 0x4e, 0x6b, 0x73, 0x71, 0x6e, 0xcc, 0xd5, 0x62, 0x1c, 0xd9, 0xf5, 0x5a, 0x52,
-	0xc2, 0x8a, 0x85, 0x8d, 0x89, 0x1c, 0xa7, 0x8c, 0xc6, 0xc4, 0x9a, 0xc2, 0x8c, 0xc6, 0xc4, 0x9e,
-	0xbb, 0x4e, 0xaf, 0xe3, 0xe7, 0xfe, 0x77, 0xff, 0x1d, 0x00, 0x00, 0xff, 0xff, 0x3e, 0x18, 0x5b,
-	0xa2, 0x20, 0x20, 0x00, 0x00,
+	// 9737 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xb4, 0xbd, 0x4d, 0x6c, 0x1c, 0x49,
+	0x96, 0x18, 0xac, 0xac, 0xe2, 0x4f, 0xd5, 0xab, 0x5f, 0x66, 0xf1, 0xa7, 0x98, 0xa4, 0xfe, 0x52,
+	0xdd, 0x2d, 0x89, 0x33, 0x2d, 0xa9, 0xd5, 0x3b, 0xdf, 0xec, 0xce, 0x8f, 0x76, 0x4a, 0x64, 0x49,
+	0xe2, 0x88, 0x22, 0x39, 0x2c, 0x4a, 0xdd, 0x9a, 0x9d, 0x45, 0x4e, 0xb2, 0x2a, 0x58, 0xcc, 0x51,
+	0x55, 0x66, 0x4d, 0x66, 0x16, 0x29, 0x6e, 0x7f, 0x7d, 0xf1, 0x5e, 0x8c, 0x35, 0x8c, 0x85, 0xb1,
+	0x36, 0xe0, 0x05, 0x8c, 0x85, 0x01, 0xef, 0xc5, 0x0b, 0x1b, 0x30, 0x7c, 0xf1, 0xcd, 0x80, 0x01,
+	0xdf, 0x6c, 0xc3, 0x80, 0x7d, 0xf2, 0x9e, 0xed, 0x83, 0x61, 0xc0, 0x27, 0xc3, 0x57, 0x1b, 0x2f,
+	0xfe, 0x32, 0x22, 0x33, 0x8b, 0xad, 0x76, 0xdb, 0x97, 0x16, 0x2b, 0x5e, 0xe4, 0x8b, 0x88, 0x17,
+	0x2f, 0x5e, 0xbc, 0xdf, 0x68, 0x28, 0x87, 0x93, 0xfe, 0x83, 0x49, 0x18, 0xc4, 0x81, 0x39, 0x3f,
+	0xf2, 0xc3, 0x49, 0xdf, 0xda, 0x1c, 0x06, 0xc1, 0x70, 0x44, 0x1e, 0xba, 0x13, 0xef, 0xa1, 0xeb,
+	0xfb, 0x41, 0xec, 0xc6, 0x5e, 0xe0, 0x47, 0xac, 0x93, 0xfd, 0x57, 0x06, 0x54, 0x8e, 0x43, 0xd7,
+	0x8f, 0xdc, 0x3e, 0x36, 0x9b, 0x0d, 0x58, 0x8c, 0xdf, 0x3b, 0x67, 0x6e, 0x74, 0xd6, 0x36, 0x6e,
+	0x19, 0xf7, 0xca, 0x66, 0x1d, 0x16, 0xdc, 0x71, 0x30, 0xf5, 0xe3, 0x76, 0xe1, 0x96, 0x71, 0xcf,
+	0x30, 0xd7, 0x61, 0xc9, 0x9f, 0x8e, 0x9d, 0x7e, 0xe0, 0x9f, 0x7a, 0xe1, 0x98, 0xe1, 0x6a, 0x17,
+	0x6f, 0x19, 0xf7, 0xe6, 0x4d, 0x13, 0xe0, 0x64, 0x14, 0xf4, 0xdf, 0xb1, 0xcf, 0xe7, 0xe8, 0xe7,
+	0xcb, 0x50, 0xe5, 0x6d, 0xc4, 0x1b, 0x9e, 0xc5, 0xed, 0x79, 0xd1, 0x33, 0xf6, 0xc6, 0xc4, 0x89,
+	0x62, 0x77, 0x3c, 0x69, 0x2f, 0xdc, 0x32, 0xee, 0x15, 0x69, 0x5b, 0x10, 0xbb, 0x23, 0xe7, 0x94,
+	0x90, 0xa8, 0xbd, 0x48, 0xdb, 0x6a, 0x30, 0x3f, 0x72, 0x4f, 0xc8, 0xa8, 0x5d, 0x42, 0x64, 0x76,
+	0x08, 0xab, 0xcf, 0x49, 0xac, 0x4c, 0x37, 0x3a, 0x22, 0xbf, 0x9d, 0x92, 0x28, 0xc6, 0x61, 0xa2,
+	0xd8, 0x0d, 0x63, 0x31, 0x8c, 0x21, 0x86, 0x21, 0xfe, 0x40, 0xb4, 0x15, 0x68, 0xdb, 0x32, 0x54,
+	0x3d, 0x7f, 0x40, 0xde, 0x3b, 0xc1, 0xe9, 0x69, 0x44, 0x62, 0x3a, 0xf5, 0x9a, 0xd9, 0x86, 0xe6,
+	0xd8, 0x7d, 0xef, 0xc4, 0x0a, 0x6a, 0xba, 0x80, 0x9a, 0xfd, 0x16, 0x4c, 0x65, 0xc0, 0x1d, 0x12,
+	0xbb, 0xde, 0x28, 0x32, 0xef, 0x41, 0x55, 0xeb, 0x6b, 0xdc, 0x2a, 0xde, 0xab, 0x3c, 0x36, 0x1f,
+	0x50, 0x92, 0x3f, 0x50, 0x09, 0xba, 0x0e, 0x4b, 0x23, 0x37, 0x8a, 0x1d, 0x6d, 0xd0, 0x02, 0x45,
+	0xfd, 0xa7, 0x05, 0xa8, 0xf4, 0x88, 0x3f, 0x10, 0x8b, 0xa8, 0xc2, 0xdc, 0x80, 0x44, 0x6c, 0xf2,
+	0x55, 0xb3, 0x05, 0x15, 0xfc, 0xe5, 0x44, 0x71, 0xe8, 0xf9, 0x43, 0xfa, 0x49, 0xd9, 0xac, 0x40,
+	0xd1, 0x1d, 0xb3, 0x49, 0x17, 0x71, 0x29, 0x13, 0xf7, 0x72, 0x4c, 0xfc, 0x38, 0xa1, 0x78, 0xd5,
+	0xdc, 0x80, 0x96, 0xda, 0x2a, 0xbe, 0x9f, 0xa7, 0xdf, 0xaf, 0x41, 0x43, 0x00, 0x43, 0x36, 0x2a,
+	0xa5, 0x7e, 0xd9, 0x5c, 0x82, 0xf2, 0x29, 0x21, 0xce, 0xc8, 0x1b, 0x7b, 0x31, 0x27, 0xfe, 0x1a,
+	0x34, 0x70, 0x93, 0x82, 0x69, 0xec, 0x44, 0xa4, 0x1f, 0xf8, 0x83, 0xa8, 0x5d, 0x12, 0x64, 0xed,
+	0x8f, 0xe2, 0x73, 0xde, 0xb9, 0x4c, 0x09, 0xb8, 0x04, 0x65, 0x24, 0xe0, 0xc4, 0x0d, 0xe3, 0xa8,
+	0x0d, 0xb4, 0x69, 0x1d, 0x96, 0x24, 0x4a, 0x67, 0x42, 0xc2, 0x3e, 0xf1, 0xe3, 0x76, 0x85, 0xa2,
+	0x5e, 0x85, 0x7a, 0x02, 0x1a, 0x47, 0x6e, 0xdc, 0xae, 0x62, 0xbb, 0xfd, 0x4b, 0xa8, 0x32, 0x82,
+	0x44, 0x93, 0xc0, 0x8f, 0x88, 0xb9, 0x02, 0x35, 0x31, 0x5d, 0x12, 0x86, 0x41, 0xc8, 0x79, 0xf2,
+	0x73, 0xa8, 0x9f, 0xba, 0xde, 0x68, 0x1a, 0x12, 0x27, 0x24, 0x6e, 0x14, 0xf8, 0x94, 0x3a, 0xf5,
+	0xc7, 0x1b, 0x9c, 0xfe, 0x87, 0xec, 0x9b, 0x67, 0xac, 0xcf, 0x11, 0xed, 0x62, 0x1f, 0x43, 0x75,
+	0xfb, 0xcc, 0xf5, 0x7d, 0x32, 0x3a, 0x0c, 0x3c, 0x9f, 0xb2, 0xcc, 0xe9, 0xd4, 0x1f, 0x78, 0xfe,
+	0xd0, 0x89, 0xdf, 0x7b, 0x03, 0x4e, 0xf5, 0x36, 0x34, 0xd5, 0x56, 0xa4, 0x1e, 0x27, 0xfd, 0x32,
+	0x54, 0x83, 0x69, 0x3c, 0x99, 0xf2, 0xad, 0x64, 0x8c, 0x63, 0x3f, 0x82, 0xe6, 0x1e, 0x72, 0x97,
+	0xef, 0xf9, 0xc3, 0xce, 0x60, 0x10, 0x92, 0x28, 0xc2, 0x23, 0x33, 0x99, 0x9e, 0xbc, 0x23, 0x97,
+	0x7c, 0xba, 0x55, 0x98, 0x3b, 0x0b, 0x22, 0xb6, 0xeb, 0x65, 0xfb, 0xbf, 0x1b, 0xd0, 0xc0, 0x45,
+	0xbe, 0x72, 0xfd, 0x4b, 0xb1, 0xf3, 0x4f, 0xa0, 0x8a, 0x1f, 0x1f, 0x07, 0x1d, 0x76, 0xd4, 0x18,
+	0x3b, 0xdd, 0xe3, 0xcb, 0x49, 0xf5, 0x7e, 0xa0, 0x76, 0xed, 0xfa, 0x71, 0x78, 0x89, 0xbc, 0x12,
+	0xbb, 0xe1, 0x90, 0xc4, 0xf4, 0x5c, 0x32, 0xf6, 0xa2, 0x67, 0xc2, 0xa5, 0x94, 0x77, 0x4e, 0x2e,
+	0x63, 0xd2, 0x2e, 0xea, 0x47, 0x6a, 0x4e, 0xec, 0xfb, 0xd8, 0xf3, 0xe9, 0x67, 0x11, 0x3f, 0x9c,
+	0xeb, 0xb0, 0x14, 0x4d, 0xf0, 0xdc, 0x4c, 0x7d, 0x7e, 0xca, 0xc9, 0x80, 0x72, 0x49, 0xc9, 0xfa,
+	0x1c, 0x96, 0xb2, 0x83, 0x57, 0xa0, 0x98, 0xac, 0xb5, 0x06, 0xf3, 0xe7, 0xee, 0x68, 0x4a, 0xe8,
+	0x1c, 0x8a, 0x3f, 0x2a, 0xfc, 0xae, 0x61, 0xdf, 0x82, 0x66, 0xb2, 0x02, 0xbe, 0xb1, 0x55, 0x98,
+	0x93, 0x44, 0x2f, 0xdb, 0x7f, 0xbb, 0xc0, 0xba, 0x6c, 0x07, 0x5e, 0x72, 0xa4, 0xab, 0x30, 0xe7,
+	0x0e, 0x06, 0x61, 0xae, 0x18, 0x2a, 0x9a, 0x36, 0x94, 0x71, 0x37, 0x70, 0x27, 0x51, 0xfc, 0x20,
+	0xb9, 0x1a, 0x9c, 0x5c, 0x07, 0xd3, 0x98, 0xed, 0xf0, 0x4f, 0x61, 0xad, 0x1f, 0x78, 0xbe, 0x13,
+	0x91, 0x11, 0xa1, 0x87, 0x11, 0x77, 0xd3, 0x8d, 0xc9, 0xf0, 0x92, 0x2e, 0xbe, 0xfe, 0x78, 0x93,
+	0x7f, 0x81, 0xe3, 0xf6, 0x44, 0xa7, 0x1e, 0xef, 0x93, 0x26, 0xea, 0x7c, 0x2e, 0x51, 0x99, 0xec,
+	0x6a, 0x42, 0x29, 0x42, 0x8a, 0xb9, 0xa3, 0x11, 0x3d, 0x3c, 0xa5, 0x94, 0xe4, 0xd2, 0xc9, 0x5c,
+	0x9e, 0x4d, 0x66, 0x3c, 0x39, 0x25, 0xfb, 0x36, 0x2c, 0x29, 0xe4, 0xc8, 0x25, 0xd9, 0xdf, 0x37,
+	0x60, 0x69, 0x9f, 0x5c, 0x70, 0x96, 0x13, 0x34, 0x7b, 0x0c, 0x73, 0xf1, 0xe5, 0x84, 0xd0, 0x3e,
+	0xf5, 0xc7, 0x1f, 0xf1, 0xe5, 0x65, 0xfa, 0x3d, 0xe0, 0x3f, 0x8f, 0x2f, 0x27, 0xc4, 0x3e, 0x80,
+	0x8a, 0xf2, 0xd3, 0x5c, 0x83, 0xd6, 0x17, 0xbb, 0xc7, 0xfb, 0xdd, 0x5e, 0xcf, 0x39, 0x7c, 0xfd,
+	0xf4, 0x65, 0xf7, 0xad, 0xf3, 0xa2, 0xd3, 0x7b, 0xd1, 0xbc, 0x66, 0xae, 0x82, 0xb9, 0xdf, 0xed,
+	0x1d, 0x77, 0x77, 0xb4, 0x76, 0xc3, 0x6c, 0x40, 0x45, 0x6d, 0x28, 0xd8, 0x16, 0xb4, 0xf7, 0xc9,
+	0xc5, 0x17, 0x5e, 0xec, 0x93, 0x28, 0xd2, 0x07, 0xb6, 0x3f, 0x06, 0x53, 0x9d, 0x0d, 0x5f, 0x5a,
+	0x03, 0x16, 0x5d, 0xd6, 0xc4, 0x57, 0xb7, 0x0b, 0xe6, 0x76, 0xe0, 0xfb, 0xa4, 0x1f, 0x1f, 0x12,
+	0x12, 0x8a, 0xd5, 0x7d, 0xac, 0x70, 0x44, 0xe5, 0xf1, 0x1a, 0x5f, 0x5d, 0xe6, 0xf8, 0x55, 0x61,
+	0x6e, 0x42, 0xc2, 0x31, 0x65, 0x94, 0x92, 0xfd, 0x09, 0xb4, 0x34, 0x54, 0xc9, 0x90, 0x13, 0x42,
+	0x42, 0x87, 0x13, 0x74, 0xde, 0x9e, 0xc0, 0xdc, 0x8b, 0xe3, 0xbd, 0x6d, 0xdc, 0x4a, 0xcf, 0xef,
+	0x07, 0x63, 0x94, 0x99, 0x06, 0xdd, 0xca, 0x34, 0xeb, 0x2d, 0x41, 0x99, 0x0a, 0x56, 0xbc, 0xd6,
+	0xe8, 0xa1, 0xaa, 0xe2, 0x5e, 0x92, 0xf7, 0x13, 0x2f, 0xa4, 0xd7, 0xa1, 0xb8, 0x6f, 0xe6, 0xc4,
+	0xcd, 0x12, 0x92, 0xf3, 0xa0, 0xcf, 0x40, 0x03, 0x32, 0x72, 0x2f, 0x19, 0x2b, 0xd9, 0x7f, 0x32,
+	0x0f, 0xb5, 0x4e, 0x3f, 0xf6, 0xce, 0x09, 0x97, 0x4b, 0x4c, 0x24, 0x8d, 0x46, 0x4e, 0xff, 0xcc,
+	0xf5, 0x71, 0x66, 0x16, 0xe5, 0x9d, 0x0d, 0x68, 0x85, 0x64, 0x1c, 0xc4, 0x84, 0xb5, 0x87, 0x24,
+	0x22, 0xe1, 0x39, 0x69, 0xaf, 0xd3, 0xc9, 0x58, 0x60, 0x8e, 0x82, 0xbe, 0x3b, 0xd2, 0x61, 0x6d,
+	0x01, 0x0b, 0x49, 0x9f, 0x78, 0xe7, 0xee, 0xc9, 0x88, 0x38, 0x27, 0xee, 0xc8, 0xf5, 0xfb, 0xa4,
+	0xbd, 0x46, 0x61, 0x82, 0xfb, 0x34, 0xd0, 0xaa, 0x90, 0xfb, 0xd3, 0xc9, 0x30, 0x74, 0x07, 0xc4,
+	0xc1, 0x1e, 0x48, 0x88, 0x15, 0x4a, 0x88, 0x07, 0xd0, 0xe8, 0x07, 0xe3, 0xb1, 0x17, 0x53, 0x81,
+	0x4c, 0x19, 0x6d, 0x99, 0x32, 0xda, 0x8a, 0x3c, 0x47, 0x02, 0x4a, 0x59, 0x69, 0x05, 0x6a, 0x7c,
+	0xe2, 0x9a, 0x38, 0x5c, 0x81, 0x5a, 0x9f, 0x2d, 0xd8, 0xa1, 0xe7, 0x97, 0xcb, 0xd7, 0x06, 0x2c,
+	0x8a, 0x75, 0x23, 0x51, 0xe7, 0x70, 0x27, 0xfa, 0xee, 0xc4, 0xed, 0x7b, 0x31, 0x3b, 0xaf, 0x45,
+	0xfc, 0x92, 0x2d, 0x56, 0x4c, 0x78, 0x5e, 0xdc, 0x26, 0x7c, 0x1c, 0xd1, 0xbe, 0x20, 0xd6, 0x38,
+	0xf5, 0x23, 0x12, 0xc7, 0x23, 0x32, 0x90, 0x20, 0x76, 0xb7, 0x6d, 0x40, 0x8b, 0x29, 0x1b, 0x91,
+	0x1b, 0x07, 0xd1, 0x99, 0x17, 0x39, 0x11, 0xde, 0x4e, 0x25, 0x0a, 0xbc, 0x09, 0x6b, 0x29, 0x20,
+	0x23, 0x23, 0x19, 0xd0, 0xa3, 0x5b, 0x44, 0xc9, 0x80, 0x3a, 0xd0, 0x74, 0x32, 0x70, 0x63, 0xc2,
+	0xae, 0xbb, 0x39, 0xd3, 0x86, 0x1a, 0x27, 0x97, 0x73, 0x16, 0x8f, 0xfa, 0x51, 0xbb, 0x42, 0xa5,
+	0x52, 0x85, 0xd3, 0x86, 0x32, 0x17, 0xb2, 0x12, 0xdd, 0x71, 0x7a, 0xdf, 0x95, 0xe8, 0x4d, 0x4a,
+	0x69, 0x86, 0x4a, 0x4f, 0xbb, 0x26, 0x16, 0xc9, 0xdb, 0x2e, 0x18, 0x1f, 0xd5, 0x69, 0x33, 0x32,
+	0x6c, 0xe8, 0x9d, 0xbb, 0x31, 0x69, 0x37, 0xe8, 0xb7, 0x4d, 0x28, 0x8d, 0xbc, 0x53, 0x82, 0x57,
+	0x74, 0xbb, 0x49, 0xbb, 0xd4, 0x61, 0x61, 0x3a, 0xa1, 0xbf, 0x97, 0x12, 0x4c, 0xc1, 0xc4, 0xe9,
+	0x8f, 0x82, 0x08, 0xf7, 0xb9, 0x6d, 0xd2, 0x0f, 0x5b, 0x50, 0xe1, 0x84, 0xa6, 0xb7, 0x5b, 0x8b,
+	0x9e, 0xb8, 0x11, 0xb4, 0xf6, 0xbc, 0x28, 0xe6, 0x9c, 0x28, 0x05, 0x4a, 0x0b, 0x2a, 0x6c, 0xc2,
+	0x4e, 0xe0, 0x8f, 0x2e, 0xf9, 0x81, 0x58, 0x81, 0x9a, 0xe7, 0xab, 0xcd, 0x05, 0x81, 0x77, 0x32,
+	0x3d, 0x19, 0x79, 0x7d, 0xd6, 0x58, 0xa4, 0x8d, 0xa8, 0xa3, 0xb0, 0x69, 0xb3, 0xd6, 0x39, 0x7a,
+	0x28, 0x9f, 0xc0, 0xb2, 0x3e, 0x1a, 0x3f, 0x95, 0x9f, 0x40, 0x89, 0xb3, 0x86, 0x20, 0xdf, 0x32,
+	0x27, 0x9f, 0x76, 0x50, 0x50, 0xc4, 0xf0, 0x3f, 0xbb, 0xe7, 0xc4, 0x8f, 0x7b, 0xd3, 0x93, 0xa8,
+	0x1f, 0x7a, 0x13, 0x3c, 0x62, 0xf6, 0x1f, 0x17, 0xc0, 0x54, 0x81, 0xaf, 0xe9, 0x2e, 0xcd, 0x10,
+	0x8d, 0xd9, 0x8e, 0x0f, 0xd8, 0x3f, 0x94, 0x81, 0xb7, 0xf2, 0x38, 0xb5, 0xf2, 0xb8, 0xa5, 0x7f,
+	0xcc, 0x2e, 0x9b, 0x0c, 0xb3, 0x17, 0x29, 0x5d, 0xcf, 0x01, 0x14, 0x84, 0x4d, 0xa8, 0x1e, 0x1c,
+	0x76, 0xf7, 0x9d, 0xed, 0x17, 0x9d, 0xfd, 0xfd, 0xee, 0x5e, 0xf3, 0x9a, 0x69, 0x42, 0x7d, 0x7b,
+	0xef, 0xa0, 0xd7, 0xdd, 0x91, 0x6d, 0x06, 0xb6, 0x75, 0xb6, 0x8f, 0x77, 0xdf, 0x74, 0x65, 0x5b,
+	0xc1, 0x5c, 0x86, 0xe6, 0xee, 0x7e, 0xaa, 0xb5, 0x68, 0xb6, 0x61, 0xf9, 0xb0, 0xbb, 0xbf, 0xb3,
+	0xbb, 0xff, 0xdc, 0xd1, 0xf0, 0xce, 0xd9, 0xff, 0xca, 0x80, 0x39, 0x14, 0x78, 0xe6, 0x7d, 0x80,
+	0x90, 0x4c, 0xa6, 0x4c, 0xeb, 0xa7, 0xfc, 0x5b, 0x91, 0xe7, 0x95, 0x49, 0x44, 0x01, 0xa4, 0x2c,
+	0x36, 0x3d, 0x71, 0x92, 0x93, 0xaa, 0x08, 0x49, 0xa6, 0x3c, 0x2b, 0x82, 0x9a, 0x2e, 0x8f, 0xaa,
+	0xfc, 0x97, 0x31, 0xe1, 0xc7, 0x67, 0x8e, 0x1e, 0x04, 0xd9, 0x16, 0x92, 0xfe, 0x79, 0x7b, 0x5e,
+	0x9c, 0x65, 0xbc, 0x36, 0x69, 0xaf, 0xe4, 0xca, 0x74, 0x63, 0xd6, 0x67, 0x51, 0x70, 0xb8, 0xe7,
+	0x9f, 0x04, 0x53, 0x7f, 0x40, 0xcf, 0x61, 0xc9, 0x36, 0x51, 0xb7, 0x8a, 0xa8, 0xdc, 0x96, 0x17,
+	0xc8, 0x00, 0x96, 0x94, 0x36, 0xce, 0x36, 0x9f, 0x53, 0x41, 0xc7, 0xa4, 0x3c, 0x9e, 0x3f, 0x9c,
+	0x74, 0xd4, 0x2e, 0xdc, 0x2a, 0x2a, 0xd7, 0xc4, 0x91, 0xd2, 0x81, 0x12, 0xc6, 0x82, 0x79, 0xd6,
+	0xcf, 0xd0, 0xce, 0x29, 0xc2, 0xec, 0x35, 0x58, 0xc1, 0x7f, 0xb3, 0xcc, 0x75, 0x0e, 0x65, 0x09,
+	0xc8, 0xd2, 0xeb, 0x1e, 0xe7, 0x31, 0xa6, 0x8d, 0x5a, 0x0a, 0x46, 0xfa, 0xc1, 0x03, 0xfa, 0x5f,
+	0x7a, 0xe9, 0x3e, 0x80, 0xb2, 0xfc, 0x41, 0x6f, 0xd0, 0x6e, 0xf7, 0xc8, 0x39, 0xd8, 0xdf, 0xdb,
+	0xdd, 0xef, 0x36, 0xaf, 0x21, 0x9b, 0xb0, 0x86, 0x67, 0xcf, 0x68, 0x8b, 0x61, 0x37, 0xa1, 0xfe,
+	0x9c, 0xc4, 0xbb, 0xfe, 0x69, 0x20, 0x08, 0xf1, 0x6f, 0x0a, 0xd0, 0x90, 0x4d, 0x9c, 0x0e, 0x6b,
+	0xd0, 0xf0, 0x06, 0xc4, 0x8f, 0xbd, 0xf8, 0x52, 0x17, 0xb9, 0x35, 0x98, 0x77, 0x47, 0x9e, 0x1b,
+	0x71, 0x51, 0xbb, 0x09, 0xcb, 0x28, 0xbf, 0x84, 0xb8, 0x92, 0x47, 0x8e, 0xd9, 0x42, 0x1b, 0xd0,
+	0x42, 0x28, 0x3f, 0xe0, 0x12, 0x38, 0x27, 0xf4, 0x7c, 0xf6, 0x29, 0x52, 0x4e, 0xaa, 0x44, 0x9a,
+	0x89, 0xb7, 0x40, 0x5b, 0x75, 0x63, 0xb0, 0x24, 0xac, 0x8f, 0xe8, 0xd2, 0xef, 0x93, 0x81, 0x13,
+	0x07, 0x88, 0xd8, 0x63, 0x0c, 0x59, 0xa2, 0x56, 0x27, 0x89, 0x62, 0x9f, 0xc4, 0x4c, 0x03, 0xc2,
+	0x09, 0xf7, 0x83, 0x51, 0x10, 0x52, 0x7b, 0xa1, 0x6c, 0x5e, 0x87, 0x15, 0x1c, 0xd5, 0xf3, 0xd3,
+	0x93, 0xaa, 0xd2, 0xb1, 0x1a, 0xb0, 0x78, 0x4e, 0xc2, 0x08, 0x19, 0xbc, 0x26, 0xd6, 0xcb, 0xd0,
+	0xd7, 0xe9, 0xcf, 0x5b, 0x50, 0x3a, 0x25, 0x6e, 0x3c, 0x0d, 0x49, 0xd4, 0x6e, 0xd0, 0xdd, 0xae,
+	0xf3, 0xbd, 0x79, 0xc6, 0x9a, 0xed, 0x97, 0xb0, 0xc8, 0xff, 0x44, 0x75, 0xf6, 0xc4, 0x63, 0x46,
+	0x58, 0x0d, 0x75, 0x09, 0xdf, 0x1d, 0x13, 0x4e, 0xb7, 0x16, 0x54, 0xe8, 0x65, 0xf0, 0xdb, 0xa9,
+	0x17, 0x92, 0x01, 0x97, 0x70, 0xa8, 0x30, 0x44, 0xce, 0x3b, 0x3f, 0xb8, 0xf0, 0xb9, 0x74, 0x7b,
+	0x4d, 0xb5, 0x17, 0x69, 0x1e, 0x73, 0x01, 0xb4, 0x04, 0x65, 0x46, 0x90, 0xe8, 0xcc, 0xe5, 0xc6,
+	0x46, 0x9a, 0x72, 0xec, 0x90, 0xad, 0x42, 0x5d, 0x58, 0xd8, 0x91, 0x33, 0x22, 0xa7, 0xdc, 0x46,
+	0xb5, 0x7f, 0x1f, 0x96, 0xb8, 0xc4, 0x39, 0x98, 0x10, 0x81, 0x35, 0x23, 0xa2, 0x8c, 0x99, 0x22,
+	0xca, 0xfe, 0xb1, 0x14, 0x8c, 0xdb, 0xa3, 0x20, 0x22, 0x1c, 0xc3, 0x32, 0x54, 0xf1, 0x82, 0x48,
+	0xd9, 0x41, 0x0d, 0x58, 0x8c, 0xa6, 0xfd, 0x3e, 0x9e, 0x74, 0xa6, 0x47, 0xfd, 0xa9, 0x01, 0x2d,
+	0xfa, 0x19, 0x47, 0x21, 0x6e, 0x88, 0x6f, 0x31, 0x01, 0x69, 0xf6, 0x33, 0xc3, 0xb1, 0x20, 0xec,
+	0x91, 0xd3, 0x20, 0xec, 0x13, 0x4e, 0x4d, 0x45, 0x0b, 0x60, 0xd2, 0xa4, 0x0d, 0xcd, 0x01, 0x19,
+	0x79, 0xe7, 0x24, 0xbc, 0x74, 0x84, 0xec, 0xa1, 0xb6, 0xac, 0xdd, 0x87, 0x95, 0xce, 0x89, 0xeb,
+	0x0f, 0x02, 0xff, 0x3b, 0x4c, 0xe9, 0x06, 0xac, 0x7a, 0x74, 0xf3, 0x9c, 0x8b, 0x33, 0x37, 0x76,
+	0x3c, 0xc7, 0x1d, 0x3b, 0x83, 0x40, 0x18, 0xdc, 0x25, 0xbb, 0x0d, 0xab, 0xe9, 0x41, 0xd8, 0x61,
+	0xb3, 0xff, 0x99, 0x01, 0x4b, 0x94, 0x20, 0xbd, 0xd8, 0x8d, 0xa7, 0x11, 0xa7, 0xe6, 0xa7, 0x50,
+	0x43, 0x6a, 0x26, 0xaa, 0x13, 0x1b, 0x7b, 0x59, 0xca, 0x02, 0xda, 0xca, 0x3a, 0xbf, 0xb8, 0x66,
+	0x7e, 0x06, 0x55, 0xd5, 0x93, 0xc2, 0x2f, 0x98, 0x75, 0xa9, 0x4f, 0xa5, 0xb9, 0xe8, 0xc5, 0x35,
+	0xf3, 0x21, 0x00, 0xa5, 0x10, 0x1d, 0xa6, 0x5d, 0xd4, 0x3f, 0xc8, 0x6c, 0xef, 0x8b, 0x6b, 0x4f,
+	0x4b, 0xa8, 0x16, 0xe0, 0xdf, 0xf6, 0x75, 0xa8, 0x69, 0x13, 0xd0, 0x6c, 0x8a, 0xaa, 0xfd, 0x67,
+	0x45, 0x30, 0x91, 0xb5, 0x52, 0xe4, 0x5c, 0x85, 0x3a, 0xb7, 0x83, 0x34, 0x8d, 0x99, 0x6a, 0x41,
+	0xc1, 0x40, 0xde, 0x77, 0x05, 0xca, 0x37, 0x16, 0x98, 0x4a, 0xa3, 0x70, 0x3e, 0x14, 0x85, 0xd8,
+	0x61, 0xea, 0x9b, 0xb0, 0xb0, 0xb9, 0x5a, 0x3d, 0x27, 0x2e, 0x84, 0xc9, 0x14, 0xfd, 0x15, 0x6e,
+	0xcc, 0xf5, 0x3a, 0x2e, 0x6b, 0x98, 0xd1, 0xc4, 0xa4, 0x8a, 0x66, 0xf6, 0x2d, 0x7e, 0x6b, 0xb3,
+	0xaf, 0xf4, 0x01, 0x66, 0xdf, 0x4d, 0x58, 0xcb, 0x51, 0xb7, 0xe9, 0xb4, 0x98, 0xf6, 0xf7, 0x09,
+	0xdc, 0xe0, 0x1d, 0xd0, 0xe3, 0x41, 0xad, 0x5d, 0xc7, 0xf3, 0x9d, 0xd3, 0x11, 0x9e, 0x61, 0xda,
+	0x0f, 0x84, 0x7b, 0x06, 0x6d, 0x3e, 0x54, 0x06, 0x69, 0x2b, 0x73, 0x7d, 0x50, 0x7b, 0x40, 0x7e,
+	0xcd, 0x34, 0x45, 0x26, 0xc5, 0x56, 0x04, 0xeb, 0x08, 0x36, 0xa7, 0xb2, 0xcc, 0xfe, 0x27, 0x06,
+	0x34, 0x71, 0x57, 0x34, 0x36, 0xfb, 0x3e, 0x54, 0xe9, 0xec, 0xfe, 0x9f, 0x71, 0xd9, 0xa7, 0x50,
+	0xa6, 0x03, 0x04, 0x13, 0xe2, 0x73, 0x26, 0x6b, 0xeb, 0x4c, 0x96, 0x08, 0x21, 0x8d, 0xc7, 0x7e,
+	0x0a, 0x2b, 0x7c, 0xf8, 0x14, 0x1b, 0x7d, 0x04, 0x0b, 0x11, 0x5d, 0x02, 0x57, 0xc1, 0x96, 0x75,
+	0x74, 0x6c, 0x79, 0xf6, 0x5f, 0xce, 0xc1, 0x6a, 0xfa, 0x7b, 0x7e, 0xbb, 0x3d, 0x83, 0x66, 0xe6,
+	0xc6, 0x62, 0x77, 0xf7, 0xf7, 0xf5, 0x75, 0xa7, 0x3e, 0x4c, 0x35, 0x5b, 0x7f, 0x5d, 0x80, 0xba,
+	0xde, 0x94, 0xb1, 0x06, 0xa9, 0x97, 0x50, 0xdc, 0xa4, 0x82, 0xb9, 0x73, 0x2c, 0x17, 0xc6, 0xd7,
+	0xdf, 0xd9, 0x50, 0x49, 0x8b, 0xe0, 0x45, 0x8a, 0x36, 0x21, 0x58, 0x69, 0x36, 0xc1, 0xe8, 0x50,
+	0xde, 0xf8, 0x24, 0x90, 0x28, 0xcb, 0xc2, 0x88, 0x1b, 0xe3, 0x7d, 0x86, 0x0b, 0xe0, 0xb7, 0x0b,
+	0x88, 0xdb, 0x9d, 0xde, 0x39, 0x91, 0x13, 0x7b, 0x23, 0x47, 0xf4, 0xa1, 0xcc, 0x39, 0x6f, 0xfe,
+	0x2c, 0x6d, 0xc3, 0x54, 0x29, 0x7d, 0xef, 0x7f, 0x10, 0x7d, 0x5f, 0xc4, 0xa3, 0xbe, 0x45, 0xa0,
+	0xa2, 0xfc, 0x44, 0xd2, 0x88, 0xf3, 0x3a, 0xc3, 0x91, 0x93, 0x33, 0xd1, 0xe2, 0x55, 0x13, 0x9d,
+	0xa3, 0xd6, 0xfa, 0xf7, 0x61, 0xf9, 0x0b, 0x77, 0x34, 0x22, 0xf1, 0x53, 0xb6, 0x6a, 0xc5, 0x0f,
+	0x7c, 0xc1, 0x1c, 0x0f, 0x8a, 0xc1, 0x82, 0x77, 0xd7, 0x4a, 0xaa, 0x3b, 0xe7, 0xa9, 0x55, 0xa8,
+	0xe3, 0x18, 0x64, 0x90, 0xda, 0xa9, 0x0d, 0x68, 0x29, 0x6e, 0x19, 0x09, 0x9c, 0x13, 0x76, 0x65,
+	0x16, 0x54, 0x14, 0x1b, 0xcf, 0x4c, 0x47, 0xd1, 0x5c, 0x10, 0xaa, 0xad, 0x68, 0xc0, 0x19, 0x19,
+	0xa8, 0x60, 0x72, 0x2a, 0xea, 0x0b, 0xb0, 0xff, 0xb2, 0x00, 0xab, 0x69, 0x08, 0x9f, 0xeb, 0x13,
+	0x68, 0xa7, 0xcc, 0x6f, 0x31, 0x0a, 0x72, 0x08, 0xee, 0xd3, 0x66, 0xae, 0x1d, 0xce, 0xf1, 0x98,
+	0x77, 0x60, 0x43, 0x6c, 0x2e, 0x9e, 0x6a, 0x27, 0xc5, 0x8a, 0x8b, 0xdc, 0xaf, 0x66, 0x69, 0x9d,
+	0x74, 0x36, 0x66, 0xec, 0x7a, 0x0b, 0xda, 0x89, 0x5d, 0x9d, 0xc2, 0x32, 0x2f, 0x2c, 0xe8, 0xa4,
+	0x87, 0x8e, 0x62, 0x6e, 0xc6, 0x49, 0x28, 0xe6, 0x1f, 0x9c, 0x5c, 0xfa, 0x15, 0xed, 0xef, 0x43,
+	0xf5, 0x28, 0x98, 0xc6, 0x72, 0xdf, 0x33, 0xaa, 0x38, 0x77, 0x94, 0xd3, 0xcf, 0xed, 0x21, 0x14,
+	0x5f, 0x04, 0x13, 0x55, 0xb7, 0x30, 0xa8, 0x6e, 0xc1, 0xcf, 0xb3, 0x23, 0x4f, 0x6f, 0x41, 0x4c,
+	0xce, 0x1d, 0xc7, 0xa8, 0xa3, 0x9e, 0x06, 0xe1, 0x85, 0x1b, 0x0e, 0xf8, 0xe4, 0x2a, 0x50, 0x3c,
+	0x25, 0x62, 0x05, 0x29, 0x2b, 0x9a, 0xa9, 0x24, 0x2e, 0xcc, 0xd3, 0x69, 0x51, 0xdf, 0x39, 0xe5,
+	0x03, 0xa6, 0xef, 0xa0, 0xa7, 0xc8, 0x10, 0x6a, 0xb1, 0x12, 0xe5, 0x90, 0x0e, 0x25, 0xd6, 0x96,
+	0xb8, 0xf6, 0xdb, 0xe8, 0x32, 0x9e, 0xa0, 0xd2, 0x8d, 0xfb, 0x0a, 0xc2, 0x87, 0x10, 0x4c, 0x6c,
+	0x1b, 0x1a, 0xfb, 0xc1, 0x80, 0x28, 0xa6, 0x40, 0x66, 0xf1, 0xf6, 0xaf, 0xa0, 0x24, 0xfa, 0x98,
+	0x36, 0xcc, 0xe1, 0x85, 0x9c, 0xba, 0x21, 0xa4, 0xd3, 0x0c, 0xfb, 0xe1, 0xa9, 0xa1, 0x17, 0xad,
+	0x90, 0xaa, 0xcc, 0x7f, 0x8c, 0xf7, 0x3e, 0x9d, 0x96, 0x24, 0x0f, 0x9d, 0x9b, 0xfd, 0x4f, 0x0d,
+	0xa8, 0xe9, 0xdf, 0xab, 0xfa, 0xf5, 0x62, 0x9e, 0x7e, 0x8d, 0xd4, 0xa2, 0x51, 0x10, 0x76, 0x49,
+	0x70, 0x5a, 0x28, 0xf3, 0x96, 0x2e, 0x20, 0xdd, 0xbc, 0x94, 0x76, 0x0b, 0x73, 0x56, 0x7f, 0x0c,
+	0x65, 0x0e, 0x27, 0xa8, 0x04, 0xaa, 0x21, 0x17, 0x9c, 0x87, 0x70, 0x00, 0x4a, 0xe3, 0x81, 0x86,
+	0x36, 0xec, 0xdf, 0x87, 0x8a, 0x0a, 0x5d, 0x82, 0x32, 0x9d, 0x4a, 0x44, 0xf8, 0xcd, 0x46, 0x27,
+	0xe2, 0x93, 0xf8, 0x22, 0x08, 0xdf, 0x25, 0x1e, 0x7b, 0x1c, 0x88, 0x7b, 0xec, 0xff, 0xb5, 0x01,
+	0x35, 0xdc, 0x56, 0xb4, 0x1c, 0x83, 0x91, 0xd7, 0xbf, 0x44, 0xb1, 0x36, 0xf0, 0xa8, 0x4f, 0x65,
+	0xc0, 0xfd, 0xbd, 0x3c, 0x58, 0x42, 0xb7, 0x1a, 0xbd, 0x7c, 0xb1, 0xcb, 0x17, 0xd9, 0x84, 0x92,
+	0xd0, 0x02, 0xf8, 0x76, 0xaf, 0x40, 0x0d, 0x83, 0x1f, 0x27, 0x6e, 0x44, 0x58, 0xec, 0xa3, 0x28,
+	0x44, 0x0e, 0x36, 0xa3, 0x16, 0xe2, 0x8c, 0xbd, 0xd1, 0xc8, 0x63, 0x40, 0xc6, 0x6d, 0xd7, 0x61,
+	0x85, 0xdb, 0xc6, 0x8e, 0xfe, 0x2d, 0x3b, 0x6f, 0x77, 0x60, 0x43, 0x05, 0xa7, 0x71, 0xd0, 0x63,
+	0x6b, 0xff, 0xcd, 0x02, 0x54, 0x84, 0xbf, 0x63, 0x30, 0x24, 0x19, 0x6f, 0x23, 0x08, 0x8b, 0x5e,
+	0xdc, 0x71, 0xf2, 0x9c, 0xf0, 0x36, 0xcd, 0x5d, 0x97, 0xda, 0xd1, 0xa2, 0xb4, 0x0e, 0x83, 0x01,
+	0xf9, 0x0c, 0xd5, 0xbf, 0x24, 0xc0, 0x80, 0x4d, 0x8f, 0x69, 0xd3, 0x7c, 0xe6, 0xbe, 0x64, 0x12,
+	0x65, 0x0b, 0xaa, 0xfc, 0x3b, 0x4a, 0xdf, 0xf6, 0xa2, 0xc6, 0xac, 0x3a, 0xed, 0x79, 0xdf, 0xc7,
+	0xa2, 0x6f, 0xe9, 0x8a, 0xbe, 0xab, 0x50, 0x4f, 0x16, 0x43, 0xcf, 0x69, 0x99, 0xee, 0xe8, 0x0a,
+	0xb4, 0x38, 0x25, 0x9e, 0x87, 0xee, 0xe4, 0x4c, 0x08, 0xdf, 0x37, 0x50, 0x55, 0x9b, 0xcd, 0x3b,
+	0x30, 0x8f, 0x43, 0x09, 0x35, 0x23, 0xff, 0xf0, 0xdc, 0x86, 0x79, 0x32, 0x18, 0x12, 0xe1, 0x6f,
+	0x30, 0x53, 0x9e, 0xa5, 0xc1, 0x90, 0xd8, 0xe7, 0xd0, 0xc0, 0x9f, 0xea, 0x99, 0x4d, 0x13, 0x7f,
+	0x2e, 0xed, 0x03, 0x65, 0x94, 0x4f, 0x49, 0x19, 0x46, 0xfa, 0xbb, 0xda, 0x76, 0x14, 0x67, 0x1b,
+	0x7c, 0xcb, 0xe8, 0x6d, 0xa7, 0x7c, 0xad, 0x7a, 0x0e, 0xfe, 0xba, 0x00, 0x15, 0xa5, 0x19, 0x89,
+	0x34, 0xc4, 0xe5, 0x3a, 0x03, 0xcf, 0x1d, 0x93, 0x98, 0x84, 0x9c, 0x73, 0x51, 0x0c, 0x9e, 0x0f,
+	0x1d, 0x8c, 0xff, 0x0d, 0xc8, 0x30, 0x24, 0x84, 0x47, 0x80, 0x57, 0xa1, 0x8e, 0xaa, 0xab, 0xd2,
+	0x5e, 0x54, 0x5d, 0x03, 0x8c, 0x62, 0x73, 0xc2, 0x35, 0xa0, 0x09, 0x16, 0xe6, 0x30, 0xb8, 0x01,
+	0xab, 0x4c, 0xb0, 0xf0, 0x43, 0xe7, 0xa4, 0xb8, 0xa1, 0x0d, 0x4d, 0x1c, 0x58, 0xec, 0x5c, 0xe4,
+	0xfd, 0x11, 0xbb, 0x9d, 0x0c, 0x84, 0xd0, 0x30, 0x8a, 0x0a, 0x29, 0x89, 0x6f, 0x70, 0x52, 0x1a,
+	0xa4, 0x2c, 0xce, 0xd5, 0x98, 0x0c, 0x3c, 0x37, 0xf5, 0x19, 0x08, 0x17, 0x39, 0x4e, 0xd0, 0x8b,
+	0x82, 0x91, 0x1b, 0x93, 0x01, 0x9f, 0x7c, 0x85, 0x4e, 0xf3, 0x73, 0x58, 0x4b, 0xd6, 0xe8, 0x0c,
+	0x3c, 0xb4, 0x65, 0x4e, 0xa6, 0x54, 0x81, 0xae, 0x6a, 0x5b, 0xbd, 0x43, 0x7b, 0x6c, 0xa3, 0x52,
+	0x63, 0xff, 0x0e, 0x54, 0x94, 0x9f, 0x78, 0x72, 0x14, 0x3a, 0x19, 0x59, 0x3a, 0xb1, 0x48, 0xf0,
+	0x06, 0xac, 0x53, 0x8e, 0x3b, 0x0e, 0x26, 0xc1, 0x28, 0x18, 0x5e, 0x6a, 0x3e, 0xa7, 0x7f, 0x64,
+	0x40, 0x4b, 0x83, 0x72, 0x1b, 0xe0, 0x2e, 0x3b, 0x08, 0xd2, 0x0d, 0xcd, 0x98, 0x74, 0x49, 0x11,
+	0x88, 0xbc, 0xe3, 0x67, 0xd0, 0x10, 0x4b, 0x17, 0x7d, 0x19, 0xaf, 0xb6, 0xb3, 0xbc, 0xca, 0x3f,
+	0x79, 0xc4, 0x34, 0x52, 0x32, 0xa0, 0x44, 0x13, 0x11, 0x36, 0xe1, 0xd1, 0xa2, 0xf6, 0xe5, 0x80,
+	0x7f, 0xc5, 0xbe, 0xb0, 0xa7, 0x00, 0xca, 0x90, 0xea, 0x8d, 0x30, 0x9f, 0x7b, 0x23, 0x2c, 0xa9,
+	0xb2, 0x1c, 0xa7, 0x5e, 0x9e, 0xa1, 0x74, 0xcb, 0x3b, 0x40, 0x5e, 0x09, 0x4c, 0xb8, 0xd3, 0x13,
+	0x63, 0xff, 0x37, 0x03, 0x96, 0xb2, 0xd3, 0x4f, 0x9f, 0xae, 0x52, 0xfe, 0xe9, 0xba, 0x9b, 0x91,
+	0x6b, 0x33, 0xbc, 0x04, 0xaa, 0xc4, 0x62, 0xd2, 0xfa, 0xfb, 0x50, 0x0f, 0x99, 0xa8, 0x11, 0x72,
+	0x68, 0xee, 0x0a, 0x39, 0x84, 0x1c, 0x3d, 0x38, 0x27, 0x61, 0xec, 0x51, 0x25, 0x9f, 0x5e, 0xc8,
+	0x32, 0x20, 0xaf, 0x38, 0x2e, 0x29, 0x60, 0x41, 0xc8, 0x57, 0xf5, 0xe4, 0x53, 0x93, 0x80, 0xb2,
+	0x42, 0x0e, 0xf1, 0x33, 0xeb, 0x5d, 0xc8, 0x5f, 0xaf, 0xba, 0x0c, 0x79, 0x17, 0xf1, 0x7d, 0xd6,
+	0x74, 0x6f, 0x9d, 0x30, 0x73, 0xb3, 0x09, 0x93, 0xab, 0x05, 0x7d, 0x84, 0x01, 0xee, 0xb8, 0x83,
+	0x9b, 0x26, 0xc4, 0x1d, 0xf2, 0x3c, 0xb9, 0x70, 0xd8, 0x46, 0x32, 0x25, 0xc5, 0x84, 0x66, 0xd2,
+	0x8b, 0xfb, 0x54, 0xfe, 0x7f, 0x68, 0xb1, 0x15, 0x71, 0x2e, 0xe9, 0xb0, 0x1c, 0x8a, 0xcf, 0x58,
+	0xd8, 0x24, 0xf0, 0xb9, 0xe9, 0x78, 0x9b, 0x4f, 0x25, 0xa7, 0xef, 0x03, 0xfe, 0x49, 0x0b, 0x2a,
+	0x9c, 0x01, 0x9d, 0x13, 0x4f, 0x24, 0x5c, 0x5c, 0x87, 0x05, 0x0e, 0x5e, 0x84, 0x62, 0x67, 0x67,
+	0xa7, 0x79, 0xcd, 0x04, 0x58, 0x38, 0xea, 0xbe, 0x3a, 0x78, 0x83, 0x4e, 0xd6, 0x3f, 0x36, 0xe0,
+	0x3a, 0xd5, 0x14, 0x7c, 0x3f, 0x98, 0xfa, 0x7d, 0x32, 0x96, 0x41, 0x01, 0xb1, 0x8c, 0xcf, 0xa1,
+	0x21, 0xb0, 0xea, 0xa7, 0xce, 0x9a, 0x3d, 0xa3, 0x84, 0x63, 0x73, 0xf9, 0x59, 0xd1, 0x79, 0x18,
+	0x47, 0x7f, 0x0a, 0x37, 0x66, 0x4d, 0x82, 0x1b, 0x02, 0x15, 0x28, 0x06, 0x13, 0x36, 0x72, 0xd9,
+	0xfe, 0xb7, 0x06, 0x2c, 0xee, 0xfa, 0xe7, 0x81, 0xd7, 0x27, 0x68, 0x5b, 0xd1, 0x30, 0xe4, 0x25,
+	0x97, 0x6e, 0x36, 0xcc, 0x47, 0xb1, 0x1b, 0x33, 0x49, 0x58, 0x97, 0x3b, 0xc8, 0xbb, 0xf7, 0x62,
+	0xee, 0x02, 0x1a, 0x93, 0x71, 0x90, 0x78, 0xfc, 0x69, 0xac, 0x6b, 0x12, 0x73, 0x7f, 0x8e, 0x09,
+	0x10, 0x3a, 0x93, 0x90, 0x78, 0x63, 0x77, 0x48, 0x78, 0xb4, 0xb3, 0x0e, 0x0b, 0xa1, 0x9a, 0x71,
+	0x22, 0x63, 0xfe, 0xf3, 0x42, 0x59, 0xe7, 0xaa, 0x3f, 0xcb, 0x1a, 0xa0, 0x4c, 0x16, 0x12, 0x1e,
+	0x00, 0xc5, 0xe9, 0x2c, 0x0a, 0x0d, 0x9a, 0xf5, 0x63, 0x8d, 0x54, 0x8e, 0xdb, 0x3f, 0x05, 0xb3,
+	0x33, 0x18, 0xf0, 0x19, 0xca, 0x15, 0x27, 0x23, 0x32, 0xef, 0x64, 0x4e, 0x1a, 0x0b, 0x53, 0xd5,
+	0x3e, 0x83, 0x0a, 0x4f, 0xfe, 0x78, 0xe1, 0x46, 0x67, 0x6c, 0xf6, 0x22, 0x0b, 0x26, 0x31, 0x40,
+	0x39, 0x2e, 0xba, 0x42, 0x7b, 0x0b, 0x4c, 0x8c, 0x28, 0xc8, 0x21, 0xe5, 0xfd, 0x2c, 0xed, 0xa0,
+	0xc4, 0x90, 0xfc, 0x21, 0xb4, 0xb4, 0xbe, 0x7c, 0x7a, 0xb7, 0x30, 0x66, 0x4c, 0x9b, 0x04, 0x3f,
+	0xd4, 0x75, 0x52, 0xa3, 0xc2, 0x21, 0xa8, 0xae, 0x8a, 0xf6, 0xff, 0x50, 0x80, 0x45, 0x3e, 0xdf,
+	0x4c, 0x36, 0x4f, 0x5e, 0x3e, 0x45, 0x96, 0x94, 0x4c, 0x1a, 0x61, 0xc8, 0xdb, 0x8d, 0xcf, 0xa8,
+	0xb9, 0x50, 0x16, 0x76, 0x0a, 0xdb, 0x8d, 0xc4, 0x8b, 0xb0, 0xa0, 0x79, 0x11, 0xf8, 0xb0, 0xdc,
+	0x8b, 0xc0, 0x7d, 0xfe, 0x98, 0x55, 0x43, 0x06, 0x8e, 0x1b, 0xc7, 0x64, 0x3c, 0x89, 0x59, 0x16,
+	0x56, 0x4d, 0xcd, 0xc2, 0x61, 0xa9, 0x2f, 0x25, 0x2a, 0x4d, 0x72, 0xf2, 0x83, 0xca, 0x39, 0xf9,
+	0x41, 0x90, 0xcd, 0x0f, 0x62, 0xf7, 0xeb, 0x6d, 0x98, 0x57, 0x9d, 0x0c, 0xa6, 0x12, 0x28, 0xed,
+	0xb0, 0x09, 0xe4, 0x24, 0xfa, 0xd4, 0xbe, 0x39, 0xd1, 0xe7, 0x1f, 0x1b, 0x6c, 0x97, 0x38, 0x50,
+	0xcd, 0x11, 0xd3, 0x92, 0xb0, 0x98, 0x4c, 0x44, 0x2f, 0x1d, 0x9d, 0x18, 0xeb, 0xdc, 0x2e, 0x08,
+	0x49, 0x19, 0x12, 0x8c, 0x29, 0x48, 0x37, 0xff, 0x26, 0x2c, 0xf7, 0xf1, 0x4a, 0x77, 0x98, 0xea,
+	0x22, 0xfb, 0x53, 0x97, 0x3f, 0xd2, 0x4f, 0xdb, 0x17, 0x87, 0x66, 0xa3, 0xf1, 0xe0, 0x17, 0xfa,
+	0x0b, 0x34, 0x20, 0xf1, 0xd9, 0xd1, 0x98, 0xb3, 0xff, 0x96, 0x01, 0xcb, 0xfa, 0x5c, 0x13, 0x96,
+	0x92, 0x43, 0xe8, 0x2c, 0x25, 0xf8, 0xc5, 0x02, 0xf3, 0xd4, 0x0b, 0xf3, 0x32, 0xcb, 0xe6, 0xf2,
+	0x93, 0xce, 0x58, 0x54, 0xdd, 0x02, 0x93, 0xad, 0x80, 0x86, 0x71, 0xd4, 0x55, 0xcc, 0xd9, 0x6f,
+	0xa0, 0xbd, 0x43, 0x46, 0x24, 0x26, 0x9d, 0xd1, 0x28, 0x4d, 0xbd, 0x4d, 0x58, 0xe6, 0xdc, 0x21,
+	0x3e, 0x52, 0x43, 0xc2, 0x09, 0x54, 0xf0, 0x8e, 0x12, 0x19, 0xb6, 0x1f, 0xc1, 0x7a, 0x0e, 0x5e,
+	0xbe, 0x52, 0x1e, 0x4c, 0x1f, 0xd0, 0x0e, 0x03, 0x6e, 0xdf, 0xff, 0x1c, 0x96, 0xd9, 0x17, 0xbc,
+	0xbb, 0x7a, 0x2c, 0xd3, 0x87, 0xa4, 0xfa, 0x0d, 0xa3, 0xaf, 0xc1, 0x4a, 0x0a, 0x17, 0xbf, 0x6d,
+	0x76, 0xa0, 0x4d, 0xd3, 0x6c, 0xa6, 0x51, 0x1c, 0x8c, 0x5f, 0x91, 0x28, 0x72, 0x87, 0x44, 0xc9,
+	0x3e, 0x9a, 0x10, 0xae, 0x0a, 0x57, 0xf1, 0x97, 0x0c, 0xec, 0xd1, 0xa0, 0xd0, 0xc0, 0x8d, 0x5d,
+	0x26, 0x0d, 0x51, 0x77, 0xcb, 0xc1, 0xc2, 0x87, 0xb8, 0x05, 0x37, 0xf8, 0x81, 0x3f, 0x21, 0x5a,
+	0x0f, 0x19, 0xd0, 0xfc, 0x3d, 0xa8, 0x69, 0x80, 0x6f, 0x31, 0xf2, 0xe7, 0x00, 0x2f, 0xc9, 0xe5,
+	0x5e, 0xd0, 0x77, 0xe3, 0x20, 0xc4, 0x53, 0x87, 0x1e, 0xf7, 0x53, 0x77, 0xec, 0xf1, 0x6d, 0x99,
+	0xc7, 0x53, 0x87, 0x6d, 0xec, 0xd4, 0xd2, 0xe8, 0x92, 0xfd, 0x73, 0xa8, 0xbd, 0x24, 0x97, 0x3b,
+	0x84, 0x09, 0xa1, 0x20, 0xa4, 0x81, 0x6b, 0xf7, 0x02, 0x15, 0x2e, 0x9a, 0xd1, 0x14, 0xf1, 0x81,
+	0x6d, 0x58, 0xc4, 0xa6, 0x51, 0xd0, 0xe7, 0x8a, 0x91, 0x50, 0x2c, 0x93, 0x21, 0xed, 0xfb, 0x30,
+	0x7f, 0xfc, 0xfe, 0x60, 0x1a, 0x27, 0x52, 0xca, 0x10, 0x0e, 0x8d, 0xc9, 0x3b, 0x87, 0x8d, 0xc0,
+	0xa5, 0xec, 0x5f, 0x19, 0x50, 0xef, 0x79, 0x43, 0x5f, 0x19, 0xf8, 0x13, 0x28, 0xe1, 0x08, 0x03,
+	0x12, 0xf5, 0x53, 0xde, 0x09, 0x7d, 0x82, 0x98, 0x72, 0xe5, 0xf9, 0xc3, 0x11, 0x71, 0xe2, 0x0b,
+	0xe2, 0xbe, 0xe3, 0x17, 0xd3, 0x2a, 0xd4, 0x85, 0xa7, 0x8f, 0x0f, 0x54, 0xe4, 0xbc, 0xb0, 0xc0,
+	0xd2, 0xf4, 0xb8, 0xda, 0x52, 0x15, 0x39, 0x99, 0x74, 0xa2, 0x78, 0x37, 0x79, 0x43, 0xca, 0x3a,
+	0xcc, 0x16, 0xc1, 0x90, 0x9e, 0x9f, 0x24, 0xf5, 0x2d, 0x70, 0x1a, 0x2d, 0xe2, 0x5c, 0x8f, 0xc8,
+	0x6f, 0x71, 0x70, 0xa4, 0x4e, 0xfc, 0x5e, 0x23, 0xce, 0x7d, 0x80, 0xc8, 0x1b, 0xfa, 0x74, 0xee,
+	0x42, 0x99, 0x16, 0x41, 0x75, 0x7d, 0x95, 0xf6, 0x26, 0x94, 0x18, 0xae, 0x68, 0x42, 0xa5, 0x8a,
+	0x7b, 0xe1, 0x44, 0xde, 0x90, 0x1d, 0xea, 0xaa, 0xfd, 0x18, 0x2a, 0xbb, 0x38, 0x7c, 0x8f, 0x76,
+	0xc7, 0xe9, 0xf1, 0x45, 0x31, 0x38, 0x6e, 0x6a, 0xe4, 0x0d, 0x75, 0x52, 0xfe, 0x04, 0x1a, 0xca,
+	0x37, 0x14, 0xf1, 0x7d, 0xa8, 0xb1, 0x55, 0xb0, 0x8e, 0xe9, 0x7c, 0x54, 0xa5, 0xbb, 0x7d, 0x0c,
+	0xcd, 0xde, 0x99, 0x1b, 0x92, 0xc1, 0x4b, 0x22, 0xd3, 0x0f, 0xdb, 0xd0, 0x24, 0x93, 0x33, 0x32,
+	0x26, 0xa1, 0x3b, 0xe2, 0x91, 0x1b, 0xbe, 0x50, 0x75, 0x8f, 0x0a, 0xb3, 0xf7, 0xc8, 0xbe, 0x0b,
+	0x4b, 0x0a, 0x56, 0x7e, 0xb2, 0x71, 0xf2, 0xb4, 0x51, 0xba, 0xa6, 0xaa, 0xf6, 0x19, 0xcc, 0xbd,
+	0x8e, 0xdf, 0x07, 0x7a, 0x36, 0x5b, 0x26, 0xb7, 0xb2, 0x20, 0x7c, 0x65, 0xcc, 0x55, 0xec, 0x24,
+	0x5e, 0x12, 0x8d, 0xb5, 0x98, 0xfa, 0x41, 0xd3, 0x5c, 0xd4, 0x6c, 0x64, 0x7a, 0xf1, 0xd9, 0x2f,
+	0xd9, 0xbd, 0xfe, 0xda, 0x8f, 0x26, 0x8a, 0x00, 0xd1, 0x12, 0xf1, 0xe4, 0x21, 0xa1, 0xa6, 0x23,
+	0x6d, 0x4a, 0xf2, 0x1c, 0xfa, 0x54, 0xdc, 0xf3, 0x34, 0x8e, 0xcf, 0xa0, 0xa5, 0x21, 0xe3, 0x2b,
+	0xb4, 0x60, 0x7e, 0x1a, 0xbf, 0x0f, 0xd2, 0x39, 0x04, 0xb8, 0x42, 0x7b, 0x95, 0x49, 0xf6, 0x8e,
+	0x30, 0x72, 0xc4, 0x81, 0xdf, 0x82, 0x95, 0x54, 0x3b, 0x47, 0x96, 0xb5, 0x88, 0xec, 0x13, 0x96,
+	0x9b, 0xf7, 0x1d, 0xd2, 0xfb, 0x50, 0xdd, 0x41, 0x0d, 0x7d, 0x48, 0x78, 0x96, 0x4e, 0x66, 0x69,
+	0xff, 0x1f, 0x34, 0x77, 0x48, 0xe8, 0x9d, 0x13, 0x85, 0x21, 0x94, 0xc3, 0x6f, 0xcc, 0x3a, 0xfc,
+	0x5b, 0xb0, 0xcc, 0xbe, 0xdb, 0x27, 0xef, 0x63, 0xe5, 0xdb, 0x1c, 0x39, 0x64, 0x7f, 0x0f, 0xd6,
+	0x0f, 0x31, 0x35, 0x28, 0x3a, 0x53, 0x52, 0xa3, 0xc5, 0x07, 0x75, 0x58, 0xc0, 0x94, 0x73, 0xf2,
+	0x9e, 0xb3, 0xc8, 0x16, 0x58, 0x79, 0x9d, 0x73, 0xd3, 0x20, 0xef, 0x83, 0xd9, 0x8d, 0x62, 0x6f,
+	0x4c, 0x95, 0x6e, 0xa2, 0x64, 0x2d, 0xe1, 0x6e, 0x3a, 0x2c, 0x6c, 0xc9, 0xcc, 0x6e, 0x7b, 0x1b,
+	0x5a, 0x5a, 0x57, 0x8e, 0x2f, 0x9d, 0xd0, 0x69, 0x08, 0x17, 0xb0, 0x68, 0xbd, 0x48, 0x62, 0xf3,
+	0x45, 0xfb, 0x4f, 0x0a, 0xd0, 0x78, 0x36, 0xf5, 0x07, 0x87, 0xd1, 0x49, 0xac, 0x5e, 0x15, 0xd1,
+	0x89, 0x48, 0xdb, 0xfe, 0x31, 0x54, 0xf0, 0x8c, 0x33, 0x76, 0x16, 0xb2, 0xe1, 0x13, 0x61, 0xfc,
+	0xea, 0x9f, 0x3e, 0x38, 0x72, 0x2f, 0x0e, 0x58, 0xc7, 0xdc, 0x3c, 0xde, 0x62, 0x6e, 0xca, 0x29,
+	0xf3, 0x08, 0x5e, 0x11, 0xe5, 0x9c, 0xff, 0x80, 0x28, 0xa7, 0xc2, 0x06, 0xd4, 0x58, 0xb4, 0x3e,
+	0x83, 0x46, 0x7a, 0x36, 0xdf, 0x94, 0xd8, 0xbb, 0x03, 0xcd, 0x64, 0x41, 0xc9, 0x6d, 0x8e, 0xd1,
+	0x5d, 0x54, 0x13, 0x12, 0x9a, 0xa0, 0x76, 0x44, 0x79, 0xd0, 0xc9, 0x9c, 0xf2, 0x79, 0xfb, 0x13,
+	0x68, 0xa0, 0x80, 0x54, 0x29, 0x9a, 0x87, 0xc4, 0x7e, 0x02, 0xcd, 0xa4, 0x5f, 0x32, 0x1a, 0xca,
+	0x61, 0x7d, 0xb4, 0x15, 0xa8, 0xf1, 0x46, 0xcf, 0x97, 0x7b, 0x50, 0xb3, 0xb7, 0xa0, 0xf5, 0xcc,
+	0xf3, 0xdd, 0x91, 0xf7, 0x47, 0xe4, 0x1b, 0xc7, 0xea, 0xc0, 0xb2, 0xde, 0xf7, 0xaa, 0xf1, 0xf8,
+	0x15, 0x71, 0x8a, 0x1f, 0x38, 0xf1, 0x7b, 0x2e, 0xa5, 0x9f, 0x41, 0x49, 0x46, 0xa4, 0xd1, 0xe9,
+	0x8f, 0xc9, 0xe4, 0xea, 0x15, 0xd2, 0x84, 0xd2, 0x07, 0x25, 0x98, 0x3b, 0x60, 0xee, 0x11, 0x37,
+	0x22, 0x6c, 0x67, 0xc4, 0xac, 0x01, 0x0a, 0x32, 0x55, 0xe3, 0xb6, 0x12, 0x63, 0x63, 0x32, 0x3a,
+	0x13, 0x12, 0xb7, 0xc0, 0x54, 0xf2, 0x53, 0x85, 0xb6, 0x4e, 0x15, 0x42, 0xfb, 0x3e, 0xb4, 0xb4,
+	0x01, 0x12, 0xe1, 0x9d, 0x7c, 0xc2, 0x74, 0x65, 0xbb, 0x0b, 0xcb, 0x47, 0x64, 0xf4, 0x5d, 0x67,
+	0x83, 0x0a, 0x59, 0x0a, 0x0d, 0xd7, 0x96, 0xf6, 0xa1, 0x8c, 0xa2, 0x93, 0x4e, 0xe7, 0xdb, 0x2e,
+	0x51, 0x9f, 0x2f, 0x5b, 0x5a, 0x8b, 0x25, 0x8b, 0x51, 0x7c, 0x52, 0xfe, 0xfe, 0x04, 0x4c, 0xb5,
+	0x51, 0x66, 0x1e, 0x56, 0x79, 0x20, 0x50, 0x15, 0xe8, 0x4d, 0x45, 0xa0, 0xd3, 0x0f, 0xec, 0x5d,
+	0x58, 0xdb, 0xc3, 0xbc, 0xee, 0x1c, 0x39, 0xa6, 0x25, 0x53, 0x24, 0x09, 0xe0, 0x05, 0xe1, 0x06,
+	0x0f, 0xce, 0x49, 0x78, 0x11, 0x7a, 0xdc, 0x68, 0x2b, 0x61, 0x12, 0x63, 0x16, 0x15, 0xa7, 0xc4,
+	0x3f, 0x34, 0x60, 0xb1, 0xc3, 0xce, 0xa7, 0xcc, 0x41, 0x32, 0x44, 0x36, 0x30, 0x79, 0x1f, 0x13,
+	0xc6, 0xb1, 0x2c, 0xdd, 0x32, 0xf1, 0x95, 0xdd, 0x80, 0xd5, 0xb1, 0x1b, 0xc5, 0x24, 0x74, 0xa8,
+	0x08, 0xf6, 0xfc, 0x21, 0x09, 0x27, 0xa1, 0xf0, 0x12, 0xd7, 0x18, 0x1f, 0xc4, 0x24, 0x44, 0x4e,
+	0xc5, 0x1e, 0x7d, 0x99, 0x7f, 0x41, 0x61, 0x9e, 0x9f, 0x81, 0xcd, 0x8b, 0x9b, 0xf8, 0xc2, 0x8d,
+	0xfb, 0x67, 0x4c, 0xad, 0xa6, 0x56, 0x3d, 0x35, 0x5d, 0x76, 0xc7, 0x93, 0x20, 0x8c, 0xf9, 0x44,
+	0x05, 0x1d, 0xd6, 0xa0, 0x71, 0xe2, 0x85, 0xf1, 0xd9, 0xc0, 0xbd, 0x54, 0xab, 0x7e, 0x6a, 0xff,
+	0x37, 0x17, 0xd2, 0x80, 0xc5, 0x41, 0x78, 0xe9, 0x84, 0x53, 0x91, 0x73, 0xf5, 0x1e, 0x56, 0x52,
+	0x93, 0xe1, 0x1b, 0x7b, 0x33, 0x11, 0x74, 0xec, 0x2a, 0xab, 0xcb, 0x8c, 0x52, 0x46, 0xde, 0x1b,
+	0xb0, 0xca, 0x51, 0x39, 0x92, 0x36, 0x78, 0x0f, 0x33, 0xb9, 0x51, 0x56, 0xe1, 0x9e, 0xaf, 0xc1,
+	0x8b, 0xf4, 0x8e, 0xbe, 0xc3, 0x54, 0x03, 0x8e, 0x4e, 0x2d, 0x5f, 0x48, 0x16, 0x6b, 0xff, 0x2e,
+	0x2c, 0xeb, 0x9d, 0x12, 0x33, 0x8f, 0xcf, 0x2e, 0x6d, 0xe6, 0xf1, 0xae, 0x98, 0x80, 0xf4, 0x9c,
+	0xc4, 0x98, 0xbd, 0x88, 0x29, 0x50, 0xaa, 0x1f, 0xff, 0x0f, 0x61, 0x2d, 0x03, 0x49, 0xea, 0x66,
+	0x42, 0xde, 0xee, 0x8c, 0x45, 0xf4, 0xaf, 0x84, 0x66, 0xa1, 0x6c, 0x3e, 0xf5, 0x7c, 0x2f, 0x3a,
+	0x23, 0x03, 0xae, 0x16, 0x60, 0xf6, 0x4d, 0x18, 0x0c, 0x65, 0xec, 0xcd, 0xb0, 0x7f, 0x00, 0x4b,
+	0x3b, 0xe4, 0x64, 0x3a, 0xdc, 0x23, 0xe7, 0x49, 0x12, 0x47, 0x15, 0xe6, 0xa2, 0xb3, 0xe0, 0x82,
+	0xe3, 0x33, 0x01, 0x46, 0x08, 0x75, 0xa2, 0x09, 0xe9, 0x73, 0x0f, 0xcc, 0x7d, 0x30, 0xd5, 0xcf,
+	0x14, 0xc1, 0x39, 0x3d, 0x71, 0xa2, 0xcb, 0x28, 0x26, 0x63, 0xe1, 0x01, 0xc4, 0xdc, 0xaa, 0x69,
+	0x1c, 0x4c, 0xbc, 0x51, 0xc0, 0xfd, 0x10, 0x62, 0x69, 0xf7, 0x61, 0x2d, 0x03, 0x49, 0x5c, 0x41,
+	0x3c, 0x85, 0x9a, 0xb9, 0x64, 0x1e, 0xc0, 0xe6, 0xab, 0x60, 0xe0, 0x9d, 0x5e, 0xe6, 0xa3, 0xc2,
+	0xfe, 0xc4, 0xa7, 0xd9, 0xcf, 0xac, 0xff, 0x4d, 0xb8, 0x3e, 0xa3, 0x3f, 0x3f, 0x7a, 0x0f, 0x60,
+	0xe3, 0x17, 0x53, 0x12, 0x2a, 0xf0, 0x7e, 0x10, 0x4a, 0xf1, 0xc1, 0x83, 0x96, 0xef, 0xc8, 0xa5,
+	0xd0, 0xd1, 0x7e, 0x07, 0x4c, 0xd9, 0x15, 0x1d, 0x77, 0xb4, 0x7b, 0x36, 0x20, 0x5d, 0x83, 0xf9,
+	0x08, 0x21, 0x2c, 0x88, 0x62, 0xff, 0x0a, 0x36, 0xf3, 0x47, 0x49, 0x94, 0xc1, 0x33, 0x32, 0x0d,
+	0xbd, 0x28, 0xf6, 0xfa, 0x1c, 0xc3, 0x7d, 0x58, 0xa0, 0x18, 0x84, 0x52, 0x21, 0xf2, 0x77, 0xb2,
+	0xa3, 0xdb, 0x1d, 0x99, 0x44, 0xb0, 0xeb, 0xa3, 0xbd, 0x93, 0xb0, 0xa5, 0xee, 0xd9, 0xbd, 0x22,
+	0x59, 0xf0, 0xcf, 0x0d, 0xa8, 0xeb, 0x38, 0x4c, 0x33, 0xf3, 0x6d, 0x39, 0x9b, 0xf6, 0x5c, 0x10,
+	0x21, 0x3e, 0x99, 0x9c, 0x5e, 0x4c, 0x25, 0xa7, 0xcb, 0xf8, 0x39, 0x4f, 0xe6, 0xa4, 0x8d, 0xf3,
+	0xa2, 0x06, 0xf0, 0x74, 0xe4, 0x4e, 0x9c, 0x44, 0x31, 0xa9, 0xc9, 0x78, 0x2d, 0x02, 0x98, 0xe7,
+	0xd0, 0x7e, 0x0a, 0x6b, 0x99, 0xe5, 0x71, 0xba, 0xdd, 0x45, 0x57, 0x1c, 0x6b, 0x6b, 0x1b, 0x9a,
+	0x5d, 0xa6, 0x7f, 0x61, 0x1f, 0xc1, 0x5a, 0x8f, 0xc4, 0xcf, 0x08, 0x79, 0xe5, 0xfa, 0xee, 0x90,
+	0xa8, 0x4e, 0x86, 0x0f, 0xa5, 0x91, 0xc2, 0x5b, 0x05, 0x21, 0xd1, 0xb3, 0x38, 0x39, 0x5b, 0x1d,
+	0x52, 0x77, 0xb7, 0xce, 0x4b, 0xdf, 0x6d, 0x93, 0x5b, 0xb0, 0xa4, 0x60, 0xe4, 0xc3, 0x74, 0xc0,
+	0xa4, 0x7c, 0x75, 0x35, 0xd3, 0x52, 0x61, 0x3f, 0xf4, 0x83, 0x90, 0xf0, 0xec, 0x0c, 0xe6, 0x26,
+	0x66, 0xab, 0x70, 0xa0, 0xf1, 0x42, 0xcc, 0xea, 0x88, 0x44, 0xd3, 0x51, 0xee, 0x44, 0xeb, 0xb0,
+	0xa0, 0x68, 0xc6, 0x86, 0x32, 0xf1, 0xe2, 0x37, 0x4d, 0xfc, 0x09, 0xb4, 0xb4, 0x39, 0xca, 0xad,
+	0x5b, 0x0c, 0xe9, 0x70, 0x62, 0xe7, 0x56, 0x85, 0x47, 0x50, 0x9f, 0x0d, 0xea, 0x0f, 0xd2, 0xa9,
+	0x42, 0x9d, 0xd8, 0x42, 0x6c, 0xfc, 0x18, 0x56, 0xd3, 0x00, 0x8e, 0xfb, 0xb6, 0xf0, 0x84, 0x33,
+	0xd3, 0x49, 0x18, 0xc6, 0x2c, 0x29, 0x88, 0x76, 0xb5, 0x97, 0x68, 0x3e, 0xb5, 0x86, 0xef, 0x07,
+	0xd0, 0x4c, 0x9a, 0x3e, 0x1c, 0x53, 0x17, 0xac, 0xee, 0x7b, 0xbc, 0x8b, 0x64, 0x22, 0x4f, 0xff,
+	0xdd, 0x74, 0xf2, 0xad, 0x4f, 0xe0, 0x2b, 0xa8, 0x69, 0x08, 0x3e, 0x9c, 0x2f, 0x45, 0x54, 0xe6,
+	0x84, 0x7e, 0x27, 0xdd, 0x06, 0x75, 0x0d, 0x5d, 0x84, 0x91, 0x74, 0xa5, 0x5b, 0x3a, 0xca, 0xad,
+	0x75, 0xb6, 0xdf, 0x40, 0xe3, 0xd5, 0x74, 0x14, 0x7b, 0xd8, 0xca, 0xa7, 0x73, 0x0f, 0x2a, 0xc9,
+	0x74, 0xc4, 0xd7, 0xb9, 0xf3, 0x59, 0x87, 0xa5, 0x31, 0x7e, 0xec, 0x64, 0x67, 0xb5, 0x0e, 0x6b,
+	0x09, 0x4a, 0x46, 0x35, 0x41, 0xfd, 0xaf, 0xc0, 0x4c, 0x40, 0x3d, 0xdf, 0x9d, 0x44, 0x67, 0x01,
+	0xda, 0xc0, 0x2d, 0xee, 0x0d, 0x4a, 0xcd, 0xdd, 0xc8, 0x9e, 0x75, 0xb1, 0xd0, 0xcf, 0x66, 0x8d,
+	0x9f, 0xf0, 0x58, 0x6a, 0x71, 0xf6, 0x04, 0xda, 0x47, 0x24, 0x8a, 0x83, 0x90, 0x24, 0x8d, 0x62,
+	0x07, 0x3f, 0xcd, 0xd0, 0x6d, 0xf6, 0xd8, 0x2f, 0xae, 0x99, 0x1b, 0x33, 0x57, 0xcf, 0x12, 0x27,
+	0x59, 0x8b, 0xfd, 0x29, 0xac, 0xf0, 0x11, 0xc5, 0x68, 0x89, 0x85, 0x8a, 0x0e, 0xd2, 0x90, 0x01,
+	0x07, 0xdc, 0x9c, 0xdd, 0x81, 0xf6, 0x1b, 0x12, 0x7a, 0xa7, 0x97, 0xea, 0xfc, 0xf8, 0x17, 0x1f,
+	0xbc, 0x33, 0xf6, 0x29, 0xb4, 0x9e, 0x93, 0x98, 0x5e, 0xd8, 0x6a, 0x76, 0x02, 0xd5, 0x05, 0xfb,
+	0xa3, 0xe9, 0x80, 0x38, 0xc3, 0x80, 0xc5, 0x39, 0x49, 0x94, 0xb8, 0x7a, 0x05, 0xec, 0x8c, 0xb8,
+	0x13, 0x67, 0x12, 0x06, 0xa7, 0x9e, 0x10, 0x81, 0x78, 0x1f, 0xe0, 0x64, 0x47, 0xc1, 0xd0, 0x19,
+	0xd1, 0x8f, 0x98, 0x15, 0xf3, 0x13, 0x00, 0x1e, 0x14, 0xeb, 0x91, 0xb4, 0x46, 0xab, 0xc6, 0x8a,
+	0x0b, 0xb9, 0xd9, 0xf9, 0x0f, 0xa1, 0x81, 0xe7, 0x1a, 0xf3, 0x70, 0x43, 0x1e, 0xb0, 0xd0, 0x51,
+	0x24, 0x4a, 0x01, 0x13, 0x61, 0xff, 0xa2, 0x00, 0xcb, 0xfa, 0xba, 0x92, 0xb2, 0x3f, 0x51, 0x29,
+	0xc0, 0xbe, 0xfc, 0x21, 0x2c, 0x50, 0xe7, 0xd1, 0x90, 0x0f, 0x7d, 0x97, 0x0f, 0x9d, 0xf7, 0x35,
+	0xcb, 0x94, 0x1d, 0x32, 0xe3, 0xf8, 0x2e, 0x54, 0x45, 0x28, 0x30, 0x22, 0xb2, 0x06, 0x75, 0x49,
+	0x9f, 0x39, 0x2e, 0x76, 0x0b, 0x20, 0x12, 0x93, 0x17, 0x09, 0x5d, 0x82, 0xeb, 0xd2, 0xab, 0xa2,
+	0xd5, 0x52, 0x94, 0x9c, 0x0e, 0x9e, 0x04, 0x1e, 0x23, 0x36, 0x01, 0x94, 0x5d, 0x58, 0x10, 0xc6,
+	0xa2, 0x46, 0xfd, 0x45, 0x6a, 0x74, 0xe0, 0x5d, 0x29, 0x29, 0x8f, 0x39, 0x81, 0x65, 0xeb, 0x53,
+	0xa8, 0xa8, 0xd3, 0x9e, 0x6d, 0xd3, 0x97, 0xa9, 0x4d, 0xbf, 0x05, 0x4b, 0xdb, 0x87, 0xaf, 0x0f,
+	0x19, 0x56, 0xc1, 0x0e, 0x2b, 0x50, 0x1b, 0x4c, 0x13, 0xe3, 0x31, 0xe2, 0x2c, 0xf8, 0x31, 0x98,
+	0x6a, 0xdf, 0x84, 0xc4, 0x62, 0x52, 0xcc, 0x98, 0xfe, 0x1e, 0xac, 0x6a, 0xe2, 0x70, 0xe7, 0x44,
+	0xb9, 0xff, 0x68, 0x89, 0x3b, 0x8d, 0x5d, 0x31, 0x9d, 0x70, 0x1d, 0xd6, 0x32, 0x9d, 0xf9, 0xd5,
+	0xf6, 0x04, 0x5a, 0x4c, 0xc5, 0xe7, 0x39, 0x3b, 0x89, 0xa6, 0x94, 0xa4, 0x53, 0x18, 0xb9, 0x69,
+	0x27, 0x2c, 0xfa, 0xeb, 0xc1, 0xca, 0x2f, 0xa6, 0x1e, 0x89, 0xfa, 0xe9, 0x12, 0x86, 0x9c, 0x40,
+	0x56, 0x5e, 0x18, 0xfc, 0x6a, 0x45, 0x00, 0xaf, 0xae, 0x31, 0x49, 0xaa, 0x06, 0xd2, 0x43, 0xf1,
+	0x45, 0x3c, 0x83, 0x8d, 0x67, 0x41, 0xc8, 0x83, 0xaf, 0xd4, 0xf2, 0xf3, 0x54, 0x1b, 0xf2, 0x83,
+	0x2f, 0x87, 0x1b, 0xb0, 0x99, 0x8f, 0x87, 0x8f, 0xb3, 0x42, 0x0f, 0xf6, 0x53, 0x12, 0xc5, 0x4f,
+	0xd1, 0xae, 0x15, 0x32, 0xf5, 0x67, 0xb0, 0xac, 0x37, 0x27, 0xd6, 0xbe, 0x52, 0xad, 0x73, 0x45,
+	0x75, 0x8a, 0xfd, 0x3d, 0x86, 0x18, 0x01, 0x18, 0x62, 0x55, 0x02, 0x33, 0x5a, 0x67, 0x16, 0xc6,
+	0xd9, 0x62, 0xc3, 0x25, 0x9d, 0x67, 0x0f, 0x67, 0x7f, 0x0c, 0x0d, 0xd1, 0x57, 0x71, 0x25, 0xe6,
+	0x74, 0x6b, 0x26, 0xdd, 0x12, 0x16, 0x40, 0x0f, 0xcc, 0x89, 0xcc, 0xb3, 0xac, 0xda, 0xff, 0xc0,
+	0x80, 0x25, 0xcc, 0x40, 0x66, 0xba, 0xbe, 0x82, 0x90, 0xc7, 0x8b, 0x93, 0xa4, 0x88, 0x74, 0x48,
+	0xa9, 0x20, 0x5e, 0x5f, 0xe0, 0x21, 0x5d, 0x25, 0x2b, 0xb3, 0x09, 0x25, 0x9a, 0xcd, 0x8f, 0x2d,
+	0x73, 0x42, 0xab, 0xe5, 0x11, 0x77, 0x69, 0x28, 0x2b, 0xfb, 0xb7, 0x20, 0x8e, 0x2f, 0xfd, 0x8a,
+	0x79, 0x75, 0x16, 0xa9, 0x67, 0xe2, 0xe7, 0x60, 0xaa, 0xb3, 0x4b, 0xc8, 0x92, 0x99, 0x5e, 0x13,
+	0x4a, 0x98, 0x8c, 0x3a, 0x71, 0x79, 0x11, 0x1e, 0x1d, 0xb3, 0xef, 0xfa, 0x7d, 0x32, 0xe2, 0x7e,
+	0x04, 0xee, 0xe5, 0xe8, 0x5d, 0x10, 0x32, 0x91, 0x16, 0xd4, 0x6b, 0x00, 0xda, 0x40, 0x5d, 0xff,
+	0x9a, 0xff, 0xc4, 0xc8, 0xf7, 0x9f, 0xa4, 0xf3, 0xb2, 0x95, 0x4c, 0x6a, 0xea, 0x73, 0x66, 0xce,
+	0xe2, 0x3f, 0x37, 0x60, 0x9e, 0xe2, 0xcd, 0x3a, 0xf0, 0x85, 0xab, 0xfe, 0x82, 0x4c, 0x04, 0x0e,
+	0x3d, 0xd9, 0x95, 0xd1, 0xf0, 0x36, 0x2c, 0x70, 0xb7, 0xdc, 0x9c, 0x26, 0x31, 0x95, 0xd9, 0xb6,
+	0xa1, 0x79, 0x12, 0x06, 0xee, 0xa0, 0x8f, 0x6a, 0xbf, 0xe6, 0x41, 0x40, 0x47, 0xa2, 0xe2, 0xea,
+	0x57, 0x2b, 0xce, 0xe6, 0xed, 0xc7, 0xcc, 0xb1, 0x23, 0xe8, 0xc0, 0x69, 0xba, 0x09, 0x0b, 0x11,
+	0x6d, 0xe1, 0xd7, 0x60, 0x55, 0x1d, 0xcf, 0x7e, 0x02, 0x0d, 0x9a, 0xb0, 0xab, 0x38, 0x8f, 0x6b,
+	0x30, 0x3f, 0x09, 0x83, 0x13, 0x51, 0x90, 0xa4, 0x26, 0x12, 0x67, 0x33, 0x6d, 0x7f, 0x06, 0xcd,
+	0xe4, 0xfb, 0xa4, 0x0a, 0x4f, 0x4b, 0x05, 0x75, 0x2f, 0x79, 0x3c, 0xa3, 0x05, 0x15, 0x91, 0x33,
+	0x74, 0x4a, 0x44, 0x26, 0xf3, 0x5d, 0x58, 0x56, 0xb2, 0x53, 0xd3, 0x2a, 0xbb, 0x32, 0xd4, 0xaf,
+	0x61, 0x25, 0xd5, 0x31, 0xf1, 0x21, 0x5c, 0x7d, 0x7f, 0xea, 0x79, 0xb3, 0xc6, 0xac, 0xbc, 0x59,
+	0xfb, 0x1d, 0xac, 0xb1, 0x4c, 0x13, 0x94, 0x34, 0xba, 0x15, 0x7d, 0x57, 0x66, 0xe0, 0xb0, 0xda,
+	0xc6, 0x35, 0x45, 0x26, 0xb1, 0x9e, 0x3c, 0xd9, 0xe5, 0x83, 0x05, 0x98, 0x05, 0xed, 0xec, 0x60,
+	0x5c, 0x78, 0x4d, 0x60, 0xe5, 0x35, 0xab, 0x40, 0x4f, 0x49, 0xea, 0x9c, 0x0a, 0xf4, 0xc2, 0x55,
+	0x15, 0xe8, 0x1f, 0x3c, 0x9b, 0x36, 0xac, 0xa6, 0x47, 0xe4, 0x73, 0xb9, 0x09, 0xd5, 0x43, 0x17,
+	0x05, 0x48, 0x8f, 0x96, 0x32, 0xd1, 0x7d, 0x71, 0x2f, 0x31, 0xed, 0x44, 0xbe, 0x55, 0xb0, 0xc0,
+	0x3a, 0x88, 0x6b, 0x47, 0x54, 0x8d, 0xcf, 0x78, 0xa5, 0x45, 0xa6, 0xcf, 0xe2, 0xd5, 0xe7, 0xf9,
+	0x89, 0x7f, 0xb5, 0x6c, 0x6f, 0x82, 0x25, 0xed, 0x17, 0x14, 0x0f, 0xb4, 0x44, 0x54, 0x1e, 0xe9,
+	0xff, 0x61, 0x40, 0x59, 0xb6, 0x22, 0x5a, 0xe4, 0x32, 0xfa, 0x82, 0x8e, 0xe3, 0x8b, 0x07, 0x73,
+	0x56, 0x33, 0x09, 0x13, 0x32, 0x15, 0xcc, 0x1d, 0xb3, 0x38, 0xda, 0xfc, 0xcc, 0xb7, 0x63, 0xf0,
+	0x61, 0x95, 0xd5, 0x60, 0x1a, 0x0f, 0x03, 0xa5, 0xc6, 0xe6, 0x1b, 0xb3, 0x4c, 0xf1, 0x23, 0xf1,
+	0x62, 0x82, 0xf3, 0xc1, 0xe5, 0xd2, 0xf7, 0x00, 0xc8, 0xb9, 0xdc, 0x44, 0xbd, 0x22, 0x48, 0x2e,
+	0x92, 0x96, 0xca, 0xd6, 0xa0, 0xd2, 0x8b, 0x03, 0xa1, 0x7c, 0xdb, 0x75, 0xa8, 0xb2, 0x9f, 0x7c,
+	0x7f, 0x7e, 0x0d, 0xcd, 0x4c, 0x69, 0xaf, 0x09, 0xe0, 0x93, 0xf7, 0xb1, 0x13, 0x92, 0x38, 0x14,
+	0x25, 0x39, 0xb4, 0x84, 0xa0, 0xff, 0x2e, 0x38, 0x3d, 0xe5, 0xfb, 0x82, 0xa5, 0x1f, 0x28, 0x60,
+	0xf8, 0xb7, 0x64, 0x30, 0xeb, 0x8c, 0xff, 0x4a, 0x1c, 0x0b, 0xc4, 0xdd, 0xa1, 0x35, 0x91, 0x8a,
+	0x73, 0x09, 0xbd, 0x1f, 0xe7, 0x42, 0x58, 0x88, 0xd8, 0x3d, 0xdb, 0xe3, 0x3b, 0x30, 0x37, 0xf2,
+	0xf8, 0x7b, 0x3e, 0x75, 0xad, 0xe8, 0x9a, 0x61, 0x41, 0x69, 0x95, 0x9c, 0x03, 0x15, 0x3b, 0x5f,
+	0xdb, 0x1a, 0x0b, 0x15, 0x66, 0xc6, 0xb5, 0x7f, 0x01, 0xab, 0x69, 0x40, 0x52, 0xd0, 0xe2, 0x8e,
+	0x46, 0xc1, 0x05, 0x0e, 0xac, 0xd6, 0xe1, 0x23, 0x03, 0x60, 0x3b, 0x5d, 0x66, 0x91, 0xa9, 0xcc,
+	0x27, 0xb8, 0x1f, 0x03, 0xee, 0xc6, 0xfa, 0x0b, 0x03, 0xea, 0xa9, 0x7a, 0xf0, 0x35, 0x68, 0x0c,
+	0x83, 0x00, 0x4b, 0x3c, 0x44, 0x53, 0x92, 0xd0, 0x85, 0x09, 0xba, 0x67, 0xc1, 0x68, 0xa0, 0x7a,
+	0x6f, 0x50, 0x27, 0x8d, 0x47, 0xfd, 0x88, 0xa7, 0x11, 0xf1, 0x02, 0xce, 0x15, 0xa8, 0xb1, 0x56,
+	0x91, 0x14, 0xc6, 0xf2, 0x50, 0x56, 0xa1, 0xce, 0x9a, 0x89, 0x3f, 0x08, 0x68, 0x9e, 0x4d, 0x41,
+	0x64, 0x15, 0x71, 0x24, 0xac, 0xf6, 0x82, 0x1b, 0x3c, 0x73, 0x58, 0x76, 0xb0, 0xcc, 0x73, 0xa8,
+	0x70, 0xcd, 0x93, 0x58, 0xb9, 0xd4, 0x95, 0xfb, 0xb5, 0x94, 0x93, 0xb1, 0xbe, 0x28, 0x8c, 0x04,
+	0x7e, 0x57, 0x2f, 0x88, 0xcc, 0x7c, 0x79, 0x9b, 0xcf, 0x0b, 0x9f, 0x94, 0x7a, 0xe9, 0xcf, 0x89,
+	0xdc, 0x2a, 0x9a, 0x20, 0x57, 0x14, 0x17, 0x5d, 0x8e, 0xb6, 0x90, 0x73, 0x71, 0xdb, 0x2f, 0x61,
+	0x25, 0x35, 0x5d, 0x25, 0x99, 0x8d, 0x9d, 0xcd, 0x62, 0x62, 0xbc, 0xf4, 0xc5, 0xad, 0x59, 0xca,
+	0x45, 0xf6, 0x1c, 0x4c, 0x4c, 0x32, 0x39, 0x0e, 0xb4, 0xaa, 0x97, 0x0d, 0x98, 0xc7, 0x0b, 0x85,
+	0xf0, 0x83, 0x56, 0x55, 0x72, 0x4f, 0x49, 0x7e, 0xaa, 0x8c, 0xfd, 0x2f, 0x0d, 0xa8, 0xa8, 0x19,
+	0x56, 0x77, 0x60, 0x91, 0x0b, 0x0c, 0x9e, 0x74, 0xaf, 0xa6, 0x61, 0xf1, 0xbc, 0x2a, 0xdc, 0x93,
+	0x90, 0x44, 0xc1, 0x88, 0x3b, 0xeb, 0x50, 0xdc, 0x2c, 0x88, 0xe2, 0x2d, 0x9e, 0x71, 0x23, 0x01,
+	0xf3, 0x02, 0x90, 0xae, 0x7f, 0x61, 0x51, 0x06, 0x9e, 0x9b, 0xa6, 0xa7, 0xad, 0x31, 0x8e, 0x9c,
+	0x55, 0x20, 0xa8, 0x65, 0xaa, 0x61, 0x48, 0x5c, 0x9d, 0x5a, 0x03, 0x16, 0xc7, 0x2c, 0x71, 0x26,
+	0x29, 0x32, 0x15, 0x12, 0x30, 0x0a, 0xa6, 0x61, 0x9f, 0x68, 0x29, 0x05, 0x1f, 0xc1, 0x5c, 0x5f,
+	0xf8, 0xc3, 0xeb, 0x89, 0x83, 0x29, 0x41, 0xb8, 0x1d, 0x0c, 0x50, 0x49, 0x6f, 0x3f, 0x27, 0x71,
+	0x6e, 0x81, 0xd6, 0xb7, 0x2a, 0xb8, 0xfe, 0xbb, 0x05, 0x58, 0xcf, 0x41, 0x24, 0x93, 0x07, 0xf2,
+	0x9e, 0x67, 0x81, 0xd9, 0xcf, 0xb3, 0x94, 0x85, 0x52, 0xa5, 0xbc, 0x19, 0x22, 0xb3, 0xdf, 0x45,
+	0xb6, 0xa2, 0x7c, 0xbc, 0x66, 0x31, 0x0d, 0x11, 0x92, 0x9d, 0xef, 0xdd, 0x8c, 0x67, 0x65, 0xe6,
+	0xaf, 0x78, 0x56, 0xe6, 0xff, 0xa8, 0x76, 0x4b, 0x4d, 0x3a, 0x66, 0x2a, 0xcf, 0xbf, 0x37, 0x60,
+	0x25, 0xbf, 0x44, 0xed, 0xaa, 0xca, 0xb2, 0x85, 0x6f, 0xaa, 0x2c, 0x9b, 0x55, 0x63, 0x39, 0xa3,
+	0x24, 0x53, 0xde, 0xce, 0x39, 0xa5, 0x4f, 0x39, 0x7a, 0x86, 0x71, 0x85, 0x9e, 0x61, 0x47, 0xd4,
+	0xf1, 0xbb, 0x1d, 0xf8, 0xfe, 0xee, 0x78, 0xe2, 0x7a, 0x21, 0xf3, 0xfc, 0x26, 0x21, 0x13, 0x42,
+	0x06, 0x49, 0x4d, 0xf3, 0x20, 0x0c, 0x26, 0xb4, 0x44, 0x87, 0xce, 0xcc, 0xc0, 0xa6, 0xdf, 0x78,
+	0x31, 0xc6, 0xba, 0xc6, 0xc2, 0xf0, 0xc4, 0xc0, 0x8a, 0x1b, 0x13, 0xbf, 0x7f, 0xe9, 0x8c, 0xc5,
+	0x9c, 0x32, 0xf7, 0x12, 0x4d, 0x3c, 0xcb, 0x0c, 0xca, 0xaf, 0x8e, 0xe7, 0xb0, 0x44, 0x13, 0x91,
+	0xbc, 0x21, 0x89, 0x62, 0xe5, 0xba, 0x1a, 0xd0, 0x06, 0x2e, 0xb6, 0x3e, 0x24, 0xcd, 0xe3, 0x2e,
+	0x98, 0x2a, 0xa2, 0xc4, 0xe2, 0xc2, 0x40, 0x38, 0x55, 0x2f, 0xb9, 0x64, 0xf9, 0x29, 0xb4, 0x0e,
+	0xc3, 0x00, 0x2f, 0xa3, 0x03, 0x5f, 0xb1, 0x68, 0x31, 0x89, 0x27, 0x8a, 0x82, 0xbe, 0x43, 0x13,
+	0xd7, 0xa4, 0xb8, 0x0c, 0xb0, 0x0f, 0x5a, 0x6c, 0x27, 0xfc, 0xf3, 0x63, 0x58, 0xd6, 0x3f, 0x4f,
+	0xb4, 0x69, 0x7a, 0x97, 0x2b, 0x1f, 0x14, 0x45, 0x00, 0x9d, 0x02, 0xce, 0x02, 0xee, 0x4d, 0xc3,
+	0x49, 0x91, 0xf7, 0x5e, 0xec, 0xc8, 0x7a, 0xb7, 0x12, 0x5a, 0xab, 0xc7, 0xa1, 0xdb, 0x7f, 0xf7,
+	0x21, 0x69, 0x84, 0x5b, 0x8f, 0xa5, 0xc3, 0x95, 0xbb, 0x63, 0x30, 0x4b, 0x7c, 0x0f, 0x5f, 0x69,
+	0xa9, 0xc0, 0x22, 0xbe, 0xaf, 0xb2, 0xbb, 0xff, 0xbc, 0x69, 0xe0, 0x0f, 0x7c, 0xb2, 0x05, 0x7f,
+	0x14, 0xb6, 0xb6, 0xa0, 0xa6, 0x67, 0xd2, 0xd6, 0xa0, 0xdc, 0x7b, 0xbd, 0xbd, 0xdd, 0xed, 0xee,
+	0x74, 0x79, 0x7e, 0xf9, 0xb3, 0xce, 0xee, 0x5e, 0x77, 0xa7, 0x69, 0x6c, 0x5d, 0xc2, 0x4a, 0x7e,
+	0x32, 0xc6, 0x0d, 0xb0, 0x7a, 0xc7, 0x47, 0x9d, 0xe3, 0xee, 0xf3, 0xb7, 0xce, 0xeb, 0x5e, 0xd7,
+	0x79, 0xbe, 0x77, 0xf0, 0xb4, 0xb3, 0xe7, 0x6c, 0x1f, 0xec, 0x3f, 0xdb, 0x7d, 0xde, 0xbc, 0x86,
+	0x8f, 0xbf, 0x48, 0xf8, 0x5e, 0xe7, 0xe8, 0x79, 0xb7, 0x77, 0xdc, 0x34, 0xcc, 0x16, 0x34, 0x64,
+	0xeb, 0x51, 0x67, 0x7f, 0xe7, 0xe0, 0x55, 0xb3, 0x60, 0xae, 0xc0, 0x92, 0x6c, 0xec, 0xbd, 0xea,
+	0xec, 0xed, 0x61, 0xdf, 0xe2, 0x56, 0x04, 0x15, 0xc5, 0x43, 0x8d, 0x0f, 0x8c, 0xec, 0x1f, 0xec,
+	0x3b, 0xdd, 0x2f, 0x77, 0x7b, 0xc7, 0xb8, 0x0e, 0x3a, 0xcf, 0xbd, 0x83, 0xed, 0x97, 0x38, 0x4f,
+	0xb3, 0x0a, 0xa5, 0xd7, 0xfb, 0xfc, 0x57, 0xc1, 0xac, 0x03, 0x1c, 0x1d, 0x6e, 0x3b, 0xec, 0xed,
+	0x99, 0x26, 0x72, 0x70, 0xad, 0xd7, 0x3d, 0x7a, 0xd3, 0x3d, 0x12, 0x4d, 0x78, 0xc5, 0x37, 0xbf,
+	0xe8, 0xec, 0x22, 0x26, 0xe7, 0xf8, 0xc0, 0xe9, 0x1d, 0x77, 0x8e, 0x8e, 0x9b, 0xff, 0xcb, 0xd8,
+	0xea, 0x40, 0x55, 0x4b, 0x35, 0x2f, 0xc1, 0x1c, 0x52, 0xb1, 0x79, 0x0d, 0x47, 0xe8, 0x6c, 0x6f,
+	0x77, 0x0f, 0x8f, 0xe9, 0x78, 0x15, 0x58, 0xec, 0x75, 0x8f, 0x8f, 0xf7, 0xe8, 0x70, 0x55, 0x28,
+	0x6d, 0x77, 0xf6, 0xb7, 0xbb, 0xf8, 0xab, 0xb8, 0xf5, 0x03, 0x68, 0x66, 0x4c, 0x0c, 0x80, 0x85,
+	0xee, 0x7e, 0xe7, 0xe9, 0x5e, 0x97, 0x6d, 0xcc, 0xce, 0x6e, 0x8f, 0xfe, 0x30, 0x10, 0x7f, 0xe7,
+	0xf5, 0xf1, 0x41, 0xb3, 0xb0, 0xf5, 0x39, 0xd4, 0x53, 0x96, 0x00, 0xae, 0xaf, 0xfb, 0xbc, 0xb3,
+	0xfd, 0xb6, 0x79, 0x8d, 0xd1, 0xa8, 0x73, 0xbc, 0xbb, 0xed, 0x60, 0xea, 0xff, 0x71, 0xd7, 0x79,
+	0xd9, 0x7d, 0xdb, 0x34, 0xb6, 0x76, 0xa1, 0xa6, 0x69, 0x9e, 0x88, 0xfc, 0xd9, 0xc1, 0xd1, 0x17,
+	0x9d, 0xa3, 0x1d, 0xf6, 0x26, 0x0b, 0xff, 0xe1, 0xe0, 0x86, 0x36, 0x0d, 0x44, 0xc9, 0xa6, 0xdd,
+	0x2c, 0xe0, 0xae, 0xef, 0xed, 0xee, 0xbf, 0x64, 0xa0, 0xe2, 0xd6, 0x7d, 0xa6, 0x4b, 0x25, 0x6a,
+	0x1e, 0x76, 0x7e, 0x8a, 0x6f, 0xf3, 0xec, 0xb0, 0x49, 0x77, 0xf6, 0xf6, 0x0e, 0xbe, 0xa0, 0x4c,
+	0xf1, 0x5f, 0x0d, 0x68, 0xa4, 0xee, 0x1f, 0x24, 0xf1, 0xde, 0xc1, 0x76, 0x67, 0x8f, 0xa2, 0x7b,
+	0x7d, 0x84, 0x0b, 0x5d, 0x87, 0x95, 0xdd, 0xfd, 0xde, 0xeb, 0x67, 0xcf, 0x76, 0xb7, 0x77, 0xbb,
+	0xfb, 0xc7, 0xce, 0x76, 0xe7, 0xb0, 0xb3, 0xbd, 0x7b, 0xfc, 0xb6, 0x69, 0x20, 0x77, 0xbc, 0x3e,
+	0xec, 0x1d, 0x1f, 0x75, 0x3b, 0xaf, 0x9c, 0xe3, 0xdd, 0x57, 0xdd, 0x83, 0xd7, 0xc7, 0xcd, 0x02,
+	0x3e, 0x0d, 0xf4, 0x7a, 0xff, 0xe5, 0xfe, 0xc1, 0x17, 0xfb, 0xce, 0x61, 0xe7, 0xed, 0x2b, 0xfc,
+	0x86, 0xbe, 0xcf, 0x86, 0x77, 0x73, 0x4b, 0x40, 0x76, 0xba, 0xb8, 0xff, 0x9d, 0xe3, 0xdd, 0x83,
+	0xfd, 0x26, 0xaa, 0x64, 0x66, 0xef, 0xf0, 0xc5, 0xee, 0xfe, 0x97, 0xce, 0x61, 0xe7, 0xa8, 0xd7,
+	0x75, 0xba, 0x47, 0x47, 0x07, 0x47, 0x4d, 0x7c, 0xe8, 0xa1, 0xb1, 0xbb, 0xbf, 0x7d, 0x70, 0x74,
+	0xd4, 0xdd, 0x3e, 0x76, 0xde, 0x74, 0xf6, 0x5e, 0x77, 0x9b, 0x0b, 0xd8, 0xd8, 0xfd, 0xf2, 0x70,
+	0xf7, 0xe8, 0xad, 0x73, 0x7c, 0x70, 0xe0, 0xf4, 0x0e, 0x0e, 0xf6, 0x9b, 0x8b, 0xe6, 0x75, 0x58,
+	0x3f, 0xee, 0xbe, 0x3a, 0x3c, 0x38, 0xea, 0x1c, 0xbd, 0x15, 0x8f, 0x11, 0xc9, 0x45, 0x94, 0xb6,
+	0xfe, 0xa7, 0x01, 0xcb, 0x79, 0x29, 0xdb, 0x38, 0x25, 0xde, 0xcb, 0x39, 0xea, 0x76, 0x7a, 0x07,
+	0xfb, 0xce, 0xfe, 0x01, 0x7d, 0x09, 0xc7, 0x82, 0xd5, 0x14, 0x40, 0xac, 0xd0, 0x30, 0x37, 0x60,
+	0x2d, 0xf3, 0x91, 0x73, 0x74, 0xf0, 0xfa, 0xb8, 0xcb, 0x96, 0x9f, 0x02, 0xb2, 0xd5, 0x60, 0xe5,
+	0xce, 0xbd, 0x14, 0x24, 0x59, 0x9c, 0xa0, 0xd4, 0x4e, 0xf7, 0xb8, 0xb3, 0xbb, 0xd7, 0x6b, 0x62,
+	0x89, 0xd0, 0x9d, 0x4c, 0x6f, 0x65, 0x1b, 0x9e, 0x76, 0xf6, 0x90, 0x59, 0x9b, 0xf3, 0x39, 0xb3,
+	0x91, 0x6c, 0xbc, 0xf0, 0xf8, 0x9f, 0xff, 0x10, 0xca, 0xb2, 0x6a, 0xd0, 0xfc, 0x0d, 0xd4, 0xb4,
+	0x6a, 0x74, 0x73, 0x43, 0x0b, 0x22, 0xe9, 0x0a, 0x87, 0xb5, 0x99, 0x0f, 0xe4, 0x72, 0xfe, 0xc6,
+	0xdf, 0xf8, 0x8f, 0xff, 0xf9, 0xcf, 0x0a, 0x6d, 0x73, 0xf5, 0xe1, 0xf9, 0x67, 0x0f, 0xf9, 0xd5,
+	0xf6, 0x90, 0x3a, 0xc2, 0xe8, 0x03, 0x38, 0xe6, 0x3b, 0x25, 0xea, 0xc3, 0x06, 0xdb, 0x4c, 0xc7,
+	0x29, 0xb4, 0xd1, 0xae, 0xcf, 0x80, 0xf2, 0xe1, 0x36, 0xe9, 0x70, 0xab, 0xe6, 0xb2, 0x3a, 0x9c,
+	0xb8, 0x3c, 0x4d, 0x42, 0x5d, 0x78, 0xea, 0xfb, 0xac, 0xe6, 0xf5, 0xc4, 0x9f, 0x9e, 0xf3, 0x6e,
+	0xab, 0xb5, 0x9e, 0x7d, 0x31, 0x95, 0x3f, 0xb1, 0x6a, 0xb7, 0xe9, 0x50, 0xa6, 0xd9, 0xc4, 0xa1,
+	0xd4, 0xc7, 0x56, 0xcd, 0x3f, 0x80, 0xb2, 0x7c, 0x1e, 0xd1, 0x5c, 0x53, 0x1e, 0xc9, 0x54, 0xdf,
+	0x8f, 0xb4, 0xda, 0x59, 0x00, 0x5f, 0xc4, 0x06, 0xc5, 0xbc, 0x62, 0x67, 0x30, 0xff, 0xc8, 0xd8,
+	0x32, 0xf7, 0x94, 0xe0, 0xe2, 0xb7, 0x59, 0x49, 0xce, 0xdb, 0xaf, 0x8f, 0x0c, 0xf3, 0xc7, 0x50,
+	0x12, 0x6f, 0x5f, 0x9a, 0xab, 0xf9, 0xcf, 0x79, 0x5a, 0x6b, 0x99, 0x76, 0x7e, 0xf5, 0x75, 0x00,
+	0x92, 0xdc, 0x4e, 0xb3, 0x3d, 0x2b, 0xdd, 0xd3, 0x5a, 0xcf, 0x81, 0x70, 0x14, 0x43, 0x58, 0xca,
+	0xbc, 0xc5, 0x68, 0xde, 0x4c, 0xfa, 0xe7, 0xbe, 0xd2, 0x78, 0x05, 0x42, 0x7b, 0x95, 0xd2, 0xae,
+	0x69, 0xd6, 0x91, 0x76, 0x3e, 0xb9, 0xe0, 0x7e, 0x25, 0xf3, 0x97, 0x34, 0xcc, 0x20, 0x9e, 0x59,
+	0x34, 0x95, 0xb7, 0x45, 0x52, 0xaf, 0x38, 0x5a, 0x56, 0x1e, 0x88, 0x63, 0x5f, 0xa6, 0xd8, 0xeb,
+	0x76, 0x19, 0xb1, 0xd3, 0x37, 0xa6, 0x70, 0x4b, 0x7e, 0x01, 0x65, 0x61, 0xed, 0x26, 0xfb, 0x9d,
+	0x7e, 0x19, 0xcc, 0x6a, 0x67, 0x01, 0x1c, 0xeb, 0x12, 0xc5, 0x5a, 0x31, 0x13, 0xac, 0xe6, 0x73,
+	0x68, 0xc9, 0x5d, 0x96, 0xef, 0x73, 0x45, 0xf2, 0x6c, 0xe4, 0x3e, 0xfe, 0x65, 0x35, 0xd3, 0xd0,
+	0x47, 0x86, 0xd9, 0x83, 0x66, 0xda, 0x7c, 0x37, 0x6f, 0x68, 0xc5, 0x60, 0x19, 0xeb, 0xdd, 0xba,
+	0x39, 0x13, 0xce, 0x77, 0xed, 0x15, 0xd4, 0x75, 0xf3, 0x5e, 0x4e, 0x2c, 0xd7, 0x1d, 0x60, 0x5d,
+	0x9f, 0x01, 0x95, 0xe8, 0x16, 0xf9, 0x4b, 0x61, 0xe6, 0x4a, 0xc2, 0xc4, 0x4a, 0xbc, 0xcf, 0x5a,
+	0x4d, 0x37, 0x73, 0xca, 0xb5, 0x28, 0xe5, 0x6a, 0x66, 0x05, 0x29, 0x37, 0x24, 0xb1, 0x87, 0x38,
+	0x46, 0xd0, 0xd0, 0x1f, 0x02, 0x51, 0xe9, 0x96, 0xf3, 0xf2, 0x8b, 0x75, 0x7d, 0x06, 0x34, 0x4f,
+	0xa6, 0x08, 0x59, 0xf2, 0x90, 0x5b, 0x2d, 0xe6, 0x1f, 0x42, 0x55, 0x7d, 0x2a, 0xd0, 0xb4, 0x94,
+	0xb5, 0xa6, 0x5e, 0x2b, 0xb4, 0x36, 0x72, 0x61, 0x3a, 0x6f, 0x99, 0x55, 0x75, 0x18, 0xf3, 0x0d,
+	0x2c, 0x65, 0x2c, 0x34, 0x79, 0x40, 0x66, 0x19, 0x81, 0xd6, 0xad, 0xd9, 0x1d, 0x38, 0xcd, 0x7f,
+	0x09, 0x0d, 0xe5, 0x29, 0xa5, 0xde, 0xa5, 0xdf, 0x97, 0x67, 0x22, 0xfb, 0xc4, 0x92, 0x95, 0x6b,
+	0x3d, 0xae, 0xd1, 0x09, 0x2f, 0xd9, 0xda, 0x84, 0xf1, 0x3c, 0x6c, 0x43, 0x45, 0xc1, 0x71, 0x15,
+	0xde, 0x35, 0x05, 0xa4, 0xbe, 0x1f, 0xf4, 0xc8, 0x30, 0xff, 0xc2, 0x80, 0xaa, 0xfa, 0x9e, 0x97,
+	0xa9, 0x15, 0xf7, 0xa6, 0xf0, 0xb4, 0x55, 0x98, 0x8a, 0xc8, 0x7e, 0x43, 0x27, 0x79, 0xb8, 0xb5,
+	0xaf, 0x6d, 0xde, 0x57, 0x9a, 0x89, 0xfc, 0x40, 0x7d, 0x51, 0xf9, 0xeb, 0x34, 0x50, 0xcd, 0x79,
+	0xfd, 0xfa, 0xe1, 0x57, 0xf4, 0x31, 0xb0, 0xaf, 0x1f, 0x19, 0x78, 0x08, 0xf4, 0x97, 0xb7, 0x24,
+	0x97, 0xe5, 0xbe, 0xfa, 0x65, 0x5d, 0x9f, 0x01, 0xe5, 0x1b, 0xf2, 0x46, 0xc9, 0x0d, 0x51, 0x5f,
+	0x7d, 0x4c, 0xc4, 0xe1, 0xac, 0x17, 0x25, 0xad, 0xf5, 0x99, 0x8f, 0x45, 0x3e, 0x32, 0xcc, 0x3d,
+	0x45, 0x92, 0x24, 0x3e, 0x5b, 0xf3, 0xb6, 0x12, 0xe1, 0xcd, 0xf7, 0xe7, 0x4a, 0x71, 0x22, 0x21,
+	0x8f, 0x0c, 0xf3, 0x47, 0xec, 0x45, 0x70, 0x51, 0xe3, 0x65, 0x2a, 0x57, 0x43, 0x9a, 0x57, 0xd4,
+	0x87, 0xb2, 0xef, 0x19, 0x8f, 0x0c, 0xf3, 0xd7, 0xd0, 0x50, 0xbe, 0xa5, 0x2c, 0xf7, 0xa1, 0xdf,
+	0xdb, 0x1f, 0xd1, 0x6d, 0xbc, 0x61, 0xaf, 0x6b, 0xdb, 0x98, 0xbe, 0x1b, 0x9f, 0x40, 0x4d, 0xf1,
+	0x42, 0xbd, 0x79, 0x2c, 0x59, 0x2f, 0xeb, 0x9b, 0xb2, 0xf2, 0xca, 0xf9, 0x7e, 0x02, 0x55, 0xd5,
+	0x1a, 0x93, 0x2c, 0x97, 0x63, 0xa2, 0x59, 0xa9, 0x72, 0xb7, 0x47, 0x86, 0x79, 0x08, 0x90, 0x94,
+	0x86, 0x9a, 0xa9, 0x0a, 0x4b, 0xb9, 0x49, 0xd9, 0xea, 0x51, 0xfd, 0x20, 0x89, 0x42, 0x4d, 0x5c,
+	0xcf, 0x6f, 0x98, 0x6c, 0xe1, 0xfd, 0x23, 0xb9, 0x9c, 0x6c, 0x3d, 0xa8, 0x65, 0xe5, 0x81, 0x38,
+	0xfe, 0x3b, 0x14, 0xff, 0x75, 0x73, 0x43, 0xc5, 0xff, 0xf0, 0x2b, 0xb5, 0x7e, 0xf4, 0x6b, 0xf3,
+	0x0d, 0xd4, 0xf6, 0x82, 0xe0, 0xdd, 0x74, 0x22, 0x16, 0x60, 0xea, 0x0b, 0xc4, 0xf8, 0xa8, 0x95,
+	0x2e, 0x1b, 0xbd, 0x4d, 0x31, 0x6f, 0x98, 0xeb, 0x3a, 0xe6, 0xa4, 0xa6, 0xf5, 0x6b, 0xf3, 0x10,
+	0xaa, 0x3b, 0x04, 0x7d, 0x5a, 0x3c, 0x08, 0xd1, 0x4a, 0xd0, 0xca, 0xa0, 0x85, 0x55, 0xd3, 0x1a,
+	0x75, 0x89, 0x3b, 0x71, 0x2f, 0x43, 0xf2, 0xdb, 0x87, 0x5f, 0xf1, 0xa8, 0xc6, 0xd7, 0xa6, 0x0b,
+	0x4b, 0x92, 0x6b, 0x25, 0x69, 0xac, 0x54, 0xed, 0xb0, 0x7a, 0x3e, 0xd2, 0xb3, 0xd6, 0x74, 0x52,
+	0x39, 0xeb, 0x48, 0xe0, 0x7c, 0x64, 0x08, 0xa1, 0xce, 0x97, 0xae, 0x0b, 0xf5, 0x54, 0xe1, 0xa1,
+	0xb5, 0x91, 0x0b, 0xcb, 0x13, 0xea, 0xa2, 0x30, 0xd1, 0x1c, 0xc1, 0x12, 0xab, 0xf8, 0x53, 0xea,
+	0x0d, 0xe5, 0x31, 0x9f, 0x55, 0xe1, 0x68, 0xdd, 0x9a, 0xdd, 0x41, 0x1f, 0x6d, 0x4b, 0x1f, 0xed,
+	0xe7, 0x50, 0xd3, 0xea, 0x0b, 0xa5, 0x3a, 0x9f, 0x57, 0xc1, 0x68, 0x6d, 0xe6, 0x03, 0xb9, 0x94,
+	0xea, 0x21, 0x2e, 0x46, 0x26, 0xf6, 0x04, 0x89, 0xa5, 0xcb, 0x1e, 0xf5, 0xb9, 0x12, 0xab, 0x95,
+	0x03, 0xd3, 0x95, 0x1d, 0xfa, 0xae, 0x87, 0xf9, 0x07, 0x50, 0xe1, 0x17, 0x15, 0x7b, 0xef, 0x43,
+	0xf9, 0x4c, 0x55, 0x02, 0xf2, 0x5e, 0x2e, 0xb9, 0x45, 0xb1, 0x59, 0x66, 0x5b, 0x62, 0x7b, 0x88,
+	0x8f, 0x9d, 0x30, 0x19, 0xee, 0x78, 0x83, 0xaf, 0xcd, 0x2f, 0x29, 0x72, 0xf9, 0xdc, 0xd0, 0xaa,
+	0x12, 0x57, 0x54, 0x91, 0x37, 0x52, 0xed, 0x79, 0x98, 0xd1, 0x6f, 0xf3, 0xf0, 0x2b, 0xee, 0xe4,
+	0xfa, 0xda, 0xbc, 0xa4, 0x91, 0x7e, 0x2d, 0xe6, 0x29, 0x49, 0x9b, 0x17, 0x32, 0xb5, 0x36, 0xf3,
+	0x81, 0x7c, 0xf3, 0xb6, 0xe8, 0x80, 0x1f, 0x99, 0xf6, 0xac, 0x01, 0x1f, 0xca, 0x18, 0xa9, 0xf9,
+	0x25, 0x00, 0xcd, 0x50, 0x64, 0x9e, 0xf4, 0x96, 0xea, 0x57, 0x17, 0x83, 0x69, 0xce, 0x76, 0xfb,
+	0x2e, 0x45, 0x7e, 0xdb, 0xbc, 0x99, 0x20, 0xa7, 0x9e, 0x79, 0x05, 0xfb, 0x57, 0xee, 0x38, 0xfe,
+	0xda, 0xdc, 0x86, 0xa6, 0xa8, 0x42, 0x12, 0x81, 0x63, 0x49, 0xb3, 0x54, 0x24, 0xda, 0x5a, 0xcb,
+	0xb4, 0x73, 0x2e, 0xf9, 0x82, 0xbe, 0x06, 0xab, 0xbe, 0xe1, 0x92, 0x68, 0xec, 0xe9, 0xe7, 0x5e,
+	0x2c, 0x33, 0x0b, 0xd2, 0xb5, 0x78, 0x36, 0x5d, 0xaa, 0xda, 0x7d, 0xa1, 0x18, 0x3f, 0x2a, 0x57,
+	0x99, 0x52, 0xe1, 0x99, 0xf5, 0x4a, 0x89, 0x65, 0xe5, 0xf5, 0x90, 0xb7, 0x24, 0xb5, 0x83, 0xd8,
+	0x63, 0x0f, 0x8a, 0x1d, 0xa4, 0xbd, 0x11, 0x61, 0xad, 0x65, 0xda, 0xf9, 0x72, 0x09, 0xac, 0x32,
+	0x44, 0xe9, 0x77, 0x11, 0xcc, 0x8f, 0xd4, 0x1d, 0x9f, 0xf5, 0x6a, 0x83, 0xf5, 0xf1, 0x37, 0xf4,
+	0x92, 0x1a, 0xc2, 0x52, 0xa6, 0x90, 0x57, 0x4a, 0x8d, 0x59, 0x85, 0xc2, 0xd6, 0xad, 0xd9, 0x1d,
+	0x38, 0xde, 0x2f, 0x61, 0x6d, 0x46, 0x0d, 0xb0, 0xf9, 0x71, 0x5a, 0x4b, 0xc8, 0xad, 0x11, 0xb6,
+	0x64, 0x4a, 0xa6, 0x0a, 0x7d, 0x64, 0x98, 0x8f, 0xa0, 0x86, 0xbe, 0x59, 0x5e, 0x45, 0xe3, 0x5e,
+	0xc8, 0x4b, 0x91, 0x57, 0xaf, 0x5a, 0x0d, 0xed, 0x77, 0x34, 0x31, 0x7f, 0x82, 0x4f, 0xd3, 0x8e,
+	0x27, 0xd3, 0x98, 0xa8, 0x65, 0xa7, 0xe9, 0xcf, 0x56, 0xb3, 0x75, 0xa3, 0xf4, 0xeb, 0x1d, 0x68,
+	0xb0, 0x92, 0x3f, 0x59, 0xeb, 0x99, 0x98, 0xdf, 0xa9, 0x9a, 0x52, 0xab, 0x9d, 0x05, 0x24, 0x66,
+	0x6d, 0xe2, 0x51, 0x96, 0x66, 0x6d, 0xc6, 0x5b, 0x6d, 0xad, 0xe7, 0x40, 0x38, 0x8a, 0xe7, 0x50,
+	0x55, 0x9d, 0xc5, 0x52, 0x4a, 0xe6, 0x38, 0xa0, 0xad, 0x8d, 0x5c, 0x18, 0x47, 0xb4, 0x03, 0x15,
+	0xa5, 0xae, 0x53, 0x53, 0x00, 0xf4, 0xc2, 0x51, 0xcb, 0xca, 0x03, 0x71, 0x2c, 0x3f, 0x87, 0x9a,
+	0x56, 0xd2, 0x69, 0xaa, 0x77, 0xd6, 0x4c, 0x31, 0x95, 0x5f, 0x05, 0xfa, 0x7b, 0x50, 0xc2, 0x82,
+	0x4a, 0x04, 0x48, 0x15, 0x41, 0xa9, 0x01, 0xbd, 0xca, 0xd8, 0xff, 0x11, 0x94, 0x65, 0x25, 0xa7,
+	0xdc, 0x98, 0x74, 0x6d, 0xa7, 0x95, 0x5f, 0x64, 0xfd, 0x14, 0x6a, 0xac, 0x27, 0xaf, 0xe6, 0x54,
+	0x2e, 0xb1, 0x6c, 0x8d, 0xe7, 0x0c, 0x1c, 0x6f, 0xc1, 0xcc, 0x16, 0x6e, 0x4a, 0xd1, 0x31, 0xb3,
+	0x00, 0xd4, 0xba, 0x7d, 0x45, 0x8f, 0x64, 0x9f, 0x94, 0xe2, 0x4d, 0xb9, 0x4f, 0xd9, 0xda, 0x4f,
+	0xcb, 0xca, 0x03, 0x71, 0x2c, 0x3f, 0x86, 0x92, 0x28, 0x58, 0x94, 0x52, 0x28, 0x55, 0x92, 0x69,
+	0xad, 0x65, 0xda, 0x93, 0x8f, 0x45, 0xfd, 0x61, 0x22, 0xc2, 0xf4, 0xc2, 0x45, 0x6b, 0x2d, 0xd3,
+	0x9e, 0x30, 0xac, 0x5a, 0x50, 0x28, 0x19, 0x36, 0xa7, 0x22, 0xd1, 0xda, 0xc8, 0x85, 0x29, 0x0c,
+	0x9b, 0x54, 0xce, 0x25, 0x0c, 0x9b, 0x29, 0xca, 0xb3, 0xac, 0x3c, 0x50, 0xc2, 0xb0, 0x5a, 0x05,
+	0x9e, 0xdc, 0xed, 0xbc, 0xf2, 0x3e, 0x6b, 0x33, 0x1f, 0x98, 0x1c, 0xe7, 0xa4, 0x9e, 0xce, 0x54,
+	0xbd, 0x30, 0x5a, 0xdd, 0x9d, 0xb5, 0x9e, 0x03, 0x91, 0x5a, 0x4f, 0x33, 0x5d, 0x09, 0x27, 0x9d,
+	0x28, 0x33, 0xaa, 0xed, 0xac, 0x9b, 0x33, 0xe1, 0xfa, 0xbc, 0x58, 0x3a, 0x98, 0x36, 0x2f, 0x2d,
+	0x53, 0xce, 0x5a, 0xcf, 0x81, 0x24, 0x64, 0xd2, 0x8a, 0xca, 0x24, 0x99, 0xf2, 0xea, 0xde, 0xac,
+	0xcd, 0x7c, 0x60, 0xc2, 0x01, 0x6a, 0x05, 0x98, 0xa6, 0xf2, 0xa6, 0x6a, 0xc7, 0xac, 0x8d, 0x5c,
+	0x18, 0x47, 0x74, 0x48, 0x9d, 0xac, 0x6a, 0xd9, 0x97, 0xea, 0x9a, 0xcc, 0x29, 0x14, 0xb3, 0x6e,
+	0xcc, 0x02, 0x27, 0x94, 0x4a, 0x4a, 0xb6, 0x24, 0xa5, 0x32, 0xc5, 0x5f, 0xd6, 0x7a, 0x0e, 0x84,
+	0xa3, 0xf8, 0x01, 0x00, 0x66, 0xe5, 0xec, 0xb8, 0x64, 0x1c, 0xf8, 0x89, 0xd9, 0x99, 0xe4, 0xed,
+	0x58, 0x2d, 0xad, 0x2d, 0x21, 0x8a, 0x9a, 0x68, 0x2d, 0x89, 0x92, 0x93, 0x93, 0x6e, 0x6d, 0xe4,
+	0xc2, 0x38, 0xa2, 0x17, 0xb0, 0xb4, 0xed, 0x4e, 0x30, 0x1a, 0x99, 0x64, 0x24, 0xcb, 0x95, 0x64,
+	0x12, 0x9a, 0xad, 0xf5, 0x1c, 0x48, 0x72, 0x5b, 0xa7, 0x12, 0x90, 0x9f, 0x05, 0x61, 0x67, 0x3a,
+	0xf0, 0x62, 0x49, 0xe6, 0xfc, 0x6c, 0x66, 0xeb, 0xc6, 0x2c, 0x70, 0xb2, 0x71, 0xa9, 0x9a, 0x33,
+	0x89, 0x31, 0xbf, 0x76, 0xcd, 0xba, 0x31, 0x0b, 0xcc, 0x31, 0x9e, 0xc0, 0x4a, 0x6e, 0x2d, 0x9b,
+	0x79, 0x47, 0x54, 0x35, 0x5c, 0x51, 0x19, 0x67, 0x7d, 0x74, 0x75, 0x27, 0x3e, 0x86, 0x03, 0xcb,
+	0x79, 0x85, 0x6a, 0xa6, 0xcd, 0xbf, 0xbe, 0xa2, 0x56, 0xce, 0xba, 0x73, 0x65, 0x9f, 0x84, 0x2c,
+	0xa9, 0x62, 0x2e, 0xf3, 0x7a, 0x6e, 0xc9, 0x56, 0x86, 0x2c, 0xb3, 0x6a, 0xc0, 0x7a, 0xd0, 0x4c,
+	0x97, 0x61, 0x49, 0x71, 0x32, 0xa3, 0xe6, 0xcb, 0xba, 0x39, 0x13, 0x9e, 0x20, 0x4d, 0xe7, 0x2b,
+	0xa6, 0x1c, 0xbd, 0x99, 0xac, 0x49, 0xeb, 0xe6, 0x4c, 0x78, 0xe2, 0xe8, 0xd5, 0xd3, 0x0e, 0xa5,
+	0x8f, 0x2b, 0x37, 0xff, 0xd1, 0xba, 0x3e, 0x03, 0xca, 0xd1, 0xed, 0x43, 0x2b, 0xa7, 0xf0, 0x48,
+	0xfa, 0xa2, 0x66, 0x17, 0x25, 0x59, 0xb9, 0x45, 0x3f, 0xe6, 0xb1, 0x38, 0x0b, 0x9d, 0xd1, 0x48,
+	0x83, 0x24, 0x4b, 0x9f, 0x51, 0xbc, 0x63, 0xad, 0x67, 0xe0, 0xb2, 0x82, 0xe7, 0x8d, 0x2c, 0x74,
+	0x49, 0xe1, 0xbc, 0x29, 0xef, 0x99, 0xfc, 0xc2, 0x1b, 0x6b, 0x53, 0xef, 0x90, 0xaa, 0x7a, 0xd9,
+	0x87, 0x66, 0xba, 0x22, 0xc6, 0x9c, 0x3d, 0x0d, 0xb9, 0x39, 0xb3, 0xaa, 0x68, 0x1e, 0xff, 0x3d,
+	0xcc, 0x75, 0xa6, 0x81, 0xeb, 0x03, 0xa8, 0xeb, 0x75, 0x65, 0x72, 0x9b, 0x72, 0xeb, 0xd0, 0xac,
+	0xeb, 0x33, 0xa0, 0x0c, 0x31, 0x33, 0x87, 0x44, 0x61, 0x99, 0xa9, 0xf8, 0xde, 0x35, 0x24, 0x6b,
+	0x99, 0x76, 0x3e, 0xaf, 0xbf, 0x63, 0x40, 0x59, 0x1e, 0x26, 0xf3, 0x09, 0x06, 0xc3, 0xc4, 0xa1,
+	0x54, 0x4c, 0x28, 0xfd, 0x24, 0xb6, 0xb3, 0x80, 0x44, 0xa1, 0x50, 0x8a, 0xf1, 0x24, 0xc1, 0xb2,
+	0x45, 0x84, 0x96, 0x95, 0x07, 0xe2, 0x73, 0xfa, 0x2f, 0x06, 0x94, 0xa4, 0xaf, 0xe8, 0x39, 0x54,
+	0x65, 0x72, 0xbb, 0xa7, 0x04, 0x83, 0xb2, 0x19, 0xef, 0x56, 0x3b, 0x07, 0x44, 0x47, 0xa3, 0x1e,
+	0xcd, 0x43, 0x68, 0x70, 0xa4, 0x2c, 0x85, 0x2e, 0x08, 0x25, 0xe1, 0x73, 0x53, 0xeb, 0xac, 0x8d,
+	0x7c, 0x68, 0x82, 0xf1, 0x89, 0x5a, 0x21, 0x48, 0xcb, 0xc8, 0xbe, 0x85, 0x3b, 0xee, 0x91, 0xf1,
+	0xf8, 0x3f, 0x19, 0x50, 0xda, 0xc6, 0xc0, 0xea, 0x4b, 0x2f, 0xe6, 0xb7, 0x97, 0x2c, 0xa6, 0x50,
+	0x6f, 0xaf, 0x74, 0xe1, 0x85, 0xb5, 0x91, 0x0b, 0xd3, 0xae, 0x41, 0x59, 0x26, 0xa1, 0x21, 0x4a,
+	0x15, 0x5a, 0x58, 0x1b, 0xb9, 0xb0, 0x44, 0x47, 0x15, 0xed, 0x2a, 0x5f, 0x69, 0x33, 0x59, 0xcb,
+	0xb4, 0xf3, 0x3d, 0xfc, 0x77, 0x05, 0x28, 0xee, 0x90, 0x73, 0xf3, 0x09, 0x54, 0x94, 0x3a, 0x1b,
+	0x33, 0xcf, 0xcb, 0x24, 0x79, 0x21, 0xaf, 0x20, 0xe7, 0x15, 0xd4, 0xf5, 0xe2, 0x17, 0xb9, 0x69,
+	0xb9, 0xe5, 0x37, 0xd6, 0xf5, 0x19, 0xd0, 0xe4, 0x02, 0xca, 0xab, 0x74, 0x91, 0x17, 0xd0, 0x15,
+	0xe5, 0x34, 0xd6, 0x9d, 0x2b, 0xfb, 0xa8, 0x76, 0x7f, 0x2a, 0x8f, 0x4a, 0xb1, 0xfb, 0xf3, 0xd3,
+	0xba, 0xac, 0x5b, 0xb3, 0x3b, 0x30, 0xbc, 0x27, 0x0b, 0xf4, 0xff, 0xb0, 0xfa, 0xf9, 0xff, 0x1e,
+	0x00, 0x92, 0xcc, 0x54, 0x23, 0x93, 0x75, 0x00, 0x00,
 }
@@ -0,0 +1,242 @@
+package lnwallet
+
+import (
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/roasbeef/btcutil"
+)
+
+// ChannelBalance breaks down the funds of a channel, as of its current view,
+// into the balance of each party, the funds locked within pending HTLCs, and
+// the commitment fee. From these, the amounts each party can actually send are
+// derived, taking into account the channel reserve each party must keep, the
+// limits placed upon the HTLCs it may offer, and, for the initiator, the fee
+// of the commitment carrying them.
+type ChannelBalance struct {
+	// Capacity is the total amount of funds within the channel.
+	Capacity btcutil.Amount
+
+	// LocalBalance and RemoteBalance are the settled balances of either
+	// party, excluding the pending HTLCs they offered, and the commitment
+	// fee paid by the initiator of the channel.
+	LocalBalance  btcutil.Amount
+	RemoteBalance btcutil.Amount
+
+	// LocalReserve is the reserve the remote party requires us to keep
+	// within the channel, while RemoteReserve is the one we require the
+	// remote party to keep.
+	LocalReserve  btcutil.Amount
+	RemoteReserve btcutil.Amount
+
+	// PendingOutgoing and PendingIncoming are the total amounts of the
+	// pending HTLCs we've offered, and of those offered to us.
+	PendingOutgoing btcutil.Amount
+	PendingIncoming btcutil.Amount
+
+	// CommitFee is the fee paid by the commitment transaction, which is
+	// deducted from the balance of the initiator of the channel.
+	CommitFee btcutil.Amount
+
+	// Spendable is the largest HTLC we can currently offer, and
+	// Receivable the largest one the remote party can currently offer.
+	Spendable  btcutil.Amount
+	Receivable btcutil.Amount
+}
+
+// NewChannelBalance breaks down the funds of the passed channel as of its
+// latest commitment, evaluating commitment fees at the minimum fee rate
+// negotiated for it. This is only the current view of channels which aren't
+// active, as those can't be updated until their link is restored. The
+// balance of an active channel is instead given by its LightningChannel,
+// which also accounts for the updates not yet committed.
+func NewChannelBalance(c *channeldb.OpenChannel) *ChannelBalance {
+	var outgoing, incoming []btcutil.Amount
+	for _, htlc := range c.Htlcs {
+		if htlc.Incoming {
+			incoming = append(incoming, htlc.Amt)
+		} else {
+			outgoing = append(outgoing, htlc.Amt)
+		}
+	}
+
+	return newChannelBalance(c, c.OurBalance, c.TheirBalance, outgoing,
+		incoming, c.MinFeePerKb/1000)
+}
+
+// Balance breaks down the funds of the channel as of its current view: our
+// latest commitment, updated with all the HTLCs added to, and removed from,
+// the update logs since, whether or not they've been committed yet.
+// Commitment fees are evaluated at the current fee rate.
+func (lc *LightningChannel) Balance() *ChannelBalance {
+	lc.RLock()
+	defer lc.RUnlock()
+
+	localBalance := lc.channelState.OurBalance
+	remoteBalance := lc.channelState.TheirBalance
+	if tip := lc.localCommitChain.tip(); tip != nil {
+		localBalance = tip.ourBalance
+		remoteBalance = tip.theirBalance
+	}
+
+	view := lc.fetchHTLCView(lc.theirLogCounter, lc.ourLogCounter)
+	for _, entry := range view.ourUpdates {
+		applyUncommittedUpdate(entry, &localBalance, &remoteBalance,
+			false)
+	}
+	for _, entry := range view.theirUpdates {
+		applyUncommittedUpdate(entry, &localBalance, &remoteBalance,
+			true)
+	}
+
+	var outgoing, incoming []btcutil.Amount
+	ourHTLCs, theirHTLCs := lc.activeHTLCs(view)
+	for _, htlc := range ourHTLCs {
+		outgoing = append(outgoing, htlc.Amount)
+	}
+	for _, htlc := range theirHTLCs {
+		incoming = append(incoming, htlc.Amount)
+	}
+
+	return newChannelBalance(lc.channelState, localBalance, remoteBalance,
+		outgoing, incoming, lc.commitFeeRate())
+}
+
+// applyUncommittedUpdate applies the passed entry of our update log, or of the
+// remote party's if remote is true, to the passed balances, unless it's
+// already reflected within our latest commitment. The balances are credited
+// and debited the same way our next commitment will be.
+func applyUncommittedUpdate(entry *PaymentDescriptor, localBalance,
+	remoteBalance *btcutil.Amount, remote bool) {
+
+	switch {
+	case entry.EntryType == Add && entry.addCommitHeightLocal != 0:
+		return
+
+	// Adding an HTLC debits the balance of the party offering it.
+	case entry.EntryType == Add && remote:
+		*remoteBalance -= entry.Amount
+	case entry.EntryType == Add:
+		*localBalance -= entry.Amount
+
+	case entry.removeCommitHeightLocal != 0:
+		return
+
+	// Settling an HTLC credits the party which settled it, while
+	// cancelling it returns its value to the party which offered it.
+	case entry.EntryType == Settle && remote:
+		*remoteBalance += entry.Amount
+	case entry.EntryType == Settle:
+		*localBalance += entry.Amount
+	case remote:
+		*localBalance += entry.Amount
+	default:
+		*remoteBalance += entry.Amount
+	}
+}
+
+// newChannelBalance breaks down the funds of the passed channel, given the
+// balances of either party, and the amounts of the pending HTLCs we've offered
+// and those offered to us. The fees of future commitments are evaluated at the
+// passed fee rate, expressed in sat/byte.
+func newChannelBalance(c *channeldb.OpenChannel, localBalance,
+	remoteBalance btcutil.Amount, outgoing, incoming []btcutil.Amount,
+	feePerByte btcutil.Amount) *ChannelBalance {
+
+	b := &ChannelBalance{
+		Capacity:      c.Capacity,
+		LocalBalance:  localBalance,
+		RemoteBalance: remoteBalance,
+		LocalReserve:  c.TheirConstraints.ChanReserve,
+		RemoteReserve: c.OurConstraints.ChanReserve,
+	}
+	for _, amt := range outgoing {
+		b.PendingOutgoing += amt
+	}
+	for _, amt := range incoming {
+		b.PendingIncoming += amt
+	}
+
+	b.CommitFee = b.Capacity - b.LocalBalance - b.RemoteBalance -
+		b.PendingOutgoing - b.PendingIncoming
+	if b.CommitFee < 0 {
+		b.CommitFee = 0
+	}
+
+	// The initiator pays the commitment fee, so the HTLCs it offers must
+	// leave enough of its balance to pay for the commitment carrying
+	// them, beyond the fee the commitment already pays.
+	localBalance, remoteBalance = b.LocalBalance, b.RemoteBalance
+	feeBuffer := nextCommitFee(c, outgoing, incoming, feePerByte) -
+		b.CommitFee
+	switch {
+	case feeBuffer > 0 && c.IsInitiator:
+		localBalance -= feeBuffer
+	case feeBuffer > 0:
+		remoteBalance -= feeBuffer
+	}
+
+	// The HTLCs we offer are constrained by the remote party, while the
+	// HTLCs they offer are constrained by us.
+	b.Spendable = spendableBalance(localBalance, b.PendingOutgoing,
+		len(outgoing), c.TheirConstraints)
+	b.Receivable = spendableBalance(remoteBalance, b.PendingIncoming,
+		len(incoming), c.OurConstraints)
+
+	return b
+}
+
+// nextCommitFee returns the fee, at the passed fee rate expressed in
+// sat/byte, of the costlier of either party's commitment once it carries the
+// passed pending HTLCs along with a new one. HTLCs below the dust limit of a
+// commitment don't add an output to it, so they don't add to its fee.
+func nextCommitFee(c *channeldb.OpenChannel, outgoing,
+	incoming []btcutil.Amount, feePerByte btcutil.Amount) btcutil.Amount {
+
+	var fee btcutil.Amount
+	for _, dustLimit := range []btcutil.Amount{
+		c.OurDustLimit, c.TheirDustLimit,
+	} {
+		var numHTLCs int
+		for _, amts := range [][]btcutil.Amount{outgoing, incoming} {
+			for _, amt := range amts {
+				if amt >= dustLimit {
+					numHTLCs++
+				}
+			}
+		}
+
+		weight := estimateCommitTxCost(numHTLCs, true)
+		size := (weight + WitnessFactor - 1) / WitnessFactor
+		commitFee := feePerByte * btcutil.Amount(size)
+		if commitFee > fee {
+			fee = commitFee
+		}
+	}
+
+	return fee
+}
+
+// spendableBalance returns the largest HTLC a party with the passed balance
+// can offer, given the total amount and number of the pending HTLCs it has
+// already offered, and the constraints it's subject to. Zero is returned if
+// not even an HTLC of the minimum size can be offered.
+func spendableBalance(balance, pendingAmt btcutil.Amount, numPending int,
+	constraints channeldb.ChannelConstraints) btcutil.Amount {
+
+	if constraints.MaxAcceptedHtlcs != 0 &&
+		numPending >= int(constraints.MaxAcceptedHtlcs) {
+
+		return 0
+	}
+
+	spendable := balance - constraints.ChanReserve
+	if constraints.MaxPendingAmount != 0 &&
+		spendable > constraints.MaxPendingAmount-pendingAmt {
+
+		spendable = constraints.MaxPendingAmount - pendingAmt
+	}
+	if spendable < 0 || spendable < constraints.MinHTLC {
+		return 0
+	}
+
+	return spendable
+}
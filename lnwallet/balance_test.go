@@ -0,0 +1,181 @@
+package lnwallet
+
+import (
+	"testing"
+
+	"github.com/btcsuite/fastsha256"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcutil"
+)
+
+// TestChannelBalance asserts that the spendable and receivable balances of a
+// channel account for the channel reserve, the pending HTLCs, and the limits
+// placed upon the HTLCs either party may offer.
+func TestChannelBalance(t *testing.T) {
+	channel := &channeldb.OpenChannel{
+		Capacity:     100000,
+		OurBalance:   60000,
+		TheirBalance: 29000,
+		OurConstraints: channeldb.ChannelConstraints{
+			ChanReserve: 1000,
+		},
+		TheirConstraints: channeldb.ChannelConstraints{
+			ChanReserve:      2000,
+			MaxPendingAmount: 50000,
+			MaxAcceptedHtlcs: 2,
+		},
+		Htlcs: []*channeldb.HTLC{
+			{Incoming: false, Amt: 4000},
+			{Incoming: true, Amt: 2000},
+		},
+	}
+
+	balance := NewChannelBalance(channel)
+
+	// The commitment fee is the remainder of the capacity.
+	if balance.CommitFee != 5000 {
+		t.Fatalf("expected commit fee of 5000, got %v",
+			balance.CommitFee)
+	}
+	if balance.PendingOutgoing != 4000 || balance.PendingIncoming != 2000 {
+		t.Fatalf("expected pending amounts of 4000 and 2000, got %v "+
+			"and %v", balance.PendingOutgoing,
+			balance.PendingIncoming)
+	}
+
+	// Our spendable balance is bounded by the max pending amount, less
+	// the HTLC we've already offered.
+	if balance.Spendable != 46000 {
+		t.Fatalf("expected spendable balance of 46000, got %v",
+			balance.Spendable)
+	}
+
+	// The remote party's is bounded by the reserve we require.
+	if balance.Receivable != 28000 {
+		t.Fatalf("expected receivable balance of 28000, got %v",
+			balance.Receivable)
+	}
+
+	// Once we've offered as many HTLCs as the remote party accepts, we
+	// can't send anything further.
+	channel.Htlcs = append(channel.Htlcs,
+		&channeldb.HTLC{Incoming: false, Amt: 1000})
+	channel.OurBalance -= 1000
+	if balance := NewChannelBalance(channel); balance.Spendable != 0 {
+		t.Fatalf("expected nothing to be spendable, got %v",
+			balance.Spendable)
+	}
+
+	// Nor can we send anything once our balance falls below the reserve.
+	channel.Htlcs = nil
+	channel.OurBalance = 1500
+	if balance := NewChannelBalance(channel); balance.Spendable != 0 {
+		t.Fatalf("expected nothing to be spendable, got %v",
+			balance.Spendable)
+	}
+}
+
+// TestChannelBalanceView asserts that the balance of a live channel accounts
+// for the HTLCs added and removed within its update logs, whether or not
+// they've been committed yet, and that the balance the initiator can spend
+// leaves room for the fee of the commitment carrying another HTLC.
+func TestChannelBalanceView(t *testing.T) {
+	aliceChannel, bobChannel, cleanUp, err := createTestChannels(3)
+	if err != nil {
+		t.Fatalf("unable to create test channels: %v", err)
+	}
+	defer cleanUp()
+
+	assertBalance := func(c *LightningChannel, local, remote, outgoing,
+		incoming btcutil.Amount) {
+
+		balance := c.Balance()
+		if balance.LocalBalance != local ||
+			balance.RemoteBalance != remote ||
+			balance.PendingOutgoing != outgoing ||
+			balance.PendingIncoming != incoming {
+
+			t.Fatalf("expected balances %v/%v with %v/%v pending, "+
+				"got %v/%v with %v/%v pending", local, remote,
+				outgoing, incoming, balance.LocalBalance,
+				balance.RemoteBalance, balance.PendingOutgoing,
+				balance.PendingIncoming)
+		}
+	}
+
+	initialBalance := aliceChannel.channelState.OurBalance
+	htlcAmount := btcutil.Amount(1e6)
+	preimage := [32]byte{1}
+	htlc := &lnwire.HTLCAddRequest{
+		RedemptionHashes: [][32]byte{fastsha256.Sum256(preimage[:])},
+		Amount:           htlcAmount,
+		Expiry:           uint32(5),
+	}
+	if _, err := aliceChannel.AddHTLC(htlc); err != nil {
+		t.Fatalf("alice unable to add htlc: %v", err)
+	}
+	if _, err := bobChannel.ReceiveHTLC(htlc); err != nil {
+		t.Fatalf("bob unable to receive htlc: %v", err)
+	}
+
+	// The HTLC is pending as soon as it's added, and stays so once it's
+	// committed.
+	for i := 0; i < 2; i++ {
+		assertBalance(aliceChannel, initialBalance-htlcAmount,
+			initialBalance, htlcAmount, 0)
+		assertBalance(bobChannel, initialBalance,
+			initialBalance-htlcAmount, 0, htlcAmount)
+
+		if i == 0 {
+			err := forceStateTransition(aliceChannel, bobChannel)
+			if err != nil {
+				t.Fatalf("unable to commit htlc: %v", err)
+			}
+		}
+	}
+
+	// Alice initiated the channel, so once commitment fees are due, she
+	// can only spend what's left after paying for a commitment carrying
+	// both her pending HTLC and a new one.
+	spendable := aliceChannel.Balance().Spendable
+	if spendable != initialBalance-htlcAmount {
+		t.Fatalf("expected spendable balance of %v, got %v",
+			initialBalance-htlcAmount, spendable)
+	}
+	feeRate := btcutil.Amount(10)
+	aliceChannel.SetDustExposureLimit(0, StaticFeeEstimator{
+		FeeRate: feeRate,
+	})
+	commitSize := (estimateCommitTxCost(2, false) + WitnessFactor - 1) /
+		WitnessFactor
+	commitFee := feeRate * btcutil.Amount(commitSize)
+	spendable = aliceChannel.Balance().Spendable
+	if spendable != initialBalance-htlcAmount-commitFee {
+		t.Fatalf("expected spendable balance of %v, got %v",
+			initialBalance-htlcAmount-commitFee, spendable)
+	}
+
+	// Once Bob settles the HTLC, its amount is credited to him, again
+	// before the settle is committed as well as after.
+	logIndex, err := bobChannel.SettleHTLC(preimage)
+	if err != nil {
+		t.Fatalf("bob unable to settle htlc: %v", err)
+	}
+	if err := aliceChannel.ReceiveHTLCSettle(preimage, logIndex); err != nil {
+		t.Fatalf("alice unable to receive settle: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		assertBalance(aliceChannel, initialBalance-htlcAmount,
+			initialBalance+htlcAmount, 0, 0)
+		assertBalance(bobChannel, initialBalance+htlcAmount,
+			initialBalance-htlcAmount, 0, 0)
+
+		if i == 0 {
+			err := forceStateTransition(bobChannel, aliceChannel)
+			if err != nil {
+				t.Fatalf("unable to commit settle: %v", err)
+			}
+		}
+	}
+}
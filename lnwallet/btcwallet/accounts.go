@@ -0,0 +1,615 @@
+package btcwallet
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+	"github.com/roasbeef/btcutil/hdkeychain"
+	"github.com/roasbeef/btcwallet/walletdb"
+)
+
+const (
+	// externalBranch is the branch of an imported account's extended
+	// public key which external addresses are derived from.
+	externalBranch = 0
+
+	// internalBranch is the branch of an imported account's extended
+	// public key which internal (change) addresses are derived from.
+	internalBranch = 1
+
+	// accountLookAhead is the number of addresses past the last derived
+	// address of each branch of an imported account which are watched.
+	// This allows outputs paying to addresses handed out by another
+	// wallet sharing the account to be detected.
+	accountLookAhead = 20
+
+	// accountScanBatch is the number of blocks scanned for outputs paying
+	// to an imported account between each write of the scan's progress.
+	accountScanBatch = 500
+
+	// dryRunAddrs is the number of addresses of each branch returned
+	// when dry running the import of an account.
+	dryRunAddrs = 5
+)
+
+var (
+	// accountBucket is the bucket within the ln namespace which stores
+	// all imported accounts. It contains a sub-bucket for each account,
+	// keyed by the name of the account.
+	accountBucket = []byte("imported-accounts")
+
+	// xpubKey stores the extended public key of an account.
+	xpubKey = []byte("xpub")
+
+	// fingerprintKey stores the master key fingerprint of an account.
+	fingerprintKey = []byte("fingerprint")
+
+	// externalCountKey stores the number of external addresses derived
+	// from an account.
+	externalCountKey = []byte("external-count")
+
+	// internalCountKey stores the number of internal addresses derived
+	// from an account.
+	internalCountKey = []byte("internal-count")
+
+	// scanHeightKey stores the height of the next block to be scanned for
+	// outputs paying to an account.
+	scanHeightKey = []byte("scan-height")
+
+	// accountOutputsBucket is the sub-bucket of an account which stores
+	// every output found to pay to the account. Each output is keyed by
+	// its outpoint, and stores its value followed by its pkScript.
+	accountOutputsBucket = []byte("outputs")
+)
+
+// fetchAccount reads the imported account stored within the passed bucket.
+func fetchAccount(name string,
+	accountBkt walletdb.Bucket) (*lnwallet.ImportedAccount, error) {
+
+	xpub, err := hdkeychain.NewKeyFromString(string(accountBkt.Get(xpubKey)))
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnwallet.ImportedAccount{
+		Name:           name,
+		ExtendedPubKey: xpub,
+		MasterKeyFingerprint: binary.BigEndian.Uint32(
+			accountBkt.Get(fingerprintKey),
+		),
+		ExternalKeyCount: binary.BigEndian.Uint32(
+			accountBkt.Get(externalCountKey),
+		),
+		InternalKeyCount: binary.BigEndian.Uint32(
+			accountBkt.Get(internalCountKey),
+		),
+	}, nil
+}
+
+// fetchImportedAccount returns the imported account with the passed name.
+func (b *BtcWallet) fetchImportedAccount(
+	name string) (*lnwallet.ImportedAccount, error) {
+
+	var account *lnwallet.ImportedAccount
+	err := b.lnNamespace.View(func(tx walletdb.Tx) error {
+		accounts := tx.RootBucket().Bucket(accountBucket)
+		if accounts == nil {
+			return lnwallet.ErrUnknownAccount
+		}
+		accountBkt := accounts.Bucket([]byte(name))
+		if accountBkt == nil {
+			return lnwallet.ErrUnknownAccount
+		}
+
+		var err error
+		account, err = fetchAccount(name, accountBkt)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// deriveAccountAddr derives the p2wkh address at the passed index of the
+// passed branch of an imported account's extended public key.
+func (b *BtcWallet) deriveAccountAddr(xpub *hdkeychain.ExtendedKey, branch,
+	index uint32) (btcutil.Address, error) {
+
+	branchKey, err := xpub.Child(branch)
+	if err != nil {
+		return nil, err
+	}
+	child, err := branchKey.Child(index)
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := child.ECPubKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return btcutil.NewAddressWitnessPubKeyHash(
+		btcutil.Hash160(pubKey.SerializeCompressed()), b.netParams,
+	)
+}
+
+// deriveAccountAddrs derives the addresses within [start, end) of the passed
+// branch of an imported account's extended public key.
+func (b *BtcWallet) deriveAccountAddrs(xpub *hdkeychain.ExtendedKey, branch,
+	start, end uint32) ([]btcutil.Address, error) {
+
+	addrs := make([]btcutil.Address, 0, end-start)
+	for i := start; i < end; i++ {
+		addr, err := b.deriveAccountAddr(xpub, branch, i)
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, addr)
+	}
+
+	return addrs, nil
+}
+
+// ImportAccount imports an account backed by the passed extended public key
+// under the passed name.
+//
+// This is a part of the WalletController interface.
+func (b *BtcWallet) ImportAccount(name string, xpub *hdkeychain.ExtendedKey,
+	masterKeyFingerprint, birthdayHeight uint32,
+	dryRun bool) (*lnwallet.ImportedAccount, []btcutil.Address,
+	[]btcutil.Address, error) {
+
+	if name == "" {
+		return nil, nil, nil, fmt.Errorf("account name must be " +
+			"specified")
+	}
+	if xpub.IsPrivate() {
+		return nil, nil, nil, fmt.Errorf("only extended public keys " +
+			"may be imported")
+	}
+	if !xpub.IsForNet(b.netParams) {
+		return nil, nil, nil, fmt.Errorf("extended public key is not " +
+			"for the active network")
+	}
+
+	account := &lnwallet.ImportedAccount{
+		Name:                 name,
+		ExtendedPubKey:       xpub,
+		MasterKeyFingerprint: masterKeyFingerprint,
+	}
+
+	if dryRun {
+		external, err := b.deriveAccountAddrs(xpub, externalBranch, 0,
+			dryRunAddrs)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		internal, err := b.deriveAccountAddrs(xpub, internalBranch, 0,
+			dryRunAddrs)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		return account, external, internal, nil
+	}
+
+	err := b.lnNamespace.Update(func(tx walletdb.Tx) error {
+		accounts, err := tx.RootBucket().CreateBucketIfNotExists(
+			accountBucket)
+		if err != nil {
+			return err
+		}
+		if accounts.Bucket([]byte(name)) != nil {
+			return lnwallet.ErrAccountExists
+		}
+
+		accountBkt, err := accounts.CreateBucket([]byte(name))
+		if err != nil {
+			return err
+		}
+
+		var fingerprint, scanHeight, zero [4]byte
+		binary.BigEndian.PutUint32(fingerprint[:], masterKeyFingerprint)
+		binary.BigEndian.PutUint32(scanHeight[:], birthdayHeight)
+		if err := accountBkt.Put(xpubKey, []byte(xpub.String())); err != nil {
+			return err
+		}
+		if err := accountBkt.Put(fingerprintKey, fingerprint[:]); err != nil {
+			return err
+		}
+		if err := accountBkt.Put(externalCountKey, zero[:]); err != nil {
+			return err
+		}
+		if err := accountBkt.Put(internalCountKey, zero[:]); err != nil {
+			return err
+		}
+		if err := accountBkt.Put(scanHeightKey, scanHeight[:]); err != nil {
+			return err
+		}
+
+		_, err = accountBkt.CreateBucket(accountOutputsBucket)
+		return err
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Now that the account is stored, we'll rescan the chain from its
+	// birthday for the outputs already paying to it.
+	account, err = b.scanImportedAccount(name)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return account, nil, nil, nil
+}
+
+// ListAccounts returns all the accounts which have been imported into the
+// wallet.
+//
+// This is a part of the WalletController interface.
+func (b *BtcWallet) ListAccounts() ([]*lnwallet.ImportedAccount, error) {
+	var accounts []*lnwallet.ImportedAccount
+	err := b.lnNamespace.View(func(tx walletdb.Tx) error {
+		accountsBkt := tx.RootBucket().Bucket(accountBucket)
+		if accountsBkt == nil {
+			return nil
+		}
+
+		return accountsBkt.ForEach(func(k, _ []byte) error {
+			account, err := fetchAccount(string(k),
+				accountsBkt.Bucket(k))
+			if err != nil {
+				return err
+			}
+
+			accounts = append(accounts, account)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+// NewImportedAddress derives the next external, or internal if change is
+// true, address of the passed imported account.
+//
+// This is a part of the WalletController interface.
+func (b *BtcWallet) NewImportedAddress(name string,
+	change bool) (btcutil.Address, error) {
+
+	branch, countKey := uint32(externalBranch), externalCountKey
+	if change {
+		branch, countKey = internalBranch, internalCountKey
+	}
+
+	var (
+		xpub  *hdkeychain.ExtendedKey
+		index uint32
+	)
+	err := b.lnNamespace.Update(func(tx walletdb.Tx) error {
+		accounts := tx.RootBucket().Bucket(accountBucket)
+		if accounts == nil {
+			return lnwallet.ErrUnknownAccount
+		}
+		accountBkt := accounts.Bucket([]byte(name))
+		if accountBkt == nil {
+			return lnwallet.ErrUnknownAccount
+		}
+
+		account, err := fetchAccount(name, accountBkt)
+		if err != nil {
+			return err
+		}
+		xpub = account.ExtendedPubKey
+
+		index = binary.BigEndian.Uint32(accountBkt.Get(countKey))
+		var next [4]byte
+		binary.BigEndian.PutUint32(next[:], index+1)
+		return accountBkt.Put(countKey, next[:])
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return b.deriveAccountAddr(xpub, branch, index)
+}
+
+// accountScript is an output script of an imported account, along with the
+// branch and index of the address it pays to.
+type accountScript struct {
+	branch uint32
+	index  uint32
+}
+
+// accountScripts returns the output scripts of all the addresses of the
+// passed imported account which are watched by the wallet.
+func (b *BtcWallet) accountScripts(
+	account *lnwallet.ImportedAccount) (map[string]accountScript, error) {
+
+	scripts := make(map[string]accountScript)
+	branches := []struct {
+		branch uint32
+		count  uint32
+	}{
+		{externalBranch, account.ExternalKeyCount},
+		{internalBranch, account.InternalKeyCount},
+	}
+	for _, branch := range branches {
+		addrs, err := b.deriveAccountAddrs(account.ExtendedPubKey,
+			branch.branch, 0, branch.count+accountLookAhead)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, addr := range addrs {
+			pkScript, err := txscript.PayToAddrScript(addr)
+			if err != nil {
+				return nil, err
+			}
+			scripts[string(pkScript)] = accountScript{
+				branch: branch.branch,
+				index:  uint32(i),
+			}
+		}
+	}
+
+	return scripts, nil
+}
+
+// matchAccountOutputs returns the outputs of the passed transactions which pay
+// to the passed imported account. Should an output pay to an address within
+// the look-ahead window of the account, then the window is moved forward past
+// it, as the address must have been handed out by another wallet sharing the
+// account.
+func (b *BtcWallet) matchAccountOutputs(account *lnwallet.ImportedAccount,
+	scripts map[string]accountScript,
+	txns []*wire.MsgTx) (map[wire.OutPoint]*wire.TxOut, error) {
+
+	outputs := make(map[wire.OutPoint]*wire.TxOut)
+	for _, tx := range txns {
+		for i, txOut := range tx.TxOut {
+			script, ok := scripts[string(txOut.PkScript)]
+			if !ok {
+				continue
+			}
+			op := wire.OutPoint{Hash: tx.TxHash(), Index: uint32(i)}
+			outputs[op] = txOut
+
+			count := &account.ExternalKeyCount
+			if script.branch == internalBranch {
+				count = &account.InternalKeyCount
+			}
+			if script.index < *count {
+				continue
+			}
+			*count = script.index + 1
+
+			newScripts, err := b.accountScripts(account)
+			if err != nil {
+				return nil, err
+			}
+			for k, v := range newScripts {
+				scripts[k] = v
+			}
+		}
+	}
+
+	return outputs, nil
+}
+
+// scanImportedAccount scans the blocks of the chain not yet scanned for
+// outputs paying to the passed imported account, recording those found. The
+// progress of the scan is written periodically, so that an interrupted scan
+// resumes where it stopped. The account, as of the end of the scan, is
+// returned.
+func (b *BtcWallet) scanImportedAccount(
+	name string) (*lnwallet.ImportedAccount, error) {
+
+	b.accountScanMtx.Lock()
+	defer b.accountScanMtx.Unlock()
+
+	var (
+		account    *lnwallet.ImportedAccount
+		scanHeight uint32
+	)
+	err := b.lnNamespace.View(func(tx walletdb.Tx) error {
+		accounts := tx.RootBucket().Bucket(accountBucket)
+		if accounts == nil {
+			return lnwallet.ErrUnknownAccount
+		}
+		accountBkt := accounts.Bucket([]byte(name))
+		if accountBkt == nil {
+			return lnwallet.ErrUnknownAccount
+		}
+
+		var err error
+		account, err = fetchAccount(name, accountBkt)
+		if err != nil {
+			return err
+		}
+		scanHeight = binary.BigEndian.Uint32(accountBkt.Get(scanHeightKey))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	scripts, err := b.accountScripts(account)
+	if err != nil {
+		return nil, err
+	}
+	_, bestHeight, err := b.rpc.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	for scanHeight <= uint32(bestHeight) {
+		batchEnd := scanHeight + accountScanBatch
+		if batchEnd > uint32(bestHeight)+1 {
+			batchEnd = uint32(bestHeight) + 1
+		}
+
+		found := make(map[wire.OutPoint]*wire.TxOut)
+		for height := scanHeight; height < batchEnd; height++ {
+			hash, err := b.rpc.GetBlockHash(int64(height))
+			if err != nil {
+				return nil, err
+			}
+			block, err := b.rpc.GetBlock(hash)
+			if err != nil {
+				return nil, err
+			}
+
+			outputs, err := b.matchAccountOutputs(account, scripts,
+				block.Transactions)
+			if err != nil {
+				return nil, err
+			}
+			for op, txOut := range outputs {
+				found[op] = txOut
+			}
+		}
+
+		err := b.putAccountScan(account, batchEnd, found)
+		if err != nil {
+			return nil, err
+		}
+		scanHeight = batchEnd
+	}
+
+	return account, nil
+}
+
+// putAccountScan records the outputs found to pay to the passed imported
+// account, along with the key counts of the account, and the height of the
+// next block to be scanned.
+func (b *BtcWallet) putAccountScan(account *lnwallet.ImportedAccount,
+	scanHeight uint32, outputs map[wire.OutPoint]*wire.TxOut) error {
+
+	return b.lnNamespace.Update(func(tx walletdb.Tx) error {
+		accountBkt := tx.RootBucket().Bucket(accountBucket).Bucket(
+			[]byte(account.Name))
+		outputsBkt := accountBkt.Bucket(accountOutputsBucket)
+
+		for op, txOut := range outputs {
+			var v [8]byte
+			binary.BigEndian.PutUint64(v[:], uint64(txOut.Value))
+			value := append(v[:], txOut.PkScript...)
+			if err := outputsBkt.Put(outpointKey(op), value); err != nil {
+				return err
+			}
+		}
+
+		var external, internal, height [4]byte
+		binary.BigEndian.PutUint32(external[:], account.ExternalKeyCount)
+		binary.BigEndian.PutUint32(internal[:], account.InternalKeyCount)
+		binary.BigEndian.PutUint32(height[:], scanHeight)
+		if err := accountBkt.Put(externalCountKey, external[:]); err != nil {
+			return err
+		}
+		if err := accountBkt.Put(internalCountKey, internal[:]); err != nil {
+			return err
+		}
+		return accountBkt.Put(scanHeightKey, height[:])
+	})
+}
+
+// ListImportedUnspent returns all the unspent outputs paying to the passed
+// imported account which have at least minConfs confirmations. The blocks
+// mined since the account was last scanned are scanned first. If minConfs is
+// zero, then the outputs within the mempool are returned too.
+//
+// This is a part of the WalletController interface.
+func (b *BtcWallet) ListImportedUnspent(name string,
+	minConfs int32) ([]*lnwallet.Utxo, error) {
+
+	account, err := b.scanImportedAccount(name)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := make(map[wire.OutPoint]*wire.TxOut)
+	err = b.lnNamespace.View(func(tx walletdb.Tx) error {
+		outputsBkt := tx.RootBucket().Bucket(accountBucket).Bucket(
+			[]byte(name)).Bucket(accountOutputsBucket)
+
+		return outputsBkt.ForEach(func(k, v []byte) error {
+			var op wire.OutPoint
+			copy(op.Hash[:], k[:chainhash.HashSize])
+			op.Index = binary.BigEndian.Uint32(k[chainhash.HashSize:])
+
+			outputs[op] = &wire.TxOut{
+				Value:    int64(binary.BigEndian.Uint64(v[:8])),
+				PkScript: append([]byte(nil), v[8:]...),
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Outputs within the mempool aren't recorded by the scan, so we'll
+	// look for them separately.
+	if minConfs <= 0 {
+		scripts, err := b.accountScripts(account)
+		if err != nil {
+			return nil, err
+		}
+		mempool, err := b.rpc.GetRawMempool()
+		if err != nil {
+			return nil, err
+		}
+
+		txns := make([]*wire.MsgTx, 0, len(mempool))
+		for _, txid := range mempool {
+			tx, err := b.rpc.GetRawTransaction(txid)
+			if err != nil {
+				return nil, err
+			}
+			txns = append(txns, tx.MsgTx())
+		}
+
+		mempoolOutputs, err := b.matchAccountOutputs(account, scripts,
+			txns)
+		if err != nil {
+			return nil, err
+		}
+		for op, txOut := range mempoolOutputs {
+			outputs[op] = txOut
+		}
+	}
+
+	var utxos []*lnwallet.Utxo
+	for op, txOut := range outputs {
+		// Only outputs which haven't yet been spent, either within a
+		// block or the mempool, are returned.
+		result, err := b.rpc.GetTxOut(&op.Hash, op.Index, true)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil || result.Confirmations < int64(minConfs) {
+			continue
+		}
+
+		utxos = append(utxos, &lnwallet.Utxo{
+			Value:         btcutil.Amount(txOut.Value),
+			OutPoint:      op,
+			PkScript:      txOut.PkScript,
+			Confirmations: result.Confirmations,
+		})
+	}
+
+	return utxos, nil
+}
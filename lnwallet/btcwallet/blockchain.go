@@ -60,10 +60,15 @@ func (b *BtcWallet) GetTransaction(txid *chainhash.Hash) (*wire.MsgTx, error) {
 	return tx.MsgTx(), nil
 }
 
-// GetBlock returns a raw block from the server given its hash.
+// GetBlock returns a raw block from the server given its hash, unless it's
+// within the block cache.
 //
 // This method is a part of the lnwallet.BlockChainIO interface.
 func (b *BtcWallet) GetBlock(blockHash *chainhash.Hash) (*wire.MsgBlock, error) {
+	if b.cfg.BlockCache != nil {
+		return b.cfg.BlockCache.GetBlock(blockHash, b.rpc.GetBlock)
+	}
+
 	block, err := b.rpc.GetBlock(blockHash)
 	if err != nil {
 		return nil, err
@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg"
@@ -38,6 +39,8 @@ type BtcWallet struct {
 	// wallet is an active instance of btcwallet.
 	wallet *base.Wallet
 
+	cfg *Config
+
 	// rpc is an an active RPC connection to btcd full-node.
 	rpc *chain.RPCClient
 
@@ -52,12 +55,38 @@ type BtcWallet struct {
 	// FetchInputInfo.
 	utxoCache map[wire.OutPoint]*wire.TxOut
 	cacheMtx  sync.RWMutex
+
+	// leaseMtx serializes all modifications to the set of leased outputs.
+	leaseMtx sync.Mutex
+
+	// accountScanMtx serializes the scans of the chain for outputs paying
+	// to imported accounts.
+	accountScanMtx sync.Mutex
+
+	// birthday is the block the wallet was born at. The chain is rescanned
+	// from this block when recovering the wallet.
+	birthday *waddrmgr.BlockStamp
+
+	// created is true if the wallet didn't exist prior to its creation
+	// within New.
+	created bool
+
+	// recoveryMode is true if the wallet was started in recovery mode.
+	recoveryMode bool
+
+	// HDKeyRing derives all the keys used within channels from the
+	// wallet's root key.
+	*keychain.HDKeyRing
 }
 
 // A compile time check to ensure that BtcWallet implements the
 // WalletController interface.
 var _ lnwallet.WalletController = (*BtcWallet)(nil)
 
+// A compile time check to ensure that BtcWallet implements the
+// keychain.SecretKeyRing interface.
+var _ keychain.SecretKeyRing = (*BtcWallet)(nil)
+
 // New returns a new fully initialized instance of BtcWallet given a valid
 // configuration struct.
 func New(cfg *Config) (*BtcWallet, error) {
@@ -112,13 +141,24 @@ func New(cfg *Config) (*BtcWallet, error) {
 		return nil, err
 	}
 
-	return &BtcWallet{
+	b := &BtcWallet{
+		cfg:         cfg,
 		wallet:      wallet,
 		rpc:         rpcc,
 		lnNamespace: walletNamespace,
 		netParams:   cfg.NetParams,
 		utxoCache:   make(map[wire.OutPoint]*wire.TxOut),
-	}, nil
+		created:     !walletExists,
+	}
+
+	// With the wallet unlocked, we can now create the key ring which will
+	// be used to derive all our channel keys.
+	b.HDKeyRing, err = b.newKeyRing()
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
 }
 
 // Start initializes the underlying rpc connection, the wallet itself, and
@@ -135,11 +175,26 @@ func (b *BtcWallet) Start() error {
 	// Start the underlying btcwallet core.
 	b.wallet.Start()
 
+	// If the wallet is to be recovered, then its sync state needs to be
+	// rewound before it's synchronized to the chain.
+	if err := b.prepareRecovery(); err != nil {
+		return err
+	}
+
 	// Pass the rpc client into the wallet so it can sync up to the
 	// current main chain.
 	b.wallet.SynchronizeRPC(b.rpc)
 
-	return nil
+	// Output locks within the wallet don't persist across restarts, so
+	// we'll re-lock the outputs of all active leases.
+	if err := b.restoreLeases(); err != nil {
+		return err
+	}
+
+	// Finally, the outputs paying to our payment keys are watched, so that
+	// those of the commitment transactions broadcast by our channel peers
+	// are swept into the wallet.
+	return b.watchPaymentKeys()
 }
 
 // Stop signals the wallet for shutdown. Shutdown may entail closing
@@ -153,7 +208,9 @@ func (b *BtcWallet) Stop() error {
 
 	b.rpc.Shutdown()
 
-	return nil
+	// The wallet's database is closed too, allowing the wallet to be
+	// opened once again, as when restarting it.
+	return b.wallet.Database().Close()
 }
 
 // ConfirmedBalance returns the sum of all the wallet's unspent outputs that
@@ -187,30 +244,32 @@ func (b *BtcWallet) ConfirmedBalance(confs int32, witness bool) (btcutil.Amount,
 }
 
 // NewAddress returns the next external or internal address for the wallet
-// dicatated by the value of the `change` paramter. If change is true, then an
+// dictated by the value of the `change` parameter. If change is true, then an
 // internal address will be returned, otherwise an external address should be
 // returned.
 //
 // This is a part of the WalletController interface.
 func (b *BtcWallet) NewAddress(t lnwallet.AddressType, change bool) (btcutil.Address, error) {
-	var addrType waddrmgr.AddressType
-
+	// Witness addresses are derived along the BIP0084 and BIP0049
+	// schemes, while legacy addresses remain derived by the wallet along
+	// the BIP0044 scheme. In either case, change addresses are derived
+	// from the internal branch, so that they're never handed out to
+	// receive payments.
 	switch t {
 	case lnwallet.WitnessPubKey:
-		addrType = waddrmgr.WitnessPubKey
+		return b.newBIPAddress(BIP0084Purpose, change)
 	case lnwallet.NestedWitnessPubKey:
-		addrType = waddrmgr.NestedWitnessPubKey
+		return b.newBIPAddress(BIP0049Purpose, change)
 	case lnwallet.PubKeyHash:
-		addrType = waddrmgr.PubKeyHash
+		if change {
+			return b.wallet.NewChangeAddress(
+				defaultAccount, waddrmgr.PubKeyHash,
+			)
+		}
+		return b.wallet.NewAddress(defaultAccount, waddrmgr.PubKeyHash)
 	default:
 		return nil, fmt.Errorf("unknown address type")
 	}
-
-	if change {
-		return b.wallet.NewAddress(defaultAccount, addrType)
-	} else {
-		return b.wallet.NewChangeAddress(defaultAccount, addrType)
-	}
 }
 
 // GetPrivKey retrives the underlying private key associated with the passed
@@ -336,6 +395,15 @@ func (b *BtcWallet) UnlockOutpoint(o wire.OutPoint) {
 //
 // This is a part of the WalletController interface.
 func (b *BtcWallet) ListUnspentWitness(minConfs int32) ([]*lnwallet.Utxo, error) {
+	// Release any expired output leases, so their outputs are once again
+	// eligible for coin selection.
+	b.leaseMtx.Lock()
+	_, err := b.expireLeases()
+	b.leaseMtx.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
 	// First, grab all the unfiltered currently unspent outputs.
 	maxConfs := int32(math.MaxInt32)
 	unspentOutputs, err := b.wallet.ListUnspent(minConfs, maxConfs, nil)
@@ -367,6 +435,8 @@ func (b *BtcWallet) ListUnspentWitness(minConfs int32) ([]*lnwallet.Utxo, error)
 					Hash:  *txid,
 					Index: output.Vout,
 				},
+				PkScript:      pkScript,
+				Confirmations: output.Confirmations,
 			}
 			witnessOutputs = append(witnessOutputs, utxo)
 		}
@@ -376,6 +446,14 @@ func (b *BtcWallet) ListUnspentWitness(minConfs int32) ([]*lnwallet.Utxo, error)
 	return witnessOutputs, nil
 }
 
+// ListAddresses returns all the addresses the wallet has generated for its
+// default account, both external and internal.
+//
+// This is a part of the WalletController interface.
+func (b *BtcWallet) ListAddresses() ([]btcutil.Address, error) {
+	return b.wallet.AccountAddresses(defaultAccount)
+}
+
 // PublishTransaction performs cursory validation (dust checks, etc), then
 // finally broadcasts the passed transaction to the Bitcoin network.
 func (b *BtcWallet) PublishTransaction(tx *wire.MsgTx) error {
@@ -452,18 +530,26 @@ func unminedTransactionsToDetail(summary base.TransactionSummary) (*lnwallet.Tra
 }
 
 // ListTransactionDetails returns a list of all transactions which are
-// relevant to the wallet.
+// relevant to the wallet, and were confirmed within the passed range of block
+// heights, inclusive. If endHeight is -1, then unconfirmed transactions are
+// included as well.
 //
 // This is a part of the WalletController interface.
-func (b *BtcWallet) ListTransactionDetails() ([]*lnwallet.TransactionDetail, error) {
+func (b *BtcWallet) ListTransactionDetails(startHeight,
+	endHeight int32) ([]*lnwallet.TransactionDetail, error) {
+
 	// Grab the best block the wallet knows of, we'll use this to calculate
 	// # of confirmations shortly below.
 	bestBlock := b.wallet.Manager.SyncedTo()
 	currentHeight := bestBlock.Height
 
 	// TODO(roasbeef): can replace with start "wallet birthday"
-	start := base.NewBlockIdentifierFromHeight(0)
-	stop := base.NewBlockIdentifierFromHeight(bestBlock.Height)
+	includeUnconfirmed := endHeight == -1
+	if includeUnconfirmed || endHeight > bestBlock.Height {
+		endHeight = bestBlock.Height
+	}
+	start := base.NewBlockIdentifierFromHeight(startHeight)
+	stop := base.NewBlockIdentifierFromHeight(endHeight)
 	txns, err := b.wallet.GetTransactions(start, stop, nil)
 	if err != nil {
 		return nil, err
@@ -484,6 +570,12 @@ func (b *BtcWallet) ListTransactionDetails() ([]*lnwallet.TransactionDetail, err
 		txDetails = append(txDetails, details...)
 	}
 	for _, tx := range txns.UnminedTransactions {
+		// Unconfirmed transactions are only included if the range
+		// extends past the current best block.
+		if !includeUnconfirmed {
+			break
+		}
+
 		detail, err := unminedTransactionsToDetail(tx)
 		if err != nil {
 			return nil, err
@@ -492,6 +584,15 @@ func (b *BtcWallet) ListTransactionDetails() ([]*lnwallet.TransactionDetail, err
 		txDetails = append(txDetails, detail)
 	}
 
+	// Finally, attach the labels of any labeled transactions.
+	labels, err := b.fetchTxLabels()
+	if err != nil {
+		return nil, err
+	}
+	for _, detail := range txDetails {
+		detail.Label = labels[detail.Hash]
+	}
+
 	return txDetails, nil
 }
 
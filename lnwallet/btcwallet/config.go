@@ -3,6 +3,7 @@ package btcwallet
 import (
 	"path/filepath"
 
+	"github.com/lightningnetwork/lnd/blockcache"
 	"github.com/roasbeef/btcd/chaincfg"
 	"github.com/roasbeef/btcd/wire"
 	"github.com/roasbeef/btcutil"
@@ -73,6 +74,22 @@ type Config struct {
 	PublicPass  []byte
 	HdSeed      []byte
 
+	// RecoveryWindow is the number of addresses of each branch of the
+	// wallet which are derived ahead of time when recovering the wallet.
+	// If non-zero, then the chain is rescanned from the wallet's birthday
+	// on start up, so that all the funds of a wallet restored from its
+	// seed are found.
+	RecoveryWindow uint32
+
+	// ResetWalletTransactions, if true, causes the chain to be rescanned
+	// from the wallet's birthday on start up, re-populating the wallet's
+	// transaction history.
+	ResetWalletTransactions bool
+
+	// BlockCache, if set, is the cache of blocks shared with the other
+	// subsystems fetching blocks from btcd.
+	BlockCache *blockcache.BlockCache
+
 	NetParams *chaincfg.Params
 }
 
@@ -0,0 +1,204 @@
+package btcwallet
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcutil"
+	"github.com/roasbeef/btcutil/hdkeychain"
+	"github.com/roasbeef/btcwallet/waddrmgr"
+	"github.com/roasbeef/btcwallet/walletdb"
+)
+
+const (
+	// BIP0049Purpose is the purpose of the BIP0049 derivation scheme,
+	// under which the keys of p2wkh outputs nested within p2sh outputs
+	// are derived: m/49'/coinType'/0'/branch/index.
+	BIP0049Purpose = 49
+
+	// BIP0084Purpose is the purpose of the BIP0084 derivation scheme,
+	// under which the keys of p2wkh outputs are derived:
+	// m/84'/coinType'/0'/branch/index.
+	BIP0084Purpose = 84
+)
+
+var (
+	// addrIndexBucket is the bucket within the ln namespace which stores
+	// the number of addresses derived along each BIP0049 and BIP0084
+	// branch. It maps a purpose and branch to the next unused index.
+	addrIndexBucket = []byte("bip-address-indexes")
+)
+
+// addrBranchKey serializes a purpose and branch for use as a key within the
+// address index bucket.
+func addrBranchKey(purpose, branch uint32) []byte {
+	var k [8]byte
+	binary.BigEndian.PutUint32(k[:4], purpose)
+	binary.BigEndian.PutUint32(k[4:], branch)
+	return k[:]
+}
+
+// nextAddrIndex returns the next unused index along the passed branch,
+// marking it as used.
+func (b *BtcWallet) nextAddrIndex(purpose, branch uint32) (uint32, error) {
+	var index uint32
+	err := b.lnNamespace.Update(func(tx walletdb.Tx) error {
+		indexes, err := tx.RootBucket().CreateBucketIfNotExists(
+			addrIndexBucket)
+		if err != nil {
+			return err
+		}
+
+		branchKey := addrBranchKey(purpose, branch)
+		if v := indexes.Get(branchKey); v != nil {
+			index = binary.BigEndian.Uint32(v)
+		}
+
+		var next [4]byte
+		binary.BigEndian.PutUint32(next[:], index+1)
+		return indexes.Put(branchKey, next[:])
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return index, nil
+}
+
+// deriveAddrKey derives the private key at m/purpose'/coinType'/0'/branch/index
+// from the wallet's root key, so that all addresses handed out can be
+// recovered from the wallet's seed alone.
+func (b *BtcWallet) deriveAddrKey(purpose, branch,
+	index uint32) (*btcec.PrivateKey, error) {
+
+	rootPriv, err := b.FetchRootKey()
+	if err != nil {
+		return nil, err
+	}
+	key, err := hdkeychain.NewMaster(rootPriv.Serialize(), b.netParams)
+	if err != nil {
+		return nil, err
+	}
+
+	path := []uint32{
+		hdkeychain.HardenedKeyStart + purpose,
+		hdkeychain.HardenedKeyStart + b.netParams.HDCoinType,
+		hdkeychain.HardenedKeyStart + 0,
+		branch,
+		index,
+	}
+	for _, i := range path {
+		key, err = key.Child(i)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return key.ECPrivKey()
+}
+
+// newBIPAddress derives the next address along the external or internal
+// branch of the BIP0084 scheme for p2wkh addresses, or of the BIP0049 scheme
+// for p2wkh addresses nested within p2sh. The key of the address is imported
+// into the wallet, so that the outputs paying to it are credited to the
+// wallet and can be signed for.
+func (b *BtcWallet) newBIPAddress(purpose uint32,
+	change bool) (btcutil.Address, error) {
+
+	var branch uint32
+	if change {
+		branch = 1
+	}
+
+	index, err := b.nextAddrIndex(purpose, branch)
+	if err != nil {
+		return nil, err
+	}
+	privKey, err := b.deriveAddrKey(purpose, branch, index)
+	if err != nil {
+		return nil, err
+	}
+
+	wif, err := btcutil.NewWIF(privKey, b.netParams, true)
+	if err != nil {
+		return nil, err
+	}
+	_, err = b.wallet.ImportPrivateKey(wif, b.birthday, false)
+	if err != nil && !waddrmgr.IsError(err, waddrmgr.ErrDuplicateAddress) {
+		return nil, err
+	}
+
+	pubKeyHash := btcutil.Hash160(privKey.PubKey().SerializeCompressed())
+	var addr btcutil.Address
+	addr, err = btcutil.NewAddressWitnessPubKeyHash(pubKeyHash, b.netParams)
+	if err != nil {
+		return nil, err
+	}
+
+	// The wallet only credits the outputs paying to a p2sh address whose
+	// script it knows, so the p2wkh witness program is imported as the
+	// redeem script of the nested address.
+	if purpose == BIP0049Purpose {
+		witnessProgram, err := txscript.PayToAddrScript(addr)
+		if err != nil {
+			return nil, err
+		}
+		_, err = b.wallet.Manager.ImportScript(
+			witnessProgram, b.birthday,
+		)
+		if err != nil &&
+			!waddrmgr.IsError(err, waddrmgr.ErrDuplicateAddress) {
+
+			return nil, err
+		}
+
+		addr, err = btcutil.NewAddressScriptHash(
+			witnessProgram, b.netParams,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := b.rpc.NotifyReceived([]btcutil.Address{addr}); err != nil {
+		return nil, err
+	}
+
+	return addr, nil
+}
+
+// nestedWitnessAddr returns the managed address of the key behind the passed
+// p2sh address, if its redeem script is a p2wkh witness program imported by
+// newBIPAddress.
+func (b *BtcWallet) nestedWitnessAddr(
+	scriptAddr waddrmgr.ManagedScriptAddress) (
+	waddrmgr.ManagedPubKeyAddress, error) {
+
+	script, err := scriptAddr.Script()
+	if err != nil {
+		return nil, err
+	}
+	if !txscript.IsPayToWitnessPubKeyHash(script) {
+		return nil, fmt.Errorf("p2sh address %v doesn't nest a p2wkh "+
+			"output", scriptAddr.Address())
+	}
+
+	addr, err := btcutil.NewAddressWitnessPubKeyHash(
+		script[2:], b.netParams,
+	)
+	if err != nil {
+		return nil, err
+	}
+	walletAddr, err := b.wallet.Manager.Address(addr)
+	if err != nil {
+		return nil, err
+	}
+	pka, ok := walletAddr.(waddrmgr.ManagedPubKeyAddress)
+	if !ok {
+		return nil, fmt.Errorf("address %v isn't backed by a key", addr)
+	}
+
+	return pka, nil
+}
@@ -0,0 +1,274 @@
+package btcwallet
+
+import (
+	"encoding/binary"
+
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcutil"
+	"github.com/roasbeef/btcutil/hdkeychain"
+	"github.com/roasbeef/btcwallet/waddrmgr"
+	"github.com/roasbeef/btcwallet/walletdb"
+)
+
+var (
+	// keyIndexBucket is the bucket within the ln namespace which stores
+	// the number of keys derived within each key family. It maps a key
+	// family to the next unused index within that family.
+	keyIndexBucket = []byte("key-family-indexes")
+
+	// keyLocatorBucket is the bucket within the ln namespace which stores
+	// the locator of each key derived by the key ring. It maps the
+	// compressed public key of a key to its family and index.
+	keyLocatorBucket = []byte("key-locators")
+)
+
+// keyIndexStore is an implementation of the keychain.IndexStore interface
+// backed by the ln namespace of the wallet's database.
+type keyIndexStore struct {
+	ns walletdb.Namespace
+}
+
+// A compile time check to ensure that keyIndexStore implements the
+// keychain.IndexStore interface.
+var _ keychain.IndexStore = (*keyIndexStore)(nil)
+
+// keyFamilyKey serializes a key family for use as a key within the key index
+// bucket.
+func keyFamilyKey(keyFam keychain.KeyFamily) []byte {
+	var k [4]byte
+	binary.BigEndian.PutUint32(k[:], uint32(keyFam))
+	return k[:]
+}
+
+// NextKeyIndex returns the next unused index within the passed key family,
+// marking it as used.
+//
+// NOTE: This is part of the keychain.IndexStore interface.
+func (k *keyIndexStore) NextKeyIndex(keyFam keychain.KeyFamily) (uint32, error) {
+	var index uint32
+	err := k.ns.Update(func(tx walletdb.Tx) error {
+		indexes, err := tx.RootBucket().CreateBucketIfNotExists(
+			keyIndexBucket)
+		if err != nil {
+			return err
+		}
+
+		famKey := keyFamilyKey(keyFam)
+		if v := indexes.Get(famKey); v != nil {
+			index = binary.BigEndian.Uint32(v)
+		}
+
+		var next [4]byte
+		binary.BigEndian.PutUint32(next[:], index+1)
+		return indexes.Put(famKey, next[:])
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return index, nil
+}
+
+// NumKeys returns the number of keys which have been derived within the
+// passed key family.
+//
+// NOTE: This is part of the keychain.IndexStore interface.
+func (k *keyIndexStore) NumKeys(keyFam keychain.KeyFamily) (uint32, error) {
+	var numKeys uint32
+	err := k.ns.View(func(tx walletdb.Tx) error {
+		indexes := tx.RootBucket().Bucket(keyIndexBucket)
+		if indexes == nil {
+			return nil
+		}
+
+		if v := indexes.Get(keyFamilyKey(keyFam)); v != nil {
+			numKeys = binary.BigEndian.Uint32(v)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return numKeys, nil
+}
+
+// PutKeyLocator stores the locator of the passed public key.
+//
+// NOTE: This is part of the keychain.IndexStore interface.
+func (k *keyIndexStore) PutKeyLocator(pubKey *btcec.PublicKey,
+	keyLoc keychain.KeyLocator) error {
+
+	return k.ns.Update(func(tx walletdb.Tx) error {
+		locators, err := tx.RootBucket().CreateBucketIfNotExists(
+			keyLocatorBucket)
+		if err != nil {
+			return err
+		}
+
+		var v [8]byte
+		binary.BigEndian.PutUint32(v[:4], uint32(keyLoc.Family))
+		binary.BigEndian.PutUint32(v[4:], keyLoc.Index)
+		return locators.Put(pubKey.SerializeCompressed(), v[:])
+	})
+}
+
+// FetchKeyLocator returns the locator of the passed public key. False is
+// returned if the locator isn't known.
+//
+// NOTE: This is part of the keychain.IndexStore interface.
+func (k *keyIndexStore) FetchKeyLocator(
+	pubKey *btcec.PublicKey) (keychain.KeyLocator, bool, error) {
+
+	var (
+		keyLoc keychain.KeyLocator
+		found  bool
+	)
+	err := k.ns.View(func(tx walletdb.Tx) error {
+		locators := tx.RootBucket().Bucket(keyLocatorBucket)
+		if locators == nil {
+			return nil
+		}
+
+		v := locators.Get(pubKey.SerializeCompressed())
+		if v == nil {
+			return nil
+		}
+
+		keyLoc.Family = keychain.KeyFamily(binary.BigEndian.Uint32(v[:4]))
+		keyLoc.Index = binary.BigEndian.Uint32(v[4:])
+		found = true
+		return nil
+	})
+	if err != nil {
+		return keychain.KeyLocator{}, false, err
+	}
+
+	return keyLoc, found, nil
+}
+
+// newKeyRing creates the key ring used to derive all the keys used within
+// channels. The key ring is rooted at the wallet's root key, so all the keys
+// it derives can be recovered from the wallet's seed alone.
+func (b *BtcWallet) newKeyRing() (*keychain.HDKeyRing, error) {
+	rootPriv, err := b.FetchRootKey()
+	if err != nil {
+		return nil, err
+	}
+
+	master, err := hdkeychain.NewMaster(rootPriv.Serialize(), b.netParams)
+	if err != nil {
+		return nil, err
+	}
+
+	return keychain.NewHDKeyRing(master, &keyIndexStore{b.lnNamespace}), nil
+}
+
+// DeriveNextKey derives the next unused key within the passed key family.
+// Payment keys are imported into the wallet as they're derived, so that the
+// outputs paying to them on a commitment transaction broadcast by the remote
+// party are swept into the wallet once the channel is closed.
+//
+// NOTE: This is part of the keychain.KeyRing interface.
+func (b *BtcWallet) DeriveNextKey(
+	keyFam keychain.KeyFamily) (keychain.KeyDescriptor, error) {
+
+	keyDesc, err := b.HDKeyRing.DeriveNextKey(keyFam)
+	if err != nil {
+		return keychain.KeyDescriptor{}, err
+	}
+	if keyFam != keychain.KeyFamilyPaymentBase {
+		return keyDesc, nil
+	}
+
+	// As the key is fresh, nothing can have paid to it yet, so watching
+	// the key's address for any future outputs suffices.
+	addr, _, err := b.importPaymentKey(keyDesc.KeyLocator)
+	if err != nil {
+		return keychain.KeyDescriptor{}, err
+	}
+	if err := b.rpc.NotifyReceived([]btcutil.Address{addr}); err != nil {
+		return keychain.KeyDescriptor{}, err
+	}
+
+	return keyDesc, nil
+}
+
+// importPaymentKey imports the payment key specified by the passed locator
+// into the wallet, so that the outputs paying to its p2wkh address are
+// credited to the wallet. The address is returned, along with whether the key
+// wasn't already imported.
+func (b *BtcWallet) importPaymentKey(
+	keyLoc keychain.KeyLocator) (btcutil.Address, bool, error) {
+
+	privKey, err := b.HDKeyRing.DerivePrivKey(keychain.KeyDescriptor{
+		KeyLocator: keyLoc,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	pubKeyHash := btcutil.Hash160(privKey.PubKey().SerializeCompressed())
+	addr, err := btcutil.NewAddressWitnessPubKeyHash(pubKeyHash, b.netParams)
+	if err != nil {
+		return nil, false, err
+	}
+
+	wif, err := btcutil.NewWIF(privKey, b.netParams, true)
+	if err != nil {
+		return nil, false, err
+	}
+	_, err = b.wallet.ImportPrivateKey(wif, b.birthday, false)
+	switch {
+	case waddrmgr.IsError(err, waddrmgr.ErrDuplicateAddress):
+		return addr, false, nil
+	case err != nil:
+		return nil, false, err
+	}
+
+	return addr, true, nil
+}
+
+// watchPaymentKeys imports all the payment keys derived so far into the
+// wallet, and watches their addresses for any outputs paying to them. Keys
+// derived before payment keys were imported are missing from the wallet, so
+// the chain is rescanned from the wallet's birthday for the outputs already
+// paying to them.
+//
+// NOTE: This MUST be called once the wallet is synchronized to the chain.
+func (b *BtcWallet) watchPaymentKeys() error {
+	indexes := &keyIndexStore{b.lnNamespace}
+	numKeys, err := indexes.NumKeys(keychain.KeyFamilyPaymentBase)
+	if err != nil {
+		return err
+	}
+
+	var addrs, imported []btcutil.Address
+	for i := uint32(0); i < numKeys; i++ {
+		addr, isNew, err := b.importPaymentKey(keychain.KeyLocator{
+			Family: keychain.KeyFamilyPaymentBase,
+			Index:  i,
+		})
+		if err != nil {
+			return err
+		}
+
+		addrs = append(addrs, addr)
+		if isNew {
+			imported = append(imported, addr)
+		}
+	}
+	if len(addrs) == 0 {
+		return nil
+	}
+
+	if err := b.rpc.NotifyReceived(addrs); err != nil {
+		return err
+	}
+	if len(imported) == 0 {
+		return nil
+	}
+
+	return b.rpc.Rescan(&b.birthday.Hash, imported, nil)
+}
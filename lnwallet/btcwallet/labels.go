@@ -0,0 +1,64 @@
+package btcwallet
+
+import (
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcwallet/walletdb"
+)
+
+var (
+	// txLabelBucket is the bucket within the ln namespace which stores the
+	// labels attached to transactions. It maps a txid to its label.
+	txLabelBucket = []byte("tx-labels")
+)
+
+// fetchTxLabels returns the labels of all the labeled transactions within
+// the database.
+func (b *BtcWallet) fetchTxLabels() (map[chainhash.Hash]string, error) {
+	labels := make(map[chainhash.Hash]string)
+	err := b.lnNamespace.View(func(tx walletdb.Tx) error {
+		labelIndex := tx.RootBucket().Bucket(txLabelBucket)
+		if labelIndex == nil {
+			return nil
+		}
+
+		return labelIndex.ForEach(func(k, v []byte) error {
+			var txid chainhash.Hash
+			copy(txid[:], k)
+			labels[txid] = string(v)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return labels, nil
+}
+
+// LabelTransaction attaches a label to the transaction with the passed hash.
+// If the transaction already has a label, then ErrTxLabelExists is returned
+// unless overwrite is true.
+//
+// This is a part of the WalletController interface.
+func (b *BtcWallet) LabelTransaction(hash chainhash.Hash, label string,
+	overwrite bool) error {
+
+	if len(label) > lnwallet.MaxTxLabelLength {
+		return lnwallet.ErrTxLabelTooLong
+	}
+
+	return b.lnNamespace.Update(func(tx walletdb.Tx) error {
+		labelIndex, err := tx.RootBucket().CreateBucketIfNotExists(
+			txLabelBucket)
+		if err != nil {
+			return err
+		}
+
+		if !overwrite && labelIndex.Get(hash[:]) != nil {
+			return lnwallet.ErrTxLabelExists
+		}
+
+		return labelIndex.Put(hash[:], []byte(label))
+	})
+}
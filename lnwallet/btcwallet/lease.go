@@ -0,0 +1,266 @@
+package btcwallet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcwallet/walletdb"
+)
+
+var (
+	// leaseBucket is the bucket within the ln namespace which stores all
+	// active output leases. It maps an outpoint to the lock ID and the
+	// expiration of its lease.
+	leaseBucket = []byte("output-leases")
+)
+
+// outpointKey serializes an outpoint for use as a key within the lease
+// bucket, and the output buckets of imported accounts.
+func outpointKey(op wire.OutPoint) []byte {
+	var k [chainhash.HashSize + 4]byte
+	copy(k[:], op.Hash[:])
+	binary.BigEndian.PutUint32(k[chainhash.HashSize:], op.Index)
+
+	return k[:]
+}
+
+// serializeLease encodes a lease as the value stored within the lease
+// bucket: the lock ID, followed by the expiration as a unix timestamp.
+func serializeLease(id lnwallet.LockID, expiration time.Time) []byte {
+	var v [32 + 8]byte
+	copy(v[:32], id[:])
+	binary.BigEndian.PutUint64(v[32:], uint64(expiration.Unix()))
+
+	return v[:]
+}
+
+// deserializeLease decodes a lease stored within the lease bucket.
+func deserializeLease(k, v []byte) *lnwallet.LockedOutput {
+	lease := &lnwallet.LockedOutput{}
+	copy(lease.Outpoint.Hash[:], k[:chainhash.HashSize])
+	lease.Outpoint.Index = binary.BigEndian.Uint32(k[chainhash.HashSize:])
+	copy(lease.LockID[:], v[:32])
+	lease.Expiration = time.Unix(int64(binary.BigEndian.Uint64(v[32:])), 0)
+
+	return lease
+}
+
+// fetchLeases returns all the leases currently stored within the database,
+// including those which have already expired.
+func (b *BtcWallet) fetchLeases() ([]*lnwallet.LockedOutput, error) {
+	var leases []*lnwallet.LockedOutput
+	err := b.lnNamespace.View(func(tx walletdb.Tx) error {
+		leaseIndex := tx.RootBucket().Bucket(leaseBucket)
+		if leaseIndex == nil {
+			return nil
+		}
+
+		return leaseIndex.ForEach(func(k, v []byte) error {
+			leases = append(leases, deserializeLease(k, v))
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return leases, nil
+}
+
+// expireLeases removes all the expired leases from the database, unlocking
+// their outputs. The set of active leases is returned.
+//
+// NOTE: This method MUST be called with the leaseMtx held.
+func (b *BtcWallet) expireLeases() ([]*lnwallet.LockedOutput, error) {
+	leases, err := b.fetchLeases()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var active, expired []*lnwallet.LockedOutput
+	for _, lease := range leases {
+		if now.Before(lease.Expiration) {
+			active = append(active, lease)
+		} else {
+			expired = append(expired, lease)
+		}
+	}
+	if len(expired) == 0 {
+		return active, nil
+	}
+
+	err = b.lnNamespace.Update(func(tx walletdb.Tx) error {
+		leaseIndex := tx.RootBucket().Bucket(leaseBucket)
+		for _, lease := range expired {
+			err := leaseIndex.Delete(outpointKey(lease.Outpoint))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, lease := range expired {
+		b.wallet.UnlockOutpoint(lease.Outpoint)
+	}
+
+	return active, nil
+}
+
+// restoreLeases locks the outputs of all the active leases persisted within
+// the database. This is called on start up, as output locks within the
+// underlying wallet don't persist across restarts.
+func (b *BtcWallet) restoreLeases() error {
+	b.leaseMtx.Lock()
+	defer b.leaseMtx.Unlock()
+
+	leases, err := b.expireLeases()
+	if err != nil {
+		return err
+	}
+	for _, lease := range leases {
+		b.wallet.LockOutpoint(lease.Outpoint)
+	}
+
+	return nil
+}
+
+// isUnspentOutput returns true if the passed outpoint is currently an unlocked
+// unspent output of the wallet.
+func (b *BtcWallet) isUnspentOutput(op wire.OutPoint) (bool, error) {
+	unspentOutputs, err := b.wallet.ListUnspent(0, math.MaxInt32, nil)
+	if err != nil {
+		return false, err
+	}
+
+	for _, output := range unspentOutputs {
+		txid, err := chainhash.NewHashFromStr(output.TxID)
+		if err != nil {
+			return false, err
+		}
+		if *txid == op.Hash && output.Vout == op.Index {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// LeaseOutput locks the passed output for the given duration using the passed
+// lock ID, preventing it from being used for coin selection. The lease is
+// persisted, so it survives restarts. If the output is already leased with
+// the same lock ID, then the lease is extended. The expiration of the lease
+// is returned.
+//
+// This is a part of the WalletController interface.
+func (b *BtcWallet) LeaseOutput(id lnwallet.LockID, op wire.OutPoint,
+	duration time.Duration) (time.Time, error) {
+
+	if duration <= 0 || duration > lnwallet.MaxLockDuration {
+		return time.Time{}, lnwallet.ErrInvalidLeaseDuration
+	}
+
+	b.leaseMtx.Lock()
+	defer b.leaseMtx.Unlock()
+
+	leases, err := b.expireLeases()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// If the output is already leased, then only the holder of the lease
+	// may extend it. Otherwise, the output must be an unlocked unspent
+	// output of the wallet.
+	var extending bool
+	for _, lease := range leases {
+		if lease.Outpoint != op {
+			continue
+		}
+		if lease.LockID != id {
+			return time.Time{}, lnwallet.ErrOutputAlreadyLeased
+		}
+		extending = true
+	}
+	if !extending {
+		isUnspent, err := b.isUnspentOutput(op)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if !isUnspent {
+			return time.Time{}, lnwallet.ErrUnknownOutput
+		}
+	}
+
+	expiration := time.Now().Add(duration)
+	err = b.lnNamespace.Update(func(tx walletdb.Tx) error {
+		leaseIndex, err := tx.RootBucket().CreateBucketIfNotExists(
+			leaseBucket)
+		if err != nil {
+			return err
+		}
+
+		return leaseIndex.Put(outpointKey(op),
+			serializeLease(id, expiration))
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	b.wallet.LockOutpoint(op)
+
+	return expiration, nil
+}
+
+// ReleaseOutput unlocks an output previously leased with the passed lock ID,
+// marking it eligible for coin selection.
+//
+// This is a part of the WalletController interface.
+func (b *BtcWallet) ReleaseOutput(id lnwallet.LockID, op wire.OutPoint) error {
+	b.leaseMtx.Lock()
+	defer b.leaseMtx.Unlock()
+
+	err := b.lnNamespace.Update(func(tx walletdb.Tx) error {
+		leaseIndex := tx.RootBucket().Bucket(leaseBucket)
+		if leaseIndex == nil {
+			return lnwallet.ErrOutputNotLeased
+		}
+
+		key := outpointKey(op)
+		v := leaseIndex.Get(key)
+		if v == nil {
+			return lnwallet.ErrOutputNotLeased
+		}
+		if !bytes.Equal(v[:32], id[:]) {
+			return lnwallet.ErrOutputLeaseMismatch
+		}
+
+		return leaseIndex.Delete(key)
+	})
+	if err != nil {
+		return err
+	}
+
+	b.wallet.UnlockOutpoint(op)
+
+	return nil
+}
+
+// ListLeasedOutputs returns all the outputs which are currently leased.
+//
+// This is a part of the WalletController interface.
+func (b *BtcWallet) ListLeasedOutputs() ([]*lnwallet.LockedOutput, error) {
+	b.leaseMtx.Lock()
+	defer b.leaseMtx.Unlock()
+
+	return b.expireLeases()
+}
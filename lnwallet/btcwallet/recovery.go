@@ -0,0 +1,170 @@
+package btcwallet
+
+import (
+	"encoding/binary"
+
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcwallet/waddrmgr"
+	"github.com/roasbeef/btcwallet/walletdb"
+)
+
+var (
+	// birthdayKey stores the wallet's birthday within the ln namespace:
+	// the height and hash of the best block at the time the wallet was
+	// first started. No transactions relevant to the wallet can exist
+	// before it.
+	birthdayKey = []byte("wallet-birthday")
+
+	// recoveryWindowKey stores the number of addresses of each branch of
+	// the wallet derived ahead of time for recovery within the ln
+	// namespace, so that they're only derived once rather than on every
+	// start up in recovery mode.
+	recoveryWindowKey = []byte("recovery-window")
+)
+
+// fetchBirthday returns the wallet's birthday. If the wallet has none yet,
+// then the best block of the chain is recorded as the birthday if the wallet
+// was just created. Otherwise, either the wallet is being recovered or
+// predates birthdays, so its entire history is unknown and the genesis block
+// is used instead.
+func (b *BtcWallet) fetchBirthday() (*waddrmgr.BlockStamp, error) {
+	var birthday *waddrmgr.BlockStamp
+	err := b.lnNamespace.View(func(tx walletdb.Tx) error {
+		v := tx.RootBucket().Get(birthdayKey)
+		if v == nil {
+			return nil
+		}
+
+		birthday = &waddrmgr.BlockStamp{
+			Height: int32(binary.BigEndian.Uint32(v[:4])),
+		}
+		copy(birthday.Hash[:], v[4:])
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if birthday != nil {
+		return birthday, nil
+	}
+
+	birthday = &waddrmgr.BlockStamp{
+		Height: 0,
+		Hash:   *b.netParams.GenesisHash,
+	}
+	if b.created && b.cfg.RecoveryWindow == 0 {
+		bestHash, bestHeight, err := b.rpc.GetBestBlock()
+		if err != nil {
+			return nil, err
+		}
+		birthday.Height = bestHeight
+		birthday.Hash = *bestHash
+	}
+
+	err = b.lnNamespace.Update(func(tx walletdb.Tx) error {
+		var v [4 + chainhash.HashSize]byte
+		binary.BigEndian.PutUint32(v[:4], uint32(birthday.Height))
+		copy(v[4:], birthday.Hash[:])
+
+		return tx.RootBucket().Put(birthdayKey, v[:])
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return birthday, nil
+}
+
+// prepareRecovery readies the wallet for recovery if requested. The addresses
+// within the recovery window are derived so that they're watched, then the
+// wallet's sync state is rewound to its birthday, causing the chain to be
+// rescanned from there once the wallet is synchronized.
+//
+// NOTE: This MUST be called before the wallet is synchronized to the chain.
+func (b *BtcWallet) prepareRecovery() error {
+	birthday, err := b.fetchBirthday()
+	if err != nil {
+		return err
+	}
+	b.birthday = birthday
+
+	if b.cfg.RecoveryWindow == 0 && !b.cfg.ResetWalletTransactions {
+		return nil
+	}
+	b.recoveryMode = true
+
+	if err := b.deriveRecoveryWindow(); err != nil {
+		return err
+	}
+
+	return b.wallet.Manager.SetSyncedTo(birthday)
+}
+
+// deriveRecoveryWindow derives the addresses of each branch of the wallet
+// within the recovery window, so that they're watched during the rescan. The
+// addresses derived are persisted, so that restarting the wallet in recovery
+// mode only derives those beyond any previously derived window.
+func (b *BtcWallet) deriveRecoveryWindow() error {
+	var derived uint32
+	err := b.lnNamespace.View(func(tx walletdb.Tx) error {
+		v := tx.RootBucket().Get(recoveryWindowKey)
+		if v != nil {
+			derived = binary.BigEndian.Uint32(v)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if b.cfg.RecoveryWindow <= derived {
+		return nil
+	}
+	numAddrs := b.cfg.RecoveryWindow - derived
+
+	_, err = b.wallet.Manager.NextExternalAddresses(defaultAccount,
+		numAddrs, waddrmgr.WitnessPubKey)
+	if err != nil {
+		return err
+	}
+	_, err = b.wallet.Manager.NextInternalAddresses(defaultAccount,
+		numAddrs, waddrmgr.WitnessPubKey)
+	if err != nil {
+		return err
+	}
+
+	return b.lnNamespace.Update(func(tx walletdb.Tx) error {
+		var v [4]byte
+		binary.BigEndian.PutUint32(v[:], b.cfg.RecoveryWindow)
+
+		return tx.RootBucket().Put(recoveryWindowKey, v[:])
+	})
+}
+
+// RecoveryProgress returns whether the wallet was started in recovery mode,
+// along with the progress of the rescan from the wallet's birthday as a value
+// between 0 and 1.
+//
+// This is a part of the WalletController interface.
+func (b *BtcWallet) RecoveryProgress() (bool, float64, error) {
+	if !b.recoveryMode {
+		return false, 0, nil
+	}
+
+	_, bestHeight, err := b.rpc.GetBestBlock()
+	if err != nil {
+		return false, 0, err
+	}
+	syncedHeight := b.wallet.Manager.SyncedTo().Height
+
+	total := bestHeight - b.birthday.Height
+	if total <= 0 || syncedHeight >= bestHeight {
+		return true, 1, nil
+	}
+	scanned := syncedHeight - b.birthday.Height
+	if scanned < 0 {
+		scanned = 0
+	}
+
+	return true, float64(scanned) / float64(total), nil
+}
@@ -3,6 +3,7 @@ package btcwallet
 import (
 	"fmt"
 
+	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/txscript"
@@ -85,7 +86,16 @@ func (b *BtcWallet) fetchPrivKey(pub *btcec.PublicKey) (*btcec.PrivateKey, error
 
 	walletddr, err := b.wallet.Manager.Address(addr)
 	if err != nil {
-		return nil, err
+		// If the key isn't known to the address manager, then it may
+		// have been derived by our key ring instead.
+		privKey, keyErr := b.DerivePrivKey(keychain.KeyDescriptor{
+			PubKey: pub,
+		})
+		if keyErr != nil {
+			return nil, err
+		}
+
+		return privKey, nil
 	}
 
 	return walletddr.(waddrmgr.ManagedPubKeyAddress).PrivKey()
@@ -112,10 +122,16 @@ func (b *BtcWallet) SignOutputRaw(tx *wire.MsgTx, signDesc *lnwallet.SignDescrip
 			signDesc.PrivateTweak)
 	}
 
+	// If the sign descriptor doesn't specify a sighash type, then we'll
+	// default to SIGHASH_ALL.
+	hashType := signDesc.HashType
+	if hashType == 0 {
+		hashType = txscript.SigHashAll
+	}
+
 	amt := signDesc.Output.Value
 	sig, err := txscript.RawTxInWitnessSignature(tx, signDesc.SigHashes,
-		signDesc.InputIndex, amt, witnessScript, txscript.SigHashAll,
-		privKey)
+		signDesc.InputIndex, amt, witnessScript, hashType, privKey)
 	if err != nil {
 		return nil, err
 	}
@@ -139,7 +155,28 @@ func (b *BtcWallet) ComputeInputScript(tx *wire.MsgTx,
 		return nil, nil
 	}
 
-	pka := walletAddr.(waddrmgr.ManagedPubKeyAddress)
+	// The p2sh addresses handed out along the BIP0049 branches are
+	// script addresses nesting the p2wkh output of an imported key, so
+	// the key is looked up through the nested witness program.
+	var (
+		pka    waddrmgr.ManagedPubKeyAddress
+		nested bool
+	)
+	switch addr := walletAddr.(type) {
+	case waddrmgr.ManagedScriptAddress:
+		pka, err = b.nestedWitnessAddr(addr)
+		if err != nil {
+			return nil, err
+		}
+		nested = true
+	case waddrmgr.ManagedPubKeyAddress:
+		pka = addr
+		nested = addr.IsNestedWitness()
+	default:
+		return nil, fmt.Errorf("unable to sign for address %v",
+			walletAddr.Address())
+	}
+
 	privKey, err := pka.PrivKey()
 	if err != nil {
 		return nil, err
@@ -151,7 +188,7 @@ func (b *BtcWallet) ComputeInputScript(tx *wire.MsgTx,
 	// If we're spending p2wkh output nested within a p2sh output, then
 	// we'll need to attach a sigScript in addition to witness data.
 	switch {
-	case pka.IsNestedWitness():
+	case nested:
 		pubKey := privKey.PubKey()
 		pubKeyHash := btcutil.Hash160(pubKey.SerializeCompressed())
 
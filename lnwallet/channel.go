@@ -10,6 +10,7 @@ import (
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 
@@ -30,6 +31,20 @@ var (
 		"available weight")
 	ErrMaxHTLCNumber = fmt.Errorf("commitment transaction exceed max " +
 		"htlc number")
+	ErrDustExposure = fmt.Errorf("htlc would exceed the max dust " +
+		"exposure of the channel")
+	ErrBelowMinHTLC = fmt.Errorf("htlc amount is below the minimum " +
+		"htlc of the channel")
+	ErrMaxAcceptedHTLCs = fmt.Errorf("htlc would exceed the max number " +
+		"of accepted htlcs of the channel")
+	ErrMaxPendingAmount = fmt.Errorf("htlc would exceed the max pending " +
+		"amount of the channel")
+	ErrBelowChanReserve = fmt.Errorf("htlc would leave the balance of " +
+		"the offering party below the channel reserve")
+	ErrCommitUpgradePending = fmt.Errorf("commitment type upgrade " +
+		"already pending")
+	ErrPendingCommitments = fmt.Errorf("channel has pending updates or " +
+		"commitments")
 )
 
 const (
@@ -45,6 +60,18 @@ const (
 	// extend the other's commitment chain non-interactively, and also
 	// serves as a flow control mechanism to a degree.
 	InitialRevocationWindow = 4
+
+	// DefaultMaxDustExposure is the default maximum amount of a channel's
+	// funds which may be lost to miners in the case that either
+	// commitment transaction is broadcast: the sum of all dust HTLCs, which
+	// are trimmed from the commitment, plus the commitment fee itself.
+	DefaultMaxDustExposure = btcutil.Amount(500000)
+
+	// FeeSpikeMultiplier is the factor the current fee rate is multiplied
+	// by when evaluating the commitment fee counted towards the dust
+	// exposure of a channel. This buffer ensures that a channel near its
+	// limit doesn't immediately exceed it once fee rates rise.
+	FeeSpikeMultiplier = 2
 )
 
 // channelState is an enum like type which represents the current state of a
@@ -129,6 +156,10 @@ type PaymentDescriptor struct {
 	// Payload is an opaque blob which is used to complete multi-hop routing.
 	Payload []byte
 
+	// Endorsed denotes if the HTLC was endorsed by the party which added
+	// it. This value is only set iff the EntryType is Add.
+	Endorsed bool
+
 	// Type denotes the exact type of the PaymentDescriptor. In the case of
 	// a Timeout, or Settle type, then the Parent field will point into the
 	// log to the HTLC being modified.
@@ -201,6 +232,10 @@ type commitment struct {
 	// commitment.
 	outgoingHTLCs []*PaymentDescriptor
 	incomingHTLCs []*PaymentDescriptor
+
+	// commitType is the format the commitment transaction was created
+	// in.
+	commitType channeldb.CommitmentType
 }
 
 // toChannelDelta converts the target commitment into a format suitable to be
@@ -427,6 +462,11 @@ type LightningChannel struct {
 	// their version of the commitment transaction on-chain.
 	UnilateralCloseSignal chan struct{}
 
+	// unilateralClose describes the commitment transaction broadcast by
+	// the remote party. It's set before the UnilateralCloseSignal is
+	// closed.
+	unilateralClose *UnilateralCloseSummary
+
 	// ContractBreach is a channel that is used to communicate the data
 	// necessary to fully resolve the channel in the case that a contract
 	// breach is detected. A contract breach occurs it is detected that the
@@ -442,6 +482,17 @@ type LightningChannel struct {
 	// channel.
 	RemoteFundingKey *btcec.PublicKey
 
+	// maxDustExposure is the maximum dust exposure permitted on either
+	// commitment transaction. New HTLCs which would push the exposure
+	// beyond this threshold are rejected. A value of zero disables the
+	// limit.
+	maxDustExposure btcutil.Amount
+
+	// feeEstimator, if non-nil, is used to determine the current fee rate
+	// commitment fees are evaluated at. Otherwise, the minimum fee rate
+	// negotiated for the channel is used.
+	feeEstimator FeeEstimator
+
 	started  int32
 	shutdown int32
 
@@ -478,6 +529,7 @@ func NewLightningChannel(signer Signer, bio BlockChainIO,
 		ForceCloseSignal:      make(chan struct{}),
 		UnilateralCloseSignal: make(chan struct{}),
 		ContractBreach:        make(chan *BreachRetribution, 1),
+		maxDustExposure:       DefaultMaxDustExposure,
 		LocalFundingKey:       state.OurMultiSigKey,
 		RemoteFundingKey:      state.TheirMultiSigKey,
 	}
@@ -492,6 +544,7 @@ func NewLightningChannel(signer Signer, bio BlockChainIO,
 		ourMessageIndex:   0,
 		theirBalance:      state.TheirBalance,
 		theirMessageIndex: 0,
+		commitType:        lc.commitTypeAt(lc.currentHeight),
 	}
 	lc.localCommitChain.addCommitment(initialCommitment)
 	lc.remoteCommitChain.addCommitment(initialCommitment)
@@ -628,6 +681,20 @@ func newBreachRetribution(chanState *channeldb.OpenChannel, stateNum uint64,
 		return nil, err
 	}
 
+	// If the channel was upgraded to pay our balance to a static payment
+	// key, the revoked commitment pays to either key, depending on
+	// whether it predates the upgrade.
+	var paymentPkScript []byte
+	if chanState.OurPaymentKey != nil {
+		paymentPkScript, err = commitScriptUnencumbered(
+			chanState.OurPaymentKey,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	localOutputKey, localOutputScript := localCommitKey, localPkScript
+
 	// In order to fully populate the breach retribution struct, we'll need
 	// to find the exact index of the local+remote commitment outputs.
 	localOutpoint := wire.OutPoint{
@@ -640,6 +707,12 @@ func newBreachRetribution(chanState *channeldb.OpenChannel, stateNum uint64,
 		switch {
 		case bytes.Equal(txOut.PkScript, localPkScript):
 			localOutpoint.Index = uint32(i)
+		case paymentPkScript != nil &&
+			bytes.Equal(txOut.PkScript, paymentPkScript):
+
+			localOutpoint.Index = uint32(i)
+			localOutputKey = chanState.OurPaymentKey
+			localOutputScript = paymentPkScript
 		case bytes.Equal(txOut.PkScript, remoteWitnessHash):
 			remoteOutpoint.Index = uint32(i)
 		}
@@ -654,9 +727,9 @@ func newBreachRetribution(chanState *channeldb.OpenChannel, stateNum uint64,
 		PendingHTLCs:      revokedSnapshot.Htlcs,
 		LocalOutpoint:     localOutpoint,
 		LocalOutputSignDesc: &SignDescriptor{
-			PubKey: localCommitKey,
+			PubKey: localOutputKey,
 			Output: &wire.TxOut{
-				PkScript: localPkScript,
+				PkScript: localOutputScript,
 				Value:    int64(revokedSnapshot.LocalBalance),
 			},
 			HashType: txscript.SigHashAll,
@@ -726,6 +799,12 @@ func (lc *LightningChannel) closeObserver(channelCloseNtfn *chainntnfs.SpendEven
 	case broadcastStateNum == currentStateNum:
 		walletLog.Infof("Unilateral close of ChannelPoint(%v) "+
 			"detected", lc.channelState.ChanID)
+		lc.unilateralClose = &UnilateralCloseSummary{
+			ChanPoint:        *lc.channelState.ChanID,
+			CommitTx:         commitTxBroadcast,
+			CommitType:       lc.commitTypeAt(broadcastStateNum),
+			OurPaymentKeyLoc: lc.channelState.OurPaymentKeyLoc,
+		}
 		close(lc.UnilateralCloseSignal)
 
 	// If the state number broadcast is lower than the remote node's
@@ -759,6 +838,34 @@ func (lc *LightningChannel) closeObserver(channelCloseNtfn *chainntnfs.SpendEven
 	}
 }
 
+// UnilateralCloseSummary describes the commitment transaction the remote
+// party broadcast to unilaterally close a channel.
+type UnilateralCloseSummary struct {
+	// ChanPoint is the funding outpoint of the closed channel.
+	ChanPoint wire.OutPoint
+
+	// CommitTx is the commitment transaction broadcast by the remote
+	// party.
+	CommitTx *wire.MsgTx
+
+	// CommitType is the format of the broadcast commitment transaction.
+	CommitType channeldb.CommitmentType
+
+	// OurPaymentKeyLoc is the key locator of the payment key our balance
+	// is paid to within static remote key commitments.
+	OurPaymentKeyLoc keychain.KeyLocator
+}
+
+// UnilateralCloseSummary returns the summary of the unilateral close of the
+// channel by the remote party. It returns nil until the UnilateralCloseSignal
+// has been closed.
+func (lc *LightningChannel) UnilateralCloseSummary() *UnilateralCloseSummary {
+	lc.RLock()
+	defer lc.RUnlock()
+
+	return lc.unilateralClose
+}
+
 // restoreStateLogs runs through the current locked-in HTLC's from the point of
 // view of the channel and insert corresponding log entries (both local and
 // remote) for each HTLC read from disk. This method is required sync the
@@ -905,6 +1012,20 @@ func (lc *LightningChannel) fetchCommitmentView(remoteChain bool,
 		dustLimit = lc.channelState.OurDustLimit
 	}
 
+	// New commitments are created in the format the channel is being
+	// upgraded to once the agreed upgrade height is reached. Within the
+	// static remote key format, the output paying to the counterparty of
+	// the commitment's owner pays to its payment key rather than to its
+	// commitment key.
+	commitType := lc.commitTypeAt(nextHeight)
+	if commitType == channeldb.CommitTypeStaticRemoteKey {
+		if remoteChain {
+			remoteKey = lc.channelState.OurPaymentKey
+		} else {
+			remoteKey = lc.channelState.TheirPaymentKey
+		}
+	}
+
 	// Generate a new commitment transaction with all the latest
 	// unsettled/un-timed out HTLC's.
 	ourCommitTx := !remoteChain
@@ -913,6 +1034,10 @@ func (lc *LightningChannel) fetchCommitmentView(remoteChain bool,
 	if err != nil {
 		return nil, err
 	}
+
+	// We track the CLTV expiry of the HTLC paid to by each output, as
+	// it breaks ties when sorting the outputs below.
+	cltvs := make([]uint32, len(commitTx.TxOut))
 	for _, htlc := range filteredHTLCView.ourUpdates {
 		if htlc.Amount < dustLimit {
 			continue
@@ -923,6 +1048,7 @@ func (lc *LightningChannel) fetchCommitmentView(remoteChain bool,
 		if err != nil {
 			return nil, err
 		}
+		cltvs = append(cltvs, htlc.Timeout)
 	}
 	for _, htlc := range filteredHTLCView.theirUpdates {
 		if htlc.Amount < dustLimit {
@@ -934,6 +1060,7 @@ func (lc *LightningChannel) fetchCommitmentView(remoteChain bool,
 		if err != nil {
 			return nil, err
 		}
+		cltvs = append(cltvs, htlc.Timeout)
 	}
 
 	// Set the state hint of the commitment transaction to facilitate
@@ -948,7 +1075,7 @@ func (lc *LightningChannel) fetchCommitmentView(remoteChain bool,
 	// Sort the transactions according to the agreed upon canonical
 	// ordering. This lets us skip sending the entire transaction over,
 	// instead we'll just send signatures.
-	txsort.InPlaceSort(commitTx)
+	SortCommitTx(commitTx, cltvs)
 
 	return &commitment{
 		txn:               commitTx,
@@ -959,6 +1086,7 @@ func (lc *LightningChannel) fetchCommitmentView(remoteChain bool,
 		theirBalance:      theirBalance,
 		outgoingHTLCs:     filteredHTLCView.ourUpdates,
 		incomingHTLCs:     filteredHTLCView.theirUpdates,
+		commitType:        commitType,
 	}, nil
 }
 
@@ -1232,6 +1360,204 @@ func (lc *LightningChannel) validateCommitmentSanity(theirLogCounter,
 	return nil
 }
 
+// SetDustExposureLimit sets the maximum dust exposure permitted on either
+// commitment transaction of the channel, along with the fee estimator used to
+// determine the fee rate commitment fees are evaluated at. A limit of zero
+// disables the dust exposure check.
+func (lc *LightningChannel) SetDustExposureLimit(maxExposure btcutil.Amount,
+	estimator FeeEstimator) {
+
+	lc.Lock()
+	defer lc.Unlock()
+
+	lc.maxDustExposure = maxExposure
+	lc.feeEstimator = estimator
+}
+
+// commitFeeRate returns the current fee rate, expressed in sat/byte, that
+// commitment fees are evaluated at.
+func (lc *LightningChannel) commitFeeRate() btcutil.Amount {
+	if lc.feeEstimator != nil {
+		return lc.feeEstimator.EstimateFeePerByte(1)
+	}
+
+	return lc.channelState.MinFeePerKb / 1000
+}
+
+// activeHTLCs returns the add entries within the passed view which haven't
+// been removed by a settle or cancel entry in either log, split into those
+// offered by us and those offered by the remote party. Unlike
+// evaluateHTLCView, the state of the channel isn't modified.
+func (lc *LightningChannel) activeHTLCs(view *htlcView) ([]*PaymentDescriptor,
+	[]*PaymentDescriptor) {
+
+	skipUs := make(map[uint32]struct{})
+	skipThem := make(map[uint32]struct{})
+	for _, entry := range view.ourUpdates {
+		if entry.EntryType != Add {
+			skipThem[entry.ParentIndex] = struct{}{}
+		}
+	}
+	for _, entry := range view.theirUpdates {
+		if entry.EntryType != Add {
+			skipUs[entry.ParentIndex] = struct{}{}
+		}
+	}
+
+	var ourHTLCs, theirHTLCs []*PaymentDescriptor
+	for _, entry := range view.ourUpdates {
+		if _, ok := skipUs[entry.Index]; entry.EntryType == Add && !ok {
+			ourHTLCs = append(ourHTLCs, entry)
+		}
+	}
+	for _, entry := range view.theirUpdates {
+		if _, ok := skipThem[entry.Index]; entry.EntryType == Add && !ok {
+			theirHTLCs = append(theirHTLCs, entry)
+		}
+	}
+
+	return ourHTLCs, theirHTLCs
+}
+
+// dustExposure returns the amount lost to miners if a commitment transaction
+// carrying the passed HTLCs is broadcast: the sum of the HTLCs below the
+// commitment's dust limit, plus the commitment fee at the passed fee rate,
+// expressed in sat/byte, raised by the FeeSpikeMultiplier.
+func dustExposure(htlcs []*PaymentDescriptor, dustLimit,
+	feePerByte btcutil.Amount) btcutil.Amount {
+
+	var (
+		dustSum  btcutil.Amount
+		numHTLCs int
+	)
+	for _, htlc := range htlcs {
+		if htlc.Amount < dustLimit {
+			dustSum += htlc.Amount
+			continue
+		}
+		numHTLCs++
+	}
+
+	commitWeight := estimateCommitTxCost(numHTLCs, false)
+	commitSize := (commitWeight + WitnessFactor - 1) / WitnessFactor
+	commitFee := feePerByte * btcutil.Amount(commitSize) * FeeSpikeMultiplier
+
+	return dustSum + commitFee
+}
+
+// validateDustExposure ensures that the dust exposure of both the local and
+// remote commitment transactions, including all the HTLCs within the update
+// logs up to the passed indexes along with the passed new HTLC, if any,
+// doesn't exceed the channel's limit.
+func (lc *LightningChannel) validateDustExposure(theirLogCounter,
+	ourLogCounter uint32, newHTLC *PaymentDescriptor) error {
+
+	if lc.maxDustExposure == 0 {
+		return nil
+	}
+
+	htlcView := lc.fetchHTLCView(theirLogCounter, ourLogCounter)
+	ourHTLCs, theirHTLCs := lc.activeHTLCs(htlcView)
+	htlcs := append(ourHTLCs, theirHTLCs...)
+	if newHTLC != nil {
+		htlcs = append(htlcs, newHTLC)
+	}
+
+	feeRate := lc.commitFeeRate()
+	dustLimits := []btcutil.Amount{
+		lc.channelState.OurDustLimit,
+		lc.channelState.TheirDustLimit,
+	}
+	for _, dustLimit := range dustLimits {
+		exposure := dustExposure(htlcs, dustLimit, feeRate)
+		if exposure > lc.maxDustExposure {
+			return ErrDustExposure
+		}
+	}
+
+	return nil
+}
+
+// validateConstraints ensures that a new HTLC, offered either by us or by the
+// remote party if incoming is true, satisfies the channel constraints imposed
+// upon the offering party, given the HTLCs within the update logs up to the
+// passed indexes.
+func (lc *LightningChannel) validateConstraints(theirLogCounter,
+	ourLogCounter uint32, newHTLC *PaymentDescriptor, incoming bool) error {
+
+	// The HTLCs we offer are constrained by the remote party, while the
+	// HTLCs they offer are constrained by us.
+	constraints := lc.channelState.TheirConstraints
+	if incoming {
+		constraints = lc.channelState.OurConstraints
+	}
+
+	if constraints.MinHTLC != 0 && newHTLC.Amount < constraints.MinHTLC {
+		return ErrBelowMinHTLC
+	}
+
+	htlcView := lc.fetchHTLCView(theirLogCounter, ourLogCounter)
+	ourHTLCs, theirHTLCs := lc.activeHTLCs(htlcView)
+	offeredHTLCs := ourHTLCs
+	if incoming {
+		offeredHTLCs = theirHTLCs
+	}
+
+	numPending := len(offeredHTLCs) + 1
+	if constraints.MaxAcceptedHtlcs != 0 &&
+		numPending > int(constraints.MaxAcceptedHtlcs) {
+
+		return ErrMaxAcceptedHTLCs
+	}
+
+	// The balance of the offering party is taken from our latest
+	// commitment, less all the HTLCs it has offered which aren't yet
+	// reflected within that commitment.
+	var balance btcutil.Amount
+	switch tip := lc.localCommitChain.tip(); {
+	case tip == nil && incoming:
+		balance = lc.channelState.TheirBalance
+	case tip == nil:
+		balance = lc.channelState.OurBalance
+	case incoming:
+		balance = tip.theirBalance
+	default:
+		balance = tip.ourBalance
+	}
+
+	pendingAmt := newHTLC.Amount
+	balance -= newHTLC.Amount
+	for _, htlc := range offeredHTLCs {
+		pendingAmt += htlc.Amount
+		if htlc.addCommitHeightLocal == 0 {
+			balance -= htlc.Amount
+		}
+	}
+
+	if constraints.MaxPendingAmount != 0 &&
+		pendingAmt > constraints.MaxPendingAmount {
+
+		return ErrMaxPendingAmount
+	}
+	if constraints.ChanReserve != 0 && balance < constraints.ChanReserve {
+		return ErrBelowChanReserve
+	}
+
+	return nil
+}
+
+// CheckDustExposure returns ErrDustExposure if the HTLCs currently within the
+// update logs of the channel push the dust exposure of either commitment
+// transaction beyond the channel's limit. This should be used to fail back an
+// HTLC just received from the remote party.
+func (lc *LightningChannel) CheckDustExposure() error {
+	lc.RLock()
+	defer lc.RUnlock()
+
+	return lc.validateDustExposure(lc.theirLogCounter, lc.ourLogCounter,
+		nil)
+}
+
 // ReceiveNewCommitment process a signature for a new commitment state sent by
 // the remote party. This method will should be called in response to the
 // remote party initiating a new change, or when the remote party sends a
@@ -1329,6 +1655,38 @@ func (lc *LightningChannel) PendingUpdates() bool {
 	return !fullySynced
 }
 
+// PendingLocalUpdates returns true if any of the updates we've added to our
+// log haven't yet been committed to both our commitment transaction and that
+// of the remote node. While a channel is quiesced, no new updates are added
+// to the log, so this reports whether our side of the channel has settled
+// down.
+func (lc *LightningChannel) PendingLocalUpdates() bool {
+	lc.RLock()
+	defer lc.RUnlock()
+
+	return lc.localCommitChain.tip().ourMessageIndex != lc.ourLogCounter ||
+		lc.remoteCommitChain.tip().ourMessageIndex != lc.ourLogCounter
+}
+
+// FullySynced returns true if every update added by either party has been
+// committed to both commitment transactions, and the prior commitments of
+// both parties have been revoked. At that point, the connection to the remote
+// peer can be dropped without leaving a commitment transition midway.
+func (lc *LightningChannel) FullySynced() bool {
+	lc.RLock()
+	defer lc.RUnlock()
+
+	localTip := lc.localCommitChain.tip()
+	remoteTip := lc.remoteCommitChain.tip()
+
+	return localTip.ourMessageIndex == lc.ourLogCounter &&
+		localTip.theirMessageIndex == lc.theirLogCounter &&
+		remoteTip.ourMessageIndex == lc.ourLogCounter &&
+		remoteTip.theirMessageIndex == lc.theirLogCounter &&
+		lc.localCommitChain.commitments.Len() == 1 &&
+		lc.remoteCommitChain.commitments.Len() == 1
+}
+
 // RevokeCurrentCommitment revokes the next lowest unrevoked commitment
 // transaction in the local commitment chain. As a result the edge of our
 // revocation window is extended by one, and the tail of our local commitment
@@ -1385,6 +1743,10 @@ func (lc *LightningChannel) RevokeCurrentCommitment() (*lnwire.CommitRevocation,
 		"our_balance=%v, their_balance=%v", lc.channelState.ChanID,
 		tail.ourBalance, tail.theirBalance)
 
+	if err := lc.maybeCompleteUpgrade(); err != nil {
+		return nil, err
+	}
+
 	revocationMsg.ChannelPoint = lc.channelState.ChanID
 	return revocationMsg, nil
 }
@@ -1507,9 +1869,189 @@ func (lc *LightningChannel) ReceiveRevocation(revMsg *lnwire.CommitRevocation) (
 	lc.compactLogs(lc.ourUpdateLog, lc.theirUpdateLog,
 		localChainTail, remoteChainTail)
 
+	if err := lc.maybeCompleteUpgrade(); err != nil {
+		return nil, err
+	}
+
 	return htlcsToForward, nil
 }
 
+// commitTypeAt returns the format of a commitment at the passed height,
+// which is the format the channel is being upgraded to from the agreed
+// upgrade height onwards.
+//
+// NOTE: The channel's mutex must be held when calling this method.
+func (lc *LightningChannel) commitTypeAt(height uint64) channeldb.CommitmentType {
+	state := lc.channelState
+	if state.PendingCommitType != state.CommitType &&
+		height >= state.UpgradeHeight {
+
+		return state.PendingCommitType
+	}
+
+	return state.CommitType
+}
+
+// CommitUpgradeHeight returns the height of the next commitment of the
+// channel, from which an upgrade agreed upon now would apply.
+func (lc *LightningChannel) CommitUpgradeHeight() uint64 {
+	lc.RLock()
+	defer lc.RUnlock()
+
+	return lc.nextCommitHeight()
+}
+
+// nextCommitHeight returns the height following the tips of both commitment
+// chains.
+//
+// NOTE: The channel's mutex must be held when calling this method.
+func (lc *LightningChannel) nextCommitHeight() uint64 {
+	height := lc.localCommitChain.tip().height
+	if lc.remoteCommitChain.tip().height > height {
+		height = lc.remoteCommitChain.tip().height
+	}
+
+	return height + 1
+}
+
+// UpgradeCommitType starts the upgrade of the channel to the passed
+// commitment type, all commitments from the passed height onwards being
+// created in the new format. The height must be the one returned by
+// CommitUpgradeHeight, on both sides of the channel. The payment keys of both
+// parties are required by the static remote key commitment type. The upgrade
+// completes once the commitments in the prior format have been revoked,
+// until which both formats are kept. As both parties must agree on the
+// commitment from which the new format applies, the channel must be
+// quiescent with all updates committed.
+func (lc *LightningChannel) UpgradeCommitType(
+	commitType channeldb.CommitmentType, height uint64,
+	ourPaymentKey keychain.KeyDescriptor,
+	theirPaymentKey *btcec.PublicKey) error {
+
+	lc.Lock()
+	defer lc.Unlock()
+
+	nextHeight := lc.nextCommitHeight()
+	state := lc.channelState
+	switch {
+	case state.PendingCommitType != state.CommitType:
+		return ErrCommitUpgradePending
+
+	case height != nextHeight:
+		return fmt.Errorf("upgrade height %v doesn't match next "+
+			"commitment height %v", height, nextHeight)
+
+	case commitType <= state.CommitType ||
+		commitType > channeldb.CommitTypeStaticRemoteKey:
+
+		return fmt.Errorf("channel can't be upgraded from commitment "+
+			"type %v to %v", state.CommitType, commitType)
+
+	case ourPaymentKey.PubKey == nil || theirPaymentKey == nil:
+		return fmt.Errorf("commitment type %v requires payment keys",
+			commitType)
+	}
+
+	// Neither chain may hold a commitment which hasn't been revoked yet,
+	// nor may any update be left uncommitted, otherwise the parties could
+	// disagree on the format of the commitments in flight.
+	localTip, remoteTip := lc.localCommitChain.tip(), lc.remoteCommitChain.tip()
+	if localTip != lc.localCommitChain.tail() ||
+		remoteTip != lc.remoteCommitChain.tail() ||
+		localTip.ourMessageIndex != lc.ourLogCounter ||
+		localTip.theirMessageIndex != lc.theirLogCounter ||
+		remoteTip.ourMessageIndex != lc.ourLogCounter ||
+		remoteTip.theirMessageIndex != lc.theirLogCounter {
+
+		return ErrPendingCommitments
+	}
+
+	walletLog.Infof("ChannelPoint(%v): upgrading commitment type from %v "+
+		"to %v", state.ChanID, state.CommitType, commitType)
+
+	return state.UpgradeCommitType(commitType, height,
+		ourPaymentKey.PubKey, ourPaymentKey.KeyLocator, theirPaymentKey)
+}
+
+// ReconcileCommitUpgrade reconciles any pending commitment type upgrade with
+// the format the remote party creates new commitments in, as announced once
+// the channel is reestablished. If the remote party never recorded the
+// upgrade, as the acceptance was lost, the upgrade is abandoned, which is
+// signalled by the returned boolean. An error is returned if the formats
+// can't be reconciled.
+func (lc *LightningChannel) ReconcileCommitUpgrade(
+	remoteType channeldb.CommitmentType) (bool, error) {
+
+	lc.Lock()
+	defer lc.Unlock()
+
+	state := lc.channelState
+	switch {
+	// Both parties create commitments in the same format.
+	case remoteType == state.PendingCommitType:
+		return false, nil
+
+	// The remote party recorded an upgrade we didn't, which it'll abandon
+	// in turn.
+	case state.PendingCommitType == state.CommitType:
+		return false, nil
+
+	case remoteType != state.CommitType:
+		return false, fmt.Errorf("remote party creates %v "+
+			"commitments, while we create %v ones", remoteType,
+			state.PendingCommitType)
+	}
+
+	// The remote party couldn't have created a commitment in the new
+	// format without having recorded the upgrade.
+	if lc.localCommitChain.tip().commitType == state.PendingCommitType ||
+		lc.remoteCommitChain.tip().commitType == state.PendingCommitType {
+
+		return false, fmt.Errorf("remote party lost the upgrade to "+
+			"commitment type %v", state.PendingCommitType)
+	}
+
+	walletLog.Infof("ChannelPoint(%v): abandoning upgrade to commitment "+
+		"type %v unknown to remote party", state.ChanID,
+		state.PendingCommitType)
+
+	return true, state.AbortCommitTypeUpgrade()
+}
+
+// maybeCompleteUpgrade completes a pending commitment type upgrade once the
+// current commitments of both chains have been created in the new format,
+// meaning all commitments in the prior format have been revoked.
+//
+// NOTE: The channel's mutex must be held when calling this method.
+func (lc *LightningChannel) maybeCompleteUpgrade() error {
+	state := lc.channelState
+	if state.CommitType == state.PendingCommitType {
+		return nil
+	}
+
+	if lc.localCommitChain.tail().commitType != state.PendingCommitType ||
+		lc.remoteCommitChain.tail().commitType != state.PendingCommitType {
+		return nil
+	}
+
+	walletLog.Infof("ChannelPoint(%v): commitment type upgrade to %v "+
+		"completed", state.ChanID, state.PendingCommitType)
+
+	return state.CompleteCommitTypeUpgrade()
+}
+
+// CommitTypes returns the format of the current commitments of the channel,
+// along with the format the channel is being upgraded to. Both are equal if
+// no upgrade is pending.
+func (lc *LightningChannel) CommitTypes() (channeldb.CommitmentType,
+	channeldb.CommitmentType) {
+
+	lc.RLock()
+	defer lc.RUnlock()
+
+	return lc.channelState.CommitType, lc.channelState.PendingCommitType
+}
+
 // compactLogs performs garbage collection within the log removing HTLC's which
 // have been removed from the point-of-view of the tail of both chains. The
 // entries which timeout/settle HTLC's are also removed.
@@ -1604,6 +2146,20 @@ func (lc *LightningChannel) AddHTLC(htlc *lnwire.HTLCAddRequest) (uint32, error)
 		Timeout:   htlc.Expiry,
 		Amount:    htlc.Amount,
 		Index:     lc.ourLogCounter,
+		Endorsed:  htlc.Endorsed,
+	}
+
+	// Ensure that the new HTLC satisfies the constraints the remote party
+	// has imposed upon us, and that it doesn't leave too much of the
+	// channel's funds to be lost to miners in the case of a broadcast.
+	err = lc.validateConstraints(lc.theirLogCounter, lc.ourLogCounter, pd,
+		false)
+	if err != nil {
+		return 0, err
+	}
+	err = lc.validateDustExposure(lc.theirLogCounter, lc.ourLogCounter, pd)
+	if err != nil {
+		return 0, err
 	}
 
 	lc.ourLogIndex[pd.Index] = lc.ourUpdateLog.PushBack(pd)
@@ -1630,6 +2186,15 @@ func (lc *LightningChannel) ReceiveHTLC(htlc *lnwire.HTLCAddRequest) (uint32, er
 		Timeout:   htlc.Expiry,
 		Amount:    htlc.Amount,
 		Index:     lc.theirLogCounter,
+		Endorsed:  htlc.Endorsed,
+	}
+
+	// An HTLC violating the constraints we've imposed upon the remote
+	// party is rejected outright.
+	err = lc.validateConstraints(lc.theirLogCounter, lc.ourLogCounter, pd,
+		true)
+	if err != nil {
+		return 0, err
 	}
 
 	lc.theirLogIndex[pd.Index] = lc.theirUpdateLog.PushBack(pd)
@@ -1785,6 +2350,11 @@ func (lc *LightningChannel) ChannelPoint() *wire.OutPoint {
 	return lc.channelState.ChanID
 }
 
+// IsInitiator returns true if we funded the channel.
+func (lc *LightningChannel) IsInitiator() bool {
+	return lc.channelState.IsInitiator
+}
+
 // addHTLC adds a new HTLC to the passed commitment transaction. One of four
 // full scripts will be generated for the HTLC output depending on if the HTLC
 // is incoming and if it's being applied to our commitment transaction or that
@@ -1877,6 +2447,40 @@ type ForceCloseSummary struct {
 	// SelfOutputSignDesc is a fully populated sign descriptor capable of
 	// generating a valid signature to sweep the self output.
 	SelfOutputSignDesc *SignDescriptor
+
+	// ChanPoint is the outpoint of the funding transaction of the closed
+	// channel.
+	ChanPoint wire.OutPoint
+
+	// HtlcTimeouts describes the outgoing HTLC outputs created by the
+	// above close tx, which are spendable by us once they've timed out.
+	HtlcTimeouts []*HtlcTimeoutResolution
+}
+
+// HtlcTimeoutResolution describes an outgoing HTLC output of our commitment
+// transaction. If the remote party doesn't claim it with the payment
+// preimage, then we're able to sweep it back to ourselves after both its
+// absolute timeout, and the relative delay of our commitment, have passed.
+type HtlcTimeoutResolution struct {
+	// Outpoint is the HTLC output created by the close tx.
+	Outpoint wire.OutPoint
+
+	// PaymentHash is the payment hash of the HTLC, whose preimage is
+	// revealed if the remote party claims the output.
+	PaymentHash [32]byte
+
+	// Expiry is the absolute height after which the HTLC output can be
+	// swept.
+	Expiry uint32
+
+	// CsvDelay is the relative maturity period, from the confirmation of
+	// the close tx, before the HTLC output can be swept.
+	CsvDelay uint32
+
+	// SignDesc is a fully populated sign descriptor capable of generating
+	// a valid signature to sweep the HTLC output through its timeout
+	// clause.
+	SignDesc *SignDescriptor
 }
 
 // getSignedCommitTx function take the latest commitment transaction and populate
@@ -1931,24 +2535,9 @@ func (lc *LightningChannel) ForceClose() (*ForceCloseSummary, error) {
 		return nil, err
 	}
 
-	// Locate the output index of the delayed commitment output back to us.
-	// We'll return the details of this output to the caller so they can
-	// sweep it once it's mature.
-	// TODO(roasbeef): also return HTLC info, assumes only p2wsh is commit
-	// tx
-	var delayIndex uint32
-	var delayScript []byte
-	for i, txOut := range commitTx.TxOut {
-		if !txscript.IsPayToWitnessScriptHash(txOut.PkScript) {
-			continue
-		}
-
-		delayIndex = uint32(i)
-		delayScript = txOut.PkScript
-	}
-
 	csvTimeout := lc.channelState.LocalCsvDelay
 	selfKey := lc.channelState.OurCommitKey
+	commitHash := commitTx.TxHash()
 
 	// Re-derive the original pkScript for out to-self output within the
 	// commitment transaction. We'll need this for the created sign
@@ -1965,6 +2554,16 @@ func (lc *LightningChannel) ForceClose() (*ForceCloseSummary, error) {
 		return nil, err
 	}
 
+	// Locate the output index of the delayed commitment output back to us.
+	// We'll return the details of this output to the caller so they can
+	// sweep it once it's mature. As HTLC outputs are also pay-to-witness
+	// script hash, the output is located by its script.
+	delayScript, err := witnessScriptHash(selfScript)
+	if err != nil {
+		return nil, err
+	}
+	_, delayIndex := FindScriptOutputIndex(commitTx, delayScript)
+
 	// With the necessary information gathered above, create a new sign
 	// descriptor which is capable of generating the signature the caller
 	// needs to sweep this output. The hash cache, and input index are not
@@ -1979,6 +2578,51 @@ func (lc *LightningChannel) ForceClose() (*ForceCloseSummary, error) {
 		HashType: txscript.SigHashAll,
 	}
 
+	// Next, we'll gather the outgoing HTLCs of the commitment transaction,
+	// which we're able to sweep back to ourselves once they've timed out.
+	// HTLCs below the dust limit don't have outputs of their own, so
+	// they're skipped.
+	revocationHash := fastsha256.Sum256(unusedRevocation[:])
+	var htlcTimeouts []*HtlcTimeoutResolution
+	for _, htlc := range lc.channelState.Htlcs {
+		if htlc.Incoming {
+			continue
+		}
+
+		htlcScript, err := senderHTLCScript(htlc.RefundTimeout,
+			csvTimeout, selfKey, lc.channelState.TheirCommitKey,
+			revocationHash[:], htlc.RHash[:])
+		if err != nil {
+			return nil, err
+		}
+		htlcPkScript, err := witnessScriptHash(htlcScript)
+		if err != nil {
+			return nil, err
+		}
+
+		// TODO(roasbeef): duplicated payment hashes...
+		found, htlcIndex := FindScriptOutputIndex(commitTx, htlcPkScript)
+		if !found {
+			continue
+		}
+
+		htlcTimeouts = append(htlcTimeouts, &HtlcTimeoutResolution{
+			Outpoint: wire.OutPoint{
+				Hash:  commitHash,
+				Index: htlcIndex,
+			},
+			PaymentHash: htlc.RHash,
+			Expiry:      htlc.RefundTimeout,
+			CsvDelay:    csvTimeout,
+			SignDesc: &SignDescriptor{
+				PubKey:        selfKey,
+				WitnessScript: htlcScript,
+				Output:        commitTx.TxOut[htlcIndex],
+				HashType:      txscript.SigHashAll,
+			},
+		})
+	}
+
 	// Finally, close the channel force close signal which notifies any
 	// subscribers that the channel has now been forcibly closed. This
 	// allows callers to begin to carry out any post channel closure
@@ -1988,11 +2632,13 @@ func (lc *LightningChannel) ForceClose() (*ForceCloseSummary, error) {
 	return &ForceCloseSummary{
 		CloseTx: commitTx,
 		SelfOutpoint: wire.OutPoint{
-			Hash:  commitTx.TxHash(),
+			Hash:  commitHash,
 			Index: delayIndex,
 		},
 		SelfOutputMaturity: csvTimeout,
 		SelfOutputSignDesc: selfSignDesc,
+		ChanPoint:          *lc.channelState.ChanID,
+		HtlcTimeouts:       htlcTimeouts,
 	}, nil
 }
 
@@ -13,6 +13,7 @@ import (
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/elkrem"
+	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/blockchain"
 	"github.com/roasbeef/btcd/btcec"
@@ -955,6 +956,185 @@ func TestCheckDustLimit(t *testing.T) {
 	}
 }
 
+// TestDustExposureLimit checks that HTLCs which would push the dust exposure
+// of a channel, the sum of its dust HTLCs plus the commitment fee, beyond the
+// configured limit are rejected.
+func TestDustExposureLimit(t *testing.T) {
+	createHTLC := func(i int, amount btcutil.Amount) *lnwire.HTLCAddRequest {
+		preimage := bytes.Repeat([]byte{byte(i)}, 32)
+		paymentHash := fastsha256.Sum256(preimage)
+		return &lnwire.HTLCAddRequest{
+			RedemptionHashes: [][32]byte{paymentHash},
+			Amount:           amount,
+			Expiry:           uint32(5),
+		}
+	}
+
+	aliceChannel, bobChannel, cleanUp, err := createTestChannels(3)
+	if err != nil {
+		t.Fatalf("unable to create test channels: %v", err)
+	}
+	defer cleanUp()
+
+	// The HTLCs are below Bob's dust limit, so each counts towards the
+	// dust exposure in full. With a zero fee rate, the limit allows for
+	// exactly two of them.
+	htlcAmount := btcutil.Amount(500)
+	if htlcAmount >= bobChannel.channelState.OurDustLimit {
+		t.Fatalf("htlc amount needs to be below Bob's dust limit")
+	}
+	aliceChannel.SetDustExposureLimit(2*htlcAmount, nil)
+	bobChannel.SetDustExposureLimit(2*htlcAmount, nil)
+
+	for i := 0; i < 2; i++ {
+		htlc := createHTLC(i, htlcAmount)
+		if _, err := aliceChannel.AddHTLC(htlc); err != nil {
+			t.Fatalf("alice unable to add htlc: %v", err)
+		}
+		if _, err := bobChannel.ReceiveHTLC(htlc); err != nil {
+			t.Fatalf("bob unable to receive htlc: %v", err)
+		}
+		if err := bobChannel.CheckDustExposure(); err != nil {
+			t.Fatalf("dust exposure within limit rejected: %v", err)
+		}
+	}
+
+	htlc := createHTLC(2, htlcAmount)
+	if _, err := aliceChannel.AddHTLC(htlc); err != ErrDustExposure {
+		t.Fatalf("expected ErrDustExposure, got %v", err)
+	}
+
+	// The HTLC received by Bob is added to his log, but should be flagged
+	// as exceeding the limit so that it can be failed back.
+	if _, err := bobChannel.ReceiveHTLC(htlc); err != nil {
+		t.Fatalf("bob unable to receive htlc: %v", err)
+	}
+	if err := bobChannel.CheckDustExposure(); err != ErrDustExposure {
+		t.Fatalf("expected ErrDustExposure, got %v", err)
+	}
+
+	// Once the commitment fee, raised by the fee spike multiplier, is
+	// accounted for, not even the HTLCs already added fit within the
+	// limit.
+	estimator := StaticFeeEstimator{FeeRate: 10}
+	aliceChannel.SetDustExposureLimit(2*htlcAmount, estimator)
+	if err := aliceChannel.CheckDustExposure(); err != ErrDustExposure {
+		t.Fatalf("expected ErrDustExposure, got %v", err)
+	}
+
+	// A limit of zero disables the check entirely.
+	aliceChannel.SetDustExposureLimit(0, estimator)
+	if _, err := aliceChannel.AddHTLC(htlc); err != nil {
+		t.Fatalf("alice unable to add htlc: %v", err)
+	}
+}
+
+// TestChannelConstraints checks that HTLCs violating the channel constraints
+// imposed upon the offering party are rejected on both ends of the channel.
+func TestChannelConstraints(t *testing.T) {
+	createHTLC := func(i int, amount btcutil.Amount) *lnwire.HTLCAddRequest {
+		preimage := bytes.Repeat([]byte{byte(i)}, 32)
+		paymentHash := fastsha256.Sum256(preimage)
+		return &lnwire.HTLCAddRequest{
+			RedemptionHashes: [][32]byte{paymentHash},
+			Amount:           amount,
+			Expiry:           uint32(5),
+		}
+	}
+
+	aliceChannel, bobChannel, cleanUp, err := createTestChannels(3)
+	if err != nil {
+		t.Fatalf("unable to create test channels: %v", err)
+	}
+	defer cleanUp()
+
+	// Bob imposes constraints upon the HTLCs offered by Alice, which are
+	// enforced by both parties.
+	setConstraints := func(c channeldb.ChannelConstraints) {
+		aliceChannel.channelState.TheirConstraints = c
+		bobChannel.channelState.OurConstraints = c
+	}
+	assertRejected := func(htlc *lnwire.HTLCAddRequest, expected error) {
+		if _, err := aliceChannel.AddHTLC(htlc); err != expected {
+			t.Fatalf("expected alice to fail with %v, got %v",
+				expected, err)
+		}
+		if _, err := bobChannel.ReceiveHTLC(htlc); err != expected {
+			t.Fatalf("expected bob to fail with %v, got %v",
+				expected, err)
+		}
+	}
+
+	constraints := channeldb.ChannelConstraints{
+		MinHTLC:          1000,
+		MaxAcceptedHtlcs: 2,
+	}
+	setConstraints(constraints)
+
+	htlcAmount := btcutil.Amount(1e6)
+	assertRejected(createHTLC(0, constraints.MinHTLC-1), ErrBelowMinHTLC)
+
+	for i := 0; i < 2; i++ {
+		htlc := createHTLC(i, htlcAmount)
+		if _, err := aliceChannel.AddHTLC(htlc); err != nil {
+			t.Fatalf("alice unable to add htlc: %v", err)
+		}
+		if _, err := bobChannel.ReceiveHTLC(htlc); err != nil {
+			t.Fatalf("bob unable to receive htlc: %v", err)
+		}
+	}
+	assertRejected(createHTLC(2, htlcAmount), ErrMaxAcceptedHTLCs)
+
+	// With room for more HTLCs, the total value pending should now be
+	// the limiting factor.
+	constraints.MaxAcceptedHtlcs = 10
+	constraints.MaxPendingAmount = 2*htlcAmount + htlcAmount/2
+	setConstraints(constraints)
+	assertRejected(createHTLC(2, htlcAmount), ErrMaxPendingAmount)
+
+	// Finally, Alice must keep her reserve once all her pending HTLCs are
+	// deducted from her balance.
+	constraints.MaxPendingAmount = 0
+	constraints.ChanReserve = aliceChannel.channelState.OurBalance -
+		3*htlcAmount + 1
+	setConstraints(constraints)
+	assertRejected(createHTLC(2, htlcAmount), ErrBelowChanReserve)
+
+	constraints.ChanReserve--
+	setConstraints(constraints)
+	if _, err := aliceChannel.AddHTLC(createHTLC(2, htlcAmount)); err != nil {
+		t.Fatalf("alice unable to add htlc: %v", err)
+	}
+}
+
+// TestVerifyConstraints checks that constraints rendering a channel unusable
+// are rejected.
+func TestVerifyConstraints(t *testing.T) {
+	capacity := btcutil.Amount(1e6)
+
+	valid := channeldb.ChannelConstraints{
+		ChanReserve:      DefaultChanReserve(capacity),
+		MaxPendingAmount: capacity,
+		MinHTLC:          1,
+		MaxAcceptedHtlcs: DefaultMaxAcceptedHTLCs,
+	}
+	if err := VerifyConstraints(valid, capacity); err != nil {
+		t.Fatalf("valid constraints rejected: %v", err)
+	}
+
+	invalid := []channeldb.ChannelConstraints{
+		{ChanReserve: capacity/5 + 1},
+		{MinHTLC: capacity},
+		{MaxPendingAmount: 10, MinHTLC: 20},
+		{MaxAcceptedHtlcs: MaxHTLCNumber + 1},
+	}
+	for i, c := range invalid {
+		if err := VerifyConstraints(c, capacity); err == nil {
+			t.Fatalf("invalid constraints #%d accepted", i)
+		}
+	}
+}
+
 func TestStateUpdatePersistence(t *testing.T) {
 	// Create a test channel which will be used for the duration of this
 	// unittest. The channel will be funded evenly with Alice having 5 BTC,
@@ -1253,3 +1433,318 @@ func TestCancelHTLC(t *testing.T) {
 			bobChannel.channelState.TheirBalance, expectedBalance)
 	}
 }
+
+// TestForceCloseHtlcTimeouts asserts that the summary of a force close
+// describes the outgoing HTLC outputs of the broadcast commitment, and that
+// they can be swept through their timeout clause once it has passed.
+func TestForceCloseHtlcTimeouts(t *testing.T) {
+	aliceChannel, bobChannel, cleanUp, err := createTestChannels(5)
+	if err != nil {
+		t.Fatalf("unable to create test channels: %v", err)
+	}
+	defer cleanUp()
+
+	// Add a new HTLC from Alice to Bob, then trigger a new state
+	// transition in order to include it in the latest state.
+	const (
+		htlcAmt    = btcutil.SatoshiPerBitcoin
+		htlcExpiry = 10
+	)
+
+	var preImage [32]byte
+	copy(preImage[:], bytes.Repeat([]byte{0xaa}, 32))
+	htlc := &lnwire.HTLCAddRequest{
+		RedemptionHashes: [][32]byte{fastsha256.Sum256(preImage[:])},
+		Amount:           htlcAmt,
+		Expiry:           htlcExpiry,
+	}
+	if _, err := aliceChannel.AddHTLC(htlc); err != nil {
+		t.Fatalf("unable to add alice htlc: %v", err)
+	}
+	if _, err := bobChannel.ReceiveHTLC(htlc); err != nil {
+		t.Fatalf("unable to add bob htlc: %v", err)
+	}
+	if err := forceStateTransition(aliceChannel, bobChannel); err != nil {
+		t.Fatalf("unable to create new commitment state: %v", err)
+	}
+
+	closeSummary, err := aliceChannel.ForceClose()
+	if err != nil {
+		t.Fatalf("unable to force close channel: %v", err)
+	}
+	closeTx := closeSummary.CloseTx
+
+	if closeSummary.ChanPoint != *aliceChannel.ChannelPoint() {
+		t.Fatalf("expected chan point %v, got %v",
+			aliceChannel.ChannelPoint(), closeSummary.ChanPoint)
+	}
+
+	// The self output should be located by its script, rather than being
+	// confused with the HTLC output.
+	selfOutput := closeTx.TxOut[closeSummary.SelfOutpoint.Index]
+	if !bytes.Equal(selfOutput.PkScript,
+		closeSummary.SelfOutputSignDesc.Output.PkScript) {
+
+		t.Fatalf("self outpoint doesn't match the self output script")
+	}
+
+	if len(closeSummary.HtlcTimeouts) != 1 {
+		t.Fatalf("expected a single htlc timeout, got %v",
+			len(closeSummary.HtlcTimeouts))
+	}
+	htlcTimeout := closeSummary.HtlcTimeouts[0]
+	if htlcTimeout.Expiry != htlcExpiry {
+		t.Fatalf("expected expiry of %v, got %v", htlcExpiry,
+			htlcTimeout.Expiry)
+	}
+	if htlcTimeout.CsvDelay != aliceChannel.channelState.LocalCsvDelay {
+		t.Fatalf("expected csv delay of %v, got %v",
+			aliceChannel.channelState.LocalCsvDelay,
+			htlcTimeout.CsvDelay)
+	}
+	htlcOutput := closeTx.TxOut[htlcTimeout.Outpoint.Index]
+	if htlcOutput.Value != htlcAmt {
+		t.Fatalf("expected htlc output of %v, got %v", htlcAmt,
+			htlcOutput.Value)
+	}
+
+	// Finally, sweep the HTLC output through its timeout clause, which
+	// should be valid once both its lock time and the relative delay of
+	// the commitment are satisfied.
+	sweepTx := wire.NewMsgTx(2)
+	sweepTx.LockTime = htlcTimeout.Expiry
+	sweepTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: htlcTimeout.Outpoint,
+		Sequence:         htlcTimeout.CsvDelay,
+	})
+	sweepTx.AddTxOut(&wire.TxOut{
+		PkScript: htlcOutput.PkScript,
+		Value:    htlcOutput.Value - 1000,
+	})
+
+	signDesc := htlcTimeout.SignDesc
+	signDesc.SigHashes = txscript.NewTxSigHashes(sweepTx)
+	signDesc.InputIndex = 0
+	witness, err := HtlcSpendTimeout(aliceChannel.signer, signDesc, sweepTx)
+	if err != nil {
+		t.Fatalf("unable to generate htlc timeout witness: %v", err)
+	}
+	sweepTx.TxIn[0].Witness = witness
+
+	vm, err := txscript.NewEngine(htlcOutput.PkScript, sweepTx, 0,
+		txscript.StandardVerifyFlags, nil, nil, htlcOutput.Value)
+	if err != nil {
+		t.Fatalf("unable to create engine: %v", err)
+	}
+	if err := vm.Execute(); err != nil {
+		t.Fatalf("htlc timeout spend is invalid: %v", err)
+	}
+}
+
+// TestCommitTypeUpgrade asserts that once both parties have upgraded a
+// channel to the static remote key commitment type, new commitments pay the
+// balance of the counterparty to its payment key, and that the upgrade
+// completes once the prior commitments have been revoked.
+func TestCommitTypeUpgrade(t *testing.T) {
+	aliceChannel, bobChannel, cleanUp, err := createTestChannels(5)
+	if err != nil {
+		t.Fatalf("unable to create test channels: %v", err)
+	}
+	defer cleanUp()
+
+	_, alicePaymentKey := btcec.PrivKeyFromBytes(btcec.S256(),
+		bytes.Repeat([]byte{0x11}, 32))
+	_, bobPaymentKey := btcec.PrivKeyFromBytes(btcec.S256(),
+		bytes.Repeat([]byte{0x22}, 32))
+
+	// The channel can't be upgraded while an update is uncommitted.
+	htlc := &lnwire.HTLCAddRequest{
+		RedemptionHashes: [][32]byte{fastsha256.Sum256([]byte{1})},
+		Amount:           btcutil.SatoshiPerBitcoin,
+		Expiry:           10,
+	}
+	if _, err := aliceChannel.AddHTLC(htlc); err != nil {
+		t.Fatalf("unable to add alice htlc: %v", err)
+	}
+	if _, err := bobChannel.ReceiveHTLC(htlc); err != nil {
+		t.Fatalf("unable to add bob htlc: %v", err)
+	}
+	upgradeHeight := aliceChannel.CommitUpgradeHeight()
+	err = aliceChannel.UpgradeCommitType(
+		channeldb.CommitTypeStaticRemoteKey, upgradeHeight,
+		keychain.KeyDescriptor{PubKey: alicePaymentKey}, bobPaymentKey,
+	)
+	if err != ErrPendingCommitments {
+		t.Fatalf("expected ErrPendingCommitments, got %v", err)
+	}
+	if err := forceStateTransition(aliceChannel, bobChannel); err != nil {
+		t.Fatalf("unable to create new commitment state: %v", err)
+	}
+
+	// With all updates committed, both parties upgrade the channel from
+	// the next commitment height, which they must agree upon.
+	upgradeHeight = aliceChannel.CommitUpgradeHeight()
+	if upgradeHeight != bobChannel.CommitUpgradeHeight() {
+		t.Fatalf("upgrade heights don't match: %v vs %v",
+			upgradeHeight, bobChannel.CommitUpgradeHeight())
+	}
+	err = aliceChannel.UpgradeCommitType(
+		channeldb.CommitTypeStaticRemoteKey, upgradeHeight+1,
+		keychain.KeyDescriptor{PubKey: alicePaymentKey}, bobPaymentKey,
+	)
+	if err == nil {
+		t.Fatalf("upgrade with mismatched height shouldn't be allowed")
+	}
+	err = aliceChannel.UpgradeCommitType(
+		channeldb.CommitTypeStaticRemoteKey, upgradeHeight,
+		keychain.KeyDescriptor{PubKey: alicePaymentKey}, bobPaymentKey,
+	)
+	if err != nil {
+		t.Fatalf("unable to upgrade alice's channel: %v", err)
+	}
+	err = bobChannel.UpgradeCommitType(
+		channeldb.CommitTypeStaticRemoteKey, upgradeHeight,
+		keychain.KeyDescriptor{PubKey: bobPaymentKey}, alicePaymentKey,
+	)
+	if err != nil {
+		t.Fatalf("unable to upgrade bob's channel: %v", err)
+	}
+
+	// A second upgrade isn't allowed until the first one completes.
+	err = aliceChannel.UpgradeCommitType(
+		channeldb.CommitTypeStaticRemoteKey, upgradeHeight,
+		keychain.KeyDescriptor{PubKey: alicePaymentKey}, bobPaymentKey,
+	)
+	if err != ErrCommitUpgradePending {
+		t.Fatalf("expected ErrCommitUpgradePending, got %v", err)
+	}
+
+	current, pending := aliceChannel.CommitTypes()
+	if current != channeldb.CommitTypeLegacy ||
+		pending != channeldb.CommitTypeStaticRemoteKey {
+		t.Fatalf("expected pending upgrade, got %v/%v", current,
+			pending)
+	}
+
+	// The next state transition creates commitments in the new format,
+	// revoking the last ones in the prior format.
+	if err := forceStateTransition(aliceChannel, bobChannel); err != nil {
+		t.Fatalf("unable to create new commitment state: %v", err)
+	}
+
+	for _, channel := range []*LightningChannel{aliceChannel, bobChannel} {
+		current, pending := channel.CommitTypes()
+		if current != channeldb.CommitTypeStaticRemoteKey ||
+			pending != channeldb.CommitTypeStaticRemoteKey {
+			t.Fatalf("upgrade not completed, got %v/%v", current,
+				pending)
+		}
+	}
+
+	// Alice's commitment must pay Bob's balance to his payment key, and
+	// Bob's commitment Alice's balance to hers.
+	assertPaysTo := func(commitTx *wire.MsgTx, key *btcec.PublicKey) {
+		pkScript, err := commitScriptUnencumbered(key)
+		if err != nil {
+			t.Fatalf("unable to create script: %v", err)
+		}
+		if found, _ := FindScriptOutputIndex(commitTx, pkScript); !found {
+			t.Fatalf("commitment doesn't pay to payment key: %v",
+				spew.Sdump(commitTx))
+		}
+	}
+	assertPaysTo(aliceChannel.localCommitChain.tip().txn, bobPaymentKey)
+	assertPaysTo(bobChannel.localCommitChain.tip().txn, alicePaymentKey)
+}
+
+// TestReconcileCommitUpgrade asserts that an upgrade recorded by only one
+// party is abandoned once the channel is reestablished, so both parties keep
+// creating commitments in the prior format.
+func TestReconcileCommitUpgrade(t *testing.T) {
+	aliceChannel, bobChannel, cleanUp, err := createTestChannels(5)
+	if err != nil {
+		t.Fatalf("unable to create test channels: %v", err)
+	}
+	defer cleanUp()
+
+	_, alicePaymentKey := btcec.PrivKeyFromBytes(btcec.S256(),
+		bytes.Repeat([]byte{0x11}, 32))
+	_, bobPaymentKey := btcec.PrivKeyFromBytes(btcec.S256(),
+		bytes.Repeat([]byte{0x22}, 32))
+
+	// Bob accepts the upgrade proposed by Alice, but his acceptance never
+	// reaches her.
+	err = bobChannel.UpgradeCommitType(
+		channeldb.CommitTypeStaticRemoteKey,
+		bobChannel.CommitUpgradeHeight(),
+		keychain.KeyDescriptor{PubKey: bobPaymentKey}, alicePaymentKey,
+	)
+	if err != nil {
+		t.Fatalf("unable to upgrade bob's channel: %v", err)
+	}
+
+	// Once the channel is reestablished, both parties announce the format
+	// they create commitments in. As Alice never recorded the upgrade,
+	// she has nothing to abandon, while Bob abandons his upgrade.
+	aborted, err := aliceChannel.ReconcileCommitUpgrade(
+		channeldb.CommitTypeStaticRemoteKey,
+	)
+	if err != nil || aborted {
+		t.Fatalf("alice has no upgrade to abandon: %v", err)
+	}
+	aborted, err = bobChannel.ReconcileCommitUpgrade(
+		channeldb.CommitTypeLegacy,
+	)
+	if err != nil {
+		t.Fatalf("unable to reconcile bob's upgrade: %v", err)
+	}
+	if !aborted {
+		t.Fatalf("bob's upgrade should be abandoned")
+	}
+	current, pending := bobChannel.CommitTypes()
+	if current != channeldb.CommitTypeLegacy ||
+		pending != channeldb.CommitTypeLegacy {
+		t.Fatalf("expected legacy channel, got %v/%v", current,
+			pending)
+	}
+
+	// Both parties should keep agreeing on new commitments.
+	htlc := &lnwire.HTLCAddRequest{
+		RedemptionHashes: [][32]byte{fastsha256.Sum256([]byte{1})},
+		Amount:           btcutil.SatoshiPerBitcoin,
+		Expiry:           10,
+	}
+	if _, err := aliceChannel.AddHTLC(htlc); err != nil {
+		t.Fatalf("unable to add alice htlc: %v", err)
+	}
+	if _, err := bobChannel.ReceiveHTLC(htlc); err != nil {
+		t.Fatalf("unable to add bob htlc: %v", err)
+	}
+	if err := forceStateTransition(aliceChannel, bobChannel); err != nil {
+		t.Fatalf("unable to create new commitment state: %v", err)
+	}
+
+	// Once both parties recorded the upgrade, there's nothing to
+	// abandon.
+	upgradeHeight := aliceChannel.CommitUpgradeHeight()
+	err = aliceChannel.UpgradeCommitType(
+		channeldb.CommitTypeStaticRemoteKey, upgradeHeight,
+		keychain.KeyDescriptor{PubKey: alicePaymentKey}, bobPaymentKey,
+	)
+	if err != nil {
+		t.Fatalf("unable to upgrade alice's channel: %v", err)
+	}
+	err = bobChannel.UpgradeCommitType(
+		channeldb.CommitTypeStaticRemoteKey, upgradeHeight,
+		keychain.KeyDescriptor{PubKey: bobPaymentKey}, alicePaymentKey,
+	)
+	if err != nil {
+		t.Fatalf("unable to upgrade bob's channel: %v", err)
+	}
+	aborted, err = aliceChannel.ReconcileCommitUpgrade(
+		channeldb.CommitTypeStaticRemoteKey,
+	)
+	if err != nil || aborted {
+		t.Fatalf("alice's upgrade shouldn't be abandoned: %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+package lnwallet
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+// CoinSelectionStrategy is an enum-like type which denotes the order in which
+// the eligible coins of the wallet are considered during coin selection.
+type CoinSelectionStrategy uint8
+
+const (
+	// CoinSelectionDefault indicates that the default strategy of the
+	// wallet should be used. If it's the wallet's strategy itself, then
+	// the coins are considered in the order the wallet lists them.
+	CoinSelectionDefault CoinSelectionStrategy = iota
+
+	// CoinSelectionLargest selects the coins with the largest value first,
+	// minimizing the number of inputs of the resulting transaction.
+	CoinSelectionLargest
+
+	// CoinSelectionRandom selects coins in a random order, avoiding the
+	// predictable selection patterns of the other strategies.
+	CoinSelectionRandom
+
+	// CoinSelectionSmallest selects the coins with the smallest value
+	// first, consolidating small outputs of the wallet.
+	CoinSelectionSmallest
+)
+
+// String returns a human readable name for the strategy.
+func (s CoinSelectionStrategy) String() string {
+	switch s {
+	case CoinSelectionDefault:
+		return "default"
+	case CoinSelectionLargest:
+		return "largest"
+	case CoinSelectionRandom:
+		return "random"
+	case CoinSelectionSmallest:
+		return "smallest"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(s))
+	}
+}
+
+// ParseCoinSelectionStrategy parses a coin selection strategy from its human
+// readable name.
+func ParseCoinSelectionStrategy(s string) (CoinSelectionStrategy, error) {
+	switch s {
+	case "default":
+		return CoinSelectionDefault, nil
+	case "largest":
+		return CoinSelectionLargest, nil
+	case "random":
+		return CoinSelectionRandom, nil
+	case "smallest":
+		return CoinSelectionSmallest, nil
+	default:
+		return 0, fmt.Errorf("unknown coin selection strategy %q, "+
+			"supported strategies are: default, largest, random, "+
+			"smallest", s)
+	}
+}
+
+// CoinSelection describes how the coins funding a transaction are to be
+// chosen from the eligible coins of the wallet.
+type CoinSelection struct {
+	// Strategy is the order in which the eligible coins are considered.
+	Strategy CoinSelectionStrategy
+
+	// Outpoints, if non-empty, restricts coin selection to only the
+	// specified outputs of the wallet, considered in the given order.
+	Outpoints []wire.OutPoint
+
+	// Account, if set, is the name of the imported account whose coins
+	// are to be used instead of those of the wallet. As the wallet can't
+	// sign for such coins, they may only be used to fund PSBTs.
+	Account string
+}
+
+// coinsByValue is a helper type which allows a slice of coins to be sorted by
+// value in ascending order.
+type coinsByValue []*Utxo
+
+func (c coinsByValue) Len() int           { return len(c) }
+func (c coinsByValue) Less(i, j int) bool { return c[i].Value < c[j].Value }
+func (c coinsByValue) Swap(i, j int)      { c[i], c[j] = c[j], c[i] }
+
+// arrangeCoins returns the coins which are eligible for coin selection, in the
+// order they should be considered according to the passed coin selection. If
+// the coin selection specifies an explicit set of outpoints, then an error is
+// returned if any of them isn't among the passed coins.
+func arrangeCoins(coins []*Utxo, selection *CoinSelection,
+	defaultStrategy CoinSelectionStrategy) ([]*Utxo, error) {
+
+	if selection != nil && len(selection.Outpoints) != 0 {
+		coinIndex := make(map[wire.OutPoint]*Utxo, len(coins))
+		for _, coin := range coins {
+			coinIndex[coin.OutPoint] = coin
+		}
+
+		selected := make([]*Utxo, 0, len(selection.Outpoints))
+		for _, op := range selection.Outpoints {
+			coin, ok := coinIndex[op]
+			if !ok {
+				return nil, fmt.Errorf("outpoint %v is not an "+
+					"available output of the wallet", op)
+			}
+			selected = append(selected, coin)
+		}
+
+		return selected, nil
+	}
+
+	strategy := defaultStrategy
+	if selection != nil && selection.Strategy != CoinSelectionDefault {
+		strategy = selection.Strategy
+	}
+
+	arranged := make([]*Utxo, len(coins))
+	copy(arranged, coins)
+
+	switch strategy {
+	// The coins are left in the order the wallet lists them.
+	case CoinSelectionDefault:
+
+	case CoinSelectionLargest:
+		sort.Sort(sort.Reverse(coinsByValue(arranged)))
+
+	case CoinSelectionSmallest:
+		sort.Sort(coinsByValue(arranged))
+
+	case CoinSelectionRandom:
+		r := rand.New(rand.NewSource(time.Now().UnixNano()))
+		for i, j := range r.Perm(len(coins)) {
+			arranged[i] = coins[j]
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown coin selection strategy: %v",
+			strategy)
+	}
+
+	return arranged, nil
+}
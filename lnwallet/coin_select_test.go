@@ -0,0 +1,196 @@
+package lnwallet
+
+import (
+	"testing"
+
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// newTestCoins returns a set of coins with the passed values, each spending a
+// distinct outpoint.
+func newTestCoins(values ...btcutil.Amount) []*Utxo {
+	coins := make([]*Utxo, 0, len(values))
+	for i, value := range values {
+		coins = append(coins, &Utxo{
+			Value:    value,
+			OutPoint: wire.OutPoint{Index: uint32(i)},
+		})
+	}
+
+	return coins
+}
+
+// coinValues returns the values of the passed coins in order.
+func coinValues(coins []*Utxo) []btcutil.Amount {
+	values := make([]btcutil.Amount, 0, len(coins))
+	for _, coin := range coins {
+		values = append(values, coin.Value)
+	}
+
+	return values
+}
+
+func assertCoinValues(t *testing.T, coins []*Utxo, expected ...btcutil.Amount) {
+	values := coinValues(coins)
+	if len(values) != len(expected) {
+		t.Fatalf("expected %v coins, got %v", len(expected), len(values))
+	}
+	for i := range values {
+		if values[i] != expected[i] {
+			t.Fatalf("expected coin values %v, got %v", expected,
+				values)
+		}
+	}
+}
+
+// TestArrangeCoins ensures that the eligible coins are ordered according to
+// the selected strategy, and that explicit outpoints restrict the selection.
+func TestArrangeCoins(t *testing.T) {
+	coins := newTestCoins(20, 50, 10, 30)
+
+	// If neither the coin selection nor the wallet specify a strategy,
+	// then the coins should be left in the order they were listed.
+	arranged, err := arrangeCoins(coins, nil, CoinSelectionDefault)
+	if err != nil {
+		t.Fatalf("unable to arrange coins: %v", err)
+	}
+	assertCoinValues(t, arranged, 20, 50, 10, 30)
+
+	// Without an explicit coin selection, the passed default strategy
+	// should be used.
+	arranged, err = arrangeCoins(coins, nil, CoinSelectionSmallest)
+	if err != nil {
+		t.Fatalf("unable to arrange coins: %v", err)
+	}
+	assertCoinValues(t, arranged, 10, 20, 30, 50)
+
+	// A strategy within the coin selection should override the default.
+	arranged, err = arrangeCoins(coins, &CoinSelection{
+		Strategy: CoinSelectionLargest,
+	}, CoinSelectionSmallest)
+	if err != nil {
+		t.Fatalf("unable to arrange coins: %v", err)
+	}
+	assertCoinValues(t, arranged, 50, 30, 20, 10)
+
+	// The random strategy should return every coin exactly once.
+	arranged, err = arrangeCoins(coins, &CoinSelection{
+		Strategy: CoinSelectionRandom,
+	}, CoinSelectionLargest)
+	if err != nil {
+		t.Fatalf("unable to arrange coins: %v", err)
+	}
+	seen := make(map[wire.OutPoint]struct{})
+	for _, coin := range arranged {
+		seen[coin.OutPoint] = struct{}{}
+	}
+	if len(arranged) != len(coins) || len(seen) != len(coins) {
+		t.Fatalf("random arrangement should contain every coin once, "+
+			"got %v", coinValues(arranged))
+	}
+
+	// The original slice of coins must be left untouched.
+	assertCoinValues(t, coins, 20, 50, 10, 30)
+
+	// Explicit outpoints should restrict the selection to only those
+	// coins, in the given order.
+	arranged, err = arrangeCoins(coins, &CoinSelection{
+		Outpoints: []wire.OutPoint{
+			coins[2].OutPoint, coins[0].OutPoint,
+		},
+	}, CoinSelectionLargest)
+	if err != nil {
+		t.Fatalf("unable to arrange coins: %v", err)
+	}
+	assertCoinValues(t, arranged, 10, 20)
+
+	// An outpoint which isn't among the coins should be rejected.
+	_, err = arrangeCoins(coins, &CoinSelection{
+		Outpoints: []wire.OutPoint{{Index: 100}},
+	}, CoinSelectionLargest)
+	if err == nil {
+		t.Fatalf("unknown outpoint should be rejected")
+	}
+}
+
+// TestParseCoinSelectionStrategy ensures that every strategy survives a round
+// trip through its human readable name.
+func TestParseCoinSelectionStrategy(t *testing.T) {
+	strategies := []CoinSelectionStrategy{
+		CoinSelectionDefault,
+		CoinSelectionLargest,
+		CoinSelectionRandom,
+		CoinSelectionSmallest,
+	}
+	for _, strategy := range strategies {
+		parsed, err := ParseCoinSelectionStrategy(strategy.String())
+		if err != nil {
+			t.Fatalf("unable to parse %v: %v", strategy, err)
+		}
+		if parsed != strategy {
+			t.Fatalf("expected %v, got %v", strategy, parsed)
+		}
+	}
+
+	if _, err := ParseCoinSelectionStrategy("biggest"); err == nil {
+		t.Fatalf("unknown strategy should be rejected")
+	}
+}
+
+// TestEstimatePsbtFeeNested ensures that spending nested p2wkh outputs, and
+// sending change to a nested p2wkh address, are accounted for in the fee of a
+// funded packet.
+func TestEstimatePsbtFeeNested(t *testing.T) {
+	tx := wire.NewMsgTx(2)
+	tx.AddTxOut(&wire.TxOut{Value: 1000, PkScript: make([]byte, P2WSHSize)})
+
+	p2wkhScript := make([]byte, P2WPKHSize)
+	p2wkhScript[0] = txscript.OP_0
+	p2wkhScript[1] = txscript.OP_DATA_20
+
+	p2shScript := make([]byte, P2SHSize)
+	p2shScript[0] = txscript.OP_HASH160
+	p2shScript[1] = txscript.OP_DATA_20
+	p2shScript[P2SHSize-1] = txscript.OP_EQUAL
+
+	nativeFee := estimatePsbtFee(1, tx, inputWeight(p2wkhScript),
+		changeOutputSize(WitnessPubKey))
+	nestedFee := estimatePsbtFee(1, tx, inputWeight(p2shScript),
+		changeOutputSize(WitnessPubKey))
+	if nestedFee-nativeFee != NestedP2WPKHSigScriptSize {
+		t.Fatalf("expected nested input to cost %v more, got %v",
+			NestedP2WPKHSigScriptSize, nestedFee-nativeFee)
+	}
+
+	nestedChangeFee := estimatePsbtFee(1, tx, inputWeight(p2wkhScript),
+		changeOutputSize(NestedWitnessPubKey))
+	if nestedChangeFee-nativeFee != P2SHSize-P2WPKHSize {
+		t.Fatalf("expected nested change to cost %v more, got %v",
+			P2SHSize-P2WPKHSize, nestedChangeFee-nativeFee)
+	}
+}
+
+// TestParseAddressType ensures that the supported address types are parsed
+// from their human readable names, and that unknown types are rejected.
+func TestParseAddressType(t *testing.T) {
+	types := map[string]AddressType{
+		"p2wkh":  WitnessPubKey,
+		"np2wkh": NestedWitnessPubKey,
+		"p2pkh":  PubKeyHash,
+	}
+	for name, addrType := range types {
+		parsed, err := ParseAddressType(name)
+		if err != nil {
+			t.Fatalf("unable to parse %v: %v", name, err)
+		}
+		if parsed != addrType {
+			t.Fatalf("expected %v, got %v", addrType, parsed)
+		}
+	}
+
+	if _, err := ParseAddressType("p2tr"); err == nil {
+		t.Fatalf("unsupported address type should be rejected")
+	}
+}
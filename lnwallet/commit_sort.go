@@ -0,0 +1,68 @@
+package lnwallet
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+// SortCommitTx sorts the outputs of the passed commitment transaction into the
+// canonical order of BOLT #3: by amount, then lexicographically by public key
+// script, then by the CLTV expiry of the HTLC paid to. This is the order of
+// BIP 69, except that HTLC outputs with the same amount and script, yet
+// differing expiries, are ordered deterministically too, so that both parties
+// of the channel, whichever implementation they run, sign the same
+// transaction. The passed slice holds the CLTV expiry of the HTLC paid to by
+// each output, zero for the outputs paying to either party. If it's nil, then
+// no output pays to an HTLC.
+//
+// NOTE: A commitment transaction only spends the funding output, so its single
+// input is already in canonical order.
+func SortCommitTx(commitTx *wire.MsgTx, cltvs []uint32) {
+	if cltvs == nil {
+		cltvs = make([]uint32, len(commitTx.TxOut))
+	}
+
+	sort.Sort(&commitOutputs{
+		outputs: commitTx.TxOut,
+		cltvs:   cltvs,
+	})
+}
+
+// commitOutputs is the list of outputs of a commitment transaction, along
+// with the CLTV expiry of the HTLC paid to by each of them. It implements
+// sort.Interface, sorting the outputs into the canonical order of BOLT #3.
+type commitOutputs struct {
+	outputs []*wire.TxOut
+	cltvs   []uint32
+}
+
+// Len returns the number of outputs. It is part of the sort.Interface
+// implementation.
+func (c *commitOutputs) Len() int {
+	return len(c.outputs)
+}
+
+// Swap swaps the outputs at the passed indices, along with their expiries.
+// It is part of the sort.Interface implementation.
+func (c *commitOutputs) Swap(i, j int) {
+	c.outputs[i], c.outputs[j] = c.outputs[j], c.outputs[i]
+	c.cltvs[i], c.cltvs[j] = c.cltvs[j], c.cltvs[i]
+}
+
+// Less returns whether the output at index i should sort before the output at
+// index j. It is part of the sort.Interface implementation.
+func (c *commitOutputs) Less(i, j int) bool {
+	outI, outJ := c.outputs[i], c.outputs[j]
+	if outI.Value != outJ.Value {
+		return outI.Value < outJ.Value
+	}
+
+	cmp := bytes.Compare(outI.PkScript, outJ.PkScript)
+	if cmp != 0 {
+		return cmp < 0
+	}
+
+	return c.cltvs[i] < c.cltvs[j]
+}
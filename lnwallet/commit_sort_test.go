@@ -0,0 +1,49 @@
+package lnwallet
+
+import (
+	"testing"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+// TestSortCommitTx asserts that the outputs of a commitment transaction are
+// sorted by amount, then by public key script, and that HTLC outputs which are
+// otherwise identical are ordered by their CLTV expiry.
+func TestSortCommitTx(t *testing.T) {
+	htlcScript := []byte{0x00, 0x20, 0x02}
+
+	// Bar the smallest, the outputs paying to the HTLC script share their
+	// amount, so they should be ordered by their expiry. The output paying
+	// to the lesser script should sort before all of them.
+	commitTx := wire.NewMsgTx()
+	outputs := []struct {
+		value  int64
+		script []byte
+		cltv   uint32
+	}{
+		{5000, htlcScript, 300},
+		{5000, []byte{0x00, 0x20, 0x01}, 0},
+		{1000, htlcScript, 100},
+		{5000, htlcScript, 200},
+		{5000, htlcScript, 0},
+	}
+	cltvs := make([]uint32, 0, len(outputs))
+	for _, output := range outputs {
+		commitTx.AddTxOut(wire.NewTxOut(output.value, output.script))
+		cltvs = append(cltvs, output.cltv)
+	}
+
+	SortCommitTx(commitTx, cltvs)
+
+	expectedOrder := []int{2, 1, 4, 3, 0}
+	for i, idx := range expectedOrder {
+		if commitTx.TxOut[i].Value != outputs[idx].value {
+			t.Fatalf("output %v: expected value %v, got %v", i,
+				outputs[idx].value, commitTx.TxOut[i].Value)
+		}
+		if cltvs[i] != outputs[idx].cltv {
+			t.Fatalf("output %v: expected cltv %v, got %v", i,
+				outputs[idx].cltv, cltvs[i])
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package lnwallet
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/roasbeef/btcrpcclient"
+	"github.com/roasbeef/btcutil"
+)
+
+// FeeEstimator provides the ability to estimate on-chain transaction fees
+// for various combinations of transaction sizes and desired confirmation time
+// (measured by number of blocks).
+type FeeEstimator interface {
+	// EstimateFeePerByte takes in a target for the number of blocks until
+	// an initial confirmation and returns the estimated fee expressed in
+	// satoshis/byte.
+	EstimateFeePerByte(numBlocks uint32) btcutil.Amount
+
+	// EstimateFeePerWeight takes in a target for the number of blocks
+	// until an initial confirmation and returns the estimated fee
+	// expressed in satoshis/weight.
+	EstimateFeePerWeight(numBlocks uint32) btcutil.Amount
+
+	// EstimateConfirmation will return the number of blocks expected for a
+	// transaction to be confirmed given a fee rate in satoshis per byte.
+	EstimateConfirmation(satPerByte btcutil.Amount) uint32
+}
+
+// StaticFeeEstimator will return a static value for all fee calculation
+// requests. It is designed to be replaced by a proper fee calculation
+// implementation.
+type StaticFeeEstimator struct {
+	// FeeRate is the static fee rate in satoshis-per-byte that will be
+	// returned by this fee estimator.
+	FeeRate btcutil.Amount
+
+	// Confirmation is the number of blocks a transaction paying the
+	// static fee rate is expected to take to confirm.
+	Confirmation uint32
+}
+
+// EstimateFeePerByte will return a static value for fee calculations.
+//
+// This is part of the FeeEstimator interface.
+func (e StaticFeeEstimator) EstimateFeePerByte(numBlocks uint32) btcutil.Amount {
+	return e.FeeRate
+}
+
+// EstimateFeePerWeight will return a static value for fee calculations.
+//
+// This is part of the FeeEstimator interface.
+func (e StaticFeeEstimator) EstimateFeePerWeight(numBlocks uint32) btcutil.Amount {
+	return e.FeeRate / 4
+}
+
+// EstimateConfirmation will return the number of blocks expected for a
+// transaction to be confirmed given a fee rate in satoshis per byte.
+//
+// This is part of the FeeEstimator interface.
+func (e StaticFeeEstimator) EstimateConfirmation(satPerByte btcutil.Amount) uint32 {
+	return e.Confirmation
+}
+
+// A compile-time assertion to ensure that StaticFeeEstimator implements the
+// FeeEstimator interface.
+var _ FeeEstimator = (*StaticFeeEstimator)(nil)
+
+// BtcdFeeEstimator is an implementation of the FeeEstimator interface backed
+// by the fee estimates of a btcd node, queried over its RPC interface. If the
+// node is unable to produce an estimate, such as before it has seen enough
+// blocks, then a fallback fee rate is used instead.
+type BtcdFeeEstimator struct {
+	// fallbackFeeRate is the fee rate in satoshis-per-byte used when btcd
+	// is unable to produce an estimate.
+	fallbackFeeRate btcutil.Amount
+
+	btcdConn *btcrpcclient.Client
+}
+
+// NewBtcdFeeEstimator creates a new BtcdFeeEstimator given a fully populated
+// rpc config that is able to successfully connect and authenticate with the
+// btcd node, and a fallback fee rate in satoshis-per-byte.
+func NewBtcdFeeEstimator(rpcConfig btcrpcclient.ConnConfig,
+	fallbackFeeRate btcutil.Amount) (*BtcdFeeEstimator, error) {
+
+	// The estimator only issues plain requests, so it doesn't need to
+	// maintain a websocket connection of its own.
+	rpcConfig.DisableConnectOnNew = true
+	rpcConfig.DisableAutoReconnect = false
+	chainConn, err := btcrpcclient.New(&rpcConfig, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BtcdFeeEstimator{
+		fallbackFeeRate: fallbackFeeRate,
+		btcdConn:        chainConn,
+	}, nil
+}
+
+// Start establishes the connection to btcd.
+func (b *BtcdFeeEstimator) Start() error {
+	return b.btcdConn.Connect(20)
+}
+
+// Stop closes the connection to btcd.
+func (b *BtcdFeeEstimator) Stop() error {
+	b.btcdConn.Shutdown()
+
+	return nil
+}
+
+// fetchEstimatePerByte queries btcd for the fee rate, in satoshis-per-byte,
+// required for a transaction to confirm within numBlocks blocks.
+func (b *BtcdFeeEstimator) fetchEstimatePerByte(
+	numBlocks uint32) (btcutil.Amount, error) {
+
+	param, err := json.Marshal(numBlocks)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := b.btcdConn.RawRequest(
+		"estimatefee", []json.RawMessage{param},
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	// The estimate is returned in BTC per kilobyte. A negative estimate
+	// signals that not enough blocks have been seen to produce one.
+	var btcPerKB float64
+	if err := json.Unmarshal(resp, &btcPerKB); err != nil {
+		return 0, err
+	}
+	if btcPerKB <= 0 {
+		return 0, fmt.Errorf("no fee estimate available for a "+
+			"target of %v blocks", numBlocks)
+	}
+
+	satPerKB, err := btcutil.NewAmount(btcPerKB)
+	if err != nil {
+		return 0, err
+	}
+
+	return satPerKB / 1000, nil
+}
+
+// EstimateFeePerByte queries btcd for the fee rate required for a transaction
+// to confirm within numBlocks blocks, falling back to the static fee rate if
+// no estimate is available.
+//
+// This is part of the FeeEstimator interface.
+func (b *BtcdFeeEstimator) EstimateFeePerByte(numBlocks uint32) btcutil.Amount {
+	feeRate, err := b.fetchEstimatePerByte(numBlocks)
+	if err != nil {
+		walletLog.Debugf("Unable to estimate fee rate, using "+
+			"fallback of %v sat/byte: %v", b.fallbackFeeRate, err)
+		return b.fallbackFeeRate
+	}
+
+	// The estimate is never allowed to drop below a single satoshi per
+	// byte, the minimum relay fee rate.
+	if feeRate < 1 {
+		feeRate = 1
+	}
+
+	return feeRate
+}
+
+// EstimateFeePerWeight queries btcd for the fee rate required for a
+// transaction to confirm within numBlocks blocks, expressed in
+// satoshis-per-weight.
+//
+// This is part of the FeeEstimator interface.
+func (b *BtcdFeeEstimator) EstimateFeePerWeight(numBlocks uint32) btcutil.Amount {
+	return b.EstimateFeePerByte(numBlocks) / 4
+}
+
+// EstimateConfirmation returns the lowest of the probed confirmation targets
+// whose fee estimate the passed fee rate, in satoshis-per-byte, satisfies.
+//
+// This is part of the FeeEstimator interface.
+func (b *BtcdFeeEstimator) EstimateConfirmation(satPerByte btcutil.Amount) uint32 {
+	confTargets := []uint32{1, 2, 3, 6, 12, 25, 144}
+	for _, confTarget := range confTargets {
+		if satPerByte >= b.EstimateFeePerByte(confTarget) {
+			return confTarget
+		}
+	}
+
+	return confTargets[len(confTargets)-1]
+}
+
+// A compile-time assertion to ensure that BtcdFeeEstimator implements the
+// FeeEstimator interface.
+var _ FeeEstimator = (*BtcdFeeEstimator)(nil)
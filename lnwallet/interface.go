@@ -4,18 +4,114 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcd/txscript"
 	"github.com/roasbeef/btcd/wire"
 	"github.com/roasbeef/btcutil"
+	"github.com/roasbeef/btcutil/hdkeychain"
 )
 
 // ErrNotMine is an error denoting that a WalletController instance is unable
 // to spend a specifid output.
 var ErrNotMine = errors.New("the passed output doesn't belong to the wallet")
 
+var (
+	// ErrOutputAlreadyLeased is returned when attempting to lease an
+	// output which is currently leased by another party.
+	ErrOutputAlreadyLeased = errors.New("output is already leased")
+
+	// ErrOutputNotLeased is returned when attempting to release an output
+	// which isn't currently leased.
+	ErrOutputNotLeased = errors.New("output is not leased")
+
+	// ErrOutputLeaseMismatch is returned when attempting to release an
+	// output using a lock ID other than the one it was leased with.
+	ErrOutputLeaseMismatch = errors.New("output is leased with a " +
+		"different lock ID")
+
+	// ErrInvalidLeaseDuration is returned when attempting to lease an
+	// output for a non-positive duration, or longer than MaxLockDuration.
+	ErrInvalidLeaseDuration = fmt.Errorf("lease duration must be "+
+		"positive, and at most %v", MaxLockDuration)
+
+	// ErrUnknownOutput is returned when attempting to lease an output
+	// which isn't an unspent output of the wallet.
+	ErrUnknownOutput = errors.New("output is not an unspent output of " +
+		"the wallet")
+
+	// ErrTxLabelExists is returned when attempting to label a transaction
+	// which already has a label, without overwriting it.
+	ErrTxLabelExists = errors.New("transaction already has a label")
+
+	// ErrTxLabelTooLong is returned when attempting to label a transaction
+	// with a label longer than MaxTxLabelLength.
+	ErrTxLabelTooLong = fmt.Errorf("transaction labels cannot exceed %v "+
+		"characters", MaxTxLabelLength)
+
+	// ErrAccountExists is returned when attempting to import an account
+	// under a name which is already in use.
+	ErrAccountExists = errors.New("account already exists")
+
+	// ErrUnknownAccount is returned when referencing an imported account
+	// which doesn't exist.
+	ErrUnknownAccount = errors.New("unknown account")
+)
+
+// MaxTxLabelLength is the maximum length of a transaction label.
+const MaxTxLabelLength = 500
+
+// DefaultLockDuration is the duration an output is leased for if no explicit
+// duration is specified.
+const DefaultLockDuration = 10 * time.Minute
+
+// MaxLockDuration is the longest duration an output may be leased for, so
+// that a forgotten lease can't lock up the funds of the wallet indefinitely.
+const MaxLockDuration = 7 * 24 * time.Hour
+
+// LockID is an identifier chosen by the party leasing an output. An output
+// can only be released using the lock ID it was leased with.
+type LockID [32]byte
+
+// LockedOutput is an output of the wallet which has been leased, preventing
+// it from being used for coin selection until the lease expires.
+type LockedOutput struct {
+	// LockID is the identifier the output was leased with.
+	LockID LockID
+
+	// Outpoint is the leased output.
+	Outpoint wire.OutPoint
+
+	// Expiration is the time at which the lease expires.
+	Expiration time.Time
+}
+
+// ImportedAccount is an account backed by an extended public key which was
+// imported into the wallet. The wallet is only able to watch the addresses of
+// an imported account, as it doesn't hold its private keys.
+type ImportedAccount struct {
+	// Name is the unique name the account was imported under.
+	Name string
+
+	// ExtendedPubKey is the extended public key backing the account.
+	ExtendedPubKey *hdkeychain.ExtendedKey
+
+	// MasterKeyFingerprint is the fingerprint of the master key the
+	// extended public key was derived from, if known. It allows external
+	// signers to locate the keys of the account.
+	MasterKeyFingerprint uint32
+
+	// ExternalKeyCount is the number of external addresses that have been
+	// derived from the account.
+	ExternalKeyCount uint32
+
+	// InternalKeyCount is the number of internal (change) addresses that
+	// have been derived from the account.
+	InternalKeyCount uint32
+}
+
 // AddressType is a enum-like type which denotes the possible address types
 // WalletController supports.
 type AddressType uint8
@@ -32,11 +128,33 @@ const (
 	PubKeyHash
 )
 
+// ParseAddressType parses an address type from its human readable name.
+func ParseAddressType(s string) (AddressType, error) {
+	switch s {
+	case "p2wkh":
+		return WitnessPubKey, nil
+	case "np2wkh":
+		return NestedWitnessPubKey, nil
+	case "p2pkh":
+		return PubKeyHash, nil
+	default:
+		return 0, fmt.Errorf("unknown address type %q, supported "+
+			"types are: p2wkh, np2wkh, p2pkh", s)
+	}
+}
+
 // Utxo is an unspent output denoted by its outpoint, and output value of the
 // original output.
 type Utxo struct {
 	Value btcutil.Amount
 	wire.OutPoint
+
+	// PkScript is the public key script of the output.
+	PkScript []byte
+
+	// Confirmations is the number of confirmations the output has. Outputs
+	// still within the mempool have zero confirmations.
+	Confirmations int64
 }
 
 // TransactionDetail describes a transaction with either inputs which belong to
@@ -72,6 +190,9 @@ type TransactionDetail struct {
 
 	// TotalFees is the total fee in satoshis paid by this transaction.
 	TotalFees int64
+
+	// Label is the optional label attached to the transaction, if any.
+	Label string
 }
 
 // TransactionSubscription is an interface which describes an object capable of
@@ -152,9 +273,16 @@ type WalletController interface {
 	// unconfirmed outputs should be returned.
 	ListUnspentWitness(confirms int32) ([]*Utxo, error)
 
+	// ListAddresses returns all the addresses the wallet has generated
+	// for its default account, both external and internal.
+	ListAddresses() ([]btcutil.Address, error)
+
 	// ListTransactionDetails returns a list of all transactions which are
-	// relevant to the wallet.
-	ListTransactionDetails() ([]*TransactionDetail, error)
+	// relevant to the wallet, and were confirmed within the passed range
+	// of block heights, inclusive. If endHeight is -1, then unconfirmed
+	// transactions are included as well.
+	ListTransactionDetails(startHeight,
+		endHeight int32) ([]*TransactionDetail, error)
 
 	// LockOutpoint marks an outpoint as locked meaning it will no longer
 	// be deemed as eligible for coin selection. Locking outputs are
@@ -166,6 +294,54 @@ type WalletController interface {
 	// eligible for coin selection.
 	UnlockOutpoint(o wire.OutPoint)
 
+	// LeaseOutput locks the passed output for the given duration using the
+	// passed lock ID, preventing it from being used for coin selection. The
+	// lease is persisted, so it survives restarts. If the output is
+	// already leased with the same lock ID, then the lease is extended.
+	// The expiration of the lease is returned.
+	LeaseOutput(id LockID, op wire.OutPoint,
+		duration time.Duration) (time.Time, error)
+
+	// ReleaseOutput unlocks an output previously leased with the passed
+	// lock ID, marking it eligible for coin selection.
+	ReleaseOutput(id LockID, op wire.OutPoint) error
+
+	// ListLeasedOutputs returns all the outputs which are currently
+	// leased.
+	ListLeasedOutputs() ([]*LockedOutput, error)
+
+	// LabelTransaction attaches a label to the transaction with the
+	// passed hash. If the transaction already has a label, then
+	// ErrTxLabelExists is returned unless overwrite is true.
+	LabelTransaction(hash chainhash.Hash, label string,
+		overwrite bool) error
+
+	// ImportAccount imports an account backed by the passed extended
+	// public key under the passed name. The addresses of the account are
+	// p2wkh addresses derived along its external (0) and internal (1)
+	// branches, and are watched by the wallet. Once imported, the chain
+	// is rescanned from the passed birthday height for the outputs
+	// already paying to the account. If dryRun is true, then the account
+	// isn't stored, but the first external and internal addresses it
+	// would derive are still returned.
+	ImportAccount(name string, xpub *hdkeychain.ExtendedKey,
+		masterKeyFingerprint, birthdayHeight uint32,
+		dryRun bool) (*ImportedAccount, []btcutil.Address,
+		[]btcutil.Address, error)
+
+	// ListAccounts returns all the accounts which have been imported into
+	// the wallet.
+	ListAccounts() ([]*ImportedAccount, error)
+
+	// NewImportedAddress derives the next external, or internal if change
+	// is true, address of the passed imported account.
+	NewImportedAddress(account string, change bool) (btcutil.Address, error)
+
+	// ListImportedUnspent returns all the unspent outputs paying to the
+	// passed imported account which have at least minConfs
+	// confirmations.
+	ListImportedUnspent(account string, minConfs int32) ([]*Utxo, error)
+
 	// PublishTransaction performs cursory validation (dust checks, etc),
 	// then finally broadcasts the passed transaction to the Bitcoin network.
 	PublishTransaction(tx *wire.MsgTx) error
@@ -185,6 +361,11 @@ type WalletController interface {
 	// it has fully synced to the current best block in the main chain.
 	IsSynced() (bool, error)
 
+	// RecoveryProgress returns whether the wallet was started in recovery
+	// mode, along with the progress of its rescan of the chain as a value
+	// between 0 and 1.
+	RecoveryProgress() (bool, float64, error)
+
 	// Start initializes the wallet, making any necessary connections,
 	// starting up required goroutines etc.
 	Start() error
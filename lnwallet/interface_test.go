@@ -12,9 +12,11 @@ import (
 	"time"
 
 	"github.com/boltdb/bolt"
+	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/chainntnfs/btcdnotify"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwallet/btcwallet"
 	"github.com/roasbeef/btcd/chaincfg"
@@ -27,6 +29,7 @@ import (
 	"github.com/roasbeef/btcd/txscript"
 	"github.com/roasbeef/btcd/wire"
 	"github.com/roasbeef/btcutil"
+	"github.com/roasbeef/btcutil/hdkeychain"
 )
 
 var (
@@ -324,6 +327,7 @@ out:
 func createTestWallet(tempTestDir string, miningNode *rpctest.Harness,
 	netParams *chaincfg.Params, notifier chainntnfs.ChainNotifier,
 	wc lnwallet.WalletController, signer lnwallet.Signer,
+	keyRing keychain.KeyRing,
 	bio lnwallet.BlockChainIO) (*lnwallet.LightningWallet, error) {
 
 	dbDir := filepath.Join(tempTestDir, "cdb")
@@ -333,7 +337,7 @@ func createTestWallet(tempTestDir string, miningNode *rpctest.Harness,
 	}
 
 	wallet, err := lnwallet.NewLightningWallet(cdb, notifier, wc, signer,
-		bio, netParams)
+		keyRing, bio, netParams)
 	if err != nil {
 		return nil, err
 	}
@@ -364,7 +368,7 @@ func testDualFundingReservationWorkflow(miner *rpctest.Harness, wallet *lnwallet
 	// Bob initiates a channel funded with 5 BTC for each side, so 10
 	// BTC total. He also generates 2 BTC in change.
 	chanReservation, err := wallet.InitChannelReservation(fundingAmount*2,
-		fundingAmount, bobNode.id, bobAddr, numReqConfs, 4, 540, 0)
+		fundingAmount, bobNode.id, bobAddr, numReqConfs, 4, 540, 0, nil)
 	if err != nil {
 		t.Fatalf("unable to initialize funding reservation: %v", err)
 	}
@@ -527,7 +531,7 @@ func testFundingTransactionLockedOutputs(miner *rpctest.Harness,
 	// Create a single channel asking for 16 BTC total.
 	fundingAmount := btcutil.Amount(8 * 1e8)
 	_, err := wallet.InitChannelReservation(fundingAmount, fundingAmount,
-		testPub, bobAddr, numReqConfs, 4, 540, 0)
+		testPub, bobAddr, numReqConfs, 4, 540, 0, nil)
 	if err != nil {
 		t.Fatalf("unable to initialize funding reservation 1: %v", err)
 	}
@@ -537,7 +541,7 @@ func testFundingTransactionLockedOutputs(miner *rpctest.Harness,
 	// that aren't locked, so this should fail.
 	amt := btcutil.Amount(900 * 1e8)
 	failedReservation, err := wallet.InitChannelReservation(amt, amt,
-		testPub, bobAddr, numReqConfs, 4, 540, 0)
+		testPub, bobAddr, numReqConfs, 4, 540, 0, nil)
 	if err == nil {
 		t.Fatalf("not error returned, should fail on coin selection")
 	}
@@ -557,14 +561,14 @@ func testFundingCancellationNotEnoughFunds(miner *rpctest.Harness,
 	// Create a reservation for 44 BTC.
 	fundingAmount := btcutil.Amount(44 * 1e8)
 	chanReservation, err := wallet.InitChannelReservation(fundingAmount,
-		fundingAmount, testPub, bobAddr, numReqConfs, 4, 540, 0)
+		fundingAmount, testPub, bobAddr, numReqConfs, 4, 540, 0, nil)
 	if err != nil {
 		t.Fatalf("unable to initialize funding reservation: %v", err)
 	}
 
 	// Attempt to create another channel with 44 BTC, this should fail.
 	_, err = wallet.InitChannelReservation(fundingAmount,
-		fundingAmount, testPub, bobAddr, numReqConfs, 4, 540, 0)
+		fundingAmount, testPub, bobAddr, numReqConfs, 4, 540, 0, nil)
 	if _, ok := err.(*lnwallet.ErrInsufficientFunds); !ok {
 		t.Fatalf("coin selection succeded should have insufficient funds: %v",
 			err)
@@ -594,7 +598,7 @@ func testFundingCancellationNotEnoughFunds(miner *rpctest.Harness,
 
 	// Request to fund a new channel should now succeed.
 	_, err = wallet.InitChannelReservation(fundingAmount, fundingAmount,
-		testPub, bobAddr, numReqConfs, 4, 540, 0)
+		testPub, bobAddr, numReqConfs, 4, 540, 0, nil)
 	if err != nil {
 		t.Fatalf("unable to initialize funding reservation: %v", err)
 	}
@@ -637,7 +641,7 @@ func testSingleFunderReservationWorkflowInitiator(miner *rpctest.Harness,
 	fundingAmt := btcutil.Amount(4 * 1e8)
 	pushAmt := btcutil.Amount(btcutil.SatoshiPerBitcoin)
 	chanReservation, err := wallet.InitChannelReservation(fundingAmt,
-		fundingAmt, bobNode.id, bobAddr, numReqConfs, 4, 540, pushAmt)
+		fundingAmt, bobNode.id, bobAddr, numReqConfs, 4, 540, pushAmt, nil)
 	if err != nil {
 		t.Fatalf("unable to init channel reservation: %v", err)
 	}
@@ -783,7 +787,7 @@ func testSingleFunderReservationWorkflowResponder(miner *rpctest.Harness,
 	// contribution and the necessary resources.
 	fundingAmt := btcutil.Amount(0)
 	chanReservation, err := wallet.InitChannelReservation(capacity,
-		fundingAmt, bobNode.id, bobAddr, numReqConfs, 4, 540, 0)
+		fundingAmt, bobNode.id, bobAddr, numReqConfs, 4, 540, 0, nil)
 	if err != nil {
 		t.Fatalf("unable to init channel reservation: %v", err)
 	}
@@ -957,7 +961,7 @@ func testListTransactionDetails(miner *rpctest.Harness, wallet *lnwallet.Lightni
 	// Next, fetch all the current transaction details.
 	// TODO(roasbeef): use ntfn client here instead?
 	time.Sleep(time.Second * 2)
-	txDetails, err := wallet.ListTransactionDetails()
+	txDetails, err := wallet.ListTransactionDetails(0, -1)
 	if err != nil {
 		t.Fatalf("unable to fetch tx details: %v", err)
 	}
@@ -1009,7 +1013,7 @@ func testListTransactionDetails(miner *rpctest.Harness, wallet *lnwallet.Lightni
 	// Fetch the transaction details again, the new transaction should be
 	// shown as debiting from the wallet's balance.
 	time.Sleep(time.Second * 2)
-	txDetails, err = wallet.ListTransactionDetails()
+	txDetails, err = wallet.ListTransactionDetails(0, -1)
 	if err != nil {
 		t.Fatalf("unable to fetch tx details: %v", err)
 	}
@@ -1036,6 +1040,22 @@ func testListTransactionDetails(miner *rpctest.Harness, wallet *lnwallet.Lightni
 	if !burnTxFound {
 		t.Fatalf("tx burning btc not found")
 	}
+
+	// Finally, when restricting the details to the blocks mined before
+	// the burn transaction was confirmed, it should no longer be found.
+	_, bestHeight, err := miner.Node.GetBestBlock()
+	if err != nil {
+		t.Fatalf("unable to get best block: %v", err)
+	}
+	txDetails, err = wallet.ListTransactionDetails(0, bestHeight-1)
+	if err != nil {
+		t.Fatalf("unable to fetch tx details: %v", err)
+	}
+	for _, txDetail := range txDetails {
+		if bytes.Equal(txDetail.Hash[:], burnTXID[:]) {
+			t.Fatalf("tx burning btc found outside of height range")
+		}
+	}
 }
 
 func testTransactionSubscriptions(miner *rpctest.Harness, w *lnwallet.LightningWallet, t *testing.T) {
@@ -1235,6 +1255,438 @@ func testSignOutputPrivateTweak(r *rpctest.Harness, w *lnwallet.LightningWallet,
 	}
 }
 
+func testListAddressesAndUnspent(miner *rpctest.Harness,
+	w *lnwallet.LightningWallet, t *testing.T) {
+
+	t.Log("Running list addresses and unspent test")
+
+	// Generate a fresh address, it should be included within the set of
+	// addresses returned by the wallet.
+	addr, err := w.NewAddress(lnwallet.WitnessPubKey, false)
+	if err != nil {
+		t.Fatalf("unable to create new address: %v", err)
+	}
+	addrs, err := w.ListAddresses()
+	if err != nil {
+		t.Fatalf("unable to list addresses: %v", err)
+	}
+	found := false
+	for _, a := range addrs {
+		if a.String() == addr.String() {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Fatalf("address %v not found in wallet addresses", addr)
+	}
+
+	// Every unspent output returned should carry its pkScript along with
+	// a non-negative number of confirmations.
+	utxos, err := w.ListUnspentWitness(0)
+	if err != nil {
+		t.Fatalf("unable to list unspent: %v", err)
+	}
+	for _, utxo := range utxos {
+		if len(utxo.PkScript) == 0 {
+			t.Fatalf("utxo %v is missing its pkScript", utxo.OutPoint)
+		}
+		if utxo.Confirmations < 0 {
+			t.Fatalf("utxo %v has negative confirmations",
+				utxo.OutPoint)
+		}
+	}
+}
+
+func testLeaseOutputs(miner *rpctest.Harness, w *lnwallet.LightningWallet,
+	t *testing.T) {
+
+	t.Log("Running lease outputs test")
+
+	utxos, err := w.ListUnspentWitness(1)
+	if err != nil {
+		t.Fatalf("unable to list unspent: %v", err)
+	}
+	if len(utxos) == 0 {
+		t.Fatalf("wallet has no unspent outputs")
+	}
+	op := utxos[0].OutPoint
+
+	containsOutput := func() bool {
+		utxos, err := w.ListUnspentWitness(1)
+		if err != nil {
+			t.Fatalf("unable to list unspent: %v", err)
+		}
+		for _, utxo := range utxos {
+			if utxo.OutPoint == op {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Leases longer than the maximum duration should be rejected.
+	lockID := lnwallet.LockID{1}
+	_, err = w.LeaseOutput(lockID, op, lnwallet.MaxLockDuration+time.Second)
+	if err != lnwallet.ErrInvalidLeaseDuration {
+		t.Fatalf("expected ErrInvalidLeaseDuration, got %v", err)
+	}
+
+	// Once leased, the output should no longer be eligible for coin
+	// selection, and a second party shouldn't be able to lease it.
+	if _, err := w.LeaseOutput(lockID, op, time.Minute); err != nil {
+		t.Fatalf("unable to lease output: %v", err)
+	}
+	if containsOutput() {
+		t.Fatalf("leased output %v still returned as unspent", op)
+	}
+	_, err = w.LeaseOutput(lnwallet.LockID{2}, op, time.Minute)
+	if err != lnwallet.ErrOutputAlreadyLeased {
+		t.Fatalf("expected ErrOutputAlreadyLeased, got %v", err)
+	}
+
+	leases, err := w.ListLeasedOutputs()
+	if err != nil {
+		t.Fatalf("unable to list leases: %v", err)
+	}
+	if len(leases) != 1 || leases[0].Outpoint != op ||
+		leases[0].LockID != lockID {
+
+		t.Fatalf("unexpected leases: %v", leases)
+	}
+
+	// Only the holder of the lease may release the output, after which
+	// it should once again be eligible for coin selection.
+	err = w.ReleaseOutput(lnwallet.LockID{2}, op)
+	if err != lnwallet.ErrOutputLeaseMismatch {
+		t.Fatalf("expected ErrOutputLeaseMismatch, got %v", err)
+	}
+	if err := w.ReleaseOutput(lockID, op); err != nil {
+		t.Fatalf("unable to release output: %v", err)
+	}
+	if !containsOutput() {
+		t.Fatalf("released output %v not returned as unspent", op)
+	}
+	if err := w.ReleaseOutput(lockID, op); err != lnwallet.ErrOutputNotLeased {
+		t.Fatalf("expected ErrOutputNotLeased, got %v", err)
+	}
+}
+
+func testSendOutputsWithLabel(miner *rpctest.Harness,
+	w *lnwallet.LightningWallet, t *testing.T) {
+
+	t.Log("Running send outputs with label test")
+
+	addr, err := w.NewAddress(lnwallet.WitnessPubKey, false)
+	if err != nil {
+		t.Fatalf("unable to create new address: %v", err)
+	}
+	script, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatalf("unable to create output script: %v", err)
+	}
+
+	// Send an output back to the wallet, attaching a label to the
+	// resulting transaction.
+	const label = "test label"
+	output := &wire.TxOut{Value: btcutil.SatoshiPerBitcoin, PkScript: script}
+	tx, err := w.SendOutputsWithCoinSelection([]*wire.TxOut{output}, 10,
+		1, nil, label)
+	if err != nil {
+		t.Fatalf("unable to send outputs: %v", err)
+	}
+	txid := tx.TxHash()
+
+	// The funding inputs should no longer be locked once broadcast.
+	if len(w.LockedOutpoints()) != 0 {
+		t.Fatalf("inputs still locked after broadcast")
+	}
+
+	fetchLabel := func() string {
+		txDetails, err := w.ListTransactionDetails(0, -1)
+		if err != nil {
+			t.Fatalf("unable to fetch tx details: %v", err)
+		}
+		for _, txDetail := range txDetails {
+			if txDetail.Hash == txid {
+				return txDetail.Label
+			}
+		}
+
+		t.Fatalf("transaction %v not found", txid)
+		return ""
+	}
+
+	time.Sleep(time.Second * 2)
+	if fetchLabel() != label {
+		t.Fatalf("expected label %q, got %q", label, fetchLabel())
+	}
+
+	// The label shouldn't be replaced unless explicitly overwritten.
+	err = w.LabelTransaction(txid, "new label", false)
+	if err != lnwallet.ErrTxLabelExists {
+		t.Fatalf("expected ErrTxLabelExists, got %v", err)
+	}
+	if err := w.LabelTransaction(txid, "new label", true); err != nil {
+		t.Fatalf("unable to overwrite label: %v", err)
+	}
+	if fetchLabel() != "new label" {
+		t.Fatalf("label wasn't overwritten, got %q", fetchLabel())
+	}
+}
+
+func testImportAccount(miner *rpctest.Harness,
+	w *lnwallet.LightningWallet, t *testing.T) {
+
+	t.Log("Running import account test")
+
+	seed := bytes.Repeat([]byte{0x03}, 32)
+	master, err := hdkeychain.NewMaster(seed, &chaincfg.SimNetParams)
+	if err != nil {
+		t.Fatalf("unable to create master key: %v", err)
+	}
+	xpub, err := master.Neuter()
+	if err != nil {
+		t.Fatalf("unable to neuter master key: %v", err)
+	}
+
+	// Private extended keys must be rejected.
+	_, _, _, err = w.ImportAccount("cold", master, 0, 0, false)
+	if err == nil {
+		t.Fatalf("expected private extended key to be rejected")
+	}
+
+	// A dry run should return the addresses of the account, without
+	// storing it.
+	_, external, internal, err := w.ImportAccount("cold", xpub, 1, 0, true)
+	if err != nil {
+		t.Fatalf("unable to dry run account import: %v", err)
+	}
+	if len(external) == 0 || len(internal) == 0 {
+		t.Fatalf("expected dry run addresses")
+	}
+	accounts, err := w.ListAccounts()
+	if err != nil {
+		t.Fatalf("unable to list accounts: %v", err)
+	}
+	if len(accounts) != 0 {
+		t.Fatalf("account stored during dry run")
+	}
+
+	_, bestHeight, err := miner.Node.GetBestBlock()
+	if err != nil {
+		t.Fatalf("unable to get best block: %v", err)
+	}
+	_, _, _, err = w.ImportAccount("cold", xpub, 1, uint32(bestHeight), false)
+	if err != nil {
+		t.Fatalf("unable to import account: %v", err)
+	}
+	_, _, _, err = w.ImportAccount("cold", xpub, 1, uint32(bestHeight), false)
+	if err != lnwallet.ErrAccountExists {
+		t.Fatalf("expected ErrAccountExists, got %v", err)
+	}
+
+	// The first address derived from the account should match the first
+	// address returned by the dry run.
+	addr, err := w.NewImportedAddress("cold", false)
+	if err != nil {
+		t.Fatalf("unable to derive account address: %v", err)
+	}
+	if addr.String() != external[0].String() {
+		t.Fatalf("expected address %v, got %v", external[0], addr)
+	}
+	accounts, err = w.ListAccounts()
+	if err != nil {
+		t.Fatalf("unable to list accounts: %v", err)
+	}
+	if len(accounts) != 1 || accounts[0].ExternalKeyCount != 1 ||
+		accounts[0].MasterKeyFingerprint != 1 {
+
+		t.Fatalf("unexpected accounts: %v", spew.Sdump(accounts))
+	}
+
+	// Send an output to the account from the miner, it should then be
+	// reported as an unspent output of the account once confirmed.
+	script, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatalf("unable to create output script: %v", err)
+	}
+	output := &wire.TxOut{Value: btcutil.SatoshiPerBitcoin, PkScript: script}
+	if _, err := miner.SendOutputs([]*wire.TxOut{output}, 10); err != nil {
+		t.Fatalf("unable to send outputs: %v", err)
+	}
+	if _, err := miner.Node.Generate(1); err != nil {
+		t.Fatalf("unable to generate block: %v", err)
+	}
+
+	utxos, err := w.ListImportedUnspent("cold", 1)
+	if err != nil {
+		t.Fatalf("unable to list account outputs: %v", err)
+	}
+	if len(utxos) != 1 || utxos[0].Value != btcutil.SatoshiPerBitcoin {
+		t.Fatalf("unexpected account outputs: %v", spew.Sdump(utxos))
+	}
+
+	// The outputs of the account shouldn't be mixed with those of the
+	// wallet.
+	walletUtxos, err := w.ListUnspentWitness(0)
+	if err != nil {
+		t.Fatalf("unable to list wallet outputs: %v", err)
+	}
+	for _, utxo := range walletUtxos {
+		if utxo.OutPoint == utxos[0].OutPoint {
+			t.Fatalf("account output listed as wallet output")
+		}
+	}
+
+	// Finally, we'll fund an account which has yet to be imported, at an
+	// address beyond the first, as if it were handed out by another
+	// wallet. Once imported with a birthday prior to the funding, the
+	// chain should be rescanned, recovering the output, and the address
+	// should be marked as used.
+	seed = bytes.Repeat([]byte{0x04}, 32)
+	master, err = hdkeychain.NewMaster(seed, &chaincfg.SimNetParams)
+	if err != nil {
+		t.Fatalf("unable to create master key: %v", err)
+	}
+	xpub, err = master.Neuter()
+	if err != nil {
+		t.Fatalf("unable to neuter master key: %v", err)
+	}
+	_, external, _, err = w.ImportAccount("restored", xpub, 0, 0, true)
+	if err != nil {
+		t.Fatalf("unable to dry run account import: %v", err)
+	}
+
+	_, birthday, err := miner.Node.GetBestBlock()
+	if err != nil {
+		t.Fatalf("unable to get best block: %v", err)
+	}
+	script, err = txscript.PayToAddrScript(external[3])
+	if err != nil {
+		t.Fatalf("unable to create output script: %v", err)
+	}
+	output = &wire.TxOut{Value: btcutil.SatoshiPerBitcoin, PkScript: script}
+	if _, err := miner.SendOutputs([]*wire.TxOut{output}, 10); err != nil {
+		t.Fatalf("unable to send outputs: %v", err)
+	}
+	if _, err := miner.Node.Generate(6); err != nil {
+		t.Fatalf("unable to generate blocks: %v", err)
+	}
+
+	account, _, _, err := w.ImportAccount("restored", xpub, 0,
+		uint32(birthday), false)
+	if err != nil {
+		t.Fatalf("unable to import account: %v", err)
+	}
+	if account.ExternalKeyCount != 4 {
+		t.Fatalf("expected 4 used external addresses, got %v",
+			account.ExternalKeyCount)
+	}
+	utxos, err = w.ListImportedUnspent("restored", 1)
+	if err != nil {
+		t.Fatalf("unable to list account outputs: %v", err)
+	}
+	if len(utxos) != 1 || utxos[0].Value != btcutil.SatoshiPerBitcoin ||
+		utxos[0].Confirmations != 6 {
+
+		t.Fatalf("unexpected account outputs: %v", spew.Sdump(utxos))
+	}
+}
+
+func testRecoveryProgress(miner *rpctest.Harness,
+	w *lnwallet.LightningWallet, t *testing.T) {
+
+	t.Log("Running recovery progress test")
+
+	// The test wallet isn't started in recovery mode, so no progress
+	// should be reported.
+	recoveryMode, progress, err := w.RecoveryProgress()
+	if err != nil {
+		t.Fatalf("unable to fetch recovery progress: %v", err)
+	}
+	if recoveryMode || progress != 0 {
+		t.Fatalf("expected no recovery, got mode=%v, progress=%v",
+			recoveryMode, progress)
+	}
+}
+
+func testRecoveryRestart(miner *rpctest.Harness,
+	w *lnwallet.LightningWallet, t *testing.T) {
+
+	t.Log("Running recovery restart test")
+
+	tempDir, err := ioutil.TempDir("", "recovery")
+	if err != nil {
+		t.Fatalf("unable to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	const recoveryWindow = 10
+	rpcConfig := miner.RPCConfig()
+	cfg := &btcwallet.Config{
+		PrivatePass:    privPass,
+		HdSeed:         bytes.Repeat([]byte{0x05}, 32),
+		DataDir:        tempDir,
+		NetParams:      &chaincfg.SimNetParams,
+		RpcHost:        rpcConfig.Host,
+		RpcUser:        rpcConfig.User,
+		RpcPass:        rpcConfig.Pass,
+		CACert:         rpcConfig.Certificates,
+		RecoveryWindow: recoveryWindow,
+	}
+
+	// startWallet starts the restored wallet in recovery mode, returning
+	// the number of addresses it has derived.
+	startWallet := func() int {
+		wallet, err := btcwallet.New(cfg)
+		if err != nil {
+			t.Fatalf("unable to create wallet: %v", err)
+		}
+		if err := wallet.Start(); err != nil {
+			t.Fatalf("unable to start wallet: %v", err)
+		}
+		defer wallet.Stop()
+
+		recoveryMode, _, err := wallet.RecoveryProgress()
+		if err != nil {
+			t.Fatalf("unable to fetch recovery progress: %v", err)
+		}
+		if !recoveryMode {
+			t.Fatalf("expected wallet to be in recovery mode")
+		}
+
+		addrs, err := wallet.ListAddresses()
+		if err != nil {
+			t.Fatalf("unable to list addresses: %v", err)
+		}
+		return len(addrs)
+	}
+
+	// The recovery window of each branch should be derived on the first
+	// start up.
+	numAddrs := startWallet()
+	if numAddrs < recoveryWindow*2 {
+		t.Fatalf("expected at least %v addresses, got %v",
+			recoveryWindow*2, numAddrs)
+	}
+
+	// Restarting the wallet in recovery mode must not derive the window
+	// once again.
+	if restartAddrs := startWallet(); restartAddrs != numAddrs {
+		t.Fatalf("expected %v addresses after restart, got %v",
+			numAddrs, restartAddrs)
+	}
+
+	// Widening the window should only derive the addresses beyond the
+	// previous one.
+	cfg.RecoveryWindow = recoveryWindow + 5
+	if widenedAddrs := startWallet(); widenedAddrs != numAddrs+10 {
+		t.Fatalf("expected %v addresses after widening the window, "+
+			"got %v", numAddrs+10, widenedAddrs)
+	}
+}
+
 var walletTests = []func(miner *rpctest.Harness, w *lnwallet.LightningWallet, test *testing.T){
 	// TODO(roasbeef): reservation tests should prob be split out
 	testDualFundingReservationWorkflow,
@@ -1246,6 +1698,12 @@ var walletTests = []func(miner *rpctest.Harness, w *lnwallet.LightningWallet, te
 	testListTransactionDetails,
 	testSignOutputPrivateTweak,
 	testCancelNonExistantReservation,
+	testListAddressesAndUnspent,
+	testLeaseOutputs,
+	testSendOutputsWithLabel,
+	testImportAccount,
+	testRecoveryProgress,
+	testRecoveryRestart,
 }
 
 type testLnWallet struct {
@@ -1307,6 +1765,7 @@ func TestLightningWallet(t *testing.T) {
 
 	var bio lnwallet.BlockChainIO
 	var signer lnwallet.Signer
+	var keyRing keychain.KeyRing
 	var wc lnwallet.WalletController
 	for _, walletDriver := range lnwallet.RegisteredWallets() {
 		tempTestDir, err := ioutil.TempDir("", "lnwallet")
@@ -1333,6 +1792,7 @@ func TestLightningWallet(t *testing.T) {
 				t.Fatalf("unable to create btcwallet: %v", err)
 			}
 			signer = wc.(*btcwallet.BtcWallet)
+			keyRing = wc.(*btcwallet.BtcWallet)
 			bio = wc.(*btcwallet.BtcWallet)
 		default:
 			t.Fatalf("unknown wallet driver: %v", walletType)
@@ -1340,7 +1800,7 @@ func TestLightningWallet(t *testing.T) {
 
 		// Funding via 20 outputs with 4BTC each.
 		lnw, err := createTestWallet(tempTestDir, miningNode, netParams,
-			chainNotifier, wc, signer, bio)
+			chainNotifier, wc, signer, keyRing, bio)
 		if err != nil {
 			t.Fatalf("unable to create test ln wallet: %v", err)
 		}
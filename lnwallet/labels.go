@@ -0,0 +1,66 @@
+package lnwallet
+
+import (
+	"fmt"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+// LabelType denotes the kind of transaction an internal label is attached
+// to.
+type LabelType string
+
+const (
+	// LabelTypeChannelOpen is used to label the funding transaction of a
+	// channel.
+	LabelTypeChannelOpen LabelType = "openchannel"
+
+	// LabelTypeChannelClose is used to label the closing transaction of a
+	// channel.
+	LabelTypeChannelClose LabelType = "closechannel"
+
+	// LabelTypeJusticeTransaction is used to label a transaction sweeping
+	// the funds of a channel breached by the remote party.
+	LabelTypeJusticeTransaction LabelType = "justicetx"
+
+	// LabelTypeSweepTransaction is used to label a transaction sweeping
+	// matured time-locked outputs back into the wallet.
+	LabelTypeSweepTransaction LabelType = "sweep"
+)
+
+// internalLabelVersion is prefixed to all the labels attached by the daemon
+// itself, distinguishing them from labels set by the user.
+const internalLabelVersion = 0
+
+// MakeLabel creates an internal label of the passed type. If the transaction
+// relates to a particular channel, then its channel point is included within
+// the label.
+func MakeLabel(labelType LabelType, chanPoint *wire.OutPoint) string {
+	if chanPoint == nil {
+		return fmt.Sprintf("%v:%v", internalLabelVersion, labelType)
+	}
+
+	return fmt.Sprintf("%v:%v:chan_point-%v", internalLabelVersion,
+		labelType, chanPoint)
+}
+
+// PublishAndLabel broadcasts the passed transaction, then attaches the passed
+// label to it if one is specified. As the transaction has already been
+// broadcast at that point, a failure to label it is only logged.
+func (l *LightningWallet) PublishAndLabel(tx *wire.MsgTx, label string) error {
+	if err := l.PublishTransaction(tx); err != nil {
+		return err
+	}
+
+	if label == "" {
+		return nil
+	}
+
+	txid := tx.TxHash()
+	if err := l.LabelTransaction(txid, label, false); err != nil {
+		walletLog.Errorf("unable to label transaction %v: %v", txid,
+			err)
+	}
+
+	return nil
+}
@@ -1,12 +1,84 @@
 package lnwallet
 
 import (
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/roasbeef/btcutil"
 	"github.com/roasbeef/btcwallet/wallet/txrules"
 )
 
+// DefaultMaxAcceptedHTLCs is the default maximum number of pending HTLCs the
+// remote party may offer us at any one time.
+const DefaultMaxAcceptedHTLCs = 483
+
+const (
+	// MaxBtcFundingAmount is the largest channel that may be opened with
+	// a peer which hasn't negotiated wumbo channels.
+	MaxBtcFundingAmount = btcutil.Amount(1<<24) - 1
+
+	// MaxBtcFundingAmountWumbo is the largest channel that may be opened
+	// with a peer once wumbo channels have been negotiated. Though the
+	// protocol doesn't impose a limit, this bounds the funds at risk
+	// within a single channel.
+	MaxBtcFundingAmountWumbo = btcutil.Amount(10 * btcutil.SatoshiPerBitcoin)
+)
+
+// DefaultMaxPendingAmount returns the default maximum total value of the
+// pending HTLCs the remote party may offer within a channel of the passed
+// capacity. Within wumbo channels, this is capped to the largest non-wumbo
+// channel, so no more than the capacity of a regular channel is at risk
+// within pending HTLCs at any one time.
+func DefaultMaxPendingAmount(capacity btcutil.Amount) btcutil.Amount {
+	if capacity > MaxBtcFundingAmount {
+		return MaxBtcFundingAmount
+	}
+
+	return capacity
+}
+
 // DefaultDustLimit is used to calculate the dust HTLC amount which will be
 // send to other node during funding process.
 func DefaultDustLimit() btcutil.Amount {
 	return txrules.GetDustThreshold(P2WSHSize, txrules.DefaultRelayFeePerKb)
 }
+
+// DefaultChanReserve returns the default channel reserve required of the
+// remote party within a channel of the passed capacity: 1% of the capacity,
+// though never below the dust limit.
+func DefaultChanReserve(capacity btcutil.Amount) btcutil.Amount {
+	reserve := capacity / 100
+	if dustLimit := DefaultDustLimit(); reserve < dustLimit {
+		return dustLimit
+	}
+
+	return reserve
+}
+
+// VerifyConstraints ensures that the channel constraints the remote party
+// wishes to impose upon us within a channel of the passed capacity are sane.
+// Constraints rendering the channel unusable, such as a reserve above 20% of
+// the capacity, are rejected.
+func VerifyConstraints(c channeldb.ChannelConstraints,
+	capacity btcutil.Amount) error {
+
+	switch {
+	case c.ChanReserve > capacity/5:
+		return fmt.Errorf("channel reserve of %v is too large for a "+
+			"channel of %v", c.ChanReserve, capacity)
+
+	case c.MinHTLC >= capacity:
+		return fmt.Errorf("min htlc of %v is too large for a channel "+
+			"of %v", c.MinHTLC, capacity)
+
+	case c.MaxPendingAmount != 0 && c.MaxPendingAmount < c.MinHTLC:
+		return fmt.Errorf("max pending amount of %v is below the min "+
+			"htlc of %v", c.MaxPendingAmount, c.MinHTLC)
+
+	case c.MaxAcceptedHtlcs > MaxHTLCNumber:
+		return fmt.Errorf("max accepted htlcs of %v exceeds the "+
+			"limit of %v", c.MaxAcceptedHtlcs, MaxHTLCNumber)
+	}
+
+	return nil
+}
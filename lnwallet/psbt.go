@@ -0,0 +1,533 @@
+package lnwallet
+
+import (
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/psbt"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+const (
+	// psbtInputWeight is the estimated weight of a p2wkh input spent by a
+	// funded PSBT. As the final witness of external inputs isn't known
+	// ahead of time, all inputs are assumed to be p2wkh.
+	//
+	//	- base: outpoint (36) + empty sigScript (1) + sequence (4)
+	//	- witness: num items (1) + sig (1 + 73) + pubkey (1 + 33)
+	psbtInputWeight = (32+4+1+4)*WitnessFactor + (1 + 1 + 73 + 1 + 33)
+
+	// psbtMinChangeAmt is the smallest change output that will be added
+	// to a funded PSBT. Any smaller amount is added to the fee instead.
+	psbtMinChangeAmt = btcutil.Amount(546)
+)
+
+// inputWeight returns the estimated weight of an input spending an output
+// with the passed script. Outputs paying to a p2sh script are assumed to be
+// nested p2wkh outputs, which additionally require a sigScript pushing the
+// witness program.
+func inputWeight(pkScript []byte) int {
+	if txscript.IsPayToScriptHash(pkScript) {
+		return psbtInputWeight + NestedP2WPKHSigScriptSize*WitnessFactor
+	}
+
+	return psbtInputWeight
+}
+
+// changeOutputSize returns the size of a change output paying to an address of
+// the passed type.
+func changeOutputSize(addrType AddressType) int {
+	if addrType == NestedWitnessPubKey {
+		return 8 + 1 + P2SHSize
+	}
+
+	return 8 + 1 + P2WPKHSize
+}
+
+// estimatePsbtFee estimates the fee in satoshis required for the unsigned
+// transaction of a packet to confirm at the passed fee rate, expressed in
+// sat/byte, once inputs of the given total weight have been added. If
+// changeSize is non-zero, then room for an additional change output of that
+// size is accounted for.
+func estimatePsbtFee(feeRate uint64, unsignedTx *wire.MsgTx, inputsWeight int,
+	changeSize int) btcutil.Amount {
+
+	// The version, lock time, and input and output counts all belong to
+	// the base size of the transaction, while the segwit marker and flag
+	// only count towards the witness data.
+	baseSize := 4 + 4 + 1 + 1
+	for _, txOut := range unsignedTx.TxOut {
+		baseSize += 8 + 1 + len(txOut.PkScript)
+	}
+	baseSize += changeSize
+
+	weight := baseSize*WitnessFactor + 2 + inputsWeight
+	vSize := (weight + WitnessFactor - 1) / WitnessFactor
+
+	return btcutil.Amount(uint64(vSize) * feeRate)
+}
+
+// fillWitnessUtxo ensures that the passed packet input, spending prevOut,
+// carries its witness UTXO. If it's missing, then it's taken from the
+// input's non-witness UTXO, the full transaction being spent, which must
+// match the spent outpoint.
+func fillWitnessUtxo(pInput *psbt.PInput, prevOut wire.OutPoint) error {
+	if pInput.WitnessUtxo != nil {
+		return nil
+	}
+
+	prevTx := pInput.NonWitnessUtxo
+	if prevTx == nil {
+		return fmt.Errorf("input %v is missing its utxo", prevOut)
+	}
+	if prevTx.TxHash() != prevOut.Hash {
+		return fmt.Errorf("non-witness utxo of input %v doesn't match "+
+			"its outpoint", prevOut)
+	}
+	if prevOut.Index >= uint32(len(prevTx.TxOut)) {
+		return fmt.Errorf("input %v spends a non-existent output",
+			prevOut)
+	}
+	pInput.WitnessUtxo = prevTx.TxOut[prevOut.Index]
+
+	return nil
+}
+
+// FundPsbt performs coin selection in order to fund the outputs of the
+// passed packet at the specified fee rate, expressed in sat/byte, using only
+// wallet outputs with at least minConfs confirmations. Any inputs already
+// present within the packet are used first: wallet inputs have their UTXO
+// information filled in, while external inputs must carry either their
+// witness or non-witness UTXO. If required, further wallet inputs, chosen
+// according to the passed coin selection, and a change output are added to
+// the packet. If the coin selection specifies an imported account, then the
+// coins of that account are used in place of those of the wallet. All the
+// wallet inputs of the packet, whether selected or pre-existing, are locked,
+// and the index of the change output, or -1 if none was added, is returned.
+func (l *LightningWallet) FundPsbt(packet *psbt.Packet, feeRate uint64,
+	minConfs int32, selection *CoinSelection) (int32, error) {
+
+	// We hold the coin select mutex while querying for outputs, and
+	// performing coin selection in order to avoid inadvertent double
+	// spends across funding transactions.
+	l.coinSelectMtx.Lock()
+	defer l.coinSelectMtx.Unlock()
+
+	coins, err := l.listCoins(minConfs, selection)
+	if err != nil {
+		return 0, err
+	}
+	walletCoins := make(map[wire.OutPoint]*Utxo, len(coins))
+	for _, coin := range coins {
+		walletCoins[coin.OutPoint] = coin
+	}
+
+	// First, we'll tally up the value of the inputs already present in
+	// the packet, filling in the UTXO information of those belonging to
+	// the wallet.
+	var (
+		inputSum    btcutil.Amount
+		packetCoins []wire.OutPoint
+	)
+	for i, txIn := range packet.UnsignedTx.TxIn {
+		prevOut := txIn.PreviousOutPoint
+		pInput := &packet.Inputs[i]
+
+		if coin, ok := walletCoins[prevOut]; ok {
+			if _, ok := l.lockedOutPoints[prevOut]; ok {
+				return 0, fmt.Errorf("input %v is locked", prevOut)
+			}
+			pInput.WitnessUtxo = &wire.TxOut{
+				Value:    int64(coin.Value),
+				PkScript: coin.PkScript,
+			}
+			packetCoins = append(packetCoins, prevOut)
+		}
+
+		if err := fillWitnessUtxo(pInput, prevOut); err != nil {
+			return 0, err
+		}
+		inputSum += btcutil.Amount(pInput.WitnessUtxo.Value)
+	}
+
+	var outputSum btcutil.Amount
+	for _, txOut := range packet.UnsignedTx.TxOut {
+		outputSum += btcutil.Amount(txOut.Value)
+	}
+
+	// The remaining wallet outputs which aren't locked are eligible for
+	// coin selection.
+	usedCoins := make(map[wire.OutPoint]struct{}, len(packetCoins))
+	for _, prevOut := range packetCoins {
+		usedCoins[prevOut] = struct{}{}
+	}
+	unused := make([]*Utxo, 0, len(coins))
+	for _, coin := range coins {
+		if _, ok := usedCoins[coin.OutPoint]; ok {
+			continue
+		}
+		unused = append(unused, coin)
+	}
+	available, err := l.eligibleCoins(unused, selection)
+	if err != nil {
+		return 0, err
+	}
+
+	var packetWeight int
+	for _, pInput := range packet.Inputs {
+		packetWeight += inputWeight(pInput.WitnessUtxo.PkScript)
+	}
+
+	// Change is sent to an address of the wallet's configured change type,
+	// unless the coins are selected from an imported account, which only
+	// derives p2wkh addresses.
+	changeType := l.ChangeAddressType
+	if selection != nil && selection.Account != "" {
+		changeType = WitnessPubKey
+	}
+
+	// Select coins until the selected amount covers both the outputs and
+	// the fee of the resulting transaction. As each selected input raises
+	// the fee, we'll repeat the selection until it converges.
+	var (
+		selectedCoins []*wire.OutPoint
+		selectedAmt   btcutil.Amount
+		changeAmt     btcutil.Amount
+	)
+	for {
+		weight := packetWeight
+		for _, coin := range selectedCoins {
+			weight += inputWeight(walletCoins[*coin].PkScript)
+		}
+
+		fee := estimatePsbtFee(feeRate, packet.UnsignedTx, weight,
+			changeOutputSize(changeType))
+		required := outputSum + fee - inputSum
+		if selectedAmt >= required {
+			changeAmt = selectedAmt - required
+			break
+		}
+
+		selectedAmt, selectedCoins, err = selectInputs(required,
+			available)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// The wallet inputs the packet already contained are locked along with
+	// the selected ones, so that they aren't selected by a concurrent
+	// funding attempt before the packet is published.
+	for _, prevOut := range packetCoins {
+		l.lockedOutPoints[prevOut] = struct{}{}
+		l.LockOutpoint(prevOut)
+	}
+	for _, coin := range selectedCoins {
+		l.addInput(packet, walletCoins[*coin])
+	}
+
+	// If the change is too small to be worth its own output, then it'll
+	// be left to the miners.
+	if changeAmt < psbtMinChangeAmt {
+		return -1, nil
+	}
+
+	// The change is sent back to the account the coins were selected
+	// from.
+	var changeAddr btcutil.Address
+	if selection != nil && selection.Account != "" {
+		changeAddr, err = l.NewImportedAddress(selection.Account, true)
+	} else {
+		changeAddr, err = l.NewAddress(changeType, true)
+	}
+	if err != nil {
+		return 0, err
+	}
+	changeScript, err := txscript.PayToAddrScript(changeAddr)
+	if err != nil {
+		return 0, err
+	}
+	packet.UnsignedTx.AddTxOut(&wire.TxOut{
+		Value:    int64(changeAmt),
+		PkScript: changeScript,
+	})
+	packet.Outputs = append(packet.Outputs, psbt.POutput{})
+
+	return int32(len(packet.UnsignedTx.TxOut) - 1), nil
+}
+
+// listCoins returns the coins with at least minConfs confirmations which the
+// passed coin selection chooses from: those of the selected imported account
+// if one is specified, or otherwise those of the wallet.
+func (l *LightningWallet) listCoins(minConfs int32,
+	selection *CoinSelection) ([]*Utxo, error) {
+
+	if selection != nil && selection.Account != "" {
+		return l.ListImportedUnspent(selection.Account, minConfs)
+	}
+
+	return l.ListUnspentWitness(minConfs)
+}
+
+// eligibleCoins returns the passed wallet coins which are eligible for coin
+// selection, in the order dictated by the passed coin selection. Coins which
+// are locked are never eligible.
+//
+// NOTE: This method MUST be called with the coinSelectMtx held.
+func (l *LightningWallet) eligibleCoins(coins []*Utxo,
+	selection *CoinSelection) ([]*Utxo, error) {
+
+	var available []*Utxo
+	for _, coin := range coins {
+		if _, ok := l.lockedOutPoints[coin.OutPoint]; ok {
+			continue
+		}
+		available = append(available, coin)
+	}
+
+	return arrangeCoins(available, selection, l.CoinSelectionStrategy)
+}
+
+// addInput locks the passed wallet coin, then adds it as an input to the
+// packet along with its UTXO information.
+//
+// NOTE: This method MUST be called with the coinSelectMtx held.
+func (l *LightningWallet) addInput(packet *psbt.Packet, coin *Utxo) {
+	l.lockedOutPoints[coin.OutPoint] = struct{}{}
+	l.LockOutpoint(coin.OutPoint)
+
+	prevOut := coin.OutPoint
+	packet.UnsignedTx.AddTxIn(wire.NewTxIn(&prevOut, nil, nil))
+	packet.Inputs = append(packet.Inputs, psbt.PInput{
+		WitnessUtxo: &wire.TxOut{
+			Value:    int64(coin.Value),
+			PkScript: coin.PkScript,
+		},
+	})
+}
+
+// releaseInputs unlocks all the wallet inputs of the passed transaction which
+// were locked during coin selection.
+func (l *LightningWallet) releaseInputs(tx *wire.MsgTx) {
+	l.coinSelectMtx.Lock()
+	defer l.coinSelectMtx.Unlock()
+
+	for _, txIn := range tx.TxIn {
+		op := txIn.PreviousOutPoint
+		if _, ok := l.lockedOutPoints[op]; !ok {
+			continue
+		}
+
+		delete(l.lockedOutPoints, op)
+		l.UnlockOutpoint(op)
+	}
+}
+
+// SendOutputsWithCoinSelection funds, signs, and broadcasts a transaction
+// paying out to the specified outputs at the passed fee rate, expressed in
+// sat/byte. Unlike SendOutputs, the coins funding the transaction are chosen
+// according to the passed coin selection, among the wallet outputs with at
+// least minConfs confirmations. If a label is specified, then it's attached
+// to the broadcast transaction.
+func (l *LightningWallet) SendOutputsWithCoinSelection(outputs []*wire.TxOut,
+	feeRate uint64, minConfs int32, selection *CoinSelection,
+	label string) (*wire.MsgTx, error) {
+
+	packet, err := psbt.New(nil, outputs, 1, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+	_, err = l.FundPsbt(packet, feeRate, minConfs, selection)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.publishPsbt(packet, label)
+}
+
+// SweepAll funds, signs, and broadcasts a transaction sweeping all the
+// eligible coins of the wallet into a single output paying to the passed
+// script, minus the fee required at the passed fee rate, expressed in
+// sat/byte. The eligible coins are those with at least minConfs
+// confirmations which aren't locked, restricted to the outpoints of the
+// passed coin selection if any are specified. If a label is specified, then
+// it's attached to the broadcast transaction.
+func (l *LightningWallet) SweepAll(pkScript []byte, feeRate uint64,
+	minConfs int32, selection *CoinSelection,
+	label string) (*wire.MsgTx, error) {
+
+	packet, err := l.fundSweep(pkScript, feeRate, minConfs, selection)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.publishPsbt(packet, label)
+}
+
+// fundSweep creates a packet spending all the eligible coins of the wallet
+// into a single output paying to the passed script. The swept coins are
+// locked.
+func (l *LightningWallet) fundSweep(pkScript []byte, feeRate uint64,
+	minConfs int32, selection *CoinSelection) (*psbt.Packet, error) {
+
+	l.coinSelectMtx.Lock()
+	defer l.coinSelectMtx.Unlock()
+
+	coins, err := l.ListUnspentWitness(minConfs)
+	if err != nil {
+		return nil, err
+	}
+	available, err := l.eligibleCoins(coins, selection)
+	if err != nil {
+		return nil, err
+	}
+	if len(available) == 0 {
+		return nil, fmt.Errorf("no eligible coins to sweep")
+	}
+
+	var (
+		totalAmt btcutil.Amount
+		weight   int
+	)
+	for _, coin := range available {
+		totalAmt += coin.Value
+		weight += inputWeight(coin.PkScript)
+	}
+
+	sweepOutput := &wire.TxOut{PkScript: pkScript}
+	packet, err := psbt.New(nil, []*wire.TxOut{sweepOutput}, 1, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	fee := estimatePsbtFee(feeRate, packet.UnsignedTx, weight, 0)
+	if totalAmt-fee < psbtMinChangeAmt {
+		return nil, &ErrInsufficientFunds{fee + psbtMinChangeAmt,
+			totalAmt}
+	}
+	packet.UnsignedTx.TxOut[0].Value = int64(totalAmt - fee)
+
+	for _, coin := range available {
+		l.addInput(packet, coin)
+	}
+
+	return packet, nil
+}
+
+// publishPsbt signs, finalizes, and broadcasts a funded packet, attaching the
+// passed label to the final transaction if one is specified. The wallet
+// inputs of the packet are unlocked once done.
+func (l *LightningWallet) publishPsbt(packet *psbt.Packet,
+	label string) (*wire.MsgTx, error) {
+
+	// Once the transaction has either been broadcast, or failed to be,
+	// the selected inputs no longer need to be reserved.
+	defer l.releaseInputs(packet.UnsignedTx)
+
+	finalTx, err := l.FinalizePsbt(packet)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.PublishAndLabel(finalTx, label); err != nil {
+		return nil, err
+	}
+
+	return finalTx, nil
+}
+
+// SignPsbt adds a partial signature to every input of the packet which
+// spends an output controlled by the wallet. Inputs which are already
+// finalized, or don't belong to the wallet are skipped. The indexes of the
+// signed inputs are returned.
+func (l *LightningWallet) SignPsbt(packet *psbt.Packet) ([]uint32, error) {
+	// Every input must carry its UTXO information, as it's required in
+	// order to generate the segwit sighash. Inputs only carrying the full
+	// transaction they spend have their witness UTXO filled in from it.
+	tx := packet.UnsignedTx
+	for i := range packet.Inputs {
+		prevOut := tx.TxIn[i].PreviousOutPoint
+		if err := fillWitnessUtxo(&packet.Inputs[i], prevOut); err != nil {
+			return nil, err
+		}
+	}
+
+	sigHashes := txscript.NewTxSigHashes(tx)
+
+	var signedInputs []uint32
+	for i := range packet.Inputs {
+		pInput := &packet.Inputs[i]
+		if pInput.FinalScriptSig != nil ||
+			pInput.FinalScriptWitness != nil {
+
+			continue
+		}
+
+		// Inputs which don't spend one of our outputs are skipped, as
+		// a remote signer refuses to sign for them.
+		prevOut := tx.TxIn[i].PreviousOutPoint
+		_, err := l.FetchInputInfo(&prevOut)
+		if err == ErrNotMine {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		signDesc := &SignDescriptor{
+			Output:     pInput.WitnessUtxo,
+			HashType:   txscript.SigHashAll,
+			SigHashes:  sigHashes,
+			InputIndex: i,
+		}
+		inputScript, err := l.Signer.ComputeInputScript(tx, signDesc)
+		if err != nil {
+			return nil, err
+		}
+
+		// A nil input script indicates that the output doesn't belong
+		// to the wallet.
+		if inputScript == nil || len(inputScript.Witness) != 2 {
+			continue
+		}
+
+		pInput.PartialSigs = []*psbt.PartialSig{{
+			PubKey:    inputScript.Witness[1],
+			Signature: inputScript.Witness[0],
+		}}
+		pInput.SighashType = uint32(txscript.SigHashAll)
+
+		// For a p2wkh output nested within a p2sh output, the sigScript
+		// pushes the witness program, which serves as the redeem
+		// script.
+		if len(inputScript.ScriptSig) != 0 {
+			pushes, err := txscript.PushedData(inputScript.ScriptSig)
+			if err != nil {
+				return nil, err
+			}
+			if len(pushes) != 1 {
+				return nil, fmt.Errorf("invalid sigScript for "+
+					"input %d", i)
+			}
+			pInput.RedeemScript = pushes[0]
+		}
+
+		signedInputs = append(signedInputs, uint32(i))
+	}
+
+	return signedInputs, nil
+}
+
+// FinalizePsbt signs all the wallet inputs of the packet, then attempts to
+// finalize every input. If successful, the final fully signed transaction is
+// returned.
+func (l *LightningWallet) FinalizePsbt(packet *psbt.Packet) (*wire.MsgTx, error) {
+	if _, err := l.SignPsbt(packet); err != nil {
+		return nil, err
+	}
+
+	if err := packet.MaybeFinalizeAll(); err != nil {
+		return nil, err
+	}
+
+	return packet.Extract()
+}
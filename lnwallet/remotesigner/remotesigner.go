@@ -0,0 +1,441 @@
+package remotesigner
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/lightningnetwork/lightning-onion"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg"
+	"github.com/roasbeef/btcd/wire"
+)
+
+const (
+	// DefaultTimeout is the default timeout of each request sent to the
+	// remote signer.
+	DefaultTimeout = 5 * time.Second
+)
+
+var (
+	// ErrFamilyNotAllowed is returned when a key is requested within a
+	// key family which isn't within the configured allowlist.
+	ErrFamilyNotAllowed = errors.New("key family not allowed by remote " +
+		"signer config")
+)
+
+// Config houses the parameters required to connect to a remote signer.
+type Config struct {
+	// RPCHost is the host:port of the gRPC interface of the remote signer.
+	RPCHost string
+
+	// TLSCertPath is the path to the TLS certificate served by the gRPC
+	// interface of the remote signer.
+	TLSCertPath string
+
+	// NetParams is the network the remote signer's wallet operates on.
+	NetParams *chaincfg.Params
+
+	// Timeout is the timeout of each request sent to the remote signer.
+	Timeout time.Duration
+
+	// AllowedFamilies is the set of key families that keys may be derived
+	// within. If empty, then all key families are permitted.
+	AllowedFamilies []keychain.KeyFamily
+}
+
+// RemoteSigner is an implementation of both the lnwallet.Signer and
+// keychain.KeyRing interfaces which forwards all operations to a remote lnd
+// instance holding the private keys. This allows a node to operate in a
+// watch-only mode, where it only ever learns the public keys used within its
+// channels. The node key is held by the remote signer too, which processes
+// the onion packets sent to the node on its behalf.
+type RemoteSigner struct {
+	cfg *Config
+
+	conn   *grpc.ClientConn
+	client lnrpc.LightningClient
+
+	allowed map[keychain.KeyFamily]struct{}
+}
+
+// A compile time check to ensure that RemoteSigner implements the
+// lnwallet.Signer interface.
+var _ lnwallet.Signer = (*RemoteSigner)(nil)
+
+// A compile time check to ensure that RemoteSigner implements the
+// keychain.KeyRing interface.
+var _ keychain.KeyRing = (*RemoteSigner)(nil)
+
+// New creates a new RemoteSigner connected to the signer specified within the
+// passed config.
+func New(cfg *Config) (*RemoteSigner, error) {
+	if cfg.Timeout == 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+
+	// The remote signer holds all of our keys, so it may only be reached
+	// over TLS.
+	creds, err := credentials.NewClientTLSFromFile(cfg.TLSCertPath, "")
+	if err != nil {
+		return nil, fmt.Errorf("unable to read remote signer TLS "+
+			"certificate: %v", err)
+	}
+	conn, err := grpc.Dial(cfg.RPCHost,
+		grpc.WithTransportCredentials(creds), grpc.WithBlock(),
+		grpc.WithTimeout(cfg.Timeout))
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to remote signer: %v",
+			err)
+	}
+
+	allowed := make(map[keychain.KeyFamily]struct{})
+	for _, keyFam := range cfg.AllowedFamilies {
+		allowed[keyFam] = struct{}{}
+	}
+
+	return &RemoteSigner{
+		cfg:     cfg,
+		conn:    conn,
+		client:  lnrpc.NewLightningClient(conn),
+		allowed: allowed,
+	}, nil
+}
+
+// Stop closes the connection to the remote signer.
+func (r *RemoteSigner) Stop() error {
+	return r.conn.Close()
+}
+
+// Ping returns an error if the remote signer can't be reached within the
+// configured timeout.
+func (r *RemoteSigner) Ping() error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.Timeout)
+	defer cancel()
+
+	_, err := r.client.GetInfo(ctx, &lnrpc.GetInfoRequest{})
+	return err
+}
+
+// checkFamily returns ErrFamilyNotAllowed if keys within the passed key family
+// may not be derived.
+func (r *RemoteSigner) checkFamily(keyFam keychain.KeyFamily) error {
+	if len(r.allowed) == 0 {
+		return nil
+	}
+	if _, ok := r.allowed[keyFam]; !ok {
+		return ErrFamilyNotAllowed
+	}
+
+	return nil
+}
+
+// signRequest builds the request to sign the passed transaction according to
+// the passed sign descriptor.
+func signRequest(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor) (*lnrpc.SignReq, error) {
+
+	var b bytes.Buffer
+	if err := tx.Serialize(&b); err != nil {
+		return nil, err
+	}
+
+	rpcDesc := &lnrpc.SignDescriptor{
+		SingleTweak:   signDesc.PrivateTweak,
+		WitnessScript: signDesc.WitnessScript,
+		Output: &lnrpc.TxOut{
+			Value:    signDesc.Output.Value,
+			PkScript: signDesc.Output.PkScript,
+		},
+		Sighash:    uint32(signDesc.HashType),
+		InputIndex: int32(signDesc.InputIndex),
+	}
+	if signDesc.PubKey != nil {
+		rpcDesc.KeyDesc = &lnrpc.KeyDescriptor{
+			RawKeyBytes: signDesc.PubKey.SerializeCompressed(),
+		}
+	}
+
+	return &lnrpc.SignReq{
+		RawTxBytes: b.Bytes(),
+		SignDescs:  []*lnrpc.SignDescriptor{rpcDesc},
+	}, nil
+}
+
+// SignOutputRaw generates a signature for the passed transaction according to
+// the data within the passed SignDescriptor, using the remote signer.
+//
+// NOTE: This is part of the lnwallet.Signer interface.
+func (r *RemoteSigner) SignOutputRaw(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor) ([]byte, error) {
+
+	req, err := signRequest(tx, signDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.Timeout)
+	defer cancel()
+
+	resp, err := r.client.SignOutputRaw(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.RawSigs) != 1 {
+		return nil, fmt.Errorf("expected 1 signature from remote "+
+			"signer, got %v", len(resp.RawSigs))
+	}
+
+	return resp.RawSigs[0], nil
+}
+
+// ComputeInputScript generates a complete InputScript for the passed
+// transaction with the signature as defined within the passed SignDescriptor,
+// using the remote signer.
+//
+// NOTE: This is part of the lnwallet.Signer interface.
+func (r *RemoteSigner) ComputeInputScript(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor) (*lnwallet.InputScript, error) {
+
+	req, err := signRequest(tx, signDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.Timeout)
+	defer cancel()
+
+	resp, err := r.client.ComputeInputScript(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.InputScripts) != 1 {
+		return nil, fmt.Errorf("expected 1 input script from remote "+
+			"signer, got %v", len(resp.InputScripts))
+	}
+
+	return &lnwallet.InputScript{
+		Witness:   resp.InputScripts[0].Witness,
+		ScriptSig: resp.InputScripts[0].SigScript,
+	}, nil
+}
+
+// parseKeyDescriptor converts a key descriptor returned by the remote signer
+// into its keychain counterpart.
+func parseKeyDescriptor(rpcDesc *lnrpc.KeyDescriptor) (keychain.KeyDescriptor,
+	error) {
+
+	if rpcDesc.KeyLoc == nil {
+		return keychain.KeyDescriptor{}, fmt.Errorf("remote signer " +
+			"returned key without locator")
+	}
+
+	pubKey, err := btcec.ParsePubKey(rpcDesc.RawKeyBytes, btcec.S256())
+	if err != nil {
+		return keychain.KeyDescriptor{}, err
+	}
+
+	return keychain.KeyDescriptor{
+		KeyLocator: keychain.KeyLocator{
+			Family: keychain.KeyFamily(rpcDesc.KeyLoc.KeyFamily),
+			Index:  uint32(rpcDesc.KeyLoc.KeyIndex),
+		},
+		PubKey: pubKey,
+	}, nil
+}
+
+// DeriveNextKey derives the next key within the passed key family using the
+// remote signer.
+//
+// NOTE: This is part of the keychain.KeyRing interface.
+func (r *RemoteSigner) DeriveNextKey(keyFam keychain.KeyFamily) (
+	keychain.KeyDescriptor, error) {
+
+	if err := r.checkFamily(keyFam); err != nil {
+		return keychain.KeyDescriptor{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.Timeout)
+	defer cancel()
+
+	resp, err := r.client.DeriveNextKey(ctx, &lnrpc.DeriveNextKeyRequest{
+		KeyFamily: int32(keyFam),
+	})
+	if err != nil {
+		return keychain.KeyDescriptor{}, err
+	}
+
+	return parseKeyDescriptor(resp)
+}
+
+// DeriveKey derives the key specified by the passed key locator using the
+// remote signer.
+//
+// NOTE: This is part of the keychain.KeyRing interface.
+func (r *RemoteSigner) DeriveKey(keyLoc keychain.KeyLocator) (
+	keychain.KeyDescriptor, error) {
+
+	if err := r.checkFamily(keyLoc.Family); err != nil {
+		return keychain.KeyDescriptor{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.Timeout)
+	defer cancel()
+
+	resp, err := r.client.DeriveKey(ctx, &lnrpc.DeriveKeyRequest{
+		KeyLoc: &lnrpc.KeyLocator{
+			KeyFamily: int32(keyLoc.Family),
+			KeyIndex:  int32(keyLoc.Index),
+		},
+	})
+	if err != nil {
+		return keychain.KeyDescriptor{}, err
+	}
+
+	return parseKeyDescriptor(resp)
+}
+
+// NodeKey returns the node key held by the remote signer, the first key of
+// the keychain.KeyFamilyNodeKey family. All ECDH and signing operations of
+// the returned key are forwarded to the signer.
+func (r *RemoteSigner) NodeKey() (keychain.NodeKey, error) {
+	keyDesc, err := r.DeriveKey(keychain.KeyLocator{
+		Family: keychain.KeyFamilyNodeKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &nodeKey{
+		signer:  r,
+		keyDesc: keyDesc,
+	}, nil
+}
+
+// nodeKey is an implementation of the keychain.NodeKey interface, backed by
+// a key held by the remote signer.
+type nodeKey struct {
+	signer  *RemoteSigner
+	keyDesc keychain.KeyDescriptor
+}
+
+// A compile time check to ensure that nodeKey implements the
+// keychain.NodeKey interface.
+var _ keychain.NodeKey = (*nodeKey)(nil)
+
+// rpcKeyLoc returns the locator of the node key in its RPC form.
+func (n *nodeKey) rpcKeyLoc() *lnrpc.KeyLocator {
+	return &lnrpc.KeyLocator{
+		KeyFamily: int32(n.keyDesc.Family),
+		KeyIndex:  int32(n.keyDesc.Index),
+	}
+}
+
+// PubKey returns the public key of the key.
+//
+// NOTE: This is part of the keychain.SingleKeyECDH interface.
+func (n *nodeKey) PubKey() *btcec.PublicKey {
+	return n.keyDesc.PubKey
+}
+
+// ECDH performs an ECDH operation between the passed public key and the key,
+// returning the sha256 of the compressed shared point.
+//
+// NOTE: This is part of the keychain.SingleKeyECDH interface.
+func (n *nodeKey) ECDH(pubKey *btcec.PublicKey) ([32]byte, error) {
+	var sharedKey [32]byte
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(), n.signer.cfg.Timeout,
+	)
+	defer cancel()
+
+	resp, err := n.signer.client.DeriveSharedKey(ctx, &lnrpc.SharedKeyRequest{
+		EphemeralPubkey: pubKey.SerializeCompressed(),
+		KeyDesc: &lnrpc.KeyDescriptor{
+			RawKeyBytes: n.keyDesc.PubKey.SerializeCompressed(),
+			KeyLoc:      n.rpcKeyLoc(),
+		},
+	})
+	if err != nil {
+		return sharedKey, err
+	}
+	if len(resp.SharedKey) != len(sharedKey) {
+		return sharedKey, fmt.Errorf("remote signer returned shared "+
+			"key of %v bytes", len(resp.SharedKey))
+	}
+	copy(sharedKey[:], resp.SharedKey)
+
+	return sharedKey, nil
+}
+
+// SignDigest signs the passed 32-byte digest.
+//
+// NOTE: This is part of the keychain.NodeKey interface.
+func (n *nodeKey) SignDigest(digest []byte) (*btcec.Signature, error) {
+	ctx, cancel := context.WithTimeout(
+		context.Background(), n.signer.cfg.Timeout,
+	)
+	defer cancel()
+
+	resp, err := n.signer.client.SignDigest(ctx, &lnrpc.SignDigestRequest{
+		KeyLoc: n.rpcKeyLoc(),
+		Digest: digest,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return btcec.ParseDERSignature(resp.Signature, btcec.S256())
+}
+
+// ProcessOnionPacket processes the passed onion packet with the node key held
+// by the remote signer.
+func (r *RemoteSigner) ProcessOnionPacket(onionPkt *sphinx.OnionPacket,
+	assocData []byte) (*sphinx.ProcessedPacket, error) {
+
+	var b bytes.Buffer
+	if err := onionPkt.Encode(&b); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.Timeout)
+	defer cancel()
+
+	resp, err := r.client.ProcessOnion(ctx, &lnrpc.ProcessOnionRequest{
+		OnionBlob: b.Bytes(),
+		AssocData: assocData,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.ExitNode {
+		return &sphinx.ProcessedPacket{Action: sphinx.ExitNode}, nil
+	}
+
+	processed := &sphinx.ProcessedPacket{
+		Action: sphinx.MoreHops,
+		Packet: &sphinx.OnionPacket{},
+	}
+	if len(resp.NextHop) != len(processed.NextHop) {
+		return nil, fmt.Errorf("remote signer returned next hop of "+
+			"%v bytes", len(resp.NextHop))
+	}
+	copy(processed.NextHop[:], resp.NextHop)
+
+	err = processed.Packet.Decode(bytes.NewReader(resp.NextOnionBlob))
+	if err != nil {
+		return nil, err
+	}
+
+	return processed, nil
+}
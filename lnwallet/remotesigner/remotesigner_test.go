@@ -0,0 +1,156 @@
+package remotesigner
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+)
+
+var testPrivKey = bytes.Repeat([]byte{0x02}, 32)
+
+// TestSignRequest ensures that a sign descriptor is properly converted into
+// the request sent to the remote signer.
+func TestSignRequest(t *testing.T) {
+	t.Parallel()
+
+	_, pubKey := btcec.PrivKeyFromBytes(btcec.S256(), testPrivKey)
+
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(&wire.TxIn{})
+	tx.AddTxOut(&wire.TxOut{Value: 1000, PkScript: []byte{0x00}})
+
+	signDesc := &lnwallet.SignDescriptor{
+		PubKey:        pubKey,
+		PrivateTweak:  []byte{0x01},
+		WitnessScript: []byte{0x51},
+		Output:        &wire.TxOut{Value: 5000, PkScript: []byte{0x00}},
+		HashType:      txscript.SigHashAll,
+		InputIndex:    0,
+	}
+
+	req, err := signRequest(tx, signDesc)
+	if err != nil {
+		t.Fatalf("unable to create sign request: %v", err)
+	}
+
+	var b bytes.Buffer
+	if err := tx.Serialize(&b); err != nil {
+		t.Fatalf("unable to serialize tx: %v", err)
+	}
+	if !bytes.Equal(req.RawTxBytes, b.Bytes()) {
+		t.Fatalf("tx mismatch")
+	}
+	if len(req.SignDescs) != 1 {
+		t.Fatalf("expected 1 sign descriptor, got %v",
+			len(req.SignDescs))
+	}
+
+	rpcDesc := req.SignDescs[0]
+	if !bytes.Equal(rpcDesc.KeyDesc.RawKeyBytes,
+		pubKey.SerializeCompressed()) {
+
+		t.Fatalf("pubkey mismatch")
+	}
+	if !bytes.Equal(rpcDesc.SingleTweak, signDesc.PrivateTweak) {
+		t.Fatalf("tweak mismatch")
+	}
+	if !bytes.Equal(rpcDesc.WitnessScript, signDesc.WitnessScript) {
+		t.Fatalf("witness script mismatch")
+	}
+	if rpcDesc.Output.Value != signDesc.Output.Value {
+		t.Fatalf("output value mismatch")
+	}
+	if txscript.SigHashType(rpcDesc.Sighash) != signDesc.HashType {
+		t.Fatalf("sighash mismatch")
+	}
+
+	// Without a public key, no key descriptor should be sent.
+	signDesc.PubKey = nil
+	req, err = signRequest(tx, signDesc)
+	if err != nil {
+		t.Fatalf("unable to create sign request: %v", err)
+	}
+	if req.SignDescs[0].KeyDesc != nil {
+		t.Fatalf("expected no key descriptor")
+	}
+}
+
+// TestParseKeyDescriptor ensures that key descriptors returned by the remote
+// signer are properly parsed, and rejected if they lack a key locator.
+func TestParseKeyDescriptor(t *testing.T) {
+	t.Parallel()
+
+	_, pubKey := btcec.PrivKeyFromBytes(btcec.S256(), testPrivKey)
+
+	keyDesc, err := parseKeyDescriptor(&lnrpc.KeyDescriptor{
+		RawKeyBytes: pubKey.SerializeCompressed(),
+		KeyLoc: &lnrpc.KeyLocator{
+			KeyFamily: int32(keychain.KeyFamilyPaymentBase),
+			KeyIndex:  7,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unable to parse key descriptor: %v", err)
+	}
+	if keyDesc.Family != keychain.KeyFamilyPaymentBase ||
+		keyDesc.Index != 7 {
+
+		t.Fatalf("locator mismatch: %v", keyDesc.KeyLocator)
+	}
+	if !keyDesc.PubKey.IsEqual(pubKey) {
+		t.Fatalf("pubkey mismatch")
+	}
+
+	_, err = parseKeyDescriptor(&lnrpc.KeyDescriptor{
+		RawKeyBytes: pubKey.SerializeCompressed(),
+	})
+	if err == nil {
+		t.Fatalf("expected key without locator to be rejected")
+	}
+}
+
+// TestCheckFamily ensures that only the allowlisted key families are
+// permitted, unless no allowlist is configured.
+func TestCheckFamily(t *testing.T) {
+	t.Parallel()
+
+	signer := &RemoteSigner{
+		allowed: make(map[keychain.KeyFamily]struct{}),
+	}
+	for _, keyFam := range keychain.KeyFamilies {
+		if err := signer.checkFamily(keyFam); err != nil {
+			t.Fatalf("expected family %v to be allowed: %v",
+				keyFam, err)
+		}
+	}
+
+	signer.allowed[keychain.KeyFamilyMultiSig] = struct{}{}
+	if err := signer.checkFamily(keychain.KeyFamilyMultiSig); err != nil {
+		t.Fatalf("expected multisig family to be allowed: %v", err)
+	}
+	err := signer.checkFamily(keychain.KeyFamilyPaymentBase)
+	if err != ErrFamilyNotAllowed {
+		t.Fatalf("expected ErrFamilyNotAllowed, got %v", err)
+	}
+}
+
+// TestNewRequiresTLS ensures that the remote signer isn't dialed without its
+// TLS certificate.
+func TestNewRequiresTLS(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(&Config{
+		RPCHost:     "localhost:10009",
+		TLSCertPath: "/nonexistent/tls.cert",
+	})
+	if err == nil {
+		t.Fatalf("expected remote signer without TLS certificate to " +
+			"be rejected")
+	}
+}
@@ -0,0 +1,212 @@
+package remotesigner
+
+import (
+	"fmt"
+	"sync"
+
+	"golang.org/x/net/context"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// WalletController wraps the WalletController of a watch-only node, so that
+// the on-chain funds of the node are held by the remote signer: new addresses
+// are derived by the signer, and coin selection draws from the outputs it
+// controls, which the signer later signs for through ComputeInputScript. All
+// other operations, such as publishing transactions, are handled by the
+// wrapped WalletController.
+type WalletController struct {
+	lnwallet.WalletController
+
+	signer *RemoteSigner
+
+	// lockedOutpoints is the set of the signer's outputs reserved by
+	// pending channel fundings, which are excluded from coin selection.
+	lockedMtx       sync.Mutex
+	lockedOutpoints map[wire.OutPoint]struct{}
+}
+
+// A compile time check to ensure that WalletController implements the
+// lnwallet.WalletController interface.
+var _ lnwallet.WalletController = (*WalletController)(nil)
+
+// NewWalletController creates a new WalletController, wrapping the passed
+// WalletController of a watch-only node whose funds are held by the passed
+// remote signer.
+func NewWalletController(wc lnwallet.WalletController,
+	signer *RemoteSigner) *WalletController {
+
+	return &WalletController{
+		WalletController: wc,
+		signer:           signer,
+		lockedOutpoints:  make(map[wire.OutPoint]struct{}),
+	}
+}
+
+// NewAddress returns the next external or internal address of the remote
+// signer's wallet.
+//
+// NOTE: This is part of the lnwallet.WalletController interface.
+func (w *WalletController) NewAddress(addrType lnwallet.AddressType,
+	change bool) (btcutil.Address, error) {
+
+	var rpcType lnrpc.NewAddressRequest_AddressType
+	switch addrType {
+	case lnwallet.WitnessPubKey:
+		rpcType = lnrpc.NewAddressRequest_WITNESS_PUBKEY_HASH
+	case lnwallet.NestedWitnessPubKey:
+		rpcType = lnrpc.NewAddressRequest_NESTED_PUBKEY_HASH
+	case lnwallet.PubKeyHash:
+		rpcType = lnrpc.NewAddressRequest_PUBKEY_HASH
+	default:
+		return nil, fmt.Errorf("unsupported address type: %v",
+			addrType)
+	}
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(), w.signer.cfg.Timeout,
+	)
+	defer cancel()
+
+	resp, err := w.signer.client.NextAddr(ctx, &lnrpc.AddrRequest{
+		Type:   rpcType,
+		Change: change,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return btcutil.DecodeAddress(resp.Address, w.signer.cfg.NetParams)
+}
+
+// listUnspent returns all the outputs of the remote signer's wallet with at
+// least the passed number of confirmations, including the locked ones.
+func (w *WalletController) listUnspent(minConfs int32) ([]*lnwallet.Utxo,
+	error) {
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(), w.signer.cfg.Timeout,
+	)
+	defer cancel()
+
+	resp, err := w.signer.client.ListUnspent(ctx, &lnrpc.ListUnspentRequest{
+		MinConfs: minConfs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	utxos := make([]*lnwallet.Utxo, 0, len(resp.Utxos))
+	for _, rpcUtxo := range resp.Utxos {
+		txid, err := chainhash.NewHashFromStr(rpcUtxo.Txid)
+		if err != nil {
+			return nil, err
+		}
+
+		utxos = append(utxos, &lnwallet.Utxo{
+			Value: btcutil.Amount(rpcUtxo.AmountSat),
+			OutPoint: wire.OutPoint{
+				Hash:  *txid,
+				Index: rpcUtxo.OutputIndex,
+			},
+			PkScript:      rpcUtxo.PkScript,
+			Confirmations: rpcUtxo.Confirmations,
+		})
+	}
+
+	return utxos, nil
+}
+
+// ListUnspentWitness returns the unlocked outputs of the remote signer's
+// wallet with at least the passed number of confirmations.
+//
+// NOTE: This is part of the lnwallet.WalletController interface.
+func (w *WalletController) ListUnspentWitness(minConfs int32) (
+	[]*lnwallet.Utxo, error) {
+
+	utxos, err := w.listUnspent(minConfs)
+	if err != nil {
+		return nil, err
+	}
+
+	w.lockedMtx.Lock()
+	defer w.lockedMtx.Unlock()
+
+	unlocked := utxos[:0]
+	for _, utxo := range utxos {
+		if _, ok := w.lockedOutpoints[utxo.OutPoint]; ok {
+			continue
+		}
+		unlocked = append(unlocked, utxo)
+	}
+
+	return unlocked, nil
+}
+
+// ConfirmedBalance returns the sum of the outputs of the remote signer's
+// wallet with at least the passed number of confirmations.
+//
+// NOTE: This is part of the lnwallet.WalletController interface.
+func (w *WalletController) ConfirmedBalance(confs int32,
+	witness bool) (btcutil.Amount, error) {
+
+	utxos, err := w.listUnspent(confs)
+	if err != nil {
+		return 0, err
+	}
+
+	var balance btcutil.Amount
+	for _, utxo := range utxos {
+		balance += utxo.Value
+	}
+
+	return balance, nil
+}
+
+// FetchInputInfo returns the output of the remote signer's wallet at the
+// passed outpoint. If the output isn't controlled by the signer, then
+// lnwallet.ErrNotMine is returned.
+//
+// NOTE: This is part of the lnwallet.WalletController interface.
+func (w *WalletController) FetchInputInfo(prevOut *wire.OutPoint) (
+	*wire.TxOut, error) {
+
+	utxos, err := w.listUnspent(0)
+	if err != nil {
+		return nil, err
+	}
+	for _, utxo := range utxos {
+		if utxo.OutPoint == *prevOut {
+			return &wire.TxOut{
+				Value:    int64(utxo.Value),
+				PkScript: utxo.PkScript,
+			}, nil
+		}
+	}
+
+	return nil, lnwallet.ErrNotMine
+}
+
+// LockOutpoint excludes the passed output of the remote signer's wallet from
+// coin selection.
+//
+// NOTE: This is part of the lnwallet.WalletController interface.
+func (w *WalletController) LockOutpoint(o wire.OutPoint) {
+	w.lockedMtx.Lock()
+	w.lockedOutpoints[o] = struct{}{}
+	w.lockedMtx.Unlock()
+}
+
+// UnlockOutpoint makes the passed output of the remote signer's wallet
+// eligible for coin selection once again.
+//
+// NOTE: This is part of the lnwallet.WalletController interface.
+func (w *WalletController) UnlockOutpoint(o wire.OutPoint) {
+	w.lockedMtx.Lock()
+	delete(w.lockedOutpoints, o)
+	w.lockedMtx.Unlock()
+}
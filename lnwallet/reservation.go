@@ -6,6 +6,7 @@ import (
 
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/txscript"
 	"github.com/roasbeef/btcd/wire"
 	"github.com/roasbeef/btcutil"
 )
@@ -38,6 +39,11 @@ type ChannelContribution struct {
 	// commitment transaction.
 	CommitKey *btcec.PublicKey
 
+	// PaymentKey is the key the other party's version of the commitment
+	// transaction pays this party's balance to, if the channel uses the
+	// static remote key commitment type.
+	PaymentKey *btcec.PublicKey
+
 	// DeliveryAddress is the address to be used for delivery of cleared
 	// channel funds in the scenario of a cooperative channel closure.
 	DeliveryAddress btcutil.Address
@@ -405,6 +411,63 @@ func (r *ChannelReservation) SetTheirDustLimit(dustLimit btcutil.Amount) {
 	r.partialState.TheirDustLimit = dustLimit
 }
 
+// Capacity returns the total capacity of the channel being funded.
+func (r *ChannelReservation) Capacity() btcutil.Amount {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.partialState.Capacity
+}
+
+// SetCommitType sets the commitment type of the channel, which defaults to
+// the legacy commitment type. As the initial commitment transactions are
+// created as the remote party's contribution is processed, this MUST be called
+// beforehand.
+func (r *ChannelReservation) SetCommitType(commitType channeldb.CommitmentType) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.partialState.CommitType = commitType
+	r.partialState.PendingCommitType = commitType
+}
+
+// SetOurConstraints sets the channel constraints we impose upon the remote
+// party.
+func (r *ChannelReservation) SetOurConstraints(c channeldb.ChannelConstraints) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.partialState.OurConstraints = c
+}
+
+// SetTheirConstraints sets the channel constraints the remote party imposes
+// upon us.
+func (r *ChannelReservation) SetTheirConstraints(c channeldb.ChannelConstraints) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.partialState.TheirConstraints = c
+}
+
+// SetOurDeliveryAddress sets the address our settled funds are paid to upon a
+// cooperative close of the channel, in place of the fresh address generated
+// by the wallet. As the delivery address is committed to during the funding
+// workflow, this MUST be called before our contribution is sent to the remote
+// party.
+func (r *ChannelReservation) SetOurDeliveryAddress(addr btcutil.Address) error {
+	deliveryScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return err
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	r.partialState.OurDeliveryScript = deliveryScript
+	r.ourContribution.DeliveryAddress = addr
+	return nil
+}
+
 // FundingOutpoint returns the outpoint of the funding transaction.
 //
 // NOTE: The pointer returned will only be set once the .ProcesContribution()
@@ -640,6 +640,37 @@ func CommitSpendTimeout(signer Signer, signDesc *SignDescriptor,
 	return witnessStack, nil
 }
 
+// HtlcSpendTimeout constructs a valid witness allowing the sender of an HTLC,
+// whose output is on their own commitment transaction, to sweep it back to
+// themselves through the timeout clause of the script. In order to properly
+// spend the output, the lock time of the sweep transaction must be set to at
+// least the absolute timeout of the HTLC, and the sequence number of the input
+// to the relative delay of the commitment transaction.
+func HtlcSpendTimeout(signer Signer, signDesc *SignDescriptor,
+	sweepTx *wire.MsgTx) (wire.TxWitness, error) {
+
+	// Ensure the transaction version supports the validation of sequence
+	// locks and CSV semantics.
+	if sweepTx.Version < 2 {
+		return nil, fmt.Errorf("version of passed transaction MUST "+
+			"be >= 2, not %v", sweepTx.Version)
+	}
+
+	sweepSig, err := signer.SignOutputRaw(sweepTx, signDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	// We place a zero as the first item of the evaluated witness stack in
+	// order to force Script execution to the HTLC timeout clause.
+	witnessStack := wire.TxWitness(make([][]byte, 3))
+	witnessStack[0] = append(sweepSig, byte(txscript.SigHashAll))
+	witnessStack[1] = []byte{0}
+	witnessStack[2] = signDesc.WitnessScript
+
+	return witnessStack, nil
+}
+
 // CommitSpendRevoke constructs a valid witness allowing a node to sweep the
 // settled output of a malicious counter-party who broadcasts a revoked
 // commitment transaction.
@@ -676,6 +707,28 @@ func CommitSpendNoDelay(signer Signer, signDesc *SignDescriptor,
 	return wire.TxWitness(inputScript.Witness), nil
 }
 
+// CommitSpendStaticRemote constructs a valid witness allowing a node to spend
+// its settled output on the counter-party's commitment transaction of a static
+// remote key channel. As the output pays the node's payment key, which is
+// derived from its seed rather than held by the wallet, the witness is built
+// from a raw signature. The WitnessScript of the passed sign descriptor must
+// be set to the p2wkh script of the output.
+func CommitSpendStaticRemote(signer Signer, signDesc *SignDescriptor,
+	sweepTx *wire.MsgTx) (wire.TxWitness, error) {
+
+	sweepSig, err := signer.SignOutputRaw(sweepTx, signDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	// This is a regular p2wkh spend: witness: <sig> <pubkey>
+	witnessStack := wire.TxWitness(make([][]byte, 2))
+	witnessStack[0] = append(sweepSig, byte(txscript.SigHashAll))
+	witnessStack[1] = signDesc.PubKey.SerializeCompressed()
+
+	return witnessStack, nil
+}
+
 // DeriveRevocationPubkey derives the revocation public key given the
 // counter-party's commitment key, and revocation pre-image derived via a
 // pseudo-random-function. In the event that we (for some reason) broadcast a
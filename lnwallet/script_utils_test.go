@@ -20,7 +20,7 @@ import (
 //   * Bob's spend from Alice's delayed output when she broadcasts a revoked
 //     commitment transaction.
 //   * Bob's spend from his unencumbered output within Alice's commitment
-//     transaction.
+//     transaction, both through the wallet and from a raw signature.
 func TestCommitmentSpendValidation(t *testing.T) {
 	// We generate a fake output, and the corresponding txin. This output
 	// doesn't need to exist, as we'll only be validating spending from the
@@ -167,6 +167,25 @@ func TestCommitmentSpendValidation(t *testing.T) {
 	if err := vm.Execute(); err != nil {
 		t.Fatalf("bob p2wkh spend is invalid: %v", err)
 	}
+
+	// Within static remote key channels, the key bob's output pays isn't
+	// held by the wallet, so he sweeps it from a raw signature instead.
+	signDesc.PubKey = bobKeyPub
+	bobStaticSpend, err := CommitSpendStaticRemote(bobSigner, signDesc,
+		sweepTx)
+	if err != nil {
+		t.Fatalf("unable to create bob static remote spend: %v", err)
+	}
+	sweepTx.TxIn[0].Witness = bobStaticSpend
+	vm, err = txscript.NewEngine(regularOutput.PkScript,
+		sweepTx, 0, txscript.StandardVerifyFlags, nil,
+		nil, int64(channelBalance))
+	if err != nil {
+		t.Fatalf("unable to create engine: %v", err)
+	}
+	if err := vm.Execute(); err != nil {
+		t.Fatalf("bob static remote spend is invalid: %v", err)
+	}
 }
 
 // TestRevocationKeyDerivation tests that given a public key, and a revocation
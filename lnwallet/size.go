@@ -27,6 +27,18 @@ const (
 	//	- PublicKeyHASH160: 20 bytes
 	P2WPKHSize = 1 + 1 + 20
 
+	// P2SH: 23 bytes
+	//	- OP_HASH160: 1 byte
+	//	- OP_DATA: 1 byte (ScriptHASH160 length)
+	//	- ScriptHASH160: 20 bytes
+	//	- OP_EQUAL: 1 byte
+	P2SHSize = 1 + 1 + 20 + 1
+
+	// NestedP2WPKHSigScriptSize: 23 bytes
+	//	- OP_DATA: 1 byte (P2WPKH witness program length)
+	//	- P2WPKH witness program: 22 bytes
+	NestedP2WPKHSigScriptSize = 1 + P2WPKHSize
+
 	// MultiSig: 71 bytes
 	//	- OP_2: 1 byte
 	//	- OP_DATA: 1 byte (pubKeyAlice length)
@@ -133,3 +145,9 @@ func estimateCommitTxCost(count int, prediction bool) int64 {
 
 	return htlcCost + baseCost + witnessCost
 }
+
+// CommitWeight returns the weight of a signed commitment transaction which
+// carries the passed number of HTLC outputs.
+func CommitWeight(numHTLCs int) int64 {
+	return estimateCommitTxCost(numHTLCs, false)
+}
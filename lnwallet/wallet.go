@@ -5,12 +5,14 @@ import (
 	"net"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/btcsuite/fastsha256"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/elkrem"
+	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/roasbeef/btcd/chaincfg"
 	"github.com/roasbeef/btcutil/hdkeychain"
 
@@ -112,6 +114,10 @@ type initFundingReserveMsg struct {
 	// The delay on the "pay-to-self" output(s) of the commitment transaction.
 	csvDelay uint32
 
+	// coinSelection, if non-nil, dictates how the coins funding the
+	// channel are chosen from the wallet.
+	coinSelection *CoinSelection
+
 	// A channel in which all errors will be sent accross. Will be nil if
 	// this initial set is succesful.
 	// NOTE: In order to avoid deadlocks, this channel MUST be buffered.
@@ -280,10 +286,26 @@ type LightningWallet struct {
 	// update the commitment state.
 	Signer Signer
 
+	// KeyRing is used to derive all the keys used within channels. As the
+	// keys are derived deterministically from the wallet's seed, they can
+	// later be recovered using only the KeyLocators stored alongside each
+	// channel.
+	KeyRing keychain.KeyRing
+
 	// ChainIO is an instance of the BlockChainIO interface. ChainIO is
 	// used to lookup the existence of outputs within the UTXO set.
 	ChainIO BlockChainIO
 
+	// CoinSelectionStrategy is the strategy used to order the eligible
+	// coins of the wallet during coin selection, unless a request
+	// specifies its own strategy.
+	CoinSelectionStrategy CoinSelectionStrategy
+
+	// ChangeAddressType is the type of the addresses the change of
+	// transactions funded by the wallet is sent to. The zero value
+	// corresponds to p2wkh addresses.
+	ChangeAddressType AddressType
+
 	// rootKey is the root HD key derived from a WalletController private
 	// key. This rootKey is used to derive all LN specific secrets.
 	rootKey *hdkeychain.ExtendedKey
@@ -326,8 +348,8 @@ type LightningWallet struct {
 // NOTE: The passed channeldb, and ChainNotifier should already be fully
 // initialized/started before being passed as a function arugment.
 func NewLightningWallet(cdb *channeldb.DB, notifier chainntnfs.ChainNotifier,
-	wallet WalletController, signer Signer, bio BlockChainIO,
-	netParams *chaincfg.Params) (*LightningWallet, error) {
+	wallet WalletController, signer Signer, keyRing keychain.KeyRing,
+	bio BlockChainIO, netParams *chaincfg.Params) (*LightningWallet, error) {
 
 	// TODO(roasbeef): need a another wallet level config
 
@@ -349,6 +371,7 @@ func NewLightningWallet(cdb *channeldb.DB, notifier chainntnfs.ChainNotifier,
 		rootKey:          rootMasterKey,
 		chainNotifier:    notifier,
 		Signer:           signer,
+		KeyRing:          keyRing,
 		WalletController: wallet,
 		ChainIO:          bio,
 		ChannelDB:        cdb,
@@ -399,14 +422,34 @@ func (l *LightningWallet) Shutdown() error {
 
 // LockOutpoints returns a list of all currently locked outpoint.
 func (l *LightningWallet) LockedOutpoints() []*wire.OutPoint {
+	l.coinSelectMtx.RLock()
+	defer l.coinSelectMtx.RUnlock()
+
 	outPoints := make([]*wire.OutPoint, 0, len(l.lockedOutPoints))
 	for outPoint := range l.lockedOutPoints {
+		outPoint := outPoint
 		outPoints = append(outPoints, &outPoint)
 	}
 
 	return outPoints
 }
 
+// LeaseOutput leases the passed output of the wallet for the given duration.
+// The coin select mutex is held while leasing the output, ensuring that an
+// output currently reserved to fund a channel can't be leased.
+func (l *LightningWallet) LeaseOutput(id LockID, op wire.OutPoint,
+	duration time.Duration) (time.Time, error) {
+
+	l.coinSelectMtx.Lock()
+	defer l.coinSelectMtx.Unlock()
+
+	if _, ok := l.lockedOutPoints[op]; ok {
+		return time.Time{}, ErrOutputAlreadyLeased
+	}
+
+	return l.WalletController.LeaseOutput(id, op, duration)
+}
+
 // ResetReservations reset the volatile wallet state which trakcs all currently
 // active reservations.
 func (l *LightningWallet) ResetReservations() {
@@ -494,7 +537,8 @@ func (l *LightningWallet) InitChannelReservation(capacity,
 	ourFundAmt btcutil.Amount, theirID *btcec.PublicKey,
 	theirAddr *net.TCPAddr, numConfs uint16,
 	csvDelay uint32, ourDustLimit btcutil.Amount,
-	pushSat btcutil.Amount) (*ChannelReservation, error) {
+	pushSat btcutil.Amount,
+	coinSelection *CoinSelection) (*ChannelReservation, error) {
 
 	// TODO(roasbeef): make the above into an initial config as part of the
 	// refactor to implement spec compliant funding flow
@@ -509,6 +553,7 @@ func (l *LightningWallet) InitChannelReservation(capacity,
 		csvDelay:      csvDelay,
 		ourDustLimit:  ourDustLimit,
 		pushSat:       pushSat,
+		coinSelection: coinSelection,
 		nodeID:        theirID,
 		nodeAddr:      theirAddr,
 		err:           errChan,
@@ -555,7 +600,8 @@ func (l *LightningWallet) handleFundingReserveRequest(req *initFundingReserveMsg
 		// tx
 		feeRate := uint64(10)
 		amt := req.fundingAmount + commitFee
-		err := l.selectCoinsAndChange(feeRate, amt, ourContribution)
+		err := l.selectCoinsAndChange(feeRate, amt, ourContribution,
+			req.coinSelection)
 		if err != nil {
 			req.err <- err
 			req.resp <- nil
@@ -563,25 +609,41 @@ func (l *LightningWallet) handleFundingReserveRequest(req *initFundingReserveMsg
 		}
 	}
 
-	// Grab two fresh keys from our HD chain, one will be used for the
-	// multi-sig funding transaction, and the other for the commitment
-	// transaction.
-	multiSigKey, err := l.NewRawKey()
+	// Derive three fresh keys from their respective key families, one
+	// will be used for the multi-sig funding transaction, and the others
+	// for the commitment transactions. The commitment key serves as both
+	// our payment and delay key within legacy channels, while the payment
+	// key is paid to by the remote commitment of static remote key
+	// channels, so both are drawn from the payment base family. The
+	// locators of the keys are stored along with the channel, allowing
+	// them to be re-derived from our seed alone.
+	multiSigKey, err := l.KeyRing.DeriveNextKey(keychain.KeyFamilyMultiSig)
 	if err != nil {
 		req.err <- err
 		req.resp <- nil
 		return
 	}
-	commitKey, err := l.NewRawKey()
+	commitKey, err := l.KeyRing.DeriveNextKey(keychain.KeyFamilyPaymentBase)
 	if err != nil {
 		req.err <- err
 		req.resp <- nil
 		return
 	}
-	reservation.partialState.OurMultiSigKey = multiSigKey
-	ourContribution.MultiSigKey = multiSigKey
-	reservation.partialState.OurCommitKey = commitKey
-	ourContribution.CommitKey = commitKey
+	paymentKey, err := l.KeyRing.DeriveNextKey(keychain.KeyFamilyPaymentBase)
+	if err != nil {
+		req.err <- err
+		req.resp <- nil
+		return
+	}
+	reservation.partialState.OurMultiSigKey = multiSigKey.PubKey
+	reservation.partialState.OurMultiSigKeyLoc = multiSigKey.KeyLocator
+	ourContribution.MultiSigKey = multiSigKey.PubKey
+	reservation.partialState.OurCommitKey = commitKey.PubKey
+	reservation.partialState.OurCommitKeyLoc = commitKey.KeyLocator
+	ourContribution.CommitKey = commitKey.PubKey
+	reservation.partialState.OurPaymentKey = paymentKey.PubKey
+	reservation.partialState.OurPaymentKeyLoc = paymentKey.KeyLocator
+	ourContribution.PaymentKey = paymentKey.PubKey
 
 	// Generate a fresh address to be used in the case of a cooperative
 	// channel close.
@@ -676,6 +738,15 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 	// Some temporary variables to cut down on the resolution verbosity.
 	pendingReservation.theirContribution = req.contribution
 	theirContribution := req.contribution
+
+	// The commitment transactions of static remote key channels can't be
+	// created without the payment key of the remote party.
+	err := verifyPaymentKey(pendingReservation.partialState, theirContribution)
+	if err != nil {
+		req.err <- err
+		return
+	}
+	pendingReservation.partialState.TheirPaymentKey = theirContribution.PaymentKey
 	ourContribution := pendingReservation.ourContribution
 
 	// Add all multi-party inputs and outputs to the transaction.
@@ -706,9 +777,9 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 	}
 	pendingReservation.partialState.FundingWitnessScript = witnessScript
 
-	// Sort the transaction. Since both side agree to a canonical
-	// ordering, by sorting we no longer need to send the entire
-	// transaction. Only signatures will be exchanged.
+	// Sort the transaction according to BIP 69. Since both side agree to
+	// a canonical ordering, by sorting we no longer need to send the
+	// entire transaction. Only signatures will be exchanged.
 	fundingTx.AddTxOut(multiSigOut)
 	txsort.InPlaceSort(pendingReservation.fundingTx)
 
@@ -790,14 +861,16 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 	ourBalance := pendingReservation.partialState.OurBalance
 	theirBalance := pendingReservation.partialState.TheirBalance
 	ourCommitKey := ourContribution.CommitKey
-	ourCommitTx, err := CreateCommitTx(fundingTxIn, ourCommitKey, theirCommitKey,
+	toThemKey, toUsKey := toRemoteKeys(pendingReservation.partialState,
+		ourCommitKey, theirCommitKey)
+	ourCommitTx, err := CreateCommitTx(fundingTxIn, ourCommitKey, toThemKey,
 		ourRevokeKey, ourContribution.CsvDelay,
 		ourBalance, theirBalance)
 	if err != nil {
 		req.err <- err
 		return
 	}
-	theirCommitTx, err := CreateCommitTx(fundingTxIn, theirCommitKey, ourCommitKey,
+	theirCommitTx, err := CreateCommitTx(fundingTxIn, theirCommitKey, toUsKey,
 		theirContribution.RevocationKey, theirContribution.CsvDelay,
 		theirBalance, ourBalance)
 	if err != nil {
@@ -826,8 +899,8 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 	// Sort both transactions according to the agreed upon cannonical
 	// ordering. This lets us skip sending the entire transaction over,
 	// instead we'll just send signatures.
-	txsort.InPlaceSort(ourCommitTx)
-	txsort.InPlaceSort(theirCommitTx)
+	SortCommitTx(ourCommitTx, nil)
+	SortCommitTx(theirCommitTx, nil)
 
 	deliveryScript, err := txscript.PayToAddrScript(theirContribution.DeliveryAddress)
 	if err != nil {
@@ -887,6 +960,15 @@ func (l *LightningWallet) handleSingleContribution(req *addSingleContributionMsg
 	pendingReservation.theirContribution = req.contribution
 	theirContribution := pendingReservation.theirContribution
 
+	// The commitment transactions of static remote key channels can't be
+	// created without the payment key of the remote party.
+	err := verifyPaymentKey(pendingReservation.partialState, theirContribution)
+	if err != nil {
+		req.err <- err
+		return
+	}
+	pendingReservation.partialState.TheirPaymentKey = theirContribution.PaymentKey
+
 	// Additionally, we can now also record the redeem script of the
 	// funding transaction.
 	// TODO(roasbeef): switch to proper pubkey derivation
@@ -1058,7 +1140,9 @@ func (l *LightningWallet) handleFundingCounterPartySigs(msg *addCounterPartySigs
 		res.partialState.FundingOutpoint, spew.Sdump(fundingTx))
 
 	// Broacast the finalized funding transaction to the network.
-	if err := l.PublishTransaction(fundingTx); err != nil {
+	label := MakeLabel(LabelTypeChannelOpen,
+		res.partialState.FundingOutpoint)
+	if err := l.PublishAndLabel(fundingTx, label); err != nil {
 		msg.err <- err
 		return
 	}
@@ -1110,14 +1194,16 @@ func (l *LightningWallet) handleSingleFunderSigs(req *addSingleFunderSigsMsg) {
 	theirCommitKey := pendingReservation.theirContribution.CommitKey
 	ourBalance := pendingReservation.partialState.OurBalance
 	theirBalance := pendingReservation.partialState.TheirBalance
-	ourCommitTx, err := CreateCommitTx(fundingTxIn, ourCommitKey, theirCommitKey,
+	toThemKey, toUsKey := toRemoteKeys(pendingReservation.partialState,
+		ourCommitKey, theirCommitKey)
+	ourCommitTx, err := CreateCommitTx(fundingTxIn, ourCommitKey, toThemKey,
 		pendingReservation.ourContribution.RevocationKey,
 		pendingReservation.ourContribution.CsvDelay, ourBalance, theirBalance)
 	if err != nil {
 		req.err <- err
 		return
 	}
-	theirCommitTx, err := CreateCommitTx(fundingTxIn, theirCommitKey, ourCommitKey,
+	theirCommitTx, err := CreateCommitTx(fundingTxIn, theirCommitKey, toUsKey,
 		req.revokeKey, pendingReservation.theirContribution.CsvDelay,
 		theirBalance, ourBalance)
 	if err != nil {
@@ -1137,9 +1223,9 @@ func (l *LightningWallet) handleSingleFunderSigs(req *addSingleFunderSigsMsg) {
 	// Sort both transactions according to the agreed upon cannonical
 	// ordering. This ensures that both parties sign the same sighash
 	// without further synchronization.
-	txsort.InPlaceSort(ourCommitTx)
+	SortCommitTx(ourCommitTx, nil)
 	pendingReservation.partialState.OurCommitTx = ourCommitTx
-	txsort.InPlaceSort(theirCommitTx)
+	SortCommitTx(theirCommitTx, nil)
 
 	witnessScript := pendingReservation.partialState.FundingWitnessScript
 	channelValue := int64(pendingReservation.partialState.Capacity)
@@ -1290,10 +1376,11 @@ out:
 // outputs which sum to at least 'numCoins' amount of satoshis. If coin
 // selection is successful/possible, then the selected coins are available
 // within the passed contribution's inputs. If necessary, a change address will
-// also be generated.
+// also be generated. If non-nil, the passed coin selection dictates which of
+// the wallet's coins are considered, and in which order.
 // TODO(roasbeef): remove hardcoded fees and req'd confs for outputs.
 func (l *LightningWallet) selectCoinsAndChange(feeRate uint64, amt btcutil.Amount,
-	contribution *ChannelContribution) error {
+	contribution *ChannelContribution, selection *CoinSelection) error {
 
 	// We hold the coin select mutex while querying for outputs, and
 	// performing coin selection in order to avoid inadvertent double
@@ -1309,6 +1396,13 @@ func (l *LightningWallet) selectCoinsAndChange(feeRate uint64, amt btcutil.Amoun
 		return err
 	}
 
+	// Order the coins according to the requested coin selection, falling
+	// back to the default strategy of the wallet.
+	coins, err = arrangeCoins(coins, selection, l.CoinSelectionStrategy)
+	if err != nil {
+		return err
+	}
+
 	// Perform coin selection over our available, unlocked unspent outputs
 	// in order to find enough coins to meet the funding amount
 	// requirements.
@@ -1333,7 +1427,7 @@ func (l *LightningWallet) selectCoinsAndChange(feeRate uint64, amt btcutil.Amoun
 	// Record any change output(s) generated as a result of the coin
 	// selection.
 	if changeAmt != 0 {
-		changeAddr, err := l.NewAddress(WitnessPubKey, true)
+		changeAddr, err := l.NewAddress(l.ChangeAddressType, true)
 		if err != nil {
 			return err
 		}
@@ -1398,6 +1492,35 @@ func initStateHints(commit1, commit2 *wire.MsgTx,
 	return nil
 }
 
+// verifyPaymentKey ensures that the remote party contributed the payment key
+// required by the commitment type of the passed pending channel.
+func verifyPaymentKey(state *channeldb.OpenChannel,
+	theirContribution *ChannelContribution) error {
+
+	if state.CommitType == channeldb.CommitTypeStaticRemoteKey &&
+		theirContribution.PaymentKey == nil {
+
+		return fmt.Errorf("remote party didn't contribute a payment "+
+			"key to a %v channel", state.CommitType)
+	}
+
+	return nil
+}
+
+// toRemoteKeys returns the keys our and their initial commitment transactions
+// respectively pay the balance of the other party to. Within legacy channels
+// these are the commitment keys of both parties, while static remote key
+// channels pay to their payment keys.
+func toRemoteKeys(state *channeldb.OpenChannel, ourCommitKey,
+	theirCommitKey *btcec.PublicKey) (*btcec.PublicKey, *btcec.PublicKey) {
+
+	if state.CommitType == channeldb.CommitTypeStaticRemoteKey {
+		return state.TheirPaymentKey, state.OurPaymentKey
+	}
+
+	return theirCommitKey, ourCommitKey
+}
+
 // selectInputs selects a slice of inputs necessary to meet the specified
 // selection amount. If input selection is unable to suceed to to insuffcient
 // funds, a non-nil error is returned. Additionally, the total amount of the
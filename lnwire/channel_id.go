@@ -1,5 +1,11 @@
 package lnwire
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
 // ChannelID represent the set of data which is needed to retrieve all
 // necessary data to validate the channel existence.
 type ChannelID struct {
@@ -38,3 +44,44 @@ func (c *ChannelID) ToUint64() uint64 {
 	return ((uint64(c.BlockHeight) << 40) | (uint64(c.TxIndex) << 16) |
 		(uint64(c.TxPosition)))
 }
+
+// String returns the human readable form of the ChannelID, which is the
+// block height, transaction index, and output index of the funding output
+// separated by an 'x', e.g. 503351x1404x0.
+func (c ChannelID) String() string {
+	return fmt.Sprintf("%dx%dx%d", c.BlockHeight, c.TxIndex, c.TxPosition)
+}
+
+// NewChanIDFromString parses the human readable form of a ChannelID as
+// returned by String.
+func NewChanIDFromString(chanID string) (ChannelID, error) {
+	parts := strings.Split(chanID, "x")
+	if len(parts) != 3 {
+		return ChannelID{}, fmt.Errorf("invalid channel ID %q, "+
+			"expected BLOCKxTXxOUT", chanID)
+	}
+
+	// The block height and transaction index are limited to 3 bytes, and
+	// the output index to 2 bytes within the compact encoding.
+	blockHeight, err := strconv.ParseUint(parts[0], 10, 24)
+	if err != nil {
+		return ChannelID{}, fmt.Errorf("invalid block height in "+
+			"channel ID %q: %v", chanID, err)
+	}
+	txIndex, err := strconv.ParseUint(parts[1], 10, 24)
+	if err != nil {
+		return ChannelID{}, fmt.Errorf("invalid tx index in "+
+			"channel ID %q: %v", chanID, err)
+	}
+	txPosition, err := strconv.ParseUint(parts[2], 10, 16)
+	if err != nil {
+		return ChannelID{}, fmt.Errorf("invalid output index in "+
+			"channel ID %q: %v", chanID, err)
+	}
+
+	return ChannelID{
+		BlockHeight: uint32(blockHeight),
+		TxIndex:     uint32(txIndex),
+		TxPosition:  uint16(txPosition),
+	}, nil
+}
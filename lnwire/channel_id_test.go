@@ -37,3 +37,39 @@ func TestChannelIDEncoding(t *testing.T) {
 		}
 	}
 }
+
+func TestChannelIDStringEncoding(t *testing.T) {
+	chanID := ChannelID{
+		BlockHeight: 503351,
+		TxIndex:     1404,
+		TxPosition:  1,
+	}
+	if chanID.String() != "503351x1404x1" {
+		t.Fatalf("unexpected string encoding: %v", chanID)
+	}
+
+	newChanID, err := NewChanIDFromString(chanID.String())
+	if err != nil {
+		t.Fatalf("unable to parse chan ID: %v", err)
+	}
+	if newChanID != chanID {
+		t.Fatalf("chan ID's don't match: expected %v got %v",
+			chanID, newChanID)
+	}
+
+	var invalidIDs = []string{
+		"",
+		"503351x1404",
+		"503351x1404x1x0",
+		"503351:1404:1",
+		"-1x1404x1",
+		"16777216x1404x1",
+		"503351x16777216x1",
+		"503351x1404x65536",
+	}
+	for _, invalidID := range invalidIDs {
+		if _, err := NewChanIDFromString(invalidID); err == nil {
+			t.Fatalf("expected %q to be rejected", invalidID)
+		}
+	}
+}
@@ -2,13 +2,50 @@ package lnwire
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 
 	"github.com/roasbeef/btcd/btcec"
 )
 
+const (
+	// ChanUpdateDirection is the bit of the flags of a channel update
+	// which signals the direction of the channel the update is for. It's
+	// unset if the update was created by the first node of the channel,
+	// and set if it was created by the second.
+	ChanUpdateDirection uint16 = 1 << 0
+
+	// ChanUpdateDisabled is the bit of the flags of a channel update which
+	// signals the creating node has disabled forwarding over its direction
+	// of the channel.
+	ChanUpdateDisabled uint16 = 1 << 1
+
+	// InboundFeeRecordType is the type of the record within the trailing
+	// TLV stream of a channel update which carries the inbound fee of the
+	// creating node.
+	InboundFeeRecordType uint16 = 55555
+
+	// maxChanUpdatePayload is the maximum size of a channel update,
+	// including its trailing TLV stream.
+	maxChanUpdatePayload = 65535
+)
+
+// InboundFee is the fee a node charges for HTLCs entering it through a
+// channel. It's added to the fee of the channel the HTLC is forwarded over,
+// and both of its components may be negative to offer a discount for such
+// traffic.
+type InboundFee struct {
+	// BaseMsat is the base fee, in millisatoshi.
+	BaseMsat int32
+
+	// FeeRateMillionths is the proportional fee, in millionths of the
+	// amount forwarded.
+	FeeRateMillionths int32
+}
+
 // ChannelUpdateAnnouncement message is used after channel has been initially
 // announced. Each side independently announces its fees and minimum expiry for
 // HTLCs and other parameters. Also this message is used to redeclare initially
@@ -28,7 +65,8 @@ type ChannelUpdateAnnouncement struct {
 
 	// Flags least-significant bit must be set to 0 if the creating node
 	// corresponds to the first node in previously sent channel
-	// announcement and 1 otherwise.
+	// announcement and 1 otherwise. The second bit is set if the creating
+	// node has disabled its direction of the channel.
 	Flags uint16
 
 	// Expiry is the minimum number of blocks this node requires to be
@@ -44,6 +82,17 @@ type ChannelUpdateAnnouncement struct {
 
 	// FeeProportionalMillionths...
 	FeeProportionalMillionths uint32
+
+	// InboundFee is the inbound fee of the creating node for this
+	// channel, or nil if it doesn't advertise one. It's carried within the
+	// optional trailing TLV stream of the update, so that nodes unaware of
+	// it can still decode the update and verify its signature.
+	InboundFee *InboundFee
+
+	// ExtraRecords holds the serialized records of the trailing TLV
+	// stream whose types are unknown to us. They're retained as is, so
+	// that the update can be relayed with its signature intact.
+	ExtraRecords []byte
 }
 
 // A compile time check to ensure ChannelUpdateAnnouncement implements the
@@ -85,9 +134,99 @@ func (c *ChannelUpdateAnnouncement) Decode(r io.Reader, pver uint32) error {
 		return err
 	}
 
+	// Any remaining bytes form the trailing TLV stream, which is absent
+	// from the updates of nodes unaware of it.
+	extraData, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	return c.decodeExtraData(extraData)
+}
+
+// decodeExtraData parses the records of the passed trailing TLV stream. Each
+// record consists of a 2-byte type, a 2-byte length, and its value, with the
+// records sorted by ascending type.
+func (c *ChannelUpdateAnnouncement) decodeExtraData(extraData []byte) error {
+	c.InboundFee = nil
+	c.ExtraRecords = nil
+
+	var lastType uint16
+	for i := 0; len(extraData) > 0; i++ {
+		if len(extraData) < 4 {
+			return errors.New("truncated channel update record")
+		}
+		recordType := binary.BigEndian.Uint16(extraData[:2])
+		length := int(binary.BigEndian.Uint16(extraData[2:4]))
+		if len(extraData) < 4+length {
+			return errors.New("truncated channel update record")
+		}
+		if i > 0 && recordType <= lastType {
+			return fmt.Errorf("channel update record of type %v "+
+				"out of order", recordType)
+		}
+		lastType = recordType
+
+		record, value := extraData[:4+length], extraData[4:4+length]
+		extraData = extraData[4+length:]
+
+		if recordType != InboundFeeRecordType {
+			c.ExtraRecords = append(c.ExtraRecords, record...)
+			continue
+		}
+
+		if length != 8 {
+			return fmt.Errorf("inbound fee record must be 8 bytes, "+
+				"is %v", length)
+		}
+		c.InboundFee = &InboundFee{
+			BaseMsat: int32(binary.BigEndian.Uint32(value[:4])),
+			FeeRateMillionths: int32(
+				binary.BigEndian.Uint32(value[4:]),
+			),
+		}
+	}
+
 	return nil
 }
 
+// extraData serializes the trailing TLV stream of the update, inserting the
+// inbound fee record, if any, among the retained records of unknown types.
+func (c *ChannelUpdateAnnouncement) extraData() []byte {
+	var inboundRecord []byte
+	if c.InboundFee != nil {
+		inboundRecord = make([]byte, 12)
+		binary.BigEndian.PutUint16(inboundRecord[:2], InboundFeeRecordType)
+		binary.BigEndian.PutUint16(inboundRecord[2:4], 8)
+		binary.BigEndian.PutUint32(
+			inboundRecord[4:8], uint32(c.InboundFee.BaseMsat),
+		)
+		binary.BigEndian.PutUint32(
+			inboundRecord[8:], uint32(c.InboundFee.FeeRateMillionths),
+		)
+	}
+
+	var b bytes.Buffer
+	records := c.ExtraRecords
+	for len(records) >= 4 {
+		recordType := binary.BigEndian.Uint16(records[:2])
+		if inboundRecord != nil && recordType > InboundFeeRecordType {
+			b.Write(inboundRecord)
+			inboundRecord = nil
+		}
+
+		end := 4 + int(binary.BigEndian.Uint16(records[2:4]))
+		if end > len(records) {
+			end = len(records)
+		}
+		b.Write(records[:end])
+		records = records[end:]
+	}
+	b.Write(inboundRecord)
+
+	return b.Bytes()
+}
+
 // Encode serializes the target ChannelUpdateAnnouncement into the passed
 // io.Writer observing the protocol version specified.
 //
@@ -107,7 +246,8 @@ func (c *ChannelUpdateAnnouncement) Encode(w io.Writer, pver uint32) error {
 		return err
 	}
 
-	return nil
+	_, err = w.Write(c.extraData())
+	return err
 }
 
 // Command returns the integer uniquely identifying this message type on the
@@ -123,33 +263,16 @@ func (c *ChannelUpdateAnnouncement) Command() uint32 {
 //
 // This is part of the lnwire.Message interface.
 func (c *ChannelUpdateAnnouncement) MaxPayloadLength(pver uint32) uint32 {
-	var length uint32
-
 	// Signature - 64 bytes
-	length += 64
-
 	// ChannelID - 8 bytes
-	length += 8
-
 	// Timestamp - 4 bytes
-	length += 4
-
 	// Flags - 2 bytes
-	length += 2
-
 	// Expiry - 2 bytes
-	length += 2
-
 	// HtlcMinimumMstat - 4 bytes
-	length += 4
-
 	// FeeBaseMstat - 4 bytes
-	length += 4
-
 	// FeeProportionalMillionths - 4 bytes
-	length += 4
-
-	return length
+	// Trailing TLV stream - the remainder
+	return maxChanUpdatePayload
 }
 
 // String returns the string representation of the target ChannelUpdateAnnouncement.
@@ -165,6 +288,8 @@ func (c *ChannelUpdateAnnouncement) String() string {
 		fmt.Sprintf("HtlcMinimumMstat:\t\t%v\n", c.HtlcMinimumMstat) +
 		fmt.Sprintf("FeeBaseMstat:\t\t%v\n", c.FeeBaseMstat) +
 		fmt.Sprintf("FeeProportionalMillionths:\t\t%v\n", c.FeeProportionalMillionths) +
+		fmt.Sprintf("InboundFee:\t\t%+v\n", c.InboundFee) +
+		fmt.Sprintf("ExtraRecords:\t\t%x\n", c.ExtraRecords) +
 		fmt.Sprintf("--- End ChannelUpdateAnnouncement ---\n")
 }
 
@@ -187,5 +312,11 @@ func (c *ChannelUpdateAnnouncement) DataToSign() ([]byte, error) {
 		return nil, err
 	}
 
+	// The trailing TLV stream is covered by the signature, including the
+	// records of types unknown to us.
+	if _, err := w.Write(c.extraData()); err != nil {
+		return nil, err
+	}
+
 	return w.Bytes(), nil
 }
@@ -16,6 +16,10 @@ func TestChannelUpdateAnnouncementEncodeDecode(t *testing.T) {
 		HtlcMinimumMstat:          maxUint32,
 		FeeBaseMstat:              maxUint32,
 		FeeProportionalMillionths: maxUint32,
+		InboundFee: &InboundFee{
+			BaseMsat:          -1000,
+			FeeRateMillionths: -500,
+		},
 	}
 
 	// Next encode the CUA message into an empty bytes buffer.
@@ -24,11 +28,11 @@ func TestChannelUpdateAnnouncementEncodeDecode(t *testing.T) {
 		t.Fatalf("unable to encode ChannelUpdateAnnouncement: %v", err)
 	}
 
-	// Ensure the max payload estimate is correct.
+	// Ensure the max payload estimate is respected.
 	serializedLength := uint32(b.Len())
-	if serializedLength != cua.MaxPayloadLength(0) {
-		t.Fatalf("payload length estimate is incorrect: expected %v "+
-			"got %v", serializedLength, cua.MaxPayloadLength(0))
+	if serializedLength > cua.MaxPayloadLength(0) {
+		t.Fatalf("payload length estimate is incorrect: %v exceeds %v",
+			serializedLength, cua.MaxPayloadLength(0))
 	}
 
 	// Deserialize the encoded CUA message into a new empty struct.
@@ -43,3 +47,96 @@ func TestChannelUpdateAnnouncementEncodeDecode(t *testing.T) {
 			cua, cua2)
 	}
 }
+
+// TestChannelUpdateAnnouncementExtraData asserts that the trailing TLV stream
+// of a channel update is optional, and that records of unknown types are
+// retained so that the signed data of a relayed update is left intact.
+func TestChannelUpdateAnnouncementExtraData(t *testing.T) {
+	cua := &ChannelUpdateAnnouncement{
+		Signature:                 someSig,
+		ChannelID:                 someChannelID,
+		Timestamp:                 maxUint32,
+		HtlcMinimumMstat:          maxUint32,
+		FeeBaseMstat:              maxUint32,
+		FeeProportionalMillionths: maxUint32,
+	}
+
+	// An update without any trailing records, as sent by nodes unaware of
+	// them, should be decoded without an inbound fee.
+	var b bytes.Buffer
+	if err := cua.Encode(&b, 0); err != nil {
+		t.Fatalf("unable to encode ChannelUpdateAnnouncement: %v", err)
+	}
+	legacyLength := b.Len()
+
+	cua2 := &ChannelUpdateAnnouncement{}
+	if err := cua2.Decode(&b, 0); err != nil {
+		t.Fatalf("unable to decode ChannelUpdateAnnouncement: %v", err)
+	}
+	if !reflect.DeepEqual(cua, cua2) {
+		t.Fatalf("encode/decode error messages don't match %#v vs %#v",
+			cua, cua2)
+	}
+
+	// Records of unknown types on either side of the inbound fee record
+	// should be retained and re-encoded in their original order.
+	lowRecord := []byte{0x00, 0x01, 0x00, 0x02, 0xaa, 0xbb}
+	highRecord := []byte{0xff, 0xff, 0x00, 0x01, 0xcc}
+	cua.ExtraRecords = append(append([]byte{}, lowRecord...), highRecord...)
+	cua.InboundFee = &InboundFee{BaseMsat: 1, FeeRateMillionths: -1}
+
+	b.Reset()
+	if err := cua.Encode(&b, 0); err != nil {
+		t.Fatalf("unable to encode ChannelUpdateAnnouncement: %v", err)
+	}
+	encoded := append([]byte{}, b.Bytes()...)
+
+	inboundRecord := []byte{
+		0xd9, 0x03, 0x00, 0x08,
+		0x00, 0x00, 0x00, 0x01,
+		0xff, 0xff, 0xff, 0xff,
+	}
+	var expectedExtra []byte
+	expectedExtra = append(expectedExtra, lowRecord...)
+	expectedExtra = append(expectedExtra, inboundRecord...)
+	expectedExtra = append(expectedExtra, highRecord...)
+	if !bytes.Equal(encoded[legacyLength:], expectedExtra) {
+		t.Fatalf("unexpected trailing records: expected %x, got %x",
+			expectedExtra, encoded[legacyLength:])
+	}
+
+	cua2 = &ChannelUpdateAnnouncement{}
+	if err := cua2.Decode(bytes.NewReader(encoded), 0); err != nil {
+		t.Fatalf("unable to decode ChannelUpdateAnnouncement: %v", err)
+	}
+	if !reflect.DeepEqual(cua, cua2) {
+		t.Fatalf("encode/decode error messages don't match %#v vs %#v",
+			cua, cua2)
+	}
+
+	// The signed data must cover the trailing records as well.
+	signed, err := cua2.DataToSign()
+	if err != nil {
+		t.Fatalf("unable to serialize signed data: %v", err)
+	}
+	if !bytes.HasSuffix(signed, expectedExtra) {
+		t.Fatalf("signed data doesn't cover the trailing records")
+	}
+
+	// Records out of order, or an inbound fee record of the wrong length,
+	// should be rejected.
+	invalid := [][]byte{
+		append(append([]byte{}, highRecord...), lowRecord...),
+		{0xd9, 0x03, 0x00, 0x04, 0x00, 0x00, 0x00, 0x01},
+		{0x00, 0x01, 0x00, 0x05, 0xaa},
+	}
+	for i, extra := range invalid {
+		payload := append(append([]byte{}, encoded[:legacyLength]...),
+			extra...)
+		err := cua2.Decode(bytes.NewReader(payload), 0)
+		if err == nil {
+			t.Fatalf("#%v: expected invalid trailing records to be "+
+				"rejected", i)
+		}
+	}
+}
@@ -33,14 +33,27 @@ type CloseRequest struct {
 	// timely channel closure.
 	// TODO(roasbeef): if initiator always pays fees, then no longer needed.
 	Fee btcutil.Amount
+
+	// DeliveryPkScript is the script the requester's settled funds are to
+	// be paid to, or nil if omitted. It's encoded as an optional trailing
+	// field, only included if both parties signaled support for upfront
+	// shutdown scripts, so that the message can still be decoded by nodes
+	// unaware of it. As the delivery scripts of both parties are committed
+	// to during the funding workflow, the recipient MUST reject the
+	// request if this script differs from the one the requester committed
+	// to.
+	DeliveryPkScript PkScript
 }
 
 // NewCloseRequest creates a new CloseRequest.
-func NewCloseRequest(cp *wire.OutPoint, sig *btcec.Signature) *CloseRequest {
+func NewCloseRequest(cp *wire.OutPoint, sig *btcec.Signature,
+	deliveryScript PkScript) *CloseRequest {
+
 	// TODO(roasbeef): update once fees aren't hardcoded
 	return &CloseRequest{
 		ChannelPoint:      cp,
 		RequesterCloseSig: sig,
+		DeliveryPkScript:  deliveryScript,
 	}
 }
 
@@ -57,6 +70,7 @@ func (c *CloseRequest) Decode(r io.Reader, pver uint32) error {
 	// RequesterCloseSig (73)
 	// 	First byte length then sig
 	// Fee (8)
+	// DeliveryPkScript (optional)
 	err := readElements(r,
 		&c.ChannelPoint,
 		&c.RequesterCloseSig,
@@ -65,6 +79,16 @@ func (c *CloseRequest) Decode(r io.Reader, pver uint32) error {
 		return err
 	}
 
+	// If the requester omitted its delivery script, then there's nothing
+	// left to read.
+	switch err := readElement(r, &c.DeliveryPkScript); err {
+	case nil:
+	case io.EOF:
+		c.DeliveryPkScript = nil
+	default:
+		return err
+	}
+
 	return nil
 }
 
@@ -76,6 +100,7 @@ func (c *CloseRequest) Encode(w io.Writer, pver uint32) error {
 	// ChannelID
 	// RequesterCloseSig
 	// Fee
+	// DeliveryPkScript (optional)
 	err := writeElements(w,
 		c.ChannelPoint,
 		c.RequesterCloseSig,
@@ -84,7 +109,11 @@ func (c *CloseRequest) Encode(w io.Writer, pver uint32) error {
 		return err
 	}
 
-	return nil
+	if c.DeliveryPkScript == nil {
+		return nil
+	}
+
+	return writeElement(w, c.DeliveryPkScript)
 }
 
 // Command returns the integer uniquely identifying this message type on the
@@ -100,8 +129,8 @@ func (c *CloseRequest) Command() uint32 {
 //
 // This is part of the lnwire.Message interface.
 func (c *CloseRequest) MaxPayloadLength(pver uint32) uint32 {
-	// 36 + 73 + 8
-	return 117
+	// 36 + 73 + 8 + 26
+	return 143
 }
 
 // Validate performs any necessary sanity checks to ensure all fields present
@@ -131,5 +160,6 @@ func (c *CloseRequest) String() string {
 		fmt.Sprintf("ChannelPoint:\t\t%v\n", c.ChannelPoint) +
 		fmt.Sprintf("CloseSig\t\t%x\n", serializedSig) +
 		fmt.Sprintf("Fee:\t\t\t%d\n", c.Fee) +
+		fmt.Sprintf("DeliveryPkScript:\t%x\n", c.DeliveryPkScript) +
 		fmt.Sprintf("--- End CloseRequest ---\n")
 }
@@ -13,6 +13,7 @@ func TestCloseRequestEncodeDecode(t *testing.T) {
 		ChannelPoint:      outpoint1,
 		RequesterCloseSig: commitSig,
 		Fee:               btcutil.Amount(10000),
+		DeliveryPkScript:  deliveryPkScript,
 	}
 
 	// Next encode the CR message into an empty bytes buffer.
@@ -33,3 +34,41 @@ func TestCloseRequestEncodeDecode(t *testing.T) {
 			cr, cr2)
 	}
 }
+
+// TestCloseRequestOptionalDeliveryScript asserts that the delivery script of
+// a CloseRequest is omitted from the wire if unset, so that requests can be
+// exchanged with nodes unaware of it.
+func TestCloseRequestOptionalDeliveryScript(t *testing.T) {
+	cr := &CloseRequest{
+		ChannelPoint:      outpoint1,
+		RequesterCloseSig: commitSig,
+		Fee:               btcutil.Amount(10000),
+	}
+
+	var b bytes.Buffer
+	if err := cr.Encode(&b, 0); err != nil {
+		t.Fatalf("unable to encode CloseRequest: %v", err)
+	}
+	withoutScript := b.Len()
+
+	cr2 := &CloseRequest{}
+	if err := cr2.Decode(&b, 0); err != nil {
+		t.Fatalf("unable to decode CloseRequest: %v", err)
+	}
+	if !reflect.DeepEqual(cr, cr2) {
+		t.Fatalf("encode/decode error messages don't match %#v vs %#v",
+			cr, cr2)
+	}
+
+	// Including the delivery script should only append it to the
+	// encoding.
+	cr.DeliveryPkScript = deliveryPkScript
+	b.Reset()
+	if err := cr.Encode(&b, 0); err != nil {
+		t.Fatalf("unable to encode CloseRequest: %v", err)
+	}
+	if b.Len() != withoutScript+1+len(deliveryPkScript) {
+		t.Fatalf("expected delivery script to be appended, got %v "+
+			"bytes", b.Len())
+	}
+}
@@ -0,0 +1,102 @@
+package lnwire
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+// CustomTypeStart is the start of the custom type range for peer messages as
+// defined in BOLT 01. Message types at or above this value are never
+// interpreted by the daemon itself, and are instead handed off to any
+// external applications that have subscribed to them.
+const CustomTypeStart = uint32(32768)
+
+// Custom represents an application-defined wire message. The payload of the
+// message is opaque to the daemon.
+type Custom struct {
+	// Type is the message type which identifies the message on the wire.
+	Type uint32
+
+	// Data is the raw payload of the message.
+	Data []byte
+}
+
+// NewCustom instantiates a new custom message, returning an error if the
+// message type falls outside of the custom type range.
+func NewCustom(msgType uint32, data []byte) (*Custom, error) {
+	if msgType < CustomTypeStart {
+		return nil, fmt.Errorf("msg type: %d not in custom range: %v+",
+			msgType, CustomTypeStart)
+	}
+
+	return &Custom{
+		Type: msgType,
+		Data: data,
+	}, nil
+}
+
+// A compile time check to ensure Custom implements the lnwire.Message
+// interface.
+var _ Message = (*Custom)(nil)
+
+// Decode deserializes a serialized Custom message stored in the passed
+// io.Reader observing the specified protocol version. The entire remainder of
+// the reader is treated as the message's payload.
+//
+// This is part of the lnwire.Message interface.
+func (c *Custom) Decode(r io.Reader, pver uint32) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	c.Data = data
+
+	return nil
+}
+
+// Encode serializes the target Custom message into the passed io.Writer
+// observing the protocol version specified.
+//
+// This is part of the lnwire.Message interface.
+func (c *Custom) Encode(w io.Writer, pver uint32) error {
+	_, err := w.Write(c.Data)
+	return err
+}
+
+// Command returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the lnwire.Message interface.
+func (c *Custom) Command() uint32 {
+	return c.Type
+}
+
+// MaxPayloadLength returns the maximum allowed payload size for a Custom
+// message observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (c *Custom) MaxPayloadLength(uint32) uint32 {
+	return MaxMessagePayload
+}
+
+// Validate performs any necessary sanity checks to ensure all fields present
+// on the Custom message are valid.
+//
+// This is part of the lnwire.Message interface.
+func (c *Custom) Validate() error {
+	if c.Type < CustomTypeStart {
+		return fmt.Errorf("msg type: %d not in custom range: %v+",
+			c.Type, CustomTypeStart)
+	}
+
+	return nil
+}
+
+// String returns the string representation of the target Custom message.
+//
+// This is part of the lnwire.Message interface.
+func (c *Custom) String() string {
+	return fmt.Sprintf("Custom(type=%v, len=%v)", c.Type, len(c.Data))
+}
@@ -0,0 +1,40 @@
+package lnwire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+func TestCustomEncodeDecode(t *testing.T) {
+	custom, err := NewCustom(CustomTypeStart+10, []byte{1, 2, 3, 4})
+	if err != nil {
+		t.Fatalf("unable to create custom message: %v", err)
+	}
+
+	// Write the message out including its header, so that the decoded
+	// message recovers its type from the wire.
+	var b bytes.Buffer
+	if _, err := WriteMessage(&b, custom, 0, wire.SimNet); err != nil {
+		t.Fatalf("unable to write custom message: %v", err)
+	}
+
+	_, msg, _, err := ReadMessage(&b, 0, wire.SimNet)
+	if err != nil {
+		t.Fatalf("unable to read custom message: %v", err)
+	}
+
+	// Assert equality of the two instances.
+	if !reflect.DeepEqual(custom, msg) {
+		t.Fatalf("encode/decode custom messages don't match %#v vs %#v",
+			custom, msg)
+	}
+}
+
+func TestCustomTypeRange(t *testing.T) {
+	if _, err := NewCustom(CustomTypeStart-1, nil); err == nil {
+		t.Fatalf("expected message type below custom range to fail")
+	}
+}
@@ -0,0 +1,107 @@
+package lnwire
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// DynAck is sent in response to a DynPropose to accept the proposed upgrade
+// of the commitment type of a channel. All commitments created after the
+// DynAck has been sent are in the new format.
+type DynAck struct {
+	// ChannelPoint identifies the channel whose commitment type is being
+	// upgraded.
+	ChannelPoint *wire.OutPoint
+
+	// PaymentKey is the static key the commitment transactions of the
+	// remote peer are to pay the balance of the sender to.
+	PaymentKey *btcec.PublicKey
+}
+
+// A compile time check to ensure DynAck implements the lnwire.Message
+// interface.
+var _ Message = (*DynAck)(nil)
+
+// Decode deserializes a serialized DynAck message stored in the passed
+// io.Reader observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (d *DynAck) Decode(r io.Reader, pver uint32) error {
+	// ChannelPoint (36)
+	// PaymentKey (33)
+	err := readElements(r,
+		&d.ChannelPoint,
+		&d.PaymentKey)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Encode serializes the target DynAck into the passed io.Writer observing the
+// protocol version specified.
+//
+// This is part of the lnwire.Message interface.
+func (d *DynAck) Encode(w io.Writer, pver uint32) error {
+	// ChannelPoint (36)
+	// PaymentKey (33)
+	err := writeElements(w,
+		d.ChannelPoint,
+		d.PaymentKey)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Command returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the lnwire.Message interface.
+func (d *DynAck) Command() uint32 {
+	return CmdDynAck
+}
+
+// MaxPayloadLength returns the maximum allowed payload size for a DynAck
+// message observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (d *DynAck) MaxPayloadLength(uint32) uint32 {
+	// 36 + 33
+	return 69
+}
+
+// Validate performs any necessary sanity checks to ensure all fields present
+// on the DynAck are valid.
+//
+// This is part of the lnwire.Message interface.
+func (d *DynAck) Validate() error {
+	if d.ChannelPoint == nil {
+		return fmt.Errorf("ack must reference a channel point")
+	}
+	if d.PaymentKey == nil {
+		return fmt.Errorf("ack must include a payment key")
+	}
+
+	return nil
+}
+
+// String returns the string representation of the target DynAck.
+//
+// This is part of the lnwire.Message interface.
+func (d *DynAck) String() string {
+	var serializedKey []byte
+	if d.PaymentKey != nil {
+		serializedKey = d.PaymentKey.SerializeCompressed()
+	}
+
+	return fmt.Sprintf("\n--- Begin DynAck ---\n") +
+		fmt.Sprintf("ChannelPoint:\t\t%v\n", d.ChannelPoint) +
+		fmt.Sprintf("PaymentKey:\t\t%x\n", serializedKey) +
+		fmt.Sprintf("--- End DynAck ---\n")
+}
@@ -0,0 +1,32 @@
+package lnwire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestDynAckEncodeDecode(t *testing.T) {
+	msg := &DynAck{
+		ChannelPoint: outpoint1,
+		PaymentKey:   pubKey,
+	}
+
+	// Next encode the DynAck message into an empty bytes buffer.
+	var b bytes.Buffer
+	if err := msg.Encode(&b, 0); err != nil {
+		t.Fatalf("unable to encode DynAck: %v", err)
+	}
+
+	// Deserialize the encoded DynAck message into a new empty struct.
+	msg2 := &DynAck{}
+	if err := msg2.Decode(&b, 0); err != nil {
+		t.Fatalf("unable to decode DynAck: %v", err)
+	}
+
+	// Assert equality of the two instances.
+	if !reflect.DeepEqual(msg, msg2) {
+		t.Fatalf("encode/decode error messages don't match %#v vs %#v",
+			msg, msg2)
+	}
+}
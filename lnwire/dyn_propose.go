@@ -0,0 +1,131 @@
+package lnwire
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// DynPropose is sent by the initiator of the quiescence of a channel to
+// propose an update of the channel's commitment parameters, upgrading the
+// channel to a new commitment type. The remote peer either accepts the
+// proposal with a DynAck, after which all commitments from the activation
+// height onwards are created in the new format, or rejects it with a
+// DynReject.
+type DynPropose struct {
+	// ChannelPoint identifies the channel whose commitment type is to be
+	// upgraded.
+	ChannelPoint *wire.OutPoint
+
+	// CommitType is the commitment type the channel is to be upgraded
+	// to.
+	CommitType uint8
+
+	// ActivationHeight is the commitment height from which the new
+	// commitment type applies, on both commitment chains. It must be the
+	// height of the next commitment of the channel, as seen by both
+	// sides.
+	ActivationHeight uint64
+
+	// PaymentKey is the static key the commitment transactions of the
+	// remote peer are to pay the balance of the sender to, as required by
+	// the static remote key commitment type.
+	PaymentKey *btcec.PublicKey
+}
+
+// A compile time check to ensure DynPropose implements the lnwire.Message
+// interface.
+var _ Message = (*DynPropose)(nil)
+
+// Decode deserializes a serialized DynPropose message stored in the passed
+// io.Reader observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (d *DynPropose) Decode(r io.Reader, pver uint32) error {
+	// ChannelPoint (36)
+	// CommitType (1)
+	// ActivationHeight (8)
+	// PaymentKey (33)
+	err := readElements(r,
+		&d.ChannelPoint,
+		&d.CommitType,
+		&d.ActivationHeight,
+		&d.PaymentKey)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Encode serializes the target DynPropose into the passed io.Writer observing
+// the protocol version specified.
+//
+// This is part of the lnwire.Message interface.
+func (d *DynPropose) Encode(w io.Writer, pver uint32) error {
+	// ChannelPoint (36)
+	// CommitType (1)
+	// ActivationHeight (8)
+	// PaymentKey (33)
+	err := writeElements(w,
+		d.ChannelPoint,
+		d.CommitType,
+		d.ActivationHeight,
+		d.PaymentKey)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Command returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the lnwire.Message interface.
+func (d *DynPropose) Command() uint32 {
+	return CmdDynPropose
+}
+
+// MaxPayloadLength returns the maximum allowed payload size for a DynPropose
+// message observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (d *DynPropose) MaxPayloadLength(uint32) uint32 {
+	// 36 + 1 + 8 + 33
+	return 78
+}
+
+// Validate performs any necessary sanity checks to ensure all fields present
+// on the DynPropose are valid.
+//
+// This is part of the lnwire.Message interface.
+func (d *DynPropose) Validate() error {
+	if d.ChannelPoint == nil {
+		return fmt.Errorf("proposal must reference a channel point")
+	}
+	if d.PaymentKey == nil {
+		return fmt.Errorf("proposal must include a payment key")
+	}
+
+	return nil
+}
+
+// String returns the string representation of the target DynPropose.
+//
+// This is part of the lnwire.Message interface.
+func (d *DynPropose) String() string {
+	var serializedKey []byte
+	if d.PaymentKey != nil {
+		serializedKey = d.PaymentKey.SerializeCompressed()
+	}
+
+	return fmt.Sprintf("\n--- Begin DynPropose ---\n") +
+		fmt.Sprintf("ChannelPoint:\t\t%v\n", d.ChannelPoint) +
+		fmt.Sprintf("CommitType:\t\t%d\n", d.CommitType) +
+		fmt.Sprintf("ActivationHeight:\t%d\n", d.ActivationHeight) +
+		fmt.Sprintf("PaymentKey:\t\t%x\n", serializedKey) +
+		fmt.Sprintf("--- End DynPropose ---\n")
+}
@@ -0,0 +1,34 @@
+package lnwire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestDynProposeEncodeDecode(t *testing.T) {
+	msg := &DynPropose{
+		ChannelPoint:     outpoint1,
+		CommitType:       1,
+		ActivationHeight: 42,
+		PaymentKey:       pubKey,
+	}
+
+	// Next encode the DynPropose message into an empty bytes buffer.
+	var b bytes.Buffer
+	if err := msg.Encode(&b, 0); err != nil {
+		t.Fatalf("unable to encode DynPropose: %v", err)
+	}
+
+	// Deserialize the encoded DynPropose message into a new empty struct.
+	msg2 := &DynPropose{}
+	if err := msg2.Decode(&b, 0); err != nil {
+		t.Fatalf("unable to decode DynPropose: %v", err)
+	}
+
+	// Assert equality of the two instances.
+	if !reflect.DeepEqual(msg, msg2) {
+		t.Fatalf("encode/decode error messages don't match %#v vs %#v",
+			msg, msg2)
+	}
+}
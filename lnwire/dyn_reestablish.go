@@ -0,0 +1,99 @@
+package lnwire
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+// DynReestablish is sent by both sides of a channel once its link is
+// started, whenever quiescence has been negotiated. It carries the
+// commitment type the sender creates new commitments in, so that an upgrade
+// which only one side recorded, as the DynAck accepting it was lost, can be
+// abandoned by that side before any commitment is signed in the new format.
+type DynReestablish struct {
+	// ChannelPoint identifies the channel being reestablished.
+	ChannelPoint *wire.OutPoint
+
+	// CommitType is the commitment type the sender creates new
+	// commitments in, which is the type of any upgrade it has recorded.
+	CommitType uint8
+}
+
+// A compile time check to ensure DynReestablish implements the
+// lnwire.Message interface.
+var _ Message = (*DynReestablish)(nil)
+
+// Decode deserializes a serialized DynReestablish message stored in the
+// passed io.Reader observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (d *DynReestablish) Decode(r io.Reader, pver uint32) error {
+	// ChannelPoint (36)
+	// CommitType (1)
+	err := readElements(r,
+		&d.ChannelPoint,
+		&d.CommitType)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Encode serializes the target DynReestablish into the passed io.Writer
+// observing the protocol version specified.
+//
+// This is part of the lnwire.Message interface.
+func (d *DynReestablish) Encode(w io.Writer, pver uint32) error {
+	// ChannelPoint (36)
+	// CommitType (1)
+	err := writeElements(w,
+		d.ChannelPoint,
+		d.CommitType)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Command returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the lnwire.Message interface.
+func (d *DynReestablish) Command() uint32 {
+	return CmdDynReestablish
+}
+
+// MaxPayloadLength returns the maximum allowed payload size for a
+// DynReestablish message observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (d *DynReestablish) MaxPayloadLength(uint32) uint32 {
+	// 36 + 1
+	return 37
+}
+
+// Validate performs any necessary sanity checks to ensure all fields present
+// on the DynReestablish are valid.
+//
+// This is part of the lnwire.Message interface.
+func (d *DynReestablish) Validate() error {
+	if d.ChannelPoint == nil {
+		return fmt.Errorf("reestablish must reference a channel point")
+	}
+
+	return nil
+}
+
+// String returns the string representation of the target DynReestablish.
+//
+// This is part of the lnwire.Message interface.
+func (d *DynReestablish) String() string {
+	return fmt.Sprintf("\n--- Begin DynReestablish ---\n") +
+		fmt.Sprintf("ChannelPoint:\t\t%v\n", d.ChannelPoint) +
+		fmt.Sprintf("CommitType:\t\t%d\n", d.CommitType) +
+		fmt.Sprintf("--- End DynReestablish ---\n")
+}
@@ -0,0 +1,32 @@
+package lnwire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestDynReestablishEncodeDecode(t *testing.T) {
+	msg := &DynReestablish{
+		ChannelPoint: outpoint1,
+		CommitType:   1,
+	}
+
+	// Next encode the DynReestablish message into an empty bytes buffer.
+	var b bytes.Buffer
+	if err := msg.Encode(&b, 0); err != nil {
+		t.Fatalf("unable to encode DynReestablish: %v", err)
+	}
+
+	// Deserialize the encoded DynReestablish message into a new empty struct.
+	msg2 := &DynReestablish{}
+	if err := msg2.Decode(&b, 0); err != nil {
+		t.Fatalf("unable to decode DynReestablish: %v", err)
+	}
+
+	// Assert equality of the two instances.
+	if !reflect.DeepEqual(msg, msg2) {
+		t.Fatalf("encode/decode error messages don't match %#v vs %#v",
+			msg, msg2)
+	}
+}
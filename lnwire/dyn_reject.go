@@ -0,0 +1,100 @@
+package lnwire
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+// DynReject is sent in response to a DynPropose to reject the proposed
+// upgrade of the commitment type of a channel, which remains in its current
+// format.
+type DynReject struct {
+	// ChannelPoint identifies the channel whose upgrade was rejected.
+	ChannelPoint *wire.OutPoint
+
+	// Reason is a human readable description of why the upgrade was
+	// rejected.
+	Reason string
+}
+
+// A compile time check to ensure DynReject implements the lnwire.Message
+// interface.
+var _ Message = (*DynReject)(nil)
+
+// Decode deserializes a serialized DynReject message stored in the passed
+// io.Reader observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (d *DynReject) Decode(r io.Reader, pver uint32) error {
+	// ChannelPoint (36)
+	// Reason (max 256)
+	err := readElements(r,
+		&d.ChannelPoint,
+		&d.Reason)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Encode serializes the target DynReject into the passed io.Writer observing
+// the protocol version specified.
+//
+// This is part of the lnwire.Message interface.
+func (d *DynReject) Encode(w io.Writer, pver uint32) error {
+	// ChannelPoint (36)
+	// Reason (max 256)
+	err := writeElements(w,
+		d.ChannelPoint,
+		d.Reason)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Command returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the lnwire.Message interface.
+func (d *DynReject) Command() uint32 {
+	return CmdDynReject
+}
+
+// MaxPayloadLength returns the maximum allowed payload size for a DynReject
+// message observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (d *DynReject) MaxPayloadLength(uint32) uint32 {
+	// 36 + 9 + 256
+	return 301
+}
+
+// Validate performs any necessary sanity checks to ensure all fields present
+// on the DynReject are valid.
+//
+// This is part of the lnwire.Message interface.
+func (d *DynReject) Validate() error {
+	if d.ChannelPoint == nil {
+		return fmt.Errorf("reject must reference a channel point")
+	}
+	if len(d.Reason) > 256 {
+		return fmt.Errorf("reject reason too long")
+	}
+
+	return nil
+}
+
+// String returns the string representation of the target DynReject.
+//
+// This is part of the lnwire.Message interface.
+func (d *DynReject) String() string {
+	return fmt.Sprintf("\n--- Begin DynReject ---\n") +
+		fmt.Sprintf("ChannelPoint:\t\t%v\n", d.ChannelPoint) +
+		fmt.Sprintf("Reason:\t\t%v\n", d.Reason) +
+		fmt.Sprintf("--- End DynReject ---\n")
+}
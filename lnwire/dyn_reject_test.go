@@ -0,0 +1,32 @@
+package lnwire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestDynRejectEncodeDecode(t *testing.T) {
+	msg := &DynReject{
+		ChannelPoint: outpoint1,
+		Reason:       "commitment type not supported",
+	}
+
+	// Next encode the DynReject message into an empty bytes buffer.
+	var b bytes.Buffer
+	if err := msg.Encode(&b, 0); err != nil {
+		t.Fatalf("unable to encode DynReject: %v", err)
+	}
+
+	// Deserialize the encoded DynReject message into a new empty struct.
+	msg2 := &DynReject{}
+	if err := msg2.Decode(&b, 0); err != nil {
+		t.Fatalf("unable to decode DynReject: %v", err)
+	}
+
+	// Assert equality of the two instances.
+	if !reflect.DeepEqual(msg, msg2) {
+		t.Fatalf("encode/decode error messages don't match %#v vs %#v",
+			msg, msg2)
+	}
+}
@@ -24,6 +24,39 @@ const (
 	// ErrorMaxPendingChannels is returned by remote peer when the number
 	// of active pending channels exceeds their maximum policy limit.
 	ErrorMaxPendingChannels ErrorCode = 1
+
+	// ErrorChanTooLarge is returned by remote peer when the proposed
+	// channel is larger than they're willing to accept.
+	ErrorChanTooLarge ErrorCode = 2
+
+	// ErrorChanTooSmall is returned by remote peer when the proposed
+	// channel is smaller than they're willing to accept.
+	ErrorChanTooSmall ErrorCode = 3
+
+	// ErrorMaxChannelsPerPeer is returned by remote peer when accepting
+	// the proposed channel would exceed the maximum number of channels
+	// they permit with a single peer.
+	ErrorMaxChannelsPerPeer ErrorCode = 4
+
+	// ErrorUpfrontShutdownMismatch is returned by remote peer when a
+	// cooperative close would pay our funds to a script other than the
+	// one we committed to during the funding workflow.
+	ErrorUpfrontShutdownMismatch ErrorCode = 5
+
+	// ErrorChanStateLost is sent by a peer which restored the channel
+	// from a static backup, having lost its state. As only we are able to
+	// close the channel, the peer requests that we force close it so its
+	// funds can be recovered.
+	ErrorChanStateLost ErrorCode = 6
+
+	// ErrorUnsupportedChanType is returned by remote peer when the
+	// proposed channel is of a type it doesn't know, or doesn't support
+	// with us.
+	ErrorUnsupportedChanType ErrorCode = 7
+
+	// ErrorChanRejected is returned by remote peer when it refuses to
+	// open any channel with us, such as when we're banned.
+	ErrorChanRejected ErrorCode = 8
 )
 
 // ErrorGeneric represents a generic error bound to an exact channel. The
@@ -0,0 +1,302 @@
+package lnwire
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+)
+
+// FeatureBit represents a feature that can be enabled in either a local or
+// global feature vector at a specific bit position. Feature bits follow the
+// "it's OK to be odd" rule, where features at even bit positions must be known
+// to a node receiving them from a peer, while odd bits do not.
+type FeatureBit uint16
+
+const (
+	// InitialRoutingSyncOptional is an optional feature bit that signals
+	// the remote peer that we'd like them to send us a full dump of their
+	// current view of the channel graph upon connection.
+	InitialRoutingSyncOptional FeatureBit = 3
+
+	// UpfrontShutdownScriptRequired is a required feature bit that signals
+	// that the node requires the delivery scripts committed to during the
+	// funding workflow to be enforced upon a cooperative close.
+	UpfrontShutdownScriptRequired FeatureBit = 4
+
+	// UpfrontShutdownScriptOptional is an optional feature bit that
+	// signals that the node commits to its delivery script during the
+	// funding workflow, and enforces the remote party's.
+	UpfrontShutdownScriptOptional FeatureBit = 5
+
+	// StaticRemoteKeyRequired is a required feature bit that signals that
+	// the node requires the remote party's output within the commitment
+	// transaction to be a non-tweaked key.
+	StaticRemoteKeyRequired FeatureBit = 12
+
+	// StaticRemoteKeyOptional is an optional feature bit that signals
+	// that the node understands, but doesn't require, a non-tweaked remote
+	// key within the commitment transaction.
+	StaticRemoteKeyOptional FeatureBit = 13
+
+	// PaymentAddrRequired is a required feature bit that signals that a
+	// node requires payment addresses, which are used to mitigate probing
+	// attacks on the receiver of a payment.
+	PaymentAddrRequired FeatureBit = 14
+
+	// PaymentAddrOptional is an optional feature bit that signals that a
+	// node supports payment addresses.
+	PaymentAddrOptional FeatureBit = 15
+
+	// MPPRequired is a required feature bit that signals that the
+	// receiver of a payment requires settlement of an invoice with more
+	// than one HTLC.
+	MPPRequired FeatureBit = 16
+
+	// MPPOptional is an optional feature bit that signals that the
+	// receiver of a payment supports settlement of an invoice with more
+	// than one HTLC.
+	MPPOptional FeatureBit = 17
+
+	// WumboChannelsRequired is a required feature bit that signals that
+	// the node requires channels larger than 2^24 satoshis to be
+	// supported.
+	WumboChannelsRequired FeatureBit = 18
+
+	// WumboChannelsOptional is an optional feature bit that signals that
+	// the node is willing to open and accept channels larger than 2^24
+	// satoshis.
+	WumboChannelsOptional FeatureBit = 19
+
+	// QuiescenceRequired is a required feature bit that signals that the
+	// node requires the quiescence (stfu) handshake to be supported.
+	QuiescenceRequired FeatureBit = 34
+
+	// QuiescenceOptional is an optional feature bit that signals that the
+	// node understands the Stfu message, and is able to quiesce a channel
+	// through the quiescence handshake.
+	QuiescenceOptional FeatureBit = 35
+
+	// maxAllowedSize is a maximum allowed size of feature vector.
+	//
+	// NOTE: Within the protocol, the maximum allowed message size is 65535
+	// bytes. Adding the overhead from the length prefix (2 bytes) we get
+	// 65533 bytes for the feature vector itself.
+	maxAllowedSize = 65533
+)
+
+// Features is a mapping of known feature bits to a descriptive name. All known
+// feature bits must be assigned a name in this mapping, and feature bit pairs
+// must be assigned together for correct behavior.
+var Features = map[FeatureBit]string{
+	InitialRoutingSyncOptional:    "initial-routing-sync",
+	UpfrontShutdownScriptRequired: "upfront-shutdown-script",
+	UpfrontShutdownScriptOptional: "upfront-shutdown-script",
+	StaticRemoteKeyRequired:       "static-remote-key",
+	StaticRemoteKeyOptional:       "static-remote-key",
+	PaymentAddrRequired:           "payment-addr",
+	PaymentAddrOptional:           "payment-addr",
+	MPPRequired:                   "multi-path-payments",
+	MPPOptional:                   "multi-path-payments",
+	WumboChannelsRequired:         "wumbo-channels",
+	WumboChannelsOptional:         "wumbo-channels",
+	QuiescenceRequired:            "quiescence",
+	QuiescenceOptional:            "quiescence",
+}
+
+// IsRequired returns true if the feature bit is even, and false otherwise.
+func (b FeatureBit) IsRequired() bool {
+	return b&0x01 == 0x00
+}
+
+// String returns a human readable name for the feature bit, including whether
+// it is the required or optional half of the pair.
+func (b FeatureBit) String() string {
+	name, ok := Features[b]
+	if !ok {
+		name = "unknown"
+	}
+
+	if b.IsRequired() {
+		return fmt.Sprintf("%s(%d, required)", name, uint16(b))
+	}
+	return fmt.Sprintf("%s(%d, optional)", name, uint16(b))
+}
+
+// Pair returns the other feature bit of the required/optional pair the
+// feature bit belongs to.
+func (b FeatureBit) Pair() FeatureBit {
+	return b ^ 0x01
+}
+
+// FeatureVector represents a set of enabled features. The set stores
+// information on enabled flags and metadata about the feature names. A
+// feature vector is serializable to a compact byte representation that is
+// included in various Lightning network messages.
+type FeatureVector struct {
+	bits map[FeatureBit]struct{}
+}
+
+// NewFeatureVector constructs a new FeatureVector with the given feature bits
+// set.
+func NewFeatureVector(bits ...FeatureBit) *FeatureVector {
+	fv := &FeatureVector{
+		bits: make(map[FeatureBit]struct{}),
+	}
+	for _, bit := range bits {
+		fv.Set(bit)
+	}
+
+	return fv
+}
+
+// Set flips the target feature bit on within the feature vector.
+func (fv *FeatureVector) Set(bit FeatureBit) {
+	fv.bits[bit] = struct{}{}
+}
+
+// Unset flips the target feature bit off within the feature vector.
+func (fv *FeatureVector) Unset(bit FeatureBit) {
+	delete(fv.bits, bit)
+}
+
+// IsSet returns whether a particular feature bit is enabled in the vector.
+func (fv *FeatureVector) IsSet(bit FeatureBit) bool {
+	_, ok := fv.bits[bit]
+	return ok
+}
+
+// HasFeature returns whether either the required or optional bit of the
+// feature pair containing the target bit is set.
+func (fv *FeatureVector) HasFeature(bit FeatureBit) bool {
+	return fv.IsSet(bit) || fv.IsSet(bit.Pair())
+}
+
+// RequiresFeature returns true if the required bit of the feature pair
+// containing the target bit is set.
+func (fv *FeatureVector) RequiresFeature(bit FeatureBit) bool {
+	if bit.IsRequired() {
+		return fv.IsSet(bit)
+	}
+	return fv.IsSet(bit.Pair())
+}
+
+// UnknownRequiredFeatures returns a list of feature bits set in the vector
+// that are required, but not present within the set of known features.
+func (fv *FeatureVector) UnknownRequiredFeatures() []FeatureBit {
+	var unknown []FeatureBit
+	for _, bit := range fv.Features() {
+		if _, ok := Features[bit]; !ok && bit.IsRequired() {
+			unknown = append(unknown, bit)
+		}
+	}
+
+	return unknown
+}
+
+// Features returns the set bits of the feature vector in ascending order.
+func (fv *FeatureVector) Features() []FeatureBit {
+	bits := make([]FeatureBit, 0, len(fv.bits))
+	for bit := range fv.bits {
+		bits = append(bits, bit)
+	}
+	sort.Sort(sortableBits(bits))
+
+	return bits
+}
+
+// sortableBits is a helper type which allows a slice of feature bits to be
+// sorted in ascending order.
+type sortableBits []FeatureBit
+
+func (s sortableBits) Len() int           { return len(s) }
+func (s sortableBits) Less(i, j int) bool { return s[i] < s[j] }
+func (s sortableBits) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// Clone returns a copy of the feature vector.
+func (fv *FeatureVector) Clone() *FeatureVector {
+	return NewFeatureVector(fv.Features()...)
+}
+
+// SerializeSize returns the number of bytes needed to represent the feature
+// vector in its compact byte form, excluding the length prefix.
+func (fv *FeatureVector) SerializeSize() int {
+	var maxBit int
+	for bit := range fv.bits {
+		if int(bit)+1 > maxBit {
+			maxBit = int(bit) + 1
+		}
+	}
+
+	return (maxBit + 7) / 8
+}
+
+// Encode writes the feature vector to the passed io.Writer as a big-endian
+// bit field, prefixed by its length in bytes.
+func (fv *FeatureVector) Encode(w io.Writer) error {
+	length := fv.SerializeSize()
+	if length > maxAllowedSize {
+		return fmt.Errorf("feature vector length %d exceeds maximum "+
+			"of %d", length, maxAllowedSize)
+	}
+
+	var l [2]byte
+	binary.BigEndian.PutUint16(l[:], uint16(length))
+	if _, err := w.Write(l[:]); err != nil {
+		return err
+	}
+
+	data := make([]byte, length)
+	for bit := range fv.bits {
+		byteIndex := length - 1 - int(bit/8)
+		data[byteIndex] |= 1 << (bit % 8)
+	}
+
+	_, err := w.Write(data)
+	return err
+}
+
+// Decode reads a length-prefixed feature vector from the passed io.Reader,
+// replacing any bits currently set within the vector.
+func (fv *FeatureVector) Decode(r io.Reader) error {
+	var l [2]byte
+	if _, err := io.ReadFull(r, l[:]); err != nil {
+		return err
+	}
+	length := int(binary.BigEndian.Uint16(l[:]))
+	if length > maxAllowedSize {
+		return fmt.Errorf("feature vector length %d exceeds maximum "+
+			"of %d", length, maxAllowedSize)
+	}
+
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return err
+	}
+
+	fv.bits = make(map[FeatureBit]struct{})
+	for i, b := range data {
+		for j := uint(0); j < 8; j++ {
+			if b&(1<<j) == 0 {
+				continue
+			}
+			// Bits beyond the range of a FeatureBit would
+			// otherwise wrap around onto lower bits.
+			index := (length-1-i)*8 + int(j)
+			if index > math.MaxUint16 {
+				return fmt.Errorf("feature bit %d exceeds "+
+					"maximum of %d", index, math.MaxUint16)
+			}
+			fv.bits[FeatureBit(index)] = struct{}{}
+		}
+	}
+
+	return nil
+}
+
+// String returns a human readable summary of the bits set within the feature
+// vector.
+func (fv *FeatureVector) String() string {
+	return fmt.Sprintf("%v", fv.Features())
+}
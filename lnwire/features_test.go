@@ -0,0 +1,83 @@
+package lnwire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+// TestFeatureVectorEncodeDecode asserts that a feature vector survives a
+// round trip through its compact wire representation.
+func TestFeatureVectorEncodeDecode(t *testing.T) {
+	fv := NewFeatureVector(
+		InitialRoutingSyncOptional,
+		PaymentAddrOptional,
+		MPPOptional,
+		FeatureBit(255),
+	)
+
+	var b bytes.Buffer
+	if err := fv.Encode(&b); err != nil {
+		t.Fatalf("unable to encode feature vector: %v", err)
+	}
+
+	// The highest bit set is 255, so exactly 32 bytes should follow the
+	// two byte length prefix.
+	if b.Len() != 2+32 {
+		t.Fatalf("expected encoded length of %v, got %v", 2+32, b.Len())
+	}
+
+	fv2 := NewFeatureVector()
+	if err := fv2.Decode(&b); err != nil {
+		t.Fatalf("unable to decode feature vector: %v", err)
+	}
+
+	if !reflect.DeepEqual(fv.Features(), fv2.Features()) {
+		t.Fatalf("feature vectors don't match: %v vs %v", fv, fv2)
+	}
+}
+
+// TestFeatureVectorDecodeOverflow asserts that a feature vector setting a bit
+// beyond the range of a FeatureBit is rejected, rather than wrapping around
+// onto a lower bit.
+func TestFeatureVectorDecodeOverflow(t *testing.T) {
+	// A vector of 8193 bytes with its most significant bit set signals
+	// bit 65543, which would otherwise wrap around onto bit 7.
+	const length = 8193
+	var b bytes.Buffer
+	b.Write([]byte{length >> 8, length & 0xff})
+	data := make([]byte, length)
+	data[0] = 0x80
+	b.Write(data)
+
+	fv := NewFeatureVector()
+	if err := fv.Decode(&b); err == nil {
+		t.Fatalf("expected overflowing feature bit to be rejected, "+
+			"got %v", fv)
+	}
+}
+
+// TestFeatureVectorUnknownRequired checks that only even bits that aren't
+// known to us are reported as unknown required features.
+func TestFeatureVectorUnknownRequired(t *testing.T) {
+	fv := NewFeatureVector(
+		PaymentAddrRequired,
+		FeatureBit(100),
+		FeatureBit(101),
+	)
+
+	unknown := fv.UnknownRequiredFeatures()
+	if len(unknown) != 1 || unknown[0] != FeatureBit(100) {
+		t.Fatalf("expected only bit 100 to be unknown, got %v", unknown)
+	}
+
+	if !fv.HasFeature(PaymentAddrOptional) {
+		t.Fatalf("payment addr should be reported as present")
+	}
+	if !fv.RequiresFeature(PaymentAddrOptional) {
+		t.Fatalf("payment addr should be reported as required")
+	}
+	if fv.HasFeature(MPPOptional) {
+		t.Fatalf("mpp shouldn't be reported as present")
+	}
+}
@@ -0,0 +1,56 @@
+package lnwire
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+// FullChannelID is the 32-byte channel ID of a channel, as defined by
+// BOLT #2. Unlike the compact ChannelID, it's known as soon as the funding
+// outpoint of the channel is, and so also identifies unconfirmed and private
+// channels. It's derived by XOR'ing the big-endian output index of the
+// funding outpoint into the last two bytes of the funding txid.
+type FullChannelID [32]byte
+
+// NewFullChanIDFromOutPoint derives the FullChannelID of the channel funded
+// by the passed outpoint.
+func NewFullChanIDFromOutPoint(op *wire.OutPoint) FullChannelID {
+	var cid FullChannelID
+	copy(cid[:], op.Hash[:])
+
+	cid[30] ^= byte(op.Index >> 8)
+	cid[31] ^= byte(op.Index)
+
+	return cid
+}
+
+// NewFullChanIDFromString parses the hex encoding of a FullChannelID, as
+// returned by String.
+func NewFullChanIDFromString(chanID string) (FullChannelID, error) {
+	var cid FullChannelID
+
+	b, err := hex.DecodeString(chanID)
+	if err != nil {
+		return cid, fmt.Errorf("invalid channel ID %q: %v", chanID, err)
+	}
+	if len(b) != len(cid) {
+		return cid, fmt.Errorf("invalid channel ID %q, expected %v "+
+			"bytes", chanID, len(cid))
+	}
+	copy(cid[:], b)
+
+	return cid, nil
+}
+
+// IsChanPoint returns true if the FullChannelID was derived from the passed
+// outpoint.
+func (c FullChannelID) IsChanPoint(op *wire.OutPoint) bool {
+	return NewFullChanIDFromOutPoint(op) == c
+}
+
+// String returns the hex encoding of the FullChannelID.
+func (c FullChannelID) String() string {
+	return hex.EncodeToString(c[:])
+}
@@ -0,0 +1,63 @@
+package lnwire
+
+import (
+	"testing"
+
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+)
+
+func TestFullChannelIDOutPointConversion(t *testing.T) {
+	var txid chainhash.Hash
+	for i := range txid {
+		txid[i] = byte(i)
+	}
+
+	op := wire.OutPoint{Hash: txid, Index: 0x0102}
+	cid := NewFullChanIDFromOutPoint(&op)
+
+	// Only the last two bytes of the txid are altered by the output
+	// index.
+	if cid[30] != txid[30]^0x01 || cid[31] != txid[31]^0x02 {
+		t.Fatalf("output index not encoded within channel ID: %v", cid)
+	}
+	for i := 0; i < 30; i++ {
+		if cid[i] != txid[i] {
+			t.Fatalf("txid altered at byte %v: %v", i, cid)
+		}
+	}
+
+	if !cid.IsChanPoint(&op) {
+		t.Fatalf("channel ID doesn't match its outpoint")
+	}
+	op.Index++
+	if cid.IsChanPoint(&op) {
+		t.Fatalf("channel ID matches a different outpoint")
+	}
+}
+
+func TestFullChannelIDStringEncoding(t *testing.T) {
+	op := wire.OutPoint{Hash: chainhash.Hash{0xaa}, Index: 1}
+	cid := NewFullChanIDFromOutPoint(&op)
+
+	newCid, err := NewFullChanIDFromString(cid.String())
+	if err != nil {
+		t.Fatalf("unable to parse channel ID: %v", err)
+	}
+	if newCid != cid {
+		t.Fatalf("channel ID's don't match: expected %v got %v",
+			cid, newCid)
+	}
+
+	var invalidIDs = []string{
+		"",
+		"zz",
+		cid.String()[2:],
+		cid.String() + "00",
+	}
+	for _, invalidID := range invalidIDs {
+		if _, err := NewFullChanIDFromString(invalidID); err == nil {
+			t.Fatalf("expected %q to be rejected", invalidID)
+		}
+	}
+}
@@ -60,6 +60,13 @@ type HTLCAddRequest struct {
 	// HTLCAddRequest message.
 	// TODO(roasbeef): can be fixed sized now that v1 Sphinx is "done".
 	OnionBlob []byte
+
+	// Endorsed is the experimental endorsement signal, indicating that the
+	// sender vouches for the HTLC to be resolved swiftly. It's encoded as
+	// an optional trailing field, which is omitted if the HTLC isn't
+	// endorsed, so that the message can still be decoded by nodes unaware
+	// of it.
+	Endorsed bool
 }
 
 // NewHTLCAddRequest returns a new empty HTLCAddRequest message.
@@ -82,6 +89,7 @@ func (c *HTLCAddRequest) Decode(r io.Reader, pver uint32) error {
 	// ContractType(1)
 	// RedemptionHashes (numOfHashes * 32 + numOfHashes)
 	// OnionBlog
+	// Endorsed (optional)
 	err := readElements(r,
 		&c.ChannelPoint,
 		&c.Expiry,
@@ -94,6 +102,17 @@ func (c *HTLCAddRequest) Decode(r io.Reader, pver uint32) error {
 		return err
 	}
 
+	// If the sender omitted the endorsement signal, then the HTLC is
+	// unendorsed.
+	var endorsed [1]byte
+	switch _, err := io.ReadFull(r, endorsed[:]); err {
+	case nil:
+		c.Endorsed = endorsed[0] == 1
+	case io.EOF:
+	default:
+		return err
+	}
+
 	return nil
 }
 
@@ -114,6 +133,12 @@ func (c *HTLCAddRequest) Encode(w io.Writer, pver uint32) error {
 		return err
 	}
 
+	if c.Endorsed {
+		if _, err := w.Write([]byte{1}); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -167,5 +192,6 @@ func (c *HTLCAddRequest) String() string {
 		fmt.Sprintf("RedemptionHashes:") +
 		redemptionHashes +
 		fmt.Sprintf("OnionBlob:\t\t\t\t%x\n", c.OnionBlob) +
+		fmt.Sprintf("Endorsed:\t%v\n", c.Endorsed) +
 		fmt.Sprintf("--- End HTLCAddRequest ---\n")
 }
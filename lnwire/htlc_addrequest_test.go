@@ -38,4 +38,19 @@ func TestHTLCAddRequestEncodeDecode(t *testing.T) {
 		t.Fatalf("encode/decode error messages don't match %#v vs %#v",
 			addReq, addReq2)
 	}
+
+	// An endorsed HTLC should carry the endorsement signal across.
+	addReq.Endorsed = true
+	b.Reset()
+	if err := addReq.Encode(&b, 0); err != nil {
+		t.Fatalf("unable to encode HTLCAddRequest: %v", err)
+	}
+	addReq3 := &HTLCAddRequest{}
+	if err := addReq3.Decode(&b, 0); err != nil {
+		t.Fatalf("unable to decode HTLCAddRequest: %v", err)
+	}
+	if !reflect.DeepEqual(addReq, addReq3) {
+		t.Fatalf("encode/decode error messages don't match %#v vs %#v",
+			addReq, addReq3)
+	}
 }
@@ -42,6 +42,16 @@ const (
 	// IncorrectValue indicates that the HTLC ultimately extended to the
 	// destination did not match the value that was expected.
 	IncorrectValue = 5
+
+	// ExpiryTooSoon indicates that the absolute timelock of the HTLC is
+	// too close to the current block height for the HTLC to be safely
+	// forwarded or accepted.
+	ExpiryTooSoon = 6
+
+	// TemporaryChannelFailure indicates that an intermediate node was
+	// temporarily unable to forward the HTLC, such as when too many
+	// HTLC's are already in flight over its channels.
+	TemporaryChannelFailure = 7
 )
 
 // String returns a human-readable version of the CancelReason type.
@@ -67,11 +77,26 @@ func (c CancelReason) String() string {
 	case IncorrectValue:
 		return "IncorrectValue: htlc value was wrong"
 
+	case ExpiryTooSoon:
+		return "ExpiryTooSoon: htlc expiry is too close to current height"
+
+	case TemporaryChannelFailure:
+		return "TemporaryChannelFailure: next hop is temporarily " +
+			"unable to forward the htlc"
+
 	default:
 		return "unknown reason"
 	}
 }
 
+// Error returns a human-readable version of the CancelReason, allowing it to
+// be returned as the error of a cancelled HTLC.
+//
+// This is part of the error interface.
+func (c CancelReason) Error() string {
+	return c.String()
+}
+
 // CancelHTLC is sent by Alice to Bob in order to remove a previously added
 // HTLC. Upon receipt of an CancelHTLC the HTLC should be removed from the next
 // commitment transaction, with the CancelHTLC propagated backwards in the
@@ -0,0 +1,93 @@
+package lnwire
+
+import (
+	"fmt"
+	"io"
+)
+
+// Init is the first message sent by either side of a new connection, revealing
+// the features supported or required by the sending node. Nodes wait for
+// receipt of the other's features to simplify error diagnosis where features
+// are incompatible. Each node MUST wait to receive init before sending any
+// other messages.
+type Init struct {
+	// GlobalFeatures is a feature vector containing the features that are
+	// relevant to the broader network, such as those advertised within
+	// node announcements.
+	GlobalFeatures *FeatureVector
+
+	// LocalFeatures is a feature vector containing the features that only
+	// affect the operation of the direct connection between the two
+	// peers.
+	LocalFeatures *FeatureVector
+}
+
+// NewInitMessage creates a new Init message populated with the passed global
+// and local feature vectors.
+func NewInitMessage(gf, lf *FeatureVector) *Init {
+	return &Init{
+		GlobalFeatures: gf,
+		LocalFeatures:  lf,
+	}
+}
+
+// A compile time check to ensure Init implements the lnwire.Message interface.
+var _ Message = (*Init)(nil)
+
+// Decode deserializes a serialized Init message stored in the passed
+// io.Reader observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (msg *Init) Decode(r io.Reader, pver uint32) error {
+	return readElements(r,
+		&msg.GlobalFeatures,
+		&msg.LocalFeatures,
+	)
+}
+
+// Encode serializes the target Init into the passed io.Writer observing the
+// protocol version specified.
+//
+// This is part of the lnwire.Message interface.
+func (msg *Init) Encode(w io.Writer, pver uint32) error {
+	return writeElements(w,
+		msg.GlobalFeatures,
+		msg.LocalFeatures,
+	)
+}
+
+// Command returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the lnwire.Message interface.
+func (msg *Init) Command() uint32 {
+	return CmdInit
+}
+
+// MaxPayloadLength returns the maximum allowed payload size for an Init
+// message observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (msg *Init) MaxPayloadLength(uint32) uint32 {
+	return 2 + maxAllowedSize + 2 + maxAllowedSize
+}
+
+// Validate performs any necessary sanity checks to ensure all fields present
+// on the Init are valid.
+//
+// This is part of the lnwire.Message interface.
+func (msg *Init) Validate() error {
+	if msg.GlobalFeatures == nil || msg.LocalFeatures == nil {
+		return fmt.Errorf("init message is missing a feature vector")
+	}
+
+	return nil
+}
+
+// String returns the string representation of the target Init.
+//
+// This is part of the lnwire.Message interface.
+func (msg *Init) String() string {
+	return fmt.Sprintf("Init(global=%v, local=%v)", msg.GlobalFeatures,
+		msg.LocalFeatures)
+}
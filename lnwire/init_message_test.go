@@ -0,0 +1,32 @@
+package lnwire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestInitEncodeDecode(t *testing.T) {
+	init1 := NewInitMessage(
+		NewFeatureVector(StaticRemoteKeyOptional),
+		NewFeatureVector(PaymentAddrOptional, MPPOptional),
+	)
+
+	// Next encode the init message into an empty bytes buffer.
+	var b bytes.Buffer
+	if err := init1.Encode(&b, 0); err != nil {
+		t.Fatalf("unable to encode init: %v", err)
+	}
+
+	// Deserialize the encoded init message into a new empty struct.
+	init2 := &Init{}
+	if err := init2.Decode(&b, 0); err != nil {
+		t.Fatalf("unable to decode init: %v", err)
+	}
+
+	// Assert equality of the two instances.
+	if !reflect.DeepEqual(init1, init2) {
+		t.Fatalf("encode/decode init messages don't match %#v vs %#v",
+			init1, init2)
+	}
+}
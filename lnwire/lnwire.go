@@ -346,6 +346,14 @@ func writeElement(w io.Writer, element interface{}) error {
 		if _, err := w.Write(port[:]); err != nil {
 			return err
 		}
+	case *FeatureVector:
+		if e == nil {
+			return fmt.Errorf("cannot write nil feature vector")
+		}
+
+		if err := e.Encode(w); err != nil {
+			return err
+		}
 	case RGB:
 		err := writeElements(w,
 			e.red,
@@ -664,6 +672,13 @@ func readElement(r io.Reader, element interface{}) error {
 			IP:   (net.IP)(ip[:]),
 			Port: int(binary.BigEndian.Uint32(port[:])),
 		}
+	case **FeatureVector:
+		fv := NewFeatureVector()
+		if err := fv.Decode(r); err != nil {
+			return err
+		}
+
+		*e = fv
 	case *RGB:
 		err := readElements(r,
 			&e.red,
@@ -22,6 +22,9 @@ const MaxMessagePayload = 1024 * 1024 * 32 //  32MB
 
 // Commands used in lightning message headers which detail the type of message.
 const (
+	// Command for the initial exchange of supported features.
+	CmdInit = uint32(16)
+
 	// Commands for opening a channel funded by one party (single funder).
 	CmdSingleFundingRequest      = uint32(100)
 	CmdSingleFundingResponse     = uint32(110)
@@ -44,6 +47,14 @@ const (
 	CmdCommitSignature  = uint32(2000)
 	CmdCommitRevocation = uint32(2010)
 
+	// Commands for quiescing an active channel, updating the parameters
+	// of a quiescent one, and reconciling them upon reconnection.
+	CmdStfu           = uint32(2100)
+	CmdDynPropose     = uint32(2110)
+	CmdDynAck         = uint32(2120)
+	CmdDynReject      = uint32(2130)
+	CmdDynReestablish = uint32(2140)
+
 	// Commands for reporting protocol errors.
 	CmdErrorGeneric = uint32(4000)
 
@@ -75,6 +86,8 @@ func makeEmptyMessage(command uint32) (Message, error) {
 	var msg Message
 
 	switch command {
+	case CmdInit:
+		msg = &Init{}
 	case CmdSingleFundingRequest:
 		msg = &SingleFundingRequest{}
 	case CmdSingleFundingResponse:
@@ -101,6 +114,16 @@ func makeEmptyMessage(command uint32) (Message, error) {
 		msg = &CommitSignature{}
 	case CmdCommitRevocation:
 		msg = &CommitRevocation{}
+	case CmdStfu:
+		msg = &Stfu{}
+	case CmdDynPropose:
+		msg = &DynPropose{}
+	case CmdDynAck:
+		msg = &DynAck{}
+	case CmdDynReject:
+		msg = &DynReject{}
+	case CmdDynReestablish:
+		msg = &DynReestablish{}
 	case CmdErrorGeneric:
 		msg = &ErrorGeneric{}
 	case CmdChannelAnnoucmentMessage:
@@ -114,6 +137,13 @@ func makeEmptyMessage(command uint32) (Message, error) {
 	case CmdPong:
 		msg = &Pong{}
 	default:
+		// Any message types within the custom range are treated as
+		// opaque messages for external applications.
+		if command >= CustomTypeStart {
+			msg = &Custom{Type: command}
+			break
+		}
+
 		return nil, fmt.Errorf("unhandled command [%d]", command)
 	}
 
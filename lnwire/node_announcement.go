@@ -24,6 +24,20 @@ type RGB struct {
 	blue  uint8
 }
 
+// NewRGB creates a new RGB color from its red, green, and blue components.
+func NewRGB(red, green, blue uint8) RGB {
+	return RGB{
+		red:   red,
+		green: green,
+		blue:  blue,
+	}
+}
+
+// Components returns the red, green, and blue components of the color.
+func (c RGB) Components() (uint8, uint8, uint8) {
+	return c.red, c.green, c.blue
+}
+
 // Alias a hex encoded UTF-8 string that may be displayed as an alternative to
 // the node's ID. Notice that aliases are not unique and may be freely chosen
 // by the node operators.
@@ -41,8 +55,12 @@ func NewAlias(s string) (Alias, error) {
 func newAlias(data []byte) (Alias, error) {
 	var a [32]byte
 
+	if len(data) > len(a) {
+		return Alias{}, errors.New("alias should be at most 32 bytes")
+	}
+
 	aliasEnd := len(data)
-	for data[aliasEnd-1] == 0 && aliasEnd > 0 {
+	for aliasEnd > 0 && data[aliasEnd-1] == 0 {
 		aliasEnd--
 	}
 
@@ -61,7 +79,7 @@ func (a *Alias) String() string {
 // Validate check that alias data length is lower than spec size.
 func (a *Alias) Validate() error {
 	nonzero := len(a.data)
-	for a.data[nonzero-1] == 0 && nonzero > 0 {
+	for nonzero > 0 && a.data[nonzero-1] == 0 {
 		nonzero--
 	}
 
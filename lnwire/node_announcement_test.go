@@ -90,3 +90,29 @@ func TestNodeAnnoucementPayloadLength(t *testing.T) {
 			"got %v", serializedLength, na.MaxPayloadLength(0))
 	}
 }
+
+func TestNewAlias(t *testing.T) {
+	// An empty alias is permitted.
+	alias, err := NewAlias("")
+	if err != nil {
+		t.Fatalf("unable to create empty alias: %v", err)
+	}
+	if alias.String() != "" {
+		t.Fatalf("expected empty alias, got %q", alias.String())
+	}
+
+	// Any trailing zero bytes should be trimmed from the alias.
+	alias, err = NewAlias("satoshi\x00\x00")
+	if err != nil {
+		t.Fatalf("unable to create alias: %v", err)
+	}
+	if alias.String() != "satoshi" {
+		t.Fatalf("expected alias %q, got %q", "satoshi", alias.String())
+	}
+
+	// An alias which doesn't fit within the wire format should be
+	// rejected.
+	if _, err := NewAlias(string(bytes.Repeat([]byte("a"), 33))); err == nil {
+		t.Fatalf("alias exceeding 32 bytes should be rejected")
+	}
+}
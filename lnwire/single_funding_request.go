@@ -25,9 +25,10 @@ type SingleFundingRequest struct {
 	ChannelID uint64
 
 	// ChannelType represents the type of channel this request would like
-	// to open. At this point, the only supported channels are type 0
-	// channels, which are channels with regular commitment transactions
-	// utilizing HTLC's for payments.
+	// to open. Type 0 channels have regular commitment transactions
+	// utilizing HTLC's for payments, while the commitment transactions of
+	// type 1 channels pay the balance of the non-owner of the commitment
+	// to its static PaymentKey.
 	ChannelType uint8
 
 	// CoinType represents which blockchain the channel will be opened
@@ -77,9 +78,45 @@ type SingleFundingRequest struct {
 	// this amount are not enforceable onchain from our point view.
 	DustLimit btcutil.Amount
 
+	// ChannelReserve is the amount the sender requires the receiver to
+	// keep as its own balance within the channel at all times, ensuring
+	// the receiver always has something to lose by broadcasting a revoked
+	// commitment.
+	ChannelReserve btcutil.Amount
+
+	// MaxValueInFlight is the maximum total value of the pending HTLCs
+	// the receiver may offer the sender at any one time.
+	MaxValueInFlight btcutil.Amount
+
+	// HtlcMinimum is the smallest HTLC the sender will accept from the
+	// receiver.
+	HtlcMinimum btcutil.Amount
+
+	// MaxAcceptedHTLCs is the maximum number of pending HTLCs the receiver
+	// may offer the sender at any one time.
+	MaxAcceptedHTLCs uint16
+
+	// PaymentKey is the key the responder's commitment transaction is to
+	// pay the initiator's balance to, if the channel uses the static
+	// remote key channel type.
+	PaymentKey *btcec.PublicKey
+
 	// TODO(roasbeef): confirmation depth
 }
 
+const (
+	// ChanTypeLegacy is the channel type of channels with regular
+	// commitment transactions, within which the balance of the non-owner
+	// of the commitment is paid to its commitment key.
+	ChanTypeLegacy uint8 = 0
+
+	// ChanTypeStaticRemoteKey is the channel type of channels whose
+	// commitment transactions pay the balance of the non-owner of the
+	// commitment to its static payment key, allowing the balance to be
+	// recovered from the seed alone.
+	ChanTypeStaticRemoteKey uint8 = 1
+)
+
 // NewSingleFundingRequest creates, and returns a new empty SingleFundingRequest.
 func NewSingleFundingRequest(chanID uint64, chanType uint8, coinType uint64,
 	fee btcutil.Amount, amt btcutil.Amount, delay uint32, ck,
@@ -117,6 +154,11 @@ func (c *SingleFundingRequest) Decode(r io.Reader, pver uint32) error {
 	// Pubkey (33)
 	// DeliveryPkScript (final delivery)
 	// DustLimit (8)
+	// ChannelReserve (8)
+	// MaxValueInFlight (8)
+	// HtlcMinimum (8)
+	// MaxAcceptedHTLCs (2)
+	// PaymentKey (33)
 	err := readElements(r,
 		&c.ChannelID,
 		&c.ChannelType,
@@ -128,7 +170,12 @@ func (c *SingleFundingRequest) Decode(r io.Reader, pver uint32) error {
 		&c.CommitmentKey,
 		&c.ChannelDerivationPoint,
 		&c.DeliveryPkScript,
-		&c.DustLimit)
+		&c.DustLimit,
+		&c.ChannelReserve,
+		&c.MaxValueInFlight,
+		&c.HtlcMinimum,
+		&c.MaxAcceptedHTLCs,
+		&c.PaymentKey)
 	if err != nil {
 		return err
 	}
@@ -152,6 +199,11 @@ func (c *SingleFundingRequest) Encode(w io.Writer, pver uint32) error {
 	// Pubkey (33)
 	// DeliveryPkScript (final delivery)
 	// DustLimit (8)
+	// ChannelReserve (8)
+	// MaxValueInFlight (8)
+	// HtlcMinimum (8)
+	// MaxAcceptedHTLCs (2)
+	// PaymentKey (33)
 	err := writeElements(w,
 		c.ChannelID,
 		c.ChannelType,
@@ -163,7 +215,12 @@ func (c *SingleFundingRequest) Encode(w io.Writer, pver uint32) error {
 		c.CommitmentKey,
 		c.ChannelDerivationPoint,
 		c.DeliveryPkScript,
-		c.DustLimit)
+		c.DustLimit,
+		c.ChannelReserve,
+		c.MaxValueInFlight,
+		c.HtlcMinimum,
+		c.MaxAcceptedHTLCs,
+		c.PaymentKey)
 	if err != nil {
 		return err
 	}
@@ -184,11 +241,11 @@ func (c *SingleFundingRequest) Command() uint32 {
 // the fields within a SingleFundingRequest. To enforce a maximum
 // DeliveryPkScript size, the size of a P2PKH public key script is used.
 // Therefore, the final breakdown is: 8 + 1 + 8 + 8 + 8 + 4 + 33 + 33 + 25 + 8
-// + 9 = 166.
+// + 9 + 8 + 8 + 8 + 2 + 33 = 225.
 //
 // This is part of the lnwire.Message interface.
 func (c *SingleFundingRequest) MaxPayloadLength(uint32) uint32 {
-	return 174
+	return 233
 }
 
 // Validate examines each populated field within the SingleFundingRequest for
@@ -216,6 +273,9 @@ func (c *SingleFundingRequest) Validate() error {
 	if c.ChannelDerivationPoint == nil {
 		return fmt.Errorf("The channel derivation point must be non-nil")
 	}
+	if c.PaymentKey == nil {
+		return fmt.Errorf("The payment key must be non-nil")
+	}
 	//if c.ChannelDerivationPoint.Y.Bit(0) != 1 {
 	//return fmt.Errorf("The channel derivation point must have an odd " +
 	//"y-coordinate")
@@ -233,6 +293,17 @@ func (c *SingleFundingRequest) Validate() error {
 		return fmt.Errorf("Dust limit should be greater than zero.")
 	}
 
+	// None of the channel constraints may be negative.
+	if c.ChannelReserve < 0 {
+		return fmt.Errorf("ChannelReserve cannot be negative")
+	}
+	if c.MaxValueInFlight < 0 {
+		return fmt.Errorf("MaxValueInFlight cannot be negative")
+	}
+	if c.HtlcMinimum < 0 {
+		return fmt.Errorf("HtlcMinimum cannot be negative")
+	}
+
 	// We're good!
 	return nil
 }
@@ -259,5 +330,9 @@ func (c *SingleFundingRequest) String() string {
 		fmt.Sprintf("ChannelDerivationPoint:\t\t\t%x\n", serializedPubkey) +
 		fmt.Sprintf("DeliveryPkScript:\t\t\t%x\n", c.DeliveryPkScript) +
 		fmt.Sprintf("DustLimit:\t\t\t%d\n", c.DustLimit) +
+		fmt.Sprintf("ChannelReserve:\t\t\t%d\n", c.ChannelReserve) +
+		fmt.Sprintf("MaxValueInFlight:\t\t%d\n", c.MaxValueInFlight) +
+		fmt.Sprintf("HtlcMinimum:\t\t\t%d\n", c.HtlcMinimum) +
+		fmt.Sprintf("MaxAcceptedHTLCs:\t\t%d\n", c.MaxAcceptedHTLCs) +
 		fmt.Sprintf("--- End SingleFundingRequest ---\n")
 }
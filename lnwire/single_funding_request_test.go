@@ -12,6 +12,11 @@ func TestSingleFundingRequestWire(t *testing.T) {
 	delivery := PkScript(bytes.Repeat([]byte{0x02}, 25))
 	sfr := NewSingleFundingRequest(20, 21, 22, 23, 5, 5, cdp, cdp,
 		delivery, 540, 10000)
+	sfr.ChannelReserve = 1000
+	sfr.MaxValueInFlight = 50000
+	sfr.HtlcMinimum = 10
+	sfr.MaxAcceptedHTLCs = 30
+	sfr.PaymentKey = pubKey
 
 	// Next encode the SFR message into an empty bytes buffer.
 	var b bytes.Buffer
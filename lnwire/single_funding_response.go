@@ -51,6 +51,29 @@ type SingleFundingResponse struct {
 	// generated for remote commitment transaction; ie. HTLCs below
 	// this amount are not enforceable onchain for their point of view.
 	DustLimit btcutil.Amount
+
+	// ChannelReserve is the amount the sender requires the receiver to
+	// keep as its own balance within the channel at all times, ensuring
+	// the receiver always has something to lose by broadcasting a revoked
+	// commitment.
+	ChannelReserve btcutil.Amount
+
+	// MaxValueInFlight is the maximum total value of the pending HTLCs
+	// the receiver may offer the sender at any one time.
+	MaxValueInFlight btcutil.Amount
+
+	// HtlcMinimum is the smallest HTLC the sender will accept from the
+	// receiver.
+	HtlcMinimum btcutil.Amount
+
+	// MaxAcceptedHTLCs is the maximum number of pending HTLCs the receiver
+	// may offer the sender at any one time.
+	MaxAcceptedHTLCs uint16
+
+	// PaymentKey is the key the initiator's commitment transaction is to
+	// pay the responder's balance to, if the channel uses the static
+	// remote key channel type.
+	PaymentKey *btcec.PublicKey
 }
 
 // NewSingleFundingResponse creates, and returns a new empty
@@ -87,6 +110,11 @@ func (c *SingleFundingResponse) Decode(r io.Reader, pver uint32) error {
 	// CsvDelay (4)
 	// DeliveryPkScript (final delivery)
 	// DustLimit (8)
+	// ChannelReserve (8)
+	// MaxValueInFlight (8)
+	// HtlcMinimum (8)
+	// MaxAcceptedHTLCs (2)
+	// PaymentKey (33)
 	err := readElements(r,
 		&c.ChannelID,
 		&c.ChannelDerivationPoint,
@@ -94,7 +122,12 @@ func (c *SingleFundingResponse) Decode(r io.Reader, pver uint32) error {
 		&c.RevocationKey,
 		&c.CsvDelay,
 		&c.DeliveryPkScript,
-		&c.DustLimit)
+		&c.DustLimit,
+		&c.ChannelReserve,
+		&c.MaxValueInFlight,
+		&c.HtlcMinimum,
+		&c.MaxAcceptedHTLCs,
+		&c.PaymentKey)
 	if err != nil {
 		return err
 	}
@@ -115,6 +148,11 @@ func (c *SingleFundingResponse) Encode(w io.Writer, pver uint32) error {
 	// CsvDelay (4)
 	// DeliveryPkScript (final delivery)
 	// DustLimit (8)
+	// ChannelReserve (8)
+	// MaxValueInFlight (8)
+	// HtlcMinimum (8)
+	// MaxAcceptedHTLCs (2)
+	// PaymentKey (33)
 	err := writeElements(w,
 		c.ChannelID,
 		c.ChannelDerivationPoint,
@@ -122,7 +160,12 @@ func (c *SingleFundingResponse) Encode(w io.Writer, pver uint32) error {
 		c.RevocationKey,
 		c.CsvDelay,
 		c.DeliveryPkScript,
-		c.DustLimit)
+		c.DustLimit,
+		c.ChannelReserve,
+		c.MaxValueInFlight,
+		c.HtlcMinimum,
+		c.MaxAcceptedHTLCs,
+		c.PaymentKey)
 	if err != nil {
 		return err
 	}
@@ -142,11 +185,12 @@ func (c *SingleFundingResponse) Command() uint32 {
 // SingleFundingResponse. This is calculated by summing the max length of all
 // the fields within a SingleFundingResponse. To enforce a maximum
 // DeliveryPkScript size, the size of a P2PKH public key script is used.
-// Therefore, the final breakdown is: 8 + (33 * 3) + 8 + 25 + 8
+// Therefore, the final breakdown is: 8 + (33 * 3) + 8 + 25 + 8 + 8 + 8 + 8 +
+// 2 + 33
 //
 // This is part of the lnwire.Message interface.
 func (c *SingleFundingResponse) MaxPayloadLength(uint32) uint32 {
-	return 148
+	return 207
 }
 
 // Validate examines each populated field within the SingleFundingResponse for
@@ -160,6 +204,9 @@ func (c *SingleFundingResponse) Validate() error {
 	if c.ChannelDerivationPoint == nil {
 		return fmt.Errorf("The channel derivation point must be non-nil")
 	}
+	if c.PaymentKey == nil {
+		return fmt.Errorf("The payment key must be non-nil")
+	}
 	//if c.ChannelDerivationPoint.Y.Bit(0) != 1 {
 	//	return fmt.Errorf("The channel derivation point must have an odd " +
 	//		"y-coordinate")
@@ -177,6 +224,17 @@ func (c *SingleFundingResponse) Validate() error {
 			"zero.")
 	}
 
+	// None of the channel constraints may be negative.
+	if c.ChannelReserve < 0 {
+		return fmt.Errorf("ChannelReserve cannot be negative")
+	}
+	if c.MaxValueInFlight < 0 {
+		return fmt.Errorf("MaxValueInFlight cannot be negative")
+	}
+	if c.HtlcMinimum < 0 {
+		return fmt.Errorf("HtlcMinimum cannot be negative")
+	}
+
 	// We're good!
 	return nil
 }
@@ -206,5 +264,9 @@ func (c *SingleFundingResponse) String() string {
 		fmt.Sprintf("CsvDelay:\t\t%d\n", c.CsvDelay) +
 		fmt.Sprintf("DeliveryPkScript:\t\t%x\n", c.DeliveryPkScript) +
 		fmt.Sprintf("DustLimit:\t\t\t%d\n", c.DustLimit) +
+		fmt.Sprintf("ChannelReserve:\t\t\t%d\n", c.ChannelReserve) +
+		fmt.Sprintf("MaxValueInFlight:\t\t%d\n", c.MaxValueInFlight) +
+		fmt.Sprintf("HtlcMinimum:\t\t\t%d\n", c.HtlcMinimum) +
+		fmt.Sprintf("MaxAcceptedHTLCs:\t\t%d\n", c.MaxAcceptedHTLCs) +
 		fmt.Sprintf("--- End SingleFundingResponse ---\n")
 }
@@ -11,6 +11,11 @@ func TestSingleFundingResponseWire(t *testing.T) {
 	delivery := PkScript(bytes.Repeat([]byte{0x02}, 25))
 	sfr := NewSingleFundingResponse(22, pubKey, pubKey, pubKey, 5,
 		delivery, 540)
+	sfr.ChannelReserve = 1000
+	sfr.MaxValueInFlight = 50000
+	sfr.HtlcMinimum = 10
+	sfr.MaxAcceptedHTLCs = 30
+	sfr.PaymentKey = pubKey
 
 	// Next encode the SFR message into an empty bytes buffer.
 	var b bytes.Buffer
@@ -0,0 +1,116 @@
+package lnwire
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+// Stfu is sent by either side of a channel to request that the channel be
+// quiesced. Once a node has sent Stfu it must not send any further updates
+// for the channel, and it only sends Stfu once all of its own pending updates
+// have been committed to both commitment transactions. After both sides have
+// sent Stfu the channel is quiescent, and its commitment state is frozen
+// until the operation the channel was quiesced for completes.
+type Stfu struct {
+	// ChannelPoint identifies the channel which is to be quiesced.
+	ChannelPoint *wire.OutPoint
+
+	// Initiator is true if the sender initiated the quiescence, and false
+	// if the Stfu is sent in response to the Stfu of the remote peer.
+	Initiator bool
+}
+
+// NewStfu creates a new Stfu message.
+func NewStfu(chanPoint *wire.OutPoint, initiator bool) *Stfu {
+	return &Stfu{
+		ChannelPoint: chanPoint,
+		Initiator:    initiator,
+	}
+}
+
+// A compile time check to ensure Stfu implements the lnwire.Message
+// interface.
+var _ Message = (*Stfu)(nil)
+
+// Decode deserializes a serialized Stfu message stored in the passed
+// io.Reader observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (s *Stfu) Decode(r io.Reader, pver uint32) error {
+	// ChannelPoint (36)
+	// Initiator (1)
+	var initiator uint8
+	err := readElements(r,
+		&s.ChannelPoint,
+		&initiator)
+	if err != nil {
+		return err
+	}
+
+	s.Initiator = initiator == 1
+
+	return nil
+}
+
+// Encode serializes the target Stfu into the passed io.Writer observing the
+// protocol version specified.
+//
+// This is part of the lnwire.Message interface.
+func (s *Stfu) Encode(w io.Writer, pver uint32) error {
+	var initiator uint8
+	if s.Initiator {
+		initiator = 1
+	}
+
+	// ChannelPoint (36)
+	// Initiator (1)
+	err := writeElements(w,
+		s.ChannelPoint,
+		initiator)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Command returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the lnwire.Message interface.
+func (s *Stfu) Command() uint32 {
+	return CmdStfu
+}
+
+// MaxPayloadLength returns the maximum allowed payload size for a Stfu
+// message observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (s *Stfu) MaxPayloadLength(uint32) uint32 {
+	// 36 + 1
+	return 37
+}
+
+// Validate performs any necessary sanity checks to ensure all fields present
+// on the Stfu are valid.
+//
+// This is part of the lnwire.Message interface.
+func (s *Stfu) Validate() error {
+	if s.ChannelPoint == nil {
+		return fmt.Errorf("stfu must reference a channel point")
+	}
+
+	return nil
+}
+
+// String returns the string representation of the target Stfu.
+//
+// This is part of the lnwire.Message interface.
+func (s *Stfu) String() string {
+	return fmt.Sprintf("\n--- Begin Stfu ---\n") +
+		fmt.Sprintf("ChannelPoint:\t\t%v\n", s.ChannelPoint) +
+		fmt.Sprintf("Initiator:\t\t%v\n", s.Initiator) +
+		fmt.Sprintf("--- End Stfu ---\n")
+}
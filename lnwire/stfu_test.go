@@ -0,0 +1,32 @@
+package lnwire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestStfuEncodeDecode(t *testing.T) {
+	for _, initiator := range []bool{true, false} {
+		stfu := NewStfu(outpoint1, initiator)
+
+		// Next encode the Stfu message into an empty bytes buffer.
+		var b bytes.Buffer
+		if err := stfu.Encode(&b, 0); err != nil {
+			t.Fatalf("unable to encode Stfu: %v", err)
+		}
+
+		// Deserialize the encoded Stfu message into a new empty
+		// struct.
+		stfu2 := &Stfu{}
+		if err := stfu2.Decode(&b, 0); err != nil {
+			t.Fatalf("unable to decode Stfu: %v", err)
+		}
+
+		// Assert equality of the two instances.
+		if !reflect.DeepEqual(stfu, stfu2) {
+			t.Fatalf("encode/decode error messages don't match "+
+				"%#v vs %#v", stfu, stfu2)
+		}
+	}
+}
@@ -2,22 +2,45 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"strings"
+	"sync"
 
 	"github.com/btcsuite/btclog"
 	"github.com/btcsuite/seelog"
+	"github.com/lightningnetwork/lnd/autopilot"
+	"github.com/lightningnetwork/lnd/blockcache"
 	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/chanfitness"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/chanstatus"
+	"github.com/lightningnetwork/lnd/feemanager"
+	"github.com/lightningnetwork/lnd/healthcheck"
 	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/logrotate"
+	"github.com/lightningnetwork/lnd/monitoring"
 	"github.com/lightningnetwork/lnd/routing"
 	"github.com/roasbeef/btcd/connmgr"
 )
 
+// defaultLogTailSize is the number of the most recent log lines retained in
+// memory.
+const defaultLogTailSize = 500
+
 // Loggers per subsystem.  Note that backendLog is a seelog logger that all of
 // the subsystem loggers route their messages to.  When adding new subsystems,
 // add a reference here, to the subsystemLoggers map, and the useLogger
 // function.
 var (
+	// logRotator is the rotating log file that, along with stdout, the
+	// backendLog writes to. It's nil until initLogRotator is called.
+	logRotator *logrotate.Rotator
+
+	// recentLogs retains the most recent lines written by the backendLog,
+	// to be returned within debug info bundles.
+	recentLogs = newLogTail(defaultLogTailSize)
+
 	backendLog = seelog.Disabled
 	ltndLog    = btclog.Disabled
 	lnwlLog    = btclog.Disabled
@@ -32,6 +55,15 @@ var (
 	brarLog    = btclog.Disabled
 	cmgrLog    = btclog.Disabled
 	crtrLog    = btclog.Disabled
+	promLog    = btclog.Disabled
+	invcLog    = btclog.Disabled
+	hlckLog    = btclog.Disabled
+	atplLog    = btclog.Disabled
+	chftLog    = btclog.Disabled
+	feemLog    = btclog.Disabled
+	chstLog    = btclog.Disabled
+	swprLog    = btclog.Disabled
+	blckLog    = btclog.Disabled
 )
 
 // subsystemLoggers maps each subsystem identifier to its associated logger.
@@ -49,6 +81,15 @@ var subsystemLoggers = map[string]btclog.Logger{
 	"BRAR": brarLog,
 	"CMGR": cmgrLog,
 	"CRTR": crtrLog,
+	"PROM": promLog,
+	"INVC": invcLog,
+	"HLCK": hlckLog,
+	"ATPL": atplLog,
+	"CHFT": chftLog,
+	"FEEM": feemLog,
+	"CHST": chstLog,
+	"SWPR": swprLog,
+	"BLCK": blckLog,
 }
 
 // useLogger updates the logger references for subsystemID to logger.  Invalid
@@ -103,31 +144,64 @@ func useLogger(subsystemID string, logger btclog.Logger) {
 	case "CRTR":
 		crtrLog = logger
 		routing.UseLogger(crtrLog)
+
+	case "PROM":
+		promLog = logger
+		monitoring.UseLogger(logger)
+
+	case "INVC":
+		invcLog = logger
+
+	case "HLCK":
+		hlckLog = logger
+		healthcheck.UseLogger(logger)
+
+	case "ATPL":
+		atplLog = logger
+		autopilot.UseLogger(logger)
+
+	case "CHFT":
+		chftLog = logger
+		chanfitness.UseLogger(logger)
+
+	case "FEEM":
+		feemLog = logger
+		feemanager.UseLogger(logger)
+
+	case "CHST":
+		chstLog = logger
+		chanstatus.UseLogger(logger)
+
+	case "SWPR":
+		swprLog = logger
+
+	case "BLCK":
+		blckLog = logger
+		blockcache.UseLogger(logger)
 	}
 }
 
-// initSeelogLogger initializes a new seelog logger that is used as the backend
-// for all logging subsystems.
-func initSeelogLogger(logFile string) {
-	config := `
-	<seelog type="adaptive" mininterval="2000000" maxinterval="100000000"
-		critmsgcount="500" minlevel="trace">
-		<outputs formatid="all">
-			<console />
-			<rollingfile type="size" filename="%s" maxsize="10485760" maxrolls="3" />
-		</outputs>
-		<formats>
-			<format id="all" format="%%Time %%Date [%%LEV] %%Msg%%n" />
-		</formats>
-	</seelog>`
-	config = fmt.Sprintf(config, logFile)
-
-	logger, err := seelog.LoggerFromConfigAsString(config)
+// initLogRotator initializes the rotating log file which, along with stdout,
+// all subsystem loggers write their output to. Once the log file exceeds
+// maxFileSize bytes it's compressed and rotated, retaining at most maxFiles
+// rotated files.
+func initLogRotator(logFile string, maxFileSize int64, maxFiles int) {
+	r, err := logrotate.New(logFile, maxFileSize, maxFiles, true)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "failed to create logger: %v", err)
+		fmt.Fprintf(os.Stderr, "failed to create log rotator: %v\n", err)
 		os.Exit(1)
 	}
 
+	logger, err := seelog.LoggerFromWriterWithMinLevelAndFormat(
+		io.MultiWriter(os.Stdout, r, recentLogs), seelog.TraceLvl,
+		"%Time %Date [%LEV] %Msg%n",
+	)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create logger: %v\n", err)
+		os.Exit(1)
+	}
+
+	logRotator = r
 	backendLog = logger
 }
 
@@ -181,3 +255,63 @@ func (c logClosure) String() string {
 func newLogClosure(c func() string) logClosure {
 	return logClosure(c)
 }
+
+// logTail is an io.Writer which retains the last lines written to it, up to a
+// maximum number of lines. It's safe for concurrent use.
+type logTail struct {
+	mtx      sync.Mutex
+	lines    []string
+	next     int
+	maxLines int
+}
+
+// newLogTail creates a new logTail retaining at most maxLines lines.
+func newLogTail(maxLines int) *logTail {
+	return &logTail{
+		lines:    make([]string, 0, maxLines),
+		maxLines: maxLines,
+	}
+}
+
+// Write records each line within the passed bytes, evicting the oldest lines
+// once the maximum number of lines is retained.
+//
+// NOTE: Part of the io.Writer interface.
+func (l *logTail) Write(p []byte) (int, error) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	msg := strings.TrimRight(string(p), "\n")
+	if msg == "" {
+		return len(p), nil
+	}
+
+	for _, line := range strings.Split(msg, "\n") {
+		if len(l.lines) < l.maxLines {
+			l.lines = append(l.lines, line)
+			continue
+		}
+
+		l.lines[l.next] = line
+		l.next = (l.next + 1) % l.maxLines
+	}
+
+	return len(p), nil
+}
+
+// Lines returns up to the n most recently written lines, oldest first. If n
+// is zero, all retained lines are returned.
+func (l *logTail) Lines(n int) []string {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	lines := make([]string, 0, len(l.lines))
+	lines = append(lines, l.lines[l.next:]...)
+	lines = append(lines, l.lines[:l.next]...)
+
+	if n > 0 && n < len(lines) {
+		lines = lines[len(lines)-n:]
+	}
+
+	return lines
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestLogTail asserts that a logTail retains only the most recently written
+// lines, in the order they were written.
+func TestLogTail(t *testing.T) {
+	t.Parallel()
+
+	tail := newLogTail(3)
+	if lines := tail.Lines(0); len(lines) != 0 {
+		t.Fatalf("expected no lines, got %v", lines)
+	}
+
+	tail.Write([]byte("a\n"))
+	tail.Write([]byte("b\nc\n"))
+	if lines := tail.Lines(0); !reflect.DeepEqual(lines,
+		[]string{"a", "b", "c"}) {
+
+		t.Fatalf("unexpected lines: %v", lines)
+	}
+
+	// Once full, the oldest lines should be evicted.
+	tail.Write([]byte("d\n"))
+	tail.Write([]byte("e\n"))
+	if lines := tail.Lines(0); !reflect.DeepEqual(lines,
+		[]string{"c", "d", "e"}) {
+
+		t.Fatalf("unexpected lines: %v", lines)
+	}
+
+	// Only the most recent lines should be returned if fewer are
+	// requested.
+	if lines := tail.Lines(2); !reflect.DeepEqual(lines,
+		[]string{"d", "e"}) {
+
+		t.Fatalf("unexpected lines: %v", lines)
+	}
+}
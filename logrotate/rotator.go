@@ -0,0 +1,208 @@
+// Package logrotate implements an io.Writer which writes to a log file,
+// rotating the file once it exceeds a maximum size. Rotated files may
+// optionally be compressed using gzip, and only a bounded number of rotated
+// files are retained.
+package logrotate
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Rotator is an io.Writer which writes to a log file, rotating the file once
+// it grows beyond a maximum size. The most recently rotated file is suffixed
+// with ".1", the one before it with ".2", and so on, with an additional
+// ".gz" suffix if compression is enabled. It's safe for concurrent use.
+type Rotator struct {
+	filename string
+	maxSize  int64
+	maxRolls int
+	compress bool
+
+	mtx  sync.Mutex
+	file *os.File
+	size int64
+}
+
+// New creates a new Rotator which writes to the named file, creating any
+// parent directories as needed. Once the file exceeds maxSize bytes, it's
+// rotated, retaining at most maxRolls rotated files. If compress is true,
+// rotated files are compressed with gzip.
+func New(filename string, maxSize int64, maxRolls int,
+	compress bool) (*Rotator, error) {
+
+	if maxSize <= 0 {
+		return nil, fmt.Errorf("max log file size must be positive")
+	}
+	if maxRolls < 0 {
+		return nil, fmt.Errorf("max number of rotated log files " +
+			"must not be negative")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0700); err != nil {
+		return nil, err
+	}
+
+	r := &Rotator{
+		filename: filename,
+		maxSize:  maxSize,
+		maxRolls: maxRolls,
+		compress: compress,
+	}
+	if err := r.openFile(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// openFile opens the current log file for appending, recording its size.
+//
+// NOTE: The mtx MUST be held when calling this method.
+func (r *Rotator) openFile() error {
+	file, err := os.OpenFile(r.filename,
+		os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	r.file = file
+	r.size = info.Size()
+
+	return nil
+}
+
+// Write writes the passed bytes to the current log file, first rotating the
+// file if the write would grow it beyond the maximum size. A single write is
+// never split across files.
+//
+// NOTE: Part of the io.Writer interface.
+func (r *Rotator) Write(p []byte) (int, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if r.file == nil {
+		return 0, fmt.Errorf("log rotator closed")
+	}
+
+	if r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+
+	return n, err
+}
+
+// Close closes the current log file. Any subsequent writes will fail.
+func (r *Rotator) Close() error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+
+	err := r.file.Close()
+	r.file = nil
+
+	return err
+}
+
+// rollName returns the name of the n-th most recently rotated file.
+func (r *Rotator) rollName(n int) string {
+	name := fmt.Sprintf("%s.%d", r.filename, n)
+	if r.compress {
+		name += ".gz"
+	}
+
+	return name
+}
+
+// rotate closes the current log file, shifts all previously rotated files
+// back by one, discarding the oldest if needed, then moves the current file
+// into place as the most recently rotated file before opening a new one.
+//
+// NOTE: The mtx MUST be held when calling this method.
+func (r *Rotator) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+	r.file = nil
+
+	// If no rotated files are to be retained, then we simply truncate
+	// the current file.
+	if r.maxRolls == 0 {
+		if err := os.Remove(r.filename); err != nil {
+			return err
+		}
+		return r.openFile()
+	}
+
+	// Discard the oldest rotated file, then shift the rest back.
+	err := os.Remove(r.rollName(r.maxRolls))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for i := r.maxRolls - 1; i > 0; i-- {
+		err := os.Rename(r.rollName(i), r.rollName(i+1))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	if r.compress {
+		if err := compressFile(r.filename, r.rollName(1)); err != nil {
+			return err
+		}
+		if err := os.Remove(r.filename); err != nil {
+			return err
+		}
+	} else {
+		if err := os.Rename(r.filename, r.rollName(1)); err != nil {
+			return err
+		}
+	}
+
+	return r.openFile()
+}
+
+// compressFile writes a gzip compressed copy of the src file to dst.
+func compressFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(out)
+	if _, err := io.Copy(gz, in); err != nil {
+		gz.Close()
+		out.Close()
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
@@ -0,0 +1,101 @@
+package logrotate
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// readFile returns the contents of the named file, decompressing it if
+// needed.
+func readFile(t *testing.T, name string, compressed bool) []byte {
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatalf("unable to open %v: %v", name, err)
+	}
+	defer f.Close()
+
+	if !compressed {
+		data, err := ioutil.ReadAll(f)
+		if err != nil {
+			t.Fatalf("unable to read %v: %v", name, err)
+		}
+		return data
+	}
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("unable to read gzip header of %v: %v", name, err)
+	}
+	data, err := ioutil.ReadAll(gz)
+	if err != nil {
+		t.Fatalf("unable to decompress %v: %v", name, err)
+	}
+	return data
+}
+
+// TestRotator asserts that log files are rotated once they exceed the maximum
+// size, that only the configured number of rotated files are retained, and
+// that rotated files are compressed if requested.
+func TestRotator(t *testing.T) {
+	for _, compress := range []bool{false, true} {
+		tempDir, err := ioutil.TempDir("", "logrotate")
+		if err != nil {
+			t.Fatalf("unable to create temp dir: %v", err)
+		}
+		defer os.RemoveAll(tempDir)
+
+		logFile := filepath.Join(tempDir, "logs", "lnd.log")
+		r, err := New(logFile, 10, 2, compress)
+		if err != nil {
+			t.Fatalf("unable to create rotator: %v", err)
+		}
+
+		// Each write fills the file, so every subsequent write should
+		// trigger a rotation.
+		writes := [][]byte{
+			bytes.Repeat([]byte("a"), 10),
+			bytes.Repeat([]byte("b"), 10),
+			bytes.Repeat([]byte("c"), 10),
+			bytes.Repeat([]byte("d"), 4),
+		}
+		for _, w := range writes {
+			if _, err := r.Write(w); err != nil {
+				t.Fatalf("unable to write: %v", err)
+			}
+		}
+
+		// A write fitting within the current file shouldn't rotate.
+		if _, err := r.Write([]byte("e")); err != nil {
+			t.Fatalf("unable to write: %v", err)
+		}
+		if err := r.Close(); err != nil {
+			t.Fatalf("unable to close rotator: %v", err)
+		}
+
+		if data := readFile(t, logFile, false); string(data) != "dddde" {
+			t.Fatalf("unexpected current log file: %q", data)
+		}
+		data := readFile(t, r.rollName(1), compress)
+		if !bytes.Equal(data, writes[2]) {
+			t.Fatalf("unexpected first rotated file: %q", data)
+		}
+		data = readFile(t, r.rollName(2), compress)
+		if !bytes.Equal(data, writes[1]) {
+			t.Fatalf("unexpected second rotated file: %q", data)
+		}
+
+		// The oldest file should've been discarded.
+		if _, err := os.Stat(r.rollName(3)); !os.IsNotExist(err) {
+			t.Fatalf("oldest rotated file should be discarded")
+		}
+
+		// Writes after closing the rotator should fail.
+		if _, err := r.Write([]byte("f")); err == nil {
+			t.Fatalf("write after close should fail")
+		}
+	}
+}
@@ -0,0 +1,18 @@
+// +build monitoring
+
+package monitoring
+
+// DefaultListen is the default address the Prometheus metrics are served on.
+const DefaultListen = "localhost:8989"
+
+// Config houses the options of the monitoring subsystem, which are only
+// available within builds using the monitoring build tag.
+type Config struct {
+	Enable bool   `long:"enable" description:"Serve Prometheus metrics concerning the operation of the daemon"`
+	Listen string `long:"listen" description:"The host:port the Prometheus metrics are served on"`
+}
+
+// Enabled returns true if metrics should be collected and served.
+func (c *Config) Enabled() bool {
+	return c.Enable
+}
@@ -0,0 +1,15 @@
+// +build !monitoring
+
+package monitoring
+
+// DefaultListen is the default address the Prometheus metrics are served on.
+const DefaultListen = ""
+
+// Config is an empty struct in builds without the monitoring build tag,
+// ensuring monitoring can't be enabled from the command line.
+type Config struct{}
+
+// Enabled always returns false in builds without the monitoring build tag.
+func (c *Config) Enabled() bool {
+	return false
+}
@@ -0,0 +1,53 @@
+// Package monitoring exposes Prometheus metrics concerning the operation of
+// the daemon on a dedicated listener. Metrics are only collected within
+// builds using the monitoring build tag, otherwise all exported functions of
+// the package are no-ops.
+package monitoring
+
+import "github.com/roasbeef/btcutil"
+
+// Sources houses the callbacks used to query the state of the daemon each
+// time the metrics are scraped. Any nil callback disables its metrics.
+type Sources struct {
+	// ChannelBalances returns the sum of our local and remote balances
+	// across all open channels.
+	ChannelBalances func() (btcutil.Amount, btcutil.Amount, error)
+
+	// InvoiceCounts returns the number of invoices which are open, and
+	// the number which have been settled.
+	InvoiceCounts func() (int, int, error)
+
+	// ChainBackendHealthy returns a non-nil error if the chain backend
+	// can't be reached.
+	ChainBackendHealthy func() error
+}
+
+// HtlcEvent identifies a type of HTLC event counted by the monitoring
+// subsystem.
+type HtlcEvent uint8
+
+const (
+	// HtlcForwarded denotes an HTLC forwarded by the switch to the next
+	// hop within its route.
+	HtlcForwarded HtlcEvent = iota
+
+	// HtlcSettled denotes an HTLC settled by the switch.
+	HtlcSettled
+
+	// HtlcFailed denotes an HTLC cancelled by the switch.
+	HtlcFailed
+)
+
+// String returns a human readable name for the HtlcEvent.
+func (e HtlcEvent) String() string {
+	switch e {
+	case HtlcForwarded:
+		return "forward"
+	case HtlcSettled:
+		return "settle"
+	case HtlcFailed:
+		return "fail"
+	default:
+		return "unknown"
+	}
+}
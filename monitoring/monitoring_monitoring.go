@@ -0,0 +1,210 @@
+// +build monitoring
+
+package monitoring
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+)
+
+var (
+	// htlcEvents counts the HTLCs forwarded, settled, and failed by the
+	// switch.
+	htlcEvents = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "lnd",
+			Subsystem: "htlcswitch",
+			Name:      "htlc_events_total",
+			Help:      "Number of HTLCs forwarded, settled, and failed by the switch",
+		},
+		[]string{"event"},
+	)
+
+	// dbTxDuration tracks the time taken by each channeldb transaction.
+	dbTxDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "lnd",
+			Subsystem: "channeldb",
+			Name:      "tx_duration_seconds",
+			Help:      "Duration of channel database transactions",
+			Buckets:   prometheus.ExponentialBuckets(0.0001, 4, 10),
+		},
+		[]string{"type"},
+	)
+
+	// startOnce ensures the metrics are only registered and served once.
+	startOnce sync.Once
+)
+
+// GetInterceptors returns the gRPC server interceptors which collect the
+// latency and count of all gRPC calls, or nil interceptors if monitoring
+// isn't enabled.
+func GetInterceptors(cfg *Config) (grpc.UnaryServerInterceptor,
+	grpc.StreamServerInterceptor) {
+
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+
+	grpc_prometheus.EnableHandlingTimeHistogram()
+
+	return grpc_prometheus.UnaryServerInterceptor,
+		grpc_prometheus.StreamServerInterceptor
+}
+
+// Start registers all metrics, including those of the passed gRPC server, and
+// begins serving them on the configured listener. The gRPC server MUST call
+// the interceptors returned by GetInterceptors, and have all of its services
+// registered. If monitoring isn't enabled, then this is a no-op.
+func Start(cfg *Config, grpcServer *grpc.Server, sources *Sources) error {
+	if !cfg.Enabled() {
+		return nil
+	}
+
+	var err error
+	startOnce.Do(func() {
+		grpc_prometheus.Register(grpcServer)
+
+		collectors := []prometheus.Collector{
+			htlcEvents, dbTxDuration, newDaemonCollector(sources),
+		}
+		for _, collector := range collectors {
+			if err = prometheus.Register(collector); err != nil {
+				return
+			}
+		}
+
+		listen := cfg.Listen
+		if listen == "" {
+			listen = DefaultListen
+		}
+
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.Handler())
+		go func() {
+			log.Infof("Prometheus metrics served on %v/metrics", listen)
+			if err := http.ListenAndServe(listen, mux); err != nil {
+				log.Errorf("unable to serve prometheus metrics: "+
+					"%v", err)
+			}
+		}()
+	})
+
+	return err
+}
+
+// IncHtlcEvent increments the count of the passed type of HTLC event.
+func IncHtlcEvent(event HtlcEvent) {
+	htlcEvents.WithLabelValues(event.String()).Inc()
+}
+
+// ObserveDBTx records the duration of a channeldb transaction, which is
+// either a read-write update, or a read-only view.
+func ObserveDBTx(update bool, elapsed time.Duration) {
+	txType := "view"
+	if update {
+		txType = "update"
+	}
+
+	dbTxDuration.WithLabelValues(txType).Observe(elapsed.Seconds())
+}
+
+// daemonCollector is a prometheus.Collector which queries the state of the
+// daemon through the configured Sources each time the metrics are scraped.
+type daemonCollector struct {
+	sources *Sources
+
+	localBalance  *prometheus.Desc
+	remoteBalance *prometheus.Desc
+	invoices      *prometheus.Desc
+	chainHealthy  *prometheus.Desc
+}
+
+// newDaemonCollector creates a new daemonCollector backed by the passed
+// sources.
+func newDaemonCollector(sources *Sources) *daemonCollector {
+	return &daemonCollector{
+		sources: sources,
+		localBalance: prometheus.NewDesc(
+			"lnd_channels_local_balance_sat",
+			"Sum of our local balance across all open channels",
+			nil, nil,
+		),
+		remoteBalance: prometheus.NewDesc(
+			"lnd_channels_remote_balance_sat",
+			"Sum of the remote balance across all open channels",
+			nil, nil,
+		),
+		invoices: prometheus.NewDesc(
+			"lnd_invoices",
+			"Number of invoices by state",
+			[]string{"state"}, nil,
+		),
+		chainHealthy: prometheus.NewDesc(
+			"lnd_chain_backend_healthy",
+			"Whether the chain backend can be reached (1) or not (0)",
+			nil, nil,
+		),
+	}
+}
+
+// Describe sends the descriptors of all metrics of the collector.
+//
+// NOTE: Part of the prometheus.Collector interface.
+func (d *daemonCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- d.localBalance
+	ch <- d.remoteBalance
+	ch <- d.invoices
+	ch <- d.chainHealthy
+}
+
+// Collect queries each of the sources, sending the resulting metrics. Sources
+// which are unset or return an error are skipped.
+//
+// NOTE: Part of the prometheus.Collector interface.
+func (d *daemonCollector) Collect(ch chan<- prometheus.Metric) {
+	if d.sources == nil {
+		return
+	}
+
+	if d.sources.ChannelBalances != nil {
+		local, remote, err := d.sources.ChannelBalances()
+		if err != nil {
+			log.Errorf("unable to fetch channel balances: %v", err)
+		} else {
+			ch <- prometheus.MustNewConstMetric(d.localBalance,
+				prometheus.GaugeValue, float64(local))
+			ch <- prometheus.MustNewConstMetric(d.remoteBalance,
+				prometheus.GaugeValue, float64(remote))
+		}
+	}
+
+	if d.sources.InvoiceCounts != nil {
+		open, settled, err := d.sources.InvoiceCounts()
+		if err != nil {
+			log.Errorf("unable to fetch invoice counts: %v", err)
+		} else {
+			ch <- prometheus.MustNewConstMetric(d.invoices,
+				prometheus.GaugeValue, float64(open), "open")
+			ch <- prometheus.MustNewConstMetric(d.invoices,
+				prometheus.GaugeValue, float64(settled),
+				"settled")
+		}
+	}
+
+	if d.sources.ChainBackendHealthy != nil {
+		healthy := 1.0
+		if err := d.sources.ChainBackendHealthy(); err != nil {
+			log.Warnf("chain backend unhealthy: %v", err)
+			healthy = 0
+		}
+		ch <- prometheus.MustNewConstMetric(d.chainHealthy,
+			prometheus.GaugeValue, healthy)
+	}
+}
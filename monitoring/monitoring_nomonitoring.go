@@ -0,0 +1,28 @@
+// +build !monitoring
+
+package monitoring
+
+import (
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// GetInterceptors returns nil interceptors in builds without the monitoring
+// build tag.
+func GetInterceptors(cfg *Config) (grpc.UnaryServerInterceptor,
+	grpc.StreamServerInterceptor) {
+
+	return nil, nil
+}
+
+// Start is a no-op in builds without the monitoring build tag.
+func Start(cfg *Config, grpcServer *grpc.Server, sources *Sources) error {
+	return nil
+}
+
+// IncHtlcEvent is a no-op in builds without the monitoring build tag.
+func IncHtlcEvent(event HtlcEvent) {}
+
+// ObserveDBTx is a no-op in builds without the monitoring build tag.
+func ObserveDBTx(update bool, elapsed time.Duration) {}
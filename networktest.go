@@ -99,6 +99,10 @@ type lightningNode struct {
 	extraArgs []string
 
 	lnrpc.LightningClient
+
+	// DevClient is a client of the Dev service, which is only served by
+	// nodes built with the dev build tag.
+	DevClient lnrpc.DevClient
 }
 
 // newLightningNode creates a new test lightning node instance from the passed
@@ -143,15 +147,15 @@ func (l *lightningNode) genArgs() []string {
 	var args []string
 
 	encodedCert := hex.EncodeToString(l.rpcCert)
-	args = append(args, fmt.Sprintf("--btcdhost=%v", l.cfg.RPCHost))
-	args = append(args, fmt.Sprintf("--rpcuser=%v", l.cfg.RPCUser))
-	args = append(args, fmt.Sprintf("--rpcpass=%v", l.cfg.RPCPass))
-	args = append(args, fmt.Sprintf("--rawrpccert=%v", encodedCert))
+	args = append(args, fmt.Sprintf("--btcd.rpchost=%v", l.cfg.RPCHost))
+	args = append(args, fmt.Sprintf("--btcd.rpcuser=%v", l.cfg.RPCUser))
+	args = append(args, fmt.Sprintf("--btcd.rpcpass=%v", l.cfg.RPCPass))
+	args = append(args, fmt.Sprintf("--btcd.rawrpccert=%v", encodedCert))
 	args = append(args, fmt.Sprintf("--rpcport=%v", l.cfg.RPCPort))
 	args = append(args, fmt.Sprintf("--peerport=%v", l.cfg.PeerPort))
 	args = append(args, fmt.Sprintf("--logdir=%v", l.cfg.LogDir))
 	args = append(args, fmt.Sprintf("--datadir=%v", l.cfg.DataDir))
-	args = append(args, fmt.Sprintf("--simnet"))
+	args = append(args, fmt.Sprintf("--bitcoin.simnet"))
 
 	if l.extraArgs != nil {
 		args = append(args, l.extraArgs...)
@@ -211,6 +215,7 @@ func (l *lightningNode) start(lndError chan error) error {
 	}
 
 	l.LightningClient = lnrpc.NewLightningClient(conn)
+	l.DevClient = lnrpc.NewDevClient(conn)
 
 	// Obtain the lnid of this node for quick identification purposes.
 	ctxb := context.Background()
@@ -929,3 +934,78 @@ func (n *networkHarness) SendCoins(ctx context.Context, amt btcutil.Amount,
 		}
 	}
 }
+
+// AssertChannelClosed asserts that the channel identified by chanPoint is no
+// longer listed among the active channels of the passed node.
+func (n *networkHarness) AssertChannelClosed(ctx context.Context,
+	node *lightningNode, chanPoint *wire.OutPoint) error {
+
+	req := &lnrpc.ListChannelsRequest{}
+	resp, err := node.ListChannels(ctx, req)
+	if err != nil {
+		return fmt.Errorf("unable fetch node's channels: %v", err)
+	}
+
+	for _, channel := range resp.Channels {
+		if channel.ChannelPoint == chanPoint.String() {
+			return fmt.Errorf("channel %v still active", chanPoint)
+		}
+	}
+
+	return nil
+}
+
+// WaitForMempoolTxns polls the mempool of the mining node until it holds
+// exactly numTxns transactions, returning their hashes. If the passed context
+// has a timeout, then if the timeout is reached before the transactions are
+// seen, an error is returned.
+func (n *networkHarness) WaitForMempoolTxns(ctx context.Context,
+	numTxns int) ([]*chainhash.Hash, error) {
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	var mempool []*chainhash.Hash
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("expected %v txns in mempool, "+
+				"found %v", numTxns, len(mempool))
+
+		case <-ticker.C:
+			var err error
+			mempool, err = n.Miner.Node.GetRawMempool()
+			if err != nil {
+				return nil, fmt.Errorf("unable to fetch "+
+					"mempool: %v", err)
+			}
+			if len(mempool) == numTxns {
+				return mempool, nil
+			}
+		}
+	}
+}
+
+// ImpairConnection delays each message sent by node a to node b by the passed
+// latency plus up to jitter, and drops them with the passed probability, the
+// randomness seeded by seed so that a failing run may be reproduced. The
+// connection is impaired in that direction alone, and the impairment persists
+// across reconnections. Passing zero values restores the connection. Node a
+// must be built with the dev build tag.
+func (n *networkHarness) ImpairConnection(ctx context.Context,
+	a, b *lightningNode, latency, jitter time.Duration, dropRate float64,
+	seed int64) error {
+
+	req := &lnrpc.SetConnImpairmentRequest{
+		PubKey:    b.PubKeyStr,
+		LatencyMs: uint32(latency / time.Millisecond),
+		JitterMs:  uint32(jitter / time.Millisecond),
+		DropRate:  dropRate,
+		Seed:      seed,
+	}
+	if _, err := a.DevClient.SetConnImpairment(ctx, req); err != nil {
+		return fmt.Errorf("unable to impair connection: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,269 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/btcsuite/fastsha256"
+	"github.com/lightningnetwork/lightning-onion"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/wire"
+)
+
+const (
+	// numOnionCacheShards is the number of independently locked shards the
+	// entries of the onionResultCache are spread over, so that onions
+	// processed by different links rarely contend on the same lock.
+	numOnionCacheShards = 16
+
+	// maxOnionCacheEntries is the maximum number of entries held within
+	// the onionResultCache. Once a shard is full, the entry expiring the
+	// soonest is evicted to make room for a new one.
+	maxOnionCacheEntries = 16384
+
+	// maxOnionCacheDelta is the maximum number of blocks past our current
+	// height an entry is retained for, regardless of the expiry of the HTLC
+	// carrying the onion. Retransmissions happen upon reconnecting, so
+	// they're only expected shortly after the HTLC was first received.
+	maxOnionCacheDelta = 144
+)
+
+// onionCacheKey identifies an HTLC within the onionResultCache by the channel
+// it was received over and its index within the channel's log.
+type onionCacheKey struct {
+	chanPoint wire.OutPoint
+	htlcIndex uint32
+}
+
+// onionCacheEntry is the result of processing an onion, retained until the
+// HTLC carrying it expires.
+type onionCacheEntry struct {
+	digest [32]byte
+	packet *sphinx.ProcessedPacket
+	expiry uint32
+}
+
+// onionCacheShard is a single shard of the onionResultCache.
+type onionCacheShard struct {
+	mtx     sync.Mutex
+	entries map[onionCacheKey]*onionCacheEntry
+}
+
+// onionResultCache caches the result of processing each onion received within
+// an HTLC. The sphinx router rejects any onion it has processed before as a
+// replay, so without the cache an HTLC retransmitted by our peer, for example
+// after a reconnection, would be failed. The earlier result is only served for
+// the same onion and payment hash received at the same index of the same
+// channel, so a replay of the onion within any other HTLC is still rejected.
+// Entries are garbage collected once the CLTV of the HTLC carrying the onion
+// expires, or at most maxOnionCacheDelta blocks after it was received.
+type onionResultCache struct {
+	started  int32
+	shutdown int32
+
+	// bestHeight is the height of the latest block we know of.
+	bestHeight uint32
+
+	shards [numOnionCacheShards]onionCacheShard
+
+	notifier chainntnfs.ChainNotifier
+	bio      lnwallet.BlockChainIO
+
+	wg   sync.WaitGroup
+	quit chan struct{}
+}
+
+// newOnionResultCache creates a new, empty onionResultCache which garbage
+// collects expired entries as the passed notifier reports new blocks.
+func newOnionResultCache(notifier chainntnfs.ChainNotifier,
+	bio lnwallet.BlockChainIO) *onionResultCache {
+
+	c := &onionResultCache{
+		notifier: notifier,
+		bio:      bio,
+		quit:     make(chan struct{}),
+	}
+	for i := range c.shards {
+		c.shards[i].entries = make(map[onionCacheKey]*onionCacheEntry)
+	}
+
+	return c
+}
+
+// Start launches the goroutine garbage collecting expired entries.
+func (c *onionResultCache) Start() error {
+	if !atomic.CompareAndSwapInt32(&c.started, 0, 1) {
+		return nil
+	}
+
+	_, bestHeight, err := c.bio.GetBestBlock()
+	if err != nil {
+		return err
+	}
+	atomic.StoreUint32(&c.bestHeight, uint32(bestHeight))
+
+	blockEpochs, err := c.notifier.RegisterBlockEpochNtfn()
+	if err != nil {
+		return err
+	}
+
+	c.wg.Add(1)
+	go c.expiryWatcher(blockEpochs)
+
+	return nil
+}
+
+// Stop stops the garbage collection of expired entries.
+func (c *onionResultCache) Stop() error {
+	if !atomic.CompareAndSwapInt32(&c.shutdown, 0, 1) {
+		return nil
+	}
+
+	close(c.quit)
+	c.wg.Wait()
+
+	return nil
+}
+
+// expiryWatcher removes the entries of expired HTLC's from the cache with
+// each new block.
+//
+// NOTE: This MUST be run as a goroutine.
+func (c *onionResultCache) expiryWatcher(
+	blockEpochs *chainntnfs.BlockEpochEvent) {
+
+	defer c.wg.Done()
+	defer blockEpochs.Cancel()
+
+	for {
+		select {
+		case epoch, ok := <-blockEpochs.Epochs:
+			if !ok {
+				return
+			}
+
+			height := uint32(epoch.Height)
+			atomic.StoreUint32(&c.bestHeight, height)
+			c.removeExpired(height)
+
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// onionDigest returns the digest of the passed onion along with the payment
+// hash of the HTLC carrying it. The payment hash is included as it's
+// authenticated within the onion, so the same onion sent with a different
+// payment hash fails to be processed.
+func onionDigest(onionBlob, rHash []byte) [32]byte {
+	preimage := make([]byte, 0, len(rHash)+len(onionBlob))
+	preimage = append(preimage, rHash...)
+	preimage = append(preimage, onionBlob...)
+
+	return fastsha256.Sum256(preimage)
+}
+
+// processOnion returns the cached result of processing the passed onion,
+// carried by the HTLC at the passed index of the channel with the passed
+// channel point, which has the passed payment hash and expiry. If the onion
+// hasn't been processed for this HTLC before, it's processed with the passed
+// closure, and the result is cached if processing succeeded.
+func (c *onionResultCache) processOnion(chanPoint wire.OutPoint,
+	htlcIndex uint32, onionBlob, rHash []byte, expiry uint32,
+	process func() (*sphinx.ProcessedPacket, error)) (*sphinx.ProcessedPacket,
+	error) {
+
+	key := onionCacheKey{
+		chanPoint: chanPoint,
+		htlcIndex: htlcIndex,
+	}
+	digest := onionDigest(onionBlob, rHash)
+	shard := &c.shards[(uint32(chanPoint.Hash[0])+htlcIndex)%
+		numOnionCacheShards]
+
+	// The shard remains locked while the onion is processed, so that an
+	// onion retransmitted concurrently is only processed once.
+	shard.mtx.Lock()
+	defer shard.mtx.Unlock()
+
+	// A cached result is only served if the HTLC carries the very same
+	// onion and payment hash as when it was first received.
+	if entry, ok := shard.entries[key]; ok && entry.digest == digest {
+		return entry.packet, nil
+	}
+
+	packet, err := process()
+	if err != nil {
+		return nil, err
+	}
+
+	// The entry is retained no longer than maxOnionCacheDelta blocks past
+	// our own height, as the expiry of the HTLC is chosen by our peer.
+	maxExpiry := atomic.LoadUint32(&c.bestHeight) + maxOnionCacheDelta
+	if expiry > maxExpiry {
+		expiry = maxExpiry
+	}
+
+	if _, ok := shard.entries[key]; !ok &&
+		len(shard.entries) >= maxOnionCacheEntries/numOnionCacheShards {
+
+		shard.evictSoonestExpiry()
+	}
+	shard.entries[key] = &onionCacheEntry{
+		digest: digest,
+		packet: packet,
+		expiry: expiry,
+	}
+
+	return packet, nil
+}
+
+// evictSoonestExpiry removes the entry expiring the soonest from the shard.
+//
+// NOTE: The shard's mtx MUST be held when calling this method.
+func (s *onionCacheShard) evictSoonestExpiry() {
+	var (
+		soonestKey onionCacheKey
+		soonest    *onionCacheEntry
+	)
+	for key, entry := range s.entries {
+		if soonest == nil || entry.expiry < soonest.expiry {
+			soonestKey, soonest = key, entry
+		}
+	}
+	if soonest != nil {
+		delete(s.entries, soonestKey)
+	}
+}
+
+// removeExpired removes the entries of all HTLC's which have expired as of the
+// passed height.
+func (c *onionResultCache) removeExpired(height uint32) {
+	for i := range c.shards {
+		shard := &c.shards[i]
+
+		shard.mtx.Lock()
+		for key, entry := range shard.entries {
+			if entry.expiry <= height {
+				delete(shard.entries, key)
+			}
+		}
+		shard.mtx.Unlock()
+	}
+}
+
+// size returns the number of entries within the cache.
+func (c *onionResultCache) size() int {
+	var n int
+	for i := range c.shards {
+		shard := &c.shards[i]
+
+		shard.mtx.Lock()
+		n += len(shard.entries)
+		shard.mtx.Unlock()
+	}
+
+	return n
+}
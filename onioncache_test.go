@@ -0,0 +1,150 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lightningnetwork/lightning-onion"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// TestOnionResultCache asserts that an onion is only processed once for the
+// HTLC carrying it while the HTLC hasn't expired, and that failures to
+// process it aren't cached.
+func TestOnionResultCache(t *testing.T) {
+	t.Parallel()
+
+	cache := newOnionResultCache(nil, nil)
+	cache.bestHeight = 50
+
+	var numProcessed int
+	processErr := errors.New("unable to process onion")
+	process := func(fail bool) func() (*sphinx.ProcessedPacket, error) {
+		return func() (*sphinx.ProcessedPacket, error) {
+			numProcessed++
+			if fail {
+				return nil, processErr
+			}
+			return &sphinx.ProcessedPacket{}, nil
+		}
+	}
+
+	chanPoint := wire.OutPoint{Index: 1}
+	onion := []byte("onion")
+	rHash := []byte("payment hash")
+
+	// A failure to process the onion shouldn't be cached.
+	_, err := cache.processOnion(chanPoint, 0, onion, rHash, 100,
+		process(true))
+	if err != processErr {
+		t.Fatalf("expected processing error, got %v", err)
+	}
+	if cache.size() != 0 {
+		t.Fatalf("failure was cached")
+	}
+
+	// Once processed successfully, the same onion retransmitted within the
+	// same HTLC should be served from the cache.
+	packet, err := cache.processOnion(chanPoint, 0, onion, rHash, 100,
+		process(false))
+	if err != nil {
+		t.Fatalf("unable to process onion: %v", err)
+	}
+	cached, err := cache.processOnion(chanPoint, 0, onion, rHash, 100,
+		process(false))
+	if err != nil {
+		t.Fatalf("unable to process onion: %v", err)
+	}
+	if cached != packet || numProcessed != 2 {
+		t.Fatalf("onion wasn't served from the cache")
+	}
+
+	// The same onion replayed within another HTLC must be processed
+	// again, so the sphinx router can reject it.
+	_, err = cache.processOnion(chanPoint, 1, onion, rHash, 100,
+		process(false))
+	if err != nil {
+		t.Fatalf("unable to process onion: %v", err)
+	}
+	if numProcessed != 3 {
+		t.Fatalf("onion of other HTLC served from the cache")
+	}
+
+	// Neither can a different onion within the same HTLC be served the
+	// cached result.
+	_, err = cache.processOnion(chanPoint, 0, []byte("other onion"), rHash,
+		100, process(false))
+	if err != nil {
+		t.Fatalf("unable to process onion: %v", err)
+	}
+	if numProcessed != 4 {
+		t.Fatalf("other onion served from the cache")
+	}
+
+	// An HTLC expiring beyond maxOnionCacheDelta blocks past our height
+	// should only be retained for maxOnionCacheDelta blocks.
+	_, err = cache.processOnion(chanPoint, 2, onion, rHash, 10000,
+		process(false))
+	if err != nil {
+		t.Fatalf("unable to process onion: %v", err)
+	}
+	if cache.size() != 3 {
+		t.Fatalf("expected 3 entries, got %d", cache.size())
+	}
+
+	// Once the first HTLCs expire, only their entries should be removed.
+	cache.removeExpired(99)
+	if cache.size() != 3 {
+		t.Fatalf("entry removed before expiry")
+	}
+	cache.removeExpired(100)
+	if cache.size() != 1 {
+		t.Fatalf("expected 1 entry after expiry, got %d", cache.size())
+	}
+	cache.removeExpired(50 + maxOnionCacheDelta)
+	if cache.size() != 0 {
+		t.Fatalf("entry retained beyond maxOnionCacheDelta")
+	}
+}
+
+// TestOnionResultCacheCapacity asserts that the number of entries within the
+// cache is bounded, with the entries expiring the soonest evicted first.
+func TestOnionResultCacheCapacity(t *testing.T) {
+	t.Parallel()
+
+	cache := newOnionResultCache(nil, nil)
+	process := func() (*sphinx.ProcessedPacket, error) {
+		return &sphinx.ProcessedPacket{}, nil
+	}
+
+	var chanPoint wire.OutPoint
+	for i := uint32(0); i < maxOnionCacheEntries*2; i++ {
+		_, err := cache.processOnion(chanPoint, i, []byte("onion"),
+			[]byte("payment hash"), 10+i%maxOnionCacheDelta,
+			process)
+		if err != nil {
+			t.Fatalf("unable to process onion: %v", err)
+		}
+	}
+
+	if cache.size() != maxOnionCacheEntries {
+		t.Fatalf("expected %d entries, got %d", maxOnionCacheEntries,
+			cache.size())
+	}
+
+	// Once a shard is full, the entry expiring the soonest within it is
+	// evicted.
+	shard := &onionCacheShard{
+		entries: map[onionCacheKey]*onionCacheEntry{
+			{htlcIndex: 0}: {expiry: 30},
+			{htlcIndex: 1}: {expiry: 10},
+			{htlcIndex: 2}: {expiry: 20},
+		},
+	}
+	shard.evictSoonestExpiry()
+	if _, ok := shard.entries[onionCacheKey{htlcIndex: 1}]; ok ||
+		len(shard.entries) != 2 {
+
+		t.Fatalf("entry expiring the soonest wasn't evicted")
+	}
+}
@@ -1,6 +1,17 @@
 package main
 
-import "github.com/roasbeef/btcd/chaincfg"
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/roasbeef/btcd/chaincfg"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+)
 
 // activeNetParams is a pointer to the parameters specific to the currently
 // active bitcoin network.
@@ -24,3 +35,66 @@ var simNetParams = netParams{
 	Params:  &chaincfg.SimNetParams,
 	rpcPort: "18556",
 }
+
+// regTestNetParams contains parameters specific to a local regression test
+// network.
+var regTestNetParams = netParams{
+	Params:  &chaincfg.RegressionNetParams,
+	rpcPort: "18334",
+}
+
+// defaultSignetChallenge is the challenge script of the default, global
+// signet: a 1-of-2 multisig of the keys of its block signers.
+const defaultSignetChallenge = "512103ad5e0edad18cb1f0fc0d28a3d4f1f3e445640" +
+	"337489abb10404f2d1e086be430210359ef5021964fe22d6f8e05b2463c9540ce96" +
+	"883fe3b278760f048f5189f2e6c452ae"
+
+// signetPowLimit is the highest proof of work value a signet block can have.
+var signetPowLimit, _ = new(big.Int).SetString(
+	"00000377ae000000000000000000000000000000000000000000000000000000", 16,
+)
+
+// newSignetParams returns the parameters specific to the signet whose blocks
+// must satisfy the passed challenge script. All signets share the same
+// genesis block, but the magic bytes of their p2p messages are derived from
+// their challenge, so nodes of distinct signets don't connect to each other.
+func newSignetParams(challenge []byte) netParams {
+	// The magic bytes are the first four bytes of the double SHA256 of
+	// the length prefixed challenge.
+	var b bytes.Buffer
+	wire.WriteVarBytes(&b, 0, challenge)
+	magic := chainhash.DoubleHashB(b.Bytes())
+
+	// The genesis block only differs from that of the main network within
+	// its timestamp, difficulty and nonce.
+	genesis := *chaincfg.MainNetParams.GenesisBlock
+	genesis.Header.Timestamp = time.Unix(1598918400, 0)
+	genesis.Header.Bits = 0x1e0377ae
+	genesis.Header.Nonce = 52613770
+	genesisHash := genesis.BlockHash()
+
+	// Custom signets are named after their magic bytes, so the data of
+	// distinct signets is kept within distinct directories.
+	name := "signet"
+	if hex.EncodeToString(challenge) != defaultSignetChallenge {
+		name = fmt.Sprintf("signet-%x", magic[:4])
+	}
+
+	// Addresses and extended keys of the signet are encoded as those of
+	// the test network.
+	params := chaincfg.TestNet3Params
+	params.Name = name
+	params.Net = wire.BitcoinNet(binary.LittleEndian.Uint32(magic[:4]))
+	params.DefaultPort = "38333"
+	params.DNSSeeds = nil
+	params.GenesisBlock = &genesis
+	params.GenesisHash = &genesisHash
+	params.PowLimit = signetPowLimit
+	params.PowLimitBits = 0x1e0377ae
+	params.Checkpoints = nil
+
+	return netParams{
+		Params:  &params,
+		rpcPort: "38332",
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+// TestSignetParams asserts that the parameters of the default signet match
+// those of the global signet, and that custom signets are distinguished by
+// their magic bytes and name.
+func TestSignetParams(t *testing.T) {
+	challenge, err := hex.DecodeString(defaultSignetChallenge)
+	if err != nil {
+		t.Fatalf("unable to decode challenge: %v", err)
+	}
+	params := newSignetParams(challenge)
+
+	const genesisHash = "00000008819873e925422c1ff0f99f7cc9bbb232af63a0" +
+		"77a480a3633bee1ef6"
+	if params.GenesisHash.String() != genesisHash {
+		t.Fatalf("expected genesis hash %v, got %v", genesisHash,
+			params.GenesisHash)
+	}
+	if params.Net != wire.BitcoinNet(0x40cf030a) {
+		t.Fatalf("expected magic 0x40cf030a, got %#x",
+			uint32(params.Net))
+	}
+	if params.Name != "signet" {
+		t.Fatalf("expected name signet, got %v", params.Name)
+	}
+
+	// A custom signet shares the genesis block of the global signet, but
+	// not its magic bytes or name.
+	customParams := newSignetParams([]byte{0x51})
+	if *customParams.GenesisHash != *params.GenesisHash {
+		t.Fatalf("custom signet should share the genesis block")
+	}
+	if customParams.Net == params.Net {
+		t.Fatalf("custom signet should have distinct magic bytes")
+	}
+	if customParams.Name == params.Name {
+		t.Fatalf("custom signet should have a distinct name")
+	}
+
+	// The parameters of the test network must be left untouched.
+	if testNetParams.Name != "testnet3" {
+		t.Fatalf("test network parameters modified")
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+// paymentNotifier dispatches the updates to the records of our outgoing
+// payments to any subscribed clients, so that they may track the progress of
+// the payments.
+type paymentNotifier struct {
+	notifier *eventNotifier
+}
+
+// newPaymentNotifier creates a new paymentNotifier with no subscribed clients.
+func newPaymentNotifier() *paymentNotifier {
+	return &paymentNotifier{
+		notifier: newEventNotifier(defaultEventQueueSize),
+	}
+}
+
+// notifyPaymentUpdate hands off the payment hash of the payment whose record
+// was just updated to all currently registered clients.
+func (p *paymentNotifier) notifyPaymentUpdate(paymentHash [32]byte) {
+	p.notifier.notify(paymentHash)
+}
+
+// SubscribePaymentUpdates returns an eventSubscription which allows the caller
+// to receive async notifications of the updates to the records of our
+// outgoing payments. Each event sent over the subscription is the [32]byte
+// payment hash of the updated payment, whose record is to be looked up anew.
+func (p *paymentNotifier) SubscribePaymentUpdates() *eventSubscription {
+	return p.notifier.subscribe()
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// TestPaymentNotifierUpdates asserts that the payment hashes of updated
+// payments are delivered to subscribers in the order the payments were
+// updated.
+func TestPaymentNotifierUpdates(t *testing.T) {
+	notifier := newPaymentNotifier()
+	client := notifier.SubscribePaymentUpdates()
+	defer client.Cancel()
+
+	updates := [][32]byte{{1}, {2}, {1}}
+	for _, paymentHash := range updates {
+		notifier.notifyPaymentUpdate(paymentHash)
+	}
+
+	for i, expected := range updates {
+		select {
+		case e := <-client.Events:
+			if paymentHash := e.([32]byte); paymentHash != expected {
+				t.Fatalf("update #%v: expected payment %x, "+
+					"got %x", i, expected[:], paymentHash[:])
+			}
+		case <-time.After(time.Second * 5):
+			t.Fatalf("update #%v not received", i)
+		}
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"container/list"
 	"crypto/rand"
 	"encoding/binary"
-	"errors"
 	"fmt"
 	"net"
 	"sync"
@@ -16,6 +15,8 @@ import (
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lightning-onion"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/feature"
+	"github.com/lightningnetwork/lnd/hodl"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
@@ -35,6 +36,11 @@ const (
 	// pingInterval is the interval at which ping messages are sent.
 	pingInterval = 30 * time.Second
 
+	// handshakeTimeout is the maximum amount of time we'll wait for the
+	// remote peer to send its Init message after the connection has been
+	// established.
+	handshakeTimeout = 15 * time.Second
+
 	// outgoingQueueLen is the buffer size of the channel which houses
 	// messages to be sent across the wire, requested by objects outside
 	// this struct.
@@ -76,6 +82,13 @@ type peer struct {
 	inbound bool
 	id      int32
 
+	// remoteGlobalFeatures and remoteLocalFeatures are the feature
+	// vectors sent by the remote peer within its Init message. They're
+	// set once during Start, before any of the helper goroutines are
+	// launched.
+	remoteGlobalFeatures *lnwire.FeatureVector
+	remoteLocalFeatures  *lnwire.FeatureVector
+
 	// For purposes of detecting retransmits, etc.
 	lastNMessages map[lnwire.Message]struct{}
 
@@ -113,6 +126,10 @@ type peer struct {
 	htlcManMtx   sync.RWMutex
 	htlcManagers map[wire.OutPoint]chan lnwire.Message
 
+	// linkControls maps each active channel to the channel used to control
+	// its htlcManager out of band. It's guarded by the htlcManMtx.
+	linkControls map[wire.OutPoint]chan *linkControlReq
+
 	// newChanBarriers is a map from a channel point to a 'barrier' which
 	// will be signalled once the channel is fully open. This barrier acts
 	// as a synchronization point for any incoming/outgoing HTLCs before
@@ -134,6 +151,12 @@ type peer struct {
 	// over.
 	remoteCloseChanReqs chan *lnwire.CloseRequest
 
+	// closeRejections maps the channel point of each cooperative close we
+	// initiated, and which is yet to confirm, to a channel over which a
+	// rejection of the close by the remote peer is delivered.
+	closeRejections   map[wire.OutPoint]chan error
+	closeRejectionMtx sync.Mutex
+
 	// nextPendingChannelID is an integer which represents the id of the
 	// next pending channel. Pending channels are tracked by this id
 	// throughout their lifetime until they become active channels, or are
@@ -177,11 +200,13 @@ func newPeer(conn net.Conn, server *server, addr *lnwire.NetAddress,
 		newChanBarriers:  make(map[wire.OutPoint]chan struct{}),
 		activeChannels:   make(map[wire.OutPoint]*lnwallet.LightningChannel),
 		htlcManagers:     make(map[wire.OutPoint]chan lnwire.Message),
+		linkControls:     make(map[wire.OutPoint]chan *linkControlReq),
 		chanSnapshotReqs: make(chan *chanSnapshotReq),
 		newChannels:      make(chan *lnwallet.LightningChannel, 1),
 
 		localCloseChanReqs:  make(chan *closeLinkReq),
 		remoteCloseChanReqs: make(chan *lnwire.CloseRequest),
+		closeRejections:     make(map[wire.OutPoint]chan error),
 
 		queueQuit: make(chan struct{}),
 		quit:      make(chan struct{}),
@@ -244,12 +269,20 @@ func (p *peer) loadActiveChannels(chans []*channeldb.OpenChannel) error {
 			dbChan.Snapshot(), downstreamLink)
 
 		upstreamLink := make(chan lnwire.Message, 10)
+		controls := make(chan *linkControlReq)
 		p.htlcManMtx.Lock()
 		p.htlcManagers[chanPoint] = upstreamLink
+		p.linkControls[chanPoint] = controls
 		p.htlcManMtx.Unlock()
 
 		p.wg.Add(1)
-		go p.htlcManager(lnChan, plexChan, downstreamLink, upstreamLink)
+		go p.htlcManager(lnChan, plexChan, downstreamLink, upstreamLink,
+			controls)
+
+		p.server.channelNotifier.notifyChannelEvent(
+			lnrpc.ChannelEventUpdate_ACTIVE_CHANNEL, chanPoint,
+			p.addr.IdentityKey,
+		)
 	}
 
 	return nil
@@ -267,6 +300,13 @@ func (p *peer) Start() error {
 
 	peerLog.Tracef("peer %v starting", p)
 
+	// Before we launch any of the helper goroutines off the peer struct,
+	// we'll first ensure proper adherence to the p2p protocol. The Init
+	// message MUST be the first message exchanged by both sides.
+	if err := p.exchangeInitMsgs(); err != nil {
+		return err
+	}
+
 	p.wg.Add(5)
 	go p.readHandler()
 	go p.queueHandler()
@@ -274,9 +314,185 @@ func (p *peer) Start() error {
 	go p.channelManager()
 	go p.pingHandler()
 
+	// If we've restored any channels with the peer from a static backup,
+	// then we'll request that it closes them now that it's online.
+	p.requestRestoredChanClosures()
+
 	return nil
 }
 
+// requestRestoredChanClosures asks the peer to force close each channel with
+// it which we've restored from a static channel backup. As the state of these
+// channels was lost, only the peer is able to close them, allowing our funds
+// within them to be recovered.
+func (p *peer) requestRestoredChanClosures() {
+	shells, err := p.server.chanDB.FetchChannelShells(p.addr.IdentityKey)
+	if err != nil {
+		peerLog.Errorf("unable to fetch restored channels of "+
+			"peerID(%v): %v", p.id, err)
+		return
+	}
+
+	for _, shell := range shells {
+		chanPoint := shell.ChanPoint
+
+		peerLog.Infof("Requesting peerID(%v) to force close restored "+
+			"ChannelPoint(%v)", p.id, chanPoint)
+
+		p.queueMsg(&lnwire.ErrorGeneric{
+			ChannelPoint: &chanPoint,
+			Problem: "channel state was lost, please force " +
+				"close the channel",
+			Code: lnwire.ErrorChanStateLost,
+		}, nil)
+	}
+}
+
+// handleChanStateLost force closes the channel the peer reported to have
+// lost the state of, after restoring it from a static channel backup. As the
+// peer is unable to close the channel itself, broadcasting our latest
+// commitment transaction is the only way for its funds to be recovered.
+func (p *peer) handleChanStateLost(msg *lnwire.ErrorGeneric) {
+	if msg.ChannelPoint == nil {
+		return
+	}
+	chanPoint := *msg.ChannelPoint
+
+	// Only a channel we have with this peer may be closed on its request.
+	p.activeChanMtx.RLock()
+	_, ok := p.activeChannels[chanPoint]
+	p.activeChanMtx.RUnlock()
+	if !ok {
+		peerLog.Warnf("PeerID(%v) lost the state of unknown "+
+			"ChannelPoint(%v)", p.id, chanPoint)
+		return
+	}
+
+	peerLog.Infof("PeerID(%v) lost the state of ChannelPoint(%v), force "+
+		"closing channel", p.id, chanPoint)
+
+	go func() {
+		updates, errChan := p.server.htlcSwitch.CloseLink(
+			&chanPoint, CloseForce,
+		)
+		for {
+			select {
+			case update := <-updates:
+				if update.GetChanClose() != nil {
+					return
+				}
+			case err := <-errChan:
+				peerLog.Errorf("Unable to force close "+
+					"ChannelPoint(%v) with lost state: %v",
+					chanPoint, err)
+				return
+			case <-p.quit:
+				return
+			}
+		}
+	}()
+}
+
+// exchangeInitMsgs sends our Init message to the remote peer, then waits for
+// the remote peer's Init message in return. The remote peer's features are
+// validated, and an error is returned if it requires a feature we don't
+// understand, or advertises a feature without its dependencies.
+func (p *peer) exchangeInitMsgs() error {
+	featureMgr := p.server.featureMgr
+	localInit := lnwire.NewInitMessage(
+		featureMgr.Get(feature.SetNodeAnn),
+		featureMgr.Get(feature.SetInit),
+	)
+	if err := p.writeMessage(localInit); err != nil {
+		return fmt.Errorf("unable to send init message: %v", err)
+	}
+
+	err := p.conn.SetReadDeadline(time.Now().Add(handshakeTimeout))
+	if err != nil {
+		return err
+	}
+	msg, _, err := p.readNextMessage()
+	if err != nil {
+		return fmt.Errorf("unable to read init message: %v", err)
+	}
+	if err := p.conn.SetReadDeadline(time.Time{}); err != nil {
+		return err
+	}
+
+	remoteInit, ok := msg.(*lnwire.Init)
+	if !ok {
+		return fmt.Errorf("expected init message as first message, "+
+			"instead got %T", msg)
+	}
+
+	err = feature.ValidateRemote(remoteInit.GlobalFeatures)
+	if err != nil {
+		return fmt.Errorf("invalid global features: %v", err)
+	}
+	if err := feature.ValidateRemote(remoteInit.LocalFeatures); err != nil {
+		return fmt.Errorf("invalid local features: %v", err)
+	}
+
+	p.remoteGlobalFeatures = remoteInit.GlobalFeatures
+	p.remoteLocalFeatures = remoteInit.LocalFeatures
+
+	peerLog.Debugf("Received init from %v: global=%v, local=%v", p,
+		p.remoteGlobalFeatures, p.remoteLocalFeatures)
+
+	return nil
+}
+
+// supportsWumbo returns true if both we and the remote peer have signalled
+// that we're willing to open and accept channels larger than 2^24 satoshis.
+func (p *peer) supportsWumbo() bool {
+	if p.remoteLocalFeatures == nil {
+		return false
+	}
+
+	localFeatures := p.server.featureMgr.Get(feature.SetInit)
+	return feature.SupportsWumbo(localFeatures) &&
+		feature.SupportsWumbo(p.remoteLocalFeatures)
+}
+
+// supportsUpfrontShutdown returns true if both we and the remote peer have
+// signalled that we commit to our delivery scripts during the funding
+// workflow, and enforce them upon a cooperative close.
+func (p *peer) supportsUpfrontShutdown() bool {
+	if p.remoteLocalFeatures == nil {
+		return false
+	}
+
+	localFeatures := p.server.featureMgr.Get(feature.SetInit)
+	return feature.SupportsUpfrontShutdown(localFeatures) &&
+		feature.SupportsUpfrontShutdown(p.remoteLocalFeatures)
+}
+
+// supportsStaticRemoteKey returns true if both we and the remote peer have
+// signalled that we understand commitments paying the balance of the remote
+// party to a static key.
+func (p *peer) supportsStaticRemoteKey() bool {
+	if p.remoteLocalFeatures == nil {
+		return false
+	}
+
+	localFeatures := p.server.featureMgr.Get(feature.SetInit)
+	return feature.SupportsStaticRemoteKey(localFeatures) &&
+		feature.SupportsStaticRemoteKey(p.remoteLocalFeatures)
+}
+
+// supportsQuiescence returns true if both we and the remote peer have
+// signalled that we understand the Stfu message, so our channels may be
+// quiesced.
+func (p *peer) supportsQuiescence() bool {
+	if p.remoteLocalFeatures == nil {
+		return false
+	}
+
+	localFeatures := p.server.featureMgr.Get(feature.SetInit)
+	return feature.SupportsQuiescence(localFeatures) &&
+		feature.SupportsQuiescence(p.remoteLocalFeatures)
+}
+
 // Stop signals the peer for a graceful shutdown. All active goroutines will be
 // signaled to wrap up any final actions. This function will also block until
 // all goroutines have exited.
@@ -324,6 +540,16 @@ func (p *peer) Disconnect() {
 		// links associated with this interface should be closed.
 		p.server.htlcSwitch.UnregisterLink(p.addr.IdentityKey, nil)
 
+		// Each of our channels with this peer is now inactive.
+		p.activeChanMtx.RLock()
+		for chanPoint := range p.activeChannels {
+			p.server.channelNotifier.notifyChannelEvent(
+				lnrpc.ChannelEventUpdate_INACTIVE_CHANNEL,
+				chanPoint, p.addr.IdentityKey,
+			)
+		}
+		p.activeChanMtx.RUnlock()
+
 		p.server.donePeers <- p
 	}()
 }
@@ -386,6 +612,24 @@ out:
 			p.remoteCloseChanReqs <- msg
 
 		case *lnwire.ErrorGeneric:
+			// A rejection of our close request isn't related to
+			// any funding workflow, so it's only logged.
+			if msg.Code == lnwire.ErrorUpfrontShutdownMismatch {
+				peerLog.Errorf("Cooperative close of "+
+					"ChannelPoint(%v) rejected by peerID(%v): %v",
+					msg.ChannelPoint, p.id, msg.Problem)
+				p.rejectLocalClose(msg)
+				break
+			}
+
+			// Neither is a peer having lost the state of a
+			// channel, which we'll force close so its funds can
+			// be recovered.
+			if msg.Code == lnwire.ErrorChanStateLost {
+				p.handleChanStateLost(msg)
+				break
+			}
+
 			p.server.fundingMgr.processErrorGeneric(msg, p)
 
 		// TODO(roasbeef): create ChanUpdater interface for the below
@@ -404,6 +648,21 @@ out:
 		case *lnwire.CommitSignature:
 			isChanUpdate = true
 			targetChan = msg.ChannelPoint
+		case *lnwire.Stfu:
+			isChanUpdate = true
+			targetChan = msg.ChannelPoint
+		case *lnwire.DynPropose:
+			isChanUpdate = true
+			targetChan = msg.ChannelPoint
+		case *lnwire.DynAck:
+			isChanUpdate = true
+			targetChan = msg.ChannelPoint
+		case *lnwire.DynReject:
+			isChanUpdate = true
+			targetChan = msg.ChannelPoint
+		case *lnwire.DynReestablish:
+			isChanUpdate = true
+			targetChan = msg.ChannelPoint
 
 		case *lnwire.NodeAnnouncement,
 			*lnwire.ChannelAnnouncement,
@@ -411,6 +670,9 @@ out:
 
 			p.server.chanRouter.ProcessRoutingMessage(msg,
 				p.addr.IdentityKey)
+
+		case *lnwire.Custom:
+			p.server.customMessages.deliver(p.addr.IdentityKey, msg)
 		}
 
 		if isChanUpdate {
@@ -476,9 +738,23 @@ func (p *peer) writeMessage(msg lnwire.Message) error {
 // writeHandler is a goroutine dedicated to reading messages off of an incoming
 // queue, and writing them out to the wire. This goroutine coordinates with the
 // queueHandler in order to ensure the incoming message queue is quickly drained.
+// If the connection to the peer is impaired, then messages are held back
+// within a delay queue, and written out once their delays elapse, so a delayed
+// message holds up neither the messages queued behind it, nor our shutdown.
 //
 // NOTE: This method MUST be run as a goroutine.
 func (p *peer) writeHandler() {
+	pubStr := string(p.addr.IdentityKey.SerializeCompressed())
+
+	// The delay timer fires once the message at the head of the delay
+	// queue is due to be written. It's only armed while the queue holds
+	// messages, delayChan being nil otherwise.
+	delayed := newDelayQueue()
+	delayTimer := time.NewTimer(time.Hour)
+	delayTimer.Stop()
+	defer delayTimer.Stop()
+	var delayChan <-chan time.Time
+
 out:
 	for {
 		select {
@@ -487,7 +763,35 @@ out:
 			// TODO(roasbeef): handle special write cases
 			}
 
-			if err := p.writeMessage(outMsg.msg); err != nil {
+			// If the connection to the peer is impaired, or messages
+			// delayed while it was are yet to be written, then the
+			// message is queued behind them so that messages are
+			// still written in order. Otherwise, it's written
+			// straight away.
+			impairment := p.server.connImpairment(pubStr)
+			if impairment != nil || !delayed.empty() {
+				var (
+					delay time.Duration
+					drop  bool
+				)
+				if impairment != nil {
+					delay, drop = impairment.next(outMsg.msg)
+				}
+
+				switch {
+				case drop:
+					peerLog.Debugf("Dropping %T to %v due to "+
+						"impaired connection", outMsg.msg, p)
+
+				case delayed.empty():
+					delayed.push(outMsg.msg, delay, time.Now())
+					delayTimer.Reset(delay)
+					delayChan = delayTimer.C
+
+				default:
+					delayed.push(outMsg.msg, delay, time.Now())
+				}
+			} else if err := p.writeMessage(outMsg.msg); err != nil {
 				peerLog.Errorf("unable to write message: %v", err)
 				p.Disconnect()
 				break out
@@ -495,6 +799,25 @@ out:
 
 			// Synchronize with the writeHandler.
 			p.sendQueueSync <- struct{}{}
+
+		case <-delayChan:
+			for _, msg := range delayed.popReleased(time.Now()) {
+				if err := p.writeMessage(msg); err != nil {
+					peerLog.Errorf("unable to write message: %v",
+						err)
+					p.Disconnect()
+					break out
+				}
+			}
+
+			// Re-arm the timer for the next delayed message, if
+			// any.
+			delayChan = nil
+			if next, ok := delayed.nextRelease(); ok {
+				delayTimer.Reset(next.Sub(time.Now()))
+				delayChan = delayTimer.C
+			}
+
 		case <-p.quit:
 			break out
 		}
@@ -617,6 +940,22 @@ func (p *peer) ChannelSnapshots() []*channeldb.ChannelSnapshot {
 	return <-resp
 }
 
+// ChannelBalance breaks down the funds of the active channel with the passed
+// funding outpoint as of the current view of its state machine. False is
+// returned if no such channel is active with the peer.
+func (p *peer) ChannelBalance(chanPoint wire.OutPoint) (*lnwallet.ChannelBalance,
+	bool) {
+
+	p.activeChanMtx.RLock()
+	channel, ok := p.activeChannels[chanPoint]
+	p.activeChanMtx.RUnlock()
+	if !ok {
+		return nil, false
+	}
+
+	return channel.Balance(), true
+}
+
 // channelManager is goroutine dedicated to handling all requests/signals
 // pertaining to the opening, cooperative closing, and force closing of all
 // channels maintained with the remote peer.
@@ -669,12 +1008,20 @@ out:
 			// a goroutine to handle commitment updates for this
 			// new channel.
 			upstreamLink := make(chan lnwire.Message, 10)
+			controls := make(chan *linkControlReq)
 			p.htlcManMtx.Lock()
 			p.htlcManagers[chanPoint] = upstreamLink
+			p.linkControls[chanPoint] = controls
 			p.htlcManMtx.Unlock()
 
 			p.wg.Add(1)
-			go p.htlcManager(newChan, plexChan, downstreamLink, upstreamLink)
+			go p.htlcManager(newChan, plexChan, downstreamLink,
+				upstreamLink, controls)
+
+			p.server.channelNotifier.notifyChannelEvent(
+				lnrpc.ChannelEventUpdate_ACTIVE_CHANNEL,
+				chanPoint, p.addr.IdentityKey,
+			)
 
 			// Close the active channel barrier signalling the
 			// readHandler that commitment related modifications to
@@ -726,12 +1073,45 @@ func (p *peer) executeCooperativeClose(channel *lnwallet.LightningChannel) (*cha
 	if err != nil {
 		return nil, err
 	}
-	closeReq := lnwire.NewCloseRequest(chanPoint, closeSig)
+
+	// Our delivery script is only included if the remote peer enforces
+	// upfront shutdown scripts, as it's otherwise unaware of the field.
+	var deliveryScript lnwire.PkScript
+	if p.supportsUpfrontShutdown() {
+		deliveryScript = channel.LocalDeliveryScript
+	}
+	closeReq := lnwire.NewCloseRequest(chanPoint, closeSig, deliveryScript)
 	p.queueMsg(closeReq, nil)
 
 	return txid, nil
 }
 
+// executeForceClose unilaterally closes the channel by broadcasting our
+// latest commitment transaction. Its outputs are then handed to the
+// utxoNursery to be swept once mature, while the switch watches the outgoing
+// HTLC outputs in order to resolve the incoming HTLC's they were forwarded
+// from.
+func (p *peer) executeForceClose(channel *lnwallet.LightningChannel) (*chainhash.Hash, error) {
+	closeSummary, err := channel.ForceClose()
+	if err != nil {
+		return nil, err
+	}
+
+	closeTx := closeSummary.CloseTx
+	txid := closeTx.TxHash()
+
+	chanPoint := channel.ChannelPoint()
+	label := lnwallet.MakeLabel(lnwallet.LabelTypeChannelClose, chanPoint)
+	if err := p.server.lnwallet.PublishAndLabel(closeTx, label); err != nil {
+		return nil, err
+	}
+
+	p.server.utxoNursery.incubateOutputs(closeSummary, nil)
+	p.server.htlcSwitch.watchHtlcTimeouts(closeSummary)
+
+	return &txid, nil
+}
+
 // handleLocalClose kicks-off the workflow to execute a cooperative or forced
 // unilateral closure of the channel initiated by a local sub-system.
 // TODO(roasbeef): if no more active channels with peer call Remove on connMgr
@@ -740,6 +1120,7 @@ func (p *peer) handleLocalClose(req *closeLinkReq) {
 	var (
 		err         error
 		closingTxid *chainhash.Hash
+		rejected    chan error
 	)
 
 	p.activeChanMtx.RLock()
@@ -751,11 +1132,24 @@ func (p *peer) handleLocalClose(req *closeLinkReq) {
 	// out this channel on-chian, so we execute the cooperative channel
 	// closre workflow.
 	case CloseRegular:
+		// The remote peer may reject our request, in which case the
+		// closing transaction will never confirm, so we'll watch for
+		// a rejection before sending the request.
+		rejected = p.watchCloseRejection(*req.chanPoint)
+
 		closingTxid, err = p.executeCooperativeClose(channel)
 		peerLog.Infof("Attempting cooperative close of "+
 			"ChannelPoint(%v) with txid: %v", req.chanPoint,
 			closingTxid)
 
+	// A type of CloseForce indicates that a local sub-system requires the
+	// channel to be resolved on-chain, so we broadcast our latest
+	// commitment transaction.
+	case CloseForce:
+		closingTxid, err = p.executeForceClose(channel)
+		peerLog.Infof("Force closing ChannelPoint(%v) with txid: %v",
+			req.chanPoint, closingTxid)
+
 	// A type of CloseBreach indicates that the counter-party has breached
 	// the cahnnel therefore we need to clean up our local state.
 	case CloseBreach:
@@ -770,6 +1164,7 @@ func (p *peer) handleLocalClose(req *closeLinkReq) {
 		return
 	}
 	if err != nil {
+		p.unwatchCloseRejection(*req.chanPoint)
 		req.err <- err
 		return
 	}
@@ -788,6 +1183,8 @@ func (p *peer) handleLocalClose(req *closeLinkReq) {
 	// ChainNotifier once the closure transaction obtains a single
 	// confirmation.
 	go func() {
+		defer p.unwatchCloseRejection(*req.chanPoint)
+
 		// TODO(roasbeef): add param for num needed confs
 		notifier := p.server.chainNotifier
 		confNtfn, err := notifier.RegisterConfirmationsNtfn(closingTxid, 1)
@@ -797,6 +1194,10 @@ func (p *peer) handleLocalClose(req *closeLinkReq) {
 		}
 
 		select {
+		case err := <-rejected:
+			req.err <- err
+			return
+
 		case height, ok := <-confNtfn.Confirmed:
 			// In the case that the ChainNotifier is shutting down,
 			// all subscriber notification channels will be closed,
@@ -832,6 +1233,70 @@ func (p *peer) handleLocalClose(req *closeLinkReq) {
 	}()
 }
 
+// watchCloseRejection returns a channel over which a rejection of our request
+// to cooperatively close the target channel is delivered.
+func (p *peer) watchCloseRejection(chanPoint wire.OutPoint) chan error {
+	rejected := make(chan error, 1)
+
+	p.closeRejectionMtx.Lock()
+	p.closeRejections[chanPoint] = rejected
+	p.closeRejectionMtx.Unlock()
+
+	return rejected
+}
+
+// unwatchCloseRejection stops watching for a rejection of our request to
+// cooperatively close the target channel.
+func (p *peer) unwatchCloseRejection(chanPoint wire.OutPoint) {
+	p.closeRejectionMtx.Lock()
+	delete(p.closeRejections, chanPoint)
+	p.closeRejectionMtx.Unlock()
+}
+
+// rejectLocalClose fails our pending request to cooperatively close the
+// channel referenced by the passed error, which the remote peer rejected. As
+// the channel has already been marked as closing, it can then only be force
+// closed.
+func (p *peer) rejectLocalClose(msg *lnwire.ErrorGeneric) {
+	if msg.ChannelPoint == nil {
+		return
+	}
+
+	p.closeRejectionMtx.Lock()
+	rejected, ok := p.closeRejections[*msg.ChannelPoint]
+	delete(p.closeRejections, *msg.ChannelPoint)
+	p.closeRejectionMtx.Unlock()
+	if !ok {
+		return
+	}
+
+	rejected <- fmt.Errorf("cooperative close of ChannelPoint(%v) "+
+		"rejected by remote peer: %v, the channel can only be force "+
+		"closed", msg.ChannelPoint, msg.Problem)
+}
+
+// verifyCloseDeliveryScript ensures that a cooperative close requested by
+// the remote peer pays its funds to the delivery script it committed to
+// during the funding workflow. Peers which don't support upfront shutdown
+// scripts omit their delivery script from the request, in which case the
+// closing transaction still pays the committed script, as it's the one the
+// requester's signature must be valid for.
+func verifyCloseDeliveryScript(req *lnwire.CloseRequest,
+	committedScript []byte) error {
+
+	if req.DeliveryPkScript == nil {
+		return nil
+	}
+
+	if !bytes.Equal(req.DeliveryPkScript, committedScript) {
+		return fmt.Errorf("delivery script %x doesn't match upfront "+
+			"shutdown script %x", req.DeliveryPkScript,
+			committedScript)
+	}
+
+	return nil
+}
+
 // handleRemoteClose completes a request for cooperative channel closure
 // initiated by the remote node.
 func (p *peer) handleRemoteClose(req *lnwire.CloseRequest) {
@@ -845,6 +1310,23 @@ func (p *peer) handleRemoteClose(req *lnwire.CloseRequest) {
 	channel := p.activeChannels[key]
 	p.activeChanMtx.RUnlock()
 
+	// The delivery scripts of both parties were committed to upfront
+	// during the funding workflow, so we'll reject any request to pay the
+	// remote party's funds elsewhere.
+	err := verifyCloseDeliveryScript(req, channel.RemoteDeliveryScript)
+	if err != nil {
+		peerLog.Errorf("Rejecting cooperative close of "+
+			"ChannelPoint(%v): %v", chanPoint, err)
+
+		p.queueMsg(&lnwire.ErrorGeneric{
+			ChannelPoint: chanPoint,
+			Problem: "delivery script doesn't match upfront " +
+				"shutdown script",
+			Code: lnwire.ErrorUpfrontShutdownMismatch,
+		}, nil)
+		return
+	}
+
 	// Now that we have their signature for the closure transaction, we
 	// can assemble the final closure transaction, complete with our
 	// signature.
@@ -865,7 +1347,8 @@ func (p *peer) handleRemoteClose(req *lnwire.CloseRequest) {
 		}))
 
 	// Finally, broadcast the closure transaction, to the network.
-	if err := p.server.lnwallet.PublishTransaction(closeTx); err != nil {
+	label := lnwallet.MakeLabel(lnwallet.LabelTypeChannelClose, &key)
+	if err := p.server.lnwallet.PublishAndLabel(closeTx, label); err != nil {
 		peerLog.Errorf("channel close tx from "+
 			"ChannelPoint(%v) rejected: %v",
 			chanPoint, err)
@@ -884,7 +1367,9 @@ func (p *peer) handleRemoteClose(req *lnwire.CloseRequest) {
 }
 
 // wipeChannel removes the passed channel from all indexes associated with the
-// peer, and deletes the channel from the database.
+// peer, and deletes the channel from the database. The output paying to us on
+// the closing transaction needs no sweeping, as our payment keys are imported
+// into the wallet, which credits the output to itself once it confirms.
 func wipeChannel(p *peer, channel *lnwallet.LightningChannel) error {
 	chanID := channel.ChannelPoint()
 
@@ -919,6 +1404,7 @@ func wipeChannel(p *peer, channel *lnwallet.LightningChannel) error {
 	// above.
 	p.htlcManMtx.RLock()
 	delete(p.htlcManagers, *chanID)
+	delete(p.linkControls, *chanID)
 	p.htlcManMtx.RUnlock()
 
 	// Finally, we purge the channel's state from the database, leaving a
@@ -929,6 +1415,15 @@ func wipeChannel(p *peer, channel *lnwallet.LightningChannel) error {
 		return err
 	}
 
+	p.server.channelNotifier.notifyChannelEvent(
+		lnrpc.ChannelEventUpdate_INACTIVE_CHANNEL, *chanID,
+		p.addr.IdentityKey,
+	)
+	p.server.channelNotifier.notifyChannelEvent(
+		lnrpc.ChannelEventUpdate_CLOSED_CHANNEL, *chanID,
+		p.addr.IdentityKey,
+	)
+
 	return nil
 }
 
@@ -944,6 +1439,99 @@ type pendingPayment struct {
 	err chan error
 }
 
+// linkControlOp is an out of band operation applied to the htlcManager of a
+// channel.
+type linkControlOp uint8
+
+const (
+	// linkQuiesce stops the htlcManager from taking new packets from the
+	// switch, and quiesces the channel through the stfu handshake with
+	// the remote peer. The operation completes once the channel is
+	// quiescent, or fails if it's resumed before it becomes so.
+	linkQuiesce linkControlOp = iota
+
+	// linkResume ends the quiescence of the channel, resuming taking
+	// packets from the switch.
+	linkResume
+
+	// linkForceCommit signs a new commitment for the remote peer, whether
+	// or not any updates are pending.
+	linkForceCommit
+
+	// linkUpgradeCommitment quiesces the channel, then negotiates an
+	// upgrade of its commitment type with the remote peer. The operation
+	// completes once the upgrade has been agreed upon, after which the
+	// channel is resumed.
+	linkUpgradeCommitment
+
+	// linkShutdown drains the channel ahead of the daemon shutting down:
+	// new HTLC's, whether offered by the switch or the remote peer, are
+	// failed. The operation completes once all pending updates have been
+	// signed and revoked by both parties.
+	linkShutdown
+)
+
+// String returns a human readable name of the operation.
+func (o linkControlOp) String() string {
+	switch o {
+	case linkQuiesce:
+		return "quiesce"
+	case linkResume:
+		return "resume"
+	case linkForceCommit:
+		return "force_commit"
+	case linkUpgradeCommitment:
+		return "upgrade_commitment"
+	case linkShutdown:
+		return "shutdown"
+	default:
+		return "unknown"
+	}
+}
+
+// linkControlReq is a request to apply an operation to the htlcManager of a
+// channel. The result of the operation is sent over the err channel.
+type linkControlReq struct {
+	op linkControlOp
+
+	// timeout, if non-zero, overrides the default duration after which a
+	// quiesced channel is automatically resumed. It's only used by the
+	// linkQuiesce operation.
+	timeout time.Duration
+
+	// commitType is the commitment type the channel is to be upgraded to
+	// by the linkUpgradeCommitment operation.
+	commitType channeldb.CommitmentType
+
+	err chan error
+}
+
+// controlLink applies the passed operation to the htlcManager of the channel
+// with the passed funding outpoint, blocking until it's been applied.
+func (p *peer) controlLink(chanPoint wire.OutPoint, req *linkControlReq) error {
+	p.htlcManMtx.RLock()
+	controls, ok := p.linkControls[chanPoint]
+	p.htlcManMtx.RUnlock()
+	if !ok {
+		return fmt.Errorf("channel %v isn't active", chanPoint)
+	}
+
+	req.err = make(chan error, 1)
+
+	select {
+	case controls <- req:
+	case <-p.quit:
+		return fmt.Errorf("peer shutting down")
+	}
+
+	select {
+	case err := <-req.err:
+		return err
+	case <-p.quit:
+		return fmt.Errorf("peer shutting down")
+	}
+}
+
 // commitmentState is the volatile+persistent state of an active channel's
 // commitment update state-machine. This struct is used by htlcManager's to
 // save meta-state required for proper functioning.
@@ -984,10 +1572,9 @@ type commitmentState struct {
 	// forwarding.
 	switchChan chan<- *htlcPacket
 
-	// sphinx is an instance of the Sphinx onion Router for this node. The
-	// router will be used to process all incoming Sphinx packets embedded
-	// within HTLC add messages.
-	sphinx *sphinx.Router
+	// sphinx processes all incoming Sphinx packets embedded within HTLC
+	// add messages with the key of this node.
+	sphinx onionProcessor
 
 	// pendingCircuits tracks the remote log index of the incoming HTLC's,
 	// mapped to the processed Sphinx packet contained within the HTLC.
@@ -997,8 +1584,56 @@ type commitmentState struct {
 	// along with the HTLC to forward the packet to the next hop.
 	pendingCircuits map[uint32]*sphinx.ProcessedPacket
 
+	// quiescer drives the quiescence handshake of the channel. While it
+	// blocks updates, no packets are taken from the switch.
+	quiescer *quiescer
+
+	// pendingUpgrade is the commitment type upgrade we initiated, if
+	// any, which is being negotiated with the remote peer.
+	pendingUpgrade *commitUpgrade
+
+	// reconcilingUpgrade is true while a commitment type upgrade we
+	// recorded is being reconciled with the remote peer, following the
+	// reestablishment of the channel. No commitment is signed until then,
+	// as its format is yet unknown.
+	reconcilingUpgrade bool
+
+	// unresolvedExitHtlcs is the set of HTLC's we're the final
+	// destination of, identified by their log index, which are still
+	// being checked against our invoices. Each is mapped to whether it
+	// has been locked in by both commitment transactions in the meantime.
+	unresolvedExitHtlcs map[uint32]bool
+
+	// exitResolutions is the channel the outcome of the checks of each
+	// unresolved exit HTLC is delivered over.
+	exitResolutions chan *exitHtlcResolution
+
+	// deferredResolutions are the settles and cancels of locked in
+	// HTLC's which were due while we had sent Stfu, and so mustn't add
+	// any updates. They're applied once the channel is resumed.
+	deferredResolutions []*exitHtlcResolution
+
+	// shutdownReq is the pending linkShutdown operation, if any. Once
+	// set, new HTLC's are failed, and the operation completes as soon as
+	// the channel is fully synced.
+	shutdownReq *linkControlReq
+
 	channel   *lnwallet.LightningChannel
 	chanPoint *wire.OutPoint
+
+	// quit is closed once the htlcManager of the channel exits.
+	quit chan struct{}
+}
+
+// exitHtlcResolution is the outcome of checking an HTLC, for which we're the
+// final destination, against our invoices.
+type exitHtlcResolution struct {
+	index   uint32
+	rHash   [32]byte
+	amt     btcutil.Amount
+	invoice *channeldb.Invoice
+	reason  lnwire.CancelReason
+	ok      bool
 }
 
 // htlcManager is the primary goroutine which drives a channel's commitment
@@ -1009,10 +1644,11 @@ type commitmentState struct {
 // used which sends htlc packets to the switch for forwarding. Additionally,
 // the htlcManager handles acting upon all timeouts for any active HTLC's,
 // manages the channel's revocation window, and also the htlc trickle
-// queue+timer for this active channels.
+// queue+timer for this active channels. Finally, out of band operations, such
+// as quiescing the link, are received over the controls channel.
 func (p *peer) htlcManager(channel *lnwallet.LightningChannel,
 	htlcPlex chan<- *htlcPacket, downstreamLink <-chan *htlcPacket,
-	upstreamLink <-chan lnwire.Message) {
+	upstreamLink <-chan lnwire.Message, controls <-chan *linkControlReq) {
 
 	chanStats := channel.StateSnapshot()
 	peerLog.Infof("HTLC manager for ChannelPoint(%v) started, "+
@@ -1020,6 +1656,12 @@ func (p *peer) htlcManager(channel *lnwallet.LightningChannel,
 		channel.ChannelPoint(), chanStats.LocalBalance,
 		chanStats.RemoteBalance, chanStats.NumUpdates)
 
+	// Limit the amount of the channel's funds which may be lost to dust
+	// HTLCs and commitment fees, evaluating fees at the fee rate currently
+	// estimated by the server.
+	channel.SetDustExposureLimit(btcutil.Amount(cfg.MaxDustExposure),
+		p.server.feeEstimator)
+
 	// A new session for this active channel has just started, therefore we
 	// need to send our initial revocation window to the remote peer.
 	for i := 0; i < lnwallet.InitialRevocationWindow; i++ {
@@ -1041,7 +1683,21 @@ func (p *peer) htlcManager(channel *lnwallet.LightningChannel,
 		pendingCircuits: make(map[uint32]*sphinx.ProcessedPacket),
 		sphinx:          p.server.sphinx,
 		switchChan:      htlcPlex,
+
+		unresolvedExitHtlcs: make(map[uint32]bool),
+		exitResolutions:     make(chan *exitHtlcResolution),
+		quit:                make(chan struct{}),
 	}
+	state.quiescer = newQuiescer(state.chanPoint, channel.IsInitiator(),
+		func(msg lnwire.Message) {
+			p.queueMsg(msg, nil)
+		}, defaultQuiescenceTimeout,
+	)
+
+	// As the acceptance of a commitment type upgrade may have been lost
+	// while we were disconnected, we'll announce the format we create
+	// commitments in, so any upgrade only one side recorded is abandoned.
+	p.reestablishCommitUpgrade(state)
 
 	// TODO(roasbeef): check to see if able to settle any currently pending
 	// HTLC's
@@ -1050,11 +1706,62 @@ func (p *peer) htlcManager(channel *lnwallet.LightningChannel,
 	batchTimer := time.Tick(10 * time.Millisecond)
 out:
 	for {
+		// Any settles and cancels deferred while we had sent Stfu are
+		// applied once the channel has been resumed.
+		if !state.quiescer.sentStfu && len(state.deferredResolutions) != 0 {
+			deferred := state.deferredResolutions
+			state.deferredResolutions = nil
+			for _, res := range deferred {
+				p.resolveLockedInHtlc(state, res)
+			}
+		}
+
+		// If the channel is being drained, the shutdown completes
+		// once no update is left to be signed or revoked.
+		if req := state.shutdownReq; req != nil && linkIsClean(state) &&
+			state.channel.FullySynced() {
+
+			req.err <- nil
+			state.shutdownReq = nil
+		}
+
+		// If quiescence is pending, we'll send our Stfu as soon as all
+		// of our updates have been committed.
+		state.quiescer.trySendStfu(linkIsClean(state))
+
+		// switchPackets is the channel packets from the switch are
+		// read from. It's set to nil while the channel is quiescing or
+		// quiescent, so no new updates originate from our side.
+		switchPackets := downstreamLink
+		if state.quiescer.blocksUpdates() || state.reconcilingUpgrade {
+			switchPackets = nil
+		}
+
+		// If we initiated a commitment upgrade, we'll propose it once
+		// the channel is quiescent. Should the channel have been
+		// resumed after our proposal was sent, the upgrade failed.
+		var upgradeQuiesced chan error
+		if upgrade := state.pendingUpgrade; upgrade != nil {
+			switch {
+			case !upgrade.proposed:
+				upgradeQuiesced = upgrade.quiesced
+			case !state.quiescer.isQuiescent():
+				p.failCommitUpgrade(state, errUpgradeAborted)
+			}
+		}
+
 		select {
 		case <-channel.UnilateralCloseSignal:
 			// TODO(roasbeef): need to send HTLC outputs to nursery
 			peerLog.Warnf("Remote peer has closed ChannelPoint(%v) on-chain",
 				state.chanPoint)
+
+			// Our balance within a static remote key channel is
+			// paid to our payment key, so we'll sweep it back
+			// into the wallet before the channel is wiped.
+			p.server.shellSweeper.sweepClosedChannel(
+				channel.UnilateralCloseSummary(),
+			)
 			if err := wipeChannel(p, channel); err != nil {
 				peerLog.Errorf("unable to wipe channel %v", err)
 			}
@@ -1109,8 +1816,70 @@ out:
 			}
 
 			state.numUnAcked += 1
-		case pkt := <-downstreamLink:
+		case pkt := <-switchPackets:
 			p.handleDownStreamPkt(state, pkt)
+		case <-state.quiescer.timer:
+			state.quiescer.timedOut()
+		case err := <-upgradeQuiesced:
+			p.proposeCommitUpgrade(state, err)
+		case res := <-state.exitResolutions:
+			p.resolveExitHtlc(state, res)
+		case req := <-controls:
+			peerLog.Debugf("Applying %v to ChannelPoint(%v)", req.op,
+				state.chanPoint)
+
+			switch req.op {
+			case linkQuiesce:
+				if !p.supportsQuiescence() {
+					req.err <- fmt.Errorf("peer doesn't " +
+						"support quiescence")
+					continue
+				}
+
+				state.quiescer.initiate(req.timeout, req.err)
+
+			case linkResume:
+				state.quiescer.resume(errQuiescenceAborted)
+				req.err <- nil
+
+			case linkForceCommit:
+				if state.quiescer.isQuiescent() {
+					req.err <- fmt.Errorf("channel is " +
+						"quiescent")
+					continue
+				}
+
+				sent, err := p.updateCommitTx(state)
+				if err != nil {
+					req.err <- err
+					continue
+				}
+				if !sent {
+					req.err <- fmt.Errorf("unable to sign " +
+						"commitment, revocation window " +
+						"exhausted")
+					continue
+				}
+
+				state.numUnAcked += 1
+				req.err <- nil
+
+			case linkUpgradeCommitment:
+				p.initiateCommitUpgrade(state, req)
+
+			case linkShutdown:
+				if state.shutdownReq != nil {
+					req.err <- fmt.Errorf("channel is " +
+						"already shutting down")
+					continue
+				}
+
+				state.shutdownReq = req
+
+			default:
+				req.err <- fmt.Errorf("unknown link "+
+					"operation: %v", req.op)
+			}
 		case msg, ok := <-upstreamLink:
 			// If the upstream message link is closed, this signals
 			// that the channel itself is being closed, therefore
@@ -1125,6 +1894,23 @@ out:
 		}
 	}
 
+	close(state.quit)
+
+	// The HTLC's we accepted, but had yet to settle, will no longer be
+	// settled by this link, so they no longer count towards their
+	// invoices.
+	for _, invoice := range state.htlcsToSettle {
+		preimage := invoice.Terms.PaymentPreimage
+		p.server.invoices.releaseAcceptedHtlc(
+			chainhash.Hash(fastsha256.Sum256(preimage[:])),
+		)
+	}
+	for _, res := range state.deferredResolutions {
+		if res.ok {
+			p.server.invoices.releaseAcceptedHtlc(res.rHash)
+		}
+	}
+
 	p.wg.Done()
 	peerLog.Tracef("htlcManager for peer %v done", p)
 }
@@ -1137,6 +1923,26 @@ func (p *peer) handleDownStreamPkt(state *commitmentState, pkt *htlcPacket) {
 	var isSettle bool
 	switch htlc := pkt.msg.(type) {
 	case *lnwire.HTLCAddRequest:
+		if p.server.hodlMask.Active(hodl.AddOutgoing) {
+			peerLog.Warn(hodl.AddOutgoing.Warning())
+
+			// The switch decremented the bandwidth of the link
+			// when forwarding the HTLC, so it's restored as the
+			// HTLC is never added.
+			p.server.htlcSwitch.UpdateLink(state.chanPoint, pkt.amt)
+			return
+		}
+
+		// No new HTLC's are offered over a channel being drained
+		// ahead of the daemon shutting down.
+		if state.shutdownReq != nil {
+			pkt.err <- lnwire.CancelReason(
+				lnwire.TemporaryChannelFailure,
+			)
+			p.server.htlcSwitch.UpdateLink(state.chanPoint, pkt.amt)
+			return
+		}
+
 		// A new payment has been initiated via the
 		// downstream channel, so we add the new HTLC
 		// to our local log, then update the commitment
@@ -1168,6 +1974,11 @@ func (p *peer) handleDownStreamPkt(state *commitmentState, pkt *htlcPacket) {
 		})
 
 	case *lnwire.HTLCSettleRequest:
+		if p.server.hodlMask.Active(hodl.SettleOutgoing) {
+			peerLog.Warn(hodl.SettleOutgoing.Warning())
+			return
+		}
+
 		// An HTLC we forward to the switch has just settle somehere
 		// upstream. Therefore we settle the HTLC within the our local
 		// state machine.
@@ -1192,6 +2003,11 @@ func (p *peer) handleDownStreamPkt(state *commitmentState, pkt *htlcPacket) {
 		isSettle = true
 
 	case *lnwire.CancelHTLC:
+		if p.server.hodlMask.Active(hodl.FailOutgoing) {
+			peerLog.Warn(hodl.FailOutgoing.Warning())
+			return
+		}
+
 		// An HTLC cancellation has been triggered somewhere upstream,
 		// we'll remove then HTLC from our local state machine.
 		logIndex, err := state.channel.CancelHTLC(pkt.payHash)
@@ -1230,10 +2046,31 @@ func (p *peer) handleDownStreamPkt(state *commitmentState, pkt *htlcPacket) {
 	}
 }
 
+// linkIsClean returns true if all of our updates have been committed to both
+// commitment transactions, and we aren't about to add any settles or
+// cancels, nor may be once the HTLC's being checked against our invoices are
+// resolved, which allows us to quiesce the channel.
+func linkIsClean(state *commitmentState) bool {
+	return !state.channel.PendingLocalUpdates() &&
+		len(state.pendingBatch) == 0 &&
+		len(state.htlcsToSettle) == 0 &&
+		len(state.htlcsToCancel) == 0 &&
+		len(state.unresolvedExitHtlcs) == 0
+}
+
 // handleUpstreamMsg processes wire messages related to commitment state
 // updates from the upstream peer. The upstream peer is the peer whom we have a
 // direct channel with, updating our respective commitment chains.
 func (p *peer) handleUpstreamMsg(state *commitmentState, msg lnwire.Message) {
+	switch msg.(type) {
+	// The remote peer may only send new updates once it has resumed the
+	// channel, so any quiescence ends.
+	case *lnwire.HTLCAddRequest, *lnwire.HTLCSettleRequest,
+		*lnwire.CancelHTLC:
+
+		state.quiescer.receiveUpdate()
+	}
+
 	switch htlcPkt := msg.(type) {
 	// TODO(roasbeef): timeouts
 	//  * fail if can't parse sphinx mix-header
@@ -1257,6 +2094,22 @@ func (p *peer) handleUpstreamMsg(state *commitmentState, msg lnwire.Message) {
 			return
 		}
 
+		// If the new HTLC pushes the dust exposure of the channel
+		// beyond its limit, then we'll cancel it after the current
+		// commitment transition.
+		if err := state.channel.CheckDustExposure(); err != nil {
+			peerLog.Errorf("unable to accept HTLC: %v", err)
+			state.htlcsToCancel[index] = lnwire.InsufficientCapacity
+			return
+		}
+
+		// Likewise, HTLC's received while the channel is being
+		// drained ahead of the daemon shutting down are cancelled.
+		if state.shutdownReq != nil {
+			state.htlcsToCancel[index] = lnwire.TemporaryChannelFailure
+			return
+		}
+
 		// TODO(roasbeef): perform sanity checks on per-hop payload
 		//  * time-lock is sane, fee, chain, etc
 
@@ -1265,9 +2118,18 @@ func (p *peer) handleUpstreamMsg(state *commitmentState, msg lnwire.Message) {
 		// packet itself as associated data in order to thwart attempts
 		// a replay attacks. In the case of a replay, an attacker is
 		// *forced* to use the same payment hash twice, thereby losing
-		// their money entirely.
+		// their money entirely. The result is cached, so that an HTLC
+		// retransmitted by our peer isn't mistaken for a replay.
 		rHash := htlcPkt.RedemptionHashes[0][:]
-		sphinxPacket, err := state.sphinx.ProcessOnionPacket(onionPkt, rHash)
+		sphinxPacket, err := p.server.onionCache.processOnion(
+			*state.chanPoint, index, htlcPkt.OnionBlob, rHash,
+			htlcPkt.Expiry,
+			func() (*sphinx.ProcessedPacket, error) {
+				return state.sphinx.ProcessOnionPacket(
+					onionPkt, rHash,
+				)
+			},
+		)
 		if err != nil {
 			// If we're unable to parse the Sphinx packet, then
 			// we'll cancel the HTLC after the current commitment
@@ -1280,34 +2142,34 @@ func (p *peer) handleUpstreamMsg(state *commitmentState, msg lnwire.Message) {
 		switch sphinxPacket.Action {
 		// We're the designated payment destination. Therefore we
 		// attempt to see if we have an invoice locally which'll allow
-		// us to settle this HTLC.
+		// us to settle this HTLC. As the htlc modifier and acceptance
+		// hooks may take a while to respond, the HTLC is checked
+		// within a goroutine of its own, so the link isn't blocked in
+		// the meantime. The outcome is applied once delivered back to
+		// the htlcManager.
 		case sphinx.ExitNode:
-			rHash := htlcPkt.RedemptionHashes[0]
-			invoice, err := p.server.invoices.LookupInvoice(rHash)
-			if err != nil {
-				// If we're the exit node, but don't recognize
-				// the payment hash, then we'll fail the HTLC
-				// on the next state transition.
-				peerLog.Errorf("unable to settle HTLC, "+
-					"payment hash (%x) unrecognized", rHash[:])
-				state.htlcsToCancel[index] = lnwire.UnknownPaymentHash
-				return
-			}
-
-			// If we're not currently in debug mode, and the
-			// extended HTLC doesn't meet the value requested, then
-			// we'll fail the HTLC.
-			if !cfg.DebugHTLC && htlcPkt.Amount < invoice.Terms.Value {
-				peerLog.Errorf("rejecting HTLC due to incorrect "+
-					"amount: expected %v, received %v",
-					invoice.Terms.Value, htlcPkt.Amount)
-				state.htlcsToCancel[index] = lnwire.IncorrectValue
-			} else {
-				// Otherwise, everything is in order and we'll
-				// settle the HTLC after the current state
-				// transition.
-				state.htlcsToSettle[index] = invoice
-			}
+			state.unresolvedExitHtlcs[index] = false
+
+			go func() {
+				invoice, reason, ok := p.checkExitHtlc(
+					*state.chanPoint, index, htlcPkt,
+					state.quit,
+				)
+
+				res := &exitHtlcResolution{
+					index:   index,
+					rHash:   htlcPkt.RedemptionHashes[0],
+					amt:     htlcPkt.Amount,
+					invoice: invoice,
+					reason:  reason,
+					ok:      ok,
+				}
+				select {
+				case state.exitResolutions <- res:
+				case <-state.quit:
+				case <-p.quit:
+				}
+			}()
 
 		// There are additional hops left within this route, so we
 		// track the next hop according to the index of this HTLC
@@ -1342,6 +2204,53 @@ func (p *peer) handleUpstreamMsg(state *commitmentState, msg lnwire.Message) {
 
 		state.cancelReasons[idx] = htlcPkt.Reason
 
+	case *lnwire.Stfu:
+		// The remote peer requests quiescence of the channel, or
+		// answers our own request. In either case, we'll send our
+		// Stfu once all of our updates have been committed. As the
+		// Stfu message is only understood once the quiescence feature
+		// has been negotiated, an unsolicited one is a protocol
+		// violation.
+		if !p.supportsQuiescence() {
+			peerLog.Errorf("received stfu from peer %v which "+
+				"didn't negotiate quiescence", p)
+			p.Disconnect()
+			return
+		}
+		state.quiescer.receiveStfu(htlcPkt)
+
+	case *lnwire.DynPropose:
+		if err := p.handleDynPropose(state, htlcPkt); err != nil {
+			peerLog.Errorf("unable to process upgrade proposal: %v",
+				err)
+			p.Disconnect()
+			return
+		}
+
+	case *lnwire.DynAck:
+		if err := p.handleDynAck(state, htlcPkt); err != nil {
+			peerLog.Errorf("unable to process upgrade ack: %v", err)
+			p.Disconnect()
+			return
+		}
+
+	case *lnwire.DynReject:
+		if err := p.handleDynReject(state, htlcPkt); err != nil {
+			peerLog.Errorf("unable to process upgrade reject: %v",
+				err)
+			p.Disconnect()
+			return
+		}
+
+	case *lnwire.DynReestablish:
+		err := p.handleDynReestablish(state, htlcPkt)
+		if err != nil {
+			peerLog.Errorf("unable to reconcile commitment type: %v",
+				err)
+			p.Disconnect()
+			return
+		}
+
 	case *lnwire.CommitSignature:
 		// We just received a new update to our local commitment chain,
 		// validate this new commitment, closing the link if invalid.
@@ -1393,6 +2302,7 @@ func (p *peer) handleUpstreamMsg(state *commitmentState, msg lnwire.Message) {
 		var bandwidthUpdate btcutil.Amount
 		settledPayments := make(map[lnwallet.PaymentHash]struct{})
 		cancelledHtlcs := make(map[uint32]struct{})
+		unresolvedHtlcs := make(map[uint32]struct{})
 		for _, htlc := range htlcsToForward {
 			parentIndex := htlc.ParentIndex
 			if p, ok := state.clearedHTCLs[parentIndex]; ok {
@@ -1404,10 +2314,9 @@ func (p *peer) handleUpstreamMsg(state *commitmentState, msg lnwire.Message) {
 					p.err <- nil
 
 				// Otherwise, the HTLC failed, so we propagate
-				// the error back to the potential caller.
+				// the reason back to the potential caller.
 				case lnwallet.Cancel:
-					errMsg := state.cancelReasons[parentIndex]
-					p.err <- errors.New(errMsg.String())
+					p.err <- state.cancelReasons[parentIndex]
 				}
 
 				delete(state.clearedHTCLs, htlc.ParentIndex)
@@ -1419,11 +2328,65 @@ func (p *peer) handleUpstreamMsg(state *commitmentState, msg lnwire.Message) {
 				continue
 			}
 
+			// If this HTLC, for which we're the final destination,
+			// is still being checked against our invoices, then
+			// it'll be settled or cancelled once the checks
+			// complete.
+			if _, ok := state.unresolvedExitHtlcs[htlc.Index]; ok {
+				state.unresolvedExitHtlcs[htlc.Index] = true
+				unresolvedHtlcs[htlc.Index] = struct{}{}
+				continue
+			}
+
+			// Once we've sent Stfu, we mustn't add any updates
+			// until the channel is resumed, so the settle or
+			// cancel of this HTLC is deferred until then.
+			invoice, settle := state.htlcsToSettle[htlc.Index]
+			reason, cancel := state.htlcsToCancel[htlc.Index]
+			if state.quiescer.sentStfu && (settle || cancel) {
+				state.deferredResolutions = append(
+					state.deferredResolutions,
+					&exitHtlcResolution{
+						index:   htlc.Index,
+						rHash:   htlc.RHash,
+						amt:     htlc.Amount,
+						invoice: invoice,
+						reason:  reason,
+						ok:      settle,
+					},
+				)
+				delete(state.htlcsToSettle, htlc.Index)
+				delete(state.htlcsToCancel, htlc.Index)
+
+				unresolvedHtlcs[htlc.Index] = struct{}{}
+				continue
+			}
+
+			// The invoice is settled ahead of the HTLC, so that an
+			// HTLC paying an invoice which has been canceled since
+			// the HTLC was accepted is cancelled instead.
+			if settle {
+				err := p.server.invoices.SettleInvoice(
+					chainhash.Hash(htlc.RHash),
+				)
+				switch {
+				case err == channeldb.ErrInvoiceAlreadyCanceled:
+					peerLog.Errorf("cancelling HTLC paying "+
+						"canceled invoice (%x)", htlc.RHash[:])
+					delete(state.htlcsToSettle, htlc.Index)
+					settle, cancel = false, true
+					reason = lnwire.UnknownPaymentHash
+
+				case err != nil:
+					peerLog.Errorf("unable to settle invoice: %v",
+						err)
+				}
+			}
+
 			// If we can settle this HTLC within our local state
 			// update log, then send the update entry to the remote
 			// party.
-			invoice, ok := state.htlcsToSettle[htlc.Index]
-			if ok {
+			if settle {
 				preimage := invoice.Terms.PaymentPreimage
 				logIndex, err := state.channel.SettleHTLC(preimage)
 				if err != nil {
@@ -1450,8 +2413,7 @@ func (p *peer) handleUpstreamMsg(state *commitmentState, msg lnwire.Message) {
 			// cancellation, then immediately cancel the HTLC as
 			// it's now locked in within both commitment
 			// transactions.
-			reason, ok := state.htlcsToCancel[htlc.Index]
-			if !ok {
+			if !cancel {
 				continue
 			}
 
@@ -1484,6 +2446,19 @@ func (p *peer) handleUpstreamMsg(state *commitmentState, msg lnwire.Message) {
 				if _, ok := cancelledHtlcs[htlc.Index]; ok {
 					continue
 				}
+				if _, ok := unresolvedHtlcs[htlc.Index]; ok {
+					continue
+				}
+
+				// If we've been instructed to hold this type
+				// of update, then it's dropped rather than
+				// forwarded to the switch.
+				if flag, ok := incomingHodlFlag(htlc); ok &&
+					p.server.hodlMask.Active(flag) {
+
+					peerLog.Warn(flag.Warning())
+					continue
+				}
 
 				onionPkt := state.pendingCircuits[htlc.Index]
 				delete(state.pendingCircuits, htlc.Index)
@@ -1529,23 +2504,298 @@ func (p *peer) handleUpstreamMsg(state *commitmentState, msg lnwire.Message) {
 			// TODO(roasbeef): wait to delete from htlcsToSettle?
 			state.numUnAcked += 1
 		}
+	}
+}
 
-		// Notify the invoiceRegistry of the invoices we just settled
-		// with this latest commitment update.
-		// TODO(roasbeef): wait until next transition?
-		for invoice, _ := range settledPayments {
-			err := p.server.invoices.SettleInvoice(chainhash.Hash(invoice))
-			if err != nil {
-				peerLog.Errorf("unable to settle invoice: %v", err)
-			}
+// checkExitHtlc checks whether the passed HTLC, for which we're the final
+// destination, can be settled against one of our invoices. If so, the invoice
+// is returned. Otherwise, the reason the HTLC is to be cancelled is returned.
+// As the checks may block on the htlc modifier and acceptance hooks, this
+// must not be called from the htlcManager. The acceptance hooks are abandoned
+// once the passed quit channel is closed.
+//
+// If uniform invoice failures are enabled, every check is performed even once
+// a prior one failed, and every failure is reported as an unknown payment
+// hash. This way, probers can tell neither from the reason nor from the timing
+// of a failure whether an invoice exists, or why it couldn't be paid.
+func (p *peer) checkExitHtlc(chanPoint wire.OutPoint, index uint32,
+	htlcPkt *lnwire.HTLCAddRequest, quit <-chan struct{}) (
+	*channeldb.Invoice, lnwire.CancelReason, bool) {
+
+	uniform := cfg.UniformInvoiceFailures
+
+	// Only the reason of the first check to fail is retained.
+	var (
+		failed bool
+		reason lnwire.CancelReason
+	)
+	fail := func(r lnwire.CancelReason) {
+		if !failed {
+			failed, reason = true, r
 		}
 	}
+
+	rHash := htlcPkt.RedemptionHashes[0]
+	invoice, err := p.server.invoices.LookupInvoice(rHash)
+	if err != nil {
+		// If we're the exit node, but don't recognize the payment
+		// hash, then we'll fail the HTLC on the next state
+		// transition.
+		peerLog.Errorf("unable to settle HTLC, payment hash (%x) "+
+			"unrecognized", rHash[:])
+		fail(lnwire.UnknownPaymentHash)
+		if !uniform {
+			return nil, reason, false
+		}
+
+		// The remaining checks are still performed, against an empty
+		// invoice, so the failure takes as long as any other.
+		invoice = &channeldb.Invoice{}
+	}
+
+	// Canceled invoices can no longer be paid.
+	if invoice.Terms.Canceled {
+		peerLog.Errorf("rejecting HTLC paying canceled invoice (%x)",
+			rHash[:])
+		fail(lnwire.UnknownPaymentHash)
+		if !uniform {
+			return nil, reason, false
+		}
+	}
+
+	// If the HTLC expires too close to the current height, then we may be
+	// unable to settle it before our peer can time it out, so we'll fail
+	// it.
+	_, bestHeight, err := p.server.bio.GetBestBlock()
+	switch {
+	case err != nil:
+		peerLog.Errorf("unable to query best block: %v", err)
+		fail(lnwire.ExpiryTooSoon)
+
+	case htlcPkt.Expiry <= uint32(bestHeight)+expiryGraceDelta:
+		peerLog.Errorf("rejecting HTLC due to expiry too soon: "+
+			"expiry=%v, height=%v", htlcPkt.Expiry, bestHeight)
+		fail(lnwire.ExpiryTooSoon)
+	}
+	if failed && !uniform {
+		return nil, reason, false
+	}
+
+	// If an external htlc modifier is registered, it may override the
+	// amount the HTLC pays towards the invoice, or cancel the HTLC
+	// outright. It's only consulted on HTLCs which haven't failed yet,
+	// unless failures are uniform, in which case it's consulted on every
+	// HTLC so failures take as long as any other, and its decision on
+	// HTLCs which have already failed is ignored.
+	amtPaid := htlcPkt.Amount
+	if !failed || uniform {
+		mod, ok := p.server.htlcModifier.modify(&exitHtlc{
+			paymentHash: rHash,
+			invoiceAmt:  invoice.Terms.Value,
+			htlcAmt:     htlcPkt.Amount,
+			expiry:      htlcPkt.Expiry,
+			chanPoint:   chanPoint,
+			htlcIndex:   index,
+		})
+		switch {
+		// Without a decision, or once the HTLC has failed, the HTLC is
+		// left unmodified.
+		case !ok || failed:
+
+		case mod.cancel:
+			peerLog.Infof("HTLC with payment hash (%x) canceled "+
+				"by htlc modifier", rHash[:])
+			fail(lnwire.UnknownPaymentHash)
+
+		case mod.amtPaid != 0:
+			peerLog.Debugf("HTLC with payment hash (%x) modified "+
+				"to pay %v, carrying %v", rHash[:],
+				mod.amtPaid, htlcPkt.Amount)
+			amtPaid = mod.amtPaid
+		}
+	}
+
+	// If we're not currently in debug mode, and the extended HTLC doesn't
+	// meet the value requested, then we'll fail the HTLC.
+	if !cfg.DebugHTLC && amtPaid < invoice.Terms.Value {
+		peerLog.Errorf("rejecting HTLC due to incorrect amount: "+
+			"expected %v, received %v", invoice.Terms.Value,
+			amtPaid)
+		fail(lnwire.IncorrectValue)
+	}
+
+	// Before accepting an HTLC paying one of our open invoices, the
+	// acceptance hooks registered with the invoice registry may impose
+	// conditions of their own upon it. As with the modifier, they're
+	// consulted on every HTLC if failures are uniform.
+	if (!failed && !invoice.Terms.Settled) || uniform {
+		err := p.server.invoices.checkAcceptHooks(&invoiceAcceptRequest{
+			htlc: &exitHtlc{
+				paymentHash: rHash,
+				invoiceAmt:  invoice.Terms.Value,
+				htlcAmt:     htlcPkt.Amount,
+				expiry:      htlcPkt.Expiry,
+				chanPoint:   chanPoint,
+				htlcIndex:   index,
+			},
+			invoice: invoice,
+		}, quit)
+		if err != nil && !failed {
+			peerLog.Infof("HTLC with payment hash (%x) canceled by "+
+				"acceptance hook: %v", rHash[:], err)
+			fail(lnwire.UnknownPaymentHash)
+		}
+	}
+
+	switch {
+	case !failed:
+		return invoice, 0, true
+
+	case uniform:
+		return nil, lnwire.UnknownPaymentHash, false
+
+	default:
+		return nil, reason, false
+	}
+}
+
+// resolveExitHtlc applies the outcome of checking an HTLC, for which we're the
+// final destination, against our invoices. If the HTLC has yet to be locked
+// in, it's marked to be settled or cancelled once it is, as any other HTLC.
+// Otherwise, it's settled or cancelled right away, and a new state transition
+// is initiated.
+func (p *peer) resolveExitHtlc(state *commitmentState,
+	res *exitHtlcResolution) {
+
+	lockedIn, ok := state.unresolvedExitHtlcs[res.index]
+	if !ok {
+		return
+	}
+	delete(state.unresolvedExitHtlcs, res.index)
+
+	settle, reason := res.ok, res.reason
+	if settle {
+		// The HTLC pays one of our invoices, so we'll let any clients
+		// tracking it know that it has been accepted, unless too many
+		// of its HTLCs are already pending.
+		err := p.server.invoices.AcceptInvoice(res.rHash, res.invoice)
+		if err != nil {
+			peerLog.Errorf("unable to accept HTLC: %v", err)
+			settle, reason = false, lnwire.UnknownPaymentHash
+		}
+	}
+
+	switch {
+	case settle && p.server.hodlMask.Active(hodl.ExitSettle):
+		// The HTLC is left pending within the channel, as we've been
+		// instructed to refrain from settling it.
+		peerLog.Warn(hodl.ExitSettle.Warning())
+		return
+
+	case !lockedIn && settle:
+		state.htlcsToSettle[res.index] = res.invoice
+		return
+
+	case !lockedIn:
+		state.htlcsToCancel[res.index] = reason
+		return
+	}
+
+	// Otherwise, the HTLC was locked in while it was being checked, so
+	// we'll settle or cancel it right away, unless we've sent Stfu, in
+	// which case it's deferred until the channel is resumed.
+	res.ok, res.reason = settle, reason
+	if state.quiescer.sentStfu {
+		state.deferredResolutions = append(
+			state.deferredResolutions, res,
+		)
+		return
+	}
+
+	p.resolveLockedInHtlc(state, res)
+}
+
+// resolveLockedInHtlc settles or cancels an HTLC, which has been locked in by
+// both commitment transactions, within our local update log, then initiates a
+// new state transition.
+func (p *peer) resolveLockedInHtlc(state *commitmentState,
+	res *exitHtlcResolution) {
+
+	// The invoice is settled ahead of the HTLC, so that an HTLC paying an
+	// invoice which has been canceled since the HTLC was accepted is
+	// cancelled instead.
+	settle := res.ok
+	if settle {
+		err := p.server.invoices.SettleInvoice(chainhash.Hash(res.rHash))
+		switch {
+		case err == channeldb.ErrInvoiceAlreadyCanceled:
+			peerLog.Errorf("cancelling HTLC paying canceled invoice "+
+				"(%x)", res.rHash[:])
+			settle, res.reason = false, lnwire.UnknownPaymentHash
+
+		case err != nil:
+			peerLog.Errorf("unable to settle invoice: %v", err)
+		}
+	}
+
+	if settle {
+		preimage := res.invoice.Terms.PaymentPreimage
+		logIndex, err := state.channel.SettleHTLC(preimage)
+		if err != nil {
+			peerLog.Errorf("unable to settle htlc: %v", err)
+			p.server.invoices.releaseAcceptedHtlc(res.rHash)
+			p.Disconnect()
+			return
+		}
+
+		p.queueMsg(&lnwire.HTLCSettleRequest{
+			ChannelPoint:     state.chanPoint,
+			HTLCKey:          lnwire.HTLCKey(logIndex),
+			RedemptionProofs: [][32]byte{preimage},
+		}, nil)
+
+		// Send an update to the htlc switch of our newly available
+		// payment bandwidth.
+		p.server.htlcSwitch.UpdateLink(state.chanPoint, res.amt)
+	} else {
+		logIndex, err := state.channel.CancelHTLC(res.rHash)
+		if err != nil {
+			peerLog.Errorf("unable to cancel htlc: %v", err)
+			p.Disconnect()
+			return
+		}
+
+		p.queueMsg(&lnwire.CancelHTLC{
+			ChannelPoint: state.chanPoint,
+			HTLCKey:      lnwire.HTLCKey(logIndex),
+			Reason:       res.reason,
+		}, nil)
+	}
+
+	if sent, err := p.updateCommitTx(state); err != nil {
+		peerLog.Errorf("unable to update commitment: %v", err)
+		p.Disconnect()
+		return
+	} else if sent {
+		state.numUnAcked += 1
+	}
 }
 
 // updateCommitTx signs, then sends an update to the remote peer adding a new
 // commitment to their commitment chain which includes all the latest updates
 // we've received+processed up to this point.
 func (p *peer) updateCommitTx(state *commitmentState) (bool, error) {
+	if p.server.hodlMask.Active(hodl.Commit) {
+		peerLog.Warn(hodl.Commit.Warning())
+		return false, nil
+	}
+
+	// Until any pending commitment type upgrade has been reconciled with
+	// the remote peer, the format of the next commitment is unknown.
+	if state.reconcilingUpgrade {
+		return false, nil
+	}
+
 	sigTheirs, logIndexTheirs, err := state.channel.SignNextCommitment()
 	if err == lnwallet.ErrNoWindow {
 		peerLog.Tracef("revocation window exhausted, unable to send %v",
@@ -1580,6 +2830,21 @@ func (p *peer) updateCommitTx(state *commitmentState) (bool, error) {
 	return true, nil
 }
 
+// incomingHodlFlag returns the hodl flag which governs the forwarding of the
+// passed locked-in log entry to the switch.
+func incomingHodlFlag(pd *lnwallet.PaymentDescriptor) (hodl.Flag, bool) {
+	switch pd.EntryType {
+	case lnwallet.Add:
+		return hodl.AddIncoming, true
+	case lnwallet.Settle:
+		return hodl.SettleIncoming, true
+	case lnwallet.Cancel:
+		return hodl.FailIncoming, true
+	default:
+		return 0, false
+	}
+}
+
 // logEntryToHtlcPkt converts a particular Lightning Commitment Protocol (LCP)
 // log entry the corresponding htlcPacket with src/dest set along with the
 // proper wire message. This helper method is provided in order to aide an
@@ -1602,9 +2867,11 @@ func logEntryToHtlcPkt(chanPoint wire.OutPoint,
 		}
 
 		msg = &lnwire.HTLCAddRequest{
+			Expiry:           pd.Timeout,
 			Amount:           btcutil.Amount(pd.Amount),
 			RedemptionHashes: [][32]byte{pd.RHash},
 			OnionBlob:        b.Bytes(),
+			Endorsed:         pd.Endorsed,
 		}
 	case lnwallet.Settle:
 		msg = &lnwire.HTLCSettleRequest{
@@ -1624,6 +2891,7 @@ func logEntryToHtlcPkt(chanPoint wire.OutPoint,
 	pkt.msg = msg
 
 	pkt.srcLink = chanPoint
+	pkt.htlcIndex = pd.Index
 	pkt.onion = onionPkt
 
 	return pkt, nil
@@ -1 +1,349 @@
 package main
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/fastsha256"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// TestVerifyCloseDeliveryScript asserts that a cooperative close requested by
+// the remote peer is rejected if it pays the remote party's funds to a script
+// other than the one committed to during the funding workflow.
+func TestVerifyCloseDeliveryScript(t *testing.T) {
+	committed := []byte{0x00, 0x14, 0x01, 0x02, 0x03}
+	other := []byte{0x00, 0x14, 0x04, 0x05, 0x06}
+
+	tests := []struct {
+		name   string
+		script lnwire.PkScript
+		valid  bool
+	}{
+		{
+			name:   "matching script",
+			script: committed,
+			valid:  true,
+		},
+		{
+			name:   "mismatched script",
+			script: other,
+			valid:  false,
+		},
+		{
+			name:   "empty script",
+			script: lnwire.PkScript{},
+			valid:  false,
+		},
+		{
+			name:   "omitted script",
+			script: nil,
+			valid:  true,
+		},
+	}
+
+	for _, test := range tests {
+		req := &lnwire.CloseRequest{
+			ChannelPoint:     &wire.OutPoint{Index: 1},
+			DeliveryPkScript: test.script,
+		}
+
+		err := verifyCloseDeliveryScript(req, committed)
+		switch {
+		case test.valid && err != nil:
+			t.Fatalf("%v: unexpected error: %v", test.name, err)
+		case !test.valid && err == nil:
+			t.Fatalf("%v: expected close to be rejected", test.name)
+		}
+	}
+}
+
+// TestRejectLocalClose asserts that the remote peer's rejection of our
+// cooperative close is delivered to the pending close request, rather than
+// leaving it waiting for a closing transaction which will never confirm.
+func TestRejectLocalClose(t *testing.T) {
+	p := &peer{
+		closeRejections: make(map[wire.OutPoint]chan error),
+	}
+
+	chanPoint := wire.OutPoint{Index: 1}
+	otherPoint := wire.OutPoint{Index: 2}
+	rejected := p.watchCloseRejection(chanPoint)
+
+	// A rejection of a close we didn't request should be ignored.
+	p.rejectLocalClose(&lnwire.ErrorGeneric{
+		ChannelPoint: &otherPoint,
+		Code:         lnwire.ErrorUpfrontShutdownMismatch,
+	})
+	select {
+	case err := <-rejected:
+		t.Fatalf("unexpected rejection: %v", err)
+	default:
+	}
+
+	p.rejectLocalClose(&lnwire.ErrorGeneric{
+		ChannelPoint: &chanPoint,
+		Problem:      "delivery script doesn't match",
+		Code:         lnwire.ErrorUpfrontShutdownMismatch,
+	})
+	select {
+	case err := <-rejected:
+		if err == nil {
+			t.Fatalf("expected rejection error")
+		}
+	default:
+		t.Fatalf("expected close to be rejected")
+	}
+
+	// Once delivered, the rejection should no longer be watched for.
+	p.closeRejectionMtx.Lock()
+	numWatched := len(p.closeRejections)
+	p.closeRejectionMtx.Unlock()
+	if numWatched != 0 {
+		t.Fatalf("expected no watched closes, got %v", numWatched)
+	}
+}
+
+// mockChainIO is a mock lnwallet.BlockChainIO, only reporting its best
+// height.
+type mockChainIO struct {
+	bestHeight int32
+}
+
+func (m *mockChainIO) GetBestBlock() (*chainhash.Hash, int32, error) {
+	return &chainhash.Hash{}, m.bestHeight, nil
+}
+
+func (m *mockChainIO) GetUtxo(txid *chainhash.Hash,
+	index uint32) (*wire.TxOut, error) {
+
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockChainIO) GetTransaction(txid *chainhash.Hash) (*wire.MsgTx,
+	error) {
+
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockChainIO) GetBlockHash(blockHeight int64) (*chainhash.Hash,
+	error) {
+
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockChainIO) GetBlock(blockHash *chainhash.Hash) (*wire.MsgBlock,
+	error) {
+
+	return nil, errors.New("not implemented")
+}
+
+// TestCheckExitHtlc asserts that HTLCs paying one of our invoices are only
+// accepted if they pay it in full and don't expire too soon, and that with
+// uniform invoice failures enabled, every failure is reported as an unknown
+// payment hash after consulting the htlc modifier and acceptance hooks, just
+// as for HTLCs which are accepted.
+func TestCheckExitHtlc(t *testing.T) {
+	oldCfg := cfg
+	defer func() {
+		cfg = oldCfg
+	}()
+
+	tempDir, err := ioutil.TempDir("", "checkexithtlc")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	cdb, err := channeldb.Open(tempDir)
+	if err != nil {
+		t.Fatalf("unable to open channeldb: %v", err)
+	}
+	defer cdb.Close()
+
+	invoices := newInvoiceRegistry(cdb, defaultInvoiceAcceptTimeout,
+		0, 0)
+	preimage := [32]byte{1, 2, 3}
+	err = invoices.AddInvoice(&channeldb.Invoice{
+		CreationDate: time.Now(),
+		Terms: channeldb.ContractTerm{
+			Value:           1000,
+			PaymentPreimage: preimage,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unable to add invoice: %v", err)
+	}
+	rHash := fastsha256.Sum256(preimage[:])
+
+	// Count the consultations of the acceptance hooks and of the htlc
+	// modifier, whose client leaves every HTLC unmodified.
+	var numHookCalls, numModifierCalls uint32
+	unregister := invoices.RegisterAcceptHook(
+		func(*invoiceAcceptRequest, <-chan struct{}) error {
+			atomic.AddUint32(&numHookCalls, 1)
+			return nil
+		},
+	)
+	defer unregister()
+
+	modifier := newHtlcModifier(5 * time.Second)
+	stream := newMockModifierStream()
+	quit := make(chan struct{})
+	defer close(quit)
+	go modifier.serve(stream, quit)
+	go func() {
+		for req := range stream.requests {
+			atomic.AddUint32(&numModifierCalls, 1)
+			stream.responses <- &lnrpc.HtlcModifyResponse{
+				RequestId: req.RequestId,
+			}
+		}
+	}()
+	for i := 0; ; i++ {
+		modifier.mtx.Lock()
+		registered := modifier.client != nil
+		modifier.mtx.Unlock()
+		if registered {
+			break
+		}
+
+		if i == 100 {
+			t.Fatalf("htlc modifier client wasn't registered")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	p := &peer{
+		server: &server{
+			invoices:     invoices,
+			bio:          &mockChainIO{bestHeight: 100},
+			htlcModifier: modifier,
+		},
+	}
+
+	tests := []struct {
+		name    string
+		uniform bool
+		rHash   [32]byte
+		amt     btcutil.Amount
+		expiry  uint32
+		ok      bool
+		reason  lnwire.CancelReason
+	}{
+		{
+			name:   "valid htlc",
+			rHash:  rHash,
+			amt:    1000,
+			expiry: 200,
+			ok:     true,
+		},
+		{
+			name:   "unknown payment hash",
+			amt:    1000,
+			expiry: 200,
+			reason: lnwire.UnknownPaymentHash,
+		},
+		{
+			name:   "expiry too soon",
+			rHash:  rHash,
+			amt:    1000,
+			expiry: 100 + expiryGraceDelta,
+			reason: lnwire.ExpiryTooSoon,
+		},
+		{
+			name:   "amount too low",
+			rHash:  rHash,
+			amt:    999,
+			expiry: 200,
+			reason: lnwire.IncorrectValue,
+		},
+		{
+			name:    "uniform valid htlc",
+			uniform: true,
+			rHash:   rHash,
+			amt:     1000,
+			expiry:  200,
+			ok:      true,
+		},
+		{
+			name:    "uniform unknown payment hash",
+			uniform: true,
+			amt:     1000,
+			expiry:  200,
+			reason:  lnwire.UnknownPaymentHash,
+		},
+		{
+			name:    "uniform expiry too soon",
+			uniform: true,
+			rHash:   rHash,
+			amt:     1000,
+			expiry:  100 + expiryGraceDelta,
+			reason:  lnwire.UnknownPaymentHash,
+		},
+		{
+			name:    "uniform amount too low",
+			uniform: true,
+			rHash:   rHash,
+			amt:     999,
+			expiry:  200,
+			reason:  lnwire.UnknownPaymentHash,
+		},
+	}
+	for _, test := range tests {
+		cfg = &config{UniformInvoiceFailures: test.uniform}
+		atomic.StoreUint32(&numHookCalls, 0)
+		atomic.StoreUint32(&numModifierCalls, 0)
+
+		invoice, reason, ok := p.checkExitHtlc(
+			wire.OutPoint{}, 0, &lnwire.HTLCAddRequest{
+				RedemptionHashes: [][32]byte{test.rHash},
+				Amount:           test.amt,
+				Expiry:           test.expiry,
+			}, nil,
+		)
+		if ok != test.ok {
+			t.Fatalf("%v: expected ok=%v, got %v", test.name,
+				test.ok, ok)
+		}
+		if ok && invoice == nil {
+			t.Fatalf("%v: accepted htlc without invoice", test.name)
+		}
+		if !ok && reason != test.reason {
+			t.Fatalf("%v: expected reason %v, got %v", test.name,
+				test.reason, reason)
+		}
+
+		// The modifier is only consulted on HTLCs which haven't
+		// failed before it, and the hooks on HTLCs which haven't
+		// failed before them, unless failures are uniform.
+		var expectedModifierCalls, expectedHookCalls uint32
+		if test.uniform || test.ok ||
+			test.reason == lnwire.IncorrectValue {
+
+			expectedModifierCalls = 1
+		}
+		if test.uniform || test.ok {
+			expectedHookCalls = 1
+		}
+		n := atomic.LoadUint32(&numModifierCalls)
+		if n != expectedModifierCalls {
+			t.Fatalf("%v: expected %v modifier calls, got %v",
+				test.name, expectedModifierCalls, n)
+		}
+		n = atomic.LoadUint32(&numHookCalls)
+		if n != expectedHookCalls {
+			t.Fatalf("%v: expected %v hook calls, got %v",
+				test.name, expectedHookCalls, n)
+		}
+	}
+}
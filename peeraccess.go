@@ -0,0 +1,210 @@
+package main
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+var (
+	// errPeerBanned is returned when a connection or channel with a banned
+	// peer is refused.
+	errPeerBanned = errors.New("peer is banned")
+
+	// errPeerNotAllowed is returned when a connection or channel with a
+	// peer which isn't within the allowlist is refused while running in
+	// allowlist only mode.
+	errPeerNotAllowed = errors.New("peer isn't allowlisted")
+)
+
+// parsePeerAccessEntry parses the peers an entry of a peer access list
+// applies to, identified either by their hex encoded public key, or by their
+// IP address.
+func parsePeerAccessEntry(target string) (*channeldb.PeerAccessEntry, error) {
+	if ip := net.ParseIP(target); ip != nil {
+		return &channeldb.PeerAccessEntry{IP: ip}, nil
+	}
+
+	pubKeyBytes, err := hex.DecodeString(target)
+	if err != nil {
+		return nil, fmt.Errorf("%q is neither a public key nor an IP "+
+			"address", target)
+	}
+	pubKey, err := btcec.ParsePubKey(pubKeyBytes, btcec.S256())
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key %q: %v", target, err)
+	}
+
+	return &channeldb.PeerAccessEntry{PubKey: pubKey}, nil
+}
+
+// parsePeerAccessEntries parses a set of peer access entries, as accepted by
+// parsePeerAccessEntry.
+func parsePeerAccessEntries(targets []string) ([]*channeldb.PeerAccessEntry,
+	error) {
+
+	entries := make([]*channeldb.PeerAccessEntry, 0, len(targets))
+	for _, target := range targets {
+		entry, err := parsePeerAccessEntry(target)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// peerAccessEntries is a set of peer access entries, keyed by their string
+// representation.
+type peerAccessEntries map[string]*channeldb.PeerAccessEntry
+
+// peerAccessManager decides which peers we accept connections and channels
+// from. Banned peers are always refused, while in allowlist only mode, any
+// peer which isn't allowlisted is refused as well. Peers are matched either
+// by their public key or by their IP address. The entries added at runtime
+// are persisted, while those passed on start up are only held in memory.
+type peerAccessManager struct {
+	db            *channeldb.DB
+	allowlistOnly bool
+
+	mtx   sync.RWMutex
+	lists map[channeldb.PeerAccessList]peerAccessEntries
+}
+
+// newPeerAccessManager creates a new peerAccessManager, loading the persisted
+// entries of both lists along with the passed ones.
+func newPeerAccessManager(db *channeldb.DB, allowlistOnly bool, banned,
+	allowed []*channeldb.PeerAccessEntry) (*peerAccessManager, error) {
+
+	m := &peerAccessManager{
+		db:            db,
+		allowlistOnly: allowlistOnly,
+		lists: map[channeldb.PeerAccessList]peerAccessEntries{
+			channeldb.BannedPeers:  make(peerAccessEntries),
+			channeldb.AllowedPeers: make(peerAccessEntries),
+		},
+	}
+
+	for list, entries := range m.lists {
+		persisted, err := db.FetchPeerAccessEntries(list)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range persisted {
+			entries[entry.String()] = entry
+		}
+	}
+	for _, entry := range banned {
+		m.lists[channeldb.BannedPeers][entry.String()] = entry
+	}
+	for _, entry := range allowed {
+		m.lists[channeldb.AllowedPeers][entry.String()] = entry
+	}
+
+	return m, nil
+}
+
+// isListed returns true if the peer with the passed public key, connected
+// over the passed address, is part of the passed list.
+func (m *peerAccessManager) isListed(list channeldb.PeerAccessList,
+	pubKey *btcec.PublicKey, addr *net.TCPAddr) bool {
+
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	entries := m.lists[list]
+	pubStr := hex.EncodeToString(pubKey.SerializeCompressed())
+	if _, ok := entries[pubStr]; ok {
+		return true
+	}
+	if addr == nil {
+		return false
+	}
+
+	_, ok := entries[addr.IP.String()]
+	return ok
+}
+
+// checkPeer returns an error if connections and channels with the peer with
+// the passed public key, connected over the passed address, must be refused.
+// The address may be nil if it's unknown.
+func (m *peerAccessManager) checkPeer(pubKey *btcec.PublicKey,
+	addr *net.TCPAddr) error {
+
+	if m.isListed(channeldb.BannedPeers, pubKey, addr) {
+		return errPeerBanned
+	}
+
+	if m.allowlistOnly && !m.isListed(channeldb.AllowedPeers, pubKey, addr) {
+		return errPeerNotAllowed
+	}
+
+	return nil
+}
+
+// addEntry adds the passed entry to the passed list, persisting it.
+func (m *peerAccessManager) addEntry(list channeldb.PeerAccessList,
+	entry *channeldb.PeerAccessEntry) error {
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if err := m.db.AddPeerAccessEntry(list, entry); err != nil {
+		return err
+	}
+	m.lists[list][entry.String()] = entry
+
+	return nil
+}
+
+// removeEntry removes the passed entry from the passed list. Entries passed
+// on start up are only removed until the next restart.
+func (m *peerAccessManager) removeEntry(list channeldb.PeerAccessList,
+	entry *channeldb.PeerAccessEntry) error {
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if err := m.db.RemovePeerAccessEntry(list, entry); err != nil {
+		return err
+	}
+	delete(m.lists[list], entry.String())
+
+	return nil
+}
+
+// entries returns the string representation of the entries of the passed
+// list, sorted.
+func (m *peerAccessManager) entries(list channeldb.PeerAccessList) []string {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	entries := make([]string, 0, len(m.lists[list]))
+	for entry := range m.lists[list] {
+		entries = append(entries, entry)
+	}
+	sort.Strings(entries)
+
+	return entries
+}
+
+// disconnectRefusedPeers disconnects from every peer whose connection we'd
+// now refuse, following a change to the peer access lists.
+func (s *server) disconnectRefusedPeers() {
+	for _, p := range s.Peers() {
+		err := s.peerAccess.checkPeer(p.addr.IdentityKey, p.addr.Address)
+		if err == nil {
+			continue
+		}
+
+		srvrLog.Infof("Disconnecting from peer %v: %v", p, err)
+		p.Disconnect()
+	}
+}
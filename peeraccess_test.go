@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// TestPeerAccessManager asserts that banned peers are refused, matched by
+// either their public key or their IP address, that only allowlisted peers
+// are accepted in allowlist only mode, and that entries added at runtime are
+// persisted across restarts.
+func TestPeerAccessManager(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "peeraccess")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	cdb, err := channeldb.Open(tempDir)
+	if err != nil {
+		t.Fatalf("unable to open channeldb: %v", err)
+	}
+	defer cdb.Close()
+
+	newPubKey := func() *btcec.PublicKey {
+		priv, err := btcec.NewPrivateKey(btcec.S256())
+		if err != nil {
+			t.Fatalf("unable to generate key: %v", err)
+		}
+		return priv.PubKey()
+	}
+	alice, bob, carol := newPubKey(), newPubKey(), newPubKey()
+	aliceAddr := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 9735}
+	bobAddr := &net.TCPAddr{IP: net.ParseIP("10.0.0.2"), Port: 9735}
+
+	// Alice is banned on start up by her public key.
+	aliceEntry, err := parsePeerAccessEntry(
+		hex.EncodeToString(alice.SerializeCompressed()),
+	)
+	if err != nil {
+		t.Fatalf("unable to parse entry: %v", err)
+	}
+	m, err := newPeerAccessManager(cdb, false,
+		[]*channeldb.PeerAccessEntry{aliceEntry}, nil)
+	if err != nil {
+		t.Fatalf("unable to create peer access manager: %v", err)
+	}
+	if err := m.checkPeer(alice, aliceAddr); err != errPeerBanned {
+		t.Fatalf("expected alice to be banned, got %v", err)
+	}
+	if err := m.checkPeer(bob, bobAddr); err != nil {
+		t.Fatalf("expected bob to be accepted, got %v", err)
+	}
+
+	// Once Bob's IP address is banned, any peer connected over it should
+	// be refused, while peers with an unknown address are still accepted.
+	bobIPEntry, err := parsePeerAccessEntry("10.0.0.2")
+	if err != nil {
+		t.Fatalf("unable to parse entry: %v", err)
+	}
+	if err := m.addEntry(channeldb.BannedPeers, bobIPEntry); err != nil {
+		t.Fatalf("unable to ban bob: %v", err)
+	}
+	if err := m.checkPeer(carol, bobAddr); err != errPeerBanned {
+		t.Fatalf("expected bob's address to be banned, got %v", err)
+	}
+	if err := m.checkPeer(bob, nil); err != nil {
+		t.Fatalf("expected bob to be accepted, got %v", err)
+	}
+
+	// Upon a restart in allowlist only mode, only the runtime ban should
+	// remain, and only allowlisted peers should be accepted.
+	carolEntry := &channeldb.PeerAccessEntry{PubKey: carol}
+	m, err = newPeerAccessManager(cdb, true, nil,
+		[]*channeldb.PeerAccessEntry{carolEntry})
+	if err != nil {
+		t.Fatalf("unable to create peer access manager: %v", err)
+	}
+	banned := m.entries(channeldb.BannedPeers)
+	if len(banned) != 1 || banned[0] != "10.0.0.2" {
+		t.Fatalf("expected only bob's address to be banned, got %v",
+			banned)
+	}
+	if err := m.checkPeer(alice, aliceAddr); err != errPeerNotAllowed {
+		t.Fatalf("expected alice not to be allowed, got %v", err)
+	}
+	if err := m.checkPeer(carol, aliceAddr); err != nil {
+		t.Fatalf("expected carol to be accepted, got %v", err)
+	}
+
+	// Bans take precedence over the allowlist.
+	if err := m.checkPeer(carol, bobAddr); err != errPeerBanned {
+		t.Fatalf("expected bob's address to be banned, got %v", err)
+	}
+}
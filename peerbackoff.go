@@ -0,0 +1,216 @@
+package main
+
+import (
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/connmgr"
+)
+
+const (
+	// minPeerBackoff is the backoff applied to a persistent peer the first
+	// time it disconnects shortly after we connected to it.
+	minPeerBackoff = time.Second * 5
+
+	// maxPeerBackoff is the largest backoff ever applied to a persistent
+	// peer.
+	maxPeerBackoff = time.Hour
+
+	// stablePeerConnDuration is how long a connection must have lasted
+	// for the peer to no longer be considered flapping once it
+	// disconnects, resetting its backoff.
+	stablePeerConnDuration = time.Minute * 10
+)
+
+// nextPeerBackoff returns the backoff to apply to a persistent peer which
+// disconnected after having been connected for connDuration, given its
+// previous backoff. The backoff doubles each time the peer disconnects
+// shortly after connecting, and is reset once a connection proves stable.
+func nextPeerBackoff(prevBackoff, connDuration time.Duration) time.Duration {
+	if connDuration >= stablePeerConnDuration {
+		return 0
+	}
+
+	if prevBackoff < minPeerBackoff {
+		return minPeerBackoff
+	}
+
+	backoff := prevBackoff * 2
+	if backoff > maxPeerBackoff {
+		return maxPeerBackoff
+	}
+
+	return backoff
+}
+
+// isPersistentPeer returns true if we're maintaining a persistent connection
+// with the peer with the passed public key.
+func (s *server) isPersistentPeer(pubKey *btcec.PublicKey) bool {
+	s.pendingConnMtx.RLock()
+	defer s.pendingConnMtx.RUnlock()
+
+	_, ok := s.persistentConnReqs[string(pubKey.SerializeCompressed())]
+	return ok
+}
+
+// recordPeerConnected records that the connection to the persistent peer
+// with the passed public key has just been established.
+func (s *server) recordPeerConnected(pubKey *btcec.PublicKey) {
+	s.peerBackoffMtx.Lock()
+	pubStr := string(pubKey.SerializeCompressed())
+	backoff, ok := s.peerBackoffs[pubStr]
+	if !ok {
+		backoff = &channeldb.PeerBackoff{}
+		s.peerBackoffs[pubStr] = backoff
+	}
+	backoff.LastConnected = time.Now()
+	updated := *backoff
+	s.peerBackoffMtx.Unlock()
+
+	// The state is persisted once the mutex has been released, so that
+	// the write doesn't hold up other callers.
+	if err := s.chanDB.PutPeerBackoff(pubKey, &updated); err != nil {
+		srvrLog.Errorf("unable to store backoff of peer %x: %v",
+			pubKey.SerializeCompressed(), err)
+	}
+}
+
+// recordPeerDisconnected records that the connection to the persistent peer
+// with the passed public key has just been lost, updating its backoff
+// according to how long the connection lasted.
+//
+// NOTE: This method writes to the database, so it mustn't be called while
+// holding the peers mutex.
+func (s *server) recordPeerDisconnected(pubKey *btcec.PublicKey) {
+	s.peerBackoffMtx.Lock()
+	pubStr := string(pubKey.SerializeCompressed())
+	backoff, ok := s.peerBackoffs[pubStr]
+	if !ok {
+		backoff = &channeldb.PeerBackoff{}
+		s.peerBackoffs[pubStr] = backoff
+	}
+
+	now := time.Now()
+	backoff.Backoff = nextPeerBackoff(
+		backoff.Backoff, now.Sub(backoff.LastConnected),
+	)
+	backoff.LastDisconnected = now
+	updated := *backoff
+	s.peerBackoffMtx.Unlock()
+
+	srvrLog.Debugf("Backoff of peer %x is now %v",
+		pubKey.SerializeCompressed(), updated.Backoff)
+
+	if err := s.chanDB.PutPeerBackoff(pubKey, &updated); err != nil {
+		srvrLog.Errorf("unable to store backoff of peer %x: %v",
+			pubKey.SerializeCompressed(), err)
+	}
+}
+
+// prunePeerBackoffs removes the backoff state of the peers we no longer
+// maintain a persistent connection with, such as those whose channels have
+// all been closed, so that it doesn't accumulate over time. It's called once
+// the persistent connections have been set up upon startup.
+func (s *server) prunePeerBackoffs() error {
+	s.pendingConnMtx.RLock()
+	persistent := make(map[string]struct{}, len(s.persistentConnReqs))
+	for pubStr := range s.persistentConnReqs {
+		persistent[pubStr] = struct{}{}
+	}
+	s.pendingConnMtx.RUnlock()
+
+	s.peerBackoffMtx.Lock()
+	var stale [][]byte
+	for pubStr := range s.peerBackoffs {
+		if _, ok := persistent[pubStr]; ok {
+			continue
+		}
+
+		stale = append(stale, []byte(pubStr))
+		delete(s.peerBackoffs, pubStr)
+	}
+	s.peerBackoffMtx.Unlock()
+
+	if len(stale) == 0 {
+		return nil
+	}
+
+	srvrLog.Debugf("Pruning backoff state of %v peers no longer "+
+		"persistent", len(stale))
+
+	return s.chanDB.DeletePeerBackoffs(stale)
+}
+
+// connectPersistentPeer hands off the passed persistent connection request
+// to the connection manager. If the backoff of the peer hasn't yet elapsed,
+// as is the case when the peer was flapping before a restart, the connection
+// is delayed until it has.
+func (s *server) connectPersistentPeer(pubStr string,
+	connReq *connmgr.ConnReq) {
+
+	s.pendingConnMtx.Lock()
+	s.persistentConnReqs[pubStr] = connReq
+	s.pendingConnMtx.Unlock()
+
+	var delay time.Duration
+	s.peerBackoffMtx.Lock()
+	if backoff, ok := s.peerBackoffs[pubStr]; ok {
+		delay = backoff.NextRetry().Sub(time.Now())
+	}
+	s.peerBackoffMtx.Unlock()
+
+	if delay <= 0 {
+		go s.connMgr.Connect(connReq)
+		return
+	}
+
+	srvrLog.Debugf("Delaying connection to %v by %v due to its backoff",
+		connReq.Addr, delay)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		select {
+		case <-time.After(delay):
+			s.connMgr.Connect(connReq)
+		case <-s.quit:
+		}
+	}()
+}
+
+// ReconnectingPeers returns the backoff state of each persistent peer we're
+// currently disconnected from, keyed by its serialized compressed public key.
+// Peers we never connected to have a zero backoff state.
+func (s *server) ReconnectingPeers() map[string]channeldb.PeerBackoff {
+	s.pendingConnMtx.RLock()
+	pubStrs := make([]string, 0, len(s.persistentConnReqs))
+	for pubStr := range s.persistentConnReqs {
+		pubStrs = append(pubStrs, pubStr)
+	}
+	s.pendingConnMtx.RUnlock()
+
+	// The connected peers are filtered out before acquiring the backoff
+	// mutex, so that the peers mutex is never held along with it.
+	disconnected := pubStrs[:0]
+	for _, pubStr := range pubStrs {
+		if !s.isPeerConnected([]byte(pubStr)) {
+			disconnected = append(disconnected, pubStr)
+		}
+	}
+
+	s.peerBackoffMtx.Lock()
+	defer s.peerBackoffMtx.Unlock()
+
+	reconnecting := make(map[string]channeldb.PeerBackoff)
+	for _, pubStr := range disconnected {
+		var backoff channeldb.PeerBackoff
+		if b, ok := s.peerBackoffs[pubStr]; ok {
+			backoff = *b
+		}
+		reconnecting[pubStr] = backoff
+	}
+
+	return reconnecting
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// TestNextPeerBackoff tests that the backoff of a persistent peer doubles
+// each time it disconnects shortly after connecting, up to its maximum, and
+// is reset once a connection proves stable.
+func TestNextPeerBackoff(t *testing.T) {
+	tests := []struct {
+		prevBackoff  time.Duration
+		connDuration time.Duration
+		expected     time.Duration
+	}{
+		{0, time.Second, minPeerBackoff},
+		{minPeerBackoff, time.Second, minPeerBackoff * 2},
+		{minPeerBackoff * 2, time.Minute, minPeerBackoff * 4},
+		{maxPeerBackoff / 2, time.Second, maxPeerBackoff},
+		{maxPeerBackoff, time.Second, maxPeerBackoff},
+		{maxPeerBackoff, stablePeerConnDuration, 0},
+		{0, time.Hour, 0},
+	}
+
+	for i, test := range tests {
+		backoff := nextPeerBackoff(test.prevBackoff, test.connDuration)
+		if backoff != test.expected {
+			t.Fatalf("test #%v: expected backoff %v, got %v", i,
+				test.expected, backoff)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"sync"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// peerEvent describes a peer coming online or going offline.
+type peerEvent struct {
+	eventType lnrpc.PeerEvent_EventType
+	pubKey    *btcec.PublicKey
+}
+
+// peerNotifier dispatches events concerning the connectivity of our peers to
+// any subscribed clients. The set of currently online peers is tracked so new
+// subscribers can be brought up to date with the current state.
+type peerNotifier struct {
+	notifier *eventNotifier
+
+	// onlinePeers maps the serialized public key of each peer currently
+	// connected to us to its public key. The mtx is held while notifying
+	// clients, so that new subscribers are brought up to date without
+	// missing, or being sent twice, any concurrent event.
+	mtx         sync.Mutex
+	onlinePeers map[string]*btcec.PublicKey
+}
+
+// newPeerNotifier creates a new peerNotifier with no subscribed clients.
+func newPeerNotifier() *peerNotifier {
+	return &peerNotifier{
+		notifier:    newEventNotifier(defaultEventQueueSize),
+		onlinePeers: make(map[string]*btcec.PublicKey),
+	}
+}
+
+// notifyPeerOnline records that the passed peer has connected, and notifies
+// all currently registered clients.
+func (p *peerNotifier) notifyPeerOnline(pubKey *btcec.PublicKey) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.onlinePeers[string(pubKey.SerializeCompressed())] = pubKey
+	p.notifier.notify(&peerEvent{
+		eventType: lnrpc.PeerEvent_PEER_ONLINE,
+		pubKey:    pubKey,
+	})
+}
+
+// notifyPeerOffline records that the passed peer has disconnected, and
+// notifies all currently registered clients.
+func (p *peerNotifier) notifyPeerOffline(pubKey *btcec.PublicKey) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	delete(p.onlinePeers, string(pubKey.SerializeCompressed()))
+	p.notifier.notify(&peerEvent{
+		eventType: lnrpc.PeerEvent_PEER_OFFLINE,
+		pubKey:    pubKey,
+	})
+}
+
+// SubscribePeerEvents returns an eventSubscription which allows the caller to
+// receive async notifications of our peers coming online or going offline.
+// An online event for each currently connected peer is delivered first,
+// bringing the caller up to date with the current state. Each event sent over
+// the subscription is a *peerEvent.
+func (p *peerNotifier) SubscribePeerEvents() *eventSubscription {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	initial := make([]interface{}, 0, len(p.onlinePeers))
+	for _, pubKey := range p.onlinePeers {
+		initial = append(initial, &peerEvent{
+			eventType: lnrpc.PeerEvent_PEER_ONLINE,
+			pubKey:    pubKey,
+		})
+	}
+
+	return p.notifier.subscribe(initial...)
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// TestPeerNotifierCurrentState asserts that new subscribers first receive an
+// online event for each connected peer, followed by any later events.
+func TestPeerNotifierCurrentState(t *testing.T) {
+	alice, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	bob, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+
+	notifier := newPeerNotifier()
+
+	// Both peers connect, but only Alice remains online by the time the
+	// client subscribes.
+	notifier.notifyPeerOnline(alice.PubKey())
+	notifier.notifyPeerOnline(bob.PubKey())
+	notifier.notifyPeerOffline(bob.PubKey())
+
+	client := notifier.SubscribePeerEvents()
+	defer client.Cancel()
+
+	notifier.notifyPeerOffline(alice.PubKey())
+
+	expected := []struct {
+		eventType lnrpc.PeerEvent_EventType
+		pubKey    *btcec.PublicKey
+	}{
+		{lnrpc.PeerEvent_PEER_ONLINE, alice.PubKey()},
+		{lnrpc.PeerEvent_PEER_OFFLINE, alice.PubKey()},
+	}
+	for i, exp := range expected {
+		select {
+		case e := <-client.Events:
+			event := e.(*peerEvent)
+			if event.eventType != exp.eventType {
+				t.Fatalf("event #%v: expected %v, got %v", i,
+					exp.eventType, event.eventType)
+			}
+			if !event.pubKey.IsEqual(exp.pubKey) {
+				t.Fatalf("event #%v: wrong peer", i)
+			}
+		case <-time.After(time.Second * 5):
+			t.Fatalf("event #%v not received", i)
+		}
+	}
+
+	select {
+	case e := <-client.Events:
+		t.Fatalf("unexpected event: %v", e.(*peerEvent).eventType)
+	case <-time.After(time.Millisecond * 100):
+	}
+}
@@ -0,0 +1,347 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+
+	"github.com/lightningnetwork/lnd/autopilot"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// chanController is the autopilot.ChannelController backed by the server,
+// opening channels just as the OpenChannel RPC does.
+type chanController struct {
+	server *server
+}
+
+// A compile time check to ensure that chanController implements the
+// autopilot.ChannelController interface.
+var _ autopilot.ChannelController = (*chanController)(nil)
+
+// OpenChannel connects to the target node if we aren't connected already,
+// then opens a channel of the passed amount to it. It returns once the
+// funding transaction has been broadcast.
+//
+// NOTE: This is part of the autopilot.ChannelController interface.
+func (c *chanController) OpenChannel(target *btcec.PublicKey,
+	amt btcutil.Amount, addrs []net.Addr) error {
+
+	if !c.server.isPeerConnected(target.SerializeCompressed()) {
+		var err error
+		for _, addr := range addrs {
+			tcpAddr, ok := addr.(*net.TCPAddr)
+			if !ok {
+				continue
+			}
+
+			err = c.server.ConnectToPeer(&lnwire.NetAddress{
+				IdentityKey: target,
+				Address:     tcpAddr,
+				ChainNet:    activeNetParams.Net,
+			}, false)
+			if err == nil {
+				break
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	updateChan, errChan := c.server.OpenChannel(-1, target, amt, 0, 1,
+		nil, channeldb.ChannelConstraints{}, nil)
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-updateChan:
+		return nil
+	case <-c.server.quit:
+		return errors.New("server shutting down")
+	}
+}
+
+// autopilotManager allows the autopilot agent to be enabled and disabled at
+// runtime, and scores nodes with the agent's heuristic regardless of whether
+// the agent is active.
+type autopilotManager struct {
+	// cfg is the config each new agent is created with.
+	cfg autopilot.Config
+
+	// heuristic is the agent's heuristic, combining the scores of each
+	// of the configured heuristics.
+	heuristic *autopilot.WeightedCombAttachment
+
+	server *server
+
+	// agent is the currently active agent, or nil if the agent is
+	// disabled. quit stops the goroutine informing the active agent of
+	// changes to our channels. Both are guarded by the mtx.
+	mtx   sync.Mutex
+	agent *autopilot.Agent
+	quit  chan struct{}
+	wg    sync.WaitGroup
+}
+
+// newAutopilotHeuristic creates the heuristic combining each of the named
+// heuristics, weighted by the passed weights.
+func newAutopilotHeuristic(
+	weights map[string]float64) (*autopilot.WeightedCombAttachment, error) {
+
+	// Combine the heuristics in a deterministic order, so that the
+	// results of queries are as well.
+	names := make([]string, 0, len(weights))
+	for name := range weights {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	heuristics := make([]*autopilot.WeightedHeuristic, 0, len(names))
+	for _, name := range names {
+		h, err := autopilot.NewHeuristic(name)
+		if err != nil {
+			return nil, err
+		}
+
+		heuristics = append(heuristics, &autopilot.WeightedHeuristic{
+			Weight:              weights[name],
+			AttachmentHeuristic: h,
+		})
+	}
+
+	return autopilot.NewWeightedCombAttachment(heuristics...)
+}
+
+// newAutopilotManager creates a new autopilotManager for the passed server,
+// with the agent's heuristic and budget determined by the passed config. The
+// agent is initially disabled.
+func newAutopilotManager(s *server,
+	cfg *autopilotConfig) (*autopilotManager, error) {
+
+	heuristic, err := newAutopilotHeuristic(cfg.Heuristic)
+	if err != nil {
+		return nil, err
+	}
+
+	return &autopilotManager{
+		heuristic: heuristic,
+		cfg: autopilot.Config{
+			Self:           s.nodeKey.PubKey(),
+			Heuristic:      heuristic,
+			ChanController: &chanController{server: s},
+			WalletBalance: func() (btcutil.Amount, error) {
+				return s.lnwallet.ConfirmedBalance(1, true)
+			},
+			Channels: func() ([]autopilot.Channel, error) {
+				return fetchAutopilotChannels(s)
+			},
+			Graph: autopilot.ChannelGraphFromDatabase(
+				s.chanDB.ChannelGraph(),
+			),
+			Constraints: autopilot.AgentConstraints{
+				Allocation:  cfg.Allocation,
+				MaxChanNum:  cfg.MaxChannels,
+				MinChanSize: btcutil.Amount(cfg.MinChannelSize),
+				MaxChanSize: btcutil.Amount(cfg.MaxChannelSize),
+			},
+		},
+		server: s,
+	}, nil
+}
+
+// fetchAutopilotChannels returns our current set of channels, including
+// those whose funding transaction awaits confirmation, in the form
+// understood by the autopilot agent.
+func fetchAutopilotChannels(s *server) ([]autopilot.Channel, error) {
+	dbChans, err := s.chanDB.FetchAllChannels()
+	if err != nil && err != channeldb.ErrNoActiveChannels {
+		return nil, err
+	}
+	pendingChans := s.fundingMgr.PendingChannels()
+
+	chans := make([]autopilot.Channel, 0, len(dbChans)+len(pendingChans))
+	known := make(map[wire.OutPoint]struct{}, len(dbChans))
+	for _, c := range dbChans {
+		chans = append(chans, autopilot.Channel{
+			ChanPoint: *c.ChanID,
+			Capacity:  c.Capacity,
+			Node:      autopilot.NewNodeID(c.IdentityPub),
+		})
+		known[*c.ChanID] = struct{}{}
+	}
+
+	// A reservation is only released once its channel has been written
+	// to the database, so a channel may briefly be reported by both.
+	// Reservations which have yet to settle on a funding outpoint are
+	// still counted.
+	for _, c := range pendingChans {
+		var chanPoint wire.OutPoint
+		if c.channelPoint != nil {
+			chanPoint = *c.channelPoint
+		}
+		if _, ok := known[chanPoint]; ok && c.channelPoint != nil {
+			continue
+		}
+
+		chans = append(chans, autopilot.Channel{
+			ChanPoint: chanPoint,
+			Capacity:  c.capacity,
+			Node:      autopilot.NewNodeID(c.identityPub),
+		})
+	}
+
+	return chans, nil
+}
+
+// Active returns whether the autopilot agent is currently active.
+func (m *autopilotManager) Active() bool {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	return m.agent != nil
+}
+
+// Enable starts a new autopilot agent, if none is active already.
+func (m *autopilotManager) Enable() error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.agent != nil {
+		return nil
+	}
+
+	agent := autopilot.New(m.cfg)
+	if err := agent.Start(); err != nil {
+		return err
+	}
+
+	// Inform the agent of every change to our channels, as each may
+	// change its budget. Should we fail to keep up with the events, then
+	// we subscribe once again, informing the agent as any of the events
+	// missed may have changed our channels.
+	quit := make(chan struct{})
+	sub := m.server.channelNotifier.SubscribeChannelEvents()
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		defer func() {
+			sub.Cancel()
+		}()
+
+		for {
+			select {
+			case <-sub.Events:
+				agent.OnChannelsChanged()
+			case <-sub.Overflow:
+				sub = m.server.channelNotifier.SubscribeChannelEvents()
+				agent.OnChannelsChanged()
+			case <-quit:
+				return
+			}
+		}
+	}()
+
+	m.agent = agent
+	m.quit = quit
+
+	return nil
+}
+
+// Disable stops the active autopilot agent, if any. Channels the agent has
+// already begun funding are left to confirm.
+func (m *autopilotManager) Disable() error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.agent == nil {
+		return nil
+	}
+
+	close(m.quit)
+	m.wg.Wait()
+
+	if err := m.agent.Stop(); err != nil {
+		return err
+	}
+
+	m.agent = nil
+	m.quit = nil
+
+	return nil
+}
+
+// NodeScores returns the scores the agent's heuristic assigns to the passed
+// nodes. If no nodes are passed, then every node within the graph is scored.
+func (m *autopilotManager) NodeScores(
+	nodes []autopilot.NodeID) (map[autopilot.NodeID]*autopilot.NodeScore,
+	error) {
+
+	return m.heuristicScores(m.heuristic, nodes, false)
+}
+
+// SetNodeScores sets the scores of the heuristic with the passed name, which
+// must be one of the configured heuristics able to have its scores set
+// externally. Once set, the active agent is informed, so it may act upon the
+// new scores.
+func (m *autopilotManager) SetNodeScores(targetHeuristic string,
+	scores map[autopilot.NodeID]float64) error {
+
+	ok, err := m.heuristic.SetNodeScores(targetHeuristic, scores)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("heuristic %v is not active, or doesn't "+
+			"accept external scores", targetHeuristic)
+	}
+
+	m.mtx.Lock()
+	if m.agent != nil {
+		m.agent.OnChannelsChanged()
+	}
+	m.mtx.Unlock()
+
+	return nil
+}
+
+// heuristicScores returns the scores the passed heuristic assigns to the
+// passed nodes, or to every node within the graph if none are passed. If
+// ignoreLocalState is set, then our existing channels aren't taken into
+// account, so nodes we already have a channel with are scored as well.
+func (m *autopilotManager) heuristicScores(h autopilot.AttachmentHeuristic,
+	nodes []autopilot.NodeID,
+	ignoreLocalState bool) (map[autopilot.NodeID]*autopilot.NodeScore, error) {
+
+	candidates := make(map[autopilot.NodeID]struct{})
+	for _, nID := range nodes {
+		candidates[nID] = struct{}{}
+	}
+
+	if len(candidates) == 0 {
+		err := m.cfg.Graph.ForEachNode(func(n autopilot.Node) error {
+			candidates[n.PubKey()] = struct{}{}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var chans []autopilot.Channel
+	if !ignoreLocalState {
+		var err error
+		chans, err = m.cfg.Channels()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return h.NodeScores(m.cfg.Graph, chans, candidates)
+}
@@ -0,0 +1,118 @@
+package psbt
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+)
+
+var (
+	// ErrNotFinalizable is returned when an input lacks the information
+	// required to construct its final script sig and witness.
+	ErrNotFinalizable = errors.New("psbt input cannot be finalized")
+
+	// ErrIncompletePsbt is returned when attempting to extract the final
+	// transaction from a packet in which not every input is finalized.
+	ErrIncompletePsbt = errors.New("psbt is not fully finalized")
+)
+
+// isFinalized returns true if the target input already carries its final
+// script sig or witness.
+func (pi *PInput) isFinalized() bool {
+	return pi.FinalScriptSig != nil || pi.FinalScriptWitness != nil
+}
+
+// IsComplete returns true if every input of the packet has been finalized.
+func (p *Packet) IsComplete() bool {
+	for i := range p.Inputs {
+		if !p.Inputs[i].isFinalized() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Finalize constructs the final script sig and witness of the target input
+// from its partial signature. Only p2wkh inputs, optionally nested within a
+// p2sh output, are currently supported.
+func (p *Packet) Finalize(inIndex int) error {
+	if inIndex < 0 || inIndex >= len(p.Inputs) {
+		return fmt.Errorf("input index %d out of range", inIndex)
+	}
+
+	pInput := &p.Inputs[inIndex]
+	if pInput.isFinalized() {
+		return nil
+	}
+	if pInput.WitnessUtxo == nil || len(pInput.PartialSigs) != 1 {
+		return ErrNotFinalizable
+	}
+
+	pkScript := pInput.WitnessUtxo.PkScript
+	sig := pInput.PartialSigs[0]
+
+	switch {
+	// A native p2wkh output only requires the signature and public key
+	// within the witness.
+	case txscript.IsPayToWitnessPubKeyHash(pkScript):
+
+	// A p2wkh output nested within a p2sh output additionally requires a
+	// script sig pushing the witness program.
+	case txscript.IsPayToScriptHash(pkScript) &&
+		txscript.IsPayToWitnessPubKeyHash(pInput.RedeemScript):
+
+		bldr := txscript.NewScriptBuilder()
+		bldr.AddData(pInput.RedeemScript)
+		sigScript, err := bldr.Script()
+		if err != nil {
+			return err
+		}
+		pInput.FinalScriptSig = sigScript
+
+	default:
+		return ErrNotFinalizable
+	}
+
+	pInput.FinalScriptWitness = [][]byte{sig.Signature, sig.PubKey}
+
+	// With the input finalized, the signing data is no longer required.
+	pInput.PartialSigs = nil
+	pInput.SighashType = 0
+	pInput.RedeemScript = nil
+	pInput.WitnessScript = nil
+	pInput.Bip32Derivation = nil
+
+	return nil
+}
+
+// MaybeFinalizeAll attempts to finalize every input of the packet, returning
+// an error if any of them cannot be finalized.
+func (p *Packet) MaybeFinalizeAll() error {
+	for i := range p.Inputs {
+		if err := p.Finalize(i); err != nil {
+			return fmt.Errorf("unable to finalize input %d: %v", i,
+				err)
+		}
+	}
+
+	return nil
+}
+
+// Extract returns the final, fully signed transaction of a complete packet.
+// The packet itself is left unmodified.
+func (p *Packet) Extract() (*wire.MsgTx, error) {
+	if !p.IsComplete() {
+		return nil, ErrIncompletePsbt
+	}
+
+	finalTx := p.UnsignedTx.Copy()
+	for i, txIn := range finalTx.TxIn {
+		txIn.SignatureScript = p.Inputs[i].FinalScriptSig
+		txIn.Witness = p.Inputs[i].FinalScriptWitness
+	}
+
+	return finalTx, nil
+}
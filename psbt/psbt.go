@@ -0,0 +1,749 @@
+package psbt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+// psbtMagic is the magic byte sequence which prefixes every serialized
+// partially signed bitcoin transaction, as defined within BIP 174.
+var psbtMagic = [5]byte{0x70, 0x73, 0x62, 0x74, 0xff}
+
+// maxPsbtKeyValueSize is the maximum size of any key or value within a
+// serialized packet. We use this to avoid allocating unbounded amounts of
+// memory when parsing an untrusted packet.
+const maxPsbtKeyValueSize = 4000000
+
+// The set of key types that are recognized within the global map of a
+// packet.
+const (
+	globalUnsignedTxType byte = 0x00
+)
+
+// The set of key types that are recognized within an input map of a packet.
+const (
+	inputNonWitnessUtxoType     byte = 0x00
+	inputWitnessUtxoType        byte = 0x01
+	inputPartialSigType         byte = 0x02
+	inputSighashType            byte = 0x03
+	inputRedeemScriptType       byte = 0x04
+	inputWitnessScriptType      byte = 0x05
+	inputBip32DerivationType    byte = 0x06
+	inputFinalScriptSigType     byte = 0x07
+	inputFinalScriptWitnessType byte = 0x08
+)
+
+// The set of key types that are recognized within an output map of a packet.
+const (
+	outputRedeemScriptType    byte = 0x00
+	outputWitnessScriptType   byte = 0x01
+	outputBip32DerivationType byte = 0x02
+)
+
+var (
+	// ErrInvalidMagic is returned when a packet doesn't begin with the
+	// BIP 174 magic bytes.
+	ErrInvalidMagic = errors.New("invalid psbt magic bytes")
+
+	// ErrDuplicateKey is returned when a key is repeated within a single
+	// map of a packet.
+	ErrDuplicateKey = errors.New("duplicate key within psbt map")
+
+	// ErrInvalidKeyData is returned when a recognized key carries
+	// unexpected key data.
+	ErrInvalidKeyData = errors.New("invalid key data within psbt map")
+
+	// ErrNoUnsignedTx is returned when a packet doesn't contain the
+	// mandatory unsigned transaction within its global map.
+	ErrNoUnsignedTx = errors.New("psbt is missing the unsigned tx")
+
+	// ErrSignedUnsignedTx is returned when the global unsigned
+	// transaction carries signature scripts or witnesses.
+	ErrSignedUnsignedTx = errors.New("psbt unsigned tx contains " +
+		"signature data")
+
+	// ErrInputCountMismatch is returned when the number of input maps
+	// doesn't match the number of inputs of the unsigned transaction.
+	ErrInputCountMismatch = errors.New("number of psbt inputs doesn't " +
+		"match the unsigned tx")
+)
+
+// Unknown is a key-value pair within a packet map that isn't recognized by
+// this package. Unknown pairs are retained so they survive a round trip
+// through parsing and serialization.
+type Unknown struct {
+	Key   []byte
+	Value []byte
+}
+
+// PartialSig is a signature for a particular input, along with the public key
+// it was generated with.
+type PartialSig struct {
+	PubKey    []byte
+	Signature []byte
+}
+
+// Bip32Derivation records the master key fingerprint and derivation path of
+// a public key used within an input or output.
+type Bip32Derivation struct {
+	PubKey               []byte
+	MasterKeyFingerprint uint32
+	Bip32Path            []uint32
+}
+
+// PInput is the set of information known about a single input of the
+// unsigned transaction.
+type PInput struct {
+	NonWitnessUtxo     *wire.MsgTx
+	WitnessUtxo        *wire.TxOut
+	PartialSigs        []*PartialSig
+	SighashType        uint32
+	RedeemScript       []byte
+	WitnessScript      []byte
+	Bip32Derivation    []*Bip32Derivation
+	FinalScriptSig     []byte
+	FinalScriptWitness [][]byte
+	Unknowns           []*Unknown
+}
+
+// POutput is the set of information known about a single output of the
+// unsigned transaction.
+type POutput struct {
+	RedeemScript    []byte
+	WitnessScript   []byte
+	Bip32Derivation []*Bip32Derivation
+	Unknowns        []*Unknown
+}
+
+// Packet is a partially signed bitcoin transaction as defined by BIP 174. It
+// wraps an unsigned transaction with the additional information required by
+// signers to add their signatures, and by a finalizer to construct the final
+// fully signed transaction.
+type Packet struct {
+	// UnsignedTx is the transaction being signed. It must not contain any
+	// signature scripts or witnesses.
+	UnsignedTx *wire.MsgTx
+
+	// Inputs holds the per-input information, one entry for each input of
+	// the unsigned transaction.
+	Inputs []PInput
+
+	// Outputs holds the per-output information, one entry for each output
+	// of the unsigned transaction.
+	Outputs []POutput
+
+	// Unknowns are any unrecognized key-value pairs of the global map.
+	Unknowns []*Unknown
+}
+
+// New creates a new packet wrapping an unsigned transaction spending the
+// passed inputs and creating the passed outputs.
+func New(inputs []*wire.OutPoint, outputs []*wire.TxOut, version int32,
+	lockTime uint32, sequences []uint32) (*Packet, error) {
+
+	if len(sequences) != len(inputs) {
+		return nil, fmt.Errorf("expected %d sequences, got %d",
+			len(inputs), len(sequences))
+	}
+
+	unsignedTx := &wire.MsgTx{
+		Version:  version,
+		LockTime: lockTime,
+	}
+	for i, in := range inputs {
+		unsignedTx.AddTxIn(&wire.TxIn{
+			PreviousOutPoint: *in,
+			Sequence:         sequences[i],
+		})
+	}
+	for _, out := range outputs {
+		unsignedTx.AddTxOut(out)
+	}
+
+	return NewFromUnsignedTx(unsignedTx)
+}
+
+// NewFromUnsignedTx creates a new packet wrapping the passed unsigned
+// transaction. An error is returned if the transaction carries any signature
+// data.
+func NewFromUnsignedTx(tx *wire.MsgTx) (*Packet, error) {
+	if err := checkUnsignedTx(tx); err != nil {
+		return nil, err
+	}
+
+	return &Packet{
+		UnsignedTx: tx,
+		Inputs:     make([]PInput, len(tx.TxIn)),
+		Outputs:    make([]POutput, len(tx.TxOut)),
+	}, nil
+}
+
+// checkUnsignedTx ensures that none of the inputs of the passed transaction
+// carry a signature script or witness.
+func checkUnsignedTx(tx *wire.MsgTx) error {
+	for _, txIn := range tx.TxIn {
+		if len(txIn.SignatureScript) != 0 || len(txIn.Witness) != 0 {
+			return ErrSignedUnsignedTx
+		}
+	}
+
+	return nil
+}
+
+// NewFromRawBytes parses a serialized packet from the passed reader. If b64
+// is true, then the packet is expected to be base64 encoded.
+func NewFromRawBytes(r io.Reader, b64 bool) (*Packet, error) {
+	if b64 {
+		r = base64.NewDecoder(base64.StdEncoding, r)
+	}
+
+	var magic [5]byte
+	if _, err := io.ReadFull(r, magic[:]); err != nil {
+		return nil, err
+	}
+	if magic != psbtMagic {
+		return nil, ErrInvalidMagic
+	}
+
+	p := &Packet{}
+
+	// First, parse the global map, which must contain the unsigned
+	// transaction.
+	seen := make(map[string]struct{})
+	for {
+		key, value, err := readKeyValue(r)
+		if err != nil {
+			return nil, err
+		}
+		if key == nil {
+			break
+		}
+		if _, ok := seen[string(key)]; ok {
+			return nil, ErrDuplicateKey
+		}
+		seen[string(key)] = struct{}{}
+
+		switch key[0] {
+		case globalUnsignedTxType:
+			if len(key) != 1 {
+				return nil, ErrInvalidKeyData
+			}
+			// The unsigned transaction is always serialized
+			// without witness data. As it may have no inputs yet,
+			// it mustn't be parsed as if it had a segwit marker.
+			tx := &wire.MsgTx{}
+			err := tx.DeserializeNoWitness(bytes.NewReader(value))
+			if err != nil {
+				return nil, err
+			}
+			if err := checkUnsignedTx(tx); err != nil {
+				return nil, err
+			}
+			p.UnsignedTx = tx
+
+		default:
+			p.Unknowns = append(p.Unknowns, &Unknown{key, value})
+		}
+	}
+	if p.UnsignedTx == nil {
+		return nil, ErrNoUnsignedTx
+	}
+
+	// With the unsigned transaction known, we'll parse exactly one map
+	// for each of its inputs and outputs.
+	p.Inputs = make([]PInput, len(p.UnsignedTx.TxIn))
+	for i := range p.Inputs {
+		if err := p.Inputs[i].deserialize(r); err != nil {
+			return nil, err
+		}
+	}
+	p.Outputs = make([]POutput, len(p.UnsignedTx.TxOut))
+	for i := range p.Outputs {
+		if err := p.Outputs[i].deserialize(r); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+// Serialize writes the packet in its binary BIP 174 encoding to the passed
+// writer.
+func (p *Packet) Serialize(w io.Writer) error {
+	if p.UnsignedTx == nil {
+		return ErrNoUnsignedTx
+	}
+	if len(p.Inputs) != len(p.UnsignedTx.TxIn) {
+		return ErrInputCountMismatch
+	}
+	if len(p.Outputs) != len(p.UnsignedTx.TxOut) {
+		return fmt.Errorf("number of psbt outputs doesn't match the " +
+			"unsigned tx")
+	}
+
+	if _, err := w.Write(psbtMagic[:]); err != nil {
+		return err
+	}
+
+	var txBuf bytes.Buffer
+	if err := p.UnsignedTx.SerializeNoWitness(&txBuf); err != nil {
+		return err
+	}
+	err := writeKeyValue(w, []byte{globalUnsignedTxType}, txBuf.Bytes())
+	if err != nil {
+		return err
+	}
+	if err := writeUnknowns(w, p.Unknowns); err != nil {
+		return err
+	}
+	if err := writeSeparator(w); err != nil {
+		return err
+	}
+
+	for i := range p.Inputs {
+		if err := p.Inputs[i].serialize(w); err != nil {
+			return err
+		}
+	}
+	for i := range p.Outputs {
+		if err := p.Outputs[i].serialize(w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// B64Encode returns the base64 encoding of the serialized packet.
+func (p *Packet) B64Encode() (string, error) {
+	var b bytes.Buffer
+	if err := p.Serialize(&b); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(b.Bytes()), nil
+}
+
+// deserialize reads a single input map from the passed reader.
+func (pi *PInput) deserialize(r io.Reader) error {
+	seen := make(map[string]struct{})
+	for {
+		key, value, err := readKeyValue(r)
+		if err != nil {
+			return err
+		}
+		if key == nil {
+			return nil
+		}
+		if _, ok := seen[string(key)]; ok {
+			return ErrDuplicateKey
+		}
+		seen[string(key)] = struct{}{}
+
+		keyData := key[1:]
+		switch key[0] {
+		case inputNonWitnessUtxoType:
+			if len(keyData) != 0 {
+				return ErrInvalidKeyData
+			}
+			tx := &wire.MsgTx{}
+			if err := tx.Deserialize(bytes.NewReader(value)); err != nil {
+				return err
+			}
+			pi.NonWitnessUtxo = tx
+
+		case inputWitnessUtxoType:
+			if len(keyData) != 0 {
+				return ErrInvalidKeyData
+			}
+			txOut, err := readTxOut(value)
+			if err != nil {
+				return err
+			}
+			pi.WitnessUtxo = txOut
+
+		case inputPartialSigType:
+			if len(keyData) != 33 && len(keyData) != 65 {
+				return ErrInvalidKeyData
+			}
+			pi.PartialSigs = append(pi.PartialSigs, &PartialSig{
+				PubKey:    keyData,
+				Signature: value,
+			})
+
+		case inputSighashType:
+			if len(keyData) != 0 || len(value) != 4 {
+				return ErrInvalidKeyData
+			}
+			pi.SighashType = binary.LittleEndian.Uint32(value)
+
+		case inputRedeemScriptType:
+			if len(keyData) != 0 {
+				return ErrInvalidKeyData
+			}
+			pi.RedeemScript = value
+
+		case inputWitnessScriptType:
+			if len(keyData) != 0 {
+				return ErrInvalidKeyData
+			}
+			pi.WitnessScript = value
+
+		case inputBip32DerivationType:
+			derivation, err := readBip32Derivation(keyData, value)
+			if err != nil {
+				return err
+			}
+			pi.Bip32Derivation = append(pi.Bip32Derivation, derivation)
+
+		case inputFinalScriptSigType:
+			if len(keyData) != 0 {
+				return ErrInvalidKeyData
+			}
+			pi.FinalScriptSig = value
+
+		case inputFinalScriptWitnessType:
+			if len(keyData) != 0 {
+				return ErrInvalidKeyData
+			}
+			witness, err := readWitness(value)
+			if err != nil {
+				return err
+			}
+			pi.FinalScriptWitness = witness
+
+		default:
+			pi.Unknowns = append(pi.Unknowns, &Unknown{key, value})
+		}
+	}
+}
+
+// serialize writes the input map to the passed writer.
+func (pi *PInput) serialize(w io.Writer) error {
+	if pi.NonWitnessUtxo != nil {
+		var b bytes.Buffer
+		if err := pi.NonWitnessUtxo.Serialize(&b); err != nil {
+			return err
+		}
+		err := writeKeyValue(w, []byte{inputNonWitnessUtxoType},
+			b.Bytes())
+		if err != nil {
+			return err
+		}
+	}
+	if pi.WitnessUtxo != nil {
+		var b bytes.Buffer
+		if err := writeTxOut(&b, pi.WitnessUtxo); err != nil {
+			return err
+		}
+		err := writeKeyValue(w, []byte{inputWitnessUtxoType}, b.Bytes())
+		if err != nil {
+			return err
+		}
+	}
+
+	// Once an input has been finalized, the signing data is no longer
+	// needed, so we omit it as mandated by BIP 174.
+	if pi.FinalScriptSig == nil && pi.FinalScriptWitness == nil {
+		for _, sig := range pi.PartialSigs {
+			key := append([]byte{inputPartialSigType}, sig.PubKey...)
+			if err := writeKeyValue(w, key, sig.Signature); err != nil {
+				return err
+			}
+		}
+		if pi.SighashType != 0 {
+			var v [4]byte
+			binary.LittleEndian.PutUint32(v[:], pi.SighashType)
+			err := writeKeyValue(w, []byte{inputSighashType}, v[:])
+			if err != nil {
+				return err
+			}
+		}
+		if pi.RedeemScript != nil {
+			err := writeKeyValue(w, []byte{inputRedeemScriptType},
+				pi.RedeemScript)
+			if err != nil {
+				return err
+			}
+		}
+		if pi.WitnessScript != nil {
+			err := writeKeyValue(w, []byte{inputWitnessScriptType},
+				pi.WitnessScript)
+			if err != nil {
+				return err
+			}
+		}
+		err := writeBip32Derivations(w, inputBip32DerivationType,
+			pi.Bip32Derivation)
+		if err != nil {
+			return err
+		}
+	}
+
+	if pi.FinalScriptSig != nil {
+		err := writeKeyValue(w, []byte{inputFinalScriptSigType},
+			pi.FinalScriptSig)
+		if err != nil {
+			return err
+		}
+	}
+	if pi.FinalScriptWitness != nil {
+		var b bytes.Buffer
+		if err := writeWitness(&b, pi.FinalScriptWitness); err != nil {
+			return err
+		}
+		err := writeKeyValue(w, []byte{inputFinalScriptWitnessType},
+			b.Bytes())
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := writeUnknowns(w, pi.Unknowns); err != nil {
+		return err
+	}
+
+	return writeSeparator(w)
+}
+
+// deserialize reads a single output map from the passed reader.
+func (po *POutput) deserialize(r io.Reader) error {
+	seen := make(map[string]struct{})
+	for {
+		key, value, err := readKeyValue(r)
+		if err != nil {
+			return err
+		}
+		if key == nil {
+			return nil
+		}
+		if _, ok := seen[string(key)]; ok {
+			return ErrDuplicateKey
+		}
+		seen[string(key)] = struct{}{}
+
+		keyData := key[1:]
+		switch key[0] {
+		case outputRedeemScriptType:
+			if len(keyData) != 0 {
+				return ErrInvalidKeyData
+			}
+			po.RedeemScript = value
+
+		case outputWitnessScriptType:
+			if len(keyData) != 0 {
+				return ErrInvalidKeyData
+			}
+			po.WitnessScript = value
+
+		case outputBip32DerivationType:
+			derivation, err := readBip32Derivation(keyData, value)
+			if err != nil {
+				return err
+			}
+			po.Bip32Derivation = append(po.Bip32Derivation, derivation)
+
+		default:
+			po.Unknowns = append(po.Unknowns, &Unknown{key, value})
+		}
+	}
+}
+
+// serialize writes the output map to the passed writer.
+func (po *POutput) serialize(w io.Writer) error {
+	if po.RedeemScript != nil {
+		err := writeKeyValue(w, []byte{outputRedeemScriptType},
+			po.RedeemScript)
+		if err != nil {
+			return err
+		}
+	}
+	if po.WitnessScript != nil {
+		err := writeKeyValue(w, []byte{outputWitnessScriptType},
+			po.WitnessScript)
+		if err != nil {
+			return err
+		}
+	}
+	err := writeBip32Derivations(w, outputBip32DerivationType,
+		po.Bip32Derivation)
+	if err != nil {
+		return err
+	}
+	if err := writeUnknowns(w, po.Unknowns); err != nil {
+		return err
+	}
+
+	return writeSeparator(w)
+}
+
+// readKeyValue reads a single key-value pair from the passed reader. A nil
+// key is returned if the map separator was read instead.
+func readKeyValue(r io.Reader) ([]byte, []byte, error) {
+	keyLen, err := wire.ReadVarInt(r, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	if keyLen == 0 {
+		return nil, nil, nil
+	}
+	if keyLen > maxPsbtKeyValueSize {
+		return nil, nil, fmt.Errorf("psbt key length %d too large",
+			keyLen)
+	}
+	key := make([]byte, keyLen)
+	if _, err := io.ReadFull(r, key); err != nil {
+		return nil, nil, err
+	}
+
+	value, err := wire.ReadVarBytes(r, 0, maxPsbtKeyValueSize, "psbt value")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return key, value, nil
+}
+
+// writeKeyValue writes a single key-value pair to the passed writer.
+func writeKeyValue(w io.Writer, key, value []byte) error {
+	if err := wire.WriteVarBytes(w, 0, key); err != nil {
+		return err
+	}
+
+	return wire.WriteVarBytes(w, 0, value)
+}
+
+// writeSeparator writes the separator which terminates a map.
+func writeSeparator(w io.Writer) error {
+	_, err := w.Write([]byte{0x00})
+	return err
+}
+
+// writeUnknowns writes all the passed unknown key-value pairs.
+func writeUnknowns(w io.Writer, unknowns []*Unknown) error {
+	for _, u := range unknowns {
+		if err := writeKeyValue(w, u.Key, u.Value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readTxOut parses a serialized transaction output.
+func readTxOut(b []byte) (*wire.TxOut, error) {
+	if len(b) < 9 {
+		return nil, fmt.Errorf("witness utxo too short")
+	}
+
+	r := bytes.NewReader(b[8:])
+	pkScript, err := wire.ReadVarBytes(r, 0, maxPsbtKeyValueSize,
+		"pkScript")
+	if err != nil {
+		return nil, err
+	}
+
+	return &wire.TxOut{
+		Value:    int64(binary.LittleEndian.Uint64(b[:8])),
+		PkScript: pkScript,
+	}, nil
+}
+
+// writeTxOut serializes the passed transaction output.
+func writeTxOut(w io.Writer, txOut *wire.TxOut) error {
+	var v [8]byte
+	binary.LittleEndian.PutUint64(v[:], uint64(txOut.Value))
+	if _, err := w.Write(v[:]); err != nil {
+		return err
+	}
+
+	return wire.WriteVarBytes(w, 0, txOut.PkScript)
+}
+
+// readWitness parses a serialized witness stack.
+func readWitness(b []byte) ([][]byte, error) {
+	r := bytes.NewReader(b)
+	numItems, err := wire.ReadVarInt(r, 0)
+	if err != nil {
+		return nil, err
+	}
+	if numItems > uint64(len(b)) {
+		return nil, fmt.Errorf("too many witness items: %d", numItems)
+	}
+
+	witness := make([][]byte, numItems)
+	for i := range witness {
+		witness[i], err = wire.ReadVarBytes(r, 0, maxPsbtKeyValueSize,
+			"witness item")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return witness, nil
+}
+
+// writeWitness serializes the passed witness stack.
+func writeWitness(w io.Writer, witness [][]byte) error {
+	if err := wire.WriteVarInt(w, 0, uint64(len(witness))); err != nil {
+		return err
+	}
+	for _, item := range witness {
+		if err := wire.WriteVarBytes(w, 0, item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readBip32Derivation parses a BIP 32 derivation entry from its key data
+// (the public key) and value (fingerprint followed by the path).
+func readBip32Derivation(keyData, value []byte) (*Bip32Derivation, error) {
+	if len(keyData) != 33 && len(keyData) != 65 {
+		return nil, ErrInvalidKeyData
+	}
+	if len(value) < 4 || len(value)%4 != 0 {
+		return nil, fmt.Errorf("invalid bip32 derivation length %d",
+			len(value))
+	}
+
+	d := &Bip32Derivation{
+		PubKey:               keyData,
+		MasterKeyFingerprint: binary.LittleEndian.Uint32(value[:4]),
+	}
+	for i := 4; i < len(value); i += 4 {
+		d.Bip32Path = append(d.Bip32Path,
+			binary.LittleEndian.Uint32(value[i:i+4]))
+	}
+
+	return d, nil
+}
+
+// writeBip32Derivations writes the passed BIP 32 derivation entries using
+// the given key type.
+func writeBip32Derivations(w io.Writer, keyType byte,
+	derivations []*Bip32Derivation) error {
+
+	for _, d := range derivations {
+		key := append([]byte{keyType}, d.PubKey...)
+
+		value := make([]byte, 4+4*len(d.Bip32Path))
+		binary.LittleEndian.PutUint32(value[:4], d.MasterKeyFingerprint)
+		for i, index := range d.Bip32Path {
+			binary.LittleEndian.PutUint32(value[4+4*i:], index)
+		}
+
+		if err := writeKeyValue(w, key, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,229 @@
+package psbt
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+var (
+	testPubKey = []byte{
+		0x02, 0x79, 0xbe, 0x66, 0x7e, 0xf9, 0xdc, 0xbb,
+		0xac, 0x55, 0xa0, 0x62, 0x95, 0xce, 0x87, 0x0b,
+		0x07, 0x02, 0x9b, 0xfc, 0xdb, 0x2d, 0xce, 0x28,
+		0xd9, 0x59, 0xf2, 0x81, 0x5b, 0x16, 0xf8, 0x17,
+		0x98,
+	}
+
+	testSig = []byte{0x30, 0x44, 0x02, 0x20, 0x01, 0x01}
+
+	// testP2wkhScript is a p2wkh output script paying to an arbitrary
+	// key hash.
+	testP2wkhScript = append([]byte{0x00, 0x14}, bytes.Repeat(
+		[]byte{0x11}, 20)...)
+)
+
+// newTestPacket returns a packet spending a single p2wkh output into a
+// single output.
+func newTestPacket(t *testing.T) *Packet {
+	prevOut := &wire.OutPoint{Index: 1}
+	prevOut.Hash[0] = 0xaa
+
+	packet, err := New(
+		[]*wire.OutPoint{prevOut},
+		[]*wire.TxOut{{Value: 90000, PkScript: testP2wkhScript}},
+		2, 0, []uint32{wire.MaxTxInSequenceNum},
+	)
+	if err != nil {
+		t.Fatalf("unable to create packet: %v", err)
+	}
+
+	packet.Inputs[0].WitnessUtxo = &wire.TxOut{
+		Value:    100000,
+		PkScript: testP2wkhScript,
+	}
+	packet.Inputs[0].SighashType = 1
+	packet.Inputs[0].Bip32Derivation = []*Bip32Derivation{{
+		PubKey:               testPubKey,
+		MasterKeyFingerprint: 0xdeadbeef,
+		Bip32Path:            []uint32{0x80000054, 0x80000000, 0, 1},
+	}}
+	packet.Outputs[0].Unknowns = []*Unknown{{
+		Key:   []byte{0xfc, 0x01},
+		Value: []byte{0x02},
+	}}
+
+	return packet
+}
+
+// assertPacketsEqual asserts that both packets have the same serialization.
+func assertPacketsEqual(t *testing.T, expected, actual *Packet) {
+	var b1, b2 bytes.Buffer
+	if err := expected.Serialize(&b1); err != nil {
+		t.Fatalf("unable to serialize packet: %v", err)
+	}
+	if err := actual.Serialize(&b2); err != nil {
+		t.Fatalf("unable to serialize packet: %v", err)
+	}
+	if !bytes.Equal(b1.Bytes(), b2.Bytes()) {
+		t.Fatalf("packets don't match: expected %x, got %x",
+			b1.Bytes(), b2.Bytes())
+	}
+}
+
+// TestPacketSerializeRoundTrip ensures that a packet survives a round trip
+// through both its binary and base64 encodings.
+func TestPacketSerializeRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	packet := newTestPacket(t)
+	packet.Inputs[0].PartialSigs = []*PartialSig{{
+		PubKey:    testPubKey,
+		Signature: testSig,
+	}}
+
+	var b bytes.Buffer
+	if err := packet.Serialize(&b); err != nil {
+		t.Fatalf("unable to serialize packet: %v", err)
+	}
+	parsed, err := NewFromRawBytes(bytes.NewReader(b.Bytes()), false)
+	if err != nil {
+		t.Fatalf("unable to parse packet: %v", err)
+	}
+	assertPacketsEqual(t, packet, parsed)
+
+	encoded, err := packet.B64Encode()
+	if err != nil {
+		t.Fatalf("unable to encode packet: %v", err)
+	}
+	parsed, err = NewFromRawBytes(bytes.NewReader([]byte(encoded)), true)
+	if err != nil {
+		t.Fatalf("unable to parse base64 packet: %v", err)
+	}
+	assertPacketsEqual(t, packet, parsed)
+}
+
+// TestPacketNoInputsRoundTrip ensures that a packet whose unsigned
+// transaction has no inputs yet, as passed in to be funded, survives a round
+// trip, rather than being mistaken for a transaction with witness data.
+func TestPacketNoInputsRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	packet, err := New(
+		nil, []*wire.TxOut{{Value: 90000, PkScript: testP2wkhScript}},
+		2, 0, nil,
+	)
+	if err != nil {
+		t.Fatalf("unable to create packet: %v", err)
+	}
+
+	var b bytes.Buffer
+	if err := packet.Serialize(&b); err != nil {
+		t.Fatalf("unable to serialize packet: %v", err)
+	}
+	parsed, err := NewFromRawBytes(bytes.NewReader(b.Bytes()), false)
+	if err != nil {
+		t.Fatalf("unable to parse packet: %v", err)
+	}
+	if len(parsed.UnsignedTx.TxIn) != 0 ||
+		len(parsed.UnsignedTx.TxOut) != 1 {
+
+		t.Fatalf("expected no inputs and a single output, got %v "+
+			"inputs and %v outputs", len(parsed.UnsignedTx.TxIn),
+			len(parsed.UnsignedTx.TxOut))
+	}
+	assertPacketsEqual(t, packet, parsed)
+}
+
+// TestPacketInvalid ensures that malformed packets are rejected.
+func TestPacketInvalid(t *testing.T) {
+	t.Parallel()
+
+	packet := newTestPacket(t)
+	var b bytes.Buffer
+	if err := packet.Serialize(&b); err != nil {
+		t.Fatalf("unable to serialize packet: %v", err)
+	}
+	raw := b.Bytes()
+
+	// A packet with the wrong magic bytes should be rejected.
+	badMagic := append([]byte{}, raw...)
+	badMagic[0] = 0x00
+	_, err := NewFromRawBytes(bytes.NewReader(badMagic), false)
+	if err != ErrInvalidMagic {
+		t.Fatalf("expected ErrInvalidMagic, got %v", err)
+	}
+
+	// A truncated packet should be rejected.
+	_, err = NewFromRawBytes(bytes.NewReader(raw[:len(raw)-1]), false)
+	if err == nil {
+		t.Fatalf("truncated packet should be rejected")
+	}
+
+	// An unsigned transaction which already contains signature data
+	// should be rejected.
+	signedTx := packet.UnsignedTx.Copy()
+	signedTx.TxIn[0].SignatureScript = []byte{0x01}
+	if _, err := NewFromUnsignedTx(signedTx); err != ErrSignedUnsignedTx {
+		t.Fatalf("expected ErrSignedUnsignedTx, got %v", err)
+	}
+}
+
+// TestFinalizeAndExtract ensures that a p2wkh input carrying a partial
+// signature can be finalized, and the final transaction extracted.
+func TestFinalizeAndExtract(t *testing.T) {
+	t.Parallel()
+
+	packet := newTestPacket(t)
+
+	// Without a signature, the input can't be finalized, so no final
+	// transaction can be extracted.
+	if err := packet.MaybeFinalizeAll(); err == nil {
+		t.Fatalf("finalization without signatures should fail")
+	}
+	if _, err := packet.Extract(); err != ErrIncompletePsbt {
+		t.Fatalf("expected ErrIncompletePsbt, got %v", err)
+	}
+
+	packet.Inputs[0].PartialSigs = []*PartialSig{{
+		PubKey:    testPubKey,
+		Signature: testSig,
+	}}
+	if err := packet.MaybeFinalizeAll(); err != nil {
+		t.Fatalf("unable to finalize packet: %v", err)
+	}
+	if !packet.IsComplete() {
+		t.Fatalf("packet should be complete")
+	}
+
+	// The finalized packet should also survive a round trip.
+	var b bytes.Buffer
+	if err := packet.Serialize(&b); err != nil {
+		t.Fatalf("unable to serialize packet: %v", err)
+	}
+	parsed, err := NewFromRawBytes(bytes.NewReader(b.Bytes()), false)
+	if err != nil {
+		t.Fatalf("unable to parse packet: %v", err)
+	}
+	assertPacketsEqual(t, packet, parsed)
+
+	finalTx, err := packet.Extract()
+	if err != nil {
+		t.Fatalf("unable to extract final tx: %v", err)
+	}
+	witness := finalTx.TxIn[0].Witness
+	if len(witness) != 2 || !bytes.Equal(witness[0], testSig) ||
+		!bytes.Equal(witness[1], testPubKey) {
+
+		t.Fatalf("invalid final witness: %x", witness)
+	}
+	if len(finalTx.TxIn[0].SignatureScript) != 0 {
+		t.Fatalf("p2wkh input shouldn't have a sig script")
+	}
+
+	// The unsigned transaction of the packet must remain untouched.
+	if len(packet.UnsignedTx.TxIn[0].Witness) != 0 {
+		t.Fatalf("extraction modified the unsigned tx")
+	}
+}
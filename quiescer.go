@@ -0,0 +1,233 @@
+package main
+
+import (
+	"errors"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// defaultQuiescenceTimeout is the default duration a channel may spend
+// quiescing, and then quiescent, before it's automatically resumed.
+const defaultQuiescenceTimeout = time.Minute
+
+var (
+	// errQuiescenceTimeout is returned to any pending quiescence requests
+	// if the channel wasn't resumed before the quiescence timeout.
+	errQuiescenceTimeout = errors.New("quiescence timed out, channel " +
+		"resumed")
+
+	// errQuiescenceAborted is returned to any pending quiescence requests
+	// if the channel is resumed before it became quiescent.
+	errQuiescenceAborted = errors.New("channel resumed before becoming " +
+		"quiescent")
+)
+
+// quiescer drives the quiescence (stfu) handshake of a single channel. Either
+// side of the channel may initiate quiescence by sending an Stfu message once
+// all of its updates have been committed, after which it must not send any
+// further updates. The other side answers with its own Stfu once its updates
+// have been committed in turn. Once both sides have sent Stfu, the channel is
+// quiescent and its commitment state is frozen, allowing operations such as
+// commitment upgrades to be carried out.
+//
+// There's no message to end quiescence: a side resumes once it's done with
+// the channel, or once the quiescence timeout expires, and the remote side
+// resumes as soon as it receives a new update from it.
+//
+// NOTE: The quiescer isn't safe for concurrent use, it's only to be accessed
+// from the htlcManager of its channel.
+type quiescer struct {
+	chanPoint *wire.OutPoint
+
+	// isFunder is true if we funded the channel. If both sides initiate
+	// quiescence at the same time, the funder is considered the
+	// initiator.
+	isFunder bool
+
+	// sendMsg sends the passed message to the remote peer.
+	sendMsg func(lnwire.Message)
+
+	// timeout is the duration after which the channel is resumed if
+	// quiescence was requested, or received, but the channel wasn't
+	// resumed since.
+	timeout time.Duration
+
+	// wantStfu is true if we must send an Stfu as soon as our updates
+	// have been committed, either because quiescence was requested
+	// locally or because the remote peer sent us an Stfu.
+	wantStfu bool
+
+	// localInitiator and remoteInitiator record the Initiator flags of
+	// the Stfu messages sent and received.
+	localInitiator  bool
+	remoteInitiator bool
+
+	// sentStfu and receivedStfu record whether Stfu was sent to, or
+	// received from, the remote peer.
+	sentStfu     bool
+	receivedStfu bool
+
+	// timer is sent upon once the quiescence timeout expires. It's nil
+	// unless the channel is quiescing or quiescent.
+	timer <-chan time.Time
+
+	// waiters are signalled once the channel becomes quiescent, or is
+	// resumed before it does.
+	waiters []chan error
+}
+
+// newQuiescer creates a new quiescer for the passed channel.
+func newQuiescer(chanPoint *wire.OutPoint, isFunder bool,
+	sendMsg func(lnwire.Message), timeout time.Duration) *quiescer {
+
+	return &quiescer{
+		chanPoint: chanPoint,
+		isFunder:  isFunder,
+		sendMsg:   sendMsg,
+		timeout:   timeout,
+	}
+}
+
+// initiate requests quiescence of the channel. The passed channel is sent
+// upon once the channel is quiescent, or with an error if it's resumed before
+// it does. If non-zero, the passed timeout overrides the default one.
+func (q *quiescer) initiate(timeout time.Duration, done chan error) {
+	if q.isQuiescent() {
+		done <- nil
+		return
+	}
+
+	q.waiters = append(q.waiters, done)
+	q.wantStfu = true
+	q.startTimer(timeout)
+}
+
+// receiveStfu processes an Stfu message received from the remote peer.
+func (q *quiescer) receiveStfu(msg *lnwire.Stfu) {
+	// A second Stfu means the remote peer resumed the channel once its
+	// own quiescence timeout expired, without sending any update, and
+	// requests quiescence anew. As timeouts on both sides may race, this
+	// isn't a protocol violation: we'll answer with a fresh Stfu of our
+	// own, and restart the timer.
+	if q.receivedStfu {
+		peerLog.Debugf("ChannelPoint(%v) quiesced anew by remote peer",
+			q.chanPoint)
+
+		q.sentStfu = false
+		q.localInitiator = false
+		q.timer = nil
+	}
+
+	q.receivedStfu = true
+	q.remoteInitiator = msg.Initiator
+	q.wantStfu = true
+	q.startTimer(0)
+
+	q.notifyIfQuiescent()
+}
+
+// trySendStfu sends our Stfu if one is due and our updates have all been
+// committed, as indicated by the clean parameter.
+func (q *quiescer) trySendStfu(clean bool) {
+	if !q.wantStfu || q.sentStfu || !clean {
+		return
+	}
+
+	// We're the initiator unless we're only answering the Stfu of the
+	// remote peer.
+	q.localInitiator = !q.receivedStfu
+	q.sentStfu = true
+	q.sendMsg(lnwire.NewStfu(q.chanPoint, q.localInitiator))
+
+	q.notifyIfQuiescent()
+}
+
+// receiveUpdate must be called whenever the remote peer sends an update for
+// the channel. As the remote peer may only do so once it has resumed, any
+// quiescence is ended.
+func (q *quiescer) receiveUpdate() {
+	if !q.receivedStfu {
+		return
+	}
+
+	peerLog.Debugf("ChannelPoint(%v) resumed by remote peer", q.chanPoint)
+
+	q.resume(errQuiescenceAborted)
+}
+
+// blocksUpdates returns true if no new updates may be added by us, as we're
+// either about to send, or have already sent, an Stfu.
+func (q *quiescer) blocksUpdates() bool {
+	return q.wantStfu || q.sentStfu
+}
+
+// isQuiescent returns true if both sides have sent Stfu.
+func (q *quiescer) isQuiescent() bool {
+	return q.sentStfu && q.receivedStfu
+}
+
+// isInitiator returns true if we're the initiator of the current quiescence.
+// If both sides claim to be the initiator, the funder of the channel is.
+func (q *quiescer) isInitiator() bool {
+	switch {
+	case q.localInitiator && q.remoteInitiator:
+		return q.isFunder
+	default:
+		return q.localInitiator
+	}
+}
+
+// timedOut must be called once the quiescence timer fires, resuming the
+// channel.
+func (q *quiescer) timedOut() {
+	peerLog.Warnf("Quiescence of ChannelPoint(%v) timed out, resuming",
+		q.chanPoint)
+
+	q.resume(errQuiescenceTimeout)
+}
+
+// resume ends any quiescence of the channel, failing pending quiescence
+// requests with the passed error.
+func (q *quiescer) resume(err error) {
+	for _, waiter := range q.waiters {
+		waiter <- err
+	}
+
+	*q = quiescer{
+		chanPoint: q.chanPoint,
+		isFunder:  q.isFunder,
+		sendMsg:   q.sendMsg,
+		timeout:   q.timeout,
+	}
+}
+
+// startTimer starts the quiescence timer, unless it's already running. If
+// non-zero, the passed timeout overrides the default one.
+func (q *quiescer) startTimer(timeout time.Duration) {
+	if q.timer != nil {
+		return
+	}
+
+	if timeout == 0 {
+		timeout = q.timeout
+	}
+	q.timer = time.After(timeout)
+}
+
+// notifyIfQuiescent signals the pending quiescence requests if the channel
+// has become quiescent.
+func (q *quiescer) notifyIfQuiescent() {
+	if !q.isQuiescent() {
+		return
+	}
+
+	peerLog.Infof("ChannelPoint(%v) is quiescent, initiator=%v",
+		q.chanPoint, q.isInitiator())
+
+	for _, waiter := range q.waiters {
+		waiter <- nil
+	}
+	q.waiters = nil
+}
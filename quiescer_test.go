@@ -0,0 +1,195 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// quiescerNode is one side of a channel, along with the Stfu messages it has
+// sent but which haven't been delivered yet.
+type quiescerNode struct {
+	*quiescer
+	sent []*lnwire.Stfu
+}
+
+// newQuiescerPair creates the quiescers of both sides of a channel, Alice
+// being the funder.
+func newQuiescerPair() (*quiescerNode, *quiescerNode) {
+	chanPoint := &wire.OutPoint{Index: 1}
+
+	newNode := func(isFunder bool) *quiescerNode {
+		n := &quiescerNode{}
+		n.quiescer = newQuiescer(chanPoint, isFunder,
+			func(msg lnwire.Message) {
+				n.sent = append(n.sent, msg.(*lnwire.Stfu))
+			}, time.Minute,
+		)
+		return n
+	}
+
+	return newNode(true), newNode(false)
+}
+
+// deliver delivers the Stfu messages sent by one side to the other.
+func deliver(t *testing.T, from, to *quiescerNode) {
+	for _, stfu := range from.sent {
+		to.receiveStfu(stfu)
+	}
+	from.sent = nil
+}
+
+// assertQuiesceResult asserts that the passed quiescence request completed
+// with the expected error.
+func assertQuiesceResult(t *testing.T, done chan error, expected error) {
+	select {
+	case err := <-done:
+		if err != expected {
+			t.Fatalf("expected %v, got %v", expected, err)
+		}
+	default:
+		t.Fatalf("quiescence request not completed")
+	}
+}
+
+// TestQuiescerHandshake asserts that a channel only becomes quiescent once
+// both sides have sent Stfu, each waiting for its updates to be committed.
+func TestQuiescerHandshake(t *testing.T) {
+	alice, bob := newQuiescerPair()
+
+	done := make(chan error, 1)
+	alice.initiate(0, done)
+	if !alice.blocksUpdates() {
+		t.Fatalf("alice should stop adding updates")
+	}
+
+	// Alice still has pending updates, so she mustn't send Stfu yet.
+	alice.trySendStfu(false)
+	if len(alice.sent) != 0 {
+		t.Fatalf("stfu sent with pending updates")
+	}
+
+	alice.trySendStfu(true)
+	deliver(t, alice, bob)
+	if !bob.receivedStfu || !bob.blocksUpdates() {
+		t.Fatalf("bob should have received stfu")
+	}
+	if alice.isQuiescent() || bob.isQuiescent() {
+		t.Fatalf("channel quiescent before bob sent stfu")
+	}
+
+	bob.trySendStfu(true)
+	deliver(t, bob, alice)
+	if !alice.isQuiescent() || !bob.isQuiescent() {
+		t.Fatalf("channel should be quiescent")
+	}
+	assertQuiesceResult(t, done, nil)
+
+	if !alice.isInitiator() || bob.isInitiator() {
+		t.Fatalf("alice should be the initiator")
+	}
+
+	// Once Alice resumes and sends an update, Bob resumes as well.
+	alice.resume(errQuiescenceAborted)
+	bob.receiveUpdate()
+	if alice.blocksUpdates() || bob.blocksUpdates() {
+		t.Fatalf("channel should be resumed")
+	}
+}
+
+// TestQuiescerSimultaneousInitiation asserts that if both sides initiate
+// quiescence at the same time, the funder is the initiator.
+func TestQuiescerSimultaneousInitiation(t *testing.T) {
+	alice, bob := newQuiescerPair()
+
+	aliceDone := make(chan error, 1)
+	bobDone := make(chan error, 1)
+	alice.initiate(0, aliceDone)
+	bob.initiate(0, bobDone)
+
+	// Both sides send Stfu flagged as the initiator before receiving the
+	// one of the other side.
+	alice.trySendStfu(true)
+	bob.trySendStfu(true)
+	deliver(t, alice, bob)
+	deliver(t, bob, alice)
+
+	assertQuiesceResult(t, aliceDone, nil)
+	assertQuiesceResult(t, bobDone, nil)
+
+	if !alice.isInitiator() || bob.isInitiator() {
+		t.Fatalf("the funder should be the initiator")
+	}
+}
+
+// TestQuiescerTimeout asserts that a channel is resumed once the quiescence
+// timeout expires, failing the pending requests.
+func TestQuiescerTimeout(t *testing.T) {
+	alice, bob := newQuiescerPair()
+
+	done := make(chan error, 1)
+	alice.initiate(10*time.Millisecond, done)
+	alice.trySendStfu(true)
+	deliver(t, alice, bob)
+
+	// Bob never answers, so the quiescence of Alice times out.
+	select {
+	case <-alice.timer:
+	case <-time.After(time.Second):
+		t.Fatalf("quiescence timer didn't fire")
+	}
+	alice.timedOut()
+
+	assertQuiesceResult(t, done, errQuiescenceTimeout)
+	if alice.blocksUpdates() || alice.timer != nil {
+		t.Fatalf("alice should be resumed")
+	}
+
+	// Bob still waits to answer, and resumes on its own timeout.
+	if !bob.blocksUpdates() || bob.timer == nil {
+		t.Fatalf("bob should be quiescing")
+	}
+}
+
+// TestQuiescerDuplicateStfu asserts that a second Stfu from the remote peer,
+// sent once its quiescence timed out, is answered with a fresh Stfu rather
+// than treated as a protocol violation.
+func TestQuiescerDuplicateStfu(t *testing.T) {
+	alice, bob := newQuiescerPair()
+
+	done := make(chan error, 1)
+	bob.initiate(10*time.Millisecond, done)
+	bob.trySendStfu(true)
+	deliver(t, bob, alice)
+	alice.trySendStfu(true)
+	alice.sent = nil
+
+	// Alice's Stfu is lost, so Bob times out and requests quiescence
+	// once more.
+	select {
+	case <-bob.timer:
+	case <-time.After(time.Second):
+		t.Fatalf("quiescence timer didn't fire")
+	}
+	bob.timedOut()
+	assertQuiesceResult(t, done, errQuiescenceTimeout)
+
+	bob.initiate(0, done)
+	bob.trySendStfu(true)
+	deliver(t, bob, alice)
+
+	// Alice should answer the new request, making the channel quiescent
+	// once more.
+	if alice.sentStfu {
+		t.Fatalf("alice should answer the new stfu")
+	}
+	alice.trySendStfu(true)
+	deliver(t, alice, bob)
+
+	assertQuiesceResult(t, done, nil)
+	if !alice.isQuiescent() || !bob.isQuiescent() {
+		t.Fatalf("both sides should be quiescent")
+	}
+}
@@ -20,4 +20,16 @@ var (
 
 	// ErrTargetNotInNetwork is returned when a
 	ErrTargetNotInNetwork = errors.New("target not found")
+
+	// ErrFeeLimitExceeded is returned when no path to the target
+	// destination is found whose total fee is within the fee limit of the
+	// payment.
+	ErrFeeLimitExceeded = errors.New("unable to find a path to " +
+		"destination within the fee limit")
+
+	// ErrCltvLimitExceeded is returned when no path to the target
+	// destination is found whose total time lock is within the CLTV limit
+	// of the payment.
+	ErrCltvLimitExceeded = errors.New("unable to find a path to " +
+		"destination within the cltv limit")
 )
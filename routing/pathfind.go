@@ -4,6 +4,7 @@ import (
 	"math"
 
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcutil"
 )
@@ -17,8 +18,31 @@ const (
 
 	// infinity is used as a starting distance in our shortest path search.
 	infinity = math.MaxFloat64
+
+	// attemptPenalty is the weight added to an edge for each failed
+	// attempt to forward an HTLC over it we expect, as estimated by the
+	// ProbabilityEstimator in use. An edge over which the HTLC is
+	// forwarded with probability p has a penalty of attemptPenalty/p.
+	attemptPenalty = 100
 )
 
+// RouteRestrictions are the restrictions a route found for a payment must
+// satisfy.
+type RouteRestrictions struct {
+	// FeeLimitMsat is the maximum total fee in millisatoshis paid to the
+	// nodes along the route. If zero, the fee isn't limited.
+	FeeLimitMsat int64
+
+	// CltvLimit is the maximum total time lock of the route. If zero, the
+	// time lock isn't limited.
+	CltvLimit uint32
+
+	// IgnoredEdges is the set of IDs of the channels the route mustn't
+	// pass through, such as those a prior attempt to send the payment
+	// failed over.
+	IgnoredEdges map[uint64]struct{}
+}
+
 // Route represents a path through the channel graph which runs over one or
 // more channels in succession. This struct carries all the information
 // required to craft the Sphinx onion packet, and send the payment along the
@@ -82,6 +106,39 @@ func computeFee(amt btcutil.Amount, edge *channeldb.ChannelEdge) btcutil.Amount
 	return edge.FeeBaseMSat + (amt*edge.FeeProportionalMillionths)/1000000
 }
 
+// computeInboundFee computes the fee charged by a node for an HTLC of `amt`
+// satoshis entering it through a channel, given the node's policy for that
+// channel. The resulting fee may be negative, as nodes may offer a discount
+// for traffic entering through particular channels. If the node hasn't
+// advertised a policy for the channel, then no inbound fee is charged.
+func computeInboundFee(amt btcutil.Amount,
+	policy *channeldb.ChannelEdge) btcutil.Amount {
+
+	if policy == nil {
+		return 0
+	}
+
+	return policy.InboundFeeBaseMSat +
+		(amt*policy.InboundFeeProportionalMillionths)/1000000
+}
+
+// computeHopFee computes the total fee a node charges to forward an HTLC of
+// `amt` satoshis over the outgoing edge, having received it through the
+// channel its inbound policy applies to. The inbound fee is computed over the
+// amount including the outgoing fee, and may discount the total fee down to
+// zero, but never below it.
+func computeHopFee(amt btcutil.Amount, outgoing,
+	inboundPolicy *channeldb.ChannelEdge) btcutil.Amount {
+
+	outboundFee := computeFee(amt, outgoing)
+	fee := outboundFee + computeInboundFee(amt+outboundFee, inboundPolicy)
+	if fee < 0 {
+		return 0
+	}
+
+	return fee
+}
+
 // newRoute returns a fully valid route between the source and target that's
 // capable of supporting a payment of `amtToSend` after fees are fully
 // computed. IF the route is too long, or the selected path cannot support the
@@ -104,12 +161,16 @@ func newRoute(amtToSend btcutil.Amount, source, target vertex,
 	// in the reverse direction which we'll use to properly calculate the
 	// timelock and fee values.
 	pathEdges := make([]*channeldb.ChannelEdge, 0, len(prevHop))
+	inboundPolicies := make([]*channeldb.ChannelEdge, 0, len(prevHop))
 	prev := target
 	for prev != source { // TODO(roasbeef): assumes no cycles
 		// Add the current hop to the limit of path edges then walk
 		// backwards from this hop via the prev pointer for this hop
 		// within the prevHop map.
 		pathEdges = append(pathEdges, prevHop[prev].edge)
+		inboundPolicies = append(
+			inboundPolicies, prevHop[prev].inboundPolicy,
+		)
 		prev = newVertex(prevHop[prev].prevNode)
 	}
 
@@ -124,13 +185,22 @@ func newRoute(amtToSend btcutil.Amount, source, target vertex,
 	runningAmt := amtToSend
 	pathLength := len(pathEdges)
 	for i, edge := range pathEdges {
+		// The node forwarding over this edge received the HTLC through
+		// the edge which precedes it in the route, so its inbound
+		// policy for that channel also applies to this hop. The first
+		// hop is ours, which carries no fee, so it has none.
+		var inboundPolicy *channeldb.ChannelEdge
+		if i != pathLength-1 {
+			inboundPolicy = inboundPolicies[i+1]
+		}
+
 		// Now we create the hop struct for this point in the route.
 		// The amount to forward is the running amount, and we compute
 		// the required fee based on this amount.
 		nextHop := &Hop{
 			Channel:       edge,
 			AmtToForward:  runningAmt,
-			Fee:           computeFee(runningAmt, edge),
+			Fee:           computeHopFee(runningAmt, edge, inboundPolicy),
 			TimeLockDelta: edge.Expiry,
 		}
 		edge.Node.PubKey.Curve = nil
@@ -194,13 +264,25 @@ func newVertex(pub *btcec.PublicKey) vertex {
 type nodeWithDist struct {
 	dist float64
 	node *channeldb.LightningNode
+
+	// fee is a lower bound of the fees paid to the nodes between the
+	// source and the node, computed over the amount to send.
+	fee btcutil.Amount
+
+	// timeLock is the total time lock of the edges between the source and
+	// the node.
+	timeLock uint32
 }
 
 // edgeWithPrev is a helper struct used in path finding that couples an
-// directional edge with the node's ID in the opposite direction.
+// directional edge with the node's ID in the opposite direction. Once a path
+// has been selected, inboundPolicy is populated with the policy of the node
+// the edge leads to for the same channel, which carries the inbound fees that
+// node charges for HTLC's entering through it.
 type edgeWithPrev struct {
-	edge     *channeldb.ChannelEdge
-	prevNode *btcec.PublicKey
+	edge          *channeldb.ChannelEdge
+	prevNode      *btcec.PublicKey
+	inboundPolicy *channeldb.ChannelEdge
 }
 
 // edgeWeight computes the weight of an edge. This value is used when searching
@@ -222,10 +304,24 @@ func edgeWeight(e *channeldb.ChannelEdge) float64 {
 // we calculate the required fee and time lock values running backwards along
 // the route. The route that's selected is the one with the lowest total fee.
 //
+// If a ProbabilityEstimator is passed, edges are additionally penalized by
+// the inverse of the probability the payment is forwarded over them, and
+// edges over which it can't be forwarded at all are skipped. If restrictions
+// are passed, paths violating them, and ignored edges, are skipped during the
+// search.
+//
 // TODO(roasbeef): make member, add caching
 //  * add k-path
 func findRoute(graph *channeldb.ChannelGraph, target *btcec.PublicKey,
-	amt btcutil.Amount) (*Route, error) {
+	amt btcutil.Amount, estimator ProbabilityEstimator,
+	restrictions *RouteRestrictions) (*Route, error) {
+
+	if restrictions == nil {
+		restrictions = &RouteRestrictions{}
+	}
+	feeLimitMsat := restrictions.FeeLimitMsat
+	cltvLimit := restrictions.CltvLimit
+	feeLimitHit, cltvLimitHit := false, false
 
 	// First initialize empty list of all the node that we've yet to
 	// visited.
@@ -293,6 +389,13 @@ func findRoute(graph *channeldb.ChannelGraph, target *btcec.PublicKey,
 			}
 		}
 
+		// If none of the unvisited nodes can be reached, for example as
+		// the edges leading to them were skipped, then the target
+		// can't be reached either.
+		if bestNode == nil {
+			break
+		}
+
 		// If we've reached our target, then we're done here and can
 		// exit the graph traversal early.
 		if bestNode.PubKey.IsEqual(target) {
@@ -304,10 +407,63 @@ func findRoute(graph *channeldb.ChannelGraph, target *btcec.PublicKey,
 		// further our graph traversal.
 		pivot := newVertex(bestNode.PubKey)
 		err := bestNode.ForEachChannel(nil, func(edge *channeldb.ChannelEdge) error {
+			// Channels which have been disabled by the node at
+			// the other end of them can't be routed through. Our
+			// own channels are exempt, as we know best whether
+			// they can carry the payment.
+			if edge.Flags&lnwire.ChanUpdateDisabled != 0 &&
+				pivot != sourceVertex {
+
+				return nil
+			}
+
+			// Likewise, channels the caller asked us to avoid are
+			// skipped.
+			if _, ok := restrictions.IgnoredEdges[edge.ChannelID]; ok {
+				return nil
+			}
+
 			// Compute the tentative distance to this new
 			// channel/edge which is the distance to our current
 			// pivot node plus the weight of this edge.
-			tempDist := distance[pivot].dist + edgeWeight(edge)
+			weight := edgeWeight(edge)
+			if estimator != nil {
+				p := estimator.EdgeProbability(edge, amt)
+				if p <= 0 {
+					return nil
+				}
+				weight += attemptPenalty / p
+			}
+			tempDist := distance[pivot].dist + weight
+
+			// The node we're pivoting from charges a fee to
+			// forward over the edge, unless it's ourselves. As
+			// fees only grow with the amount forwarded, the fee
+			// computed over the amount to send is a lower bound,
+			// so any path through the edge exceeding the fee limit
+			// with it is skipped. Inbound discounts are only known
+			// once a path is selected, so they aren't accounted
+			// for here.
+			tempFee := distance[pivot].fee
+			if pivot != sourceVertex {
+				tempFee += computeFee(amt, edge)
+			}
+			if feeLimitMsat != 0 &&
+				int64(tempFee)*1000 > feeLimitMsat {
+
+				feeLimitHit = true
+				return nil
+			}
+
+			// Every edge, including our own, adds its time lock
+			// delta to the total time lock of the route, so paths
+			// exceeding the CLTV limit with it are skipped too.
+			tempTimeLock := distance[pivot].timeLock +
+				uint32(edge.Expiry)
+			if cltvLimit != 0 && tempTimeLock > cltvLimit {
+				cltvLimitHit = true
+				return nil
+			}
 
 			// If this new tentative distance is better than the
 			// current best known distance to this node, then we
@@ -320,8 +476,10 @@ func findRoute(graph *channeldb.ChannelGraph, target *btcec.PublicKey,
 				// TODO(roasbeef): unconditionally add for all
 				// paths
 				distance[v] = nodeWithDist{
-					dist: tempDist,
-					node: edge.Node,
+					dist:     tempDist,
+					node:     edge.Node,
+					fee:      tempFee,
+					timeLock: tempTimeLock,
 				}
 				prev[v] = edgeWithPrev{
 					edge:     edge,
@@ -336,13 +494,79 @@ func findRoute(graph *channeldb.ChannelGraph, target *btcec.PublicKey,
 	}
 
 	// If the target node isn't found in the prev hop map, then a path
-	// doesn't exist, so we terminate in an error.
+	// doesn't exist, so we terminate in an error. If edges were skipped
+	// as they exceeded the fee or CLTV limit, then we'll report that
+	// instead.
 	if _, ok := prev[newVertex(target)]; !ok {
-		return nil, ErrNoPathFound
+		switch {
+		case feeLimitHit:
+			return nil, ErrFeeLimitExceeded
+		case cltvLimitHit:
+			return nil, ErrCltvLimitExceeded
+		default:
+			return nil, ErrNoPathFound
+		}
+	}
+
+	// With a path found, we'll populate the inbound policy of each node
+	// along it for the channel the HTLC will enter it through, so the
+	// inbound fees can be accounted for.
+	targetVerex := newVertex(target)
+	if err := fetchInboundPolicies(graph, sourceVertex, targetVerex,
+		prev); err != nil {
+
+		return nil, err
 	}
 
 	// Otherwise, we construct a new route which calculate the relevant
 	// total fees and proper time lock values for each hop.
-	targetVerex := newVertex(target)
-	return newRoute(amt, sourceVertex, targetVerex, prev)
+	route, err := newRoute(amt, sourceVertex, targetVerex, prev)
+	if err != nil {
+		return nil, err
+	}
+
+	// Finally, the exact fees of the route, which also account for the
+	// fees paid on the fees of the subsequent hops, must be within the
+	// fee limit.
+	if feeLimitMsat != 0 &&
+		int64(route.TotalFees)*1000 > feeLimitMsat {
+
+		return nil, ErrFeeLimitExceeded
+	}
+	if cltvLimit != 0 && route.TotalTimeLock > cltvLimit {
+		return nil, ErrCltvLimitExceeded
+	}
+
+	return route, nil
+}
+
+// fetchInboundPolicies walks the path from the target back to the source,
+// populating the inbound policy of each edge along it with the policy of the
+// node the edge leads to for the same channel.
+func fetchInboundPolicies(graph *channeldb.ChannelGraph, source, target vertex,
+	prevHop map[vertex]edgeWithPrev) error {
+
+	for v := target; v != source; {
+		hop := prevHop[v]
+
+		edge1, edge2, err := graph.FetchChannelEdgesByID(hop.edge.ChannelID)
+		if err != nil {
+			return err
+		}
+
+		// If the least significant bit of the flags is unset, then
+		// the edge was advertised by the first node of the channel, so
+		// the policy of the node it leads to is the second edge.
+		// Either may be nil if the node hasn't advertised its policy.
+		if hop.edge.Flags&1 == 0 {
+			hop.inboundPolicy = edge2
+		} else {
+			hop.inboundPolicy = edge1
+		}
+		prevHop[v] = hop
+
+		v = newVertex(hop.prevNode)
+	}
+
+	return nil
 }
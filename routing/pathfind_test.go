@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcd/wire"
@@ -262,7 +263,7 @@ func TestBasicGraphPathFinding(t *testing.T) {
 
 	const paymentAmt = btcutil.Amount(100)
 	target := aliases["sophon"]
-	route, err := findRoute(graph, target, paymentAmt)
+	route, err := findRoute(graph, target, paymentAmt, nil, nil)
 	if err != nil {
 		t.Fatalf("unable to find route: %v", err)
 	}
@@ -298,7 +299,7 @@ func TestBasicGraphPathFinding(t *testing.T) {
 	// exist two possible paths in the graph, but the shorter (1 hop) path
 	// should be selected.
 	target = aliases["luoji"]
-	route, err = findRoute(graph, target, paymentAmt)
+	route, err = findRoute(graph, target, paymentAmt, nil, nil)
 	if err != nil {
 		t.Fatalf("unable to find route: %v", err)
 	}
@@ -326,6 +327,112 @@ func TestBasicGraphPathFinding(t *testing.T) {
 	}
 }
 
+// TestRouteFeeLimit asserts that only routes within the fee limit of a payment
+// are found.
+func TestRouteFeeLimit(t *testing.T) {
+	graph, cleanUp, aliases, err := parseTestGraph(basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create graph: %v", err)
+	}
+
+	// The only route to sophon is through songoku, which charges a fee of
+	// 10 satoshis to forward a payment of 100 satoshis.
+	const paymentAmt = btcutil.Amount(100)
+	target := aliases["sophon"]
+
+	route, err := findRoute(graph, target, paymentAmt, nil,
+		&RouteRestrictions{FeeLimitMsat: 10000})
+	if err != nil {
+		t.Fatalf("unable to find route: %v", err)
+	}
+	if route.TotalFees != 10 {
+		t.Fatalf("expected fee of 10, got %v", route.TotalFees)
+	}
+
+	// A limit even a single millisatoshi below the fee is exceeded.
+	_, err = findRoute(graph, target, paymentAmt, nil,
+		&RouteRestrictions{FeeLimitMsat: 9999})
+	if err != ErrFeeLimitExceeded {
+		t.Fatalf("expected ErrFeeLimitExceeded, got %v", err)
+	}
+
+	// A direct route to luoji carries no fee, so it's found under any
+	// fee limit.
+	_, err = findRoute(graph, aliases["luoji"], paymentAmt, nil,
+		&RouteRestrictions{FeeLimitMsat: 1})
+	if err != nil {
+		t.Fatalf("unable to find route: %v", err)
+	}
+}
+
+// TestRouteCltvLimit asserts that only routes within the CLTV limit of a
+// payment are found.
+func TestRouteCltvLimit(t *testing.T) {
+	graph, cleanUp, aliases, err := parseTestGraph(basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create graph: %v", err)
+	}
+
+	// The only route to sophon is through songoku, each of its two hops
+	// carrying a time lock delta of one block.
+	const paymentAmt = btcutil.Amount(100)
+	target := aliases["sophon"]
+
+	route, err := findRoute(graph, target, paymentAmt, nil,
+		&RouteRestrictions{CltvLimit: 2})
+	if err != nil {
+		t.Fatalf("unable to find route: %v", err)
+	}
+	if route.TotalTimeLock != 2 {
+		t.Fatalf("expected time lock of 2, got %v", route.TotalTimeLock)
+	}
+
+	_, err = findRoute(graph, target, paymentAmt, nil,
+		&RouteRestrictions{CltvLimit: 1})
+	if err != ErrCltvLimitExceeded {
+		t.Fatalf("expected ErrCltvLimitExceeded, got %v", err)
+	}
+}
+
+// TestRouteIgnoredEdges asserts that routes never pass through the edges the
+// caller asked to ignore.
+func TestRouteIgnoredEdges(t *testing.T) {
+	graph, cleanUp, aliases, err := parseTestGraph(basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create graph: %v", err)
+	}
+
+	// Satoshi is reached directly over our channel with them, or through
+	// luoji otherwise.
+	const paymentAmt = btcutil.Amount(100)
+	target := aliases["satoshi"]
+	restrictions := &RouteRestrictions{
+		IgnoredEdges: map[uint64]struct{}{
+			2340213491: {},
+		},
+	}
+
+	route, err := findRoute(graph, target, paymentAmt, nil, restrictions)
+	if err != nil {
+		t.Fatalf("unable to find route: %v", err)
+	}
+	if len(route.Hops) != 2 || route.Hops[0].Channel.ChannelID != 689530843 {
+		t.Fatalf("expected route of 2 hops through luoji, got %v hops",
+			len(route.Hops))
+	}
+
+	// Once the channel between luoji and satoshi is ignored too, there's
+	// no route left.
+	restrictions.IgnoredEdges[523452362] = struct{}{}
+	_, err = findRoute(graph, target, paymentAmt, nil, restrictions)
+	if err != ErrNoPathFound {
+		t.Fatalf("expected ErrNoPathFound, got %v", err)
+	}
+}
+
 func TestNewRoutePathTooLong(t *testing.T) {
 	// Ensure that potential paths which are over the maximum hop-limit are
 	// rejected.
@@ -342,6 +449,90 @@ func TestNewRoutePathTooLong(t *testing.T) {
 	}
 }
 
+// TestNewRouteInboundFees tests that the inbound fees of a forwarding node
+// for the channel an HTLC enters it through are added to the fee of the hop,
+// and that a discount never results in a negative fee.
+func TestNewRouteInboundFees(t *testing.T) {
+	var keys [3]*btcec.PublicKey
+	for i := range keys {
+		priv, err := btcec.NewPrivateKey(btcec.S256())
+		if err != nil {
+			t.Fatalf("unable to generate key: %v", err)
+		}
+		keys[i] = priv.PubKey()
+	}
+	source, hub, target := keys[0], keys[1], keys[2]
+
+	tests := []struct {
+		inboundBase btcutil.Amount
+		inboundRate btcutil.Amount
+		expectedFee btcutil.Amount
+	}{
+		// Without inbound fees, only the outbound fee is charged.
+		{0, 0, 1100},
+
+		// The inbound fee is computed over the amount including the
+		// outbound fee: -500 + (101100 * -1000) / 1000000 = -601.
+		{-500, -1000, 499},
+
+		// A positive inbound fee is simply added.
+		{200, 0, 1300},
+
+		// A discount larger than the outbound fee results in a free
+		// hop.
+		{-5000, 0, 0},
+	}
+
+	const paymentAmt = btcutil.Amount(100000)
+	for i, test := range tests {
+		firstHop := &channeldb.ChannelEdge{
+			ChannelID: 1,
+			Capacity:  btcutil.SatoshiPerBitcoin,
+			Node:      &channeldb.LightningNode{PubKey: hub},
+		}
+		secondHop := &channeldb.ChannelEdge{
+			ChannelID:                 2,
+			Capacity:                  btcutil.SatoshiPerBitcoin,
+			FeeBaseMSat:               1000,
+			FeeProportionalMillionths: 1000,
+			Node:                      &channeldb.LightningNode{PubKey: target},
+		}
+		hubPolicy := &channeldb.ChannelEdge{
+			ChannelID:                        1,
+			InboundFeeBaseMSat:               test.inboundBase,
+			InboundFeeProportionalMillionths: test.inboundRate,
+		}
+
+		path := map[vertex]edgeWithPrev{
+			newVertex(hub): {
+				edge:          firstHop,
+				prevNode:      source,
+				inboundPolicy: hubPolicy,
+			},
+			newVertex(target): {
+				edge:     secondHop,
+				prevNode: hub,
+			},
+		}
+
+		route, err := newRoute(
+			paymentAmt, newVertex(source), newVertex(target), path,
+		)
+		if err != nil {
+			t.Fatalf("test #%v: unable to create route: %v", i, err)
+		}
+
+		if route.TotalFees != test.expectedFee {
+			t.Fatalf("test #%v: expected fee of %v, got %v", i,
+				test.expectedFee, route.TotalFees)
+		}
+		if route.TotalAmount != paymentAmt+test.expectedFee {
+			t.Fatalf("test #%v: expected total amount of %v, got %v",
+				i, paymentAmt+test.expectedFee, route.TotalAmount)
+		}
+	}
+}
+
 func TestPathNotAvailable(t *testing.T) {
 	graph, cleanUp, _, err := parseTestGraph(basicGraphFilePath)
 	defer cleanUp()
@@ -362,7 +553,42 @@ func TestPathNotAvailable(t *testing.T) {
 		t.Fatalf("unable to parse pubkey: %v", err)
 	}
 
-	if _, err := findRoute(graph, unknownNode, 100); err != ErrNoPathFound {
+	if _, err := findRoute(graph, unknownNode, 100, nil, nil); err != ErrNoPathFound {
+		t.Fatalf("path shouldn't have been found: %v", err)
+	}
+}
+
+// TestPathDisabledEdge tests that channels disabled by the node at the other
+// end of them aren't routed through, unlike our own disabled channels.
+func TestPathDisabledEdge(t *testing.T) {
+	graph, cleanUp, aliases, err := parseTestGraph(basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create graph: %v", err)
+	}
+
+	disableEdge := func(chanID uint64) {
+		edge, _, err := graph.FetchChannelEdgesByID(chanID)
+		if err != nil {
+			t.Fatalf("unable to fetch edge: %v", err)
+		}
+		edge.Flags |= lnwire.ChanUpdateDisabled
+		if err := graph.UpdateEdgeInfo(edge); err != nil {
+			t.Fatalf("unable to update edge: %v", err)
+		}
+	}
+
+	// Disabling our own direction of the channel to son goku shouldn't
+	// prevent us from paying sophon through it.
+	disableEdge(12345)
+	if _, err := findRoute(graph, aliases["sophon"], 100, nil, nil); err != nil {
+		t.Fatalf("unable to find route: %v", err)
+	}
+
+	// Once son goku disables its direction of the channel to sophon,
+	// there's no path left.
+	disableEdge(3495345)
+	if _, err := findRoute(graph, aliases["sophon"], 100, nil, nil); err != ErrNoPathFound {
 		t.Fatalf("path shouldn't have been found: %v", err)
 	}
 }
@@ -385,12 +611,56 @@ func TestPathInsufficientCapacity(t *testing.T) {
 	target := aliases["sophon"]
 
 	const payAmt = btcutil.SatoshiPerBitcoin
-	_, err = findRoute(graph, target, payAmt)
+	_, err = findRoute(graph, target, payAmt, nil, nil)
 	if err != ErrInsufficientCapacity {
 		t.Fatalf("graph shouldn't be able to support payment: %v", err)
 	}
 }
 
+// TestPathFindingEstimators tests that path finding selects the same routes
+// when taking the success probability of each edge into account, and that
+// edges over which the payment can't be forwarded are skipped.
+func TestPathFindingEstimators(t *testing.T) {
+	graph, cleanUp, aliases, err := parseTestGraph(basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create graph: %v", err)
+	}
+
+	estimators := []ProbabilityEstimator{
+		&AprioriEstimator{HopProbability: DefaultAprioriHopProbability},
+		&BimodalEstimator{Scale: DefaultBimodalScale},
+	}
+	for _, estimator := range estimators {
+		// The shortest path to sophon spans two hops, while luo ji is
+		// reachable directly.
+		route, err := findRoute(graph, aliases["sophon"], 100, estimator, nil)
+		if err != nil {
+			t.Fatalf("unable to find route: %v", err)
+		}
+		if len(route.Hops) != 2 {
+			t.Fatalf("expected route of length 2, got %v",
+				len(route.Hops))
+		}
+
+		route, err = findRoute(graph, aliases["luoji"], 100, estimator, nil)
+		if err != nil {
+			t.Fatalf("unable to find route: %v", err)
+		}
+		if len(route.Hops) != 1 {
+			t.Fatalf("expected route of length 1, got %v",
+				len(route.Hops))
+		}
+
+		// The channel between son goku and sophon is too small to
+		// carry 1000 satoshis, so no path is left to sophon.
+		_, err = findRoute(graph, aliases["sophon"], 1000, estimator, nil)
+		if err != ErrNoPathFound {
+			t.Fatalf("path shouldn't have been found: %v", err)
+		}
+	}
+}
+
 func TestPathInsufficientCapacityWithFee(t *testing.T) {
 	// TODO(roasbeef): encode live graph to json
 }
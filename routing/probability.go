@@ -0,0 +1,133 @@
+package routing
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/roasbeef/btcutil"
+)
+
+const (
+	// AprioriEstimatorName is the name of the AprioriEstimator.
+	AprioriEstimatorName = "apriori"
+
+	// BimodalEstimatorName is the name of the BimodalEstimator.
+	BimodalEstimatorName = "bimodal"
+
+	// DefaultAprioriHopProbability is the default probability the
+	// AprioriEstimator assumes an HTLC is forwarded over an edge with.
+	DefaultAprioriHopProbability = 0.6
+
+	// DefaultBimodalScale is the default scale of the liquidity
+	// distribution assumed by the BimodalEstimator.
+	DefaultBimodalScale = btcutil.Amount(300000)
+)
+
+// ProbabilityEstimator estimates the probability that an HTLC is successfully
+// forwarded over an edge of the channel graph. Path finding weighs edges by
+// the inverse of this probability, so that routes likely to succeed are
+// preferred.
+type ProbabilityEstimator interface {
+	// EdgeProbability returns the probability, between 0 and 1, that an
+	// HTLC of amt satoshis is forwarded over the passed edge. A
+	// probability of 0 excludes the edge from path finding.
+	EdgeProbability(edge *channeldb.ChannelEdge, amt btcutil.Amount) float64
+}
+
+// AprioriEstimator assumes that any HTLC which fits within the capacity of a
+// channel is forwarded over it with the same fixed probability, whatever its
+// amount. Longer routes are thus considered less likely to succeed.
+type AprioriEstimator struct {
+	// HopProbability is the probability that an HTLC is forwarded over an
+	// edge. It must lie within (0, 1].
+	HopProbability float64
+}
+
+// EdgeProbability returns the probability that an HTLC of amt satoshis is
+// forwarded over the passed edge.
+//
+// NOTE: This is part of the ProbabilityEstimator interface.
+func (a *AprioriEstimator) EdgeProbability(edge *channeldb.ChannelEdge,
+	amt btcutil.Amount) float64 {
+
+	if amt > edge.Capacity {
+		return 0
+	}
+
+	return a.HopProbability
+}
+
+// BimodalEstimator assumes that the liquidity of a channel is mostly found
+// on either of its sides, as channels tend to be depleted in one direction.
+// The balance available to forward an HTLC is modelled by the density
+//
+//	P(x) ~ exp(-x/s) + exp(-(c-x)/s)
+//
+// over [0, c], c being the capacity of the channel and s the scale. The
+// probability that an HTLC is forwarded is the probability that the balance
+// is at least its amount. As the scale grows large compared to the capacity,
+// the distribution approaches a uniform one.
+type BimodalEstimator struct {
+	// Scale is the scale of the liquidity distribution, in satoshis. It
+	// must be positive.
+	Scale btcutil.Amount
+}
+
+// EdgeProbability returns the probability that an HTLC of amt satoshis is
+// forwarded over the passed edge.
+//
+// NOTE: This is part of the ProbabilityEstimator interface.
+func (b *BimodalEstimator) EdgeProbability(edge *channeldb.ChannelEdge,
+	amt btcutil.Amount) float64 {
+
+	if amt > edge.Capacity || edge.Capacity <= 0 {
+		return 0
+	}
+	if amt <= 0 {
+		return 1
+	}
+
+	// The primitive of the density is, up to a constant factor,
+	// F(x) = exp(-(c-x)/s) - exp(-x/s), so the probability is given by
+	// (F(c) - F(amt)) / (F(c) - F(0)).
+	s := float64(b.Scale)
+	c := float64(edge.Capacity)
+	primitive := func(x float64) float64 {
+		return math.Exp(-(c-x)/s) - math.Exp(-x/s)
+	}
+
+	norm := primitive(c) - primitive(0)
+	if norm <= 0 {
+		return 0
+	}
+
+	return (primitive(c) - primitive(float64(amt))) / norm
+}
+
+// NewProbabilityEstimator returns the estimator with the passed name,
+// configured with the passed parameters of the apriori and bimodal models.
+func NewProbabilityEstimator(name string, hopProbability float64,
+	scale btcutil.Amount) (ProbabilityEstimator, error) {
+
+	switch name {
+	case AprioriEstimatorName:
+		if hopProbability <= 0 || hopProbability > 1 {
+			return nil, fmt.Errorf("hop probability must lie "+
+				"within (0, 1], is instead %v", hopProbability)
+		}
+		return &AprioriEstimator{HopProbability: hopProbability}, nil
+
+	case BimodalEstimatorName:
+		if scale <= 0 {
+			return nil, fmt.Errorf("bimodal scale must be "+
+				"positive, is instead %v", int64(scale))
+		}
+		return &BimodalEstimator{Scale: scale}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown probability estimator %q, "+
+			"supported estimators are: %v, %v", name,
+			AprioriEstimatorName, BimodalEstimatorName)
+	}
+}
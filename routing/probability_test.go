@@ -0,0 +1,86 @@
+package routing
+
+import (
+	"math"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/roasbeef/btcutil"
+)
+
+// assertProbability asserts that the estimated probability lies within a
+// small margin of the expected one.
+func assertProbability(t *testing.T, estimator ProbabilityEstimator,
+	capacity, amt btcutil.Amount, expected float64) {
+
+	edge := &channeldb.ChannelEdge{Capacity: capacity}
+	p := estimator.EdgeProbability(edge, amt)
+	if math.Abs(p-expected) > 0.001 {
+		t.Fatalf("probability of %v over capacity %v: expected %v, "+
+			"got %v", amt, capacity, expected, p)
+	}
+}
+
+// TestAprioriEstimator asserts that the apriori estimator assigns its hop
+// probability to any HTLC fitting within the capacity of a channel.
+func TestAprioriEstimator(t *testing.T) {
+	estimator := &AprioriEstimator{HopProbability: 0.6}
+
+	assertProbability(t, estimator, 100000, 1, 0.6)
+	assertProbability(t, estimator, 100000, 100000, 0.6)
+	assertProbability(t, estimator, 100000, 100001, 0)
+}
+
+// TestBimodalEstimator asserts that the bimodal estimator models liquidity
+// concentrated on either side of a channel, approaching a uniform
+// distribution as the scale grows large compared to the capacity.
+func TestBimodalEstimator(t *testing.T) {
+	// With a small scale, the balance is most likely found at either end
+	// of the channel, so any HTLC well within the capacity succeeds half
+	// of the time.
+	estimator := &BimodalEstimator{Scale: 1000}
+	assertProbability(t, estimator, 1000000, 0, 1)
+	assertProbability(t, estimator, 1000000, 100000, 0.5)
+	assertProbability(t, estimator, 1000000, 500000, 0.5)
+	assertProbability(t, estimator, 1000000, 900000, 0.5)
+	assertProbability(t, estimator, 1000000, 1000000, 0)
+	assertProbability(t, estimator, 1000000, 1000001, 0)
+
+	// With a large scale, the probability decreases linearly with the
+	// amount.
+	estimator = &BimodalEstimator{Scale: 1000000000}
+	assertProbability(t, estimator, 1000000, 250000, 0.75)
+	assertProbability(t, estimator, 1000000, 500000, 0.5)
+	assertProbability(t, estimator, 1000000, 750000, 0.25)
+}
+
+// TestNewProbabilityEstimator asserts that estimators are only created with
+// valid parameters.
+func TestNewProbabilityEstimator(t *testing.T) {
+	tests := []struct {
+		name           string
+		hopProbability float64
+		scale          btcutil.Amount
+		valid          bool
+	}{
+		{AprioriEstimatorName, 0.6, 0, true},
+		{AprioriEstimatorName, 1, 0, true},
+		{AprioriEstimatorName, 0, DefaultBimodalScale, false},
+		{AprioriEstimatorName, 1.1, 0, false},
+		{BimodalEstimatorName, 0, DefaultBimodalScale, true},
+		{BimodalEstimatorName, 0.6, 0, false},
+		{"unknown", 0.6, DefaultBimodalScale, false},
+	}
+	for i, test := range tests {
+		_, err := NewProbabilityEstimator(
+			test.name, test.hopProbability, test.scale,
+		)
+		if test.valid && err != nil {
+			t.Fatalf("test #%v: unable to create estimator: %v", i,
+				err)
+		}
+		if !test.valid && err == nil {
+			t.Fatalf("test #%v: invalid estimator created", i)
+		}
+	}
+}
@@ -2,6 +2,9 @@ package routing
 
 import (
 	"encoding/hex"
+	"fmt"
+	"image/color"
+	"runtime"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -16,6 +19,11 @@ import (
 	"github.com/roasbeef/btcutil"
 )
 
+// DefaultNumValidationWorkers is the default maximum number of network
+// announcements validated in parallel. As validating an announcement mostly
+// waits on the chain backend, several workers are used per CPU.
+var DefaultNumValidationWorkers = runtime.NumCPU() * 4
+
 // FeeSchema is the set fee configuration for a Lighting Node on the network.
 // Using the coefficients described within he schema, the required fee to
 // forward outgoing payments can be derived.
@@ -73,6 +81,16 @@ type Config struct {
 	// key.
 	SendMessages func(target *btcec.PublicKey, msg ...lnwire.Message) error
 
+	// NumValidationWorkers is the maximum number of network announcements
+	// validated in parallel. If zero, DefaultNumValidationWorkers is
+	// used.
+	NumValidationWorkers int
+
+	// Estimator estimates the probability that payments are forwarded
+	// over each edge, which path finding takes into account. If nil,
+	// edges are weighed by their time lock delta alone.
+	Estimator ProbabilityEstimator
+
 	// TODO(roasbeef): need a SendToSwitch func
 	//  * possibly lift switch into package?
 	//  *
@@ -103,8 +121,24 @@ type ChannelRouter struct {
 
 	networkMsgs chan *routingMsg
 
+	// validationBarrier allows independent network announcements to be
+	// validated in parallel.
+	validationBarrier *ValidationBarrier
+
+	// announcementBatch is the set of accepted announcements to be
+	// broadcast once the trickle timer next ticks.
+	announcementBatch []lnwire.Message
+	batchMtx          sync.Mutex
+
 	syncRequests chan *syncRequest
 
+	// topologyClients maps a client's unique notification ID to a
+	// topologyClient client that contains its notification dispatch
+	// channel.
+	ntfnClientMtx   sync.RWMutex
+	ntfnClientID    uint64
+	topologyClients map[uint64]*topologyClient
+
 	fakeSig *btcec.Signature
 
 	started uint32
@@ -138,13 +172,22 @@ func New(cfg Config) (*ChannelRouter, error) {
 		return nil, err
 	}
 
+	numWorkers := cfg.NumValidationWorkers
+	if numWorkers == 0 {
+		numWorkers = DefaultNumValidationWorkers
+	}
+
+	quit := make(chan struct{})
 	return &ChannelRouter{
-		cfg:          &cfg,
-		self:         self,
-		fakeSig:      fakeSig,
-		networkMsgs:  make(chan *routingMsg),
-		syncRequests: make(chan *syncRequest),
-		quit:         make(chan struct{}),
+		cfg:               &cfg,
+		self:              self,
+		fakeSig:           fakeSig,
+		networkMsgs:       make(chan *routingMsg),
+		validationBarrier: NewValidationBarrier(numWorkers, quit),
+		syncRequests:      make(chan *syncRequest),
+		quit:              quit,
+
+		topologyClients: make(map[uint64]*topologyClient),
 	}, nil
 }
 
@@ -269,12 +312,13 @@ func (r *ChannelRouter) syncGraphWithChain() error {
 		// With the spent outputs gathered, attempt to prune the
 		// channel graph, also passing in the hash+height of the block
 		// being pruned so the prune tip can be updated.
-		numClosed, err := r.cfg.Graph.PruneGraph(spentOutputs, nextHash,
-			nextHeight)
+		closedChans, err := r.cfg.Graph.PruneGraph(spentOutputs,
+			nextHash, nextHeight)
 		if err != nil {
 			return err
 		}
 
+		numClosed := uint32(len(closedChans))
 		log.Infof("Block %v (height=%v) closed %v channels",
 			nextHash, nextHeight, numClosed)
 
@@ -296,8 +340,6 @@ func (r *ChannelRouter) syncGraphWithChain() error {
 func (r *ChannelRouter) networkHandler() {
 	defer r.wg.Done()
 
-	var announcementBatch []lnwire.Message
-
 	trickleTimer := time.NewTicker(time.Millisecond * 300)
 	defer trickleTimer.Stop()
 
@@ -310,19 +352,17 @@ func (r *ChannelRouter) networkHandler() {
 			// TODO(roasbeef): this loop would mostly be moved to
 			// the discovery service
 
-			// Process the network announcement to determine if this
-			// is either a new announcement from our PoV or an
-			// update to a prior vertex/edge we previously
-			// accepted.
-			accepted := r.processNetworkAnnouncement(netMsg.msg)
-
-			// If the update was accepted, then add it to our next
-			// announcement batch to be broadcast once the trickle
-			// timer ticks gain.
-			if accepted {
-				announcementBatch = append(announcementBatch, netMsg.msg)
+			// Once a worker is free, the announcement is handed
+			// off to it to be processed in parallel with any
+			// other announcements it doesn't depend on.
+			job, ok := r.validationBarrier.InitJob(netMsg.msg)
+			if !ok {
+				return
 			}
 
+			r.wg.Add(1)
+			go r.handleNetworkAnnouncement(job)
+
 			// TODO(roasbeef): remove all unconnected vertexes
 			// after N blocks pass with no corresponding
 			// announcements.
@@ -361,7 +401,7 @@ func (r *ChannelRouter) networkHandler() {
 			// the channel graph, also passing in the hash+height
 			// of the block being pruned so the prune tip can be
 			// updated.
-			numClosed, err := r.cfg.Graph.PruneGraph(spentOutputs,
+			closedChans, err := r.cfg.Graph.PruneGraph(spentOutputs,
 				newBlock.Hash, uint32(newBlock.Height))
 			if err != nil {
 				log.Errorf("unable to prune routing table: %v", err)
@@ -369,12 +409,29 @@ func (r *ChannelRouter) networkHandler() {
 			}
 
 			log.Infof("Block %v (height=%v) closed %v channels",
-				newBlock.Hash, newBlock.Height, numClosed)
+				newBlock.Hash, newBlock.Height, len(closedChans))
+
+			if len(closedChans) == 0 {
+				continue
+			}
+
+			// Notify all currently registered clients of the newly
+			// closed channels.
+			closeSummaries := createCloseSummaries(uint32(newBlock.Height),
+				closedChans...)
+			r.notifyTopologyChange(&TopologyChange{
+				ClosedChannels: closeSummaries,
+			})
 
 		// The trickle timer has ticked, which indicates we should
 		// flush to the network the pending batch of new announcements
 		// we've received since the last trickle tick.
 		case <-trickleTimer.C:
+			r.batchMtx.Lock()
+			announcementBatch := r.announcementBatch
+			r.announcementBatch = nil
+			r.batchMtx.Unlock()
+
 			// If the current announcement batch is nil, then we
 			// have no further work here.
 			if len(announcementBatch) == 0 {
@@ -386,16 +443,17 @@ func (r *ChannelRouter) networkHandler() {
 
 			// If we have new things to announce then broadcast
 			// then to all our immediately connected peers.
+			// If we aren't able to, then the batch is retried
+			// along with the next one.
 			err := r.cfg.Broadcast(nil, announcementBatch...)
 			if err != nil {
 				log.Errorf("unable to send batch announcement: %v", err)
-				continue
-			}
 
-			// If we we're able to broadcast the current batch
-			// successfully, then we reset the batch for a new
-			// round of announcements.
-			announcementBatch = nil
+				r.batchMtx.Lock()
+				r.announcementBatch = append(announcementBatch,
+					r.announcementBatch...)
+				r.batchMtx.Unlock()
+			}
 
 		// We've just received a new request to synchronize a peer with
 		// our latest graph state. This indicates that a peer has just
@@ -419,6 +477,44 @@ func (r *ChannelRouter) networkHandler() {
 	}
 }
 
+// handleNetworkAnnouncement processes the announcement of the passed job once
+// the announcements it depends on have been processed. If the announcement is
+// accepted, then it's added to our next announcement batch to be broadcast
+// once the trickle timer ticks again. Additionally, we'll notify any topology
+// clients of the new change to the channel graph.
+//
+// NOTE: This MUST be run as a goroutine.
+func (r *ChannelRouter) handleNetworkAnnouncement(job *validationJob) {
+	defer r.wg.Done()
+	defer r.validationBarrier.CompleteJob(job)
+
+	if !r.validationBarrier.WaitForDependencies(job) {
+		return
+	}
+
+	// Process the network announcement to determine if this is either a
+	// new announcement from our PoV or an update to a prior vertex/edge
+	// we previously accepted.
+	if !r.processNetworkAnnouncement(job.msg) {
+		return
+	}
+
+	r.batchMtx.Lock()
+	r.announcementBatch = append(r.announcementBatch, job.msg)
+	r.batchMtx.Unlock()
+
+	topChange := &TopologyChange{}
+	err := addToTopologyChange(r.cfg.Graph, topChange, job.msg)
+	if err != nil {
+		log.Errorf("unable to update topology change notification: %v",
+			err)
+	}
+
+	if !topChange.isEmpty() {
+		r.notifyTopologyChange(topChange)
+	}
+}
+
 // processNetworkAnnouncement processes a new network relate authenticated
 // channel or node announcement. If the update didn't affect the internal state
 // of the draft due to either being out of date, invalid, or redundant, then
@@ -454,10 +550,12 @@ func (r *ChannelRouter) processNetworkAnnouncement(msg lnwire.Message) bool {
 			return false
 		}
 
+		red, green, blue := msg.RGBColor.Components()
 		node := &channeldb.LightningNode{
 			LastUpdate: msgTimestamp,
 			Address:    msg.Address,
 			PubKey:     msg.NodeID,
+			Color:      color.RGBA{R: red, G: green, B: blue},
 			Alias:      msg.Alias.String(),
 		}
 
@@ -539,7 +637,7 @@ func (r *ChannelRouter) processNetworkAnnouncement(msg lnwire.Message) bool {
 		// check if we already have the most up to date information for
 		// that edge. If so, then we can exit early.
 		updateTimestamp := time.Unix(int64(msg.Timestamp), 0)
-		switch msg.Flags {
+		switch msg.Flags & lnwire.ChanUpdateDirection {
 
 		// A flag set of 0 indicates this is an announcement for
 		// the "first" node in the channel.
@@ -596,6 +694,15 @@ func (r *ChannelRouter) processNetworkAnnouncement(msg lnwire.Message) bool {
 			Capacity: btcutil.Amount(utxo.Value) - 5000,
 		}
 
+		if msg.InboundFee != nil {
+			chanUpdate.InboundFeeBaseMSat = btcutil.Amount(
+				msg.InboundFee.BaseMsat,
+			)
+			chanUpdate.InboundFeeProportionalMillionths = btcutil.Amount(
+				msg.InboundFee.FeeRateMillionths,
+			)
+		}
+
 		err = r.cfg.Graph.UpdateEdgeInfo(chanUpdate)
 		if err != nil {
 			log.Errorf("unable to add channel: %v", err)
@@ -658,7 +765,9 @@ func (r *ChannelRouter) syncChannelGraph(syncReq *syncRequest) error {
 			Timestamp: uint32(node.LastUpdate.Unix()),
 			Address:   node.Address,
 			NodeID:    node.PubKey,
-			Alias:     alias,
+			RGBColor: lnwire.NewRGB(node.Color.R, node.Color.G,
+				node.Color.B),
+			Alias: alias,
 		}
 		announceMessages = append(announceMessages, ann)
 
@@ -697,6 +806,7 @@ func (r *ChannelRouter) syncChannelGraph(syncReq *syncRequest) error {
 			HtlcMinimumMstat:          uint32(e1.MinHTLC),
 			FeeBaseMstat:              uint32(e1.FeeBaseMSat),
 			FeeProportionalMillionths: uint32(e1.FeeProportionalMillionths),
+			InboundFee:                edgeInboundFee(e1),
 		}
 		chanUpdate2 := &lnwire.ChannelUpdateAnnouncement{
 			Signature:                 r.fakeSig,
@@ -707,6 +817,7 @@ func (r *ChannelRouter) syncChannelGraph(syncReq *syncRequest) error {
 			HtlcMinimumMstat:          uint32(e2.MinHTLC),
 			FeeBaseMstat:              uint32(e2.FeeBaseMSat),
 			FeeProportionalMillionths: uint32(e2.FeeProportionalMillionths),
+			InboundFee:                edgeInboundFee(e2),
 		}
 
 		numEdges++
@@ -750,6 +861,11 @@ func (r *ChannelRouter) fetchChanPoint(chanID *lnwire.ChannelID) (*wire.OutPoint
 
 	// Finally once we have the block itself, we seek to the targeted
 	// transaction index to obtain the funding output and txid.
+	if int(chanID.TxIndex) >= len(fundingBlock.Transactions) {
+		return nil, fmt.Errorf("tx index %v out of range, block %v "+
+			"has %v transactions", chanID.TxIndex, blockHash,
+			len(fundingBlock.Transactions))
+	}
 	fundingTx := fundingBlock.Transactions[chanID.TxIndex]
 	return &wire.OutPoint{
 		Hash:  fundingTx.TxHash(),
@@ -785,10 +901,124 @@ func (r *ChannelRouter) ProcessRoutingMessage(msg lnwire.Message, src *btcec.Pub
 	}
 }
 
+// OwnChannelPolicy returns our direction of the channel identified by the
+// passed funding outpoint, which carries the policy we've announced for it. If
+// we haven't announced a policy for the channel, then ErrEdgeNotFound is
+// returned.
+func (r *ChannelRouter) OwnChannelPolicy(
+	chanPoint *wire.OutPoint) (*channeldb.ChannelEdge, error) {
+
+	edge1, edge2, err := r.cfg.Graph.FetchChannelEdgesByOutpoint(chanPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// Our direction of the channel is the edge which doesn't lead back to
+	// ourselves.
+	for _, edge := range []*channeldb.ChannelEdge{edge1, edge2} {
+		if edge != nil && !edge.Node.PubKey.IsEqual(r.self.PubKey) {
+			return edge, nil
+		}
+	}
+
+	return nil, channeldb.ErrEdgeNotFound
+}
+
+// UpdateChannelPolicy updates the fee schema of our direction of the channel
+// identified by the passed funding outpoint. The remainder of our current
+// policy is kept, and the resulting channel update is processed as any other,
+// so it'll be broadcast to our peers during the next announcement epoch.
+func (r *ChannelRouter) UpdateChannelPolicy(chanPoint *wire.OutPoint,
+	schema *FeeSchema) error {
+
+	edge, err := r.OwnChannelPolicy(chanPoint)
+	if err != nil {
+		return err
+	}
+
+	chanUpdate := r.newOwnChannelUpdate(edge)
+	chanUpdate.FeeBaseMstat = uint32(schema.BaseFee)
+	chanUpdate.FeeProportionalMillionths = uint32(schema.FeeRate)
+
+	r.ProcessRoutingMessage(chanUpdate, r.self.PubKey)
+	return nil
+}
+
+// SetChannelDisabled disables, or re-enables, forwarding over our direction of
+// the channel identified by the passed funding outpoint. As with fee updates,
+// the remainder of our policy is kept, and the channel update is broadcast to
+// our peers during the next announcement epoch.
+func (r *ChannelRouter) SetChannelDisabled(chanPoint *wire.OutPoint,
+	disabled bool) error {
+
+	edge, err := r.OwnChannelPolicy(chanPoint)
+	if err != nil {
+		return err
+	}
+
+	chanUpdate := r.newOwnChannelUpdate(edge)
+	if disabled {
+		chanUpdate.Flags |= lnwire.ChanUpdateDisabled
+	} else {
+		chanUpdate.Flags &^= lnwire.ChanUpdateDisabled
+	}
+
+	r.ProcessRoutingMessage(chanUpdate, r.self.PubKey)
+	return nil
+}
+
+// newOwnChannelUpdate creates a channel update for our direction of a channel
+// which carries over the policy of the passed edge, to be modified by the
+// caller before it's processed.
+func (r *ChannelRouter) newOwnChannelUpdate(
+	edge *channeldb.ChannelEdge) *lnwire.ChannelUpdateAnnouncement {
+
+	// As updates with a timestamp no later than the current one are
+	// ignored, we'll ensure the timestamp strictly increases even if
+	// several updates are made within the same second.
+	timestamp := time.Now()
+	if !timestamp.After(edge.LastUpdate) {
+		timestamp = edge.LastUpdate.Add(time.Second)
+	}
+
+	// TODO(roasbeef): add real sig
+	return &lnwire.ChannelUpdateAnnouncement{
+		Signature:                 r.fakeSig,
+		ChannelID:                 lnwire.NewChanIDFromInt(edge.ChannelID),
+		Timestamp:                 uint32(timestamp.Unix()),
+		Flags:                     edge.Flags,
+		Expiry:                    edge.Expiry,
+		HtlcMinimumMstat:          uint32(edge.MinHTLC),
+		FeeBaseMstat:              uint32(edge.FeeBaseMSat),
+		FeeProportionalMillionths: uint32(edge.FeeProportionalMillionths),
+
+		// NOTE: Our own inbound fee is never advertised, as the switch
+		// doesn't enforce it on the HTLCs it forwards.
+	}
+}
+
+// edgeInboundFee returns the inbound fee of the passed edge to be carried
+// within its channel update, or nil if the edge doesn't have one.
+func edgeInboundFee(edge *channeldb.ChannelEdge) *lnwire.InboundFee {
+	if edge.InboundFeeBaseMSat == 0 &&
+		edge.InboundFeeProportionalMillionths == 0 {
+
+		return nil
+	}
+
+	return &lnwire.InboundFee{
+		BaseMsat:          int32(edge.InboundFeeBaseMSat),
+		FeeRateMillionths: int32(edge.InboundFeeProportionalMillionths),
+	}
+}
+
 // FindRoute attempts to query the ChannelRouter for the "best" path to a
 // particular target destination which is able to send `amt` after factoring in
-// channel capacities and cumulative fees along the route.
-func (r *ChannelRouter) FindRoute(target *btcec.PublicKey, amt btcutil.Amount) (*Route, error) {
+// channel capacities and cumulative fees along the route. If restrictions are
+// passed, only routes satisfying them are considered.
+func (r *ChannelRouter) FindRoute(target *btcec.PublicKey, amt btcutil.Amount,
+	restrictions *RouteRestrictions) (*Route, error) {
+
 	dest := target.SerializeCompressed()
 
 	log.Debugf("Searching for path to %x, sending %v", dest, amt)
@@ -803,7 +1033,9 @@ func (r *ChannelRouter) FindRoute(target *btcec.PublicKey, amt btcutil.Amount) (
 	}
 
 	// TODO(roasbeef): add k-shortest paths
-	route, err := findRoute(r.cfg.Graph, target, amt)
+	route, err := findRoute(
+		r.cfg.Graph, target, amt, r.cfg.Estimator, restrictions,
+	)
 	if err != nil {
 		log.Errorf("Unable to find path: %v", err)
 		return nil, err
@@ -828,23 +1060,3 @@ func (r *ChannelRouter) FindRoute(target *btcec.PublicKey, amt btcutil.Amount) (
 func (r *ChannelRouter) SendPayment() error {
 	return nil
 }
-
-// TopologyClient...
-// TODO(roasbeef): put in discovery package?
-type TopologyClient struct {
-}
-
-// TopologyChange...
-type TopologyChange struct {
-	NewNodes    []*channeldb.LinkNode
-	NewChannels []*channeldb.ChannelEdge
-}
-
-// notifyTopologyChange...
-func (r *ChannelRouter) notifyTopologyChange() {
-}
-
-// SubscribeTopology....
-func (r *ChannelRouter) SubscribeTopology() (*TopologyClient, error) {
-	return nil, nil
-}
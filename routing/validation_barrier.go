@@ -0,0 +1,207 @@
+package routing
+
+import (
+	"sync"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// validationJob is a network announcement being validated by one of the
+// workers of a ValidationBarrier.
+type validationJob struct {
+	msg lnwire.Message
+
+	// dependencies are the signals of the jobs which must complete before
+	// this job may be processed.
+	dependencies []chan struct{}
+
+	// done is closed once the job completes.
+	done chan struct{}
+}
+
+// ValidationBarrier allows independent network announcements to be validated
+// in parallel by a bounded set of workers, while preserving the order between
+// dependent announcements. A channel update waits for the announcement of its
+// channel to be validated, and a node announcement waits for the
+// announcements of its channels to be validated, as neither is accepted for
+// an unknown channel or node. Additionally, the updates of a channel, and the
+// announcements of a node, are processed in the order they arrived, so that a
+// newer update is never overwritten by an older one.
+type ValidationBarrier struct {
+	// validationSemaphore holds a value for each job being processed,
+	// limiting the number of jobs processed in parallel.
+	validationSemaphore chan struct{}
+
+	mtx sync.Mutex
+
+	// chanAnnFinSignal maps the ID of each channel whose announcement is
+	// being validated to the signal of the announcement's job.
+	chanAnnFinSignal map[uint64]chan struct{}
+
+	// nodeChanAnns maps each node to the jobs of the announcements of its
+	// channels being validated.
+	nodeChanAnns map[[33]byte]map[*validationJob]struct{}
+
+	// chanUpdateSignal and nodeAnnSignal map each channel and node to the
+	// signal of the job of its most recent update or announcement.
+	chanUpdateSignal map[uint64]chan struct{}
+	nodeAnnSignal    map[[33]byte]chan struct{}
+
+	quit chan struct{}
+}
+
+// NewValidationBarrier creates a new ValidationBarrier processing at most
+// numWorkers jobs in parallel. The passed quit channel is closed to abort any
+// jobs waiting to be processed.
+func NewValidationBarrier(numWorkers int,
+	quit chan struct{}) *ValidationBarrier {
+
+	return &ValidationBarrier{
+		validationSemaphore: make(chan struct{}, numWorkers),
+		chanAnnFinSignal:    make(map[uint64]chan struct{}),
+		nodeChanAnns: make(
+			map[[33]byte]map[*validationJob]struct{},
+		),
+		chanUpdateSignal: make(map[uint64]chan struct{}),
+		nodeAnnSignal:    make(map[[33]byte]chan struct{}),
+		quit:             quit,
+	}
+}
+
+// nodeKey returns the key of the passed node within the maps of the barrier.
+func nodeKey(pub *btcec.PublicKey) [33]byte {
+	var key [33]byte
+	copy(key[:], pub.SerializeCompressed())
+	return key
+}
+
+// InitJob creates a job for the passed announcement once a worker is free to
+// process it, recording the jobs it depends on. Jobs MUST be created in the
+// order their announcements arrived, though may be processed in any order
+// once created. False is returned if the barrier quit before a worker became
+// free.
+func (v *ValidationBarrier) InitJob(
+	msg lnwire.Message) (*validationJob, bool) {
+
+	select {
+	case v.validationSemaphore <- struct{}{}:
+	case <-v.quit:
+		return nil, false
+	}
+
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+
+	job := &validationJob{
+		msg:  msg,
+		done: make(chan struct{}),
+	}
+
+	switch msg := msg.(type) {
+	case *lnwire.ChannelAnnouncement:
+		// A duplicate announcement of a channel must wait for the
+		// first, so that it's found to be known.
+		chanID := msg.ChannelID.ToUint64()
+		if signal, ok := v.chanAnnFinSignal[chanID]; ok {
+			job.dependencies = append(job.dependencies, signal)
+		}
+		v.chanAnnFinSignal[chanID] = job.done
+
+		for _, pub := range []*btcec.PublicKey{
+			msg.FirstNodeID, msg.SecondNodeID,
+		} {
+			key := nodeKey(pub)
+			if _, ok := v.nodeChanAnns[key]; !ok {
+				v.nodeChanAnns[key] = make(
+					map[*validationJob]struct{},
+				)
+			}
+			v.nodeChanAnns[key][job] = struct{}{}
+		}
+
+	case *lnwire.ChannelUpdateAnnouncement:
+		chanID := msg.ChannelID.ToUint64()
+		if signal, ok := v.chanAnnFinSignal[chanID]; ok {
+			job.dependencies = append(job.dependencies, signal)
+		}
+		if signal, ok := v.chanUpdateSignal[chanID]; ok {
+			job.dependencies = append(job.dependencies, signal)
+		}
+		v.chanUpdateSignal[chanID] = job.done
+
+	case *lnwire.NodeAnnouncement:
+		// Only the channel announcements which arrived before the
+		// node announcement are waited for, so that a steady stream of
+		// new channels can't hold it back indefinitely.
+		key := nodeKey(msg.NodeID)
+		for chanAnnJob := range v.nodeChanAnns[key] {
+			job.dependencies = append(
+				job.dependencies, chanAnnJob.done,
+			)
+		}
+		if signal, ok := v.nodeAnnSignal[key]; ok {
+			job.dependencies = append(job.dependencies, signal)
+		}
+		v.nodeAnnSignal[key] = job.done
+	}
+
+	return job, true
+}
+
+// WaitForDependencies blocks until all the jobs the passed job depends on
+// have completed. False is returned if the barrier quit in the meantime.
+func (v *ValidationBarrier) WaitForDependencies(job *validationJob) bool {
+	for _, signal := range job.dependencies {
+		select {
+		case <-signal:
+		case <-v.quit:
+			return false
+		}
+	}
+
+	return true
+}
+
+// CompleteJob signals the jobs depending on the passed job that it has
+// completed, and frees its worker. It MUST be called for every job created,
+// whether or not it was processed.
+func (v *ValidationBarrier) CompleteJob(job *validationJob) {
+	v.mtx.Lock()
+
+	close(job.done)
+
+	switch msg := job.msg.(type) {
+	case *lnwire.ChannelAnnouncement:
+		chanID := msg.ChannelID.ToUint64()
+		if v.chanAnnFinSignal[chanID] == job.done {
+			delete(v.chanAnnFinSignal, chanID)
+		}
+
+		for _, pub := range []*btcec.PublicKey{
+			msg.FirstNodeID, msg.SecondNodeID,
+		} {
+			key := nodeKey(pub)
+			delete(v.nodeChanAnns[key], job)
+			if len(v.nodeChanAnns[key]) == 0 {
+				delete(v.nodeChanAnns, key)
+			}
+		}
+
+	case *lnwire.ChannelUpdateAnnouncement:
+		chanID := msg.ChannelID.ToUint64()
+		if v.chanUpdateSignal[chanID] == job.done {
+			delete(v.chanUpdateSignal, chanID)
+		}
+
+	case *lnwire.NodeAnnouncement:
+		key := nodeKey(msg.NodeID)
+		if v.nodeAnnSignal[key] == job.done {
+			delete(v.nodeAnnSignal, key)
+		}
+	}
+
+	v.mtx.Unlock()
+
+	<-v.validationSemaphore
+}
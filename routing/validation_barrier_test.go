@@ -0,0 +1,163 @@
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// newTestPub returns a distinct public key for each passed seed.
+func newTestPub(seed byte) *btcec.PublicKey {
+	var privBytes [32]byte
+	privBytes[31] = seed
+	_, pub := btcec.PrivKeyFromBytes(btcec.S256(), privBytes[:])
+	return pub
+}
+
+// waitAsync waits for the dependencies of the passed job in the background,
+// returning a channel which is closed once they've completed.
+func waitAsync(barrier *ValidationBarrier, job *validationJob) chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		if barrier.WaitForDependencies(job) {
+			close(done)
+		}
+	}()
+
+	return done
+}
+
+// assertBlocked asserts that the passed signal isn't closed in the near
+// future.
+func assertBlocked(t *testing.T, signal chan struct{}, desc string) {
+	select {
+	case <-signal:
+		t.Fatalf("%v wasn't blocked", desc)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// assertUnblocked asserts that the passed signal is closed.
+func assertUnblocked(t *testing.T, signal chan struct{}, desc string) {
+	select {
+	case <-signal:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("%v remained blocked", desc)
+	}
+}
+
+// TestValidationBarrierDependencies asserts that channel updates and node
+// announcements wait for the announcements of their channels, while
+// independent announcements don't wait on each other.
+func TestValidationBarrierDependencies(t *testing.T) {
+	t.Parallel()
+
+	quit := make(chan struct{})
+	defer close(quit)
+	barrier := NewValidationBarrier(10, quit)
+
+	node1, node2, node3 := newTestPub(1), newTestPub(2), newTestPub(3)
+	chanID := lnwire.NewChanIDFromInt(1)
+	initJob := func(msg lnwire.Message) *validationJob {
+		job, ok := barrier.InitJob(msg)
+		if !ok {
+			t.Fatalf("unable to init job")
+		}
+		return job
+	}
+
+	chanAnn := initJob(&lnwire.ChannelAnnouncement{
+		ChannelID:    chanID,
+		FirstNodeID:  node1,
+		SecondNodeID: node2,
+	})
+	chanUpdate1 := initJob(&lnwire.ChannelUpdateAnnouncement{
+		ChannelID: chanID,
+	})
+	chanUpdate2 := initJob(&lnwire.ChannelUpdateAnnouncement{
+		ChannelID: chanID,
+	})
+	nodeAnn := initJob(&lnwire.NodeAnnouncement{
+		NodeID: node1,
+	})
+	otherNodeAnn := initJob(&lnwire.NodeAnnouncement{
+		NodeID: node3,
+	})
+	otherChanAnn := initJob(&lnwire.ChannelAnnouncement{
+		ChannelID:    lnwire.NewChanIDFromInt(2),
+		FirstNodeID:  node1,
+		SecondNodeID: node3,
+	})
+
+	// Neither announcement of a channel, nor the announcement of a node
+	// without any pending channel announcements, should wait on anything.
+	assertUnblocked(t, waitAsync(barrier, chanAnn), "channel announcement")
+	assertUnblocked(t, waitAsync(barrier, otherChanAnn),
+		"other channel announcement")
+	assertUnblocked(t, waitAsync(barrier, otherNodeAnn),
+		"other node announcement")
+
+	// The updates of the channel and the announcement of its node should
+	// wait for the announcement of the channel.
+	update1Done := waitAsync(barrier, chanUpdate1)
+	update2Done := waitAsync(barrier, chanUpdate2)
+	nodeAnnDone := waitAsync(barrier, nodeAnn)
+	assertBlocked(t, update1Done, "channel update")
+	assertBlocked(t, nodeAnnDone, "node announcement")
+
+	barrier.CompleteJob(chanAnn)
+	assertUnblocked(t, update1Done, "channel update")
+
+	// The second update should wait for the first.
+	assertBlocked(t, update2Done, "second channel update")
+	barrier.CompleteJob(chanUpdate1)
+	assertUnblocked(t, update2Done, "second channel update")
+
+	// The node announcement was announced before the other channel
+	// announcement of the node was, so shouldn't wait on it.
+	assertUnblocked(t, nodeAnnDone, "node announcement")
+}
+
+// TestValidationBarrierWorkers asserts that no more than the maximum number
+// of jobs are processed at once, and that waiting for a worker is aborted
+// once the barrier quits.
+func TestValidationBarrierWorkers(t *testing.T) {
+	t.Parallel()
+
+	quit := make(chan struct{})
+	barrier := NewValidationBarrier(1, quit)
+
+	msg := &lnwire.NodeAnnouncement{
+		NodeID: newTestPub(1),
+	}
+	job, ok := barrier.InitJob(msg)
+	if !ok {
+		t.Fatalf("unable to init job")
+	}
+
+	// With the only worker busy, the next job must wait for it to be
+	// freed.
+	initDone := make(chan struct{})
+	go func() {
+		if _, ok := barrier.InitJob(msg); ok {
+			close(initDone)
+		}
+	}()
+	assertBlocked(t, initDone, "second job")
+
+	barrier.CompleteJob(job)
+	assertUnblocked(t, initDone, "second job")
+
+	// The worker is now busy with the second job, so a third job should
+	// be aborted once the barrier quits.
+	aborted := make(chan struct{})
+	go func() {
+		if _, ok := barrier.InitJob(msg); !ok {
+			close(aborted)
+		}
+	}()
+	close(quit)
+	assertUnblocked(t, aborted, "third job")
+}
@@ -8,6 +8,9 @@ import (
 	"io"
 	"math"
 	"net"
+	"runtime/pprof"
+	"sort"
+	"strings"
 	"time"
 
 	"sync"
@@ -16,10 +19,18 @@ import (
 	"github.com/btcsuite/fastsha256"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lightning-onion"
+	"github.com/lightningnetwork/lnd/chanbackup"
+	"github.com/lightningnetwork/lnd/chanfitness"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/chanstatus"
+	"github.com/lightningnetwork/lnd/feature"
+	"github.com/lightningnetwork/lnd/hodl"
+	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwallet/remotesigner"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/psbt"
 	"github.com/lightningnetwork/lnd/routing"
 	"github.com/lightningnetwork/lnd/zpay32"
 	"github.com/roasbeef/btcd/btcec"
@@ -28,6 +39,7 @@ import (
 	"github.com/roasbeef/btcd/txscript"
 	"github.com/roasbeef/btcd/wire"
 	"github.com/roasbeef/btcutil"
+	"github.com/roasbeef/btcutil/hdkeychain"
 	"github.com/roasbeef/btcwallet/waddrmgr"
 	"golang.org/x/net/context"
 )
@@ -36,6 +48,14 @@ var (
 	defaultAccount uint32 = waddrmgr.DefaultAccountNum
 )
 
+// defaultAccountName is the name under which the wallet's own account is
+// listed alongside any imported accounts.
+const defaultAccountName = "default"
+
+// payReqCacheSize is the number of decoded payment requests cached by the
+// rpcServer.
+const payReqCacheSize = 1000
+
 // rpcServer is a gRPC, RPC front end to the lnd daemon.
 // TODO(roasbeef): pagination support for the list-style calls
 type rpcServer struct {
@@ -44,6 +64,16 @@ type rpcServer struct {
 
 	server *server
 
+	// payReqCache caches the payment requests decoded on behalf of
+	// clients, so repeated decodes of the same request are cheap.
+	payReqCache *zpay32.DecodeCache
+
+	// signerOnion processes the onion packets destined to the watch-only
+	// node we act as the remote signer of, if any. It's created on first
+	// use.
+	signerOnionMtx sync.Mutex
+	signerOnion    *sphinx.Router
+
 	wg sync.WaitGroup
 
 	quit chan struct{}
@@ -53,9 +83,14 @@ type rpcServer struct {
 // LightningServer gRPC service.
 var _ lnrpc.LightningServer = (*rpcServer)(nil)
 
-// newRpcServer creates and returns a new instance of the rpcServer.
-func newRpcServer(s *server) *rpcServer {
-	return &rpcServer{server: s, quit: make(chan struct{}, 1)}
+// newRpcServer creates and returns a new instance of the rpcServer. It's
+// bound to the server which it serves the calls of once the server is
+// created.
+func newRpcServer() *rpcServer {
+	return &rpcServer{
+		payReqCache: zpay32.NewDecodeCache(payReqCacheSize),
+		quit:        make(chan struct{}, 1),
+	}
 }
 
 // Start launches any helper goroutines required for the rpcServer
@@ -102,30 +137,136 @@ func addrPairsToOutputs(addrPairs map[string]int64) ([]*wire.TxOut, error) {
 	return outputs, nil
 }
 
+// calculateFeeRate returns the fee rate, expressed in sat/byte, to be used
+// for an on-chain transaction. An explicit fee rate takes precedence,
+// otherwise our fee estimator is consulted using the target number of
+// confirmations, defaulting to 6 blocks if none is specified.
+func (r *rpcServer) calculateFeeRate(satPerByte int64,
+	targetConf uint32) (uint64, error) {
+
+	switch {
+	case satPerByte < 0:
+		return 0, fmt.Errorf("sat_per_byte cannot be negative")
+
+	case satPerByte != 0 && targetConf != 0:
+		return 0, fmt.Errorf("either sat_per_byte or target_conf may " +
+			"be specified, but not both")
+
+	case satPerByte != 0:
+		return uint64(satPerByte), nil
+	}
+
+	if targetConf == 0 {
+		targetConf = 6
+	}
+
+	return uint64(r.server.feeEstimator.EstimateFeePerByte(targetConf)), nil
+}
+
+// parseMinConfs returns the minimum number of confirmations the coins
+// funding an on-chain transaction must have. Unless unconfirmed coins are
+// explicitly allowed, coins need at least a single confirmation.
+func parseMinConfs(minConfs int32, spendUnconfirmed bool) (int32, error) {
+	switch {
+	case minConfs < 0:
+		return 0, fmt.Errorf("min_confs cannot be negative")
+
+	case minConfs != 0 && spendUnconfirmed:
+		return 0, fmt.Errorf("min_confs cannot be set when " +
+			"spend_unconfirmed is true")
+
+	case spendUnconfirmed:
+		return 0, nil
+
+	case minConfs == 0:
+		return 1, nil
+	}
+
+	return minConfs, nil
+}
+
 // sendCoinsOnChain makes an on-chain transaction in or to send coins to one or
 // more addresses specified in the passed payment map. The payment map maps an
-// address to a specified output value to be sent to that address.
-func (r *rpcServer) sendCoinsOnChain(paymentMap map[string]int64) (*chainhash.Hash, error) {
+// address to a specified output value to be sent to that address. The
+// transaction is funded at the passed fee rate using coins with at least
+// minConfs confirmations, chosen according to the passed coin selection. If
+// a label is specified, then it's attached to the transaction.
+func (r *rpcServer) sendCoinsOnChain(paymentMap map[string]int64,
+	feeRate uint64, minConfs int32, coinSelection *lnwallet.CoinSelection,
+	label string) (*chainhash.Hash, error) {
+
 	outputs, err := addrPairsToOutputs(paymentMap)
 	if err != nil {
 		return nil, err
 	}
 
-	return r.server.lnwallet.SendOutputs(outputs)
+	tx, err := r.server.lnwallet.SendOutputsWithCoinSelection(outputs,
+		feeRate, minConfs, coinSelection, label)
+	if err != nil {
+		return nil, err
+	}
+
+	txid := tx.TxHash()
+	return &txid, nil
 }
 
 // SendCoins executes a request to send coins to a particular address. Unlike
-// SendMany, this RPC call only allows creating a single output at a time.
+// SendMany, this RPC call only allows creating a single output at a time. If
+// send_all is set, then all the eligible coins of the wallet are swept to the
+// address, minus the fee.
 func (r *rpcServer) SendCoins(ctx context.Context,
 	in *lnrpc.SendCoinsRequest) (*lnrpc.SendCoinsResponse, error) {
 
-	rpcsLog.Infof("[sendcoins] addr=%v, amt=%v", in.Addr, btcutil.Amount(in.Amount))
+	rpcsLog.Infof("[sendcoins] addr=%v, amt=%v, send_all=%v", in.Addr,
+		btcutil.Amount(in.Amount), in.SendAll)
 
-	paymentMap := map[string]int64{in.Addr: in.Amount}
-	txid, err := r.sendCoinsOnChain(paymentMap)
+	feeRate, err := r.calculateFeeRate(in.SatPerByte, in.TargetConf)
+	if err != nil {
+		return nil, err
+	}
+	minConfs, err := parseMinConfs(in.MinConfs, in.SpendUnconfirmed)
 	if err != nil {
 		return nil, err
 	}
+	coinSelection, err := parseCoinSelection(in.CoinSelectionStrategy,
+		in.Outpoints)
+	if err != nil {
+		return nil, err
+	}
+
+	var txid *chainhash.Hash
+	if in.SendAll {
+		if in.Amount != 0 {
+			return nil, fmt.Errorf("amount must be zero when " +
+				"send_all is set")
+		}
+
+		addr, err := btcutil.DecodeAddress(in.Addr,
+			activeNetParams.Params)
+		if err != nil {
+			return nil, err
+		}
+		pkScript, err := txscript.PayToAddrScript(addr)
+		if err != nil {
+			return nil, err
+		}
+
+		tx, err := r.server.lnwallet.SweepAll(pkScript, feeRate,
+			minConfs, coinSelection, in.Label)
+		if err != nil {
+			return nil, err
+		}
+
+		hash := tx.TxHash()
+		txid = &hash
+	} else {
+		paymentMap := map[string]int64{in.Addr: in.Amount}
+		txid, err = r.sendCoinsOnChain(paymentMap, feeRate, minConfs,
+			coinSelection, in.Label)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	rpcsLog.Infof("[sendcoins] spend generated txid: %v", txid.String())
 
@@ -137,7 +278,17 @@ func (r *rpcServer) SendCoins(ctx context.Context,
 func (r *rpcServer) SendMany(ctx context.Context,
 	in *lnrpc.SendManyRequest) (*lnrpc.SendManyResponse, error) {
 
-	txid, err := r.sendCoinsOnChain(in.AddrToAmount)
+	feeRate, err := r.calculateFeeRate(in.SatPerByte, in.TargetConf)
+	if err != nil {
+		return nil, err
+	}
+	minConfs, err := parseMinConfs(in.MinConfs, in.SpendUnconfirmed)
+	if err != nil {
+		return nil, err
+	}
+
+	txid, err := r.sendCoinsOnChain(in.AddrToAmount, feeRate, minConfs,
+		nil, in.Label)
 	if err != nil {
 		return nil, err
 	}
@@ -147,20 +298,28 @@ func (r *rpcServer) SendMany(ctx context.Context,
 	return &lnrpc.SendManyResponse{Txid: txid.String()}, nil
 }
 
+// parseAddressType translates the gRPC proto address type to the wallet
+// controller's available address types.
+func parseAddressType(t lnrpc.NewAddressRequest_AddressType) (lnwallet.AddressType, error) {
+	switch t {
+	case lnrpc.NewAddressRequest_WITNESS_PUBKEY_HASH:
+		return lnwallet.WitnessPubKey, nil
+	case lnrpc.NewAddressRequest_NESTED_PUBKEY_HASH:
+		return lnwallet.NestedWitnessPubKey, nil
+	case lnrpc.NewAddressRequest_PUBKEY_HASH:
+		return lnwallet.PubKeyHash, nil
+	default:
+		return 0, fmt.Errorf("unknown address type: %v", t)
+	}
+}
+
 // NewAddress creates a new address under control of the local wallet.
 func (r *rpcServer) NewAddress(ctx context.Context,
 	in *lnrpc.NewAddressRequest) (*lnrpc.NewAddressResponse, error) {
 
-	// Translate the gRPC proto address type to the wallet controller's
-	// available address types.
-	var addrType lnwallet.AddressType
-	switch in.Type {
-	case lnrpc.NewAddressRequest_WITNESS_PUBKEY_HASH:
-		addrType = lnwallet.WitnessPubKey
-	case lnrpc.NewAddressRequest_NESTED_PUBKEY_HASH:
-		addrType = lnwallet.NestedWitnessPubKey
-	case lnrpc.NewAddressRequest_PUBKEY_HASH:
-		addrType = lnwallet.PubKeyHash
+	addrType, err := parseAddressType(in.Type)
+	if err != nil {
+		return nil, err
 	}
 
 	addr, err := r.server.lnwallet.NewAddress(addrType, false)
@@ -204,9 +363,25 @@ func (r *rpcServer) ConnectPeer(ctx context.Context,
 		return nil, err
 	}
 
-	host, err := net.ResolveTCPAddr("tcp", in.Addr.Host)
-	if err != nil {
-		return nil, err
+	// If no host was provided, then we'll connect to the peer over the
+	// address it has advertised within the channel graph.
+	var host *net.TCPAddr
+	if in.Addr.Host == "" {
+		graph := r.server.chanDB.ChannelGraph()
+		node, err := graph.FetchLightningNode(pubkey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find address of "+
+				"peer: %v", err)
+		}
+		if node.Address == nil {
+			return nil, fmt.Errorf("peer has no known address")
+		}
+		host = node.Address
+	} else {
+		host, err = net.ResolveTCPAddr("tcp", in.Addr.Host)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	peerAddr := &lnwire.NetAddress{
@@ -250,11 +425,26 @@ func (r *rpcServer) OpenChannel(in *lnrpc.OpenChannelRequest,
 		return err
 	}
 
+	coinSelection, err := parseCoinSelection(in.CoinSelectionStrategy,
+		in.Outpoints)
+	if err != nil {
+		return err
+	}
+	constraints, err := parseChanConstraints(in, localFundingAmt)
+	if err != nil {
+		return err
+	}
+	deliveryAddr, err := parseUpfrontShutdownAddr(in.CloseAddress)
+	if err != nil {
+		return err
+	}
+
 	// Instruct the server to trigger the necessary events to attempt to
 	// open a new channel. A stream is returned in place, this stream will
 	// be used to consume updates of the state of the pending channel.
 	updateChan, errChan := r.server.OpenChannel(in.TargetPeerId,
-		nodepubKey, localFundingAmt, remoteInitialBalance, in.NumConfs)
+		nodepubKey, localFundingAmt, remoteInitialBalance, in.NumConfs,
+		coinSelection, constraints, deliveryAddr)
 
 	var outpoint wire.OutPoint
 out:
@@ -297,6 +487,36 @@ out:
 	return nil
 }
 
+// parseChanConstraints extracts the channel constraints to impose upon the
+// remote party from an open channel request, ensuring they're sane for a
+// channel of the passed capacity.
+func parseChanConstraints(in *lnrpc.OpenChannelRequest,
+	capacity btcutil.Amount) (channeldb.ChannelConstraints, error) {
+
+	if in.RemoteChanReserveSat < 0 || in.RemoteMaxValueInFlightSat < 0 ||
+		in.MinHtlcSat < 0 {
+
+		return channeldb.ChannelConstraints{}, fmt.Errorf("channel " +
+			"constraints cannot be negative")
+	}
+	if in.RemoteMaxHtlcs > math.MaxUint16 {
+		return channeldb.ChannelConstraints{}, fmt.Errorf("remote max "+
+			"htlcs cannot exceed %v", math.MaxUint16)
+	}
+
+	constraints := channeldb.ChannelConstraints{
+		ChanReserve:      btcutil.Amount(in.RemoteChanReserveSat),
+		MaxPendingAmount: btcutil.Amount(in.RemoteMaxValueInFlightSat),
+		MinHTLC:          btcutil.Amount(in.MinHtlcSat),
+		MaxAcceptedHtlcs: uint16(in.RemoteMaxHtlcs),
+	}
+	if err := lnwallet.VerifyConstraints(constraints, capacity); err != nil {
+		return channeldb.ChannelConstraints{}, err
+	}
+
+	return constraints, nil
+}
+
 // OpenChannelSync is a synchronous version of the OpenChannel RPC call. This
 // call is meant to be consumed by clients to the REST proxy. As with all other
 // sync calls, all byte slices are instead to be populated as hex encoded
@@ -331,8 +551,23 @@ func (r *rpcServer) OpenChannelSync(ctx context.Context,
 			"initial state must be below the local funding amount")
 	}
 
+	coinSelection, err := parseCoinSelection(in.CoinSelectionStrategy,
+		in.Outpoints)
+	if err != nil {
+		return nil, err
+	}
+	constraints, err := parseChanConstraints(in, localFundingAmt)
+	if err != nil {
+		return nil, err
+	}
+	deliveryAddr, err := parseUpfrontShutdownAddr(in.CloseAddress)
+	if err != nil {
+		return nil, err
+	}
+
 	updateChan, errChan := r.server.OpenChannel(in.TargetPeerId,
-		nodepubKey, localFundingAmt, remoteInitialBalance, in.NumConfs)
+		nodepubKey, localFundingAmt, remoteInitialBalance, in.NumConfs,
+		coinSelection, constraints, deliveryAddr)
 
 	select {
 	// If an error occurs them immediately return the error to the client.
@@ -363,23 +598,41 @@ func (r *rpcServer) OpenChannelSync(ctx context.Context,
 }
 
 // CloseChannel attempts to close an active channel identified by its channel
-// point. The actions of this method can additionally be augmented to attempt
-// a force close after a timeout period in the case of an inactive peer.
+// point, or its short channel ID. The actions of this method can additionally
+// be augmented to attempt a force close after a timeout period in the case of
+// an inactive peer.
 func (r *rpcServer) CloseChannel(in *lnrpc.CloseChannelRequest,
 	updateStream lnrpc.Lightning_CloseChannelServer) error {
 
 	force := in.Force
-	index := in.ChannelPoint.OutputIndex
-	txid, err := chainhash.NewHash(in.ChannelPoint.FundingTxid)
+	chanPoint, err := r.closeChanPoint(in)
 	if err != nil {
-		rpcsLog.Errorf("[closechannel] invalid txid: %v", err)
+		rpcsLog.Errorf("[closechannel] invalid channel: %v", err)
 		return err
 	}
-	chanPoint := wire.NewOutPoint(txid, index)
 
 	rpcsLog.Tracef("[closechannel] request for ChannelPoint(%v)",
 		chanPoint)
 
+	// If a delivery address was specified, then we'll decode the script
+	// the funds of the channel are to be paid to.
+	var deliveryScript []byte
+	if in.DeliveryAddress != "" {
+		deliveryAddr, err := btcutil.DecodeAddress(in.DeliveryAddress,
+			activeNetParams.Params)
+		if err != nil {
+			return err
+		}
+		if !deliveryAddr.IsForNet(activeNetParams.Params) {
+			return fmt.Errorf("delivery address %v is not for the "+
+				"active network", in.DeliveryAddress)
+		}
+		deliveryScript, err = txscript.PayToAddrScript(deliveryAddr)
+		if err != nil {
+			return err
+		}
+	}
+
 	var (
 		updateChan chan *lnrpc.CloseStatusUpdate
 		errChan    chan error
@@ -397,7 +650,7 @@ func (r *rpcServer) CloseChannel(in *lnrpc.CloseChannelRequest,
 		if err != nil {
 			return err
 		}
-		closingTxid, err := r.forceCloseChan(channel)
+		closingTxid, err := r.forceCloseChan(channel, deliveryScript)
 		if err != nil {
 			return err
 		}
@@ -440,6 +693,11 @@ func (r *rpcServer) CloseChannel(in *lnrpc.CloseChannelRequest,
 					errChan <- err
 					return
 				}
+				r.server.channelNotifier.notifyChannelEvent(
+					lnrpc.ChannelEventUpdate_CLOSED_CHANNEL,
+					*chanPoint,
+					&channel.StateSnapshot().RemoteIdentity,
+				)
 			case <-r.quit:
 				return
 			}
@@ -463,9 +721,29 @@ func (r *rpcServer) CloseChannel(in *lnrpc.CloseChannelRequest,
 
 	} else {
 		// Otherwise, the caller has requested a regular interactive
-		// cooperative channel closure. So we'll forward the request to
-		// the htlc switch which will handle the negotiation and
-		// broadcast details.
+		// cooperative channel closure, which requires the peer to be
+		// online. The delivery scripts of both parties were committed
+		// to when the channel was opened, so a delivery address may
+		// only be specified if it matches ours.
+		dbChan, err := r.fetchOpenChannel(*chanPoint)
+		if err != nil {
+			return err
+		}
+		nodePub := dbChan.IdentityPub.SerializeCompressed()
+		if !r.server.isPeerConnected(nodePub) {
+			return fmt.Errorf("peer of ChannelPoint(%v) is offline, "+
+				"the channel can only be force closed", chanPoint)
+		}
+		if deliveryScript != nil &&
+			!bytes.Equal(deliveryScript, dbChan.OurDeliveryScript) {
+
+			return fmt.Errorf("delivery address %v doesn't match "+
+				"the upfront shutdown script of "+
+				"ChannelPoint(%v)", in.DeliveryAddress, chanPoint)
+		}
+
+		// So we'll forward the request to the htlc switch which will
+		// handle the negotiation and broadcast details.
 		updateChan, errChan = r.server.htlcSwitch.CloseLink(chanPoint,
 			CloseRegular)
 	}
@@ -501,9 +779,67 @@ out:
 	return nil
 }
 
+// closeChanPoint returns the funding outpoint of the channel targeted by the
+// close request. A channel may be identified by either its channel point, or
+// its short channel ID if it's within the channel graph.
+func (r *rpcServer) closeChanPoint(in *lnrpc.CloseChannelRequest) (*wire.OutPoint, error) {
+	switch {
+	case in.ChannelPoint != nil && in.ChanId != 0:
+		return nil, fmt.Errorf("only one of channel_point and chan_id " +
+			"may be set")
+
+	case in.ChannelPoint != nil:
+		txid, err := chainhash.NewHash(in.ChannelPoint.FundingTxid)
+		if err != nil {
+			return nil, err
+		}
+		return wire.NewOutPoint(txid, in.ChannelPoint.OutputIndex), nil
+
+	case in.ChanId != 0:
+		graph := r.server.chanDB.ChannelGraph()
+		edge1, edge2, err := graph.FetchChannelEdgesByID(in.ChanId)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find channel with "+
+				"chan_id %v: %v", in.ChanId, err)
+		}
+
+		// Either direction of the channel may have yet to be
+		// advertised, but both carry the channel point.
+		edge := edge1
+		if edge == nil {
+			edge = edge2
+		}
+		if edge == nil {
+			return nil, fmt.Errorf("unable to find channel with "+
+				"chan_id %v", in.ChanId)
+		}
+
+		chanPoint := edge.ChannelPoint
+		return &chanPoint, nil
+
+	default:
+		return nil, fmt.Errorf("either channel_point or chan_id must " +
+			"be set")
+	}
+}
+
 // fetchActiveChannel attempts to locate a channel identified by it's channel
 // point from the database's set of all currently opened channels.
 func (r *rpcServer) fetchActiveChannel(chanPoint wire.OutPoint) (*lnwallet.LightningChannel, error) {
+	dbChan, err := r.fetchOpenChannel(chanPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// Otherwise, we create a fully populated channel state machine which
+	// uses the db channel as backing storage.
+	return lnwallet.NewLightningChannel(r.server.lnwallet.Signer,
+		r.server.bio, r.server.chainNotifier, dbChan)
+}
+
+// fetchOpenChannel attempts to locate the persisted state of the channel
+// identified by the passed channel point.
+func (r *rpcServer) fetchOpenChannel(chanPoint wire.OutPoint) (*channeldb.OpenChannel, error) {
 	dbChannels, err := r.server.chanDB.FetchAllChannels()
 	if err != nil {
 		return nil, err
@@ -525,18 +861,18 @@ func (r *rpcServer) fetchActiveChannel(chanPoint wire.OutPoint) (*lnwallet.Light
 		return nil, fmt.Errorf("unable to find channel")
 	}
 
-	// Otherwise, we create a fully populated channel state machine which
-	// uses the db channel as backing storage.
-	return lnwallet.NewLightningChannel(r.server.lnwallet.Signer,
-		r.server.bio, r.server.chainNotifier, dbChan)
+	return dbChan, nil
 }
 
 // forceCloseChan executes a unilateral close of the target channel by
 // broadcasting the current commitment state directly on-chain. Once the
 // commitment transaction has been broadcast, a struct describing the final
 // state of the channel is sent to the utxoNursery in order to ultimately sweep
-// the immature outputs.
-func (r *rpcServer) forceCloseChan(channel *lnwallet.LightningChannel) (*chainhash.Hash, error) {
+// the immature outputs. If a delivery script is passed, the outputs are swept
+// to it rather than back into the wallet.
+func (r *rpcServer) forceCloseChan(channel *lnwallet.LightningChannel,
+	deliveryScript []byte) (*chainhash.Hash, error) {
+
 	// Execute a unilateral close shutting down all further channel
 	// operation.
 	closeSummary, err := channel.ForceClose()
@@ -553,13 +889,18 @@ func (r *rpcServer) forceCloseChan(channel *lnwallet.LightningChannel) (*chainha
 		channel.ChannelPoint(), newLogClosure(func() string {
 			return spew.Sdump(closeTx)
 		}))
-	if err := r.server.lnwallet.PublishTransaction(closeTx); err != nil {
+	label := lnwallet.MakeLabel(lnwallet.LabelTypeChannelClose,
+		channel.ChannelPoint())
+	if err := r.server.lnwallet.PublishAndLabel(closeTx, label); err != nil {
 		return nil, err
 	}
 
 	// Send the closed channel summary over to the utxoNursery in order to
-	// have its outputs swept back into the wallet once they're mature.
-	r.server.utxoNursery.incubateOutputs(closeSummary)
+	// have its outputs swept back into the wallet once they're mature,
+	// while the switch resolves the incoming HTLC's of any outgoing HTLC's
+	// once they're resolved on-chain.
+	r.server.utxoNursery.incubateOutputs(closeSummary, deliveryScript)
+	r.server.htlcSwitch.watchHtlcTimeouts(closeSummary)
 
 	return &txid, nil
 }
@@ -578,7 +919,7 @@ func (r *rpcServer) GetInfo(ctx context.Context,
 	}
 
 	pendingChannels := r.server.fundingMgr.NumPendingChannels()
-	idPub := r.server.identityPriv.PubKey().SerializeCompressed()
+	idPub := r.server.nodeKey.PubKey().SerializeCompressed()
 
 	bestHash, bestHeight, err := r.server.bio.GetBestBlock()
 	if err != nil {
@@ -590,15 +931,39 @@ func (r *rpcServer) GetInfo(ctx context.Context,
 		return nil, err
 	}
 
+	// Any of our open channels which aren't currently active belong to
+	// peers which are offline.
+	dbChannels, err := r.server.chanDB.FetchAllChannels()
+	if err != nil {
+		return nil, err
+	}
+	var inactiveChannels uint32
+	if uint32(len(dbChannels)) > activeChannels {
+		inactiveChannels = uint32(len(dbChannels)) - activeChannels
+	}
+
+	selfNode, err := r.server.chanDB.ChannelGraph().SourceNode()
+	if err != nil {
+		return nil, err
+	}
+
+	features := marshalFeatures(r.server.featureMgr.Get(feature.SetNodeAnn))
+
 	return &lnrpc.GetInfoResponse{
-		IdentityPubkey:     hex.EncodeToString(idPub),
-		NumPendingChannels: pendingChannels,
-		NumActiveChannels:  activeChannels,
-		NumPeers:           uint32(len(serverPeers)),
-		BlockHeight:        uint32(bestHeight),
-		BlockHash:          bestHash.String(),
-		SyncedToChain:      isSynced,
-		Testnet:            activeNetParams.Params == &chaincfg.TestNet3Params,
+		IdentityPubkey:      hex.EncodeToString(idPub),
+		Alias:               selfNode.Alias,
+		Color:               encodeHexColor(selfNode.Color),
+		NumPendingChannels:  pendingChannels,
+		NumActiveChannels:   activeChannels,
+		NumInactiveChannels: inactiveChannels,
+		NumPeers:            uint32(len(serverPeers)),
+		BlockHeight:         uint32(bestHeight),
+		BlockHash:           bestHash.String(),
+		SyncedToChain:       isSynced,
+		Testnet:             activeNetParams.Params == &chaincfg.TestNet3Params,
+		Version:             version(),
+		Chain:               activeNetParams.Name,
+		Features:            features,
 	}, nil
 }
 
@@ -626,18 +991,132 @@ func (r *rpcServer) ListPeers(ctx context.Context,
 			BytesSent: atomic.LoadUint64(&serverPeer.bytesSent),
 		}
 
+		// If the peer has forwarded any HTLC's to us, then we'll also
+		// report the local reputation derived from their outcome.
+		reputation := r.server.htlcSwitch.reputation
+		peerKey := string(nodePub)
+		if rep, ok := reputation.reputation(peerKey); ok {
+			peer.Reputation = &lnrpc.PeerReputation{
+				HtlcsForwarded: rep.forwarded,
+				HtlcsEndorsed:  rep.endorsed,
+				HtlcsSettled:   rep.settled,
+				HtlcsFailed:    rep.failed,
+				AvgHoldTime: int64(
+					rep.avgHoldTime() / time.Millisecond,
+				),
+				GoodReputation: reputation.goodReputation(peerKey),
+			}
+		}
+
 		resp.Peers = append(resp.Peers, peer)
 	}
 
+	for pubStr, backoff := range r.server.ReconnectingPeers() {
+		peer := &lnrpc.ReconnectingPeer{
+			PubKey:  hex.EncodeToString([]byte(pubStr)),
+			Backoff: int64(backoff.Backoff.Seconds()),
+		}
+		if !backoff.LastConnected.IsZero() {
+			peer.LastConnected = backoff.LastConnected.Unix()
+		}
+		if !backoff.LastDisconnected.IsZero() {
+			peer.NextRetry = backoff.NextRetry().Unix()
+		}
+
+		resp.ReconnectingPeers = append(resp.ReconnectingPeers, peer)
+	}
+
 	rpcsLog.Debugf("[listpeers] yielded %v peers", serverPeers)
 
 	return resp, nil
 }
 
+// SubscribePeerEvents returns a uni-directional stream which sends an update
+// each time a peer comes online or goes offline. An online event is first
+// sent for each peer we're currently connected to.
+func (r *rpcServer) SubscribePeerEvents(req *lnrpc.PeerEventSubscription,
+	updateStream lnrpc.Lightning_SubscribePeerEventsServer) error {
+
+	eventClient := r.server.peerNotifier.SubscribePeerEvents()
+	defer eventClient.Cancel()
+
+	for {
+		select {
+		case e := <-eventClient.Events:
+			event := e.(*peerEvent)
+			update := &lnrpc.PeerEvent{
+				PubKey: hex.EncodeToString(
+					event.pubKey.SerializeCompressed(),
+				),
+				Type: event.eventType,
+			}
+			if err := updateStream.Send(update); err != nil {
+				return err
+			}
+		case <-eventClient.Overflow:
+			return errEventQueueOverflow
+		case <-updateStream.Context().Done():
+			return nil
+		case <-r.quit:
+			return nil
+		}
+	}
+}
+
+// UpdatePeerAccess adds a peer to, or removes it from, the list of banned
+// peers or the allowlist, then disconnects from any peer whose connection
+// would now be refused.
+func (r *rpcServer) UpdatePeerAccess(ctx context.Context,
+	in *lnrpc.UpdatePeerAccessRequest) (*lnrpc.UpdatePeerAccessResponse, error) {
+
+	var list channeldb.PeerAccessList
+	switch in.List {
+	case lnrpc.PeerAccessList_BANNED:
+		list = channeldb.BannedPeers
+	case lnrpc.PeerAccessList_ALLOWED:
+		list = channeldb.AllowedPeers
+	default:
+		return nil, fmt.Errorf("unknown peer access list: %v", in.List)
+	}
+
+	entry, err := parsePeerAccessEntry(in.Peer)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Infof("[updatepeeraccess] list=%v, peer=%v, remove=%v", list,
+		entry, in.Remove)
+
+	if in.Remove {
+		err = r.server.peerAccess.removeEntry(list, entry)
+	} else {
+		err = r.server.peerAccess.addEntry(list, entry)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r.server.disconnectRefusedPeers()
+
+	return &lnrpc.UpdatePeerAccessResponse{}, nil
+}
+
+// ListPeerAccess returns the list of banned peers and the allowlist.
+func (r *rpcServer) ListPeerAccess(ctx context.Context,
+	in *lnrpc.ListPeerAccessRequest) (*lnrpc.ListPeerAccessResponse, error) {
+
+	peerAccess := r.server.peerAccess
+	return &lnrpc.ListPeerAccessResponse{
+		Banned:        peerAccess.entries(channeldb.BannedPeers),
+		Allowed:       peerAccess.entries(channeldb.AllowedPeers),
+		AllowlistOnly: peerAccess.allowlistOnly,
+	}, nil
+}
+
 // WalletBalance returns the sum of all confirmed unspent outputs under control
-// by the wallet. This method can be modified by having the request specify
-// only witness outputs should be factored into the final output sum.
-// TODO(roasbeef): split into total and confirmed/unconfirmed
+// by the wallet, along with the total, unconfirmed and locked balances of the
+// wallet. This method can be modified by having the request specify only
+// witness outputs should be factored into the final output sum.
 // TODO(roasbeef): add async hooks into wallet balance changes
 func (r *rpcServer) WalletBalance(ctx context.Context,
 	in *lnrpc.WalletBalanceRequest) (*lnrpc.WalletBalanceResponse, error) {
@@ -646,14 +1125,67 @@ func (r *rpcServer) WalletBalance(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	totalBalance, err := r.server.lnwallet.ConfirmedBalance(
+		0, in.WitnessOnly,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	lockedBalance, err := r.lockedBalance()
+	if err != nil {
+		return nil, err
+	}
 
-	rpcsLog.Debugf("[walletbalance] balance=%v", balance)
+	rpcsLog.Debugf("[walletbalance] balance=%v, total=%v, locked=%v",
+		balance, totalBalance, lockedBalance)
 
-	return &lnrpc.WalletBalanceResponse{balance.ToBTC()}, nil
+	return &lnrpc.WalletBalanceResponse{
+		Balance:            balance.ToBTC(),
+		TotalBalance:       int64(totalBalance),
+		ConfirmedBalance:   int64(balance),
+		UnconfirmedBalance: int64(totalBalance - balance),
+		LockedBalance:      int64(lockedBalance),
+	}, nil
+}
+
+// lockedBalance returns the total value of the outputs of the wallet which
+// are either leased, or reserved to fund pending channels.
+func (r *rpcServer) lockedBalance() (btcutil.Amount, error) {
+	locked := make(map[wire.OutPoint]struct{})
+	for _, op := range r.server.lnwallet.LockedOutpoints() {
+		locked[*op] = struct{}{}
+	}
+	leases, err := r.server.lnwallet.ListLeasedOutputs()
+	if err != nil {
+		return 0, err
+	}
+	for _, lease := range leases {
+		locked[lease.Outpoint] = struct{}{}
+	}
+	if len(locked) == 0 {
+		return 0, nil
+	}
+
+	utxos, err := r.server.lnwallet.ListUnspentWitness(0)
+	if err != nil {
+		return 0, err
+	}
+
+	var balance btcutil.Amount
+	for _, utxo := range utxos {
+		if _, ok := locked[utxo.OutPoint]; ok {
+			balance += utxo.Value
+		}
+	}
+
+	return balance, nil
 }
 
 // ChannelBalance returns the total available channel flow across all open
-// channels in satoshis.
+// channels in satoshis, broken down into the settled and unsettled balances
+// on either side, the balances of the channels yet to be fully opened, and
+// the balances of the open channels of each commitment type.
 func (r *rpcServer) ChannelBalance(ctx context.Context,
 	in *lnrpc.ChannelBalanceRequest) (*lnrpc.ChannelBalanceResponse, error) {
 
@@ -662,12 +1194,60 @@ func (r *rpcServer) ChannelBalance(ctx context.Context,
 		return nil, err
 	}
 
-	var balance btcutil.Amount
+	resp := &lnrpc.ChannelBalanceResponse{}
+	typeBalances := make(
+		map[channeldb.CommitmentType]*lnrpc.CommitmentTypeBalance,
+	)
 	for _, channel := range channels {
-		balance += channel.OurBalance
+		var unsettledLocal, unsettledRemote btcutil.Amount
+		for _, htlc := range channel.Htlcs {
+			if htlc.Incoming {
+				unsettledRemote += htlc.Amt
+			} else {
+				unsettledLocal += htlc.Amt
+			}
+		}
+
+		resp.LocalBalance += int64(channel.OurBalance)
+		resp.RemoteBalance += int64(channel.TheirBalance)
+		resp.UnsettledLocalBalance += int64(unsettledLocal)
+		resp.UnsettledRemoteBalance += int64(unsettledRemote)
+
+		typeBalance, ok := typeBalances[channel.CommitType]
+		if !ok {
+			typeBalance = &lnrpc.CommitmentTypeBalance{
+				CommitmentType: lnrpc.CommitmentType(
+					channel.CommitType,
+				),
+			}
+			typeBalances[channel.CommitType] = typeBalance
+		}
+		typeBalance.NumChannels++
+		typeBalance.LocalBalance += int64(channel.OurBalance)
+		typeBalance.RemoteBalance += int64(channel.TheirBalance)
+		typeBalance.UnsettledLocalBalance += int64(unsettledLocal)
+		typeBalance.UnsettledRemoteBalance += int64(unsettledRemote)
 	}
+	resp.Balance = resp.LocalBalance
 
-	return &lnrpc.ChannelBalanceResponse{Balance: int64(balance)}, nil
+	for _, pendingOpen := range r.server.fundingMgr.PendingChannels() {
+		resp.PendingOpenLocalBalance += int64(pendingOpen.localBalance)
+		resp.PendingOpenRemoteBalance += int64(pendingOpen.remoteBalance)
+	}
+
+	// The balances of each commitment type are returned in the order of
+	// the commitment types.
+	commitType := channeldb.CommitmentType(0)
+	for len(resp.CommitmentTypeBalances) < len(typeBalances) {
+		if typeBalance, ok := typeBalances[commitType]; ok {
+			resp.CommitmentTypeBalances = append(
+				resp.CommitmentTypeBalances, typeBalance,
+			)
+		}
+		commitType++
+	}
+
+	return resp, nil
 }
 
 // PendingChannels returns a list of all the channels that are currently
@@ -701,19 +1281,102 @@ func (r *rpcServer) PendingChannels(ctx context.Context,
 		}
 	}
 	if includeClose {
-	}
-
+		closingChans, err := r.fetchClosingChannels()
+		if err != nil {
+			return nil, err
+		}
+		pendingChannels = append(pendingChannels, closingChans...)
+	}
+
 	return &lnrpc.PendingChannelResponse{
 		PendingChannels: pendingChannels,
 	}, nil
 }
 
+// fetchClosingChannels returns a description of each force closed channel
+// whose outputs are still being incubated by the utxoNursery, along with the
+// funds in limbo within them and the height at which they mature.
+func (r *rpcServer) fetchClosingChannels() (
+	[]*lnrpc.PendingChannelResponse_PendingChannel, error) {
+
+	limboOutputs, err := r.server.utxoNursery.limboOutputs()
+	if err != nil {
+		return nil, err
+	}
+
+	_, bestHeight, err := r.server.bio.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	blocksTilMaturity := func(maturityHeight uint32) int32 {
+		if maturityHeight == 0 {
+			return 0
+		}
+		return int32(maturityHeight) - bestHeight
+	}
+
+	// The outputs are grouped by the channel whose close created them,
+	// preserving the order they were returned in.
+	var closingChans []*lnrpc.PendingChannelResponse_PendingChannel
+	chanIndex := make(map[wire.OutPoint]*lnrpc.PendingChannelResponse_PendingChannel)
+	for _, kid := range limboOutputs {
+		closingChan, ok := chanIndex[kid.originChanPoint]
+		if !ok {
+			closingChan = &lnrpc.PendingChannelResponse_PendingChannel{
+				ChannelPoint: kid.originChanPoint.String(),
+				ClosingTxid:  kid.outPoint.Hash.String(),
+				Status:       lnrpc.ChannelStatus_CLOSING,
+			}
+			chanIndex[kid.originChanPoint] = closingChan
+			closingChans = append(closingChans, closingChan)
+		}
+
+		closingChan.LimboBalance += int64(kid.amt)
+
+		maturityHeight := kid.maturityHeight()
+		if maturityHeight > closingChan.MaturityHeight {
+			closingChan.MaturityHeight = maturityHeight
+			closingChan.BlocksTilMaturity = blocksTilMaturity(
+				maturityHeight,
+			)
+		}
+
+		switch kid.witnessType {
+		case commitmentTimeLock:
+			closingChan.LocalBalance += int64(kid.amt)
+
+		case htlcOfferedTimeout:
+			closingChan.PendingHtlcs = append(closingChan.PendingHtlcs,
+				&lnrpc.PendingChannelResponse_PendingHtlc{
+					Outpoint:       kid.outPoint.String(),
+					Amount:         int64(kid.amt),
+					MaturityHeight: maturityHeight,
+					BlocksTilMaturity: blocksTilMaturity(
+						maturityHeight,
+					),
+				})
+		}
+	}
+
+	return closingChans, nil
+}
+
 // ListChannels returns a description of all direct active, open channels the
-// node knows of.
-// TODO(roasbeef): add 'online' bit to response
+// node knows of. The channels may optionally be filtered by whether their
+// peer is online, and whether they're part of the public channel graph.
 func (r *rpcServer) ListChannels(ctx context.Context,
 	in *lnrpc.ListChannelsRequest) (*lnrpc.ListChannelsResponse, error) {
 
+	if in.ActiveOnly && in.InactiveOnly {
+		return nil, fmt.Errorf("either active_only or inactive_only " +
+			"may be set, but not both")
+	}
+	if in.PublicOnly && in.PrivateOnly {
+		return nil, fmt.Errorf("either public_only or private_only " +
+			"may be set, but not both")
+	}
+
 	resp := &lnrpc.ListChannelsResponse{}
 
 	graph := r.server.chanDB.ChannelGraph()
@@ -732,9 +1395,44 @@ func (r *rpcServer) ListChannels(ctx context.Context,
 		chanPoint := dbChannel.ChanID
 
 		// With the channel point known, retrieve the network channel
-		// ID from the database.
-		var chanID uint64
-		chanID, _ = graph.ChannelID(chanPoint)
+		// ID from the database. Channels which haven't been announced
+		// to the public graph are considered private.
+		chanID, err := graph.ChannelID(chanPoint)
+		isPrivate := err != nil
+
+		isActive := r.server.isPeerConnected(nodePub)
+
+		switch {
+		case in.ActiveOnly && !isActive:
+			continue
+		case in.InactiveOnly && isActive:
+			continue
+		case in.PublicOnly && isPrivate:
+			continue
+		case in.PrivateOnly && !isPrivate:
+			continue
+		}
+
+		// The commitment fee is the portion of the channel's capacity
+		// which isn't paid out by the outputs of our commitment.
+		commitFee := dbChannel.Capacity
+		if dbChannel.OurCommitTx != nil {
+			for _, txOut := range dbChannel.OurCommitTx.TxOut {
+				commitFee -= btcutil.Amount(txOut.Value)
+			}
+		}
+
+		// The lifetime and uptime of the channel are only known if
+		// it's being monitored, which is the case for all channels
+		// open since the server started.
+		var lifetime, uptime time.Duration
+		insights, err := r.server.chanEventStore.Insights(*chanPoint)
+		if err == nil {
+			lifetime = insights.Lifetime
+			uptime = insights.Uptime
+		}
+
+		balance := r.channelBalance(dbChannel)
 
 		channel := &lnrpc.ActiveChannel{
 			RemotePubkey:          nodeID,
@@ -747,9 +1445,33 @@ func (r *rpcServer) ListChannels(ctx context.Context,
 			TotalSatoshisReceived: int64(dbChannel.TotalSatoshisReceived),
 			NumUpdates:            dbChannel.NumUpdates,
 			PendingHtlcs:          make([]*lnrpc.HTLC, len(dbChannel.Htlcs)),
+			Active:                isActive,
+			CoopClosable:          isActive,
+			CommitFee:             int64(commitFee),
+			CommitWeight:          lnwallet.CommitWeight(len(dbChannel.Htlcs)),
+			Private:               isPrivate,
+			Lifetime:              int64(lifetime.Seconds()),
+			Uptime:                int64(uptime.Seconds()),
+			CommitmentType: lnrpc.CommitmentType(
+				dbChannel.CommitType,
+			),
+			UpgradePending: dbChannel.PendingCommitType !=
+				dbChannel.CommitType,
+			SpendableBalance:  int64(balance.Spendable),
+			ReceivableBalance: int64(balance.Receivable),
+			LocalChanReserve:  int64(balance.LocalReserve),
+			RemoteChanReserve: int64(balance.RemoteReserve),
+			FullChanId: lnwire.NewFullChanIDFromOutPoint(
+				chanPoint,
+			).String(),
+		}
+		if !isPrivate {
+			channel.ChanIdStr = lnwire.NewChanIDFromInt(chanID).String()
 		}
 
 		for i, htlc := range dbChannel.Htlcs {
+			channel.UnsettledBalance += int64(htlc.Amt)
+
 			channel.PendingHtlcs[i] = &lnrpc.HTLC{
 				Incoming:         htlc.Incoming,
 				Amount:           int64(htlc.Amt),
@@ -765,10 +1487,204 @@ func (r *rpcServer) ListChannels(ctx context.Context,
 	return resp, nil
 }
 
-// savePayment saves a successfully completed payment to the database for
-// historical record keeping.
-func (r *rpcServer) savePayment(route *routing.Route, amount btcutil.Amount,
-	rHash []byte) error {
+// GetChannelBalance breaks down the balance of a single channel, returning the
+// amounts we can actually send and receive over it, which account for the
+// channel reserve, the pending HTLCs, and the commitment fee.
+func (r *rpcServer) GetChannelBalance(ctx context.Context,
+	in *lnrpc.GetChannelBalanceRequest) (*lnrpc.GetChannelBalanceResponse,
+	error) {
+
+	if in.ChannelPoint == nil {
+		return nil, fmt.Errorf("channel_point must be set")
+	}
+
+	txid, err := chainhash.NewHash(in.ChannelPoint.FundingTxid)
+	if err != nil {
+		return nil, err
+	}
+	chanPoint := wire.NewOutPoint(txid, in.ChannelPoint.OutputIndex)
+
+	dbChan, err := r.fetchOpenChannel(*chanPoint)
+	if err != nil {
+		return nil, err
+	}
+	balance := r.channelBalance(dbChan)
+
+	return &lnrpc.GetChannelBalanceResponse{
+		Capacity:          int64(balance.Capacity),
+		LocalBalance:      int64(balance.LocalBalance),
+		RemoteBalance:     int64(balance.RemoteBalance),
+		LocalChanReserve:  int64(balance.LocalReserve),
+		RemoteChanReserve: int64(balance.RemoteReserve),
+		PendingOutgoing:   int64(balance.PendingOutgoing),
+		PendingIncoming:   int64(balance.PendingIncoming),
+		CommitFee:         int64(balance.CommitFee),
+		SpendableBalance:  int64(balance.Spendable),
+		ReceivableBalance: int64(balance.Receivable),
+	}, nil
+}
+
+// channelBalance breaks down the funds of the passed channel. The balance of
+// an active channel is taken from the current view of its state machine, so
+// the updates not yet committed are accounted for. Channels which aren't
+// active can't be updated, so their latest commitment is their current view.
+func (r *rpcServer) channelBalance(
+	dbChannel *channeldb.OpenChannel) *lnwallet.ChannelBalance {
+
+	nodePub := dbChannel.IdentityPub.SerializeCompressed()
+	if serverPeer, ok := r.server.findPeer(nodePub); ok {
+		balance, ok := serverPeer.ChannelBalance(*dbChannel.ChanID)
+		if ok {
+			return balance
+		}
+	}
+
+	return lnwallet.NewChannelBalance(dbChannel)
+}
+
+// AbandonChannel removes all state of a channel from the database, recording
+// it as closed, without broadcasting anything. As any funds within the channel
+// are forfeited unless recovered by other means, outside of dev builds the
+// caller must explicitly acknowledge this.
+func (r *rpcServer) AbandonChannel(ctx context.Context,
+	in *lnrpc.AbandonChannelRequest) (*lnrpc.AbandonChannelResponse, error) {
+
+	if !isDevBuild && !in.IKnowWhatIAmDoing {
+		return nil, fmt.Errorf("abandoning a channel forfeits its " +
+			"funds, i_know_what_i_am_doing must be set outside " +
+			"of dev builds")
+	}
+	if in.ChannelPoint == nil {
+		return nil, fmt.Errorf("channel_point must be set")
+	}
+
+	txid, err := chainhash.NewHash(in.ChannelPoint.FundingTxid)
+	if err != nil {
+		return nil, err
+	}
+	chanPoint := wire.NewOutPoint(txid, in.ChannelPoint.OutputIndex)
+
+	dbChan, err := r.fetchOpenChannel(*chanPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// The channel's state may only be removed while no link is using
+	// it, so the peer must be disconnected beforehand.
+	nodePub := dbChan.IdentityPub.SerializeCompressed()
+	if r.server.isPeerConnected(nodePub) {
+		return nil, fmt.Errorf("peer of ChannelPoint(%v) is online, "+
+			"disconnect it before abandoning the channel", chanPoint)
+	}
+
+	rpcsLog.Warnf("[abandonchannel] abandoning ChannelPoint(%v) without "+
+		"any on-chain action", chanPoint)
+
+	if err := dbChan.CloseChannel(); err != nil {
+		return nil, err
+	}
+
+	r.server.channelNotifier.notifyChannelEvent(
+		lnrpc.ChannelEventUpdate_CLOSED_CHANNEL, *chanPoint,
+		dbChan.IdentityPub,
+	)
+
+	// The breachArbiter no longer needs to watch the channel either.
+	select {
+	case r.server.breachArbiter.settledContracts <- chanPoint:
+	case <-r.quit:
+		return nil, fmt.Errorf("rpc server shutting down")
+	}
+
+	return &lnrpc.AbandonChannelResponse{}, nil
+}
+
+// SubscribeChannelEvents returns a uni-directional stream which sends an
+// update each time one of our channels is pending open, opened, closed, or
+// becomes active or inactive.
+func (r *rpcServer) SubscribeChannelEvents(req *lnrpc.ChannelEventSubscription,
+	updateStream lnrpc.Lightning_SubscribeChannelEventsServer) error {
+
+	eventClient := r.server.channelNotifier.SubscribeChannelEvents()
+	defer eventClient.Cancel()
+
+	for {
+		select {
+		case e := <-eventClient.Events:
+			event := e.(*channelEvent)
+			update := &lnrpc.ChannelEventUpdate{
+				Type: event.eventType,
+				ChannelPoint: &lnrpc.ChannelPoint{
+					FundingTxid: event.chanPoint.Hash[:],
+					OutputIndex: event.chanPoint.Index,
+				},
+				RemotePubkey: hex.EncodeToString(
+					event.remotePub.SerializeCompressed(),
+				),
+			}
+			if err := updateStream.Send(update); err != nil {
+				return err
+			}
+		case <-eventClient.Overflow:
+			return errEventQueueOverflow
+		case <-updateStream.Context().Done():
+			return nil
+		case <-r.quit:
+			return nil
+		}
+	}
+}
+
+// SubscribeHtlcEvents returns a uni-directional stream which sends an event
+// each time an HTLC traversing the switch is forwarded, fails to be
+// forwarded, is failed back, or is settled.
+func (r *rpcServer) SubscribeHtlcEvents(req *lnrpc.SubscribeHtlcEventsRequest,
+	updateStream lnrpc.Lightning_SubscribeHtlcEventsServer) error {
+
+	eventClient := r.server.htlcNotifier.SubscribeHtlcEvents()
+	defer eventClient.Cancel()
+
+	rpcChanPoint := func(chanPoint *wire.OutPoint) *lnrpc.ChannelPoint {
+		if chanPoint == nil {
+			return nil
+		}
+		return &lnrpc.ChannelPoint{
+			FundingTxid: chanPoint.Hash[:],
+			OutputIndex: chanPoint.Index,
+		}
+	}
+
+	for {
+		select {
+		case e := <-eventClient.Events:
+			event := e.(*htlcEvent)
+			update := &lnrpc.HtlcEvent{
+				EventType:            event.eventType,
+				IncomingChannelPoint: rpcChanPoint(event.incomingChan),
+				OutgoingChannelPoint: rpcChanPoint(event.outgoingChan),
+				PaymentHash:          hex.EncodeToString(event.payHash[:]),
+				AmtSat:               int64(event.amt),
+				FailureReason:        event.failure,
+				TimestampNs:          event.timestamp.UnixNano(),
+			}
+			if err := updateStream.Send(update); err != nil {
+				return err
+			}
+		case <-eventClient.Overflow:
+			return errEventQueueOverflow
+		case <-updateStream.Context().Done():
+			return nil
+		case <-r.quit:
+			return nil
+		}
+	}
+}
+
+// sendHTLC sends the HTLC packet of an attempt to settle a payment over the
+// passed route, returning a record of the attempt, along with the error the
+// HTLC failed with, if any.
+func (r *rpcServer) sendHTLC(htlcPkt *htlcPacket,
+	route *routing.Route) (*channeldb.PaymentAttempt, error) {
 
 	paymentPath := make([][33]byte, len(route.Hops))
 	for i, hop := range route.Hops {
@@ -776,6 +1692,50 @@ func (r *rpcServer) savePayment(route *routing.Route, amount btcutil.Amount,
 		copy(paymentPath[i][:], hopPub)
 	}
 
+	attempt := &channeldb.PaymentAttempt{
+		Fee:            route.TotalFees,
+		TimeLockLength: route.TotalTimeLock,
+		Path:           paymentPath,
+		AttemptTime:    time.Now(),
+	}
+
+	err := r.server.htlcSwitch.SendHTLC(htlcPkt)
+	attempt.ResolveTime = time.Now()
+	if err != nil {
+		attempt.Failure = htlcFailure(err)
+	}
+
+	return attempt, err
+}
+
+// htlcFailure describes the error the HTLC of an attempt to settle a payment
+// failed with. Cancellations are relayed back to us by the first hop, while
+// any other error arises locally. As cancellations don't identify the node
+// which originated them, their source is left unset.
+func htlcFailure(err error) *channeldb.HTLCFailure {
+	failure := &channeldb.HTLCFailure{
+		Message: err.Error(),
+	}
+	if reason, ok := err.(lnwire.CancelReason); ok {
+		failure.Cancelled = true
+		failure.Reason = reason
+	}
+
+	return failure
+}
+
+// savePayment saves the outcome of an attempt to settle a payment to the
+// database for historical record keeping. The payment succeeded unless the
+// attempt failed.
+func (r *rpcServer) savePayment(attempt *channeldb.PaymentAttempt,
+	amount btcutil.Amount, rHash []byte,
+	params channeldb.PaymentParams) error {
+
+	status := channeldb.StatusSucceeded
+	if attempt.Failure != nil {
+		status = channeldb.StatusFailed
+	}
+
 	payment := &channeldb.OutgoingPayment{
 		Invoice: channeldb.Invoice{
 			Terms: channeldb.ContractTerm{
@@ -783,13 +1743,53 @@ func (r *rpcServer) savePayment(route *routing.Route, amount btcutil.Amount,
 			},
 			CreationDate: time.Now(),
 		},
-		Path:           paymentPath,
-		Fee:            route.TotalFees,
-		TimeLockLength: route.TotalTimeLock,
+		Path:           attempt.Path,
+		Fee:            attempt.Fee,
+		TimeLockLength: attempt.TimeLockLength,
+		Status:         status,
+		Params:         params,
+		AttemptTime:    attempt.AttemptTime,
+		ResolveTime:    attempt.ResolveTime,
+		Failure:        attempt.Failure,
 	}
 	copy(payment.PaymentHash[:], rHash)
 
-	return r.server.chanDB.AddPayment(payment)
+	// A successful payment to ourselves settled one of our own invoices,
+	// so we'll link the two records by copying the memo, receipt and
+	// preimage of the invoice into the payment.
+	if len(attempt.Path) == 0 && status == channeldb.StatusSucceeded {
+		invoice, err := r.server.invoices.LookupInvoice(
+			chainhash.Hash(payment.PaymentHash),
+		)
+		if err != nil {
+			return err
+		}
+
+		payment.Memo = invoice.Memo
+		payment.Receipt = invoice.Receipt
+		payment.Terms.PaymentPreimage = invoice.Terms.PaymentPreimage
+	}
+
+	if err := r.server.chanDB.AddPayment(payment); err != nil {
+		return err
+	}
+
+	// Let any clients tracking the payment know of its new attempt.
+	r.server.paymentNotifier.notifyPaymentUpdate(payment.PaymentHash)
+
+	return nil
+}
+
+// saveFailedPayment records a failed attempt to settle a payment. Failing to
+// do so is only logged, so the failure of the payment itself is reported.
+func (r *rpcServer) saveFailedPayment(attempt *channeldb.PaymentAttempt,
+	amount btcutil.Amount, rHash []byte, params channeldb.PaymentParams) {
+
+	err := r.savePayment(attempt, amount, rHash, params)
+	if err != nil {
+		rpcsLog.Errorf("Unable to save failed payment(%x): %v", rHash,
+			err)
+	}
 }
 
 // SendPayment dispatches a bi-directional streaming RPC for sending payments
@@ -829,7 +1829,7 @@ func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer)
 				// attempt to decode it, populating the
 				// nextPayment accordingly.
 				if nextPayment.PaymentRequest != "" {
-					payReq, err := zpay32.Decode(nextPayment.PaymentRequest)
+					payReq, err := r.payReqCache.Decode(nextPayment.PaymentRequest)
 					if err != nil {
 						errChan <- err
 						return
@@ -861,6 +1861,12 @@ func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer)
 				return err
 
 			}
+
+			params, err := paymentParams(nextPayment)
+			if err != nil {
+				return err
+			}
+
 			// If we're in debug HTLC mode, then all outgoing
 			// HTLC's will pay to the same debug rHash. Otherwise,
 			// we pay to the rHash specified within the RPC
@@ -872,11 +1878,7 @@ func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer)
 				copy(rHash[:], nextPayment.PaymentHash)
 			}
 
-			// Construct and HTLC packet which a payment route (if
-			// one is found) to the destination using a Sphinx
-			// onion packet to encode the route.
-			htlcPkt, route, err := r.constructPaymentRoute(destNode, amt,
-				rHash)
+			feeLimitMsat, err := paymentFeeLimit(nextPayment, amt)
 			if err != nil {
 				return err
 			}
@@ -888,23 +1890,17 @@ func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer)
 			// TODO(roasbeef): semaphore to limit num outstanding
 			// goroutines.
 			go func() {
-				// Finally, send this next packet to the
-				// routing layer in order to complete the next
-				// payment.
-				if err := r.server.htlcSwitch.SendHTLC(htlcPkt); err != nil {
-					errChan <- err
-					return
-				}
-
-				// Save the completed payment to the database
-				// for record keeping purposes.
-				if err := r.savePayment(route, amt, rHash[:]); err != nil {
+				// Finally, dispatch the payment, recording
+				// its outcome within the database for record
+				// keeping purposes.
+				reason, err := r.dispatchPayment(destNode, amt,
+					rHash, feeLimitMsat, params)
+				resp, err := sendResponse(reason, err)
+				if err != nil {
 					errChan <- err
 					return
 				}
 
-				// TODO(roasbeef): proper responses
-				resp := &lnrpc.SendResponse{}
 				if err := paymentStream.Send(resp); err != nil {
 					errChan <- err
 					return
@@ -932,7 +1928,7 @@ func (r *rpcServer) SendPaymentSync(ctx context.Context,
 	// If the proto request has an encoded payment request, then we we'll
 	// use that solely to dipatch the payment.
 	if nextPayment.PaymentRequest != "" {
-		payReq, err := zpay32.Decode(nextPayment.PaymentRequest)
+		payReq, err := r.payReqCache.Decode(nextPayment.PaymentRequest)
 		if err != nil {
 			return nil, err
 		}
@@ -969,133 +1965,686 @@ func (r *rpcServer) SendPaymentSync(ctx context.Context,
 		amt = btcutil.Amount(nextPayment.Amt)
 	}
 
-	// Construct and HTLC packet which a payment route (if
-	// one is found) to the destination using a Sphinx
-	// onoin packet to encode the route.
-	htlcPkt, route, err := r.constructPaymentRoute(destPub, amt, rHash)
+	params, err := paymentParams(nextPayment)
 	if err != nil {
 		return nil, err
 	}
 
-	// Next, send this next packet to the routing layer in order to
-	// complete the next payment.
-	if err := r.server.htlcSwitch.SendHTLC(htlcPkt); err != nil {
-		return nil, err
-	}
-
-	// With the payment completed successfully, we now ave the details of
-	// the completed payment to the databse for historical record keeping.
-	if err := r.savePayment(route, amt, rHash[:]); err != nil {
+	// With the payment conditions known, we dispatch the payment, saving
+	// the details of its outcome to the database for historical record
+	// keeping.
+	feeLimitMsat, err := paymentFeeLimit(nextPayment, amt)
+	if err != nil {
 		return nil, err
 	}
+	reason, err := r.dispatchPayment(destPub, amt, rHash, feeLimitMsat,
+		params)
 
-	return &lnrpc.SendResponse{}, nil
+	return sendResponse(reason, err)
 }
 
-// constructPaymentRoute attempts to construct a complete HTLC packet which
-// encapsulates a Sphinx onion packet that encodes the end-to-end route any
-// payment instructions necessary to complete an HTLC. If a route is unable to
-// be located, then an error is returned indicating as much.
-func (r *rpcServer) constructPaymentRoute(destNode *btcec.PublicKey,
-	amt btcutil.Amount, rHash [32]byte) (*htlcPacket, *routing.Route, error) {
-
-	const queryTimeout = time.Duration(time.Second * 10)
+// sendResponse returns the response to a send request, given the reason the
+// payment was abandoned for, and the error it failed with. The failure of an
+// abandoned payment is reported within the response, while any other error
+// fails the request itself.
+func sendResponse(reason channeldb.FailureReason,
+	err error) (*lnrpc.SendResponse, error) {
 
-	// Query the channel router for a potential path to the destination
-	// node that can support our payment amount. If a path is ultimately
-	// unavailable, then an error will be returned.
-	route, err := r.server.chanRouter.FindRoute(destNode, amt)
-	if err != nil {
-		return nil, nil, err
-	}
-	rpcsLog.Tracef("[sendpayment] selected route: %#v", route)
+	switch {
+	case reason != channeldb.FailureReasonNone:
+		return &lnrpc.SendResponse{
+			PaymentError:  err.Error(),
+			FailureReason: lnrpc.PaymentFailureReason(reason),
+		}, nil
 
-	// Generate the raw encoded sphinx packet to be included along with the
-	// HTLC add message.  We snip off the first hop from the path as within
-	// the routing table's star graph, we're always the first hop.
-	sphinxPacket, err := generateSphinxPacket(route, rHash[:])
-	if err != nil {
-		return nil, nil, err
-	}
+	case err != nil:
+		return nil, err
 
-	// Craft an HTLC packet to send to the routing sub-system. The
-	// meta-data within this packet will be used to route the payment
-	// through the network.
-	htlcAdd := &lnwire.HTLCAddRequest{
-		Amount:           route.TotalAmount,
-		RedemptionHashes: [][32]byte{rHash},
-		OnionBlob:        sphinxPacket,
+	default:
+		return &lnrpc.SendResponse{}, nil
 	}
-
-	firstHopPub := route.Hops[0].Channel.Node.PubKey.SerializeCompressed()
-	destInterface := chainhash.Hash(fastsha256.Sum256(firstHopPub))
-
-	return &htlcPacket{
-		dest: destInterface,
-		msg:  htlcAdd,
-	}, route, nil
 }
 
-// generateSphinxPacket generates then encodes a sphinx packet which encodes
-// the onion route specified by the passed layer 3 route. The blob returned
-// from this function can immediately be included within an HTLC add packet to
-// be sent to the first hop within the route.
-func generateSphinxPacket(route *routing.Route, paymentHash []byte) ([]byte, error) {
-	// First obtain all the public keys along the route which are contained
-	// in each hop.
-	nodes := make([]*btcec.PublicKey, len(route.Hops))
-	for i, hop := range route.Hops {
-		// We create a new instance of the public key to avoid possibly
-		// mutating the curve parameters, which are unset in a higher
-		// level in order to avoid spamming the logs.
-		pub := btcec.PublicKey{
-			btcec.S256(),
-			hop.Channel.Node.PubKey.X,
-			hop.Channel.Node.PubKey.Y,
-		}
-		nodes[i] = &pub
+// SendToRouteV2 makes a single attempt to settle a payment over the passed
+// route, such as one returned by QueryRoute, returning the outcome of the
+// attempt. A failed attempt isn't retried, nor reported as an error of the
+// RPC, leaving the caller in control of retrying. Each attempt is recorded
+// within the payments store, folded into the payment with the same payment
+// hash as with SendPayment.
+func (r *rpcServer) SendToRouteV2(ctx context.Context,
+	req *lnrpc.SendToRouteRequest) (*lnrpc.HTLCAttempt, error) {
+
+	if len(req.PaymentHash) != 32 {
+		return nil, fmt.Errorf("payment hash must be exactly 32 "+
+			"bytes, is instead %v", len(req.PaymentHash))
 	}
+	var rHash [32]byte
+	copy(rHash[:], req.PaymentHash)
 
-	// Next we generate the per-hop payload which gives each node within
-	// the route the necessary information (fees, CLTV value, etc) to
-	// properly forward the payment.
-	// TODO(roasbeef): properly set CLTV value, payment amount, and chain
-	// within hop paylods.
-	var hopPayloads [][]byte
-	for i := 0; i < len(route.Hops); i++ {
-		payload := bytes.Repeat([]byte{byte('A' + i)},
-			sphinx.HopPayloadSize)
-		hopPayloads = append(hopPayloads, payload)
+	route, err := r.unmarshalRoute(req.Route)
+	if err != nil {
+		return nil, err
 	}
 
-	sessionKey, err := btcec.NewPrivateKey(btcec.S256())
+	htlcPkt, err := r.newPaymentPacket(route, rHash)
 	if err != nil {
 		return nil, err
 	}
 
-	// Next generate the onion routing packet which allows
-	// us to perform privacy preserving source routing
-	// across the network.
-	sphinxPacket, err := sphinx.NewOnionPacket(nodes, sessionKey,
-		hopPayloads, paymentHash)
+	rpcsLog.Debugf("[sendtoroute] sending HTLC with payment hash %x "+
+		"over route of %v hops", rHash[:], len(route.Hops))
+
+	attempt, err := r.sendHTLC(htlcPkt, route)
 	if err != nil {
-		return nil, err
+		rpcsLog.Debugf("[sendtoroute] attempt to settle payment(%x) "+
+			"failed: %v", rHash[:], err)
 	}
 
-	// Finally, encode Sphinx packet using it's wire representation to be
-	// included within the HTLC add packet.
-	var onionBlob bytes.Buffer
-	if err := sphinxPacket.Encode(&onionBlob); err != nil {
+	// The amount paid to the destination excludes the fees of the route.
+	amt := route.TotalAmount - route.TotalFees
+	err = r.savePayment(attempt, amt, rHash[:], channeldb.PaymentParams{})
+	if err != nil {
 		return nil, err
 	}
 
-	rpcsLog.Tracef("[sendpayment] generated sphinx packet: %v",
-		newLogClosure(func() string {
-			// We unset the internal curve here in order to keep
-			// the logs from getting noisy.
-			sphinxPacket.Header.EphemeralKey.Curve = nil
-			return spew.Sdump(sphinxPacket)
-		}))
+	status := channeldb.StatusSucceeded
+	if attempt.Failure != nil {
+		status = channeldb.StatusFailed
+	}
+
+	return marshalHtlcAttempt(attempt, status), nil
+}
+
+// TrackPayment returns a uni-directional stream which sends the current state
+// of the payment with the passed payment hash, followed by an update each
+// time an attempt to settle it is recorded, until it either succeeds or is
+// abandoned.
+func (r *rpcServer) TrackPayment(req *lnrpc.TrackPaymentRequest,
+	updateStream lnrpc.Lightning_TrackPaymentServer) error {
+
+	if len(req.PaymentHash) != 32 {
+		return fmt.Errorf("payment hash must be exactly 32 bytes, is "+
+			"instead %v", len(req.PaymentHash))
+	}
+	var rHash [32]byte
+	copy(rHash[:], req.PaymentHash)
+
+	// The client is subscribed before the payment is looked up, so no
+	// update to it can slip by in between.
+	updates := r.server.paymentNotifier.SubscribePaymentUpdates()
+	defer updates.Cancel()
+
+	for {
+		payment, err := r.server.chanDB.FetchPayment(rHash)
+		if err != nil {
+			return err
+		}
+		if err := updateStream.Send(marshalPayment(payment)); err != nil {
+			return err
+		}
+
+		// Once the payment has succeeded, or has been abandoned, it
+		// won't be updated any further.
+		if payment.Status == channeldb.StatusSucceeded ||
+			payment.FailureReason != channeldb.FailureReasonNone {
+
+			return nil
+		}
+
+		// Otherwise, we'll wait for the payment to be updated.
+		var updated bool
+		for !updated {
+			select {
+			case e := <-updates.Events:
+				updated = e.([32]byte) == rHash
+			case <-updates.Overflow:
+				return errEventQueueOverflow
+			case <-updateStream.Context().Done():
+				return nil
+			case <-r.quit:
+				return nil
+			}
+		}
+	}
+}
+
+// unmarshalRoute converts the passed RPC route into the route the HTLC of a
+// payment is sent over, looking up the node each of its hops leads to within
+// the channel graph. The route's totals must be consistent with its final hop,
+// and its time lock must be set.
+func (r *rpcServer) unmarshalRoute(rpcRoute *lnrpc.Route) (*routing.Route,
+	error) {
+
+	if rpcRoute == nil || len(rpcRoute.Hops) == 0 {
+		return nil, fmt.Errorf("route must contain at least one hop")
+	}
+
+	// Once the fees are deducted, the amount extended to the first hop
+	// must be the amount forwarded by the last hop to the destination.
+	lastHop := rpcRoute.Hops[len(rpcRoute.Hops)-1]
+	if rpcRoute.TotalAmt-rpcRoute.TotalFees != lastHop.AmtToForward {
+		return nil, fmt.Errorf("total amount of %v less total fees "+
+			"of %v doesn't match amount of %v paid by last hop",
+			rpcRoute.TotalAmt, rpcRoute.TotalFees,
+			lastHop.AmtToForward)
+	}
+	if rpcRoute.TotalTimeLock == 0 {
+		return nil, fmt.Errorf("route must have a total time lock")
+	}
+
+	route := &routing.Route{
+		TotalTimeLock: rpcRoute.TotalTimeLock,
+		TotalFees:     btcutil.Amount(rpcRoute.TotalFees),
+		TotalAmount:   btcutil.Amount(rpcRoute.TotalAmt),
+		Hops:          make([]*routing.Hop, len(rpcRoute.Hops)),
+	}
+
+	// Starting from ourselves, each hop's channel must lead on from the
+	// node the previous hop led to.
+	graph := r.server.chanDB.ChannelGraph()
+	prevNode := r.server.nodeKey.PubKey()
+	for i, hop := range rpcRoute.Hops {
+		node1, node2, err := graph.FetchChannelNodes(hop.ChanId)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find channel %v of "+
+				"hop %v: %v", hop.ChanId, i, err)
+		}
+
+		var nextNode *btcec.PublicKey
+		switch {
+		case node1.IsEqual(prevNode):
+			nextNode = node2
+		case node2.IsEqual(prevNode):
+			nextNode = node1
+		default:
+			return nil, fmt.Errorf("channel %v of hop %v doesn't "+
+				"continue the route", hop.ChanId, i)
+		}
+
+		route.Hops[i] = &routing.Hop{
+			Channel: &channeldb.ChannelEdge{
+				ChannelID: hop.ChanId,
+				Capacity:  btcutil.Amount(hop.ChanCapacity),
+				Node: &channeldb.LightningNode{
+					PubKey: nextNode,
+				},
+			},
+			AmtToForward: btcutil.Amount(hop.AmtToForward),
+			Fee:          btcutil.Amount(hop.Fee),
+		}
+		prevNode = nextNode
+	}
+
+	return route, nil
+}
+
+// htlcFailureCodes maps the reasons an HTLC may be cancelled for to their RPC
+// failure codes.
+var htlcFailureCodes = map[lnwire.CancelReason]lnrpc.HTLCFailureCode{
+	lnwire.InsufficientCapacity:    lnrpc.HTLCFailureCode_INSUFFICIENT_CAPACITY,
+	lnwire.UpstreamTimeout:         lnrpc.HTLCFailureCode_UPSTREAM_TIMEOUT,
+	lnwire.UnknownPaymentHash:      lnrpc.HTLCFailureCode_UNKNOWN_PAYMENT_HASH,
+	lnwire.UnknownDestination:      lnrpc.HTLCFailureCode_UNKNOWN_DESTINATION,
+	lnwire.SphinxParseError:        lnrpc.HTLCFailureCode_SPHINX_PARSE_ERROR,
+	lnwire.IncorrectValue:          lnrpc.HTLCFailureCode_INCORRECT_VALUE,
+	lnwire.ExpiryTooSoon:           lnrpc.HTLCFailureCode_EXPIRY_TOO_SOON,
+	lnwire.TemporaryChannelFailure: lnrpc.HTLCFailureCode_TEMPORARY_CHANNEL_FAILURE,
+}
+
+// marshalHtlcAttempt converts the passed record of an attempt to settle a
+// payment, which had the passed outcome, into its RPC representation.
+func marshalHtlcAttempt(attempt *channeldb.PaymentAttempt,
+	status channeldb.PaymentStatus) *lnrpc.HTLCAttempt {
+
+	path := make([]string, len(attempt.Path))
+	for i, hop := range attempt.Path {
+		path[i] = hex.EncodeToString(hop[:])
+	}
+
+	rpcAttempt := &lnrpc.HTLCAttempt{
+		Status:        lnrpc.PaymentStatus(status),
+		Path:          path,
+		Fee:           int64(attempt.Fee),
+		TotalTimeLock: attempt.TimeLockLength,
+	}
+	if !attempt.AttemptTime.IsZero() {
+		rpcAttempt.AttemptTimeNs = attempt.AttemptTime.UnixNano()
+	}
+	if !attempt.ResolveTime.IsZero() {
+		rpcAttempt.ResolveTimeNs = attempt.ResolveTime.UnixNano()
+	}
+
+	if failure := attempt.Failure; failure != nil {
+		rpcAttempt.Failure = &lnrpc.HTLCFailure{
+			FailureSourceIndex: failure.SourceIndex,
+			Message:            failure.Message,
+		}
+		if failure.Cancelled {
+			rpcAttempt.Failure.Code = htlcFailureCodes[failure.Reason]
+		}
+	}
+
+	return rpcAttempt
+}
+
+// paymentRetryInterval is the interval at which failed attempts to settle a
+// payment carrying a timeout are retried.
+const paymentRetryInterval = time.Second
+
+// paymentParams returns the limits placed upon a payment by the passed send
+// request.
+func paymentParams(req *lnrpc.SendRequest) (channeldb.PaymentParams, error) {
+	if req.TimeoutSeconds < 0 {
+		return channeldb.PaymentParams{}, fmt.Errorf("payment timeout "+
+			"must be positive, is instead %v", req.TimeoutSeconds)
+	}
+
+	// Payments are only ever sent over a single route, as multi-path
+	// payments aren't supported yet.
+	if req.MaxParts > 1 {
+		return channeldb.PaymentParams{}, fmt.Errorf("payment can't be "+
+			"split into %v parts, as multi-path payments aren't "+
+			"supported", req.MaxParts)
+	}
+
+	return channeldb.PaymentParams{
+		Timeout:   time.Duration(req.TimeoutSeconds) * time.Second,
+		CltvLimit: req.CltvLimit,
+		MaxParts:  req.MaxParts,
+	}, nil
+}
+
+// paymentFeeLimit returns the maximum total routing fee in millisatoshis of a
+// payment of the passed amount made by the passed send request. The request
+// may limit the fee to a fixed amount in either satoshis or millisatoshis, or
+// to a percentage of the amount of the payment. Otherwise, the default fee
+// limit of the daemon applies. Percentage-based limits are raised to the fee
+// limit floor, so that tiny payments can still be routed. A limit of zero
+// means the fee isn't limited.
+//
+// NOTE: The limit bounds the fees of all parts of a payment combined, rather
+// than those of each part.
+func paymentFeeLimit(req *lnrpc.SendRequest,
+	amt btcutil.Amount) (int64, error) {
+
+	var numLimits int
+	for _, limit := range []int64{
+		req.FeeLimit, req.FeeLimitMsat, req.FeeLimitPercent,
+	} {
+		if limit < 0 {
+			return 0, fmt.Errorf("fee limit must not be negative")
+		}
+		if limit != 0 {
+			numLimits++
+		}
+	}
+
+	switch {
+	case numLimits > 1:
+		return 0, fmt.Errorf("only one of fee_limit, fee_limit_msat " +
+			"and fee_limit_percent may be set")
+
+	case req.FeeLimit != 0:
+		return req.FeeLimit * 1000, nil
+
+	case req.FeeLimitMsat != 0:
+		return req.FeeLimitMsat, nil
+
+	case req.FeeLimitPercent != 0:
+		return percentFeeLimit(amt, req.FeeLimitPercent), nil
+
+	case cfg.FeeLimit != 0:
+		return cfg.FeeLimit * 1000, nil
+
+	case cfg.FeeLimitPercent != 0:
+		return percentFeeLimit(amt, cfg.FeeLimitPercent), nil
+
+	default:
+		return 0, nil
+	}
+}
+
+// percentFeeLimit returns the passed percentage of the passed payment amount
+// in millisatoshis, raised to the fee limit floor. As the percentage is
+// computed in millisatoshis, the limits of small payments aren't truncated to
+// a whole satoshi.
+func percentFeeLimit(amt btcutil.Amount, percent int64) int64 {
+	feeLimitMsat := int64(amt) * 1000 * percent / 100
+	if feeLimitMsat < cfg.FeeLimitFloor*1000 {
+		feeLimitMsat = cfg.FeeLimitFloor * 1000
+	}
+
+	return feeLimitMsat
+}
+
+// dispatchPayment attempts to settle a payment of the passed amount to the
+// destination node, recording the outcome of each attempt. If the payment
+// carries a timeout, failed attempts are retried until it expires, after
+// which no further attempts are made. An attempt in flight once the timeout
+// expires is still allowed to resolve. The channels an attempt may have failed
+// over are avoided by the routes of subsequent attempts, and the lack of a
+// route is retried as well, as the graph may change in the meantime. If the
+// payment is abandoned, the reason it was abandoned for is returned along
+// with the error it failed with.
+func (r *rpcServer) dispatchPayment(destNode *btcec.PublicKey,
+	amt btcutil.Amount, rHash [32]byte, feeLimitMsat int64,
+	params channeldb.PaymentParams) (channeldb.FailureReason, error) {
+
+	var deadline time.Time
+	if params.Timeout != 0 {
+		deadline = time.Now().Add(params.Timeout)
+	}
+
+	restrictions := &routing.RouteRestrictions{
+		FeeLimitMsat: feeLimitMsat,
+		CltvLimit:    params.CltvLimit,
+		IgnoredEdges: make(map[uint64]struct{}),
+	}
+
+	for {
+		// Construct and HTLC packet which a payment route (if one is
+		// found) to the destination using a Sphinx onion packet to
+		// encode the route.
+		htlcPkt, route, err := r.constructPaymentRoute(destNode, amt,
+			rHash, restrictions)
+		switch {
+		case err != nil && !isNoRouteError(err):
+			reason := channeldb.FailureReasonError
+			r.failPayment(rHash, amt, params, reason, err)
+			return reason, err
+
+		// If no route is left once the channels prior attempts failed
+		// over are avoided, they're reconsidered from the next
+		// attempt on, as their failures may have been temporary.
+		case err != nil:
+			restrictions.IgnoredEdges = make(map[uint64]struct{})
+
+		// Otherwise, send this packet to the routing layer in order
+		// to complete the payment.
+		default:
+			var attempt *channeldb.PaymentAttempt
+			attempt, err = r.sendHTLC(htlcPkt, route)
+			if err == nil {
+				err := r.savePayment(attempt, amt, rHash[:],
+					params)
+				return channeldb.FailureReasonNone, err
+			}
+			r.saveFailedPayment(attempt, amt, rHash[:], params)
+
+			for _, chanID := range failedEdges(route, err) {
+				restrictions.IgnoredEdges[chanID] = struct{}{}
+			}
+		}
+
+		// Without a timeout, or if the destination rejected the
+		// payment itself, we abandon the payment straight away. Once
+		// the timeout of the payment has expired, or would do so
+		// before the next attempt, we abandon it as well.
+		reason := paymentFailureReason(err)
+		switch {
+		case deadline.IsZero(),
+			reason == channeldb.FailureReasonIncorrectPaymentDetails:
+
+			r.failPayment(rHash, amt, params, reason, err)
+			return reason, err
+
+		case time.Now().Add(paymentRetryInterval).After(deadline):
+			reason := channeldb.FailureReasonTimeout
+			r.failPayment(rHash, amt, params, reason, err)
+			return reason, err
+		}
+
+		rpcsLog.Debugf("Attempt to settle payment(%x) failed, "+
+			"retrying: %v", rHash[:], err)
+
+		select {
+		case <-time.After(paymentRetryInterval):
+		case <-r.quit:
+			reason := channeldb.FailureReasonCanceled
+			r.failPayment(rHash, amt, params, reason, err)
+			return reason, err
+		}
+	}
+}
+
+// failedEdges returns the IDs of the channels of the passed route which the
+// attempt to send a payment over it, having failed with the passed error, may
+// have failed over. Errors arising locally concern our own channel, the first
+// of the route. Cancellations relayed back to us don't identify the node which
+// failed the HTLC, but as it was forwarded over our own channel, any of the
+// channels past it may be at fault.
+func failedEdges(route *routing.Route, err error) []uint64 {
+	// Payments to ourselves aren't routed over any channel.
+	if len(route.Hops) == 0 {
+		return nil
+	}
+
+	hops := route.Hops[:1]
+	if _, ok := err.(lnwire.CancelReason); ok {
+		hops = route.Hops[1:]
+	}
+
+	chanIDs := make([]uint64, 0, len(hops))
+	for _, hop := range hops {
+		chanIDs = append(chanIDs, hop.Channel.ChannelID)
+	}
+
+	return chanIDs
+}
+
+// paymentFailureReason classifies the error the final attempt to settle a
+// payment failed with, once the payment is abandoned.
+func paymentFailureReason(err error) channeldb.FailureReason {
+	if isNoRouteError(err) {
+		return channeldb.FailureReasonNoRoute
+	}
+
+	switch err {
+	case errInsufficientBandwidth:
+		return channeldb.FailureReasonInsufficientBalance
+
+	case lnwire.CancelReason(lnwire.UnknownPaymentHash),
+		lnwire.CancelReason(lnwire.IncorrectValue):
+		return channeldb.FailureReasonIncorrectPaymentDetails
+
+	default:
+		return channeldb.FailureReasonError
+	}
+}
+
+// isNoRouteError returns whether the passed error, returned when constructing
+// the route of a payment, denotes that no suitable route was found.
+func isNoRouteError(err error) bool {
+	switch err {
+	case routing.ErrNoPathFound, routing.ErrInsufficientCapacity,
+		routing.ErrMaxHopsExceeded, routing.ErrTargetNotInNetwork,
+		routing.ErrFeeLimitExceeded, routing.ErrCltvLimitExceeded:
+		return true
+
+	default:
+		return false
+	}
+}
+
+// failPayment records the reason the payment with the passed payment hash,
+// which failed with the passed error, was abandoned. Payments for which no
+// HTLC was ever sent, such as those for which no route was found at all, are
+// recorded first, along with the error they failed with, so they're listed
+// like any other failed payment. Failing to record the reason is only logged,
+// so the failure of the payment itself is reported.
+func (r *rpcServer) failPayment(rHash [32]byte, amt btcutil.Amount,
+	params channeldb.PaymentParams, reason channeldb.FailureReason,
+	payErr error) {
+
+	err := r.server.chanDB.FailPayment(rHash, reason)
+	if err == channeldb.ErrPaymentNotFound {
+		attempt := &channeldb.PaymentAttempt{
+			Failure: htlcFailure(payErr),
+		}
+		err = r.savePayment(attempt, amt, rHash[:], params)
+		if err == nil {
+			err = r.server.chanDB.FailPayment(rHash, reason)
+		}
+	}
+	if err != nil {
+		rpcsLog.Errorf("unable to record failure reason of "+
+			"payment(%x): %v", rHash[:], err)
+		return
+	}
+
+	r.server.paymentNotifier.notifyPaymentUpdate(rHash)
+}
+
+// constructPaymentRoute attempts to construct a complete HTLC packet which
+// encapsulates a Sphinx onion packet that encodes the end-to-end route any
+// payment instructions necessary to complete an HTLC. If a route is unable to
+// be located which satisfies the passed restrictions, then an error is returned
+// indicating as much.
+func (r *rpcServer) constructPaymentRoute(destNode *btcec.PublicKey,
+	amt btcutil.Amount, rHash [32]byte,
+	restrictions *routing.RouteRestrictions) (*htlcPacket, *routing.Route,
+	error) {
+
+	const queryTimeout = time.Duration(time.Second * 10)
+
+	// Payments to ourselves aren't routed through the network, instead
+	// the switch settles them directly against the invoice they pay.
+	if destNode.IsEqual(r.server.nodeKey.PubKey()) {
+		if cfg.NoSelfPayments {
+			return nil, nil, fmt.Errorf("self payments are disabled")
+		}
+
+		htlcAdd := &lnwire.HTLCAddRequest{
+			Amount:           amt,
+			RedemptionHashes: [][32]byte{rHash},
+		}
+		return &htlcPacket{
+			msg:         htlcAdd,
+			selfPayment: true,
+		}, &routing.Route{TotalAmount: amt}, nil
+	}
+
+	// Query the channel router for a potential path to the destination
+	// node that can support our payment amount within the restrictions of
+	// the caller, if any. If a path is ultimately unavailable, then an
+	// error will be returned.
+	route, err := r.server.chanRouter.FindRoute(destNode, amt, restrictions)
+	if err != nil {
+		return nil, nil, err
+	}
+	rpcsLog.Tracef("[sendpayment] selected route: %#v", route)
+
+	htlcPkt, err := r.newPaymentPacket(route, rHash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return htlcPkt, route, nil
+}
+
+// newPaymentPacket crafts the HTLC packet of a payment with the passed
+// payment hash, to be routed over the passed route by encoding it within a
+// Sphinx onion packet.
+func (r *rpcServer) newPaymentPacket(route *routing.Route,
+	rHash [32]byte) (*htlcPacket, error) {
+
+	// Generate the raw encoded sphinx packet to be included along with the
+	// HTLC add message.  We snip off the first hop from the path as within
+	// the routing table's star graph, we're always the first hop.
+	sphinxPacket, err := generateSphinxPacket(route, rHash[:])
+	if err != nil {
+		return nil, err
+	}
+
+	// The absolute expiry of the HTLC we extend to the first hop is the
+	// current height plus the cumulative time lock of the route, leaving
+	// each hop enough time to enforce its advertised CLTV delta.
+	_, bestHeight, err := r.server.bio.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// Craft an HTLC packet to send to the routing sub-system. The
+	// meta-data within this packet will be used to route the payment
+	// through the network. As we're the origin of the payment, we always
+	// endorse it.
+	htlcAdd := &lnwire.HTLCAddRequest{
+		Expiry:           uint32(bestHeight) + route.TotalTimeLock,
+		Amount:           route.TotalAmount,
+		RedemptionHashes: [][32]byte{rHash},
+		OnionBlob:        sphinxPacket,
+		Endorsed:         true,
+	}
+
+	firstHopPub := route.Hops[0].Channel.Node.PubKey.SerializeCompressed()
+	destInterface := chainhash.Hash(fastsha256.Sum256(firstHopPub))
+
+	return &htlcPacket{
+		dest: destInterface,
+		msg:  htlcAdd,
+	}, nil
+}
+
+// generateSphinxPacket generates then encodes a sphinx packet which encodes
+// the onion route specified by the passed layer 3 route. The blob returned
+// from this function can immediately be included within an HTLC add packet to
+// be sent to the first hop within the route.
+func generateSphinxPacket(route *routing.Route, paymentHash []byte) ([]byte, error) {
+	// First obtain all the public keys along the route which are contained
+	// in each hop.
+	nodes := make([]*btcec.PublicKey, len(route.Hops))
+	for i, hop := range route.Hops {
+		// We create a new instance of the public key to avoid possibly
+		// mutating the curve parameters, which are unset in a higher
+		// level in order to avoid spamming the logs.
+		pub := btcec.PublicKey{
+			btcec.S256(),
+			hop.Channel.Node.PubKey.X,
+			hop.Channel.Node.PubKey.Y,
+		}
+		nodes[i] = &pub
+	}
+
+	// Next we generate the per-hop payload which gives each node within
+	// the route the necessary information (fees, CLTV value, etc) to
+	// properly forward the payment.
+	// TODO(roasbeef): properly set CLTV value, payment amount, and chain
+	// within hop paylods.
+	var hopPayloads [][]byte
+	for i := 0; i < len(route.Hops); i++ {
+		payload := bytes.Repeat([]byte{byte('A' + i)},
+			sphinx.HopPayloadSize)
+		hopPayloads = append(hopPayloads, payload)
+	}
+
+	sessionKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+
+	// Next generate the onion routing packet which allows
+	// us to perform privacy preserving source routing
+	// across the network.
+	sphinxPacket, err := sphinx.NewOnionPacket(nodes, sessionKey,
+		hopPayloads, paymentHash)
+	if err != nil {
+		return nil, err
+	}
+
+	// Finally, encode Sphinx packet using it's wire representation to be
+	// included within the HTLC add packet.
+	var onionBlob bytes.Buffer
+	if err := sphinxPacket.Encode(&onionBlob); err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Tracef("[sendpayment] generated sphinx packet: %v",
+		newLogClosure(func() string {
+			// We unset the internal curve here in order to keep
+			// the logs from getting noisy.
+			sphinxPacket.Header.EphemeralKey.Curve = nil
+			return spew.Sdump(sphinxPacket)
+		}))
 
 	return onionBlob.Bytes(), nil
 }
@@ -1144,6 +2693,12 @@ func (r *rpcServer) AddInvoice(ctx context.Context,
 		return nil, fmt.Errorf("zero value invoices are disallowed")
 	}
 
+	// An expiry of zero signals that the invoice never expires.
+	if invoice.Expiry < 0 {
+		return nil, fmt.Errorf("negative invoice expiry: %v",
+			invoice.Expiry)
+	}
+
 	i := &channeldb.Invoice{
 		CreationDate: time.Now(),
 		Memo:         []byte(invoice.Memo),
@@ -1151,6 +2706,7 @@ func (r *rpcServer) AddInvoice(ctx context.Context,
 		Terms: channeldb.ContractTerm{
 			Value: btcutil.Amount(invoice.Value),
 		},
+		Expiry: time.Duration(invoice.Expiry) * time.Second,
 	}
 	copy(i.Terms.PaymentPreimage[:], paymentPreimage[:])
 
@@ -1168,10 +2724,16 @@ func (r *rpcServer) AddInvoice(ctx context.Context,
 	// be used by clients to query for the state of a particular invoice.
 	rHash := fastsha256.Sum256(paymentPreimage[:])
 
+	// If the invoice expires, then it'll be canceled once it does, unless
+	// it's been paid by then.
+	if expiry, ok := i.ExpiryTime(); ok {
+		r.server.invoiceExpiries.addInvoice(rHash, expiry)
+	}
+
 	// Finally we also create an encoded payment request which allows the
 	// caller to comactly send the invoice to the payer.
 	payReqString := zpay32.Encode(&zpay32.PaymentRequest{
-		Destination: r.server.identityPriv.PubKey(),
+		Destination: r.server.nodeKey.PubKey(),
 		PaymentHash: rHash,
 		Amount:      btcutil.Amount(invoice.Value),
 	})
@@ -1230,6 +2792,45 @@ func (r *rpcServer) LookupInvoice(ctx context.Context,
 		RPreimage: invoice.Terms.PaymentPreimage[:],
 		Value:     int64(invoice.Terms.Value),
 		Settled:   invoice.Terms.Settled,
+		State:     invoiceState(invoice),
+		Expiry:    int64(invoice.Expiry / time.Second),
+	}, nil
+}
+
+// invoiceState returns the state of the passed invoice, as stored within the
+// database. As accepted HTLCs are only tracked in memory, the state of an
+// invoice being paid is reported as open.
+func invoiceState(invoice *channeldb.Invoice) lnrpc.InvoiceState {
+	switch {
+	case invoice.Terms.Settled:
+		return lnrpc.InvoiceState_SETTLED
+	case invoice.Terms.Canceled:
+		return lnrpc.InvoiceState_CANCELED
+	default:
+		return lnrpc.InvoiceState_OPEN
+	}
+}
+
+// DecodePayReq decodes the passed payment request, returning the
+// destination, payment hash and amount encoded within it. Decoded payment
+// requests are cached, so repeated decodes of the same request are cheap.
+func (r *rpcServer) DecodePayReq(ctx context.Context,
+	req *lnrpc.PayReqString) (*lnrpc.PayReq, error) {
+
+	rpcsLog.Tracef("[decodepayreq] decoding: %v", req.PayReq)
+
+	payReq, err := r.payReqCache.Decode(req.PayReq)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode payment request: %v",
+			err)
+	}
+
+	return &lnrpc.PayReq{
+		Destination: hex.EncodeToString(
+			payReq.Destination.SerializeCompressed(),
+		),
+		PaymentHash: hex.EncodeToString(payReq.PaymentHash[:]),
+		NumSatoshis: int64(payReq.Amount),
 	}, nil
 }
 
@@ -1252,6 +2853,8 @@ func (r *rpcServer) ListInvoices(ctx context.Context,
 			Value:        int64(dbInvoice.Terms.Value),
 			Settled:      dbInvoice.Terms.Settled,
 			CreationDate: dbInvoice.CreationDate.Unix(),
+			State:        invoiceState(dbInvoice),
+			Expiry:       int64(dbInvoice.Expiry / time.Second),
 		}
 
 		invoices[i] = invoice
@@ -1263,7 +2866,7 @@ func (r *rpcServer) ListInvoices(ctx context.Context,
 }
 
 // SubscribeInvoices returns a uni-directional stream (sever -> client) for
-// notifying the client of newly added/settled invoices.
+// notifying the client of newly added/settled/canceled invoices.
 func (r *rpcServer) SubscribeInvoices(req *lnrpc.InvoiceSubscription,
 	updateStream lnrpc.Lightning_SubscribeInvoicesServer) error {
 
@@ -1280,6 +2883,22 @@ func (r *rpcServer) SubscribeInvoices(req *lnrpc.InvoiceSubscription,
 				RPreimage: settledInvoice.Terms.PaymentPreimage[:],
 				Value:     int64(settledInvoice.Terms.Value),
 				Settled:   settledInvoice.Terms.Settled,
+				State:     lnrpc.InvoiceState_SETTLED,
+			}
+			if err := updateStream.Send(invoice); err != nil {
+				return err
+			}
+
+		case canceledInvoice := <-invoiceClient.CanceledInvoices:
+			invoice := &lnrpc.Invoice{
+				Memo:      string(canceledInvoice.Memo[:]),
+				Receipt:   canceledInvoice.Receipt[:],
+				RPreimage: canceledInvoice.Terms.PaymentPreimage[:],
+				Value:     int64(canceledInvoice.Terms.Value),
+				State:     lnrpc.InvoiceState_CANCELED,
+				Expiry: int64(
+					canceledInvoice.Expiry / time.Second,
+				),
 			}
 			if err := updateStream.Send(invoice); err != nil {
 				return err
@@ -1337,18 +2956,45 @@ func (r *rpcServer) SubscribeTransactions(req *lnrpc.GetTransactionsRequest,
 }
 
 // GetTransactions returns a list of describing all the known transactions
-// relevant to the wallet.
-func (r *rpcServer) GetTransactions(context.Context,
-	*lnrpc.GetTransactionsRequest) (*lnrpc.TransactionDetails, error) {
+// relevant to the wallet. The transactions may be filtered by the range of
+// block heights they were confirmed within, and paginated using an index
+// offset into the resulting list.
+func (r *rpcServer) GetTransactions(ctx context.Context,
+	in *lnrpc.GetTransactionsRequest) (*lnrpc.TransactionDetails, error) {
+
+	// If no end height was specified, then all transactions up to and
+	// including those still unconfirmed are returned.
+	endHeight := in.EndHeight
+	if endHeight == 0 {
+		endHeight = -1
+	}
+	if endHeight != -1 && endHeight < in.StartHeight {
+		return nil, fmt.Errorf("end_height cannot be below " +
+			"start_height")
+	}
 
-	// TODO(roasbeef): add pagination support
-	transactions, err := r.server.lnwallet.ListTransactionDetails()
+	transactions, err := r.server.lnwallet.ListTransactionDetails(
+		in.StartHeight, endHeight)
 	if err != nil {
 		return nil, err
 	}
 
+	// Apply the pagination parameters, returning at most the requested
+	// number of transactions following the index offset.
+	offset := int(in.IndexOffset)
+	if offset > len(transactions) {
+		offset = len(transactions)
+	}
+	transactions = transactions[offset:]
+	if in.MaxTransactions != 0 &&
+		int(in.MaxTransactions) < len(transactions) {
+
+		transactions = transactions[:in.MaxTransactions]
+	}
+
 	txDetails := &lnrpc.TransactionDetails{
-		Transactions: make([]*lnrpc.Transaction, len(transactions)),
+		Transactions:    make([]*lnrpc.Transaction, len(transactions)),
+		LastIndexOffset: uint32(offset + len(transactions)),
 	}
 	for i, tx := range transactions {
 		txDetails.Transactions[i] = &lnrpc.Transaction{
@@ -1356,8 +3002,10 @@ func (r *rpcServer) GetTransactions(context.Context,
 			Amount:           tx.Value.ToBTC(),
 			NumConfirmations: tx.NumConfirmations,
 			BlockHash:        tx.BlockHash.String(),
+			BlockHeight:      tx.BlockHeight,
 			TimeStamp:        tx.Timestamp,
 			TotalFees:        tx.TotalFees,
+			Label:            tx.Label,
 		}
 	}
 
@@ -1384,12 +3032,9 @@ func (r *rpcServer) DescribeGraph(context.Context,
 	// within the graph), collating their current state into the RPC
 	// response.
 	err := graph.ForEachNode(func(node *channeldb.LightningNode) error {
-		resp.Nodes = append(resp.Nodes, &lnrpc.LightningNode{
-			LastUpdate: uint32(node.LastUpdate.Unix()),
-			PubKey:     hex.EncodeToString(node.PubKey.SerializeCompressed()),
-			Address:    node.Address.String(),
-			Alias:      node.Alias,
-		})
+		rpcNode := marshalDbNode(node)
+		rpcNode.Features = r.nodeFeatures(node.PubKey)
+		resp.Nodes = append(resp.Nodes, rpcNode)
 		return nil
 	})
 	if err != nil {
@@ -1411,36 +3056,213 @@ func (r *rpcServer) DescribeGraph(context.Context,
 	return resp, nil
 }
 
+// nodeFeatures returns the global features of the passed node. As node
+// announcements don't carry feature bits, they're only known for our own node,
+// and for the nodes we're connected to from their init message. Otherwise, nil
+// is returned.
+func (r *rpcServer) nodeFeatures(pubKey *btcec.PublicKey) []*lnrpc.Feature {
+	if pubKey.IsEqual(r.server.nodeKey.PubKey()) {
+		return marshalFeatures(r.server.featureMgr.Get(feature.SetNodeAnn))
+	}
+
+	p, ok := r.server.findPeer(pubKey.SerializeCompressed())
+	if !ok || p.remoteGlobalFeatures == nil {
+		return nil
+	}
+
+	return marshalFeatures(p.remoteGlobalFeatures)
+}
+
+// marshalDbNode converts a node within the channel graph into its RPC
+// representation.
+func marshalDbNode(node *channeldb.LightningNode) *lnrpc.LightningNode {
+	rpcNode := &lnrpc.LightningNode{
+		LastUpdate: uint32(node.LastUpdate.Unix()),
+		PubKey:     hex.EncodeToString(node.PubKey.SerializeCompressed()),
+		Address:    node.Address.String(),
+		Alias:      node.Alias,
+		Color:      encodeHexColor(node.Color),
+	}
+
+	if node.Address != nil {
+		rpcNode.Addresses = []*lnrpc.NodeAddress{
+			{
+				Network:  node.Address.Network(),
+				Addr:     node.Address.String(),
+				LastSeen: uint32(node.LastUpdate.Unix()),
+			},
+		}
+	}
+
+	return rpcNode
+}
+
 func marshalDbEdge(c1, c2 *channeldb.ChannelEdge) *lnrpc.ChannelEdge {
 	node1Pub := c2.Node.PubKey.SerializeCompressed()
 	node2Pub := c1.Node.PubKey.SerializeCompressed()
 
 	edge := &lnrpc.ChannelEdge{
-		ChannelId:  c1.ChannelID,
-		ChanPoint:  c1.ChannelPoint.String(),
-		LastUpdate: uint32(c1.LastUpdate.Unix()),
-		Node1Pub:   hex.EncodeToString(node1Pub),
-		Node2Pub:   hex.EncodeToString(node2Pub),
-		Capacity:   int64(c1.Capacity),
+		ChannelId:    c1.ChannelID,
+		ChannelIdStr: lnwire.NewChanIDFromInt(c1.ChannelID).String(),
+		ChanPoint:    c1.ChannelPoint.String(),
+		LastUpdate:   uint32(c1.LastUpdate.Unix()),
+		Node1Pub:     hex.EncodeToString(node1Pub),
+		Node2Pub:     hex.EncodeToString(node2Pub),
+		Capacity:     int64(c1.Capacity),
+		FullChanId: lnwire.NewFullChanIDFromOutPoint(
+			&c1.ChannelPoint,
+		).String(),
 	}
 
 	edge.Node1Policy = &lnrpc.RoutingPolicy{
-		TimeLockDelta:    uint32(c1.Expiry),
-		MinHtlc:          int64(c1.MinHTLC),
-		FeeBaseMsat:      int64(c1.FeeBaseMSat),
-		FeeRateMilliMsat: int64(c1.FeeProportionalMillionths),
+		TimeLockDelta:           uint32(c1.Expiry),
+		MinHtlc:                 int64(c1.MinHTLC),
+		FeeBaseMsat:             int64(c1.FeeBaseMSat),
+		FeeRateMilliMsat:        int64(c1.FeeProportionalMillionths),
+		InboundFeeBaseMsat:      int64(c1.InboundFeeBaseMSat),
+		InboundFeeRateMilliMsat: int64(c1.InboundFeeProportionalMillionths),
+		Disabled:                c1.Flags&lnwire.ChanUpdateDisabled != 0,
 	}
 
 	edge.Node2Policy = &lnrpc.RoutingPolicy{
-		TimeLockDelta:    uint32(c2.Expiry),
-		MinHtlc:          int64(c2.MinHTLC),
-		FeeBaseMsat:      int64(c2.FeeBaseMSat),
-		FeeRateMilliMsat: int64(c2.FeeProportionalMillionths),
+		TimeLockDelta:           uint32(c2.Expiry),
+		MinHtlc:                 int64(c2.MinHTLC),
+		FeeBaseMsat:             int64(c2.FeeBaseMSat),
+		FeeRateMilliMsat:        int64(c2.FeeProportionalMillionths),
+		InboundFeeBaseMsat:      int64(c2.InboundFeeBaseMSat),
+		InboundFeeRateMilliMsat: int64(c2.InboundFeeProportionalMillionths),
+		Disabled:                c2.Flags&lnwire.ChanUpdateDisabled != 0,
 	}
 
 	return edge
 }
 
+// SubscribeChannelGraph launches a streaming RPC that allows the caller to
+// receive notifications upon each new change to the known channel graph
+// topology: new nodes appearing, nodes updating their attributes, channels
+// having their routing policies updated, and channels being closed.
+func (r *rpcServer) SubscribeChannelGraph(req *lnrpc.GraphTopologySubscription,
+	updateStream lnrpc.Lightning_SubscribeChannelGraphServer) error {
+
+	// First, we start by subscribing to a new intent to receive
+	// notifications from the channel router.
+	client, err := r.server.chanRouter.SubscribeTopology()
+	if err != nil {
+		return err
+	}
+
+	// Ensure that the resources for the topology update client is cleaned
+	// up once either the server, or client exists.
+	defer client.Cancel()
+
+	for {
+		select {
+
+		// A new update has been sent by the channel router, we'll
+		// marshal it into the form expected by the gRPC client, then
+		// send it off.
+		case topChange := <-client.TopologyChanges:
+			// Convert the struct from the channel router into the
+			// form expected by the gRPC service then send it off
+			// to the client.
+			graphUpdate := marshallTopologyChange(topChange)
+			for i, nodeUpdate := range topChange.NodeUpdates {
+				graphUpdate.NodeUpdates[i].Features =
+					r.nodeFeatures(nodeUpdate.IdentityKey)
+			}
+			if err := updateStream.Send(graphUpdate); err != nil {
+				return err
+			}
+
+		// The client has gone away, so we'll stop sending it updates.
+		case <-updateStream.Context().Done():
+			return nil
+
+		// The server is quitting, so we'll exit immediately. Returning
+		// nil will close the client's read end of the stream.
+		case <-r.quit:
+			return nil
+		}
+	}
+}
+
+// marshallTopologyChange performs a mapping from the topology change struct
+// returned by the router to the form of notifications expected by the current
+// gRPC service.
+func marshallTopologyChange(topChange *routing.TopologyChange) *lnrpc.GraphTopologyUpdate {
+	// encodeKey is a simple helper function that converts a live public
+	// key into a hex-encoded version of the compressed serialization for
+	// the public key.
+	encodeKey := func(k *btcec.PublicKey) string {
+		return hex.EncodeToString(k.SerializeCompressed())
+	}
+
+	nodeUpdates := make([]*lnrpc.NodeUpdate, len(topChange.NodeUpdates))
+	for i, nodeUpdate := range topChange.NodeUpdates {
+		addrs := make([]string, len(nodeUpdate.Addresses))
+		for i, addr := range nodeUpdate.Addresses {
+			addrs[i] = addr.String()
+		}
+
+		nodeUpdates[i] = &lnrpc.NodeUpdate{
+			Addresses:   addrs,
+			IdentityKey: encodeKey(nodeUpdate.IdentityKey),
+			Alias:       nodeUpdate.Alias,
+			Color:       encodeHexColor(nodeUpdate.Color),
+		}
+	}
+
+	channelUpdates := make([]*lnrpc.ChannelEdgeUpdate, len(topChange.ChannelEdgeUpdates))
+	for i, channelUpdate := range topChange.ChannelEdgeUpdates {
+		channelUpdates[i] = &lnrpc.ChannelEdgeUpdate{
+			ChanId:    channelUpdate.ChanID,
+			ChanIdStr: lnwire.NewChanIDFromInt(channelUpdate.ChanID).String(),
+			ChanPoint: &lnrpc.ChannelPoint{
+				FundingTxid: channelUpdate.ChanPoint.Hash[:],
+				OutputIndex: channelUpdate.ChanPoint.Index,
+			},
+			Capacity: int64(channelUpdate.Capacity),
+			RoutingPolicy: &lnrpc.RoutingPolicy{
+				TimeLockDelta:           uint32(channelUpdate.TimeLockDelta),
+				MinHtlc:                 int64(channelUpdate.MinHTLC),
+				FeeBaseMsat:             int64(channelUpdate.BaseFee),
+				FeeRateMilliMsat:        int64(channelUpdate.FeeRate),
+				InboundFeeBaseMsat:      int64(channelUpdate.InboundBaseFee),
+				InboundFeeRateMilliMsat: int64(channelUpdate.InboundFeeRate),
+				Disabled:                channelUpdate.Disabled,
+			},
+			AdvertisingNode: encodeKey(channelUpdate.AdvertisingNode),
+			ConnectingNode:  encodeKey(channelUpdate.ConnectingNode),
+			FullChanId: lnwire.NewFullChanIDFromOutPoint(
+				&channelUpdate.ChanPoint,
+			).String(),
+		}
+	}
+
+	closedChans := make([]*lnrpc.ClosedChannelUpdate, len(topChange.ClosedChannels))
+	for i, closedChan := range topChange.ClosedChannels {
+		closedChans[i] = &lnrpc.ClosedChannelUpdate{
+			ChanId:       closedChan.ChanID,
+			ChanIdStr:    lnwire.NewChanIDFromInt(closedChan.ChanID).String(),
+			Capacity:     int64(closedChan.Capacity),
+			ClosedHeight: closedChan.ClosedHeight,
+			ChanPoint: &lnrpc.ChannelPoint{
+				FundingTxid: closedChan.ChanPoint.Hash[:],
+				OutputIndex: closedChan.ChanPoint.Index,
+			},
+			FullChanId: lnwire.NewFullChanIDFromOutPoint(
+				&closedChan.ChanPoint,
+			).String(),
+		}
+	}
+
+	return &lnrpc.GraphTopologyUpdate{
+		NodeUpdates:    nodeUpdates,
+		ChannelUpdates: channelUpdates,
+		ClosedChans:    closedChans,
+	}
+}
+
 // GetChainInfo returns the latest authenticated network announcement for the
 // given channel identified by its channel ID: an 8-byte integer which uniquely
 // identifies the location of transaction's funding output within the block
@@ -1448,7 +3270,12 @@ func marshalDbEdge(c1, c2 *channeldb.ChannelEdge) *lnrpc.ChannelEdge {
 func (r *rpcServer) GetChanInfo(_ context.Context, in *lnrpc.ChanInfoRequest) (*lnrpc.ChannelEdge, error) {
 	graph := r.server.chanDB.ChannelGraph()
 
-	edge1, edge2, err := graph.FetchChannelEdgesByID(in.ChanId)
+	chanID, err := r.chanInfoID(in)
+	if err != nil {
+		return nil, err
+	}
+
+	edge1, edge2, err := graph.FetchChannelEdgesByID(chanID)
 	if err != nil {
 		return nil, err
 	}
@@ -1461,6 +3288,86 @@ func (r *rpcServer) GetChanInfo(_ context.Context, in *lnrpc.ChanInfoRequest) (*
 	return channelEdge, nil
 }
 
+// chanInfoID resolves the compact channel ID of the channel targeted by a
+// GetChanInfo request, which may identify the channel by any form of its
+// channel ID or by its channel point.
+func (r *rpcServer) chanInfoID(in *lnrpc.ChanInfoRequest) (uint64, error) {
+	numSet := 0
+	if in.ChanId != 0 {
+		numSet++
+	}
+	if in.ChanIdStr != "" {
+		numSet++
+	}
+	if in.ChanPoint != nil {
+		numSet++
+	}
+	if in.FullChanId != "" {
+		numSet++
+	}
+	if numSet != 1 {
+		return 0, fmt.Errorf("exactly one of chan_id, chan_id_str, " +
+			"chan_point and full_chan_id must be set")
+	}
+
+	switch {
+	case in.ChanIdStr != "":
+		chanID, err := lnwire.NewChanIDFromString(in.ChanIdStr)
+		if err != nil {
+			return 0, err
+		}
+		return chanID.ToUint64(), nil
+
+	case in.ChanPoint != nil:
+		txid, err := chainhash.NewHash(in.ChanPoint.FundingTxid)
+		if err != nil {
+			return 0, err
+		}
+		chanPoint := wire.NewOutPoint(txid, in.ChanPoint.OutputIndex)
+
+		graph := r.server.chanDB.ChannelGraph()
+		chanID, err := graph.ChannelID(chanPoint)
+		if err != nil {
+			return 0, fmt.Errorf("unable to find channel with "+
+				"chan_point %v: %v", chanPoint, err)
+		}
+		return chanID, nil
+
+	// As the output index can't be recovered from the 32-byte channel
+	// ID, the channel is found by scanning the graph for the channel
+	// whose channel point it was derived from.
+	case in.FullChanId != "":
+		fullChanID, err := lnwire.NewFullChanIDFromString(in.FullChanId)
+		if err != nil {
+			return 0, err
+		}
+
+		var (
+			chanID uint64
+			found  bool
+		)
+		graph := r.server.chanDB.ChannelGraph()
+		err = graph.ForEachChannel(func(e, _ *channeldb.ChannelEdge) error {
+			if !found && fullChanID.IsChanPoint(&e.ChannelPoint) {
+				chanID = e.ChannelID
+				found = true
+			}
+			return nil
+		})
+		if err != nil {
+			return 0, err
+		}
+		if !found {
+			return 0, fmt.Errorf("unable to find channel with "+
+				"full_chan_id %v", fullChanID)
+		}
+		return chanID, nil
+
+	default:
+		return in.ChanId, nil
+	}
+}
+
 // GetNodeInfo returns the latest advertised and aggregate authenticated
 // channel information for the specified node identified by its public key.
 func (r *rpcServer) GetNodeInfo(_ context.Context, in *lnrpc.NodeInfoRequest) (*lnrpc.NodeInfo, error) {
@@ -1500,18 +3407,88 @@ func (r *rpcServer) GetNodeInfo(_ context.Context, in *lnrpc.NodeInfoRequest) (*
 		return nil, err
 	}
 
+	rpcNode := marshalDbNode(node)
+	rpcNode.Features = r.nodeFeatures(pubKey)
+
 	return &lnrpc.NodeInfo{
-		Node: &lnrpc.LightningNode{
-			LastUpdate: uint32(node.LastUpdate.Unix()),
-			PubKey:     in.PubKey,
-			Address:    node.Address.String(),
-			Alias:      node.Alias,
-		},
+		Node:          rpcNode,
 		NumChannels:   numChannels,
 		TotalCapacity: int64(totalCapcity),
 	}, nil
 }
 
+// GetNodeAddresses returns the known network addresses of the specified node,
+// along with the time each was last seen. The address advertised within the
+// channel graph is reported, as well as the address of our connection to the
+// node if we dialed it. As node announcements don't carry feature bits, the
+// node's features are only known, and returned, while we're connected to it.
+func (r *rpcServer) GetNodeAddresses(_ context.Context,
+	in *lnrpc.NodeAddressesRequest) (*lnrpc.NodeAddressesResponse, error) {
+
+	pubKeyBytes, err := hex.DecodeString(in.PubKey)
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := btcec.ParsePubKey(pubKeyBytes, btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+
+	var addrs []*lnrpc.NodeAddress
+	addAddr := func(addr *net.TCPAddr, lastSeen time.Time) {
+		// If the address is already known, then we'll only keep the
+		// latest time it was seen.
+		for _, a := range addrs {
+			if a.Addr == addr.String() {
+				if uint32(lastSeen.Unix()) > a.LastSeen {
+					a.LastSeen = uint32(lastSeen.Unix())
+				}
+				return
+			}
+		}
+
+		addrs = append(addrs, &lnrpc.NodeAddress{
+			Network:  addr.Network(),
+			Addr:     addr.String(),
+			LastSeen: uint32(lastSeen.Unix()),
+		})
+	}
+
+	graph := r.server.chanDB.ChannelGraph()
+	node, err := graph.FetchLightningNode(pubKey)
+	switch {
+	case err == channeldb.ErrGraphNodeNotFound:
+	case err != nil:
+		return nil, err
+	case node.Address != nil:
+		addAddr(node.Address, node.LastUpdate)
+	}
+
+	// If we're connected to the node, then its features are known from
+	// its init message. The address of the connection is only of use if
+	// we dialed the node, as inbound connections come from an ephemeral
+	// port.
+	var features []*lnrpc.Feature
+	p, ok := r.server.findPeer(pubKey.SerializeCompressed())
+	if ok {
+		if p.remoteGlobalFeatures != nil {
+			features = marshalFeatures(p.remoteGlobalFeatures)
+		}
+		if !p.inbound && p.addr.Address != nil {
+			addAddr(p.addr.Address, time.Now())
+		}
+	}
+
+	if err == channeldb.ErrGraphNodeNotFound && !ok {
+		return nil, err
+	}
+
+	return &lnrpc.NodeAddressesResponse{
+		Addresses: addrs,
+		Features:  features,
+	}, nil
+}
+
 // QueryRoute attempts to query the daemons' Channel Router for a possible
 // route to a target destination capable of carrying a specific amount of
 // satoshis within the route's flow. The retuned route contains the full
@@ -1520,7 +3497,6 @@ func (r *rpcServer) GetNodeInfo(_ context.Context, in *lnrpc.NodeInfoRequest) (*
 // within the HTLC.
 //
 // TODO(roasbeef): should return a slice of routes in reality
-//  * create separate PR to send based on well formatted route
 func (r *rpcServer) QueryRoute(_ context.Context, in *lnrpc.RouteRequest) (*lnrpc.Route, error) {
 	// First parse the hex-encdoed public key into a full public key objet
 	// we can properly manipulate.
@@ -1537,7 +3513,7 @@ func (r *rpcServer) QueryRoute(_ context.Context, in *lnrpc.RouteRequest) (*lnrp
 	// can carry `in.Amt` satoshis _including_ the total fee required on
 	// the route.
 	route, err := r.server.chanRouter.FindRoute(pubKey,
-		btcutil.Amount(in.Amt))
+		btcutil.Amount(in.Amt), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1554,6 +3530,7 @@ func (r *rpcServer) QueryRoute(_ context.Context, in *lnrpc.RouteRequest) (*lnrp
 	for i, hop := range route.Hops {
 		resp.Hops[i] = &lnrpc.Hop{
 			ChanId:       hop.Channel.ChannelID,
+			ChanIdStr:    lnwire.NewChanIDFromInt(hop.Channel.ChannelID).String(),
 			ChanCapacity: int64(hop.Channel.Capacity),
 			AmtToForward: int64(hop.AmtToForward),
 			Fee:          int64(hop.Fee),
@@ -1563,6 +3540,76 @@ func (r *rpcServer) QueryRoute(_ context.Context, in *lnrpc.RouteRequest) (*lnrp
 	return resp, nil
 }
 
+// EstimateRouteFee estimates the fee and time lock of a payment of the
+// requested amount to the destination, allowing them to be displayed before
+// the payment is made. Unless a probe is requested, the estimate is based on
+// pathfinding alone. Otherwise, a payment with an unpayable payment hash is
+// sent along the route found, and the estimate is only returned if the probe
+// reaches the destination.
+func (r *rpcServer) EstimateRouteFee(ctx context.Context,
+	in *lnrpc.RouteFeeRequest) (*lnrpc.RouteFeeResponse, error) {
+
+	pubKeyBytes, err := hex.DecodeString(in.PubKey)
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := btcec.ParsePubKey(pubKeyBytes, btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+	amt := btcutil.Amount(in.Amt)
+
+	// Payments to ourselves aren't routed through the network, so they
+	// carry neither a fee nor a time lock.
+	if pubKey.IsEqual(r.server.nodeKey.PubKey()) {
+		return &lnrpc.RouteFeeResponse{}, nil
+	}
+
+	if !in.Probe {
+		route, err := r.server.chanRouter.FindRoute(pubKey, amt, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return &lnrpc.RouteFeeResponse{
+			RoutingFee:    int64(route.TotalFees),
+			TimeLockDelay: route.TotalTimeLock,
+		}, nil
+	}
+
+	// The probe pays to a random payment hash, so the destination is
+	// unable to settle it.
+	var probeHash [32]byte
+	if _, err := rand.Read(probeHash[:]); err != nil {
+		return nil, err
+	}
+
+	htlcPkt, route, err := r.constructPaymentRoute(pubKey, amt, probeHash,
+		nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Debugf("Probing route to %v for %v", in.PubKey, amt)
+
+	// The probe having reached the destination is signalled by the
+	// destination rejecting its unknown payment hash. Any other failure
+	// means the route is unable to carry the payment.
+	err = r.server.htlcSwitch.SendHTLC(htlcPkt)
+	switch {
+	case err == nil:
+		return nil, fmt.Errorf("probe payment was unexpectedly settled")
+
+	case err != lnwire.CancelReason(lnwire.UnknownPaymentHash):
+		return nil, fmt.Errorf("probe payment failed: %v", err)
+	}
+
+	return &lnrpc.RouteFeeResponse{
+		RoutingFee:    int64(route.TotalFees),
+		TimeLockDelay: route.TotalTimeLock,
+	}, nil
+}
+
 // GetNetworkInfo returns some basic stats about the known channel graph from
 // the PoV of the node.
 func (r *rpcServer) GetNetworkInfo(context.Context, *lnrpc.NetworkInfoRequest) (*lnrpc.NetworkInfo, error) {
@@ -1572,6 +3619,7 @@ func (r *rpcServer) GetNetworkInfo(context.Context, *lnrpc.NetworkInfoRequest) (
 	var (
 		numNodes             uint32
 		numChannels          uint32
+		numIsolatedNodes     uint32
 		maxChanOut           uint32
 		totalNetworkCapacity btcutil.Amount
 		minChannelSize       btcutil.Amount = math.MaxInt64
@@ -1597,6 +3645,7 @@ func (r *rpcServer) GetNetworkInfo(context.Context, *lnrpc.NetworkInfoRequest) (
 	// With all the nodes gathered, we can now perform a basic traversal to
 	// ascertain the graph's diameter, and also the max out-degree of a
 	// node.
+	outDegrees := make([]uint32, 0, len(nodes))
 	for _, node := range nodes {
 		var outDegree uint32
 		err := node.ForEachChannel(nil, func(c *channeldb.ChannelEdge) error {
@@ -1608,14 +3657,20 @@ func (r *rpcServer) GetNetworkInfo(context.Context, *lnrpc.NetworkInfoRequest) (
 		}
 
 		if outDegree > maxChanOut {
-			outDegree = maxChanOut
+			maxChanOut = outDegree
+		}
+		if outDegree == 0 {
+			numIsolatedNodes++
 		}
+		outDegrees = append(outDegrees, outDegree)
 	}
 
 	// Finally, we traverse each channel visiting both channel edges at
 	// once to avoid double counting any stats we're attempting to gather.
+	var chanSizes []btcutil.Amount
 	if err := graph.ForEachChannel(func(c1, c2 *channeldb.ChannelEdge) error {
 		chanCapacity := c1.Capacity
+		chanSizes = append(chanSizes, chanCapacity)
 
 		if chanCapacity < minChannelSize {
 			minChannelSize = chanCapacity
@@ -1633,64 +3688,2188 @@ func (r *rpcServer) GetNetworkInfo(context.Context, *lnrpc.NetworkInfoRequest) (
 		return nil, err
 	}
 
+	// With an empty graph, the averages below are undefined, so we'll
+	// leave them zeroed.
+	var avgOutDegree, avgChannelSize float64
+	if numNodes != 0 {
+		avgOutDegree = float64(numChannels) / float64(numNodes)
+	}
+	if numChannels != 0 {
+		avgChannelSize = float64(totalNetworkCapacity) /
+			float64(numChannels)
+	} else {
+		minChannelSize = 0
+	}
+
 	// TODO(roasbeef): also add oldest channel?
 	return &lnrpc.NetworkInfo{
-		MaxOutDegree:         maxChanOut,
-		AvgOutDegree:         float64(numChannels) / float64(numNodes),
-		NumNodes:             numNodes,
-		NumChannels:          numChannels,
-		TotalNetworkCapacity: int64(totalNetworkCapacity),
-		AvgChannelSize:       float64(totalNetworkCapacity) / float64(numChannels),
-		MinChannelSize:       int64(minChannelSize),
-		MaxChannelSize:       int64(maxChannelSize),
+		MaxOutDegree:          maxChanOut,
+		AvgOutDegree:          avgOutDegree,
+		NumNodes:              numNodes,
+		NumChannels:           numChannels,
+		TotalNetworkCapacity:  int64(totalNetworkCapacity),
+		AvgChannelSize:        avgChannelSize,
+		MinChannelSize:        int64(minChannelSize),
+		MaxChannelSize:        int64(maxChannelSize),
+		MedianChannelSize:     int64(medianAmount(chanSizes)),
+		NumIsolatedNodes:      numIsolatedNodes,
+		OutDegreeDistribution: degreeDistribution(outDegrees),
 	}, nil
 }
 
-// ListPayments returns a list of all outgoing payments.
-func (r *rpcServer) ListPayments(context.Context,
-	*lnrpc.ListPaymentsRequest) (*lnrpc.ListPaymentsResponse, error) {
+// medianAmount returns the median of the passed amounts, or zero if no
+// amounts are passed.
+func medianAmount(amts []btcutil.Amount) btcutil.Amount {
+	if len(amts) == 0 {
+		return 0
+	}
+
+	sorted := make([]btcutil.Amount, len(amts))
+	copy(sorted, amts)
+	sort.Sort(amountSlice(sorted))
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// amountSlice implements sort.Interface for a slice of amounts.
+type amountSlice []btcutil.Amount
+
+func (s amountSlice) Len() int           { return len(s) }
+func (s amountSlice) Less(i, j int) bool { return s[i] < s[j] }
+func (s amountSlice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// degreeDistribution tallies the number of nodes having each of the passed
+// out-degrees, returning the counts in increasing order of out-degree.
+func degreeDistribution(outDegrees []uint32) []*lnrpc.DegreeCount {
+	counts := make(map[uint32]uint32)
+	degrees := make([]int, 0)
+	for _, degree := range outDegrees {
+		if counts[degree] == 0 {
+			degrees = append(degrees, int(degree))
+		}
+		counts[degree]++
+	}
+	sort.Ints(degrees)
+
+	distribution := make([]*lnrpc.DegreeCount, 0, len(degrees))
+	for _, degree := range degrees {
+		distribution = append(distribution, &lnrpc.DegreeCount{
+			OutDegree: uint32(degree),
+			NumNodes:  counts[uint32(degree)],
+		})
+	}
+
+	return distribution
+}
+
+// ListPayments returns a page of the outgoing payments, optionally filtered by
+// their creation date.
+func (r *rpcServer) ListPayments(_ context.Context,
+	in *lnrpc.ListPaymentsRequest) (*lnrpc.ListPaymentsResponse, error) {
+
+	rpcsLog.Debugf("[ListPayments] index_offset=%v, max_payments=%v, "+
+		"reversed=%v", in.IndexOffset, in.MaxPayments, in.Reversed)
+
+	query := channeldb.PaymentsQuery{
+		IndexOffset:        in.IndexOffset,
+		MaxPayments:        in.MaxPayments,
+		Reversed:           in.Reversed,
+		CountTotalPayments: in.CountTotalPayments,
+	}
+	if in.CreationDateStart != 0 {
+		query.CreationDateStart = time.Unix(
+			int64(in.CreationDateStart), 0,
+		)
+	}
+	if in.CreationDateEnd != 0 {
+		query.CreationDateEnd = time.Unix(int64(in.CreationDateEnd), 0)
+	}
+	if !query.CreationDateEnd.IsZero() &&
+		query.CreationDateEnd.Before(query.CreationDateStart) {
 
-	rpcsLog.Debugf("[ListPayments]")
+		return nil, fmt.Errorf("creation_date_end cannot be before " +
+			"creation_date_start")
+	}
 
-	payments, err := r.server.chanDB.FetchAllPayments()
+	queryResp, err := r.server.chanDB.QueryPayments(query)
 	if err != nil {
 		return nil, err
 	}
+	payments := queryResp.Payments
 
 	paymentsResp := &lnrpc.ListPaymentsResponse{
-		Payments: make([]*lnrpc.Payment, len(payments)),
+		Payments:         make([]*lnrpc.Payment, len(payments)),
+		FirstIndexOffset: queryResp.FirstIndexOffset,
+		LastIndexOffset:  queryResp.LastIndexOffset,
+		TotalNumPayments: queryResp.TotalNumPayments,
 	}
 	for i, payment := range payments {
-		path := make([]string, len(payment.Path))
-		for i, hop := range payment.Path {
-			path[i] = hex.EncodeToString(hop[:])
-		}
+		paymentsResp.Payments[i] = marshalPayment(payment)
+	}
+
+	return paymentsResp, nil
+}
+
+// marshalPayment converts the passed outgoing payment into its RPC
+// representation.
+func marshalPayment(payment *channeldb.OutgoingPayment) *lnrpc.Payment {
+	path := make([]string, len(payment.Path))
+	for i, hop := range payment.Path {
+		path[i] = hex.EncodeToString(hop[:])
+	}
+
+	htlcs := make([]*lnrpc.HTLCAttempt, 0, len(payment.FailedAttempts)+1)
+	for i := range payment.FailedAttempts {
+		htlcs = append(htlcs, marshalHtlcAttempt(
+			&payment.FailedAttempts[i], channeldb.StatusFailed,
+		))
+	}
+	final := payment.FinalAttempt()
+	htlcs = append(htlcs, marshalHtlcAttempt(&final, payment.Status))
+
+	return &lnrpc.Payment{
+		PaymentHash:       hex.EncodeToString(payment.PaymentHash[:]),
+		Value:             int64(payment.Terms.Value),
+		CreationDate:      payment.CreationDate.Unix(),
+		Path:              path,
+		Status:            lnrpc.PaymentStatus(payment.Status),
+		NumFailedAttempts: uint32(len(payment.FailedAttempts)),
+		PaymentIndex:      payment.SequenceNum,
+		TimeoutSeconds: int32(
+			payment.Params.Timeout / time.Second,
+		),
+		CltvLimit: payment.Params.CltvLimit,
+		MaxParts:  payment.Params.MaxParts,
+		Htlcs:     htlcs,
+		FailureReason: lnrpc.PaymentFailureReason(
+			payment.FailureReason,
+		),
+	}
+}
+
+// DeleteAllPayments deletes all outgoing payments from DB. If either option
+// of the request is set, then only failed payments, or the failed attempts of
+// succeeded payments, are deleted instead.
+func (r *rpcServer) DeleteAllPayments(_ context.Context,
+	in *lnrpc.DeleteAllPaymentsRequest) (*lnrpc.DeleteAllPaymentsResponse, error) {
 
-		paymentsResp.Payments[i] = &lnrpc.Payment{
-			PaymentHash:  hex.EncodeToString(payment.PaymentHash[:]),
-			Value:        int64(payment.Terms.Value),
-			CreationDate: payment.CreationDate.Unix(),
-			Path:         path,
+	rpcsLog.Debugf("[DeleteAllPayments] failed_payments_only=%v, "+
+		"failed_attempts_only=%v", in.FailedPaymentsOnly,
+		in.FailedAttemptsOnly)
+
+	if !in.FailedPaymentsOnly && !in.FailedAttemptsOnly {
+		if err := r.server.chanDB.DeleteAllPayments(); err != nil {
+			return nil, err
 		}
+
+		return &lnrpc.DeleteAllPaymentsResponse{}, nil
 	}
 
-	return paymentsResp, nil
+	numDeleted, err := r.server.chanDB.DeleteFailedPayments(
+		in.FailedPaymentsOnly, in.FailedAttemptsOnly,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.DeleteAllPaymentsResponse{
+		NumDeleted: int64(numDeleted),
+	}, nil
+}
+
+// DeletePayment deletes the outgoing payment with the given payment hash from
+// the DB, or only the records of its failed attempts.
+func (r *rpcServer) DeletePayment(_ context.Context,
+	in *lnrpc.DeletePaymentRequest) (*lnrpc.DeletePaymentResponse, error) {
+
+	if len(in.PaymentHash) != 32 {
+		return nil, fmt.Errorf("payment hash must be exactly 32 "+
+			"bytes, is instead %v", len(in.PaymentHash))
+	}
+
+	var paymentHash [32]byte
+	copy(paymentHash[:], in.PaymentHash)
+
+	rpcsLog.Debugf("[DeletePayment] payment_hash=%x, "+
+		"failed_attempts_only=%v", paymentHash, in.FailedAttemptsOnly)
+
+	err := r.server.chanDB.DeletePayment(paymentHash, in.FailedAttemptsOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.DeletePaymentResponse{}, nil
 }
 
-// DeleteAllPayments deletes all outgoing payments from DB.
-func (r *rpcServer) DeleteAllPayments(context.Context,
-	*lnrpc.DeleteAllPaymentsRequest) (*lnrpc.DeleteAllPaymentsResponse, error) {
+// SetAlias sets the alias of our node, broadcasting an updated node
+// announcement to the network.
+func (r *rpcServer) SetAlias(_ context.Context,
+	in *lnrpc.SetAliasRequest) (*lnrpc.SetAliasResponse, error) {
+
+	rpcsLog.Debugf("[setalias] alias=%v", in.NewAlias)
+
+	alias, err := parseAlias(in.NewAlias)
+	if err != nil {
+		return nil, err
+	}
 
-	rpcsLog.Debugf("[DeleteAllPayments]")
+	if err := r.server.updateNodeAnnouncement(
+		nodeAnnSetAlias(alias)); err != nil {
 
-	if err := r.server.chanDB.DeleteAllPayments(); err != nil {
 		return nil, err
 	}
 
-	return &lnrpc.DeleteAllPaymentsResponse{}, nil
+	return &lnrpc.SetAliasResponse{}, nil
 }
 
-// SetAlias...
-func (r *rpcServer) SetAlias(context.Context, *lnrpc.SetAliasRequest) (*lnrpc.SetAliasResponse, error) {
-	return nil, nil
+// parseAlias parses and validates an alias to be set within our node
+// announcement.
+func parseAlias(aliasStr string) (lnwire.Alias, error) {
+	alias, err := lnwire.NewAlias(aliasStr)
+	if err != nil {
+		return alias, err
+	}
+	if err := alias.Validate(); err != nil {
+		return alias, err
+	}
+
+	return alias, nil
+}
+
+// UpdateNodeAnnouncement updates our node announcement with the requested
+// alias, color, address, and feature bits, then re-signs and broadcasts it to
+// the network without requiring a restart. Fields left empty within the
+// request are left unchanged.
+func (r *rpcServer) UpdateNodeAnnouncement(_ context.Context,
+	in *lnrpc.NodeAnnouncementUpdateRequest) (
+	*lnrpc.NodeAnnouncementUpdateResponse, error) {
+
+	rpcsLog.Debugf("[updatenodeannouncement] alias=%v, color=%v, "+
+		"address=%v, num_feature_updates=%v", in.Alias, in.Color,
+		in.Address, len(in.FeatureUpdates))
+
+	var (
+		modifiers []nodeAnnModifier
+		ops       []string
+	)
+
+	if in.Alias != "" {
+		alias, err := parseAlias(in.Alias)
+		if err != nil {
+			return nil, err
+		}
+
+		modifiers = append(modifiers, nodeAnnSetAlias(alias))
+		ops = append(ops, fmt.Sprintf("alias set to %v", in.Alias))
+	}
+
+	if in.Color != "" {
+		nodeColor, err := parseHexColor(in.Color)
+		if err != nil {
+			return nil, err
+		}
+
+		rgb := lnwire.NewRGB(nodeColor.R, nodeColor.G, nodeColor.B)
+		modifiers = append(modifiers, nodeAnnSetColor(rgb))
+		ops = append(ops, fmt.Sprintf("color set to %v", in.Color))
+	}
+
+	if in.Address != "" {
+		addr, err := net.ResolveTCPAddr("tcp", in.Address)
+		if err != nil {
+			return nil, err
+		}
+
+		modifiers = append(modifiers, nodeAnnSetAddr(addr))
+		ops = append(ops, fmt.Sprintf("address set to %v", addr))
+	}
+
+	// Any feature updates are validated up front, rejecting the update as
+	// a whole if it would break any feature dependencies, yet they're only
+	// applied to the set of features we advertise once our updated node
+	// announcement has been broadcast.
+	var nodeAnnFeatures *lnwire.FeatureVector
+	if len(in.FeatureUpdates) != 0 {
+		var setBits, unsetBits []lnwire.FeatureBit
+		for _, update := range in.FeatureUpdates {
+			bit := lnwire.FeatureBit(update.FeatureBit)
+
+			switch update.Action {
+			case lnrpc.UpdateFeatureAction_ADD:
+				setBits = append(setBits, bit)
+				ops = append(ops, fmt.Sprintf("feature %v set",
+					bit))
+
+			case lnrpc.UpdateFeatureAction_REMOVE:
+				unsetBits = append(unsetBits, bit)
+				ops = append(ops, fmt.Sprintf("feature %v "+
+					"unset", bit))
+
+			default:
+				return nil, fmt.Errorf("unknown feature "+
+					"update action: %v", update.Action)
+			}
+		}
+
+		var err error
+		nodeAnnFeatures, err = r.server.featureMgr.PrepareFeatureSetUpdate(
+			feature.SetNodeAnn, setBits, unsetBits,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("no node announcement updates requested")
+	}
+
+	if err := r.server.updateNodeAnnouncement(modifiers...); err != nil {
+		return nil, err
+	}
+	if nodeAnnFeatures != nil {
+		r.server.featureMgr.SetFeatureSet(
+			feature.SetNodeAnn, nodeAnnFeatures,
+		)
+	}
+
+	return &lnrpc.NodeAnnouncementUpdateResponse{
+		Ops: ops,
+	}, nil
+}
+
+// SendCustomMessage sends a custom peer message to the target peer. The
+// message type must fall within the custom message range, and within any
+// ranges permitted by the daemon's configuration.
+func (r *rpcServer) SendCustomMessage(ctx context.Context,
+	in *lnrpc.SendCustomMessageRequest) (*lnrpc.SendCustomMessageResponse, error) {
+
+	rpcsLog.Tracef("[sendcustommessage] peer=%x, type=%v, len=%v",
+		in.Peer, in.Type, len(in.Data))
+
+	peerKey, err := btcec.ParsePubKey(in.Peer, btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+
+	if !r.server.customMessages.isAllowed(in.Type) {
+		return nil, fmt.Errorf("custom message type %v not permitted",
+			in.Type)
+	}
+
+	msg, err := lnwire.NewCustom(in.Type, in.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.server.sendToPeer(peerKey, msg); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.SendCustomMessageResponse{}, nil
+}
+
+// SubscribeCustomMessages returns a uni-directional stream (server -> client)
+// over which any permitted custom messages received from our peers are sent.
+func (r *rpcServer) SubscribeCustomMessages(req *lnrpc.SubscribeCustomMessagesRequest,
+	updateStream lnrpc.Lightning_SubscribeCustomMessagesServer) error {
+
+	msgClient := r.server.customMessages.SubscribeMessages()
+	defer msgClient.Cancel()
+
+	for {
+		select {
+		case event := <-msgClient.Events:
+			customMsg := event.(*customMessage)
+			msg := &lnrpc.CustomMessage{
+				Peer: customMsg.peer.SerializeCompressed(),
+				Type: customMsg.msg.Type,
+				Data: customMsg.msg.Data,
+			}
+			if err := updateStream.Send(msg); err != nil {
+				return err
+			}
+		case <-msgClient.Overflow:
+			return errEventQueueOverflow
+		case <-updateStream.Context().Done():
+			return nil
+		case <-r.quit:
+			return nil
+		}
+	}
+}
+
+// parseSignDescriptors converts the passed RPC sign descriptors into their
+// lnwallet counterparts, populating the sighash midstate for the passed
+// transaction. A public key is only required for each descriptor if
+// requirePubKey is true.
+func parseSignDescriptors(tx *wire.MsgTx, rpcDescs []*lnrpc.SignDescriptor,
+	requirePubKey bool) ([]*lnwallet.SignDescriptor, error) {
+
+	if len(rpcDescs) == 0 {
+		return nil, fmt.Errorf("at least one sign descriptor must be " +
+			"specified")
+	}
+
+	sigHashes := txscript.NewTxSigHashes(tx)
+	signDescs := make([]*lnwallet.SignDescriptor, 0, len(rpcDescs))
+	for i, rpcDesc := range rpcDescs {
+		if rpcDesc.Output == nil {
+			return nil, fmt.Errorf("sign descriptor %v is missing "+
+				"its output", i)
+		}
+		if rpcDesc.InputIndex < 0 ||
+			int(rpcDesc.InputIndex) >= len(tx.TxIn) {
+
+			return nil, fmt.Errorf("sign descriptor %v has invalid "+
+				"input index %v", i, rpcDesc.InputIndex)
+		}
+
+		var pubKey *btcec.PublicKey
+		keyDesc := rpcDesc.KeyDesc
+		switch {
+		case keyDesc != nil && len(keyDesc.RawKeyBytes) != 0:
+			var err error
+			pubKey, err = btcec.ParsePubKey(keyDesc.RawKeyBytes,
+				btcec.S256())
+			if err != nil {
+				return nil, err
+			}
+		case requirePubKey:
+			return nil, fmt.Errorf("sign descriptor %v is missing "+
+				"its public key", i)
+		}
+
+		// If no sighash type was specified, then we'll default to
+		// SIGHASH_ALL.
+		hashType := txscript.SigHashType(rpcDesc.Sighash)
+		if hashType == 0 {
+			hashType = txscript.SigHashAll
+		}
+
+		signDescs = append(signDescs, &lnwallet.SignDescriptor{
+			PubKey:        pubKey,
+			PrivateTweak:  rpcDesc.SingleTweak,
+			WitnessScript: rpcDesc.WitnessScript,
+			Output: &wire.TxOut{
+				Value:    rpcDesc.Output.Value,
+				PkScript: rpcDesc.Output.PkScript,
+			},
+			HashType:   hashType,
+			SigHashes:  sigHashes,
+			InputIndex: int(rpcDesc.InputIndex),
+		})
+	}
+
+	return signDescs, nil
+}
+
+// SignOutputRaw generates a signature for each of the passed sign descriptors
+// over the passed transaction. The signatures are generated using the wallet
+// key identified by each descriptor, and are returned without a sighash
+// flag.
+func (r *rpcServer) SignOutputRaw(ctx context.Context,
+	in *lnrpc.SignReq) (*lnrpc.SignResp, error) {
+
+	tx := &wire.MsgTx{}
+	if err := tx.Deserialize(bytes.NewReader(in.RawTxBytes)); err != nil {
+		return nil, fmt.Errorf("unable to decode tx: %v", err)
+	}
+
+	rpcsLog.Tracef("[signoutputraw] txid=%v, num_descs=%v", tx.TxHash(),
+		len(in.SignDescs))
+
+	signDescs, err := parseSignDescriptors(tx, in.SignDescs, true)
+	if err != nil {
+		return nil, err
+	}
+
+	signer := r.server.lnwallet.Signer
+	resp := &lnrpc.SignResp{
+		RawSigs: make([][]byte, 0, len(signDescs)),
+	}
+	for _, signDesc := range signDescs {
+		if err := r.checkSignerKey(signDesc.PubKey); err != nil {
+			return nil, err
+		}
+
+		sig, err := signer.SignOutputRaw(tx, signDesc)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.RawSigs = append(resp.RawSigs, sig)
+	}
+
+	return resp, nil
+}
+
+// checkSignerKey returns remotesigner.ErrFamilyNotAllowed if the watch-only
+// nodes we act as the remote signer of may not sign with the passed key, as
+// it's outside of the permitted key families.
+func (r *rpcServer) checkSignerKey(pubKey *btcec.PublicKey) error {
+	if len(cfg.signerFamilies) == 0 {
+		return nil
+	}
+
+	// The key must be one of our key ring, so that its family is known.
+	keyRing, ok := r.server.lnwallet.KeyRing.(keychain.SecretKeyRing)
+	if !ok {
+		return fmt.Errorf("private keys are held by a remote signer")
+	}
+	keyLoc, err := keyRing.LocateKey(pubKey)
+	if err != nil {
+		return remotesigner.ErrFamilyNotAllowed
+	}
+
+	return checkSignerFamily(keyLoc.Family)
+}
+
+// ComputeInputScript generates a complete input script (witness and, for
+// nested outputs, sigScript) for each of the passed sign descriptors. Each
+// output being spent must be controlled by the wallet.
+func (r *rpcServer) ComputeInputScript(ctx context.Context,
+	in *lnrpc.SignReq) (*lnrpc.InputScriptResp, error) {
+
+	tx := &wire.MsgTx{}
+	if err := tx.Deserialize(bytes.NewReader(in.RawTxBytes)); err != nil {
+		return nil, fmt.Errorf("unable to decode tx: %v", err)
+	}
+
+	rpcsLog.Tracef("[computeinputscript] txid=%v, num_descs=%v",
+		tx.TxHash(), len(in.SignDescs))
+
+	signDescs, err := parseSignDescriptors(tx, in.SignDescs, false)
+	if err != nil {
+		return nil, err
+	}
+
+	signer := r.server.lnwallet.Signer
+	resp := &lnrpc.InputScriptResp{
+		InputScripts: make([]*lnrpc.InputScript, 0, len(signDescs)),
+	}
+	for _, signDesc := range signDescs {
+		inputScript, err := signer.ComputeInputScript(tx, signDesc)
+		if err != nil {
+			return nil, err
+		}
+		if inputScript == nil {
+			return nil, fmt.Errorf("output %v isn't controlled by "+
+				"the wallet", signDesc.InputIndex)
+		}
+
+		resp.InputScripts = append(resp.InputScripts, &lnrpc.InputScript{
+			Witness:   inputScript.Witness,
+			SigScript: inputScript.ScriptSig,
+		})
+	}
+
+	return resp, nil
+}
+
+// checkSignerFamily returns remotesigner.ErrFamilyNotAllowed if the
+// watch-only nodes we act as the remote signer of may not use the keys within
+// the passed key family.
+func checkSignerFamily(keyFam keychain.KeyFamily) error {
+	if len(cfg.signerFamilies) == 0 {
+		return nil
+	}
+	for _, allowed := range cfg.signerFamilies {
+		if allowed == keyFam {
+			return nil
+		}
+	}
+
+	return remotesigner.ErrFamilyNotAllowed
+}
+
+// signerPrivKey derives the private key at the passed key locator, on behalf
+// of a watch-only node we act as the remote signer of.
+func (r *rpcServer) signerPrivKey(rpcLoc *lnrpc.KeyLocator) (*btcec.PrivateKey,
+	error) {
+
+	if rpcLoc.KeyFamily < 0 || rpcLoc.KeyIndex < 0 {
+		return nil, fmt.Errorf("invalid key locator: family=%v, "+
+			"index=%v", rpcLoc.KeyFamily, rpcLoc.KeyIndex)
+	}
+	keyLoc := keychain.KeyLocator{
+		Family: keychain.KeyFamily(rpcLoc.KeyFamily),
+		Index:  uint32(rpcLoc.KeyIndex),
+	}
+	if err := checkSignerFamily(keyLoc.Family); err != nil {
+		return nil, err
+	}
+
+	// The private keys are out of reach if we're a watch-only node
+	// ourselves.
+	keyRing, ok := r.server.lnwallet.KeyRing.(keychain.SecretKeyRing)
+	if !ok {
+		return nil, fmt.Errorf("private keys are held by a remote " +
+			"signer")
+	}
+
+	return keyRing.DerivePrivKey(keychain.KeyDescriptor{
+		KeyLocator: keyLoc,
+	})
+}
+
+// DeriveSharedKey performs an ECDH operation between the passed ephemeral
+// public key and one of our keys, returning the sha256 of the compressed
+// shared point. This is the same construction used within the brontide
+// handshake. If a key locator is specified, then the key it locates is used,
+// typically the node key of a watch-only node we act as the remote signer of.
+// Otherwise, the node's own identity key is used.
+func (r *rpcServer) DeriveSharedKey(ctx context.Context,
+	in *lnrpc.SharedKeyRequest) (*lnrpc.SharedKeyResponse, error) {
+
+	ephemeralPub, err := btcec.ParsePubKey(in.EphemeralPubkey, btcec.S256())
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse ephemeral pubkey: %v",
+			err)
+	}
+
+	var ecdhKey keychain.SingleKeyECDH = r.server.nodeKey
+	if in.KeyDesc != nil && in.KeyDesc.KeyLoc != nil {
+		privKey, err := r.signerPrivKey(in.KeyDesc.KeyLoc)
+		if err != nil {
+			return nil, err
+		}
+		ecdhKey = &keychain.PrivKeyNodeKey{PrivKey: privKey}
+	}
+
+	// If a public key was specified, ensure that it matches the key we're
+	// about to use.
+	if in.KeyDesc != nil && len(in.KeyDesc.RawKeyBytes) != 0 {
+		pubKey := ecdhKey.PubKey().SerializeCompressed()
+		if !bytes.Equal(in.KeyDesc.RawKeyBytes, pubKey) {
+			return nil, fmt.Errorf("public key doesn't match the " +
+				"requested key")
+		}
+	}
+
+	sharedKey, err := ecdhKey.ECDH(ephemeralPub)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.SharedKeyResponse{SharedKey: sharedKey[:]}, nil
+}
+
+// SignDigest signs the passed digest with the key at the passed key locator,
+// allowing a watch-only node we act as the remote signer of to sign its
+// gossip messages with its node key.
+func (r *rpcServer) SignDigest(ctx context.Context,
+	in *lnrpc.SignDigestRequest) (*lnrpc.SignDigestResponse, error) {
+
+	if in.KeyLoc == nil {
+		return nil, fmt.Errorf("a key locator must be specified")
+	}
+	if len(in.Digest) != 32 {
+		return nil, fmt.Errorf("digest must be 32 bytes, got %v",
+			len(in.Digest))
+	}
+
+	privKey, err := r.signerPrivKey(in.KeyLoc)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := privKey.Sign(in.Digest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.SignDigestResponse{Signature: sig.Serialize()}, nil
+}
+
+// ProcessOnion processes an onion packet destined to the watch-only node we
+// act as the remote signer of, using its node key.
+func (r *rpcServer) ProcessOnion(ctx context.Context,
+	in *lnrpc.ProcessOnionRequest) (*lnrpc.ProcessOnionResponse, error) {
+
+	router, err := r.signerOnionRouter()
+	if err != nil {
+		return nil, err
+	}
+
+	onionPkt := &sphinx.OnionPacket{}
+	err = onionPkt.Decode(bytes.NewReader(in.OnionBlob))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode onion packet: %v", err)
+	}
+
+	processed, err := router.ProcessOnionPacket(onionPkt, in.AssocData)
+	if err != nil {
+		return nil, err
+	}
+
+	switch processed.Action {
+	case sphinx.ExitNode:
+		return &lnrpc.ProcessOnionResponse{ExitNode: true}, nil
+
+	case sphinx.MoreHops:
+		var b bytes.Buffer
+		if err := processed.Packet.Encode(&b); err != nil {
+			return nil, err
+		}
+
+		return &lnrpc.ProcessOnionResponse{
+			NextHop:       processed.NextHop[:],
+			NextOnionBlob: b.Bytes(),
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("malformed onion packet")
+	}
+}
+
+// signerOnionRouter returns the Sphinx router processing the onion packets
+// destined to the watch-only node we act as the remote signer of, creating it
+// on first use.
+func (r *rpcServer) signerOnionRouter() (*sphinx.Router, error) {
+	r.signerOnionMtx.Lock()
+	defer r.signerOnionMtx.Unlock()
+
+	if r.signerOnion != nil {
+		return r.signerOnion, nil
+	}
+
+	privKey, err := r.signerPrivKey(&lnrpc.KeyLocator{
+		KeyFamily: int32(keychain.KeyFamilyNodeKey),
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.signerOnion = sphinx.NewRouter(privKey, activeNetParams.Params)
+
+	return r.signerOnion, nil
+}
+
+// ListUnspent returns the set of unspent witness outputs controlled by the
+// wallet which have between the specified minimum and maximum number of
+// confirmations.
+func (r *rpcServer) ListUnspent(ctx context.Context,
+	in *lnrpc.ListUnspentRequest) (*lnrpc.ListUnspentResponse, error) {
+
+	minConfs := in.MinConfs
+	maxConfs := in.MaxConfs
+	if maxConfs == 0 {
+		maxConfs = math.MaxInt32
+	}
+	if minConfs < 0 || maxConfs < minConfs {
+		return nil, fmt.Errorf("invalid confirmation range: min=%v, "+
+			"max=%v", minConfs, maxConfs)
+	}
+
+	// If an imported account is specified, then its outputs are returned
+	// rather than those of the wallet.
+	var (
+		utxos []*lnwallet.Utxo
+		err   error
+	)
+	if in.Account != "" {
+		utxos, err = r.server.lnwallet.ListImportedUnspent(in.Account,
+			minConfs)
+	} else {
+		utxos, err = r.server.lnwallet.ListUnspentWitness(minConfs)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &lnrpc.ListUnspentResponse{
+		Utxos: make([]*lnrpc.Utxo, 0, len(utxos)),
+	}
+	for _, utxo := range utxos {
+		if utxo.Confirmations > int64(maxConfs) {
+			continue
+		}
+
+		resp.Utxos = append(resp.Utxos, &lnrpc.Utxo{
+			Txid:          utxo.Hash.String(),
+			OutputIndex:   utxo.Index,
+			AmountSat:     int64(utxo.Value),
+			PkScript:      utxo.PkScript,
+			Confirmations: utxo.Confirmations,
+		})
+	}
+
+	rpcsLog.Debugf("[listunspent] min_confs=%v, max_confs=%v, num_utxos=%v",
+		minConfs, maxConfs, len(resp.Utxos))
+
+	return resp, nil
+}
+
+// ListAddresses returns all the addresses the wallet has generated.
+func (r *rpcServer) ListAddresses(ctx context.Context,
+	in *lnrpc.ListAddressesRequest) (*lnrpc.ListAddressesResponse, error) {
+
+	addrs, err := r.server.lnwallet.ListAddresses()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &lnrpc.ListAddressesResponse{
+		Addresses: make([]string, 0, len(addrs)),
+	}
+	for _, addr := range addrs {
+		resp.Addresses = append(resp.Addresses, addr.String())
+	}
+
+	return resp, nil
+}
+
+// NextAddr returns the next unused external or internal address of the
+// requested type from the wallet.
+func (r *rpcServer) NextAddr(ctx context.Context,
+	in *lnrpc.AddrRequest) (*lnrpc.NewAddressResponse, error) {
+
+	// Addresses of imported accounts are always p2wkh addresses derived
+	// from the account's extended public key.
+	if in.Account != "" {
+		if in.Type != lnrpc.NewAddressRequest_WITNESS_PUBKEY_HASH {
+			return nil, fmt.Errorf("imported accounts only support " +
+				"p2wkh addresses")
+		}
+
+		addr, err := r.server.lnwallet.NewImportedAddress(in.Account,
+			in.Change)
+		if err != nil {
+			return nil, err
+		}
+
+		rpcsLog.Infof("[nextaddr] addr=%v, account=%v, change=%v",
+			addr.String(), in.Account, in.Change)
+		return &lnrpc.NewAddressResponse{Address: addr.String()}, nil
+	}
+
+	addrType, err := parseAddressType(in.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	addr, err := r.server.lnwallet.NewAddress(addrType, in.Change)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Infof("[nextaddr] addr=%v, change=%v", addr.String(), in.Change)
+	return &lnrpc.NewAddressResponse{Address: addr.String()}, nil
+}
+
+// marshalKeyDescriptor converts the passed key descriptor into its RPC
+// counterpart.
+func marshalKeyDescriptor(keyDesc keychain.KeyDescriptor) *lnrpc.KeyDescriptor {
+	return &lnrpc.KeyDescriptor{
+		RawKeyBytes: keyDesc.PubKey.SerializeCompressed(),
+		KeyLoc: &lnrpc.KeyLocator{
+			KeyFamily: int32(keyDesc.Family),
+			KeyIndex:  int32(keyDesc.Index),
+		},
+	}
+}
+
+// DeriveKey derives the key specified by the passed key locator from the
+// wallet's key ring. If no key locator is specified, then the next public key
+// within the wallet's HD key-chain is returned instead.
+func (r *rpcServer) DeriveKey(ctx context.Context,
+	in *lnrpc.DeriveKeyRequest) (*lnrpc.KeyDescriptor, error) {
+
+	if in.KeyLoc != nil {
+		if in.KeyLoc.KeyFamily < 0 || in.KeyLoc.KeyIndex < 0 {
+			return nil, fmt.Errorf("invalid key locator: family=%v, "+
+				"index=%v", in.KeyLoc.KeyFamily, in.KeyLoc.KeyIndex)
+		}
+		err := checkSignerFamily(keychain.KeyFamily(in.KeyLoc.KeyFamily))
+		if err != nil {
+			return nil, err
+		}
+
+		keyDesc, err := r.server.lnwallet.KeyRing.DeriveKey(
+			keychain.KeyLocator{
+				Family: keychain.KeyFamily(in.KeyLoc.KeyFamily),
+				Index:  uint32(in.KeyLoc.KeyIndex),
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return marshalKeyDescriptor(keyDesc), nil
+	}
+
+	pubKey, err := r.server.lnwallet.NewRawKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.KeyDescriptor{
+		RawKeyBytes: pubKey.SerializeCompressed(),
+	}, nil
+}
+
+// DeriveNextKey derives the next unused key within the passed key family of
+// the wallet's key ring.
+func (r *rpcServer) DeriveNextKey(ctx context.Context,
+	in *lnrpc.DeriveNextKeyRequest) (*lnrpc.KeyDescriptor, error) {
+
+	if in.KeyFamily < 0 {
+		return nil, fmt.Errorf("invalid key family: %v", in.KeyFamily)
+	}
+	err := checkSignerFamily(keychain.KeyFamily(in.KeyFamily))
+	if err != nil {
+		return nil, err
+	}
+
+	keyDesc, err := r.server.lnwallet.KeyRing.DeriveNextKey(
+		keychain.KeyFamily(in.KeyFamily),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Debugf("[derivenextkey] family=%v, index=%v", keyDesc.Family,
+		keyDesc.Index)
+
+	return marshalKeyDescriptor(keyDesc), nil
+}
+
+// PublishTransaction broadcasts the passed fully signed transaction to the
+// network.
+func (r *rpcServer) PublishTransaction(ctx context.Context,
+	in *lnrpc.PublishTransactionRequest) (*lnrpc.PublishTransactionResponse, error) {
+
+	tx := &wire.MsgTx{}
+	if err := tx.Deserialize(bytes.NewReader(in.TxHex)); err != nil {
+		return nil, fmt.Errorf("unable to deserialize tx: %v", err)
+	}
+
+	if err := r.server.lnwallet.PublishTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	txid := tx.TxHash()
+	rpcsLog.Infof("[publishtransaction] txid=%v", txid)
+
+	return &lnrpc.PublishTransactionResponse{Txid: txid.String()}, nil
+}
+
+// EstimateFee returns the fee rate the wallet would currently use for a
+// transaction targeting confirmation within the given number of blocks.
+func (r *rpcServer) EstimateFee(ctx context.Context,
+	in *lnrpc.EstimateFeeRequest) (*lnrpc.EstimateFeeResponse, error) {
+
+	if in.ConfTarget == 0 {
+		return nil, fmt.Errorf("conf_target must be greater than zero")
+	}
+
+	feeEstimator := r.server.feeEstimator
+	return &lnrpc.EstimateFeeResponse{
+		SatPerByte:   int64(feeEstimator.EstimateFeePerByte(in.ConfTarget)),
+		SatPerWeight: int64(feeEstimator.EstimateFeePerWeight(in.ConfTarget)),
+	}, nil
+}
+
+// FundPsbt funds the outputs of either a template packet, or a set of raw
+// outputs, using the coins of the wallet. The selected wallet inputs are
+// locked, and a change output is added if required.
+func (r *rpcServer) FundPsbt(ctx context.Context,
+	in *lnrpc.FundPsbtRequest) (*lnrpc.FundPsbtResponse, error) {
+
+	var (
+		packet *psbt.Packet
+		err    error
+	)
+	switch {
+	case len(in.Psbt) != 0 && len(in.RawOutputs) != 0:
+		return nil, fmt.Errorf("only one of psbt or raw_outputs may " +
+			"be specified")
+
+	case len(in.Psbt) != 0:
+		packet, err = psbt.NewFromRawBytes(bytes.NewReader(in.Psbt),
+			false)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse psbt: %v", err)
+		}
+
+	case len(in.RawOutputs) != 0:
+		outputs, err := addrPairsToOutputs(in.RawOutputs)
+		if err != nil {
+			return nil, err
+		}
+		packet, err = psbt.New(nil, outputs, 2, 0, nil)
+		if err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, fmt.Errorf("either psbt or raw_outputs must be " +
+			"specified")
+	}
+
+	feeRate, err := r.calculateFeeRate(in.SatPerByte, in.TargetConf)
+	if err != nil {
+		return nil, err
+	}
+
+	coinSelection, err := parseCoinSelection(in.CoinSelectionStrategy,
+		nil)
+	if err != nil {
+		return nil, err
+	}
+	if in.Account != "" {
+		if coinSelection == nil {
+			coinSelection = &lnwallet.CoinSelection{}
+		}
+		coinSelection.Account = in.Account
+	}
+
+	changeIndex, err := r.server.lnwallet.FundPsbt(packet, feeRate, 1,
+		coinSelection)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	if err := packet.Serialize(&b); err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Debugf("[fundpsbt] num_inputs=%v, num_outputs=%v, "+
+		"change_index=%v, fee_rate=%v", len(packet.UnsignedTx.TxIn),
+		len(packet.UnsignedTx.TxOut), changeIndex, feeRate)
+
+	return &lnrpc.FundPsbtResponse{
+		FundedPsbt:        b.Bytes(),
+		ChangeOutputIndex: changeIndex,
+	}, nil
+}
+
+// SignPsbt adds partial signatures to all the inputs of the passed packet
+// which spend outputs controlled by the wallet.
+func (r *rpcServer) SignPsbt(ctx context.Context,
+	in *lnrpc.SignPsbtRequest) (*lnrpc.SignPsbtResponse, error) {
+
+	packet, err := psbt.NewFromRawBytes(bytes.NewReader(in.FundedPsbt),
+		false)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse psbt: %v", err)
+	}
+
+	signedInputs, err := r.server.lnwallet.SignPsbt(packet)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	if err := packet.Serialize(&b); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.SignPsbtResponse{
+		SignedPsbt:   b.Bytes(),
+		SignedInputs: signedInputs,
+	}, nil
+}
+
+// FinalizePsbt signs all the wallet inputs of the passed packet, then
+// finalizes every input, returning the final signed transaction. All
+// external inputs must have been signed beforehand.
+func (r *rpcServer) FinalizePsbt(ctx context.Context,
+	in *lnrpc.FinalizePsbtRequest) (*lnrpc.FinalizePsbtResponse, error) {
+
+	packet, err := psbt.NewFromRawBytes(bytes.NewReader(in.FundedPsbt),
+		false)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse psbt: %v", err)
+	}
+
+	finalTx, err := r.server.lnwallet.FinalizePsbt(packet)
+	if err != nil {
+		return nil, err
+	}
+
+	var psbtBuf, txBuf bytes.Buffer
+	if err := packet.Serialize(&psbtBuf); err != nil {
+		return nil, err
+	}
+	if err := finalTx.Serialize(&txBuf); err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Infof("[finalizepsbt] txid=%v", finalTx.TxHash())
+
+	return &lnrpc.FinalizePsbtResponse{
+		SignedPsbt: psbtBuf.Bytes(),
+		RawFinalTx: txBuf.Bytes(),
+	}, nil
+}
+
+// parseOutPoint converts an RPC outpoint into its wire representation. The
+// txid may be specified either as raw bytes, or as a string.
+func parseOutPoint(op *lnrpc.OutPoint) (*wire.OutPoint, error) {
+	if op == nil {
+		return nil, fmt.Errorf("an outpoint must be specified")
+	}
+
+	var txid *chainhash.Hash
+	switch {
+	case len(op.TxidBytes) != 0:
+		hash, err := chainhash.NewHash(op.TxidBytes)
+		if err != nil {
+			return nil, err
+		}
+		txid = hash
+
+	case op.TxidStr != "":
+		hash, err := chainhash.NewHashFromStr(op.TxidStr)
+		if err != nil {
+			return nil, err
+		}
+		txid = hash
+
+	default:
+		return nil, fmt.Errorf("a txid must be specified")
+	}
+
+	return wire.NewOutPoint(txid, op.OutputIndex), nil
+}
+
+// parseCoinSelection converts the coin selection strategy and explicit
+// outpoints of an RPC request into a coin selection for the wallet. If neither
+// was specified, then nil is returned, deferring to the wallet's default.
+func parseCoinSelection(strategy lnrpc.CoinSelectionStrategy,
+	outpoints []*lnrpc.OutPoint) (*lnwallet.CoinSelection, error) {
+
+	selection := &lnwallet.CoinSelection{}
+	switch strategy {
+	case lnrpc.CoinSelectionStrategy_STRATEGY_USE_GLOBAL_CONFIG:
+		selection.Strategy = lnwallet.CoinSelectionDefault
+	case lnrpc.CoinSelectionStrategy_STRATEGY_LARGEST:
+		selection.Strategy = lnwallet.CoinSelectionLargest
+	case lnrpc.CoinSelectionStrategy_STRATEGY_RANDOM:
+		selection.Strategy = lnwallet.CoinSelectionRandom
+	case lnrpc.CoinSelectionStrategy_STRATEGY_SMALLEST:
+		selection.Strategy = lnwallet.CoinSelectionSmallest
+	default:
+		return nil, fmt.Errorf("unknown coin selection strategy: %v",
+			strategy)
+	}
+
+	for _, rpcOutPoint := range outpoints {
+		op, err := parseOutPoint(rpcOutPoint)
+		if err != nil {
+			return nil, err
+		}
+		selection.Outpoints = append(selection.Outpoints, *op)
+	}
+
+	if selection.Strategy == lnwallet.CoinSelectionDefault &&
+		len(selection.Outpoints) == 0 {
+
+		return nil, nil
+	}
+
+	return selection, nil
+}
+
+// marshalOutPoint converts a wire outpoint into its RPC representation.
+func marshalOutPoint(op *wire.OutPoint) *lnrpc.OutPoint {
+	return &lnrpc.OutPoint{
+		TxidBytes:   op.Hash[:],
+		TxidStr:     op.Hash.String(),
+		OutputIndex: op.Index,
+	}
+}
+
+// parseLockID converts a raw lock ID into its typed representation.
+func parseLockID(id []byte) (lnwallet.LockID, error) {
+	var lockID lnwallet.LockID
+	if len(id) != len(lockID) {
+		return lockID, fmt.Errorf("lock id must be %v bytes, got %v",
+			len(lockID), len(id))
+	}
+	copy(lockID[:], id)
+
+	return lockID, nil
+}
+
+// LeaseOutput locks an output of the wallet for the requested duration,
+// preventing it from being used for coin selection until the lease expires or
+// is released.
+func (r *rpcServer) LeaseOutput(ctx context.Context,
+	in *lnrpc.LeaseOutputRequest) (*lnrpc.LeaseOutputResponse, error) {
+
+	lockID, err := parseLockID(in.Id)
+	if err != nil {
+		return nil, err
+	}
+	op, err := parseOutPoint(in.Outpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// The requested duration is checked before converting it, as a large
+	// enough number of seconds would overflow a time.Duration.
+	duration := lnwallet.DefaultLockDuration
+	if in.ExpirationSeconds != 0 {
+		maxSeconds := uint64(lnwallet.MaxLockDuration / time.Second)
+		if in.ExpirationSeconds > maxSeconds {
+			return nil, lnwallet.ErrInvalidLeaseDuration
+		}
+		duration = time.Duration(in.ExpirationSeconds) * time.Second
+	}
+
+	expiration, err := r.server.lnwallet.LeaseOutput(lockID, *op, duration)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Debugf("[leaseoutput] outpoint=%v, expiration=%v", op,
+		expiration)
+
+	return &lnrpc.LeaseOutputResponse{
+		Expiration: uint64(expiration.Unix()),
+	}, nil
+}
+
+// ReleaseOutput unlocks an output previously leased with the same lock ID.
+func (r *rpcServer) ReleaseOutput(ctx context.Context,
+	in *lnrpc.ReleaseOutputRequest) (*lnrpc.ReleaseOutputResponse, error) {
+
+	lockID, err := parseLockID(in.Id)
+	if err != nil {
+		return nil, err
+	}
+	op, err := parseOutPoint(in.Outpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.server.lnwallet.ReleaseOutput(lockID, *op); err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Debugf("[releaseoutput] outpoint=%v", op)
+
+	return &lnrpc.ReleaseOutputResponse{}, nil
+}
+
+// ListLeases returns all the outputs of the wallet which are currently
+// leased.
+func (r *rpcServer) ListLeases(ctx context.Context,
+	in *lnrpc.ListLeasesRequest) (*lnrpc.ListLeasesResponse, error) {
+
+	leases, err := r.server.lnwallet.ListLeasedOutputs()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &lnrpc.ListLeasesResponse{
+		LockedUtxos: make([]*lnrpc.UtxoLease, 0, len(leases)),
+	}
+	for _, lease := range leases {
+		id := lease.LockID
+		resp.LockedUtxos = append(resp.LockedUtxos, &lnrpc.UtxoLease{
+			Id:         id[:],
+			Outpoint:   marshalOutPoint(&lease.Outpoint),
+			Expiration: uint64(lease.Expiration.Unix()),
+		})
+	}
+
+	return resp, nil
+}
+
+// LabelTransaction attaches a label to a transaction of the wallet. An
+// existing label is only replaced if overwrite is set.
+func (r *rpcServer) LabelTransaction(ctx context.Context,
+	in *lnrpc.LabelTransactionRequest) (*lnrpc.LabelTransactionResponse, error) {
+
+	if in.Label == "" {
+		return nil, fmt.Errorf("cannot label a transaction with an " +
+			"empty label")
+	}
+
+	txid, err := chainhash.NewHash(in.Txid)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only transactions which are relevant to the wallet may be labeled.
+	transactions, err := r.server.lnwallet.ListTransactionDetails(0, -1)
+	if err != nil {
+		return nil, err
+	}
+	var known bool
+	for _, tx := range transactions {
+		if tx.Hash == *txid {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return nil, fmt.Errorf("transaction %v not found in wallet",
+			txid)
+	}
+
+	err = r.server.lnwallet.LabelTransaction(*txid, in.Label, in.Overwrite)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Debugf("[labeltransaction] txid=%v, label=%v", txid, in.Label)
+
+	return &lnrpc.LabelTransactionResponse{}, nil
+}
+
+// ListSweeps returns all the sweep transactions broadcast to recover our funds
+// from closed channels: those of the utxoNursery sweeping time-locked outputs,
+// the justice transactions of the breachArbiter and those of the shellSweeper
+// sweeping restored channels. The height at which each sweep confirmed is
+// sourced from the wallet.
+func (r *rpcServer) ListSweeps(ctx context.Context,
+	in *lnrpc.ListSweepsRequest) (*lnrpc.ListSweepsResponse, error) {
+
+	sweeps, err := fetchSweepRecords(r.server.chanDB)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := r.server.lnwallet.ListTransactionDetails(0, -1)
+	if err != nil {
+		return nil, err
+	}
+	confHeights := make(map[chainhash.Hash]int32, len(transactions))
+	for _, tx := range transactions {
+		confHeights[tx.Hash] = tx.BlockHeight
+	}
+
+	resp := &lnrpc.ListSweepsResponse{
+		Sweeps: make([]*lnrpc.Sweep, 0, len(sweeps)),
+	}
+	for _, sweep := range sweeps {
+		txid := sweep.tx.TxHash()
+
+		var amtSwept int64
+		for _, txOut := range sweep.tx.TxOut {
+			amtSwept += txOut.Value
+		}
+
+		rpcSweep := &lnrpc.Sweep{
+			Txid:               txid.String(),
+			AmountSwept:        amtSwept,
+			TotalFees:          int64(sweep.fee()),
+			Inputs:             make([]*lnrpc.SweepInput, 0, len(sweep.inputs)),
+			BroadcastHeight:    sweep.broadcastHeight,
+			ConfirmationHeight: confHeights[txid],
+		}
+		for i, input := range sweep.inputs {
+			rpcSweep.Inputs = append(rpcSweep.Inputs, &lnrpc.SweepInput{
+				Outpoint: marshalOutPoint(
+					&sweep.tx.TxIn[i].PreviousOutPoint,
+				),
+				Amount:      int64(input.amt),
+				WitnessType: input.witnessType.String(),
+			})
+		}
+
+		resp.Sweeps = append(resp.Sweeps, rpcSweep)
+	}
+
+	rpcsLog.Debugf("[listsweeps] returning %v sweeps", len(resp.Sweeps))
+
+	return resp, nil
+}
+
+// marshalAccount converts the passed imported account into its RPC
+// counterpart.
+func marshalAccount(account *lnwallet.ImportedAccount) *lnrpc.Account {
+	return &lnrpc.Account{
+		Name:                 account.Name,
+		ExtendedPublicKey:    account.ExtendedPubKey.String(),
+		MasterKeyFingerprint: account.MasterKeyFingerprint,
+		ExternalKeyCount:     account.ExternalKeyCount,
+		InternalKeyCount:     account.InternalKeyCount,
+		WatchOnly:            true,
+	}
+}
+
+// ImportAccount imports an account backed by an extended public key, allowing
+// its addresses to be derived and its outputs to be watched. The outputs of an
+// imported account can't be spent by the wallet, but may be used to fund
+// PSBTs which are then signed externally.
+func (r *rpcServer) ImportAccount(ctx context.Context,
+	in *lnrpc.ImportAccountRequest) (*lnrpc.ImportAccountResponse, error) {
+
+	if in.Name == defaultAccountName {
+		return nil, fmt.Errorf("account name %v is reserved",
+			defaultAccountName)
+	}
+
+	xpub, err := hdkeychain.NewKeyFromString(in.ExtendedPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse extended public key: %v",
+			err)
+	}
+
+	account, external, internal, err := r.server.lnwallet.ImportAccount(
+		in.Name, xpub, in.MasterKeyFingerprint, in.BirthdayHeight,
+		in.DryRun,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &lnrpc.ImportAccountResponse{
+		Account: marshalAccount(account),
+	}
+	for _, addr := range external {
+		resp.DryRunExternalAddrs = append(resp.DryRunExternalAddrs,
+			addr.String())
+	}
+	for _, addr := range internal {
+		resp.DryRunInternalAddrs = append(resp.DryRunInternalAddrs,
+			addr.String())
+	}
+
+	rpcsLog.Infof("[importaccount] name=%v, birthday_height=%v, "+
+		"dry_run=%v", in.Name, in.BirthdayHeight, in.DryRun)
+
+	return resp, nil
+}
+
+// ListAccounts returns the default account of the wallet, along with all the
+// accounts which have been imported. If a name is specified, then only the
+// account with that name is returned.
+func (r *rpcServer) ListAccounts(ctx context.Context,
+	in *lnrpc.ListAccountsRequest) (*lnrpc.ListAccountsResponse, error) {
+
+	accounts, err := r.server.lnwallet.ListAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &lnrpc.ListAccountsResponse{}
+	if in.Name == "" || in.Name == defaultAccountName {
+		resp.Accounts = append(resp.Accounts, &lnrpc.Account{
+			Name: defaultAccountName,
+		})
+	}
+	for _, account := range accounts {
+		if in.Name != "" && in.Name != account.Name {
+			continue
+		}
+
+		resp.Accounts = append(resp.Accounts, marshalAccount(account))
+	}
+
+	if in.Name != "" && len(resp.Accounts) == 0 {
+		return nil, lnwallet.ErrUnknownAccount
+	}
+
+	return resp, nil
+}
+
+// GetRecoveryInfo returns whether the wallet was started in recovery mode, and
+// if so, the progress of its rescan of the chain from the wallet's birthday.
+func (r *rpcServer) GetRecoveryInfo(ctx context.Context,
+	in *lnrpc.GetRecoveryInfoRequest) (*lnrpc.GetRecoveryInfoResponse, error) {
+
+	recoveryMode, progress, err := r.server.lnwallet.RecoveryProgress()
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.GetRecoveryInfoResponse{
+		RecoveryMode:     recoveryMode,
+		RecoveryFinished: recoveryMode && progress == 1,
+		Progress:         progress,
+	}, nil
+}
+
+// DebugLevel allows a caller to programmatically set the logging verbosity of
+// lnd. The logging can be targeted according to a coarse daemon-wide logging
+// level, or in a granular fashion to specify the logging for a target
+// sub-system.
+func (r *rpcServer) DebugLevel(ctx context.Context,
+	req *lnrpc.DebugLevelRequest) (*lnrpc.DebugLevelResponse, error) {
+
+	// If show is set, then we simply print out the list of available
+	// sub-systems.
+	if req.Show {
+		return &lnrpc.DebugLevelResponse{
+			SubSystems: strings.Join(supportedSubsystems(), " "),
+		}, nil
+	}
+
+	rpcsLog.Infof("[debuglevel] changing debug level to: %v", req.LevelSpec)
+
+	// Otherwise, we'll attempt to set the logging level using the
+	// specified level spec.
+	if err := parseAndSetDebugLevels(req.LevelSpec); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.DebugLevelResponse{}, nil
+}
+
+// StopDaemon gracefully shuts down the daemon, as if it had received a SIGINT
+// (Ctrl+C) signal. The shutdown is requested asynchronously, so the response
+// is sent before the RPC server stops.
+func (r *rpcServer) StopDaemon(ctx context.Context,
+	in *lnrpc.StopRequest) (*lnrpc.StopResponse, error) {
+
+	rpcsLog.Infof("[stopdaemon] shutdown requested")
+
+	requestShutdown()
+
+	return &lnrpc.StopResponse{}, nil
+}
+
+// GetDebugInfo returns a bundle of the daemon's sanitized config and runtime
+// state, including the features we advertise, the status of our subservers,
+// and the most recent lines of the log, to be attached to support requests.
+// Stack traces of all goroutines and a heap profile are included on request.
+func (r *rpcServer) GetDebugInfo(ctx context.Context,
+	in *lnrpc.GetDebugInfoRequest) (*lnrpc.GetDebugInfoResponse, error) {
+
+	rpcsLog.Debugf("[getdebuginfo] goroutines=%v, heap_profile=%v",
+		in.IncludeGoroutines, in.IncludeHeapProfile)
+
+	featureMgr := r.server.featureMgr
+	featureSets := make([]*lnrpc.FeatureSet, 0, len(featureMgr.ListSets()))
+	for _, set := range featureMgr.ListSets() {
+		featureSets = append(featureSets, &lnrpc.FeatureSet{
+			Name:     set.String(),
+			Features: marshalFeatures(featureMgr.Get(set)),
+		})
+	}
+
+	subservers := []*lnrpc.SubserverStatus{
+		{
+			Name:   "autopilot",
+			Active: r.server.pilot.Active(),
+		},
+		{
+			Name:   "feemanager",
+			Active: r.server.feeManager != nil,
+		},
+		{
+			Name:   "prometheus",
+			Active: cfg.Prometheus.Enabled(),
+		},
+		{
+			Name:   "remotesigner",
+			Active: cfg.RemoteSigner,
+		},
+		{
+			Name:   "hodl",
+			Active: r.server.hodlMask != hodl.MaskNone,
+		},
+	}
+
+	resp := &lnrpc.GetDebugInfoResponse{
+		Version:     version(),
+		Config:      sanitizedConfig(cfg),
+		FeatureSets: featureSets,
+		Subservers:  subservers,
+		ProfilePort: cfg.Profile,
+		LogLines:    recentLogs.Lines(int(in.NumLogLines)),
+	}
+
+	if in.IncludeGoroutines {
+		var b bytes.Buffer
+		err := pprof.Lookup("goroutine").WriteTo(&b, 2)
+		if err != nil {
+			return nil, err
+		}
+		resp.Goroutines = b.String()
+	}
+
+	if in.IncludeHeapProfile {
+		var b bytes.Buffer
+		if err := pprof.Lookup("heap").WriteTo(&b, 0); err != nil {
+			return nil, err
+		}
+		resp.HeapProfile = b.Bytes()
+	}
+
+	return resp, nil
+}
+
+// maxCPUProfileDuration is the longest duration a CPU profile may be captured
+// for over the RPC interface.
+const maxCPUProfileDuration = 5 * time.Minute
+
+// CaptureCPUProfile profiles the CPU usage of the daemon for the requested
+// duration, returning the profile in the pprof format. Only a single CPU
+// profile, including those served by the profiling HTTP server, may be
+// captured at a time.
+func (r *rpcServer) CaptureCPUProfile(ctx context.Context,
+	in *lnrpc.CPUProfileRequest) (*lnrpc.CPUProfileResponse, error) {
+
+	duration := time.Duration(in.DurationSecs) * time.Second
+	if duration == 0 || duration > maxCPUProfileDuration {
+		return nil, fmt.Errorf("profile duration must be between 1 "+
+			"and %v seconds", maxCPUProfileDuration.Seconds())
+	}
+
+	rpcsLog.Infof("[capturecpuprofile] profiling CPU for %v", duration)
+
+	var b bytes.Buffer
+	if err := pprof.StartCPUProfile(&b); err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-time.After(duration):
+	case <-ctx.Done():
+		pprof.StopCPUProfile()
+		return nil, ctx.Err()
+	case <-r.quit:
+		pprof.StopCPUProfile()
+		return nil, fmt.Errorf("rpc server shutting down")
+	}
+	pprof.StopCPUProfile()
+
+	return &lnrpc.CPUProfileResponse{
+		Profile: b.Bytes(),
+	}, nil
+}
+
+// ExportChannelDbForAudit writes a copy of the channel database, stripped of
+// the secrets which would allow its holder to steal funds, to the requested
+// path.
+func (r *rpcServer) ExportChannelDbForAudit(ctx context.Context,
+	in *lnrpc.ExportChannelDbRequest) (*lnrpc.ExportChannelDbResponse, error) {
+
+	if in.DestPath == "" {
+		return nil, fmt.Errorf("destination path must be set")
+	}
+
+	rpcsLog.Infof("[exportchanneldbforaudit] exporting channel database "+
+		"to %v", in.DestPath)
+
+	if err := r.server.chanDB.ExportForAudit(in.DestPath); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.ExportChannelDbResponse{}, nil
+}
+
+// marshalFeatures converts the passed feature vector into its RPC format.
+func marshalFeatures(fv *lnwire.FeatureVector) []*lnrpc.Feature {
+	features := make([]*lnrpc.Feature, 0, len(fv.Features()))
+	for _, bit := range fv.Features() {
+		name, isKnown := lnwire.Features[bit]
+		features = append(features, &lnrpc.Feature{
+			Bit:        uint32(bit),
+			Name:       name,
+			IsRequired: bit.IsRequired(),
+			IsKnown:    isKnown,
+		})
+	}
+
+	return features
+}
+
+// ChannelInsights returns the lifetime, uptime and flap count of the requested
+// channel, or of every monitored channel if none is requested.
+func (r *rpcServer) ChannelInsights(ctx context.Context,
+	in *lnrpc.ChannelInsightsRequest) (*lnrpc.ChannelInsightsResponse, error) {
+
+	var insights []*chanfitness.ChannelInsights
+	if in.ChanPoint == nil {
+		insights = r.server.chanEventStore.AllInsights()
+	} else {
+		txid, err := chainhash.NewHash(in.ChanPoint.FundingTxid)
+		if err != nil {
+			return nil, err
+		}
+		chanPoint := wire.NewOutPoint(txid, in.ChanPoint.OutputIndex)
+
+		chanInsights, err := r.server.chanEventStore.Insights(*chanPoint)
+		if err != nil {
+			return nil, err
+		}
+		insights = append(insights, chanInsights)
+	}
+
+	resp := &lnrpc.ChannelInsightsResponse{}
+	for _, i := range insights {
+		var lastFlap int64
+		if i.FlapCount > 0 {
+			lastFlap = i.LastFlap.Unix()
+		}
+
+		resp.Insights = append(resp.Insights, &lnrpc.ChannelInsight{
+			ChanPoint:    i.ChanPoint.String(),
+			RemotePubkey: hex.EncodeToString(i.Peer),
+			Lifetime:     int64(i.Lifetime.Seconds()),
+			Uptime:       int64(i.Uptime.Seconds()),
+			ActiveTime:   int64(i.ActiveTime.Seconds()),
+			FlapCount:    uint32(i.FlapCount),
+			LastFlap:     lastFlap,
+		})
+	}
+
+	return resp, nil
+}
+
+// SetFeeManagement opts a channel into, or out of, automatic fee management.
+// The choice is persisted, so it outlives restarts of the daemon. Channels can
+// only opt in while the fee manager is active.
+func (r *rpcServer) SetFeeManagement(ctx context.Context,
+	in *lnrpc.SetFeeManagementRequest) (*lnrpc.SetFeeManagementResponse, error) {
+
+	if r.server.feeManager == nil {
+		return nil, fmt.Errorf("fee manager isn't active, it can be " +
+			"activated with the feemanager.active option")
+	}
+	if in.ChanPoint == nil {
+		return nil, fmt.Errorf("a channel point must be specified")
+	}
+
+	txid, err := chainhash.NewHash(in.ChanPoint.FundingTxid)
+	if err != nil {
+		return nil, err
+	}
+	chanPoint := wire.NewOutPoint(txid, in.ChanPoint.OutputIndex)
+
+	rpcsLog.Infof("[setfeemanagement] chan_point=%v, enable=%v",
+		chanPoint, in.Enable)
+
+	// Only our open channels can opt into fee management, though any
+	// channel may opt out.
+	if in.Enable {
+		dbChannels, err := r.server.chanDB.FetchAllChannels()
+		if err != nil && err != channeldb.ErrNoActiveChannels {
+			return nil, err
+		}
+
+		var found bool
+		for _, dbChannel := range dbChannels {
+			if *dbChannel.ChanID == *chanPoint {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unable to find open channel "+
+				"%v", chanPoint)
+		}
+	}
+
+	err = r.server.feeManager.SetManaged(*chanPoint, in.Enable)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.SetFeeManagementResponse{}, nil
+}
+
+// UpdateChanStatus disables, or re-enables, forwarding over one of our open
+// channels, or returns it to automatic management where it's disabled while
+// its peer is offline. Manually set statuses are persisted, so they outlive
+// restarts of the daemon.
+func (r *rpcServer) UpdateChanStatus(ctx context.Context,
+	in *lnrpc.UpdateChanStatusRequest) (*lnrpc.UpdateChanStatusResponse, error) {
+
+	if in.ChanPoint == nil {
+		return nil, fmt.Errorf("a channel point must be specified")
+	}
+
+	txid, err := chainhash.NewHash(in.ChanPoint.FundingTxid)
+	if err != nil {
+		return nil, err
+	}
+	chanPoint := wire.NewOutPoint(txid, in.ChanPoint.OutputIndex)
+
+	var action chanstatus.Action
+	switch in.Action {
+	case lnrpc.ChanStatusAction_ENABLE:
+		action = chanstatus.ActionEnable
+	case lnrpc.ChanStatusAction_DISABLE:
+		action = chanstatus.ActionDisable
+	case lnrpc.ChanStatusAction_AUTO:
+		action = chanstatus.ActionAuto
+	default:
+		return nil, fmt.Errorf("unknown channel status action: %v",
+			in.Action)
+	}
+
+	rpcsLog.Infof("[updatechanstatus] chan_point=%v, action=%v",
+		chanPoint, action)
+
+	// Only our open channels can have their status set manually, though
+	// any channel may be returned to automatic management.
+	if action != chanstatus.ActionAuto {
+		dbChannels, err := r.server.chanDB.FetchAllChannels()
+		if err != nil && err != channeldb.ErrNoActiveChannels {
+			return nil, err
+		}
+
+		var found bool
+		for _, dbChannel := range dbChannels {
+			if *dbChannel.ChanID == *chanPoint {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unable to find open channel "+
+				"%v", chanPoint)
+		}
+	}
+
+	err = r.server.chanStatusMgr.SetStatus(*chanPoint, action)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.UpdateChanStatusResponse{}, nil
+}
+
+// UpgradeChannel upgrades the commitment type of an active channel without
+// closing it. The channel is quiesced while the upgrade is negotiated with the
+// remote peer, and resumed once it's been agreed upon. The channel keeps its
+// prior commitment type until the commitments created before the upgrade
+// have been revoked.
+func (r *rpcServer) UpgradeChannel(ctx context.Context,
+	in *lnrpc.UpgradeChannelRequest) (*lnrpc.UpgradeChannelResponse, error) {
+
+	if in.ChanPoint == nil {
+		return nil, fmt.Errorf("a channel point must be specified")
+	}
+
+	txid, err := chainhash.NewHash(in.ChanPoint.FundingTxid)
+	if err != nil {
+		return nil, err
+	}
+	chanPoint := wire.NewOutPoint(txid, in.ChanPoint.OutputIndex)
+
+	var commitType channeldb.CommitmentType
+	switch in.CommitmentType {
+	case lnrpc.CommitmentType_STATIC_REMOTE_KEY:
+		commitType = channeldb.CommitTypeStaticRemoteKey
+	default:
+		return nil, fmt.Errorf("unable to upgrade channel to "+
+			"commitment type %v", in.CommitmentType)
+	}
+
+	rpcsLog.Infof("[upgradechannel] chan_point=%v, commitment_type=%v",
+		chanPoint, commitType)
+
+	req := &linkControlReq{
+		op:         linkUpgradeCommitment,
+		commitType: commitType,
+	}
+	if err := r.server.controlChannelLink(*chanPoint, req); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.UpgradeChannelResponse{}, nil
+}
+
+// channelBackup creates a static backup of the passed channel.
+func (r *rpcServer) channelBackup(dbChan *channeldb.OpenChannel) (chanbackup.Single, error) {
+	// The short channel ID is only known for channels within the graph.
+	graph := r.server.chanDB.ChannelGraph()
+	shortChanID, _ := graph.ChannelID(dbChan.ChanID)
+
+	// Every address we've reached the peer at is included, so it can be
+	// located upon restoring the channel.
+	var addrs []*net.TCPAddr
+	linkNode, err := r.server.chanDB.FetchLinkNode(dbChan.IdentityPub)
+	switch {
+	case err == nil:
+		addrs = linkNode.Addresses
+	case err != channeldb.ErrNodeNotFound &&
+		err != channeldb.ErrLinkNodesNotFound:
+
+		return chanbackup.Single{}, err
+	}
+
+	return chanbackup.NewSingle(dbChan, shortChanID, addrs), nil
+}
+
+// ExportChannelBackup returns an encrypted static backup of the channel with
+// the passed channel point.
+func (r *rpcServer) ExportChannelBackup(ctx context.Context,
+	in *lnrpc.ExportChannelBackupRequest) (*lnrpc.ChannelBackup, error) {
+
+	if in.ChanPoint == nil {
+		return nil, fmt.Errorf("a channel point must be specified")
+	}
+
+	txid, err := chainhash.NewHash(in.ChanPoint.FundingTxid)
+	if err != nil {
+		return nil, err
+	}
+	chanPoint := wire.NewOutPoint(txid, in.ChanPoint.OutputIndex)
+
+	dbChan, err := r.fetchOpenChannel(*chanPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	single, err := r.channelBackup(dbChan)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	if err := single.PackToWriter(&b, r.server.lnwallet.KeyRing); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.ChannelBackup{
+		ChanPoint:  in.ChanPoint,
+		ChanBackup: b.Bytes(),
+	}, nil
+}
+
+// ExportAllChannelBackups returns encrypted static backups of all our open
+// channels, both individually and as a single multi-channel backup.
+func (r *rpcServer) ExportAllChannelBackups(ctx context.Context,
+	in *lnrpc.ChanBackupExportRequest) (*lnrpc.ChanBackupSnapshot, error) {
+
+	dbChannels, err := r.server.chanDB.FetchAllChannels()
+	if err != nil && err != channeldb.ErrNoActiveChannels {
+		return nil, err
+	}
+
+	keyRing := r.server.lnwallet.KeyRing
+	singleBackups := &lnrpc.ChannelBackups{}
+	multiBackup := &lnrpc.MultiChanBackup{}
+	multi := chanbackup.Multi{
+		Version: chanbackup.DefaultMultiVersion,
+	}
+	for _, dbChan := range dbChannels {
+		single, err := r.channelBackup(dbChan)
+		if err != nil {
+			return nil, err
+		}
+
+		var b bytes.Buffer
+		if err := single.PackToWriter(&b, keyRing); err != nil {
+			return nil, err
+		}
+
+		chanPoint := &lnrpc.ChannelPoint{
+			FundingTxid: dbChan.ChanID.Hash[:],
+			OutputIndex: dbChan.ChanID.Index,
+		}
+		singleBackups.ChanBackups = append(singleBackups.ChanBackups,
+			&lnrpc.ChannelBackup{
+				ChanPoint:  chanPoint,
+				ChanBackup: b.Bytes(),
+			},
+		)
+		multiBackup.ChanPoints = append(multiBackup.ChanPoints, chanPoint)
+		multi.StaticBackups = append(multi.StaticBackups, single)
+	}
+
+	var b bytes.Buffer
+	if err := multi.PackToWriter(&b, keyRing); err != nil {
+		return nil, err
+	}
+	multiBackup.MultiChanBackup = b.Bytes()
+
+	return &lnrpc.ChanBackupSnapshot{
+		SingleChanBackups: singleBackups,
+		MultiChanBackup:   multiBackup,
+	}, nil
+}
+
+// unpackSingleBackups decrypts and deserializes the passed single channel
+// backups.
+func (r *rpcServer) unpackSingleBackups(
+	backups *lnrpc.ChannelBackups) ([]chanbackup.Single, error) {
+
+	if backups == nil || len(backups.ChanBackups) == 0 {
+		return nil, fmt.Errorf("no channel backups specified")
+	}
+
+	var singles []chanbackup.Single
+	for _, chanBackup := range backups.ChanBackups {
+		var single chanbackup.Single
+		err := single.UnpackFromReader(
+			bytes.NewReader(chanBackup.ChanBackup),
+			r.server.lnwallet.KeyRing,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to unpack channel "+
+				"backup: %v", err)
+		}
+		singles = append(singles, single)
+	}
+
+	return singles, nil
+}
+
+// unpackMultiBackup decrypts and deserializes the passed multi-channel
+// backup, returning the single channel backups within it.
+func (r *rpcServer) unpackMultiBackup(backup []byte) ([]chanbackup.Single, error) {
+	var multi chanbackup.Multi
+	err := multi.UnpackFromReader(
+		bytes.NewReader(backup), r.server.lnwallet.KeyRing,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unpack multi-channel "+
+			"backup: %v", err)
+	}
+
+	return multi.StaticBackups, nil
+}
+
+// unpackChannelBackups decrypts and deserializes the single channel backups
+// within the passed restore request.
+func (r *rpcServer) unpackChannelBackups(
+	in *lnrpc.RestoreChanBackupRequest) ([]chanbackup.Single, error) {
+
+	switch backup := in.Backup.(type) {
+	case *lnrpc.RestoreChanBackupRequest_ChanBackups:
+		return r.unpackSingleBackups(backup.ChanBackups)
+
+	case *lnrpc.RestoreChanBackupRequest_MultiChanBackup:
+		return r.unpackMultiBackup(backup.MultiChanBackup)
+
+	default:
+		return nil, fmt.Errorf("either chan_backups or " +
+			"multi_chan_backup must be specified")
+	}
+}
+
+// RestoreChannelBackups restores the channels within the passed static
+// backups. A shell of each channel is stored, and its peer is requested to
+// force close it so the funds within it can be recovered. Channels which are
+// still open are skipped, as their state wasn't lost.
+func (r *rpcServer) RestoreChannelBackups(ctx context.Context,
+	in *lnrpc.RestoreChanBackupRequest) (*lnrpc.RestoreBackupResponse, error) {
+
+	singles, err := r.unpackChannelBackups(in)
+	if err != nil {
+		return nil, err
+	}
+
+	restoredAt := time.Now()
+	var shells []*channeldb.ChannelShell
+	for _, single := range singles {
+		if _, err := r.fetchOpenChannel(single.FundingOutpoint); err == nil {
+			rpcsLog.Infof("[restorechanbackup] skipping open "+
+				"ChannelPoint(%v)", single.FundingOutpoint)
+			continue
+		}
+
+		shells = append(shells, single.ChannelShell(restoredAt))
+	}
+
+	rpcsLog.Infof("[restorechanbackup] restoring %v channels",
+		len(shells))
+
+	if err := r.server.restoreChannelShells(shells); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.RestoreBackupResponse{
+		NumRestored: uint32(len(shells)),
+	}, nil
+}
+
+// VerifyChanBackup checks that the passed backup snapshot was created by our
+// wallet and is of a known format, without restoring any channels. This
+// allows off-site backups to be validated continuously.
+func (r *rpcServer) VerifyChanBackup(ctx context.Context,
+	in *lnrpc.ChanBackupSnapshot) (*lnrpc.VerifyChanBackupResponse, error) {
+
+	if in.SingleChanBackups == nil && in.MultiChanBackup == nil {
+		return nil, fmt.Errorf("either single_chan_backups or " +
+			"multi_chan_backup must be specified")
+	}
+
+	var singles []chanbackup.Single
+	if in.SingleChanBackups != nil {
+		unpacked, err := r.unpackSingleBackups(in.SingleChanBackups)
+		if err != nil {
+			return nil, err
+		}
+		singles = append(singles, unpacked...)
+	}
+	if in.MultiChanBackup != nil {
+		unpacked, err := r.unpackMultiBackup(
+			in.MultiChanBackup.MultiChanBackup,
+		)
+		if err != nil {
+			return nil, err
+		}
+		singles = append(singles, unpacked...)
+	}
+
+	resp := &lnrpc.VerifyChanBackupResponse{}
+	for _, single := range singles {
+		chanPoint := single.FundingOutpoint
+		resp.ChanPoints = append(resp.ChanPoints, &lnrpc.ChannelPoint{
+			FundingTxid: chanPoint.Hash[:],
+			OutputIndex: chanPoint.Index,
+		})
+	}
+
+	return resp, nil
+}
+
+// AutopilotStatus returns whether the autopilot agent is currently active.
+func (r *rpcServer) AutopilotStatus(ctx context.Context,
+	in *lnrpc.AutopilotStatusRequest) (*lnrpc.AutopilotStatusResponse, error) {
+
+	return &lnrpc.AutopilotStatusResponse{
+		Active: r.server.pilot.Active(),
+	}, nil
+}
+
+// ModifyAutopilotStatus enables or disables the autopilot agent. The change
+// lasts until the daemon restarts, after which the autopilot.active option
+// applies once again.
+func (r *rpcServer) ModifyAutopilotStatus(ctx context.Context,
+	in *lnrpc.ModifyAutopilotStatusRequest) (*lnrpc.ModifyAutopilotStatusResponse, error) {
+
+	rpcsLog.Infof("[modifyautopilotstatus] enable=%v", in.Enable)
+
+	var err error
+	if in.Enable {
+		err = r.server.pilot.Enable()
+	} else {
+		err = r.server.pilot.Disable()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.ModifyAutopilotStatusResponse{}, nil
+}
+
+// QueryAutopilotScores returns the scores the autopilot agent's heuristic
+// assigns to the requested nodes, or to every node within the channel graph
+// if none are requested. Scores can be queried whether or not the agent is
+// active.
+func (r *rpcServer) QueryAutopilotScores(ctx context.Context,
+	in *lnrpc.QueryAutopilotScoresRequest) (*lnrpc.QueryAutopilotScoresResponse, error) {
+
+	nodes, err := parseAutopilotNodeIDs(in.Pubkeys)
+	if err != nil {
+		return nil, err
+	}
+
+	scores, err := r.server.pilot.NodeScores(nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.QueryAutopilotScoresResponse{
+		Heuristic: r.server.pilot.heuristic.Name(),
+		Scores:    marshalAutopilotScores(scores),
+	}, nil
 }
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing"
+	"github.com/roasbeef/btcutil"
+	"golang.org/x/net/context"
+)
+
+// TestNetworkInfoStats asserts that the median channel size and out-degree
+// distribution reported by GetNetworkInfo are computed properly.
+func TestNetworkInfoStats(t *testing.T) {
+	if median := medianAmount(nil); median != 0 {
+		t.Fatalf("expected zero median, got %v", median)
+	}
+
+	oddSizes := []btcutil.Amount{5, 1, 3}
+	if median := medianAmount(oddSizes); median != 3 {
+		t.Fatalf("expected median of 3, got %v", median)
+	}
+	evenSizes := []btcutil.Amount{4, 1, 8, 2}
+	if median := medianAmount(evenSizes); median != 3 {
+		t.Fatalf("expected median of 3, got %v", median)
+	}
+
+	// The passed amounts shouldn't be re-ordered.
+	if evenSizes[0] != 4 {
+		t.Fatalf("amounts were modified: %v", evenSizes)
+	}
+
+	distribution := degreeDistribution([]uint32{3, 0, 1, 3, 0, 3})
+	expected := []*lnrpc.DegreeCount{
+		{OutDegree: 0, NumNodes: 2},
+		{OutDegree: 1, NumNodes: 1},
+		{OutDegree: 3, NumNodes: 3},
+	}
+	if !reflect.DeepEqual(distribution, expected) {
+		t.Fatalf("expected distribution %v, got %v", expected,
+			distribution)
+	}
+}
+
+// TestHexColor asserts that node colors are properly parsed from, and
+// encoded into, their hex representation.
+func TestHexColor(t *testing.T) {
+	nodeColor, err := parseHexColor("#3399ff")
+	if err != nil {
+		t.Fatalf("unable to parse color: %v", err)
+	}
+	if nodeColor.R != 0x33 || nodeColor.G != 0x99 || nodeColor.B != 0xff {
+		t.Fatalf("color parsed incorrectly: %v", nodeColor)
+	}
+	if colorStr := encodeHexColor(nodeColor); colorStr != "#3399ff" {
+		t.Fatalf("expected #3399ff, got %v", colorStr)
+	}
+
+	invalidColors := []string{"", "3399ff", "#3399f", "#3399fg", "#3399ff0"}
+	for _, colorStr := range invalidColors {
+		if _, err := parseHexColor(colorStr); err == nil {
+			t.Fatalf("color %q should be rejected", colorStr)
+		}
+	}
+}
+
+// TestStopDaemon asserts that StopDaemon requests a graceful shutdown without
+// blocking, even if a shutdown is already pending.
+func TestStopDaemon(t *testing.T) {
+	r := &rpcServer{}
+	for i := 0; i < 2; i++ {
+		_, err := r.StopDaemon(context.Background(), &lnrpc.StopRequest{})
+		if err != nil {
+			t.Fatalf("unable to stop daemon: %v", err)
+		}
+	}
+
+	select {
+	case <-shutdownRequestChannel:
+	default:
+		t.Fatalf("shutdown wasn't requested")
+	}
+}
+
+// TestPaymentFeeLimit asserts that the fee limit in millisatoshis of a payment
+// is derived from its send request, or from the default fee limit otherwise,
+// and that percentage-based limits are raised to the fee limit floor.
+func TestPaymentFeeLimit(t *testing.T) {
+	oldCfg := cfg
+	defer func() {
+		cfg = oldCfg
+	}()
+	cfg = &config{
+		FeeLimitPercent: 2,
+		FeeLimitFloor:   10,
+	}
+
+	tests := []struct {
+		req          *lnrpc.SendRequest
+		amt          btcutil.Amount
+		feeLimitMsat int64
+	}{
+		// A fixed fee limit is used as is.
+		{
+			req:          &lnrpc.SendRequest{FeeLimit: 5},
+			amt:          100000,
+			feeLimitMsat: 5000,
+		},
+
+		// As is a fixed fee limit in millisatoshis.
+		{
+			req:          &lnrpc.SendRequest{FeeLimitMsat: 5500},
+			amt:          100000,
+			feeLimitMsat: 5500,
+		},
+
+		// A percentage of the amount is used if set, without being
+		// truncated to a whole satoshi.
+		{
+			req:          &lnrpc.SendRequest{FeeLimitPercent: 5},
+			amt:          100010,
+			feeLimitMsat: 5000500,
+		},
+
+		// Tiny payments are limited to the floor instead.
+		{
+			req:          &lnrpc.SendRequest{FeeLimitPercent: 5},
+			amt:          100,
+			feeLimitMsat: 10000,
+		},
+
+		// Otherwise, the default limit applies.
+		{
+			req:          &lnrpc.SendRequest{},
+			amt:          100000,
+			feeLimitMsat: 2000000,
+		},
+	}
+	for i, test := range tests {
+		feeLimitMsat, err := paymentFeeLimit(test.req, test.amt)
+		if err != nil {
+			t.Fatalf("test %v: unable to compute fee limit: %v", i,
+				err)
+		}
+		if feeLimitMsat != test.feeLimitMsat {
+			t.Fatalf("test %v: expected fee limit %v, got %v", i,
+				test.feeLimitMsat, feeLimitMsat)
+		}
+	}
+
+	// A fixed default limit takes precedence over a percentage.
+	cfg.FeeLimit = 50
+	feeLimitMsat, err := paymentFeeLimit(&lnrpc.SendRequest{}, 100000)
+	if err != nil {
+		t.Fatalf("unable to compute fee limit: %v", err)
+	}
+	if feeLimitMsat != 50000 {
+		t.Fatalf("expected fee limit 50000, got %v", feeLimitMsat)
+	}
+
+	// Setting several limits, or a negative one, is rejected.
+	invalidReqs := []*lnrpc.SendRequest{
+		{FeeLimit: 5, FeeLimitPercent: 5},
+		{FeeLimit: 5, FeeLimitMsat: 5000},
+		{FeeLimitMsat: 5000, FeeLimitPercent: 5},
+		{FeeLimit: -1},
+		{FeeLimitMsat: -1},
+		{FeeLimitPercent: -1},
+	}
+	for _, req := range invalidReqs {
+		if _, err := paymentFeeLimit(req, 100000); err == nil {
+			t.Fatalf("request %v should be rejected", req)
+		}
+	}
+}
+
+// TestPaymentParams asserts that the limits placed upon a payment are taken
+// from its send request, and that requests to split it are rejected.
+func TestPaymentParams(t *testing.T) {
+	params, err := paymentParams(&lnrpc.SendRequest{
+		TimeoutSeconds: 60,
+		CltvLimit:      1440,
+		MaxParts:       1,
+	})
+	if err != nil {
+		t.Fatalf("unable to derive payment params: %v", err)
+	}
+	expectedParams := channeldb.PaymentParams{
+		Timeout:   time.Minute,
+		CltvLimit: 1440,
+		MaxParts:  1,
+	}
+	if params != expectedParams {
+		t.Fatalf("expected params %v, got %v", expectedParams, params)
+	}
+
+	invalidReqs := []*lnrpc.SendRequest{
+		{TimeoutSeconds: -1},
+		{MaxParts: 2},
+	}
+	for _, req := range invalidReqs {
+		if _, err := paymentParams(req); err == nil {
+			t.Fatalf("request %v should be rejected", req)
+		}
+	}
+}
+
+// TestFailedEdges asserts that local failures of a payment attempt are
+// attributed to our own channel, and cancellations relayed back to us to the
+// channels past it.
+func TestFailedEdges(t *testing.T) {
+	route := &routing.Route{
+		Hops: []*routing.Hop{
+			{Channel: &channeldb.ChannelEdge{ChannelID: 1}},
+			{Channel: &channeldb.ChannelEdge{ChannelID: 2}},
+			{Channel: &channeldb.ChannelEdge{ChannelID: 3}},
+		},
+	}
+
+	chanIDs := failedEdges(route, errInsufficientBandwidth)
+	if !reflect.DeepEqual(chanIDs, []uint64{1}) {
+		t.Fatalf("expected local failure over channel 1, got %v",
+			chanIDs)
+	}
+
+	chanIDs = failedEdges(
+		route, lnwire.CancelReason(lnwire.TemporaryChannelFailure),
+	)
+	if !reflect.DeepEqual(chanIDs, []uint64{2, 3}) {
+		t.Fatalf("expected remote failure over channels 2 and 3, "+
+			"got %v", chanIDs)
+	}
+
+	// Payments to ourselves aren't routed over any channel.
+	chanIDs = failedEdges(&routing.Route{}, errors.New("no route"))
+	if chanIDs != nil {
+		t.Fatalf("expected no failed channels, got %v", chanIDs)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"image/color"
 	"net"
 	"sync"
 	"sync/atomic"
@@ -13,12 +14,21 @@ import (
 	"github.com/lightningnetwork/lightning-onion"
 	"github.com/lightningnetwork/lnd/brontide"
 	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/chanfitness"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/chanstatus"
+	"github.com/lightningnetwork/lnd/feature"
+	"github.com/lightningnetwork/lnd/feemanager"
+	"github.com/lightningnetwork/lnd/hodl"
+	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/monitoring"
 	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcd/connmgr"
+	"github.com/roasbeef/btcd/wire"
 	"github.com/roasbeef/btcutil"
 
 	"github.com/lightningnetwork/lnd/routing"
@@ -32,18 +42,37 @@ type server struct {
 	started  int32 // atomic
 	shutdown int32 // atomic
 
-	// identityPriv is the private key used to authenticate any incoming
-	// connections.
-	identityPriv *btcec.PrivateKey
+	// nodeKey is our node's identity key, used to authenticate any
+	// incoming connections and to sign our announcements. Its private key
+	// is held by the remote signer in watch-only mode.
+	nodeKey keychain.NodeKey
 
 	// lightningID is the sha256 of the public key corresponding to our
 	// long-term identity private key.
 	lightningID [32]byte
 
+	// currentNodeAnn is the latest node announcement we've signed, which
+	// advertises our alias, color, and address to the network. It's
+	// guarded by the nodeAnnMtx.
+	nodeAnnMtx     sync.Mutex
+	currentNodeAnn *lnwire.NodeAnnouncement
+
+	// chanEventStore records the connectivity of our peers and the
+	// activity of our channels, from which the uptime of each channel is
+	// derived.
+	chanEventStore *chanfitness.ChannelEventStore
+
 	peersMtx   sync.RWMutex
 	peersByID  map[int32]*peer
 	peersByPub map[string]*peer
 
+	// connImpairments are the simulated network impairments applied to
+	// the connections to our peers, keyed by their serialized public
+	// keys. As they're kept by the server, they persist across
+	// reconnections.
+	impairmentMtx   sync.RWMutex
+	connImpairments map[string]*connImpairment
+
 	rpcServer *rpcServer
 
 	chainNotifier chainntnfs.ChainNotifier
@@ -51,6 +80,10 @@ type server struct {
 	bio      lnwallet.BlockChainIO
 	lnwallet *lnwallet.LightningWallet
 
+	// feeEstimator is used to estimate the fee rate required for an
+	// on-chain transaction to confirm within a target number of blocks.
+	feeEstimator lnwallet.FeeEstimator
+
 	fundingMgr *fundingManager
 	chanDB     *channeldb.DB
 
@@ -58,17 +91,85 @@ type server struct {
 	invoices      *invoiceRegistry
 	breachArbiter *breachArbiter
 
+	// invoiceExpiries cancels our open invoices once they expire.
+	invoiceExpiries *invoiceExpiryWatcher
+
 	chanRouter *routing.ChannelRouter
 
 	utxoNursery *utxoNursery
 
-	sphinx *sphinx.Router
+	// shellSweeper recovers our balance within channels restored from a
+	// static backup once their peers force close them.
+	shellSweeper *shellSweeper
+
+	sphinx onionProcessor
+
+	// onionCache caches the result of processing each onion received
+	// within an HTLC, so that retransmitted HTLC's aren't rejected as
+	// replays.
+	onionCache *onionResultCache
+
+	// htlcModifier allows an external process to modify the HTLCs
+	// arriving for our invoices.
+	htlcModifier *htlcModifier
+
+	// featureMgr dispatches the feature vectors we advertise to peers
+	// within the various feature sets.
+	featureMgr *feature.Manager
+
+	// customMessages dispatches custom messages received from our peers
+	// to any subscribed RPC clients.
+	customMessages *customMessageRouter
+
+	// channelNotifier dispatches events concerning our channels to any
+	// subscribed RPC clients.
+	channelNotifier *channelNotifier
+
+	// peerNotifier dispatches events concerning the connectivity of our
+	// peers to any subscribed RPC clients.
+	peerNotifier *peerNotifier
+
+	// htlcNotifier dispatches events concerning the HTLC's forwarded,
+	// failed, and settled by the htlcSwitch to any subscribed RPC
+	// clients.
+	htlcNotifier *htlcNotifier
+
+	// paymentNotifier dispatches the updates to the records of our
+	// outgoing payments to any subscribed RPC clients.
+	paymentNotifier *paymentNotifier
+
+	// pilot manages the autopilot agent, which opens channels on our
+	// behalf while enabled.
+	pilot *autopilotManager
+
+	// feeManager adjusts the fees of the channels which opted into fee
+	// management. It's nil unless the fee manager is active.
+	feeManager *feemanager.Manager
+
+	// chanStatusMgr disables and re-enables our channels, either manually
+	// or as their peers go offline and come back online.
+	chanStatusMgr *chanstatus.Manager
+
+	// hodlMask is the set of hodl points at which our links will
+	// intentionally hold HTLC updates. It's only ever non-empty within
+	// dev builds.
+	hodlMask hodl.Mask
 
 	connMgr *connmgr.ConnManager
 
+	// peerAccess decides which peers we accept connections and channels
+	// from.
+	peerAccess *peerAccessManager
+
 	pendingConnMtx     sync.RWMutex
 	persistentConnReqs map[string]*connmgr.ConnReq
 
+	// peerBackoffs is the reconnection backoff state of our persistent
+	// peers, keyed by their serialized compressed public key. It's
+	// persisted so that it survives restarts.
+	peerBackoffMtx sync.Mutex
+	peerBackoffs   map[string]*channeldb.PeerBackoff
+
 	broadcastRequests chan *broadcastReq
 	sendRequests      chan *sendReq
 
@@ -80,48 +181,101 @@ type server struct {
 	quit chan struct{}
 }
 
+// onionProcessor processes the onion packets of the HTLCs offered to us using
+// our node key. It's implemented by sphinx.Router, and by the remote signer
+// when it holds our node key.
+type onionProcessor interface {
+	// ProcessOnionPacket processes the passed onion packet, which
+	// commits to the passed associated data, returning the routing
+	// instructions it holds for us.
+	ProcessOnionPacket(onionPkt *sphinx.OnionPacket,
+		assocData []byte) (*sphinx.ProcessedPacket, error)
+}
+
 // newServer creates a new instance of the server which is to listen using the
-// passed listener address.
+// passed listener address. The passed node key identifies our node within the
+// network, with the passed onionProcessor processing the onion packets sent
+// to it. The passed rpcServer is bound to the new server.
 func newServer(listenAddrs []string, notifier chainntnfs.ChainNotifier,
 	bio lnwallet.BlockChainIO, wallet *lnwallet.LightningWallet,
-	chanDB *channeldb.DB) (*server, error) {
-
-	privKey, err := wallet.GetIdentitykey()
-	if err != nil {
-		return nil, err
-	}
+	feeEstimator lnwallet.FeeEstimator, nodeKey keychain.NodeKey,
+	onion onionProcessor, chanDB *channeldb.DB,
+	rpcServer *rpcServer) (*server, error) {
 
+	var err error
 	listeners := make([]net.Listener, len(listenAddrs))
 	for i, addr := range listenAddrs {
-		listeners[i], err = brontide.NewListener(privKey, addr)
+		listeners[i], err = brontide.NewListener(nodeKey, addr)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	serializedPubKey := privKey.PubKey().SerializeCompressed()
+	featureMgr, err := feature.NewManager(feature.Config{
+		NoWumbo: !cfg.Protocol.WumboChannels,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	peerBackoffs, err := chanDB.FetchPeerBackoffs()
+	if err != nil {
+		return nil, err
+	}
+
+	peerAccess, err := newPeerAccessManager(chanDB, cfg.AllowlistOnly,
+		cfg.bannedPeers, cfg.allowedPeers)
+	if err != nil {
+		return nil, err
+	}
+
+	serializedPubKey := nodeKey.PubKey().SerializeCompressed()
+	invoices := newInvoiceRegistry(chanDB, cfg.InvoiceAcceptTimeout,
+		cfg.MaxOpenInvoices, cfg.MaxInvoiceHtlcs)
+	htlcNotifier := newHtlcNotifier()
 	s := &server{
 		lnwallet:      wallet,
 		bio:           bio,
 		chainNotifier: notifier,
 		chanDB:        chanDB,
 
-		invoices:    newInvoiceRegistry(chanDB),
-		utxoNursery: newUtxoNursery(chanDB, notifier, wallet),
-		htlcSwitch:  newHtlcSwitch(),
+		feeEstimator: feeEstimator,
+
+		invoices:        invoices,
+		invoiceExpiries: newInvoiceExpiryWatcher(invoices),
+		htlcSwitch: newHtlcSwitch(notifier, bio, invoices,
+			cfg.TimeLockDelta, htlcNotifier, cfg.HtlcLimits,
+			cfg.Reputation),
+
+		nodeKey: nodeKey,
+
+		featureMgr:     featureMgr,
+		hodlMask:       cfg.Hodl.Mask(),
+		customMessages: newCustomMessageRouter(cfg.customMsgRanges),
 
-		identityPriv: privKey,
+		channelNotifier: newChannelNotifier(),
+		peerNotifier:    newPeerNotifier(),
+		htlcNotifier:    htlcNotifier,
+		paymentNotifier: newPaymentNotifier(),
 
-		// TODO(roasbeef): derive proper onion key based on rotation
-		// schedule
-		sphinx:      sphinx.NewRouter(privKey, activeNetParams.Params),
-		lightningID: fastsha256.Sum256(serializedPubKey),
+		sphinx:       onion,
+		onionCache:   newOnionResultCache(notifier, bio),
+		htlcModifier: newHtlcModifier(defaultHtlcModifierTimeout),
+		lightningID:  fastsha256.Sum256(serializedPubKey),
 
+		peerAccess:         peerAccess,
 		persistentConnReqs: make(map[string]*connmgr.ConnReq),
+		peerBackoffs:       peerBackoffs,
 
 		peersByID:  make(map[int32]*peer),
 		peersByPub: make(map[string]*peer),
 
+		connImpairments: make(map[string]*connImpairment),
+
+		chanEventStore: chanfitness.NewChannelEventStore(
+			&chanfitness.Config{},
+		),
+
 		newPeers:  make(chan *peer, 10),
 		donePeers: make(chan *peer, 10),
 
@@ -132,6 +286,11 @@ func newServer(listenAddrs []string, notifier chainntnfs.ChainNotifier,
 		quit:    make(chan struct{}),
 	}
 
+	// The nursery prices its sweeps using the fee estimator of the server.
+	s.utxoNursery = newUtxoNursery(chanDB, notifier, wallet, s.feeEstimator)
+	s.shellSweeper = newShellSweeper(chanDB, notifier, wallet,
+		s.feeEstimator)
+
 	// If the debug HTLC flag is on, then we invoice a "master debug"
 	// invoice which all outgoing payments will be sent and all incoming
 	// HTLC's with the debug R-Hash immediately settled.
@@ -142,38 +301,73 @@ func newServer(listenAddrs []string, notifier chainntnfs.ChainNotifier,
 			debugPre[:], debugHash[:])
 	}
 
+	if s.hodlMask != hodl.MaskNone {
+		srvrLog.Warnf("Links will hold HTLC updates at: %v", s.hodlMask)
+	}
+
 	// TODO(roasbeef): add --externalip flag?
 	selfAddr, ok := listeners[0].Addr().(*net.TCPAddr)
 	if !ok {
 		return nil, fmt.Errorf("default listener must be TCP")
 	}
 
-	chanGraph := chanDB.ChannelGraph()
-	self := &channeldb.LightningNode{
-		LastUpdate: time.Now(),
-		Address:    selfAddr,
-		PubKey:     privKey.PubKey(),
-		// TODO(roasbeef): make alias configurable
-		Alias: hex.EncodeToString(serializedPubKey[:10]),
+	// If no alias was configured, then we'll fall back to a prefix of our
+	// public key.
+	alias := cfg.Alias
+	if alias == "" {
+		alias = hex.EncodeToString(serializedPubKey[:10])
 	}
-	if err := chanGraph.SetSourceNode(self); err != nil {
+	nodeAlias, err := lnwire.NewAlias(alias)
+	if err != nil {
 		return nil, err
 	}
 
+	// With our initial node announcement assembled, sign it, setting our
+	// node as the source node within the channel graph.
+	s.currentNodeAnn = &lnwire.NodeAnnouncement{
+		Address: selfAddr,
+		NodeID:  nodeKey.PubKey(),
+		RGBColor: lnwire.NewRGB(cfg.color.R, cfg.color.G,
+			cfg.color.B),
+		Alias: nodeAlias,
+	}
+	if _, err := s.genNodeAnnouncement(); err != nil {
+		return nil, err
+	}
+
+	chanGraph := chanDB.ChannelGraph()
+
 	s.chanRouter, err = routing.New(routing.Config{
 		Graph:        chanGraph,
 		Chain:        bio,
 		Notifier:     notifier,
 		Broadcast:    s.broadcastMessage,
 		SendMessages: s.sendToPeer,
+
+		NumValidationWorkers: cfg.GraphValidationWorkers,
+		Estimator:            cfg.Routing.probabilityEstimator,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	s.rpcServer = newRpcServer(s)
-	s.breachArbiter = newBreachArbiter(wallet, chanDB, notifier, s.htlcSwitch)
-	s.fundingMgr = newFundingManager(wallet, s.breachArbiter)
+	rpcServer.server = s
+	s.rpcServer = rpcServer
+	s.pilot, err = newAutopilotManager(s, cfg.Autopilot)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.FeeManager.Active {
+		s.feeManager = newFeeManager(s, cfg.FeeManager)
+	}
+	s.chanStatusMgr = newChanStatusManager(s, cfg.ChanStatus)
+	s.breachArbiter = newBreachArbiter(wallet, chanDB, notifier,
+		s.htlcSwitch, s.channelNotifier, s.shellSweeper)
+	s.fundingMgr = newFundingManager(&fundingConfig{
+		Wallet:        wallet,
+		BreachArbiter: s.breachArbiter,
+		CheckPeer:     s.peerAccess.checkPeer,
+	})
 
 	// TODO(roasbeef): introduce closure and config system to decouple the
 	// initialization above ^
@@ -187,7 +381,7 @@ func newServer(listenAddrs []string, notifier chainntnfs.ChainNotifier,
 		RetryDuration:  time.Second * 5,
 		TargetOutbound: 100,
 		GetNewAddress:  nil,
-		Dial:           noiseDial(s.identityPriv),
+		Dial:           noiseDial(s.nodeKey),
 		OnConnection:   s.outboundPeerConnected,
 	})
 	if err != nil {
@@ -215,18 +409,245 @@ func newServer(listenAddrs []string, notifier chainntnfs.ChainNotifier,
 
 		// Send the persistent connection request to the connection
 		// manager, saving the request itself so we can
-		// cancel/restart the process as needed.
+		// cancel/restart the process as needed. If the peer was
+		// flapping before we restarted, the connection is delayed
+		// until its backoff has elapsed.
 		connReq := &connmgr.ConnReq{
 			Addr:      lnAddr,
 			Permanent: true,
 		}
-		s.persistentConnReqs[pubStr] = connReq
-		go s.connMgr.Connect(connReq)
+		s.connectPersistentPeer(pubStr, connReq)
+	}
+
+	// We'll also maintain persistent connections to the peers of any
+	// channels restored from a static backup, so they can be requested
+	// to close them.
+	shells, err := s.chanDB.FetchChannelShells(nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, shell := range shells {
+		pubStr := string(shell.RemotePub.SerializeCompressed())
+		if _, ok := s.persistentConnReqs[pubStr]; ok {
+			continue
+		}
+		if len(shell.Addresses) == 0 {
+			continue
+		}
+
+		lnAddr := &lnwire.NetAddress{
+			IdentityKey: shell.RemotePub,
+			Address:     shell.Addresses[0],
+			ChainNet:    activeNetParams.Net,
+		}
+		srvrLog.Debugf("Attempting persistent connection to restored "+
+			"channel peer %v", lnAddr)
+
+		connReq := &connmgr.ConnReq{
+			Addr:      lnAddr,
+			Permanent: true,
+		}
+		s.connectPersistentPeer(pubStr, connReq)
+	}
+
+	if err := s.prunePeerBackoffs(); err != nil {
+		return nil, err
 	}
 
 	return s, nil
 }
 
+// restoreChannelShells stores the passed shells of channels restored from a
+// static backup, then reaches out to the peer of each channel so it can be
+// requested to close it. Upon restarts, persistent connections to the peers
+// are maintained until their shells are removed.
+func (s *server) restoreChannelShells(shells []*channeldb.ChannelShell) error {
+	if err := s.chanDB.AddChannelShells(shells); err != nil {
+		return err
+	}
+
+	// Our balance within static remote key channels can be swept once
+	// they're closed, so we'll watch for their closure right away.
+	s.shellSweeper.watchShells(shells)
+
+	// As all the channels with a peer are requested to be closed at once,
+	// each peer only needs to be reached out to once.
+	peers := make(map[string]struct{})
+	for _, shell := range shells {
+		pubStr := string(shell.RemotePub.SerializeCompressed())
+		if _, ok := peers[pubStr]; ok {
+			continue
+		}
+		peers[pubStr] = struct{}{}
+
+		// If we're already connected to the peer, then we can request
+		// that it closes the channels right away.
+		s.peersMtx.RLock()
+		p, ok := s.peersByPub[pubStr]
+		s.peersMtx.RUnlock()
+		if ok {
+			p.requestRestoredChanClosures()
+			continue
+		}
+
+		// Otherwise, we'll connect to it, after which the request is
+		// sent as the peer starts. Each address is attempted until a
+		// connection succeeds.
+		go func(shell *channeldb.ChannelShell) {
+			for _, addr := range shell.Addresses {
+				lnAddr := &lnwire.NetAddress{
+					IdentityKey: shell.RemotePub,
+					Address:     addr,
+					ChainNet:    activeNetParams.Net,
+				}
+				err := s.ConnectToPeer(lnAddr, false)
+				if err == nil {
+					return
+				}
+
+				srvrLog.Errorf("Unable to connect to restored "+
+					"channel peer %v: %v", lnAddr, err)
+			}
+		}(shell)
+	}
+
+	return nil
+}
+
+// monitoringSources returns the callbacks through which the monitoring
+// subsystem queries the state of our channels, invoices, and chain backend.
+func (s *server) monitoringSources() *monitoring.Sources {
+	return &monitoring.Sources{
+		ChannelBalances: func() (btcutil.Amount, btcutil.Amount, error) {
+			channels, err := s.chanDB.FetchAllChannels()
+			if err != nil {
+				return 0, 0, err
+			}
+
+			var local, remote btcutil.Amount
+			for _, channel := range channels {
+				local += channel.OurBalance
+				remote += channel.TheirBalance
+			}
+
+			return local, remote, nil
+		},
+		InvoiceCounts: func() (int, int, error) {
+			invoices, err := s.chanDB.FetchAllInvoices(false)
+			switch {
+			case err == channeldb.ErrNoInvoicesCreated:
+				return 0, 0, nil
+			case err != nil:
+				return 0, 0, err
+			}
+
+			var open, settled int
+			for _, invoice := range invoices {
+				if invoice.Terms.Settled {
+					settled++
+				} else {
+					open++
+				}
+			}
+
+			return open, settled, nil
+		},
+		ChainBackendHealthy: func() error {
+			_, _, err := s.bio.GetBestBlock()
+			return err
+		},
+	}
+}
+
+// nodeAnnModifier is a closure which modifies a field of our node
+// announcement prior to it being re-signed.
+type nodeAnnModifier func(*lnwire.NodeAnnouncement)
+
+// nodeAnnSetAlias is a nodeAnnModifier which sets the alias of our node.
+func nodeAnnSetAlias(alias lnwire.Alias) nodeAnnModifier {
+	return func(ann *lnwire.NodeAnnouncement) {
+		ann.Alias = alias
+	}
+}
+
+// nodeAnnSetColor is a nodeAnnModifier which sets the color of our node.
+func nodeAnnSetColor(rgb lnwire.RGB) nodeAnnModifier {
+	return func(ann *lnwire.NodeAnnouncement) {
+		ann.RGBColor = rgb
+	}
+}
+
+// nodeAnnSetAddr is a nodeAnnModifier which sets the address our node is
+// reachable at.
+func nodeAnnSetAddr(addr *net.TCPAddr) nodeAnnModifier {
+	return func(ann *lnwire.NodeAnnouncement) {
+		ann.Address = addr
+	}
+}
+
+// genNodeAnnouncement applies the passed modifiers to our current node
+// announcement, then signs the result with a fresh timestamp. Our node within
+// the channel graph is updated to reflect the new announcement, which is
+// returned so the caller may broadcast it.
+func (s *server) genNodeAnnouncement(
+	modifiers ...nodeAnnModifier) (*lnwire.NodeAnnouncement, error) {
+
+	s.nodeAnnMtx.Lock()
+	defer s.nodeAnnMtx.Unlock()
+
+	newAnn := *s.currentNodeAnn
+	for _, modifier := range modifiers {
+		modifier(&newAnn)
+	}
+
+	// The timestamp of each announcement must be strictly greater than
+	// that of the last, otherwise the network will ignore it as stale.
+	timestamp := uint32(time.Now().Unix())
+	if timestamp <= s.currentNodeAnn.Timestamp {
+		timestamp = s.currentNodeAnn.Timestamp + 1
+	}
+	newAnn.Timestamp = timestamp
+
+	data, err := newAnn.DataToSign()
+	if err != nil {
+		return nil, err
+	}
+	newAnn.Signature, err = s.nodeKey.SignDigest(chainhash.DoubleHashB(data))
+	if err != nil {
+		return nil, err
+	}
+
+	red, green, blue := newAnn.RGBColor.Components()
+	self := &channeldb.LightningNode{
+		LastUpdate: time.Unix(int64(newAnn.Timestamp), 0),
+		Address:    newAnn.Address,
+		PubKey:     newAnn.NodeID,
+		Color:      color.RGBA{R: red, G: green, B: blue},
+		Alias:      newAnn.Alias.String(),
+	}
+	if err := s.chanDB.ChannelGraph().SetSourceNode(self); err != nil {
+		return nil, err
+	}
+
+	s.currentNodeAnn = &newAnn
+
+	return &newAnn, nil
+}
+
+// updateNodeAnnouncement re-signs our node announcement after applying the
+// passed modifiers, and broadcasts it to all connected peers.
+func (s *server) updateNodeAnnouncement(modifiers ...nodeAnnModifier) error {
+	nodeAnn, err := s.genNodeAnnouncement(modifiers...)
+	if err != nil {
+		return err
+	}
+
+	srvrLog.Infof("Broadcasting updated node announcement: alias=%v",
+		nodeAnn.Alias.String())
+
+	return s.broadcastMessage(nil, nodeAnn)
+}
+
 // Start starts the main daemon server, all requested listeners, and any helper
 // goroutines.
 func (s *server) Start() error {
@@ -253,18 +674,53 @@ func (s *server) Start() error {
 	if err := s.htlcSwitch.Start(); err != nil {
 		return err
 	}
+	if err := s.onionCache.Start(); err != nil {
+		return err
+	}
+	if err := s.invoiceExpiries.Start(); err != nil {
+		return err
+	}
 	if err := s.utxoNursery.Start(); err != nil {
 		return err
 	}
+	if err := s.shellSweeper.Start(); err != nil {
+		return err
+	}
 	if err := s.breachArbiter.Start(); err != nil {
 		return err
 	}
 	if err := s.chanRouter.Start(); err != nil {
 		return err
 	}
+	if err := s.chanEventStore.Start(); err != nil {
+		return err
+	}
+	if s.feeManager != nil {
+		if err := s.feeManager.Start(); err != nil {
+			return err
+		}
+	}
+	if err := s.chanStatusMgr.Start(); err != nil {
+		return err
+	}
+
+	// Begin monitoring each of our existing channels. We subscribe to
+	// channel events beforehand, so no channel opened in the mean time is
+	// missed.
+	chanEvents := s.channelNotifier.SubscribeChannelEvents()
+	channels, err := s.chanDB.FetchAllChannels()
+	if err != nil && err != channeldb.ErrNoActiveChannels {
+		chanEvents.Cancel()
+		return err
+	}
+	for _, channel := range channels {
+		s.chanEventStore.AddChannel(*channel.ChanID,
+			channel.IdentityPub.SerializeCompressed())
+	}
 
-	s.wg.Add(1)
+	s.wg.Add(2)
 	go s.queryHandler()
+	go s.channelEventTracker(chanEvents)
 
 	return nil
 }
@@ -278,14 +734,34 @@ func (s *server) Stop() error {
 		return nil
 	}
 
-	// Shutdown the wallet, funding manager, and the rpc server.
-	s.chainNotifier.Stop()
+	// Subsystems are stopped in dependency order. First, we stop taking
+	// on new work: RPC requests, new channels, and channel updates.
+	s.pilot.Disable()
 	s.rpcServer.Stop()
 	s.fundingMgr.Stop()
-	s.chanRouter.Stop()
+	if s.feeManager != nil {
+		s.feeManager.Stop()
+	}
+	s.chanStatusMgr.Stop()
+
+	// Next, we drain the channels of all peers, then stop them, so that
+	// their links no longer accept new HTLC's, nor forward any to the
+	// switch once it has stopped.
+	s.stopPeers()
 	s.htlcSwitch.Stop()
+	s.onionCache.Stop()
+	s.invoiceExpiries.Stop()
+
+	// With no channel activity left, the subsystems watching the chain on
+	// behalf of our channels can be stopped, followed by the chain
+	// notifier they depend upon, and finally the wallet. The database is
+	// closed last, once the server has stopped.
+	s.chanRouter.Stop()
 	s.utxoNursery.Stop()
+	s.shellSweeper.Stop()
 	s.breachArbiter.Stop()
+	s.chanEventStore.Stop()
+	s.chainNotifier.Stop()
 
 	s.lnwallet.Shutdown()
 
@@ -296,6 +772,68 @@ func (s *server) Stop() error {
 	return nil
 }
 
+// stopPeers drains the channels of all connected peers, then stops them,
+// waiting for their goroutines to exit. Draining is bounded by half of the
+// shutdown timeout, leaving the remainder for the other subsystems to stop.
+func (s *server) stopPeers() {
+	s.peersMtx.RLock()
+	peers := make([]*peer, 0, len(s.peersByPub))
+	for _, peer := range s.peersByPub {
+		peers = append(peers, peer)
+	}
+	s.peersMtx.RUnlock()
+
+	s.drainPeers(peers, cfg.ShutdownTimeout/2)
+
+	for _, peer := range peers {
+		peer.Stop()
+	}
+}
+
+// drainPeers drains the channels of the passed peers, so that no commitment
+// transition is left midway once the connections are closed. New HTLC's are
+// failed in the meantime. It returns once all channels have been drained, or
+// the timeout expires.
+func (s *server) drainPeers(peers []*peer, timeout time.Duration) {
+	var wg sync.WaitGroup
+	for _, p := range peers {
+		p.htlcManMtx.RLock()
+		chanPoints := make([]wire.OutPoint, 0, len(p.linkControls))
+		for chanPoint := range p.linkControls {
+			chanPoints = append(chanPoints, chanPoint)
+		}
+		p.htlcManMtx.RUnlock()
+
+		for _, chanPoint := range chanPoints {
+			wg.Add(1)
+			go func(p *peer, chanPoint wire.OutPoint) {
+				defer wg.Done()
+
+				req := &linkControlReq{op: linkShutdown}
+				err := p.controlLink(chanPoint, req)
+				if err != nil {
+					srvrLog.Warnf("Unable to drain "+
+						"ChannelPoint(%v): %v",
+						chanPoint, err)
+				}
+			}(p, chanPoint)
+		}
+	}
+
+	drained := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+	case <-time.After(timeout):
+		srvrLog.Warnf("Channels weren't drained within %v, stopping "+
+			"peers regardless", timeout)
+	}
+}
+
 // WaitForShutdown blocks all goroutines have been stopped.
 func (s *server) WaitForShutdown() {
 	s.wg.Wait()
@@ -402,7 +940,19 @@ func (s *server) peerConnected(conn net.Conn, connReq *connmgr.ConnReq, inbound
 	// TODO(roasbeef): update IP address for link-node
 	//  * also mark last-seen, do it one single transaction?
 
-	peer.Start()
+	// Starting the peer exchanges Init messages with the remote node. If
+	// the remote node requires features we don't understand, or sends an
+	// inconsistent feature vector, then we'll drop the connection.
+	if err := peer.Start(); err != nil {
+		srvrLog.Errorf("unable to start peer %v: %v", peer, err)
+
+		// Stopping the peer closes the connection and halts any
+		// channel goroutines launched when its channels were loaded,
+		// so we only need to remove its links from the switch.
+		peer.Stop()
+		s.htlcSwitch.UnregisterLink(peerAddr.IdentityKey, nil)
+		return
+	}
 	s.newPeers <- peer
 }
 
@@ -427,6 +977,16 @@ func (s *server) inboundPeerConnected(conn net.Conn) {
 		return
 	}
 
+	// Refuse the connection if the peer is banned, or isn't allowlisted
+	// while running in allowlist only mode.
+	remoteAddr, _ := conn.RemoteAddr().(*net.TCPAddr)
+	if err := s.peerAccess.checkPeer(nodePub, remoteAddr); err != nil {
+		srvrLog.Infof("Refusing inbound connection from peer %x: %v",
+			nodePub.SerializeCompressed(), err)
+		conn.Close()
+		return
+	}
+
 	// However, if we receive an incoming connection from a peer we're
 	// attempting to maintain a persistent connection with then we need to
 	// cancel the ongoing connection attempts to ensure that we don't end
@@ -461,6 +1021,20 @@ func (s *server) outboundPeerConnected(connReq *connmgr.ConnReq, conn net.Conn)
 		return
 	}
 
+	// Refuse the connection if the peer is banned, or isn't allowlisted
+	// while running in allowlist only mode, ceasing any further attempts
+	// to connect to it.
+	remoteAddr, _ := conn.RemoteAddr().(*net.TCPAddr)
+	if err := s.peerAccess.checkPeer(nodePub, remoteAddr); err != nil {
+		srvrLog.Infof("Refusing outbound connection to peer %x: %v",
+			nodePub.SerializeCompressed(), err)
+		conn.Close()
+		if connReq != nil {
+			s.connMgr.Remove(connReq.ID())
+		}
+		return
+	}
+
 	s.peerConnected(conn, connReq, true)
 }
 
@@ -486,6 +1060,14 @@ func (s *server) addPeer(p *peer) {
 	s.peersByPub[string(p.addr.IdentityKey.SerializeCompressed())] = p
 	s.peersMtx.Unlock()
 
+	if s.isPersistentPeer(p.addr.IdentityKey) {
+		s.recordPeerConnected(p.addr.IdentityKey)
+	}
+
+	s.chanEventStore.PeerOnline(p.addr.IdentityKey.SerializeCompressed())
+	s.peerNotifier.notifyPeerOnline(p.addr.IdentityKey)
+	s.chanStatusMgr.PeerOnline(p.addr.IdentityKey)
+
 	// Once the peer has been added to our indexes, send a message to the
 	// channel router so we can synchronize our view of the channel graph
 	// with this new peer.
@@ -496,22 +1078,150 @@ func (s *server) addPeer(p *peer) {
 // peers.
 func (s *server) removePeer(p *peer) {
 	s.peersMtx.Lock()
-	defer s.peersMtx.Unlock()
 
 	srvrLog.Debugf("removing peer %v", p)
 
 	if p == nil {
+		s.peersMtx.Unlock()
 		return
 	}
 
 	// Ignore deleting peers if we're shutting down.
 	if atomic.LoadInt32(&s.shutdown) != 0 {
+		s.peersMtx.Unlock()
 		p.Stop()
 		return
 	}
 
 	delete(s.peersByID, p.id)
 	delete(s.peersByPub, string(p.addr.IdentityKey.SerializeCompressed()))
+	s.peersMtx.Unlock()
+
+	// The backoff of the peer is persisted once the peers mutex has been
+	// released, so that the write doesn't hold up connection handling.
+	if s.isPersistentPeer(p.addr.IdentityKey) {
+		s.recordPeerDisconnected(p.addr.IdentityKey)
+	}
+
+	s.chanEventStore.PeerOffline(p.addr.IdentityKey.SerializeCompressed())
+	s.peerNotifier.notifyPeerOffline(p.addr.IdentityKey)
+	s.chanStatusMgr.PeerOffline(p.addr.IdentityKey)
+}
+
+// channelEventTracker records the events concerning our channels within the
+// channel event store, so their uptime can be derived.
+//
+// NOTE: This MUST be run as a goroutine.
+func (s *server) channelEventTracker(sub *eventSubscription) {
+	defer s.wg.Done()
+	defer func() {
+		sub.Cancel()
+	}()
+
+	for {
+		select {
+		case e := <-sub.Events:
+			event := e.(*channelEvent)
+			switch event.eventType {
+			case lnrpc.ChannelEventUpdate_OPEN_CHANNEL:
+				s.chanEventStore.AddChannel(event.chanPoint,
+					event.remotePub.SerializeCompressed())
+
+			case lnrpc.ChannelEventUpdate_CLOSED_CHANNEL:
+				s.chanEventStore.RemoveChannel(event.chanPoint)
+
+			case lnrpc.ChannelEventUpdate_ACTIVE_CHANNEL:
+				s.chanEventStore.ChannelActive(event.chanPoint)
+
+			case lnrpc.ChannelEventUpdate_INACTIVE_CHANNEL:
+				s.chanEventStore.ChannelInactive(event.chanPoint)
+			}
+
+		// If we failed to keep up with the events, then we subscribe
+		// once again, and begin monitoring any channels opened in
+		// the mean time.
+		case <-sub.Overflow:
+			srvrLog.Warnf("Channel event tracker fell behind, " +
+				"resubscribing")
+
+			sub = s.channelNotifier.SubscribeChannelEvents()
+			channels, err := s.chanDB.FetchAllChannels()
+			if err != nil && err != channeldb.ErrNoActiveChannels {
+				srvrLog.Errorf("Unable to fetch channels: %v",
+					err)
+				continue
+			}
+			for _, channel := range channels {
+				s.chanEventStore.AddChannel(*channel.ChanID,
+					channel.IdentityPub.SerializeCompressed())
+			}
+
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// isPeerConnected returns true if we're currently connected to the peer with
+// the passed serialized public key.
+func (s *server) isPeerConnected(pubKey []byte) bool {
+	s.peersMtx.RLock()
+	defer s.peersMtx.RUnlock()
+
+	_, ok := s.peersByPub[string(pubKey)]
+	return ok
+}
+
+// findPeer returns the peer with the passed serialized public key, if we're
+// currently connected to it.
+func (s *server) findPeer(pubKey []byte) (*peer, bool) {
+	s.peersMtx.RLock()
+	defer s.peersMtx.RUnlock()
+
+	p, ok := s.peersByPub[string(pubKey)]
+	return p, ok
+}
+
+// controlChannelLink applies the passed operation to the link of the active
+// channel with the passed funding outpoint, whichever peer it's with.
+func (s *server) controlChannelLink(chanPoint wire.OutPoint,
+	req *linkControlReq) error {
+
+	for _, p := range s.Peers() {
+		p.htlcManMtx.RLock()
+		_, ok := p.linkControls[chanPoint]
+		p.htlcManMtx.RUnlock()
+		if !ok {
+			continue
+		}
+
+		return p.controlLink(chanPoint, req)
+	}
+
+	return fmt.Errorf("channel %v isn't active", chanPoint)
+}
+
+// setConnImpairment applies the passed impairment to the connection to the
+// peer with the passed serialized public key, replacing any prior one. If the
+// impairment is nil, then the connection is no longer impaired.
+func (s *server) setConnImpairment(pubStr string, impairment *connImpairment) {
+	s.impairmentMtx.Lock()
+	defer s.impairmentMtx.Unlock()
+
+	if impairment == nil {
+		delete(s.connImpairments, pubStr)
+		return
+	}
+	s.connImpairments[pubStr] = impairment
+}
+
+// connImpairment returns the impairment applied to the connection to the peer
+// with the passed serialized public key, if any.
+func (s *server) connImpairment(pubStr string) *connImpairment {
+	s.impairmentMtx.RLock()
+	defer s.impairmentMtx.RUnlock()
+
+	return s.connImpairments[pubStr]
 }
 
 // connectPeerMsg is a message requesting the server to open a connection to a
@@ -548,6 +1258,18 @@ type openChanReq struct {
 
 	numConfs uint32
 
+	// coinSelection, if non-nil, dictates how the coins funding the
+	// channel are chosen from the wallet.
+	coinSelection *lnwallet.CoinSelection
+
+	// constraints are the channel constraints to impose upon the remote
+	// party. Any left unset fall back to the configured defaults.
+	constraints channeldb.ChannelConstraints
+
+	// deliveryAddr, if non-nil, is the upfront shutdown address our funds
+	// are paid to upon a cooperative close of the channel.
+	deliveryAddr btcutil.Address
+
 	updates chan *lnrpc.OpenStatusUpdate
 	err     chan error
 }
@@ -668,6 +1390,13 @@ func (s *server) handleConnectPeer(msg *connectPeerMsg) {
 	}
 	s.peersMtx.RUnlock()
 
+	// Don't bother connecting to peers we'd refuse the connection of.
+	err := s.peerAccess.checkPeer(msg.addr.IdentityKey, msg.addr.Address)
+	if err != nil {
+		msg.err <- fmt.Errorf("unable to connect to %v: %v", addr, err)
+		return
+	}
+
 	// If there's already a pending connection request for this pubkey,
 	// then we ignore this request to ensure we don't create a redundant
 	// connection.
@@ -685,10 +1414,16 @@ func (s *server) handleConnectPeer(msg *connectPeerMsg) {
 	// persistent connection to the peer.
 	srvrLog.Debugf("Connecting to %v", addr)
 	if msg.persistent {
-		go s.connMgr.Connect(&connmgr.ConnReq{
+		connReq := &connmgr.ConnReq{
 			Addr:      addr,
 			Permanent: true,
-		})
+		}
+
+		s.pendingConnMtx.Lock()
+		s.persistentConnReqs[targetPub] = connReq
+		s.pendingConnMtx.Unlock()
+
+		go s.connMgr.Connect(connReq)
 	} else {
 		// If we're not making a persistent connection, then we'll
 		// attempt to connect o the target peer, returning an error
@@ -697,7 +1432,7 @@ func (s *server) handleConnectPeer(msg *connectPeerMsg) {
 			// Attempt to connect to the remote node. If the we
 			// can't make the connection, or the crypto negotiation
 			// breaks down, then return an error to the caller.
-			conn, err := brontide.Dial(s.identityPriv, addr)
+			conn, err := brontide.Dial(s.nodeKey, addr)
 			if err != nil {
 				msg.err <- err
 				return
@@ -762,8 +1497,10 @@ func (s *server) ConnectToPeer(addr *lnwire.NetAddress,
 // OpenChannel sends a request to the server to open a channel to the specified
 // peer identified by ID with the passed channel funding paramters.
 func (s *server) OpenChannel(peerID int32, nodeKey *btcec.PublicKey,
-	localAmt, pushAmt btcutil.Amount,
-	numConfs uint32) (chan *lnrpc.OpenStatusUpdate, chan error) {
+	localAmt, pushAmt btcutil.Amount, numConfs uint32,
+	coinSelection *lnwallet.CoinSelection,
+	constraints channeldb.ChannelConstraints,
+	deliveryAddr btcutil.Address) (chan *lnrpc.OpenStatusUpdate, chan error) {
 
 	errChan := make(chan error, 1)
 	updateChan := make(chan *lnrpc.OpenStatusUpdate, 1)
@@ -774,6 +1511,9 @@ func (s *server) OpenChannel(peerID int32, nodeKey *btcec.PublicKey,
 		localFundingAmt: localAmt,
 		pushAmt:         pushAmt,
 		numConfs:        numConfs,
+		coinSelection:   coinSelection,
+		constraints:     constraints,
+		deliveryAddr:    deliveryAddr,
 		updates:         updateChan,
 		err:             errChan,
 	}
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"bytes"
+	"sync"
+	"sync/atomic"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// shellSweeper recovers our funds within static remote key channels closed by
+// the remote party. As the state of channels restored from a static backup was
+// lost, their peers are requested to force close them. Within static remote
+// key channels, the commitment transaction of the peer pays our balance to our
+// payment key, whose locator is held by the backup, so the balance can be
+// swept back into the wallet using our seed alone. Once a restored channel is
+// closed, its shell is removed. Live static remote key channels, including
+// those upgraded to the format, are swept the same way once their unilateral
+// close has been detected.
+type shellSweeper struct {
+	wallet       *lnwallet.LightningWallet
+	notifier     chainntnfs.ChainNotifier
+	feeEstimator lnwallet.FeeEstimator
+	db           *channeldb.DB
+
+	// watched is the set of funding outpoints of the channel shells
+	// currently being watched, guarded by the mutex.
+	mu      sync.Mutex
+	watched map[wire.OutPoint]struct{}
+
+	started uint32
+	stopped uint32
+	quit    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// newShellSweeper creates a new shellSweeper backed by the passed wallet,
+// chain notifier, fee estimator and database.
+func newShellSweeper(db *channeldb.DB, notifier chainntnfs.ChainNotifier,
+	wallet *lnwallet.LightningWallet,
+	feeEstimator lnwallet.FeeEstimator) *shellSweeper {
+
+	return &shellSweeper{
+		wallet:       wallet,
+		notifier:     notifier,
+		feeEstimator: feeEstimator,
+		db:           db,
+		watched:      make(map[wire.OutPoint]struct{}),
+		quit:         make(chan struct{}),
+	}
+}
+
+// Start resumes watching the shells of all restored channels whose funds
+// haven't been recovered yet.
+func (s *shellSweeper) Start() error {
+	if !atomic.CompareAndSwapUint32(&s.started, 0, 1) {
+		return nil
+	}
+
+	swprLog.Tracef("Starting shell sweeper")
+
+	shells, err := s.db.FetchChannelShells(nil)
+	if err != nil {
+		return err
+	}
+	s.watchShells(shells)
+
+	return nil
+}
+
+// Stop signals the shellSweeper to exit, blocking until all its goroutines
+// have exited.
+func (s *shellSweeper) Stop() error {
+	if !atomic.CompareAndSwapUint32(&s.stopped, 0, 1) {
+		return nil
+	}
+
+	swprLog.Infof("Shell sweeper shutting down")
+
+	close(s.quit)
+	s.wg.Wait()
+
+	return nil
+}
+
+// watchShells starts watching the funding outputs of the passed shells of
+// static remote key channels, sweeping our balance once the peer closes each
+// channel. Shells already being watched are skipped.
+func (s *shellSweeper) watchShells(shells []*channeldb.ChannelShell) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, shell := range shells {
+		if shell.CommitType != channeldb.CommitTypeStaticRemoteKey {
+			continue
+		}
+		if _, ok := s.watched[shell.ChanPoint]; ok {
+			continue
+		}
+		s.watched[shell.ChanPoint] = struct{}{}
+
+		s.wg.Add(1)
+		go s.watchShell(shell)
+	}
+}
+
+// watchShell waits for the funding output of the passed channel shell to be
+// spent, then sweeps our balance from the closing transaction and removes the
+// shell.
+//
+// NOTE: This MUST be run as a goroutine.
+func (s *shellSweeper) watchShell(shell *channeldb.ChannelShell) {
+	defer s.wg.Done()
+
+	spendEvent, err := s.notifier.RegisterSpendNtfn(&shell.ChanPoint)
+	if err != nil {
+		swprLog.Errorf("Unable to watch restored ChannelPoint(%v): %v",
+			shell.ChanPoint, err)
+		return
+	}
+
+	var spend *chainntnfs.SpendDetail
+	select {
+	case detail, ok := <-spendEvent.Spend:
+		if !ok {
+			return
+		}
+		spend = detail
+
+	case <-s.quit:
+		return
+	}
+
+	swprLog.Infof("Restored ChannelPoint(%v) closed by txid=%v",
+		shell.ChanPoint, spend.SpenderTxHash)
+
+	if err := s.sweepShell(shell, spend.SpendingTx); err != nil {
+		swprLog.Errorf("Unable to sweep restored ChannelPoint(%v): %v",
+			shell.ChanPoint, err)
+		return
+	}
+
+	if err := s.db.DeleteChannelShell(&shell.ChanPoint); err != nil {
+		swprLog.Errorf("Unable to delete shell of restored "+
+			"ChannelPoint(%v): %v", shell.ChanPoint, err)
+	}
+
+	s.mu.Lock()
+	delete(s.watched, shell.ChanPoint)
+	s.mu.Unlock()
+}
+
+// sweepClosedChannel sweeps our balance from the commitment transaction the
+// remote party broadcast to unilaterally close a live channel. Channels not
+// using the static remote key format, or already being swept, are skipped.
+func (s *shellSweeper) sweepClosedChannel(
+	summary *lnwallet.UnilateralCloseSummary) {
+
+	if summary == nil ||
+		summary.CommitType != channeldb.CommitTypeStaticRemoteKey {
+
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-s.quit:
+		return
+	default:
+	}
+
+	if _, ok := s.watched[summary.ChanPoint]; ok {
+		return
+	}
+	s.watched[summary.ChanPoint] = struct{}{}
+
+	shell := &channeldb.ChannelShell{
+		ChanPoint:     summary.ChanPoint,
+		CommitType:    summary.CommitType,
+		PaymentKeyLoc: summary.OurPaymentKeyLoc,
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		err := s.sweepShell(shell, summary.CommitTx)
+		if err != nil {
+			swprLog.Errorf("Unable to sweep closed "+
+				"ChannelPoint(%v): %v", shell.ChanPoint, err)
+		}
+	}()
+}
+
+// sweepShell sweeps the output paying our payment key within the passed
+// closing transaction of a channel into the wallet. If the closing
+// transaction doesn't pay us, as our balance was below the dust limit, there's
+// nothing to sweep.
+func (s *shellSweeper) sweepShell(shell *channeldb.ChannelShell,
+	closeTx *wire.MsgTx) error {
+
+	paymentKey, err := s.wallet.KeyRing.DeriveKey(shell.PaymentKeyLoc)
+	if err != nil {
+		return err
+	}
+	paymentAddr, err := btcutil.NewAddressWitnessPubKeyHash(
+		btcutil.Hash160(paymentKey.PubKey.SerializeCompressed()),
+		activeNetParams.Params,
+	)
+	if err != nil {
+		return err
+	}
+	pkScript, err := txscript.PayToAddrScript(paymentAddr)
+	if err != nil {
+		return err
+	}
+
+	var toUs *kidOutput
+	for i, txOut := range closeTx.TxOut {
+		if !bytes.Equal(txOut.PkScript, pkScript) {
+			continue
+		}
+
+		signDesc := &lnwallet.SignDescriptor{
+			PubKey:        paymentKey.PubKey,
+			WitnessScript: pkScript,
+			Output:        txOut,
+			HashType:      txscript.SigHashAll,
+		}
+		toUs = &kidOutput{
+			amt: btcutil.Amount(txOut.Value),
+			outPoint: wire.OutPoint{
+				Hash:  closeTx.TxHash(),
+				Index: uint32(i),
+			},
+			signDescriptor:  signDesc,
+			witnessType:     commitmentToRemote,
+			originChanPoint: shell.ChanPoint,
+		}
+		toUs.witnessFunc = toUs.witnessType.generateFunc(
+			&s.wallet.Signer, signDesc,
+		)
+		break
+	}
+	if toUs == nil {
+		swprLog.Infof("No balance to sweep from ChannelPoint(%v)",
+			shell.ChanPoint)
+		return nil
+	}
+
+	feePerWeight := s.feeEstimator.EstimateFeePerWeight(
+		defaultSweepConfTarget,
+	)
+	sweepTx, err := createSweepTx(
+		s.wallet, []*kidOutput{toUs}, nil, feePerWeight,
+	)
+	if err != nil {
+		return err
+	}
+
+	swprLog.Infof("Sweeping %v from ChannelPoint(%v) with sweep tx: %v",
+		toUs.amt, shell.ChanPoint,
+		newLogClosure(func() string {
+			return spew.Sdump(sweepTx)
+		}))
+
+	if err := s.wallet.PublishTransaction(sweepTx); err != nil {
+		return err
+	}
+
+	// Record the sweep so it's listed along with the other sweeps. As it
+	// has already been broadcast, failing to do so doesn't fail the sweep.
+	_, bestHeight, err := s.wallet.ChainIO.GetBestBlock()
+	if err == nil {
+		err = putSweepRecord(s.db, &sweepRecord{
+			tx: sweepTx,
+			inputs: []sweepInput{{
+				amt:         toUs.amt,
+				witnessType: toUs.witnessType,
+			}},
+			broadcastHeight: uint32(bestHeight),
+		})
+	}
+	if err != nil {
+		swprLog.Errorf("Unable to record sweep tx %v: %v",
+			sweepTx.TxHash(), err)
+	}
+
+	return nil
+}
@@ -10,6 +10,10 @@ import (
 // interruptChannel is used to receive SIGINT (Ctrl+C) signals.
 var interruptChannel chan os.Signal
 
+// shutdownRequestChannel is used to request a graceful shutdown from within
+// the daemon, which is handled in the same way as a SIGINT (Ctrl+C) signal.
+var shutdownRequestChannel = make(chan struct{}, 1)
+
 // addHandlerChannel is used to add an interrupt handler to the list of handlers
 // to be invoked on SIGINT (Ctrl+C) signals.
 var addHandlerChannel = make(chan func())
@@ -28,30 +32,39 @@ func mainInterruptHandler() {
 	// immediately.
 	var isShutdown bool
 
+	// shutdown invokes all interrupt callbacks and signals the main
+	// goroutine to shutdown, unless a shutdown is already in progress.
+	shutdown := func(reason string) {
+		// Ignore more than one shutdown signal.
+		if isShutdown {
+			ltndLog.Infof("Received %v.  Already shutting down...",
+				reason)
+			return
+		}
+
+		isShutdown = true
+		ltndLog.Infof("Received %v.  Shutting down...", reason)
+
+		// Run handlers in LIFO order.
+		for i := range interruptCallbacks {
+			idx := len(interruptCallbacks) - 1 - i
+			callback := interruptCallbacks[idx]
+			callback()
+		}
+
+		// Signal the main goroutine to shutdown.
+		go func() {
+			shutdownChannel <- struct{}{}
+		}()
+	}
+
 	for {
 		select {
 		case <-interruptChannel:
-			// Ignore more than one shutdown signal.
-			if isShutdown {
-				ltndLog.Infof("Received SIGINT (Ctrl+C).  " +
-					"Already shutting down...")
-				continue
-			}
-
-			isShutdown = true
-			ltndLog.Infof("Received SIGINT (Ctrl+C).  Shutting down...")
-
-			// Run handlers in LIFO order.
-			for i := range interruptCallbacks {
-				idx := len(interruptCallbacks) - 1 - i
-				callback := interruptCallbacks[idx]
-				callback()
-			}
+			shutdown("SIGINT (Ctrl+C)")
 
-			// Signal the main goroutine to shutdown.
-			go func() {
-				shutdownChannel <- struct{}{}
-			}()
+		case <-shutdownRequestChannel:
+			shutdown("shutdown request")
 
 		case handler := <-addHandlerChannel:
 			// The shutdown signal has already been received, so
@@ -78,3 +91,13 @@ func addInterruptHandler(handler func()) {
 
 	addHandlerChannel <- handler
 }
+
+// requestShutdown requests a graceful shutdown of the daemon, invoking all
+// interrupt handlers as if a SIGINT (Ctrl+C) signal had been received. It
+// never blocks, and any requests made while one is pending are ignored.
+func requestShutdown() {
+	select {
+	case shutdownRequestChannel <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// stateServicePrefix is the prefix of the full method names of the State
+// service's calls, which are served throughout the startup of the daemon.
+const stateServicePrefix = "/lnrpc.State/"
+
+// errRPCStarting is returned by the calls of all services other than the
+// State service until the daemon's RPC services are active.
+var errRPCStarting = grpc.Errorf(codes.Unavailable, "the daemon is still "+
+	"starting, only the State service is available")
+
+// stateServer implements the State gRPC service, reporting the phase of the
+// daemon's startup. It's served from the very start of the daemon by the main
+// gRPC server, whose other services are gated by the interceptors of the
+// stateServer until the daemon reaches the RPC_ACTIVE state.
+type stateServer struct {
+	mtx   sync.Mutex
+	state lnrpc.WalletState
+
+	// rpcActive is true once the daemon has reached the RPC_ACTIVE
+	// state, after which the calls of all services are served. It's
+	// guarded by the mtx.
+	rpcActive bool
+
+	// changed is closed, then replaced, each time the state changes,
+	// waking up all subscribed clients. It's guarded by the mtx.
+	changed chan struct{}
+
+	quit chan struct{}
+}
+
+// A compile time check to ensure that stateServer fully implements the
+// StateServer gRPC service.
+var _ lnrpc.StateServer = (*stateServer)(nil)
+
+// newStateServer creates a new stateServer in the WAITING_TO_START state.
+func newStateServer() *stateServer {
+	return &stateServer{
+		state:   lnrpc.WalletState_WAITING_TO_START,
+		changed: make(chan struct{}),
+		quit:    make(chan struct{}),
+	}
+}
+
+// setState transitions the daemon to the passed state, notifying all
+// subscribed clients.
+func (s *stateServer) setState(state lnrpc.WalletState) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	ltndLog.Debugf("Daemon state changed from %v to %v", s.state, state)
+
+	s.state = state
+	if state == lnrpc.WalletState_RPC_ACTIVE ||
+		state == lnrpc.WalletState_SERVER_ACTIVE {
+
+		s.rpcActive = true
+	}
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// currentState returns the current state, along with a channel which is
+// closed once the state next changes.
+func (s *stateServer) currentState() (lnrpc.WalletState, chan struct{}) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return s.state, s.changed
+}
+
+// serving returns true if calls to the passed full method name may be served
+// in the current state.
+func (s *stateServer) serving(fullMethod string) bool {
+	if strings.HasPrefix(fullMethod, stateServicePrefix) {
+		return true
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return s.rpcActive
+}
+
+// unaryGate returns a unary interceptor which rejects the calls of all
+// services other than the State service until the daemon's RPC services are
+// active. Calls which are served are passed through the next interceptor, if
+// any.
+func (s *stateServer) unaryGate(
+	next grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+
+	return func(ctx context.Context, req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+
+		if !s.serving(info.FullMethod) {
+			return nil, errRPCStarting
+		}
+		if next != nil {
+			return next(ctx, req, info, handler)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// streamGate returns a stream interceptor which rejects the streams of all
+// services other than the State service until the daemon's RPC services are
+// active. Streams which are served are passed through the next interceptor,
+// if any.
+func (s *stateServer) streamGate(
+	next grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+
+	return func(srv interface{}, ss grpc.ServerStream,
+		info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+
+		if !s.serving(info.FullMethod) {
+			return errRPCStarting
+		}
+		if next != nil {
+			return next(srv, ss, info, handler)
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+// Stop causes all active SubscribeState streams to return.
+func (s *stateServer) Stop() {
+	close(s.quit)
+}
+
+// GetState returns the current state of the daemon.
+func (s *stateServer) GetState(ctx context.Context,
+	in *lnrpc.GetStateRequest) (*lnrpc.GetStateResponse, error) {
+
+	state, _ := s.currentState()
+	return &lnrpc.GetStateResponse{State: state}, nil
+}
+
+// SubscribeState sends the current state of the daemon, followed by each
+// subsequent state as the daemon transitions to it. If the daemon transitions
+// through several states before the client receives an update, only the most
+// recent is sent.
+func (s *stateServer) SubscribeState(in *lnrpc.SubscribeStateRequest,
+	updateStream lnrpc.State_SubscribeStateServer) error {
+
+	for {
+		state, changed := s.currentState()
+		err := updateStream.Send(&lnrpc.SubscribeStateResponse{
+			State: state,
+		})
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-changed:
+		case <-updateStream.Context().Done():
+			return updateStream.Context().Err()
+		case <-s.quit:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"golang.org/x/net/context"
+)
+
+// TestStateServer asserts that the stateServer reports each state it
+// transitions to, waking up any clients waiting on a change.
+func TestStateServer(t *testing.T) {
+	s := newStateServer()
+
+	resp, err := s.GetState(context.Background(), &lnrpc.GetStateRequest{})
+	if err != nil {
+		t.Fatalf("unable to get state: %v", err)
+	}
+	if resp.State != lnrpc.WalletState_WAITING_TO_START {
+		t.Fatalf("expected initial state %v, got %v",
+			lnrpc.WalletState_WAITING_TO_START, resp.State)
+	}
+
+	states := []lnrpc.WalletState{
+		lnrpc.WalletState_UNLOCKED,
+		lnrpc.WalletState_RPC_ACTIVE,
+		lnrpc.WalletState_SERVER_ACTIVE,
+	}
+	for _, state := range states {
+		_, changed := s.currentState()
+		select {
+		case <-changed:
+			t.Fatalf("state change signaled prematurely")
+		default:
+		}
+
+		s.setState(state)
+
+		select {
+		case <-changed:
+		default:
+			t.Fatalf("state change to %v not signaled", state)
+		}
+
+		resp, err := s.GetState(
+			context.Background(), &lnrpc.GetStateRequest{},
+		)
+		if err != nil {
+			t.Fatalf("unable to get state: %v", err)
+		}
+		if resp.State != state {
+			t.Fatalf("expected state %v, got %v", state,
+				resp.State)
+		}
+	}
+}
@@ -4,15 +4,18 @@ import (
 	"bytes"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"io"
 	"sync"
 	"sync/atomic"
 
 	"github.com/boltdb/bolt"
+	"github.com/btcsuite/fastsha256"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/blockchain"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/txscript"
 	"github.com/roasbeef/btcd/wire"
@@ -39,6 +42,46 @@ var (
 	// re-organized out of the chain.
 	kindergartenBucket = []byte("kdg")
 
+	// sweepScriptBucket maps the outpoints of outputs which should be
+	// swept to a particular script, rather than to a fresh wallet
+	// address, to that script. Entries are removed along with the
+	// outputs once they've graduated from the kindergarten bucket.
+	sweepScriptBucket = []byte("swp")
+
+	// sweepDeadlineBucket maps the outpoints of outputs which must be
+	// swept by a particular height, such as HTLC outputs which the remote
+	// party can claim once they expire, to that height. Entries are
+	// removed along with the outputs once they've graduated from the
+	// kindergarten bucket.
+	sweepDeadlineBucket = []byte("sdl")
+
+	// absoluteMaturityBucket maps the outpoints of outputs which are
+	// encumbered by an absolute time-lock enforced by CheckLockTimeVerify,
+	// such as outgoing HTLC outputs, to the height after which they may be
+	// spent. Entries are removed along with the outputs once they've
+	// graduated from the kindergarten bucket.
+	absoluteMaturityBucket = []byte("abs")
+
+	// originChanPointBucket maps the outpoints of all incubated outputs to
+	// the funding outpoint of the channel whose close created them, so
+	// they can be reported along with their channel. Entries are removed
+	// along with the outputs once they've graduated from the kindergarten
+	// bucket.
+	originChanPointBucket = []byte("ocp")
+
+	// htlcPaymentHashBucket maps the outpoints of outgoing HTLC outputs to
+	// the payment hash of their HTLC, so a claim of the output by the
+	// remote party with the preimage can be recognized. Entries are
+	// removed along with the outputs once they've graduated from the
+	// kindergarten bucket, or have been claimed.
+	htlcPaymentHashBucket = []byte("hph")
+
+	// sweepTxBucket stores a record of each sweep transaction broadcast
+	// by the nursery, the breachArbiter or the shellSweeper, keyed by a sequence number in the order they were
+	// broadcast. Unlike the other buckets, its entries are never removed,
+	// so the funds recovered from past closes can be reconciled.
+	sweepTxBucket = []byte("stx")
+
 	// lastGraduatedHeightKey is used to persist the last blockheight that
 	// has been checked for graduating outputs. When the nursery is
 	// restarted, lastGraduatedHeightKey is used to determine the point
@@ -48,6 +91,19 @@ var (
 	byteOrder = binary.BigEndian
 )
 
+const (
+	// defaultSweepConfTarget is the confirmation target used to estimate
+	// the fee of sweeps of outputs which don't need to be swept by a
+	// particular height.
+	defaultSweepConfTarget = 6
+
+	// sweepFeeBumpPercent is the minimum percentage by which the fee rate
+	// of an unconfirmed sweep with a deadline is raised with each block,
+	// so that its fee rises as the deadline approaches even if the fee
+	// estimator doesn't account for the confirmation target.
+	sweepFeeBumpPercent = 25
+)
+
 // witnessType determines how an output's witness will be generated. The
 // default commitmentTimeLock type will generate a witness that will allow
 // spending of a time-locked transaction enforced by CheckSequenceVerify.
@@ -55,8 +111,47 @@ type witnessType uint16
 
 const (
 	commitmentTimeLock witnessType = 0
+
+	// htlcOfferedTimeout generates a witness spending an outgoing HTLC
+	// output of our commitment transaction through its timeout clause,
+	// which is enforced by both CheckLockTimeVerify and
+	// CheckSequenceVerify.
+	htlcOfferedTimeout witnessType = 1
+
+	// commitmentToRemote generates a witness spending our output on the
+	// commitment transaction of the remote party within a static remote
+	// key channel, which pays our payment key.
+	commitmentToRemote witnessType = 2
+
+	// commitmentNoDelay describes our output on the commitment
+	// transaction of the remote party, swept without a delay, such as
+	// within a justice transaction.
+	commitmentNoDelay witnessType = 3
+
+	// commitmentRevoke describes the delayed output of the remote party
+	// on a revoked commitment transaction, swept using the revocation
+	// key.
+	commitmentRevoke witnessType = 4
 )
 
+// String returns a human readable version of the witnessType.
+func (wt witnessType) String() string {
+	switch wt {
+	case commitmentTimeLock:
+		return "CommitmentTimeLock"
+	case htlcOfferedTimeout:
+		return "HtlcOfferedTimeout"
+	case commitmentToRemote:
+		return "CommitmentToRemote"
+	case commitmentNoDelay:
+		return "CommitmentNoDelay"
+	case commitmentRevoke:
+		return "CommitmentRevoke"
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint16(wt))
+	}
+}
+
 // witnessGenerator represents a function which is able to generate the final
 // witness for a particular public key script. This function acts as an
 // abstraction layer, hiding the details of the underlying script from the
@@ -65,8 +160,7 @@ type witnessGenerator func(tx *wire.MsgTx, hc *txscript.TxSigHashes,
 	inputIndex int) ([][]byte, error)
 
 // generateFunc will return the witnessGenerator function that a kidOutput uses
-// to generate the witness for a sweep transaction. Currently there is only one
-// witnessType but this will be expanded.
+// to generate the witness for a sweep transaction.
 func (wt witnessType) generateFunc(signer *lnwallet.Signer,
 	descriptor *lnwallet.SignDescriptor) witnessGenerator {
 
@@ -81,6 +175,28 @@ func (wt witnessType) generateFunc(signer *lnwallet.Signer,
 
 			return lnwallet.CommitSpendTimeout(*signer, desc, tx)
 		}
+
+	case htlcOfferedTimeout:
+		return func(tx *wire.MsgTx, hc *txscript.TxSigHashes,
+			inputIndex int) ([][]byte, error) {
+
+			desc := descriptor
+			desc.SigHashes = hc
+			desc.InputIndex = inputIndex
+
+			return lnwallet.HtlcSpendTimeout(*signer, desc, tx)
+		}
+
+	case commitmentToRemote:
+		return func(tx *wire.MsgTx, hc *txscript.TxSigHashes,
+			inputIndex int) ([][]byte, error) {
+
+			desc := descriptor
+			desc.SigHashes = hc
+			desc.InputIndex = inputIndex
+
+			return lnwallet.CommitSpendStaticRemote(*signer, desc, tx)
+		}
 	}
 
 	return nil
@@ -100,10 +216,30 @@ type utxoNursery struct {
 	notifier chainntnfs.ChainNotifier
 	wallet   *lnwallet.LightningWallet
 
+	// feeEstimator is used to estimate the fee rate of sweep transactions,
+	// according to the deadline of the outputs being swept.
+	feeEstimator lnwallet.FeeEstimator
+
 	db *channeldb.DB
 
 	requests chan *incubationRequest
 
+	// pendingSweeps are the sweep transactions broadcast by the nursery
+	// which have yet to confirm, keyed by an ID assigned to each. Their
+	// fee rate is re-estimated with each block, so they can be replaced
+	// by sweeps paying a higher fee as their deadline approaches. It's
+	// only accessed by the incubator, once the nursery has started.
+	pendingSweeps map[uint64]*pendingSweep
+	nextSweepID   uint64
+
+	// sweepConfs is the channel the IDs of pending sweeps are delivered
+	// over once they confirm.
+	sweepConfs chan uint64
+
+	// htlcClaims is the channel the outgoing HTLC outputs claimed by the
+	// remote party with the preimage are delivered over.
+	htlcClaims chan *kidOutput
+
 	started uint32
 	stopped uint32
 	quit    chan struct{}
@@ -111,16 +247,21 @@ type utxoNursery struct {
 }
 
 // newUtxoNursery creates a new instance of the utxoNursery from a
-// ChainNotifier and LightningWallet instance.
+// ChainNotifier, LightningWallet and FeeEstimator instance.
 func newUtxoNursery(db *channeldb.DB, notifier chainntnfs.ChainNotifier,
-	wallet *lnwallet.LightningWallet) *utxoNursery {
+	wallet *lnwallet.LightningWallet,
+	feeEstimator lnwallet.FeeEstimator) *utxoNursery {
 
 	return &utxoNursery{
-		notifier: notifier,
-		wallet:   wallet,
-		requests: make(chan *incubationRequest),
-		db:       db,
-		quit:     make(chan struct{}),
+		notifier:      notifier,
+		wallet:        wallet,
+		feeEstimator:  feeEstimator,
+		requests:      make(chan *incubationRequest),
+		db:            db,
+		pendingSweeps: make(map[uint64]*pendingSweep),
+		sweepConfs:    make(chan uint64),
+		htlcClaims:    make(chan *kidOutput),
+		quit:          make(chan struct{}),
 	}
 }
 
@@ -137,6 +278,18 @@ func (u *utxoNursery) Start() error {
 		return err
 	}
 
+	// The outgoing HTLC outputs still being incubated may be claimed by
+	// the remote party with the preimage, so we'll watch them for spends.
+	kids, err := u.limboOutputs()
+	if err != nil {
+		return err
+	}
+	for _, kid := range kids {
+		if kid.witnessType == htlcOfferedTimeout {
+			u.watchHtlcOutput(kid)
+		}
+	}
+
 	// Register with the notifier to receive notifications for each newly
 	// connected block. We register during startup to ensure that no blocks
 	// are missed while we are handling blocks that were missed during the
@@ -166,6 +319,17 @@ func (u *utxoNursery) reloadPreschool() error {
 
 		return psclBucket.ForEach(func(outputBytes, kidBytes []byte) error {
 			psclOutput, err := deserializeKidOutput(bytes.NewBuffer(kidBytes))
+			if err != nil {
+				return err
+			}
+
+			// The absolute maturity of the output is needed to
+			// determine when it graduates, so we'll populate it
+			// from its bucket along with the rest of its index.
+			err = fetchKidIndexes(tx, []*kidOutput{psclOutput})
+			if err != nil {
+				return err
+			}
 
 			outpoint := psclOutput.outPoint
 			sourceTxid := outpoint.Hash
@@ -226,7 +390,10 @@ func (u *utxoNursery) catchUpKindergarten() error {
 	// Loop through and check for graduating outputs at each of the missed
 	// block heights.
 	for graduationHeight := lastGraduatedHeight + 1; graduationHeight <= uint32(bestHeight); graduationHeight++ {
-		if err := u.graduateKindergarten(graduationHeight); err != nil {
+		err := u.graduateKindergarten(
+			graduationHeight, uint32(bestHeight),
+		)
+		if err != nil {
 			return err
 		}
 	}
@@ -266,6 +433,209 @@ type kidOutput struct {
 
 	signDescriptor *lnwallet.SignDescriptor
 	witnessType    witnessType
+
+	// sweepPkScript, if set, is the script the output should be swept to
+	// once mature. Otherwise, it's swept to a fresh wallet address.
+	sweepPkScript []byte
+
+	// deadlineHeight, if non-zero, is the height by which the sweep of the
+	// output must be confirmed, such as the expiry of an HTLC after which
+	// the remote party may claim it. The closer the sweep is broadcast to
+	// the deadline, the higher its fee rate.
+	deadlineHeight uint32
+
+	// absoluteMaturity, if non-zero, is the height after which the output
+	// may be spent, as enforced by CheckLockTimeVerify. The output matures
+	// once both this height and its relative maturity have been reached.
+	absoluteMaturity uint32
+
+	// originChanPoint is the funding outpoint of the channel whose close
+	// created the output.
+	originChanPoint wire.OutPoint
+
+	// paymentHash is the payment hash of the HTLC of an outgoing HTLC
+	// output, whose preimage is revealed if the remote party claims it.
+	paymentHash [32]byte
+}
+
+// maturityHeight returns the height at which the output can be swept, or zero
+// if the transaction creating it hasn't yet been confirmed.
+func (k *kidOutput) maturityHeight() uint32 {
+	if k.confHeight == 0 {
+		return 0
+	}
+
+	maturityHeight := k.confHeight + k.blocksToMaturity
+	if k.absoluteMaturity > maturityHeight {
+		maturityHeight = k.absoluteMaturity
+	}
+
+	return maturityHeight
+}
+
+// kidIndex is a bucket storing a piece of data of each incubated output which
+// isn't serialized along with the output itself, keyed by its outpoint.
+type kidIndex struct {
+	bucket []byte
+
+	// value returns the data to be stored for the passed output, or nil
+	// if there's none.
+	value func(k *kidOutput) []byte
+
+	// set populates the passed output with its stored data.
+	set func(k *kidOutput, value []byte)
+}
+
+// kidIndexes are all the indexes the data of incubated outputs is stored
+// within, beyond their serialization.
+var kidIndexes = []kidIndex{
+	{
+		bucket: sweepScriptBucket,
+		value: func(k *kidOutput) []byte {
+			return k.sweepPkScript
+		},
+		set: func(k *kidOutput, value []byte) {
+			k.sweepPkScript = make([]byte, len(value))
+			copy(k.sweepPkScript, value)
+		},
+	},
+	{
+		bucket: sweepDeadlineBucket,
+		value: func(k *kidOutput) []byte {
+			return uint32IndexValue(k.deadlineHeight)
+		},
+		set: func(k *kidOutput, value []byte) {
+			if len(value) == 4 {
+				k.deadlineHeight = byteOrder.Uint32(value)
+			}
+		},
+	},
+	{
+		bucket: absoluteMaturityBucket,
+		value: func(k *kidOutput) []byte {
+			return uint32IndexValue(k.absoluteMaturity)
+		},
+		set: func(k *kidOutput, value []byte) {
+			if len(value) == 4 {
+				k.absoluteMaturity = byteOrder.Uint32(value)
+			}
+		},
+	},
+	{
+		bucket: originChanPointBucket,
+		value: func(k *kidOutput) []byte {
+			var b bytes.Buffer
+			if err := writeOutpoint(&b, &k.originChanPoint); err != nil {
+				return nil
+			}
+			return b.Bytes()
+		},
+		set: func(k *kidOutput, value []byte) {
+			readOutpoint(bytes.NewReader(value), &k.originChanPoint)
+		},
+	},
+	{
+		bucket: htlcPaymentHashBucket,
+		value: func(k *kidOutput) []byte {
+			if k.paymentHash == [32]byte{} {
+				return nil
+			}
+			return k.paymentHash[:]
+		},
+		set: func(k *kidOutput, value []byte) {
+			copy(k.paymentHash[:], value)
+		},
+	},
+}
+
+// uint32IndexValue serializes a height stored within a kidIndex, returning nil
+// if it isn't set.
+func uint32IndexValue(height uint32) []byte {
+	if height == 0 {
+		return nil
+	}
+
+	var b [4]byte
+	byteOrder.PutUint32(b[:], height)
+	return b[:]
+}
+
+// putKidIndexes records the data of the passed output within each of the
+// kidIndexes it has data for.
+func putKidIndexes(tx *bolt.Tx, k *kidOutput) error {
+	var outpointBytes bytes.Buffer
+	if err := writeOutpoint(&outpointBytes, &k.outPoint); err != nil {
+		return err
+	}
+
+	for _, index := range kidIndexes {
+		value := index.value(k)
+		if len(value) == 0 {
+			continue
+		}
+
+		indexBucket, err := tx.CreateBucketIfNotExists(index.bucket)
+		if err != nil {
+			return err
+		}
+		if err := indexBucket.Put(outpointBytes.Bytes(), value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fetchKidIndexes populates the passed outputs with their data stored within
+// the kidIndexes.
+func fetchKidIndexes(tx *bolt.Tx, kids []*kidOutput) error {
+	for _, index := range kidIndexes {
+		indexBucket := tx.Bucket(index.bucket)
+		if indexBucket == nil {
+			continue
+		}
+
+		for _, kid := range kids {
+			var outpointBytes bytes.Buffer
+			err := writeOutpoint(&outpointBytes, &kid.outPoint)
+			if err != nil {
+				return err
+			}
+
+			value := indexBucket.Get(outpointBytes.Bytes())
+			if value == nil {
+				continue
+			}
+
+			index.set(kid, value)
+		}
+	}
+
+	return nil
+}
+
+// deleteKidIndexes removes the data of the passed outputs from each of the
+// kidIndexes.
+func deleteKidIndexes(tx *bolt.Tx, kids []*kidOutput) error {
+	for _, index := range kidIndexes {
+		indexBucket := tx.Bucket(index.bucket)
+		if indexBucket == nil {
+			continue
+		}
+
+		for _, kid := range kids {
+			var outpointBytes bytes.Buffer
+			err := writeOutpoint(&outpointBytes, &kid.outPoint)
+			if err != nil {
+				return err
+			}
+			if err := indexBucket.Delete(outpointBytes.Bytes()); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
 }
 
 // incubationRequest is a request to the utxoNursery to incubate a set of
@@ -276,9 +646,13 @@ type incubationRequest struct {
 }
 
 // incubateOutputs sends a request to utxoNursery to incubate the outputs
-// defined within the summary of a closed channel. Individually, as all outputs
-// reach maturity they'll be swept back into the wallet.
-func (u *utxoNursery) incubateOutputs(closeSummary *lnwallet.ForceCloseSummary) {
+// defined within the summary of a closed channel: our delayed commitment
+// output, and any outgoing HTLC outputs, which can be swept once they've timed
+// out. Individually, as all outputs reach maturity they'll be swept back into
+// the wallet, or to the passed script if one is set.
+func (u *utxoNursery) incubateOutputs(closeSummary *lnwallet.ForceCloseSummary,
+	sweepPkScript []byte) {
+
 	outputAmt := btcutil.Amount(closeSummary.SelfOutputSignDesc.Output.Value)
 	selfOutput := &kidOutput{
 		amt:              outputAmt,
@@ -286,10 +660,33 @@ func (u *utxoNursery) incubateOutputs(closeSummary *lnwallet.ForceCloseSummary)
 		blocksToMaturity: closeSummary.SelfOutputMaturity,
 		signDescriptor:   closeSummary.SelfOutputSignDesc,
 		witnessType:      commitmentTimeLock,
+		sweepPkScript:    sweepPkScript,
+		originChanPoint:  closeSummary.ChanPoint,
+	}
+	outputs := []*kidOutput{selfOutput}
+
+	// Until we sweep an HTLC output through its timeout clause, the remote
+	// party may still claim it with the preimage. So its deadline is its
+	// expiry, which causes it to be swept at the highest fee rate as soon
+	// as it matures. Our own output can only be claimed by us, so it has
+	// no deadline.
+	for _, htlc := range closeSummary.HtlcTimeouts {
+		outputs = append(outputs, &kidOutput{
+			amt:              btcutil.Amount(htlc.SignDesc.Output.Value),
+			outPoint:         htlc.Outpoint,
+			blocksToMaturity: htlc.CsvDelay,
+			absoluteMaturity: htlc.Expiry,
+			deadlineHeight:   htlc.Expiry,
+			signDescriptor:   htlc.SignDesc,
+			witnessType:      htlcOfferedTimeout,
+			sweepPkScript:    sweepPkScript,
+			originChanPoint:  closeSummary.ChanPoint,
+			paymentHash:      htlc.PaymentHash,
+		})
 	}
 
 	u.requests <- &incubationRequest{
-		outputs: []*kidOutput{selfOutput},
+		outputs: outputs,
 	}
 }
 
@@ -336,6 +733,10 @@ out:
 				// kindergarten bucket once the channel close
 				// transaction has been confirmed.
 				go output.waitForPromotion(u.db, confChan)
+
+				if output.witnessType == htlcOfferedTimeout {
+					u.watchHtlcOutput(output)
+				}
 			}
 		case epoch, ok := <-newBlockChan.Epochs:
 			// If the epoch channel has been closed, then the
@@ -352,11 +753,28 @@ out:
 			// outputs out of the kindergarten bucket. Graduation
 			// entails successfully sweeping a time-locked output.
 			height := uint32(epoch.Height)
-			if err := u.graduateKindergarten(height); err != nil {
+			if err := u.graduateKindergarten(height, height); err != nil {
 				utxnLog.Errorf("error while graduating "+
 					"kindergarten outputs: %v", err)
 			}
 
+			// The sweeps broadcast at prior heights which have
+			// yet to confirm are priced anew, as their deadlines
+			// are now closer.
+			u.bumpSweeps(height)
+
+		case sweepID := <-u.sweepConfs:
+			// Once any of the transactions of a sweep confirms,
+			// those it replaced or which replaced it are no
+			// longer watched.
+			if sweep, ok := u.pendingSweeps[sweepID]; ok {
+				close(sweep.done)
+				delete(u.pendingSweeps, sweepID)
+			}
+
+		case kid := <-u.htlcClaims:
+			u.removeClaimedOutput(kid)
+
 		case <-u.quit:
 			break out
 		}
@@ -388,6 +806,13 @@ func (k *kidOutput) enterPreschool(db *channeldb.DB) error {
 			return err
 		}
 
+		// The data of the output which isn't serialized along with it,
+		// such as the script it should be swept to, is recorded within
+		// its indexes so it survives restarts.
+		if err := putKidIndexes(tx, k); err != nil {
+			return err
+		}
+
 		utxnLog.Infof("Outpoint %v now in preschool, waiting for "+
 			"initial confirmation", k.outPoint)
 
@@ -428,6 +853,13 @@ func (k *kidOutput) waitForPromotion(db *channeldb.DB, confChan *chainntnfs.Conf
 		if err := writeOutpoint(&outpointBytes, &k.outPoint); err != nil {
 			return err
 		}
+
+		// If the output is no longer in preschool, then it has been
+		// claimed by the remote party in the meantime.
+		if psclBucket.Get(outpointBytes.Bytes()) == nil {
+			return nil
+		}
+
 		if err := psclBucket.Delete(outpointBytes.Bytes()); err != nil {
 			utxnLog.Errorf("unable to delete kindergarten output from "+
 				"preschool bucket: %v", k.outPoint)
@@ -439,7 +871,7 @@ func (k *kidOutput) waitForPromotion(db *channeldb.DB, confChan *chainntnfs.Conf
 			return err
 		}
 
-		maturityHeight := k.confHeight + k.blocksToMaturity
+		maturityHeight := k.maturityHeight()
 
 		heightBytes := make([]byte, 4)
 		byteOrder.PutUint32(heightBytes, uint32(maturityHeight))
@@ -476,8 +908,9 @@ func (k *kidOutput) waitForPromotion(db *channeldb.DB, confChan *chainntnfs.Conf
 // from the commitment transaction has become spendable. graduateKindergarten
 // is called both when a new block notification has been received and also at
 // startup in order to process graduations from blocks missed while the UTXO
-// nursery was offline.
-func (u *utxoNursery) graduateKindergarten(blockHeight uint32) error {
+// nursery was offline, in which case bestHeight, the height of the current
+// best block, is used to price the sweeps rather than blockHeight.
+func (u *utxoNursery) graduateKindergarten(blockHeight, bestHeight uint32) error {
 	// First fetch the set of outputs that we can "graduate" at this
 	// particular block height. We can graduate an output once we've
 	// reached its height maturity.
@@ -487,11 +920,19 @@ func (u *utxoNursery) graduateKindergarten(blockHeight uint32) error {
 	}
 
 	// If we're able to graduate any outputs, then create a single
-	// transaction which sweeps them all into the wallet.
+	// transaction which sweeps them all into the wallet. The sweeps are
+	// tracked until they confirm, so their fee can be raised if needed.
 	if len(kgtnOutputs) > 0 {
-		if err := sweepGraduatingOutputs(u.wallet, kgtnOutputs); err != nil {
+		sweeps, err := sweepGraduatingOutputs(
+			u.db, u.wallet, u.feeEstimator, kgtnOutputs, bestHeight,
+		)
+		if err != nil {
 			return err
 		}
+
+		for _, sweep := range sweeps {
+			u.trackSweep(sweep)
+		}
 	}
 
 	// Using a re-org safety margin of 6-blocks, delete any outputs which
@@ -506,6 +947,71 @@ func (u *utxoNursery) graduateKindergarten(blockHeight uint32) error {
 	return putLastHeightGraduated(u.db, blockHeight)
 }
 
+// limboOutputs returns all outputs incubated by the nursery which have yet to
+// be swept: those awaiting the confirmation of the transaction creating them,
+// whose confHeight is zero, and those awaiting their maturity.
+func (u *utxoNursery) limboOutputs() ([]*kidOutput, error) {
+	var kids []*kidOutput
+	err := u.db.View(func(tx *bolt.Tx) error {
+		if psclBucket := tx.Bucket(preschoolBucket); psclBucket != nil {
+			err := psclBucket.ForEach(func(_, kidBytes []byte) error {
+				kid, err := deserializeKidOutput(
+					bytes.NewReader(kidBytes),
+				)
+				if err != nil {
+					return err
+				}
+
+				kids = append(kids, kid)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		kgtnBucket := tx.Bucket(kindergartenBucket)
+		if kgtnBucket == nil {
+			return fetchKidIndexes(tx, kids)
+		}
+
+		// Outputs at or below the last graduated height have already
+		// been swept, and are only kept around in case of a reorg.
+		var lastGraduatedHeight uint32
+		if heightBytes := kgtnBucket.Get(lastGraduatedHeightKey); heightBytes != nil {
+			lastGraduatedHeight = byteOrder.Uint32(heightBytes)
+		}
+
+		err := kgtnBucket.ForEach(func(heightBytes, kidBytes []byte) error {
+			if len(heightBytes) != 4 ||
+				byteOrder.Uint32(heightBytes) <= lastGraduatedHeight {
+
+				return nil
+			}
+
+			kgtnOutputs, err := deserializeKidList(
+				bytes.NewReader(kidBytes),
+			)
+			if err != nil {
+				return err
+			}
+
+			kids = append(kids, kgtnOutputs...)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		return fetchKidIndexes(tx, kids)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return kids, nil
+}
+
 // fetchGraduatingOutputs checks the "kindergarten" database bucket whenever a
 // new block is received in order to determine if commitment transaction
 // outputs have become newly spendable. If fetchGraduatingOutputs finds outputs
@@ -545,6 +1051,16 @@ func fetchGraduatingOutputs(db *channeldb.DB, wallet *lnwallet.LightningWallet,
 		utxnLog.Errorf("error while deserializing list of kidOutputs: %v", err)
 	}
 
+	// The scripts the outputs should be swept to, along with the rest of
+	// their data which isn't stored with them, are populated from their
+	// indexes.
+	err = db.View(func(tx *bolt.Tx) error {
+		return fetchKidIndexes(tx, kgtnOutputs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	// For each of the outputs, we also generate its proper witness
 	// function based on its witness type. This varies if the output is on
 	// our commitment transaction or theirs, and also if it's an HTLC
@@ -561,48 +1077,601 @@ func fetchGraduatingOutputs(db *channeldb.DB, wallet *lnwallet.LightningWallet,
 	return kgtnOutputs, nil
 }
 
-// sweepGraduatingOutputs generates and broadcasts the transaction that
-// transfers control of funds from a channel commitment transaction to the
-// user's wallet.
-func sweepGraduatingOutputs(wallet *lnwallet.LightningWallet, kgtnOutputs []*kidOutput) error {
+// sweepGraduatingOutputs generates and broadcasts the transactions that
+// transfer control of funds from a channel commitment transaction to the
+// user's wallet. The outputs are swept in the sets returned by sweepSets. The
+// fee rate of each transaction is estimated according to the earliest
+// deadline of the outputs it sweeps, relative to the passed height.
+func sweepGraduatingOutputs(db *channeldb.DB, wallet *lnwallet.LightningWallet,
+	feeEstimator lnwallet.FeeEstimator, kgtnOutputs []*kidOutput,
+	blockHeight uint32) ([]*pendingSweep, error) {
+
+	var sweeps []*pendingSweep
+	for _, outputs := range sweepSets(kgtnOutputs) {
+		sweep, err := sweepOutputs(
+			db, wallet, feeEstimator, outputs,
+			outputs[0].sweepPkScript, blockHeight,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		sweeps = append(sweeps, sweep)
+	}
+
+	return sweeps, nil
+}
+
+// sweepSets splits the passed outputs into the sets swept by a single
+// transaction each. Outgoing HTLC outputs are swept by a dedicated
+// transaction each, as the remote party may claim them with the preimage,
+// which would invalidate any sweep spending them along with other outputs.
+// The remaining outputs are swept by a transaction for each script they
+// should be swept to.
+func sweepSets(kgtnOutputs []*kidOutput) [][]*kidOutput {
+	var (
+		sets       [][]*kidOutput
+		scriptSets = make(map[string]int)
+	)
+	for _, kid := range kgtnOutputs {
+		if kid.witnessType == htlcOfferedTimeout {
+			sets = append(sets, []*kidOutput{kid})
+			continue
+		}
+
+		script := string(kid.sweepPkScript)
+		i, ok := scriptSets[script]
+		if !ok {
+			i = len(sets)
+			scriptSets[script] = i
+			sets = append(sets, nil)
+		}
+		sets[i] = append(sets[i], kid)
+	}
+
+	return sets
+}
+
+// sweepOutputs generates and broadcasts a single transaction sweeping the
+// passed outputs to the passed script, or to the wallet if it's nil. A record
+// of the sweep is kept within the database once it's broadcast.
+func sweepOutputs(db *channeldb.DB, wallet *lnwallet.LightningWallet,
+	feeEstimator lnwallet.FeeEstimator, kgtnOutputs []*kidOutput,
+	sweepPkScript []byte, blockHeight uint32) (*pendingSweep, error) {
+
+	// The fee rate of the sweep is determined by the number of blocks
+	// left until the earliest deadline of the outputs.
+	confTarget := sweepConfTarget(kgtnOutputs, blockHeight)
+	feePerWeight := feeEstimator.EstimateFeePerWeight(confTarget)
+
+	sweepTx, err := publishSweep(
+		db, wallet, kgtnOutputs, sweepPkScript, feePerWeight,
+		confTarget, blockHeight,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pendingSweep{
+		outputs:      kgtnOutputs,
+		tx:           sweepTx,
+		feePerWeight: feePerWeight,
+	}, nil
+}
+
+// publishSweep creates and broadcasts a transaction sweeping the passed
+// outputs to the passed script, or to the wallet if it's nil, at the passed
+// fee rate. A record of the sweep is kept within the database once it's
+// broadcast.
+func publishSweep(db *channeldb.DB, wallet *lnwallet.LightningWallet,
+	kgtnOutputs []*kidOutput, sweepPkScript []byte,
+	feePerWeight btcutil.Amount, confTarget,
+	blockHeight uint32) (*wire.MsgTx, error) {
+
 	// Create a transaction which sweeps all the newly mature outputs into
 	// a output controlled by the wallet.
 	// TODO(roasbeef): can be more intelligent about buffering outputs to
 	// be more efficient on-chain.
-	sweepTx, err := createSweepTx(wallet, kgtnOutputs)
+	sweepTx, err := createSweepTx(
+		wallet, kgtnOutputs, sweepPkScript, feePerWeight,
+	)
 	if err != nil {
 		// TODO(roasbeef): retry logic?
 		utxnLog.Errorf("unable to create sweep tx: %v", err)
-		return err
+		return nil, err
 	}
 
-	utxnLog.Infof("Sweeping %v time-locked outputs "+
-		"with sweep tx: %v", len(kgtnOutputs),
+	utxnLog.Infof("Sweeping %v time-locked outputs with a confirmation "+
+		"target of %v blocks (%v sat/weight) with sweep tx: %v",
+		len(kgtnOutputs), confTarget, feePerWeight,
 		newLogClosure(func() string {
 			return spew.Sdump(sweepTx)
 		}))
 
 	// With the sweep transaction fully signed, broadcast the transaction
-	// to the network. Additionally, we can stop tracking these outputs as
-	// they've just been swept.
-	if err := wallet.PublishTransaction(sweepTx); err != nil {
+	// to the network.
+	label := lnwallet.MakeLabel(lnwallet.LabelTypeSweepTransaction, nil)
+	if err := wallet.PublishAndLabel(sweepTx, label); err != nil {
 		utxnLog.Errorf("unable to broadcast sweep tx: %v, %v",
 			err, spew.Sdump(sweepTx))
+		return nil, err
+	}
+
+	// As the sweep has already been broadcast, failing to record it
+	// doesn't fail the sweep itself.
+	record := &sweepRecord{
+		tx:              sweepTx,
+		broadcastHeight: blockHeight,
+	}
+	for _, kid := range kgtnOutputs {
+		record.inputs = append(record.inputs, sweepInput{
+			amt:         kid.amt,
+			witnessType: kid.witnessType,
+		})
+	}
+	if err := putSweepRecord(db, record); err != nil {
+		utxnLog.Errorf("unable to record sweep tx %v: %v",
+			sweepTx.TxHash(), err)
+	}
+
+	return sweepTx, nil
+}
+
+// bumpedSweepFeeRate returns the fee rate an unconfirmed sweep paying the
+// passed fee rate should be replaced at, given the current estimate for its
+// confirmation target. If the sweep has a deadline, which is the case if its
+// target is below the default one, the fee rate is raised by at least
+// sweepFeeBumpPercent.
+func bumpedSweepFeeRate(feePerWeight, estimate btcutil.Amount,
+	confTarget uint32) btcutil.Amount {
+
+	if confTarget < defaultSweepConfTarget {
+		minFeePerWeight := feePerWeight * (100 + sweepFeeBumpPercent) / 100
+		if minFeePerWeight <= feePerWeight {
+			minFeePerWeight = feePerWeight + 1
+		}
+		if estimate < minFeePerWeight {
+			estimate = minFeePerWeight
+		}
+	}
+
+	return estimate
+}
+
+// pendingSweep is a sweep transaction broadcast by the nursery which has yet
+// to confirm.
+type pendingSweep struct {
+	// outputs are the outputs swept by the transaction, along with their
+	// witness functions, so the sweep can be signed anew.
+	outputs []*kidOutput
+
+	// tx is the latest transaction broadcast to sweep the outputs.
+	tx *wire.MsgTx
+
+	// feePerWeight is the fee rate paid by the latest transaction.
+	feePerWeight btcutil.Amount
+
+	// done is closed once any of the transactions of the sweep confirms.
+	done chan struct{}
+}
+
+// trackSweep tracks the passed sweep until it confirms, so its fee rate can be
+// raised in the meantime.
+func (u *utxoNursery) trackSweep(sweep *pendingSweep) {
+	id := u.nextSweepID
+	u.nextSweepID++
+
+	sweep.done = make(chan struct{})
+	u.pendingSweeps[id] = sweep
+	u.watchSweepTx(id, sweep, sweep.tx)
+}
+
+// watchSweepTx delivers the passed ID of a pending sweep over the sweepConfs
+// channel once the passed transaction sweeping its outputs confirms. As the sweep may
+// be replaced, each of its transactions is watched.
+func (u *utxoNursery) watchSweepTx(id uint64, sweep *pendingSweep,
+	sweepTx *wire.MsgTx) {
+
+	txid := sweepTx.TxHash()
+	confChan, err := u.notifier.RegisterConfirmationsNtfn(&txid, 1)
+	if err != nil {
+		utxnLog.Errorf("unable to register sweep tx %v for "+
+			"confirmation: %v", txid, err)
+		return
+	}
+
+	u.wg.Add(1)
+	go func() {
+		defer u.wg.Done()
+
+		select {
+		case _, ok := <-confChan.Confirmed:
+			if !ok {
+				return
+			}
+		case <-sweep.done:
+			return
+		case <-u.quit:
+			return
+		}
+
+		select {
+		case u.sweepConfs <- id:
+		case <-sweep.done:
+		case <-u.quit:
+		}
+	}()
+}
+
+// bumpSweeps re-estimates the fee rate of each pending sweep at the passed
+// height, replacing the sweeps whose fee rate has risen. Sweeps with a
+// deadline have their fee rate raised by at least sweepFeeBumpPercent with
+// each block, as the fee estimator may not account for the confirmation
+// target.
+func (u *utxoNursery) bumpSweeps(blockHeight uint32) {
+	for id, sweep := range u.pendingSweeps {
+		confTarget := sweepConfTarget(sweep.outputs, blockHeight)
+		feePerWeight := bumpedSweepFeeRate(
+			sweep.feePerWeight,
+			u.feeEstimator.EstimateFeePerWeight(confTarget),
+			confTarget,
+		)
+		if feePerWeight <= sweep.feePerWeight {
+			continue
+		}
+
+		// The replacement pays to the same script as the transaction
+		// it replaces.
+		sweepPkScript := sweep.tx.TxOut[0].PkScript
+		sweepTx, err := publishSweep(
+			u.db, u.wallet, sweep.outputs, sweepPkScript,
+			feePerWeight, confTarget, blockHeight,
+		)
+		if err != nil {
+			utxnLog.Errorf("unable to replace sweep tx %v: %v",
+				sweep.tx.TxHash(), err)
+			continue
+		}
+
+		sweep.tx = sweepTx
+		sweep.feePerWeight = feePerWeight
+		u.watchSweepTx(id, sweep, sweepTx)
+	}
+}
+
+// watchHtlcOutput watches the passed outgoing HTLC output for a spend by the
+// remote party claiming it with the preimage, in which case the output is
+// delivered over the htlcClaims channel. Our own sweep of the output through
+// its timeout clause doesn't reveal the preimage, and is therefore ignored.
+func (u *utxoNursery) watchHtlcOutput(kid *kidOutput) {
+	spendNtfn, err := u.notifier.RegisterSpendNtfn(&kid.outPoint)
+	if err != nil {
+		utxnLog.Errorf("unable to watch HTLC output %v for spends: %v",
+			kid.outPoint, err)
+		return
+	}
+
+	u.wg.Add(1)
+	go func() {
+		defer u.wg.Done()
+
+		select {
+		case spend, ok := <-spendNtfn.Spend:
+			if !ok {
+				return
+			}
+
+			txIn := spend.SpendingTx.TxIn[spend.SpenderInputIndex]
+			if !revealsPreimage(txIn.Witness, kid.paymentHash) {
+				return
+			}
+		case <-u.quit:
+			return
+		}
+
+		select {
+		case u.htlcClaims <- kid:
+		case <-u.quit:
+		}
+	}()
+}
+
+// revealsPreimage returns true if the passed witness contains the preimage of
+// the passed payment hash.
+func revealsPreimage(witness wire.TxWitness, paymentHash [32]byte) bool {
+	for _, item := range witness {
+		if len(item) == 32 && fastsha256.Sum256(item) == paymentHash {
+			return true
+		}
+	}
+
+	return false
+}
+
+// removeClaimedOutput stops incubating the passed outgoing HTLC output, which
+// has been claimed by the remote party with the preimage, abandoning any
+// pending sweep of it.
+func (u *utxoNursery) removeClaimedOutput(kid *kidOutput) {
+	utxnLog.Infof("HTLC output %v claimed by the remote party with the "+
+		"preimage", kid.outPoint)
+
+	if err := removeKidOutput(u.db, kid.outPoint); err != nil {
+		utxnLog.Errorf("unable to remove claimed HTLC output %v: %v",
+			kid.outPoint, err)
+	}
+
+	for id, sweep := range u.pendingSweeps {
+		for _, output := range sweep.outputs {
+			if output.outPoint != kid.outPoint {
+				continue
+			}
+
+			close(sweep.done)
+			delete(u.pendingSweeps, id)
+			break
+		}
+	}
+}
+
+// removeKidOutput removes the output with the passed outpoint from the
+// preschool or kindergarten bucket it's incubated within, along with its
+// indexes.
+func removeKidOutput(db *channeldb.DB, outPoint wire.OutPoint) error {
+	var outpointBytes bytes.Buffer
+	if err := writeOutpoint(&outpointBytes, &outPoint); err != nil {
+		return err
+	}
+
+	return db.Update(func(tx *bolt.Tx) error {
+		if psclBucket := tx.Bucket(preschoolBucket); psclBucket != nil {
+			err := psclBucket.Delete(outpointBytes.Bytes())
+			if err != nil {
+				return err
+			}
+		}
+
+		kgtnBucket := tx.Bucket(kindergartenBucket)
+		if kgtnBucket == nil {
+			return deleteKidIndexes(
+				tx, []*kidOutput{{outPoint: outPoint}},
+			)
+		}
+
+		// Outputs within the kindergarten are stored in lists keyed by
+		// their maturity height, so we'll rewrite the lists holding
+		// the output without it.
+		updatedLists := make(map[string][]byte)
+		err := kgtnBucket.ForEach(func(heightBytes, kidBytes []byte) error {
+			if len(heightBytes) != 4 {
+				return nil
+			}
+
+			kids, err := deserializeKidList(bytes.NewReader(kidBytes))
+			if err != nil {
+				return err
+			}
+
+			var (
+				found     bool
+				remaining bytes.Buffer
+			)
+			for _, kid := range kids {
+				if kid.outPoint == outPoint {
+					found = true
+					continue
+				}
+				if err := serializeKidOutput(&remaining, kid); err != nil {
+					return err
+				}
+			}
+			if found {
+				updatedLists[string(heightBytes)] = remaining.Bytes()
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for heightBytes, kidBytes := range updatedLists {
+			if len(kidBytes) == 0 {
+				err = kgtnBucket.Delete([]byte(heightBytes))
+			} else {
+				err = kgtnBucket.Put([]byte(heightBytes), kidBytes)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		return deleteKidIndexes(tx, []*kidOutput{{outPoint: outPoint}})
+	})
+}
+
+// sweepInput describes an output spent by a sweep transaction.
+type sweepInput struct {
+	amt         btcutil.Amount
+	witnessType witnessType
+}
+
+// sweepRecord is the record of a sweep transaction broadcast by the daemon.
+type sweepRecord struct {
+	tx *wire.MsgTx
+
+	// inputs describes the outputs spent by each of the inputs of the
+	// transaction, in the same order.
+	inputs []sweepInput
+
+	// broadcastHeight is the height of the best block when the
+	// transaction was broadcast.
+	broadcastHeight uint32
+}
+
+// fee returns the fee paid by the sweep transaction.
+func (s *sweepRecord) fee() btcutil.Amount {
+	var fee btcutil.Amount
+	for _, input := range s.inputs {
+		fee += input.amt
+	}
+	for _, txOut := range s.tx.TxOut {
+		fee -= btcutil.Amount(txOut.Value)
+	}
+
+	return fee
+}
+
+// serializeSweepRecord writes the passed sweep record to the passed writer.
+func serializeSweepRecord(w io.Writer, s *sweepRecord) error {
+	var scratch [8]byte
+	byteOrder.PutUint32(scratch[:4], s.broadcastHeight)
+	if _, err := w.Write(scratch[:4]); err != nil {
 		return err
 	}
 
+	if err := s.tx.Serialize(w); err != nil {
+		return err
+	}
+
+	for _, input := range s.inputs {
+		byteOrder.PutUint64(scratch[:], uint64(input.amt))
+		if _, err := w.Write(scratch[:]); err != nil {
+			return err
+		}
+
+		byteOrder.PutUint16(scratch[:2], uint16(input.witnessType))
+		if _, err := w.Write(scratch[:2]); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// deserializeSweepRecord reads a sweep record from the passed reader. The
+// number of inputs described is that of the inputs of the transaction.
+func deserializeSweepRecord(r io.Reader) (*sweepRecord, error) {
+	var scratch [8]byte
+	if _, err := io.ReadFull(r, scratch[:4]); err != nil {
+		return nil, err
+	}
+	s := &sweepRecord{
+		broadcastHeight: byteOrder.Uint32(scratch[:4]),
+		tx:              &wire.MsgTx{},
+	}
+
+	if err := s.tx.Deserialize(r); err != nil {
+		return nil, err
+	}
+
+	s.inputs = make([]sweepInput, len(s.tx.TxIn))
+	for i := range s.inputs {
+		if _, err := io.ReadFull(r, scratch[:]); err != nil {
+			return nil, err
+		}
+		s.inputs[i].amt = btcutil.Amount(byteOrder.Uint64(scratch[:]))
+
+		if _, err := io.ReadFull(r, scratch[:2]); err != nil {
+			return nil, err
+		}
+		s.inputs[i].witnessType = witnessType(byteOrder.Uint16(scratch[:2]))
+	}
+
+	return s, nil
+}
+
+// putSweepRecord adds the passed record to the sweep transaction bucket.
+func putSweepRecord(db *channeldb.DB, s *sweepRecord) error {
+	var b bytes.Buffer
+	if err := serializeSweepRecord(&b, s); err != nil {
+		return err
+	}
+
+	return db.Update(func(tx *bolt.Tx) error {
+		sweepBucket, err := tx.CreateBucketIfNotExists(sweepTxBucket)
+		if err != nil {
+			return err
+		}
+
+		seq, err := sweepBucket.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		var seqBytes [8]byte
+		byteOrder.PutUint64(seqBytes[:], seq)
+		return sweepBucket.Put(seqBytes[:], b.Bytes())
+	})
+}
+
+// fetchSweepRecords returns the records of all sweep transactions broadcast by
+// the daemon, in the order they were broadcast.
+func fetchSweepRecords(db *channeldb.DB) ([]*sweepRecord, error) {
+	var records []*sweepRecord
+	err := db.View(func(tx *bolt.Tx) error {
+		sweepBucket := tx.Bucket(sweepTxBucket)
+		if sweepBucket == nil {
+			return nil
+		}
+
+		return sweepBucket.ForEach(func(_, recordBytes []byte) error {
+			record, err := deserializeSweepRecord(
+				bytes.NewReader(recordBytes),
+			)
+			if err != nil {
+				return err
+			}
+
+			records = append(records, record)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// sweepConfTarget returns the confirmation target of a sweep of the passed
+// outputs broadcast at the passed height. If any of the outputs has a deadline,
+// the target is the number of blocks left until the earliest one, so sweeps pay
+// a higher fee rate the closer they're broadcast to their deadline.
+func sweepConfTarget(outputs []*kidOutput, blockHeight uint32) uint32 {
+	confTarget := uint32(defaultSweepConfTarget)
+	for _, o := range outputs {
+		if o.deadlineHeight == 0 {
+			continue
+		}
+
+		// If the deadline is the next block, or has already passed,
+		// then the sweep should confirm as soon as possible.
+		if o.deadlineHeight <= blockHeight+1 {
+			return 1
+		}
+
+		if blocksLeft := o.deadlineHeight - blockHeight; blocksLeft < confTarget {
+			confTarget = blocksLeft
+		}
+	}
+
+	return confTarget
+}
+
 // createSweepTx creates a final sweeping transaction with all witnesses in
 // place for all inputs. The created transaction has a single output sending
-// all the funds back to the source wallet.
+// all the funds, minus the fee at the passed rate, to the passed script, or
+// back to the source wallet if it's nil.
 func createSweepTx(wallet *lnwallet.LightningWallet,
-	matureOutputs []*kidOutput) (*wire.MsgTx, error) {
+	matureOutputs []*kidOutput, pkScript []byte,
+	feePerWeight btcutil.Amount) (*wire.MsgTx, error) {
 
-	pkScript, err := newSweepPkScript(wallet)
-	if err != nil {
-		return nil, err
+	if len(pkScript) == 0 {
+		var err error
+		pkScript, err = newSweepPkScript(wallet)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	var totalSum btcutil.Amount
@@ -613,7 +1682,7 @@ func createSweepTx(wallet *lnwallet.LightningWallet,
 	sweepTx := wire.NewMsgTx(2)
 	sweepTx.AddTxOut(&wire.TxOut{
 		PkScript: pkScript,
-		Value:    int64(totalSum - 5000),
+		Value:    int64(totalSum),
 	})
 	for _, utxo := range matureOutputs {
 		sweepTx.AddTxIn(&wire.TxIn{
@@ -621,21 +1690,51 @@ func createSweepTx(wallet *lnwallet.LightningWallet,
 			// TODO(roasbeef): assumes pure block delays
 			Sequence: utxo.blocksToMaturity,
 		})
-	}
 
-	// TODO(roasbeef): insert fee calculation
-	//  * remove hardcoded fee above
+		// In order to satisfy the CheckLockTimeVerify of any outputs
+		// with an absolute maturity, the lock time of the sweep is set
+		// to the latest of them.
+		if utxo.absoluteMaturity > sweepTx.LockTime {
+			sweepTx.LockTime = utxo.absoluteMaturity
+		}
+	}
 
 	// With all the inputs in place, use each output's unique witness
 	// function to generate the final witness required for spending.
-	hashCache := txscript.NewTxSigHashes(sweepTx)
-	for i, txIn := range sweepTx.TxIn {
-		witness, err := matureOutputs[i].witnessFunc(sweepTx, hashCache, i)
-		if err != nil {
-			return nil, err
+	signSweep := func() error {
+		hashCache := txscript.NewTxSigHashes(sweepTx)
+		for i, txIn := range sweepTx.TxIn {
+			witness, err := matureOutputs[i].witnessFunc(
+				sweepTx, hashCache, i,
+			)
+			if err != nil {
+				return err
+			}
+
+			txIn.Witness = witness
 		}
 
-		txIn.Witness = witness
+		return nil
+	}
+
+	// The weight of the transaction depends on the witnesses, so we'll
+	// first sign it without a fee in order to determine its weight. The
+	// signatures commit to the value of the output, so the transaction is
+	// then signed again once the fee has been deducted.
+	if err := signSweep(); err != nil {
+		return nil, err
+	}
+
+	weight := blockchain.GetTransactionWeight(btcutil.NewTx(sweepTx))
+	fee := feePerWeight * btcutil.Amount(weight)
+	if fee >= totalSum {
+		return nil, fmt.Errorf("sweep fee of %v exceeds the %v swept",
+			fee, totalSum)
+	}
+
+	sweepTx.TxOut[0].Value = int64(totalSum - fee)
+	if err := signSweep(); err != nil {
+		return nil, err
 	}
 
 	return sweepTx, nil
@@ -669,6 +1768,12 @@ func deleteGraduatedOutputs(db *channeldb.DB, deleteHeight uint32) error {
 			return err
 		}
 
+		// The data of the outputs within their indexes is no longer
+		// needed either.
+		if err := deleteKidIndexes(tx, sweptOutputs); err != nil {
+			return err
+		}
+
 		utxnLog.Infof("Deleting %v swept outputs from kindergarten bucket "+
 			"at block height: %v", len(sweptOutputs), deleteHeight)
 
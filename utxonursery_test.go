@@ -3,10 +3,16 @@ package main
 import (
 	"bytes"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"reflect"
 	"testing"
 
+	"github.com/btcsuite/fastsha256"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/blockchain"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcd/txscript"
@@ -243,3 +249,492 @@ func TestSerializeKidOutput(t *testing.T) {
 		t.Fatalf("kidOutputs don't match %+v vs %+v", kid, deserializedKid)
 	}
 }
+
+// TestCreateSweepTxDeliveryScript asserts that outputs incubated with a
+// delivery script are swept to that script rather than to the wallet.
+func TestCreateSweepTxDeliveryScript(t *testing.T) {
+	deliveryScript := []byte{
+		0x00, 0x14, 0x55, 0x3d, 0x79, 0xa9, 0x1e, 0x8b, 0x94, 0x10,
+		0x6a, 0x50, 0x8b, 0x06, 0x24, 0x65, 0x6b, 0x2a, 0x73, 0x6d,
+		0x30, 0x2d,
+	}
+	witness := [][]byte{{0x01}}
+
+	kids := []*kidOutput{
+		{
+			amt:              btcutil.Amount(13e7),
+			outPoint:         outPoints[0],
+			blocksToMaturity: 144,
+			sweepPkScript:    deliveryScript,
+		},
+		{
+			amt:              btcutil.Amount(24e7),
+			outPoint:         outPoints[1],
+			blocksToMaturity: 144,
+			sweepPkScript:    deliveryScript,
+		},
+	}
+	for _, kid := range kids {
+		kid.witnessFunc = func(*wire.MsgTx, *txscript.TxSigHashes,
+			int) ([][]byte, error) {
+
+			return witness, nil
+		}
+	}
+
+	// As the delivery script is set, the wallet shouldn't be consulted
+	// for a sweep address.
+	const feePerWeight = 10
+	sweepTx, err := createSweepTx(nil, kids, deliveryScript, feePerWeight)
+	if err != nil {
+		t.Fatalf("unable to create sweep tx: %v", err)
+	}
+
+	if len(sweepTx.TxOut) != 1 {
+		t.Fatalf("expected a single output, got %v", len(sweepTx.TxOut))
+	}
+	if !bytes.Equal(sweepTx.TxOut[0].PkScript, deliveryScript) {
+		t.Fatalf("expected output to pay to %x, got %x",
+			deliveryScript, sweepTx.TxOut[0].PkScript)
+	}
+	if len(sweepTx.TxIn) != len(kids) {
+		t.Fatalf("expected %v inputs, got %v", len(kids),
+			len(sweepTx.TxIn))
+	}
+	for i, txIn := range sweepTx.TxIn {
+		if txIn.PreviousOutPoint != kids[i].outPoint {
+			t.Fatalf("input %v spends %v, expected %v", i,
+				txIn.PreviousOutPoint, kids[i].outPoint)
+		}
+		if !reflect.DeepEqual(txIn.Witness, wire.TxWitness(witness)) {
+			t.Fatalf("input %v has unexpected witness", i)
+		}
+	}
+
+	// The fee should be deducted from the swept funds according to the
+	// weight of the signed transaction.
+	weight := blockchain.GetTransactionWeight(btcutil.NewTx(sweepTx))
+	expectedValue := kids[0].amt + kids[1].amt -
+		feePerWeight*btcutil.Amount(weight)
+	if sweepTx.TxOut[0].Value != int64(expectedValue) {
+		t.Fatalf("expected output value of %v, got %v", expectedValue,
+			sweepTx.TxOut[0].Value)
+	}
+}
+
+// TestSweepConfTarget asserts that the confirmation target of a sweep is
+// derived from the earliest deadline of the outputs it sweeps.
+func TestSweepConfTarget(t *testing.T) {
+	const blockHeight = 1000
+
+	tests := []struct {
+		deadlines []uint32
+		target    uint32
+	}{
+		// Without deadlines, the default target should be used.
+		{
+			deadlines: []uint32{0, 0},
+			target:    defaultSweepConfTarget,
+		},
+		// A deadline further away than the default target shouldn't
+		// lower the fee rate.
+		{
+			deadlines: []uint32{0, blockHeight + 100},
+			target:    defaultSweepConfTarget,
+		},
+		// The earliest deadline should determine the target.
+		{
+			deadlines: []uint32{blockHeight + 5, blockHeight + 3},
+			target:    3,
+		},
+		// Passed deadlines should be swept as soon as possible.
+		{
+			deadlines: []uint32{blockHeight + 5, blockHeight - 2},
+			target:    1,
+		},
+		{
+			deadlines: []uint32{blockHeight + 1},
+			target:    1,
+		},
+	}
+
+	for i, test := range tests {
+		var kids []*kidOutput
+		for _, deadline := range test.deadlines {
+			kids = append(kids, &kidOutput{deadlineHeight: deadline})
+		}
+
+		target := sweepConfTarget(kids, blockHeight)
+		if target != test.target {
+			t.Fatalf("test #%v: expected target of %v, got %v", i,
+				test.target, target)
+		}
+	}
+}
+
+// TestBumpedSweepFeeRate asserts that the fee rate of sweeps with a deadline
+// rises with each block, even if the fee estimate doesn't, while sweeps
+// without a deadline follow the estimate.
+func TestBumpedSweepFeeRate(t *testing.T) {
+	tests := []struct {
+		feePerWeight btcutil.Amount
+		estimate     btcutil.Amount
+		confTarget   uint32
+		expected     btcutil.Amount
+	}{
+		// Without a deadline, the estimate should be used.
+		{
+			feePerWeight: 10,
+			estimate:     10,
+			confTarget:   defaultSweepConfTarget,
+			expected:     10,
+		},
+		{
+			feePerWeight: 10,
+			estimate:     20,
+			confTarget:   defaultSweepConfTarget,
+			expected:     20,
+		},
+		// With a deadline, the fee rate should be raised by at least
+		// sweepFeeBumpPercent.
+		{
+			feePerWeight: 100,
+			estimate:     100,
+			confTarget:   3,
+			expected:     125,
+		},
+		{
+			feePerWeight: 100,
+			estimate:     200,
+			confTarget:   1,
+			expected:     200,
+		},
+		// Low fee rates should still be raised.
+		{
+			feePerWeight: 2,
+			estimate:     2,
+			confTarget:   1,
+			expected:     3,
+		},
+	}
+
+	for i, test := range tests {
+		feePerWeight := bumpedSweepFeeRate(
+			test.feePerWeight, test.estimate, test.confTarget,
+		)
+		if feePerWeight != test.expected {
+			t.Fatalf("test #%v: expected fee rate of %v, got %v", i,
+				test.expected, feePerWeight)
+		}
+	}
+}
+
+// TestKidIndexesPersisted asserts that the data of incubated outputs stored
+// within their indexes, rather than along with them, survives being read back
+// from the database.
+func TestKidIndexesPersisted(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "nursery")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	cdb, err := channeldb.Open(tempDir)
+	if err != nil {
+		t.Fatalf("unable to open channeldb: %v", err)
+	}
+	defer cdb.Close()
+
+	pk, err := btcec.ParsePubKey(keys[2], btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to parse pub key: %v", err)
+	}
+	descriptor := signDescriptors[1]
+	descriptor.PubKey = pk
+
+	kid := &kidOutput{
+		amt:              btcutil.Amount(24e7),
+		outPoint:         outPoints[1],
+		blocksToMaturity: 144,
+		signDescriptor:   &descriptor,
+		witnessType:      htlcOfferedTimeout,
+		sweepPkScript:    []byte{0x00, 0x14, 0x01},
+		deadlineHeight:   500,
+		absoluteMaturity: 500,
+		originChanPoint:  outPoints[2],
+		paymentHash:      [32]byte{1, 2, 3},
+	}
+	if err := kid.enterPreschool(cdb); err != nil {
+		t.Fatalf("unable to add output to preschool: %v", err)
+	}
+
+	nursery := &utxoNursery{db: cdb}
+	kids, err := nursery.limboOutputs()
+	if err != nil {
+		t.Fatalf("unable to fetch incubated outputs: %v", err)
+	}
+	if len(kids) != 1 {
+		t.Fatalf("expected 1 incubated output, got %v", len(kids))
+	}
+
+	if !reflect.DeepEqual(kid, kids[0]) {
+		t.Fatalf("kidOutputs don't match %+v vs %+v", kid, kids[0])
+	}
+}
+
+// TestSweepSets asserts that outgoing HTLC outputs are swept by a dedicated
+// transaction each, while the remaining outputs are swept together per script.
+func TestSweepSets(t *testing.T) {
+	scriptA := []byte{0x00, 0x14, 0x01}
+	scriptB := []byte{0x00, 0x14, 0x02}
+
+	selfA := &kidOutput{outPoint: outPoints[0], sweepPkScript: scriptA}
+	selfB := &kidOutput{outPoint: outPoints[1], sweepPkScript: scriptB}
+	otherA := &kidOutput{outPoint: outPoints[2], sweepPkScript: scriptA}
+	htlc1 := &kidOutput{
+		outPoint:      wire.OutPoint{Index: 1},
+		sweepPkScript: scriptA,
+		witnessType:   htlcOfferedTimeout,
+	}
+	htlc2 := &kidOutput{
+		outPoint:      wire.OutPoint{Index: 2},
+		sweepPkScript: scriptA,
+		witnessType:   htlcOfferedTimeout,
+	}
+
+	sets := sweepSets([]*kidOutput{selfA, htlc1, selfB, otherA, htlc2})
+	expected := [][]*kidOutput{
+		{selfA, otherA},
+		{htlc1},
+		{selfB},
+		{htlc2},
+	}
+	if !reflect.DeepEqual(sets, expected) {
+		t.Fatalf("expected sweep sets %v, got %v", expected, sets)
+	}
+}
+
+// TestRemoveKidOutput asserts that an outgoing HTLC output claimed by the
+// remote party is recognized by the preimage it reveals, and that it's
+// removed from the nursery, whether it's still in preschool or has reached
+// the kindergarten, while the outputs incubated along with it remain.
+func TestRemoveKidOutput(t *testing.T) {
+	preimage := [32]byte{9}
+	paymentHash := fastsha256.Sum256(preimage[:])
+
+	claimWitness := wire.TxWitness{{0x30}, preimage[:], {0}, {1}, {0x63}}
+	if !revealsPreimage(claimWitness, paymentHash) {
+		t.Fatalf("claim with the preimage not recognized")
+	}
+	timeoutWitness := wire.TxWitness{{0x30}, {0}, {0x63}}
+	if revealsPreimage(timeoutWitness, paymentHash) {
+		t.Fatalf("sweep through the timeout clause mistaken for a " +
+			"claim")
+	}
+
+	tempDir, err := ioutil.TempDir("", "nursery")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	cdb, err := channeldb.Open(tempDir)
+	if err != nil {
+		t.Fatalf("unable to open channeldb: %v", err)
+	}
+	defer cdb.Close()
+
+	pk, err := btcec.ParsePubKey(keys[2], btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to parse pub key: %v", err)
+	}
+	descriptor := signDescriptors[0]
+	descriptor.PubKey = pk
+
+	kids := make([]*kidOutput, 3)
+	for i := range kids {
+		kids[i] = &kidOutput{
+			amt:              btcutil.Amount(1e6),
+			outPoint:         outPoints[i],
+			blocksToMaturity: 144,
+			signDescriptor:   &descriptor,
+			witnessType:      htlcOfferedTimeout,
+			paymentHash:      paymentHash,
+		}
+		if err := kids[i].enterPreschool(cdb); err != nil {
+			t.Fatalf("unable to add output to preschool: %v", err)
+		}
+	}
+
+	// The last two outputs are promoted to the kindergarten, where they
+	// mature at the same height.
+	for _, kid := range kids[1:] {
+		confChan := &chainntnfs.ConfirmationEvent{
+			Confirmed: make(chan *chainntnfs.TxConfirmation, 1),
+		}
+		confChan.Confirmed <- &chainntnfs.TxConfirmation{
+			BlockHeight: 100,
+		}
+		kid.waitForPromotion(cdb, confChan)
+	}
+
+	nursery := &utxoNursery{db: cdb}
+	assertIncubated := func(expected ...wire.OutPoint) {
+		limbo, err := nursery.limboOutputs()
+		if err != nil {
+			t.Fatalf("unable to fetch incubated outputs: %v", err)
+		}
+
+		var outPoints []wire.OutPoint
+		for _, kid := range limbo {
+			outPoints = append(outPoints, kid.outPoint)
+		}
+		if !reflect.DeepEqual(outPoints, expected) {
+			t.Fatalf("expected incubated outputs %v, got %v",
+				expected, outPoints)
+		}
+	}
+	assertIncubated(outPoints[0], outPoints[1], outPoints[2])
+
+	if err := removeKidOutput(cdb, outPoints[0]); err != nil {
+		t.Fatalf("unable to remove preschool output: %v", err)
+	}
+	assertIncubated(outPoints[1], outPoints[2])
+
+	if err := removeKidOutput(cdb, outPoints[1]); err != nil {
+		t.Fatalf("unable to remove kindergarten output: %v", err)
+	}
+	assertIncubated(outPoints[2])
+
+	if err := removeKidOutput(cdb, outPoints[2]); err != nil {
+		t.Fatalf("unable to remove kindergarten output: %v", err)
+	}
+	assertIncubated()
+}
+
+// TestKidOutputMaturityHeight asserts that outputs mature once both their
+// relative and absolute maturities have been reached, and that the lock time
+// of their sweep satisfies the latest absolute maturity.
+func TestKidOutputMaturityHeight(t *testing.T) {
+	selfOutput := &kidOutput{
+		amt:              btcutil.Amount(13e7),
+		outPoint:         outPoints[0],
+		blocksToMaturity: 144,
+		witnessType:      commitmentTimeLock,
+	}
+	htlcOutput := &kidOutput{
+		amt:              btcutil.Amount(24e7),
+		outPoint:         outPoints[1],
+		blocksToMaturity: 144,
+		absoluteMaturity: 500,
+		witnessType:      htlcOfferedTimeout,
+	}
+
+	// Until the outputs are confirmed, their maturity is unknown.
+	if height := htlcOutput.maturityHeight(); height != 0 {
+		t.Fatalf("expected unconfirmed output to have no maturity "+
+			"height, got %v", height)
+	}
+
+	selfOutput.confHeight = 300
+	htlcOutput.confHeight = 300
+	if height := selfOutput.maturityHeight(); height != 444 {
+		t.Fatalf("expected maturity height of 444, got %v", height)
+	}
+	if height := htlcOutput.maturityHeight(); height != 500 {
+		t.Fatalf("expected maturity height of 500, got %v", height)
+	}
+
+	// Once the relative maturity exceeds the absolute one, it should
+	// determine the maturity height instead.
+	htlcOutput.confHeight = 400
+	if height := htlcOutput.maturityHeight(); height != 544 {
+		t.Fatalf("expected maturity height of 544, got %v", height)
+	}
+
+	kids := []*kidOutput{selfOutput, htlcOutput}
+	for _, kid := range kids {
+		kid.witnessFunc = func(*wire.MsgTx, *txscript.TxSigHashes,
+			int) ([][]byte, error) {
+
+			return [][]byte{{0x01}}, nil
+		}
+	}
+
+	deliveryScript := []byte{0x00, 0x14}
+	sweepTx, err := createSweepTx(nil, kids, deliveryScript, 0)
+	if err != nil {
+		t.Fatalf("unable to create sweep tx: %v", err)
+	}
+	if sweepTx.LockTime != htlcOutput.absoluteMaturity {
+		t.Fatalf("expected lock time of %v, got %v",
+			htlcOutput.absoluteMaturity, sweepTx.LockTime)
+	}
+}
+
+// TestSerializeSweepRecord asserts that sweep records survive a round trip
+// through their serialization, and that their fee is derived from the values
+// of their inputs and outputs.
+func TestSerializeSweepRecord(t *testing.T) {
+	sweepTx := wire.NewMsgTx(2)
+	sweepTx.LockTime = 500
+	sweepTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: outPoints[0],
+		Sequence:         144,
+		Witness:          [][]byte{{0x01}},
+	})
+	sweepTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: outPoints[1],
+		Sequence:         144,
+		Witness:          [][]byte{{0x02}},
+	})
+	sweepTx.AddTxOut(&wire.TxOut{
+		PkScript: []byte{0x00, 0x14},
+		Value:    int64(btcutil.Amount(37e7) - 1500),
+	})
+
+	record := &sweepRecord{
+		tx: sweepTx,
+		inputs: []sweepInput{
+			{amt: btcutil.Amount(13e7), witnessType: commitmentTimeLock},
+			{amt: btcutil.Amount(24e7), witnessType: htlcOfferedTimeout},
+		},
+		broadcastHeight: 600,
+	}
+
+	var b bytes.Buffer
+	if err := serializeSweepRecord(&b, record); err != nil {
+		t.Fatalf("unable to serialize sweep record: %v", err)
+	}
+	deserializedRecord, err := deserializeSweepRecord(&b)
+	if err != nil {
+		t.Fatalf("unable to deserialize sweep record: %v", err)
+	}
+
+	// The transactions are compared in their serialized form, as an empty
+	// signature script is deserialized as an empty rather than nil slice.
+	var txBytes, deserializedTxBytes bytes.Buffer
+	if err := record.tx.Serialize(&txBytes); err != nil {
+		t.Fatalf("unable to serialize tx: %v", err)
+	}
+	if err := deserializedRecord.tx.Serialize(
+		&deserializedTxBytes,
+	); err != nil {
+		t.Fatalf("unable to serialize tx: %v", err)
+	}
+	if !bytes.Equal(txBytes.Bytes(), deserializedTxBytes.Bytes()) {
+		t.Fatalf("sweep txns don't match %x vs %x", txBytes.Bytes(),
+			deserializedTxBytes.Bytes())
+	}
+	if !reflect.DeepEqual(record.inputs, deserializedRecord.inputs) {
+		t.Fatalf("sweep inputs don't match %+v vs %+v", record.inputs,
+			deserializedRecord.inputs)
+	}
+	if deserializedRecord.broadcastHeight != record.broadcastHeight {
+		t.Fatalf("expected broadcast height of %v, got %v",
+			record.broadcastHeight, deserializedRecord.broadcastHeight)
+	}
+	if fee := deserializedRecord.fee(); fee != 1500 {
+		t.Fatalf("expected fee of 1500, got %v", fee)
+	}
+}
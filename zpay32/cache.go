@@ -0,0 +1,83 @@
+package zpay32
+
+import (
+	"container/list"
+	"sync"
+)
+
+// cacheEntry is a decoded payment request within the cache.
+type cacheEntry struct {
+	payReq  string
+	decoded *PaymentRequest
+}
+
+// DecodeCache is an LRU cache of decoded payment requests, keyed by their
+// encoding, so that repeated decodes of the same payment request are cheap.
+// Requests which fail to decode aren't cached. As the cached payment requests
+// are shared between all callers, those returned by the cache MUST NOT be
+// modified. It's safe for concurrent use.
+type DecodeCache struct {
+	capacity int
+
+	mtx     sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+// NewDecodeCache creates a new DecodeCache holding at most capacity decoded
+// payment requests. If capacity is zero, nothing is cached.
+func NewDecodeCache(capacity int) *DecodeCache {
+	return &DecodeCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+// Decode returns the decoded form of the passed payment request, decoding it
+// only if it isn't already cached.
+func (c *DecodeCache) Decode(payReq string) (*PaymentRequest, error) {
+	c.mtx.Lock()
+	if elem, ok := c.entries[payReq]; ok {
+		c.lru.MoveToFront(elem)
+		decoded := elem.Value.(*cacheEntry).decoded
+		c.mtx.Unlock()
+
+		return decoded, nil
+	}
+	c.mtx.Unlock()
+
+	decoded, err := Decode(payReq)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	// The payment request may have been cached by a concurrent caller
+	// while we were decoding it.
+	if _, ok := c.entries[payReq]; ok || c.capacity == 0 {
+		return decoded, nil
+	}
+
+	c.entries[payReq] = c.lru.PushFront(&cacheEntry{
+		payReq:  payReq,
+		decoded: decoded,
+	})
+	for c.lru.Len() > c.capacity {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).payReq)
+	}
+
+	return decoded, nil
+}
+
+// Len returns the number of decoded payment requests within the cache.
+func (c *DecodeCache) Len() int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.lru.Len()
+}
@@ -0,0 +1,71 @@
+package zpay32
+
+import (
+	"testing"
+
+	"github.com/roasbeef/btcutil"
+)
+
+// TestDecodeCache tests that decoded payment requests are served from the
+// cache, and that the least recently used one is evicted once the cache is
+// full.
+func TestDecodeCache(t *testing.T) {
+	encode := func(amt btcutil.Amount) string {
+		return Encode(&PaymentRequest{
+			Destination: testPubKey,
+			PaymentHash: testPayHash,
+			Amount:      amt,
+		})
+	}
+	payReq1, payReq2, payReq3 := encode(1), encode(2), encode(3)
+
+	cache := NewDecodeCache(2)
+	decoded1, err := cache.Decode(payReq1)
+	if err != nil {
+		t.Fatalf("unable to decode payment request: %v", err)
+	}
+	if decoded1.Amount != 1 {
+		t.Fatalf("expected amount 1, got %v", decoded1.Amount)
+	}
+
+	// Decoding the same payment request again should return the cached
+	// result.
+	cached, err := cache.Decode(payReq1)
+	if err != nil {
+		t.Fatalf("unable to decode payment request: %v", err)
+	}
+	if cached != decoded1 {
+		t.Fatalf("payment request wasn't served from the cache")
+	}
+
+	// Once a third payment request is decoded, the second one, being the
+	// least recently used, should be evicted.
+	if _, err := cache.Decode(payReq2); err != nil {
+		t.Fatalf("unable to decode payment request: %v", err)
+	}
+	if _, err := cache.Decode(payReq1); err != nil {
+		t.Fatalf("unable to decode payment request: %v", err)
+	}
+	if _, err := cache.Decode(payReq3); err != nil {
+		t.Fatalf("unable to decode payment request: %v", err)
+	}
+	if cache.Len() != 2 {
+		t.Fatalf("expected 2 cached payment requests, got %v",
+			cache.Len())
+	}
+	if _, ok := cache.entries[payReq2]; ok {
+		t.Fatalf("least recently used payment request wasn't evicted")
+	}
+	if cached, _ := cache.Decode(payReq1); cached != decoded1 {
+		t.Fatalf("recently used payment request was evicted")
+	}
+
+	// Payment requests failing to decode, such as truncated ones,
+	// shouldn't be cached.
+	if _, err := cache.Decode(payReq1[:16]); err != ErrInvalidLength {
+		t.Fatalf("expected ErrInvalidLength, got %v", err)
+	}
+	if cache.Len() != 2 {
+		t.Fatalf("invalid payment request was cached")
+	}
+}
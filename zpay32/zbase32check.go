@@ -22,6 +22,10 @@ const invoiceSize = 33 + 32 + 8
 // an error somewhere in the bitstream.
 var ErrCheckSumMismatch = errors.New("the checksum is incorrect")
 
+// ErrInvalidLength is returned by the Decode function if the decoded payment
+// request isn't the size of an invoice along with its check-sum.
+var ErrInvalidLength = errors.New("the payment request has an invalid length")
+
 // PaymentRequest is a bare-bones invoice for a payment within the Lightning
 // Network.  With the details of the invoice, the sender has all the data
 // necessary to send a payment to the recipient.
@@ -84,6 +88,12 @@ func Decode(payData string) (*PaymentRequest, error) {
 		return nil, err
 	}
 
+	// The payment request may have been provided by an untrusted party,
+	// so its length must be checked before it's sliced.
+	if len(payReqBytes) != invoiceSize+crc32.Size {
+		return nil, ErrInvalidLength
+	}
+
 	// With the bytes decoded, we first verify the checksum to ensure the
 	// payment request wasn't altered in its decoded form.
 	invoiceBytes := payReqBytes[:invoiceSize]